@@ -53,7 +53,7 @@ func (as *Autoscaler[I, S]) Start(ctx context.Context) {
 
 			as.mostRecentSample = sample
 
-			scaleResult := as.scaleFunc(as.instance, sample)
+			scaleResult := as.instance.ApplyAutoscalingPolicy(as.scaleFunc(as.instance, sample))
 			if scaleResult != nil && scaleResult.ResultValid {
 				as.instance.ConsumeScaleResult(scaleResult) // Send autoscaling result back to instance
 			}
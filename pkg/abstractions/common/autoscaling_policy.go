@@ -0,0 +1,113 @@
+package abstractions
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/types"
+	"github.com/rs/zerolog/log"
+)
+
+// Policies are re-read periodically rather than on every sample, so changes apply within this interval
+const autoscalingPolicyRefreshInterval = 15 * time.Second
+
+// autoscalingPolicyCache holds the stub's custom metric policy. It's only touched by the autoscaler loop.
+type autoscalingPolicyCache struct {
+	policy   *types.AutoscalingPolicy
+	loadedAt time.Time
+}
+
+// RecordAutoscalingMetric stores the latest value a container reported for a metric. Each container keeps a
+// single value, and values older than types.AutoscalingMetricMaxAge are ignored and cleaned up on read.
+func RecordAutoscalingMetric(ctx context.Context, rdb *common.RedisClient, stubId, metric, containerId string, value float64) error {
+	sample, err := json.Marshal(types.AutoscalingMetricSample{Value: value, Timestamp: time.Now().Unix()})
+	if err != nil {
+		return err
+	}
+
+	key := common.RedisKeys.GatewayAutoscalingMetric(stubId, metric)
+	if err := rdb.HSet(ctx, key, containerId, sample).Err(); err != nil {
+		return err
+	}
+
+	return rdb.Expire(ctx, key, types.AutoscalingMetricMaxAge).Err()
+}
+
+// ReadAutoscalingMetric returns the latest value of a metric from each container still reporting it
+func ReadAutoscalingMetric(ctx context.Context, rdb *common.RedisClient, stubId, metric string) ([]float64, error) {
+	key := common.RedisKeys.GatewayAutoscalingMetric(stubId, metric)
+
+	samples, err := rdb.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().Add(-types.AutoscalingMetricMaxAge).Unix()
+
+	values := make([]float64, 0, len(samples))
+	for containerId, raw := range samples {
+		var sample types.AutoscalingMetricSample
+		if err := json.Unmarshal([]byte(raw), &sample); err != nil || sample.Timestamp < cutoff {
+			rdb.HDel(ctx, key, containerId)
+			continue
+		}
+
+		values = append(values, sample.Value)
+	}
+
+	return values, nil
+}
+
+func (i *AutoscaledInstance) currentAutoscalingPolicy() *types.AutoscalingPolicy {
+	if time.Since(i.autoscalingPolicy.loadedAt) < autoscalingPolicyRefreshInterval {
+		return i.autoscalingPolicy.policy
+	}
+
+	policy, err := i.BackendRepo.GetAutoscalingPolicy(i.Ctx, i.Stub.ExternalId)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Warn().Err(err).Str("stub_id", i.Stub.ExternalId).Msg("unable to get autoscaling policy")
+			return i.autoscalingPolicy.policy
+		}
+
+		policy = nil
+	}
+
+	i.autoscalingPolicy = autoscalingPolicyCache{policy: policy, loadedAt: time.Now()}
+	return policy
+}
+
+// ApplyAutoscalingPolicy raises a scale result to the container count the stub's custom metric policy asks
+// for, and to its keep warm pool while that's scheduled. Without a policy, or before any container has
+// reported the metric, the result is left as is.
+func (i *AutoscaledInstance) ApplyAutoscalingPolicy(result *AutoscalerResult) *AutoscalerResult {
+	if !i.Stub.Type.IsDeployment() || i.StubConfig.Autoscaler == nil {
+		return result
+	}
+
+	result = i.applyKeepWarmPolicy(result)
+
+	policy := i.currentAutoscalingPolicy()
+	if policy == nil {
+		return result
+	}
+
+	values, err := ReadAutoscalingMetric(i.Ctx, i.Rdb, i.Stub.ExternalId, policy.Metric)
+	if err != nil || len(values) == 0 {
+		return result
+	}
+
+	maxReplicas := min(uint64(i.StubConfig.Autoscaler.MaxContainers), i.AppConfig.GatewayService.StubLimits.MaxReplicas)
+	desiredContainers := min(policy.DesiredContainers(values), int(maxReplicas))
+
+	if result == nil || !result.ResultValid {
+		return &AutoscalerResult{DesiredContainers: desiredContainers, ResultValid: true}
+	}
+
+	result.DesiredContainers = max(result.DesiredContainers, desiredContainers)
+	return result
+}
@@ -0,0 +1,177 @@
+package abstractions
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+const (
+	// Slots are leased and renewed while held, so ones held by a gateway that went away free up on their own
+	concurrencyLeaseTTL time.Duration = 30 * time.Second
+	// Queued requests that stop polling for this long are dropped from the queue
+	concurrencyQueueStaleAfter time.Duration = 10 * time.Second
+	concurrencyPollInterval    time.Duration = 250 * time.Millisecond
+)
+
+var (
+	ErrConcurrencyQueueFull = errors.New("deployment is at its concurrency limit and its queue is full")
+	ErrConcurrencyShed      = errors.New("request was dropped from the deployment's queue to make room for newer requests")
+)
+
+// Both scripts start by dropping expired slots and stale queue entries.
+//
+// KEYS: active slots (holder -> lease expiry), queue (holder -> enqueue time), queue seen (holder -> last poll)
+const concurrencyPurgeScript = `
+local now = tonumber(ARGV[2])
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", now)
+local stale = redis.call("ZRANGEBYSCORE", KEYS[3], "-inf", tonumber(ARGV[6]))
+for _, holder in ipairs(stale) do
+	redis.call("ZREM", KEYS[2], holder)
+	redis.call("ZREM", KEYS[3], holder)
+end
+`
+
+// ARGV: holder, now, lease expiry, max concurrent, max queue depth, stale before, overflow policy
+//
+// Returns 1 if a slot was taken right away, 0 if the holder was queued and -1 if the queue is full
+var concurrencyEnqueueScript = redis.NewScript(concurrencyPurgeScript + `
+local max = tonumber(ARGV[4])
+if redis.call("ZCARD", KEYS[2]) == 0 and redis.call("ZCARD", KEYS[1]) < max then
+	redis.call("ZADD", KEYS[1], tonumber(ARGV[3]), ARGV[1])
+	return 1
+end
+
+if redis.call("ZCARD", KEYS[2]) >= tonumber(ARGV[5]) then
+	if ARGV[7] ~= "shed_oldest" then
+		return -1
+	end
+
+	local oldest = redis.call("ZPOPMIN", KEYS[2])
+	if #oldest > 0 then
+		redis.call("ZREM", KEYS[3], oldest[1])
+	end
+end
+
+redis.call("ZADD", KEYS[2], now, ARGV[1])
+redis.call("ZADD", KEYS[3], now, ARGV[1])
+return 0
+`)
+
+// ARGV: same as the enqueue script
+//
+// Returns 1 if the holder took a slot, 0 if it should keep waiting and -1 if it's no longer queued
+var concurrencyAcquireScript = redis.NewScript(concurrencyPurgeScript + `
+if not redis.call("ZSCORE", KEYS[2], ARGV[1]) then
+	return -1
+end
+redis.call("ZADD", KEYS[3], now, ARGV[1])
+
+local free = tonumber(ARGV[4]) - redis.call("ZCARD", KEYS[1])
+if free > 0 and redis.call("ZRANK", KEYS[2], ARGV[1]) < free then
+	redis.call("ZREM", KEYS[2], ARGV[1])
+	redis.call("ZREM", KEYS[3], ARGV[1])
+	redis.call("ZADD", KEYS[1], tonumber(ARGV[3]), ARGV[1])
+	return 1
+end
+
+return 0
+`)
+
+// ConcurrencyLimiter enforces a deployment's concurrency policy across gateway replicas. Requests over the
+// limit wait in a FIFO queue in redis.
+type ConcurrencyLimiter struct {
+	rdb *common.RedisClient
+}
+
+func NewConcurrencyLimiter(rdb *common.RedisClient) *ConcurrencyLimiter {
+	return &ConcurrencyLimiter{rdb: rdb}
+}
+
+// Acquire takes one of the stub's slots for holderId, waiting in the queue if they're all taken. The returned
+// func gives the slot back; until it's called, the slot's lease is kept alive.
+func (l *ConcurrencyLimiter) Acquire(ctx context.Context, stubId, holderId string, policy *types.ConcurrencyPolicy) (func(), error) {
+	if policy == nil || policy.MaxConcurrentRequests == 0 {
+		return func() {}, nil
+	}
+
+	keys := []string{
+		common.RedisKeys.GatewayConcurrencyActive(stubId),
+		common.RedisKeys.GatewayConcurrencyQueue(stubId),
+		common.RedisKeys.GatewayConcurrencyQueueSeen(stubId),
+	}
+
+	args := func() []interface{} {
+		now := time.Now()
+		return []interface{}{
+			holderId,
+			now.UnixMilli(),
+			now.Add(concurrencyLeaseTTL).UnixMilli(),
+			policy.MaxConcurrentRequests,
+			policy.MaxQueueDepth,
+			now.Add(-concurrencyQueueStaleAfter).UnixMilli(),
+			string(policy.OverflowPolicy),
+		}
+	}
+
+	result, err := concurrencyEnqueueScript.Run(ctx, l.rdb, keys, args()...).Int()
+	if err != nil {
+		return nil, err
+	}
+
+	if result == -1 {
+		return nil, ErrConcurrencyQueueFull
+	}
+
+	ticker := time.NewTicker(concurrencyPollInterval)
+	defer ticker.Stop()
+
+	for result == 0 {
+		select {
+		case <-ctx.Done():
+			l.rdb.ZRem(context.Background(), keys[1], holderId)
+			l.rdb.ZRem(context.Background(), keys[2], holderId)
+			return nil, ctx.Err()
+		case <-ticker.C:
+			result, err = concurrencyAcquireScript.Run(ctx, l.rdb, keys, args()...).Int()
+			if err != nil && ctx.Err() == nil {
+				log.Warn().Err(err).Str("stub_id", stubId).Msg("failed to check concurrency queue")
+				result = 0
+			} else if result == -1 {
+				return nil, ErrConcurrencyShed
+			}
+		}
+	}
+
+	return l.hold(keys[0], holderId), nil
+}
+
+// hold renews the holder's lease until the returned func is called
+func (l *ConcurrencyLimiter) hold(activeKey, holderId string) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		ticker := time.NewTicker(concurrencyLeaseTTL / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				l.rdb.ZAddXX(ctx, activeKey, redis.Z{Score: float64(time.Now().Add(concurrencyLeaseTTL).UnixMilli()), Member: holderId})
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		l.rdb.ZRem(context.Background(), activeKey, holderId)
+	}
+}
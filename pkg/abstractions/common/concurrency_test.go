@@ -0,0 +1,117 @@
+package abstractions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/repository"
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+type acquireResult struct {
+	release func()
+	err     error
+}
+
+func acquireAsync(limiter *ConcurrencyLimiter, holderId string, policy *types.ConcurrencyPolicy) chan acquireResult {
+	result := make(chan acquireResult, 1)
+	go func() {
+		release, err := limiter.Acquire(context.Background(), "stub", holderId, policy)
+		result <- acquireResult{release: release, err: err}
+	}()
+	return result
+}
+
+func TestConcurrencyLimiterReject(t *testing.T) {
+	rdb, err := repository.NewRedisClientForTest()
+	require.NoError(t, err)
+
+	limiter := NewConcurrencyLimiter(rdb)
+	policy := &types.ConcurrencyPolicy{MaxConcurrentRequests: 1, MaxQueueDepth: 1, OverflowPolicy: types.ConcurrencyOverflowReject}
+
+	releaseFirst, err := limiter.Acquire(context.Background(), "stub", "first", policy)
+	require.NoError(t, err)
+
+	second := acquireAsync(limiter, "second", policy)
+	time.Sleep(2 * concurrencyPollInterval)
+
+	_, err = limiter.Acquire(context.Background(), "stub", "third", policy)
+	assert.ErrorIs(t, err, ErrConcurrencyQueueFull)
+
+	select {
+	case <-second:
+		t.Fatal("second request took a slot while the first still held it")
+	default:
+	}
+
+	releaseFirst()
+
+	select {
+	case result := <-second:
+		require.NoError(t, result.err)
+		result.release()
+	case <-time.After(2 * time.Second):
+		t.Fatal("second request didn't get a slot once the first was released")
+	}
+}
+
+func TestConcurrencyLimiterShedOldest(t *testing.T) {
+	rdb, err := repository.NewRedisClientForTest()
+	require.NoError(t, err)
+
+	limiter := NewConcurrencyLimiter(rdb)
+	policy := &types.ConcurrencyPolicy{MaxConcurrentRequests: 1, MaxQueueDepth: 1, OverflowPolicy: types.ConcurrencyOverflowShedOldest}
+
+	releaseFirst, err := limiter.Acquire(context.Background(), "stub", "first", policy)
+	require.NoError(t, err)
+
+	second := acquireAsync(limiter, "second", policy)
+	time.Sleep(2 * concurrencyPollInterval)
+
+	third := acquireAsync(limiter, "third", policy)
+
+	select {
+	case result := <-second:
+		assert.ErrorIs(t, result.err, ErrConcurrencyShed)
+	case <-time.After(2 * time.Second):
+		t.Fatal("oldest queued request wasn't shed")
+	}
+
+	releaseFirst()
+
+	select {
+	case result := <-third:
+		require.NoError(t, result.err)
+		result.release()
+	case <-time.After(2 * time.Second):
+		t.Fatal("newest request didn't get a slot once the first was released")
+	}
+}
+
+func TestConcurrencyLimiterCancelledWhileQueued(t *testing.T) {
+	rdb, err := repository.NewRedisClientForTest()
+	require.NoError(t, err)
+
+	limiter := NewConcurrencyLimiter(rdb)
+	policy := &types.ConcurrencyPolicy{MaxConcurrentRequests: 1, MaxQueueDepth: 1, OverflowPolicy: types.ConcurrencyOverflowReject}
+
+	release, err := limiter.Acquire(context.Background(), "stub", "first", policy)
+	require.NoError(t, err)
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*concurrencyPollInterval)
+	defer cancel()
+
+	_, err = limiter.Acquire(ctx, "stub", "second", policy)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// The cancelled request gave up its place in the queue
+	queued, err := rdb.ZCard(context.Background(), common.RedisKeys.GatewayConcurrencyQueue("stub")).Result()
+	require.NoError(t, err)
+	assert.Zero(t, queued)
+}
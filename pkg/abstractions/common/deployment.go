@@ -12,6 +12,7 @@ import (
 	"github.com/beam-cloud/beta9/pkg/repository"
 	"github.com/beam-cloud/beta9/pkg/types"
 	expirable "github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/rs/zerolog/log"
 )
 
 const (
@@ -89,7 +90,9 @@ func ParseAndValidateDeploymentStubId(
 		}
 
 		if stubConfig.Pricing == nil && stubConfig.Authorized && authInfo.Workspace.ExternalId != stub.Workspace.ExternalId {
-			return "", apiv1.HTTPUnauthorized("Invalid token")
+			if !deploymentGranted(ctx, backendRepo, authInfo, stub) {
+				return "", apiv1.HTTPUnauthorized("Invalid token")
+			}
 		}
 	}
 
@@ -99,3 +102,20 @@ func ParseAndValidateDeploymentStubId(
 
 	return stubId, nil
 }
+
+// deploymentGranted reports whether the caller's workspace holds a grant to invoke the deployment a stub from
+// another workspace belongs to
+func deploymentGranted(ctx context.Context, backendRepo repository.BackendRepository, authInfo *auth.AuthInfo, stub *types.StubWithRelated) bool {
+	deployment, err := backendRepo.GetDeploymentByStubExternalId(ctx, stub.Workspace.Id, stub.ExternalId)
+	if err != nil || deployment == nil {
+		return false
+	}
+
+	granted, err := auth.AuthorizeResource(ctx, backendRepo, authInfo.Workspace, stub.Workspace.Id, types.ResourceGrantDeployment, deployment.Name)
+	if err != nil {
+		log.Error().Err(err).Str("stub_id", stub.ExternalId).Msg("failed to check deployment grant")
+		return false
+	}
+
+	return granted
+}
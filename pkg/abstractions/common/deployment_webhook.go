@@ -0,0 +1,54 @@
+package abstractions
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/beam-cloud/beta9/pkg/repository"
+	"github.com/beam-cloud/beta9/pkg/types"
+	"github.com/rs/zerolog/log"
+)
+
+// DeploymentWebhookPayload is the JSON body delivered to webhooks for deployment events
+type DeploymentWebhookPayload struct {
+	Event        types.WebhookEvent `json:"event"`
+	WorkspaceId  string             `json:"workspace_id"`
+	DeploymentId string             `json:"deployment_id"`
+	Name         string             `json:"name"`
+	Version      uint               `json:"version"`
+	StubId       string             `json:"stub_id"`
+	StubType     string             `json:"stub_type"`
+	// SourceVersion is the version whose code the deployment now runs, set when a version is promoted
+	SourceVersion *uint `json:"source_version,omitempty"`
+	// Containers is the new container count, set when a deployment is scaled
+	Containers *uint     `json:"containers,omitempty"`
+	Reason     string    `json:"reason,omitempty"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// NotifyDeploymentEvent queues a delivery of event to every webhook in the deployment's workspace subscribed to it.
+// Failures are only logged, since the change to the deployment has already been made.
+func NotifyDeploymentEvent(ctx context.Context, config types.AppConfig, backendRepo repository.BackendRepository, workspaceExternalId string, deployment *types.Deployment, stubExternalId string, payload DeploymentWebhookPayload) {
+	if !config.GatewayService.DeploymentWebhooks.Enabled {
+		return
+	}
+
+	payload.WorkspaceId = workspaceExternalId
+	payload.DeploymentId = deployment.ExternalId
+	payload.Name = deployment.Name
+	payload.Version = deployment.Version
+	payload.StubId = stubExternalId
+	payload.StubType = deployment.StubType
+	payload.Timestamp = time.Now().UTC()
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		log.Error().Err(err).Str("deployment_id", deployment.ExternalId).Msg("failed to encode webhook payload")
+		return
+	}
+
+	if _, err := backendRepo.CreateObjectWebhookDeliveries(ctx, deployment.WorkspaceId, payload.Event, string(body)); err != nil {
+		log.Error().Err(err).Str("deployment_id", deployment.ExternalId).Str("event", string(payload.Event)).Msg("failed to queue webhook deliveries")
+	}
+}
@@ -18,6 +18,7 @@ const IgnoreScalingEventInterval = 10 * time.Second
 
 type IAutoscaledInstance interface {
 	ConsumeScaleResult(*AutoscalerResult)
+	ApplyAutoscalingPolicy(*AutoscalerResult) *AutoscalerResult
 	ConsumeContainerEvent(types.ContainerEvent)
 	HandleScalingEvent(int) error
 	Sync() error
@@ -88,6 +89,9 @@ type AutoscaledInstance struct {
 	// Callbacks
 	StartContainersFunc func(containersToRun int) error
 	StopContainersFunc  func(containersToStop int) error
+
+	autoscalingPolicy autoscalingPolicyCache
+	keepWarmPolicy    keepWarmPolicyCache
 }
 
 func NewAutoscaledInstance(ctx context.Context, cfg *AutoscaledInstanceConfig) (*AutoscaledInstance, error) {
@@ -207,9 +211,10 @@ func (i *AutoscaledInstance) Monitor() error {
 			}
 
 			if err := i.HandleScalingEvent(desiredContainers); err != nil {
-				if _, ok := err.(*types.ThrottledByConcurrencyLimitError); ok {
+				switch err.(type) {
+				case *types.ThrottledByConcurrencyLimitError, *types.PoolQuotaExceededError:
 					if time.Now().After(ignoreScalingEventWindow) {
-						log.Info().Str("instance_name", i.Name).Msg("throttled by concurrency limit")
+						log.Info().Str("instance_name", i.Name).Str("reason", err.Error()).Msg("throttled by concurrency limit or pool quota")
 						ignoreScalingEventWindow = time.Now().Add(IgnoreScalingEventInterval)
 					}
 				}
@@ -350,6 +355,22 @@ func (i *AutoscaledInstance) emitUnhealthyEvent(stubId, currentState, reason str
 
 	log.Info().Str("instance_name", i.Name).Msgf("%s\n", reason)
 	go i.EventRepo.PushStubStateUnhealthy(i.Workspace.ExternalId, stubId, currentState, state, reason, containers)
+
+	if currentState == types.StubStateDegraded && i.Stub.Type.IsDeployment() {
+		go i.notifyCrashLooping(reason)
+	}
+}
+
+func (i *AutoscaledInstance) notifyCrashLooping(reason string) {
+	deployment, err := i.BackendRepo.GetDeploymentByStubExternalId(i.Ctx, i.Workspace.Id, i.Stub.ExternalId)
+	if err != nil || deployment == nil {
+		return
+	}
+
+	NotifyDeploymentEvent(i.Ctx, i.AppConfig, i.BackendRepo, i.Workspace.ExternalId, &deployment.Deployment, i.Stub.ExternalId, DeploymentWebhookPayload{
+		Event:  types.DeploymentWebhookEventCrashLooping,
+		Reason: reason,
+	})
 }
 
 type InstanceController struct {
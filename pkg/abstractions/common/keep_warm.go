@@ -0,0 +1,81 @@
+package abstractions
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+// keepWarmPolicyCache holds the stub's keep warm policy. It's only touched by the autoscaler loop.
+type keepWarmPolicyCache struct {
+	policy   *types.KeepWarmPolicy
+	loadedAt time.Time
+}
+
+// KeepWarmActive reports whether the policy's schedule holds containers warm at the given time
+func KeepWarmActive(policy *types.KeepWarmPolicy, now time.Time) (bool, error) {
+	schedule, err := common.ParseCronSchedule(policy.Schedule)
+	if err != nil {
+		return false, err
+	}
+
+	location, err := time.LoadLocation(policy.Timezone)
+	if err != nil {
+		return false, err
+	}
+
+	return schedule.Matches(now.In(location)), nil
+}
+
+func (i *AutoscaledInstance) currentKeepWarmPolicy() *types.KeepWarmPolicy {
+	if time.Since(i.keepWarmPolicy.loadedAt) < autoscalingPolicyRefreshInterval {
+		return i.keepWarmPolicy.policy
+	}
+
+	policy, err := i.BackendRepo.GetKeepWarmPolicy(i.Ctx, i.Stub.ExternalId)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Warn().Err(err).Str("stub_id", i.Stub.ExternalId).Msg("unable to get keep warm policy")
+			return i.keepWarmPolicy.policy
+		}
+
+		policy = nil
+	}
+
+	i.keepWarmPolicy = keepWarmPolicyCache{policy: policy, loadedAt: time.Now()}
+	return policy
+}
+
+// applyKeepWarmPolicy raises a scale result to the stub's keep warm pool while its schedule matches. Outside
+// the schedule, the autoscaler scales the pool back down as usual.
+func (i *AutoscaledInstance) applyKeepWarmPolicy(result *AutoscalerResult) *AutoscalerResult {
+	policy := i.currentKeepWarmPolicy()
+	if policy == nil {
+		return result
+	}
+
+	active, err := KeepWarmActive(policy, time.Now())
+	if err != nil {
+		log.Warn().Err(err).Str("stub_id", i.Stub.ExternalId).Msg("invalid keep warm policy")
+		return result
+	}
+
+	if !active {
+		return result
+	}
+
+	maxReplicas := min(uint64(i.StubConfig.Autoscaler.MaxContainers), i.AppConfig.GatewayService.StubLimits.MaxReplicas)
+	desiredContainers := int(min(uint64(policy.Containers), maxReplicas))
+
+	if result == nil || !result.ResultValid {
+		return &AutoscalerResult{DesiredContainers: desiredContainers, ResultValid: true}
+	}
+
+	result.DesiredContainers = max(result.DesiredContainers, desiredContainers)
+	return result
+}
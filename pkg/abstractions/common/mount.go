@@ -34,7 +34,14 @@ func ConfigureContainerRequestMounts(containerId, stubObjectId string, workspace
 			LocalPath: path.Join(types.DefaultVolumesPath, workspace.Name, v.Id),
 			LinkPath:  path.Join(types.TempContainerWorkspace(containerId), v.MountPath),
 			MountPath: path.Join(types.WorkerContainerVolumePath, v.MountPath),
-			ReadOnly:  false,
+			ReadOnly:  v.ReadOnly,
+		}
+
+		// Volumes shared from another workspace are mounted read-only from the owner's path
+		if v.OwnerWorkspaceName != "" {
+			mount.LocalPath = path.Join(types.DefaultVolumesPath, v.OwnerWorkspaceName, v.Id)
+			mount.ReadOnly = true
+			mount.SharedVolumeId = v.Id
 		}
 
 		if v.Config != nil {
@@ -0,0 +1,88 @@
+package abstractions
+
+import (
+	"context"
+	"time"
+
+	"github.com/beam-cloud/beta9/pkg/repository"
+	"github.com/beam-cloud/beta9/pkg/types"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	rollingRestartStepTimeout  time.Duration = 5 * time.Minute
+	rollingRestartPollInterval time.Duration = 2 * time.Second
+)
+
+// ContainerStopper stops containers, usually the scheduler
+type ContainerStopper interface {
+	Stop(stopArgs *types.StopContainerArgs) error
+}
+
+// RollingRestart stops a stub's running containers one at a time. Before stopping the next container it waits
+// for the autoscaler to bring up a replacement for every container stopped so far, so the deployment keeps
+// serving while it picks up a new config. A step that times out is logged and the restart carries on.
+func RollingRestart(ctx context.Context, containerRepo repository.ContainerRepository, stopper ContainerStopper, stubId string) {
+	containers, err := containerRepo.GetActiveContainersByStubId(stubId)
+	if err != nil {
+		log.Error().Err(err).Str("stub_id", stubId).Msg("failed to get containers for rolling restart")
+		return
+	}
+
+	original := map[string]bool{}
+	for _, container := range containers {
+		original[container.ContainerId] = true
+	}
+
+	stopped := 0
+	for i, container := range containers {
+		if err := stopper.Stop(&types.StopContainerArgs{ContainerId: container.ContainerId, Reason: types.StopContainerReasonUser}); err != nil {
+			log.Warn().Err(err).Str("container_id", container.ContainerId).Msg("failed to stop container during rolling restart")
+			continue
+		}
+		stopped++
+
+		// The last container doesn't need to wait for a replacement
+		if i == len(containers)-1 {
+			return
+		}
+
+		if !waitForReplacements(ctx, containerRepo, stubId, original, stopped) {
+			log.Warn().Str("stub_id", stubId).Msg("timed out waiting for replacement container, continuing rolling restart")
+		}
+	}
+}
+
+// waitForReplacements waits until the stub has at least count running containers that weren't among the
+// original ones. Replacements are counted against the containers stopped so far, so a single new container
+// can't stand in for two stopped ones.
+func waitForReplacements(ctx context.Context, containerRepo repository.ContainerRepository, stubId string, original map[string]bool, count int) bool {
+	ctx, cancel := context.WithTimeout(ctx, rollingRestartStepTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(rollingRestartPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+			containers, err := containerRepo.GetActiveContainersByStubId(stubId)
+			if err != nil {
+				continue
+			}
+
+			replacements := 0
+			for _, container := range containers {
+				if !original[container.ContainerId] && container.Status == types.ContainerStatusRunning {
+					replacements++
+				}
+			}
+
+			if replacements >= count {
+				return true
+			}
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package abstractions
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/beam-cloud/beta9/pkg/repository"
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+type fakeStubContainers struct {
+	repository.ContainerRepository
+	mu         sync.Mutex
+	containers []types.ContainerState
+}
+
+func (f *fakeStubContainers) GetActiveContainersByStubId(stubId string) ([]types.ContainerState, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]types.ContainerState{}, f.containers...), nil
+}
+
+func (f *fakeStubContainers) set(containers ...types.ContainerState) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.containers = containers
+}
+
+type fakeStopper struct {
+	mu      sync.Mutex
+	stopped []string
+}
+
+func (f *fakeStopper) Stop(stopArgs *types.StopContainerArgs) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.stopped = append(f.stopped, stopArgs.ContainerId)
+	return nil
+}
+
+func running(id string) types.ContainerState {
+	return types.ContainerState{ContainerId: id, Status: types.ContainerStatusRunning}
+}
+
+func withFastRollingRestart(t *testing.T) {
+	stepTimeout, pollInterval := rollingRestartStepTimeout, rollingRestartPollInterval
+	rollingRestartStepTimeout, rollingRestartPollInterval = 200*time.Millisecond, 10*time.Millisecond
+	t.Cleanup(func() {
+		rollingRestartStepTimeout, rollingRestartPollInterval = stepTimeout, pollInterval
+	})
+}
+
+func TestWaitForReplacementsCountsStoppedContainers(t *testing.T) {
+	withFastRollingRestart(t)
+
+	repo := &fakeStubContainers{}
+	original := map[string]bool{"a": true, "b": true, "c": true}
+
+	repo.set(running("b"), running("c"), running("new-1"))
+	assert.True(t, waitForReplacements(context.Background(), repo, "stub", original, 1))
+
+	// A single replacement doesn't cover two stopped containers
+	repo.set(running("c"), running("new-1"))
+	assert.False(t, waitForReplacements(context.Background(), repo, "stub", original, 2))
+
+	// Containers that aren't running yet don't count
+	repo.set(running("c"), running("new-1"), types.ContainerState{ContainerId: "new-2", Status: types.ContainerStatusPending})
+	assert.False(t, waitForReplacements(context.Background(), repo, "stub", original, 2))
+
+	repo.set(running("c"), running("new-1"), running("new-2"))
+	assert.True(t, waitForReplacements(context.Background(), repo, "stub", original, 2))
+}
+
+func TestRollingRestartWaitsForReplacements(t *testing.T) {
+	withFastRollingRestart(t)
+
+	repo := &fakeStubContainers{}
+	repo.set(running("a"), running("b"))
+	stopper := &fakeStopper{}
+
+	done := make(chan struct{})
+	go func() {
+		RollingRestart(context.Background(), repo, stopper, "stub")
+		close(done)
+	}()
+
+	// Only the first container is stopped until its replacement is running
+	time.Sleep(50 * time.Millisecond)
+	stopper.mu.Lock()
+	assert.Equal(t, []string{"a"}, stopper.stopped)
+	stopper.mu.Unlock()
+
+	repo.set(running("b"), running("new-1"))
+	<-done
+
+	assert.Equal(t, []string{"a", "b"}, stopper.stopped)
+}
@@ -1,26 +1,55 @@
 package abstractions
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/repository"
 	"github.com/beam-cloud/beta9/pkg/types"
+	"github.com/rs/zerolog/log"
 )
 
+// ConfigureContainerRequestSecrets returns the stub's secrets as env vars, along with the secrets it mounts as
+// files. Pinned secrets use the value captured in the stub config, the rest use the secret's latest version.
 func ConfigureContainerRequestSecrets(
+	ctx context.Context,
+	backendRepo repository.BackendRepository,
 	workspace *types.Workspace,
 	stubConfig types.StubConfigV1,
-) ([]string, error) {
+) ([]string, *types.SecretMount, error) {
 	secretKey, err := common.ParseSecretKey(*workspace.SigningKey)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
+	latestValues := latestSecretValues(ctx, backendRepo, workspace, stubConfig.Secrets)
+
 	secretEnv := []string{}
+	var secretMount *types.SecretMount
 	for _, secret := range stubConfig.Secrets {
-		secretValue, err := common.Decrypt(secretKey, secret.Value)
+		value := secret.Value
+		if latestValue, ok := latestValues[secret.Name]; ok && !secret.Pinned {
+			value = latestValue
+		}
+
+		secretValue, err := common.Decrypt(secretKey, value)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+
+		if secret.Mount {
+			if secretMount == nil {
+				mountPath := stubConfig.SecretMountPath
+				if mountPath == "" {
+					mountPath = types.DefaultSecretMountPath
+				}
+
+				secretMount = &types.SecretMount{Path: mountPath, Files: map[string]string{}}
+			}
+
+			secretMount.Files[secret.Name] = secretValue
+			continue
 		}
 
 		secretEnv = append(
@@ -29,5 +58,33 @@ func ConfigureContainerRequestSecrets(
 		)
 	}
 
-	return secretEnv, nil
+	return secretEnv, secretMount, nil
+}
+
+// latestSecretValues looks up the current (encrypted) value of each unpinned secret. Secrets that can't be
+// looked up keep the value captured in the stub config.
+func latestSecretValues(ctx context.Context, backendRepo repository.BackendRepository, workspace *types.Workspace, secrets []types.Secret) map[string]string {
+	names := []string{}
+	for _, secret := range secrets {
+		if !secret.Pinned {
+			names = append(names, secret.Name)
+		}
+	}
+
+	values := map[string]string{}
+	if len(names) == 0 || backendRepo == nil {
+		return values
+	}
+
+	latest, err := backendRepo.GetSecretsByName(ctx, workspace, names)
+	if err != nil {
+		log.Warn().Err(err).Str("workspace_id", workspace.ExternalId).Msg("unable to look up latest secret values, using stub values")
+		return values
+	}
+
+	for _, secret := range latest {
+		values[secret.Name] = secret.Value
+	}
+
+	return values
 }
@@ -0,0 +1,319 @@
+package abstractions
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	expirable "github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+
+	apiv1 "github.com/beam-cloud/beta9/pkg/api/v1"
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/repository"
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+const (
+	taskTriggerBeta9SignatureHeader  = "X-Beta9-Signature"
+	taskTriggerBeta9TimestampHeader  = "X-Beta9-Timestamp"
+	taskTriggerGitHubSignatureHeader = "X-Hub-Signature-256"
+	taskTriggerStripeSignatureHeader = "Stripe-Signature"
+	taskTriggerSignatureHeader       = "X-Signature"
+
+	defaultTaskTriggerMaxPayloadBytes    = 1 << 20
+	defaultTaskTriggerSignatureTolerance = 5 * time.Minute
+
+	// taskTriggerHeaderPrefix marks input mappings that read a request header instead of the payload
+	taskTriggerHeaderPrefix = "header."
+	// taskTriggerPayloadKwarg is where the whole payload goes for triggers without an input mapping
+	taskTriggerPayloadKwarg = "payload"
+)
+
+var (
+	errTaskTriggerSignature        = errors.New("invalid signature")
+	errTaskTriggerSignatureExpired = errors.New("signature timestamp is outside the allowed tolerance")
+)
+
+// TaskTriggerRequest is a verified delivery to a task trigger, ready to be run as a task
+type TaskTriggerRequest struct {
+	AuthInfo *auth.AuthInfo
+	StubId   string
+	Payload  *types.TaskPayload
+}
+
+// ParseTaskTriggerRequest verifies a delivery to the trigger in the url and maps its payload to the inputs of a
+// task on the trigger's deployment. Errors are HTTP errors that can be returned as they are.
+func ParseTaskTriggerRequest(
+	ctx echo.Context,
+	config types.TaskTriggersConfig,
+	deploymentStubCache *expirable.LRU[string, string],
+	stubType string,
+	backendRepo repository.BackendRepository,
+) (*TaskTriggerRequest, error) {
+	if !config.Enabled {
+		return nil, apiv1.HTTPNotFound()
+	}
+
+	request := ctx.Request()
+
+	trigger, err := backendRepo.GetTaskTrigger(request.Context(), ctx.Param("triggerId"))
+	if err != nil {
+		log.Error().Err(err).Str("trigger_id", ctx.Param("triggerId")).Msg("failed to get task trigger")
+		return nil, apiv1.HTTPInternalServerError("Unable to get trigger")
+	}
+
+	// Triggers of other stub types are served by their own routes
+	if trigger == nil || trigger.StubType != stubType {
+		return nil, apiv1.HTTPNotFound()
+	}
+
+	maxBytes := taskTriggerMaxPayloadBytes(config, trigger.MaxPayloadBytes)
+	if request.ContentLength > maxBytes {
+		return nil, apiv1.NewHTTPError(http.StatusRequestEntityTooLarge, fmt.Sprintf("Payload exceeds the limit of %d bytes", maxBytes))
+	}
+
+	defer request.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(request.Body, maxBytes+1))
+	if err != nil {
+		return nil, apiv1.HTTPBadRequest("Unable to read payload")
+	}
+
+	if int64(len(body)) > maxBytes {
+		return nil, apiv1.NewHTTPError(http.StatusRequestEntityTooLarge, fmt.Sprintf("Payload exceeds the limit of %d bytes", maxBytes))
+	}
+
+	tolerance := config.SignatureTolerance
+	if tolerance <= 0 {
+		tolerance = defaultTaskTriggerSignatureTolerance
+	}
+
+	if err := VerifyTaskTriggerSignature(trigger.SignatureScheme, trigger.Secret, request.Header, body, time.Now(), tolerance); err != nil {
+		return nil, apiv1.HTTPUnauthorized("Invalid signature")
+	}
+
+	payload, err := MapTaskTriggerPayload(trigger.InputMapping, body, request.Header)
+	if err != nil {
+		return nil, apiv1.HTTPBadRequest(err.Error())
+	}
+
+	authInfo := &auth.AuthInfo{Workspace: &trigger.Workspace}
+	stubId, err := ParseAndValidateDeploymentStubId(
+		request.Context(),
+		deploymentStubCache,
+		authInfo,
+		"",
+		trigger.DeploymentName,
+		"",
+		stubType,
+		backendRepo,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TaskTriggerRequest{
+		AuthInfo: authInfo,
+		StubId:   stubId,
+		Payload:  payload,
+	}, nil
+}
+
+// taskTriggerMaxPayloadBytes is the trigger's own limit, if it's lower than the gateway's
+func taskTriggerMaxPayloadBytes(config types.TaskTriggersConfig, triggerMaxBytes int64) int64 {
+	maxBytes := config.MaxPayloadBytes
+	if maxBytes <= 0 {
+		maxBytes = defaultTaskTriggerMaxPayloadBytes
+	}
+
+	if triggerMaxBytes > 0 && triggerMaxBytes < maxBytes {
+		return triggerMaxBytes
+	}
+
+	return maxBytes
+}
+
+// VerifyTaskTriggerSignature checks a delivery was signed with the trigger's secret. Schemes that sign a timestamp
+// also refuse deliveries signed more than tolerance away from now, so captured deliveries can't be replayed.
+func VerifyTaskTriggerSignature(scheme types.TaskTriggerSignatureScheme, secret string, header http.Header, body []byte, now time.Time, tolerance time.Duration) error {
+	switch scheme {
+	case types.TaskTriggerSignatureBeta9:
+		timestamp, err := strconv.ParseInt(header.Get(taskTriggerBeta9TimestampHeader), 10, 64)
+		if err != nil {
+			return errTaskTriggerSignature
+		}
+
+		if !withinTolerance(timestamp, now, tolerance) {
+			return errTaskTriggerSignatureExpired
+		}
+
+		if !auth.VerifyPayloadSignature(body, secret, header.Get(taskTriggerBeta9SignatureHeader), timestamp) {
+			return errTaskTriggerSignature
+		}
+
+		return nil
+	case types.TaskTriggerSignatureGitHub:
+		signature, ok := strings.CutPrefix(header.Get(taskTriggerGitHubSignatureHeader), "sha256=")
+		if !ok || !hmacSHA256Equal(secret, body, signature) {
+			return errTaskTriggerSignature
+		}
+
+		return nil
+	case types.TaskTriggerSignatureStripe:
+		return verifyStripeSignature(secret, header.Get(taskTriggerStripeSignatureHeader), body, now, tolerance)
+	case types.TaskTriggerSignatureHMACSHA256:
+		signature := strings.TrimPrefix(header.Get(taskTriggerSignatureHeader), "sha256=")
+		if !hmacSHA256Equal(secret, body, signature) {
+			return errTaskTriggerSignature
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("unknown signature scheme %q", scheme)
+}
+
+// verifyStripeSignature checks a Stripe-Signature header, t=<timestamp>,v1=<signature>. Stripe sends a v1
+// signature for each of the endpoint's active secrets while one is being rolled, so any of them can match.
+func verifyStripeSignature(secret string, header string, body []byte, now time.Time, tolerance time.Duration) error {
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	t, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return errTaskTriggerSignature
+	}
+
+	if !withinTolerance(t, now, tolerance) {
+		return errTaskTriggerSignatureExpired
+	}
+
+	signed := append([]byte(timestamp+"."), body...)
+	for _, signature := range signatures {
+		if hmacSHA256Equal(secret, signed, signature) {
+			return nil
+		}
+	}
+
+	return errTaskTriggerSignature
+}
+
+func hmacSHA256Equal(secret string, data []byte, hexSignature string) bool {
+	signature, err := hex.DecodeString(hexSignature)
+	if err != nil {
+		return false
+	}
+
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write(data)
+	return hmac.Equal(h.Sum(nil), signature)
+}
+
+func withinTolerance(timestamp int64, now time.Time, tolerance time.Duration) bool {
+	delta := now.Sub(time.Unix(timestamp, 0))
+	return delta <= tolerance && delta >= -tolerance
+}
+
+// MapTaskTriggerPayload turns a delivery into task inputs. Without a mapping the whole payload is passed as the
+// payload kwarg, otherwise each kwarg gets the value at its path. Kwargs whose path isn't in the delivery are
+// left out, so the task's defaults apply.
+func MapTaskTriggerPayload(mapping types.TaskTriggerInputMapping, body []byte, header http.Header) (*types.TaskPayload, error) {
+	var data interface{}
+	if len(bytes.TrimSpace(body)) > 0 {
+		if err := json.Unmarshal(body, &data); err != nil {
+			return nil, errors.New("payload must be JSON")
+		}
+	}
+
+	payload := &types.TaskPayload{
+		Kwargs: make(map[string]interface{}),
+	}
+
+	if len(mapping) == 0 {
+		payload.Kwargs[taskTriggerPayloadKwarg] = data
+		return payload, nil
+	}
+
+	for kwarg, path := range mapping {
+		if name, ok := strings.CutPrefix(path, taskTriggerHeaderPrefix); ok {
+			if values := header.Values(name); len(values) > 0 {
+				payload.Kwargs[kwarg] = values[0]
+			}
+			continue
+		}
+
+		if value, ok := lookupPayloadPath(data, path); ok {
+			payload.Kwargs[kwarg] = value
+		}
+	}
+
+	return payload, nil
+}
+
+// lookupPayloadPath follows a dot separated path of object keys and list indexes, where an empty path is the
+// whole payload
+func lookupPayloadPath(data interface{}, path string) (interface{}, bool) {
+	if path == "" || path == "." {
+		return data, true
+	}
+
+	current := data
+	for _, segment := range strings.Split(path, ".") {
+		switch value := current.(type) {
+		case map[string]interface{}:
+			next, ok := value[segment]
+			if !ok {
+				return nil, false
+			}
+			current = next
+		case []interface{}:
+			i, err := strconv.Atoi(segment)
+			if err != nil || i < 0 || i >= len(value) {
+				return nil, false
+			}
+			current = value[i]
+		default:
+			return nil, false
+		}
+	}
+
+	return current, true
+}
+
+// ValidateTaskTriggerInputMapping checks mapping keys are usable kwarg names and that paths aren't empty
+func ValidateTaskTriggerInputMapping(mapping map[string]string) error {
+	for kwarg, path := range mapping {
+		if kwarg == "" || strings.ContainsAny(kwarg, " .") {
+			return fmt.Errorf("invalid input name %q", kwarg)
+		}
+
+		if name, ok := strings.CutPrefix(path, taskTriggerHeaderPrefix); ok && name == "" {
+			return fmt.Errorf("input %q is missing a header name", kwarg)
+		}
+	}
+
+	return nil
+}
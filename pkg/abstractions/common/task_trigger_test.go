@@ -0,0 +1,120 @@
+package abstractions
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+func testHMAC(secret string, data string) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(data))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func TestVerifyTaskTriggerSignature(t *testing.T) {
+	secret := "whsec_test"
+	body := []byte(`{"action":"opened"}`)
+	now := time.Now()
+	tolerance := 5 * time.Minute
+
+	signature := auth.SignPayload(body, secret)
+	beta9 := http.Header{}
+	beta9.Set(taskTriggerBeta9SignatureHeader, signature.Key)
+	beta9.Set(taskTriggerBeta9TimestampHeader, strconv.FormatInt(signature.Timestamp, 10))
+
+	github := http.Header{}
+	github.Set(taskTriggerGitHubSignatureHeader, "sha256="+testHMAC(secret, string(body)))
+
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	stripe := http.Header{}
+	stripe.Set(taskTriggerStripeSignatureHeader, fmt.Sprintf("t=%s,v1=%s,v1=%s", timestamp, testHMAC("old", timestamp+"."+string(body)), testHMAC(secret, timestamp+"."+string(body))))
+
+	staleTimestamp := strconv.FormatInt(now.Add(-time.Hour).Unix(), 10)
+	staleStripe := http.Header{}
+	staleStripe.Set(taskTriggerStripeSignatureHeader, fmt.Sprintf("t=%s,v1=%s", staleTimestamp, testHMAC(secret, staleTimestamp+"."+string(body))))
+
+	generic := http.Header{}
+	generic.Set(taskTriggerSignatureHeader, testHMAC(secret, string(body)))
+
+	tests := []struct {
+		name    string
+		scheme  types.TaskTriggerSignatureScheme
+		header  http.Header
+		body    []byte
+		wantErr bool
+	}{
+		{"beta9", types.TaskTriggerSignatureBeta9, beta9, body, false},
+		{"beta9 tampered body", types.TaskTriggerSignatureBeta9, beta9, []byte(`{}`), true},
+		{"github", types.TaskTriggerSignatureGitHub, github, body, false},
+		{"github missing header", types.TaskTriggerSignatureGitHub, http.Header{}, body, true},
+		{"github wrong scheme header", types.TaskTriggerSignatureStripe, github, body, true},
+		{"stripe with rolled secret", types.TaskTriggerSignatureStripe, stripe, body, false},
+		{"stripe replayed", types.TaskTriggerSignatureStripe, staleStripe, body, true},
+		{"hmac-sha256", types.TaskTriggerSignatureHMACSHA256, generic, body, false},
+		{"hmac-sha256 tampered body", types.TaskTriggerSignatureHMACSHA256, generic, []byte(`{}`), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyTaskTriggerSignature(tt.scheme, secret, tt.header, tt.body, now, tolerance)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMapTaskTriggerPayload(t *testing.T) {
+	body := []byte(`{"action":"opened","pull_request":{"number":7,"labels":[{"name":"bug"}]}}`)
+	header := http.Header{}
+	header.Set("X-GitHub-Event", "pull_request")
+
+	payload, err := MapTaskTriggerPayload(nil, body, header)
+	require.NoError(t, err)
+	assert.Equal(t, "opened", payload.Kwargs["payload"].(map[string]interface{})["action"])
+
+	payload, err = MapTaskTriggerPayload(types.TaskTriggerInputMapping{
+		"action":  "action",
+		"number":  "pull_request.number",
+		"label":   "pull_request.labels.0.name",
+		"event":   "header.X-GitHub-Event",
+		"missing": "pull_request.title",
+	}, body, header)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"action": "opened",
+		"number": float64(7),
+		"label":  "bug",
+		"event":  "pull_request",
+	}, payload.Kwargs)
+
+	_, err = MapTaskTriggerPayload(nil, []byte("action=opened"), header)
+	assert.Error(t, err)
+
+	payload, err = MapTaskTriggerPayload(nil, nil, header)
+	require.NoError(t, err)
+	assert.Nil(t, payload.Kwargs["payload"])
+}
+
+func TestTaskTriggerMaxPayloadBytes(t *testing.T) {
+	config := types.TaskTriggersConfig{MaxPayloadBytes: 1024}
+
+	assert.Equal(t, int64(1024), taskTriggerMaxPayloadBytes(config, 0))
+	assert.Equal(t, int64(512), taskTriggerMaxPayloadBytes(config, 512))
+	assert.Equal(t, int64(1024), taskTriggerMaxPayloadBytes(config, 4096))
+	assert.Equal(t, int64(defaultTaskTriggerMaxPayloadBytes), taskTriggerMaxPayloadBytes(types.TaskTriggersConfig{}, 0))
+}
@@ -7,7 +7,7 @@ import (
 	"github.com/beam-cloud/beta9/pkg/types"
 )
 
-func TrackTaskCost(duration time.Duration, stub *types.StubWithRelated, pricingConfig *types.PricingPolicy, usageMetricsRepo repository.UsageMetricsRepository, taskId, externalWorkspaceId string) {
+func TrackTaskCost(duration time.Duration, stub *types.StubWithRelated, pricingConfig *types.PricingPolicy, usageMetricsRepo repository.UsageMetricsRepository, taskId, gpu, externalWorkspaceId string) {
 	if stub.Workspace.ExternalId == externalWorkspaceId {
 		return
 	}
@@ -24,6 +24,7 @@ func TrackTaskCost(duration time.Duration, stub *types.StubWithRelated, pricingC
 		"app_id":       stub.App.ExternalId,
 		"workspace_id": externalWorkspaceId,
 		"task_id":      taskId,
+		"gpu":          gpu,
 		"value":        totalCostCents,
 	}, totalCostCents)
 }
@@ -10,6 +10,7 @@ import (
 type endpointAutoscalerSample struct {
 	TotalRequests     int64
 	CurrentContainers int64
+	OpenConnections   int64
 }
 
 func endpointSampleFunc(i *endpointInstance) (*endpointAutoscalerSample, error) {
@@ -29,11 +30,36 @@ func endpointSampleFunc(i *endpointInstance) (*endpointAutoscalerSample, error)
 	sample := &endpointAutoscalerSample{
 		TotalRequests:     int64(totalRequests),
 		CurrentContainers: int64(currentContainers),
+		OpenConnections:   countOpenConnections(i),
 	}
 
 	return sample, nil
 }
 
+// countOpenConnections sums the long-lived connections open across the stub's containers. It's only sampled
+// for stubs that scale on connections.
+func countOpenConnections(i *endpointInstance) int64 {
+	if i.StubConfig.Connections == nil || i.StubConfig.Connections.ConnectionsPerContainer == 0 {
+		return 0
+	}
+
+	containers, err := i.ContainerRepo.GetActiveContainersByStubId(i.Stub.ExternalId)
+	if err != nil {
+		return 0
+	}
+
+	var total int64
+	for _, container := range containers {
+		connections, err := openConnections(i.Ctx, i.Rdb, i.Workspace.Name, i.Stub.ExternalId, container.ContainerId)
+		if err != nil {
+			continue
+		}
+		total += connections
+	}
+
+	return total
+}
+
 func endpointDeploymentScaleFunc(i *endpointInstance, s *endpointAutoscalerSample) *abstractions.AutoscalerResult {
 	desiredContainers := 0
 
@@ -51,8 +77,21 @@ func endpointDeploymentScaleFunc(i *endpointInstance, s *endpointAutoscalerSampl
 			tasksPerContainer = int64(i.StubConfig.Autoscaler.TasksPerContainer)
 		}
 
-		desiredContainers = int(s.TotalRequests / int64(tasksPerContainer))
-		if s.TotalRequests%int64(tasksPerContainer) > 0 {
+		// Open connections are requests too, but when the stub sets a target for them they're scaled on
+		// that instead, since a container can hold many more idle connections than busy requests
+		requests := s.TotalRequests
+		if policy := i.StubConfig.Connections; policy != nil && policy.ConnectionsPerContainer > 0 && s.OpenConnections > 0 {
+			requests = max(requests-s.OpenConnections, 0)
+
+			connectionsPerContainer := int64(policy.ConnectionsPerContainer)
+			desiredContainers = int(s.OpenConnections / connectionsPerContainer)
+			if s.OpenConnections%connectionsPerContainer > 0 {
+				desiredContainers += 1
+			}
+		}
+
+		desiredContainers += int(requests / int64(tasksPerContainer))
+		if requests%int64(tasksPerContainer) > 0 {
 			desiredContainers += 1
 		}
 
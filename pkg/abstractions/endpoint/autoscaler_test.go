@@ -138,3 +138,60 @@ func TestDeploymentScaleFuncWithMaxTasksPerContainer(t *testing.T) {
 	assert.Equal(t, true, result.ResultValid)
 	assert.Equal(t, 2, result.DesiredContainers)
 }
+
+func TestDeploymentScaleFuncWithConnectionsPerContainer(t *testing.T) {
+	autoscaledInstance := &abstractions.AutoscaledInstance{
+		Ctx: context.Background(),
+		Stub: &types.StubWithRelated{
+			Stub: types.Stub{
+				ExternalId: "test",
+			},
+		},
+		AppConfig: types.AppConfig{},
+	}
+
+	autoscaledInstance.AppConfig.GatewayService = types.GatewayServiceConfig{
+		StubLimits: types.StubLimits{
+			MaxReplicas: 10,
+		},
+	}
+	autoscaledInstance.StubConfig = &types.StubConfigV1{}
+	autoscaledInstance.StubConfig.Autoscaler = &types.Autoscaler{
+		Type:              "queue_depth",
+		MaxContainers:     10,
+		TasksPerContainer: 1,
+	}
+	autoscaledInstance.StubConfig.Connections = &types.ConnectionPolicy{
+		ConnectionsPerContainer: 100,
+	}
+
+	instance := &endpointInstance{}
+	instance.AutoscaledInstance = autoscaledInstance
+
+	// Connections are scaled on their own target instead of one container per request
+	sample := &endpointAutoscalerSample{
+		TotalRequests:   150,
+		OpenConnections: 150,
+	}
+
+	result := endpointDeploymentScaleFunc(instance, sample)
+	assert.Equal(t, true, result.ResultValid)
+	assert.Equal(t, 2, result.DesiredContainers)
+
+	// Requests that aren't connections still count against tasks per container
+	sample = &endpointAutoscalerSample{
+		TotalRequests:   52,
+		OpenConnections: 50,
+	}
+
+	result = endpointDeploymentScaleFunc(instance, sample)
+	assert.Equal(t, true, result.ResultValid)
+	assert.Equal(t, 3, result.DesiredContainers)
+
+	// Without a target, connections count as requests
+	autoscaledInstance.StubConfig.Connections = nil
+
+	result = endpointDeploymentScaleFunc(instance, sample)
+	assert.Equal(t, true, result.ResultValid)
+	assert.Equal(t, 10, result.DesiredContainers)
+}
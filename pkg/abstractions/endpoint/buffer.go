@@ -16,12 +16,15 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/labstack/echo/v4"
 	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/net/http2"
 
 	abstractions "github.com/beam-cloud/beta9/pkg/abstractions/common"
 	"github.com/beam-cloud/beta9/pkg/common"
 	"github.com/beam-cloud/beta9/pkg/metrics"
 	"github.com/beam-cloud/beta9/pkg/network"
 	"github.com/beam-cloud/beta9/pkg/repository"
+	"github.com/beam-cloud/beta9/pkg/scheduler"
 	"github.com/beam-cloud/beta9/pkg/task"
 	"github.com/beam-cloud/beta9/pkg/types"
 )
@@ -58,13 +61,19 @@ type RequestBuffer struct {
 	workspace               *types.Workspace
 	rdb                     *common.RedisClient
 	containerRepo           repository.ContainerRepository
+	scheduler               *scheduler.Scheduler
 	buffer                  *abstractions.RingBuffer[*request]
 	availableContainers     []container
 	availableContainersLock sync.RWMutex
 	maxTokens               int
 	isASGI                  bool
+	isGRPC                  bool
+	grpcTransport           *http2.Transport
 	keyEventManager         *common.KeyEventManager
 	keyEventChan            chan common.KeyEvent
+	healthCheck             *types.HealthCheck
+	healthStates            map[string]*containerHealthState
+	healthStatesLock        sync.Mutex
 }
 
 func NewRequestBuffer(
@@ -74,11 +83,13 @@ func NewRequestBuffer(
 	stubId string,
 	size int,
 	containerRepo repository.ContainerRepository,
+	scheduler *scheduler.Scheduler,
 	keyEventManager *common.KeyEventManager,
 	stubConfig *types.StubConfigV1,
 	tailscale *network.Tailscale,
 	tsConfig types.TailscaleConfig,
 	isASGI bool,
+	isGRPC bool,
 ) *RequestBuffer {
 	rb := &RequestBuffer{
 		ctx:                     ctx,
@@ -90,6 +101,7 @@ func NewRequestBuffer(
 		availableContainers:     []container{},
 		availableContainersLock: sync.RWMutex{},
 		containerRepo:           containerRepo,
+		scheduler:               scheduler,
 		keyEventManager:         keyEventManager,
 		keyEventChan:            make(chan common.KeyEvent),
 		httpClient:              &http.Client{},
@@ -97,13 +109,25 @@ func NewRequestBuffer(
 		tsConfig:                tsConfig,
 		maxTokens:               int(stubConfig.Workers),
 		isASGI:                  isASGI,
+		isGRPC:                  isGRPC,
+		healthCheck:             stubConfig.HealthCheck,
+		healthStates:            map[string]*containerHealthState{},
 	}
 
-	if stubConfig.ConcurrentRequests > 1 && isASGI {
+	if stubConfig.ConcurrentRequests > 1 && (isASGI || isGRPC) {
 		// Floor is set to the number of workers
 		rb.maxTokens = max(int(stubConfig.ConcurrentRequests), rb.maxTokens)
 	}
 
+	if isGRPC {
+		rb.grpcTransport = newGRPCTransport(tailscale, tsConfig)
+
+		go func() {
+			<-ctx.Done()
+			rb.grpcTransport.CloseIdleConnections()
+		}()
+	}
+
 	go rb.discoverContainers()
 	go rb.processRequests()
 
@@ -151,8 +175,22 @@ func (rb *RequestBuffer) ForwardRequest(ctx echo.Context, task *EndpointTask) er
 		case <-rb.ctx.Done():
 			return nil
 		case <-ctx.Request().Context().Done():
+			if !requestTimedOut(ctx) {
+				if !req.processed {
+					rb.cancelInFlightTask(req.task, types.TaskRequestCancelled)
+				}
+				return nil
+			}
+
 			if !req.processed {
-				rb.cancelInFlightTask(req.task, types.TaskRequestCancelled)
+				rb.cancelInFlightTask(req.task, types.TaskRequestTimedOut)
+				return writeRequestTimedOut(ctx)
+			}
+
+			// The request is with a container, let it write the timeout response
+			select {
+			case <-done:
+			case <-rb.ctx.Done():
 			}
 			return nil
 		case <-done:
@@ -189,6 +227,20 @@ func (rb *RequestBuffer) processRequests() {
 }
 
 func (rb *RequestBuffer) checkAddressIsReady(address string) bool {
+	// gRPC servers don't answer HTTP/1 requests, so they're ready once they accept connections
+	if rb.isGRPC {
+		ctx, cancel := context.WithTimeout(rb.ctx, checkAddressIsReadyTimeout)
+		defer cancel()
+
+		conn, err := network.GetDialer(address, rb.tailscale, rb.tsConfig)(ctx, "tcp", address)
+		if err != nil {
+			return false
+		}
+
+		conn.Close()
+		return true
+	}
+
 	httpClient, err := rb.getHttpClient(address, checkAddressIsReadyTimeout)
 	if err != nil {
 		return false
@@ -223,6 +275,10 @@ func (rb *RequestBuffer) discoverContainers() {
 				continue
 			}
 
+			if rb.healthCheck != nil {
+				rb.pruneHealthStates(containerStates)
+			}
+
 			var wg sync.WaitGroup
 			availableContainersChan := make(chan container, len(containerStates))
 
@@ -235,6 +291,11 @@ func (rb *RequestBuffer) discoverContainers() {
 						return
 					}
 
+					// Containers being scaled down only finish the connections they already have
+					if isDraining(rb.ctx, rb.rdb, rb.workspace.Name, rb.stubId, cs.ContainerId) {
+						return
+					}
+
 					containerAddress, err := rb.containerRepo.GetContainerAddress(cs.ContainerId)
 					if err != nil {
 						return
@@ -249,7 +310,15 @@ func (rb *RequestBuffer) discoverContainers() {
 					// that means we currently have 5-3 -> 2 requests in flight
 					inFlightRequests := rb.maxTokens - availableTokens
 
-					if rb.checkAddressIsReady(containerAddress) {
+					// Stubs with their own health check only route to containers passing it
+					var ready bool
+					if rb.healthCheck != nil {
+						ready = rb.containerIsHealthy(cs, containerAddress)
+					} else {
+						ready = rb.checkAddressIsReady(containerAddress)
+					}
+
+					if ready {
 						availableContainersChan <- container{
 							id:               cs.ContainerId,
 							address:          containerAddress,
@@ -400,7 +469,9 @@ func (rb *RequestBuffer) handleRequest(req *request) {
 	defer rb.afterRequest(req, c.id)
 
 	req.processed = true
-	if req.ctx.IsWebSocket() {
+	if rb.isGRPC {
+		rb.handleGRPCRequest(req, c)
+	} else if req.ctx.IsWebSocket() {
 		rb.handleWSRequest(req, c)
 	} else {
 		rb.handleHttpRequest(req, c)
@@ -435,6 +506,12 @@ func (rb *RequestBuffer) handleHttpRequest(req *request, c container) {
 				return
 			}
 
+			if requestTooLarge(err) {
+				writeRequestTooLarge(req.ctx, int64(rb.stubConfig.RequestLimits.MaxRequestBodyBytes))
+				rb.cancelInFlightTask(req.task, types.TaskInvalidRequestPayload)
+				return
+			}
+
 			req.ctx.JSON(http.StatusBadRequest, map[string]interface{}{
 				"error": err.Error(),
 			})
@@ -471,7 +548,11 @@ func (rb *RequestBuffer) handleHttpRequest(req *request, c container) {
 		}
 	}
 
-	httpReq, err := http.NewRequestWithContext(request.Context(), request.Method, containerUrl, requestBody)
+	// Cancelled on its own when an event stream goes idle
+	reqCtx, cancelReq := context.WithCancel(request.Context())
+	defer cancelReq()
+
+	httpReq, err := http.NewRequestWithContext(reqCtx, request.Method, containerUrl, requestBody)
 	if err != nil {
 		req.ctx.JSON(http.StatusInternalServerError, map[string]interface{}{
 			"error": "Internal server error",
@@ -493,11 +574,29 @@ func (rb *RequestBuffer) handleHttpRequest(req *request, c container) {
 	if err != nil {
 		if req.ctx.Request().Context().Err() == context.Canceled {
 			rb.cancelInFlightTask(req.task, types.TaskRequestCancelled)
+		} else if requestTimedOut(req.ctx) {
+			rb.cancelInFlightTask(req.task, types.TaskRequestTimedOut)
+			writeRequestTimedOut(req.ctx)
+		} else if requestTooLarge(err) {
+			rb.cancelInFlightTask(req.task, types.TaskInvalidRequestPayload)
+			writeRequestTooLarge(req.ctx, int64(rb.stubConfig.RequestLimits.MaxRequestBodyBytes))
 		}
 		return
 	}
 	defer resp.Body.Close()
 
+	var maxResponseBytes int64
+	if rb.stubConfig.RequestLimits != nil {
+		maxResponseBytes = int64(rb.stubConfig.RequestLimits.MaxResponseBytes)
+	}
+
+	if maxResponseBytes > 0 && resp.ContentLength > maxResponseBytes {
+		req.ctx.JSON(http.StatusBadGateway, map[string]interface{}{
+			"error": fmt.Sprintf("Response exceeds the limit of %d bytes", maxResponseBytes),
+		})
+		return
+	}
+
 	// Set response headers and status code before writing the body
 	for key, values := range resp.Header {
 		for _, value := range values {
@@ -513,11 +612,32 @@ func (rb *RequestBuffer) handleHttpRequest(req *request, c container) {
 		streamingSupported = false
 	}
 
+	// Event streams stay open as long as the container keeps sending, so they're tracked as connections
+	var body io.Reader = resp.Body
+	if isEventStream(resp) {
+		stopTracking := rb.trackConnection(c.id, req.task.msg.TaskId)
+		defer stopTracking()
+
+		if idleTimeout := rb.idleTimeout(); idleTimeout > 0 {
+			watcher := newIdleWatcher()
+			body = &idleTrackingReader{reader: resp.Body, watcher: watcher}
+			go watcher.watch(reqCtx, idleTimeout, cancelReq)
+		}
+	}
+
 	// Send response to client in chunks
+	var written int64
 	buf := make([]byte, 4096)
 	for {
-		n, err := resp.Body.Read(buf)
+		n, err := body.Read(buf)
 		if n > 0 {
+			// Headers are already out, so all that's left is to cut the response off
+			written += int64(n)
+			if maxResponseBytes > 0 && written > maxResponseBytes {
+				log.Warn().Str("stub_id", rb.stubId).Str("task_id", req.task.msg.TaskId).Int64("max_response_bytes", maxResponseBytes).Msg("response exceeded limit, truncating")
+				break
+			}
+
 			req.ctx.Response().Writer.Write(buf[:n])
 
 			if streamingSupported {
@@ -526,7 +646,9 @@ func (rb *RequestBuffer) handleHttpRequest(req *request, c container) {
 		}
 
 		if err != nil {
-			if err != io.EOF && err != context.Canceled {
+			if requestTimedOut(req.ctx) {
+				rb.cancelInFlightTask(req.task, types.TaskRequestTimedOut)
+			} else if err != io.EOF && err != context.Canceled {
 				req.ctx.JSON(http.StatusInternalServerError, map[string]interface{}{
 					"error": "Internal server error",
 				})
@@ -610,20 +732,50 @@ func (rb *RequestBuffer) proxyWebsocketConnection(r *request, c container, diale
 	}
 
 	go rb.heartBeat(r, c.id) // Send heartbeat via redis for duration of request
-	go forwardWSConn(wsSrc.NetConn(), wsDst.NetConn())
 
-	forwardWSConn(wsDst.NetConn(), wsSrc.NetConn())
+	stopTracking := rb.trackConnection(c.id, r.task.msg.TaskId)
+	defer stopTracking()
+
+	var watcher *idleWatcher
+	if idleTimeout := rb.idleTimeout(); idleTimeout > 0 {
+		watcher = newIdleWatcher()
+
+		ctx, cancel := context.WithCancel(rb.ctx)
+		defer cancel()
+
+		go watcher.watch(ctx, idleTimeout, func() {
+			closeIdleWSConn(wsSrc)
+			closeIdleWSConn(wsDst)
+		})
+	}
+
+	go forwardWSConn(wsSrc.NetConn(), wsDst.NetConn(), watcher)
+
+	forwardWSConn(wsDst.NetConn(), wsSrc.NetConn(), watcher)
 
 	return nil
 }
 
-func forwardWSConn(src net.Conn, dst net.Conn) {
+// closeIdleWSConn tells the peer why the connection is going away before closing it. Nothing has been sent
+// for the idle timeout, so the close frame won't land in the middle of a forwarded one.
+func closeIdleWSConn(conn *websocket.Conn) {
+	message := websocket.FormatCloseMessage(websocket.CloseGoingAway, "idle timeout")
+	conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(time.Second))
+	conn.Close()
+}
+
+func forwardWSConn(src net.Conn, dst net.Conn, watcher *idleWatcher) {
 	defer func() {
 		src.Close()
 		dst.Close()
 	}()
 
-	_, err := io.Copy(src, dst)
+	var reader io.Reader = dst
+	if watcher != nil {
+		reader = &idleTrackingReader{reader: dst, watcher: watcher}
+	}
+
+	_, err := io.Copy(src, reader)
 	if err != nil {
 		return
 	}
@@ -0,0 +1,132 @@
+package endpoint
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/beam-cloud/beta9/pkg/common"
+)
+
+const (
+	idleCheckInterval time.Duration = time.Second
+)
+
+// trackConnection records a long-lived connection to a container until the returned func is called. Each
+// connection holds a lease in the container's connection set, renewed with the request heartbeat, so connections
+// dropped along with a gateway fall out of the count on their own.
+func (rb *RequestBuffer) trackConnection(containerId, taskId string) func() {
+	key := Keys.endpointConnections(rb.workspace.Name, rb.stubId, containerId)
+	tokenKey := Keys.endpointRequestTokens(rb.workspace.Name, rb.stubId, containerId)
+
+	renew := func() {
+		rb.rdb.ZAdd(rb.ctx, key, redis.Z{Score: float64(time.Now().Unix()), Member: taskId})
+		rb.rdb.Expire(rb.ctx, key, endpointRequestHeartbeatKeepAlive)
+
+		// Connections can outlive the task timeout the request token is kept for
+		rb.rdb.Expire(rb.ctx, tokenKey, time.Duration(rb.stubConfig.TaskPolicy.Timeout)*time.Second)
+	}
+	renew()
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(endpointRequestHeartbeatInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-rb.ctx.Done():
+				return
+			case <-ticker.C:
+				renew()
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		rb.rdb.ZRem(context.Background(), key, taskId)
+	}
+}
+
+// openConnections returns the number of long-lived connections open to a container
+func openConnections(ctx context.Context, rdb *common.RedisClient, workspaceName, stubId, containerId string) (int64, error) {
+	key := Keys.endpointConnections(workspaceName, stubId, containerId)
+	minScore := strconv.FormatInt(time.Now().Add(-endpointRequestHeartbeatKeepAlive).Unix(), 10)
+
+	return rdb.ZCount(ctx, key, minScore, "+inf").Result()
+}
+
+// idleTimeout returns how long a long-lived connection may sit idle before it's closed, 0 if it may stay open
+func (rb *RequestBuffer) idleTimeout() time.Duration {
+	if rb.stubConfig.Connections == nil {
+		return 0
+	}
+
+	return rb.stubConfig.Connections.IdleTimeout()
+}
+
+func isEventStream(resp *http.Response) bool {
+	return strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream")
+}
+
+// isDraining reports whether a container is being scaled down and shouldn't take new requests
+func isDraining(ctx context.Context, rdb *common.RedisClient, workspaceName, stubId, containerId string) bool {
+	exists, err := rdb.Exists(ctx, Keys.endpointDraining(workspaceName, stubId, containerId)).Result()
+	return err == nil && exists > 0
+}
+
+// idleWatcher closes a connection once no data has moved over it for the idle timeout
+type idleWatcher struct {
+	lastActivity atomic.Int64
+}
+
+func newIdleWatcher() *idleWatcher {
+	w := &idleWatcher{}
+	w.touch()
+	return w
+}
+
+func (w *idleWatcher) touch() {
+	w.lastActivity.Store(time.Now().UnixNano())
+}
+
+// watch calls onIdle once the connection has been idle for timeout, unless ctx is done first
+func (w *idleWatcher) watch(ctx context.Context, timeout time.Duration, onIdle func()) {
+	ticker := time.NewTicker(min(idleCheckInterval, timeout))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if time.Since(time.Unix(0, w.lastActivity.Load())) >= timeout {
+				onIdle()
+				return
+			}
+		}
+	}
+}
+
+// idleTrackingReader marks the watcher active whenever data is read
+type idleTrackingReader struct {
+	reader  io.Reader
+	watcher *idleWatcher
+}
+
+func (r *idleTrackingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		r.watcher.touch()
+	}
+	return n, err
+}
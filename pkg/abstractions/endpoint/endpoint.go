@@ -10,6 +10,7 @@ import (
 
 	jsoniter "github.com/json-iterator/go"
 
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 
 	abstractions "github.com/beam-cloud/beta9/pkg/abstractions/common"
@@ -48,12 +49,14 @@ type HttpEndpointService struct {
 	tailscale         *network.Tailscale
 	taskDispatcher    *task.Dispatcher
 	controller        *abstractions.InstanceController
+	limiter           *abstractions.ConcurrencyLimiter
 }
 
 var (
 	DefaultEndpointRequestTimeoutS int    = 600 // 10 minutes
 	DefaultEndpointRequestTTL      uint32 = 600 // 10 minutes
 	ASGIRoutePrefix                string = "/asgi"
+	GRPCRoutePrefix                string = "/grpc"
 
 	endpointContainerPrefix           string        = "endpoint"
 	endpointRoutePrefix               string        = "/endpoint"
@@ -102,6 +105,7 @@ func NewHTTPEndpointService(
 		taskDispatcher:    opts.TaskDispatcher,
 		eventRepo:         opts.EventRepo,
 		usageMetricsRepo:  opts.UsageMetricsRepo,
+		limiter:           abstractions.NewConcurrencyLimiter(opts.RedisClient),
 	}
 
 	// Listen for container events with a certain prefix
@@ -112,7 +116,7 @@ func NewHTTPEndpointService(
 	}
 	eventManager.Listen()
 
-	es.controller = abstractions.NewInstanceController(ctx, es.InstanceFactory, []string{types.StubTypeEndpointDeployment, types.StubTypeASGIDeployment}, es.backendRepo, es.rdb)
+	es.controller = abstractions.NewInstanceController(ctx, es.InstanceFactory, []string{types.StubTypeEndpointDeployment, types.StubTypeASGIDeployment, types.StubTypeGRPCDeployment}, es.backendRepo, es.rdb)
 	err = es.controller.Init()
 	if err != nil {
 		return nil, err
@@ -122,9 +126,10 @@ func NewHTTPEndpointService(
 	es.taskDispatcher.Register(string(types.ExecutorEndpoint), es.endpointTaskFactory)
 
 	// Register HTTP routes
-	authMiddleware := auth.AuthMiddleware(es.backendRepo, es.workspaceRepo)
+	authMiddleware := auth.AuthMiddleware(es.backendRepo, es.workspaceRepo, opts.Config.GatewayService.IPAllowList)
 	registerEndpointRoutes(opts.RouteGroup.Group(endpointRoutePrefix, authMiddleware), es)
 	registerASGIRoutes(opts.RouteGroup.Group(ASGIRoutePrefix, authMiddleware), es)
+	registerGRPCRoutes(opts.RouteGroup.Group(GRPCRoutePrefix, authMiddleware), es)
 
 	return es, nil
 }
@@ -160,6 +165,12 @@ func (es *HttpEndpointService) forwardRequest(
 		return err
 	}
 
+	releaseLimits, err := applyRequestLimits(ctx, instance.StubConfig.RequestLimits)
+	defer releaseLimits()
+	if err != nil || ctx.Response().Committed {
+		return err
+	}
+
 	tasksInFlight, err := es.taskRepo.TasksInFlight(ctx.Request().Context(), instance.Workspace.Name, stubId)
 	if err != nil {
 		return ctx.JSON(http.StatusInternalServerError, map[string]interface{}{
@@ -174,6 +185,27 @@ func (es *HttpEndpointService) forwardRequest(
 		})
 	}
 
+	// Wait for a slot if the deployment caps concurrent requests
+	release, err := es.limiter.Acquire(ctx.Request().Context(), stubId, uuid.NewString(), instance.StubConfig.Concurrency)
+	if err != nil {
+		if errors.Is(err, abstractions.ErrConcurrencyQueueFull) || errors.Is(err, abstractions.ErrConcurrencyShed) {
+			return ctx.JSON(http.StatusTooManyRequests, map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+
+		if requestTimedOut(ctx) {
+			return writeRequestTimedOut(ctx)
+		}
+
+		if ctx.Request().Context().Err() != nil {
+			return nil
+		}
+
+		return err
+	}
+	defer release()
+
 	// Needed for backwards compatibility
 	ttl := instance.StubConfig.TaskPolicy.TTL
 	if ttl == 0 {
@@ -268,11 +300,14 @@ func (es *HttpEndpointService) getOrCreateEndpointInstance(ctx context.Context,
 		return nil, err
 	}
 
-	if stub.Type.Kind() == types.StubTypeASGI {
+	switch stub.Type.Kind() {
+	case types.StubTypeASGI:
 		instance.isASGI = true
+	case types.StubTypeGRPC:
+		instance.isGRPC = true
 	}
 
-	instance.buffer = NewRequestBuffer(autoscaledInstance.Ctx, es.rdb, &stub.Workspace, stubId, requestBufferSize, es.containerRepo, es.keyEventManager, stubConfig, es.tailscale, es.config.Tailscale, instance.isASGI)
+	instance.buffer = NewRequestBuffer(autoscaledInstance.Ctx, es.rdb, &stub.Workspace, stubId, requestBufferSize, es.containerRepo, es.scheduler, es.keyEventManager, stubConfig, es.tailscale, es.config.Tailscale, instance.isASGI, instance.isGRPC)
 
 	// Embed autoscaled instance struct
 	instance.AutoscaledInstance = autoscaledInstance
@@ -290,7 +325,10 @@ func (es *HttpEndpointService) getOrCreateEndpointInstance(ctx context.Context,
 		}
 	}
 
-	if len(instance.EntryPoint) == 0 {
+	// gRPC stubs run the user's own server, which listens on BIND_PORT
+	if instance.isGRPC {
+		instance.EntryPoint = instance.StubConfig.EntryPoint
+	} else if len(instance.EntryPoint) == 0 {
 		instance.EntryPoint = []string{instance.StubConfig.PythonVersion, "-m", "beta9.runner.endpoint"}
 	}
 
@@ -315,6 +353,8 @@ var (
 	endpointInstanceLock     string = "endpoint:%s:%s:instance_lock"
 	endpointRequestTokens    string = "endpoint:%s:%s:request_tokens:%s"
 	endpointRequestHeartbeat string = "endpoint:%s:%s:request_heartbeat:%s:%s"
+	endpointConnections      string = "endpoint:%s:%s:connections:%s"
+	endpointDraining         string = "endpoint:%s:%s:draining:%s"
 )
 
 func (k *keys) endpointKeepWarmLock(workspaceName, stubId, containerId string) string {
@@ -332,3 +372,11 @@ func (k *keys) endpointRequestTokens(workspaceName, stubId, containerId string)
 func (k *keys) endpointRequestHeartbeat(workspaceName, stubId, taskId, containerId string) string {
 	return fmt.Sprintf(endpointRequestHeartbeat, workspaceName, stubId, taskId, containerId)
 }
+
+func (k *keys) endpointConnections(workspaceName, stubId, containerId string) string {
+	return fmt.Sprintf(endpointConnections, workspaceName, stubId, containerId)
+}
+
+func (k *keys) endpointDraining(workspaceName, stubId, containerId string) string {
+	return fmt.Sprintf(endpointDraining, workspaceName, stubId, containerId)
+}
@@ -0,0 +1,198 @@
+package endpoint
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/net/http2"
+	"google.golang.org/grpc/codes"
+
+	abstractions "github.com/beam-cloud/beta9/pkg/abstractions/common"
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/network"
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+// gRPC calls are always POST /<service>/<method>, so the routes below carry both as path params. Clients can't
+// prefix that path, which is why calls normally reach these routes through the subdomain middleware, or through
+// /service when the gateway routes a call on its service name alone.
+func registerGRPCRoutes(g *echo.Group, es *HttpEndpointService) *endpointGroup {
+	group := &endpointGroup{routeGroup: g, es: es, cache: abstractions.NewDeploymentStubCache(), splits: newTrafficSplitCache()}
+
+	g.POST("/id/:stubId/:service/:method", auth.WithAuth(group.GRPCRequest))
+	g.POST("/:deploymentName/latest/:service/:method", auth.WithAuth(group.GRPCRequest))
+	g.POST("/:deploymentName/v:version/:service/:method", auth.WithAuth(group.GRPCRequest))
+	g.POST("/public/:stubId/:service/:method", auth.WithAssumedStubAuth(group.GRPCRequest, group.es.IsPublic))
+	g.POST("/service/:service/:method", auth.WithAuth(group.GRPCServiceRequest))
+
+	g.POST("/id/:stubId/warmup", auth.WithAuth(group.WarmupGRPC))
+	g.POST("/:deploymentName/latest/warmup", auth.WithAuth(group.WarmupGRPC))
+	g.POST("/:deploymentName/v:version/warmup", auth.WithAuth(group.WarmupGRPC))
+
+	return group
+}
+
+func (g *endpointGroup) GRPCRequest(ctx echo.Context) error {
+	cc, _ := ctx.(*auth.HttpAuthContext)
+
+	version, split, canary := g.trafficSplitVersion(ctx, cc.AuthInfo, types.StubTypeGRPCDeployment)
+
+	stubId, err := abstractions.ParseAndValidateDeploymentStubId(
+		ctx.Request().Context(),
+		g.cache,
+		cc.AuthInfo,
+		ctx.Param("stubId"),
+		ctx.Param("deploymentName"),
+		version,
+		types.StubTypeGRPCDeployment,
+		g.es.backendRepo,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = g.es.forwardRequest(ctx, cc.AuthInfo, stubId)
+	if canary {
+		g.recordCanaryOutcome(ctx, cc.AuthInfo, split, err)
+	}
+
+	return err
+}
+
+// GRPCServiceRequest serves a call with the latest gRPC deployment in the caller's workspace that exposes
+// the called service
+func (g *endpointGroup) GRPCServiceRequest(ctx echo.Context) error {
+	cc, _ := ctx.(*auth.HttpAuthContext)
+	service := ctx.Param("service")
+
+	cacheKey := fmt.Sprintf("%s|grpc_service|%s", cc.AuthInfo.Workspace.ExternalId, service)
+
+	stubId, ok := g.cache.Get(cacheKey)
+	if !ok {
+		deployment, err := g.es.backendRepo.GetLatestDeploymentByGRPCService(ctx.Request().Context(), cc.AuthInfo.Workspace.Id, service)
+		if err != nil {
+			return writeGRPCStatus(ctx, codes.Internal, "unable to resolve service")
+		}
+
+		if deployment == nil {
+			return writeGRPCStatus(ctx, codes.Unimplemented, fmt.Sprintf("unknown service %s", service))
+		}
+
+		stubId = deployment.Stub.ExternalId
+		g.cache.Add(cacheKey, stubId)
+	}
+
+	return g.es.forwardRequest(ctx, cc.AuthInfo, stubId)
+}
+
+func (g *endpointGroup) WarmupGRPC(ctx echo.Context) error {
+	return g.warmup(ctx, types.StubTypeGRPCDeployment)
+}
+
+// writeGRPCStatus ends a call with a trailers-only response, which is how gRPC servers report errors
+func writeGRPCStatus(ctx echo.Context, code codes.Code, message string) error {
+	header := ctx.Response().Header()
+	header.Set("Content-Type", "application/grpc")
+	header.Set("Grpc-Status", strconv.Itoa(int(code)))
+	header.Set("Grpc-Message", message)
+	ctx.Response().WriteHeader(http.StatusOK)
+	return nil
+}
+
+// newGRPCTransport returns a transport that speaks HTTP/2 without TLS to containers, since gRPC servers
+// don't serve HTTP/1
+func newGRPCTransport(tailscale *network.Tailscale, tsConfig types.TailscaleConfig) *http2.Transport {
+	return &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, netw, addr string, _ *tls.Config) (net.Conn, error) {
+			return network.GetDialer(addr, tailscale, tsConfig)(ctx, netw, addr)
+		},
+	}
+}
+
+func (rb *RequestBuffer) handleGRPCRequest(req *request, c container) {
+	request := req.ctx.Request()
+
+	containerUrl := fmt.Sprintf("http://%s/%s/%s", c.address, req.ctx.Param("service"), req.ctx.Param("method"))
+
+	httpReq, err := http.NewRequestWithContext(request.Context(), request.Method, containerUrl, request.Body)
+	if err != nil {
+		writeGRPCStatus(req.ctx, codes.Internal, "internal server error")
+		return
+	}
+	httpReq.ContentLength = request.ContentLength
+
+	// Metadata travels as headers, so copying them forwards it as is
+	for key, values := range request.Header {
+		for _, val := range values {
+			httpReq.Header.Add(key, val)
+		}
+	}
+
+	httpReq.Header.Add("X-TASK-ID", req.task.msg.TaskId) // Add task ID to header
+	go rb.heartBeat(req, c.id)                           // Send heartbeat via redis for duration of request
+
+	resp, err := rb.grpcTransport.RoundTrip(httpReq)
+	if err != nil {
+		if request.Context().Err() == context.Canceled {
+			rb.cancelInFlightTask(req.task, types.TaskRequestCancelled)
+		} else if requestTimedOut(req.ctx) {
+			rb.cancelInFlightTask(req.task, types.TaskRequestTimedOut)
+			writeGRPCStatus(req.ctx, codes.DeadlineExceeded, "request timed out")
+		} else {
+			writeGRPCStatus(req.ctx, codes.Unavailable, "unable to reach server")
+		}
+		return
+	}
+	defer resp.Body.Close()
+
+	for key, values := range resp.Header {
+		for _, value := range values {
+			req.ctx.Response().Header().Add(key, value)
+		}
+	}
+	req.ctx.Response().WriteHeader(resp.StatusCode)
+
+	// Flush every message as it arrives so streaming calls aren't held up
+	flusher, streamingSupported := req.ctx.Response().Writer.(http.Flusher)
+
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := resp.Body.Read(buf)
+		if n > 0 {
+			req.ctx.Response().Writer.Write(buf[:n])
+
+			if streamingSupported {
+				flusher.Flush()
+			}
+		}
+
+		if err != nil {
+			if err != io.EOF {
+				if requestTimedOut(req.ctx) {
+					rb.cancelInFlightTask(req.task, types.TaskRequestTimedOut)
+				}
+
+				// The server never finished the call, so it didn't send a status either
+				req.ctx.Response().Header().Set(http.TrailerPrefix+"Grpc-Status", strconv.Itoa(int(codes.Unavailable)))
+				req.ctx.Response().Header().Set(http.TrailerPrefix+"Grpc-Message", "stream terminated by server")
+				return
+			}
+
+			break
+		}
+	}
+
+	// The call's status is sent in trailers, which are only known once the body has been read
+	for key, values := range resp.Trailer {
+		for _, value := range values {
+			req.ctx.Response().Header().Add(http.TrailerPrefix+key, value)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package endpoint
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/beam-cloud/beta9/pkg/network"
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+type containerHealthState struct {
+	health      types.ContainerHealth
+	nextCheckAt time.Time
+}
+
+// containerIsHealthy reports whether a container passes the stub's health check and can take traffic. Checks
+// run at the configured interval; in between, the last result is reused.
+func (rb *RequestBuffer) containerIsHealthy(cs types.ContainerState, address string) bool {
+	now := time.Now()
+
+	rb.healthStatesLock.Lock()
+	state, ok := rb.healthStates[cs.ContainerId]
+	if !ok {
+		state = &containerHealthState{health: types.ContainerHealth{ContainerId: cs.ContainerId, Status: types.ContainerHealthStatusStarting}}
+		rb.healthStates[cs.ContainerId] = state
+	}
+
+	if now.Before(state.nextCheckAt) {
+		healthy := state.health.Status == types.ContainerHealthStatusHealthy
+		rb.healthStatesLock.Unlock()
+		return healthy
+	}
+	state.nextCheckAt = now.Add(rb.healthCheck.Interval())
+	rb.healthStatesLock.Unlock()
+
+	checkErr := rb.runHealthCheck(address)
+
+	startedAt := time.Unix(cs.StartedAt, 0)
+	if cs.StartedAt == 0 {
+		startedAt = now
+	}
+
+	rb.healthStatesLock.Lock()
+	restart := state.health.Record(rb.healthCheck, checkErr, startedAt, now)
+	health := state.health
+	rb.healthStatesLock.Unlock()
+
+	if err := rb.containerRepo.SetContainerHealth(cs.ContainerId, &health); err != nil {
+		log.Warn().Err(err).Str("container_id", cs.ContainerId).Msg("failed to save container health")
+	}
+
+	if restart {
+		log.Info().Str("container_id", cs.ContainerId).Str("stub_id", rb.stubId).Uint("failures", health.ConsecutiveFailures).Str("error", health.LastError).Msg("restarting container after failed health checks")
+
+		if err := rb.scheduler.Stop(&types.StopContainerArgs{ContainerId: cs.ContainerId, Reason: types.StopContainerReasonUnhealthy}); err != nil {
+			log.Error().Err(err).Str("container_id", cs.ContainerId).Msg("unable to stop unhealthy container")
+		}
+	}
+
+	return health.Status == types.ContainerHealthStatusHealthy
+}
+
+func (rb *RequestBuffer) runHealthCheck(address string) error {
+	ctx, cancel := context.WithTimeout(rb.ctx, rb.healthCheck.Timeout())
+	defer cancel()
+
+	if rb.healthCheck.Type == types.HealthCheckTypeTCP {
+		conn, err := network.GetDialer(address, rb.tailscale, rb.tsConfig)(ctx, "tcp", address)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+
+	httpClient, err := rb.getHttpClient(address, rb.healthCheck.Timeout())
+	if err != nil {
+		return err
+	}
+	defer httpClient.CloseIdleConnections()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://%s%s", address, rb.healthCheck.Path), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("health check returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// pruneHealthStates forgets containers that are no longer active
+func (rb *RequestBuffer) pruneHealthStates(containerStates []types.ContainerState) {
+	active := make(map[string]bool, len(containerStates))
+	for _, cs := range containerStates {
+		active[cs.ContainerId] = true
+	}
+
+	rb.healthStatesLock.Lock()
+	defer rb.healthStatesLock.Unlock()
+
+	for containerId := range rb.healthStates {
+		if !active[containerId] {
+			delete(rb.healthStates, containerId)
+		}
+	}
+}
@@ -12,10 +12,11 @@ type endpointGroup struct {
 	routeGroup *echo.Group
 	es         *HttpEndpointService
 	cache      *expirable.LRU[string, string]
+	splits     *expirable.LRU[string, *types.DeploymentTrafficSplit]
 }
 
 func registerEndpointRoutes(g *echo.Group, es *HttpEndpointService) *endpointGroup {
-	group := &endpointGroup{routeGroup: g, es: es, cache: abstractions.NewDeploymentStubCache()}
+	group := &endpointGroup{routeGroup: g, es: es, cache: abstractions.NewDeploymentStubCache(), splits: newTrafficSplitCache()}
 
 	g.POST("/id/:stubId", auth.WithAuth(group.EndpointRequest))
 	g.POST("/:deploymentName", auth.WithAuth(group.EndpointRequest))
@@ -38,7 +39,7 @@ func registerEndpointRoutes(g *echo.Group, es *HttpEndpointService) *endpointGro
 }
 
 func registerASGIRoutes(g *echo.Group, es *HttpEndpointService) *endpointGroup {
-	group := &endpointGroup{routeGroup: g, es: es, cache: abstractions.NewDeploymentStubCache()}
+	group := &endpointGroup{routeGroup: g, es: es, cache: abstractions.NewDeploymentStubCache(), splits: newTrafficSplitCache()}
 
 	g.Any("/id/:stubId", auth.WithAuth(group.ASGIRequest))
 	g.Any("/id/:stubId/:subPath", auth.WithAuth(group.ASGIRequest))
@@ -62,13 +63,15 @@ func registerASGIRoutes(g *echo.Group, es *HttpEndpointService) *endpointGroup {
 func (g *endpointGroup) EndpointRequest(ctx echo.Context) error {
 	cc, _ := ctx.(*auth.HttpAuthContext)
 
+	version, split, canary := g.trafficSplitVersion(ctx, cc.AuthInfo, types.StubTypeEndpointDeployment)
+
 	stubId, err := abstractions.ParseAndValidateDeploymentStubId(
 		ctx.Request().Context(),
 		g.cache,
 		cc.AuthInfo,
 		ctx.Param("stubId"),
 		ctx.Param("deploymentName"),
-		ctx.Param("version"),
+		version,
 		types.StubTypeEndpointDeployment,
 		g.es.backendRepo,
 	)
@@ -76,19 +79,26 @@ func (g *endpointGroup) EndpointRequest(ctx echo.Context) error {
 		return err
 	}
 
-	return g.es.forwardRequest(ctx, cc.AuthInfo, stubId)
+	err = g.es.forwardRequest(ctx, cc.AuthInfo, stubId)
+	if canary {
+		g.recordCanaryOutcome(ctx, cc.AuthInfo, split, err)
+	}
+
+	return err
 }
 
 func (g *endpointGroup) ASGIRequest(ctx echo.Context) error {
 	cc, _ := ctx.(*auth.HttpAuthContext)
 
+	version, split, canary := g.trafficSplitVersion(ctx, cc.AuthInfo, types.StubTypeASGIDeployment)
+
 	stubId, err := abstractions.ParseAndValidateDeploymentStubId(
 		ctx.Request().Context(),
 		g.cache,
 		cc.AuthInfo,
 		ctx.Param("stubId"),
 		ctx.Param("deploymentName"),
-		ctx.Param("version"),
+		version,
 		types.StubTypeASGIDeployment,
 		g.es.backendRepo,
 	)
@@ -96,7 +106,12 @@ func (g *endpointGroup) ASGIRequest(ctx echo.Context) error {
 		return err
 	}
 
-	return g.es.forwardRequest(ctx, cc.AuthInfo, stubId)
+	err = g.es.forwardRequest(ctx, cc.AuthInfo, stubId)
+	if canary {
+		g.recordCanaryOutcome(ctx, cc.AuthInfo, split, err)
+	}
+
+	return err
 }
 
 func (g *endpointGroup) WarmUpEndpoint(ctx echo.Context) error {
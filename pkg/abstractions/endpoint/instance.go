@@ -30,10 +30,11 @@ type endpointInstance struct {
 	*abstractions.AutoscaledInstance
 	buffer *RequestBuffer
 	isASGI bool
+	isGRPC bool
 }
 
 func (i *endpointInstance) startContainers(containersToRun int) error {
-	secrets, err := abstractions.ConfigureContainerRequestSecrets(i.Workspace, *i.buffer.stubConfig)
+	secrets, secretMount, err := abstractions.ConfigureContainerRequestSecrets(i.Ctx, i.BackendRepo, i.Workspace, *i.buffer.stubConfig)
 	if err != nil {
 		return err
 	}
@@ -93,6 +94,11 @@ func (i *endpointInstance) startContainers(containersToRun int) error {
 			Memory:            i.StubConfig.Runtime.Memory,
 			GpuRequest:        gpuRequest,
 			GpuCount:          uint32(gpuCount),
+			GpuFraction:       i.StubConfig.Runtime.GpuFraction,
+			MigProfile:        i.StubConfig.Runtime.MigProfile,
+			Architectures:     i.StubConfig.Runtime.Architectures,
+			Affinity:          i.StubConfig.Affinity,
+			PriorityClass:     i.StubConfig.PriorityClass,
 			AppId:             i.Stub.App.ExternalId,
 			ImageId:           i.StubConfig.Runtime.ImageId,
 			StubId:            i.Stub.ExternalId,
@@ -103,6 +109,8 @@ func (i *endpointInstance) startContainers(containersToRun int) error {
 			Stub:              *i.Stub,
 			CheckpointEnabled: checkpointEnabled,
 			Preemptable:       true,
+			Sidecars:          i.StubConfig.Sidecars,
+			SecretMount:       secretMount,
 		}
 
 		// Set initial keepwarm to prevent rapid spin-up/spin-down of containers
@@ -134,24 +142,67 @@ func (i *endpointInstance) stopContainers(containersToStop int) error {
 		return err
 	}
 
-	for c := 0; c < containersToStop && len(containerIds) > 0; c++ {
-		idx := rnd.Intn(len(containerIds))
-		containerId := containerIds[idx]
+	// Containers that are already draining go first, so each pass doesn't start draining new ones
+	draining := []string{}
+	others := []string{}
+	for _, containerId := range containerIds {
+		if isDraining(i.Ctx, i.Rdb, i.Workspace.Name, i.Stub.ExternalId, containerId) {
+			draining = append(draining, containerId)
+		} else {
+			others = append(others, containerId)
+		}
+	}
+	rnd.Shuffle(len(others), func(a, b int) { others[a], others[b] = others[b], others[a] })
+
+	candidates := append(draining, others...)
+	for _, containerId := range candidates[:min(containersToStop, len(candidates))] {
+		if !i.drained(containerId) {
+			continue
+		}
 
 		err := i.Scheduler.Stop(&types.StopContainerArgs{ContainerId: containerId, Reason: types.StopContainerReasonScheduler})
 		if err != nil {
 			log.Error().Str("instance_name", i.Name).Err(err).Msg("unable to stop container")
 			return err
 		}
-
-		// Remove the containerId from the containerIds slice to avoid
-		// sending multiple stop requests to the same container
-		containerIds = append(containerIds[:idx], containerIds[idx+1:]...)
 	}
 
 	return nil
 }
 
+// drained reports whether a container can be stopped without cutting off open connections. A container that
+// still has some stops taking new requests and is given the stub's drain timeout for them to close.
+func (i *endpointInstance) drained(containerId string) bool {
+	policy := i.StubConfig.Connections
+	if policy == nil || policy.DrainTimeoutSeconds == 0 || !i.IsActive {
+		return true
+	}
+
+	connections, err := openConnections(i.Ctx, i.Rdb, i.Workspace.Name, i.Stub.ExternalId, containerId)
+	if err != nil || connections == 0 {
+		return true
+	}
+
+	// The marker outlives the drain timeout so its start time can be checked after the timeout passes
+	key := Keys.endpointDraining(i.Workspace.Name, i.Stub.ExternalId, containerId)
+	started, err := i.Rdb.SetNX(i.Ctx, key, time.Now().Unix(), 2*policy.DrainTimeout()).Result()
+	if err != nil {
+		return true
+	}
+
+	if started {
+		log.Info().Str("instance_name", i.Name).Str("container_id", containerId).Int64("connections", connections).Msg("draining container")
+		return false
+	}
+
+	startedAt, err := i.Rdb.Get(i.Ctx, key).Int64()
+	if err != nil {
+		return true
+	}
+
+	return time.Since(time.Unix(startedAt, 0)) >= policy.DrainTimeout()
+}
+
 func (i *endpointInstance) stoppableContainers() ([]string, error) {
 	containers, err := i.ContainerRepo.GetActiveContainersByStubId(i.Stub.ExternalId)
 	if err != nil {
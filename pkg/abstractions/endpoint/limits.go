@@ -0,0 +1,70 @@
+package endpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+// applyRequestLimits rejects bodies that are declared to be over the stub's limit, caps the ones that
+// aren't, and bounds how long the request can take, queueing included. The returned func releases the
+// request's deadline and must be called once the request is done.
+func applyRequestLimits(ctx echo.Context, limits *types.RequestLimits) (context.CancelFunc, error) {
+	if limits == nil {
+		return func() {}, nil
+	}
+
+	request := ctx.Request()
+
+	if limits.MaxRequestBodyBytes > 0 {
+		maxBytes := int64(limits.MaxRequestBodyBytes)
+		if request.ContentLength > maxBytes {
+			return func() {}, writeRequestTooLarge(ctx, maxBytes)
+		}
+
+		request.Body = http.MaxBytesReader(ctx.Response(), request.Body, maxBytes)
+	}
+
+	// Websockets are long-lived by design, so only plain requests get a deadline
+	if limits.MaxRequestDurationSeconds == 0 || ctx.IsWebSocket() {
+		return func() {}, nil
+	}
+
+	reqCtx, cancel := context.WithTimeout(request.Context(), limits.MaxRequestDuration())
+	ctx.SetRequest(request.WithContext(reqCtx))
+	return cancel, nil
+}
+
+func requestTimedOut(ctx echo.Context) bool {
+	return errors.Is(ctx.Request().Context().Err(), context.DeadlineExceeded)
+}
+
+func requestTooLarge(err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	return errors.As(err, &maxBytesErr)
+}
+
+func writeRequestTimedOut(ctx echo.Context) error {
+	if ctx.Response().Committed {
+		return nil
+	}
+
+	return ctx.JSON(http.StatusGatewayTimeout, map[string]interface{}{
+		"error": "Request exceeded the maximum duration",
+	})
+}
+
+func writeRequestTooLarge(ctx echo.Context, maxBytes int64) error {
+	if ctx.Response().Committed {
+		return nil
+	}
+
+	return ctx.JSON(http.StatusRequestEntityTooLarge, map[string]interface{}{
+		"error": fmt.Sprintf("Request body exceeds the limit of %d bytes", maxBytes),
+	})
+}
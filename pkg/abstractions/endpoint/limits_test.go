@@ -0,0 +1,78 @@
+package endpoint
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+func newLimitsTestContext(body string, contentLength int64) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.ContentLength = contentLength
+	rec := httptest.NewRecorder()
+	return echo.New().NewContext(req, rec), rec
+}
+
+func TestApplyRequestLimits(t *testing.T) {
+	t.Run("no limits", func(t *testing.T) {
+		ctx, _ := newLimitsTestContext("hello", 5)
+
+		release, err := applyRequestLimits(ctx, nil)
+		defer release()
+		assert.NoError(t, err)
+		assert.False(t, ctx.Response().Committed)
+
+		_, hasDeadline := ctx.Request().Context().Deadline()
+		assert.False(t, hasDeadline)
+	})
+
+	t.Run("declared body over limit", func(t *testing.T) {
+		ctx, rec := newLimitsTestContext("hello world", 11)
+
+		release, err := applyRequestLimits(ctx, &types.RequestLimits{MaxRequestBodyBytes: 5})
+		defer release()
+		assert.NoError(t, err)
+		assert.True(t, ctx.Response().Committed)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	})
+
+	t.Run("streamed body over limit", func(t *testing.T) {
+		ctx, _ := newLimitsTestContext("hello world", -1)
+
+		release, err := applyRequestLimits(ctx, &types.RequestLimits{MaxRequestBodyBytes: 5})
+		defer release()
+		assert.NoError(t, err)
+		assert.False(t, ctx.Response().Committed)
+
+		_, err = io.ReadAll(ctx.Request().Body)
+		assert.True(t, requestTooLarge(err))
+	})
+
+	t.Run("duration", func(t *testing.T) {
+		ctx, rec := newLimitsTestContext("", 0)
+
+		release, err := applyRequestLimits(ctx, &types.RequestLimits{MaxRequestDurationSeconds: 1})
+		defer release()
+		assert.NoError(t, err)
+
+		deadline, hasDeadline := ctx.Request().Context().Deadline()
+		assert.True(t, hasDeadline)
+		assert.WithinDuration(t, time.Now().Add(time.Second), deadline, 100*time.Millisecond)
+
+		<-ctx.Request().Context().Done()
+		assert.True(t, requestTimedOut(ctx))
+		assert.ErrorIs(t, ctx.Request().Context().Err(), context.DeadlineExceeded)
+
+		assert.NoError(t, writeRequestTimedOut(ctx))
+		assert.Equal(t, http.StatusGatewayTimeout, rec.Code)
+	})
+}
@@ -0,0 +1,115 @@
+package endpoint
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	abstractions "github.com/beam-cloud/beta9/pkg/abstractions/common"
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/types"
+	expirable "github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	trafficSplitCacheSize = 2000
+	// Kept short so a split that was promoted or rolled back through another gateway stops applying quickly
+	trafficSplitCacheTTL = time.Second * 10
+)
+
+func newTrafficSplitCache() *expirable.LRU[string, *types.DeploymentTrafficSplit] {
+	return expirable.NewLRU[string, *types.DeploymentTrafficSplit](trafficSplitCacheSize, nil, trafficSplitCacheTTL)
+}
+
+// trafficSplitVersion picks the version for a request to a deployment's latest version while it has an
+// active traffic split. Requests for a stub id or an explicit version are never split.
+func (g *endpointGroup) trafficSplitVersion(ctx echo.Context, authInfo *auth.AuthInfo, stubType string) (string, *types.DeploymentTrafficSplit, bool) {
+	version := ctx.Param("version")
+	deploymentName := ctx.Param("deploymentName")
+	if ctx.Param("stubId") != "" || deploymentName == "" || version != "" {
+		return version, nil, false
+	}
+
+	cacheKey := fmt.Sprintf("%s|%s|%s", authInfo.Workspace.ExternalId, deploymentName, stubType)
+
+	split, ok := g.splits.Get(cacheKey)
+	if !ok {
+		var err error
+		split, err = g.es.backendRepo.GetTrafficSplit(ctx.Request().Context(), authInfo.Workspace.Id, deploymentName, stubType)
+		if err != nil {
+			log.Warn().Err(err).Str("deployment", deploymentName).Msg("unable to get traffic split")
+			return version, nil, false
+		}
+
+		g.splits.Add(cacheKey, split)
+	}
+
+	if split == nil || split.Status != types.TrafficSplitStatusActive {
+		return version, nil, false
+	}
+
+	picked, canary := split.PickVersion(uint(rand.Intn(100)))
+	return strconv.FormatUint(uint64(picked), 10), split, canary
+}
+
+// recordCanaryOutcome counts a request served by the canary, and rolls the deployment back to the stable
+// version once the canary's error rate goes over the split's threshold
+func (g *endpointGroup) recordCanaryOutcome(ctx echo.Context, authInfo *auth.AuthInfo, split *types.DeploymentTrafficSplit, err error) {
+	failed := err != nil || ctx.Response().Status >= http.StatusInternalServerError
+
+	key := common.RedisKeys.GatewayTrafficSplitOutcomes(split.Id)
+	requests, _ := g.es.rdb.HIncrBy(g.es.ctx, key, "requests", 1).Result()
+
+	failures, _ := g.es.rdb.HGet(g.es.ctx, key, "failures").Int64()
+	if failed {
+		failures, _ = g.es.rdb.HIncrBy(g.es.ctx, key, "failures", 1).Result()
+	}
+
+	if !split.ErrorRateExceeded(requests, failures) {
+		return
+	}
+
+	go g.rollbackCanary(g.es.ctx, authInfo, split, requests, failures)
+}
+
+func (g *endpointGroup) rollbackCanary(ctx context.Context, authInfo *auth.AuthInfo, split *types.DeploymentTrafficSplit, requests, failures int64) {
+	// Only the first gateway to end the split rolls back
+	ended, err := g.es.backendRepo.EndTrafficSplit(ctx, split.Id, types.TrafficSplitStatusRolledBack)
+	if err != nil || !ended {
+		return
+	}
+
+	g.splits.Purge()
+
+	logger := log.With().
+		Str("workspace_id", authInfo.Workspace.ExternalId).
+		Str("deployment", split.Name).
+		Uint("canary_version", split.CanaryVersion).
+		Uint("stable_version", split.StableVersion).
+		Int64("requests", requests).
+		Int64("failures", failures).
+		Logger()
+
+	deployment, err := g.es.backendRepo.RollbackDeployment(ctx, authInfo.Workspace.Id, split.Name, split.StubType, split.StableVersion)
+	if err != nil {
+		logger.Error().Err(err).Msg("canary exceeded its error rate but rollback failed")
+		return
+	}
+
+	g.cache.Purge()
+	logger.Warn().Uint("version", deployment.Version).Msg("canary exceeded its error rate, rolled back to stable version")
+
+	if restored, err := g.es.backendRepo.GetDeploymentByExternalId(ctx, authInfo.Workspace.Id, deployment.ExternalId); err == nil && restored != nil {
+		abstractions.NotifyDeploymentEvent(ctx, g.es.config, g.es.backendRepo, authInfo.Workspace.ExternalId, deployment, restored.Stub.ExternalId, abstractions.DeploymentWebhookPayload{
+			Event:         types.DeploymentWebhookEventPromoted,
+			SourceVersion: &split.StableVersion,
+			Reason:        "canary rolled back",
+		})
+	}
+}
@@ -100,7 +100,7 @@ func NewPetriBotService(ctx context.Context, opts BotServiceOpts) (BotService, e
 	pbs.taskDispatcher.Register(string(types.ExecutorBot), pbs.botTaskFactory)
 
 	// Register HTTP routes
-	authMiddleware := auth.AuthMiddleware(pbs.backendRepo, pbs.workspaceRepo)
+	authMiddleware := auth.AuthMiddleware(pbs.backendRepo, pbs.workspaceRepo, opts.Config.GatewayService.IPAllowList)
 	registerBotRoutes(pbs.routeGroup.Group(botRoutePrefix, authMiddleware), pbs)
 
 	return pbs, nil
@@ -31,13 +31,14 @@ type FunctionService interface {
 const (
 	DefaultFunctionTaskTTL uint32 = 3600 * 12 // 12 hours
 
-	functionRoutePrefix              string        = "/function"
-	scheduleRoutePrefix              string        = "/schedule"
-	defaultFunctionContainerCpu      int64         = 100
-	defaultFunctionContainerMemory   int64         = 128
-	defaultFunctionHeartbeatTimeoutS int64         = 60
-	functionArgsExpirationTimeout    time.Duration = 600 * time.Second
-	functionResultExpirationTimeout  time.Duration = 600 * time.Second
+	functionRoutePrefix               string        = "/function"
+	scheduleRoutePrefix               string        = "/schedule"
+	defaultFunctionContainerCpu       int64         = 100
+	defaultFunctionContainerMemory    int64         = 128
+	defaultFunctionHeartbeatTimeoutS  int64         = 60
+	functionArgsExpirationTimeout     time.Duration = 600 * time.Second
+	functionResultExpirationTimeout   time.Duration = 600 * time.Second
+	functionContainerExitPollInterval time.Duration = 5 * time.Second
 )
 
 type ContainerFunctionService struct {
@@ -56,6 +57,7 @@ type ContainerFunctionService struct {
 	keyEventManager  *common.KeyEventManager
 	rdb              *common.RedisClient
 	routeGroup       *echo.Group
+	limiter          *abstractions.ConcurrencyLimiter
 }
 
 type FunctionServiceOpts struct {
@@ -96,16 +98,20 @@ func NewContainerFunctionService(ctx context.Context,
 		routeGroup:       opts.RouteGroup,
 		eventRepo:        opts.EventRepo,
 		usageMetricsRepo: opts.UsageMetricsRepo,
+		limiter:          abstractions.NewConcurrencyLimiter(opts.RedisClient),
 	}
 
 	// Register task dispatcher
 	fs.taskDispatcher.Register(string(types.ExecutorFunction), fs.functionTaskFactory)
 
 	// Register HTTP routes
-	authMiddleware := auth.AuthMiddleware(fs.backendRepo, fs.workspaceRepo)
-	registerFunctionRoutes(fs.routeGroup.Group(functionRoutePrefix, authMiddleware), fs)
+	authMiddleware := auth.AuthMiddleware(fs.backendRepo, fs.workspaceRepo, opts.Config.GatewayService.IPAllowList)
+	functionGroup := registerFunctionRoutes(fs.routeGroup.Group(functionRoutePrefix, authMiddleware), fs)
 	registerFunctionRoutes(fs.routeGroup.Group(scheduleRoutePrefix, authMiddleware), fs)
 
+	// Only functions can be triggered, scheduled jobs run on their schedule
+	functionGroup.routerGroup.POST("/trigger/:triggerId", functionGroup.FunctionTrigger)
+
 	go fs.listenForScheduledJobs()
 
 	return fs, nil
@@ -228,6 +234,26 @@ func (fs *ContainerFunctionService) stream(ctx context.Context, stream pb.Functi
 	return containerStream.Stream(ctx, authInfo, containerId)
 }
 
+// releaseOnContainerExit gives an invocation's concurrency slot back once its container is gone
+func (fs *ContainerFunctionService) releaseOnContainerExit(containerId string, release func()) {
+	defer release()
+
+	ticker := time.NewTicker(functionContainerExitPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-fs.ctx.Done():
+			return
+		case <-ticker.C:
+			_, err := fs.containerRepo.GetContainerState(containerId)
+			if _, ok := err.(*types.ErrContainerStateNotFound); ok {
+				return
+			}
+		}
+	}
+}
+
 func (fs *ContainerFunctionService) FunctionGetArgs(ctx context.Context, in *pb.FunctionGetArgsRequest) (*pb.FunctionGetArgsResponse, error) {
 	authInfo, _ := auth.AuthInfoFromContext(ctx)
 
@@ -1,6 +1,7 @@
 package function
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
@@ -60,6 +61,52 @@ func (g *functionGroup) FunctionInvoke(ctx echo.Context) error {
 
 	task, err := g.fs.invoke(ctx.Request().Context(), cc.AuthInfo, stubId, payload)
 	if err != nil {
+		if errors.Is(err, abstractions.ErrConcurrencyQueueFull) || errors.Is(err, abstractions.ErrConcurrencyShed) {
+			return ctx.JSON(http.StatusTooManyRequests, map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+
+		var policyErr *types.ErrWorkspacePolicyViolation
+		if errors.As(err, &policyErr) {
+			return ctx.JSON(http.StatusForbidden, map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]interface{}{
+		"task_id": task.Metadata().TaskId,
+	})
+}
+
+// FunctionTrigger runs the function a task trigger points at, for webhooks from external services. Deliveries
+// are authenticated by their signature rather than a token.
+func (g *functionGroup) FunctionTrigger(ctx echo.Context) error {
+	request, err := abstractions.ParseTaskTriggerRequest(ctx, g.fs.config.GatewayService.TaskTriggers, g.cache, types.StubTypeFunctionDeployment, g.fs.backendRepo)
+	if err != nil {
+		return err
+	}
+
+	task, err := g.fs.invoke(ctx.Request().Context(), request.AuthInfo, request.StubId, request.Payload)
+	if err != nil {
+		if errors.Is(err, abstractions.ErrConcurrencyQueueFull) || errors.Is(err, abstractions.ErrConcurrencyShed) {
+			return ctx.JSON(http.StatusTooManyRequests, map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+
+		var policyErr *types.ErrWorkspacePolicyViolation
+		if errors.As(err, &policyErr) {
+			return ctx.JSON(http.StatusForbidden, map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+
 		return ctx.JSON(http.StatusInternalServerError, map[string]interface{}{
 			"error": err.Error(),
 		})
@@ -89,6 +89,24 @@ func (t *FunctionTask) run(ctx context.Context, stub *types.StubWithRelated, tas
 		return err
 	}
 
+	// Wait behind the deployment's other invocations if it caps concurrency. This happens before the args
+	// are stored so they can't expire while queued.
+	release, err := t.fs.limiter.Acquire(ctx, stub.ExternalId, t.msg.TaskId, stubConfig.Concurrency)
+	if err != nil {
+		task.Status = types.TaskStatusCancelled
+		task.EndedAt = types.NullTime{}.Now()
+		t.fs.backendRepo.UpdateTask(context.Background(), task.ExternalId, *task)
+
+		return err
+	}
+
+	scheduled := false
+	defer func() {
+		if !scheduled {
+			release()
+		}
+	}()
+
 	args, err := json.Marshal(types.TaskPayload{
 		Args:   t.msg.Args,
 		Kwargs: t.msg.Kwargs,
@@ -131,7 +149,9 @@ func (t *FunctionTask) run(ctx context.Context, stub *types.StubWithRelated, tas
 		return err
 	}
 
-	secrets, err := abstractions.ConfigureContainerRequestSecrets(
+	secrets, secretMount, err := abstractions.ConfigureContainerRequestSecrets(
+		ctx,
+		t.fs.backendRepo,
 		&stub.Workspace,
 		stubConfig,
 	)
@@ -168,33 +188,49 @@ func (t *FunctionTask) run(ctx context.Context, stub *types.StubWithRelated, tas
 	}
 
 	err = t.fs.scheduler.Run(&types.ContainerRequest{
-		ContainerId: t.containerId,
-		Env:         env,
-		Cpu:         stubConfig.Runtime.Cpu,
-		Memory:      stubConfig.Runtime.Memory,
-		GpuRequest:  gpuRequest,
-		GpuCount:    uint32(gpuCount),
-		ImageId:     stubConfig.Runtime.ImageId,
-		StubId:      stub.ExternalId,
-		AppId:       stub.App.ExternalId,
-		WorkspaceId: stub.Workspace.ExternalId,
-		Workspace:   stub.Workspace,
-		EntryPoint:  []string{stubConfig.PythonVersion, "-m", "beta9.runner.function"},
-		Mounts:      mounts,
-		Stub:        *stub,
+		ContainerId:   t.containerId,
+		Env:           env,
+		Cpu:           stubConfig.Runtime.Cpu,
+		Memory:        stubConfig.Runtime.Memory,
+		GpuRequest:    gpuRequest,
+		GpuCount:      uint32(gpuCount),
+		GpuFraction:   stubConfig.Runtime.GpuFraction,
+		MigProfile:    stubConfig.Runtime.MigProfile,
+		Architectures: stubConfig.Runtime.Architectures,
+		Affinity:      stubConfig.Affinity,
+		PriorityClass: stubConfig.PriorityClass,
+		ImageId:       stubConfig.Runtime.ImageId,
+		StubId:        stub.ExternalId,
+		AppId:         stub.App.ExternalId,
+		WorkspaceId:   stub.Workspace.ExternalId,
+		Workspace:     stub.Workspace,
+		EntryPoint:    []string{stubConfig.PythonVersion, "-m", "beta9.runner.function"},
+		Mounts:        mounts,
+		Stub:          *stub,
+		SecretMount:   secretMount,
 	})
 	if err != nil {
-		if _, ok := err.(*types.ThrottledByConcurrencyLimitError); ok {
+		task.Status = types.TaskStatusCancelled
+
+		switch err.(type) {
+		case *types.ThrottledByConcurrencyLimitError:
 			log.Info().Str("task_id", task.ExternalId).Str("reason", err.Error()).Msg("task cancelled due to concurrency limit")
+		case *types.PoolQuotaExceededError:
+			log.Info().Str("task_id", task.ExternalId).Str("reason", err.Error()).Msg("task rejected due to pool quota")
+			task.Status = types.TaskStatusQuotaExceeded
 		}
 
-		task.Status = types.TaskStatusCancelled
 		task.EndedAt = types.NullTime{}.Now()
 		t.fs.backendRepo.UpdateTask(ctx, task.ExternalId, *task)
 
 		return err
 	}
 
+	if stubConfig.Concurrency != nil {
+		scheduled = true
+		go t.fs.releaseOnContainerExit(t.containerId, release)
+	}
+
 	return nil
 }
 
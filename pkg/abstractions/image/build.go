@@ -47,6 +47,7 @@ type Build struct {
 	ctx             context.Context
 	config          types.AppConfig
 	opts            *BuildOpts
+	arch            string
 	success         *atomic.Bool
 	containerID     string
 	imageID         string
@@ -317,6 +318,11 @@ func (b *Build) generateContainerRequest() (*types.ContainerRequest, error) {
 		Mounts:      b.mounts,
 	}
 
+	// The build runs on a worker of the architecture it's building for, which archives the image for it
+	if b.arch != "" {
+		req.Architectures = []string{b.arch}
+	}
+
 	if b.opts.BuildCtxObject != "" {
 		req.Stub.Object.ExternalId = b.opts.BuildCtxObject
 		req.Mounts = append(req.Mounts, types.Mount{
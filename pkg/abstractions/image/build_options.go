@@ -29,6 +29,7 @@ type BuildOpts struct {
 	Gpu                string
 	IgnorePython       bool
 	ClipVersion        uint32
+	Architectures      []string
 }
 
 func (o *BuildOpts) String() string {
@@ -50,6 +51,7 @@ func (o *BuildOpts) String() string {
 	fmt.Fprintf(&b, "  \"ForceRebuild\": %v", o.ForceRebuild)
 	fmt.Fprintf(&b, "  \"Gpu\": %q,", o.Gpu)
 	fmt.Fprintf(&b, "  \"IgnorePython\": %v,", o.IgnorePython)
+	fmt.Fprintf(&b, "  \"Architectures\": %#v,", o.Architectures)
 	fmt.Fprintf(&b, "}")
 	return b.String()
 }
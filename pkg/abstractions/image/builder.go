@@ -190,12 +190,26 @@ func (b *Builder) waitForBuildContainer(ctx context.Context, build *Build) error
 	return nil
 }
 
-// Build user image
+// Build user image, once for each of the architectures it's built for
 func (b *Builder) Build(ctx context.Context, opts *BuildOpts, outputChan chan common.OutputMsg) error {
+	architectures := types.ImageArchitectures(opts.Architectures)
+	for i, arch := range architectures {
+		if err := b.buildArch(ctx, opts, arch, i == len(architectures)-1, outputChan); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// buildArch builds the image on a worker of the architecture. Only the last architecture's build reports the
+// build as done.
+func (b *Builder) buildArch(ctx context.Context, opts *BuildOpts, arch string, last bool, outputChan chan common.OutputMsg) error {
 	build, err := NewBuild(ctx, opts, outputChan, b.config)
 	if err != nil {
 		return err
 	}
+	build.arch = arch
 
 	// Image ID is already calculated in verifyImage with the final Dockerfile
 	// Just use the imageID from opts (passed from verifyImage via BuildImage)
@@ -242,6 +256,11 @@ func (b *Builder) Build(ctx context.Context, opts *BuildOpts, outputChan chan co
 	// Delete the build container TTL key so it won't expire later and trigger stop storms
 	b.containerRepo.DeleteBuildContainerTTL(build.containerID)
 
+	if !last {
+		build.log(false, fmt.Sprintf("Built image for %s\n", arch))
+		return nil
+	}
+
 	// Send final completion message with image ID
 	build.setSuccess(true)
 	build.logWithImageAndPythonVersion(true, "Build completed successfully")
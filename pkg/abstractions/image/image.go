@@ -83,7 +83,11 @@ func NewContainerImageService(
 
 func (is *ContainerImageService) VerifyImageBuild(ctx context.Context, in *pb.VerifyImageBuildRequest) (*pb.VerifyImageBuildResponse, error) {
 	if in.ImageId != nil && *in.ImageId != "" {
-		exists, err := is.builder.Exists(ctx, *in.ImageId)
+		if err := types.ValidateArchitectures(in.Architectures); err != nil {
+			return nil, err
+		}
+
+		exists, err := is.imageExists(ctx, *in.ImageId, in.Architectures)
 		if err != nil {
 			return nil, err
 		}
@@ -122,6 +126,7 @@ func (is *ContainerImageService) BuildImage(in *pb.BuildImageRequest, stream pb.
 		Secrets:          in.Secrets,
 		Gpu:              in.Gpu,
 		IgnorePython:     in.IgnorePython,
+		Architectures:    in.Architectures,
 	}
 
 	imageId, exists, _, buildOptions, err := is.verifyImage(stream.Context(), verifyReq)
@@ -193,8 +198,12 @@ func (is *ContainerImageService) BuildImage(in *pb.BuildImageRequest, stream pb.
 func (is *ContainerImageService) verifyImage(ctx context.Context, in *pb.VerifyImageBuildRequest) (string, bool, bool, *BuildOpts, error) {
 	var valid bool = true
 
+	if err := types.ValidateArchitectures(in.Architectures); err != nil {
+		return "", false, false, nil, err
+	}
+
 	if in.ImageId != nil && *in.ImageId != "" {
-		exists, err := is.builder.Exists(ctx, *in.ImageId)
+		exists, err := is.imageExists(ctx, *in.ImageId, in.Architectures)
 		if err != nil {
 			return "", false, false, nil, err
 		}
@@ -228,6 +237,7 @@ func (is *ContainerImageService) verifyImage(ctx context.Context, in *pb.VerifyI
 		BuildSecrets:   buildSecrets,
 		Gpu:            in.Gpu,
 		ClipVersion:    is.config.ImageService.ClipVersion,
+		Architectures:  in.Architectures,
 	}
 
 	// Only set default beta9 base image if not using a custom Dockerfile
@@ -288,8 +298,8 @@ func (is *ContainerImageService) verifyImage(ctx context.Context, in *pb.VerifyI
 		valid = false
 	}
 
-	// Check registry for physical existence
-	exists, err := is.builder.Exists(ctx, imageId)
+	// Check registry for physical existence, for each architecture the image is built for
+	exists, err := is.imageExists(ctx, imageId, in.Architectures)
 	if err != nil {
 		return "", false, false, nil, err
 	}
@@ -309,6 +319,18 @@ func (is *ContainerImageService) verifyImage(ctx context.Context, in *pb.VerifyI
 	return imageId, exists, valid, opts, nil
 }
 
+// imageExists reports whether the image has been built for all of the architectures
+func (is *ContainerImageService) imageExists(ctx context.Context, imageId string, architectures []string) (bool, error) {
+	for _, arch := range types.ImageArchitectures(architectures) {
+		exists, err := is.builder.Exists(ctx, types.ImageIdForArch(imageId, arch))
+		if err != nil || !exists {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
 func (is *ContainerImageService) retrieveBuildSecrets(ctx context.Context, secrets []string, authInfo *auth.AuthInfo) ([]string, error) {
 	var buildSecrets []string
 	if secrets != nil {
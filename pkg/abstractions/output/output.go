@@ -7,7 +7,6 @@ import (
 	"os"
 	"path"
 	"path/filepath"
-	"strings"
 	"time"
 
 	"github.com/beam-cloud/beta9/pkg/auth"
@@ -31,18 +30,22 @@ type OutputService interface {
 	OutputSaveStream(stream pb.OutputService_OutputSaveStreamServer) error
 	OutputStat(ctx context.Context, in *pb.OutputStatRequest) (*pb.OutputStatResponse, error)
 	OutputPublicURL(ctx context.Context, in *pb.OutputPublicURLRequest) (*pb.OutputPublicURLResponse, error)
+	SetOutputRetentionPolicy(ctx context.Context, in *pb.SetOutputRetentionPolicyRequest) (*pb.SetOutputRetentionPolicyResponse, error)
+	GetOutputRetentionPolicy(ctx context.Context, in *pb.GetOutputRetentionPolicyRequest) (*pb.GetOutputRetentionPolicyResponse, error)
 }
 
 type OutputRedisService struct {
 	pb.UnimplementedOutputServiceServer
 
+	ctx         context.Context
 	config      types.AppConfig
 	rdb         *common.RedisClient
 	backendRepo repository.BackendRepository
 }
 
-func NewOutputRedisService(config types.AppConfig, redisClient *common.RedisClient, backendRepo repository.BackendRepository, routeGroup *echo.Group) (OutputService, error) {
+func NewOutputRedisService(ctx context.Context, config types.AppConfig, redisClient *common.RedisClient, backendRepo repository.BackendRepository, routeGroup *echo.Group) (OutputService, error) {
 	outputService := &OutputRedisService{
+		ctx:         ctx,
 		config:      config,
 		rdb:         redisClient,
 		backendRepo: backendRepo,
@@ -50,6 +53,8 @@ func NewOutputRedisService(config types.AppConfig, redisClient *common.RedisClie
 
 	registerOutputRoutes(routeGroup.Group(outputRoutePrefix), outputService)
 
+	go outputService.reapOutputs()
+
 	return outputService, nil
 }
 
@@ -277,52 +282,25 @@ func GetTaskOutputPath(workspaceName string, task *types.TaskWithRelated, output
 	return filepath.Join(types.DefaultOutputsPath, workspaceName, task.Stub.ExternalId, task.ExternalId, outputId, filepath.Base(filename))
 }
 
-func GetTaskOutputFiles(ctx context.Context, authInfo *auth.AuthInfo, workspaceName string, task *types.TaskWithRelated) (map[string]string, error) {
-	outputPath := GetTaskOutputRootPath(workspaceName, task)
-
+// GetTaskOutputFiles returns the outputs saved by a task
+func GetTaskOutputFiles(ctx context.Context, authInfo *auth.AuthInfo, workspaceName string, task *types.TaskWithRelated) ([]OutputFile, error) {
 	if authInfo.Workspace.StorageAvailable() {
-		outputPath = path.Join(types.DefaultOutputsPrefix, task.Stub.ExternalId, task.ExternalId)
 		storageClient, err := clients.NewWorkspaceStorageClient(ctx, authInfo.Workspace.Name, authInfo.Workspace.Storage)
 		if err != nil {
 			return nil, err
 		}
 
-		objects, err := storageClient.ListWithPrefix(ctx, outputPath)
-		if err != nil {
-			return nil, err
-		}
-
-		filePaths := map[string]string{}
-
-		for _, object := range objects {
-			if object.Key != nil && !strings.HasSuffix(*object.Key, "/") {
-				filePaths[filepath.Base(filepath.Dir(*object.Key))] = filepath.Base(*object.Key)
-			}
-		}
-
-		return filePaths, nil
+		return listOutputs(ctx, storageClient, path.Join(types.DefaultOutputsPrefix, task.Stub.ExternalId, task.ExternalId)+"/", "")
 	}
 
-	filePaths := map[string]string{}
-	filepath.WalkDir(outputPath, func(path string, d os.DirEntry, err error) error {
-		if err != nil && os.IsNotExist(err) {
-			return nil
-		}
-		if !d.IsDir() {
-			outputId := filepath.Base(filepath.Dir(path))
-			fileName := filepath.Base(path)
-			filePaths[outputId] = fileName
-		}
-		return nil
-	})
-
-	return filePaths, nil
+	return listOutputs(ctx, nil, "", GetTaskOutputRootPath(workspaceName, task))
 }
 
 // Redis keys
 var (
-	Keys                   = &keys{}
-	outputPublicURL string = "output:%s"
+	Keys                       = &keys{}
+	outputPublicURL     string = "output:%s"
+	outputRetentionLock string = "output:retention:lock"
 )
 
 type keys struct{}
@@ -330,3 +308,7 @@ type keys struct{}
 func (k *keys) outputPublicURL(outputId string) string {
 	return fmt.Sprintf(outputPublicURL, outputId)
 }
+
+func (k *keys) outputRetentionLock() string {
+	return outputRetentionLock
+}
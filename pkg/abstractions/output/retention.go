@@ -0,0 +1,347 @@
+package output
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/clients"
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/repository"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	outputReaperInterval time.Duration = 10 * time.Minute
+	outputReaperLockTtlS int           = 300
+)
+
+// OutputFile is a single saved output, stored under <stub>/<task>/<output id>/<filename>
+type OutputFile struct {
+	TaskId   string
+	Id       string
+	Filename string
+	Key      string
+	Size     int64
+	ModTime  time.Time
+}
+
+func (o *OutputRedisService) SetOutputRetentionPolicy(ctx context.Context, in *pb.SetOutputRetentionPolicyRequest) (*pb.SetOutputRetentionPolicyResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionWrite) {
+		return &pb.SetOutputRetentionPolicyResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	stub, err := o.backendRepo.GetStubByExternalId(ctx, in.StubId)
+	if err != nil || stub == nil || stub.WorkspaceId != authInfo.Workspace.Id {
+		return &pb.SetOutputRetentionPolicyResponse{
+			Ok:     false,
+			ErrMsg: "Stub not found",
+		}, nil
+	}
+
+	policy := in.Policy
+	if policy == nil || (policy.MaxAgeSeconds == 0 && policy.MaxCount == 0 && policy.MaxTotalBytes == 0) {
+		if err := o.backendRepo.DeleteOutputRetentionPolicy(ctx, stub.Id); err != nil {
+			return &pb.SetOutputRetentionPolicyResponse{
+				Ok:     false,
+				ErrMsg: "Unable to remove retention policy",
+			}, nil
+		}
+
+		return &pb.SetOutputRetentionPolicyResponse{Ok: true, Policy: &pb.OutputRetentionPolicy{}}, nil
+	}
+
+	if policy.MaxAgeSeconds > math.MaxInt64/uint64(time.Second) || policy.MaxTotalBytes > math.MaxInt64 {
+		return &pb.SetOutputRetentionPolicyResponse{
+			Ok:     false,
+			ErrMsg: "Retention limit is too large",
+		}, nil
+	}
+
+	updated, err := o.backendRepo.SetOutputRetentionPolicy(ctx, types.OutputRetentionPolicy{
+		StubId:        stub.Id,
+		MaxAgeSeconds: int64(policy.MaxAgeSeconds),
+		MaxCount:      int(policy.MaxCount),
+		MaxTotalBytes: int64(policy.MaxTotalBytes),
+	})
+	if err != nil {
+		return &pb.SetOutputRetentionPolicyResponse{
+			Ok:     false,
+			ErrMsg: "Unable to set retention policy",
+		}, nil
+	}
+
+	return &pb.SetOutputRetentionPolicyResponse{
+		Ok:     true,
+		Policy: retentionPolicyToProto(updated),
+	}, nil
+}
+
+func (o *OutputRedisService) GetOutputRetentionPolicy(ctx context.Context, in *pb.GetOutputRetentionPolicyRequest) (*pb.GetOutputRetentionPolicyResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	stub, err := o.backendRepo.GetStubByExternalId(ctx, in.StubId)
+	if err != nil || stub == nil || stub.WorkspaceId != authInfo.Workspace.Id {
+		return &pb.GetOutputRetentionPolicyResponse{
+			Ok:     false,
+			ErrMsg: "Stub not found",
+		}, nil
+	}
+
+	policy, err := GetOutputRetentionPolicy(ctx, o.backendRepo, stub.ExternalId)
+	if err != nil {
+		return &pb.GetOutputRetentionPolicyResponse{
+			Ok:     false,
+			ErrMsg: "Unable to get retention policy",
+		}, nil
+	}
+
+	// Stubs without a policy keep their outputs forever
+	return &pb.GetOutputRetentionPolicyResponse{
+		Ok:     true,
+		Policy: retentionPolicyToProto(policy),
+	}, nil
+}
+
+// GetOutputRetentionPolicy returns the stub's retention policy, nil if it doesn't have one
+func GetOutputRetentionPolicy(ctx context.Context, backendRepo repository.BackendRepository, stubExternalId string) (*types.OutputRetentionPolicy, error) {
+	policy, err := backendRepo.GetOutputRetentionPolicy(ctx, stubExternalId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+func retentionPolicyToProto(policy *types.OutputRetentionPolicy) *pb.OutputRetentionPolicy {
+	if policy == nil {
+		return &pb.OutputRetentionPolicy{}
+	}
+
+	return &pb.OutputRetentionPolicy{
+		MaxAgeSeconds: uint64(policy.MaxAgeSeconds),
+		MaxCount:      uint32(policy.MaxCount),
+		MaxTotalBytes: uint64(policy.MaxTotalBytes),
+	}
+}
+
+// reapOutputs periodically trims stubs' outputs down to their retention policies. Only one gateway replica
+// reaps at a time.
+func (o *OutputRedisService) reapOutputs() {
+	ticker := time.NewTicker(outputReaperInterval)
+	defer ticker.Stop()
+
+	lock := common.NewRedisLock(o.rdb)
+	lockKey := Keys.outputRetentionLock()
+
+	for {
+		select {
+		case <-o.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := lock.Acquire(o.ctx, lockKey, common.RedisLockOptions{TtlS: outputReaperLockTtlS, Retries: 0}); err != nil {
+				continue
+			}
+
+			o.reapOutputsOnce()
+			lock.Release(lockKey)
+		}
+	}
+}
+
+func (o *OutputRedisService) reapOutputsOnce() {
+	policies, err := o.backendRepo.ListOutputRetentionPolicies(o.ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to list output retention policies")
+		return
+	}
+
+	workspaces := map[uint]*types.Workspace{}
+	deadline := time.Now().Add(time.Duration(outputReaperLockTtlS) * time.Second / 2)
+
+	for i := range policies {
+		if time.Now().After(deadline) {
+			return
+		}
+
+		policy := &policies[i]
+
+		workspace, ok := workspaces[policy.WorkspaceId]
+		if !ok {
+			workspace, err = o.backendRepo.GetWorkspace(o.ctx, policy.WorkspaceId)
+			if err != nil {
+				continue
+			}
+			workspaces[policy.WorkspaceId] = workspace
+		}
+
+		deleted, err := o.applyRetentionPolicy(o.ctx, workspace, policy, time.Now())
+		if err != nil {
+			log.Warn().Err(err).Str("stub_id", policy.StubExternalId).Msg("failed to apply output retention policy")
+		}
+		if deleted > 0 {
+			log.Info().Str("stub_id", policy.StubExternalId).Int("count", deleted).Msg("deleted outputs past retention")
+		}
+	}
+}
+
+func (o *OutputRedisService) applyRetentionPolicy(ctx context.Context, workspace *types.Workspace, policy *types.OutputRetentionPolicy, now time.Time) (int, error) {
+	var storageClient *clients.WorkspaceStorageClient
+	if workspace.StorageAvailable() {
+		var err error
+		storageClient, err = clients.NewWorkspaceStorageClient(ctx, workspace.Name, workspace.Storage)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	outputs, err := listOutputs(ctx, storageClient, path.Join(types.DefaultOutputsPrefix, policy.StubExternalId)+"/", filepath.Join(types.DefaultOutputsPath, workspace.Name, policy.StubExternalId))
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, output := range expiredOutputs(outputs, policy, now) {
+		if err := deleteOutput(ctx, storageClient, output); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+
+	return deleted, nil
+}
+
+// expiredOutputs returns the outputs the policy no longer retains. Outputs are kept newest first until they're
+// older than the max age or would exceed the max count or total size.
+func expiredOutputs(outputs []OutputFile, policy *types.OutputRetentionPolicy, now time.Time) []OutputFile {
+	sorted := make([]OutputFile, len(outputs))
+	copy(sorted, outputs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ModTime.After(sorted[j].ModTime)
+	})
+
+	expired := []OutputFile{}
+	count, totalBytes := 0, int64(0)
+	full := false
+
+	for _, output := range sorted {
+		retainUntil := policy.RetainUntil(output.ModTime)
+
+		// Once a limit is reached every older output goes too, even a smaller one that would still fit
+		full = full ||
+			(policy.MaxCount > 0 && count+1 > policy.MaxCount) ||
+			(policy.MaxTotalBytes > 0 && totalBytes+output.Size > policy.MaxTotalBytes)
+
+		if full || (retainUntil != nil && !now.Before(retainUntil.Time)) {
+			expired = append(expired, output)
+			continue
+		}
+
+		count++
+		totalBytes += output.Size
+	}
+
+	return expired
+}
+
+// listOutputs returns the outputs stored under a stub or task's prefix in workspace storage, or under its
+// local directory when the workspace has no storage
+func listOutputs(ctx context.Context, storageClient *clients.WorkspaceStorageClient, storagePrefix, localDir string) ([]OutputFile, error) {
+	outputs := []OutputFile{}
+
+	if storageClient != nil {
+		objects, err := storageClient.ListWithPrefix(ctx, storagePrefix)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, object := range objects {
+			if object.Key == nil || strings.HasSuffix(*object.Key, "/") {
+				continue
+			}
+
+			output := outputFromPath(*object.Key)
+			if object.Size != nil {
+				output.Size = *object.Size
+			}
+			if object.LastModified != nil {
+				output.ModTime = *object.LastModified
+			}
+			outputs = append(outputs, output)
+		}
+
+		return outputs, nil
+	}
+
+	err := filepath.WalkDir(localDir, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+
+		output := outputFromPath(p)
+		output.Size = info.Size()
+		output.ModTime = info.ModTime()
+		outputs = append(outputs, output)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return outputs, nil
+}
+
+func outputFromPath(p string) OutputFile {
+	outputDir := filepath.Dir(p)
+
+	return OutputFile{
+		TaskId:   filepath.Base(filepath.Dir(outputDir)),
+		Id:       filepath.Base(outputDir),
+		Filename: filepath.Base(p),
+		Key:      p,
+	}
+}
+
+func deleteOutput(ctx context.Context, storageClient *clients.WorkspaceStorageClient, output OutputFile) error {
+	if storageClient != nil {
+		return storageClient.Delete(ctx, output.Key)
+	}
+
+	outputDir := filepath.Dir(output.Key)
+	if err := os.RemoveAll(outputDir); err != nil {
+		return err
+	}
+
+	// Remove the task's directory once its last output is gone
+	os.Remove(filepath.Dir(outputDir))
+	return nil
+}
@@ -0,0 +1,72 @@
+package output
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/beam-cloud/beta9/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func outputIds(outputs []OutputFile) []string {
+	ids := []string{}
+	for _, output := range outputs {
+		ids = append(ids, output.Id)
+	}
+	return ids
+}
+
+func TestExpiredOutputs(t *testing.T) {
+	now := time.Now()
+	outputs := []OutputFile{
+		{Id: "oldest", Size: 10, ModTime: now.Add(-4 * time.Hour)},
+		{Id: "newest", Size: 10, ModTime: now.Add(-1 * time.Minute)},
+		{Id: "old", Size: 1, ModTime: now.Add(-3 * time.Hour)},
+		{Id: "new", Size: 30, ModTime: now.Add(-1 * time.Hour)},
+	}
+
+	tests := []struct {
+		name    string
+		policy  types.OutputRetentionPolicy
+		expired []string
+	}{
+		{name: "no limits", policy: types.OutputRetentionPolicy{}, expired: []string{}},
+		{name: "max age", policy: types.OutputRetentionPolicy{MaxAgeSeconds: 7200}, expired: []string{"old", "oldest"}},
+		{name: "max count", policy: types.OutputRetentionPolicy{MaxCount: 3}, expired: []string{"oldest"}},
+		{name: "max total bytes", policy: types.OutputRetentionPolicy{MaxTotalBytes: 40}, expired: []string{"old", "oldest"}},
+		{name: "tightest wins", policy: types.OutputRetentionPolicy{MaxAgeSeconds: 86400, MaxCount: 1, MaxTotalBytes: 1000}, expired: []string{"new", "old", "oldest"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expired, outputIds(expiredOutputs(outputs, &tt.policy, now)))
+		})
+	}
+}
+
+func TestListOutputsLocal(t *testing.T) {
+	stubDir := filepath.Join(t.TempDir(), "stub-id")
+	outputPath := filepath.Join(stubDir, "task-id", "output-id", "result.txt")
+	require.NoError(t, os.MkdirAll(filepath.Dir(outputPath), 0755))
+	require.NoError(t, os.WriteFile(outputPath, []byte("hello"), 0644))
+
+	outputs, err := listOutputs(context.Background(), nil, "", stubDir)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(outputs))
+	assert.Equal(t, "task-id", outputs[0].TaskId)
+	assert.Equal(t, "output-id", outputs[0].Id)
+	assert.Equal(t, "result.txt", outputs[0].Filename)
+	assert.Equal(t, int64(5), outputs[0].Size)
+
+	require.NoError(t, deleteOutput(context.Background(), nil, outputs[0]))
+	_, err = os.Stat(filepath.Join(stubDir, "task-id"))
+	assert.True(t, os.IsNotExist(err))
+
+	outputs, err = listOutputs(context.Background(), nil, "", filepath.Join(t.TempDir(), "missing"))
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(outputs))
+}
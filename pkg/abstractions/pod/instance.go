@@ -20,7 +20,7 @@ type podInstance struct {
 }
 
 func (i *podInstance) startContainers(containersToRun int) error {
-	secrets, err := abstractions.ConfigureContainerRequestSecrets(i.Workspace, *i.StubConfig)
+	secrets, secretMount, err := abstractions.ConfigureContainerRequestSecrets(i.Ctx, i.BackendRepo, i.Workspace, *i.StubConfig)
 	if err != nil {
 		return err
 	}
@@ -75,6 +75,11 @@ func (i *podInstance) startContainers(containersToRun int) error {
 			Memory:            i.StubConfig.Runtime.Memory,
 			GpuRequest:        gpuRequest,
 			GpuCount:          uint32(gpuCount),
+			GpuFraction:       i.StubConfig.Runtime.GpuFraction,
+			MigProfile:        i.StubConfig.Runtime.MigProfile,
+			Architectures:     i.StubConfig.Runtime.Architectures,
+			Affinity:          i.StubConfig.Affinity,
+			PriorityClass:     i.StubConfig.PriorityClass,
 			ImageId:           i.StubConfig.Runtime.ImageId,
 			StubId:            i.Stub.ExternalId,
 			AppId:             i.Stub.App.ExternalId,
@@ -88,6 +93,8 @@ func (i *podInstance) startContainers(containersToRun int) error {
 			BlockNetwork:      i.StubConfig.BlockNetwork,
 			AllowList:         i.StubConfig.AllowList,
 			DockerEnabled:     i.StubConfig.DockerEnabled,
+			Sidecars:          i.StubConfig.Sidecars,
+			SecretMount:       secretMount,
 		}
 
 		ttl := time.Duration(i.StubConfig.KeepWarmSeconds) * time.Second
@@ -106,7 +106,7 @@ func NewPodService(
 		return nil, err
 	}
 
-	authMiddleware := auth.AuthMiddleware(ps.backendRepo, ps.workspaceRepo)
+	authMiddleware := auth.AuthMiddleware(ps.backendRepo, ps.workspaceRepo, opts.Config.GatewayService.IPAllowList)
 
 	registerPodGroup(opts.RouteGroup.Group(podRoutePrefix, authMiddleware), ps)
 	registerPodGroup(opts.RouteGroup.Group(sandboxRoutePrefix, authMiddleware), ps)
@@ -251,28 +251,17 @@ func (ps *GenericPodService) getOrCreatePodInstance(stubId string, options ...fu
 	return instance, nil
 }
 
-func (s *GenericPodService) run(ctx context.Context, authInfo *auth.AuthInfo, stub *types.StubWithRelated, imageId *string, checkpoint *types.Checkpoint) (string, error) {
+// run starts the stub's pod, returning the IDs of its containers. Pods spanning several nodes start a container
+// on each, by rank, which are scheduled as one gang.
+func (s *GenericPodService) run(ctx context.Context, authInfo *auth.AuthInfo, stub *types.StubWithRelated, imageId *string, checkpoint *types.Checkpoint) ([]string, error) {
 	stubConfig := types.StubConfigV1{}
 	if err := json.Unmarshal([]byte(stub.Config), &stubConfig); err != nil {
-		return "", err
+		return nil, err
 	}
 
-	secrets, err := abstractions.ConfigureContainerRequestSecrets(authInfo.Workspace, stubConfig)
+	secrets, secretMount, err := abstractions.ConfigureContainerRequestSecrets(ctx, s.backendRepo, authInfo.Workspace, stubConfig)
 	if err != nil {
-		return "", err
-	}
-
-	containerId := s.generateContainerId(stub.ExternalId, stub.Type)
-
-	mounts, err := abstractions.ConfigureContainerRequestMounts(
-		containerId,
-		stub.Object.ExternalId,
-		authInfo.Workspace,
-		stubConfig,
-		stub.ExternalId,
-	)
-	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	env := []string{}
@@ -295,8 +284,10 @@ func (s *GenericPodService) run(ctx context.Context, authInfo *auth.AuthInfo, st
 		gpuCount = 1
 	}
 
+	nodes := max(stubConfig.Nodes, 1)
+
 	checkpointEnabled := stubConfig.CheckpointEnabled
-	if gpuCount > 1 {
+	if gpuCount > 1 || nodes > 1 {
 		checkpointEnabled = false
 	}
 
@@ -305,14 +296,6 @@ func (s *GenericPodService) run(ctx context.Context, authInfo *auth.AuthInfo, st
 		ports = stubConfig.Ports
 	}
 
-	ttl := time.Duration(stubConfig.KeepWarmSeconds) * time.Second
-	key := Keys.podKeepWarmLock(authInfo.Workspace.Name, stub.ExternalId, containerId)
-	if ttl <= 0 {
-		s.rdb.Set(context.Background(), key, 1, 0) // Never expire
-	} else {
-		s.rdb.SetEx(context.Background(), key, 1, ttl)
-	}
-
 	if imageId == nil {
 		imageId = &stubConfig.Runtime.ImageId
 	}
@@ -320,33 +303,73 @@ func (s *GenericPodService) run(ctx context.Context, authInfo *auth.AuthInfo, st
 	// Validate allowlist CIDR entries before scheduling
 	if len(stubConfig.AllowList) > 0 {
 		if err := common.ValidateAllowList(stubConfig.AllowList); err != nil {
-			return "", err
+			return nil, err
 		}
 	}
 
-	err = s.scheduler.Run(&types.ContainerRequest{
-		ContainerId:       containerId,
-		StubId:            stub.ExternalId,
-		Env:               env,
-		Cpu:               stubConfig.Runtime.Cpu,
-		Memory:            stubConfig.Runtime.Memory,
-		GpuRequest:        gpuRequest,
-		GpuCount:          uint32(gpuCount),
-		Mounts:            mounts,
-		Stub:              *stub,
-		ImageId:           *imageId,
-		WorkspaceId:       authInfo.Workspace.ExternalId,
-		Workspace:         *authInfo.Workspace,
-		EntryPoint:        stubConfig.EntryPoint,
-		Ports:             ports,
-		CheckpointEnabled: checkpointEnabled,
-		Checkpoint:        checkpoint,
-		BlockNetwork:      stubConfig.BlockNetwork,
-		AllowList:         stubConfig.AllowList,
-		DockerEnabled:     stubConfig.DockerEnabled,
-	})
+	ttl := time.Duration(stubConfig.KeepWarmSeconds) * time.Second
+
+	containerIds := make([]string, nodes)
+	requests := make([]*types.ContainerRequest, nodes)
+	for i := range requests {
+		containerId := s.generateContainerId(stub.ExternalId, stub.Type)
+
+		mounts, err := abstractions.ConfigureContainerRequestMounts(
+			containerId,
+			stub.Object.ExternalId,
+			authInfo.Workspace,
+			stubConfig,
+			stub.ExternalId,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		key := Keys.podKeepWarmLock(authInfo.Workspace.Name, stub.ExternalId, containerId)
+		if ttl <= 0 {
+			s.rdb.Set(context.Background(), key, 1, 0) // Never expire
+		} else {
+			s.rdb.SetEx(context.Background(), key, 1, ttl)
+		}
+
+		containerIds[i] = containerId
+		requests[i] = &types.ContainerRequest{
+			ContainerId:       containerId,
+			StubId:            stub.ExternalId,
+			Env:               env,
+			Cpu:               stubConfig.Runtime.Cpu,
+			Memory:            stubConfig.Runtime.Memory,
+			GpuRequest:        gpuRequest,
+			GpuCount:          uint32(gpuCount),
+			GpuFraction:       stubConfig.Runtime.GpuFraction,
+			MigProfile:        stubConfig.Runtime.MigProfile,
+			Architectures:     stubConfig.Runtime.Architectures,
+			Affinity:          stubConfig.Affinity,
+			PriorityClass:     stubConfig.PriorityClass,
+			Mounts:            mounts,
+			Stub:              *stub,
+			ImageId:           *imageId,
+			WorkspaceId:       authInfo.Workspace.ExternalId,
+			Workspace:         *authInfo.Workspace,
+			EntryPoint:        stubConfig.EntryPoint,
+			Ports:             ports,
+			CheckpointEnabled: checkpointEnabled,
+			Checkpoint:        checkpoint,
+			BlockNetwork:      stubConfig.BlockNetwork,
+			AllowList:         stubConfig.AllowList,
+			DockerEnabled:     stubConfig.DockerEnabled,
+			Sidecars:          stubConfig.Sidecars,
+			SecretMount:       secretMount,
+		}
+	}
+
+	if nodes > 1 {
+		err = s.scheduler.RunGang(requests, s.config.Worker.GangScheduling.Timeout)
+	} else {
+		err = s.scheduler.Run(requests[0])
+	}
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	go s.eventRepo.PushRunStubEvent(
@@ -354,7 +377,7 @@ func (s *GenericPodService) run(ctx context.Context, authInfo *auth.AuthInfo, st
 		&stub.Stub,
 	)
 
-	return containerId, nil
+	return containerIds, nil
 }
 
 func (s *GenericPodService) CreatePod(ctx context.Context, in *pb.CreatePodRequest) (*pb.CreatePodResponse, error) {
@@ -395,7 +418,7 @@ func (s *GenericPodService) CreatePod(ctx context.Context, in *pb.CreatePodReque
 		}
 	}
 
-	containerId, err := s.run(ctx, authInfo, stub, in.ImageId, checkpoint)
+	containerIds, err := s.run(ctx, authInfo, stub, in.ImageId, checkpoint)
 	if err != nil {
 		return &pb.CreatePodResponse{
 			Ok:       false,
@@ -404,9 +427,10 @@ func (s *GenericPodService) CreatePod(ctx context.Context, in *pb.CreatePodReque
 	}
 
 	return &pb.CreatePodResponse{
-		Ok:          true,
-		ContainerId: containerId,
-		StubId:      stub.ExternalId,
+		Ok:           true,
+		ContainerId:  containerIds[0],
+		ContainerIds: containerIds,
+		StubId:       stub.ExternalId,
 	}, nil
 }
 
@@ -436,8 +460,8 @@ func (s *GenericPodService) cloneStub(ctx context.Context, workspace *types.Work
 		}
 	}
 
-	for _, secret := range parentSecrets {
-		secret, err := s.backendRepo.GetSecretByName(ctx, workspace, secret.Name)
+	for _, parentSecret := range parentSecrets {
+		secret, err := s.backendRepo.GetSecretByName(ctx, workspace, parentSecret.Name)
 		if err != nil {
 			if err == sql.ErrNoRows {
 				continue
@@ -451,6 +475,8 @@ func (s *GenericPodService) cloneStub(ctx context.Context, workspace *types.Work
 			Value:     secret.Value,
 			CreatedAt: secret.CreatedAt,
 			UpdatedAt: secret.UpdatedAt,
+			Version:   secret.Version,
+			Mount:     parentSecret.Mount,
 		})
 	}
 
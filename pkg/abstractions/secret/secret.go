@@ -4,11 +4,15 @@ import (
 	"context"
 	"database/sql"
 
+	abstractions "github.com/beam-cloud/beta9/pkg/abstractions/common"
 	"github.com/beam-cloud/beta9/pkg/auth"
 	"github.com/beam-cloud/beta9/pkg/repository"
+	"github.com/beam-cloud/beta9/pkg/scheduler"
+	"github.com/beam-cloud/beta9/pkg/types"
 	pb "github.com/beam-cloud/beta9/proto"
 	"github.com/labstack/echo/v4"
 	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -19,22 +23,28 @@ type SecretService interface {
 	ListSecrets(ctx context.Context, req *pb.ListSecretsRequest) (*pb.ListSecretsResponse, error)
 	UpdateSecret(ctx context.Context, req *pb.UpdateSecretRequest) (*pb.UpdateSecretResponse, error)
 	DeleteSecret(ctx context.Context, req *pb.DeleteSecretRequest) (*pb.DeleteSecretResponse, error)
+	RotateSecret(ctx context.Context, req *pb.RotateSecretRequest) (*pb.RotateSecretResponse, error)
+	GetSecretVersion(ctx context.Context, req *pb.GetSecretVersionRequest) (*pb.GetSecretVersionResponse, error)
 }
 
 type WorkspaceSecretService struct {
 	pb.UnimplementedSecretServiceServer
-	backendRepo repository.BackendRepository
+	backendRepo   repository.BackendRepository
+	containerRepo repository.ContainerRepository
+	scheduler     *scheduler.Scheduler
 }
 
 var secretRoutePrefix = "/secret"
 
-func NewSecretService(backendRepo repository.BackendRepository, workspaceRepo repository.WorkspaceRepository, routeGroup *echo.Group) SecretService {
+func NewSecretService(backendRepo repository.BackendRepository, workspaceRepo repository.WorkspaceRepository, containerRepo repository.ContainerRepository, scheduler *scheduler.Scheduler, ipAllowList types.IPAllowListConfig, routeGroup *echo.Group) SecretService {
 	ss := &WorkspaceSecretService{
-		backendRepo: backendRepo,
+		backendRepo:   backendRepo,
+		containerRepo: containerRepo,
+		scheduler:     scheduler,
 	}
 
 	// Register HTTP routes
-	authMiddleware := auth.AuthMiddleware(backendRepo, workspaceRepo)
+	authMiddleware := auth.AuthMiddleware(backendRepo, workspaceRepo, ipAllowList)
 	registerSecretRoutes(routeGroup.Group(secretRoutePrefix, authMiddleware), ss)
 
 	return ss
@@ -62,7 +72,7 @@ func handleErrMsg(err error) string {
 func (s *WorkspaceSecretService) CreateSecret(ctx context.Context, req *pb.CreateSecretRequest) (*pb.CreateSecretResponse, error) {
 	authInfo, _ := auth.AuthInfoFromContext(ctx)
 
-	if !auth.HasPermission(authInfo) {
+	if !auth.HasPermission(authInfo, types.PermissionWrite) {
 		return &pb.CreateSecretResponse{
 			Ok:     false,
 			ErrMsg: "Unauthorized Access",
@@ -102,6 +112,7 @@ func (s *WorkspaceSecretService) GetSecret(ctx context.Context, req *pb.GetSecre
 		Secret: &pb.Secret{
 			Name:      secret.Name,
 			Value:     secret.Value,
+			Version:   uint32(secret.Version),
 			UpdatedAt: timestamppb.New(secret.UpdatedAt),
 			CreatedAt: timestamppb.New(secret.CreatedAt),
 		},
@@ -111,7 +122,7 @@ func (s *WorkspaceSecretService) GetSecret(ctx context.Context, req *pb.GetSecre
 func (s *WorkspaceSecretService) ListSecrets(ctx context.Context, req *pb.ListSecretsRequest) (*pb.ListSecretsResponse, error) {
 	authInfo, _ := auth.AuthInfoFromContext(ctx)
 
-	if !auth.HasPermission(authInfo) {
+	if !auth.HasPermission(authInfo, types.PermissionRead) {
 		return &pb.ListSecretsResponse{
 			Ok:     false,
 			ErrMsg: "Unauthorized Access",
@@ -131,6 +142,7 @@ func (s *WorkspaceSecretService) ListSecrets(ctx context.Context, req *pb.ListSe
 		secretList = append(secretList, &pb.Secret{
 			Name:      secret.Name,
 			Value:     secret.Value,
+			Version:   uint32(secret.Version),
 			UpdatedAt: timestamppb.New(secret.UpdatedAt),
 			CreatedAt: timestamppb.New(secret.CreatedAt),
 		})
@@ -146,7 +158,7 @@ func (s *WorkspaceSecretService) ListSecrets(ctx context.Context, req *pb.ListSe
 func (s *WorkspaceSecretService) UpdateSecret(ctx context.Context, req *pb.UpdateSecretRequest) (*pb.UpdateSecretResponse, error) {
 	authInfo, _ := auth.AuthInfoFromContext(ctx)
 
-	if !auth.HasPermission(authInfo) {
+	if !auth.HasPermission(authInfo, types.PermissionWrite) {
 		return &pb.UpdateSecretResponse{
 			Ok:     false,
 			ErrMsg: "Unauthorized Access",
@@ -170,7 +182,7 @@ func (s *WorkspaceSecretService) UpdateSecret(ctx context.Context, req *pb.Updat
 func (s *WorkspaceSecretService) DeleteSecret(ctx context.Context, req *pb.DeleteSecretRequest) (*pb.DeleteSecretResponse, error) {
 	authInfo, _ := auth.AuthInfoFromContext(ctx)
 
-	if !auth.HasPermission(authInfo) {
+	if !auth.HasPermission(authInfo, types.PermissionWrite) {
 		return &pb.DeleteSecretResponse{
 			Ok:     false,
 			ErrMsg: "Unauthorized Access",
@@ -190,3 +202,122 @@ func (s *WorkspaceSecretService) DeleteSecret(ctx context.Context, req *pb.Delet
 		ErrMsg: "",
 	}, nil
 }
+
+func (s *WorkspaceSecretService) RotateSecret(ctx context.Context, req *pb.RotateSecretRequest) (*pb.RotateSecretResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionWrite) {
+		return &pb.RotateSecretResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	secret, err := s.backendRepo.RotateSecret(ctx, authInfo.Workspace, authInfo.Token.Id, req.Name, req.Value)
+	if err != nil {
+		return &pb.RotateSecretResponse{
+			Ok:     false,
+			ErrMsg: handleErrMsg(err),
+		}, nil
+	}
+
+	deployments, err := s.deploymentsFollowingSecret(ctx, authInfo.Workspace, secret.Name)
+	if err != nil {
+		return &pb.RotateSecretResponse{
+			Ok:      false,
+			ErrMsg:  "Secret rotated, but unable to find deployments using it",
+			Version: uint32(secret.Version),
+		}, nil
+	}
+
+	affectedDeployments := make([]string, 0, len(deployments))
+	for _, deployment := range deployments {
+		affectedDeployments = append(affectedDeployments, deployment.ExternalId)
+	}
+
+	if !req.SkipRestart && len(deployments) > 0 {
+		go s.restartDeployments(deployments, secret)
+	}
+
+	return &pb.RotateSecretResponse{
+		Ok:                  true,
+		Version:             uint32(secret.Version),
+		AffectedDeployments: affectedDeployments,
+	}, nil
+}
+
+func (s *WorkspaceSecretService) GetSecretVersion(ctx context.Context, req *pb.GetSecretVersionRequest) (*pb.GetSecretVersionResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionWrite) {
+		return &pb.GetSecretVersionResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	var (
+		secret *types.Secret
+		err    error
+	)
+	if req.Version == 0 {
+		secret, err = s.backendRepo.GetSecretByNameDecrypted(ctx, authInfo.Workspace, req.Name)
+	} else {
+		secret, err = s.backendRepo.GetSecretVersionDecrypted(ctx, authInfo.Workspace, req.Name, uint(req.Version))
+	}
+	if err != nil {
+		return &pb.GetSecretVersionResponse{
+			Ok:     false,
+			ErrMsg: handleErrMsg(err),
+		}, nil
+	}
+
+	return &pb.GetSecretVersionResponse{
+		Ok: true,
+		Secret: &pb.Secret{
+			Name:      secret.Name,
+			Value:     secret.Value,
+			Version:   uint32(secret.Version),
+			UpdatedAt: timestamppb.New(secret.UpdatedAt),
+			CreatedAt: timestamppb.New(secret.CreatedAt),
+		},
+	}, nil
+}
+
+// deploymentsFollowingSecret returns the workspace's active deployments that use the latest version of a secret
+func (s *WorkspaceSecretService) deploymentsFollowingSecret(ctx context.Context, workspace *types.Workspace, secretName string) ([]types.DeploymentWithRelated, error) {
+	active := true
+	deployments, err := s.backendRepo.ListDeploymentsWithRelated(ctx, types.DeploymentFilter{
+		WorkspaceID: workspace.Id,
+		Active:      &active,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	following := []types.DeploymentWithRelated{}
+	for _, deployment := range deployments {
+		stubConfig, err := deployment.Stub.UnmarshalConfig()
+		if err != nil {
+			continue
+		}
+
+		for _, secret := range stubConfig.Secrets {
+			if secret.Name == secretName && !secret.Pinned {
+				following = append(following, deployment)
+				break
+			}
+		}
+	}
+
+	return following, nil
+}
+
+// restartDeployments rolls each deployment's containers onto the rotated value one at a time, so the
+// deployment keeps serving while new containers start
+func (s *WorkspaceSecretService) restartDeployments(deployments []types.DeploymentWithRelated, secret *types.Secret) {
+	for _, deployment := range deployments {
+		log.Info().Str("deployment_id", deployment.ExternalId).Str("secret", secret.Name).Uint("version", secret.Version).Msg("restarting deployment for rotated secret")
+		abstractions.RollingRestart(context.Background(), s.containerRepo, s.scheduler, deployment.Stub.ExternalId)
+	}
+}
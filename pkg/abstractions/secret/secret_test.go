@@ -0,0 +1,40 @@
+package secret
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/beam-cloud/beta9/pkg/repository"
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+func TestDeploymentsFollowingSecret(t *testing.T) {
+	backendRepo, mock := repository.NewBackendPostgresRepositoryForTest()
+	service := &WorkspaceSecretService{backendRepo: backendRepo}
+
+	mock.ExpectQuery("SELECT (.+) FROM deployment").
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "external_id", "stub.external_id", "stub.config"}).
+				AddRow(1, "env-latest", "stub-1", `{"secrets":[{"name":"API_KEY"}]}`).
+				AddRow(2, "env-pinned", "stub-2", `{"secrets":[{"name":"API_KEY","version":1,"pinned":true}]}`).
+				AddRow(3, "mount-latest", "stub-3", `{"secrets":[{"name":"API_KEY","mount":true}]}`).
+				AddRow(4, "mount-pinned", "stub-4", `{"secrets":[{"name":"API_KEY","version":2,"pinned":true,"mount":true}]}`).
+				AddRow(5, "other-secret", "stub-5", `{"secrets":[{"name":"DB_PASSWORD","mount":true}]}`),
+		)
+
+	deployments, err := service.deploymentsFollowingSecret(context.Background(), &types.Workspace{Id: 1}, "API_KEY")
+	require.NoError(t, err)
+
+	ids := []string{}
+	for _, deployment := range deployments {
+		ids = append(ids, deployment.ExternalId)
+	}
+
+	// Secrets mounted as files follow the latest version just like env secrets
+	assert.Equal(t, []string{"env-latest", "mount-latest"}, ids)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
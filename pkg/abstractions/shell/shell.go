@@ -104,7 +104,7 @@ func NewSSHShellService(
 		keyEventChan:    make(chan common.KeyEvent),
 	}
 
-	authMiddleware := auth.AuthMiddleware(opts.BackendRepo, opts.WorkspaceRepo)
+	authMiddleware := auth.AuthMiddleware(opts.BackendRepo, opts.WorkspaceRepo, opts.Config.GatewayService.IPAllowList)
 	registerShellRoutes(opts.RouteGroup.Group(shellRoutePrefix, authMiddleware), ss)
 
 	// Listen for shell container ttl events
@@ -345,7 +345,9 @@ func (ss *SSHShellService) CreateStandaloneShell(ctx context.Context, in *pb.Cre
 		}, nil
 	}
 
-	secrets, err := abstractions.ConfigureContainerRequestSecrets(
+	secrets, secretMount, err := abstractions.ConfigureContainerRequestSecrets(
+		ctx,
+		ss.backendRepo,
 		authInfo.Workspace,
 		stubConfig,
 	)
@@ -393,20 +395,26 @@ func (ss *SSHShellService) CreateStandaloneShell(ctx context.Context, in *pb.Cre
 	}
 
 	err = ss.scheduler.Run(&types.ContainerRequest{
-		ContainerId: containerId,
-		Env:         env,
-		Cpu:         stubConfig.Runtime.Cpu,
-		Memory:      stubConfig.Runtime.Memory,
-		GpuRequest:  gpuRequest,
-		GpuCount:    uint32(gpuCount),
-		ImageId:     stubConfig.Runtime.ImageId,
-		StubId:      stub.ExternalId,
-		AppId:       stub.App.ExternalId,
-		WorkspaceId: authInfo.Workspace.ExternalId,
-		Workspace:   *authInfo.Workspace,
-		EntryPoint:  entryPoint,
-		Mounts:      mounts,
-		Stub:        *stub,
+		ContainerId:   containerId,
+		Env:           env,
+		Cpu:           stubConfig.Runtime.Cpu,
+		Memory:        stubConfig.Runtime.Memory,
+		GpuRequest:    gpuRequest,
+		GpuCount:      uint32(gpuCount),
+		GpuFraction:   stubConfig.Runtime.GpuFraction,
+		MigProfile:    stubConfig.Runtime.MigProfile,
+		Architectures: stubConfig.Runtime.Architectures,
+		Affinity:      stubConfig.Affinity,
+		PriorityClass: stubConfig.PriorityClass,
+		ImageId:       stubConfig.Runtime.ImageId,
+		StubId:        stub.ExternalId,
+		AppId:         stub.App.ExternalId,
+		WorkspaceId:   authInfo.Workspace.ExternalId,
+		Workspace:     *authInfo.Workspace,
+		EntryPoint:    entryPoint,
+		Mounts:        mounts,
+		Stub:          *stub,
+		SecretMount:   secretMount,
 	})
 	if err != nil {
 		return &pb.CreateStandaloneShellResponse{
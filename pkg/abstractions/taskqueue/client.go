@@ -32,7 +32,7 @@ func (qc *taskQueueClient) Push(ctx context.Context, taskMessage *types.TaskMess
 		return err
 	}
 
-	err = qc.rdb.RPush(ctx, Keys.taskQueueList(taskMessage.WorkspaceName, taskMessage.StubId), encodedMessage).Err()
+	err = qc.rdb.RPush(ctx, Keys.taskQueuePriorityList(taskMessage.WorkspaceName, taskMessage.StubId, taskMessage.Policy.Priority), encodedMessage).Err()
 	if err != nil {
 		return err
 	}
@@ -40,13 +40,15 @@ func (qc *taskQueueClient) Push(ctx context.Context, taskMessage *types.TaskMess
 	return nil
 }
 
-func (qc *taskQueueClient) Pop(ctx context.Context, workspaceName, stubId, containerId string) ([]byte, error) {
-	queueLength, err := qc.rdb.LLen(ctx, Keys.taskQueueList(workspaceName, stubId)).Result()
+// Pop takes the next task from the highest priority queue with work whose running limit hasn't been
+// reached. Limits cap the running tasks at or below a priority; a priority without a limit is never held back.
+func (qc *taskQueueClient) Pop(ctx context.Context, workspaceName, stubId, containerId string, limits map[types.TaskPriority]int64) ([]byte, error) {
+	queueKey, priority, err := qc.nextQueue(ctx, workspaceName, stubId, limits)
 	if err != nil {
 		return nil, err
 	}
 
-	if queueLength == 0 {
+	if queueKey == "" {
 		return nil, nil
 	}
 
@@ -60,7 +62,7 @@ func (qc *taskQueueClient) Pop(ctx context.Context, workspaceName, stubId, conta
 	}()
 
 	// Now actually pop the task from the queue
-	task, err := qc.rdb.LPop(ctx, Keys.taskQueueList(workspaceName, stubId)).Bytes()
+	task, err := qc.rdb.LPop(ctx, queueKey).Bytes()
 	if err != nil {
 		if err == redis.Nil {
 			return nil, nil
@@ -92,17 +94,116 @@ func (qc *taskQueueClient) Pop(ctx context.Context, workspaceName, stubId, conta
 		return nil, err
 	}
 
+	err = qc.rdb.ZAdd(ctx, Keys.taskQueueRunningPriority(workspaceName, stubId, priority), redis.Z{
+		Score:  float64(time.Now().Unix()),
+		Member: tm.TaskId,
+	}).Err()
+	if err != nil {
+		return nil, err
+	}
+
 	return task, nil
 }
 
-// Get queue length
+// nextQueue returns the queue the next task should be taken from, or an empty key if there is none
+func (qc *taskQueueClient) nextQueue(ctx context.Context, workspaceName, stubId string, limits map[types.TaskPriority]int64) (string, types.TaskPriority, error) {
+	for _, priority := range types.TaskPriorities {
+		queueKey := Keys.taskQueuePriorityList(workspaceName, stubId, priority)
+
+		queueLength, err := qc.rdb.LLen(ctx, queueKey).Result()
+		if err != nil {
+			return "", "", err
+		}
+
+		if queueLength == 0 {
+			continue
+		}
+
+		limit, ok := limits[priority]
+		if !ok || limit <= 0 {
+			return queueKey, priority, nil
+		}
+
+		running, err := qc.runningTasksAtOrBelow(ctx, workspaceName, stubId, priority)
+		if err != nil {
+			return "", "", err
+		}
+
+		if running < limit {
+			return queueKey, priority, nil
+		}
+	}
+
+	return "", "", nil
+}
+
+// runningTasksAtOrBelow counts the running tasks with the given priority or a lower one. Running tasks
+// are refreshed by their monitor, so tasks whose container went away age out of the count.
+func (qc *taskQueueClient) runningTasksAtOrBelow(ctx context.Context, workspaceName, stubId string, priority types.TaskPriority) (int64, error) {
+	cutoff := strconv.FormatInt(time.Now().Add(-time.Duration(defaultTaskRunningExpiration)*time.Second).Unix(), 10)
+
+	var running int64
+	for i := len(types.TaskPriorities) - 1; i >= 0; i-- {
+		key := Keys.taskQueueRunningPriority(workspaceName, stubId, types.TaskPriorities[i])
+
+		if err := qc.rdb.ZRemRangeByScore(ctx, key, "-inf", "("+cutoff).Err(); err != nil {
+			return 0, err
+		}
+
+		count, err := qc.rdb.ZCard(ctx, key).Result()
+		if err != nil {
+			return 0, err
+		}
+
+		running += count
+		if types.TaskPriorities[i] == priority {
+			break
+		}
+	}
+
+	return running, nil
+}
+
+// RefreshRunningTask keeps a running task counted against its priority's limit
+func (qc *taskQueueClient) RefreshRunningTask(ctx context.Context, workspaceName, stubId, taskId string) error {
+	for _, priority := range types.TaskPriorities {
+		err := qc.rdb.ZAddArgs(ctx, Keys.taskQueueRunningPriority(workspaceName, stubId, priority), redis.ZAddArgs{
+			XX:      true,
+			Members: []redis.Z{{Score: float64(time.Now().Unix()), Member: taskId}},
+		}).Err()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RemoveRunningTask stops counting a task against its priority's limit
+func (qc *taskQueueClient) RemoveRunningTask(ctx context.Context, workspaceName, stubId, taskId string) error {
+	for _, priority := range types.TaskPriorities {
+		err := qc.rdb.ZRem(ctx, Keys.taskQueueRunningPriority(workspaceName, stubId, priority), taskId).Err()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Get queue length, across every priority
 func (qc *taskQueueClient) QueueLength(ctx context.Context, workspaceName, stubId string) (int64, error) {
-	res, err := qc.rdb.LLen(ctx, Keys.taskQueueList(workspaceName, stubId)).Result()
-	if err != nil {
-		return -1, err
+	var length int64
+	for _, priority := range types.TaskPriorities {
+		res, err := qc.rdb.LLen(ctx, Keys.taskQueuePriorityList(workspaceName, stubId, priority)).Result()
+		if err != nil {
+			return -1, err
+		}
+
+		length += res
 	}
 
-	return res, nil
+	return length, nil
 }
 
 // Check how many tasks are running (which is the same as the number of tasks "claimed")
@@ -26,6 +26,7 @@ func registerTaskQueueRoutes(g *echo.Group, tq *RedisTaskQueue) *taskQueueGroup
 	g.POST("/:deploymentName/latest", auth.WithAuth(group.TaskQueuePut))
 	g.POST("/:deploymentName/v:version", auth.WithAuth(group.TaskQueuePut))
 	g.POST("/public/:stubId", auth.WithAssumedStubAuth(group.TaskQueuePut, group.tq.isPublic))
+	g.POST("/trigger/:triggerId", group.TaskQueueTrigger)
 
 	g.POST("/id/:stubId/warmup", auth.WithAuth(group.TaskQueueWarmUp))
 	g.POST("/:deploymentName/warmup", auth.WithAuth(group.TaskQueueWarmUp))
@@ -59,7 +60,14 @@ func (g *taskQueueGroup) TaskQueuePut(ctx echo.Context) error {
 		})
 	}
 
-	taskId, err := g.tq.put(ctx.Request().Context(), cc.AuthInfo, stubId, payload)
+	priority, err := types.ParseTaskPriority(ctx.QueryParam("priority"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	taskId, err := g.tq.put(ctx.Request().Context(), cc.AuthInfo, stubId, payload, priority)
 	if err != nil {
 		if _, ok := err.(*types.ErrExceededTaskLimit); ok {
 			return ctx.JSON(http.StatusTooManyRequests, map[string]interface{}{
@@ -67,6 +75,44 @@ func (g *taskQueueGroup) TaskQueuePut(ctx echo.Context) error {
 			})
 		}
 
+		if _, ok := err.(*types.ErrWorkspacePolicyViolation); ok {
+			return ctx.JSON(http.StatusForbidden, map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]interface{}{
+		"task_id": taskId,
+	})
+}
+
+// TaskQueueTrigger puts a task on the queue a task trigger points at, for webhooks from external services.
+// Deliveries are authenticated by their signature rather than a token.
+func (g *taskQueueGroup) TaskQueueTrigger(ctx echo.Context) error {
+	request, err := abstractions.ParseTaskTriggerRequest(ctx, g.tq.config.GatewayService.TaskTriggers, g.cache, types.StubTypeTaskQueueDeployment, g.tq.backendRepo)
+	if err != nil {
+		return err
+	}
+
+	taskId, err := g.tq.put(ctx.Request().Context(), request.AuthInfo, request.StubId, request.Payload, "")
+	if err != nil {
+		if _, ok := err.(*types.ErrExceededTaskLimit); ok {
+			return ctx.JSON(http.StatusTooManyRequests, map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+
+		if _, ok := err.(*types.ErrWorkspacePolicyViolation); ok {
+			return ctx.JSON(http.StatusForbidden, map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+
 		return ctx.JSON(http.StatusInternalServerError, map[string]interface{}{
 			"error": err.Error(),
 		})
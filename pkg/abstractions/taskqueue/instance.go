@@ -31,7 +31,7 @@ type taskQueueInstance struct {
 }
 
 func (i *taskQueueInstance) startContainers(containersToRun int) error {
-	secrets, err := abstractions.ConfigureContainerRequestSecrets(i.Workspace, *i.StubConfig)
+	secrets, secretMount, err := abstractions.ConfigureContainerRequestSecrets(i.Ctx, i.BackendRepo, i.Workspace, *i.StubConfig)
 	if err != nil {
 		return err
 	}
@@ -90,6 +90,11 @@ func (i *taskQueueInstance) startContainers(containersToRun int) error {
 			Memory:            i.StubConfig.Runtime.Memory,
 			GpuRequest:        gpuRequest,
 			GpuCount:          uint32(gpuCount),
+			GpuFraction:       i.StubConfig.Runtime.GpuFraction,
+			MigProfile:        i.StubConfig.Runtime.MigProfile,
+			Architectures:     i.StubConfig.Runtime.Architectures,
+			Affinity:          i.StubConfig.Affinity,
+			PriorityClass:     i.StubConfig.PriorityClass,
 			ImageId:           i.StubConfig.Runtime.ImageId,
 			StubId:            i.Stub.ExternalId,
 			AppId:             i.Stub.App.ExternalId,
@@ -99,6 +104,8 @@ func (i *taskQueueInstance) startContainers(containersToRun int) error {
 			Mounts:            mounts,
 			Stub:              *i.Stub,
 			CheckpointEnabled: checkpointEnabled,
+			Sidecars:          i.StubConfig.Sidecars,
+			SecretMount:       secretMount,
 		}
 
 		// Set initial keepwarm to prevent rapid spin-up/spin-down of containers
@@ -1,10 +1,16 @@
 package taskqueue
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/beam-cloud/beta9/pkg/types"
+)
 
 // Redis keys
 var (
 	taskQueueList                 string = "taskqueue:%s:%s"
+	taskQueuePriorityList         string = "taskqueue:%s:%s:priority:%s"
+	taskQueueRunningPriority      string = "taskqueue:%s:%s:running_priority:%s"
 	taskQueueInstanceLock         string = "taskqueue:%s:%s:instance_lock"
 	taskQueueTaskDuration         string = "taskqueue:%s:%s:task_duration"
 	taskQueueAverageTaskDuration  string = "taskqueue:%s:%s:avg_task_duration"
@@ -27,6 +33,20 @@ func (k *keys) taskQueueList(workspaceName, stubId string) string {
 	return fmt.Sprintf(taskQueueList, workspaceName, stubId)
 }
 
+// taskQueuePriorityList is the queue for tasks of one priority. Normal priority keeps using the original
+// queue key, so tasks queued before priorities existed are still dispatched.
+func (k *keys) taskQueuePriorityList(workspaceName, stubId string, priority types.TaskPriority) string {
+	if priority.OrDefault() == types.TaskPriorityNormal {
+		return k.taskQueueList(workspaceName, stubId)
+	}
+
+	return fmt.Sprintf(taskQueuePriorityList, workspaceName, stubId, priority)
+}
+
+func (k *keys) taskQueueRunningPriority(workspaceName, stubId string, priority types.TaskPriority) string {
+	return fmt.Sprintf(taskQueueRunningPriority, workspaceName, stubId, priority.OrDefault())
+}
+
 func (k *keys) taskQueueTaskHeartbeat(workspaceName, stubId, taskId string) string {
 	return fmt.Sprintf(taskQueueTaskHeartbeat, workspaceName, stubId, taskId)
 }
@@ -125,7 +125,7 @@ func NewRedisTaskQueueService(
 	tq.taskDispatcher.Register(string(types.ExecutorTaskQueue), tq.taskQueueTaskFactory)
 
 	// Register HTTP routes
-	authMiddleware := auth.AuthMiddleware(opts.BackendRepo, opts.WorkspaceRepo)
+	authMiddleware := auth.AuthMiddleware(opts.BackendRepo, opts.WorkspaceRepo, opts.Config.GatewayService.IPAllowList)
 	registerTaskQueueRoutes(opts.RouteGroup.Group(taskQueueRoutePrefix, authMiddleware), tq)
 
 	return tq, nil
@@ -173,7 +173,7 @@ func (tq *RedisTaskQueue) getStubConfig(stubId string) (*types.StubConfigV1, err
 	return config, nil
 }
 
-func (tq *RedisTaskQueue) put(ctx context.Context, authInfo *auth.AuthInfo, stubId string, payload *types.TaskPayload) (string, error) {
+func (tq *RedisTaskQueue) put(ctx context.Context, authInfo *auth.AuthInfo, stubId string, payload *types.TaskPayload, priority types.TaskPriority) (string, error) {
 	instance, err := tq.getOrCreateQueueInstance(stubId)
 	if err != nil {
 		return "", err
@@ -189,6 +189,10 @@ func (tq *RedisTaskQueue) put(ctx context.Context, authInfo *auth.AuthInfo, stub
 	}
 
 	policy := instance.StubConfig.TaskPolicy
+	if priority != "" {
+		policy.Priority = priority
+	}
+
 	if policy.TTL == 0 {
 		// Required for backwards compatibility
 		policy.TTL = DefaultTaskQueueTaskTTL
@@ -218,7 +222,14 @@ func (tq *RedisTaskQueue) TaskQueuePut(ctx context.Context, in *pb.TaskQueuePutR
 		}, nil
 	}
 
-	taskId, err := tq.put(ctx, authInfo, in.StubId, &payload)
+	priority, err := types.ParseTaskPriority(in.Priority)
+	if err != nil {
+		return &pb.TaskQueuePutResponse{
+			Ok: false,
+		}, nil
+	}
+
+	taskId, err := tq.put(ctx, authInfo, in.StubId, &payload, priority)
 	return &pb.TaskQueuePutResponse{
 		Ok:     err == nil,
 		TaskId: taskId,
@@ -235,10 +246,12 @@ func (tq *RedisTaskQueue) TaskQueuePop(ctx context.Context, in *pb.TaskQueuePopR
 		}, nil
 	}
 
+	limits := tq.priorityLimits(instance)
+
 	// Retrieve the next "valid" task (not in a completed state) from the queue
 	task, msg := func() (*types.TaskWithRelated, []byte) {
 		for {
-			msg, err := instance.client.Pop(ctx, authInfo.Workspace.Name, in.StubId, in.ContainerId)
+			msg, err := instance.client.Pop(ctx, authInfo.Workspace.Name, in.StubId, in.ContainerId, limits)
 			if err != nil {
 				return nil, nil
 			}
@@ -262,6 +275,7 @@ func (tq *RedisTaskQueue) TaskQueuePop(ctx context.Context, in *pb.TaskQueuePopR
 			if t.Status.IsCompleted() {
 				instance.client.rdb.Del(ctx, Keys.taskQueueTaskRunningLock(authInfo.Workspace.Name, in.StubId, in.ContainerId, t.ExternalId))
 				instance.client.rdb.SRem(ctx, Keys.taskQueueTaskRunningLockIndex(authInfo.Workspace.Name, in.StubId, in.ContainerId), t.ExternalId)
+				instance.client.RemoveRunningTask(ctx, authInfo.Workspace.Name, in.StubId, t.ExternalId)
 				continue
 			}
 
@@ -283,6 +297,11 @@ func (tq *RedisTaskQueue) TaskQueuePop(ctx context.Context, in *pb.TaskQueuePopR
 	task.StartedAt = types.NullTime{}.Now()
 	task.Status = types.TaskStatusRunning
 
+	// Record the GPU type the scheduler picked from the stub's acceptable GPUs
+	if state, err := tq.containerRepo.GetContainerState(in.ContainerId); err == nil {
+		task.Gpu = state.Gpu
+	}
+
 	err = tq.rdb.SAdd(ctx, Keys.taskQueueTaskRunningLockIndex(authInfo.Workspace.Name, in.StubId, in.ContainerId), task.ExternalId).Err()
 	if err != nil {
 		return &pb.TaskQueuePopResponse{
@@ -309,6 +328,29 @@ func (tq *RedisTaskQueue) TaskQueuePop(ctx context.Context, in *pb.TaskQueuePopR
 	}, nil
 }
 
+// priorityLimits works out how many tasks at or below each priority may run at once for a queue, holding
+// back the configured share of its task slots for higher priorities. Each priority keeps at least one slot.
+func (tq *RedisTaskQueue) priorityLimits(instance *taskQueueInstance) map[types.TaskPriority]int64 {
+	if instance.StubConfig.Autoscaler == nil {
+		return nil
+	}
+
+	containers := min(int64(instance.StubConfig.Autoscaler.MaxContainers), int64(tq.config.GatewayService.StubLimits.MaxReplicas))
+	slots := containers * int64(max(instance.StubConfig.Workers, 1))
+	if slots <= 0 {
+		return nil
+	}
+
+	reservations := tq.config.Abstractions.TaskQueue
+	highReserved := slots * int64(reservations.HighPriorityReservedPercent) / 100
+	normalReserved := slots * int64(reservations.NormalPriorityReservedPercent) / 100
+
+	return map[types.TaskPriority]int64{
+		types.TaskPriorityNormal: max(slots-highReserved, 1),
+		types.TaskPriorityLow:    max(slots-highReserved-normalReserved, 1),
+	}
+}
+
 func (tq *RedisTaskQueue) TaskQueueComplete(ctx context.Context, in *pb.TaskQueueCompleteRequest) (*pb.TaskQueueCompleteResponse, error) {
 	authInfo, _ := auth.AuthInfoFromContext(ctx)
 
@@ -349,6 +391,13 @@ func (tq *RedisTaskQueue) TaskQueueComplete(ctx context.Context, in *pb.TaskQueu
 		}, nil
 	}
 
+	err = tq.queueClient.RemoveRunningTask(ctx, authInfo.Workspace.Name, in.StubId, in.TaskId)
+	if err != nil {
+		return &pb.TaskQueueCompleteResponse{
+			Ok: false,
+		}, nil
+	}
+
 	err = tq.rdb.RPush(ctx, Keys.taskQueueTaskDuration(authInfo.Workspace.Name, in.StubId), in.TaskDuration).Err()
 	if err != nil {
 		return &pb.TaskQueueCompleteResponse{
@@ -385,6 +434,7 @@ func (tq *RedisTaskQueue) TaskQueueComplete(ctx context.Context, in *pb.TaskQueu
 				instance.StubConfig.Pricing,
 				tq.usageMetricsRepo,
 				in.TaskId,
+				task.Gpu,
 				externalWorkspace.ExternalId,
 			)
 		}
@@ -549,6 +599,11 @@ func (tq *RedisTaskQueue) TaskQueueMonitor(req *pb.TaskQueueMonitorRequest, stre
 				return err
 			}
 
+			err = tq.queueClient.RefreshRunningTask(ctx, authInfo.Workspace.Name, req.StubId, task.ExternalId)
+			if err != nil {
+				return err
+			}
+
 			claimed, err := tq.taskRepo.IsClaimed(ctx, authInfo.Workspace.Name, req.StubId, task.ExternalId)
 			if err != nil {
 				return err
@@ -686,18 +741,23 @@ func (tq *RedisTaskQueue) retryTask(ctx context.Context, authInfo *auth.AuthInfo
 		}
 	}
 
-	msg := ""
-	if task.Message().Retries >= task.Message().Policy.MaxRetries {
-		msg = fmt.Sprintf("Exceeded retry limit of %d for task <%s>", task.Message().Policy.MaxRetries, task.Message().TaskId)
-	}
-
-	err = tq.taskDispatcher.RetryTask(ctx, task)
+	retries := task.Message().Retries
+	retried, err := tq.taskDispatcher.RetryTask(ctx, task, types.TaskFailure{
+		Reason:      types.TaskFailureRequested,
+		ContainerId: in.ContainerId,
+	})
 	if err != nil {
 		return &pb.TaskQueueCompleteResponse{
 			Ok: false,
 		}
 	}
 
+	// The limit may come from the stub's retry policy, so report the attempts made rather than the limit
+	msg := ""
+	if !retried {
+		msg = fmt.Sprintf("Exceeded retry limit after %d retries for task <%s>", retries, task.Message().TaskId)
+	}
+
 	return &pb.TaskQueueCompleteResponse{
 		Ok:      true,
 		Message: msg,
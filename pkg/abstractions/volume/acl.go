@@ -0,0 +1,38 @@
+package volume
+
+import (
+	"context"
+	"errors"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+var errVolumeAccessDenied = errors.New("Unauthorized Access")
+
+// checkVolumeAccess returns an error unless the volume's ACL allows the caller the given access
+func (vs *GlobalVolumeService) checkVolumeAccess(ctx context.Context, authInfo *auth.AuthInfo, volume *types.Volume, access types.ResourceAccess) error {
+	acl, err := vs.backendRepo.ListAccessControlEntries(ctx, types.ACLResourceVolume, volume.Id)
+	if err != nil {
+		return errors.New("unable to check volume access")
+	}
+
+	if !auth.HasResourceAccess(authInfo, acl, access) {
+		return errVolumeAccessDenied
+	}
+
+	return nil
+}
+
+// checkPathAccess checks the ACL of the volume a path is in. Paths in volumes that don't exist are left for
+// the operation itself to reject.
+func (vs *GlobalVolumeService) checkPathAccess(ctx context.Context, authInfo *auth.AuthInfo, workspace *types.Workspace, inputPath string, access types.ResourceAccess) error {
+	volumeName, _ := parseVolumeInput(inputPath)
+
+	volume, err := vs.backendRepo.GetVolume(ctx, workspace.Id, volumeName)
+	if err != nil {
+		return nil
+	}
+
+	return vs.checkVolumeAccess(ctx, authInfo, volume, access)
+}
@@ -79,6 +79,8 @@ func (g *volumeGroup) CreateVolume(ctx echo.Context) error {
 }
 
 func (g *volumeGroup) UploadFile(ctx echo.Context) error {
+	cc, _ := ctx.(*auth.HttpAuthContext)
+
 	workspaceId := ctx.Param("workspaceId")
 	workspace, err := g.gvs.backendRepo.GetWorkspaceByExternalId(ctx.Request().Context(), workspaceId)
 	if err != nil {
@@ -122,6 +124,7 @@ func (g *volumeGroup) UploadFile(ctx echo.Context) error {
 		return g.gvs.copyPathStream(
 			ctx.Request().Context(),
 			ch,
+			cc.AuthInfo,
 			&workspace,
 		)
 	})
@@ -181,6 +184,10 @@ func (g *volumeGroup) DownloadFile(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid volume path")
 	}
 
+	if err := g.gvs.checkPathAccess(ctx.Request().Context(), cc.AuthInfo, &workspace, decodedVolumePath, types.ResourceAccessRead); err != nil {
+		return echo.NewHTTPError(http.StatusForbidden, err.Error())
+	}
+
 	if cc.AuthInfo.Workspace.StorageAvailable() {
 		presignedUrl, err := g.generatePresignedURL(
 			ctx.Request().Context(),
@@ -220,6 +227,10 @@ func (g *volumeGroup) Ls(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid volume path")
 	}
 
+	if err := g.gvs.checkPathAccess(ctx.Request().Context(), cc.AuthInfo, cc.AuthInfo.Workspace, decodedVolumePath, types.ResourceAccessRead); err != nil {
+		return echo.NewHTTPError(http.StatusForbidden, err.Error())
+	}
+
 	if paths, err := g.gvs.listPath(
 		ctx.Request().Context(),
 		decodedVolumePath,
@@ -246,6 +257,10 @@ func (g *volumeGroup) Rm(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid volume path")
 	}
 
+	if err := g.gvs.checkPathAccess(ctx.Request().Context(), cc.AuthInfo, cc.AuthInfo.Workspace, decodedVolumePath, types.ResourceAccessWrite); err != nil {
+		return echo.NewHTTPError(http.StatusForbidden, err.Error())
+	}
+
 	if _, err := g.gvs.deletePath(
 		ctx.Request().Context(),
 		decodedVolumePath,
@@ -276,6 +291,10 @@ func (g *volumeGroup) GenerateDownloadToken(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid volume path")
 	}
 
+	if err := g.gvs.checkPathAccess(ctx.Request().Context(), cc.AuthInfo, workspace, decodedVolumePath, types.ResourceAccessRead); err != nil {
+		return echo.NewHTTPError(http.StatusForbidden, err.Error())
+	}
+
 	token, err := g.gvs.GenerateWorkspaceVolumePathDownloadToken(workspaceId, decodedVolumePath)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate download token")
@@ -303,6 +322,10 @@ func (g *volumeGroup) GetDownloadURL(ctx echo.Context) error {
 		return g.GenerateDownloadToken(ctx)
 	}
 
+	if err := g.gvs.checkPathAccess(ctx.Request().Context(), cc.AuthInfo, workspace, decodedVolumePath, types.ResourceAccessRead); err != nil {
+		return echo.NewHTTPError(http.StatusForbidden, err.Error())
+	}
+
 	url, err := g.generatePresignedURL(ctx.Request().Context(), workspace, decodedVolumePath, http.MethodGet)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate presigned URL")
@@ -334,6 +357,10 @@ func (g *volumeGroup) GetUploadURL(ctx echo.Context) error {
 		return g.GenerateDownloadToken(ctx)
 	}
 
+	if err := g.gvs.checkPathAccess(ctx.Request().Context(), cc.AuthInfo, workspace, decodedVolumePath, types.ResourceAccessWrite); err != nil {
+		return echo.NewHTTPError(http.StatusForbidden, err.Error())
+	}
+
 	url, err := g.generatePresignedURL(ctx.Request().Context(), workspace, decodedVolumePath, http.MethodPut)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate presigned URL")
@@ -68,6 +68,29 @@ func (s *GlobalVolumeService) CreatePresignedURL(ctx context.Context, in *pb.Cre
 		}, nil
 	}
 
+	access := types.ResourceAccessRead
+	if in.Method == pb.PresignedURLMethod_PutObject || in.Method == pb.PresignedURLMethod_UploadPart {
+		access = types.ResourceAccessWrite
+	}
+
+	if err := s.checkVolumeAccess(ctx, authInfo, volume, access); err != nil {
+		return &pb.CreatePresignedURLResponse{
+			Ok:     false,
+			ErrMsg: err.Error(),
+		}, nil
+	}
+
+	// Uploads signed for a fixed length are held to the quota up front; multipart parts were accounted for when
+	// the upload was created
+	if in.Method == pb.PresignedURLMethod_PutObject && in.Params != nil {
+		if msg := s.checkUploadQuota(ctx, authInfo.Workspace, volume, int64(in.Params.ContentLength)); msg != "" {
+			return &pb.CreatePresignedURLResponse{
+				Ok:     false,
+				ErrMsg: msg,
+			}, nil
+		}
+	}
+
 	var s3Client *s3.Client
 	var presignClient *s3.PresignClient
 
@@ -83,6 +106,13 @@ func (s *GlobalVolumeService) CreatePresignedURL(ctx context.Context, in *pb.Cre
 			}, nil
 		}
 
+		if !storageClient.S3Compatible() {
+			return &pb.CreatePresignedURLResponse{
+				Ok:     false,
+				ErrMsg: clients.ErrStorageOperationUnsupported.Error(),
+			}, nil
+		}
+
 		s3Client = storageClient.S3Client()
 		presignClient = storageClient.PresignClient()
 		key = joinCleanPath(types.DefaultVolumesPrefix, volume.ExternalId, in.VolumePath)
@@ -175,6 +205,20 @@ func (s *GlobalVolumeService) CreateMultipartUpload(ctx context.Context, in *pb.
 		}, nil
 	}
 
+	if err := s.checkVolumeAccess(ctx, authInfo, volume, types.ResourceAccessWrite); err != nil {
+		return &pb.CreateMultipartUploadResponse{
+			Ok:     false,
+			ErrMsg: err.Error(),
+		}, nil
+	}
+
+	if msg := s.checkUploadQuota(ctx, authInfo.Workspace, volume, int64(in.FileSize)); msg != "" {
+		return &pb.CreateMultipartUploadResponse{
+			Ok:     false,
+			ErrMsg: msg,
+		}, nil
+	}
+
 	var s3Client *s3.Client
 	bucket := s.config.BucketName
 	key := joinCleanPath(types.DefaultVolumesPrefix, authInfo.Workspace.Name, volume.ExternalId, in.VolumePath)
@@ -188,6 +232,13 @@ func (s *GlobalVolumeService) CreateMultipartUpload(ctx context.Context, in *pb.
 			}, nil
 		}
 
+		if !storageClient.S3Compatible() {
+			return &pb.CreateMultipartUploadResponse{
+				Ok:     false,
+				ErrMsg: clients.ErrStorageOperationUnsupported.Error(),
+			}, nil
+		}
+
 		s3Client = storageClient.S3Client()
 		bucket = storageClient.BucketName()
 		key = joinCleanPath(types.DefaultVolumesPrefix, volume.ExternalId, in.VolumePath)
@@ -279,6 +330,13 @@ func (s *GlobalVolumeService) CompleteMultipartUpload(ctx context.Context, in *p
 			}, nil
 		}
 
+		if !storageClient.S3Compatible() {
+			return &pb.CompleteMultipartUploadResponse{
+				Ok:     false,
+				ErrMsg: clients.ErrStorageOperationUnsupported.Error(),
+			}, nil
+		}
+
 		s3Client = storageClient.S3Client()
 		bucket = storageClient.BucketName()
 		key = joinCleanPath(types.DefaultVolumesPrefix, volume.ExternalId, in.VolumePath)
@@ -341,6 +399,13 @@ func (s *GlobalVolumeService) AbortMultipartUpload(ctx context.Context, in *pb.A
 			}, nil
 		}
 
+		if !storageClient.S3Compatible() {
+			return &pb.AbortMultipartUploadResponse{
+				Ok:     false,
+				ErrMsg: clients.ErrStorageOperationUnsupported.Error(),
+			}, nil
+		}
+
 		s3Client = storageClient.S3Client()
 		bucket = storageClient.BucketName()
 		key = joinCleanPath(types.DefaultVolumesPrefix, volume.ExternalId, in.VolumePath)
@@ -364,3 +429,17 @@ func (s *GlobalVolumeService) AbortMultipartUpload(ctx context.Context, in *pb.A
 		Ok: true,
 	}, nil
 }
+
+// checkUploadQuota returns an error message if an upload of size bytes wouldn't fit the volume's quotas
+func (s *GlobalVolumeService) checkUploadQuota(ctx context.Context, workspace *types.Workspace, volume *types.Volume, size int64) string {
+	quota, err := s.volumeQuota(ctx, workspace, volume)
+	if err != nil {
+		return "Unable to check volume quota"
+	}
+
+	if err := quota.Reserve(size); err != nil {
+		return volumeQuotaExceededErrMessage
+	}
+
+	return ""
+}
@@ -0,0 +1,291 @@
+package volume
+
+import (
+	"context"
+	"io"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/clients"
+	"github.com/beam-cloud/beta9/pkg/repository"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+	"github.com/rs/zerolog/log"
+)
+
+const volumeQuotaExceededErrMessage = "Volume storage quota exceeded"
+
+// VolumeQuota tracks how many more bytes may be written into a volume before either the volume's own quota
+// or its workspace's quota across all volumes is exceeded. Writers reserve bytes as content arrives.
+type VolumeQuota struct {
+	mu        sync.Mutex
+	limited   bool
+	remaining int64
+	exceeded  types.ErrVolumeQuotaExceeded
+}
+
+// Reserve accounts for n more bytes, returning *types.ErrVolumeQuotaExceeded if they don't fit
+func (q *VolumeQuota) Reserve(n int64) error {
+	if q == nil {
+		return nil
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if !q.limited {
+		return nil
+	}
+
+	if q.remaining < n {
+		err := q.exceeded
+		return &err
+	}
+
+	q.remaining -= n
+	return nil
+}
+
+// Release gives back n bytes, e.g. those of a file that is being replaced
+func (q *VolumeQuota) Release(n int64) {
+	if q == nil {
+		return
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.remaining += n
+}
+
+func (q *VolumeQuota) Limited() bool {
+	return q != nil && q.limited
+}
+
+// Reader wraps r so that reading past the remaining quota fails
+func (q *VolumeQuota) Reader(r io.Reader) io.Reader {
+	if !q.Limited() {
+		return r
+	}
+
+	return &quotaReader{r: r, quota: q}
+}
+
+// tighten lowers the allowance to quota-used if that's less than what's currently allowed
+func (q *VolumeQuota) tighten(workspace bool, used, quota int64) {
+	if quota <= 0 {
+		return
+	}
+
+	remaining := quota - used
+	if q.limited && remaining >= q.remaining {
+		return
+	}
+
+	q.limited = true
+	q.remaining = remaining
+	q.exceeded = types.ErrVolumeQuotaExceeded{Workspace: workspace, UsedBytes: used, QuotaBytes: quota}
+}
+
+type quotaReader struct {
+	r     io.Reader
+	quota *VolumeQuota
+}
+
+func (r *quotaReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if n > 0 {
+		if qErr := r.quota.Reserve(int64(n)); qErr != nil {
+			return n, qErr
+		}
+	}
+
+	return n, err
+}
+
+// WorkspaceVolumeQuotaBytes returns the limit on the total size of a workspace's volumes, 0 if unlimited.
+// A workspace override takes precedence over the gateway default.
+func WorkspaceVolumeQuotaBytes(ctx context.Context, backendRepo repository.BackendRepository, defaultQuota int64, workspace *types.Workspace) (int64, error) {
+	override, err := backendRepo.GetWorkspaceVolumeQuota(ctx, workspace.Id)
+	if err != nil {
+		return 0, err
+	}
+
+	quota := defaultQuota
+	if override != nil {
+		quota = *override
+	}
+	if quota < 0 {
+		quota = 0
+	}
+
+	return quota, nil
+}
+
+// NewVolumeQuota returns the allowance for writing into volume. Usage is only measured for the quotas that are
+// set, so unlimited volumes don't pay for walking their contents on every write. A nil volume only applies the
+// workspace's quota.
+func NewVolumeQuota(ctx context.Context, backendRepo repository.BackendRepository, defaultQuota int64, workspace *types.Workspace, volume *types.Volume) (*VolumeQuota, error) {
+	q := &VolumeQuota{}
+
+	if volume != nil && volume.QuotaBytes > 0 {
+		usage, err := GetVolumeUsage(ctx, workspace, volume.ExternalId)
+		if err != nil {
+			return nil, err
+		}
+		q.tighten(false, usage.UsedBytes, volume.QuotaBytes)
+	}
+
+	workspaceQuota, err := WorkspaceVolumeQuotaBytes(ctx, backendRepo, defaultQuota, workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	if workspaceQuota > 0 {
+		usage, err := GetWorkspaceVolumeUsage(ctx, workspace)
+		if err != nil {
+			return nil, err
+		}
+		q.tighten(true, usage.UsedBytes, workspaceQuota)
+	}
+
+	return q, nil
+}
+
+// GetVolumeUsage returns the bytes and files currently stored in a volume
+func GetVolumeUsage(ctx context.Context, workspace *types.Workspace, volumeExternalId string) (*types.VolumeUsage, error) {
+	return usage(ctx, workspace, volumeStoragePrefix(volumeExternalId), JoinVolumePath(workspace.Name, volumeExternalId))
+}
+
+// GetWorkspaceVolumeUsage returns the bytes and files stored across all of a workspace's volumes
+func GetWorkspaceVolumeUsage(ctx context.Context, workspace *types.Workspace) (*types.VolumeUsage, error) {
+	return usage(ctx, workspace, types.DefaultVolumesPrefix+"/", path.Join(types.DefaultVolumesPath, workspace.Name))
+}
+
+func usage(ctx context.Context, workspace *types.Workspace, storagePrefix, localDir string) (*types.VolumeUsage, error) {
+	if workspace.StorageAvailable() {
+		storageClient, err := clients.NewWorkspaceStorageClient(ctx, workspace.Name, workspace.Storage)
+		if err != nil {
+			return nil, err
+		}
+
+		objects, err := storageClient.ListWithPrefix(ctx, storagePrefix)
+		if err != nil {
+			return nil, err
+		}
+
+		usage := &types.VolumeUsage{}
+		for _, obj := range objects {
+			if obj.Key == nil || strings.HasSuffix(*obj.Key, "/") {
+				continue
+			}
+
+			usage.FileCount++
+			if obj.Size != nil {
+				usage.UsedBytes += *obj.Size
+			}
+		}
+
+		return usage, nil
+	}
+
+	size, fileCount, err := dirUsage(localDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.VolumeUsage{UsedBytes: int64(size), FileCount: int64(fileCount)}, nil
+}
+
+func (vs *GlobalVolumeService) volumeQuota(ctx context.Context, workspace *types.Workspace, volume *types.Volume) (*VolumeQuota, error) {
+	return NewVolumeQuota(ctx, vs.backendRepo, vs.storageConfig.VolumeQuotaBytes, workspace, volume)
+}
+
+func (vs *GlobalVolumeService) GetVolumeUsage(ctx context.Context, in *pb.GetVolumeUsageRequest) (*pb.GetVolumeUsageResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	resp := &pb.GetVolumeUsageResponse{Ok: true}
+
+	if in.Name != "" {
+		volume, err := vs.backendRepo.GetVolume(ctx, authInfo.Workspace.Id, in.Name)
+		if err != nil {
+			return &pb.GetVolumeUsageResponse{
+				Ok:     false,
+				ErrMsg: "Unable to find volume",
+			}, nil
+		}
+
+		usage, err := GetVolumeUsage(ctx, authInfo.Workspace, volume.ExternalId)
+		if err != nil {
+			log.Error().Err(err).Str("volume_id", volume.ExternalId).Msg("failed to get volume usage")
+			return &pb.GetVolumeUsageResponse{
+				Ok:     false,
+				ErrMsg: "Unable to get volume usage",
+			}, nil
+		}
+
+		resp.UsedBytes = usage.UsedBytes
+		resp.FileCount = usage.FileCount
+		resp.QuotaBytes = volume.QuotaBytes
+	}
+
+	usage, err := GetWorkspaceVolumeUsage(ctx, authInfo.Workspace)
+	if err != nil {
+		log.Error().Err(err).Str("workspace_id", authInfo.Workspace.ExternalId).Msg("failed to get workspace volume usage")
+		return &pb.GetVolumeUsageResponse{
+			Ok:     false,
+			ErrMsg: "Unable to get volume usage",
+		}, nil
+	}
+
+	quota, err := WorkspaceVolumeQuotaBytes(ctx, vs.backendRepo, vs.storageConfig.VolumeQuotaBytes, authInfo.Workspace)
+	if err != nil {
+		return &pb.GetVolumeUsageResponse{
+			Ok:     false,
+			ErrMsg: "Unable to get volume quota",
+		}, nil
+	}
+
+	resp.WorkspaceUsedBytes = usage.UsedBytes
+	resp.WorkspaceFileCount = usage.FileCount
+	resp.WorkspaceQuotaBytes = quota
+
+	return resp, nil
+}
+
+func (vs *GlobalVolumeService) SetVolumeQuota(ctx context.Context, in *pb.SetVolumeQuotaRequest) (*pb.SetVolumeQuotaResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionWrite) {
+		return &pb.SetVolumeQuotaResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	if in.QuotaBytes < 0 {
+		return &pb.SetVolumeQuotaResponse{
+			Ok:     false,
+			ErrMsg: "Quota must not be negative",
+		}, nil
+	}
+
+	volume, err := vs.backendRepo.GetVolume(ctx, authInfo.Workspace.Id, in.Name)
+	if err != nil {
+		return &pb.SetVolumeQuotaResponse{
+			Ok:     false,
+			ErrMsg: "Unable to find volume",
+		}, nil
+	}
+
+	if err := vs.backendRepo.SetVolumeQuota(ctx, volume.Id, in.QuotaBytes); err != nil {
+		return &pb.SetVolumeQuotaResponse{
+			Ok:     false,
+			ErrMsg: "Unable to set volume quota",
+		}, nil
+	}
+
+	return &pb.SetVolumeQuotaResponse{Ok: true}, nil
+}
@@ -0,0 +1,58 @@
+package volume
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/beam-cloud/beta9/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVolumeQuotaUnlimited(t *testing.T) {
+	q := &VolumeQuota{}
+	assert.False(t, q.Limited())
+	assert.NoError(t, q.Reserve(1<<40))
+}
+
+func TestVolumeQuotaTightestWins(t *testing.T) {
+	q := &VolumeQuota{}
+	q.tighten(false, 60, 100)
+	q.tighten(true, 900, 1000)
+	q.tighten(true, 0, 0)
+
+	assert.True(t, q.Limited())
+	assert.NoError(t, q.Reserve(40))
+
+	err := q.Reserve(1)
+	var quotaErr *types.ErrVolumeQuotaExceeded
+	assert.True(t, errors.As(err, &quotaErr))
+	assert.False(t, quotaErr.Workspace)
+	assert.Equal(t, int64(100), quotaErr.QuotaBytes)
+
+	q.Release(10)
+	assert.NoError(t, q.Reserve(10))
+}
+
+func TestVolumeQuotaOverQuota(t *testing.T) {
+	q := &VolumeQuota{}
+	q.tighten(true, 120, 100)
+
+	assert.Error(t, q.Reserve(0))
+}
+
+func TestVolumeQuotaReader(t *testing.T) {
+	q := &VolumeQuota{}
+	q.tighten(false, 0, 8)
+
+	_, err := io.Copy(io.Discard, q.Reader(bytes.NewReader(make([]byte, 16))))
+	assert.Error(t, err)
+
+	q = &VolumeQuota{}
+	q.tighten(false, 0, 16)
+
+	n, err := io.Copy(io.Discard, q.Reader(bytes.NewReader(make([]byte, 16))))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(16), n)
+}
@@ -0,0 +1,112 @@
+package volume
+
+import (
+	"context"
+	"time"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ShareVolume grants (or revokes) another workspace read-only access to one of the caller's volumes
+func (vs *GlobalVolumeService) ShareVolume(ctx context.Context, in *pb.ShareVolumeRequest) (*pb.ShareVolumeResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionWrite) {
+		return &pb.ShareVolumeResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	volume, err := vs.backendRepo.GetVolume(ctx, authInfo.Workspace.Id, in.Name)
+	if err != nil {
+		return &pb.ShareVolumeResponse{
+			Ok:     false,
+			ErrMsg: "Unable to find volume",
+		}, nil
+	}
+
+	grantee, err := vs.backendRepo.GetWorkspaceByExternalId(ctx, in.GranteeWorkspaceId)
+	if err != nil {
+		return &pb.ShareVolumeResponse{
+			Ok:     false,
+			ErrMsg: "Unable to find grantee workspace",
+		}, nil
+	}
+
+	if grantee.Id == authInfo.Workspace.Id {
+		return &pb.ShareVolumeResponse{
+			Ok:     false,
+			ErrMsg: "Volumes can't be shared with their own workspace",
+		}, nil
+	}
+
+	if in.Revoke {
+		if err := vs.backendRepo.DeleteResourceGrant(ctx, authInfo.Workspace.Id, grantee.Id, types.ResourceGrantVolume, volume.ExternalId); err != nil {
+			return &pb.ShareVolumeResponse{
+				Ok:     false,
+				ErrMsg: "Unable to revoke volume share",
+			}, nil
+		}
+
+		return &pb.ShareVolumeResponse{
+			Ok:       true,
+			VolumeId: volume.ExternalId,
+		}, nil
+	}
+
+	// Shared volumes are mounted from the owner's path on the shared volume filesystem, which
+	// workspace storage volumes don't live on
+	if authInfo.Workspace.StorageAvailable() {
+		return &pb.ShareVolumeResponse{
+			Ok:     false,
+			ErrMsg: "Sharing isn't supported for volumes in workspace storage",
+		}, nil
+	}
+
+	var expiresAt *time.Time
+	if in.ExpiresInSeconds > 0 {
+		t := time.Now().Add(time.Duration(in.ExpiresInSeconds) * time.Second)
+		expiresAt = &t
+	}
+
+	share, err := vs.backendRepo.CreateResourceGrant(ctx, authInfo.Workspace.Id, grantee.Id, types.ResourceGrantVolume, volume.ExternalId, expiresAt)
+	if err != nil {
+		log.Error().Err(err).Str("volume_id", volume.ExternalId).Msg("failed to share volume")
+		return &pb.ShareVolumeResponse{
+			Ok:     false,
+			ErrMsg: "Unable to share volume",
+		}, nil
+	}
+
+	resp := &pb.ShareVolumeResponse{
+		Ok:       true,
+		VolumeId: volume.ExternalId,
+	}
+	if share.ExpiresAt.Valid {
+		resp.ExpiresAt = timestamppb.New(share.ExpiresAt.Time)
+	}
+
+	return resp, nil
+}
+
+func sharedVolumeToProto(v *types.SharedVolume) *pb.VolumeInstance {
+	instance := &pb.VolumeInstance{
+		Id:            v.ExternalId,
+		Name:          v.Name,
+		CreatedAt:     timestamppb.New(v.CreatedAt.Time),
+		UpdatedAt:     timestamppb.New(v.UpdatedAt.Time),
+		WorkspaceId:   v.Workspace.ExternalId,
+		WorkspaceName: v.Workspace.Name,
+		Shared:        true,
+	}
+	if v.ExpiresAt.Valid {
+		instance.ShareExpiresAt = timestamppb.New(v.ExpiresAt.Time)
+	}
+
+	return instance
+}
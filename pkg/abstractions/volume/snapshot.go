@@ -0,0 +1,415 @@
+package volume
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/clients"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Max number of objects copied at once when snapshotting or restoring a volume in workspace storage
+const snapshotCopyConcurrency = 16
+
+func (vs *GlobalVolumeService) SnapshotVolume(ctx context.Context, in *pb.SnapshotVolumeRequest) (*pb.SnapshotVolumeResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionWrite) {
+		return &pb.SnapshotVolumeResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	volume, err := vs.backendRepo.GetVolume(ctx, authInfo.Workspace.Id, in.Name)
+	if err != nil {
+		return &pb.SnapshotVolumeResponse{
+			Ok:     false,
+			ErrMsg: "Unable to find volume",
+		}, nil
+	}
+
+	snapshot, err := vs.snapshotVolume(ctx, authInfo.Workspace, volume, in.SnapshotName)
+	if err != nil {
+		log.Error().Err(err).Str("volume_id", volume.ExternalId).Msg("failed to snapshot volume")
+		return &pb.SnapshotVolumeResponse{
+			Ok:     false,
+			ErrMsg: "Unable to snapshot volume",
+		}, nil
+	}
+
+	return &pb.SnapshotVolumeResponse{
+		Ok:       true,
+		Snapshot: snapshotToProto(volume, snapshot),
+	}, nil
+}
+
+func (vs *GlobalVolumeService) ListSnapshots(ctx context.Context, in *pb.ListSnapshotsRequest) (*pb.ListSnapshotsResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	volume, err := vs.backendRepo.GetVolume(ctx, authInfo.Workspace.Id, in.Name)
+	if err != nil {
+		return &pb.ListSnapshotsResponse{
+			Ok:     false,
+			ErrMsg: "Unable to find volume",
+		}, nil
+	}
+
+	snapshots, err := vs.backendRepo.ListVolumeSnapshots(ctx, volume.Id)
+	if err != nil {
+		return &pb.ListSnapshotsResponse{
+			Ok:     false,
+			ErrMsg: "Unable to list snapshots",
+		}, nil
+	}
+
+	pbSnapshots := make([]*pb.VolumeSnapshot, len(snapshots))
+	for i := range snapshots {
+		pbSnapshots[i] = snapshotToProto(volume, &snapshots[i])
+	}
+
+	return &pb.ListSnapshotsResponse{
+		Ok:        true,
+		Snapshots: pbSnapshots,
+	}, nil
+}
+
+func (vs *GlobalVolumeService) RestoreVolume(ctx context.Context, in *pb.RestoreVolumeRequest) (*pb.RestoreVolumeResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionWrite) {
+		return &pb.RestoreVolumeResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	volume, err := vs.backendRepo.GetVolume(ctx, authInfo.Workspace.Id, in.Name)
+	if err != nil {
+		return &pb.RestoreVolumeResponse{
+			Ok:     false,
+			ErrMsg: "Unable to find volume",
+		}, nil
+	}
+
+	snapshot, err := vs.backendRepo.GetVolumeSnapshot(ctx, volume.Id, in.SnapshotId)
+	if err != nil {
+		return &pb.RestoreVolumeResponse{
+			Ok:     false,
+			ErrMsg: "Unable to find snapshot",
+		}, nil
+	}
+
+	if err := vs.checkRestoreQuota(ctx, authInfo.Workspace, volume, snapshot); err != nil {
+		errMsg := "Unable to check volume quota"
+		var quotaErr *types.ErrVolumeQuotaExceeded
+		if errors.As(err, &quotaErr) {
+			errMsg = volumeQuotaExceededErrMessage
+		}
+
+		return &pb.RestoreVolumeResponse{
+			Ok:     false,
+			ErrMsg: errMsg,
+		}, nil
+	}
+
+	if err := vs.restoreVolume(ctx, authInfo.Workspace, volume, snapshot); err != nil {
+		log.Error().Err(err).Str("volume_id", volume.ExternalId).Str("snapshot_id", snapshot.ExternalId).Msg("failed to restore volume")
+		return &pb.RestoreVolumeResponse{
+			Ok:     false,
+			ErrMsg: "Unable to restore volume",
+		}, nil
+	}
+
+	return &pb.RestoreVolumeResponse{
+		Ok: true,
+	}, nil
+}
+
+func snapshotToProto(volume *types.Volume, snapshot *types.VolumeSnapshot) *pb.VolumeSnapshot {
+	return &pb.VolumeSnapshot{
+		Id:         snapshot.ExternalId,
+		VolumeName: volume.Name,
+		Name:       snapshot.Name,
+		Size:       snapshot.Size,
+		FileCount:  snapshot.FileCount,
+		CreatedAt:  timestamppb.New(snapshot.CreatedAt.Time),
+	}
+}
+
+// Snapshot business logic
+func volumeStoragePrefix(volumeExternalId string) string {
+	return path.Join(types.DefaultVolumesPrefix, volumeExternalId) + "/"
+}
+
+func snapshotStoragePrefix(volumeExternalId string, snapshotExternalId ...string) string {
+	return path.Join(append([]string{types.DefaultVolumeSnapshotsPrefix, volumeExternalId}, snapshotExternalId...)...) + "/"
+}
+
+func snapshotPath(workspaceName, volumeExternalId string, snapshotExternalId ...string) string {
+	return path.Join(append([]string{types.DefaultVolumeSnapshotsPath, workspaceName, volumeExternalId}, snapshotExternalId...)...)
+}
+
+// snapshotVolume copies a volume's contents aside and records the copy. Nothing is recorded if the copy fails.
+func (vs *GlobalVolumeService) snapshotVolume(ctx context.Context, workspace *types.Workspace, volume *types.Volume, name string) (*types.VolumeSnapshot, error) {
+	snapshot := &types.VolumeSnapshot{
+		ExternalId: uuid.New().String(),
+		VolumeId:   volume.Id,
+		Name:       name,
+	}
+
+	if workspace.StorageAvailable() {
+		storageClient, err := clients.NewWorkspaceStorageClient(ctx, workspace.Name, workspace.Storage)
+		if err != nil {
+			return nil, err
+		}
+
+		dstPrefix := snapshotStoragePrefix(volume.ExternalId, snapshot.ExternalId)
+		snapshot.Size, snapshot.FileCount, err = copyStoragePrefix(ctx, storageClient, volumeStoragePrefix(volume.ExternalId), dstPrefix)
+		if err != nil {
+			storageClient.DeleteWithPrefix(context.Background(), dstPrefix)
+			return nil, err
+		}
+	} else {
+		dst := snapshotPath(workspace.Name, volume.ExternalId, snapshot.ExternalId)
+		if err := os.MkdirAll(path.Dir(dst), os.FileMode(0755)); err != nil {
+			return nil, err
+		}
+
+		var err error
+		if err = clonePath(ctx, JoinVolumePath(workspace.Name, volume.ExternalId), dst); err == nil {
+			snapshot.Size, snapshot.FileCount, err = dirUsage(dst)
+		}
+		if err != nil {
+			os.RemoveAll(dst)
+			return nil, err
+		}
+	}
+
+	return vs.backendRepo.CreateVolumeSnapshot(ctx, snapshot)
+}
+
+// checkRestoreQuota checks that a snapshot's contents fit in place of the volume's current contents
+func (vs *GlobalVolumeService) checkRestoreQuota(ctx context.Context, workspace *types.Workspace, volume *types.Volume, snapshot *types.VolumeSnapshot) error {
+	quota, err := vs.volumeQuota(ctx, workspace, volume)
+	if err != nil {
+		return err
+	}
+
+	if !quota.Limited() {
+		return nil
+	}
+
+	current, err := GetVolumeUsage(ctx, workspace, volume.ExternalId)
+	if err != nil {
+		return err
+	}
+	quota.Release(current.UsedBytes)
+
+	return quota.Reserve(int64(snapshot.Size))
+}
+
+// restoreVolume replaces a volume's contents with a snapshot's. Local volumes are restored in place, so
+// containers that have the volume mounted see the restored files.
+func (vs *GlobalVolumeService) restoreVolume(ctx context.Context, workspace *types.Workspace, volume *types.Volume, snapshot *types.VolumeSnapshot) error {
+	if workspace.StorageAvailable() {
+		storageClient, err := clients.NewWorkspaceStorageClient(ctx, workspace.Name, workspace.Storage)
+		if err != nil {
+			return err
+		}
+
+		volumePrefix := volumeStoragePrefix(volume.ExternalId)
+		if _, err := storageClient.DeleteWithPrefix(ctx, volumePrefix); err != nil {
+			return err
+		}
+
+		_, _, err = copyStoragePrefix(ctx, storageClient, snapshotStoragePrefix(volume.ExternalId, snapshot.ExternalId), volumePrefix)
+		return err
+	}
+
+	src := snapshotPath(workspace.Name, volume.ExternalId, snapshot.ExternalId)
+	if _, err := os.Stat(src); err != nil {
+		return err
+	}
+
+	volumeDir := JoinVolumePath(workspace.Name, volume.ExternalId)
+	if err := os.MkdirAll(volumeDir, os.FileMode(0755)); err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(volumeDir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if err := os.RemoveAll(filepath.Join(volumeDir, entry.Name())); err != nil {
+			return err
+		}
+	}
+
+	entries, err = os.ReadDir(src)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if err := clonePath(ctx, filepath.Join(src, entry.Name()), filepath.Join(volumeDir, entry.Name())); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteSnapshots removes the copies behind all of a volume's snapshots
+func (vs *GlobalVolumeService) deleteSnapshots(ctx context.Context, workspace *types.Workspace, volume *types.Volume) error {
+	if workspace.StorageAvailable() {
+		storageClient, err := clients.NewWorkspaceStorageClient(ctx, workspace.Name, workspace.Storage)
+		if err != nil {
+			return err
+		}
+
+		_, err = storageClient.DeleteWithPrefix(ctx, snapshotStoragePrefix(volume.ExternalId))
+		return err
+	}
+
+	return os.RemoveAll(snapshotPath(workspace.Name, volume.ExternalId))
+}
+
+// copyStoragePrefix copies every object under srcPrefix to dstPrefix and returns the bytes and files copied
+func copyStoragePrefix(ctx context.Context, storageClient *clients.WorkspaceStorageClient, srcPrefix, dstPrefix string) (uint64, uint64, error) {
+	objects, err := storageClient.ListWithPrefix(ctx, srcPrefix)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(snapshotCopyConcurrency)
+
+	var size, fileCount uint64
+	for _, obj := range objects {
+		key := *obj.Key
+		if !strings.HasSuffix(key, "/") {
+			fileCount++
+			if obj.Size != nil {
+				size += uint64(*obj.Size)
+			}
+		}
+
+		g.Go(func() error {
+			return storageClient.CopyObject(gCtx, key, dstPrefix+strings.TrimPrefix(key, srcPrefix))
+		})
+	}
+
+	return size, fileCount, g.Wait()
+}
+
+// clonePath copies src to dst, which must not exist. On JuiceFS this is a metadata-only clone; elsewhere
+// the data is copied.
+func clonePath(ctx context.Context, src, dst string) error {
+	if juicefs, err := exec.LookPath("juicefs"); err == nil {
+		output, err := exec.CommandContext(ctx, juicefs, "clone", "--preserve", src, dst).CombinedOutput()
+		if err == nil {
+			return nil
+		}
+
+		log.Debug().Str("src", src).Str("output", string(output)).Err(err).Msg("juicefs clone failed, falling back to copy")
+		os.RemoveAll(dst)
+	}
+
+	return copyPath(src, dst)
+}
+
+// copyPath recursively copies a file or directory, keeping permissions and symlinks
+func copyPath(src, dst string) error {
+	return filepath.Walk(src, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		switch {
+		case info.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm())
+		case info.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(p)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case info.Mode().IsRegular():
+			return copyFile(p, target, info.Mode().Perm())
+		}
+
+		// Skip devices, sockets and pipes
+		return nil
+	})
+}
+
+func copyFile(src, dst string, perm os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
+
+// dirUsage returns the total size and number of regular files under a directory
+func dirUsage(dir string) (uint64, uint64, error) {
+	var size, fileCount uint64
+
+	err := filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+
+		size += uint64(info.Size())
+		fileCount++
+		return nil
+	})
+
+	return size, fileCount, err
+}
@@ -0,0 +1,48 @@
+package volume
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyPath(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "src")
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "data", "nested"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "data", "a.txt"), []byte("hello"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "data", "nested", "b.bin"), []byte("world!"), 0600))
+	require.NoError(t, os.Symlink("data/a.txt", filepath.Join(src, "link")))
+
+	dst := filepath.Join(t.TempDir(), "dst")
+	require.NoError(t, copyPath(src, dst))
+
+	content, err := os.ReadFile(filepath.Join(dst, "data", "nested", "b.bin"))
+	require.NoError(t, err)
+	assert.Equal(t, "world!", string(content))
+
+	info, err := os.Stat(filepath.Join(dst, "data", "nested", "b.bin"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	link, err := os.Readlink(filepath.Join(dst, "link"))
+	require.NoError(t, err)
+	assert.Equal(t, "data/a.txt", link)
+
+	size, fileCount, err := dirUsage(dst)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(11), size)
+	assert.Equal(t, uint64(2), fileCount)
+
+	// Copying onto an existing file fails rather than overwriting it
+	assert.Error(t, copyPath(filepath.Join(src, "data", "a.txt"), filepath.Join(dst, "data", "a.txt")))
+}
+
+func TestDirUsageMissingDir(t *testing.T) {
+	size, fileCount, err := dirUsage(filepath.Join(t.TempDir(), "missing"))
+	require.NoError(t, err)
+	assert.Zero(t, size)
+	assert.Zero(t, fileCount)
+}
@@ -0,0 +1,573 @@
+package volume
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/clients"
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	volumeTransferConcurrency      = 8
+	volumeTransferProgressInterval = time.Second
+	volumeTransferDialTimeout      = 10 * time.Second
+	volumeTransferDefaultRegion    = "us-east-1"
+)
+
+var errTransferPrivateEndpoint = errors.New("bucket endpoint resolves to a private address")
+
+// transferFile is a file copied between a volume and an external bucket, identified by its path relative to
+// the transfer's root on either side
+type transferFile struct {
+	Path    string
+	Size    int64
+	ModTime time.Time
+}
+
+func (vs *GlobalVolumeService) ExportVolume(in *pb.ExportVolumeRequest, stream pb.VolumeService_ExportVolumeServer) error {
+	ctx := stream.Context()
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionWrite) {
+		return stream.Send(&pb.VolumeTransferResponse{Ok: false, ErrMsg: "Unauthorized Access"})
+	}
+
+	volume, err := vs.backendRepo.GetVolume(ctx, authInfo.Workspace.Id, in.Name)
+	if err != nil {
+		return stream.Send(&pb.VolumeTransferResponse{Ok: false, ErrMsg: "Unable to find volume"})
+	}
+
+	bucket, err := vs.newTransferBucket(ctx, authInfo.Workspace, in.Target)
+	if err != nil {
+		return stream.Send(&pb.VolumeTransferResponse{Ok: false, ErrMsg: err.Error()})
+	}
+
+	root, err := newVolumeTransferRoot(ctx, authInfo.Workspace, volume, in.Path)
+	if err != nil {
+		return stream.Send(&pb.VolumeTransferResponse{Ok: false, ErrMsg: err.Error()})
+	}
+
+	files, err := root.list(ctx)
+	if err != nil {
+		log.Error().Err(err).Str("volume_id", volume.ExternalId).Msg("failed to list volume for export")
+		return stream.Send(&pb.VolumeTransferResponse{Ok: false, ErrMsg: "Unable to list volume"})
+	}
+
+	skipped := 0
+	if in.Incremental {
+		existing, err := bucket.list(ctx)
+		if err != nil {
+			return stream.Send(&pb.VolumeTransferResponse{Ok: false, ErrMsg: fmt.Sprintf("Unable to list bucket: %v", err)})
+		}
+		files, skipped = changedFiles(files, existing)
+	}
+
+	return runTransfer(ctx, stream, files, skipped, func(ctx context.Context, f transferFile) error {
+		r, err := root.open(ctx, f)
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+
+		return bucket.put(ctx, f, r)
+	})
+}
+
+func (vs *GlobalVolumeService) ImportVolume(in *pb.ImportVolumeRequest, stream pb.VolumeService_ImportVolumeServer) error {
+	ctx := stream.Context()
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionWrite) {
+		return stream.Send(&pb.VolumeTransferResponse{Ok: false, ErrMsg: "Unauthorized Access"})
+	}
+
+	volume, err := vs.backendRepo.GetVolume(ctx, authInfo.Workspace.Id, in.Name)
+	if err != nil {
+		return stream.Send(&pb.VolumeTransferResponse{Ok: false, ErrMsg: "Unable to find volume"})
+	}
+
+	bucket, err := vs.newTransferBucket(ctx, authInfo.Workspace, in.Target)
+	if err != nil {
+		return stream.Send(&pb.VolumeTransferResponse{Ok: false, ErrMsg: err.Error()})
+	}
+
+	root, err := newVolumeTransferRoot(ctx, authInfo.Workspace, volume, in.Path)
+	if err != nil {
+		return stream.Send(&pb.VolumeTransferResponse{Ok: false, ErrMsg: err.Error()})
+	}
+
+	files, err := bucket.list(ctx)
+	if err != nil {
+		return stream.Send(&pb.VolumeTransferResponse{Ok: false, ErrMsg: fmt.Sprintf("Unable to list bucket: %v", err)})
+	}
+
+	skipped := 0
+	if in.Incremental {
+		existing, err := root.list(ctx)
+		if err != nil {
+			log.Error().Err(err).Str("volume_id", volume.ExternalId).Msg("failed to list volume for import")
+			return stream.Send(&pb.VolumeTransferResponse{Ok: false, ErrMsg: "Unable to list volume"})
+		}
+		files, skipped = changedFiles(files, existing)
+	}
+
+	var total int64
+	for _, f := range files {
+		total += f.Size
+	}
+
+	quota, err := vs.volumeQuota(ctx, authInfo.Workspace, volume)
+	if err != nil {
+		return stream.Send(&pb.VolumeTransferResponse{Ok: false, ErrMsg: "Unable to check volume quota"})
+	}
+
+	// Listed sizes come from the source bucket and can't be trusted, so they only fail imports that can't fit
+	// early. The bytes actually read are what count against the quota.
+	if err := quota.Reserve(total); err != nil {
+		return stream.Send(&pb.VolumeTransferResponse{Ok: false, ErrMsg: volumeQuotaExceededErrMessage})
+	}
+	quota.Release(total)
+
+	return runTransfer(ctx, stream, files, skipped, func(ctx context.Context, f transferFile) error {
+		r, err := bucket.get(ctx, f)
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+
+		// Overwriting replaces the existing file, so only the growth counts against the quota
+		quota.Release(root.size(ctx, f))
+
+		return root.write(ctx, f, quota.Reader(r))
+	})
+}
+
+type transferStream interface {
+	Send(*pb.VolumeTransferResponse) error
+}
+
+// runTransfer copies files concurrently, streaming progress at most once per interval and a final summary.
+// The stream is only written from the calling goroutine.
+func runTransfer(ctx context.Context, stream transferStream, files []transferFile, skipped int, transfer func(context.Context, transferFile) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	progress := &pb.VolumeTransferResponse{
+		Ok:           true,
+		FilesTotal:   int64(len(files) + skipped),
+		FilesSkipped: int64(skipped),
+	}
+
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(volumeTransferConcurrency)
+
+	done := make(chan int64)
+	result := make(chan error, 1)
+
+	go func() {
+		for _, f := range files {
+			if gCtx.Err() != nil {
+				break
+			}
+
+			g.Go(func() error {
+				if err := transfer(gCtx, f); err != nil {
+					return fmt.Errorf("%s: %w", f.Path, err)
+				}
+
+				select {
+				case done <- f.Size:
+				case <-gCtx.Done():
+				}
+				return nil
+			})
+		}
+
+		result <- g.Wait()
+		close(done)
+	}()
+
+	var sendErr error
+	lastSent := time.Now()
+	for size := range done {
+		progress.FilesTransferred++
+		progress.BytesTransferred += size
+
+		if sendErr == nil && time.Since(lastSent) >= volumeTransferProgressInterval {
+			lastSent = time.Now()
+			if sendErr = stream.Send(progress); sendErr != nil {
+				cancel()
+			}
+		}
+	}
+
+	err := <-result
+	if sendErr != nil {
+		return sendErr
+	}
+
+	progress.Done = true
+	if err != nil {
+		log.Warn().Err(err).Msg("volume transfer failed")
+		progress.Ok = false
+		progress.ErrMsg = fmt.Sprintf("Transfer failed: %v", err)
+	}
+
+	return stream.Send(progress)
+}
+
+// changedFiles drops the files whose counterpart on the other side has the same size and is at least as new
+func changedFiles(files, existing []transferFile) ([]transferFile, int) {
+	byPath := make(map[string]transferFile, len(existing))
+	for _, f := range existing {
+		byPath[f.Path] = f
+	}
+
+	changed := make([]transferFile, 0, len(files))
+	for _, f := range files {
+		if other, ok := byPath[f.Path]; ok && other.Size == f.Size && !other.ModTime.Before(f.ModTime) {
+			continue
+		}
+		changed = append(changed, f)
+	}
+
+	return changed, len(files) - len(changed)
+}
+
+// transferBucket is a user's bucket, or a prefix of it, that volume contents are exported to or imported from
+type transferBucket struct {
+	client *s3.Client
+	name   string
+	prefix string
+}
+
+// newTransferBucket builds a client for the target bucket. Credentials are either passed in the request or
+// named workspace secrets; with neither the bucket is accessed anonymously.
+func (vs *GlobalVolumeService) newTransferBucket(ctx context.Context, workspace *types.Workspace, target *pb.VolumeTransferTarget) (*transferBucket, error) {
+	if target == nil || target.BucketName == "" {
+		return nil, errors.New("Bucket name is required")
+	}
+
+	accessKey, secretKey := target.AccessKey, target.SecretKey
+	if target.AccessKeySecret != "" {
+		secret, err := vs.backendRepo.GetSecretByNameDecrypted(ctx, workspace, target.AccessKeySecret)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get secret: %s", target.AccessKeySecret)
+		}
+		accessKey = secret.Value
+	}
+	if target.SecretKeySecret != "" {
+		secret, err := vs.backendRepo.GetSecretByNameDecrypted(ctx, workspace, target.SecretKeySecret)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get secret: %s", target.SecretKeySecret)
+		}
+		secretKey = secret.Value
+	}
+
+	var credentialsProvider aws.CredentialsProvider = aws.AnonymousCredentials{}
+	if accessKey != "" || secretKey != "" {
+		credentialsProvider = credentials.NewStaticCredentialsProvider(accessKey, secretKey, "")
+	}
+
+	region := target.Region
+	if region == "" {
+		region = volumeTransferDefaultRegion
+	}
+
+	options := s3.Options{
+		Credentials:  credentialsProvider,
+		Region:       region,
+		UsePathStyle: target.ForcePathStyle,
+		HTTPClient:   newTransferHTTPClient(),
+	}
+	if target.EndpointUrl != "" {
+		options.BaseEndpoint = aws.String(target.EndpointUrl)
+	}
+
+	prefix := strings.Trim(path.Clean("/"+target.Prefix), "/")
+
+	return &transferBucket{client: s3.New(options), name: target.BucketName, prefix: prefix}, nil
+}
+
+// newTransferHTTPClient refuses to connect to loopback, private and link-local addresses, so user supplied
+// endpoints can't be used to reach services inside the cluster
+func newTransferHTTPClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: volumeTransferDialTimeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			ip := net.ParseIP(host)
+			if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+				ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+				return errTransferPrivateEndpoint
+			}
+
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{Transport: transport}
+}
+
+func (b *transferBucket) key(p string) string {
+	return path.Join(b.prefix, p)
+}
+
+// list returns the bucket's objects under the prefix. Keys that would resolve outside of a volume are skipped.
+func (b *transferBucket) list(ctx context.Context) ([]transferFile, error) {
+	listPrefix := ""
+	if b.prefix != "" {
+		listPrefix = b.prefix + "/"
+	}
+
+	var files []transferFile
+	paginator := s3.NewListObjectsV2Paginator(b.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(b.name),
+		Prefix: aws.String(listPrefix),
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, obj := range page.Contents {
+			key := aws.ToString(obj.Key)
+			if strings.HasSuffix(key, "/") {
+				continue
+			}
+
+			rel, err := common.SanitizeArchivePath(strings.TrimPrefix(key, listPrefix))
+			if err != nil {
+				continue
+			}
+
+			files = append(files, transferFile{Path: rel, Size: aws.ToInt64(obj.Size), ModTime: aws.ToTime(obj.LastModified)})
+		}
+	}
+
+	return files, nil
+}
+
+func (b *transferBucket) put(ctx context.Context, f transferFile, r io.Reader) error {
+	_, err := manager.NewUploader(b.client).Upload(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(b.name),
+		Key:    aws.String(b.key(f.Path)),
+		Body:   r,
+	})
+	return err
+}
+
+func (b *transferBucket) get(ctx context.Context, f transferFile) (io.ReadCloser, error) {
+	resp, err := b.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(b.name),
+		Key:    aws.String(b.key(f.Path)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+// volumeTransferRoot is the directory within a volume that a transfer reads from or writes to, either in
+// workspace storage or on the local volume filesystem
+type volumeTransferRoot struct {
+	storageClient *clients.WorkspaceStorageClient
+	prefix        string
+	dir           string
+}
+
+func newVolumeTransferRoot(ctx context.Context, workspace *types.Workspace, volume *types.Volume, volumePath string) (*volumeTransferRoot, error) {
+	volumePath = strings.Trim(path.Clean("/"+volumePath), "/")
+
+	if workspace.StorageAvailable() {
+		storageClient, err := clients.NewWorkspaceStorageClient(ctx, workspace.Name, workspace.Storage)
+		if err != nil {
+			return nil, err
+		}
+
+		prefix := volumeStoragePrefix(volume.ExternalId)
+		if volumePath != "" {
+			prefix = path.Join(prefix, volumePath) + "/"
+		}
+
+		return &volumeTransferRoot{storageClient: storageClient, prefix: prefix}, nil
+	}
+
+	_, dir, err := GetVolumePaths(workspace.Name, volume.ExternalId, volumePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &volumeTransferRoot{dir: dir}, nil
+}
+
+func (r *volumeTransferRoot) list(ctx context.Context) ([]transferFile, error) {
+	var files []transferFile
+
+	if r.storageClient != nil {
+		objects, err := r.storageClient.ListWithPrefix(ctx, r.prefix)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, obj := range objects {
+			key := aws.ToString(obj.Key)
+			if strings.HasSuffix(key, "/") {
+				continue
+			}
+
+			files = append(files, transferFile{Path: strings.TrimPrefix(key, r.prefix), Size: aws.ToInt64(obj.Size), ModTime: aws.ToTime(obj.LastModified)})
+		}
+
+		return files, nil
+	}
+
+	err := filepath.WalkDir(r.dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+
+		rel, err := filepath.Rel(r.dir, p)
+		if err != nil {
+			return err
+		}
+
+		files = append(files, transferFile{Path: filepath.ToSlash(rel), Size: info.Size(), ModTime: info.ModTime()})
+		return nil
+	})
+
+	return files, err
+}
+
+func (r *volumeTransferRoot) open(ctx context.Context, f transferFile) (io.ReadCloser, error) {
+	if r.storageClient != nil {
+		return r.storageClient.DownloadRangeWithReader(ctx, r.prefix+f.Path, 0, 0)
+	}
+
+	return os.Open(filepath.Join(r.dir, f.Path))
+}
+
+// write stores an imported file. Local files keep the source's modification time, so a later incremental
+// import can tell them apart from files that changed since.
+func (r *volumeTransferRoot) write(ctx context.Context, f transferFile, src io.Reader) error {
+	if r.storageClient != nil {
+		return r.storageClient.UploadWithReader(ctx, r.prefix+f.Path, src)
+	}
+
+	filePath := filepath.Join(r.dir, f.Path)
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return err
+	}
+
+	// The volume may already hold symlinks, so make sure the file's directory is still inside the root
+	resolvedRoot, err := filepath.EvalSymlinks(r.dir)
+	if err != nil {
+		return err
+	}
+
+	resolvedDir, err := filepath.EvalSymlinks(filepath.Dir(filePath))
+	if err != nil {
+		return err
+	}
+
+	if resolvedDir != resolvedRoot && !strings.HasPrefix(resolvedDir, resolvedRoot+string(os.PathSeparator)) {
+		return errors.New("path resolves outside the volume")
+	}
+
+	// The temporary file gets a fresh name that's created exclusively, so it can't be a symlink planted in the
+	// volume and concurrent imports of the same path don't write to the same file
+	file, err := os.CreateTemp(resolvedDir, "."+filepath.Base(filePath)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := file.Name()
+
+	if err := file.Chmod(0644); err != nil {
+		file.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if _, err := io.Copy(file, src); err != nil {
+		file.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if !f.ModTime.IsZero() {
+		os.Chtimes(tmpPath, f.ModTime, f.ModTime)
+	}
+
+	if err := os.Rename(tmpPath, filepath.Join(resolvedDir, filepath.Base(filePath))); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	return nil
+}
+
+// size returns the size of the file an import would replace, or 0 if there isn't one
+func (r *volumeTransferRoot) size(ctx context.Context, f transferFile) int64 {
+	if r.storageClient != nil {
+		exists, head, err := r.storageClient.Head(ctx, r.prefix+f.Path)
+		if err != nil || !exists || head == nil {
+			return 0
+		}
+		return aws.ToInt64(head.ContentLength)
+	}
+
+	info, err := os.Lstat(filepath.Join(r.dir, f.Path))
+	if err != nil || !info.Mode().IsRegular() {
+		return 0
+	}
+	return info.Size()
+}
@@ -0,0 +1,104 @@
+package volume
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	pb "github.com/beam-cloud/beta9/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTransferStream struct {
+	sent []*pb.VolumeTransferResponse
+}
+
+func (s *fakeTransferStream) Send(resp *pb.VolumeTransferResponse) error {
+	s.sent = append(s.sent, resp)
+	return nil
+}
+
+func TestChangedFiles(t *testing.T) {
+	now := time.Now()
+	files := []transferFile{
+		{Path: "same", Size: 1, ModTime: now},
+		{Path: "resized", Size: 2, ModTime: now},
+		{Path: "newer", Size: 3, ModTime: now},
+		{Path: "missing", Size: 4, ModTime: now},
+	}
+	existing := []transferFile{
+		{Path: "same", Size: 1, ModTime: now.Add(time.Minute)},
+		{Path: "resized", Size: 1, ModTime: now.Add(time.Minute)},
+		{Path: "newer", Size: 3, ModTime: now.Add(-time.Minute)},
+	}
+
+	changed, skipped := changedFiles(files, existing)
+	assert.Equal(t, 1, skipped)
+	assert.Equal(t, []transferFile{files[1], files[2], files[3]}, changed)
+}
+
+func TestRunTransfer(t *testing.T) {
+	files := []transferFile{{Path: "a", Size: 3}, {Path: "b", Size: 5}}
+
+	stream := &fakeTransferStream{}
+	err := runTransfer(context.Background(), stream, files, 2, func(ctx context.Context, f transferFile) error {
+		return nil
+	})
+	require.NoError(t, err)
+
+	last := stream.sent[len(stream.sent)-1]
+	assert.True(t, last.Ok)
+	assert.True(t, last.Done)
+	assert.Equal(t, int64(4), last.FilesTotal)
+	assert.Equal(t, int64(2), last.FilesTransferred)
+	assert.Equal(t, int64(8), last.BytesTransferred)
+
+	stream = &fakeTransferStream{}
+	err = runTransfer(context.Background(), stream, files, 0, func(ctx context.Context, f transferFile) error {
+		return errors.New("denied")
+	})
+	require.NoError(t, err)
+
+	last = stream.sent[len(stream.sent)-1]
+	assert.False(t, last.Ok)
+	assert.True(t, last.Done)
+}
+
+func TestVolumeTransferRootLocal(t *testing.T) {
+	root := &volumeTransferRoot{dir: t.TempDir()}
+	modTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	f := transferFile{Path: "nested/data.bin", Size: 5, ModTime: modTime}
+	require.NoError(t, root.write(context.Background(), f, bytes.NewReader([]byte("hello"))))
+
+	files, err := root.list(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(files))
+	assert.Equal(t, "nested/data.bin", files[0].Path)
+	assert.Equal(t, int64(5), files[0].Size)
+	assert.True(t, files[0].ModTime.Equal(modTime))
+
+	outside := t.TempDir()
+	require.NoError(t, os.Symlink(outside, filepath.Join(root.dir, "escape")))
+	err = root.write(context.Background(), transferFile{Path: "escape/x"}, bytes.NewReader(nil))
+	assert.Error(t, err)
+
+	// A symlink planted where a fixed temporary name would be isn't followed
+	target := filepath.Join(outside, "target")
+	require.NoError(t, os.WriteFile(target, []byte("keep"), 0644))
+	require.NoError(t, os.Symlink(target, filepath.Join(root.dir, "data.bin.tmp")))
+	require.NoError(t, root.write(context.Background(), transferFile{Path: "data.bin"}, bytes.NewReader([]byte("hello"))))
+
+	content, err := os.ReadFile(target)
+	require.NoError(t, err)
+	assert.Equal(t, "keep", string(content))
+
+	content, err = os.ReadFile(filepath.Join(root.dir, "data.bin"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(content))
+}
@@ -30,13 +30,22 @@ type VolumeService interface {
 	DeletePath(ctx context.Context, in *pb.DeletePathRequest) (*pb.DeletePathResponse, error)
 	MovePath(ctx context.Context, in *pb.MovePathRequest) (*pb.MovePathResponse, error)
 	CopyPathStream(stream pb.VolumeService_CopyPathStreamServer) error
+	SnapshotVolume(ctx context.Context, in *pb.SnapshotVolumeRequest) (*pb.SnapshotVolumeResponse, error)
+	ListSnapshots(ctx context.Context, in *pb.ListSnapshotsRequest) (*pb.ListSnapshotsResponse, error)
+	RestoreVolume(ctx context.Context, in *pb.RestoreVolumeRequest) (*pb.RestoreVolumeResponse, error)
+	ShareVolume(ctx context.Context, in *pb.ShareVolumeRequest) (*pb.ShareVolumeResponse, error)
+	GetVolumeUsage(ctx context.Context, in *pb.GetVolumeUsageRequest) (*pb.GetVolumeUsageResponse, error)
+	SetVolumeQuota(ctx context.Context, in *pb.SetVolumeQuotaRequest) (*pb.SetVolumeQuotaResponse, error)
+	ExportVolume(in *pb.ExportVolumeRequest, stream pb.VolumeService_ExportVolumeServer) error
+	ImportVolume(in *pb.ImportVolumeRequest, stream pb.VolumeService_ImportVolumeServer) error
 }
 
 type GlobalVolumeService struct {
 	pb.UnimplementedVolumeServiceServer
-	config      types.FileServiceConfig
-	backendRepo repository.BackendRepository
-	rdb         *common.RedisClient
+	config        types.FileServiceConfig
+	storageConfig types.StorageConfig
+	backendRepo   repository.BackendRepository
+	rdb           *common.RedisClient
 }
 
 type FileInfo struct {
@@ -53,15 +62,16 @@ type VolumePathTokenData struct {
 
 var volumeRoutePrefix string = "/volume"
 
-func NewGlobalVolumeService(config types.FileServiceConfig, backendRepo repository.BackendRepository, workspaceRepo repository.WorkspaceRepository, rdb *common.RedisClient, routeGroup *echo.Group) (VolumeService, error) {
+func NewGlobalVolumeService(config types.FileServiceConfig, storageConfig types.StorageConfig, ipAllowList types.IPAllowListConfig, backendRepo repository.BackendRepository, workspaceRepo repository.WorkspaceRepository, rdb *common.RedisClient, routeGroup *echo.Group) (VolumeService, error) {
 	gvs := &GlobalVolumeService{
-		config:      config,
-		backendRepo: backendRepo,
-		rdb:         rdb,
+		config:        config,
+		storageConfig: storageConfig,
+		backendRepo:   backendRepo,
+		rdb:           rdb,
 	}
 
 	// Register HTTP routes
-	authMiddleware := auth.AuthMiddleware(backendRepo, workspaceRepo)
+	authMiddleware := auth.AuthMiddleware(backendRepo, workspaceRepo, ipAllowList)
 	registerVolumeRoutes(routeGroup.Group(volumeRoutePrefix, authMiddleware), gvs)
 
 	return gvs, nil
@@ -94,7 +104,7 @@ func (vs *GlobalVolumeService) GetOrCreateVolume(ctx context.Context, in *pb.Get
 func (vs *GlobalVolumeService) DeleteVolume(ctx context.Context, in *pb.DeleteVolumeRequest) (*pb.DeleteVolumeResponse, error) {
 	authInfo, _ := auth.AuthInfoFromContext(ctx)
 
-	if !auth.HasPermission(authInfo) {
+	if !auth.HasPermission(authInfo, types.PermissionWrite) {
 		return &pb.DeleteVolumeResponse{
 			Ok:     false,
 			ErrMsg: "Unauthorized Access",
@@ -134,9 +144,22 @@ func (vs *GlobalVolumeService) ListVolumes(ctx context.Context, in *pb.ListVolum
 			UpdatedAt:     timestamppb.New(v.UpdatedAt.Time),
 			WorkspaceId:   v.Workspace.ExternalId,
 			WorkspaceName: v.Workspace.Name,
+			QuotaBytes:    v.QuotaBytes,
 		}
 	}
 
+	shared, err := vs.backendRepo.ListSharedVolumes(ctx, authInfo.Workspace.Id)
+	if err != nil {
+		return &pb.ListVolumesResponse{
+			Ok:     false,
+			ErrMsg: err.Error(),
+		}, nil
+	}
+
+	for i := range shared {
+		vols = append(vols, sharedVolumeToProto(&shared[i]))
+	}
+
 	return &pb.ListVolumesResponse{
 		Ok:      true,
 		Volumes: vols,
@@ -146,6 +169,13 @@ func (vs *GlobalVolumeService) ListVolumes(ctx context.Context, in *pb.ListVolum
 func (vs *GlobalVolumeService) ListPath(ctx context.Context, in *pb.ListPathRequest) (*pb.ListPathResponse, error) {
 	authInfo, _ := auth.AuthInfoFromContext(ctx)
 
+	if err := vs.checkPathAccess(ctx, authInfo, authInfo.Workspace, in.Path, types.ResourceAccessRead); err != nil {
+		return &pb.ListPathResponse{
+			Ok:     false,
+			ErrMsg: err.Error(),
+		}, nil
+	}
+
 	paths, err := vs.listPath(ctx, in.Path, authInfo.Workspace)
 	if err != nil {
 		return &pb.ListPathResponse{
@@ -192,7 +222,7 @@ func (vs *GlobalVolumeService) CopyPathStream(stream pb.VolumeService_CopyPathSt
 		}
 	}()
 
-	if err := vs.copyPathStream(ctx, ch, authInfo.Workspace); err != nil {
+	if err := vs.copyPathStream(ctx, ch, authInfo, authInfo.Workspace); err != nil {
 		return stream.SendAndClose(&pb.CopyPathResponse{
 			Ok:     false,
 			ErrMsg: err.Error(),
@@ -205,6 +235,13 @@ func (vs *GlobalVolumeService) CopyPathStream(stream pb.VolumeService_CopyPathSt
 func (vs *GlobalVolumeService) DeletePath(ctx context.Context, in *pb.DeletePathRequest) (*pb.DeletePathResponse, error) {
 	authInfo, _ := auth.AuthInfoFromContext(ctx)
 
+	if err := vs.checkPathAccess(ctx, authInfo, authInfo.Workspace, in.Path, types.ResourceAccessWrite); err != nil {
+		return &pb.DeletePathResponse{
+			Ok:     false,
+			ErrMsg: err.Error(),
+		}, nil
+	}
+
 	paths, err := vs.deletePath(ctx, in.Path, authInfo.Workspace)
 	if err != nil {
 		return &pb.DeletePathResponse{
@@ -222,6 +259,13 @@ func (vs *GlobalVolumeService) DeletePath(ctx context.Context, in *pb.DeletePath
 func (vs *GlobalVolumeService) MovePath(ctx context.Context, in *pb.MovePathRequest) (*pb.MovePathResponse, error) {
 	authInfo, _ := auth.AuthInfoFromContext(ctx)
 
+	if err := vs.checkPathAccess(ctx, authInfo, authInfo.Workspace, in.GetOriginalPath(), types.ResourceAccessWrite); err != nil {
+		return &pb.MovePathResponse{
+			Ok:     false,
+			ErrMsg: err.Error(),
+		}, nil
+	}
+
 	newPath, err := vs.movePath(ctx, in.GetOriginalPath(), in.GetNewPath(), authInfo.Workspace)
 	if err != nil {
 		return &pb.MovePathResponse{
@@ -239,6 +283,13 @@ func (vs *GlobalVolumeService) MovePath(ctx context.Context, in *pb.MovePathRequ
 func (vs *GlobalVolumeService) StatPath(ctx context.Context, in *pb.StatPathRequest) (*pb.StatPathResponse, error) {
 	authInfo, _ := auth.AuthInfoFromContext(ctx)
 
+	if err := vs.checkPathAccess(ctx, authInfo, authInfo.Workspace, in.Path, types.ResourceAccessRead); err != nil {
+		return &pb.StatPathResponse{
+			Ok:     false,
+			ErrMsg: err.Error(),
+		}, nil
+	}
+
 	path, err := vs.getFilePath(ctx, in.Path, authInfo.Workspace)
 	if err != nil {
 		return &pb.StatPathResponse{
@@ -291,6 +342,10 @@ func (vs *GlobalVolumeService) deleteVolume(ctx context.Context, workspace *type
 		return err
 	}
 
+	if err := vs.deleteSnapshots(ctx, workspace, volume); err != nil {
+		return err
+	}
+
 	if workspace.StorageAvailable() {
 		storageClient, err := clients.NewWorkspaceStorageClient(ctx, workspace.Name, workspace.Storage)
 		if err != nil {
@@ -332,10 +387,11 @@ type CopyPathContent struct {
 	Content []byte
 }
 
-func (vs *GlobalVolumeService) copyPathStream(ctx context.Context, stream <-chan CopyPathContent, workspace *types.Workspace) error {
+func (vs *GlobalVolumeService) copyPathStream(ctx context.Context, stream <-chan CopyPathContent, authInfo *auth.AuthInfo, workspace *types.Workspace) error {
 	var file *os.File
 	var fullVolumePath string
 	var tmpFileSuffix string = ".tmp"
+	var quota *VolumeQuota
 
 	for chunk := range stream {
 		if file == nil {
@@ -353,12 +409,26 @@ func (vs *GlobalVolumeService) copyPathStream(ctx context.Context, stream <-chan
 				return errors.New("unable to find volume")
 			}
 
+			if err := vs.checkVolumeAccess(ctx, authInfo, volume, types.ResourceAccessWrite); err != nil {
+				return err
+			}
+
 			// Get paths and prevent access above parent directory
 			_, fullVolumePath, err = GetVolumePaths(workspace.Name, volume.ExternalId, volumePath)
 			if err != nil {
 				return err
 			}
 
+			quota, err = vs.volumeQuota(ctx, workspace, volume)
+			if err != nil {
+				return errors.New("unable to check volume quota")
+			}
+
+			// Overwriting replaces the existing file, so only the growth counts against the quota
+			if info, err := os.Stat(fullVolumePath); err == nil && info.Mode().IsRegular() {
+				quota.Release(info.Size())
+			}
+
 			os.MkdirAll(path.Dir(fullVolumePath), os.FileMode(0755))
 			file, err = os.Create(fullVolumePath + tmpFileSuffix)
 			if err != nil {
@@ -367,6 +437,11 @@ func (vs *GlobalVolumeService) copyPathStream(ctx context.Context, stream <-chan
 			defer file.Close()
 		}
 
+		if err := quota.Reserve(int64(len(chunk.Content))); err != nil {
+			os.RemoveAll(fullVolumePath + tmpFileSuffix)
+			return err
+		}
+
 		if _, err := file.Write(chunk.Content); err != nil {
 			os.RemoveAll(fullVolumePath + tmpFileSuffix)
 			return errors.New("unable to write file content to volume")
@@ -120,6 +120,7 @@ func (g *DeploymentGroup) RetrieveDeployment(ctx echo.Context) error {
 	} else if deployment == nil {
 		return HTTPNotFound()
 	} else {
+		deployment.Health = g.containerHealth(deployment)
 		deployment.Stub.SanitizeConfig()
 		serializedDeployment, err := serializer.Serialize(deployment)
 		if err != nil {
@@ -130,6 +131,30 @@ func (g *DeploymentGroup) RetrieveDeployment(ctx echo.Context) error {
 	}
 }
 
+// containerHealth returns the health of the deployment's active containers, nil if its stub has no health check
+func (g *DeploymentGroup) containerHealth(deployment *types.DeploymentWithRelated) []types.ContainerHealth {
+	stubConfig, err := deployment.Stub.UnmarshalConfig()
+	if err != nil || stubConfig == nil || stubConfig.HealthCheck == nil {
+		return nil
+	}
+
+	containers, err := g.containerRepo.GetActiveContainersByStubId(deployment.Stub.ExternalId)
+	if err != nil {
+		return nil
+	}
+
+	health := make([]types.ContainerHealth, 0, len(containers))
+	for _, container := range containers {
+		containerHealth, err := g.containerRepo.GetContainerHealth(container.ContainerId)
+		if err != nil || containerHealth == nil {
+			containerHealth = &types.ContainerHealth{ContainerId: container.ContainerId, Status: types.ContainerHealthStatusStarting}
+		}
+		health = append(health, *containerHealth)
+	}
+
+	return health
+}
+
 func (g *DeploymentGroup) StopDeployment(ctx echo.Context) error {
 	cc, _ := ctx.(*auth.HttpAuthContext)
 	deploymentId := ctx.Param("deploymentId")
@@ -356,6 +381,7 @@ var stubTypeMap = map[string]string{
 	"endpoint":  types.StubTypeEndpointDeployment,
 	"http":      types.StubTypeEndpointDeployment,
 	"asgi":      types.StubTypeASGIDeployment,
+	"grpc":      types.StubTypeGRPCDeployment,
 	"function":  types.StubTypeFunctionDeployment,
 }
 
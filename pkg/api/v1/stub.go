@@ -354,7 +354,8 @@ func (g *StubGroup) copyObjectContents(ctx context.Context, destinationWorkspace
 	newObjectStorageFilePath := path.Join(types.DefaultObjectPrefix, newObject.ExternalId)
 
 	// If both workspaces have the storage client available and both are pointed to same storage provider, copy the object with the storage client
-	if sourceWorkspace.StorageAvailable() && destinationWorkspace.StorageAvailable() && sourceWorkspace.Storage.EndpointUrl == destinationWorkspace.Storage.EndpointUrl {
+	if sourceWorkspace.StorageAvailable() && destinationWorkspace.StorageAvailable() && sourceWorkspace.Storage.EndpointUrl == destinationWorkspace.Storage.EndpointUrl &&
+		sourceWorkspace.Storage.StorageProvider() == types.WorkspaceStorageProviderS3 && destinationWorkspace.Storage.StorageProvider() == types.WorkspaceStorageProviderS3 {
 		storageClient, err := clients.NewDefaultStorageClient(ctx, g.config)
 		if err != nil {
 			return 0, err
@@ -475,8 +476,8 @@ func (g *StubGroup) cloneStub(ctx context.Context, workspace *types.Workspace, s
 		return nil, HTTPBadRequest("Multi-GPU containers are not enabled for this workspace.")
 	}
 
-	for _, secret := range parentSecrets {
-		secret, err := g.backendRepo.GetSecretByName(ctx, workspace, secret.Name)
+	for _, parentSecret := range parentSecrets {
+		secret, err := g.backendRepo.GetSecretByName(ctx, workspace, parentSecret.Name)
 		if err != nil {
 			if err == sql.ErrNoRows {
 				continue
@@ -490,6 +491,8 @@ func (g *StubGroup) cloneStub(ctx context.Context, workspace *types.Workspace, s
 			Value:     secret.Value,
 			CreatedAt: secret.CreatedAt,
 			UpdatedAt: secret.UpdatedAt,
+			Version:   secret.Version,
+			Mount:     parentSecret.Mount,
 		})
 	}
 
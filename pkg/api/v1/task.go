@@ -263,12 +263,26 @@ func (g *TaskGroup) addOutputsToTask(ctx context.Context, authInfo *auth.AuthInf
 		return err
 	}
 
-	for outputId, fileName := range outputFiles {
-		url, err := output.SetPublicURL(ctx, g.config, g.backendRepo, g.redisClient, authInfo, task.ExternalId, outputId, fileName, DefaultTaskOutputExpirationS)
+	if len(outputFiles) == 0 {
+		return nil
+	}
+
+	policy, err := output.GetOutputRetentionPolicy(ctx, g.backendRepo, task.Stub.ExternalId)
+	if err != nil {
+		return err
+	}
+
+	for _, outputFile := range outputFiles {
+		url, err := output.SetPublicURL(ctx, g.config, g.backendRepo, g.redisClient, authInfo, task.ExternalId, outputFile.Id, outputFile.Filename, DefaultTaskOutputExpirationS)
 		if err != nil {
 			return err
 		}
-		task.Outputs = append(task.Outputs, types.TaskOutput{Name: fileName, URL: url, ExpiresIn: DefaultTaskOutputExpirationS})
+		task.Outputs = append(task.Outputs, types.TaskOutput{
+			Name:        outputFile.Filename,
+			URL:         url,
+			ExpiresIn:   DefaultTaskOutputExpirationS,
+			RetainUntil: policy.RetainUntil(outputFile.ModTime),
+		})
 	}
 
 	return nil
@@ -26,11 +26,11 @@ func NewTokenGroup(g *echo.Group, backendRepo repository.BackendRepository, work
 	}
 
 	g.PATCH("/admin/:workspaceId", auth.WithClusterAdminAuth(group.ClusterAdminUpdateAllWorkspaceTokens))
-	g.POST("/:workspaceId", auth.WithStrictWorkspaceAuth(group.CreateWorkspaceToken))
-	g.GET("/:workspaceId", auth.WithStrictWorkspaceAuth(group.ListWorkspaceTokens))
-	g.GET("/:workspaceId/signing-key", auth.WithStrictWorkspaceAuth(group.GetSigningKey))
-	g.POST("/:workspaceId/:tokenId/toggle", auth.WithStrictWorkspaceAuth(group.ToggleWorkspaceToken))
-	g.DELETE("/:workspaceId/:tokenId", auth.WithStrictWorkspaceAuth(group.DeleteWorkspaceToken))
+	g.POST("/:workspaceId", auth.WithWorkspaceAdminAuth(group.CreateWorkspaceToken))
+	g.GET("/:workspaceId", auth.WithWorkspaceAdminAuth(group.ListWorkspaceTokens))
+	g.GET("/:workspaceId/signing-key", auth.WithWorkspaceAdminAuth(group.GetSigningKey))
+	g.POST("/:workspaceId/:tokenId/toggle", auth.WithWorkspaceAdminAuth(group.ToggleWorkspaceToken))
+	g.DELETE("/:workspaceId/:tokenId", auth.WithWorkspaceAdminAuth(group.DeleteWorkspaceToken))
 
 	return group
 }
@@ -95,9 +95,11 @@ func (g *TokenGroup) ClusterAdminUpdateAllWorkspaceTokens(ctx echo.Context) erro
 
 		if req.Disabled {
 			err = g.workspaceRepo.RevokeToken(token.Key)
-			if err != nil {
-				return HTTPInternalServerError("Failed to revoke token")
-			}
+		} else {
+			err = g.workspaceRepo.RestoreToken(token.Key)
+		}
+		if err != nil {
+			return HTTPInternalServerError("Failed to revoke token")
 		}
 	}
 
@@ -158,9 +160,11 @@ func (g *TokenGroup) ToggleWorkspaceToken(ctx echo.Context) error {
 
 	if !toggledToken.Active {
 		err = g.workspaceRepo.RevokeToken(token.Key)
-		if err != nil {
-			return HTTPInternalServerError("Failed to revoke token")
-		}
+	} else {
+		err = g.workspaceRepo.RestoreToken(token.Key)
+	}
+	if err != nil {
+		return HTTPInternalServerError("Failed to revoke token")
 	}
 
 	return ctx.JSON(http.StatusOK, serializedToken)
@@ -36,6 +36,7 @@ func NewWorkspaceGroup(g *echo.Group, backendRepo repository.BackendRepository,
 	g.GET("/:workspaceId/export", auth.WithStrictWorkspaceAuth(group.ExportWorkspaceConfig))
 	g.POST("/:workspaceId/set-external-storage", auth.WithStrictWorkspaceAuth(group.SetExternalWorkspaceStorage))
 	g.POST("/:workspaceId/create-storage", auth.WithStrictWorkspaceAuth(group.CreateWorkspaceStorage))
+	g.POST("/:workspaceId/set-replica-storage", auth.WithStrictWorkspaceAuth(group.SetReplicaWorkspaceStorage))
 
 	return group
 }
@@ -119,17 +120,19 @@ type CreateWorkspaceStorageRequest struct {
 	BucketName  string `json:"bucket_name" validate:"required"`
 	AccessKey   string `json:"access_key" validate:"required"`
 	SecretKey   string `json:"secret_key" validate:"required"`
-	EndpointUrl string `json:"endpoint_url" validate:"required"`
-	Region      string `json:"region" validate:"required"`
+	EndpointUrl string `json:"endpoint_url"`
+	Region      string `json:"region"`
+	Provider    string `json:"provider" validate:"omitempty,oneof=s3 gcs azure"`
 }
 
-// SetExternalWorkspaceStorage takes in the details for accessing an external s3 compatible storage bucket.
+// SetExternalWorkspaceStorage takes in the details for accessing an external storage bucket.
 // This includes:
-// - Bucket name
-// - Access key
-// - Secret key
-// - Endpoint URL
-// - Region
+// - Provider (s3, gcs or azure, defaulting to s3)
+// - Bucket name (the container name for azure)
+// - Access key (the service account email for gcs, the account name for azure)
+// - Secret key (the service account JSON key for gcs, the account key for azure)
+// - Endpoint URL (required for s3)
+// - Region (required for s3)
 // It then creates a new workspace storage object for that bucket and sets it as the storage bucket for the workspace.
 func (g *WorkspaceGroup) SetExternalWorkspaceStorage(ctx echo.Context) error {
 	workspaceId := ctx.Param("workspaceId")
@@ -154,12 +157,21 @@ func (g *WorkspaceGroup) SetExternalWorkspaceStorage(ctx echo.Context) error {
 		return HTTPBadRequest("Missing required fields: " + strings.Join(missingFields, ", "))
 	}
 
+	if request.Provider == "" {
+		request.Provider = types.WorkspaceStorageProviderS3
+	}
+
+	if request.Provider == types.WorkspaceStorageProviderS3 && (request.EndpointUrl == "" || request.Region == "") {
+		return HTTPBadRequest("Missing required fields: EndpointUrl, Region")
+	}
+
 	storage := &types.WorkspaceStorage{
 		BucketName:  &request.BucketName,
 		AccessKey:   &request.AccessKey,
 		SecretKey:   &request.SecretKey,
 		EndpointUrl: &request.EndpointUrl,
 		Region:      &request.Region,
+		Provider:    &request.Provider,
 	}
 
 	storageClient, err := clients.NewWorkspaceStorageClient(ctx.Request().Context(), workspace.Name, storage)
@@ -184,6 +196,75 @@ func (g *WorkspaceGroup) SetExternalWorkspaceStorage(ctx echo.Context) error {
 	return ctx.JSON(http.StatusCreated, createdStorage)
 }
 
+// SetReplicaWorkspaceStorage takes in the details for accessing a storage bucket in another region, with the same
+// fields as SetExternalWorkspaceStorage except that the region is always required. Objects are then replicated to it
+// in the background so workers in that region can read a nearby copy. It replaces any previous replica storage.
+func (g *WorkspaceGroup) SetReplicaWorkspaceStorage(ctx echo.Context) error {
+	cc, _ := ctx.(*auth.HttpAuthContext)
+
+	workspace := cc.AuthInfo.Workspace
+	if workspace.ExternalId != ctx.Param("workspaceId") {
+		return HTTPUnauthorized("Invalid token for workspace")
+	}
+
+	if !workspace.StorageAvailable() {
+		return HTTPBadRequest("Workspace storage must be set up before adding a replica")
+	}
+
+	var request CreateWorkspaceStorageRequest
+	if err := ctx.Bind(&request); err != nil {
+		return HTTPBadRequest("Invalid payload")
+	}
+
+	v := validator.New()
+	if err := v.Struct(request); err != nil {
+		var missingFields []string
+		for _, err := range err.(validator.ValidationErrors) {
+			missingFields = append(missingFields, err.Field())
+		}
+
+		return HTTPBadRequest("Missing required fields: " + strings.Join(missingFields, ", "))
+	}
+
+	if request.Provider == "" {
+		request.Provider = types.WorkspaceStorageProviderS3
+	}
+
+	if request.Region == "" || (request.Provider == types.WorkspaceStorageProviderS3 && request.EndpointUrl == "") {
+		return HTTPBadRequest("Missing required fields: EndpointUrl, Region")
+	}
+
+	if workspace.Storage.Region != nil && *workspace.Storage.Region == request.Region {
+		return HTTPBadRequest("Replica storage must be in a different region than workspace storage")
+	}
+
+	storage := &types.WorkspaceStorage{
+		BucketName:  &request.BucketName,
+		AccessKey:   &request.AccessKey,
+		SecretKey:   &request.SecretKey,
+		EndpointUrl: &request.EndpointUrl,
+		Region:      &request.Region,
+		Provider:    &request.Provider,
+	}
+
+	storageClient, err := clients.NewWorkspaceStorageClient(ctx.Request().Context(), workspace.Name, storage)
+	if err != nil {
+		return HTTPInternalServerError("Unable to create replica storage")
+	}
+
+	err = storageClient.ValidateBucketAccess(ctx.Request().Context())
+	if err != nil {
+		return HTTPInternalServerError("Unable to access bucket: " + err.Error())
+	}
+
+	createdStorage, err := g.backendRepo.SetWorkspaceReplicaStorage(ctx.Request().Context(), workspace.Id, *storage)
+	if err != nil {
+		return HTTPInternalServerError("Unable to create replica storage")
+	}
+
+	return ctx.JSON(http.StatusCreated, createdStorage)
+}
+
 // CreateWorkspaceStorage creates a new bucket in the configured default storage provider.
 // It then creates a new workspace storage object for that bucket and sets it as the storage bucket for the workspace.
 func (g *WorkspaceGroup) CreateWorkspaceStorage(ctx echo.Context) error {
@@ -231,12 +312,13 @@ func (g *WorkspaceGroup) setupDefaultWorkspaceStorage(ctx echo.Context, bucketNa
 	return createdStorage, err
 }
 
-// revokeTokenIfPresent revokes the token found in the Authorization header, if present.
+// revokeTokenIfPresent drops the cached copy of the token found in the Authorization header, if present,
+// so its workspace's new storage is picked up on its next use.
 func (g *WorkspaceGroup) revokeTokenIfPresent(ctx echo.Context) error {
 	authHeader := ctx.Request().Header.Get("Authorization")
 	tokenKey := strings.TrimPrefix(authHeader, "Bearer ")
 	if tokenKey != "" {
-		err := g.workspaceRepo.RevokeToken(tokenKey)
+		err := g.workspaceRepo.InvalidateToken(tokenKey)
 		if err != nil {
 			ctx.Logger().Errorf("Failed to revoke token %s after storage update: %v", tokenKey, err)
 			return err
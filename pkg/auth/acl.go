@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+// HasResourceAccess reports whether the caller can use a resource with the given access control entries.
+// Resources without entries are open to the whole workspace. Once a resource has entries, only the tokens and
+// roles they name can use it, apart from workspace admins, who can always use it and manage its entries.
+//
+// Entries only narrow what the caller's role allows, they never grant access the role doesn't have.
+func HasResourceAccess(authInfo *AuthInfo, acl []types.AccessControlEntry, access types.ResourceAccess) bool {
+	if len(acl) == 0 {
+		return true
+	}
+
+	if authInfo == nil || authInfo.Token == nil {
+		return false
+	}
+
+	if HasPermission(authInfo, types.PermissionAdmin) {
+		return true
+	}
+
+	for _, entry := range acl {
+		if !entry.Access.Allows(access) {
+			continue
+		}
+
+		switch entry.PrincipalType {
+		case types.ACLPrincipalToken:
+			if entry.Principal == authInfo.Token.ExternalId {
+				return true
+			}
+		case types.ACLPrincipalRole:
+			if entry.Principal == string(authInfo.Token.WorkspaceRole()) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
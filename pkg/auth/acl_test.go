@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+func TestHasResourceAccess(t *testing.T) {
+	withToken := func(externalId string, role types.WorkspaceRole) *AuthInfo {
+		return &AuthInfo{Token: &types.Token{ExternalId: externalId, TokenType: types.TokenTypeWorkspace, Role: string(role)}}
+	}
+
+	developer := withToken("tok-dev", types.WorkspaceRoleDeveloper)
+	reader := withToken("tok-reader", types.WorkspaceRoleReadOnly)
+	admin := withToken("tok-admin", types.WorkspaceRoleAdmin)
+
+	// Resources without entries are open to the workspace
+	assert.True(t, HasResourceAccess(developer, nil, types.ResourceAccessWrite))
+	assert.True(t, HasResourceAccess(&AuthInfo{}, nil, types.ResourceAccessRead))
+
+	acl := []types.AccessControlEntry{
+		{PrincipalType: types.ACLPrincipalToken, Principal: "tok-dev", Access: types.ResourceAccessWrite},
+		{PrincipalType: types.ACLPrincipalRole, Principal: string(types.WorkspaceRoleReadOnly), Access: types.ResourceAccessRead},
+	}
+
+	assert.True(t, HasResourceAccess(developer, acl, types.ResourceAccessRead))
+	assert.True(t, HasResourceAccess(developer, acl, types.ResourceAccessWrite))
+	assert.True(t, HasResourceAccess(reader, acl, types.ResourceAccessRead))
+	assert.False(t, HasResourceAccess(reader, acl, types.ResourceAccessWrite))
+	assert.False(t, HasResourceAccess(withToken("tok-other", types.WorkspaceRoleDeveloper), acl, types.ResourceAccessRead))
+	assert.False(t, HasResourceAccess(&AuthInfo{}, acl, types.ResourceAccessRead))
+
+	// Admins can always use resources, so they can manage their entries
+	assert.True(t, HasResourceAccess(admin, acl, types.ResourceAccessWrite))
+}
@@ -0,0 +1,238 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/repository"
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+// Entries waiting to be written. RPCs never wait on the audit store, so entries are dropped when it falls this
+// far behind.
+const auditLogQueueSize = 1000
+
+// AuditInterceptor records every mutating RPC to the audit log once it's handled. It has to run after the
+// AuthInterceptor, since entries are attributed to the token the RPC was made with.
+type AuditInterceptor struct {
+	backendRepo repository.BackendRepository
+	entries     chan types.AuditLogEntry
+}
+
+func NewAuditInterceptor(ctx context.Context, backendRepo repository.BackendRepository) *AuditInterceptor {
+	ai := &AuditInterceptor{
+		backendRepo: backendRepo,
+		entries:     make(chan types.AuditLogEntry, auditLogQueueSize),
+	}
+
+	go ai.write(ctx)
+	return ai
+}
+
+func (ai *AuditInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !IsMutatingMethod(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		ctx, caller := withAuditCaller(ctx)
+		resp, err := handler(ctx, req)
+		ai.record(ctx, caller, info.FullMethod, req, resp, err)
+		return resp, err
+	}
+}
+
+func (ai *AuditInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !IsMutatingMethod(info.FullMethod) {
+			return handler(srv, stream)
+		}
+
+		ctx, caller := withAuditCaller(stream.Context())
+		recorded := &auditedStream{ServerStream: stream, ctx: ctx}
+		err := handler(srv, recorded)
+		ai.record(ctx, caller, info.FullMethod, recorded.firstMessage(), nil, err)
+		return err
+	}
+}
+
+// auditedStream keeps the first message a client sends, which is the request for server streams and carries
+// the ids of what's being changed for client streams
+type auditedStream struct {
+	grpc.ServerStream
+	ctx   context.Context
+	mu    sync.Mutex
+	first interface{}
+}
+
+func (s *auditedStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *auditedStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.mu.Lock()
+		if s.first == nil {
+			s.first = m
+		}
+		s.mu.Unlock()
+	}
+
+	return err
+}
+
+func (s *auditedStream) firstMessage() interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.first
+}
+
+func (ai *AuditInterceptor) record(ctx context.Context, caller *auditCaller, method string, req interface{}, resp interface{}, err error) {
+	authInfo, ok := AuthInfoFromContext(ctx)
+	if !ok || authInfo == nil {
+		authInfo = caller.get()
+	}
+
+	if authInfo == nil || authInfo.Workspace == nil || authInfo.Token == nil {
+		return
+	}
+
+	entry := types.AuditLogEntry{
+		WorkspaceId: authInfo.Workspace.Id,
+		TokenId:     authInfo.Token.ExternalId,
+		Method:      method,
+		ResourceIds: auditResourceIds(req),
+		Outcome:     string(common.AuditOutcomeSuccess),
+	}
+
+	if err != nil {
+		entry.Outcome = string(common.AuditOutcomeFailure)
+		entry.StatusCode = status.Code(err).String()
+		entry.Error = status.Convert(err).Message()
+	} else if errMsg, failed := auditResponseFailure(resp); failed {
+		entry.Outcome = string(common.AuditOutcomeFailure)
+		entry.Error = errMsg
+	}
+
+	select {
+	case ai.entries <- entry:
+	default:
+		log.Error().Str("method", method).Str("workspace_id", authInfo.Workspace.ExternalId).Msg("audit log queue is full, dropping entry")
+	}
+}
+
+type auditCallerKey struct{}
+
+// auditCaller is who an unauthenticated RPC turned out to be made by, e.g. the token an invite was accepted
+// with, so entries for RPCs that authenticate their callers themselves can still be attributed
+type auditCaller struct {
+	mu       sync.Mutex
+	authInfo *AuthInfo
+}
+
+func withAuditCaller(ctx context.Context) (context.Context, *auditCaller) {
+	caller := &auditCaller{}
+	return context.WithValue(ctx, auditCallerKey{}, caller), caller
+}
+
+func (c *auditCaller) get() *AuthInfo {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.authInfo
+}
+
+// SetAuditCaller attributes the audit entry of an unauthenticated RPC to the given token and workspace. It does
+// nothing if the RPC isn't audited.
+func SetAuditCaller(ctx context.Context, authInfo *AuthInfo) {
+	caller, ok := ctx.Value(auditCallerKey{}).(*auditCaller)
+	if !ok {
+		return
+	}
+
+	caller.mu.Lock()
+	defer caller.mu.Unlock()
+	caller.authInfo = authInfo
+}
+
+func (ai *AuditInterceptor) write(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case entry := <-ai.entries:
+			if err := ai.backendRepo.CreateAuditLogEntry(ctx, entry); err != nil {
+				log.Error().Err(err).Str("method", entry.Method).Msg("failed to write audit log entry")
+			}
+		}
+	}
+}
+
+// auditResourceIds collects the ids a request refers to: its string fields named id or ending in _id, and the
+// string lists ending in _ids
+func auditResourceIds(req interface{}) types.AuditResourceIds {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return nil
+	}
+
+	ids := types.AuditResourceIds{}
+	msg.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.Kind() != protoreflect.StringKind {
+			return true
+		}
+
+		name := string(fd.Name())
+		switch {
+		case fd.IsList() && strings.HasSuffix(name, "_ids"):
+			list := v.List()
+			values := make([]string, 0, list.Len())
+			for i := 0; i < list.Len(); i++ {
+				values = append(values, list.Get(i).String())
+			}
+			ids[name] = strings.Join(values, ",")
+		case !fd.IsList() && !fd.IsMap() && (name == "id" || strings.HasSuffix(name, "_id")):
+			ids[name] = v.String()
+		}
+
+		return true
+	})
+
+	return ids
+}
+
+// auditResponseFailure reports whether a response says the RPC failed. Most RPCs return failures as ok: false
+// with a message rather than as errors.
+func auditResponseFailure(resp interface{}) (string, bool) {
+	msg, ok := resp.(proto.Message)
+	if !ok || msg == nil {
+		return "", false
+	}
+
+	m := msg.ProtoReflect()
+	if !m.IsValid() {
+		return "", false
+	}
+
+	fields := m.Descriptor().Fields()
+	okField := fields.ByName("ok")
+	if okField == nil || okField.Kind() != protoreflect.BoolKind || m.Get(okField).Bool() {
+		return "", false
+	}
+
+	for _, name := range []protoreflect.Name{"err_msg", "error_msg"} {
+		if fd := fields.ByName(name); fd != nil && fd.Kind() == protoreflect.StringKind {
+			return m.Get(fd).String(), true
+		}
+	}
+
+	return "", true
+}
@@ -0,0 +1,280 @@
+package auth
+
+import "strings"
+
+// unauditedServices are called by workers, other cluster components and health checks rather than by users, so
+// none of their RPCs are audited
+var unauditedServices = map[string]bool{
+	"BackendRepositoryService":                 true,
+	"ContainerRepositoryService":               true,
+	"WorkerCertificateService":                 true,
+	"WorkerRepositoryService":                  true,
+	"container.ContainerService":               true,
+	"providers.ProviderPlugin":                 true,
+	"scheduler.Scheduler":                      true,
+	"grpc.health.v1.Health":                    true,
+	"grpc.reflection.v1.ServerReflection":      true,
+	"grpc.reflection.v1alpha.ServerReflection": true,
+}
+
+// readOnlyMethods are the user facing RPCs that don't change anything, so they're left out of the audit log
+var readOnlyMethods = map[string]bool{
+	"/gateway.GatewayService/Authorize":                true,
+	"/gateway.GatewayService/HeadObject":               true,
+	"/gateway.GatewayService/ListObjects":              true,
+	"/gateway.GatewayService/GetObjectSignature":       true,
+	"/gateway.GatewayService/GetObjectURL":             true,
+	"/gateway.GatewayService/GetObjectStream":          true,
+	"/gateway.GatewayService/ListCorruptedObjects":     true,
+	"/gateway.GatewayService/GetObjectLifecyclePolicy": true,
+	"/gateway.GatewayService/ListObjectVersions":       true,
+	"/gateway.GatewayService/GetStorageUsage":          true,
+	"/gateway.GatewayService/ListWebhooks":             true,
+	"/gateway.GatewayService/ListTaskTriggers":         true,
+	"/gateway.GatewayService/GetWorkspacePolicy":       true,
+	"/gateway.GatewayService/ListPoolQuotas":           true,
+	"/gateway.GatewayService/ListCapacityReservations": true,
+	"/gateway.GatewayService/ListContainers":           true,
+	"/gateway.GatewayService/StreamLogs":               true,
+	"/gateway.GatewayService/ListTasks":                true,
+	"/gateway.GatewayService/GetTaskRetryPolicy":       true,
+	"/gateway.GatewayService/GetURL":                   true,
+	"/gateway.GatewayService/ListDeployments":          true,
+	"/gateway.GatewayService/ListDeploymentEnv":        true,
+	"/gateway.GatewayService/ListCustomDomains":        true,
+	"/gateway.GatewayService/ListDeploymentHistory":    true,
+	"/gateway.GatewayService/GetAutoscalingPolicy":     true,
+	"/gateway.GatewayService/GetKeepWarm":              true,
+	"/gateway.GatewayService/ListPools":                true,
+	"/gateway.GatewayService/ListMachines":             true,
+	"/gateway.GatewayService/ListWorkers":              true,
+	"/gateway.GatewayService/SimulateScheduling":       true,
+	"/gateway.GatewayService/ExportWorkspaceConfig":    true,
+
+	"/image.ImageService/VerifyImageBuild": true,
+
+	"/map.MapService/MapGet":   true,
+	"/map.MapService/MapCount": true,
+	"/map.MapService/MapKeys":  true,
+
+	"/output.OutputService/OutputStat":               true,
+	"/output.OutputService/OutputPublicURL":          true,
+	"/output.OutputService/GetOutputRetentionPolicy": true,
+
+	"/pod.PodService/SandboxStatus":            true,
+	"/pod.PodService/SandboxStdout":            true,
+	"/pod.PodService/SandboxStderr":            true,
+	"/pod.PodService/SandboxListProcesses":     true,
+	"/pod.PodService/SandboxDownloadFile":      true,
+	"/pod.PodService/SandboxStatFile":          true,
+	"/pod.PodService/SandboxListFiles":         true,
+	"/pod.PodService/SandboxFindInFiles":       true,
+	"/pod.PodService/SandboxListUrls":          true,
+	"/pod.PodService/SandboxWaitForCompletion": true,
+
+	"/simplequeue.SimpleQueueService/SimpleQueuePeek": true,
+	"/simplequeue.SimpleQueueService/SimpleQueueSize": true,
+
+	"/secret.SecretService/GetSecret":        true,
+	"/secret.SecretService/ListSecrets":      true,
+	"/secret.SecretService/GetSecretVersion": true,
+
+	"/signal.SignalService/SignalMonitor": true,
+
+	"/taskqueue.TaskQueueService/TaskQueueLength": true,
+
+	"/volume.VolumeService/ListVolumes":        true,
+	"/volume.VolumeService/ListPath":           true,
+	"/volume.VolumeService/StatPath":           true,
+	"/volume.VolumeService/ListSnapshots":      true,
+	"/volume.VolumeService/GetVolumeUsage":     true,
+	"/volume.VolumeService/ExportVolume":       true,
+	"/volume.VolumeService/GetFileServiceInfo": true,
+}
+
+// mutatingMethods are the user facing RPCs that change state in a workspace, which are all audited
+var mutatingMethods = map[string]bool{
+	"/bot.BotService/PopBotTask":           true,
+	"/bot.BotService/PushBotMarkers":       true,
+	"/bot.BotService/PushBotEvent":         true,
+	"/bot.BotService/PushBotEventBlocking": true,
+
+	"/endpoint.EndpointService/StartEndpointServe": true,
+
+	"/function.FunctionService/FunctionInvoke":    true,
+	"/function.FunctionService/FunctionGetArgs":   true,
+	"/function.FunctionService/FunctionSetResult": true,
+	"/function.FunctionService/FunctionMonitor":   true,
+	"/function.FunctionService/FunctionSchedule":  true,
+
+	"/gateway.GatewayService/SignPayload":               true,
+	"/gateway.GatewayService/CreateObject":              true,
+	"/gateway.GatewayService/PutObjectStream":           true,
+	"/gateway.GatewayService/PutObjectStreamV2":         true,
+	"/gateway.GatewayService/PatchObject":               true,
+	"/gateway.GatewayService/RenameObject":              true,
+	"/gateway.GatewayService/TagObject":                 true,
+	"/gateway.GatewayService/GetResourceACL":            true,
+	"/gateway.GatewayService/SetResourceACL":            true,
+	"/gateway.GatewayService/MigrateObjects":            true,
+	"/gateway.GatewayService/CompleteObject":            true,
+	"/gateway.GatewayService/DeleteObject":              true,
+	"/gateway.GatewayService/DeleteObjects":             true,
+	"/gateway.GatewayService/LockObject":                true,
+	"/gateway.GatewayService/CheckObjectConsistency":    true,
+	"/gateway.GatewayService/SetObjectLifecyclePolicy":  true,
+	"/gateway.GatewayService/RestoreObjectVersion":      true,
+	"/gateway.GatewayService/CopyObject":                true,
+	"/gateway.GatewayService/ExtractObject":             true,
+	"/gateway.GatewayService/InitiateUpload":            true,
+	"/gateway.GatewayService/AppendChunk":               true,
+	"/gateway.GatewayService/CompleteUpload":            true,
+	"/gateway.GatewayService/CreateWebhook":             true,
+	"/gateway.GatewayService/DeleteWebhook":             true,
+	"/gateway.GatewayService/CreateTaskTrigger":         true,
+	"/gateway.GatewayService/DeleteTaskTrigger":         true,
+	"/gateway.GatewayService/SetUploadBandwidthLimit":   true,
+	"/gateway.GatewayService/SetWorkspaceVolumeQuota":   true,
+	"/gateway.GatewayService/SetWorkspacePolicy":        true,
+	"/gateway.GatewayService/SetPoolQuota":              true,
+	"/gateway.GatewayService/ReserveCapacity":           true,
+	"/gateway.GatewayService/CancelCapacityReservation": true,
+	"/gateway.GatewayService/GetWorkspaceIPAllowList":   true,
+	"/gateway.GatewayService/SetWorkspaceIPAllowList":   true,
+	"/gateway.GatewayService/CheckpointContainer":       true,
+	"/gateway.GatewayService/StopContainer":             true,
+	"/gateway.GatewayService/AttachToContainer":         true,
+	"/gateway.GatewayService/ExecInContainer":           true,
+	"/gateway.GatewayService/PortForward":               true,
+	"/gateway.GatewayService/StartTask":                 true,
+	"/gateway.GatewayService/EndTask":                   true,
+	"/gateway.GatewayService/StopTasks":                 true,
+	"/gateway.GatewayService/CancelTasks":               true,
+	"/gateway.GatewayService/RequeueTasks":              true,
+	"/gateway.GatewayService/SetTaskRetryPolicy":        true,
+	"/gateway.GatewayService/GetOrCreateStub":           true,
+	"/gateway.GatewayService/DeployStub":                true,
+	"/gateway.GatewayService/StopDeployment":            true,
+	"/gateway.GatewayService/StartDeployment":           true,
+	"/gateway.GatewayService/ScaleDeployment":           true,
+	"/gateway.GatewayService/DeleteDeployment":          true,
+	"/gateway.GatewayService/RollbackDeployment":        true,
+	"/gateway.GatewayService/SetDeploymentEnv":          true,
+	"/gateway.GatewayService/UnsetDeploymentEnv":        true,
+	"/gateway.GatewayService/AddCustomDomain":           true,
+	"/gateway.GatewayService/RemoveCustomDomain":        true,
+	"/gateway.GatewayService/SetTrafficSplit":           true,
+	"/gateway.GatewayService/PromoteVersion":            true,
+	"/gateway.GatewayService/SetAutoscalingPolicy":      true,
+	"/gateway.GatewayService/ReportAutoscalingMetrics":  true,
+	"/gateway.GatewayService/SetKeepWarm":               true,
+	"/gateway.GatewayService/CreateMachine":             true,
+	"/gateway.GatewayService/DeleteMachine":             true,
+	"/gateway.GatewayService/ListTokens":                true,
+	"/gateway.GatewayService/CreateToken":               true,
+	"/gateway.GatewayService/ToggleToken":               true,
+	"/gateway.GatewayService/DeleteToken":               true,
+	"/gateway.GatewayService/SetTokenRateLimit":         true,
+	"/gateway.GatewayService/RotateToken":               true,
+	"/gateway.GatewayService/ExchangeSessionToken":      true,
+	"/gateway.GatewayService/RefreshSessionToken":       true,
+	"/gateway.GatewayService/RevokeSessionToken":        true,
+	"/gateway.GatewayService/ListMemberRoles":           true,
+	"/gateway.GatewayService/SetMemberRole":             true,
+	"/gateway.GatewayService/InviteMember":              true,
+	"/gateway.GatewayService/AcceptInvite":              true,
+	"/gateway.GatewayService/RevokeInvite":              true,
+	"/gateway.GatewayService/CreateServiceAccount":      true,
+	"/gateway.GatewayService/ListServiceAccounts":       true,
+	"/gateway.GatewayService/DeleteServiceAccount":      true,
+	"/gateway.GatewayService/IssueServiceAccountToken":  true,
+	"/gateway.GatewayService/RenewServiceAccountToken":  true,
+	"/gateway.GatewayService/RevokeServiceAccountToken": true,
+	"/gateway.GatewayService/CordonWorker":              true,
+	"/gateway.GatewayService/UncordonWorker":            true,
+	"/gateway.GatewayService/DrainWorker":               true,
+	"/gateway.GatewayService/SetWorkerLabels":           true,
+	"/gateway.GatewayService/GrantResourceAccess":       true,
+	"/gateway.GatewayService/ListResourceGrants":        true,
+	"/gateway.GatewayService/QueryAuditLog":             true,
+
+	"/image.ImageService/BuildImage": true,
+
+	"/map.MapService/MapSet":    true,
+	"/map.MapService/MapDelete": true,
+
+	"/output.OutputService/OutputSaveStream":         true,
+	"/output.OutputService/SetOutputRetentionPolicy": true,
+
+	"/pod.PodService/CreatePod":                        true,
+	"/pod.PodService/SandboxExec":                      true,
+	"/pod.PodService/SandboxKill":                      true,
+	"/pod.PodService/SandboxUploadFile":                true,
+	"/pod.PodService/SandboxDeleteFile":                true,
+	"/pod.PodService/SandboxCreateDirectory":           true,
+	"/pod.PodService/SandboxDeleteDirectory":           true,
+	"/pod.PodService/SandboxExposePort":                true,
+	"/pod.PodService/SandboxUpdateNetworkPermissions":  true,
+	"/pod.PodService/SandboxReplaceInFiles":            true,
+	"/pod.PodService/SandboxConnect":                   true,
+	"/pod.PodService/SandboxUpdateTTL":                 true,
+	"/pod.PodService/SandboxCreateImageFromFilesystem": true,
+	"/pod.PodService/SandboxSnapshotMemory":            true,
+
+	"/simplequeue.SimpleQueueService/SimpleQueuePut":   true,
+	"/simplequeue.SimpleQueueService/SimpleQueuePop":   true,
+	"/simplequeue.SimpleQueueService/SimpleQueueEmpty": true,
+
+	"/secret.SecretService/CreateSecret": true,
+	"/secret.SecretService/DeleteSecret": true,
+	"/secret.SecretService/UpdateSecret": true,
+	"/secret.SecretService/RotateSecret": true,
+
+	"/shell.ShellService/CreateStandaloneShell":          true,
+	"/shell.ShellService/CreateShellInExistingContainer": true,
+
+	"/signal.SignalService/SignalSet":   true,
+	"/signal.SignalService/SignalClear": true,
+
+	"/taskqueue.TaskQueueService/TaskQueuePut":        true,
+	"/taskqueue.TaskQueueService/TaskQueuePop":        true,
+	"/taskqueue.TaskQueueService/TaskQueueMonitor":    true,
+	"/taskqueue.TaskQueueService/TaskQueueComplete":   true,
+	"/taskqueue.TaskQueueService/StartTaskQueueServe": true,
+
+	"/volume.VolumeService/GetOrCreateVolume":       true,
+	"/volume.VolumeService/DeleteVolume":            true,
+	"/volume.VolumeService/DeletePath":              true,
+	"/volume.VolumeService/CopyPathStream":          true,
+	"/volume.VolumeService/MovePath":                true,
+	"/volume.VolumeService/SnapshotVolume":          true,
+	"/volume.VolumeService/RestoreVolume":           true,
+	"/volume.VolumeService/SetVolumeQuota":          true,
+	"/volume.VolumeService/ImportVolume":            true,
+	"/volume.VolumeService/ShareVolume":             true,
+	"/volume.VolumeService/CreatePresignedURL":      true,
+	"/volume.VolumeService/CreateMultipartUpload":   true,
+	"/volume.VolumeService/CompleteMultipartUpload": true,
+	"/volume.VolumeService/AbortMultipartUpload":    true,
+}
+
+// IsMutatingMethod reports whether an RPC is recorded in the audit log. RPCs that haven't been classified are
+// treated as mutating, so a new RPC is audited until it's listed as read only.
+func IsMutatingMethod(method string) bool {
+	if unauditedServices[methodService(method)] || readOnlyMethods[method] {
+		return false
+	}
+
+	return true
+}
+
+// isClassifiedMethod reports whether an RPC has been explicitly listed as audited or not
+func isClassifiedMethod(method string) bool {
+	return unauditedServices[methodService(method)] || readOnlyMethods[method] || mutatingMethods[method]
+}
+
+// methodService returns the service part of a full method name, e.g. gateway.GatewayService
+func methodService(method string) string {
+	service, _, _ := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+	return service
+}
@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+)
+
+func TestIsMutatingMethod(t *testing.T) {
+	assert.True(t, IsMutatingMethod("/gateway.GatewayService/DeleteObject"))
+	assert.True(t, IsMutatingMethod("/gateway.GatewayService/CreateToken"))
+	assert.True(t, IsMutatingMethod("/gateway.GatewayService/AcceptInvite"))
+	assert.True(t, IsMutatingMethod("/gateway.GatewayService/RefreshSessionToken"))
+	assert.True(t, IsMutatingMethod("/gateway.GatewayService/RevokeSessionToken"))
+	assert.True(t, IsMutatingMethod("/gateway.GatewayService/SetPoolQuota"))
+	assert.True(t, IsMutatingMethod("/gateway.GatewayService/ReserveCapacity"))
+	assert.True(t, IsMutatingMethod("/gateway.GatewayService/CancelCapacityReservation"))
+	assert.False(t, IsMutatingMethod("/gateway.GatewayService/ListObjects"))
+	assert.False(t, IsMutatingMethod("/gateway.GatewayService/QueryAuditLog"))
+	assert.False(t, IsMutatingMethod("/scheduler.Scheduler/RunContainer"))
+	assert.False(t, IsMutatingMethod("/grpc.health.v1.Health/Check"))
+
+	// Methods nobody classified are audited
+	assert.True(t, IsMutatingMethod("/gateway.GatewayService/SomeNewMethod"))
+}
+
+func TestEveryMethodIsClassifiedForAudit(t *testing.T) {
+	count := 0
+	protoregistry.GlobalFiles.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		options, ok := fd.Options().(*descriptorpb.FileOptions)
+		if !ok || options.GetGoPackage() != "github.com/beam-cloud/beta9/proto" {
+			return true
+		}
+
+		services := fd.Services()
+		for i := 0; i < services.Len(); i++ {
+			methods := services.Get(i).Methods()
+			for j := 0; j < methods.Len(); j++ {
+				method := fmt.Sprintf("/%s/%s", services.Get(i).FullName(), methods.Get(j).Name())
+				assert.True(t, isClassifiedMethod(method), "%s is not classified as audited or not", method)
+				count++
+			}
+		}
+
+		return true
+	})
+
+	assert.NotZero(t, count)
+}
+
+func TestAuditCaller(t *testing.T) {
+	authInfo := &AuthInfo{Token: &types.Token{ExternalId: "tok-1"}, Workspace: &types.Workspace{Id: 1}}
+
+	// Without the interceptor there's nothing to attribute
+	SetAuditCaller(context.Background(), authInfo)
+
+	ctx, caller := withAuditCaller(context.Background())
+	assert.Nil(t, caller.get())
+
+	SetAuditCaller(ctx, authInfo)
+	assert.Equal(t, authInfo, caller.get())
+}
+
+func TestAuditResourceIds(t *testing.T) {
+	assert.Equal(t, types.AuditResourceIds{"token_id": "tok-1"}, auditResourceIds(&pb.DeleteTokenRequest{TokenId: "tok-1"}))
+	assert.Equal(t, types.AuditResourceIds{"task_ids": "a,b"}, auditResourceIds(&pb.StopTasksRequest{TaskIds: []string{"a", "b"}}))
+	assert.Equal(t, types.AuditResourceIds{}, auditResourceIds(&pb.DeleteTokenRequest{}))
+	assert.Nil(t, auditResourceIds(nil))
+}
+
+func TestAuditResponseFailure(t *testing.T) {
+	errMsg, failed := auditResponseFailure(&pb.StopTasksResponse{Ok: false, ErrMsg: "Unauthorized Access"})
+	assert.True(t, failed)
+	assert.Equal(t, "Unauthorized Access", errMsg)
+
+	_, failed = auditResponseFailure(&pb.StopTasksResponse{Ok: true})
+	assert.False(t, failed)
+
+	_, failed = auditResponseFailure((*pb.StopTasksResponse)(nil))
+	assert.False(t, failed)
+
+	_, failed = auditResponseFailure(&pb.ExportWorkspaceConfigResponse{})
+	assert.False(t, failed)
+}
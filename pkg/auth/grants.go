@@ -0,0 +1,27 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/beam-cloud/beta9/pkg/repository"
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+// AuthorizeResource reports whether a workspace may use a resource owned by the workspace with ownerWorkspaceId.
+// Workspaces can always use their own resources, other workspaces need an unexpired grant on it.
+func AuthorizeResource(ctx context.Context, backendRepo repository.BackendRepository, workspace *types.Workspace, ownerWorkspaceId uint, resourceType types.ResourceGrantType, resourceId string) (bool, error) {
+	if workspace == nil {
+		return false, nil
+	}
+
+	if workspace.Id == ownerWorkspaceId {
+		return true, nil
+	}
+
+	grant, err := backendRepo.GetResourceGrant(ctx, ownerWorkspaceId, workspace.Id, resourceType, resourceId)
+	if err != nil {
+		return false, err
+	}
+
+	return grant != nil, nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/beam-cloud/beta9/pkg/types"
 
 	"github.com/beam-cloud/beta9/pkg/repository"
+	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -18,6 +19,9 @@ var authContextKey = "auth"
 type AuthInfo struct {
 	Workspace *types.Workspace
 	Token     *types.Token
+
+	// Session is the id of the session the request was made with, if it used a session token
+	Session string
 }
 
 func AuthInfoFromContext(ctx context.Context) (*AuthInfo, bool) {
@@ -29,14 +33,26 @@ type AuthInterceptor struct {
 	unauthenticatedMethods map[string]bool
 	backendRepo            repository.BackendRepository
 	workspaceRepo          repository.WorkspaceRepository
+	oidc                   *OIDCAuthenticator
+	serviceAccounts        *serviceAccountTracker
+	allowList              *ipAllowListChecker
+	sessions               *SessionManager
 }
 
-func NewAuthInterceptor(config types.AppConfig, backendRepo repository.BackendRepository, workspaceRepo repository.WorkspaceRepository) *AuthInterceptor {
+// NewAuthInterceptor returns an interceptor that authenticates requests with workspace tokens, or with ID tokens
+// from the identity provider if oidc isn't nil
+func NewAuthInterceptor(config types.AppConfig, backendRepo repository.BackendRepository, workspaceRepo repository.WorkspaceRepository, oidc *OIDCAuthenticator) *AuthInterceptor {
 	return &AuthInterceptor{
-		backendRepo:   backendRepo,
-		workspaceRepo: workspaceRepo,
+		backendRepo:     backendRepo,
+		workspaceRepo:   workspaceRepo,
+		oidc:            oidc,
+		serviceAccounts: newServiceAccountTracker(backendRepo),
+		allowList:       newIPAllowListChecker(config.GatewayService.IPAllowList, backendRepo, workspaceRepo),
+		sessions:        NewSessionManager(config.GatewayService.SessionTokens, backendRepo, workspaceRepo),
 		unauthenticatedMethods: map[string]bool{
 			"/gateway.GatewayService/Authorize":                         true,
+			"/gateway.GatewayService/AcceptInvite":                      true,
+			"/gateway.GatewayService/RefreshSessionToken":               true,
 			"/grpc.health.v1.Health/Check":                              true,
 			"/grpc.reflection.v1.ServerReflection/ServerReflectionInfo": config.DebugMode,
 		},
@@ -44,20 +60,15 @@ func NewAuthInterceptor(config types.AppConfig, backendRepo repository.BackendRe
 }
 
 func (ai *AuthInterceptor) getToken(tokenKey string) (*types.Token, *types.Workspace, error) {
-	token, workspace, err := ai.workspaceRepo.AuthorizeToken(tokenKey)
-	if err != nil {
-		token, workspace, err = ai.backendRepo.AuthorizeToken(context.TODO(), tokenKey)
-		if err != nil {
-			return nil, nil, err
-		}
+	if IsSessionToken(tokenKey) {
+		return nil, nil, errSessionNotFound
+	}
 
-		err = ai.workspaceRepo.SetAuthorizationToken(token, workspace)
-		if err != nil {
-			return nil, nil, err
-		}
+	if ai.oidc != nil && IsIDToken(tokenKey) {
+		return ai.oidc.Authenticate(context.TODO(), tokenKey)
 	}
 
-	return token, workspace, nil
+	return getCachedToken(context.TODO(), ai.backendRepo, ai.workspaceRepo, tokenKey)
 }
 
 func (ai *AuthInterceptor) isAuthRequired(method string) bool {
@@ -73,24 +84,44 @@ func (ai *AuthInterceptor) validateToken(md metadata.MD) (*AuthInfo, bool) {
 
 	var token *types.Token
 	var workspace *types.Workspace
+	var session string
 	var err error
 
-	token, workspace, err = ai.getToken(tokenKey)
+	if ai.sessions != nil && IsSessionToken(tokenKey) {
+		token, workspace, session, err = ai.sessions.Authenticate(context.TODO(), tokenKey)
+	} else {
+		token, workspace, err = ai.getToken(tokenKey)
+	}
 	if err != nil {
 		return nil, false
 	}
 
 	// For now, restricted tokens should not be allowed to access grpc calls
-	if !token.Active || token.DisabledByClusterAdmin {
+	if !token.Active || token.DisabledByClusterAdmin || token.Expired() || isRevoked(ai.workspaceRepo, token) {
 		return nil, false
 	}
 
+	ai.serviceAccounts.touch(token)
+
 	return &AuthInfo{
 		Token:     token,
 		Workspace: workspace,
+		Session:   session,
 	}, true
 }
 
+// isRevoked reports whether a token is on the revocation list, which takes effect before cached copies of the
+// token expire. Tokens are refused if the list can't be checked.
+func isRevoked(workspaceRepo repository.WorkspaceRepository, token *types.Token) bool {
+	revoked, err := workspaceRepo.IsTokenRevoked(token.Key)
+	if err != nil {
+		log.Error().Err(err).Str("token_id", token.ExternalId).Msg("failed to check token revocation")
+		return true
+	}
+
+	return revoked
+}
+
 type wrappedStream struct {
 	grpc.ServerStream
 	ctx context.Context
@@ -115,6 +146,14 @@ func (ai *AuthInterceptor) Stream() grpc.StreamServerInterceptor {
 			return status.Errorf(codes.Unauthenticated, "invalid or missing token")
 		}
 
+		if ai.isAuthRequired(info.FullMethod) && !HasMethodScope(authInfo, info.FullMethod) {
+			return status.Errorf(codes.PermissionDenied, "token scopes do not allow %s", info.FullMethod)
+		}
+
+		if !ai.allowList.allowed(stream.Context(), authInfo, ai.allowList.grpcClientIP(stream.Context(), md)) {
+			return status.Errorf(codes.PermissionDenied, "client ip is not in the workspace's allowlist")
+		}
+
 		// Create a new context with the AuthInfo
 		ctxWithAuth := ai.newContextWithAuth(stream.Context(), authInfo)
 
@@ -144,6 +183,14 @@ func (ai *AuthInterceptor) Unary() grpc.UnaryServerInterceptor {
 			return nil, status.Errorf(codes.Unauthenticated, "invalid or missing token")
 		}
 
+		if ai.isAuthRequired(info.FullMethod) && !HasMethodScope(authInfo, info.FullMethod) {
+			return nil, status.Errorf(codes.PermissionDenied, "token scopes do not allow %s", info.FullMethod)
+		}
+
+		if !ai.allowList.allowed(ctx, authInfo, ai.allowList.grpcClientIP(ctx, md)) {
+			return nil, status.Errorf(codes.PermissionDenied, "client ip is not in the workspace's allowlist")
+		}
+
 		// Attach the auth info to context
 		ctx = ai.newContextWithAuth(ctx, authInfo)
 		return handler(ctx, req)
@@ -151,9 +198,24 @@ func (ai *AuthInterceptor) Unary() grpc.UnaryServerInterceptor {
 }
 
 func (ai *AuthInterceptor) newContextWithAuth(ctx context.Context, authInfo *AuthInfo) context.Context {
+	return ContextWithAuthInfo(ctx, authInfo)
+}
+
+// ContextWithAuthInfo attaches auth info to a context, for requests authenticated outside of the interceptors
+func ContextWithAuthInfo(ctx context.Context, authInfo *AuthInfo) context.Context {
 	return context.WithValue(ctx, authContextKey, authInfo)
 }
 
-func HasPermission(authInfo *AuthInfo) bool {
-	return authInfo.Token.TokenType != types.TokenTypeWorkspaceRestricted
+// HasPermission reports whether the caller's token grants the given permission in its workspace.
+// Restricted tokens are never allowed, cluster admin tokens always are and every other token is
+// limited to what its workspace role allows.
+func HasPermission(authInfo *AuthInfo, permission types.Permission) bool {
+	switch authInfo.Token.TokenType {
+	case types.TokenTypeWorkspaceRestricted:
+		return false
+	case types.TokenTypeClusterAdmin:
+		return true
+	}
+
+	return authInfo.Token.WorkspaceRole().Can(permission)
 }
@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/beam-cloud/beta9/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasPermission(t *testing.T) {
+	tests := []struct {
+		name       string
+		tokenType  string
+		role       string
+		permission types.Permission
+		want       bool
+	}{
+		{"tokens without a role are admins", types.TokenTypeWorkspace, "", types.PermissionAdmin, true},
+		{"admin can manage the workspace", types.TokenTypeWorkspace, "admin", types.PermissionAdmin, true},
+		{"developer can write", types.TokenTypeWorkspace, "developer", types.PermissionWrite, true},
+		{"developer can deploy", types.TokenTypeWorkspace, "developer", types.PermissionDeploy, true},
+		{"developer can't manage the workspace", types.TokenTypeWorkspace, "developer", types.PermissionAdmin, false},
+		{"read only can read", types.TokenTypeWorkspace, "read_only", types.PermissionRead, true},
+		{"read only can't write", types.TokenTypeWorkspace, "read_only", types.PermissionWrite, false},
+		{"read only can't deploy", types.TokenTypeWorkspace, "read_only", types.PermissionDeploy, false},
+		{"deploy only can deploy", types.TokenTypeWorkspace, "deploy_only", types.PermissionDeploy, true},
+		{"deploy only can't write", types.TokenTypeWorkspace, "deploy_only", types.PermissionWrite, false},
+		{"unknown roles have no permissions", types.TokenTypeWorkspace, "owner", types.PermissionRead, false},
+		{"restricted tokens have no permissions", types.TokenTypeWorkspaceRestricted, "admin", types.PermissionRead, false},
+		{"cluster admins have every permission", types.TokenTypeClusterAdmin, "read_only", types.PermissionAdmin, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authInfo := &AuthInfo{
+				Token: &types.Token{TokenType: tt.tokenType, Role: tt.role},
+			}
+
+			assert.Equal(t, tt.want, HasPermission(authInfo, tt.permission))
+		})
+	}
+}
+
+func TestHasMethodScope(t *testing.T) {
+	tests := []struct {
+		name   string
+		scopes types.TokenScopes
+		method string
+		want   bool
+	}{
+		{"tokens without scopes can call anything", nil, "/gateway.GatewayService/CreateToken", true},
+		{"write scope allows uploads", types.TokenScopes{"objects:write"}, "/gateway.GatewayService/PutObjectStream", true},
+		{"write scope allows reads", types.TokenScopes{"objects:write"}, "/gateway.GatewayService/HeadObject", true},
+		{"read scope doesn't allow uploads", types.TokenScopes{"objects:read"}, "/gateway.GatewayService/PutObjectStream", false},
+		{"scopes don't carry over to other resources", types.TokenScopes{"objects:admin"}, "/gateway.GatewayService/ListTasks", false},
+		{"either scope allows creating stubs", types.TokenScopes{"deployments:write"}, "/gateway.GatewayService/GetOrCreateStub", true},
+		{"workspace read scope allows listing pool quotas", types.TokenScopes{"workspace:read"}, "/gateway.GatewayService/ListPoolQuotas", true},
+		{"workspace write scope doesn't allow setting pool quotas", types.TokenScopes{"workspace:write"}, "/gateway.GatewayService/SetPoolQuota", false},
+		{"workspace admin scope allows reserving capacity", types.TokenScopes{"workspace:admin"}, "/gateway.GatewayService/ReserveCapacity", true},
+		{"workspace read scope doesn't allow cancelling reservations", types.TokenScopes{"workspace:read"}, "/gateway.GatewayService/CancelCapacityReservation", false},
+		{"unlisted methods are denied", types.TokenScopes{"workspace:admin"}, "/scheduler.Scheduler/RunContainer", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authInfo := &AuthInfo{
+				Token: &types.Token{TokenType: types.TokenTypeWorkspace, Scopes: tt.scopes},
+			}
+
+			assert.Equal(t, tt.want, HasMethodScope(authInfo, tt.method))
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"net"
 	"net/http"
 	"strings"
 
@@ -14,7 +15,15 @@ type HttpAuthContext struct {
 	AuthInfo *AuthInfo
 }
 
-func AuthMiddleware(backendRepo repository.BackendRepository, workspaceRepo repository.WorkspaceRepository) echo.MiddlewareFunc {
+func AuthMiddleware(backendRepo repository.BackendRepository, workspaceRepo repository.WorkspaceRepository, ipAllowList types.IPAllowListConfig) echo.MiddlewareFunc {
+	return OIDCAuthMiddleware(backendRepo, workspaceRepo, ipAllowList, nil)
+}
+
+// OIDCAuthMiddleware is AuthMiddleware that also accepts ID tokens from the identity provider, if oidc isn't nil
+func OIDCAuthMiddleware(backendRepo repository.BackendRepository, workspaceRepo repository.WorkspaceRepository, ipAllowList types.IPAllowListConfig, oidc *OIDCAuthenticator) echo.MiddlewareFunc {
+	serviceAccounts := newServiceAccountTracker(backendRepo)
+	allowList := newIPAllowListChecker(ipAllowList, backendRepo, workspaceRepo)
+
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			var tokenKey string
@@ -34,28 +43,46 @@ func AuthMiddleware(backendRepo repository.BackendRepository, workspaceRepo repo
 			var workspace *types.Workspace
 			var err error
 
-			token, workspace, err = workspaceRepo.AuthorizeToken(tokenKey)
-			if err != nil {
-				token, workspace, err = backendRepo.AuthorizeToken(c.Request().Context(), tokenKey)
+			if oidc != nil && IsIDToken(tokenKey) {
+				token, workspace, err = oidc.Authenticate(req.Context(), tokenKey)
 				if err != nil {
 					return echo.NewHTTPError(http.StatusUnauthorized)
 				}
-
-				err = workspaceRepo.SetAuthorizationToken(token, workspace)
+			} else {
+				token, workspace, err = workspaceRepo.AuthorizeToken(tokenKey)
 				if err != nil {
-					return echo.NewHTTPError(http.StatusInternalServerError)
+					token, workspace, err = backendRepo.AuthorizeToken(c.Request().Context(), tokenKey)
+					if err != nil {
+						return echo.NewHTTPError(http.StatusUnauthorized)
+					}
+
+					err = workspaceRepo.SetAuthorizationToken(token, workspace)
+					if err != nil {
+						return echo.NewHTTPError(http.StatusInternalServerError)
+					}
 				}
 			}
 
-			if !token.Active || token.DisabledByClusterAdmin {
+			if !token.Active || token.DisabledByClusterAdmin || token.Expired() || isRevoked(workspaceRepo, token) {
 				return echo.NewHTTPError(http.StatusUnauthorized)
 			}
 
+			serviceAccounts.touch(token)
+
+			// Scopes are checked per RPC, so scoped tokens can only be used with the gRPC API
+			if token.Scopes.Restricted() {
+				return echo.NewHTTPError(http.StatusForbidden, "scoped tokens can only be used with the gRPC API")
+			}
+
 			authInfo := &AuthInfo{
 				Token:     token,
 				Workspace: workspace,
 			}
 
+			if !allowList.allowed(req.Context(), authInfo, allowList.httpClientIP(req)) {
+				return echo.NewHTTPError(http.StatusForbidden, "client ip is not in the workspace's allowlist")
+			}
+
 			cc := &HttpAuthContext{c, authInfo}
 			return next(cc)
 		}
@@ -140,6 +167,22 @@ func WithStrictWorkspaceAuth(next func(ctx echo.Context) error) func(ctx echo.Co
 	}
 }
 
+// This limits an api endpoint to tokens with the admin role in the workspace, or cluster admins.
+func WithWorkspaceAdminAuth(next func(ctx echo.Context) error) func(ctx echo.Context) error {
+	return func(ctx echo.Context) error {
+		if err := verifyWorkspaceAuth(ctx, ctx.Param("workspaceId")); err != nil {
+			return err
+		}
+
+		cc, _ := ctx.(*HttpAuthContext)
+		if !HasPermission(cc.AuthInfo, types.PermissionAdmin) {
+			return echo.NewHTTPError(http.StatusUnauthorized)
+		}
+
+		return next(ctx)
+	}
+}
+
 func WithClusterAdminAuth(next func(ctx echo.Context) error) func(ctx echo.Context) error {
 	return func(ctx echo.Context) error {
 		cc, ok := ctx.(*HttpAuthContext)
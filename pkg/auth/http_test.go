@@ -45,6 +45,12 @@ func addTokenRow(
 				token.UpdatedAt.Time,
 			),
 		)
+
+	// Cluster admin tokens aren't subject to their workspace's IP allowlist, so it's only loaded for others
+	if token.TokenType != types.TokenTypeClusterAdmin {
+		mock.ExpectQuery("SELECT ip_allowlist FROM workspace").
+			WillReturnRows(sqlmock.NewRows([]string{"ip_allowlist"}).AddRow("{}"))
+	}
 }
 
 func mockBackendWithValidToken() MockDetails {
@@ -89,7 +95,7 @@ func TestAuthMiddleWare(t *testing.T) {
 	mockDetails := mockBackendWithValidToken()
 
 	e := echo.New()
-	e.Use(AuthMiddleware(mockDetails.backendRepo, mockDetails.workspaceRepo))
+	e.Use(AuthMiddleware(mockDetails.backendRepo, mockDetails.workspaceRepo, types.IPAllowListConfig{}))
 
 	// 1. Test with valid token
 	e.GET("/", func(ctx echo.Context) error {
@@ -144,6 +150,16 @@ func TestAuthMiddleWare(t *testing.T) {
 				mockDetails.mockRedis.FlushAll(context.Background())
 			},
 		},
+		{
+			name:           "Test with revoked token that was cached again",
+			tokenKey:       mockDetails.tokenForTest.Key,
+			expectedStatus: 401,
+			prepares: func() {
+				mockDetails.mockRedis.FlushAll(context.Background())
+				mockDetails.workspaceRepo.RevokeToken(mockDetails.tokenForTest.Key)
+				mockDetails.workspaceRepo.SetAuthorizationToken(&mockDetails.tokenForTest, mockDetails.tokenForTest.Workspace)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -168,7 +184,7 @@ func TestAuthMiddleWare(t *testing.T) {
 func TestWithAuth(t *testing.T) {
 	mockDetails := mockBackendWithValidToken()
 	e := echo.New()
-	e.Use(AuthMiddleware(mockDetails.backendRepo, mockDetails.workspaceRepo))
+	e.Use(AuthMiddleware(mockDetails.backendRepo, mockDetails.workspaceRepo, types.IPAllowListConfig{}))
 
 	e.GET("/", WithAuth(func(c echo.Context) error {
 		cc, ok := c.(*HttpAuthContext)
@@ -242,7 +258,7 @@ func TestWithAuth(t *testing.T) {
 func TestWithWorkspaceAuth(t *testing.T) {
 	mockDetails := mockBackendWithValidToken()
 	e := echo.New()
-	e.Use(AuthMiddleware(mockDetails.backendRepo, mockDetails.workspaceRepo))
+	e.Use(AuthMiddleware(mockDetails.backendRepo, mockDetails.workspaceRepo, types.IPAllowListConfig{}))
 
 	e.GET("/:workspaceId", WithWorkspaceAuth(func(c echo.Context) error {
 		if c.(*HttpAuthContext).AuthInfo.Token.TokenType == types.TokenTypeClusterAdmin {
@@ -352,7 +368,7 @@ func TestWithWorkspaceAuth(t *testing.T) {
 func TestWithClusterAdminAuth(t *testing.T) {
 	mockDetails := mockBackendWithValidToken()
 	e := echo.New()
-	e.Use(AuthMiddleware(mockDetails.backendRepo, mockDetails.workspaceRepo))
+	e.Use(AuthMiddleware(mockDetails.backendRepo, mockDetails.workspaceRepo, types.IPAllowListConfig{}))
 
 	e.GET("/", WithClusterAdminAuth(func(c echo.Context) error {
 		assert.NotNil(t, c.(*HttpAuthContext).AuthInfo.Token)
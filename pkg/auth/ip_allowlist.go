@@ -0,0 +1,168 @@
+package auth
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/beam-cloud/beta9/pkg/repository"
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+// ipAllowListChecker checks requests against their workspace's IP allowlist. Allowlists are cached in redis and
+// the cache is updated when they change, so every gateway enforces a new list on the next request.
+type ipAllowListChecker struct {
+	backendRepo      repository.BackendRepository
+	workspaceRepo    repository.WorkspaceRepository
+	trustedProxies   []*net.IPNet
+	internalNetworks []*net.IPNet
+}
+
+func newIPAllowListChecker(config types.IPAllowListConfig, backendRepo repository.BackendRepository, workspaceRepo repository.WorkspaceRepository) *ipAllowListChecker {
+	return &ipAllowListChecker{
+		backendRepo:      backendRepo,
+		workspaceRepo:    workspaceRepo,
+		trustedProxies:   ParseNetworks(config.TrustedProxies),
+		internalNetworks: ParseNetworks(config.InternalNetworks),
+	}
+}
+
+// IPAllowList checks requests to HTTP APIs that authenticate outside of the auth middleware, like the S3 API,
+// against their workspace's IP allowlist
+type IPAllowList struct {
+	checker *ipAllowListChecker
+}
+
+func NewIPAllowList(config types.IPAllowListConfig, backendRepo repository.BackendRepository, workspaceRepo repository.WorkspaceRepository) *IPAllowList {
+	return &IPAllowList{checker: newIPAllowListChecker(config, backendRepo, workspaceRepo)}
+}
+
+// AllowsHTTPRequest reports whether the caller's workspace can be used from the address the request came from
+func (l *IPAllowList) AllowsHTTPRequest(req *http.Request, authInfo *AuthInfo) bool {
+	return l.checker.allowed(req.Context(), authInfo, l.checker.httpClientIP(req))
+}
+
+// allowed reports whether the workspace can be used from ip. Cluster tokens and requests from inside the cluster
+// are always allowed, and if the allowlist can't be loaded the request is refused.
+func (c *ipAllowListChecker) allowed(ctx context.Context, authInfo *AuthInfo, ip net.IP) bool {
+	if authInfo.Workspace == nil {
+		return true
+	}
+
+	switch authInfo.Token.TokenType {
+	case types.TokenTypeClusterAdmin, types.TokenTypeWorker, types.TokenTypeMachine:
+		return true
+	}
+
+	if ip != nil && containsIP(c.internalNetworks, ip) {
+		return true
+	}
+
+	cidrs, err := c.allowList(ctx, authInfo.Workspace)
+	if err != nil {
+		log.Error().Err(err).Str("workspace_id", authInfo.Workspace.ExternalId).Msg("failed to load workspace ip allowlist")
+		return false
+	}
+
+	if len(cidrs) == 0 {
+		return true
+	}
+
+	return ip != nil && containsIP(ParseNetworks(cidrs), ip)
+}
+
+func (c *ipAllowListChecker) allowList(ctx context.Context, workspace *types.Workspace) ([]string, error) {
+	cidrs, cached, err := c.workspaceRepo.GetIPAllowListByWorkspaceId(workspace.ExternalId)
+	if err == nil && cached {
+		return cidrs, nil
+	}
+
+	cidrs, err = c.backendRepo.GetWorkspaceIPAllowList(ctx, workspace.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.workspaceRepo.SetIPAllowListByWorkspaceId(workspace.ExternalId, cidrs); err != nil {
+		log.Warn().Err(err).Str("workspace_id", workspace.ExternalId).Msg("failed to cache workspace ip allowlist")
+	}
+
+	return cidrs, nil
+}
+
+// grpcClientIP returns the address a gRPC request came from. The HTTP API reaches the gRPC server through a proxy
+// on loopback, which like trusted proxies reports the original address in x-forwarded-for.
+func (c *ipAllowListChecker) grpcClientIP(ctx context.Context, md metadata.MD) net.IP {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return nil
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+
+	return ClientIP(net.ParseIP(host), md.Get("x-forwarded-for"), c.trustedProxies)
+}
+
+func (c *ipAllowListChecker) httpClientIP(req *http.Request) net.IP {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		host = req.RemoteAddr
+	}
+
+	return ClientIP(net.ParseIP(host), req.Header.Values("X-Forwarded-For"), c.trustedProxies)
+}
+
+// ClientIP walks x-forwarded-for from the nearest hop back, returning the first address that isn't a trusted
+// proxy. Addresses further back than that could have been set by the client, so they're never used.
+func ClientIP(remote net.IP, forwardedFor []string, trustedProxies []*net.IPNet) net.IP {
+	var hops []string
+	for _, header := range forwardedFor {
+		for _, hop := range strings.Split(header, ",") {
+			hops = append(hops, strings.TrimSpace(hop))
+		}
+	}
+
+	ip := remote
+	for i := len(hops) - 1; i >= 0; i-- {
+		if ip == nil || !(ip.IsLoopback() || containsIP(trustedProxies, ip)) {
+			break
+		}
+
+		ip = net.ParseIP(hops[i])
+	}
+
+	return ip
+}
+
+// ParseNetworks parses CIDRs, skipping any that are invalid
+func ParseNetworks(cidrs []string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			log.Warn().Str("cidr", cidr).Msg("skipping invalid cidr")
+			continue
+		}
+
+		networks = append(networks, network)
+	}
+
+	return networks
+}
+
+func containsIP(networks []*net.IPNet, ip net.IP) bool {
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
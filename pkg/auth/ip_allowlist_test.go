@@ -0,0 +1,35 @@
+package auth
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientIP(t *testing.T) {
+	trusted := ParseNetworks([]string{"10.0.0.0/8"})
+
+	// Direct connections use the peer address, whatever they claim to be forwarded for
+	assert.Equal(t, "203.0.113.7", ClientIP(net.ParseIP("203.0.113.7"), []string{"198.51.100.1"}, trusted).String())
+
+	// Trusted proxies and the loopback gRPC proxy are skipped
+	assert.Equal(t, "198.51.100.1", ClientIP(net.ParseIP("10.1.2.3"), []string{"198.51.100.1"}, trusted).String())
+	assert.Equal(t, "198.51.100.1", ClientIP(net.ParseIP("127.0.0.1"), []string{"198.51.100.1, 10.4.5.6"}, trusted).String())
+
+	// Addresses before the first untrusted hop could be spoofed by the client
+	assert.Equal(t, "198.51.100.1", ClientIP(net.ParseIP("10.1.2.3"), []string{"192.0.2.9", "198.51.100.1"}, trusted).String())
+
+	// All hops trusted, the furthest one is the client
+	assert.Equal(t, "10.9.9.9", ClientIP(net.ParseIP("127.0.0.1"), []string{"10.9.9.9"}, trusted).String())
+
+	assert.Nil(t, ClientIP(net.ParseIP("127.0.0.1"), []string{"not-an-ip"}, trusted))
+}
+
+func TestParseNetworks(t *testing.T) {
+	networks := ParseNetworks([]string{"10.0.0.0/8", "bogus", "2001:db8::/32"})
+	assert.Len(t, networks, 2)
+	assert.True(t, containsIP(networks, net.ParseIP("10.200.0.1")))
+	assert.True(t, containsIP(networks, net.ParseIP("2001:db8::1")))
+	assert.False(t, containsIP(networks, net.ParseIP("192.168.0.1")))
+}
@@ -0,0 +1,411 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	expirable "github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/rs/zerolog/log"
+
+	"github.com/beam-cloud/beta9/pkg/repository"
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+const (
+	oidcDefaultGroupsClaim = "groups"
+	oidcHttpTimeout        = 10 * time.Second
+	// Unknown key ids make us fetch the provider's keys again, but no more often than this
+	oidcKeyRefreshInterval = time.Minute
+	oidcSessionCacheSize   = 10000
+	oidcSessionCacheTTL    = time.Minute
+)
+
+var (
+	errOIDCNoGroupMapping = errors.New("none of the user's groups are mapped to a workspace")
+	errOIDCUnknownKey     = errors.New("id token is signed with an unknown key")
+)
+
+// OIDCClaims are the parts of a verified ID token used to sign the user in
+type OIDCClaims struct {
+	Subject string
+	Email   string
+	Groups  []string
+}
+
+// OIDCAuthenticator signs users in with ID tokens from an OpenID Connect identity provider. The first time a
+// user signs in to a workspace they're provisioned a workspace token, which every later ID token of theirs acts
+// as. Their role follows their groups, so it's updated whenever the mapping for their groups changes.
+type OIDCAuthenticator struct {
+	config        types.OIDCConfig
+	backendRepo   repository.BackendRepository
+	workspaceRepo repository.WorkspaceRepository
+	keys          *oidcKeySet
+
+	// ID tokens that were recently signed in, by their hash, and the key of the token they act as
+	sessions *expirable.LRU[string, string]
+}
+
+// NewOIDCAuthenticator returns nil if OIDC isn't enabled
+func NewOIDCAuthenticator(config types.OIDCConfig, backendRepo repository.BackendRepository, workspaceRepo repository.WorkspaceRepository) *OIDCAuthenticator {
+	if !config.Enabled {
+		return nil
+	}
+
+	if config.GroupsClaim == "" {
+		config.GroupsClaim = oidcDefaultGroupsClaim
+	}
+
+	return &OIDCAuthenticator{
+		config:        config,
+		backendRepo:   backendRepo,
+		workspaceRepo: workspaceRepo,
+		keys:          newOIDCKeySet(config.IssuerURL),
+		sessions:      expirable.NewLRU[string, string](oidcSessionCacheSize, nil, oidcSessionCacheTTL),
+	}
+}
+
+// IsIDToken reports whether a bearer token looks like a JWT rather than a workspace token key
+func IsIDToken(bearer string) bool {
+	parts := strings.Split(bearer, ".")
+	if len(parts) != 3 {
+		return false
+	}
+
+	header, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return false
+	}
+
+	var h struct {
+		Alg string `json:"alg"`
+	}
+	return json.Unmarshal(header, &h) == nil && h.Alg != ""
+}
+
+// Authenticate verifies an ID token and returns the workspace token the user acts as, provisioning one if this
+// is the first time they've signed in to the workspace their groups map to
+func (a *OIDCAuthenticator) Authenticate(ctx context.Context, idToken string) (*types.Token, *types.Workspace, error) {
+	claims, err := a.Verify(ctx, idToken)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sessionKey := hashIDToken(idToken)
+	tokenKey, ok := a.sessions.Get(sessionKey)
+	if ok {
+		return getCachedToken(ctx, a.backendRepo, a.workspaceRepo, tokenKey)
+	}
+
+	mapping, ok := matchOIDCGroupMapping(a.config.GroupMappings, claims.Groups)
+	if !ok {
+		return nil, nil, errOIDCNoGroupMapping
+	}
+
+	workspace, err := a.backendRepo.GetWorkspaceByExternalId(ctx, mapping.Workspace)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get workspace %s: %w", mapping.Workspace, err)
+	}
+
+	identity, err := a.provision(ctx, claims, &workspace)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	token, tokenWorkspace, err := getCachedToken(ctx, a.backendRepo, a.workspaceRepo, identity.TokenKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	role := types.WorkspaceRole(mapping.Role)
+	if token.WorkspaceRole() != role {
+		updated, err := a.backendRepo.SetTokenRole(ctx, workspace.Id, token.ExternalId, role)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if err := a.workspaceRepo.InvalidateToken(token.Key); err != nil {
+			log.Error().Err(err).Str("token_id", token.ExternalId).Msg("failed to invalidate cached token")
+		}
+
+		token.Role = updated.Role
+	}
+
+	a.sessions.Add(sessionKey, token.Key)
+	return token, tokenWorkspace, nil
+}
+
+// provision returns the identity a user signs in to a workspace as, creating it and its token the first time
+func (a *OIDCAuthenticator) provision(ctx context.Context, claims *OIDCClaims, workspace *types.Workspace) (*types.OIDCIdentity, error) {
+	identity, err := a.backendRepo.GetOIDCIdentity(ctx, a.config.IssuerURL, claims.Subject, workspace.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if identity != nil {
+		if identity.Email != claims.Email {
+			if err := a.backendRepo.UpdateOIDCIdentityEmail(ctx, identity.Id, claims.Email); err != nil {
+				log.Error().Err(err).Str("subject", claims.Subject).Msg("failed to update oidc identity email")
+			}
+		}
+
+		return identity, nil
+	}
+
+	token, err := a.backendRepo.CreateToken(ctx, workspace.Id, types.TokenTypeWorkspace, true)
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err = a.backendRepo.CreateOIDCIdentity(ctx, a.config.IssuerURL, claims.Subject, claims.Email, workspace.Id, token.Id)
+	if err != nil {
+		if err := a.backendRepo.DeleteToken(ctx, workspace.Id, token.ExternalId); err != nil {
+			log.Error().Err(err).Str("token_id", token.ExternalId).Msg("failed to delete unused oidc token")
+		}
+
+		// Another request may have signed the user in at the same time
+		identity, getErr := a.backendRepo.GetOIDCIdentity(ctx, a.config.IssuerURL, claims.Subject, workspace.Id)
+		if getErr != nil || identity == nil {
+			return nil, err
+		}
+
+		return identity, nil
+	}
+
+	log.Info().Str("subject", claims.Subject).Str("email", claims.Email).Str("workspace_id", workspace.ExternalId).Msg("provisioned oidc user")
+	return identity, nil
+}
+
+// Verify checks an ID token was signed by the identity provider for this gateway and hasn't expired
+func (a *OIDCAuthenticator) Verify(ctx context.Context, idToken string) (*OIDCClaims, error) {
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{"RS256", "RS384", "RS512"}))
+
+	mapClaims := jwt.MapClaims{}
+	_, err := parser.ParseWithClaims(idToken, mapClaims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return a.keys.key(ctx, kid)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	issuer, _ := mapClaims["iss"].(string)
+	if strings.TrimSuffix(issuer, "/") != a.keys.issuerURL {
+		return nil, errors.New("id token has the wrong issuer")
+	}
+
+	if !mapClaims.VerifyAudience(a.config.ClientID, true) {
+		return nil, errors.New("id token has the wrong audience")
+	}
+
+	if !mapClaims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, errors.New("id token has no expiry")
+	}
+
+	subject, _ := mapClaims["sub"].(string)
+	if subject == "" {
+		return nil, errors.New("id token has no subject")
+	}
+
+	email, _ := mapClaims["email"].(string)
+	return &OIDCClaims{
+		Subject: subject,
+		Email:   email,
+		Groups:  stringsClaim(mapClaims[a.config.GroupsClaim]),
+	}, nil
+}
+
+// matchOIDCGroupMapping returns the first mapping for one of the groups with a valid role
+func matchOIDCGroupMapping(mappings []types.OIDCGroupMapping, groups []string) (types.OIDCGroupMapping, bool) {
+	for _, mapping := range mappings {
+		if !types.WorkspaceRole(mapping.Role).IsValid() {
+			continue
+		}
+
+		for _, group := range groups {
+			if group == mapping.Group {
+				return mapping, true
+			}
+		}
+	}
+
+	return types.OIDCGroupMapping{}, false
+}
+
+// stringsClaim reads a claim holding either a list of strings or a single one
+func stringsClaim(claim interface{}) []string {
+	switch v := claim.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, value := range v {
+			if s, ok := value.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+
+	return nil
+}
+
+func hashIDToken(idToken string) string {
+	sum := sha256.Sum256([]byte(idToken))
+	return hex.EncodeToString(sum[:])
+}
+
+// getCachedToken looks a token up by its key, from the token cache if it's there
+func getCachedToken(ctx context.Context, backendRepo repository.BackendRepository, workspaceRepo repository.WorkspaceRepository, tokenKey string) (*types.Token, *types.Workspace, error) {
+	token, workspace, err := workspaceRepo.AuthorizeToken(tokenKey)
+	if err == nil {
+		return token, workspace, nil
+	}
+
+	token, workspace, err = backendRepo.AuthorizeToken(ctx, tokenKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := workspaceRepo.SetAuthorizationToken(token, workspace); err != nil {
+		return nil, nil, err
+	}
+
+	return token, workspace, nil
+}
+
+// oidcKeySet holds the identity provider's signing keys, found through its discovery document
+type oidcKeySet struct {
+	issuerURL   string
+	client      *http.Client
+	mu          sync.Mutex
+	keys        map[string]*rsa.PublicKey
+	refreshedAt time.Time
+}
+
+func newOIDCKeySet(issuerURL string) *oidcKeySet {
+	return &oidcKeySet{
+		issuerURL: strings.TrimSuffix(issuerURL, "/"),
+		client:    &http.Client{Timeout: oidcHttpTimeout},
+		keys:      map[string]*rsa.PublicKey{},
+	}
+}
+
+func (k *oidcKeySet) key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if key, ok := k.lookup(kid); ok {
+		return key, nil
+	}
+
+	if time.Since(k.refreshedAt) < oidcKeyRefreshInterval {
+		return nil, errOIDCUnknownKey
+	}
+
+	keys, err := k.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	k.keys = keys
+	k.refreshedAt = time.Now()
+
+	if key, ok := k.lookup(kid); ok {
+		return key, nil
+	}
+
+	return nil, errOIDCUnknownKey
+}
+
+// lookup finds a key by id. Tokens without a key id can only be checked if the provider has a single key.
+func (k *oidcKeySet) lookup(kid string) (*rsa.PublicKey, bool) {
+	if kid == "" && len(k.keys) == 1 {
+		for _, key := range k.keys {
+			return key, true
+		}
+	}
+
+	key, ok := k.keys[kid]
+	return key, ok
+}
+
+func (k *oidcKeySet) fetch(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	var discovery struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+	}
+	if err := k.getJSON(ctx, k.issuerURL+"/.well-known/openid-configuration", &discovery); err != nil {
+		return nil, fmt.Errorf("failed to get oidc discovery document: %w", err)
+	}
+
+	if strings.TrimSuffix(discovery.Issuer, "/") != k.issuerURL {
+		return nil, fmt.Errorf("oidc discovery document is for issuer %q", discovery.Issuer)
+	}
+
+	var jwks struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := k.getJSON(ctx, discovery.JWKSURI, &jwks); err != nil {
+		return nil, fmt.Errorf("failed to get oidc signing keys: %w", err)
+	}
+
+	keys := map[string]*rsa.PublicKey{}
+	for _, jwk := range jwks.Keys {
+		if jwk.Kty != "RSA" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			continue
+		}
+
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			continue
+		}
+
+		keys[jwk.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+
+	return keys, nil
+}
+
+func (k *oidcKeySet) getJSON(ctx context.Context, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, url)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
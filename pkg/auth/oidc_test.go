@@ -0,0 +1,146 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+func newTestIdentityProvider(t *testing.T, key *rsa.PrivateKey) *httptest.Server {
+	var server *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   server.URL,
+			"jwks_uri": server.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "test",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+
+	server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func signIDToken(t *testing.T, key *rsa.PrivateKey, kid string, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func TestOIDCAuthenticatorVerify(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	server := newTestIdentityProvider(t, key)
+	authenticator := NewOIDCAuthenticator(types.OIDCConfig{
+		Enabled:   true,
+		IssuerURL: server.URL,
+		ClientID:  "beta9",
+	}, nil, nil)
+
+	claims := func(overrides jwt.MapClaims) jwt.MapClaims {
+		c := jwt.MapClaims{
+			"iss":    server.URL,
+			"aud":    "beta9",
+			"sub":    "user-1",
+			"email":  "user@example.com",
+			"groups": []string{"ml-team", "eng"},
+			"exp":    time.Now().Add(time.Hour).Unix(),
+		}
+		for k, v := range overrides {
+			c[k] = v
+		}
+		return c
+	}
+
+	verified, err := authenticator.Verify(context.Background(), signIDToken(t, key, "test", claims(nil)))
+	require.NoError(t, err)
+	assert.Equal(t, &OIDCClaims{Subject: "user-1", Email: "user@example.com", Groups: []string{"ml-team", "eng"}}, verified)
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"wrong issuer", signIDToken(t, key, "test", claims(jwt.MapClaims{"iss": "https://other.example.com"}))},
+		{"wrong audience", signIDToken(t, key, "test", claims(jwt.MapClaims{"aud": "other"}))},
+		{"expired", signIDToken(t, key, "test", claims(jwt.MapClaims{"exp": time.Now().Add(-time.Minute).Unix()}))},
+		{"no subject", signIDToken(t, key, "test", claims(jwt.MapClaims{"sub": ""}))},
+		{"unknown key", signIDToken(t, otherKey, "other", claims(nil))},
+		{"wrong key", signIDToken(t, otherKey, "test", claims(nil))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := authenticator.Verify(context.Background(), tt.token)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestMatchOIDCGroupMapping(t *testing.T) {
+	mappings := []types.OIDCGroupMapping{
+		{Group: "admins", Workspace: "ws-1", Role: "admin"},
+		{Group: "broken", Workspace: "ws-1", Role: "owner"},
+		{Group: "ml-team", Workspace: "ws-1", Role: "developer"},
+		{Group: "eng", Workspace: "ws-2", Role: "read_only"},
+	}
+
+	tests := []struct {
+		name   string
+		groups []string
+		want   string
+		ok     bool
+	}{
+		{"first mapping wins", []string{"eng", "ml-team"}, "developer", true},
+		{"admins", []string{"admins", "eng"}, "admin", true},
+		{"invalid roles are skipped", []string{"broken", "eng"}, "read_only", true},
+		{"no matching group", []string{"sales"}, "", false},
+		{"no groups", nil, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapping, ok := matchOIDCGroupMapping(mappings, tt.groups)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, mapping.Role)
+		})
+	}
+}
+
+func TestIsIDToken(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	assert.True(t, IsIDToken(signIDToken(t, key, "test", jwt.MapClaims{"sub": "user-1"})))
+	assert.False(t, IsIDToken(base64.URLEncoding.EncodeToString(make([]byte, 64))))
+	assert.False(t, IsIDToken("a.b.c"))
+}
@@ -0,0 +1,229 @@
+package auth
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+const (
+	// Header sent with rejected requests, in whole seconds like the HTTP header of the same name
+	RetryAfterHeader = "retry-after"
+
+	// Streams are leased and renewed while open, so ones held by a gateway that went away free up on their own
+	rateLimitStreamLeaseTTL time.Duration = 30 * time.Second
+	// Streams close at unpredictable times, so clients over the stream limit are told to retry after this long
+	rateLimitStreamRetryAfter time.Duration = time.Second
+)
+
+// Refills the token's bucket for the time since it was last used, then takes one request from it.
+//
+// KEYS: bucket
+// ARGV: now (ms), requests per second, burst
+//
+// Returns 0 if the request is allowed, otherwise how many milliseconds until it would be
+var rateLimitTakeScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local burst = tonumber(ARGV[3])
+
+local bucket = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(bucket[1]) or burst
+local ts = tonumber(bucket[2]) or now
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate / 1000)
+
+local wait = 0
+if tokens < 1 then
+	wait = math.ceil((1 - tokens) * 1000 / rate)
+else
+	tokens = tokens - 1
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "ts", now)
+redis.call("PEXPIRE", KEYS[1], math.ceil(burst * 1000 / rate) + 1000)
+return wait
+`)
+
+// KEYS: open streams (holder -> lease expiry)
+// ARGV: holder, now (ms), lease expiry (ms), max concurrent streams
+//
+// Returns 1 if the stream was opened, 0 if the token is at its limit
+var rateLimitOpenStreamScript = redis.NewScript(`
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", tonumber(ARGV[2]))
+if redis.call("ZCARD", KEYS[1]) >= tonumber(ARGV[4]) then
+	return 0
+end
+
+redis.call("ZADD", KEYS[1], tonumber(ARGV[3]), ARGV[1])
+redis.call("PEXPIRE", KEYS[1], tonumber(ARGV[3]) - tonumber(ARGV[2]))
+return 1
+`)
+
+// RateLimitInterceptor limits the requests per second and concurrent streams of each token, counted in redis so
+// the limits hold across gateways. It has to run after the AuthInterceptor. Requests over a limit fail with
+// ResourceExhausted and a retry-after header.
+type RateLimitInterceptor struct {
+	config types.RateLimitsConfig
+	rdb    *common.RedisClient
+}
+
+func NewRateLimitInterceptor(config types.RateLimitsConfig, rdb *common.RedisClient) *RateLimitInterceptor {
+	return &RateLimitInterceptor{config: config, rdb: rdb}
+}
+
+func (ri *RateLimitInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		token, limit := ri.limitFor(ctx)
+		if limit == nil {
+			return handler(ctx, req)
+		}
+
+		if wait := ri.take(ctx, token, limit); wait > 0 {
+			grpc.SetHeader(ctx, retryAfter(wait))
+			return nil, rateLimitExceeded("request rate", wait)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func (ri *RateLimitInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := stream.Context()
+
+		token, limit := ri.limitFor(ctx)
+		if limit == nil {
+			return handler(srv, stream)
+		}
+
+		if wait := ri.take(ctx, token, limit); wait > 0 {
+			stream.SetHeader(retryAfter(wait))
+			return rateLimitExceeded("request rate", wait)
+		}
+
+		release, ok := ri.openStream(ctx, token, limit)
+		if !ok {
+			stream.SetHeader(retryAfter(rateLimitStreamRetryAfter))
+			return rateLimitExceeded("concurrent stream", rateLimitStreamRetryAfter)
+		}
+		defer release()
+
+		return handler(srv, stream)
+	}
+}
+
+// limitFor returns the limit of the token the request was made with, or nil if it isn't limited. Worker and
+// machine tokens are used by the cluster itself, so they're only limited if they have a limit of their own.
+func (ri *RateLimitInterceptor) limitFor(ctx context.Context) (*types.Token, *types.RateLimit) {
+	authInfo, ok := AuthInfoFromContext(ctx)
+	if !ok || authInfo == nil || authInfo.Token == nil {
+		return nil, nil
+	}
+
+	token := authInfo.Token
+	if token.RateLimit != nil {
+		return token, token.RateLimit
+	}
+
+	if !ri.config.Enabled || token.TokenType == types.TokenTypeWorker || token.TokenType == types.TokenTypeMachine {
+		return nil, nil
+	}
+
+	return token, &types.RateLimit{
+		RequestsPerSecond:    ri.config.RequestsPerSecond,
+		Burst:                ri.config.Burst,
+		MaxConcurrentStreams: ri.config.MaxConcurrentStreams,
+	}
+}
+
+// take uses up one of the token's requests, returning how long until it could make the request if it's over
+// its limit. Requests are let through if redis can't be reached.
+func (ri *RateLimitInterceptor) take(ctx context.Context, token *types.Token, limit *types.RateLimit) time.Duration {
+	if limit.RequestsPerSecond <= 0 {
+		return 0
+	}
+
+	burst := max(limit.Burst, 1)
+	keys := []string{common.RedisKeys.GatewayRateLimitBucket(token.ExternalId)}
+
+	wait, err := rateLimitTakeScript.Run(ctx, ri.rdb, keys, time.Now().UnixMilli(), limit.RequestsPerSecond, burst).Int64()
+	if err != nil {
+		log.Warn().Err(err).Str("token_id", token.ExternalId).Msg("failed to check token rate limit")
+		return 0
+	}
+
+	return time.Duration(wait) * time.Millisecond
+}
+
+// openStream counts a stream against the token's limit until the returned func is called
+func (ri *RateLimitInterceptor) openStream(ctx context.Context, token *types.Token, limit *types.RateLimit) (func(), bool) {
+	if limit.MaxConcurrentStreams <= 0 {
+		return func() {}, true
+	}
+
+	key := common.RedisKeys.GatewayRateLimitStreams(token.ExternalId)
+	holderId := uuid.New().String()
+
+	now := time.Now()
+	opened, err := rateLimitOpenStreamScript.Run(ctx, ri.rdb, []string{key}, holderId, now.UnixMilli(), now.Add(rateLimitStreamLeaseTTL).UnixMilli(), limit.MaxConcurrentStreams).Int()
+	if err != nil {
+		log.Warn().Err(err).Str("token_id", token.ExternalId).Msg("failed to check token stream limit")
+		return func() {}, true
+	}
+
+	if opened == 0 {
+		return nil, false
+	}
+
+	return ri.hold(key, holderId), true
+}
+
+// hold renews the stream's lease until the returned func is called
+func (ri *RateLimitInterceptor) hold(key, holderId string) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		ticker := time.NewTicker(rateLimitStreamLeaseTTL / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				ri.rdb.ZAddXX(ctx, key, redis.Z{Score: float64(time.Now().Add(rateLimitStreamLeaseTTL).UnixMilli()), Member: holderId})
+				ri.rdb.PExpire(ctx, key, rateLimitStreamLeaseTTL)
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		ri.rdb.ZRem(context.Background(), key, holderId)
+	}
+}
+
+func retryAfter(wait time.Duration) metadata.MD {
+	return metadata.Pairs(RetryAfterHeader, strconv.Itoa(retryAfterSeconds(wait)))
+}
+
+// retryAfterSeconds rounds up, so clients that wait as long as they're told aren't rejected again
+func retryAfterSeconds(wait time.Duration) int {
+	return max(int(math.Ceil(wait.Seconds())), 1)
+}
+
+func rateLimitExceeded(limit string, wait time.Duration) error {
+	return status.Errorf(codes.ResourceExhausted, "Token %s limit exceeded, retry after %ds", limit, retryAfterSeconds(wait))
+}
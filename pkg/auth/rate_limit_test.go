@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+func TestRetryAfterSeconds(t *testing.T) {
+	assert.Equal(t, 1, retryAfterSeconds(0))
+	assert.Equal(t, 1, retryAfterSeconds(200*time.Millisecond))
+	assert.Equal(t, 2, retryAfterSeconds(1001*time.Millisecond))
+	assert.Equal(t, 3, retryAfterSeconds(3*time.Second))
+}
+
+func TestRateLimitFor(t *testing.T) {
+	ri := NewRateLimitInterceptor(types.RateLimitsConfig{Enabled: true, RequestsPerSecond: 10, Burst: 20, MaxConcurrentStreams: 2}, nil)
+
+	withToken := func(token *types.Token) context.Context {
+		return context.WithValue(context.Background(), authContextKey, &AuthInfo{Token: token, Workspace: &types.Workspace{}})
+	}
+
+	_, limit := ri.limitFor(context.Background())
+	assert.Nil(t, limit)
+
+	_, limit = ri.limitFor(withToken(&types.Token{TokenType: types.TokenTypeWorkspace}))
+	assert.Equal(t, &types.RateLimit{RequestsPerSecond: 10, Burst: 20, MaxConcurrentStreams: 2}, limit)
+
+	_, limit = ri.limitFor(withToken(&types.Token{TokenType: types.TokenTypeWorker}))
+	assert.Nil(t, limit)
+
+	override := &types.RateLimit{RequestsPerSecond: 1, Burst: 1}
+	_, limit = ri.limitFor(withToken(&types.Token{TokenType: types.TokenTypeWorker, RateLimit: override}))
+	assert.Equal(t, override, limit)
+
+	ri.config.Enabled = false
+	_, limit = ri.limitFor(withToken(&types.Token{TokenType: types.TokenTypeWorkspace}))
+	assert.Nil(t, limit)
+}
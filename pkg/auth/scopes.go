@@ -0,0 +1,277 @@
+package auth
+
+import (
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+func scope(resource types.TokenScopeResource, access types.TokenScopeAccess) types.TokenScope {
+	return types.NewTokenScope(resource, access)
+}
+
+var (
+	objectsRead      = scope(types.TokenScopeResourceObjects, types.TokenScopeAccessRead)
+	objectsWrite     = scope(types.TokenScopeResourceObjects, types.TokenScopeAccessWrite)
+	objectsAdmin     = scope(types.TokenScopeResourceObjects, types.TokenScopeAccessAdmin)
+	volumesRead      = scope(types.TokenScopeResourceVolumes, types.TokenScopeAccessRead)
+	volumesWrite     = scope(types.TokenScopeResourceVolumes, types.TokenScopeAccessWrite)
+	volumesAdmin     = scope(types.TokenScopeResourceVolumes, types.TokenScopeAccessAdmin)
+	secretsRead      = scope(types.TokenScopeResourceSecrets, types.TokenScopeAccessRead)
+	secretsWrite     = scope(types.TokenScopeResourceSecrets, types.TokenScopeAccessWrite)
+	tasksRead        = scope(types.TokenScopeResourceTasks, types.TokenScopeAccessRead)
+	tasksWrite       = scope(types.TokenScopeResourceTasks, types.TokenScopeAccessWrite)
+	tasksAdmin       = scope(types.TokenScopeResourceTasks, types.TokenScopeAccessAdmin)
+	deploymentsRead  = scope(types.TokenScopeResourceDeployments, types.TokenScopeAccessRead)
+	deploymentsWrite = scope(types.TokenScopeResourceDeployments, types.TokenScopeAccessWrite)
+	deploymentsAdmin = scope(types.TokenScopeResourceDeployments, types.TokenScopeAccessAdmin)
+	containersRead   = scope(types.TokenScopeResourceContainers, types.TokenScopeAccessRead)
+	containersWrite  = scope(types.TokenScopeResourceContainers, types.TokenScopeAccessWrite)
+	imagesRead       = scope(types.TokenScopeResourceImages, types.TokenScopeAccessRead)
+	imagesWrite      = scope(types.TokenScopeResourceImages, types.TokenScopeAccessWrite)
+	workspaceRead    = scope(types.TokenScopeResourceWorkspace, types.TokenScopeAccessRead)
+	workspaceWrite   = scope(types.TokenScopeResourceWorkspace, types.TokenScopeAccessWrite)
+	workspaceAdmin   = scope(types.TokenScopeResourceWorkspace, types.TokenScopeAccessAdmin)
+
+	// anyScope lets any scoped token call an RPC, for RPCs that check the caller themselves
+	anyScope = []types.TokenScope{objectsRead, volumesRead, secretsRead, tasksRead, deploymentsRead, containersRead, imagesRead, workspaceRead}
+)
+
+// methodScopes lists the scopes that let a scoped token call each RPC; holding any one of them is enough.
+// Methods that aren't listed, like the ones workers call, can't be called with a scoped token.
+var methodScopes = map[string][]types.TokenScope{
+	// Objects
+	"/gateway.GatewayService/HeadObject":               {objectsRead},
+	"/gateway.GatewayService/ListObjects":              {objectsRead},
+	"/gateway.GatewayService/CreateObject":             {objectsWrite},
+	"/gateway.GatewayService/PutObjectStream":          {objectsWrite},
+	"/gateway.GatewayService/PutObjectStreamV2":        {objectsWrite},
+	"/gateway.GatewayService/GetObjectSignature":       {objectsRead},
+	"/gateway.GatewayService/PatchObject":              {objectsWrite},
+	"/gateway.GatewayService/GetObjectURL":             {objectsRead},
+	"/gateway.GatewayService/GetObjectStream":          {objectsRead},
+	"/gateway.GatewayService/RenameObject":             {objectsWrite},
+	"/gateway.GatewayService/TagObject":                {objectsWrite},
+	"/gateway.GatewayService/MigrateObjects":           {objectsAdmin},
+	"/gateway.GatewayService/CompleteObject":           {objectsWrite},
+	"/gateway.GatewayService/DeleteObject":             {objectsWrite},
+	"/gateway.GatewayService/DeleteObjects":            {objectsWrite},
+	"/gateway.GatewayService/LockObject":               {objectsWrite},
+	"/gateway.GatewayService/ListCorruptedObjects":     {objectsRead},
+	"/gateway.GatewayService/CheckObjectConsistency":   {objectsAdmin},
+	"/gateway.GatewayService/SetObjectLifecyclePolicy": {objectsAdmin},
+	"/gateway.GatewayService/GetObjectLifecyclePolicy": {objectsRead},
+	"/gateway.GatewayService/ListObjectVersions":       {objectsRead},
+	"/gateway.GatewayService/RestoreObjectVersion":     {objectsWrite},
+	"/gateway.GatewayService/CopyObject":               {objectsWrite},
+	"/gateway.GatewayService/ExtractObject":            {objectsWrite},
+	"/gateway.GatewayService/GetStorageUsage":          {objectsRead},
+	"/gateway.GatewayService/InitiateUpload":           {objectsWrite},
+	"/gateway.GatewayService/AppendChunk":              {objectsWrite},
+	"/gateway.GatewayService/CompleteUpload":           {objectsWrite},
+
+	// Containers
+	"/gateway.GatewayService/CheckpointContainer": {containersWrite},
+	"/gateway.GatewayService/ListContainers":      {containersRead},
+	"/gateway.GatewayService/StopContainer":       {containersWrite},
+	"/gateway.GatewayService/AttachToContainer":   {containersWrite},
+	"/gateway.GatewayService/ExecInContainer":     {containersWrite},
+	"/gateway.GatewayService/StreamLogs":          {containersRead},
+	"/gateway.GatewayService/PortForward":         {containersWrite},
+
+	// Tasks
+	"/gateway.GatewayService/StartTask":          {tasksWrite},
+	"/gateway.GatewayService/EndTask":            {tasksWrite},
+	"/gateway.GatewayService/StopTasks":          {tasksWrite},
+	"/gateway.GatewayService/ListTasks":          {tasksRead},
+	"/gateway.GatewayService/CancelTasks":        {tasksWrite},
+	"/gateway.GatewayService/RequeueTasks":       {tasksWrite},
+	"/gateway.GatewayService/SetTaskRetryPolicy": {tasksAdmin},
+	"/gateway.GatewayService/GetTaskRetryPolicy": {tasksRead},
+
+	// Deployments. Stubs are created before running tasks as well as before deploying.
+	"/gateway.GatewayService/GetOrCreateStub":          {tasksWrite, deploymentsWrite},
+	"/gateway.GatewayService/DeployStub":               {deploymentsWrite},
+	"/gateway.GatewayService/GetURL":                   {deploymentsRead},
+	"/gateway.GatewayService/ListDeployments":          {deploymentsRead},
+	"/gateway.GatewayService/StopDeployment":           {deploymentsWrite},
+	"/gateway.GatewayService/StartDeployment":          {deploymentsWrite},
+	"/gateway.GatewayService/ScaleDeployment":          {deploymentsWrite},
+	"/gateway.GatewayService/DeleteDeployment":         {deploymentsAdmin},
+	"/gateway.GatewayService/RollbackDeployment":       {deploymentsWrite},
+	"/gateway.GatewayService/SetDeploymentEnv":         {deploymentsWrite},
+	"/gateway.GatewayService/UnsetDeploymentEnv":       {deploymentsWrite},
+	"/gateway.GatewayService/ListDeploymentEnv":        {deploymentsRead},
+	"/gateway.GatewayService/AddCustomDomain":          {deploymentsAdmin},
+	"/gateway.GatewayService/ListCustomDomains":        {deploymentsRead},
+	"/gateway.GatewayService/RemoveCustomDomain":       {deploymentsAdmin},
+	"/gateway.GatewayService/ListDeploymentHistory":    {deploymentsRead},
+	"/gateway.GatewayService/SetTrafficSplit":          {deploymentsWrite},
+	"/gateway.GatewayService/PromoteVersion":           {deploymentsWrite},
+	"/gateway.GatewayService/SetAutoscalingPolicy":     {deploymentsWrite},
+	"/gateway.GatewayService/GetAutoscalingPolicy":     {deploymentsRead},
+	"/gateway.GatewayService/ReportAutoscalingMetrics": {deploymentsWrite},
+	"/gateway.GatewayService/SetKeepWarm":              {deploymentsWrite},
+	"/gateway.GatewayService/GetKeepWarm":              {deploymentsRead},
+
+	// Workspace
+	"/gateway.GatewayService/SignPayload":               {workspaceWrite},
+	"/gateway.GatewayService/CreateWebhook":             {workspaceAdmin},
+	"/gateway.GatewayService/ListWebhooks":              {workspaceRead},
+	"/gateway.GatewayService/DeleteWebhook":             {workspaceAdmin},
+	"/gateway.GatewayService/CreateTaskTrigger":         {deploymentsWrite},
+	"/gateway.GatewayService/ListTaskTriggers":          {deploymentsRead},
+	"/gateway.GatewayService/DeleteTaskTrigger":         {deploymentsWrite},
+	"/gateway.GatewayService/SetUploadBandwidthLimit":   {workspaceAdmin},
+	"/gateway.GatewayService/SetWorkspaceVolumeQuota":   {workspaceAdmin},
+	"/gateway.GatewayService/SetWorkspacePolicy":        {workspaceAdmin},
+	"/gateway.GatewayService/GetWorkspacePolicy":        {workspaceRead},
+	"/gateway.GatewayService/SetPoolQuota":              {workspaceAdmin},
+	"/gateway.GatewayService/ListPoolQuotas":            {workspaceRead},
+	"/gateway.GatewayService/ReserveCapacity":           {workspaceAdmin},
+	"/gateway.GatewayService/ListCapacityReservations":  {workspaceRead},
+	"/gateway.GatewayService/CancelCapacityReservation": {workspaceAdmin},
+	"/gateway.GatewayService/GetWorkspaceIPAllowList":   {workspaceAdmin},
+	"/gateway.GatewayService/SetWorkspaceIPAllowList":   {workspaceAdmin},
+	"/gateway.GatewayService/ListPools":                 {workspaceRead},
+	"/gateway.GatewayService/ListMachines":              {workspaceRead},
+	"/gateway.GatewayService/CreateMachine":             {workspaceAdmin},
+	"/gateway.GatewayService/DeleteMachine":             {workspaceAdmin},
+	"/gateway.GatewayService/ListTokens":                {workspaceAdmin},
+	"/gateway.GatewayService/CreateToken":               {workspaceAdmin},
+	"/gateway.GatewayService/ToggleToken":               {workspaceAdmin},
+	"/gateway.GatewayService/DeleteToken":               {workspaceAdmin},
+	"/gateway.GatewayService/SetTokenRateLimit":         {workspaceAdmin},
+	"/gateway.GatewayService/RotateToken":               {workspaceAdmin},
+	"/gateway.GatewayService/ExchangeSessionToken":      anyScope,
+	"/gateway.GatewayService/RevokeSessionToken":        anyScope,
+	"/gateway.GatewayService/GetResourceACL":            {workspaceAdmin},
+	"/gateway.GatewayService/SetResourceACL":            {workspaceAdmin},
+	"/gateway.GatewayService/ListMemberRoles":           {workspaceAdmin},
+	"/gateway.GatewayService/SetMemberRole":             {workspaceAdmin},
+	"/gateway.GatewayService/InviteMember":              {workspaceAdmin},
+	"/gateway.GatewayService/RevokeInvite":              {workspaceAdmin},
+	"/gateway.GatewayService/ListWorkers":               {workspaceRead},
+	"/gateway.GatewayService/CordonWorker":              {workspaceAdmin},
+	"/gateway.GatewayService/UncordonWorker":            {workspaceAdmin},
+	"/gateway.GatewayService/DrainWorker":               {workspaceAdmin},
+	"/gateway.GatewayService/SetWorkerLabels":           {workspaceAdmin},
+	"/gateway.GatewayService/SimulateScheduling":        {workspaceRead},
+	"/gateway.GatewayService/ExportWorkspaceConfig":     {workspaceRead},
+	"/gateway.GatewayService/GrantResourceAccess":       {workspaceAdmin},
+	"/gateway.GatewayService/ListResourceGrants":        {workspaceAdmin},
+	"/gateway.GatewayService/QueryAuditLog":             {workspaceAdmin},
+
+	// Service accounts
+	"/gateway.GatewayService/CreateServiceAccount":      {workspaceAdmin},
+	"/gateway.GatewayService/ListServiceAccounts":       {workspaceAdmin},
+	"/gateway.GatewayService/DeleteServiceAccount":      {workspaceAdmin},
+	"/gateway.GatewayService/IssueServiceAccountToken":  {workspaceAdmin},
+	"/gateway.GatewayService/RenewServiceAccountToken":  {workspaceAdmin},
+	"/gateway.GatewayService/RevokeServiceAccountToken": {workspaceAdmin},
+
+	// Abstractions
+	"/endpoint.EndpointService/StartEndpointServe":       {deploymentsWrite},
+	"/taskqueue.TaskQueueService/StartTaskQueueServe":    {deploymentsWrite},
+	"/taskqueue.TaskQueueService/TaskQueuePut":           {tasksWrite},
+	"/taskqueue.TaskQueueService/TaskQueuePop":           {tasksWrite},
+	"/taskqueue.TaskQueueService/TaskQueueMonitor":       {tasksWrite},
+	"/taskqueue.TaskQueueService/TaskQueueComplete":      {tasksWrite},
+	"/taskqueue.TaskQueueService/TaskQueueLength":        {tasksRead},
+	"/function.FunctionService/FunctionInvoke":           {tasksWrite},
+	"/function.FunctionService/FunctionGetArgs":          {tasksWrite},
+	"/function.FunctionService/FunctionSetResult":        {tasksWrite},
+	"/function.FunctionService/FunctionMonitor":          {tasksWrite},
+	"/function.FunctionService/FunctionSchedule":         {deploymentsWrite},
+	"/bot.BotService/PopBotTask":                         {tasksWrite},
+	"/bot.BotService/PushBotMarkers":                     {tasksWrite},
+	"/bot.BotService/PushBotEvent":                       {tasksWrite},
+	"/bot.BotService/PushBotEventBlocking":               {tasksWrite},
+	"/signal.SignalService/SignalSet":                    {tasksWrite},
+	"/signal.SignalService/SignalClear":                  {tasksWrite},
+	"/signal.SignalService/SignalMonitor":                {tasksRead},
+	"/map.MapService/MapSet":                             {tasksWrite},
+	"/map.MapService/MapGet":                             {tasksRead},
+	"/map.MapService/MapDelete":                          {tasksWrite},
+	"/map.MapService/MapCount":                           {tasksRead},
+	"/map.MapService/MapKeys":                            {tasksRead},
+	"/simplequeue.SimpleQueueService/SimpleQueuePut":     {tasksWrite},
+	"/simplequeue.SimpleQueueService/SimpleQueuePop":     {tasksWrite},
+	"/simplequeue.SimpleQueueService/SimpleQueuePeek":    {tasksRead},
+	"/simplequeue.SimpleQueueService/SimpleQueueEmpty":   {tasksWrite},
+	"/simplequeue.SimpleQueueService/SimpleQueueSize":    {tasksRead},
+	"/output.OutputService/OutputSaveStream":             {tasksWrite},
+	"/output.OutputService/OutputStat":                   {tasksRead},
+	"/output.OutputService/OutputPublicURL":              {tasksRead},
+	"/output.OutputService/SetOutputRetentionPolicy":     {tasksAdmin},
+	"/output.OutputService/GetOutputRetentionPolicy":     {tasksRead},
+	"/image.ImageService/VerifyImageBuild":               {imagesRead},
+	"/image.ImageService/BuildImage":                     {imagesWrite},
+	"/secret.SecretService/CreateSecret":                 {secretsWrite},
+	"/secret.SecretService/DeleteSecret":                 {secretsWrite},
+	"/secret.SecretService/UpdateSecret":                 {secretsWrite},
+	"/secret.SecretService/GetSecret":                    {secretsRead},
+	"/secret.SecretService/ListSecrets":                  {secretsRead},
+	"/secret.SecretService/RotateSecret":                 {secretsWrite},
+	"/secret.SecretService/GetSecretVersion":             {secretsRead},
+	"/shell.ShellService/CreateStandaloneShell":          {containersWrite},
+	"/shell.ShellService/CreateShellInExistingContainer": {containersWrite},
+	"/pod.PodService/CreatePod":                          {containersWrite},
+	"/pod.PodService/SandboxExec":                        {containersWrite},
+	"/pod.PodService/SandboxStatus":                      {containersRead},
+	"/pod.PodService/SandboxStdout":                      {containersRead},
+	"/pod.PodService/SandboxStderr":                      {containersRead},
+	"/pod.PodService/SandboxKill":                        {containersWrite},
+	"/pod.PodService/SandboxListProcesses":               {containersRead},
+	"/pod.PodService/SandboxUploadFile":                  {containersWrite},
+	"/pod.PodService/SandboxDownloadFile":                {containersRead},
+	"/pod.PodService/SandboxStatFile":                    {containersRead},
+	"/pod.PodService/SandboxListFiles":                   {containersRead},
+	"/pod.PodService/SandboxDeleteFile":                  {containersWrite},
+	"/pod.PodService/SandboxCreateDirectory":             {containersWrite},
+	"/pod.PodService/SandboxDeleteDirectory":             {containersWrite},
+	"/pod.PodService/SandboxExposePort":                  {containersWrite},
+	"/pod.PodService/SandboxUpdateNetworkPermissions":    {containersWrite},
+	"/pod.PodService/SandboxReplaceInFiles":              {containersWrite},
+	"/pod.PodService/SandboxFindInFiles":                 {containersRead},
+	"/pod.PodService/SandboxConnect":                     {containersWrite},
+	"/pod.PodService/SandboxUpdateTTL":                   {containersWrite},
+	"/pod.PodService/SandboxCreateImageFromFilesystem":   {containersWrite, imagesWrite},
+	"/pod.PodService/SandboxSnapshotMemory":              {containersWrite},
+	"/pod.PodService/SandboxListUrls":                    {containersRead},
+	"/pod.PodService/SandboxWaitForCompletion":           {containersRead},
+	"/volume.VolumeService/GetOrCreateVolume":            {volumesWrite},
+	"/volume.VolumeService/DeleteVolume":                 {volumesAdmin},
+	"/volume.VolumeService/ListVolumes":                  {volumesRead},
+	"/volume.VolumeService/ListPath":                     {volumesRead},
+	"/volume.VolumeService/DeletePath":                   {volumesWrite},
+	"/volume.VolumeService/CopyPathStream":               {volumesWrite},
+	"/volume.VolumeService/MovePath":                     {volumesWrite},
+	"/volume.VolumeService/StatPath":                     {volumesRead},
+	"/volume.VolumeService/SnapshotVolume":               {volumesWrite},
+	"/volume.VolumeService/ListSnapshots":                {volumesRead},
+	"/volume.VolumeService/RestoreVolume":                {volumesWrite},
+	"/volume.VolumeService/GetVolumeUsage":               {volumesRead},
+	"/volume.VolumeService/SetVolumeQuota":               {volumesAdmin},
+	"/volume.VolumeService/ExportVolume":                 {volumesRead},
+	"/volume.VolumeService/ImportVolume":                 {volumesWrite},
+	"/volume.VolumeService/ShareVolume":                  {volumesAdmin},
+	"/volume.VolumeService/GetFileServiceInfo":           {volumesRead},
+	"/volume.VolumeService/CreatePresignedURL":           {volumesWrite},
+	"/volume.VolumeService/CreateMultipartUpload":        {volumesWrite},
+	"/volume.VolumeService/CompleteMultipartUpload":      {volumesWrite},
+	"/volume.VolumeService/AbortMultipartUpload":         {volumesWrite},
+}
+
+// HasMethodScope reports whether the caller's token scopes allow calling the given RPC. Tokens without
+// scopes are only limited by their role, which is checked by each RPC.
+func HasMethodScope(authInfo *AuthInfo, method string) bool {
+	if !authInfo.Token.Scopes.Restricted() {
+		return true
+	}
+
+	required, ok := methodScopes[method]
+	if !ok {
+		return false
+	}
+
+	return authInfo.Token.Scopes.Allows(required...)
+}
@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	expirable "github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/rs/zerolog/log"
+
+	"github.com/beam-cloud/beta9/pkg/repository"
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+const (
+	serviceAccountTouchCacheSize = 10000
+	// Service accounts' last used time is only recorded this often, so busy tokens don't write on every request
+	serviceAccountTouchInterval = time.Minute
+)
+
+// serviceAccountTracker records when service accounts last used one of their tokens
+type serviceAccountTracker struct {
+	backendRepo repository.BackendRepository
+	recent      *expirable.LRU[uint, struct{}]
+}
+
+func newServiceAccountTracker(backendRepo repository.BackendRepository) *serviceAccountTracker {
+	return &serviceAccountTracker{
+		backendRepo: backendRepo,
+		recent:      expirable.NewLRU[uint, struct{}](serviceAccountTouchCacheSize, nil, serviceAccountTouchInterval),
+	}
+}
+
+func (t *serviceAccountTracker) touch(token *types.Token) {
+	if token.ServiceAccountId == nil {
+		return
+	}
+
+	id := *token.ServiceAccountId
+	if t.recent.Contains(id) {
+		return
+	}
+	t.recent.Add(id, struct{}{})
+
+	go func() {
+		if err := t.backendRepo.TouchServiceAccount(context.Background(), id); err != nil {
+			log.Error().Err(err).Uint("service_account_id", id).Msg("failed to record service account use")
+		}
+	}()
+}
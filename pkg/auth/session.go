@@ -0,0 +1,290 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+
+	"github.com/beam-cloud/beta9/pkg/repository"
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+const (
+	sessionTokenIssuer = "beta9-session"
+	sessionUseAccess   = "access"
+	sessionUseRefresh  = "refresh"
+)
+
+var (
+	errSessionNotFound    = errors.New("session expired or was revoked")
+	errSessionRefreshUsed = errors.New("refresh token was already used")
+)
+
+// SessionTokens are the credentials of a session. Access tokens are sent as bearer tokens, the refresh token is
+// only sent to RefreshSessionToken, which replaces both.
+type SessionTokens struct {
+	SessionId             string
+	AccessToken           string
+	AccessTokenExpiresAt  time.Time
+	RefreshToken          string
+	RefreshTokenExpiresAt time.Time
+}
+
+type sessionClaims struct {
+	jwt.RegisteredClaims
+	WorkspaceId string `json:"wid"`
+	Use         string `json:"use"`
+}
+
+// SessionManager starts, refreshes and revokes sessions. Sessions are kept in the workspace repository, so they
+// work across gateways and are gone as soon as they're revoked. Every request made with a session also checks
+// the token it was started from, so disabling or revoking that token ends its sessions too.
+type SessionManager struct {
+	config        types.SessionTokensConfig
+	backendRepo   repository.BackendRepository
+	workspaceRepo repository.WorkspaceRepository
+}
+
+// NewSessionManager returns nil if session tokens aren't enabled
+func NewSessionManager(config types.SessionTokensConfig, backendRepo repository.BackendRepository, workspaceRepo repository.WorkspaceRepository) *SessionManager {
+	if !config.Enabled || config.SigningKey == "" {
+		return nil
+	}
+
+	return &SessionManager{
+		config:        config,
+		backendRepo:   backendRepo,
+		workspaceRepo: workspaceRepo,
+	}
+}
+
+// IsSessionToken reports whether a bearer token is a session access or refresh token, without verifying it
+func IsSessionToken(bearer string) bool {
+	if !IsIDToken(bearer) {
+		return false
+	}
+
+	claims := &sessionClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(bearer, claims); err != nil {
+		return false
+	}
+
+	return claims.Issuer == sessionTokenIssuer
+}
+
+// Start begins a session for a workspace token. Sessions are always scoped: to the requested scopes, which the
+// token must allow, or to the configured defaults the token allows.
+func (m *SessionManager) Start(token *types.Token, workspace *types.Workspace, requested []string) (*SessionTokens, error) {
+	scopes, err := sessionScopes(token.Scopes, requested, m.config.DefaultScopes)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionId := uuid.New().String()
+	session := &types.TokenSession{
+		TokenKey:    token.Key,
+		WorkspaceId: workspace.ExternalId,
+		Scopes:      scopes,
+		ExpiresAt:   time.Now().Add(m.config.SessionTTL),
+	}
+
+	tokens, err := m.sign(sessionId, session)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.workspaceRepo.SetTokenSession(sessionId, session); err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// Refresh spends a refresh token for new session tokens. Refresh tokens can only be used once; using one again
+// means it leaked, so the session is revoked.
+func (m *SessionManager) Refresh(refreshToken string) (*SessionTokens, error) {
+	claims, err := m.parse(refreshToken, sessionUseRefresh)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := m.workspaceRepo.GetTokenSession(claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	if session == nil {
+		return nil, errSessionNotFound
+	}
+
+	tokens, err := m.sign(claims.Subject, session)
+	if err != nil {
+		return nil, err
+	}
+
+	// The session is only replaced if it's still on the refresh token being spent, so of concurrent refreshes
+	// with the same token only one succeeds. Otherwise the token was already used and the session is revoked.
+	rotated, err := m.workspaceRepo.RotateTokenSession(claims.Subject, claims.ID, session)
+	if err != nil {
+		return nil, err
+	}
+
+	if !rotated {
+		return nil, errSessionRefreshUsed
+	}
+
+	return tokens, nil
+}
+
+// Revoke ends a session right away
+func (m *SessionManager) Revoke(sessionId string) error {
+	return m.workspaceRepo.DeleteTokenSession(sessionId)
+}
+
+// Get returns nil if the session expired or was revoked
+func (m *SessionManager) Get(sessionId string) (*types.TokenSession, error) {
+	return m.workspaceRepo.GetTokenSession(sessionId)
+}
+
+// Authenticate verifies a session access token and returns the token it acts as, narrowed to the session's
+// scopes, along with the session's id
+func (m *SessionManager) Authenticate(ctx context.Context, accessToken string) (*types.Token, *types.Workspace, string, error) {
+	claims, err := m.parse(accessToken, sessionUseAccess)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	session, err := m.workspaceRepo.GetTokenSession(claims.Subject)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	if session == nil {
+		return nil, nil, "", errSessionNotFound
+	}
+
+	token, workspace, err := getCachedToken(ctx, m.backendRepo, m.workspaceRepo, session.TokenKey)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	narrowed := *token
+	narrowed.Scopes = session.Scopes
+	return &narrowed, workspace, claims.Subject, nil
+}
+
+// sign moves the session on to a new refresh id and signs tokens for it, which only become valid once the session
+// is stored
+func (m *SessionManager) sign(sessionId string, session *types.TokenSession) (*SessionTokens, error) {
+	now := time.Now()
+	session.RefreshId = uuid.New().String()
+
+	accessExpiresAt := now.Add(m.config.AccessTokenTTL)
+	if accessExpiresAt.After(session.ExpiresAt) {
+		accessExpiresAt = session.ExpiresAt
+	}
+
+	accessToken, err := signSessionToken(m.config.SigningKey, sessionClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    sessionTokenIssuer,
+			Subject:   sessionId,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(accessExpiresAt),
+		},
+		WorkspaceId: session.WorkspaceId,
+		Use:         sessionUseAccess,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := signSessionToken(m.config.SigningKey, sessionClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    sessionTokenIssuer,
+			Subject:   sessionId,
+			ID:        session.RefreshId,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(session.ExpiresAt),
+		},
+		WorkspaceId: session.WorkspaceId,
+		Use:         sessionUseRefresh,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &SessionTokens{
+		SessionId:             sessionId,
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessExpiresAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: session.ExpiresAt,
+	}, nil
+}
+
+func (m *SessionManager) parse(bearer string, use string) (*sessionClaims, error) {
+	return parseSessionToken(m.config.SigningKey, bearer, use)
+}
+
+func signSessionToken(signingKey string, claims sessionClaims) (string, error) {
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(signingKey))
+}
+
+// parseSessionToken verifies a session token's signature and expiry, and that it's meant for use
+func parseSessionToken(signingKey string, bearer string, use string) (*sessionClaims, error) {
+	claims := &sessionClaims{}
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	_, err := parser.ParseWithClaims(bearer, claims, func(*jwt.Token) (interface{}, error) {
+		return []byte(signingKey), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.Issuer != sessionTokenIssuer || claims.Use != use || claims.Subject == "" {
+		return nil, fmt.Errorf("not a session %s token", use)
+	}
+
+	return claims, nil
+}
+
+// sessionScopes picks the scopes a session is narrowed to. Requested scopes must all be allowed by the token's
+// own scopes, while defaults the token doesn't allow are left out.
+func sessionScopes(tokenScopes types.TokenScopes, requested []string, defaults []string) (types.TokenScopes, error) {
+	if len(requested) > 0 {
+		scopes, err := types.ParseTokenScopes(requested)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, scope := range scopes {
+			if !tokenScopes.Allows(scope) {
+				return nil, fmt.Errorf("token scopes do not allow %s", scope)
+			}
+		}
+
+		return scopes, nil
+	}
+
+	parsed, err := types.ParseTokenScopes(defaults)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := types.TokenScopes{}
+	for _, scope := range parsed {
+		if tokenScopes.Allows(scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	if len(scopes) == 0 {
+		return nil, errors.New("token scopes do not allow any of the default session scopes")
+	}
+
+	return scopes, nil
+}
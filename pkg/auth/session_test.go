@@ -0,0 +1,134 @@
+package auth
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/repository"
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+func newTestSessionClaims(use string, expiresAt time.Time) sessionClaims {
+	return sessionClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    sessionTokenIssuer,
+			Subject:   "session-1",
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		WorkspaceId: "workspace-1",
+		Use:         use,
+	}
+}
+
+func TestParseSessionToken(t *testing.T) {
+	access, err := signSessionToken("secret", newTestSessionClaims(sessionUseAccess, time.Now().Add(time.Minute)))
+	require.NoError(t, err)
+
+	assert.True(t, IsSessionToken(access))
+	assert.False(t, IsSessionToken("a-workspace-token-key"))
+
+	claims, err := parseSessionToken("secret", access, sessionUseAccess)
+	require.NoError(t, err)
+	assert.Equal(t, "session-1", claims.Subject)
+	assert.Equal(t, "workspace-1", claims.WorkspaceId)
+
+	_, err = parseSessionToken("other-secret", access, sessionUseAccess)
+	assert.Error(t, err)
+
+	_, err = parseSessionToken("secret", access, sessionUseRefresh)
+	assert.Error(t, err)
+
+	expired, err := signSessionToken("secret", newTestSessionClaims(sessionUseAccess, time.Now().Add(-time.Minute)))
+	require.NoError(t, err)
+
+	_, err = parseSessionToken("secret", expired, sessionUseAccess)
+	assert.Error(t, err)
+
+	idToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{Issuer: "https://idp.example.com"}).SignedString([]byte("secret"))
+	require.NoError(t, err)
+	assert.False(t, IsSessionToken(idToken))
+}
+
+func TestSessionScopes(t *testing.T) {
+	unscoped := types.TokenScopes{}
+	scoped, err := types.ParseTokenScopes([]string{"objects:write", "tasks:read"})
+	require.NoError(t, err)
+
+	defaults := []string{"objects:read", "volumes:read", "tasks:read"}
+
+	scopes, err := sessionScopes(unscoped, nil, defaults)
+	require.NoError(t, err)
+	assert.Equal(t, defaults, scopes.Strings())
+
+	scopes, err = sessionScopes(scoped, nil, defaults)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"objects:read", "tasks:read"}, scopes.Strings())
+
+	scopes, err = sessionScopes(scoped, []string{"objects:write"}, defaults)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"objects:write"}, scopes.Strings())
+
+	_, err = sessionScopes(scoped, []string{"volumes:read"}, defaults)
+	assert.Error(t, err)
+
+	_, err = sessionScopes(scoped, nil, []string{"secrets:read"})
+	assert.Error(t, err)
+}
+
+func TestRefreshSpendsRefreshTokenOnce(t *testing.T) {
+	s, err := miniredis.Run()
+	require.NoError(t, err)
+	defer s.Close()
+
+	rdb, err := common.NewRedisClient(types.RedisConfig{Addrs: []string{s.Addr()}, Mode: types.RedisModeSingle})
+	require.NoError(t, err)
+
+	manager := NewSessionManager(types.SessionTokensConfig{
+		Enabled:        true,
+		SigningKey:     "secret",
+		AccessTokenTTL: time.Minute,
+		SessionTTL:     time.Hour,
+		DefaultScopes:  []string{"objects:read"},
+	}, nil, repository.NewWorkspaceRedisRepositoryForTest(rdb))
+
+	tokens, err := manager.Start(&types.Token{Key: "token-1"}, &types.Workspace{ExternalId: "workspace-1"}, nil)
+	require.NoError(t, err)
+
+	// Refreshes racing with the same refresh token can't all get new tokens
+	const attempts = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, attempts)
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := manager.Refresh(tokens.RefreshToken)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	succeeded := 0
+	for err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		// Refreshes that come after the session was revoked find it gone
+		assert.Contains(t, []error{errSessionRefreshUsed, errSessionNotFound}, err)
+	}
+	assert.Equal(t, 1, succeeded)
+
+	// Reusing the refresh token revoked the session
+	session, err := manager.Get(tokens.SessionId)
+	require.NoError(t, err)
+	assert.Nil(t, session)
+}
@@ -15,10 +15,22 @@ type Signature struct {
 }
 
 func SignPayload(payload []byte, secretKey string) Signature {
-	base64Payload := base64.StdEncoding.EncodeToString(payload)
-
 	currentTime := time.Now().Unix()
-	dataToSign := fmt.Sprintf("%s:%d", base64Payload, currentTime) // Concatenate base64 payload with timestamp
+
+	return Signature{
+		Key:       signPayloadAt(payload, secretKey, currentTime),
+		Timestamp: currentTime,
+	}
+}
+
+// VerifyPayloadSignature reports whether signature is what SignPayload returned for the payload at timestamp
+func VerifyPayloadSignature(payload []byte, secretKey string, signature string, timestamp int64) bool {
+	return hmac.Equal([]byte(signPayloadAt(payload, secretKey, timestamp)), []byte(signature))
+}
+
+func signPayloadAt(payload []byte, secretKey string, timestamp int64) string {
+	base64Payload := base64.StdEncoding.EncodeToString(payload)
+	dataToSign := fmt.Sprintf("%s:%d", base64Payload, timestamp) // Concatenate base64 payload with timestamp
 
 	// Initialize HMAC with SHA256 and secret key
 	h := hmac.New(sha256.New, []byte(secretKey))
@@ -26,10 +38,5 @@ func SignPayload(payload []byte, secretKey string) Signature {
 
 	// Compute the HMAC signature
 	signature := h.Sum(nil)
-	hexSignature := hex.EncodeToString(signature)
-
-	return Signature{
-		Key:       hexSignature,
-		Timestamp: currentTime,
-	}
+	return hex.EncodeToString(signature)
 }
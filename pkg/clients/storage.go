@@ -26,13 +26,72 @@ type StorageClient struct {
 	presignClient *s3.PresignClient
 }
 
+var ErrStorageOperationUnsupported = errors.New("operation is not supported by this storage provider")
+
+// WorkspaceStorageBackend is implemented for each kind of object store a workspace's storage can live in.
+// Keys are relative to the workspace's bucket. Results use the S3 types the rest of the codebase already
+// expects, with only the fields the provider can report filled in.
+type WorkspaceStorageBackend interface {
+	UploadWithReader(ctx context.Context, key string, data io.Reader) error
+	Head(ctx context.Context, key string) (bool, *s3.HeadObjectOutput, error)
+	Exists(ctx context.Context, key string) (bool, error)
+	DownloadRangeWithReader(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	ListDirectory(ctx context.Context, dir string) ([]s3types.Object, error)
+	ListWithPrefix(ctx context.Context, prefix string) ([]s3types.Object, error)
+	DeleteWithPrefix(ctx context.Context, prefix string) ([]string, error)
+	CopyObject(ctx context.Context, sourceKey, destinationKey string) error
+	GeneratePresignedPutURL(ctx context.Context, key string, expiresInSeconds int64) (string, error)
+	GeneratePresignedGetURL(ctx context.Context, key string, expiresInSeconds int64) (string, error)
+	// PresignedPutHeaders are the headers a client must send along with a presigned PUT
+	PresignedPutHeaders() map[string]string
+	ValidateBucketAccess(ctx context.Context) error
+}
+
 type WorkspaceStorageClient struct {
 	WorkspaceName    string
 	WorkspaceStorage *types.WorkspaceStorage
-	StorageClient    *StorageClient
+
+	// StorageClient is only set for S3 compatible storage
+	StorageClient *StorageClient
+	backend       WorkspaceStorageBackend
 }
 
 func NewWorkspaceStorageClient(ctx context.Context, workspaceName string, workspaceStorage *types.WorkspaceStorage) (*WorkspaceStorageClient, error) {
+	client := &WorkspaceStorageClient{
+		WorkspaceName:    workspaceName,
+		WorkspaceStorage: workspaceStorage,
+	}
+
+	switch provider := workspaceStorage.StorageProvider(); provider {
+	case types.WorkspaceStorageProviderS3:
+		storageClient, err := newS3StorageClient(ctx, workspaceStorage)
+		if err != nil {
+			return nil, err
+		}
+
+		client.StorageClient = storageClient
+		client.backend = &s3WorkspaceStorage{client: storageClient, bucket: *workspaceStorage.BucketName}
+	case types.WorkspaceStorageProviderGCS:
+		backend, err := newGCSWorkspaceStorage(ctx, workspaceStorage)
+		if err != nil {
+			return nil, err
+		}
+		client.backend = backend
+	case types.WorkspaceStorageProviderAzure:
+		backend, err := newAzureWorkspaceStorage(workspaceStorage)
+		if err != nil {
+			return nil, err
+		}
+		client.backend = backend
+	default:
+		return nil, fmt.Errorf("unknown storage provider: %s", provider)
+	}
+
+	return client, nil
+}
+
+func newS3StorageClient(ctx context.Context, workspaceStorage *types.WorkspaceStorage) (*StorageClient, error) {
 	cfg, err := config.LoadDefaultConfig(ctx,
 		config.WithRegion(*workspaceStorage.Region),
 		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(
@@ -53,13 +112,9 @@ func NewWorkspaceStorageClient(ctx context.Context, workspaceName string, worksp
 	s3Client := s3.NewFromConfig(cfg)
 	presignClient := s3.NewPresignClient(s3Client)
 
-	return &WorkspaceStorageClient{
-		WorkspaceName:    workspaceName,
-		WorkspaceStorage: workspaceStorage,
-		StorageClient: &StorageClient{
-			s3Client:      s3Client,
-			presignClient: presignClient,
-		},
+	return &StorageClient{
+		s3Client:      s3Client,
+		presignClient: presignClient,
 	}, nil
 }
 
@@ -176,6 +231,25 @@ func (c *StorageClient) DownloadWithReader(ctx context.Context, key string, buck
 	return resp.Body, nil
 }
 
+// DownloadRangeWithReader reads length bytes of an object starting at offset. A length of 0 reads to the end of the object.
+func (c *StorageClient) DownloadRangeWithReader(ctx context.Context, key string, offset, length int64, bucket string) (io.ReadCloser, error) {
+	byteRange := fmt.Sprintf("bytes=%d-", offset)
+	if length > 0 {
+		byteRange = fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)
+	}
+
+	resp, err := c.s3Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+		Range:  aws.String(byteRange),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
 func (c *StorageClient) Delete(ctx context.Context, key string, bucket string) error {
 	_, err := c.s3Client.DeleteObject(ctx, &s3.DeleteObjectInput{
 		Bucket: aws.String(bucket),
@@ -259,16 +333,99 @@ func (c *StorageClient) GeneratePresignedPutURLs(ctx context.Context, keys []str
 	return urls, nil
 }
 
-func (c *StorageClient) ListWithPrefix(ctx context.Context, prefix string, bucket string) ([]s3types.Object, error) {
-	resp, err := c.s3Client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+func (c *StorageClient) CreateMultipartUpload(ctx context.Context, key string, bucket string) (string, error) {
+	output, err := c.s3Client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
 		Bucket: aws.String(bucket),
-		Prefix: aws.String(prefix),
+		Key:    aws.String(key),
 	})
 	if err != nil {
-		return nil, err
+		return "", fmt.Errorf("failed to create multipart upload: %w", err)
+	}
+
+	return aws.ToString(output.UploadId), nil
+}
+
+func (c *StorageClient) GeneratePresignedUploadPartURLs(ctx context.Context, key, uploadId string, partCount int32, expiresInSeconds int64, bucket string) ([]string, error) {
+	urls := make([]string, 0, partCount)
+	for partNumber := int32(1); partNumber <= partCount; partNumber++ {
+		result, err := c.presignClient.PresignUploadPart(ctx, &s3.UploadPartInput{
+			Bucket:     aws.String(bucket),
+			Key:        aws.String(key),
+			UploadId:   aws.String(uploadId),
+			PartNumber: aws.Int32(partNumber),
+		}, s3.WithPresignExpires(time.Duration(expiresInSeconds)*time.Second))
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate presigned URL for part %d: %w", partNumber, err)
+		}
+
+		urls = append(urls, result.URL)
+	}
+
+	return urls, nil
+}
+
+type CompletedPart struct {
+	PartNumber int32
+	ETag       string
+}
+
+func (c *StorageClient) CompleteMultipartUpload(ctx context.Context, key, uploadId string, parts []CompletedPart, bucket string) error {
+	completedParts := make([]s3types.CompletedPart, 0, len(parts))
+	for _, part := range parts {
+		completedParts = append(completedParts, s3types.CompletedPart{
+			PartNumber: aws.Int32(part.PartNumber),
+			ETag:       aws.String(part.ETag),
+		})
+	}
+
+	_, err := c.s3Client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:   aws.String(bucket),
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadId),
+		MultipartUpload: &s3types.CompletedMultipartUpload{
+			Parts: completedParts,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to complete multipart upload: %w", err)
 	}
 
-	return resp.Contents, nil
+	return nil
+}
+
+func (c *StorageClient) AbortMultipartUpload(ctx context.Context, key, uploadId string, bucket string) error {
+	_, err := c.s3Client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(bucket),
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadId),
+	})
+	return err
+}
+
+func (c *StorageClient) ListWithPrefix(ctx context.Context, prefix string, bucket string) ([]s3types.Object, error) {
+	var continuationToken *string
+	var objects []s3types.Object
+
+	for {
+		resp, err := c.s3Client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+			Bucket:            aws.String(bucket),
+			Prefix:            aws.String(prefix),
+			ContinuationToken: continuationToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		objects = append(objects, resp.Contents...)
+
+		if resp.IsTruncated == nil || !*resp.IsTruncated {
+			break
+		}
+
+		continuationToken = resp.NextContinuationToken
+	}
+
+	return objects, nil
 }
 
 func (c *StorageClient) DeleteWithPrefix(ctx context.Context, prefix string, bucket string) ([]string, error) {
@@ -382,72 +539,217 @@ func (c *StorageClient) ValidateBucketAccess(ctx context.Context, bucketName str
 	return nil
 }
 
+// s3WorkspaceStorage is the WorkspaceStorageBackend for S3 compatible storage
+type s3WorkspaceStorage struct {
+	client *StorageClient
+	bucket string
+}
+
+func (b *s3WorkspaceStorage) UploadWithReader(ctx context.Context, key string, data io.Reader) error {
+	return b.client.UploadToBucketWithReader(ctx, key, data, b.bucket)
+}
+
+func (b *s3WorkspaceStorage) Head(ctx context.Context, key string) (bool, *s3.HeadObjectOutput, error) {
+	return b.client.Head(ctx, key, b.bucket)
+}
+
+func (b *s3WorkspaceStorage) Exists(ctx context.Context, key string) (bool, error) {
+	return b.client.Exists(ctx, key, b.bucket)
+}
+
+func (b *s3WorkspaceStorage) DownloadRangeWithReader(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	return b.client.DownloadRangeWithReader(ctx, key, offset, length, b.bucket)
+}
+
+func (b *s3WorkspaceStorage) Delete(ctx context.Context, key string) error {
+	return b.client.Delete(ctx, key, b.bucket)
+}
+
+func (b *s3WorkspaceStorage) ListDirectory(ctx context.Context, dir string) ([]s3types.Object, error) {
+	return b.client.ListDirectory(ctx, dir, b.bucket)
+}
+
+func (b *s3WorkspaceStorage) ListWithPrefix(ctx context.Context, prefix string) ([]s3types.Object, error) {
+	return b.client.ListWithPrefix(ctx, prefix, b.bucket)
+}
+
+func (b *s3WorkspaceStorage) DeleteWithPrefix(ctx context.Context, prefix string) ([]string, error) {
+	return b.client.DeleteWithPrefix(ctx, prefix, b.bucket)
+}
+
+func (b *s3WorkspaceStorage) CopyObject(ctx context.Context, sourceKey, destinationKey string) error {
+	return b.client.CopyObject(ctx, CopyObjectInput{
+		SourceKey:             sourceKey,
+		SourceBucketName:      b.bucket,
+		DestinationKey:        destinationKey,
+		DestinationBucketName: b.bucket,
+	})
+}
+
+func (b *s3WorkspaceStorage) GeneratePresignedPutURL(ctx context.Context, key string, expiresInSeconds int64) (string, error) {
+	return b.client.GeneratePresignedPutURL(ctx, key, expiresInSeconds, b.bucket)
+}
+
+func (b *s3WorkspaceStorage) GeneratePresignedGetURL(ctx context.Context, key string, expiresInSeconds int64) (string, error) {
+	return b.client.GeneratePresignedGetURL(ctx, key, expiresInSeconds, b.bucket)
+}
+
+func (b *s3WorkspaceStorage) PresignedPutHeaders() map[string]string {
+	return nil
+}
+
+func (b *s3WorkspaceStorage) ValidateBucketAccess(ctx context.Context) error {
+	return b.client.ValidateBucketAccess(ctx, b.bucket)
+}
+
+// S3Client returns nil if the workspace's storage isn't S3 compatible
 func (c *WorkspaceStorageClient) S3Client() *s3.Client {
+	if c.StorageClient == nil {
+		return nil
+	}
 	return c.StorageClient.s3Client
 }
 
+// PresignClient returns nil if the workspace's storage isn't S3 compatible
 func (c *WorkspaceStorageClient) PresignClient() *s3.PresignClient {
+	if c.StorageClient == nil {
+		return nil
+	}
 	return c.StorageClient.presignClient
 }
 
+func (c *WorkspaceStorageClient) Provider() string {
+	return c.WorkspaceStorage.StorageProvider()
+}
+
+// S3Compatible reports whether the storage can be used through the S3 API directly, e.g. for multipart uploads
+func (c *WorkspaceStorageClient) S3Compatible() bool {
+	return c.StorageClient != nil
+}
+
 func (c *WorkspaceStorageClient) BucketName() string {
 	return *c.WorkspaceStorage.BucketName
 }
 
 func (c *WorkspaceStorageClient) Upload(ctx context.Context, key string, data []byte) error {
-	return c.StorageClient.UploadToBucket(ctx, key, data, *c.WorkspaceStorage.BucketName)
+	return c.backend.UploadWithReader(ctx, key, bytes.NewReader(data))
 }
 
 func (c *WorkspaceStorageClient) UploadWithReader(ctx context.Context, key string, data io.Reader) error {
-	return c.StorageClient.UploadToBucketWithReader(ctx, key, data, *c.WorkspaceStorage.BucketName)
+	return c.backend.UploadWithReader(ctx, key, data)
 }
 
 func (c *WorkspaceStorageClient) Head(ctx context.Context, key string) (bool, *s3.HeadObjectOutput, error) {
-	return c.StorageClient.Head(ctx, key, *c.WorkspaceStorage.BucketName)
+	return c.backend.Head(ctx, key)
 }
 
 func (c *WorkspaceStorageClient) Exists(ctx context.Context, key string) (bool, error) {
-	return c.StorageClient.Exists(ctx, key, *c.WorkspaceStorage.BucketName)
+	return c.backend.Exists(ctx, key)
 }
 
 func (c *WorkspaceStorageClient) Download(ctx context.Context, key string) ([]byte, error) {
-	return c.StorageClient.Download(ctx, key, *c.WorkspaceStorage.BucketName)
+	reader, err := c.backend.DownloadRangeWithReader(ctx, key, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return io.ReadAll(reader)
 }
 
 func (c WorkspaceStorageClient) DownloadWithReader(ctx context.Context, key string) (io.ReadCloser, error) {
-	return c.StorageClient.DownloadWithReader(ctx, key, *c.WorkspaceStorage.BucketName)
+	return c.backend.DownloadRangeWithReader(ctx, key, 0, 0)
+}
+
+func (c *WorkspaceStorageClient) DownloadRangeWithReader(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	return c.backend.DownloadRangeWithReader(ctx, key, offset, length)
 }
 
 func (c *WorkspaceStorageClient) Delete(ctx context.Context, key string) error {
-	return c.StorageClient.Delete(ctx, key, *c.WorkspaceStorage.BucketName)
+	return c.backend.Delete(ctx, key)
 }
 
 func (c *WorkspaceStorageClient) ListDirectory(ctx context.Context, dir string) ([]s3types.Object, error) {
-	return c.StorageClient.ListDirectory(ctx, dir, *c.WorkspaceStorage.BucketName)
+	return c.backend.ListDirectory(ctx, dir)
 }
 
 func (c *WorkspaceStorageClient) GeneratePresignedPutURL(ctx context.Context, key string, expiresInSeconds int64) (string, error) {
-	return c.StorageClient.GeneratePresignedPutURL(ctx, key, expiresInSeconds, *c.WorkspaceStorage.BucketName)
+	return c.backend.GeneratePresignedPutURL(ctx, key, expiresInSeconds)
 }
 
 func (c *WorkspaceStorageClient) GeneratePresignedGetURL(ctx context.Context, key string, expiresInSeconds int64) (string, error) {
-	return c.StorageClient.GeneratePresignedGetURL(ctx, key, expiresInSeconds, *c.WorkspaceStorage.BucketName)
+	return c.backend.GeneratePresignedGetURL(ctx, key, expiresInSeconds)
+}
+
+func (c *WorkspaceStorageClient) PresignedPutHeaders() map[string]string {
+	return c.backend.PresignedPutHeaders()
 }
 
 func (c *WorkspaceStorageClient) GeneratePresignedPutURLs(ctx context.Context, keys []string, expiresInSeconds int64) (map[string]string, error) {
-	return c.StorageClient.GeneratePresignedPutURLs(ctx, keys, expiresInSeconds, *c.WorkspaceStorage.BucketName)
+	urls := make(map[string]string)
+	for _, key := range keys {
+		url, err := c.backend.GeneratePresignedPutURL(ctx, key, expiresInSeconds)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate presigned URL for key %s: %w", key, err)
+		}
+		urls[key] = url
+	}
+
+	return urls, nil
+}
+
+func (c *WorkspaceStorageClient) CreateMultipartUpload(ctx context.Context, key string) (string, error) {
+	if c.StorageClient == nil {
+		return "", ErrStorageOperationUnsupported
+	}
+	return c.StorageClient.CreateMultipartUpload(ctx, key, *c.WorkspaceStorage.BucketName)
+}
+
+func (c *WorkspaceStorageClient) GeneratePresignedUploadPartURLs(ctx context.Context, key, uploadId string, partCount int32, expiresInSeconds int64) ([]string, error) {
+	if c.StorageClient == nil {
+		return nil, ErrStorageOperationUnsupported
+	}
+	return c.StorageClient.GeneratePresignedUploadPartURLs(ctx, key, uploadId, partCount, expiresInSeconds, *c.WorkspaceStorage.BucketName)
+}
+
+func (c *WorkspaceStorageClient) CompleteMultipartUpload(ctx context.Context, key, uploadId string, parts []CompletedPart) error {
+	if c.StorageClient == nil {
+		return ErrStorageOperationUnsupported
+	}
+	return c.StorageClient.CompleteMultipartUpload(ctx, key, uploadId, parts, *c.WorkspaceStorage.BucketName)
+}
+
+func (c *WorkspaceStorageClient) AbortMultipartUpload(ctx context.Context, key, uploadId string) error {
+	if c.StorageClient == nil {
+		return ErrStorageOperationUnsupported
+	}
+	return c.StorageClient.AbortMultipartUpload(ctx, key, uploadId, *c.WorkspaceStorage.BucketName)
 }
 
 func (c *WorkspaceStorageClient) ListWithPrefix(ctx context.Context, prefix string) ([]s3types.Object, error) {
-	return c.StorageClient.ListWithPrefix(ctx, prefix, *c.WorkspaceStorage.BucketName)
+	return c.backend.ListWithPrefix(ctx, prefix)
 }
 
 func (c *WorkspaceStorageClient) DeleteWithPrefix(ctx context.Context, prefix string) ([]string, error) {
-	return c.StorageClient.DeleteWithPrefix(ctx, prefix, *c.WorkspaceStorage.BucketName)
+	return c.backend.DeleteWithPrefix(ctx, prefix)
 }
+
 func (c *WorkspaceStorageClient) MoveObject(ctx context.Context, sourceKey, destinationKey string) error {
-	return c.StorageClient.MoveObject(ctx, sourceKey, destinationKey, *c.WorkspaceStorage.BucketName)
+	if err := c.backend.CopyObject(ctx, sourceKey, destinationKey); err != nil {
+		return fmt.Errorf("failed to copy file from %s to %s: %w", sourceKey, destinationKey, err)
+	}
+
+	if err := c.backend.Delete(ctx, sourceKey); err != nil {
+		return fmt.Errorf("failed to delete original file %s: %w", sourceKey, err)
+	}
+
+	return nil
 }
+
+func (c *WorkspaceStorageClient) CopyObject(ctx context.Context, sourceKey, destinationKey string) error {
+	return c.backend.CopyObject(ctx, sourceKey, destinationKey)
+}
+
 func (c *WorkspaceStorageClient) ValidateBucketAccess(ctx context.Context) error {
-	return c.StorageClient.ValidateBucketAccess(ctx, *c.WorkspaceStorage.BucketName)
+	return c.backend.ValidateBucketAccess(ctx)
 }
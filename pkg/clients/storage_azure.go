@@ -0,0 +1,527 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+const (
+	azureStorageApiVersion = "2020-12-06"
+	azureSasTimeFormat     = "2006-01-02T15:04:05Z"
+	azureBlockSize         = 8 * 1024 * 1024
+	azureCopyPollInterval  = 500 * time.Millisecond
+)
+
+// azureWorkspaceStorage stores workspace objects in an Azure Blob Storage container. The workspace
+// storage's access key is the storage account name, its secret key the account key and its bucket
+// the container. Requests are authorized with Shared Key and URLs are signed as service SAS.
+type azureWorkspaceStorage struct {
+	account    string
+	key        []byte
+	container  string
+	endpoint   *url.URL
+	httpClient *http.Client
+}
+
+type azureStorageError struct {
+	StatusCode int
+	Code       string
+}
+
+func (e *azureStorageError) Error() string {
+	return fmt.Sprintf("azure storage request failed: %d %s", e.StatusCode, e.Code)
+}
+
+func isAzureNotFound(err error) bool {
+	var azureErr *azureStorageError
+	return errors.As(err, &azureErr) && azureErr.StatusCode == http.StatusNotFound
+}
+
+func newAzureWorkspaceStorage(workspaceStorage *types.WorkspaceStorage) (*azureWorkspaceStorage, error) {
+	if workspaceStorage.AccessKey == nil || workspaceStorage.SecretKey == nil {
+		return nil, errors.New("an account name and key are required for Azure storage")
+	}
+
+	key, err := base64.StdEncoding.DecodeString(*workspaceStorage.SecretKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Azure account key: %w", err)
+	}
+
+	// The endpoint only needs to be set for emulators and sovereign clouds
+	endpoint := fmt.Sprintf("https://%s.blob.core.windows.net", *workspaceStorage.AccessKey)
+	if workspaceStorage.EndpointUrl != nil && *workspaceStorage.EndpointUrl != "" {
+		endpoint = *workspaceStorage.EndpointUrl
+	}
+
+	endpointURL, err := url.Parse(strings.TrimSuffix(endpoint, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid Azure endpoint: %w", err)
+	}
+
+	return &azureWorkspaceStorage{
+		account:    *workspaceStorage.AccessKey,
+		key:        key,
+		container:  *workspaceStorage.BucketName,
+		endpoint:   endpointURL,
+		httpClient: http.DefaultClient,
+	}, nil
+}
+
+func (b *azureWorkspaceStorage) containerURL(query url.Values) *url.URL {
+	u := b.endpoint.JoinPath(b.container)
+	u.RawQuery = query.Encode()
+	return u
+}
+
+func (b *azureWorkspaceStorage) blobURL(key string, query url.Values) *url.URL {
+	u := b.endpoint.JoinPath(b.container, key)
+	u.RawQuery = query.Encode()
+	return u
+}
+
+// do sends a request authorized with the account key. Responses outside of 2xx are returned as an *azureStorageError.
+func (b *azureWorkspaceStorage) do(ctx context.Context, method string, u *url.URL, headers map[string]string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = int64(len(body))
+
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set("x-ms-date", time.Now().UTC().Format(http.TimeFormat))
+	req.Header.Set("x-ms-version", azureStorageApiVersion)
+	req.Header.Set("Authorization", fmt.Sprintf("SharedKey %s:%s", b.account, b.sign(b.sharedKeyStringToSign(req))))
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		return nil, &azureStorageError{StatusCode: resp.StatusCode, Code: resp.Header.Get("x-ms-error-code")}
+	}
+
+	return resp, nil
+}
+
+func (b *azureWorkspaceStorage) sign(stringToSign string) string {
+	mac := hmac.New(sha256.New, b.key)
+	mac.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// sharedKeyStringToSign builds the string signed for Shared Key authorization. The Date header is left
+// empty since x-ms-date is always set.
+func (b *azureWorkspaceStorage) sharedKeyStringToSign(req *http.Request) string {
+	contentLength := ""
+	if req.ContentLength > 0 {
+		contentLength = strconv.FormatInt(req.ContentLength, 10)
+	}
+
+	var msHeaders []string
+	for name := range req.Header {
+		if name = strings.ToLower(name); strings.HasPrefix(name, "x-ms-") {
+			msHeaders = append(msHeaders, name)
+		}
+	}
+	sort.Strings(msHeaders)
+
+	var canonicalized strings.Builder
+	for _, name := range msHeaders {
+		canonicalized.WriteString(name + ":" + strings.TrimSpace(req.Header.Get(name)) + "\n")
+	}
+
+	canonicalized.WriteString("/" + b.account + req.URL.EscapedPath())
+
+	query := req.URL.Query()
+	params := make([]string, 0, len(query))
+	for name := range query {
+		params = append(params, name)
+	}
+	sort.Strings(params)
+
+	for _, name := range params {
+		values := query[name]
+		sort.Strings(values)
+		canonicalized.WriteString("\n" + strings.ToLower(name) + ":" + strings.Join(values, ","))
+	}
+
+	return strings.Join([]string{
+		req.Method,
+		req.Header.Get("Content-Encoding"),
+		req.Header.Get("Content-Language"),
+		contentLength,
+		req.Header.Get("Content-MD5"),
+		req.Header.Get("Content-Type"),
+		"",
+		req.Header.Get("If-Modified-Since"),
+		req.Header.Get("If-Match"),
+		req.Header.Get("If-None-Match"),
+		req.Header.Get("If-Unmodified-Since"),
+		req.Header.Get("Range"),
+		canonicalized.String(),
+	}, "\n")
+}
+
+// UploadWithReader stores content that fits in one block with a single request and anything larger as a
+// list of blocks, since the length of the reader isn't known up front
+func (b *azureWorkspaceStorage) UploadWithReader(ctx context.Context, key string, data io.Reader) error {
+	var blockIds []string
+	buf := make([]byte, azureBlockSize)
+
+	for {
+		n, err := io.ReadFull(data, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return err
+		}
+		last := err != nil
+
+		if last && len(blockIds) == 0 {
+			resp, err := b.do(ctx, http.MethodPut, b.blobURL(key, nil), map[string]string{"x-ms-blob-type": "BlockBlob"}, buf[:n])
+			if err != nil {
+				return err
+			}
+			return resp.Body.Close()
+		}
+
+		if n > 0 {
+			blockId := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%08d", len(blockIds))))
+			resp, err := b.do(ctx, http.MethodPut, b.blobURL(key, url.Values{"comp": {"block"}, "blockid": {blockId}}), nil, buf[:n])
+			if err != nil {
+				return err
+			}
+			resp.Body.Close()
+
+			blockIds = append(blockIds, blockId)
+		}
+
+		if last {
+			break
+		}
+	}
+
+	var blockList bytes.Buffer
+	blockList.WriteString(`<?xml version="1.0" encoding="utf-8"?><BlockList>`)
+	for _, blockId := range blockIds {
+		blockList.WriteString("<Latest>" + blockId + "</Latest>")
+	}
+	blockList.WriteString("</BlockList>")
+
+	resp, err := b.do(ctx, http.MethodPut, b.blobURL(key, url.Values{"comp": {"blocklist"}}), nil, blockList.Bytes())
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func (b *azureWorkspaceStorage) Head(ctx context.Context, key string) (bool, *s3.HeadObjectOutput, error) {
+	resp, err := b.do(ctx, http.MethodHead, b.blobURL(key, nil), nil, nil)
+	if err != nil {
+		return false, nil, err
+	}
+	resp.Body.Close()
+
+	output := &s3.HeadObjectOutput{
+		ContentLength: aws.Int64(resp.ContentLength),
+		ContentType:   aws.String(resp.Header.Get("Content-Type")),
+		ETag:          aws.String(resp.Header.Get("ETag")),
+	}
+
+	if lastModified, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		output.LastModified = aws.Time(lastModified)
+	}
+
+	return true, output, nil
+}
+
+func (b *azureWorkspaceStorage) Exists(ctx context.Context, key string) (bool, error) {
+	_, _, err := b.Head(ctx, key)
+	if isAzureNotFound(err) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+func (b *azureWorkspaceStorage) DownloadRangeWithReader(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	byteRange := fmt.Sprintf("bytes=%d-", offset)
+	if length > 0 {
+		byteRange = fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)
+	}
+
+	resp, err := b.do(ctx, http.MethodGet, b.blobURL(key, nil), map[string]string{"x-ms-range": byteRange}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+func (b *azureWorkspaceStorage) Delete(ctx context.Context, key string) error {
+	resp, err := b.do(ctx, http.MethodDelete, b.blobURL(key, nil), nil, nil)
+	if isAzureNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+type azureBlobList struct {
+	Blobs []struct {
+		Name       string `xml:"Name"`
+		Properties struct {
+			LastModified  string `xml:"Last-Modified"`
+			Etag          string `xml:"Etag"`
+			ContentLength int64  `xml:"Content-Length"`
+		} `xml:"Properties"`
+	} `xml:"Blobs>Blob"`
+	Prefixes []struct {
+		Name string `xml:"Name"`
+	} `xml:"Blobs>BlobPrefix"`
+	NextMarker string `xml:"NextMarker"`
+}
+
+// list calls visit with each page of blobs under prefix
+func (b *azureWorkspaceStorage) list(ctx context.Context, prefix, delimiter string, visit func(page *azureBlobList)) error {
+	marker := ""
+
+	for {
+		query := url.Values{"restype": {"container"}, "comp": {"list"}, "prefix": {prefix}}
+		if delimiter != "" {
+			query.Set("delimiter", delimiter)
+		}
+		if marker != "" {
+			query.Set("marker", marker)
+		}
+
+		resp, err := b.do(ctx, http.MethodGet, b.containerURL(query), nil, nil)
+		if err != nil {
+			return err
+		}
+
+		var page azureBlobList
+		err = xml.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		visit(&page)
+
+		if page.NextMarker == "" {
+			return nil
+		}
+		marker = page.NextMarker
+	}
+}
+
+func azureObjects(page *azureBlobList) []s3types.Object {
+	objects := make([]s3types.Object, 0, len(page.Blobs))
+	for _, blob := range page.Blobs {
+		obj := s3types.Object{
+			Key:  aws.String(blob.Name),
+			Size: aws.Int64(blob.Properties.ContentLength),
+			ETag: aws.String(blob.Properties.Etag),
+		}
+
+		if lastModified, err := http.ParseTime(blob.Properties.LastModified); err == nil {
+			obj.LastModified = aws.Time(lastModified)
+		}
+
+		objects = append(objects, obj)
+	}
+
+	return objects
+}
+
+func (b *azureWorkspaceStorage) ListDirectory(ctx context.Context, dir string) ([]s3types.Object, error) {
+	if !strings.HasSuffix(dir, "/") {
+		dir += "/"
+	}
+
+	var allObjects []s3types.Object
+	err := b.list(ctx, dir, "/", func(page *azureBlobList) {
+		for _, obj := range azureObjects(page) {
+			if *obj.Key != dir {
+				allObjects = append(allObjects, obj)
+			}
+		}
+
+		for _, prefix := range page.Prefixes {
+			allObjects = append(allObjects, s3types.Object{
+				Key:          aws.String(prefix.Name),
+				Size:         aws.Int64(0),
+				LastModified: aws.Time(time.Now()),
+			})
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return allObjects, nil
+}
+
+func (b *azureWorkspaceStorage) ListWithPrefix(ctx context.Context, prefix string) ([]s3types.Object, error) {
+	var objects []s3types.Object
+	err := b.list(ctx, prefix, "", func(page *azureBlobList) {
+		objects = append(objects, azureObjects(page)...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return objects, nil
+}
+
+func (b *azureWorkspaceStorage) DeleteWithPrefix(ctx context.Context, prefix string) ([]string, error) {
+	objects, err := b.ListWithPrefix(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var deletedObjects []string
+	for _, obj := range objects {
+		if err := b.Delete(ctx, *obj.Key); err != nil {
+			return nil, err
+		}
+
+		deletedObjects = append(deletedObjects, strings.TrimPrefix(*obj.Key, prefix))
+	}
+
+	return deletedObjects, nil
+}
+
+// CopyObject copies within the container. Copies within an account usually finish before the response,
+// but may be reported as pending, in which case the destination is polled until the copy settles.
+func (b *azureWorkspaceStorage) CopyObject(ctx context.Context, sourceKey, destinationKey string) error {
+	resp, err := b.do(ctx, http.MethodPut, b.blobURL(destinationKey, nil), map[string]string{"x-ms-copy-source": b.blobURL(sourceKey, nil).String()}, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	copyStatus := resp.Header.Get("x-ms-copy-status")
+	for copyStatus == "pending" {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(azureCopyPollInterval):
+		}
+
+		resp, err := b.do(ctx, http.MethodHead, b.blobURL(destinationKey, nil), nil, nil)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+
+		copyStatus = resp.Header.Get("x-ms-copy-status")
+	}
+
+	if copyStatus != "" && copyStatus != "success" {
+		return fmt.Errorf("failed to copy blob: %s", copyStatus)
+	}
+
+	return nil
+}
+
+func (b *azureWorkspaceStorage) GeneratePresignedPutURL(ctx context.Context, key string, expiresInSeconds int64) (string, error) {
+	return b.sasURL(key, "cw", expiresInSeconds, ""), nil
+}
+
+func (b *azureWorkspaceStorage) GeneratePresignedGetURL(ctx context.Context, key string, expiresInSeconds int64) (string, error) {
+	return b.sasURL(key, "r", expiresInSeconds, "attachment"), nil
+}
+
+// sasURL signs a service SAS granting permissions on a single blob until it expires
+func (b *azureWorkspaceStorage) sasURL(key, permissions string, expiresInSeconds int64, contentDisposition string) string {
+	u := b.blobURL(key, nil)
+	expiry := time.Now().UTC().Add(time.Duration(expiresInSeconds) * time.Second).Format(azureSasTimeFormat)
+
+	protocol := "https"
+	if u.Scheme == "http" {
+		protocol = "https,http"
+	}
+
+	stringToSign := strings.Join([]string{
+		permissions,
+		"", // start
+		expiry,
+		fmt.Sprintf("/blob/%s/%s/%s", b.account, b.container, key),
+		"", // identifier
+		"", // ip
+		protocol,
+		azureStorageApiVersion,
+		"b",
+		"", // snapshot time
+		"", // encryption scope
+		"", // cache control
+		contentDisposition,
+		"", // content encoding
+		"", // content language
+		"", // content type
+	}, "\n")
+
+	query := url.Values{
+		"sv":  {azureStorageApiVersion},
+		"sr":  {"b"},
+		"sp":  {permissions},
+		"se":  {expiry},
+		"spr": {protocol},
+		"sig": {b.sign(stringToSign)},
+	}
+	if contentDisposition != "" {
+		query.Set("rscd", contentDisposition)
+	}
+
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// PresignedPutHeaders are required since Azure needs to be told which kind of blob to create
+func (b *azureWorkspaceStorage) PresignedPutHeaders() map[string]string {
+	return map[string]string{"x-ms-blob-type": "BlockBlob"}
+}
+
+func (b *azureWorkspaceStorage) ValidateBucketAccess(ctx context.Context) error {
+	resp, err := b.do(ctx, http.MethodHead, b.containerURL(url.Values{"restype": {"container"}}), nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to access bucket: %w", err)
+	}
+	resp.Body.Close()
+
+	if err := b.UploadWithReader(ctx, testObjectKey, strings.NewReader("")); err != nil {
+		return fmt.Errorf("failed to write to bucket: %w", err)
+	}
+
+	if err := b.Delete(ctx, testObjectKey); err != nil {
+		return fmt.Errorf("failed to delete test object: %w", err)
+	}
+
+	return nil
+}
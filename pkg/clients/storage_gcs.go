@@ -0,0 +1,228 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/beam-cloud/beta9/pkg/types"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+)
+
+// gcsWorkspaceStorage stores workspace objects in a Google Cloud Storage bucket. The workspace storage's
+// secret key holds a service account JSON key, which is also used to sign URLs.
+type gcsWorkspaceStorage struct {
+	client *storage.Client
+	bucket *storage.BucketHandle
+}
+
+func newGCSWorkspaceStorage(ctx context.Context, workspaceStorage *types.WorkspaceStorage) (*gcsWorkspaceStorage, error) {
+	if workspaceStorage.SecretKey == nil || *workspaceStorage.SecretKey == "" {
+		return nil, errors.New("a service account key is required for GCS storage")
+	}
+
+	opts := []option.ClientOption{option.WithCredentialsJSON([]byte(*workspaceStorage.SecretKey))}
+	if workspaceStorage.EndpointUrl != nil && *workspaceStorage.EndpointUrl != "" {
+		opts = append(opts, option.WithEndpoint(*workspaceStorage.EndpointUrl))
+	}
+
+	client, err := storage.NewClient(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCS client: %w", err)
+	}
+
+	return &gcsWorkspaceStorage{
+		client: client,
+		bucket: client.Bucket(*workspaceStorage.BucketName),
+	}, nil
+}
+
+func (b *gcsWorkspaceStorage) UploadWithReader(ctx context.Context, key string, data io.Reader) error {
+	writer := b.bucket.Object(key).NewWriter(ctx)
+	if _, err := io.Copy(writer, data); err != nil {
+		writer.Close()
+		return err
+	}
+
+	return writer.Close()
+}
+
+func (b *gcsWorkspaceStorage) Head(ctx context.Context, key string) (bool, *s3.HeadObjectOutput, error) {
+	attrs, err := b.bucket.Object(key).Attrs(ctx)
+	if err != nil {
+		return false, nil, err
+	}
+
+	return true, &s3.HeadObjectOutput{
+		ContentLength: aws.Int64(attrs.Size),
+		ContentType:   aws.String(attrs.ContentType),
+		ETag:          aws.String(attrs.Etag),
+		LastModified:  aws.Time(attrs.Updated),
+	}, nil
+}
+
+func (b *gcsWorkspaceStorage) Exists(ctx context.Context, key string) (bool, error) {
+	_, err := b.bucket.Object(key).Attrs(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+func (b *gcsWorkspaceStorage) DownloadRangeWithReader(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	// GCS reads to the end of the object for a negative length
+	if length <= 0 {
+		length = -1
+	}
+
+	return b.bucket.Object(key).NewRangeReader(ctx, offset, length)
+}
+
+func (b *gcsWorkspaceStorage) Delete(ctx context.Context, key string) error {
+	err := b.bucket.Object(key).Delete(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+func (b *gcsWorkspaceStorage) ListDirectory(ctx context.Context, dir string) ([]s3types.Object, error) {
+	if !strings.HasSuffix(dir, "/") {
+		dir += "/"
+	}
+
+	var allObjects []s3types.Object
+
+	it := b.bucket.Objects(ctx, &storage.Query{Prefix: dir, Delimiter: "/"})
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// Directories are reported with only a prefix set
+		if attrs.Prefix != "" {
+			allObjects = append(allObjects, s3types.Object{
+				Key:          aws.String(attrs.Prefix),
+				Size:         aws.Int64(0),
+				LastModified: aws.Time(time.Now()),
+			})
+			continue
+		}
+
+		if attrs.Name != dir {
+			allObjects = append(allObjects, gcsObject(attrs))
+		}
+	}
+
+	return allObjects, nil
+}
+
+func (b *gcsWorkspaceStorage) ListWithPrefix(ctx context.Context, prefix string) ([]s3types.Object, error) {
+	var objects []s3types.Object
+
+	it := b.bucket.Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		objects = append(objects, gcsObject(attrs))
+	}
+
+	return objects, nil
+}
+
+func (b *gcsWorkspaceStorage) DeleteWithPrefix(ctx context.Context, prefix string) ([]string, error) {
+	objects, err := b.ListWithPrefix(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var deletedObjects []string
+	for _, obj := range objects {
+		if err := b.Delete(ctx, *obj.Key); err != nil {
+			return nil, err
+		}
+
+		deletedObjects = append(deletedObjects, strings.TrimPrefix(*obj.Key, prefix))
+	}
+
+	return deletedObjects, nil
+}
+
+func (b *gcsWorkspaceStorage) CopyObject(ctx context.Context, sourceKey, destinationKey string) error {
+	_, err := b.bucket.Object(destinationKey).CopierFrom(b.bucket.Object(sourceKey)).Run(ctx)
+	return err
+}
+
+func (b *gcsWorkspaceStorage) GeneratePresignedPutURL(ctx context.Context, key string, expiresInSeconds int64) (string, error) {
+	return b.signedURL(key, http.MethodPut, expiresInSeconds, nil)
+}
+
+func (b *gcsWorkspaceStorage) GeneratePresignedGetURL(ctx context.Context, key string, expiresInSeconds int64) (string, error) {
+	return b.signedURL(key, http.MethodGet, expiresInSeconds, url.Values{"response-content-disposition": {"attachment"}})
+}
+
+// signedURL signs with the service account from the client's credentials
+func (b *gcsWorkspaceStorage) signedURL(key, method string, expiresInSeconds int64, params url.Values) (string, error) {
+	signedURL, err := b.bucket.SignedURL(key, &storage.SignedURLOptions{
+		Scheme:          storage.SigningSchemeV4,
+		Method:          method,
+		Expires:         time.Now().Add(time.Duration(expiresInSeconds) * time.Second),
+		QueryParameters: params,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to generate signed URL: %w", err)
+	}
+
+	return signedURL, nil
+}
+
+func (b *gcsWorkspaceStorage) PresignedPutHeaders() map[string]string {
+	return nil
+}
+
+func (b *gcsWorkspaceStorage) ValidateBucketAccess(ctx context.Context) error {
+	if _, err := b.bucket.Attrs(ctx); err != nil {
+		return fmt.Errorf("failed to access bucket: %w", err)
+	}
+
+	if err := b.UploadWithReader(ctx, testObjectKey, strings.NewReader("")); err != nil {
+		return fmt.Errorf("failed to write to bucket: %w", err)
+	}
+
+	if err := b.Delete(ctx, testObjectKey); err != nil {
+		return fmt.Errorf("failed to delete test object: %w", err)
+	}
+
+	return nil
+}
+
+func gcsObject(attrs *storage.ObjectAttrs) s3types.Object {
+	return s3types.Object{
+		Key:          aws.String(attrs.Name),
+		Size:         aws.Int64(attrs.Size),
+		ETag:         aws.String(attrs.Etag),
+		LastModified: aws.Time(attrs.Updated),
+	}
+}
@@ -0,0 +1,204 @@
+package common
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"strings"
+)
+
+var (
+	ErrUnsupportedArchive = errors.New("object is not a zip or tar archive")
+	ErrUnsafeArchivePath  = errors.New("archive entry escapes the destination")
+	ErrArchiveTooLarge    = errors.New("archive exceeds the extraction limits")
+
+	zipMagic      = []byte("PK\x03\x04")
+	emptyZipMagic = []byte("PK\x05\x06")
+	tarMagic      = []byte("ustar")
+)
+
+const (
+	tarMagicOffset  = 257
+	archiveSniffLen = tarMagicOffset + 5
+)
+
+// ArchiveLimits bounds what ExtractArchive will write. Sizes are counted from the bytes actually
+// extracted rather than trusted from entry headers.
+type ArchiveLimits struct {
+	MaxFiles int
+	MaxBytes int64
+}
+
+// ArchiveEntryWriter stores one regular file from an archive under its sanitized, slash separated name
+type ArchiveEntryWriter func(name string, mode os.FileMode, r io.Reader) error
+
+// ArchiveSummary describes what ExtractArchive wrote
+type ArchiveSummary struct {
+	Files int
+	Bytes int64
+}
+
+// SanitizeArchivePath cleans an archive entry name into a relative path, rejecting names that are
+// absolute or climb out of the directory they're extracted into
+func SanitizeArchivePath(name string) (string, error) {
+	name = strings.ReplaceAll(name, "\\", "/")
+	if name == "" || strings.ContainsRune(name, 0) || path.IsAbs(name) {
+		return "", ErrUnsafeArchivePath
+	}
+
+	cleaned := path.Clean(name)
+	if cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", ErrUnsafeArchivePath
+	}
+
+	return cleaned, nil
+}
+
+// ExtractArchive writes the regular files of a zip or tar archive (optionally gzip or zstd compressed)
+// through write. Directories are implied by file names, and links and other special entries are skipped
+// so nothing written can point outside the destination.
+func ExtractArchive(f *os.File, limits ArchiveLimits, write ArchiveEntryWriter) (ArchiveSummary, error) {
+	fileInfo, err := f.Stat()
+	if err != nil {
+		return ArchiveSummary{}, err
+	}
+
+	header := make([]byte, archiveSniffLen)
+	n, err := f.ReadAt(header, 0)
+	if err != nil && err != io.EOF {
+		return ArchiveSummary{}, err
+	}
+	header = header[:n]
+
+	extractor := &archiveExtractor{limits: limits, write: write}
+
+	if bytes.HasPrefix(header, zipMagic) || bytes.HasPrefix(header, emptyZipMagic) {
+		err := extractor.extractZip(f, fileInfo.Size())
+		return extractor.summary, err
+	}
+
+	decompressed, err := NewDecompressionReader(DetectCompression(header), io.NewSectionReader(f, 0, fileInfo.Size()))
+	if err != nil {
+		return ArchiveSummary{}, err
+	}
+	defer decompressed.Close()
+
+	// Compressed tars are only recognisable once decompressed
+	buffered := bufio.NewReader(decompressed)
+	sniffed, err := buffered.Peek(archiveSniffLen)
+	if err != nil && err != io.EOF {
+		return ArchiveSummary{}, err
+	}
+
+	if len(sniffed) < archiveSniffLen || !bytes.Equal(sniffed[tarMagicOffset:], tarMagic) {
+		return ArchiveSummary{}, ErrUnsupportedArchive
+	}
+
+	err = extractor.extractTar(buffered)
+	return extractor.summary, err
+}
+
+type archiveExtractor struct {
+	limits  ArchiveLimits
+	write   ArchiveEntryWriter
+	summary ArchiveSummary
+}
+
+func (e *archiveExtractor) extractZip(r io.ReaderAt, size int64) error {
+	reader, err := zip.NewReader(r, size)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range reader.File {
+		if !file.Mode().IsRegular() {
+			continue
+		}
+
+		err := func() error {
+			content, err := file.Open()
+			if err != nil {
+				return err
+			}
+			defer content.Close()
+
+			return e.extractEntry(file.Name, file.Mode(), content)
+		}()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *archiveExtractor) extractTar(r io.Reader) error {
+	reader := tar.NewReader(r)
+
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		if err := e.extractEntry(header.Name, header.FileInfo().Mode(), reader); err != nil {
+			return err
+		}
+	}
+}
+
+func (e *archiveExtractor) extractEntry(name string, mode os.FileMode, r io.Reader) error {
+	name, err := SanitizeArchivePath(name)
+	if err != nil {
+		return err
+	}
+
+	if e.limits.MaxFiles > 0 && e.summary.Files >= e.limits.MaxFiles {
+		return ErrArchiveTooLarge
+	}
+
+	counter := &archiveByteCounter{r: r, summary: &e.summary, maxBytes: e.limits.MaxBytes}
+	err = e.write(name, mode.Perm(), counter)
+
+	// Writers may wrap the limit error, or stop reading at it without reporting it
+	if e.limits.MaxBytes > 0 && e.summary.Bytes > e.limits.MaxBytes {
+		return ErrArchiveTooLarge
+	}
+
+	if err != nil {
+		return err
+	}
+
+	e.summary.Files++
+	return nil
+}
+
+// archiveByteCounter fails reads once more than maxBytes have been extracted in total
+type archiveByteCounter struct {
+	r        io.Reader
+	summary  *ArchiveSummary
+	maxBytes int64
+}
+
+func (c *archiveByteCounter) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.summary.Bytes += int64(n)
+
+	if c.maxBytes > 0 && c.summary.Bytes > c.maxBytes {
+		return n, ErrArchiveTooLarge
+	}
+
+	return n, err
+}
@@ -0,0 +1,124 @@
+package common
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestArchive(t *testing.T, content []byte) *os.File {
+	f, err := os.Create(filepath.Join(t.TempDir(), "archive"))
+	require.NoError(t, err)
+	t.Cleanup(func() { f.Close() })
+
+	_, err = f.Write(content)
+	require.NoError(t, err)
+	return f
+}
+
+func newTestZip(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		fw, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = fw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func newTestTar(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	w := tar.NewWriter(&buf)
+	for name, content := range files {
+		require.NoError(t, w.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.WriteHeader(&tar.Header{Name: "link", Linkname: "/etc/passwd", Typeflag: tar.TypeSymlink}))
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func collectArchive(t *testing.T, content []byte, limits ArchiveLimits) (map[string]string, ArchiveSummary, error) {
+	extracted := map[string]string{}
+	summary, err := ExtractArchive(writeTestArchive(t, content), limits, func(name string, mode os.FileMode, r io.Reader) error {
+		data, err := io.ReadAll(r)
+		extracted[name] = string(data)
+		return err
+	})
+	return extracted, summary, err
+}
+
+func TestExtractArchive(t *testing.T) {
+	files := map[string]string{"a.txt": "hello", "dir/b.txt": "world"}
+
+	var gzipped bytes.Buffer
+	gw := gzip.NewWriter(&gzipped)
+	_, err := gw.Write(newTestTar(t, files))
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+
+	for name, content := range map[string][]byte{
+		"zip":    newTestZip(t, files),
+		"tar":    newTestTar(t, files),
+		"tar.gz": gzipped.Bytes(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			extracted, summary, err := collectArchive(t, content, ArchiveLimits{})
+			require.NoError(t, err)
+			assert.Equal(t, files, extracted)
+			assert.Equal(t, ArchiveSummary{Files: 2, Bytes: 10}, summary)
+		})
+	}
+}
+
+func TestExtractArchiveRejectsTraversal(t *testing.T) {
+	_, _, err := collectArchive(t, newTestZip(t, map[string]string{"../escape.txt": "x"}), ArchiveLimits{})
+	assert.ErrorIs(t, err, ErrUnsafeArchivePath)
+
+	_, _, err = collectArchive(t, newTestTar(t, map[string]string{"/etc/cron.d/job": "x"}), ArchiveLimits{})
+	assert.ErrorIs(t, err, ErrUnsafeArchivePath)
+}
+
+func TestExtractArchiveLimits(t *testing.T) {
+	files := map[string]string{"a.txt": "hello", "b.txt": "world"}
+
+	_, _, err := collectArchive(t, newTestZip(t, files), ArchiveLimits{MaxFiles: 1})
+	assert.ErrorIs(t, err, ErrArchiveTooLarge)
+
+	_, _, err = collectArchive(t, newTestTar(t, files), ArchiveLimits{MaxBytes: 8})
+	assert.ErrorIs(t, err, ErrArchiveTooLarge)
+}
+
+func TestExtractArchiveUnsupported(t *testing.T) {
+	_, _, err := collectArchive(t, []byte("just some text"), ArchiveLimits{})
+	assert.ErrorIs(t, err, ErrUnsupportedArchive)
+}
+
+func TestSanitizeArchivePath(t *testing.T) {
+	for name, expected := range map[string]string{
+		"a/b/../c.txt": "a/c.txt",
+		"./a.txt":      "a.txt",
+		"dir\\b.txt":   "dir/b.txt",
+	} {
+		cleaned, err := SanitizeArchivePath(name)
+		require.NoError(t, err)
+		assert.Equal(t, expected, cleaned)
+	}
+
+	for _, name := range []string{"", ".", "..", "../a", "a/../../b", "/abs", "\\abs"} {
+		_, err := SanitizeArchivePath(name)
+		assert.ErrorIs(t, err, ErrUnsafeArchivePath, name)
+	}
+}
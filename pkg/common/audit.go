@@ -0,0 +1,91 @@
+package common
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/beam-cloud/beta9/pkg/types"
+	"github.com/rs/zerolog"
+)
+
+type AuditOutcome string
+
+const (
+	AuditOutcomeSuccess AuditOutcome = "success"
+	AuditOutcomeFailure AuditOutcome = "failure"
+)
+
+type AuditEvent struct {
+	Action       string
+	Principal    string
+	WorkspaceId  string
+	ResourceType string
+	ResourceId   string
+	Outcome      AuditOutcome
+	Reason       string
+	Attributes   map[string]interface{}
+}
+
+// AuditLogger writes audit events to a dedicated sink, separate from the service logs,
+// so they can be retained and queried independently
+type AuditLogger struct {
+	enabled bool
+	logger  zerolog.Logger
+}
+
+func NewAuditLogger(config types.AuditConfig) (*AuditLogger, error) {
+	if !config.Enabled {
+		return &AuditLogger{enabled: false, logger: zerolog.Nop()}, nil
+	}
+
+	var writer io.Writer
+	switch config.Sink {
+	case types.AuditSinkStdout, "":
+		writer = os.Stdout
+	case types.AuditSinkFile:
+		if err := os.MkdirAll(filepath.Dir(config.Path), 0755); err != nil {
+			return nil, err
+		}
+
+		f, err := os.OpenFile(config.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0640)
+		if err != nil {
+			return nil, err
+		}
+		writer = f
+	default:
+		return nil, fmt.Errorf("unsupported audit sink: %s", config.Sink)
+	}
+
+	return &AuditLogger{
+		enabled: true,
+		logger:  zerolog.New(writer).With().Str("log_type", "audit").Logger(),
+	}, nil
+}
+
+func (a *AuditLogger) Log(event AuditEvent) {
+	if a == nil || !a.enabled {
+		return
+	}
+
+	e := a.logger.Log().
+		Time("timestamp", time.Now().UTC()).
+		Str("action", event.Action).
+		Str("principal", event.Principal).
+		Str("workspace_id", event.WorkspaceId).
+		Str("resource_type", event.ResourceType).
+		Str("resource_id", event.ResourceId).
+		Str("outcome", string(event.Outcome))
+
+	if event.Reason != "" {
+		e = e.Str("reason", event.Reason)
+	}
+
+	if len(event.Attributes) > 0 {
+		e = e.Fields(event.Attributes)
+	}
+
+	e.Send()
+}
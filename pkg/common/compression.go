@@ -0,0 +1,67 @@
+package common
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/beam-cloud/beta9/pkg/types"
+	"github.com/klauspost/compress/zstd"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+// NewCompressionWriter wraps w so bytes written to it are compressed with the given codec.
+// Close must be called to flush any buffered data; it does not close w.
+func NewCompressionWriter(codec types.ObjectCompression, w io.Writer) (io.WriteCloser, error) {
+	switch codec {
+	case types.ObjectCompressionNone:
+		return nopWriteCloser{w}, nil
+	case types.ObjectCompressionGzip:
+		return gzip.NewWriter(w), nil
+	case types.ObjectCompressionZstd:
+		return zstd.NewWriter(w)
+	}
+
+	return nil, fmt.Errorf("unsupported compression codec: %s", codec)
+}
+
+// NewDecompressionReader wraps r so bytes read from it are decompressed with the given codec
+func NewDecompressionReader(codec types.ObjectCompression, r io.Reader) (io.ReadCloser, error) {
+	switch codec {
+	case types.ObjectCompressionNone:
+		return io.NopCloser(r), nil
+	case types.ObjectCompressionGzip:
+		return gzip.NewReader(r)
+	case types.ObjectCompressionZstd:
+		decoder, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return decoder.IOReadCloser(), nil
+	}
+
+	return nil, fmt.Errorf("unsupported compression codec: %s", codec)
+}
+
+// DetectCompression infers the codec of a stored object from its leading bytes
+func DetectCompression(header []byte) types.ObjectCompression {
+	switch {
+	case bytes.HasPrefix(header, zstdMagic):
+		return types.ObjectCompressionZstd
+	case bytes.HasPrefix(header, gzipMagic):
+		return types.ObjectCompressionGzip
+	}
+
+	return types.ObjectCompressionNone
+}
@@ -0,0 +1,50 @@
+package common
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/beam-cloud/beta9/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressionRoundTrip(t *testing.T) {
+	payload := bytes.Repeat([]byte("beta9 object payload "), 1024)
+
+	for _, codec := range []types.ObjectCompression{
+		types.ObjectCompressionNone,
+		types.ObjectCompressionGzip,
+		types.ObjectCompressionZstd,
+	} {
+		t.Run(string(codec), func(t *testing.T) {
+			var buf bytes.Buffer
+
+			w, err := NewCompressionWriter(codec, &buf)
+			require.NoError(t, err)
+
+			_, err = w.Write(payload)
+			require.NoError(t, err)
+			require.NoError(t, w.Close())
+
+			assert.Equal(t, codec, DetectCompression(buf.Bytes()))
+
+			r, err := NewDecompressionReader(codec, &buf)
+			require.NoError(t, err)
+			defer r.Close()
+
+			out, err := io.ReadAll(r)
+			require.NoError(t, err)
+			assert.Equal(t, payload, out)
+		})
+	}
+}
+
+func TestCompressionUnsupportedCodec(t *testing.T) {
+	_, err := NewCompressionWriter("lz4", io.Discard)
+	assert.Error(t, err)
+
+	_, err = NewDecompressionReader("lz4", bytes.NewReader(nil))
+	assert.Error(t, err)
+}
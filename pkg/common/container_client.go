@@ -8,6 +8,7 @@ import (
 	"io"
 	"net"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	pb "github.com/beam-cloud/beta9/proto"
@@ -39,11 +40,21 @@ func NewContainerClient(serviceUrl, serviceToken string, existingConn net.Conn)
 	return client, nil
 }
 
+// Set when internal TLS is enabled, so connections to workers' container servers use mutual TLS
+var containerClientTLS atomic.Pointer[tls.Config]
+
+// SetContainerClientTLS makes container clients connect with the given TLS config
+func SetContainerClientTLS(config *tls.Config) {
+	containerClientTLS.Store(config)
+}
+
 func (c *ContainerClient) connect() error {
 	grpcOption := grpc.WithTransportCredentials(insecure.NewCredentials())
 
 	isTLS := strings.HasSuffix(c.ServiceUrl, "443")
-	if isTLS {
+	if internalTLS := containerClientTLS.Load(); internalTLS != nil {
+		grpcOption = grpc.WithTransportCredentials(credentials.NewTLS(internalTLS))
+	} else if isTLS {
 		h2creds := credentials.NewTLS(&tls.Config{NextProtos: []string{"h2"}})
 		grpcOption = grpc.WithTransportCredentials(h2creds)
 	}
@@ -303,6 +314,33 @@ func (c *ContainerClient) StreamLogs(ctx context.Context, containerId string, ou
 	}
 }
 
+// StreamLogEntries sends each log entry a container's worker streams back for the request to the callback
+func (c *ContainerClient) StreamLogEntries(ctx context.Context, request *pb.ContainerStreamLogsRequest, callback func(*pb.LogEntry) error) error {
+	stream, err := c.client.ContainerStreamLogs(ctx, request)
+	if err != nil {
+		return fmt.Errorf("error creating log stream: %w", err)
+	}
+
+	for {
+		logEntry, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("error receiving from log stream: %w", err)
+		}
+
+		if logEntry.Entry == nil {
+			continue
+		}
+
+		if err := callback(logEntry.Entry); err != nil {
+			return err
+		}
+	}
+}
+
 func generateProgressBar(progress int, total int) string {
 	barWidth := 50
 	progressWidth := (progress * barWidth) / total
@@ -332,6 +370,14 @@ func (c *ContainerClient) Checkpoint(ctx context.Context, containerId string) (*
 	return resp, nil
 }
 
+func (c *ContainerClient) WorkerHealth(ctx context.Context) (*pb.WorkerHealthResponse, error) {
+	resp, err := c.client.WorkerHealth(ctx, &pb.WorkerHealthRequest{})
+	if err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
 func (c *ContainerClient) Archive(ctx context.Context, containerId, imageId string, outputChan chan OutputMsg) error {
 	outputChan <- OutputMsg{Archiving: true, Done: false, Success: false, Msg: "\nSaving image, this may take a few minutes...\n"}
 	stream, err := c.client.ContainerArchive(ctx, &pb.ContainerArchiveRequest{ContainerId: containerId,
@@ -377,6 +423,17 @@ func (c *ContainerClient) Archive(ctx context.Context, containerId, imageId stri
 	}
 }
 
+// ExecStream opens a stream that runs a command in the container. The first message sent must start the command.
+func (c *ContainerClient) ExecStream(ctx context.Context) (pb.ContainerService_ContainerExecStreamClient, error) {
+	return c.client.ContainerExecStream(ctx)
+}
+
+// PortForward opens a stream that carries one TCP connection to a port inside the container. The first message sent
+// must start the connection.
+func (c *ContainerClient) PortForward(ctx context.Context) (pb.ContainerService_ContainerPortForwardClient, error) {
+	return c.client.ContainerPortForward(ctx)
+}
+
 func (c *ContainerClient) SyncWorkspace(ctx context.Context, request *pb.SyncContainerWorkspaceRequest) (*pb.SyncContainerWorkspaceResponse, error) {
 	resp, err := c.client.ContainerSyncWorkspace(ctx, request)
 	if err != nil {
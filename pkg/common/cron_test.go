@@ -0,0 +1,52 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCronSchedule(t *testing.T) {
+	for _, expr := range []string{"* * * * *", "*/15 9-17 * * 1-5", "0,30 8 1 1-12/2 0", "5/10 * * * 7"} {
+		_, err := ParseCronSchedule(expr)
+		assert.NoError(t, err, expr)
+	}
+
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+		_, err := ParseCronSchedule(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestCronScheduleMatches(t *testing.T) {
+	// 2024-01-01 was a Monday
+	monday := func(hour, minute int) time.Time {
+		return time.Date(2024, time.January, 1, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		expr string
+		at   time.Time
+		want bool
+	}{
+		{"* 9-17 * * 1-5", monday(9, 0), true},
+		{"* 9-17 * * 1-5", monday(17, 59), true},
+		{"* 9-17 * * 1-5", monday(18, 0), false},
+		{"* 9-17 * * 1-5", monday(8, 59), false},
+		{"* 9-17 * * 1-5", monday(12, 0).AddDate(0, 0, 5), false},
+		{"*/15 * * * *", monday(10, 45), true},
+		{"*/15 * * * *", monday(10, 46), false},
+		{"* * * * 7", monday(10, 0).AddDate(0, 0, 6), true},
+		// With both day fields restricted, either one matching is enough
+		{"* * 15 * 1", monday(10, 0), true},
+		{"* * 1 * 5", monday(10, 0), true},
+		{"* * 2 * 5", monday(10, 0), false},
+	}
+
+	for _, tt := range tests {
+		schedule, err := ParseCronSchedule(tt.expr)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, schedule.Matches(tt.at), "%s at %s", tt.expr, tt.at)
+	}
+}
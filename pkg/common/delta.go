@@ -0,0 +1,177 @@
+package common
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"io"
+)
+
+const (
+	MinDeltaBlockSize     = 1024
+	MaxDeltaBlockSize     = 1024 * 1024
+	DefaultDeltaBlockSize = 8 * 1024
+
+	// Larger objects get larger blocks so their signatures stay a manageable size
+	maxDeltaBlockCount = 64 * 1024
+)
+
+var ErrDeltaBlockOutOfRange = errors.New("delta block is out of range")
+
+// BlockSignature identifies one fixed size block of an object. Weak is a rolling checksum that is cheap to
+// slide across new content one byte at a time; Strong confirms a weak match.
+type BlockSignature struct {
+	Weak   uint32
+	Strong []byte
+}
+
+// DeltaOp is one step in rebuilding new content from a base object: either a copy of one of the base's
+// blocks or literal bytes that weren't found in the base
+type DeltaOp struct {
+	CopyBlock  bool
+	BlockIndex uint32
+	Data       []byte
+}
+
+// DeltaBlockSize returns the block size used for signatures of an object of the given size. A requested
+// size is clamped to the supported range; otherwise the default grows with the object.
+func DeltaBlockSize(size int64, requested int) int {
+	blockSize := requested
+	if blockSize <= 0 {
+		blockSize = DefaultDeltaBlockSize
+		for int64(blockSize)*maxDeltaBlockCount < size && blockSize < MaxDeltaBlockSize {
+			blockSize *= 2
+		}
+	}
+
+	return min(max(blockSize, MinDeltaBlockSize), MaxDeltaBlockSize)
+}
+
+// rollingChecksum is the rsync weak checksum: two 16 bit sums that can be updated in constant time as
+// the window slides forward by one byte
+type rollingChecksum struct {
+	a, b   uint32
+	length uint32
+}
+
+func newRollingChecksum(block []byte) *rollingChecksum {
+	c := &rollingChecksum{length: uint32(len(block))}
+	for i, x := range block {
+		c.a += uint32(x)
+		c.b += uint32(len(block)-i) * uint32(x)
+	}
+	return c
+}
+
+func (c *rollingChecksum) sum() uint32 {
+	return (c.a & 0xffff) | (c.b&0xffff)<<16
+}
+
+// roll removes out from the front of the window and appends in to the back
+func (c *rollingChecksum) roll(out, in byte) {
+	c.a = c.a - uint32(out) + uint32(in)
+	c.b = c.b - c.length*uint32(out) + c.a
+}
+
+func strongChecksum(block []byte) []byte {
+	sum := sha256.Sum256(block)
+	return sum[:]
+}
+
+// ComputeBlockSignatures reads r to the end and returns the signature of each blockSize block. The last
+// block may be shorter.
+func ComputeBlockSignatures(r io.Reader, blockSize int) ([]BlockSignature, error) {
+	var signatures []BlockSignature
+	block := make([]byte, blockSize)
+
+	for {
+		n, err := io.ReadFull(r, block)
+		if n > 0 {
+			signatures = append(signatures, BlockSignature{
+				Weak:   newRollingChecksum(block[:n]).sum(),
+				Strong: strongChecksum(block[:n]),
+			})
+		}
+
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return signatures, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// ComputeDelta returns the ops that rebuild data from a base object with the given block signatures. Only
+// full size blocks are matched, since a short final block can't be found at an arbitrary offset.
+func ComputeDelta(signatures []BlockSignature, blockSize int, data []byte) []DeltaOp {
+	blocks := make(map[uint32][]uint32)
+	for i, signature := range signatures {
+		blocks[signature.Weak] = append(blocks[signature.Weak], uint32(i))
+	}
+
+	var ops []DeltaOp
+	literalStart := 0
+
+	findBlock := func(window []byte, weak uint32) (uint32, bool) {
+		candidates, ok := blocks[weak]
+		if !ok {
+			return 0, false
+		}
+
+		strong := strongChecksum(window)
+		for _, index := range candidates {
+			if bytes.Equal(signatures[index].Strong, strong) {
+				return index, true
+			}
+		}
+		return 0, false
+	}
+
+	offset := 0
+	var checksum *rollingChecksum
+	for offset+blockSize <= len(data) {
+		if checksum == nil {
+			checksum = newRollingChecksum(data[offset : offset+blockSize])
+		}
+
+		if index, ok := findBlock(data[offset:offset+blockSize], checksum.sum()); ok {
+			if literalStart < offset {
+				ops = append(ops, DeltaOp{Data: data[literalStart:offset]})
+			}
+
+			ops = append(ops, DeltaOp{CopyBlock: true, BlockIndex: index})
+			offset += blockSize
+			literalStart = offset
+			checksum = nil
+			continue
+		}
+
+		if offset+blockSize < len(data) {
+			checksum.roll(data[offset], data[offset+blockSize])
+		}
+		offset++
+	}
+
+	if literalStart < len(data) {
+		ops = append(ops, DeltaOp{Data: data[literalStart:]})
+	}
+
+	return ops
+}
+
+// ReadDeltaBlock returns the index'th blockSize block of a base object of the given size
+func ReadDeltaBlock(base io.ReaderAt, baseSize int64, blockSize int, index uint32) ([]byte, error) {
+	offset := int64(index) * int64(blockSize)
+	if offset >= baseSize {
+		return nil, ErrDeltaBlockOutOfRange
+	}
+
+	block := make([]byte, min(int64(blockSize), baseSize-offset))
+	if _, err := base.ReadAt(block, offset); err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	return block, nil
+}
@@ -0,0 +1,78 @@
+package common
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func applyDelta(t *testing.T, base []byte, blockSize int, ops []DeltaOp) []byte {
+	var out bytes.Buffer
+	for _, op := range ops {
+		if !op.CopyBlock {
+			out.Write(op.Data)
+			continue
+		}
+
+		block, err := ReadDeltaBlock(bytes.NewReader(base), int64(len(base)), blockSize, op.BlockIndex)
+		require.NoError(t, err)
+		out.Write(block)
+	}
+	return out.Bytes()
+}
+
+func TestComputeDelta(t *testing.T) {
+	blockSize := MinDeltaBlockSize
+	rng := rand.New(rand.NewSource(1))
+
+	base := make([]byte, blockSize*20+123)
+	rng.Read(base)
+
+	inserted := make([]byte, 77)
+	rng.Read(inserted)
+
+	// An insertion shifts everything after it, which only the rolling checksum can realign with
+	modified := append(append(append([]byte{}, base[:blockSize*5+10]...), inserted...), base[blockSize*5+10:]...)
+	modified[blockSize*15] ^= 0xff
+
+	signatures, err := ComputeBlockSignatures(bytes.NewReader(base), blockSize)
+	require.NoError(t, err)
+	assert.Len(t, signatures, 21)
+
+	ops := ComputeDelta(signatures, blockSize, modified)
+	assert.Equal(t, modified, applyDelta(t, base, blockSize, ops))
+
+	literalBytes := 0
+	for _, op := range ops {
+		literalBytes += len(op.Data)
+	}
+	assert.Less(t, literalBytes, blockSize*4)
+}
+
+func TestComputeDeltaWithoutBase(t *testing.T) {
+	data := bytes.Repeat([]byte("beta9"), 1000)
+
+	ops := ComputeDelta(nil, MinDeltaBlockSize, data)
+	assert.Equal(t, []DeltaOp{{Data: data}}, ops)
+}
+
+func TestReadDeltaBlockOutOfRange(t *testing.T) {
+	base := make([]byte, MinDeltaBlockSize+1)
+
+	block, err := ReadDeltaBlock(bytes.NewReader(base), int64(len(base)), MinDeltaBlockSize, 1)
+	require.NoError(t, err)
+	assert.Len(t, block, 1)
+
+	_, err = ReadDeltaBlock(bytes.NewReader(base), int64(len(base)), MinDeltaBlockSize, 2)
+	assert.ErrorIs(t, err, ErrDeltaBlockOutOfRange)
+}
+
+func TestDeltaBlockSize(t *testing.T) {
+	assert.Equal(t, DefaultDeltaBlockSize, DeltaBlockSize(1024, 0))
+	assert.Equal(t, MinDeltaBlockSize, DeltaBlockSize(1024, 10))
+	assert.Equal(t, MaxDeltaBlockSize, DeltaBlockSize(1<<40, 0))
+	assert.Equal(t, 16*1024, DeltaBlockSize(int64(DefaultDeltaBlockSize)*maxDeltaBlockCount+1, 0))
+}
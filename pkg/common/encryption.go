@@ -0,0 +1,367 @@
+package common
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+const (
+	objectDataKeySize         = 32
+	objectEncryptionChunkSize = 64 * 1024
+	objectEncryptionNonceSize = 12
+	secretKeyPrefix           = "sk_"
+)
+
+var (
+	objectEncryptionMagic = []byte("b9e1")
+
+	ErrObjectEncryptionTruncated = errors.New("encrypted object is truncated")
+	ErrObjectEncryptionInvalid   = errors.New("encrypted object is invalid")
+)
+
+// ObjectKeyWrapper protects per-workspace object data keys at rest. The gateway master key is the only
+// implementation today; an external KMS can be supported by implementing this interface.
+type ObjectKeyWrapper interface {
+	WrapKey(dataKey []byte) (string, error)
+	UnwrapKey(wrappedKey string) ([]byte, error)
+}
+
+// NewObjectKeyWrapper returns the key wrapper configured for object encryption
+func NewObjectKeyWrapper(config types.ObjectEncryptionConfig) (ObjectKeyWrapper, error) {
+	if !strings.HasPrefix(config.MasterKey, secretKeyPrefix) {
+		return nil, errors.New("object encryption master key must start with " + secretKeyPrefix)
+	}
+
+	masterKey, err := ParseSecretKey(config.MasterKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(masterKey) != objectDataKeySize {
+		return nil, fmt.Errorf("object encryption master key must be %d bytes", objectDataKeySize)
+	}
+
+	return &masterKeyWrapper{masterKey: masterKey}, nil
+}
+
+type masterKeyWrapper struct {
+	masterKey []byte
+}
+
+func (w *masterKeyWrapper) WrapKey(dataKey []byte) (string, error) {
+	return Encrypt(w.masterKey, base64.StdEncoding.EncodeToString(dataKey))
+}
+
+func (w *masterKeyWrapper) UnwrapKey(wrappedKey string) ([]byte, error) {
+	encoded, err := Decrypt(w.masterKey, wrappedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return base64.StdEncoding.DecodeString(encoded)
+}
+
+// ObjectDataKey is a key used to encrypt object content along with its wrapped form, which is stored
+// alongside the content so it can be decrypted by anything holding the master key
+type ObjectDataKey struct {
+	Plaintext []byte
+	Wrapped   string
+}
+
+// GenerateObjectDataKey returns a new random key for encrypting object content
+func GenerateObjectDataKey() ([]byte, error) {
+	key := make([]byte, objectDataKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// UnwrapObjectDataKey returns the key an object's stored content was encrypted with, or nil if the
+// object is stored in plaintext
+func UnwrapObjectDataKey(config types.ObjectEncryptionConfig, encryption types.ObjectEncryption, wrappedKey string) ([]byte, error) {
+	switch encryption {
+	case types.ObjectEncryptionNone:
+		return nil, nil
+	case types.ObjectEncryptionAES256GCM:
+	default:
+		return nil, fmt.Errorf("unsupported object encryption: %s", encryption)
+	}
+
+	wrapper, err := NewObjectKeyWrapper(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapper.UnwrapKey(wrappedKey)
+}
+
+func newObjectAEAD(dataKey []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// Encrypted objects are a header (magic, the wrapped data key and a random base nonce) followed by records of at most
+// objectEncryptionChunkSize plaintext bytes. Each record is a 4 byte ciphertext length, a flag marking
+// the final record and the sealed chunk. The record index is mixed into the nonce and the flag is
+// authenticated, so reordered, dropped or truncated records fail to decrypt.
+const (
+	recordFlagMore  byte = 0
+	recordFlagFinal byte = 1
+)
+
+func recordNonce(base []byte, index uint64) []byte {
+	nonce := make([]byte, len(base))
+	copy(nonce, base)
+
+	offset := len(nonce) - 8
+	binary.BigEndian.PutUint64(nonce[offset:], binary.BigEndian.Uint64(nonce[offset:])^index)
+	return nonce
+}
+
+type encryptionWriter struct {
+	w      io.Writer
+	aead   cipher.AEAD
+	nonce  []byte
+	index  uint64
+	buf    []byte
+	closed bool
+}
+
+// NewEncryptionWriter wraps w so bytes written to it are encrypted with dataKey.
+// Close must be called to write the final record; it does not close w.
+func NewEncryptionWriter(dataKey *ObjectDataKey, w io.Writer) (io.WriteCloser, error) {
+	aead, err := newObjectAEAD(dataKey.Plaintext)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(dataKey.Wrapped) > math.MaxUint16 {
+		return nil, errors.New("wrapped object data key is too long")
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	header := append([]byte{}, objectEncryptionMagic...)
+	header = binary.BigEndian.AppendUint16(header, uint16(len(dataKey.Wrapped)))
+	header = append(header, dataKey.Wrapped...)
+	header = append(header, nonce...)
+
+	if _, err := w.Write(header); err != nil {
+		return nil, err
+	}
+
+	return &encryptionWriter{w: w, aead: aead, nonce: nonce}, nil
+}
+
+// IsEncryptedObject reports whether header is the start of an object written by NewEncryptionWriter
+func IsEncryptedObject(header []byte) bool {
+	return bytes.HasPrefix(header, objectEncryptionMagic)
+}
+
+// readEncryptionHeader consumes the header of an encrypted object, returning the wrapped data key and base nonce
+func readEncryptionHeader(r io.Reader, nonceSize int) (string, []byte, error) {
+	prefix := make([]byte, len(objectEncryptionMagic)+2)
+	if _, err := io.ReadFull(r, prefix); err != nil {
+		return "", nil, ErrObjectEncryptionTruncated
+	}
+
+	if !IsEncryptedObject(prefix) {
+		return "", nil, ErrObjectEncryptionInvalid
+	}
+
+	rest := make([]byte, int(binary.BigEndian.Uint16(prefix[len(objectEncryptionMagic):]))+nonceSize)
+	if _, err := io.ReadFull(r, rest); err != nil {
+		return "", nil, ErrObjectEncryptionTruncated
+	}
+
+	wrappedKeySize := len(rest) - nonceSize
+	return string(rest[:wrappedKeySize]), rest[wrappedKeySize:], nil
+}
+
+// ReadObjectWrappedKey returns the wrapped data key embedded in an encrypted object's header
+func ReadObjectWrappedKey(r io.Reader) (string, error) {
+	wrappedKey, _, err := readEncryptionHeader(r, objectEncryptionNonceSize)
+	return wrappedKey, err
+}
+
+func (e *encryptionWriter) Write(p []byte) (int, error) {
+	if e.closed {
+		return 0, errors.New("write to closed encryption writer")
+	}
+
+	e.buf = append(e.buf, p...)
+
+	// A full chunk is only sealed once more data follows it, so the last record can be marked final on Close
+	for len(e.buf) > objectEncryptionChunkSize {
+		if err := e.seal(e.buf[:objectEncryptionChunkSize], recordFlagMore); err != nil {
+			return 0, err
+		}
+		e.buf = e.buf[objectEncryptionChunkSize:]
+	}
+
+	return len(p), nil
+}
+
+func (e *encryptionWriter) Close() error {
+	if e.closed {
+		return nil
+	}
+	e.closed = true
+
+	return e.seal(e.buf, recordFlagFinal)
+}
+
+func (e *encryptionWriter) seal(chunk []byte, flag byte) error {
+	sealed := e.aead.Seal(nil, recordNonce(e.nonce, e.index), chunk, []byte{flag})
+	e.index++
+
+	header := make([]byte, 5)
+	binary.BigEndian.PutUint32(header, uint32(len(sealed)))
+	header[4] = flag
+
+	if _, err := e.w.Write(header); err != nil {
+		return err
+	}
+
+	_, err := e.w.Write(sealed)
+	return err
+}
+
+type decryptionReader struct {
+	r     io.Reader
+	aead  cipher.AEAD
+	nonce []byte
+	index uint64
+	plain []byte
+	done  bool
+}
+
+// NewDecryptionReader wraps r so bytes read from it are decrypted with dataKey
+func NewDecryptionReader(dataKey []byte, r io.Reader) (io.Reader, error) {
+	aead, err := newObjectAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	_, nonce, err := readEncryptionHeader(r, aead.NonceSize())
+	if err != nil {
+		return nil, err
+	}
+
+	return &decryptionReader{r: r, aead: aead, nonce: nonce}, nil
+}
+
+func (d *decryptionReader) Read(p []byte) (int, error) {
+	for len(d.plain) == 0 {
+		if d.done {
+			return 0, io.EOF
+		}
+
+		if err := d.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, d.plain)
+	d.plain = d.plain[n:]
+	return n, nil
+}
+
+func (d *decryptionReader) open() error {
+	header := make([]byte, 5)
+	if _, err := io.ReadFull(d.r, header); err != nil {
+		return ErrObjectEncryptionTruncated
+	}
+
+	length := binary.BigEndian.Uint32(header)
+	flag := header[4]
+	if length > uint32(objectEncryptionChunkSize+d.aead.Overhead()) || (flag != recordFlagMore && flag != recordFlagFinal) {
+		return ErrObjectEncryptionInvalid
+	}
+
+	sealed := make([]byte, length)
+	if _, err := io.ReadFull(d.r, sealed); err != nil {
+		return ErrObjectEncryptionTruncated
+	}
+
+	plain, err := d.aead.Open(nil, recordNonce(d.nonce, d.index), sealed, []byte{flag})
+	if err != nil {
+		return ErrObjectEncryptionInvalid
+	}
+
+	d.index++
+	d.plain = plain
+	d.done = flag == recordFlagFinal
+	return nil
+}
+
+type objectWriter struct {
+	io.Writer
+	closers []io.Closer
+}
+
+func (w *objectWriter) Close() error {
+	for _, c := range w.closers {
+		if err := c.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewObjectWriter wraps w so object content written to it is compressed with the given codec and then,
+// if dataKey is set, encrypted. Close flushes every layer; it does not close w.
+func NewObjectWriter(compression types.ObjectCompression, dataKey *ObjectDataKey, w io.Writer) (io.WriteCloser, error) {
+	var closers []io.Closer
+
+	if dataKey != nil {
+		encrypted, err := NewEncryptionWriter(dataKey, w)
+		if err != nil {
+			return nil, err
+		}
+		closers = append(closers, encrypted)
+		w = encrypted
+	}
+
+	compressed, err := NewCompressionWriter(compression, w)
+	if err != nil {
+		return nil, err
+	}
+
+	// The compressor must flush into the encryption layer before the final record is sealed
+	closers = append([]io.Closer{compressed}, closers...)
+	return &objectWriter{Writer: compressed, closers: closers}, nil
+}
+
+// NewObjectReader reverses NewObjectWriter, returning the original content of a stored object
+func NewObjectReader(compression types.ObjectCompression, dataKey []byte, r io.Reader) (io.ReadCloser, error) {
+	if dataKey != nil {
+		decrypted, err := NewDecryptionReader(dataKey, r)
+		if err != nil {
+			return nil, err
+		}
+		r = decrypted
+	}
+
+	return NewDecompressionReader(compression, r)
+}
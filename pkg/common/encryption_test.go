@@ -0,0 +1,154 @@
+package common
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"testing"
+
+	"github.com/beam-cloud/beta9/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestObjectDataKey(t *testing.T) *ObjectDataKey {
+	key, err := GenerateObjectDataKey()
+	require.NoError(t, err)
+
+	return &ObjectDataKey{Plaintext: key, Wrapped: "wrapped-" + base64.StdEncoding.EncodeToString(key[:4])}
+}
+
+func TestObjectEncryptionRoundTrip(t *testing.T) {
+	dataKey := newTestObjectDataKey(t)
+
+	for name, size := range map[string]int{
+		"empty":      0,
+		"small":      100,
+		"exactChunk": objectEncryptionChunkSize,
+		"multiChunk": objectEncryptionChunkSize*3 + 17,
+	} {
+		t.Run(name, func(t *testing.T) {
+			payload := make([]byte, size)
+			_, err := rand.Read(payload)
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			w, err := NewEncryptionWriter(dataKey, &buf)
+			require.NoError(t, err)
+
+			_, err = w.Write(payload)
+			require.NoError(t, err)
+			require.NoError(t, w.Close())
+
+			if size > 0 {
+				assert.False(t, bytes.Contains(buf.Bytes(), payload))
+			}
+
+			wrappedKey, err := ReadObjectWrappedKey(bytes.NewReader(buf.Bytes()))
+			require.NoError(t, err)
+			assert.Equal(t, dataKey.Wrapped, wrappedKey)
+
+			r, err := NewDecryptionReader(dataKey.Plaintext, &buf)
+			require.NoError(t, err)
+
+			out, err := io.ReadAll(r)
+			require.NoError(t, err)
+			assert.Equal(t, payload, out)
+		})
+	}
+}
+
+func TestObjectEncryptionDetectsTampering(t *testing.T) {
+	dataKey := newTestObjectDataKey(t)
+
+	payload := bytes.Repeat([]byte("beta9"), objectEncryptionChunkSize)
+
+	var buf bytes.Buffer
+	w, err := NewEncryptionWriter(dataKey, &buf)
+	require.NoError(t, err)
+	_, err = w.Write(payload)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	encrypted := buf.Bytes()
+
+	t.Run("truncated", func(t *testing.T) {
+		r, err := NewDecryptionReader(dataKey.Plaintext, bytes.NewReader(encrypted[:len(encrypted)/2]))
+		require.NoError(t, err)
+
+		_, err = io.ReadAll(r)
+		assert.ErrorIs(t, err, ErrObjectEncryptionTruncated)
+	})
+
+	t.Run("modified", func(t *testing.T) {
+		modified := append([]byte{}, encrypted...)
+		modified[len(modified)-1] ^= 0xff
+
+		r, err := NewDecryptionReader(dataKey.Plaintext, bytes.NewReader(modified))
+		require.NoError(t, err)
+
+		_, err = io.ReadAll(r)
+		assert.ErrorIs(t, err, ErrObjectEncryptionInvalid)
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		otherKey, err := GenerateObjectDataKey()
+		require.NoError(t, err)
+
+		r, err := NewDecryptionReader(otherKey, bytes.NewReader(encrypted))
+		require.NoError(t, err)
+
+		_, err = io.ReadAll(r)
+		assert.ErrorIs(t, err, ErrObjectEncryptionInvalid)
+	})
+}
+
+func TestObjectWriterCompressesThenEncrypts(t *testing.T) {
+	dataKey := newTestObjectDataKey(t)
+
+	payload := bytes.Repeat([]byte("beta9 object payload "), 8192)
+
+	var buf bytes.Buffer
+	w, err := NewObjectWriter(types.ObjectCompressionZstd, dataKey, &buf)
+	require.NoError(t, err)
+	_, err = w.Write(payload)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	assert.Less(t, buf.Len(), len(payload))
+
+	r, err := NewObjectReader(types.ObjectCompressionZstd, dataKey.Plaintext, &buf)
+	require.NoError(t, err)
+	defer r.Close()
+
+	out, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, payload, out)
+}
+
+func TestMasterKeyWrapper(t *testing.T) {
+	masterKey := make([]byte, 32)
+	_, err := rand.Read(masterKey)
+	require.NoError(t, err)
+
+	wrapper, err := NewObjectKeyWrapper(types.ObjectEncryptionConfig{
+		Enabled:   true,
+		MasterKey: "sk_" + base64.StdEncoding.EncodeToString(masterKey),
+	})
+	require.NoError(t, err)
+
+	dataKey, err := GenerateObjectDataKey()
+	require.NoError(t, err)
+
+	wrapped, err := wrapper.WrapKey(dataKey)
+	require.NoError(t, err)
+	assert.NotContains(t, wrapped, base64.StdEncoding.EncodeToString(dataKey))
+
+	unwrapped, err := wrapper.UnwrapKey(wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, dataKey, unwrapped)
+
+	_, err = NewObjectKeyWrapper(types.ObjectEncryptionConfig{Enabled: true, MasterKey: "not-a-key"})
+	assert.Error(t, err)
+}
@@ -22,6 +22,7 @@ var (
 	schedulerWorkerAddress           string = "scheduler:container:worker_addr:%s"
 	schedulerContainerLock           string = "scheduler:container:lock:%s"
 	schedulerContainerExitCode       string = "scheduler:container:exit_code:%s"
+	schedulerContainerHealth         string = "scheduler:container:health:%s"
 	schedulerCheckpointState         string = "scheduler:checkpoint_state:%s:%s"
 	schedulerServeLock               string = "scheduler:serve:lock:%s:%s"
 	schedulerStubState               string = "scheduler:stub:state:%s"
@@ -32,6 +33,23 @@ var (
 	gatewayDefaultDeployment           string = "gateway:default_deployment:%s"
 	gatewayDeploymentMinContainerCount string = "gateway:min_containers:%s"
 	gatewayAuthKey                     string = "gateway:auth:%s:%s"
+	gatewayObjectReaperLock            string = "gateway:object_reaper:lock"
+	gatewayObjectMigratorLock          string = "gateway:object_migrator:lock"
+	gatewayObjectMigrationPending      string = "gateway:object_migration:pending"
+	gatewayObjectIntegrityLock         string = "gateway:object_integrity:lock"
+	gatewayObjectReplicatorLock        string = "gateway:object_replicator:lock"
+	gatewayObjectWebhookLock           string = "gateway:object_webhook:lock"
+	gatewayObjectUploads               string = "gateway:object_uploads"
+	gatewayTrafficSplitOutcomes        string = "gateway:traffic_split:%d:outcomes"
+	gatewayAutoscalingMetric           string = "gateway:autoscaling_metric:%s:%s"
+	gatewayCustomDomain                string = "gateway:custom_domain:%s"
+	gatewayACMECache                   string = "gateway:acme:%s"
+	gatewayConcurrencyActive           string = "gateway:concurrency:{%s}:active"
+	gatewayConcurrencyQueue            string = "gateway:concurrency:{%s}:queue"
+	gatewayConcurrencyQueueSeen        string = "gateway:concurrency:{%s}:queue_seen"
+	gatewayAuditLogPrunerLock          string = "gateway:audit_log_pruner:lock"
+	gatewayRateLimitBucket             string = "gateway:rate_limit:{%s}:bucket"
+	gatewayRateLimitStreams            string = "gateway:rate_limit:{%s}:streams"
 )
 
 var (
@@ -41,6 +59,8 @@ var (
 	workerNetworkLock            string = "worker:network:%s:lock"
 	workerNetworkIpIndex         string = "worker:network:%s:ip_index"
 	workerNetworkContainerIp     string = "worker:network:%s:container_ip:%s"
+	workerObjectCacheIndex       string = "worker:object_cache:%s"
+	workerObjectCacheInvalidated string = "worker:%s:object_cache:invalidated"
 )
 
 var (
@@ -49,6 +69,7 @@ var (
 	workerPoolStateLock   string = "workerpool:%s:state:lock"
 	workerPoolSizerLock   string = "workerpool:%s:sizer:lock"
 	workerPoolCleanerLock string = "workerpool:%s:cleaner:lock"
+	workerPoolDemand      string = "workerpool:%s:demand:%d"
 )
 
 var (
@@ -69,6 +90,12 @@ var (
 	workspaceConcurrencyLimit        string = "workspace:concurrency_limit:%s"
 	workspaceConcurrencyLimitLock    string = "workspace:concurrency_limit:lock:%s"
 	workspaceAuthorizedToken         string = "workspace:authorization:token:%s"
+	workspaceRevokedToken            string = "workspace:authorization:revoked:%s"
+	workspaceTokenSession            string = "workspace:authorization:session:%s"
+	workspaceIPAllowList             string = "workspace:ip_allowlist:%s"
+	workspacePolicy                  string = "workspace:policy:%s"
+	workspacePoolQuotas              string = "workspace:pool_quotas"
+	workspaceCapacityReservations    string = "workspace:capacity_reservations"
 )
 
 var (
@@ -169,6 +196,10 @@ func (rk *redisKeys) SchedulerContainerExitCode(containerId string) string {
 	return fmt.Sprintf(schedulerContainerExitCode, containerId)
 }
 
+func (rk *redisKeys) SchedulerContainerHealth(containerId string) string {
+	return fmt.Sprintf(schedulerContainerHealth, containerId)
+}
+
 func (rk *redisKeys) SchedulerCheckpointState(workspaceName, checkpointId string) string {
 	return fmt.Sprintf(schedulerCheckpointState, workspaceName, checkpointId)
 }
@@ -194,6 +225,74 @@ func (rk *redisKeys) GatewayDeploymentMinContainerCount(appId string) string {
 	return fmt.Sprintf(gatewayDeploymentMinContainerCount, appId)
 }
 
+func (rk *redisKeys) GatewayObjectReaperLock() string {
+	return gatewayObjectReaperLock
+}
+
+func (rk *redisKeys) GatewayObjectMigratorLock() string {
+	return gatewayObjectMigratorLock
+}
+
+func (rk *redisKeys) GatewayObjectMigrationPending() string {
+	return gatewayObjectMigrationPending
+}
+
+func (rk *redisKeys) GatewayObjectIntegrityLock() string {
+	return gatewayObjectIntegrityLock
+}
+
+func (rk *redisKeys) GatewayObjectReplicatorLock() string {
+	return gatewayObjectReplicatorLock
+}
+
+func (rk *redisKeys) GatewayObjectWebhookLock() string {
+	return gatewayObjectWebhookLock
+}
+
+func (rk *redisKeys) GatewayObjectUploads() string {
+	return gatewayObjectUploads
+}
+
+func (rk *redisKeys) GatewayTrafficSplitOutcomes(splitId uint) string {
+	return fmt.Sprintf(gatewayTrafficSplitOutcomes, splitId)
+}
+
+func (rk *redisKeys) GatewayCustomDomain(domain string) string {
+	return fmt.Sprintf(gatewayCustomDomain, domain)
+}
+
+func (rk *redisKeys) GatewayACMECache(key string) string {
+	return fmt.Sprintf(gatewayACMECache, key)
+}
+
+func (rk *redisKeys) GatewayAutoscalingMetric(stubId, metric string) string {
+	return fmt.Sprintf(gatewayAutoscalingMetric, stubId, metric)
+}
+
+func (rk *redisKeys) GatewayConcurrencyActive(stubId string) string {
+	return fmt.Sprintf(gatewayConcurrencyActive, stubId)
+}
+
+func (rk *redisKeys) GatewayConcurrencyQueue(stubId string) string {
+	return fmt.Sprintf(gatewayConcurrencyQueue, stubId)
+}
+
+func (rk *redisKeys) GatewayConcurrencyQueueSeen(stubId string) string {
+	return fmt.Sprintf(gatewayConcurrencyQueueSeen, stubId)
+}
+
+func (rk *redisKeys) GatewayAuditLogPrunerLock() string {
+	return gatewayAuditLogPrunerLock
+}
+
+func (rk *redisKeys) GatewayRateLimitBucket(tokenId string) string {
+	return fmt.Sprintf(gatewayRateLimitBucket, tokenId)
+}
+
+func (rk *redisKeys) GatewayRateLimitStreams(tokenId string) string {
+	return fmt.Sprintf(gatewayRateLimitStreams, tokenId)
+}
+
 // Worker keys
 func (rk *redisKeys) WorkerPrefix() string {
 	return workerPrefix
@@ -219,6 +318,14 @@ func (rk *redisKeys) WorkerNetworkContainerIp(networkPrefix, containerId string)
 	return fmt.Sprintf(workerNetworkContainerIp, networkPrefix, containerId)
 }
 
+func (rk *redisKeys) WorkerObjectCacheIndex(digest string) string {
+	return fmt.Sprintf(workerObjectCacheIndex, digest)
+}
+
+func (rk *redisKeys) WorkerObjectCacheInvalidated(workerId string) string {
+	return fmt.Sprintf(workerObjectCacheInvalidated, workerId)
+}
+
 // Worker Pool keys
 func (rk *redisKeys) WorkerPoolPrefix() string {
 	return workerPoolPrefix
@@ -240,6 +347,10 @@ func (rk *redisKeys) WorkerPoolCleanerLock(poolName string) string {
 	return fmt.Sprintf(workerPoolCleanerLock, poolName)
 }
 
+func (rk *redisKeys) WorkerPoolDemand(poolName string, hour int64) string {
+	return fmt.Sprintf(workerPoolDemand, poolName, hour)
+}
+
 // Task keys
 func (rk *redisKeys) TaskPrefix() string {
 	return taskPrefix
@@ -294,6 +405,30 @@ func (rl *redisKeys) WorkspaceAuthorizedToken(token string) string {
 	return fmt.Sprintf(workspaceAuthorizedToken, token)
 }
 
+func (rl *redisKeys) WorkspaceRevokedToken(token string) string {
+	return fmt.Sprintf(workspaceRevokedToken, token)
+}
+
+func (rk *redisKeys) WorkspaceTokenSession(sessionId string) string {
+	return fmt.Sprintf(workspaceTokenSession, sessionId)
+}
+
+func (rk *redisKeys) WorkspaceIPAllowList(workspaceId string) string {
+	return fmt.Sprintf(workspaceIPAllowList, workspaceId)
+}
+
+func (rk *redisKeys) WorkspacePolicy(workspaceId string) string {
+	return fmt.Sprintf(workspacePolicy, workspaceId)
+}
+
+func (rk *redisKeys) WorkspacePoolQuotas() string {
+	return workspacePoolQuotas
+}
+
+func (rk *redisKeys) WorkspaceCapacityReservations() string {
+	return workspaceCapacityReservations
+}
+
 // Tailscale keys
 func (rk *redisKeys) TailscalePrefix() string {
 	return tailscalePrefix
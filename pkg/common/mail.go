@@ -0,0 +1,49 @@
+package common
+
+import (
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+// Mailer sends plain text emails through an SMTP server
+type Mailer struct {
+	config types.SMTPConfig
+}
+
+// NewMailer returns nil if no SMTP host is configured
+func NewMailer(config types.SMTPConfig) *Mailer {
+	if config.Host == "" {
+		return nil
+	}
+
+	return &Mailer{config: config}
+}
+
+func (m *Mailer) Send(to string, subject string, body string) error {
+	if strings.ContainsAny(to, "\r\n") || strings.ContainsAny(subject, "\r\n") {
+		return fmt.Errorf("invalid email header")
+	}
+
+	var auth smtp.Auth
+	if m.config.Username != "" {
+		auth = smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.Host)
+	}
+
+	msg := strings.Join([]string{
+		"From: " + m.config.From,
+		"To: " + to,
+		"Subject: " + subject,
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=utf-8",
+		"",
+		body,
+	}, "\r\n")
+
+	addr := net.JoinHostPort(m.config.Host, strconv.Itoa(m.config.Port))
+	return smtp.SendMail(addr, auth, m.config.From, []string{to}, []byte(msg))
+}
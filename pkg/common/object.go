@@ -2,6 +2,7 @@ package common
 
 import (
 	"archive/zip"
+	"bufio"
 	"bytes"
 	"context"
 	"fmt"
@@ -15,7 +16,15 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-func ExtractObjectFile(ctx context.Context, objectPath, destPath string) error {
+// ExtractObjectFile unarchives an object into destPath, detecting whether it was stored compressed
+func ExtractObjectFile(ctx context.Context, objectPath, destPath string, encryption types.ObjectEncryptionConfig) error {
+	return ExtractCompressedObjectFile(ctx, objectPath, destPath, types.ObjectCompressionNone, encryption)
+}
+
+// ExtractCompressedObjectFile unarchives an object stored with the given compression into destPath.
+// If no compression is recorded for the object, it is detected from the file's contents. Encrypted
+// objects are decrypted with the data key embedded in them, unwrapped using the configured master key.
+func ExtractCompressedObjectFile(ctx context.Context, objectPath, destPath string, compression types.ObjectCompression, encryption types.ObjectEncryptionConfig) error {
 	if _, err := os.Stat(destPath); !os.IsNotExist(err) {
 		// Folder already exists, so skip extraction
 		return nil
@@ -28,14 +37,119 @@ func ExtractObjectFile(ctx context.Context, objectPath, destPath string) error {
 		return err
 	}
 
+	// Objects may be stored compressed or encrypted by the gateway; decode them before unarchiving
+	archivePath, cleanup, err := decompressObjectFile(objectPath, compression, encryption)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
 	zip := archiver.NewZip()
-	if err := zip.Unarchive(objectPath, destPath); err != nil {
+	if err := zip.Unarchive(archivePath, destPath); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// decompressObjectFile returns a path to the decoded contents of objectPath. If the object
+// isn't compressed or encrypted, objectPath itself is returned. The returned cleanup func
+// removes any temporary file created along the way.
+func decompressObjectFile(objectPath string, compression types.ObjectCompression, encryption types.ObjectEncryptionConfig) (string, func(), error) {
+	noop := func() {}
+
+	f, err := os.Open(objectPath)
+	if err != nil {
+		return "", noop, err
+	}
+	defer f.Close()
+
+	header := make([]byte, 4)
+	n, err := io.ReadFull(f, header)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", noop, err
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return "", noop, err
+	}
+
+	var r io.Reader = f
+	encrypted := IsEncryptedObject(header[:n])
+	if encrypted {
+		r, err = newEmbeddedKeyDecryptionReader(f, encryption)
+		if err != nil {
+			return "", noop, err
+		}
+	}
+
+	// Objects written before compression was recorded, or copied without metadata, are sniffed instead
+	if compression == types.ObjectCompressionNone {
+		buffered := bufio.NewReader(r)
+		sniffed, err := buffered.Peek(len(header))
+		if err != nil && err != io.EOF {
+			return "", noop, err
+		}
+
+		compression = DetectCompression(sniffed)
+		r = buffered
+	}
+
+	if compression == types.ObjectCompressionNone && !encrypted {
+		return objectPath, noop, nil
+	}
+
+	reader, err := NewDecompressionReader(compression, r)
+	if err != nil {
+		return "", noop, err
+	}
+	defer reader.Close()
+
+	tmpFile, err := os.CreateTemp("", "object-*.zip")
+	if err != nil {
+		return "", noop, err
+	}
+	cleanup := func() { os.Remove(tmpFile.Name()) }
+
+	if _, err := io.Copy(tmpFile, reader); err != nil {
+		tmpFile.Close()
+		cleanup()
+		return "", noop, err
+	}
+
+	if err := tmpFile.Close(); err != nil {
+		cleanup()
+		return "", noop, err
+	}
+
+	return tmpFile.Name(), cleanup, nil
+}
+
+// newEmbeddedKeyDecryptionReader decrypts an object using the wrapped data key stored in its header,
+// for callers that don't have the object's record at hand
+func newEmbeddedKeyDecryptionReader(f *os.File, encryption types.ObjectEncryptionConfig) (io.Reader, error) {
+	wrappedKey, err := ReadObjectWrappedKey(f)
+	if err != nil {
+		return nil, err
+	}
+
+	wrapper, err := NewObjectKeyWrapper(encryption)
+	if err != nil {
+		return nil, err
+	}
+
+	dataKey, err := wrapper.UnwrapKey(wrappedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	return NewDecryptionReader(dataKey, f)
+}
+
 func UnzipBytesToPath(destPath string, objBytes []byte, request *types.ContainerRequest) error {
 	if _, err := os.Stat(destPath); !os.IsNotExist(err) {
 		return nil
@@ -0,0 +1,335 @@
+package common
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// Name every internal certificate is valid for. Internal connections are often made by IP or through a
+	// tunnel, so clients verify this name instead of the address they dialed.
+	InternalTLSServerName = "beta9.internal"
+
+	// How long before the end of their lifetime certificates are renewed
+	certificateRenewalFraction = 3
+	certificateRetryInterval   = 30 * time.Second
+	// Certificates are backdated a little so they're valid on hosts with slightly skewed clocks
+	certificateClockSkew = 5 * time.Minute
+)
+
+// CertificateAuthority signs the short-lived certificates the gateway and workers use to authenticate each other
+type CertificateAuthority struct {
+	cert    *x509.Certificate
+	certPEM []byte
+	key     crypto.Signer
+	pool    *x509.CertPool
+}
+
+// LoadCertificateAuthority reads a PEM encoded CA certificate and its private key
+func LoadCertificateAuthority(certFile, keyFile string) (*CertificateAuthority, error) {
+	certPEM, err := os.ReadFile(certFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ca certificate: %w", err)
+	}
+
+	keyPEM, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ca key: %w", err)
+	}
+
+	pair, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ca key pair: %w", err)
+	}
+
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if !cert.IsCA {
+		return nil, errors.New("ca certificate is not a certificate authority")
+	}
+
+	key, ok := pair.PrivateKey.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("unsupported ca key type")
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+
+	return &CertificateAuthority{cert: cert, certPEM: certPEM, key: key, pool: pool}, nil
+}
+
+func (ca *CertificateAuthority) CertPEM() []byte {
+	return ca.certPEM
+}
+
+func (ca *CertificateAuthority) Pool() *x509.CertPool {
+	return ca.pool
+}
+
+// SignCSR issues a certificate for the key in a PEM encoded certificate request. The subject is always
+// commonName, whatever the request asks for, so callers can't get certificates for someone else.
+func (ca *CertificateAuthority) SignCSR(csrPEM []byte, commonName string, ttl time.Duration) ([]byte, time.Time, error) {
+	block, _ := pem.Decode(csrPEM)
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		return nil, time.Time{}, errors.New("invalid certificate request")
+	}
+
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	if err := csr.CheckSignature(); err != nil {
+		return nil, time.Time{}, fmt.Errorf("invalid certificate request signature: %w", err)
+	}
+
+	return ca.sign(csr.PublicKey, commonName, ttl)
+}
+
+// Issue creates a key and a certificate for it, for the gateway's own use
+func (ca *CertificateAuthority) Issue(commonName string, ttl time.Duration) (*tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	certPEM, _, err := ca.sign(key.Public(), commonName, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	keyPEM, err := encodeECKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cert, nil
+}
+
+func (ca *CertificateAuthority) sign(publicKey crypto.PublicKey, commonName string, ttl time.Duration) ([]byte, time.Time, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	now := time.Now()
+	notAfter := now.Add(ttl)
+	if notAfter.After(ca.cert.NotAfter) {
+		notAfter = ca.cert.NotAfter
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{InternalTLSServerName},
+		NotBefore:    now.Add(-certificateClockSkew),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		// Both sides of internal connections use the same kind of certificate
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, publicKey, ca.key)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), notAfter, nil
+}
+
+// NewCertificateRequest creates a key and a PEM encoded request for a certificate for it. The key is returned
+// PEM encoded as well, ready to be paired with the issued certificate.
+func NewCertificateRequest(commonName string) (csrPEM []byte, keyPEM []byte, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: commonName},
+	}, key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyPEM, err = encodeECKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}), keyPEM, nil
+}
+
+func encodeECKey(key *ecdsa.PrivateKey) ([]byte, error) {
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), nil
+}
+
+// RotatingCertificate holds a certificate that's replaced before it expires. TLS configs made from it pick up
+// the current certificate on every handshake, so connections made after a rotation use the new one.
+type RotatingCertificate struct {
+	mu    sync.RWMutex
+	cert  *tls.Certificate
+	pool  *x509.CertPool
+	issue func() (*tls.Certificate, *x509.CertPool, error)
+}
+
+// NewRotatingCertificate issues the first certificate right away, and keeps issuing new ones until ctx is done
+func NewRotatingCertificate(ctx context.Context, issue func() (*tls.Certificate, *x509.CertPool, error)) (*RotatingCertificate, error) {
+	rc := &RotatingCertificate{issue: issue}
+	if err := rc.rotate(); err != nil {
+		return nil, err
+	}
+
+	go rc.renew(ctx)
+	return rc, nil
+}
+
+func (rc *RotatingCertificate) rotate() error {
+	cert, pool, err := rc.issue()
+	if err != nil {
+		return err
+	}
+
+	if cert.Leaf == nil {
+		leaf, err := x509.ParseCertificate(cert.Certificate[0])
+		if err != nil {
+			return err
+		}
+		cert.Leaf = leaf
+	}
+
+	rc.mu.Lock()
+	rc.cert = cert
+	rc.pool = pool
+	rc.mu.Unlock()
+
+	return nil
+}
+
+func (rc *RotatingCertificate) renew(ctx context.Context) {
+	for {
+		rc.mu.RLock()
+		leaf := rc.cert.Leaf
+		rc.mu.RUnlock()
+
+		wait := time.Until(leaf.NotAfter) / certificateRenewalFraction
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+
+		for {
+			err := rc.rotate()
+			if err == nil {
+				break
+			}
+
+			log.Error().Err(err).Time("expires_at", leaf.NotAfter).Msg("failed to renew internal certificate")
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(certificateRetryInterval):
+			}
+		}
+	}
+}
+
+func (rc *RotatingCertificate) current() (*tls.Certificate, *x509.CertPool) {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+	return rc.cert, rc.pool
+}
+
+// ServerTLSConfig requires clients to present a certificate from the internal CA
+func (rc *RotatingCertificate) ServerTLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ClientAuth: tls.RequireAnyClientCert,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			cert, _ := rc.current()
+			return cert, nil
+		},
+		// The CA pool can change when certificates rotate, so the chain is verified here instead of with ClientCAs
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			_, pool := rc.current()
+			return verifyInternalPeer(rawCerts, pool, x509.ExtKeyUsageClientAuth)
+		},
+	}
+}
+
+// ClientTLSConfig presents the current certificate and only trusts servers with a certificate from the internal CA
+func (rc *RotatingCertificate) ClientTLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: []string{"h2"},
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, _ := rc.current()
+			return cert, nil
+		},
+		// Verified against the current CA pool below, since it can change when certificates rotate
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			_, pool := rc.current()
+			return verifyInternalPeer(rawCerts, pool, x509.ExtKeyUsageServerAuth)
+		},
+	}
+}
+
+func verifyInternalPeer(rawCerts [][]byte, pool *x509.CertPool, usage x509.ExtKeyUsage) error {
+	if len(rawCerts) == 0 {
+		return errors.New("no certificate presented")
+	}
+
+	certs := make([]*x509.Certificate, 0, len(rawCerts))
+	for _, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return err
+		}
+		certs = append(certs, cert)
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	_, err := certs[0].Verify(x509.VerifyOptions{
+		DNSName:       InternalTLSServerName,
+		Roots:         pool,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{usage},
+	})
+	return err
+}
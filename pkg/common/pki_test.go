@@ -0,0 +1,124 @@
+package common
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCA(t *testing.T) *CertificateAuthority {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+
+	keyPEM, err := encodeECKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "ca.crt"), filepath.Join(dir, "ca.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, keyPEM, 0600))
+
+	ca, err := LoadCertificateAuthority(certFile, keyFile)
+	require.NoError(t, err)
+	return ca
+}
+
+func TestSignCSR(t *testing.T) {
+	ca := newTestCA(t)
+
+	csrPEM, _, err := NewCertificateRequest("someone-else")
+	require.NoError(t, err)
+
+	certPEM, expiresAt, err := ca.SignCSR(csrPEM, "worker-1", time.Hour)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), expiresAt, time.Minute)
+
+	block, _ := pem.Decode(certPEM)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	assert.Equal(t, "worker-1", cert.Subject.CommonName)
+
+	// Certificates never outlive the CA
+	_, expiresAt, err = ca.SignCSR(csrPEM, "worker-1", 48*time.Hour)
+	require.NoError(t, err)
+	assert.True(t, !expiresAt.After(ca.cert.NotAfter))
+
+	_, _, err = ca.SignCSR([]byte("not a csr"), "worker-1", time.Hour)
+	assert.Error(t, err)
+}
+
+func TestRotatingCertificateMutualTLS(t *testing.T) {
+	ca := newTestCA(t)
+	otherCA := newTestCA(t)
+
+	issuer := func(ca *CertificateAuthority, name string) func() (*tls.Certificate, *x509.CertPool, error) {
+		return func() (*tls.Certificate, *x509.CertPool, error) {
+			cert, err := ca.Issue(name, time.Hour)
+			return cert, ca.Pool(), err
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server, err := NewRotatingCertificate(ctx, issuer(ca, "gateway"))
+	require.NoError(t, err)
+	client, err := NewRotatingCertificate(ctx, issuer(ca, "worker"))
+	require.NoError(t, err)
+	stranger, err := NewRotatingCertificate(ctx, issuer(otherCA, "stranger"))
+	require.NoError(t, err)
+
+	handshake := func(clientConfig *tls.Config) error {
+		serverConn, clientConn := net.Pipe()
+		defer serverConn.Close()
+
+		errs := make(chan error, 1)
+		go func() {
+			errs <- tls.Server(serverConn, server.ServerTLSConfig()).Handshake()
+		}()
+
+		// Pipes are unbuffered, so the client end is closed before waiting on the server in case either side gave up
+		clientErr := tls.Client(clientConn, clientConfig).Handshake()
+		clientConn.Close()
+
+		serverErr := <-errs
+		if clientErr != nil {
+			return clientErr
+		}
+		return serverErr
+	}
+
+	assert.NoError(t, handshake(client.ClientTLSConfig()))
+	assert.Error(t, handshake(stranger.ClientTLSConfig()))
+
+	noCert := client.ClientTLSConfig()
+	noCert.GetClientCertificate = nil
+	assert.Error(t, handshake(noCert))
+}
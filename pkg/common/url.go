@@ -81,3 +81,14 @@ func BuildSandboxURL(externalUrl, urlType string, stub *types.StubWithRelated, p
 
 	return url
 }
+
+// BuildTaskTriggerURL returns the url external services deliver webhooks for a task trigger to. Deliveries are
+// authenticated by their signature, so it's always a path on the gateway's own host.
+func BuildTaskTriggerURL(externalUrl string, trigger *types.TaskTrigger) string {
+	parsedUrl, err := url.Parse(externalUrl)
+	if err != nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%s://%s/%s/trigger/%s", parsedUrl.Scheme, parsedUrl.Host, types.StubType(trigger.StubType).Kind(), trigger.ExternalId)
+}
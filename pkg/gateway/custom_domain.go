@@ -0,0 +1,154 @@
+package gateway
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+
+	"github.com/beam-cloud/beta9/pkg/common"
+	gatewaymiddleware "github.com/beam-cloud/beta9/pkg/gateway/middleware"
+	"github.com/beam-cloud/beta9/pkg/repository"
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+// acmeCache keeps ACME account keys, certificates and pending challenges in redis, so every gateway replica
+// serves the same certificates and can answer challenges for issuances started by another replica
+type acmeCache struct {
+	rdb         *common.RedisClient
+	backendRepo repository.BackendRepository
+}
+
+func (c *acmeCache) Get(ctx context.Context, key string) ([]byte, error) {
+	data, err := c.rdb.Get(ctx, common.RedisKeys.GatewayACMECache(key)).Bytes()
+	if err == redis.Nil {
+		return nil, autocert.ErrCacheMiss
+	}
+
+	return data, err
+}
+
+func (c *acmeCache) Put(ctx context.Context, key string, data []byte) error {
+	if err := c.rdb.Set(ctx, common.RedisKeys.GatewayACMECache(key), data, 0).Err(); err != nil {
+		return err
+	}
+
+	// Certificates are stored under their domain, with a suffix for RSA certificates. Account keys and
+	// challenge tokens have other suffixes.
+	domain, suffix, _ := strings.Cut(key, "+")
+	if suffix == "" || suffix == "rsa" {
+		if expiresAt, ok := certificateExpiry(data); ok {
+			if err := c.backendRepo.UpdateCustomDomainStatus(ctx, domain, types.CustomDomainStatusActive, "", &expiresAt); err != nil {
+				log.Warn().Err(err).Str("domain", domain).Msg("failed to update custom domain status")
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *acmeCache) Delete(ctx context.Context, key string) error {
+	return c.rdb.Del(ctx, common.RedisKeys.GatewayACMECache(key)).Err()
+}
+
+// certificateExpiry returns when the leaf certificate in autocert's cached PEM bundle expires
+func certificateExpiry(data []byte) (expiresAt time.Time, ok bool) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return expiresAt, false
+		}
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return expiresAt, false
+		}
+
+		return cert.NotAfter, true
+	}
+}
+
+// newCertManager issues and renews certificates for custom domains through ACME. It only issues for verified
+// domains attached to a deployment.
+func (g *Gateway) newCertManager(resolver *gatewaymiddleware.CustomDomainResolver) *autocert.Manager {
+	config := g.Config.GatewayService.CustomDomains
+
+	return &autocert.Manager{
+		Prompt: autocert.AcceptTOS,
+		Cache:  &acmeCache{rdb: g.RedisClient, backendRepo: g.BackendRepo},
+		Email:  config.ACMEEmail,
+		Client: &acme.Client{DirectoryURL: config.ACMEDirectoryURL},
+		HostPolicy: func(ctx context.Context, host string) error {
+			if resolver.Resolve(ctx, host) == "" {
+				return fmt.Errorf("%s isn't a verified domain attached to a deployment", host)
+			}
+			return nil
+		},
+	}
+}
+
+// getCertificate picks a custom domain's certificate by SNI, recording issuance failures on the domain
+func (g *Gateway) getCertificate(certManager *autocert.Manager, resolver *gatewaymiddleware.CustomDomainResolver) func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+		cert, err := certManager.GetCertificate(hello)
+		if err == nil {
+			return cert, nil
+		}
+
+		domain := strings.ToLower(hello.ServerName)
+		if resolver.Resolve(hello.Context(), domain) == "" {
+			return nil, err
+		}
+
+		customDomain, lookupErr := g.BackendRepo.GetCustomDomain(hello.Context(), domain)
+		if lookupErr == nil && customDomain != nil && (customDomain.Status != types.CustomDomainStatusFailed || customDomain.LastError != err.Error()) {
+			if updateErr := g.BackendRepo.UpdateCustomDomainStatus(hello.Context(), domain, types.CustomDomainStatusFailed, err.Error(), nil); updateErr != nil {
+				log.Warn().Err(updateErr).Str("domain", domain).Msg("failed to update custom domain status")
+			}
+		}
+
+		return nil, err
+	}
+}
+
+// initCustomDomains serves HTTPS for custom domains and answers ACME HTTP challenges on the plain HTTP server
+func (g *Gateway) initCustomDomains(resolver *gatewaymiddleware.CustomDomainResolver, handler http.Handler) {
+	certManager := g.newCertManager(resolver)
+
+	tlsConfig := certManager.TLSConfig()
+	tlsConfig.GetCertificate = g.getCertificate(certManager, resolver)
+
+	g.httpsServer = &http.Server{
+		Addr:      fmt.Sprintf(":%d", g.Config.GatewayService.CustomDomains.Port),
+		Handler:   handler,
+		TLSConfig: tlsConfig,
+	}
+
+	g.httpServer.Handler = certManager.HTTPHandler(g.httpServer.Handler)
+}
+
+func (g *Gateway) serveCustomDomains() {
+	lis, err := net.Listen("tcp", g.httpsServer.Addr)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to listen")
+	}
+
+	if err := g.httpsServer.ServeTLS(lis, "", ""); err != nil && err != http.ErrServerClosed {
+		log.Fatal().Err(err).Msg("failed to start custom domain https server")
+	}
+}
@@ -2,11 +2,14 @@ package gateway
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
@@ -49,6 +52,7 @@ import (
 	pb "github.com/beam-cloud/beta9/proto"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/health"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
@@ -59,7 +63,10 @@ type Gateway struct {
 	pb.UnimplementedSchedulerServer
 	Config               types.AppConfig
 	httpServer           *http.Server
+	httpsServer          *http.Server
 	grpcServer           *grpc.Server
+	internalGrpcServer   *grpc.Server
+	internalCA           *common.CertificateAuthority
 	RedisClient          *common.RedisClient
 	TaskDispatcher       *task.Dispatcher
 	TaskRepo             repository.TaskRepository
@@ -79,6 +86,7 @@ type Gateway struct {
 	cancelFunc           context.CancelFunc
 	baseRouteGroup       *echo.Group
 	rootRouteGroup       *echo.Group
+	oidcAuthenticator    *auth.OIDCAuthenticator
 }
 
 func NewGateway() (*Gateway, error) {
@@ -150,7 +158,7 @@ func NewGateway() (*Gateway, error) {
 	workerRepo := repository.NewWorkerRedisRepository(redisClient, config.Worker)
 	workerPoolRepo := repository.NewWorkerPoolRedisRepository(redisClient)
 	taskRepo := repository.NewTaskRedisRepository(redisClient)
-	taskDispatcher, err := task.NewDispatcher(ctx, taskRepo)
+	taskDispatcher, err := task.NewDispatcher(ctx, taskRepo, backendRepo, containerRepo, workspaceRepo)
 	if err != nil {
 		return nil, err
 	}
@@ -169,6 +177,7 @@ func NewGateway() (*Gateway, error) {
 	gateway.EventRepo = eventRepo
 	gateway.workerRepo = workerRepo
 	gateway.DefaultStorageClient = storageClient
+	gateway.oidcAuthenticator = auth.NewOIDCAuthenticator(config.GatewayService.OIDC, backendRepo, workspaceRepo)
 
 	return gateway, nil
 }
@@ -197,14 +206,33 @@ func (g *Gateway) initHttp() error {
 		pprof.Register(e)
 	}
 
+	var customDomainResolver *gatewaymiddleware.CustomDomainResolver
+	if g.Config.GatewayService.CustomDomains.Enabled {
+		customDomainResolver = gatewaymiddleware.NewCustomDomainResolver(g.Config.GatewayService.HTTP.GetExternalURL(), g.BackendRepo, g.RedisClient)
+	}
+
 	skipSubdomainRoutes := func(c echo.Context) bool {
 		baseDomain := gatewaymiddleware.ParseHostFromURL(g.Config.GatewayService.HTTP.GetExternalURL())
 		subdomain := gatewaymiddleware.ParseSubdomain(c.Request().Host, baseDomain)
-		return subdomain != ""
+		if subdomain != "" {
+			return true
+		}
+
+		return customDomainResolver != nil && customDomainResolver.Resolve(c.Request().Context(), c.Request().Host) != ""
+	}
+
+	// S3 keys can end with a slash, and S3 clients sign the path exactly as they send it
+	skipTrailingSlashRoutes := func(c echo.Context) bool {
+		requestPath := c.Request().URL.Path
+		if requestPath == gatewayservices.S3RoutePrefix || strings.HasPrefix(requestPath, gatewayservices.S3RoutePrefix+"/") {
+			return true
+		}
+
+		return skipSubdomainRoutes(c)
 	}
 
 	e.Pre(middleware.RemoveTrailingSlashWithConfig(middleware.TrailingSlashConfig{
-		Skipper: skipSubdomainRoutes,
+		Skipper: skipTrailingSlashRoutes,
 	}))
 
 	configureEchoLogger(e, g.Config.GatewayService.HTTP.EnablePrettyLogs)
@@ -214,6 +242,10 @@ func (g *Gateway) initHttp() error {
 		AllowMethods: g.Config.GatewayService.HTTP.CORS.AllowedMethods,
 	}))
 	e.Use(gatewaymiddleware.Subdomain(g.Config.GatewayService.HTTP.GetExternalURL(), g.BackendRepo, g.RedisClient))
+	if customDomainResolver != nil {
+		e.Use(gatewaymiddleware.CustomDomain(customDomainResolver))
+	}
+	e.Use(gatewaymiddleware.GRPCService())
 	e.Use(middleware.Recover())
 
 	// Accept both HTTP/2 and HTTP/1
@@ -222,7 +254,11 @@ func (g *Gateway) initHttp() error {
 		Handler: h2c.NewHandler(e, &http2.Server{}),
 	}
 
-	authMiddleware := auth.AuthMiddleware(g.BackendRepo, g.WorkspaceRepo)
+	if customDomainResolver != nil {
+		g.initCustomDomains(customDomainResolver, e)
+	}
+
+	authMiddleware := auth.OIDCAuthMiddleware(g.BackendRepo, g.WorkspaceRepo, g.Config.GatewayService.IPAllowList, g.oidcAuthenticator)
 	g.baseRouteGroup = e.Group(apiv1.HttpServerBaseRoute)
 	g.rootRouteGroup = e.Group(apiv1.HttpServerRootRoute)
 
@@ -241,11 +277,24 @@ func (g *Gateway) initHttp() error {
 }
 
 func (g *Gateway) initGrpc() error {
-	authInterceptor := auth.NewAuthInterceptor(g.Config, g.BackendRepo, g.WorkspaceRepo)
+	authInterceptor := auth.NewAuthInterceptor(g.Config, g.BackendRepo, g.WorkspaceRepo, g.oidcAuthenticator)
+	unaryInterceptors := []grpc.UnaryServerInterceptor{authInterceptor.Unary()}
+	streamInterceptors := []grpc.StreamServerInterceptor{authInterceptor.Stream()}
+
+	// Tokens can have their own limit even when there's no default, so this always runs
+	rateLimitInterceptor := auth.NewRateLimitInterceptor(g.Config.GatewayService.RateLimits, g.RedisClient)
+	unaryInterceptors = append(unaryInterceptors, rateLimitInterceptor.Unary())
+	streamInterceptors = append(streamInterceptors, rateLimitInterceptor.Stream())
+
+	if g.Config.Monitoring.Audit.Store.Enabled {
+		auditInterceptor := auth.NewAuditInterceptor(g.ctx, g.BackendRepo)
+		unaryInterceptors = append(unaryInterceptors, auditInterceptor.Unary())
+		streamInterceptors = append(streamInterceptors, auditInterceptor.Stream())
+	}
 
 	serverOptions := []grpc.ServerOption{
-		grpc.UnaryInterceptor(authInterceptor.Unary()),
-		grpc.StreamInterceptor(authInterceptor.Stream()),
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
 		grpc.MaxRecvMsgSize(g.Config.GatewayService.GRPC.MaxRecvMsgSize * 1024 * 1024),
 		grpc.MaxSendMsgSize(g.Config.GatewayService.GRPC.MaxSendMsgSize * 1024 * 1024),
 	}
@@ -254,6 +303,39 @@ func (g *Gateway) initGrpc() error {
 		serverOptions...,
 	)
 
+	if g.Config.GatewayService.InternalTLS.Enabled {
+		if err := g.initInternalGrpc(serverOptions); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// initInternalGrpc creates the server workers talk to the gateway through, which requires mutual TLS. The
+// gateway's own certificate is signed by the internal CA and used both for this server and for connecting to
+// workers.
+func (g *Gateway) initInternalGrpc(serverOptions []grpc.ServerOption) error {
+	config := g.Config.GatewayService.InternalTLS
+
+	ca, err := common.LoadCertificateAuthority(config.CACertFile, config.CAKeyFile)
+	if err != nil {
+		return err
+	}
+	g.internalCA = ca
+
+	hostname, _ := os.Hostname()
+	cert, err := common.NewRotatingCertificate(g.ctx, func() (*tls.Certificate, *x509.CertPool, error) {
+		cert, err := ca.Issue("gateway-"+hostname, config.CertTTL)
+		return cert, ca.Pool(), err
+	})
+	if err != nil {
+		return err
+	}
+
+	common.SetContainerClientTLS(cert.ClientTLSConfig())
+
+	g.internalGrpcServer = grpc.NewServer(append(serverOptions, grpc.Creds(credentials.NewTLS(cert.ServerTLSConfig())))...)
 	return nil
 }
 
@@ -263,7 +345,7 @@ func (g *Gateway) initGrpcProxy(grpcAddr string) error {
 	g.httpServer.RegisterOnShutdown(func() {
 		cancel()
 	})
-	mux := runtime.NewServeMux()
+	mux := runtime.NewServeMux(runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher))
 	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
 	if err := pb.RegisterPodServiceHandlerFromEndpoint(ctx, mux, grpcAddr, opts); err != nil {
 		return err
@@ -285,16 +367,35 @@ func (g *Gateway) initGrpcProxy(grpcAddr string) error {
 	return nil
 }
 
-// Register repository services
+// outgoingHeaderMatcher passes retry-after hints on to HTTP clients as the standard header, and prefixes other
+// gRPC headers the way grpc-gateway does by default
+func outgoingHeaderMatcher(key string) (string, bool) {
+	if key == auth.RetryAfterHeader {
+		return "Retry-After", true
+	}
+
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
+// Register repository services. With internal TLS they're only served on the internal port, and the public
+// port serves the certificates workers need to reach it.
 func (g *Gateway) registerRepositoryServices() error {
+	server := g.grpcServer
+	if g.internalGrpcServer != nil {
+		server = g.internalGrpcServer
+
+		wc := repositoryservices.NewWorkerCertificateService(g.internalCA, g.Config.GatewayService.InternalTLS.CertTTL, g.workerRepo)
+		pb.RegisterWorkerCertificateServiceServer(g.grpcServer, wc)
+	}
+
 	wr := repositoryservices.NewWorkerRepositoryService(g.ctx, g.workerRepo)
-	pb.RegisterWorkerRepositoryServiceServer(g.grpcServer, wr)
+	pb.RegisterWorkerRepositoryServiceServer(server, wr)
 
 	cr := repositoryservices.NewContainerRepositoryService(g.ctx, g.ContainerRepo)
-	pb.RegisterContainerRepositoryServiceServer(g.grpcServer, cr)
+	pb.RegisterContainerRepositoryServiceServer(server, cr)
 
 	br := repositoryservices.NewBackendRepositoryService(g.ctx, g.BackendRepo)
-	pb.RegisterBackendRepositoryServiceServer(g.grpcServer, br)
+	pb.RegisterBackendRepositoryServiceServer(server, br)
 
 	return nil
 }
@@ -394,7 +495,7 @@ func (g *Gateway) registerServices() error {
 	pb.RegisterEndpointServiceServer(g.grpcServer, ws)
 
 	// Register volume service
-	vs, err := volume.NewGlobalVolumeService(g.Config.FileService, g.BackendRepo, g.WorkspaceRepo, g.RedisClient, g.rootRouteGroup)
+	vs, err := volume.NewGlobalVolumeService(g.Config.FileService, g.Config.Storage, g.Config.GatewayService.IPAllowList, g.BackendRepo, g.WorkspaceRepo, g.RedisClient, g.rootRouteGroup)
 	if err != nil {
 		return err
 	}
@@ -421,14 +522,14 @@ func (g *Gateway) registerServices() error {
 	pb.RegisterPodServiceServer(g.grpcServer, ps)
 
 	// Register output service
-	o, err := output.NewOutputRedisService(g.Config, g.RedisClient, g.BackendRepo, g.rootRouteGroup)
+	o, err := output.NewOutputRedisService(g.ctx, g.Config, g.RedisClient, g.BackendRepo, g.rootRouteGroup)
 	if err != nil {
 		return err
 	}
 	pb.RegisterOutputServiceServer(g.grpcServer, o)
 
 	// Register Secret service
-	secretService := secret.NewSecretService(g.BackendRepo, g.WorkspaceRepo, g.rootRouteGroup)
+	secretService := secret.NewSecretService(g.BackendRepo, g.WorkspaceRepo, g.ContainerRepo, g.Scheduler, g.Config.GatewayService.IPAllowList, g.rootRouteGroup)
 	pb.RegisterSecretServiceServer(g.grpcServer, secretService)
 
 	// Register Signal service
@@ -488,6 +589,7 @@ func (g *Gateway) registerServices() error {
 		Ctx:              g.ctx,
 		Config:           g.Config,
 		BackendRepo:      g.BackendRepo,
+		WorkspaceRepo:    g.WorkspaceRepo,
 		ContainerRepo:    g.ContainerRepo,
 		ProviderRepo:     g.ProviderRepo,
 		Scheduler:        g.Scheduler,
@@ -498,6 +600,7 @@ func (g *Gateway) registerServices() error {
 		WorkerPoolRepo:   g.WorkerPoolRepo,
 		UsageMetricsRepo: g.UsageMetricsRepo,
 		Tailscale:        g.Tailscale,
+		RouteGroup:       g.rootRouteGroup,
 	})
 	if err != nil {
 		return err
@@ -562,6 +665,21 @@ func (g *Gateway) Start() error {
 		}
 	}()
 
+	if g.internalGrpcServer != nil {
+		go func() {
+			lis, err := net.Listen("tcp", fmt.Sprintf(":%d", g.Config.GatewayService.InternalTLS.Port))
+			if err != nil {
+				log.Fatal().Err(err).Msg("failed to listen")
+			}
+
+			if err := g.internalGrpcServer.Serve(lis); err != nil {
+				log.Fatal().Err(err).Msg("failed to start internal grpc server")
+			}
+		}()
+
+		log.Info().Int("port", g.Config.GatewayService.InternalTLS.Port).Msg("gateway internal grpc server running")
+	}
+
 	go func() {
 		lis, err := net.Listen("tcp", fmt.Sprintf(":%d", g.Config.GatewayService.HTTP.Port))
 		if err != nil {
@@ -573,6 +691,11 @@ func (g *Gateway) Start() error {
 		}
 	}()
 
+	if g.httpsServer != nil {
+		go g.serveCustomDomains()
+		log.Info().Int("port", g.Config.GatewayService.CustomDomains.Port).Msg("gateway custom domain https server running")
+	}
+
 	log.Info().Int("port", g.Config.GatewayService.HTTP.Port).Msg("gateway http server running")
 	log.Info().Int("port", g.Config.GatewayService.GRPC.Port).Msg("gateway grpc server running")
 
@@ -597,21 +720,33 @@ func (g *Gateway) shutdown() {
 		return g.httpServer.Shutdown(ctx)
 	})
 
-	eg.Go(func() error {
-		done := make(chan struct{})
-		go func() {
-			g.grpcServer.GracefulStop()
-			close(done)
-		}()
+	if g.httpsServer != nil {
+		eg.Go(func() error {
+			return g.httpsServer.Shutdown(ctx)
+		})
+	}
 
-		select {
-		case <-ctx.Done():
-			g.grpcServer.Stop()
-			return ctx.Err()
-		case <-done:
-			return nil
+	for _, server := range []*grpc.Server{g.grpcServer, g.internalGrpcServer} {
+		if server == nil {
+			continue
 		}
-	})
+
+		eg.Go(func() error {
+			done := make(chan struct{})
+			go func() {
+				server.GracefulStop()
+				close(done)
+			}()
+
+			select {
+			case <-ctx.Done():
+				server.Stop()
+				return ctx.Err()
+			case <-done:
+				return nil
+			}
+		})
+	}
 
 	g.cancelFunc()
 
@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+const (
+	customDomainKeyTtl time.Duration = time.Minute
+	// customDomainMiss is cached for hosts that aren't custom domains
+	customDomainMiss = "-"
+)
+
+type CustomDomainBackendRepo interface {
+	common.SubdomainBackendRepo
+	GetCustomDomain(ctx context.Context, domain string) (*types.CustomDomain, error)
+}
+
+// CustomDomainResolver maps hosts to the subdomain of the deployment they're attached to. Domains whose
+// ownership hasn't been verified resolve to nothing. Lookups, misses included, are cached in redis so requests
+// for the gateway's own hosts don't reach the database.
+type CustomDomainResolver struct {
+	baseDomain  string
+	backendRepo CustomDomainBackendRepo
+	redisClient *common.RedisClient
+}
+
+func NewCustomDomainResolver(externalURL string, backendRepo CustomDomainBackendRepo, redisClient *common.RedisClient) *CustomDomainResolver {
+	return &CustomDomainResolver{
+		baseDomain:  strings.ToLower(ParseHostFromURL(externalURL)),
+		backendRepo: backendRepo,
+		redisClient: redisClient,
+	}
+}
+
+// Resolve returns the subdomain of the deployment host is attached to, or an empty string if host isn't a
+// custom domain
+func (r *CustomDomainResolver) Resolve(ctx context.Context, host string) string {
+	domain, _, err := net.SplitHostPort(host)
+	if err != nil {
+		domain = host
+	}
+	domain = strings.TrimSuffix(strings.ToLower(domain), ".")
+
+	if domain == "" || !strings.Contains(domain, ".") || net.ParseIP(domain) != nil ||
+		domain == r.baseDomain || strings.HasSuffix(domain, "."+r.baseDomain) {
+		return ""
+	}
+
+	key := common.RedisKeys.GatewayCustomDomain(domain)
+	cached, err := r.redisClient.Get(ctx, key).Result()
+	if err == nil {
+		if cached == customDomainMiss {
+			return ""
+		}
+		return cached
+	} else if err != redis.Nil {
+		return ""
+	}
+
+	customDomain, err := r.backendRepo.GetCustomDomain(ctx, domain)
+	if err != nil {
+		return ""
+	}
+
+	subdomain := customDomainMiss
+	if customDomain != nil && customDomain.VerifiedAt.Valid {
+		subdomain = customDomain.Subdomain
+	}
+	r.redisClient.Set(ctx, key, subdomain, customDomainKeyTtl)
+
+	if subdomain == customDomainMiss {
+		return ""
+	}
+	return subdomain
+}
+
+// CustomDomain is middleware that routes requests sent to a custom domain as if they had been sent to the
+// subdomain of the deployment the domain is attached to, so they reach its latest version.
+func CustomDomain(resolver *CustomDomainResolver) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			subdomain := resolver.Resolve(ctx.Request().Context(), ctx.Request().Host)
+			if subdomain == "" {
+				return next(ctx)
+			}
+
+			fields := &common.SubdomainFields{Subdomain: subdomain}
+			stub, err := common.GetStubForSubdomain(ctx.Request().Context(), resolver.backendRepo, fields)
+			if err != nil {
+				return next(ctx)
+			}
+
+			return routeToHandler(ctx, common.BuildHandlerPath(stub, fields), next)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/beam-cloud/beta9/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type customDomainRepoForTest struct {
+	middlewareRepoForTest
+	domains map[string]*types.CustomDomain
+}
+
+func (r *customDomainRepoForTest) GetCustomDomain(ctx context.Context, domain string) (*types.CustomDomain, error) {
+	return r.domains[domain], nil
+}
+
+func TestCustomDomainResolver(t *testing.T) {
+	backendRepo := &customDomainRepoForTest{
+		domains: map[string]*types.CustomDomain{
+			"api.example.com":     {Domain: "api.example.com", Subdomain: "api-7a7db8c", VerifiedAt: types.NullTime{}.Now()},
+			"pending.example.com": {Domain: "pending.example.com", Subdomain: "pending-7a7db8c"},
+		},
+	}
+
+	tests := []struct {
+		name string
+		host string
+		want string
+	}{
+		{"verified domain", "api.example.com", "api-7a7db8c"},
+		{"verified domain with port", "API.example.com:443", "api-7a7db8c"},
+		{"unverified domain", "pending.example.com", ""},
+		{"unknown domain", "other.example.com", ""},
+		{"gateway subdomain", "task-7a7db8c.app.beam.run", ""},
+		{"ip address", "10.0.0.1", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			redisClient, err := NewRedisClientForTest()
+			require.NoError(t, err)
+
+			resolver := NewCustomDomainResolver("https://app.beam.run", backendRepo, redisClient)
+			assert.Equal(t, tt.want, resolver.Resolve(context.Background(), tt.host))
+
+			// Cached lookups resolve the same way
+			assert.Equal(t, tt.want, resolver.Resolve(context.Background(), tt.host))
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// grpcServiceHandlerPath serves calls from the deployment in the caller's workspace that exposes the called service
+	grpcServiceHandlerPath = "/grpc/service"
+)
+
+// IsGRPCRequest reports whether req is a gRPC call, i.e. an HTTP/2 POST to /<service>/<method>
+func IsGRPCRequest(req *http.Request) bool {
+	if req.ProtoMajor != 2 || req.Method != http.MethodPost {
+		return false
+	}
+
+	if !strings.HasPrefix(req.Header.Get("Content-Type"), "application/grpc") {
+		return false
+	}
+
+	service, method, ok := strings.Cut(strings.TrimPrefix(req.URL.Path, "/"), "/")
+	return ok && service != "" && method != "" && !strings.Contains(method, "/")
+}
+
+// GRPCService is middleware that routes gRPC calls by the service they call. gRPC clients can't put a
+// deployment in the call's path, so calls sent to the gateway's own host are served by whichever deployment
+// in the caller's workspace exposes the service. Calls sent to a deployment's subdomain are routed by the
+// subdomain middleware before they get here.
+func GRPCService() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			if !IsGRPCRequest(ctx.Request()) {
+				return next(ctx)
+			}
+
+			return routeToHandler(ctx, grpcServiceHandlerPath, next)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsGRPCRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		contentType string
+		protoMajor  int
+		want        bool
+	}{
+		{"unary call", http.MethodPost, "/inference.v1.Predictor/Predict", "application/grpc", 2, true},
+		{"proto codec", http.MethodPost, "/inference.v1.Predictor/Predict", "application/grpc+proto", 2, true},
+		{"http1", http.MethodPost, "/inference.v1.Predictor/Predict", "application/grpc", 1, false},
+		{"json", http.MethodPost, "/inference.v1.Predictor/Predict", "application/json", 2, false},
+		{"get", http.MethodGet, "/inference.v1.Predictor/Predict", "application/grpc", 2, false},
+		{"no method", http.MethodPost, "/inference.v1.Predictor", "application/grpc", 2, false},
+		{"nested path", http.MethodPost, "/endpoint/id/stub/Predict", "application/grpc", 2, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("Content-Type", tt.contentType)
+			req.ProtoMajor = tt.protoMajor
+
+			assert.Equal(t, tt.want, IsGRPCRequest(req))
+		})
+	}
+}
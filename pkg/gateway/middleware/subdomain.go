@@ -57,23 +57,29 @@ func Subdomain(externalURL string, backendRepo common.SubdomainBackendRepo, redi
 				}
 			}
 
-			originalPath := ctx.Request().URL.Path
-			hasTrailingSlash := strings.HasSuffix(originalPath, "/") && originalPath != "/"
+			return routeToHandler(ctx, handlerPath, next)
+		}
+	}
+}
 
-			handlerPathFull := path.Join("/", handlerPath, originalPath)
-			if hasTrailingSlash && !strings.HasSuffix(handlerPathFull, "/") {
-				handlerPathFull += "/"
-			}
+// routeToHandler serves the request with the handler registered under handlerPath, keeping the rest of the
+// request's path. Requests that don't match a handler fall through to next.
+func routeToHandler(ctx echo.Context, handlerPath string, next echo.HandlerFunc) error {
+	originalPath := ctx.Request().URL.Path
+	hasTrailingSlash := strings.HasSuffix(originalPath, "/") && originalPath != "/"
 
-			ctx.Echo().Router().Find(ctx.Request().Method, handlerPathFull, ctx)
-			if handler := ctx.Handler(); handler != nil {
-				ctx.Request().URL.Path = handlerPathFull
-				return handler(ctx)
-			}
+	handlerPathFull := path.Join("/", handlerPath, originalPath)
+	if hasTrailingSlash && !strings.HasSuffix(handlerPathFull, "/") {
+		handlerPathFull += "/"
+	}
 
-			return next(ctx)
-		}
+	ctx.Echo().Router().Find(ctx.Request().Method, handlerPathFull, ctx)
+	if handler := ctx.Handler(); handler != nil {
+		ctx.Request().URL.Path = handlerPathFull
+		return handler(ctx)
 	}
+
+	return next(ctx)
 }
 
 func ParseSubdomain(host, baseDomain string) string {
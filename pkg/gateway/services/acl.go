@@ -0,0 +1,179 @@
+package gatewayservices
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+)
+
+func (gws *GatewayService) GetResourceACL(ctx context.Context, in *pb.GetResourceACLRequest) (*pb.GetResourceACLResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionAdmin) {
+		return &pb.GetResourceACLResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	resourceId, err := gws.aclResourceId(ctx, authInfo.Workspace, in.ResourceType, in.ResourceId)
+	if err != nil {
+		return &pb.GetResourceACLResponse{
+			Ok:       false,
+			ErrorMsg: err.Error(),
+		}, nil
+	}
+
+	entries, err := gws.backendRepo.ListAccessControlEntries(ctx, in.ResourceType, resourceId)
+	if err != nil {
+		return &pb.GetResourceACLResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to get access control entries",
+		}, nil
+	}
+
+	return &pb.GetResourceACLResponse{
+		Ok:      true,
+		Entries: accessControlEntriesToProto(entries),
+	}, nil
+}
+
+// SetResourceACL grants a token or role access to an object or volume, or removes its entry. The first entry
+// makes the resource private to the principals its entries name, and to workspace admins.
+func (gws *GatewayService) SetResourceACL(ctx context.Context, in *pb.SetResourceACLRequest) (*pb.SetResourceACLResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionAdmin) {
+		return &pb.SetResourceACLResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	resourceId, err := gws.aclResourceId(ctx, authInfo.Workspace, in.ResourceType, in.ResourceId)
+	if err != nil {
+		return &pb.SetResourceACLResponse{
+			Ok:       false,
+			ErrorMsg: err.Error(),
+		}, nil
+	}
+
+	switch in.PrincipalType {
+	case types.ACLPrincipalToken:
+		if _, err := gws.backendRepo.GetTokenByExternalId(ctx, authInfo.Workspace.Id, in.Principal); err != nil && !in.Remove {
+			return &pb.SetResourceACLResponse{
+				Ok:       false,
+				ErrorMsg: "Token not found",
+			}, nil
+		}
+	case types.ACLPrincipalRole:
+		if !types.WorkspaceRole(in.Principal).IsValid() {
+			return &pb.SetResourceACLResponse{
+				Ok:       false,
+				ErrorMsg: "Invalid role. Allowed roles: admin, developer, read_only, deploy_only",
+			}, nil
+		}
+	default:
+		return &pb.SetResourceACLResponse{
+			Ok:       false,
+			ErrorMsg: "Invalid principal type. Allowed types: token, role",
+		}, nil
+	}
+
+	if in.Remove {
+		err = gws.backendRepo.DeleteAccessControlEntry(ctx, in.ResourceType, resourceId, in.PrincipalType, in.Principal)
+	} else {
+		access := types.ResourceAccess(in.Access)
+		if !access.IsValid() {
+			return &pb.SetResourceACLResponse{
+				Ok:       false,
+				ErrorMsg: "Invalid access. Allowed access: read, write",
+			}, nil
+		}
+
+		err = gws.backendRepo.SetAccessControlEntry(ctx, authInfo.Workspace.Id, in.ResourceType, resourceId, in.PrincipalType, in.Principal, access)
+	}
+	if err != nil {
+		log.Error().Err(err).Str("resource_type", in.ResourceType).Str("resource_id", in.ResourceId).Msg("failed to set access control entry")
+		return &pb.SetResourceACLResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to set access control entry",
+		}, nil
+	}
+
+	entries, err := gws.backendRepo.ListAccessControlEntries(ctx, in.ResourceType, resourceId)
+	if err != nil {
+		return &pb.SetResourceACLResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to get access control entries",
+		}, nil
+	}
+
+	return &pb.SetResourceACLResponse{
+		Ok:      true,
+		Entries: accessControlEntriesToProto(entries),
+	}, nil
+}
+
+// aclResourceId looks up the internal id of an object or volume in the workspace
+func (gws *GatewayService) aclResourceId(ctx context.Context, workspace *types.Workspace, resourceType, externalId string) (uint, error) {
+	switch resourceType {
+	case types.ACLResourceObject:
+		object, err := gws.backendRepo.GetObjectByExternalId(ctx, externalId, workspace.Id)
+		if err != nil {
+			return 0, errors.New("Object not found")
+		}
+
+		return object.Id, nil
+	case types.ACLResourceVolume:
+		volume, err := gws.backendRepo.GetVolumeByExternalId(ctx, workspace.Id, externalId)
+		if err != nil || volume == nil {
+			return 0, errors.New("Volume not found")
+		}
+
+		return volume.Id, nil
+	}
+
+	return 0, errors.New("Invalid resource type. Allowed types: object, volume")
+}
+
+// objectAccessible checks an object's ACL allows the caller the given access
+func (gws *GatewayService) objectAccessible(ctx context.Context, authInfo *auth.AuthInfo, object *types.Object, access types.ResourceAccess) (bool, error) {
+	acl, err := gws.backendRepo.ListAccessControlEntries(ctx, types.ACLResourceObject, object.Id)
+	if err != nil {
+		return false, err
+	}
+
+	return auth.HasResourceAccess(authInfo, acl, access), nil
+}
+
+// objectACLs loads the ACLs of a page of objects in one query, keyed by object id. Objects without an ACL
+// are missing from the result, which auth.HasResourceAccess treats as open to everyone.
+func (gws *GatewayService) objectACLs(ctx context.Context, objects []types.Object) (map[uint][]types.AccessControlEntry, error) {
+	objectIds := make([]uint, 0, len(objects))
+	for _, object := range objects {
+		objectIds = append(objectIds, object.Id)
+	}
+
+	return gws.backendRepo.ListAccessControlEntriesForResources(ctx, types.ACLResourceObject, objectIds)
+}
+
+func accessControlEntriesToProto(entries []types.AccessControlEntry) []*pb.AccessControlEntry {
+	result := make([]*pb.AccessControlEntry, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, &pb.AccessControlEntry{
+			PrincipalType: entry.PrincipalType,
+			Principal:     entry.Principal,
+			Access:        string(entry.Access),
+			CreatedAt:     timestamppb.New(entry.CreatedAt.Time),
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,133 @@
+package gatewayservices
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+)
+
+const (
+	auditLogDefaultLimit  = 100
+	auditLogMaxLimit      = 1000
+	auditLogPruneInterval = time.Hour
+	auditLogPruneLockTtlS = 300
+)
+
+// QueryAuditLog lists the mutating RPCs made in the caller's workspace, newest first
+func (gws *GatewayService) QueryAuditLog(ctx context.Context, in *pb.QueryAuditLogRequest) (*pb.QueryAuditLogResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionAdmin) {
+		return &pb.QueryAuditLogResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	if !gws.appConfig.Monitoring.Audit.Store.Enabled {
+		return &pb.QueryAuditLogResponse{
+			Ok:     false,
+			ErrMsg: "The audit log isn't enabled.",
+		}, nil
+	}
+
+	limit := int(in.Limit)
+	if limit <= 0 {
+		limit = auditLogDefaultLimit
+	}
+	limit = min(limit, auditLogMaxLimit)
+
+	filter := types.AuditLogFilter{
+		WorkspaceId: authInfo.Workspace.Id,
+		TokenId:     in.ActorTokenId,
+		Method:      in.Method,
+		BeforeId:    in.BeforeId,
+		Limit:       limit,
+	}
+	if in.Since != nil {
+		filter.Since = in.Since.AsTime()
+	}
+	if in.Until != nil {
+		filter.Until = in.Until.AsTime()
+	}
+
+	if !filter.Since.IsZero() && !filter.Until.IsZero() && !filter.Since.Before(filter.Until) {
+		return &pb.QueryAuditLogResponse{
+			Ok:     false,
+			ErrMsg: "since must be before until.",
+		}, nil
+	}
+
+	entries, err := gws.backendRepo.ListAuditLogEntries(ctx, filter)
+	if err != nil {
+		log.Error().Err(err).Str("workspace_id", authInfo.Workspace.ExternalId).Msg("failed to list audit log entries")
+		return &pb.QueryAuditLogResponse{
+			Ok:     false,
+			ErrMsg: "Unable to query the audit log.",
+		}, nil
+	}
+
+	resp := &pb.QueryAuditLogResponse{
+		Ok:      true,
+		Entries: make([]*pb.AuditLogEntry, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		resp.Entries = append(resp.Entries, &pb.AuditLogEntry{
+			Id:           entry.Id,
+			ActorTokenId: entry.TokenId,
+			Method:       entry.Method,
+			ResourceIds:  entry.ResourceIds,
+			Outcome:      entry.Outcome,
+			StatusCode:   entry.StatusCode,
+			Error:        entry.Error,
+			CreatedAt:    timestamppb.New(entry.CreatedAt.Time),
+		})
+	}
+
+	if len(entries) == limit {
+		resp.NextBeforeId = entries[len(entries)-1].Id
+	}
+
+	return resp, nil
+}
+
+// pruneAuditLog removes audit log entries once they're older than the retention period
+func (gws *GatewayService) pruneAuditLog() {
+	store := gws.appConfig.Monitoring.Audit.Store
+	if !store.Enabled || store.Retention <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(auditLogPruneInterval)
+	defer ticker.Stop()
+
+	lock := common.NewRedisLock(gws.redisClient)
+	lockKey := common.RedisKeys.GatewayAuditLogPrunerLock()
+
+	for {
+		select {
+		case <-gws.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := lock.Acquire(gws.ctx, lockKey, common.RedisLockOptions{TtlS: auditLogPruneLockTtlS, Retries: 0}); err != nil {
+				continue
+			}
+
+			deleted, err := gws.backendRepo.DeleteAuditLogEntriesBefore(gws.ctx, time.Now().Add(-store.Retention))
+			if err != nil {
+				log.Error().Err(err).Msg("failed to prune audit log")
+			} else if deleted > 0 {
+				log.Info().Int64("deleted", deleted).Msg("pruned audit log")
+			}
+
+			lock.Release(lockKey)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package gatewayservices
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+
+	abstractions "github.com/beam-cloud/beta9/pkg/abstractions/common"
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+)
+
+const maxAutoscalingMetricsPerReport = 32
+
+var autoscalingMetricNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_.:-]{1,64}$`)
+
+func autoscalingPolicyToProto(policy *types.AutoscalingPolicy) *pb.AutoscalingPolicy {
+	if policy == nil {
+		return nil
+	}
+
+	return &pb.AutoscalingPolicy{
+		Metric:      policy.Metric,
+		TargetValue: policy.TargetValue,
+	}
+}
+
+func validateAutoscalingPolicy(policy *pb.AutoscalingPolicy) error {
+	if !autoscalingMetricNameRegex.MatchString(policy.Metric) {
+		return fmt.Errorf("Metric names may only contain letters, digits and _.:- and be at most 64 characters")
+	}
+
+	if policy.TargetValue <= 0 || math.IsInf(policy.TargetValue, 0) || math.IsNaN(policy.TargetValue) {
+		return fmt.Errorf("Target value must be greater than 0")
+	}
+
+	return nil
+}
+
+// SetAutoscalingPolicy scales a deployment on a metric its containers report, alongside its autoscaler
+func (gws *GatewayService) SetAutoscalingPolicy(ctx context.Context, in *pb.SetAutoscalingPolicyRequest) (*pb.SetAutoscalingPolicyResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionDeploy) {
+		return &pb.SetAutoscalingPolicyResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	stub, err := gws.resolvePolicyStub(ctx, authInfo, in.StubId, in.DeploymentId)
+	if err != nil {
+		return &pb.SetAutoscalingPolicyResponse{
+			Ok:     false,
+			ErrMsg: err.Error(),
+		}, nil
+	}
+
+	if !stub.Type.IsDeployment() {
+		return &pb.SetAutoscalingPolicyResponse{
+			Ok:     false,
+			ErrMsg: "Autoscaling policies can only be set on deployments",
+		}, nil
+	}
+
+	if in.Policy == nil {
+		if err := gws.backendRepo.DeleteAutoscalingPolicy(ctx, stub.Id); err != nil {
+			return &pb.SetAutoscalingPolicyResponse{
+				Ok:     false,
+				ErrMsg: "Unable to remove autoscaling policy",
+			}, nil
+		}
+
+		return &pb.SetAutoscalingPolicyResponse{Ok: true}, nil
+	}
+
+	if err := validateAutoscalingPolicy(in.Policy); err != nil {
+		return &pb.SetAutoscalingPolicyResponse{
+			Ok:     false,
+			ErrMsg: err.Error(),
+		}, nil
+	}
+
+	policy, err := gws.backendRepo.SetAutoscalingPolicy(ctx, types.AutoscalingPolicy{
+		StubId:      stub.Id,
+		Metric:      in.Policy.Metric,
+		TargetValue: in.Policy.TargetValue,
+	})
+	if err != nil {
+		return &pb.SetAutoscalingPolicyResponse{
+			Ok:     false,
+			ErrMsg: "Unable to set autoscaling policy",
+		}, nil
+	}
+
+	return &pb.SetAutoscalingPolicyResponse{
+		Ok:     true,
+		Policy: autoscalingPolicyToProto(policy),
+	}, nil
+}
+
+func (gws *GatewayService) GetAutoscalingPolicy(ctx context.Context, in *pb.GetAutoscalingPolicyRequest) (*pb.GetAutoscalingPolicyResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionRead) {
+		return &pb.GetAutoscalingPolicyResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	stub, err := gws.resolvePolicyStub(ctx, authInfo, in.StubId, in.DeploymentId)
+	if err != nil {
+		return &pb.GetAutoscalingPolicyResponse{
+			Ok:     false,
+			ErrMsg: err.Error(),
+		}, nil
+	}
+
+	policy, err := gws.backendRepo.GetAutoscalingPolicy(ctx, stub.ExternalId)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return &pb.GetAutoscalingPolicyResponse{
+			Ok:     false,
+			ErrMsg: "Unable to get autoscaling policy",
+		}, nil
+	}
+
+	return &pb.GetAutoscalingPolicyResponse{
+		Ok:     true,
+		Policy: autoscalingPolicyToProto(policy),
+	}, nil
+}
+
+// ReportAutoscalingMetrics records the latest metric values from one of a stub's containers. Values from
+// every container are summed against the policy's per-container target by the autoscaler.
+func (gws *GatewayService) ReportAutoscalingMetrics(ctx context.Context, in *pb.ReportAutoscalingMetricsRequest) (*pb.ReportAutoscalingMetricsResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if len(in.Metrics) == 0 || len(in.Metrics) > maxAutoscalingMetricsPerReport {
+		return &pb.ReportAutoscalingMetricsResponse{
+			Ok:     false,
+			ErrMsg: fmt.Sprintf("Between 1 and %d metrics may be reported at once", maxAutoscalingMetricsPerReport),
+		}, nil
+	}
+
+	if in.ContainerId == "" {
+		return &pb.ReportAutoscalingMetricsResponse{
+			Ok:     false,
+			ErrMsg: "Container ID is required",
+		}, nil
+	}
+
+	stub, err := gws.backendRepo.GetStubByExternalId(ctx, in.StubId)
+	if err != nil || stub == nil || stub.WorkspaceId != authInfo.Workspace.Id {
+		return &pb.ReportAutoscalingMetricsResponse{
+			Ok:     false,
+			ErrMsg: errPolicyStubNotFound.Error(),
+		}, nil
+	}
+
+	for metric, value := range in.Metrics {
+		if !autoscalingMetricNameRegex.MatchString(metric) || math.IsNaN(value) || math.IsInf(value, 0) {
+			return &pb.ReportAutoscalingMetricsResponse{
+				Ok:     false,
+				ErrMsg: fmt.Sprintf("Invalid metric: %s", metric),
+			}, nil
+		}
+	}
+
+	for metric, value := range in.Metrics {
+		if err := abstractions.RecordAutoscalingMetric(ctx, gws.redisClient, stub.ExternalId, metric, in.ContainerId, value); err != nil {
+			return &pb.ReportAutoscalingMetricsResponse{
+				Ok:     false,
+				ErrMsg: "Unable to record metrics",
+			}, nil
+		}
+	}
+
+	return &pb.ReportAutoscalingMetricsResponse{Ok: true}, nil
+}
@@ -0,0 +1,296 @@
+package gatewayservices
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/repository"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+)
+
+const (
+	auditActionCapacityReservationCreate = "capacity_reservation.create"
+	auditActionCapacityReservationCancel = "capacity_reservation.cancel"
+	auditResourceCapacityReservation     = "capacity_reservation"
+)
+
+// ReserveCapacity holds GPUs of a pool for the caller's workspace during a time window. The scheduler adds
+// workers ahead of the window if the pool doesn't have the GPUs free, and keeps other workspaces off them until
+// the window ends.
+func (gws *GatewayService) ReserveCapacity(ctx context.Context, in *pb.ReserveCapacityRequest) (*pb.ReserveCapacityResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionAdmin) {
+		return &pb.ReserveCapacityResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	reservation, err := gws.parseCapacityReservation(in, time.Now())
+	if err != nil {
+		return &pb.ReserveCapacityResponse{
+			Ok:       false,
+			ErrorMsg: err.Error(),
+		}, nil
+	}
+
+	if err := gws.checkReservationQuota(ctx, authInfo.Workspace.ExternalId, reservation); err != nil {
+		gws.auditCapacityReservation(authInfo, auditActionCapacityReservationCreate, "", reservation, err)
+		return &pb.ReserveCapacityResponse{
+			Ok:       false,
+			ErrorMsg: err.Error(),
+		}, nil
+	}
+
+	created, err := gws.backendRepo.CreateCapacityReservation(ctx, authInfo.Workspace.Id, *reservation)
+
+	reservationId := ""
+	if created != nil {
+		reservationId = created.ExternalId
+	}
+	gws.auditCapacityReservation(authInfo, auditActionCapacityReservationCreate, reservationId, reservation, err)
+
+	if err != nil {
+		return &pb.ReserveCapacityResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to reserve capacity",
+		}, nil
+	}
+
+	gws.reloadCapacityReservations(ctx)
+
+	return &pb.ReserveCapacityResponse{
+		Ok:          true,
+		Reservation: capacityReservationToProto(created),
+	}, nil
+}
+
+func (gws *GatewayService) ListCapacityReservations(ctx context.Context, in *pb.ListCapacityReservationsRequest) (*pb.ListCapacityReservationsResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionRead) {
+		return &pb.ListCapacityReservationsResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	reservations, err := repository.LoadCapacityReservations(ctx, gws.workspaceRepo, gws.backendRepo)
+	if err != nil {
+		return &pb.ListCapacityReservationsResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to list capacity reservations",
+		}, nil
+	}
+
+	response := &pb.ListCapacityReservationsResponse{Ok: true, Reservations: []*pb.CapacityReservation{}}
+	for i := range reservations {
+		if reservations[i].WorkspaceId == authInfo.Workspace.ExternalId {
+			response.Reservations = append(response.Reservations, capacityReservationToProto(&reservations[i]))
+		}
+	}
+
+	return response, nil
+}
+
+// CancelCapacityReservation lets go of a reservation's GPUs, including during its window. Workers added for it
+// are left to spin down once they're idle.
+func (gws *GatewayService) CancelCapacityReservation(ctx context.Context, in *pb.CancelCapacityReservationRequest) (*pb.CancelCapacityReservationResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionAdmin) {
+		return &pb.CancelCapacityReservationResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	err := gws.backendRepo.DeleteCapacityReservation(ctx, authInfo.Workspace.Id, in.ReservationId)
+	gws.auditCapacityReservation(authInfo, auditActionCapacityReservationCancel, in.ReservationId, nil, err)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &pb.CancelCapacityReservationResponse{
+			Ok:       false,
+			ErrorMsg: "Reservation not found",
+		}, nil
+	}
+
+	if err != nil {
+		return &pb.CancelCapacityReservationResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to cancel reservation",
+		}, nil
+	}
+
+	gws.reloadCapacityReservations(ctx)
+
+	return &pb.CancelCapacityReservationResponse{
+		Ok: true,
+	}, nil
+}
+
+// parseCapacityReservation validates a new reservation and picks the pool it holds GPUs in. Pools partitioned
+// into MIG slices can't hand out whole GPUs, so they're only used when named.
+func (gws *GatewayService) parseCapacityReservation(in *pb.ReserveCapacityRequest, now time.Time) (*types.CapacityReservation, error) {
+	config := gws.appConfig.Worker.CapacityReservations
+
+	gpuType := types.GpuType(in.GpuType)
+	if gpuType == "" || gpuType == types.GPU_ANY {
+		return nil, errors.New("gpu_type is required")
+	}
+
+	if in.GpuCount == 0 {
+		return nil, errors.New("gpu_count must be at least 1")
+	}
+
+	if config.MaxGpus > 0 && in.GpuCount > config.MaxGpus {
+		return nil, fmt.Errorf("a reservation can hold at most %d GPUs", config.MaxGpus)
+	}
+
+	startsAt := now
+	if in.StartsAt != nil && in.StartsAt.AsTime().After(now) {
+		startsAt = in.StartsAt.AsTime()
+	}
+
+	if in.EndsAt == nil || !in.EndsAt.AsTime().After(startsAt) {
+		return nil, errors.New("ends_at must be after the start of the reservation")
+	}
+	endsAt := in.EndsAt.AsTime()
+
+	if config.MaxDuration > 0 && endsAt.Sub(startsAt) > config.MaxDuration {
+		return nil, fmt.Errorf("a reservation can last at most %s", config.MaxDuration)
+	}
+
+	poolName := in.PoolName
+	if poolName != "" {
+		pool, ok := gws.appConfig.Worker.Pools[poolName]
+		if !ok {
+			return nil, fmt.Errorf("pool %s not found", poolName)
+		}
+
+		if types.GpuType(pool.GPUType) != gpuType {
+			return nil, fmt.Errorf("pool %s doesn't have %s GPUs", poolName, gpuType)
+		}
+	} else {
+		names := []string{}
+		for name, pool := range gws.appConfig.Worker.Pools {
+			if types.GpuType(pool.GPUType) == gpuType && len(pool.MigSlices) == 0 {
+				names = append(names, name)
+			}
+		}
+
+		if len(names) == 0 {
+			return nil, fmt.Errorf("no pool has %s GPUs", gpuType)
+		}
+
+		sort.Strings(names)
+		poolName = names[0]
+	}
+
+	return &types.CapacityReservation{
+		PoolName: poolName,
+		GpuType:  gpuType,
+		GpuCount: in.GpuCount,
+		StartsAt: types.Time{Time: startsAt},
+		EndsAt:   types.Time{Time: endsAt},
+	}, nil
+}
+
+// checkReservationQuota keeps a workspace's reservations in a pool within its GPU quota for the pool. All of its
+// reservations that overlap the new one count, whether or not they overlap each other.
+func (gws *GatewayService) checkReservationQuota(ctx context.Context, workspaceId string, reservation *types.CapacityReservation) error {
+	quotas, err := repository.LoadPoolQuotas(ctx, gws.workspaceRepo, gws.backendRepo)
+	if err != nil {
+		return errors.New("Unable to load pool quotas")
+	}
+
+	var maxGpus uint32
+	for _, quota := range quotas {
+		if quota.WorkspaceId == workspaceId && quota.PoolName == reservation.PoolName {
+			maxGpus = quota.MaxGpus
+		}
+	}
+
+	if maxGpus == 0 {
+		return nil
+	}
+
+	reservations, err := repository.LoadCapacityReservations(ctx, gws.workspaceRepo, gws.backendRepo)
+	if err != nil {
+		return errors.New("Unable to load capacity reservations")
+	}
+
+	gpus := reservation.GpuCount
+	for _, r := range reservations {
+		if r.WorkspaceId == workspaceId && r.PoolName == reservation.PoolName &&
+			r.StartsAt.Before(reservation.EndsAt.Time) && reservation.StartsAt.Before(r.EndsAt.Time) {
+			gpus += r.GpuCount
+		}
+	}
+
+	if gpus > maxGpus {
+		return fmt.Errorf("reservations would hold %d GPUs of pool %s, over the workspace's quota of %d", gpus, reservation.PoolName, maxGpus)
+	}
+
+	return nil
+}
+
+// reloadCapacityReservations refreshes the cached reservations, which every workspace's scheduling decisions
+// depend on
+func (gws *GatewayService) reloadCapacityReservations(ctx context.Context) {
+	reservations, err := gws.backendRepo.ListCapacityReservations(ctx)
+	if err != nil {
+		return
+	}
+
+	if err := gws.workspaceRepo.SetCapacityReservations(reservations); err != nil {
+		log.Warn().Err(err).Msg("failed to cache capacity reservations")
+	}
+}
+
+func (gws *GatewayService) auditCapacityReservation(authInfo *auth.AuthInfo, action, reservationId string, reservation *types.CapacityReservation, err error) {
+	event := common.AuditEvent{
+		Action:       action,
+		WorkspaceId:  authInfo.Workspace.ExternalId,
+		ResourceType: auditResourceCapacityReservation,
+		ResourceId:   reservationId,
+		Outcome:      auditOutcome(err),
+		Reason:       errorMessage(err),
+	}
+	if reservation != nil {
+		event.Attributes = map[string]interface{}{
+			"pool_name": reservation.PoolName,
+			"gpu_type":  string(reservation.GpuType),
+			"gpu_count": reservation.GpuCount,
+			"starts_at": reservation.StartsAt.Serialize(),
+			"ends_at":   reservation.EndsAt.Serialize(),
+		}
+	}
+	if authInfo.Token != nil {
+		event.Principal = authInfo.Token.ExternalId
+	}
+	gws.auditLogger.Log(event)
+}
+
+func capacityReservationToProto(reservation *types.CapacityReservation) *pb.CapacityReservation {
+	return &pb.CapacityReservation{
+		ReservationId: reservation.ExternalId,
+		WorkspaceId:   reservation.WorkspaceId,
+		PoolName:      reservation.PoolName,
+		GpuType:       string(reservation.GpuType),
+		GpuCount:      reservation.GpuCount,
+		StartsAt:      timestamppb.New(reservation.StartsAt.Time),
+		EndsAt:        timestamppb.New(reservation.EndsAt.Time),
+		CreatedAt:     timestamppb.New(reservation.CreatedAt.Time),
+	}
+}
@@ -47,15 +47,17 @@ func (gws GatewayService) ListContainers(ctx context.Context, in *pb.ListContain
 		}
 
 		containers = append(containers, &pb.Container{
-			ContainerId:  state.ContainerId,
-			StubId:       state.StubId,
-			WorkspaceId:  state.WorkspaceId,
-			Status:       string(state.Status),
-			ScheduledAt:  timestamppb.New(time.Unix(state.ScheduledAt, 0)),
-			StartedAt:    timestamppb.New(time.Unix(state.StartedAt, 0)),
-			WorkerId:     containerWorkerMap[state.ContainerId].WorkerId,
-			MachineId:    containerWorkerMap[state.ContainerId].MachineId,
-			DeploymentId: deploymentId,
+			ContainerId:     state.ContainerId,
+			StubId:          state.StubId,
+			WorkspaceId:     state.WorkspaceId,
+			Status:          string(state.Status),
+			ScheduledAt:     timestamppb.New(time.Unix(state.ScheduledAt, 0)),
+			StartedAt:       timestamppb.New(time.Unix(state.StartedAt, 0)),
+			WorkerId:        containerWorkerMap[state.ContainerId].WorkerId,
+			MachineId:       containerWorkerMap[state.ContainerId].MachineId,
+			DeploymentId:    deploymentId,
+			GpuInterconnect: state.GpuInterconnect,
+			NumaNodes:       state.NumaNodes,
 		})
 	}
 
@@ -99,7 +101,7 @@ func (gws GatewayService) CheckpointContainer(ctx context.Context, in *pb.Checkp
 	authInfo, _ := auth.AuthInfoFromContext(ctx)
 	workspaceId := authInfo.Workspace.ExternalId
 
-	if !auth.HasPermission(authInfo) {
+	if !auth.HasPermission(authInfo, types.PermissionWrite) {
 		return &pb.CheckpointContainerResponse{
 			Ok:       false,
 			ErrorMsg: "Unauthorized Access",
@@ -356,3 +358,167 @@ func (gws *GatewayService) AttachToContainer(stream pb.GatewayService_AttachToCo
 		return err
 	}
 }
+
+// ExecInContainer runs a command inside one of the workspace's running containers, relaying stdin to it
+// and its stdout and stderr back until it exits
+func (gws *GatewayService) ExecInContainer(stream pb.GatewayService_ExecInContainerServer) error {
+	ctx := stream.Context()
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	initMsg, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	if !auth.HasPermission(authInfo, types.PermissionWrite) {
+		return stream.Send(&pb.ExecInContainerResponse{Done: true, ExitCode: -1, ErrorMsg: "Unauthorized Access"})
+	}
+
+	start := initMsg.GetStart()
+	if start == nil || len(start.Command) == 0 {
+		return stream.Send(&pb.ExecInContainerResponse{Done: true, ExitCode: -1, ErrorMsg: "The first message must start a command"})
+	}
+
+	client, container, err := gws.getClient(ctx, start.ContainerId, authInfo.Token.Key, authInfo.Workspace.ExternalId)
+	if err != nil {
+		return stream.Send(&pb.ExecInContainerResponse{Done: true, ExitCode: -1, ErrorMsg: "Container not found"})
+	}
+
+	if container.Status != types.ContainerStatusRunning {
+		return stream.Send(&pb.ExecInContainerResponse{Done: true, ExitCode: -1, ErrorMsg: "Container is not running"})
+	}
+
+	ctx, cancel := common.MergeContexts(gws.ctx, ctx)
+	defer cancel()
+
+	execStream, err := client.ExecStream(ctx)
+	if err != nil {
+		return stream.Send(&pb.ExecInContainerResponse{Done: true, ExitCode: -1, ErrorMsg: "Unable to connect to container"})
+	}
+
+	if err := execStream.Send(initMsg); err != nil {
+		return stream.Send(&pb.ExecInContainerResponse{Done: true, ExitCode: -1, ErrorMsg: "Unable to start command"})
+	}
+
+	// Relay stdin from the client until it closes its side of the stream
+	go func() {
+		for {
+			inMsg, err := stream.Recv()
+			if err != nil {
+				execStream.CloseSend()
+				return
+			}
+
+			if inMsg.GetStart() != nil {
+				continue
+			}
+
+			if err := execStream.Send(inMsg); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		resp, err := execStream.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			return stream.Send(&pb.ExecInContainerResponse{Done: true, ExitCode: -1, ErrorMsg: "Lost connection to container"})
+		}
+
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+
+		if resp.Done {
+			return nil
+		}
+	}
+}
+
+// PortForward tunnels one TCP connection from the client to a port inside one of the workspace's running
+// containers. Clients open a stream for each local connection they accept.
+func (gws *GatewayService) PortForward(stream pb.GatewayService_PortForwardServer) error {
+	ctx := stream.Context()
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	initMsg, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	if !auth.HasPermission(authInfo, types.PermissionWrite) {
+		return stream.Send(&pb.PortForwardResponse{Done: true, ErrorMsg: "Unauthorized Access"})
+	}
+
+	start := initMsg.GetStart()
+	if start == nil {
+		return stream.Send(&pb.PortForwardResponse{Done: true, ErrorMsg: "The first message must start the connection"})
+	}
+
+	if start.Port == 0 || start.Port > 65535 {
+		return stream.Send(&pb.PortForwardResponse{Done: true, ErrorMsg: "Port must be between 1 and 65535"})
+	}
+
+	client, container, err := gws.getClient(ctx, start.ContainerId, authInfo.Token.Key, authInfo.Workspace.ExternalId)
+	if err != nil {
+		return stream.Send(&pb.PortForwardResponse{Done: true, ErrorMsg: "Container not found"})
+	}
+
+	if container.Status != types.ContainerStatusRunning {
+		return stream.Send(&pb.PortForwardResponse{Done: true, ErrorMsg: "Container is not running"})
+	}
+
+	ctx, cancel := common.MergeContexts(gws.ctx, ctx)
+	defer cancel()
+
+	forwardStream, err := client.PortForward(ctx)
+	if err != nil {
+		return stream.Send(&pb.PortForwardResponse{Done: true, ErrorMsg: "Unable to connect to container"})
+	}
+
+	if err := forwardStream.Send(initMsg); err != nil {
+		return stream.Send(&pb.PortForwardResponse{Done: true, ErrorMsg: "Unable to connect to container"})
+	}
+
+	// Relay data from the client until it closes its side of the connection
+	go func() {
+		for {
+			inMsg, err := stream.Recv()
+			if err != nil {
+				forwardStream.CloseSend()
+				return
+			}
+
+			if inMsg.GetStart() != nil {
+				continue
+			}
+
+			if err := forwardStream.Send(inMsg); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		resp, err := forwardStream.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			return stream.Send(&pb.PortForwardResponse{Done: true, ErrorMsg: "Lost connection to container"})
+		}
+
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+
+		if resp.Done {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,265 @@
+package gatewayservices
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/lib/pq"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/common"
+	gatewaymiddleware "github.com/beam-cloud/beta9/pkg/gateway/middleware"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+)
+
+const customDomainVerificationTimeout = 5 * time.Second
+
+// lookupTXT resolves the TXT records proving a workspace controls a custom domain
+var lookupTXT = net.DefaultResolver.LookupTXT
+
+func (gws *GatewayService) AddCustomDomain(ctx context.Context, in *pb.AddCustomDomainRequest) (*pb.AddCustomDomainResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionDeploy) {
+		return &pb.AddCustomDomainResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	config := gws.appConfig.GatewayService.CustomDomains
+	if !config.Enabled {
+		return &pb.AddCustomDomainResponse{
+			Ok:     false,
+			ErrMsg: "Custom domains are not enabled",
+		}, nil
+	}
+
+	baseDomain := gatewaymiddleware.ParseHostFromURL(gws.appConfig.GatewayService.HTTP.GetExternalURL())
+	domain, err := types.NormalizeCustomDomain(in.Domain, baseDomain)
+	if err != nil {
+		return &pb.AddCustomDomainResponse{
+			Ok:     false,
+			ErrMsg: err.Error(),
+		}, nil
+	}
+
+	deployment, err := gws.backendRepo.GetDeploymentByExternalId(ctx, authInfo.Workspace.Id, in.DeploymentId)
+	if err != nil {
+		return &pb.AddCustomDomainResponse{
+			Ok:     false,
+			ErrMsg: "Unable to get deployment",
+		}, nil
+	}
+
+	if deployment == nil {
+		return &pb.AddCustomDomainResponse{
+			Ok:     false,
+			ErrMsg: "Deployment not found",
+		}, nil
+	}
+
+	kind := types.StubType(deployment.StubType).Kind()
+	if kind != types.StubTypeEndpoint && kind != types.StubTypeASGI && kind != types.StubTypeGRPC {
+		return &pb.AddCustomDomainResponse{
+			Ok:     false,
+			ErrMsg: "Custom domains can only be attached to endpoints",
+		}, nil
+	}
+
+	if config.MaxPerWorkspace > 0 {
+		existing, err := gws.backendRepo.ListCustomDomains(ctx, authInfo.Workspace.Id, 0)
+		if err != nil {
+			return &pb.AddCustomDomainResponse{
+				Ok:     false,
+				ErrMsg: "Unable to add custom domain",
+			}, nil
+		}
+
+		if len(existing) >= config.MaxPerWorkspace {
+			return &pb.AddCustomDomainResponse{
+				Ok:     false,
+				ErrMsg: fmt.Sprintf("Workspaces can have at most %d custom domains", config.MaxPerWorkspace),
+			}, nil
+		}
+	}
+
+	customDomain, err := gws.backendRepo.CreateCustomDomain(ctx, authInfo.Workspace.Id, deployment.Id, domain)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			return &pb.AddCustomDomainResponse{
+				Ok:     false,
+				ErrMsg: fmt.Sprintf("%s is already attached to a deployment", domain),
+			}, nil
+		}
+
+		return &pb.AddCustomDomainResponse{
+			Ok:     false,
+			ErrMsg: "Unable to add custom domain",
+		}, nil
+	}
+
+	// The TXT record may already be in place, otherwise the domain stays pending until it's listed again
+	if err := gws.verifyCustomDomain(ctx, customDomain); err != nil {
+		customDomain.LastError = err.Error()
+	}
+
+	return &pb.AddCustomDomainResponse{
+		Ok:     true,
+		Domain: gws.customDomainToProto(customDomain),
+	}, nil
+}
+
+func (gws *GatewayService) ListCustomDomains(ctx context.Context, in *pb.ListCustomDomainsRequest) (*pb.ListCustomDomainsResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	var deploymentId uint
+	if in.DeploymentId != "" {
+		deployment, err := gws.backendRepo.GetDeploymentByExternalId(ctx, authInfo.Workspace.Id, in.DeploymentId)
+		if err != nil {
+			return &pb.ListCustomDomainsResponse{
+				Ok:     false,
+				ErrMsg: "Unable to get deployment",
+			}, nil
+		}
+
+		if deployment == nil {
+			return &pb.ListCustomDomainsResponse{
+				Ok:     false,
+				ErrMsg: "Deployment not found",
+			}, nil
+		}
+
+		deploymentId = deployment.Id
+	}
+
+	customDomains, err := gws.backendRepo.ListCustomDomains(ctx, authInfo.Workspace.Id, deploymentId)
+	if err != nil {
+		return &pb.ListCustomDomainsResponse{
+			Ok:     false,
+			ErrMsg: "Unable to list custom domains",
+		}, nil
+	}
+
+	domains := make([]*pb.CustomDomain, 0, len(customDomains))
+	for i := range customDomains {
+		if err := gws.verifyCustomDomain(ctx, &customDomains[i]); err != nil {
+			customDomains[i].LastError = err.Error()
+		}
+
+		domains = append(domains, gws.customDomainToProto(&customDomains[i]))
+	}
+
+	return &pb.ListCustomDomainsResponse{
+		Ok:      true,
+		Domains: domains,
+	}, nil
+}
+
+func (gws *GatewayService) RemoveCustomDomain(ctx context.Context, in *pb.RemoveCustomDomainRequest) (*pb.RemoveCustomDomainResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionDeploy) {
+		return &pb.RemoveCustomDomainResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	customDomain, err := gws.backendRepo.GetCustomDomainByExternalId(ctx, authInfo.Workspace.Id, in.Id)
+	if err != nil {
+		return &pb.RemoveCustomDomainResponse{
+			Ok:     false,
+			ErrMsg: "Unable to get custom domain",
+		}, nil
+	}
+
+	if customDomain == nil {
+		return &pb.RemoveCustomDomainResponse{
+			Ok:     false,
+			ErrMsg: "Custom domain not found",
+		}, nil
+	}
+
+	if err := gws.backendRepo.DeleteCustomDomain(ctx, customDomain.Id); err != nil {
+		return &pb.RemoveCustomDomainResponse{
+			Ok:     false,
+			ErrMsg: "Unable to remove custom domain",
+		}, nil
+	}
+
+	// Stop routing the domain and forget its certificates, so they aren't served or renewed anymore. An
+	// unverified claim never routed anything, and the certificates may belong to the workspace that verified it.
+	if customDomain.VerifiedAt.Valid {
+		gws.redisClient.Del(ctx,
+			common.RedisKeys.GatewayCustomDomain(customDomain.Domain),
+			common.RedisKeys.GatewayACMECache(customDomain.Domain),
+			common.RedisKeys.GatewayACMECache(customDomain.Domain+"+rsa"),
+		)
+	}
+
+	return &pb.RemoveCustomDomainResponse{
+		Ok: true,
+	}, nil
+}
+
+// verifyCustomDomain checks whether the domain's TXT record holds its verification token, and starts routing
+// the domain once it does. Domains that are already verified aren't checked again.
+func (gws *GatewayService) verifyCustomDomain(ctx context.Context, customDomain *types.CustomDomain) error {
+	if customDomain.VerifiedAt.Valid {
+		return nil
+	}
+
+	lookupCtx, cancel := context.WithTimeout(ctx, customDomainVerificationTimeout)
+	defer cancel()
+
+	records, _ := lookupTXT(lookupCtx, customDomain.VerificationRecord())
+	if !customDomain.MatchesVerification(records) {
+		return fmt.Errorf("waiting for a TXT record at %s holding the verification token", customDomain.VerificationRecord())
+	}
+
+	if err := gws.backendRepo.VerifyCustomDomain(ctx, customDomain.Id); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			return fmt.Errorf("%s is already attached to a deployment", customDomain.Domain)
+		}
+
+		return fmt.Errorf("unable to verify %s", customDomain.Domain)
+	}
+
+	customDomain.VerifiedAt = types.NullTime{}.Now()
+
+	// Drop any cached miss so the domain routes right away
+	gws.redisClient.Del(ctx, common.RedisKeys.GatewayCustomDomain(customDomain.Domain))
+
+	return nil
+}
+
+func (gws *GatewayService) customDomainToProto(customDomain *types.CustomDomain) *pb.CustomDomain {
+	cnameTarget := gws.appConfig.GatewayService.CustomDomains.CNAMETarget
+	if cnameTarget == "" {
+		cnameTarget = gws.appConfig.GatewayService.HTTP.ExternalHost
+	}
+
+	domain := &pb.CustomDomain{
+		Id:           customDomain.ExternalId,
+		Domain:       customDomain.Domain,
+		DeploymentId: customDomain.DeploymentExternalId,
+		Status:       string(customDomain.Status),
+		LastError:    customDomain.LastError,
+		CnameTarget:  cnameTarget,
+		CreatedAt:    timestamppb.New(customDomain.CreatedAt.Time),
+
+		VerificationRecord: customDomain.VerificationRecord(),
+		VerificationToken:  customDomain.VerificationToken,
+	}
+
+	if customDomain.CertificateExpiresAt.Valid {
+		domain.CertificateExpiresAt = timestamppb.New(customDomain.CertificateExpiresAt.Time)
+	}
+
+	return domain
+}
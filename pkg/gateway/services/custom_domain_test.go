@@ -0,0 +1,83 @@
+package gatewayservices
+
+import (
+	"context"
+	"testing"
+
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/repository"
+	"github.com/beam-cloud/beta9/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type customDomainVerificationRepo struct {
+	repository.BackendRepository
+	verified []uint
+}
+
+func (r *customDomainVerificationRepo) VerifyCustomDomain(ctx context.Context, id uint) error {
+	r.verified = append(r.verified, id)
+	return nil
+}
+
+func TestVerifyCustomDomain(t *testing.T) {
+	defaultLookupTXT := lookupTXT
+	t.Cleanup(func() { lookupTXT = defaultLookupTXT })
+
+	tests := []struct {
+		name         string
+		verified     bool
+		records      []string
+		wantErr      bool
+		wantVerified []uint
+	}{
+		{"matching record", false, []string{"token-1"}, false, []uint{1}},
+		{"another workspace's token", false, []string{"token-2"}, true, nil},
+		{"no record yet", false, nil, true, nil},
+		{"already verified", true, nil, false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			redisClient, err := repository.NewRedisClientForTest()
+			require.NoError(t, err)
+
+			lookedUp := []string{}
+			lookupTXT = func(ctx context.Context, name string) ([]string, error) {
+				lookedUp = append(lookedUp, name)
+				return tt.records, nil
+			}
+
+			backendRepo := &customDomainVerificationRepo{}
+			gws := &GatewayService{backendRepo: backendRepo, redisClient: redisClient}
+
+			customDomain := &types.CustomDomain{Id: 1, Domain: "api.example.com", VerificationToken: "token-1"}
+			if tt.verified {
+				customDomain.VerifiedAt = types.NullTime{}.Now()
+			}
+
+			// A miss cached while the domain was unverified
+			require.NoError(t, redisClient.Set(context.Background(), common.RedisKeys.GatewayCustomDomain(customDomain.Domain), "-", 0).Err())
+
+			err = gws.verifyCustomDomain(context.Background(), customDomain)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wantVerified, backendRepo.verified)
+			assert.Equal(t, !tt.wantErr, customDomain.VerifiedAt.Valid)
+			if tt.verified {
+				assert.Empty(t, lookedUp)
+			} else {
+				assert.Equal(t, []string{"_beta9-verification.api.example.com"}, lookedUp)
+			}
+
+			exists, err := redisClient.Exists(context.Background(), common.RedisKeys.GatewayCustomDomain(customDomain.Domain)).Result()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantVerified == nil, exists == 1)
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	abstractions "github.com/beam-cloud/beta9/pkg/abstractions/common"
 	"github.com/beam-cloud/beta9/pkg/auth"
 	common "github.com/beam-cloud/beta9/pkg/common"
 	"github.com/beam-cloud/beta9/pkg/types"
@@ -21,6 +22,9 @@ func (gws *GatewayService) ListDeployments(ctx context.Context, in *pb.ListDeplo
 
 	filter := types.DeploymentFilter{
 		WorkspaceID: authInfo.Workspace.Id,
+		Cursor:      in.Cursor,
+		SortBy:      in.SortBy,
+		SortOrder:   in.SortOrder,
 	}
 
 	limit := uint32(1000)
@@ -30,9 +34,15 @@ func (gws *GatewayService) ListDeployments(ctx context.Context, in *pb.ListDeplo
 	filter.Limit = limit
 
 	for field, value := range in.Filters {
+		if len(value.Values) == 0 {
+			continue
+		}
+
 		switch field {
 		case "name":
 			filter.Name = value.Values[0]
+		case "name_prefix":
+			filter.NamePrefix = value.Values[0]
 		case "active":
 			v := strings.ToLower(value.Values[0])
 
@@ -43,15 +53,50 @@ func (gws *GatewayService) ListDeployments(ctx context.Context, in *pb.ListDeplo
 			} else if val, err := strconv.ParseBool(v); err == nil {
 				filter.Active = ptr.To(val)
 			}
+		case "status":
+			switch strings.ToLower(value.Values[0]) {
+			case "active":
+				filter.Active = ptr.To(true)
+			case "stopped":
+				filter.Active = ptr.To(false)
+			default:
+				return &pb.ListDeploymentsResponse{
+					Ok:     false,
+					ErrMsg: fmt.Sprintf("Invalid status: %s", value.Values[0]),
+				}, nil
+			}
 		case "version":
 			val, err := strconv.ParseUint(value.Values[0], 10, 32)
 			if err == nil {
 				filter.Version = uint(val)
 			}
+		case "stub_type":
+			filter.StubType = value.Values
+		case "created_after", "created_before":
+			t, err := time.Parse(time.RFC3339, value.Values[0])
+			if err != nil {
+				return &pb.ListDeploymentsResponse{
+					Ok:     false,
+					ErrMsg: fmt.Sprintf("Invalid %s timestamp: %s", field, value.Values[0]),
+				}, nil
+			}
+
+			if field == "created_after" {
+				filter.CreatedAtStart = t.Format(time.RFC3339Nano)
+			} else {
+				filter.CreatedAtEnd = t.Format(time.RFC3339Nano)
+			}
 		}
 	}
 
-	deploymentsWithRelated, err := gws.backendRepo.ListDeploymentsWithRelated(ctx, filter)
+	if _, _, err := filter.Sort(); err != nil {
+		return &pb.ListDeploymentsResponse{
+			Ok:     false,
+			ErrMsg: err.Error(),
+		}, nil
+	}
+
+	page, err := gws.backendRepo.ListDeploymentsPaginated(ctx, filter)
 	if err != nil {
 		return &pb.ListDeploymentsResponse{
 			Ok:     false,
@@ -59,8 +104,8 @@ func (gws *GatewayService) ListDeployments(ctx context.Context, in *pb.ListDeplo
 		}, nil
 	}
 
-	deployments := make([]*pb.Deployment, len(deploymentsWithRelated))
-	for i, deployment := range deploymentsWithRelated {
+	deployments := make([]*pb.Deployment, len(page.Data))
+	for i, deployment := range page.Data {
 		deployments[i] = &pb.Deployment{
 			Id:            deployment.ExternalId,
 			Name:          deployment.Name,
@@ -76,16 +121,81 @@ func (gws *GatewayService) ListDeployments(ctx context.Context, in *pb.ListDeplo
 		}
 	}
 
+	if in.IncludeStats {
+		if err := gws.addDeploymentStats(ctx, authInfo.Workspace, page.Data, deployments); err != nil {
+			return &pb.ListDeploymentsResponse{
+				Ok:     false,
+				ErrMsg: "Unable to get deployment stats",
+			}, nil
+		}
+	}
+
 	return &pb.ListDeploymentsResponse{
 		Ok:          true,
 		Deployments: deployments,
+		NextCursor:  page.Next,
 	}, nil
 }
 
+// addDeploymentStats fills in the stats of a page of deployments with one task query and one container lookup,
+// rather than a round trip per deployment
+func (gws *GatewayService) addDeploymentStats(ctx context.Context, workspace *types.Workspace, deployments []types.DeploymentWithRelated, pbDeployments []*pb.Deployment) error {
+	stubIds := make([]uint, len(deployments))
+	for i, deployment := range deployments {
+		stubIds[i] = deployment.Deployment.StubId
+	}
+
+	stats, err := gws.backendRepo.GetDeploymentStats(ctx, stubIds)
+	if err != nil {
+		return err
+	}
+
+	containers, err := gws.containerRepo.GetActiveContainersByWorkspaceId(workspace.ExternalId)
+	if err != nil {
+		return err
+	}
+
+	activeContainers := map[string]int{}
+	healthyContainers := map[string]int{}
+	unhealthyContainers := map[string]int{}
+	for _, container := range containers {
+		activeContainers[container.StubId]++
+
+		// Only containers of stubs with a health check have their health recorded
+		health, err := gws.containerRepo.GetContainerHealth(container.ContainerId)
+		if err != nil || health == nil {
+			continue
+		}
+
+		switch health.Status {
+		case types.ContainerHealthStatusHealthy:
+			healthyContainers[container.StubId]++
+		case types.ContainerHealthStatusUnhealthy:
+			unhealthyContainers[container.StubId]++
+		}
+	}
+
+	for i, deployment := range deployments {
+		stubStats := stats[deployment.Deployment.StubId]
+
+		pbDeployments[i].Stats = &pb.DeploymentStats{
+			ActiveContainers:    uint32(activeContainers[deployment.Stub.ExternalId]),
+			PendingTasks:        uint32(stubStats.PendingTasks),
+			HealthyContainers:   uint32(healthyContainers[deployment.Stub.ExternalId]),
+			UnhealthyContainers: uint32(unhealthyContainers[deployment.Stub.ExternalId]),
+		}
+		if stubStats.LastInvokedAt.Valid {
+			pbDeployments[i].Stats.LastInvokedAt = timestamppb.New(stubStats.LastInvokedAt.Time)
+		}
+	}
+
+	return nil
+}
+
 func (gws *GatewayService) StopDeployment(ctx context.Context, in *pb.StopDeploymentRequest) (*pb.StopDeploymentResponse, error) {
 	authInfo, _ := auth.AuthInfoFromContext(ctx)
 
-	if !auth.HasPermission(authInfo) {
+	if !auth.HasPermission(authInfo, types.PermissionDeploy) {
 		return &pb.StopDeploymentResponse{
 			Ok:     false,
 			ErrMsg: "Unauthorized Access",
@@ -155,6 +265,12 @@ func (gws *GatewayService) ScaleDeployment(ctx context.Context, in *pb.ScaleDepl
 		}, nil
 	}
 
+	containers := uint(in.Containers)
+	abstractions.NotifyDeploymentEvent(ctx, gws.appConfig, gws.backendRepo, authInfo.Workspace.ExternalId, &deploymentWithRelated.Deployment, deploymentWithRelated.Stub.ExternalId, abstractions.DeploymentWebhookPayload{
+		Event:      types.DeploymentWebhookEventScaled,
+		Containers: &containers,
+	})
+
 	return &pb.ScaleDeploymentResponse{
 		Ok: true,
 	}, nil
@@ -163,7 +279,7 @@ func (gws *GatewayService) ScaleDeployment(ctx context.Context, in *pb.ScaleDepl
 func (gws *GatewayService) StartDeployment(ctx context.Context, in *pb.StartDeploymentRequest) (*pb.StartDeploymentResponse, error) {
 	authInfo, _ := auth.AuthInfoFromContext(ctx)
 
-	if !auth.HasPermission(authInfo) {
+	if !auth.HasPermission(authInfo, types.PermissionDeploy) {
 		return &pb.StartDeploymentResponse{
 			Ok:     false,
 			ErrMsg: "Unauthorized Access",
@@ -212,7 +328,7 @@ func (gws *GatewayService) StartDeployment(ctx context.Context, in *pb.StartDepl
 func (gws *GatewayService) DeleteDeployment(ctx context.Context, in *pb.DeleteDeploymentRequest) (*pb.DeleteDeploymentResponse, error) {
 	authInfo, _ := auth.AuthInfoFromContext(ctx)
 
-	if !auth.HasPermission(authInfo) {
+	if !auth.HasPermission(authInfo, types.PermissionDeploy) {
 		return &pb.DeleteDeploymentResponse{
 			Ok:     false,
 			ErrMsg: "Unauthorized Access",
@@ -256,6 +372,137 @@ func (gws *GatewayService) DeleteDeployment(ctx context.Context, in *pb.DeleteDe
 	}, nil
 }
 
+func (gws *GatewayService) RollbackDeployment(ctx context.Context, in *pb.RollbackDeploymentRequest) (*pb.RollbackDeploymentResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionDeploy) {
+		return &pb.RollbackDeploymentResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	deploymentWithRelated, err := gws.backendRepo.GetDeploymentByExternalId(ctx, authInfo.Workspace.Id, in.Id)
+	if err != nil || deploymentWithRelated == nil {
+		return &pb.RollbackDeploymentResponse{
+			Ok:     false,
+			ErrMsg: "Deployment not found",
+		}, nil
+	}
+
+	// Scheduled jobs are registered against a single deployment when deployed, so a new version
+	// pointing at an old stub wouldn't be scheduled
+	if deploymentWithRelated.StubType == types.StubTypeScheduledJobDeployment {
+		return &pb.RollbackDeploymentResponse{
+			Ok:     false,
+			ErrMsg: "Scheduled job deployments can't be rolled back, redeploy the previous version instead",
+		}, nil
+	}
+
+	latest, err := gws.backendRepo.GetLatestDeploymentByName(ctx, authInfo.Workspace.Id, deploymentWithRelated.Name, deploymentWithRelated.StubType, true)
+	if err != nil || latest == nil {
+		return &pb.RollbackDeploymentResponse{
+			Ok:     false,
+			ErrMsg: "Unable to get latest deployment",
+		}, nil
+	}
+
+	targetVersion := uint(in.Version)
+	if targetVersion == 0 {
+		targetVersion = latest.Version - 1
+	}
+
+	if targetVersion == 0 || targetVersion >= latest.Version {
+		return &pb.RollbackDeploymentResponse{
+			Ok:     false,
+			ErrMsg: "Version must be earlier than the latest version",
+		}, nil
+	}
+
+	target, err := gws.backendRepo.GetDeploymentByNameAndVersion(ctx, authInfo.Workspace.Id, latest.Name, targetVersion, latest.StubType)
+	if err != nil {
+		return &pb.RollbackDeploymentResponse{
+			Ok:     false,
+			ErrMsg: fmt.Sprintf("Version %d not found", targetVersion),
+		}, nil
+	}
+
+	if target.StubId == latest.StubId {
+		return &pb.RollbackDeploymentResponse{
+			Ok:     false,
+			ErrMsg: fmt.Sprintf("Latest version already runs version %d", targetVersion),
+		}, nil
+	}
+
+	deployment, err := gws.backendRepo.RollbackDeployment(ctx, authInfo.Workspace.Id, latest.Name, latest.StubType, targetVersion)
+	if err != nil {
+		return &pb.RollbackDeploymentResponse{
+			Ok:     false,
+			ErrMsg: "Unable to roll back deployment",
+		}, nil
+	}
+
+	// Publish reload instance event so the restored stub's min containers come back up
+	eventBus := common.NewEventBus(gws.redisClient)
+	eventBus.Send(&common.Event{Type: common.EventTypeReloadInstance, Retries: 3, LockAndDelete: false, Args: map[string]any{
+		"stub_id":   target.Stub.ExternalId,
+		"stub_type": target.StubType,
+		"timestamp": time.Now().Unix(),
+	}})
+
+	abstractions.NotifyDeploymentEvent(ctx, gws.appConfig, gws.backendRepo, authInfo.Workspace.ExternalId, deployment, target.Stub.ExternalId, abstractions.DeploymentWebhookPayload{
+		Event:         types.DeploymentWebhookEventPromoted,
+		SourceVersion: &targetVersion,
+		Reason:        "rollback",
+	})
+
+	return &pb.RollbackDeploymentResponse{
+		Ok:           true,
+		DeploymentId: deployment.ExternalId,
+		Version:      uint32(deployment.Version),
+		StubId:       target.Stub.ExternalId,
+	}, nil
+}
+
+func (gws *GatewayService) ListDeploymentHistory(ctx context.Context, in *pb.ListDeploymentHistoryRequest) (*pb.ListDeploymentHistoryResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	deploymentWithRelated, err := gws.backendRepo.GetDeploymentByExternalId(ctx, authInfo.Workspace.Id, in.Id)
+	if err != nil || deploymentWithRelated == nil {
+		return &pb.ListDeploymentHistoryResponse{
+			Ok:     false,
+			ErrMsg: "Deployment not found",
+		}, nil
+	}
+
+	history, err := gws.backendRepo.ListDeploymentHistory(ctx, authInfo.Workspace.Id, deploymentWithRelated.Name, deploymentWithRelated.StubType)
+	if err != nil {
+		return &pb.ListDeploymentHistoryResponse{
+			Ok:     false,
+			ErrMsg: "Unable to list deployment history",
+		}, nil
+	}
+
+	entries := make([]*pb.DeploymentHistoryEntry, len(history))
+	for i, h := range history {
+		entries[i] = &pb.DeploymentHistoryEntry{
+			Version:   uint32(h.Version),
+			StubId:    h.StubExternalId,
+			Action:    string(h.Action),
+			CreatedAt: timestamppb.New(h.CreatedAt.Time),
+		}
+
+		if h.RolledBackToVersion != nil {
+			entries[i].RolledBackToVersion = ptr.To(uint32(*h.RolledBackToVersion))
+		}
+	}
+
+	return &pb.ListDeploymentHistoryResponse{
+		Ok:      true,
+		History: entries,
+	}, nil
+}
+
 func (gws *GatewayService) stopDeployments(deployments []types.DeploymentWithRelated, ctx context.Context) error {
 	for _, deployment := range deployments {
 		// Stop scheduled job
@@ -274,12 +521,19 @@ func (gws *GatewayService) stopDeployments(deployments []types.DeploymentWithRel
 		}
 
 		// Disable deployment
+		wasActive := deployment.Active
 		deployment.Active = false
 		_, err = gws.backendRepo.UpdateDeployment(ctx, deployment.Deployment)
 		if err != nil {
 			return err
 		}
 
+		if wasActive {
+			abstractions.NotifyDeploymentEvent(ctx, gws.appConfig, gws.backendRepo, deployment.Workspace.ExternalId, &deployment.Deployment, deployment.Stub.ExternalId, abstractions.DeploymentWebhookPayload{
+				Event: types.DeploymentWebhookEventStopped,
+			})
+		}
+
 		// Publish reload instance event
 		eventBus := common.NewEventBus(gws.redisClient)
 		eventBus.Send(&common.Event{Type: common.EventTypeReloadInstance, Retries: 3, LockAndDelete: false, Args: map[string]any{
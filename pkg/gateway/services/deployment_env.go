@@ -0,0 +1,221 @@
+package gatewayservices
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	abstractions "github.com/beam-cloud/beta9/pkg/abstractions/common"
+	"github.com/beam-cloud/beta9/pkg/auth"
+	common "github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+	"github.com/rs/zerolog/log"
+)
+
+func (gws *GatewayService) SetDeploymentEnv(ctx context.Context, in *pb.SetDeploymentEnvRequest) (*pb.SetDeploymentEnvResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionDeploy) {
+		return &pb.SetDeploymentEnvResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	if len(in.Env) == 0 && len(in.Secrets) == 0 {
+		return &pb.SetDeploymentEnvResponse{
+			Ok:     false,
+			ErrMsg: "No environment variables or secrets provided",
+		}, nil
+	}
+
+	for name := range in.Env {
+		if err := types.ValidateEnvName(name); err != nil {
+			return &pb.SetDeploymentEnvResponse{
+				Ok:     false,
+				ErrMsg: err.Error(),
+			}, nil
+		}
+	}
+
+	secrets := make([]types.Secret, 0, len(in.Secrets))
+	for _, name := range in.Secrets {
+		if _, ok := in.Env[name]; ok {
+			return &pb.SetDeploymentEnvResponse{
+				Ok:     false,
+				ErrMsg: fmt.Sprintf("%s can't be both an environment variable and a secret", name),
+			}, nil
+		}
+
+		secret, err := gws.backendRepo.GetSecretByName(ctx, authInfo.Workspace, name)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return &pb.SetDeploymentEnvResponse{
+					Ok:     false,
+					ErrMsg: fmt.Sprintf("Secret %s not found", name),
+				}, nil
+			}
+
+			return &pb.SetDeploymentEnvResponse{
+				Ok:     false,
+				ErrMsg: "Unable to get secret",
+			}, nil
+		}
+
+		secrets = append(secrets, types.Secret{
+			Name:      secret.Name,
+			Value:     secret.Value,
+			CreatedAt: secret.CreatedAt,
+			UpdatedAt: secret.UpdatedAt,
+			Version:   secret.Version,
+		})
+	}
+
+	deployment, errMsg := gws.getDeploymentForEnv(ctx, authInfo, in.Id)
+	if deployment == nil {
+		return &pb.SetDeploymentEnvResponse{
+			Ok:     false,
+			ErrMsg: errMsg,
+		}, nil
+	}
+
+	err := gws.updateDeploymentEnv(ctx, *deployment, func(stubConfig *types.StubConfigV1) bool {
+		stubConfig.SetEnv(in.Env)
+		stubConfig.SetEnvSecrets(secrets)
+		return true
+	})
+	if err != nil {
+		return &pb.SetDeploymentEnvResponse{
+			Ok:     false,
+			ErrMsg: "Unable to update deployment environment",
+		}, nil
+	}
+
+	return &pb.SetDeploymentEnvResponse{
+		Ok: true,
+	}, nil
+}
+
+func (gws *GatewayService) UnsetDeploymentEnv(ctx context.Context, in *pb.UnsetDeploymentEnvRequest) (*pb.UnsetDeploymentEnvResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionDeploy) {
+		return &pb.UnsetDeploymentEnvResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	if len(in.Keys) == 0 {
+		return &pb.UnsetDeploymentEnvResponse{
+			Ok:     false,
+			ErrMsg: "No environment variables provided",
+		}, nil
+	}
+
+	deployment, errMsg := gws.getDeploymentForEnv(ctx, authInfo, in.Id)
+	if deployment == nil {
+		return &pb.UnsetDeploymentEnvResponse{
+			Ok:     false,
+			ErrMsg: errMsg,
+		}, nil
+	}
+
+	// Nothing to restart if none of the keys were set
+	err := gws.updateDeploymentEnv(ctx, *deployment, func(stubConfig *types.StubConfigV1) bool {
+		return stubConfig.UnsetEnv(in.Keys) > 0
+	})
+	if err != nil {
+		return &pb.UnsetDeploymentEnvResponse{
+			Ok:     false,
+			ErrMsg: "Unable to update deployment environment",
+		}, nil
+	}
+
+	return &pb.UnsetDeploymentEnvResponse{
+		Ok: true,
+	}, nil
+}
+
+func (gws *GatewayService) ListDeploymentEnv(ctx context.Context, in *pb.ListDeploymentEnvRequest) (*pb.ListDeploymentEnvResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	deployment, errMsg := gws.getDeploymentForEnv(ctx, authInfo, in.Id)
+	if deployment == nil {
+		return &pb.ListDeploymentEnvResponse{
+			Ok:     false,
+			ErrMsg: errMsg,
+		}, nil
+	}
+
+	stubConfig := &types.StubConfigV1{}
+	if err := json.Unmarshal([]byte(deployment.Stub.Config), stubConfig); err != nil {
+		return &pb.ListDeploymentEnvResponse{
+			Ok:     false,
+			ErrMsg: "Unable to read deployment environment",
+		}, nil
+	}
+
+	secrets := []string{}
+	for _, secret := range stubConfig.Secrets {
+		if !secret.Mount {
+			secrets = append(secrets, secret.Name)
+		}
+	}
+	slices.Sort(secrets)
+
+	return &pb.ListDeploymentEnvResponse{
+		Ok:      true,
+		Env:     stubConfig.EnvMap(),
+		Secrets: secrets,
+	}, nil
+}
+
+func (gws *GatewayService) getDeploymentForEnv(ctx context.Context, authInfo *auth.AuthInfo, id string) (*types.DeploymentWithRelated, string) {
+	deployment, err := gws.backendRepo.GetDeploymentByExternalId(ctx, authInfo.Workspace.Id, id)
+	if err != nil {
+		return nil, "Unable to get deployment"
+	}
+
+	if deployment == nil {
+		return nil, "Deployment not found"
+	}
+
+	return deployment, ""
+}
+
+// updateDeploymentEnv applies update to the deployment's stub config and, if it changed anything, rolls the
+// deployment's containers onto the new config. Versions that share the stub pick up the change too.
+func (gws *GatewayService) updateDeploymentEnv(ctx context.Context, deployment types.DeploymentWithRelated, update func(*types.StubConfigV1) bool) error {
+	stubConfig := &types.StubConfigV1{}
+	if err := json.Unmarshal([]byte(deployment.Stub.Config), stubConfig); err != nil {
+		return err
+	}
+
+	if !update(stubConfig) {
+		return nil
+	}
+
+	if err := gws.backendRepo.UpdateStubConfig(ctx, deployment.Stub.Id, stubConfig); err != nil {
+		return err
+	}
+
+	// Publish reload instance event
+	eventBus := common.NewEventBus(gws.redisClient)
+	eventBus.Send(&common.Event{Type: common.EventTypeReloadInstance, Retries: 3, LockAndDelete: false, Args: map[string]any{
+		"stub_id":   deployment.Stub.ExternalId,
+		"stub_type": deployment.StubType,
+		"timestamp": time.Now().Unix(),
+	}})
+
+	if deployment.Active {
+		go abstractions.RollingRestart(gws.ctx, gws.containerRepo, gws.scheduler, deployment.Stub.ExternalId)
+	}
+
+	return nil
+}
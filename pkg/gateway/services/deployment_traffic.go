@@ -0,0 +1,229 @@
+package gatewayservices
+
+import (
+	"context"
+	"fmt"
+
+	abstractions "github.com/beam-cloud/beta9/pkg/abstractions/common"
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+)
+
+// Canaries need a few requests before their error rate means anything
+const defaultTrafficSplitMinRequests = 20
+
+func trafficSplitToProto(split *types.DeploymentTrafficSplit) *pb.TrafficSplit {
+	return &pb.TrafficSplit{
+		DeploymentName:     split.Name,
+		StableVersion:      uint32(split.StableVersion),
+		CanaryVersion:      uint32(split.CanaryVersion),
+		CanaryPercent:      uint32(split.CanaryPercent),
+		ErrorRateThreshold: split.ErrorRateThreshold,
+		MinRequests:        uint32(split.MinRequests),
+		Status:             string(split.Status),
+	}
+}
+
+func isTrafficSplittable(stubType string) bool {
+	return stubType == types.StubTypeEndpointDeployment || stubType == types.StubTypeASGIDeployment || stubType == types.StubTypeGRPCDeployment
+}
+
+// SetTrafficSplit sends a share of a deployment's unversioned requests to a canary version, with the rest
+// going to a stable version, until the canary is promoted or rolled back
+func (gws *GatewayService) SetTrafficSplit(ctx context.Context, in *pb.SetTrafficSplitRequest) (*pb.SetTrafficSplitResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionDeploy) {
+		return &pb.SetTrafficSplitResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	deploymentWithRelated, err := gws.backendRepo.GetDeploymentByExternalId(ctx, authInfo.Workspace.Id, in.Id)
+	if err != nil || deploymentWithRelated == nil {
+		return &pb.SetTrafficSplitResponse{
+			Ok:     false,
+			ErrMsg: "Deployment not found",
+		}, nil
+	}
+
+	if !isTrafficSplittable(deploymentWithRelated.StubType) {
+		return &pb.SetTrafficSplitResponse{
+			Ok:     false,
+			ErrMsg: "Traffic can only be split for endpoint and ASGI deployments",
+		}, nil
+	}
+
+	if in.CanaryPercent > 100 {
+		return &pb.SetTrafficSplitResponse{
+			Ok:     false,
+			ErrMsg: "Canary percent must be between 0 and 100",
+		}, nil
+	}
+
+	if in.ErrorRateThreshold < 0 || in.ErrorRateThreshold > 1 {
+		return &pb.SetTrafficSplitResponse{
+			Ok:     false,
+			ErrMsg: "Error rate threshold must be between 0 and 1",
+		}, nil
+	}
+
+	name, stubType := deploymentWithRelated.Name, deploymentWithRelated.StubType
+
+	canaryVersion := uint(in.CanaryVersion)
+	if canaryVersion == 0 {
+		latest, err := gws.backendRepo.GetLatestDeploymentByName(ctx, authInfo.Workspace.Id, name, stubType, true)
+		if err != nil || latest == nil {
+			return &pb.SetTrafficSplitResponse{
+				Ok:     false,
+				ErrMsg: "Unable to get latest deployment",
+			}, nil
+		}
+
+		canaryVersion = latest.Version
+	}
+
+	if uint(in.StableVersion) >= canaryVersion {
+		return &pb.SetTrafficSplitResponse{
+			Ok:     false,
+			ErrMsg: "Stable version must be earlier than the canary version",
+		}, nil
+	}
+
+	stable, err := gws.backendRepo.GetDeploymentByNameAndVersion(ctx, authInfo.Workspace.Id, name, uint(in.StableVersion), stubType)
+	if err != nil {
+		return &pb.SetTrafficSplitResponse{
+			Ok:     false,
+			ErrMsg: fmt.Sprintf("Version %d not found", in.StableVersion),
+		}, nil
+	}
+
+	canary, err := gws.backendRepo.GetDeploymentByNameAndVersion(ctx, authInfo.Workspace.Id, name, canaryVersion, stubType)
+	if err != nil {
+		return &pb.SetTrafficSplitResponse{
+			Ok:     false,
+			ErrMsg: fmt.Sprintf("Version %d not found", canaryVersion),
+		}, nil
+	}
+
+	if !stable.Active || !canary.Active {
+		return &pb.SetTrafficSplitResponse{
+			Ok:     false,
+			ErrMsg: "Both versions must be active",
+		}, nil
+	}
+
+	minRequests := uint(in.MinRequests)
+	if minRequests == 0 {
+		minRequests = defaultTrafficSplitMinRequests
+	}
+
+	split, err := gws.backendRepo.SetTrafficSplit(ctx, types.DeploymentTrafficSplit{
+		WorkspaceId:        authInfo.Workspace.Id,
+		Name:               name,
+		StubType:           stubType,
+		StableVersion:      stable.Version,
+		CanaryVersion:      canary.Version,
+		CanaryPercent:      uint(in.CanaryPercent),
+		ErrorRateThreshold: in.ErrorRateThreshold,
+		MinRequests:        minRequests,
+	})
+	if err != nil {
+		return &pb.SetTrafficSplitResponse{
+			Ok:     false,
+			ErrMsg: "Unable to set traffic split",
+		}, nil
+	}
+
+	// The canary's error rate starts over with each split
+	gws.redisClient.Del(ctx, common.RedisKeys.GatewayTrafficSplitOutcomes(split.Id))
+
+	return &pb.SetTrafficSplitResponse{
+		Ok:    true,
+		Split: trafficSplitToProto(split),
+	}, nil
+}
+
+// PromoteVersion ends a deployment's traffic split by sending all of its unversioned requests to the canary
+func (gws *GatewayService) PromoteVersion(ctx context.Context, in *pb.PromoteVersionRequest) (*pb.PromoteVersionResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionDeploy) {
+		return &pb.PromoteVersionResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	deploymentWithRelated, err := gws.backendRepo.GetDeploymentByExternalId(ctx, authInfo.Workspace.Id, in.Id)
+	if err != nil || deploymentWithRelated == nil {
+		return &pb.PromoteVersionResponse{
+			Ok:     false,
+			ErrMsg: "Deployment not found",
+		}, nil
+	}
+
+	name, stubType := deploymentWithRelated.Name, deploymentWithRelated.StubType
+
+	split, err := gws.backendRepo.GetTrafficSplit(ctx, authInfo.Workspace.Id, name, stubType)
+	if err != nil || split == nil || split.Status != types.TrafficSplitStatusActive {
+		return &pb.PromoteVersionResponse{
+			Ok:     false,
+			ErrMsg: "Deployment has no active traffic split",
+		}, nil
+	}
+
+	ended, err := gws.backendRepo.EndTrafficSplit(ctx, split.Id, types.TrafficSplitStatusPromoted)
+	if err != nil || !ended {
+		return &pb.PromoteVersionResponse{
+			Ok:     false,
+			ErrMsg: "Traffic split already ended",
+		}, nil
+	}
+
+	latest, err := gws.backendRepo.GetLatestDeploymentByName(ctx, authInfo.Workspace.Id, name, stubType, true)
+	if err != nil || latest == nil {
+		return &pb.PromoteVersionResponse{
+			Ok:     false,
+			ErrMsg: "Unable to get latest deployment",
+		}, nil
+	}
+
+	if latest.Version == split.CanaryVersion {
+		abstractions.NotifyDeploymentEvent(ctx, gws.appConfig, gws.backendRepo, authInfo.Workspace.ExternalId, &latest.Deployment, latest.Stub.ExternalId, abstractions.DeploymentWebhookPayload{
+			Event:         types.DeploymentWebhookEventPromoted,
+			SourceVersion: &split.CanaryVersion,
+			Reason:        "canary promoted",
+		})
+
+		return &pb.PromoteVersionResponse{
+			Ok:      true,
+			Version: uint32(latest.Version),
+		}, nil
+	}
+
+	// A newer version was deployed during the split, so point the name back at the canary
+	deployment, err := gws.backendRepo.RollbackDeployment(ctx, authInfo.Workspace.Id, name, stubType, split.CanaryVersion)
+	if err != nil {
+		return &pb.PromoteVersionResponse{
+			Ok:     false,
+			ErrMsg: "Unable to promote canary version",
+		}, nil
+	}
+
+	if promoted, err := gws.backendRepo.GetDeploymentByExternalId(ctx, authInfo.Workspace.Id, deployment.ExternalId); err == nil && promoted != nil {
+		abstractions.NotifyDeploymentEvent(ctx, gws.appConfig, gws.backendRepo, authInfo.Workspace.ExternalId, deployment, promoted.Stub.ExternalId, abstractions.DeploymentWebhookPayload{
+			Event:         types.DeploymentWebhookEventPromoted,
+			SourceVersion: &split.CanaryVersion,
+			Reason:        "canary promoted",
+		})
+	}
+
+	return &pb.PromoteVersionResponse{
+		Ok:      true,
+		Version: uint32(deployment.Version),
+	}, nil
+}
@@ -0,0 +1,231 @@
+package gatewayservices
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+)
+
+const (
+	auditActionGrantCreate = "grant.create"
+	auditActionGrantRevoke = "grant.revoke"
+	auditResourceGrant     = "resource_grant"
+)
+
+// GrantResourceAccess lets another workspace invoke one of the caller's deployments, read one of its volumes or
+// pull one of its images, or revokes that access
+func (gws *GatewayService) GrantResourceAccess(ctx context.Context, in *pb.GrantResourceAccessRequest) (*pb.GrantResourceAccessResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionAdmin) {
+		return &pb.GrantResourceAccessResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	resourceType := types.ResourceGrantType(in.ResourceType)
+	if !resourceType.IsValid() {
+		return &pb.GrantResourceAccessResponse{
+			Ok:     false,
+			ErrMsg: "Invalid resource type. Allowed types: deployment, volume, image",
+		}, nil
+	}
+
+	grantee, err := gws.backendRepo.GetWorkspaceByExternalId(ctx, in.GranteeWorkspaceId)
+	if err != nil {
+		return &pb.GrantResourceAccessResponse{
+			Ok:     false,
+			ErrMsg: "Unable to find grantee workspace",
+		}, nil
+	}
+
+	if grantee.Id == authInfo.Workspace.Id {
+		return &pb.GrantResourceAccessResponse{
+			Ok:     false,
+			ErrMsg: "Resources can't be granted to their own workspace",
+		}, nil
+	}
+
+	resourceId, err := gws.grantResourceId(ctx, authInfo.Workspace, resourceType, in.ResourceId, in.Revoke)
+	if err != nil {
+		return &pb.GrantResourceAccessResponse{
+			Ok:     false,
+			ErrMsg: err.Error(),
+		}, nil
+	}
+
+	event := common.AuditEvent{
+		Action:       auditActionGrantCreate,
+		Principal:    authInfo.Token.ExternalId,
+		WorkspaceId:  authInfo.Workspace.ExternalId,
+		ResourceType: auditResourceGrant,
+		ResourceId:   string(resourceType) + "/" + resourceId,
+		Attributes: map[string]interface{}{
+			"grantee_workspace_id": grantee.ExternalId,
+		},
+	}
+
+	if in.Revoke {
+		err := gws.backendRepo.DeleteResourceGrant(ctx, authInfo.Workspace.Id, grantee.Id, resourceType, resourceId)
+
+		event.Action = auditActionGrantRevoke
+		event.Outcome = auditOutcome(err)
+		event.Reason = errorMessage(err)
+		gws.auditLogger.Log(event)
+
+		if err != nil {
+			return &pb.GrantResourceAccessResponse{
+				Ok:     false,
+				ErrMsg: "Unable to revoke grant",
+			}, nil
+		}
+
+		return &pb.GrantResourceAccessResponse{Ok: true}, nil
+	}
+
+	var expiresAt *time.Time
+	if in.ExpiresInSeconds > 0 {
+		t := time.Now().Add(time.Duration(in.ExpiresInSeconds) * time.Second)
+		expiresAt = &t
+	}
+
+	grant, err := gws.backendRepo.CreateResourceGrant(ctx, authInfo.Workspace.Id, grantee.Id, resourceType, resourceId, expiresAt)
+
+	event.Outcome = auditOutcome(err)
+	event.Reason = errorMessage(err)
+	gws.auditLogger.Log(event)
+
+	if err != nil {
+		log.Error().Err(err).Str("resource_type", in.ResourceType).Str("resource_id", resourceId).Msg("failed to create resource grant")
+		return &pb.GrantResourceAccessResponse{
+			Ok:     false,
+			ErrMsg: "Unable to create grant",
+		}, nil
+	}
+
+	return &pb.GrantResourceAccessResponse{
+		Ok: true,
+		Grant: resourceGrantToProto(&types.ResourceGrantWithRelated{
+			ResourceGrant:    *grant,
+			Workspace:        *authInfo.Workspace,
+			GranteeWorkspace: grantee,
+		}),
+	}, nil
+}
+
+func (gws *GatewayService) ListResourceGrants(ctx context.Context, in *pb.ListResourceGrantsRequest) (*pb.ListResourceGrantsResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionAdmin) {
+		return &pb.ListResourceGrantsResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	resourceType := types.ResourceGrantType(in.ResourceType)
+	if resourceType != "" && !resourceType.IsValid() {
+		return &pb.ListResourceGrantsResponse{
+			Ok:     false,
+			ErrMsg: "Invalid resource type. Allowed types: deployment, volume, image",
+		}, nil
+	}
+
+	grants, err := gws.backendRepo.ListResourceGrants(ctx, authInfo.Workspace.Id, in.Received, resourceType)
+	if err != nil {
+		return &pb.ListResourceGrantsResponse{
+			Ok:     false,
+			ErrMsg: "Unable to list grants",
+		}, nil
+	}
+
+	result := make([]*pb.ResourceGrant, 0, len(grants))
+	for i := range grants {
+		result = append(result, resourceGrantToProto(&grants[i]))
+	}
+
+	return &pb.ListResourceGrantsResponse{
+		Ok:     true,
+		Grants: result,
+	}, nil
+}
+
+// grantResourceId checks the workspace owns the resource and returns the id grants on it are kept under.
+// Revoking only needs the id, so grants outlive the checks on resources that have since gone away.
+func (gws *GatewayService) grantResourceId(ctx context.Context, workspace *types.Workspace, resourceType types.ResourceGrantType, id string, revoke bool) (string, error) {
+	if id == "" {
+		return "", errors.New("Resource ID is required")
+	}
+
+	switch resourceType {
+	case types.ResourceGrantDeployment:
+		if revoke {
+			return id, nil
+		}
+
+		deployments, err := gws.backendRepo.ListDeploymentsWithRelated(ctx, types.DeploymentFilter{
+			WorkspaceID: workspace.Id,
+			Name:        id,
+		})
+		if err != nil || len(deployments) == 0 {
+			return "", errors.New("Deployment not found")
+		}
+
+		return id, nil
+	case types.ResourceGrantVolume:
+		volume, err := gws.backendRepo.GetVolume(ctx, workspace.Id, id)
+		if err != nil {
+			return "", errors.New("Volume not found")
+		}
+
+		// Granted volumes are mounted from the owner's path on the shared volume filesystem, which workspace
+		// storage volumes don't live on
+		if !revoke && workspace.StorageAvailable() {
+			return "", errors.New("Grants aren't supported for volumes in workspace storage")
+		}
+
+		return volume.ExternalId, nil
+	case types.ResourceGrantImage:
+		if revoke {
+			return id, nil
+		}
+
+		// Images without registry credentials can be pulled by any workspace, so only images pulled with the
+		// workspace's own credentials can be granted
+		ownerId, err := gws.backendRepo.GetImageCredentialWorkspaceId(ctx, id)
+		if err != nil || ownerId != workspace.Id {
+			return "", errors.New("Image not found")
+		}
+
+		return id, nil
+	}
+
+	return "", errors.New("Invalid resource type. Allowed types: deployment, volume, image")
+}
+
+func resourceGrantToProto(grant *types.ResourceGrantWithRelated) *pb.ResourceGrant {
+	result := &pb.ResourceGrant{
+		ResourceType:         string(grant.ResourceType),
+		ResourceId:           grant.ResourceId,
+		WorkspaceId:          grant.Workspace.ExternalId,
+		WorkspaceName:        grant.Workspace.Name,
+		GranteeWorkspaceId:   grant.GranteeWorkspace.ExternalId,
+		GranteeWorkspaceName: grant.GranteeWorkspace.Name,
+		Permission:           grant.ResourceType.Permission(),
+		CreatedAt:            timestamppb.New(grant.CreatedAt.Time),
+	}
+	if grant.ExpiresAt.Valid {
+		result.ExpiresAt = timestamppb.New(grant.ExpiresAt.Time)
+	}
+
+	return result
+}
@@ -0,0 +1,117 @@
+package gatewayservices
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+)
+
+const (
+	auditActionWorkspaceSetIPAllowList = "workspace.set_ip_allowlist"
+	maxIPAllowListEntries              = 100
+)
+
+func (gws *GatewayService) GetWorkspaceIPAllowList(ctx context.Context, in *pb.GetWorkspaceIPAllowListRequest) (*pb.GetWorkspaceIPAllowListResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionAdmin) {
+		return &pb.GetWorkspaceIPAllowListResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	cidrs, err := gws.backendRepo.GetWorkspaceIPAllowList(ctx, authInfo.Workspace.Id)
+	if err != nil {
+		return &pb.GetWorkspaceIPAllowListResponse{
+			Ok:     false,
+			ErrMsg: "Unable to get IP allowlist.",
+		}, nil
+	}
+
+	return &pb.GetWorkspaceIPAllowListResponse{
+		Ok:    true,
+		Cidrs: cidrs,
+	}, nil
+}
+
+// SetWorkspaceIPAllowList replaces the CIDRs the workspace's API can be used from. The cached list is replaced
+// too, so it's enforced on the next request to any gateway.
+func (gws *GatewayService) SetWorkspaceIPAllowList(ctx context.Context, in *pb.SetWorkspaceIPAllowListRequest) (*pb.SetWorkspaceIPAllowListResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionAdmin) {
+		return &pb.SetWorkspaceIPAllowListResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	cidrs, err := normalizeIPAllowList(in.Cidrs)
+	if err != nil {
+		return &pb.SetWorkspaceIPAllowListResponse{
+			Ok:     false,
+			ErrMsg: err.Error(),
+		}, nil
+	}
+
+	err = gws.backendRepo.SetWorkspaceIPAllowList(ctx, authInfo.Workspace.Id, cidrs)
+
+	gws.auditLogger.Log(common.AuditEvent{
+		Action:       auditActionWorkspaceSetIPAllowList,
+		Principal:    authInfo.Token.ExternalId,
+		WorkspaceId:  authInfo.Workspace.ExternalId,
+		ResourceType: auditResourceWorkspace,
+		ResourceId:   authInfo.Workspace.ExternalId,
+		Outcome:      auditOutcome(err),
+		Reason:       errorMessage(err),
+		Attributes: map[string]interface{}{
+			"cidrs": cidrs,
+		},
+	})
+
+	if err != nil {
+		return &pb.SetWorkspaceIPAllowListResponse{
+			Ok:     false,
+			ErrMsg: "Unable to set IP allowlist.",
+		}, nil
+	}
+
+	if err := gws.workspaceRepo.SetIPAllowListByWorkspaceId(authInfo.Workspace.ExternalId, cidrs); err != nil {
+		log.Error().Err(err).Str("workspace_id", authInfo.Workspace.ExternalId).Msg("failed to cache workspace ip allowlist")
+	}
+
+	return &pb.SetWorkspaceIPAllowListResponse{
+		Ok:    true,
+		Cidrs: cidrs,
+	}, nil
+}
+
+// normalizeIPAllowList validates an allowlist, returning its CIDRs in canonical form without duplicates
+func normalizeIPAllowList(entries []string) ([]string, error) {
+	if len(entries) > maxIPAllowListEntries {
+		return nil, fmt.Errorf("IP allowlists can have at most %d entries.", maxIPAllowListEntries)
+	}
+
+	cidrs := make([]string, 0, len(entries))
+	seen := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		cidr, _, err := common.ValidateCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid entry %q: %v.", entry, err)
+		}
+
+		if !seen[cidr] {
+			seen[cidr] = true
+			cidrs = append(cidrs, cidr)
+		}
+	}
+
+	return cidrs, nil
+}
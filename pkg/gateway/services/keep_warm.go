@@ -0,0 +1,155 @@
+package gatewayservices
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	abstractions "github.com/beam-cloud/beta9/pkg/abstractions/common"
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+)
+
+func keepWarmPolicyToProto(policy *types.KeepWarmPolicy) *pb.KeepWarmPolicy {
+	if policy == nil {
+		return nil
+	}
+
+	return &pb.KeepWarmPolicy{
+		Schedule:   policy.Schedule,
+		Timezone:   policy.Timezone,
+		Containers: uint32(policy.Containers),
+	}
+}
+
+func keepWarmActive(policy *types.KeepWarmPolicy) bool {
+	if policy == nil {
+		return false
+	}
+
+	active, _ := abstractions.KeepWarmActive(policy, time.Now())
+	return active
+}
+
+func (gws *GatewayService) validateKeepWarmPolicy(policy *pb.KeepWarmPolicy) error {
+	if _, err := common.ParseCronSchedule(policy.Schedule); err != nil {
+		return fmt.Errorf("Invalid schedule: %v", err)
+	}
+
+	if _, err := time.LoadLocation(policy.Timezone); err != nil {
+		return fmt.Errorf("Invalid timezone: %s", policy.Timezone)
+	}
+
+	maxReplicas := gws.appConfig.GatewayService.StubLimits.MaxReplicas
+	if policy.Containers < 1 || uint64(policy.Containers) > maxReplicas {
+		return fmt.Errorf("Containers must be between 1 and %d", maxReplicas)
+	}
+
+	return nil
+}
+
+// SetKeepWarm holds a number of a deployment's containers warm during the hours its schedule matches
+func (gws *GatewayService) SetKeepWarm(ctx context.Context, in *pb.SetKeepWarmRequest) (*pb.SetKeepWarmResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionDeploy) {
+		return &pb.SetKeepWarmResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	stub, err := gws.resolvePolicyStub(ctx, authInfo, in.StubId, in.DeploymentId)
+	if err != nil {
+		return &pb.SetKeepWarmResponse{
+			Ok:     false,
+			ErrMsg: err.Error(),
+		}, nil
+	}
+
+	kind := stub.Type.Kind()
+	if !stub.Type.IsDeployment() || (kind != types.StubTypeEndpoint && kind != types.StubTypeASGI && kind != types.StubTypeGRPC && kind != types.StubTypeTaskQueue) {
+		return &pb.SetKeepWarmResponse{
+			Ok:     false,
+			ErrMsg: "Keep warm can only be set on endpoint and task queue deployments",
+		}, nil
+	}
+
+	if in.Policy == nil {
+		if err := gws.backendRepo.DeleteKeepWarmPolicy(ctx, stub.Id); err != nil {
+			return &pb.SetKeepWarmResponse{
+				Ok:     false,
+				ErrMsg: "Unable to remove keep warm policy",
+			}, nil
+		}
+
+		return &pb.SetKeepWarmResponse{Ok: true}, nil
+	}
+
+	if in.Policy.Timezone == "" {
+		in.Policy.Timezone = "UTC"
+	}
+
+	if err := gws.validateKeepWarmPolicy(in.Policy); err != nil {
+		return &pb.SetKeepWarmResponse{
+			Ok:     false,
+			ErrMsg: err.Error(),
+		}, nil
+	}
+
+	policy, err := gws.backendRepo.SetKeepWarmPolicy(ctx, types.KeepWarmPolicy{
+		StubId:     stub.Id,
+		Schedule:   in.Policy.Schedule,
+		Timezone:   in.Policy.Timezone,
+		Containers: uint(in.Policy.Containers),
+	})
+	if err != nil {
+		return &pb.SetKeepWarmResponse{
+			Ok:     false,
+			ErrMsg: "Unable to set keep warm policy",
+		}, nil
+	}
+
+	return &pb.SetKeepWarmResponse{
+		Ok:     true,
+		Policy: keepWarmPolicyToProto(policy),
+		Active: keepWarmActive(policy),
+	}, nil
+}
+
+func (gws *GatewayService) GetKeepWarm(ctx context.Context, in *pb.GetKeepWarmRequest) (*pb.GetKeepWarmResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionRead) {
+		return &pb.GetKeepWarmResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	stub, err := gws.resolvePolicyStub(ctx, authInfo, in.StubId, in.DeploymentId)
+	if err != nil {
+		return &pb.GetKeepWarmResponse{
+			Ok:     false,
+			ErrMsg: err.Error(),
+		}, nil
+	}
+
+	policy, err := gws.backendRepo.GetKeepWarmPolicy(ctx, stub.ExternalId)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return &pb.GetKeepWarmResponse{
+			Ok:     false,
+			ErrMsg: "Unable to get keep warm policy",
+		}, nil
+	}
+
+	return &pb.GetKeepWarmResponse{
+		Ok:     true,
+		Policy: keepWarmPolicyToProto(policy),
+		Active: keepWarmActive(policy),
+	}, nil
+}
@@ -0,0 +1,200 @@
+package gatewayservices
+
+import (
+	"sync"
+	"time"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	logStreamBatchSize     = 100
+	logStreamFlushInterval = 100 * time.Millisecond
+	// How often a followed deployment is checked for containers that started after the stream did
+	logStreamContainerScanInterval = 5 * time.Second
+)
+
+// StreamLogs streams filtered log entries from a container, a task's container, or every container of a
+// deployment. Matching entries from each container's log file are replayed first, then followed live.
+func (gws *GatewayService) StreamLogs(in *pb.StreamLogsRequest, stream pb.GatewayService_StreamLogsServer) error {
+	ctx := stream.Context()
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionRead) {
+		return stream.Send(&pb.StreamLogsResponse{Done: true, ErrorMsg: "Unauthorized Access"})
+	}
+
+	filter := &pb.LogFilter{}
+	if in.Filter != nil {
+		filter = &pb.LogFilter{
+			Level:    in.Filter.Level,
+			Contains: in.Filter.Contains,
+			Since:    in.Filter.Since,
+			TaskId:   in.Filter.TaskId,
+		}
+	}
+
+	var (
+		listContainers func() ([]string, error)
+		rescan         bool
+	)
+
+	switch source := in.Source.(type) {
+	case *pb.StreamLogsRequest_ContainerId:
+		listContainers = func() ([]string, error) {
+			return []string{source.ContainerId}, nil
+		}
+	case *pb.StreamLogsRequest_TaskId:
+		task, err := gws.backendRepo.GetTaskWithRelated(ctx, source.TaskId)
+		if err != nil || task == nil || task.Workspace.ExternalId != authInfo.Workspace.ExternalId {
+			return stream.Send(&pb.StreamLogsResponse{Done: true, ErrorMsg: "Task not found"})
+		}
+
+		filter.TaskId = task.ExternalId
+		listContainers = func() ([]string, error) {
+			return []string{task.ContainerId}, nil
+		}
+	case *pb.StreamLogsRequest_DeploymentId:
+		deployment, err := gws.backendRepo.GetDeploymentByExternalId(ctx, authInfo.Workspace.Id, source.DeploymentId)
+		if err != nil || deployment == nil {
+			return stream.Send(&pb.StreamLogsResponse{Done: true, ErrorMsg: "Deployment not found"})
+		}
+
+		rescan = in.Follow
+		listContainers = func() ([]string, error) {
+			containers, err := gws.containerRepo.GetActiveContainersByStubId(deployment.Stub.ExternalId)
+			if err != nil {
+				return nil, err
+			}
+
+			containerIds := make([]string, 0, len(containers))
+			for _, container := range containers {
+				containerIds = append(containerIds, container.ContainerId)
+			}
+
+			return containerIds, nil
+		}
+	default:
+		return stream.Send(&pb.StreamLogsResponse{Done: true, ErrorMsg: "A container, task or deployment is required"})
+	}
+
+	ctx, cancel := common.MergeContexts(gws.ctx, ctx)
+	defer cancel()
+
+	entries := make(chan *pb.LogEntry, logStreamBatchSize)
+	started := map[string]bool{}
+	wg := sync.WaitGroup{}
+
+	startStreams := func() {
+		containerIds, err := listContainers()
+		if err != nil {
+			log.Warn().Err(err).Msg("unable to list containers for log stream")
+			return
+		}
+
+		for _, containerId := range containerIds {
+			if started[containerId] {
+				continue
+			}
+
+			client, _, err := gws.getClient(ctx, containerId, authInfo.Token.Key, authInfo.Workspace.ExternalId)
+			if err != nil {
+				continue
+			}
+
+			started[containerId] = true
+
+			request := &pb.ContainerStreamLogsRequest{
+				ContainerId: containerId,
+				Filter:      filter,
+				TailLines:   in.TailLines,
+				Follow:      in.Follow,
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				err := client.StreamLogEntries(ctx, request, func(entry *pb.LogEntry) error {
+					select {
+					case entries <- entry:
+						return nil
+					case <-ctx.Done():
+						return ctx.Err()
+					}
+				})
+				if err != nil && ctx.Err() == nil {
+					log.Warn().Err(err).Str("container_id", request.ContainerId).Msg("log stream ended")
+				}
+			}()
+		}
+	}
+
+	startStreams()
+
+	if len(started) == 0 && !rescan {
+		return stream.Send(&pb.StreamLogsResponse{Done: true, ErrorMsg: "No running containers found"})
+	}
+
+	// Without rescans no more streams are started, so the response is done once every stream ends
+	finished := make(chan struct{})
+	var scanC <-chan time.Time
+	if rescan {
+		scanTicker := time.NewTicker(logStreamContainerScanInterval)
+		defer scanTicker.Stop()
+		scanC = scanTicker.C
+	} else {
+		go func() {
+			wg.Wait()
+			close(finished)
+		}()
+	}
+
+	flushTicker := time.NewTicker(logStreamFlushInterval)
+	defer flushTicker.Stop()
+
+	batch := []*pb.LogEntry{}
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		err := stream.Send(&pb.StreamLogsResponse{Entries: batch})
+		batch = []*pb.LogEntry{}
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case entry := <-entries:
+			batch = append(batch, entry)
+			if len(batch) >= logStreamBatchSize {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		case <-flushTicker.C:
+			if err := flush(); err != nil {
+				return err
+			}
+		case <-scanC:
+			startStreams()
+		case <-finished:
+			for len(entries) > 0 {
+				batch = append(batch, <-entries)
+			}
+
+			if err := flush(); err != nil {
+				return err
+			}
+
+			return stream.Send(&pb.StreamLogsResponse{Done: true})
+		}
+	}
+}
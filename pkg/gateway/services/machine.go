@@ -14,7 +14,7 @@ import (
 func (gws *GatewayService) ListMachines(ctx context.Context, in *pb.ListMachinesRequest) (*pb.ListMachinesResponse, error) {
 	authInfo, _ := auth.AuthInfoFromContext(ctx)
 
-	if !auth.HasPermission(authInfo) {
+	if !auth.HasPermission(authInfo, types.PermissionAdmin) {
 		return &pb.ListMachinesResponse{
 			Ok:       false,
 			ErrMsg:   "Unauthorized Access",
@@ -77,6 +77,8 @@ func (gws *GatewayService) ListMachines(ctx context.Context, in *pb.ListMachines
 				LastKeepalive: machine.State.LastKeepalive,
 				Created:       machine.State.Created,
 				AgentVersion:  machine.State.AgentVersion,
+				InstanceType:  machine.State.InstanceType,
+				HourlyPrice:   machine.State.HourlyPrice,
 				MachineMetrics: &pb.MachineMetrics{
 					TotalCpuAvailable:    int32(machine.Metrics.TotalCpuAvailable),
 					TotalMemoryAvailable: int32(machine.Metrics.TotalMemoryAvailable),
@@ -123,6 +125,8 @@ func (gws *GatewayService) ListMachines(ctx context.Context, in *pb.ListMachines
 					LastKeepalive: machine.State.LastKeepalive,
 					Created:       machine.State.Created,
 					AgentVersion:  machine.State.AgentVersion,
+					InstanceType:  machine.State.InstanceType,
+					HourlyPrice:   machine.State.HourlyPrice,
 					MachineMetrics: &pb.MachineMetrics{
 						TotalCpuAvailable:    int32(machine.Metrics.TotalCpuAvailable),
 						TotalMemoryAvailable: int32(machine.Metrics.TotalMemoryAvailable),
@@ -151,7 +155,7 @@ func (gws *GatewayService) ListMachines(ctx context.Context, in *pb.ListMachines
 func (gws *GatewayService) CreateMachine(ctx context.Context, in *pb.CreateMachineRequest) (*pb.CreateMachineResponse, error) {
 	authInfo, _ := auth.AuthInfoFromContext(ctx)
 
-	if !auth.HasPermission(authInfo) {
+	if !auth.HasPermission(authInfo, types.PermissionAdmin) {
 		return &pb.CreateMachineResponse{
 			Ok:     false,
 			ErrMsg: "Unauthorized Access",
@@ -224,7 +228,7 @@ func (gws *GatewayService) CreateMachine(ctx context.Context, in *pb.CreateMachi
 func (gws *GatewayService) DeleteMachine(ctx context.Context, in *pb.DeleteMachineRequest) (*pb.DeleteMachineResponse, error) {
 	authInfo, _ := auth.AuthInfoFromContext(ctx)
 
-	if !auth.HasPermission(authInfo) {
+	if !auth.HasPermission(authInfo, types.PermissionAdmin) {
 		return &pb.DeleteMachineResponse{
 			Ok:     false,
 			ErrMsg: "Unauthorized Access",
@@ -0,0 +1,106 @@
+package gatewayservices
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+	"github.com/rs/zerolog/log"
+)
+
+// ListMemberRoles lists the workspace's tokens along with the role each of them acts with
+func (gws *GatewayService) ListMemberRoles(ctx context.Context, in *pb.ListMemberRolesRequest) (*pb.ListMemberRolesResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionAdmin) {
+		return &pb.ListMemberRolesResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	tokens, err := gws.backendRepo.ListTokens(ctx, authInfo.Workspace.Id)
+	if err != nil {
+		return &pb.ListMemberRolesResponse{
+			Ok:     false,
+			ErrMsg: "Unable to list members.",
+		}, nil
+	}
+
+	members := make([]*pb.Member, 0, len(tokens))
+	for i := range tokens {
+		members = append(members, memberFromToken(&tokens[i]))
+	}
+
+	return &pb.ListMemberRolesResponse{
+		Ok:      true,
+		Members: members,
+	}, nil
+}
+
+// SetMemberRole changes the role a workspace token acts with. Admins can't change their own role, so a
+// workspace can't be left without one by accident.
+func (gws *GatewayService) SetMemberRole(ctx context.Context, in *pb.SetMemberRoleRequest) (*pb.SetMemberRoleResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionAdmin) {
+		return &pb.SetMemberRoleResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	role := types.WorkspaceRole(in.Role)
+	if !role.IsValid() {
+		return &pb.SetMemberRoleResponse{
+			Ok:     false,
+			ErrMsg: "Invalid role. Allowed roles: admin, developer, read_only, deploy_only",
+		}, nil
+	}
+
+	if in.TokenId == authInfo.Token.ExternalId {
+		return &pb.SetMemberRoleResponse{
+			Ok:     false,
+			ErrMsg: "Unable to change the role of the token making the request.",
+		}, nil
+	}
+
+	token, err := gws.backendRepo.GetTokenByExternalId(ctx, authInfo.Workspace.Id, in.TokenId)
+	if err != nil || token.TokenType == types.TokenTypeWorker {
+		return &pb.SetMemberRoleResponse{
+			Ok:     false,
+			ErrMsg: "Token not found.",
+		}, nil
+	}
+
+	token, err = gws.backendRepo.SetTokenRole(ctx, authInfo.Workspace.Id, in.TokenId, role)
+	if err != nil {
+		return &pb.SetMemberRoleResponse{
+			Ok:     false,
+			ErrMsg: "Unable to set member role.",
+		}, nil
+	}
+
+	// Drop the cached token so the new role applies to its next request
+	if err := gws.workspaceRepo.InvalidateToken(token.Key); err != nil {
+		log.Error().Err(err).Str("token_id", token.ExternalId).Msg("failed to invalidate cached token")
+	}
+
+	return &pb.SetMemberRoleResponse{
+		Ok:     true,
+		Member: memberFromToken(token),
+	}, nil
+}
+
+func memberFromToken(token *types.Token) *pb.Member {
+	return &pb.Member{
+		TokenId:   token.ExternalId,
+		TokenType: token.TokenType,
+		Role:      string(token.WorkspaceRole()),
+		Active:    token.Active,
+		CreatedAt: timestamppb.New(token.CreatedAt.Time),
+	}
+}
@@ -0,0 +1,216 @@
+package gatewayservices
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+)
+
+const (
+	defaultInviteTTL   = 7 * 24 * time.Hour
+	inviteTokenLength  = 32
+	inviteEmailSubject = "You've been invited to a beta9 workspace"
+)
+
+// InviteMember creates an invitation to join the caller's workspace with a role, and emails it to the invitee
+// if the gateway can send email. The invitation token is only returned here.
+func (gws *GatewayService) InviteMember(ctx context.Context, in *pb.InviteMemberRequest) (*pb.InviteMemberResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionAdmin) {
+		return &pb.InviteMemberResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	address, err := mail.ParseAddress(in.Email)
+	if err != nil {
+		return &pb.InviteMemberResponse{
+			Ok:     false,
+			ErrMsg: "Invalid email address.",
+		}, nil
+	}
+
+	role := types.WorkspaceRole(in.Role)
+	if !role.IsValid() {
+		return &pb.InviteMemberResponse{
+			Ok:     false,
+			ErrMsg: "Invalid role. Allowed roles: admin, developer, read_only, deploy_only",
+		}, nil
+	}
+
+	inviteToken, err := generateInviteToken()
+	if err != nil {
+		return &pb.InviteMemberResponse{
+			Ok:     false,
+			ErrMsg: "Unable to create invitation.",
+		}, nil
+	}
+
+	ttl := gws.inviteTTL()
+	if in.ExpiresInSeconds > 0 {
+		ttl = min(ttl, time.Duration(in.ExpiresInSeconds)*time.Second)
+	}
+
+	invite, err := gws.backendRepo.CreateWorkspaceInvite(ctx, authInfo.Workspace.Id, address.Address, role, hashInviteToken(inviteToken), time.Now().Add(ttl))
+	if err != nil {
+		log.Error().Err(err).Str("workspace_id", authInfo.Workspace.ExternalId).Msg("failed to create workspace invite")
+		return &pb.InviteMemberResponse{
+			Ok:     false,
+			ErrMsg: "Unable to create invitation.",
+		}, nil
+	}
+
+	emailSent := false
+	if gws.mailer != nil {
+		if err := gws.mailer.Send(invite.Email, inviteEmailSubject, gws.inviteEmailBody(authInfo.Workspace, invite, inviteToken)); err != nil {
+			log.Error().Err(err).Str("invite_id", invite.ExternalId).Msg("failed to email workspace invite")
+		} else {
+			emailSent = true
+		}
+	}
+
+	return &pb.InviteMemberResponse{
+		Ok:          true,
+		Invite:      workspaceInviteToProto(invite),
+		InviteToken: inviteToken,
+		EmailSent:   emailSent,
+	}, nil
+}
+
+// AcceptInvite is called by the invitee, who doesn't have a token yet. Using the invitation creates the token
+// they join the workspace with.
+func (gws *GatewayService) AcceptInvite(ctx context.Context, in *pb.AcceptInviteRequest) (*pb.AcceptInviteResponse, error) {
+	if in.InviteToken == "" {
+		return &pb.AcceptInviteResponse{
+			Ok:     false,
+			ErrMsg: "Invalid or expired invitation.",
+		}, nil
+	}
+
+	invite, token, err := gws.backendRepo.AcceptWorkspaceInvite(ctx, hashInviteToken(in.InviteToken))
+	if err != nil {
+		log.Error().Err(err).Msg("failed to accept workspace invite")
+		return &pb.AcceptInviteResponse{
+			Ok:     false,
+			ErrMsg: "Unable to accept invitation.",
+		}, nil
+	}
+
+	if invite == nil {
+		return &pb.AcceptInviteResponse{
+			Ok:     false,
+			ErrMsg: "Invalid or expired invitation.",
+		}, nil
+	}
+
+	workspace, err := gws.backendRepo.GetWorkspace(ctx, invite.WorkspaceId)
+	if err != nil {
+		return &pb.AcceptInviteResponse{
+			Ok:     false,
+			ErrMsg: "Unable to accept invitation.",
+		}, nil
+	}
+
+	log.Info().Str("invite_id", invite.ExternalId).Str("workspace_id", workspace.ExternalId).Str("token_id", token.ExternalId).Msg("workspace invite accepted")
+	auth.SetAuditCaller(ctx, &auth.AuthInfo{Token: token, Workspace: workspace})
+
+	return &pb.AcceptInviteResponse{
+		Ok:          true,
+		WorkspaceId: workspace.ExternalId,
+		Token:       token.Key,
+	}, nil
+}
+
+// RevokeInvite stops a pending invitation from being used
+func (gws *GatewayService) RevokeInvite(ctx context.Context, in *pb.RevokeInviteRequest) (*pb.RevokeInviteResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionAdmin) {
+		return &pb.RevokeInviteResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	invite, err := gws.backendRepo.RevokeWorkspaceInvite(ctx, authInfo.Workspace.Id, in.InviteId)
+	if err != nil {
+		return &pb.RevokeInviteResponse{
+			Ok:     false,
+			ErrMsg: "Unable to revoke invitation.",
+		}, nil
+	}
+
+	if invite == nil {
+		return &pb.RevokeInviteResponse{
+			Ok:     false,
+			ErrMsg: "Pending invitation not found.",
+		}, nil
+	}
+
+	return &pb.RevokeInviteResponse{Ok: true}, nil
+}
+
+func (gws *GatewayService) inviteTTL() time.Duration {
+	if gws.appConfig.GatewayService.Invites.TTL > 0 {
+		return gws.appConfig.GatewayService.Invites.TTL
+	}
+
+	return defaultInviteTTL
+}
+
+func (gws *GatewayService) inviteEmailBody(workspace *types.Workspace, invite *types.WorkspaceInvite, inviteToken string) string {
+	body := fmt.Sprintf("You've been invited to join the %s workspace as %s.\n\n", workspace.Name, invite.Role)
+
+	if acceptURL := gws.appConfig.GatewayService.Invites.AcceptURL; acceptURL != "" {
+		if u, err := url.Parse(acceptURL); err == nil {
+			q := u.Query()
+			q.Set("token", inviteToken)
+			u.RawQuery = q.Encode()
+			body += fmt.Sprintf("Accept the invitation at %s\n\n", u.String())
+		}
+	} else {
+		body += fmt.Sprintf("Accept the invitation with this token: %s\n\n", inviteToken)
+	}
+
+	return body + fmt.Sprintf("The invitation expires at %s.\n", invite.ExpiresAt.Time.UTC().Format(time.RFC1123))
+}
+
+func generateInviteToken() (string, error) {
+	randomBytes := make([]byte, inviteTokenLength)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(randomBytes), nil
+}
+
+func hashInviteToken(inviteToken string) string {
+	sum := sha256.Sum256([]byte(inviteToken))
+	return hex.EncodeToString(sum[:])
+}
+
+func workspaceInviteToProto(invite *types.WorkspaceInvite) *pb.WorkspaceInvite {
+	return &pb.WorkspaceInvite{
+		InviteId:  invite.ExternalId,
+		Email:     invite.Email,
+		Role:      invite.Role,
+		Status:    string(invite.Status),
+		ExpiresAt: timestamppb.New(invite.ExpiresAt.Time),
+		CreatedAt: timestamppb.New(invite.CreatedAt.Time),
+	}
+}
@@ -2,38 +2,140 @@ package gatewayservices
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"io"
 	"os"
 	"path"
+	"strings"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/beam-cloud/beta9/pkg/auth"
 	"github.com/beam-cloud/beta9/pkg/clients"
+	"github.com/beam-cloud/beta9/pkg/common"
 	"github.com/beam-cloud/beta9/pkg/types"
 	pb "github.com/beam-cloud/beta9/proto"
+	"github.com/lib/pq"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const (
-	defaultObjectPutExpirationS = 60 * 60 * 24
+	defaultObjectPutExpirationS       = 60 * 60 * 24
+	defaultStorageOperationTimeout    = 30 * time.Second
+	storageOperationTimeoutErrMessage = "Storage operation timed out"
 )
 
+// withStorageTimeout derives a context bounding a single storage backend call so a hung
+// backend can't hold a request for its entire gRPC deadline
+func (gws *GatewayService) withStorageTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	timeout := gws.appConfig.GatewayService.StorageOperationTimeout
+	if timeout <= 0 {
+		timeout = defaultStorageOperationTimeout
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+func isStorageTimeout(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded)
+}
+
+const (
+	objectChecksumMismatchErrMessage = "Object content does not match the declared hash"
+	objectUploadExpiredErrMessage    = "Upload expired after receiving no content, try again"
+)
+
+// isValidObjectHash reports whether hash looks like a hex encoded SHA256, which is what clients declare for objects
+func isValidObjectHash(hash string) bool {
+	if len(hash) != sha256.Size*2 {
+		return false
+	}
+
+	_, err := hex.DecodeString(hash)
+	return err == nil
+}
+
+// objectDigestMatches reports whether a digest computed by the gateway matches the hash declared by the client
+func objectDigestMatches(declared string, digest []byte) bool {
+	return strings.EqualFold(declared, hex.EncodeToString(digest))
+}
+
+const (
+	auditActionObjectCreate     = "object.create"
+	auditActionObjectPut        = "object.put"
+	auditActionObjectPresignPut = "object.presign_put"
+	auditActionObjectLock       = "object.lock"
+	auditActionObjectRename     = "object.rename"
+)
+
+// auditObject records an object mutation in the audit log. An empty errMsg marks the action as successful.
+func (gws *GatewayService) auditObject(authInfo *auth.AuthInfo, action, objectId, hash string, size int64, errMsg string) {
+	event := common.AuditEvent{
+		Action:       action,
+		ResourceType: "object",
+		ResourceId:   objectId,
+		Outcome:      common.AuditOutcomeSuccess,
+		Reason:       errMsg,
+		Attributes: map[string]interface{}{
+			"hash": hash,
+			"size": size,
+		},
+	}
+
+	if errMsg != "" {
+		event.Outcome = common.AuditOutcomeFailure
+	}
+
+	if authInfo != nil {
+		event.WorkspaceId = authInfo.Workspace.ExternalId
+		if authInfo.Token != nil {
+			event.Principal = authInfo.Token.ExternalId
+		}
+	}
+
+	gws.auditLogger.Log(event)
+}
+
+// getObjectByHashOrKey looks up an object by its logical key when one is given, otherwise by content hash
+func (gws *GatewayService) getObjectByHashOrKey(ctx context.Context, workspaceId uint, hash, key string) (*types.Object, error) {
+	if key != "" {
+		return gws.backendRepo.GetObjectByKey(ctx, key, workspaceId)
+	}
+
+	return gws.backendRepo.GetObjectByHash(ctx, hash, workspaceId)
+}
+
 func (gws *GatewayService) HeadObject(ctx context.Context, in *pb.HeadObjectRequest) (*pb.HeadObjectResponse, error) {
 	authInfo, _ := auth.AuthInfoFromContext(ctx)
 
 	useWorkspaceStorage := authInfo.Workspace.StorageAvailable()
-	existingObject, err := gws.backendRepo.GetObjectByHash(ctx, in.Hash, authInfo.Workspace.Id)
+	existingObject, err := gws.getObjectByHashOrKey(ctx, authInfo.Workspace.Id, in.Hash, in.Key)
+
 	if err == nil {
+		accessible, err := gws.objectAccessible(ctx, authInfo, existingObject, types.ResourceAccessRead)
+		if err != nil || !accessible {
+			return &pb.HeadObjectResponse{
+				Ok:       false,
+				ErrorMsg: "Unauthorized Access",
+			}, nil
+		}
+
 		exists := true
 
 		// Check if the actual object file exists, not just the directory
-		objectPath := path.Join(types.DefaultObjectPath, authInfo.Workspace.Name, existingObject.ExternalId)
+		objectPath := localObjectPath(authInfo.Workspace.Name, existingObject.ExternalId)
 		if _, err := os.Stat(objectPath); os.IsNotExist(err) {
 			exists = false
 		}
 
-		if useWorkspaceStorage {
+		// Objects still on the gateway filesystem are served from there until they're migrated
+		if useWorkspaceStorage && (existingObject.Location != types.ObjectLocationLocal || !exists) {
 			storageClient, err := clients.NewWorkspaceStorageClient(ctx, authInfo.Workspace.Name, authInfo.Workspace.Storage)
 			if err != nil {
 				return &pb.HeadObjectResponse{
@@ -42,7 +144,14 @@ func (gws *GatewayService) HeadObject(ctx context.Context, in *pb.HeadObjectRequ
 				}, nil
 			}
 
-			exists, err = storageClient.Exists(ctx, path.Join(types.DefaultObjectPrefix, existingObject.ExternalId))
+			storageCtx, cancel := gws.withStorageTimeout(ctx)
+			defer cancel()
+
+			exists, err = storageClient.Exists(storageCtx, workspaceObjectKey(existingObject.ExternalId))
+			if isStorageTimeout(err) {
+				return nil, status.Error(codes.DeadlineExceeded, storageOperationTimeoutErrMessage)
+			}
+
 			if err != nil {
 				return &pb.HeadObjectResponse{
 					Ok:       false,
@@ -51,16 +160,41 @@ func (gws *GatewayService) HeadObject(ctx context.Context, in *pb.HeadObjectRequ
 			}
 		}
 
+		// Objects flagged by a consistency check must be uploaded again
+		if existingObject.Incomplete {
+			exists = false
+		}
+
 		if exists {
+			gws.touchObject(ctx, existingObject.ExternalId)
+
+			replicas, err := gws.backendRepo.ListObjectReplicas(ctx, existingObject.Id)
+			if err != nil {
+				log.Warn().Err(err).Str("object_id", existingObject.ExternalId).Msg("unable to list object replicas")
+			}
+
+			nearestRegion := existingObject.Region
+			if in.Region != existingObject.Region && gws.objectReplicaStorage(ctx, authInfo.Workspace, replicas, in.Region) != nil {
+				nearestRegion = in.Region
+			}
+
 			return &pb.HeadObjectResponse{
 				Ok:     true,
 				Exists: true,
 				ObjectMetadata: &pb.ObjectMetadata{
-					Name: existingObject.Hash,
-					Size: existingObject.Size,
+					Name:   existingObject.Hash,
+					Size:   existingObject.Size,
+					Key:    aws.ToString(existingObject.Key),
+					Region: existingObject.Region,
 				},
 				ObjectId:            existingObject.ExternalId,
 				UseWorkspaceStorage: useWorkspaceStorage,
+				StoredSize:          existingObject.StoredSizeOrSize(),
+				Region:              existingObject.Region,
+				Digest:              existingObject.Digest,
+				Location:            string(existingObject.Location),
+				NearestRegion:       nearestRegion,
+				Replicas:            objectReplicasToProto(replicas),
 			}, nil
 		} else {
 			return &pb.HeadObjectResponse{
@@ -81,7 +215,7 @@ func (gws *GatewayService) HeadObject(ctx context.Context, in *pb.HeadObjectRequ
 func (gws *GatewayService) CreateObject(ctx context.Context, in *pb.CreateObjectRequest) (*pb.CreateObjectResponse, error) {
 	authInfo, _ := auth.AuthInfoFromContext(ctx)
 
-	objectPath := path.Join(types.DefaultObjectPath, authInfo.Workspace.Name)
+	objectPath := localObjectDir(authInfo.Workspace.Name)
 	os.MkdirAll(objectPath, 0644)
 
 	storageClient, err := clients.NewWorkspaceStorageClient(ctx, authInfo.Workspace.Name, authInfo.Workspace.Storage)
@@ -92,56 +226,248 @@ func (gws *GatewayService) CreateObject(ctx context.Context, in *pb.CreateObject
 		}, nil
 	}
 
+	// Presigned uploads bypass the gateway so the content can't be verified here, only the declared hash
+	if !isValidObjectHash(in.Hash) {
+		return &pb.CreateObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Hash must be a hex encoded SHA256",
+		}, nil
+	}
+
+	if in.PartCount > 1 && !storageClient.S3Compatible() {
+		return &pb.CreateObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Multipart uploads are not supported by this workspace's storage provider",
+		}, nil
+	}
+
+	if in.PartCount <= 1 && in.Size > maxPresignedPutObjectSize {
+		return &pb.CreateObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Objects larger than 5GB must be uploaded in multiple parts",
+		}, nil
+	}
+
+	if in.RetainUntil != nil && !in.RetainUntil.AsTime().After(time.Now()) {
+		return &pb.CreateObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Retention time must be in the future",
+		}, nil
+	}
+
+	if err := validateObjectTags(in.Tags); err != nil {
+		return &pb.CreateObjectResponse{
+			Ok:       false,
+			ErrorMsg: err.Error(),
+		}, nil
+	}
+
 	object, err := gws.backendRepo.GetObjectByHash(ctx, in.Hash, authInfo.Workspace.Id)
+	if err == nil {
+		if accessible, err := gws.objectAccessible(ctx, authInfo, object, types.ResourceAccessWrite); err != nil || !accessible {
+			return &pb.CreateObjectResponse{
+				Ok:       false,
+				ErrorMsg: "Unauthorized Access",
+			}, nil
+		}
+	}
+
 	if err == nil && !in.Overwrite {
+		if len(in.Tags) > 0 {
+			if _, err := gws.tagObject(ctx, object, in.Tags, nil, false); err != nil {
+				return &pb.CreateObjectResponse{
+					Ok:       false,
+					ErrorMsg: "Unable to tag object",
+				}, nil
+			}
+		}
+
 		return &pb.CreateObjectResponse{
 			Ok:       true,
 			ObjectId: object.ExternalId,
 		}, nil
 	}
 
+	if object != nil && object.IsLocked() {
+		lockErr := &types.ErrObjectLocked{ObjectId: object.ExternalId, RetainUntil: object.RetainUntil.Time}
+		gws.auditObject(authInfo, auditActionObjectCreate, object.ExternalId, in.Hash, in.Size, lockErr.Error())
+		return nil, status.Error(codes.FailedPrecondition, lockErr.Error())
+	}
+
+	usage, err := gws.objectStorageUsage(ctx, authInfo.Workspace.Id)
+	if err != nil {
+		return &pb.CreateObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to check storage usage",
+		}, nil
+	}
+
+	// Overwriting replaces the existing object's content, so only the growth counts against the quota
+	additional := in.Size
+	if object != nil {
+		additional -= object.Size
+	}
+
+	if err := gws.checkObjectQuota(usage, additional); err != nil {
+		gws.auditObject(authInfo, auditActionObjectCreate, "", in.Hash, in.Size, err.Error())
+		return &pb.CreateObjectResponse{
+			Ok:       false,
+			ErrorMsg: objectQuotaExceededErrMessage,
+		}, nil
+	}
+
 	if object == nil {
 		object, err = gws.backendRepo.CreateObject(ctx, in.Hash, in.Size, authInfo.Workspace.Id)
 		if err != nil {
+			gws.auditObject(authInfo, auditActionObjectCreate, "", in.Hash, in.Size, err.Error())
 			return &pb.CreateObjectResponse{
 				Ok:       false,
 				ErrorMsg: "Unable to create object",
 			}, nil
 		}
+
+		gws.auditObject(authInfo, auditActionObjectCreate, object.ExternalId, in.Hash, in.Size, "")
+		gws.notifyObjectEvent(ctx, authInfo.Workspace, types.ObjectWebhookEventCreated, object)
+	} else {
+		// Overwriting replaces the stored content, so the previous content is kept as a version first
+		if err := gws.snapshotObjectVersion(ctx, authInfo.Workspace, object); err != nil {
+			log.Error().Err(err).Str("object_id", object.ExternalId).Msg("failed to snapshot object version")
+			gws.auditObject(authInfo, auditActionObjectCreate, object.ExternalId, in.Hash, in.Size, err.Error())
+			return &pb.CreateObjectResponse{
+				Ok:       false,
+				ErrorMsg: "Unable to preserve the previous object version",
+			}, nil
+		}
+
+		gws.pruneObjectVersions(ctx, authInfo.Workspace, object)
+		gws.invalidateCachedObject(object)
+		invalidateResolvedObjectPath(authInfo.Workspace.Name, object.ExternalId)
+
+		overwritten := *object
+		overwritten.Hash, overwritten.Size = in.Hash, in.Size
+		gws.notifyObjectEvent(ctx, authInfo.Workspace, types.ObjectWebhookEventOverwritten, &overwritten)
+	}
+
+	// Presigned uploads go straight to workspace storage
+	if err := gws.backendRepo.UpdateObjectLocationByExternalId(ctx, object.ExternalId, types.ObjectLocationWorkspaceStorage); err != nil {
+		return &pb.CreateObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to create object",
+		}, nil
+	}
+
+	if len(in.Tags) > 0 {
+		if _, err := gws.tagObject(ctx, object, in.Tags, nil, false); err != nil {
+			return &pb.CreateObjectResponse{
+				Ok:       false,
+				ErrorMsg: "Unable to tag object",
+			}, nil
+		}
+	}
+
+	if in.RetainUntil != nil {
+		if err := gws.backendRepo.LockObject(ctx, object.ExternalId, authInfo.Workspace.Id, in.RetainUntil.AsTime()); err != nil {
+			gws.auditObject(authInfo, auditActionObjectLock, object.ExternalId, in.Hash, in.Size, err.Error())
+			return &pb.CreateObjectResponse{
+				Ok:       false,
+				ErrorMsg: "Unable to lock object",
+			}, nil
+		}
+
+		gws.auditObject(authInfo, auditActionObjectLock, object.ExternalId, in.Hash, in.Size, "")
+	}
+
+	if in.PartCount > 1 {
+		return gws.createMultipartObject(ctx, authInfo, storageClient, object, in)
+	}
+
+	storageCtx, cancel := gws.withStorageTimeout(ctx)
+	defer cancel()
+
+	presignedURL, err := storageClient.GeneratePresignedPutURL(storageCtx, workspaceObjectKey(object.ExternalId), defaultObjectPutExpirationS)
+	if isStorageTimeout(err) {
+		gws.auditObject(authInfo, auditActionObjectPresignPut, object.ExternalId, in.Hash, in.Size, err.Error())
+		return nil, status.Error(codes.DeadlineExceeded, storageOperationTimeoutErrMessage)
 	}
 
-	presignedURL, err := storageClient.GeneratePresignedPutURL(ctx, path.Join(types.DefaultObjectPrefix, object.ExternalId), defaultObjectPutExpirationS)
 	if err != nil {
+		gws.auditObject(authInfo, auditActionObjectPresignPut, object.ExternalId, in.Hash, in.Size, err.Error())
 		return &pb.CreateObjectResponse{
 			Ok:       false,
 			ErrorMsg: "Unable to generate presigned URL",
 		}, nil
 	}
 
+	gws.auditObject(authInfo, auditActionObjectPresignPut, object.ExternalId, in.Hash, in.Size, "")
 	return &pb.CreateObjectResponse{
-		Ok:           true,
-		ObjectId:     object.ExternalId,
-		PresignedUrl: presignedURL,
+		Ok:               true,
+		ObjectId:         object.ExternalId,
+		PresignedUrl:     presignedURL,
+		PresignedHeaders: storageClient.PresignedPutHeaders(),
 	}, nil
 }
 
 func (gws *GatewayService) PutObjectStream(stream pb.GatewayService_PutObjectStreamServer) error {
+	return gws.putObject(&putObjectStreamV1{stream: stream})
+}
+
+// putObject receives an object's content from an upload stream and stores it on the gateway filesystem
+func (gws *GatewayService) putObject(stream putObjectStream) error {
 	log.Info().Msg("PutObjectStream: starting")
 	ctx := stream.Context()
 	authInfo, _ := auth.AuthInfoFromContext(ctx)
 
-	if !auth.HasPermission(authInfo) {
+	if !auth.HasPermission(authInfo, types.PermissionDeploy) {
 		log.Warn().Msg("PutObjectStream: unauthorized access")
+		gws.auditObject(authInfo, auditActionObjectPut, "", "", 0, "Unauthorized Access")
 		return status.Error(codes.PermissionDenied, "Unauthorized Access")
 	}
 
-	objectPath := path.Join(types.DefaultObjectPath, authInfo.Workspace.Name)
+	release, err := gws.uploadLimiter.Acquire(ctx)
+	if err != nil {
+		log.Warn().Err(err).Msg("PutObjectStream: no upload slot available")
+		gws.auditObject(authInfo, auditActionObjectPut, "", "", 0, err.Error())
+		return status.Error(codes.ResourceExhausted, "Too many concurrent uploads, try again later")
+	}
+	defer release()
+
+	throttle := gws.workspaceUploadThrottle(ctx, authInfo.Workspace)
+
+	objectPath := localObjectDir(authInfo.Workspace.Name)
 	os.MkdirAll(objectPath, 0644)
 
+	compression := gws.appConfig.Storage.ObjectCompression
+	maxChunkSize, _ := gws.objectStreamLimits()
+
 	var size int
+	var hash string
 	var file *os.File
+	var writer io.WriteCloser
 	var newObject *types.Object
+	var heartbeat *uploadHeartbeat
 	var chunkCount int
+	var usage *types.ObjectStorageUsage
+	var tags map[string]string
+	hasher := sha256.New()
+
+	sendAndClose := func(resp *pb.PutObjectResponse) error {
+		objectId := ""
+		if newObject != nil {
+			objectId = newObject.ExternalId
+		}
+
+		gws.auditObject(authInfo, auditActionObjectPut, objectId, hash, int64(size), resp.ErrorMsg)
+		return stream.Finish(resp)
+	}
+
+	dataKey, err := gws.objectDataKey(ctx, authInfo.Workspace)
+	if err != nil {
+		log.Error().Err(err).Msg("PutObjectStream: error getting workspace encryption key")
+		return sendAndClose(&pb.PutObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to prepare object encryption",
+		})
+	}
 
 	for {
 		request, err := stream.Recv()
@@ -152,7 +478,45 @@ func (gws *GatewayService) PutObjectStream(stream pb.GatewayService_PutObjectStr
 
 		if err != nil {
 			log.Error().Err(err).Msg("PutObjectStream: error receiving stream")
-			return stream.SendAndClose(&pb.PutObjectResponse{
+
+			errMsg := "Unable to receive stream of bytes"
+			var streamErr *putObjectStreamError
+			if errors.As(err, &streamErr) {
+				errMsg = streamErr.msg
+			}
+
+			if newObject != nil {
+				os.Remove(path.Join(objectPath, newObject.ExternalId))
+				gws.backendRepo.DeleteObjectByExternalId(ctx, newObject.ExternalId)
+			}
+
+			return sendAndClose(&pb.PutObjectResponse{
+				Ok:       false,
+				ErrorMsg: errMsg,
+			})
+		}
+
+		if int64(len(request.ObjectContent)) > maxChunkSize {
+			log.Warn().Int("chunk_size", len(request.ObjectContent)).Msg("PutObjectStream: chunk too large")
+			if newObject != nil {
+				os.Remove(path.Join(objectPath, newObject.ExternalId))
+				gws.backendRepo.DeleteObjectByExternalId(ctx, newObject.ExternalId)
+			}
+
+			return sendAndClose(&pb.PutObjectResponse{
+				Ok:       false,
+				ErrorMsg: fmt.Sprintf("Chunks must be at most %d bytes", maxChunkSize),
+			})
+		}
+
+		if err := throttle.Wait(ctx, len(request.ObjectContent)); err != nil {
+			log.Warn().Err(err).Msg("PutObjectStream: upload cancelled while throttled")
+			if newObject != nil {
+				os.Remove(path.Join(objectPath, newObject.ExternalId))
+				gws.backendRepo.DeleteObjectByExternalId(ctx, newObject.ExternalId)
+			}
+
+			return sendAndClose(&pb.PutObjectResponse{
 				Ok:       false,
 				ErrorMsg: "Unable to receive stream of bytes",
 			})
@@ -160,71 +524,407 @@ func (gws *GatewayService) PutObjectStream(stream pb.GatewayService_PutObjectStr
 
 		chunkCount++
 		if file == nil {
+			hash = request.Hash
+			tags = request.Tags
+
+			if err := validateObjectTags(tags); err != nil {
+				return sendAndClose(&pb.PutObjectResponse{
+					Ok:       false,
+					ErrorMsg: err.Error(),
+				})
+			}
+
+			existingObject, err := gws.backendRepo.GetObjectByHash(ctx, request.Hash, authInfo.Workspace.Id)
+			if err == nil {
+				if accessible, err := gws.objectAccessible(ctx, authInfo, existingObject, types.ResourceAccessWrite); err != nil || !accessible {
+					gws.auditObject(authInfo, auditActionObjectPut, existingObject.ExternalId, hash, 0, "Unauthorized Access")
+					return status.Error(codes.PermissionDenied, "Unauthorized Access")
+				}
+			}
+			if err == nil && existingObject.IsLocked() {
+				lockErr := &types.ErrObjectLocked{ObjectId: existingObject.ExternalId, RetainUntil: existingObject.RetainUntil.Time}
+				gws.auditObject(authInfo, auditActionObjectPut, existingObject.ExternalId, hash, 0, lockErr.Error())
+				return status.Error(codes.FailedPrecondition, lockErr.Error())
+			}
+
+			usage, err = gws.objectStorageUsage(ctx, authInfo.Workspace.Id)
+			if err != nil {
+				log.Error().Err(err).Msg("PutObjectStream: error getting storage usage")
+				return sendAndClose(&pb.PutObjectResponse{
+					Ok:       false,
+					ErrorMsg: "Unable to check storage usage",
+				})
+			}
+
 			log.Info().Str("hash", request.Hash).Msg("PutObjectStream: creating object")
 			newObject, err = gws.backendRepo.CreateObject(ctx, request.Hash, 0, authInfo.Workspace.Id)
 			if err != nil {
 				log.Error().Err(err).Msg("PutObjectStream: error creating object in repo")
-				return stream.SendAndClose(&pb.PutObjectResponse{
+				return sendAndClose(&pb.PutObjectResponse{
 					Ok:       false,
 					ErrorMsg: "Unable to create object",
 				})
 			}
 
+			// Content is written to a temp file and only renamed into place once it's complete, so an
+			// interrupted upload never leaves a truncated object behind
 			filePath := path.Join(objectPath, newObject.ExternalId)
 			log.Info().Str("path", filePath).Msg("PutObjectStream: creating file")
-			file, err = os.Create(filePath)
+			file, err = createLocalObjectTempFile(filePath)
 			if err != nil {
 				log.Error().Err(err).Str("path", filePath).Msg("PutObjectStream: error creating file")
 				gws.backendRepo.DeleteObjectByExternalId(ctx, newObject.ExternalId)
-				return stream.SendAndClose(&pb.PutObjectResponse{
+				return sendAndClose(&pb.PutObjectResponse{
 					Ok:       false,
 					ErrorMsg: "Unable to create file",
 				})
 			}
 			defer file.Close()
+			defer os.Remove(file.Name())
+
+			heartbeat = gws.startUploadHeartbeat(ctx, authInfo.Workspace.Id, newObject.ExternalId)
+			defer heartbeat.Finish(gws.ctx)
+
+			// Stored bytes are compressed and encrypted transparently; size and hash continue to describe the original content
+			writer, err = common.NewObjectWriter(compression, dataKey, file)
+			if err != nil {
+				log.Error().Err(err).Str("compression", string(compression)).Msg("PutObjectStream: error creating object writer")
+				os.Remove(filePath)
+				gws.backendRepo.DeleteObjectByExternalId(ctx, newObject.ExternalId)
+				return sendAndClose(&pb.PutObjectResponse{
+					Ok:       false,
+					ErrorMsg: "Unable to create file",
+				})
+			}
+		}
+
+		// The janitor already removed the object row if the upload was considered abandoned
+		if !heartbeat.Beat(ctx) {
+			log.Warn().Str("object_id", newObject.ExternalId).Msg("PutObjectStream: upload expired")
+			return sendAndClose(&pb.PutObjectResponse{
+				Ok:       false,
+				ErrorMsg: objectUploadExpiredErrMessage,
+			})
 		}
 
-		s, err := file.Write(request.ObjectContent)
+		// The size isn't known up front, so the quota is enforced as content arrives
+		if err := gws.checkObjectQuota(usage, int64(size+len(request.ObjectContent))); err != nil {
+			log.Warn().Err(err).Msg("PutObjectStream: storage quota exceeded")
+			os.Remove(path.Join(objectPath, newObject.ExternalId))
+			gws.backendRepo.DeleteObjectByExternalId(ctx, newObject.ExternalId)
+			return sendAndClose(&pb.PutObjectResponse{
+				Ok:       false,
+				ErrorMsg: objectQuotaExceededErrMessage,
+			})
+		}
+
+		s, err := writer.Write(request.ObjectContent)
 		if err != nil {
 			log.Error().Err(err).Msg("PutObjectStream: error writing to file")
 			os.Remove(path.Join(objectPath, newObject.ExternalId))
 			gws.backendRepo.DeleteObjectByExternalId(ctx, newObject.ExternalId)
-			return stream.SendAndClose(&pb.PutObjectResponse{
+			return sendAndClose(&pb.PutObjectResponse{
 				Ok:       false,
 				ErrorMsg: "Unable to write file content",
 			})
 		}
+		hasher.Write(request.ObjectContent[:s])
 		size += s
+
+		if err := stream.Progress(int64(size)); err != nil {
+			log.Error().Err(err).Msg("PutObjectStream: error sending progress")
+			os.Remove(path.Join(objectPath, newObject.ExternalId))
+			gws.backendRepo.DeleteObjectByExternalId(ctx, newObject.ExternalId)
+			gws.auditObject(authInfo, auditActionObjectPut, newObject.ExternalId, hash, int64(size), err.Error())
+			return err
+		}
 	}
 
 	log.Info().Int("size", size).Msg("PutObjectStream: syncing file")
 	// Sync file to ensure data is flushed to the filesystem (required for JuiceFS)
 	if file != nil {
+		if err := writer.Close(); err != nil {
+			log.Error().Err(err).Msg("PutObjectStream: error flushing compressed content")
+			os.Remove(path.Join(objectPath, newObject.ExternalId))
+			gws.backendRepo.DeleteObjectByExternalId(ctx, newObject.ExternalId)
+			return sendAndClose(&pb.PutObjectResponse{
+				Ok:       false,
+				ErrorMsg: "Unable to write file content",
+			})
+		}
+
 		if err := file.Sync(); err != nil {
 			log.Error().Err(err).Msg("PutObjectStream: error syncing file")
 			os.Remove(path.Join(objectPath, newObject.ExternalId))
 			gws.backendRepo.DeleteObjectByExternalId(ctx, newObject.ExternalId)
-			return stream.SendAndClose(&pb.PutObjectResponse{
+			return sendAndClose(&pb.PutObjectResponse{
 				Ok:       false,
 				ErrorMsg: "Unable to sync file content",
 			})
 		}
 	}
 
+	digest := hasher.Sum(nil)
+	if !objectDigestMatches(hash, digest) {
+		log.Warn().Str("hash", hash).Str("digest", hex.EncodeToString(digest)).Msg("PutObjectStream: checksum mismatch")
+		os.Remove(path.Join(objectPath, newObject.ExternalId))
+		gws.backendRepo.DeleteObjectByExternalId(ctx, newObject.ExternalId)
+		return sendAndClose(&pb.PutObjectResponse{
+			Ok:       false,
+			ErrorMsg: objectChecksumMismatchErrMessage,
+		})
+	}
+
+	if heartbeat != nil && !heartbeat.Finish(gws.ctx) {
+		log.Warn().Str("object_id", newObject.ExternalId).Msg("PutObjectStream: upload expired")
+		return sendAndClose(&pb.PutObjectResponse{
+			Ok:       false,
+			ErrorMsg: objectUploadExpiredErrMessage,
+		})
+	}
+
+	if file != nil {
+		if err := os.Rename(file.Name(), path.Join(objectPath, newObject.ExternalId)); err != nil {
+			log.Error().Err(err).Msg("PutObjectStream: error moving file into place")
+			gws.backendRepo.DeleteObjectByExternalId(ctx, newObject.ExternalId)
+			return sendAndClose(&pb.PutObjectResponse{
+				Ok:       false,
+				ErrorMsg: "Unable to write file content",
+			})
+		}
+
+		invalidateResolvedObjectPath(authInfo.Workspace.Name, newObject.ExternalId)
+	}
+
 	log.Info().Msg("PutObjectStream: updating object size")
 	if err := gws.backendRepo.UpdateObjectSizeByExternalId(ctx, newObject.ExternalId, size); err != nil {
 		log.Error().Err(err).Msg("PutObjectStream: error updating object size")
 		os.Remove(path.Join(objectPath, newObject.ExternalId))
 		gws.backendRepo.DeleteObjectByExternalId(ctx, newObject.ExternalId)
-		return stream.SendAndClose(&pb.PutObjectResponse{
+		return sendAndClose(&pb.PutObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to complete file upload",
+		})
+	}
+
+	if err := gws.backendRepo.UpdateObjectDigestByExternalId(ctx, newObject.ExternalId, hex.EncodeToString(digest)); err != nil {
+		log.Error().Err(err).Msg("PutObjectStream: error updating object digest")
+		os.Remove(path.Join(objectPath, newObject.ExternalId))
+		gws.backendRepo.DeleteObjectByExternalId(ctx, newObject.ExternalId)
+		return sendAndClose(&pb.PutObjectResponse{
 			Ok:       false,
 			ErrorMsg: "Unable to complete file upload",
 		})
 	}
 
+	storedSize := int64(size)
+	if fileInfo, err := file.Stat(); err == nil {
+		storedSize = fileInfo.Size()
+	}
+
+	if compression != types.ObjectCompressionNone || dataKey != nil {
+		if err := gws.backendRepo.UpdateObjectCompressionByExternalId(ctx, newObject.ExternalId, compression, storedSize); err != nil {
+			log.Error().Err(err).Msg("PutObjectStream: error updating object compression")
+			os.Remove(path.Join(objectPath, newObject.ExternalId))
+			gws.backendRepo.DeleteObjectByExternalId(ctx, newObject.ExternalId)
+			return sendAndClose(&pb.PutObjectResponse{
+				Ok:       false,
+				ErrorMsg: "Unable to complete file upload",
+			})
+		}
+	}
+
+	if dataKey != nil {
+		encryption, wrappedDataKey := objectEncryptionMetadata(dataKey)
+		if err := gws.backendRepo.UpdateObjectEncryptionByExternalId(ctx, newObject.ExternalId, encryption, wrappedDataKey); err != nil {
+			log.Error().Err(err).Msg("PutObjectStream: error updating object encryption")
+			os.Remove(path.Join(objectPath, newObject.ExternalId))
+			gws.backendRepo.DeleteObjectByExternalId(ctx, newObject.ExternalId)
+			return sendAndClose(&pb.PutObjectResponse{
+				Ok:       false,
+				ErrorMsg: "Unable to complete file upload",
+			})
+		}
+	}
+
+	if len(tags) > 0 {
+		if _, err := gws.tagObject(ctx, newObject, tags, nil, false); err != nil {
+			log.Error().Err(err).Msg("PutObjectStream: error tagging object")
+			os.Remove(path.Join(objectPath, newObject.ExternalId))
+			gws.backendRepo.DeleteObjectByExternalId(ctx, newObject.ExternalId)
+			return sendAndClose(&pb.PutObjectResponse{
+				Ok:       false,
+				ErrorMsg: "Unable to tag object",
+			})
+		}
+	}
+
+	gws.deduplicateObject(ctx, authInfo.Workspace, newObject, hex.EncodeToString(digest), int64(size), storedSize, compression)
+
+	newObject.Size = int64(size)
+	gws.notifyObjectEvent(ctx, authInfo.Workspace, types.ObjectWebhookEventCreated, newObject)
+
 	log.Info().Str("object_id", newObject.ExternalId).Int("size", size).Msg("PutObjectStream: completed successfully")
-	return stream.SendAndClose(&pb.PutObjectResponse{
+	return sendAndClose(&pb.PutObjectResponse{
 		Ok:       true,
 		ObjectId: newObject.ExternalId,
 	})
 }
+
+func (gws *GatewayService) LockObject(ctx context.Context, in *pb.LockObjectRequest) (*pb.LockObjectResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionWrite) {
+		return &pb.LockObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	if in.RetainUntil == nil || !in.RetainUntil.AsTime().After(time.Now()) {
+		return &pb.LockObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Retention time must be in the future",
+		}, nil
+	}
+
+	object, err := gws.backendRepo.GetObjectByExternalId(ctx, in.ObjectId, authInfo.Workspace.Id)
+	if err != nil {
+		return &pb.LockObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Object not found",
+		}, nil
+	}
+
+	if accessible, err := gws.objectAccessible(ctx, authInfo, &object, types.ResourceAccessWrite); err != nil || !accessible {
+		return &pb.LockObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	if err := gws.backendRepo.LockObject(ctx, object.ExternalId, authInfo.Workspace.Id, in.RetainUntil.AsTime()); err != nil {
+		gws.auditObject(authInfo, auditActionObjectLock, object.ExternalId, object.Hash, object.Size, err.Error())
+		return &pb.LockObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to lock object",
+		}, nil
+	}
+
+	gws.auditObject(authInfo, auditActionObjectLock, object.ExternalId, object.Hash, object.Size, "")
+	return &pb.LockObjectResponse{
+		Ok: true,
+	}, nil
+}
+
+func (gws *GatewayService) RenameObject(ctx context.Context, in *pb.RenameObjectRequest) (*pb.RenameObjectResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionWrite) {
+		return &pb.RenameObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	object, err := gws.backendRepo.GetObjectByExternalId(ctx, in.ObjectId, authInfo.Workspace.Id)
+	if err != nil {
+		return &pb.RenameObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Object not found",
+		}, nil
+	}
+
+	if accessible, err := gws.objectAccessible(ctx, authInfo, &object, types.ResourceAccessWrite); err != nil || !accessible {
+		return &pb.RenameObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	err = gws.backendRepo.UpdateObjectKeyByExternalId(ctx, object.ExternalId, authInfo.Workspace.Id, in.Key)
+	if err != nil {
+		gws.auditObject(authInfo, auditActionObjectRename, object.ExternalId, object.Hash, object.Size, err.Error())
+
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			return nil, status.Errorf(codes.AlreadyExists, "Object key already exists: %s", in.Key)
+		}
+
+		return &pb.RenameObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to rename object",
+		}, nil
+	}
+
+	gws.auditObject(authInfo, auditActionObjectRename, object.ExternalId, object.Hash, object.Size, "")
+	return &pb.RenameObjectResponse{
+		Ok: true,
+	}, nil
+}
+
+func (gws *GatewayService) ListObjects(ctx context.Context, in *pb.ListObjectsRequest) (*pb.ListObjectsResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	filter := types.ObjectFilter{
+		WorkspaceID: authInfo.Workspace.Id,
+		Prefix:      in.Prefix,
+		MinSize:     in.MinSize,
+		MaxSize:     in.MaxSize,
+		Cursor:      in.Cursor,
+		Tags:        in.Tags,
+	}
+
+	limit := uint32(1000)
+	if in.Limit > 0 && in.Limit < limit {
+		limit = in.Limit
+	}
+	filter.Limit = limit
+
+	if in.CreatedAfter != nil {
+		filter.CreatedAtStart = in.CreatedAfter.AsTime().Format(time.RFC3339Nano)
+	}
+
+	if in.CreatedBefore != nil {
+		filter.CreatedAtEnd = in.CreatedBefore.AsTime().Format(time.RFC3339Nano)
+	}
+
+	page, err := gws.backendRepo.ListObjectsPaginated(ctx, filter)
+	if err != nil {
+		return &pb.ListObjectsResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to list objects",
+		}, nil
+	}
+
+	acls, err := gws.objectACLs(ctx, page.Data)
+	if err != nil {
+		return &pb.ListObjectsResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to list objects",
+		}, nil
+	}
+
+	// Objects the caller can't read are left out, so a page may come back short of the limit
+	objects := make([]*pb.ObjectInfo, 0, len(page.Data))
+	for _, object := range page.Data {
+		if !auth.HasResourceAccess(authInfo, acls[object.Id], types.ResourceAccessRead) {
+			continue
+		}
+
+		objects = append(objects, &pb.ObjectInfo{
+			ObjectId:   object.ExternalId,
+			Hash:       object.Hash,
+			Size:       object.Size,
+			Key:        aws.ToString(object.Key),
+			CreatedAt:  timestamppb.New(object.CreatedAt.Time),
+			LastUsedAt: timestamppb.New(object.LastUsedAt.Time),
+			Tags:       object.Tags,
+		})
+	}
+
+	return &pb.ListObjectsResponse{
+		Ok:         true,
+		Objects:    objects,
+		NextCursor: page.Next,
+	}, nil
+}
@@ -0,0 +1,271 @@
+package gatewayservices
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/repository"
+	repoCommon "github.com/beam-cloud/beta9/pkg/repository/common"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const deniedObjectHash = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+// deniedObjectRepo serves a single object whose ACL only grants access to another token. Any other
+// repository call panics, so a handler that gets past its ACL check fails the test.
+type deniedObjectRepo struct {
+	repository.BackendRepository
+	object types.Object
+}
+
+func newDeniedObjectRepo() *deniedObjectRepo {
+	key := "data/model.bin"
+	return &deniedObjectRepo{
+		object: types.Object{
+			Id:          1,
+			ExternalId:  "object-1",
+			Hash:        deniedObjectHash,
+			Size:        4,
+			WorkspaceId: 1,
+			Key:         &key,
+			Digest:      deniedObjectHash,
+		},
+	}
+}
+
+func (r *deniedObjectRepo) GetObjectByExternalId(ctx context.Context, externalId string, workspaceId uint) (types.Object, error) {
+	return r.object, nil
+}
+
+func (r *deniedObjectRepo) GetObjectByHash(ctx context.Context, hash string, workspaceId uint) (*types.Object, error) {
+	object := r.object
+	return &object, nil
+}
+
+func (r *deniedObjectRepo) GetObjectByKey(ctx context.Context, key string, workspaceId uint) (*types.Object, error) {
+	object := r.object
+	return &object, nil
+}
+
+func (r *deniedObjectRepo) ListObjectsPaginated(ctx context.Context, filters types.ObjectFilter) (repoCommon.CursorPaginationInfo[types.Object], error) {
+	return repoCommon.CursorPaginationInfo[types.Object]{Data: []types.Object{r.object}}, nil
+}
+
+func (r *deniedObjectRepo) ListObjectsByKey(ctx context.Context, workspaceId uint, prefix string, startAfter string, limit int) ([]types.Object, error) {
+	if startAfter >= *r.object.Key {
+		return nil, nil
+	}
+
+	return []types.Object{r.object}, nil
+}
+
+func (r *deniedObjectRepo) ListAccessControlEntries(ctx context.Context, resourceType string, resourceId uint) ([]types.AccessControlEntry, error) {
+	return []types.AccessControlEntry{
+		{PrincipalType: types.ACLPrincipalToken, Principal: "other-token", Access: types.ResourceAccessWrite},
+	}, nil
+}
+
+func (r *deniedObjectRepo) ListAccessControlEntriesForResources(ctx context.Context, resourceType string, resourceIds []uint) (map[uint][]types.AccessControlEntry, error) {
+	acls := map[uint][]types.AccessControlEntry{}
+	for _, resourceId := range resourceIds {
+		acls[resourceId], _ = r.ListAccessControlEntries(ctx, resourceType, resourceId)
+	}
+
+	return acls, nil
+}
+
+func deniedObjectAuthInfo() *auth.AuthInfo {
+	return &auth.AuthInfo{
+		Workspace: &types.Workspace{Id: 1, ExternalId: "workspace-1", Name: "workspace-1"},
+		Token: &types.Token{
+			ExternalId: "token-1",
+			TokenType:  types.TokenTypeWorkspace,
+			Role:       string(types.WorkspaceRoleDeveloper),
+		},
+	}
+}
+
+func TestObjectRPCsCheckObjectACL(t *testing.T) {
+	gws := &GatewayService{backendRepo: newDeniedObjectRepo()}
+	authInfo := deniedObjectAuthInfo()
+	ctx := auth.ContextWithAuthInfo(context.Background(), authInfo)
+
+	tests := []struct {
+		name string
+		call func() (string, error)
+	}{
+		{"CopyObject", func() (string, error) {
+			resp, err := gws.CopyObject(ctx, &pb.CopyObjectRequest{ObjectId: "object-1", TargetWorkspaceId: "workspace-2"})
+			return resp.GetErrorMsg(), err
+		}},
+		{"ExtractObject", func() (string, error) {
+			resp, err := gws.ExtractObject(ctx, &pb.ExtractObjectRequest{ObjectId: "object-1"})
+			return resp.GetErrorMsg(), err
+		}},
+		{"GetObjectSignature", func() (string, error) {
+			resp, err := gws.GetObjectSignature(ctx, &pb.GetObjectSignatureRequest{ObjectId: "object-1"})
+			return resp.GetErrorMsg(), err
+		}},
+		{"RenameObject", func() (string, error) {
+			resp, err := gws.RenameObject(ctx, &pb.RenameObjectRequest{ObjectId: "object-1", Key: "renamed"})
+			return resp.GetErrorMsg(), err
+		}},
+		{"LockObject", func() (string, error) {
+			resp, err := gws.LockObject(ctx, &pb.LockObjectRequest{ObjectId: "object-1", RetainUntil: timestamppb.New(time.Now().Add(time.Hour))})
+			return resp.GetErrorMsg(), err
+		}},
+		{"TagObject", func() (string, error) {
+			resp, err := gws.TagObject(ctx, &pb.TagObjectRequest{ObjectId: "object-1", Tags: map[string]string{"team": "ml"}})
+			return resp.GetErrorMsg(), err
+		}},
+		{"ListObjectVersions", func() (string, error) {
+			resp, err := gws.ListObjectVersions(ctx, &pb.ListObjectVersionsRequest{ObjectId: "object-1"})
+			return resp.GetErrorMsg(), err
+		}},
+		{"RestoreObjectVersion", func() (string, error) {
+			resp, err := gws.RestoreObjectVersion(ctx, &pb.RestoreObjectVersionRequest{ObjectId: "object-1", VersionId: "version-1"})
+			return resp.GetErrorMsg(), err
+		}},
+		{"InitiateUpload", func() (string, error) {
+			resp, err := gws.InitiateUpload(ctx, &pb.InitiateUploadRequest{Hash: deniedObjectHash, Size: 4})
+			return resp.GetErrorMsg(), err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errMsg, err := tt.call()
+			if err != nil {
+				assert.Equal(t, codes.PermissionDenied, status.Code(err))
+				return
+			}
+
+			assert.Equal(t, "Unauthorized Access", errMsg)
+		})
+	}
+
+	t.Run("ResolveObjectPath", func(t *testing.T) {
+		_, err := gws.ResolveObjectPath(ctx, authInfo, "object-1")
+		assert.ErrorIs(t, err, ErrObjectAccessForbidden)
+	})
+
+	t.Run("ListObjects", func(t *testing.T) {
+		resp, err := gws.ListObjects(ctx, &pb.ListObjectsRequest{})
+		require.NoError(t, err)
+		assert.True(t, resp.Ok)
+		assert.Empty(t, resp.Objects)
+	})
+}
+
+func TestS3ObjectsCheckObjectACL(t *testing.T) {
+	g := &s3Group{gws: &GatewayService{backendRepo: newDeniedObjectRepo()}}
+	authInfo := deniedObjectAuthInfo()
+	ctx := context.Background()
+
+	_, s3Err := g.getObject(ctx, authInfo, "data/model.bin")
+	assert.Equal(t, errS3AccessDenied, s3Err)
+
+	result := s3ListObjectsResult{MaxKeys: s3DefaultMaxKeys}
+	_, err := g.listEntries(ctx, authInfo, &result, "")
+	require.NoError(t, err)
+	assert.Empty(t, result.Contents)
+	assert.False(t, result.IsTruncated)
+}
+
+// objectPageRepo lists a page of objects whose ACLs can only be loaded for the whole page at once. Loading
+// one object's ACL panics.
+type objectPageRepo struct {
+	repository.BackendRepository
+	objects  []types.Object
+	acls     map[uint][]types.AccessControlEntry
+	aclLoads int
+}
+
+func newObjectPageRepo() *objectPageRepo {
+	repo := &objectPageRepo{
+		acls: map[uint][]types.AccessControlEntry{
+			2: {{PrincipalType: types.ACLPrincipalToken, Principal: "other-token", Access: types.ResourceAccessRead}},
+			3: {{PrincipalType: types.ACLPrincipalToken, Principal: "token-1", Access: types.ResourceAccessRead}},
+		},
+	}
+
+	for i, key := range []string{"a.bin", "b.bin", "c.bin", "d.bin"} {
+		repo.objects = append(repo.objects, types.Object{Id: uint(i + 1), ExternalId: key, WorkspaceId: 1, Key: &key})
+	}
+
+	return repo
+}
+
+func (r *objectPageRepo) ListObjectsPaginated(ctx context.Context, filters types.ObjectFilter) (repoCommon.CursorPaginationInfo[types.Object], error) {
+	return repoCommon.CursorPaginationInfo[types.Object]{Data: r.objects}, nil
+}
+
+func (r *objectPageRepo) ListObjectsByKey(ctx context.Context, workspaceId uint, prefix string, startAfter string, limit int) ([]types.Object, error) {
+	objects := []types.Object{}
+	for _, object := range r.objects {
+		if *object.Key > startAfter && len(objects) < limit {
+			objects = append(objects, object)
+		}
+	}
+
+	return objects, nil
+}
+
+func (r *objectPageRepo) ListAccessControlEntriesForResources(ctx context.Context, resourceType string, resourceIds []uint) (map[uint][]types.AccessControlEntry, error) {
+	r.aclLoads++
+
+	acls := map[uint][]types.AccessControlEntry{}
+	for _, resourceId := range resourceIds {
+		if acl, ok := r.acls[resourceId]; ok {
+			acls[resourceId] = acl
+		}
+	}
+
+	return acls, nil
+}
+
+func TestListObjectsLoadsPageACLsAtOnce(t *testing.T) {
+	authInfo := deniedObjectAuthInfo()
+
+	t.Run("ListObjects", func(t *testing.T) {
+		repo := newObjectPageRepo()
+		gws := &GatewayService{backendRepo: repo}
+
+		resp, err := gws.ListObjects(auth.ContextWithAuthInfo(context.Background(), authInfo), &pb.ListObjectsRequest{})
+		require.NoError(t, err)
+		require.True(t, resp.Ok)
+
+		keys := []string{}
+		for _, object := range resp.Objects {
+			keys = append(keys, object.Key)
+		}
+		assert.Equal(t, []string{"a.bin", "c.bin", "d.bin"}, keys)
+		assert.Equal(t, 1, repo.aclLoads)
+	})
+
+	t.Run("S3 listing", func(t *testing.T) {
+		repo := newObjectPageRepo()
+		g := &s3Group{gws: &GatewayService{backendRepo: repo}}
+
+		result := s3ListObjectsResult{MaxKeys: 2}
+		next, err := g.listEntries(context.Background(), authInfo, &result, "")
+		require.NoError(t, err)
+
+		keys := []string{}
+		for _, entry := range result.Contents {
+			keys = append(keys, entry.Key)
+		}
+		assert.Equal(t, []string{"a.bin", "c.bin"}, keys)
+		assert.True(t, result.IsTruncated)
+		assert.Equal(t, "c.bin", next)
+		assert.Equal(t, 2, repo.aclLoads)
+	})
+}
@@ -0,0 +1,97 @@
+package gatewayservices
+
+import (
+	"context"
+	"os"
+	"path"
+
+	"github.com/beam-cloud/beta9/pkg/types"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	objectBlobDirName = ".blobs"
+)
+
+// objectBlobPath returns the canonical on-disk path of a shared blob. Workspace objects backed by a
+// blob are hard links to this file, so the data stays alive as long as any object still links to it.
+func objectBlobPath(digest string) string {
+	return path.Join(types.DefaultObjectPath, objectBlobDirName, digest)
+}
+
+// objectDeduplicationEnabled reports whether objects may share blobs. Blobs are shared across workspaces,
+// so they can't be used once each workspace's content is encrypted with its own key. An object is only
+// linked to a blob after the gateway has hashed the bytes the client uploaded for it, so knowing a digest
+// never gives access to another workspace's content.
+func (gws *GatewayService) objectDeduplicationEnabled() bool {
+	return gws.appConfig.Storage.ObjectDeduplication && !gws.appConfig.Storage.ObjectEncryption.Enabled
+}
+
+// deduplicateObject links a freshly written object to the shared blob for its digest. If the blob
+// already exists, the object's own copy is replaced with a link to the blob's data; otherwise the
+// object's data becomes the blob. Failures are logged and leave the object with its own copy.
+func (gws *GatewayService) deduplicateObject(ctx context.Context, workspace *types.Workspace, object *types.Object, digest string, size, storedSize int64, compression types.ObjectCompression) {
+	if !gws.objectDeduplicationEnabled() {
+		return
+	}
+
+	blob, err := gws.backendRepo.LinkObjectBlob(ctx, object.ExternalId, digest, size, storedSize, compression)
+	if err != nil {
+		log.Warn().Err(err).Str("object_id", object.ExternalId).Msg("unable to link object to blob")
+		return
+	}
+
+	objectPath := localObjectPath(workspace.Name, object.ExternalId)
+	blobPath := objectBlobPath(digest)
+
+	if blob.RefCount > 1 {
+		tmpPath := objectPath + ".blob"
+		if err := os.Link(blobPath, tmpPath); err == nil {
+			if err := os.Rename(tmpPath, objectPath); err != nil {
+				os.Remove(tmpPath)
+				log.Warn().Err(err).Str("object_id", object.ExternalId).Msg("unable to replace object with blob link")
+				return
+			}
+
+			if blob.Compression != compression {
+				if err := gws.backendRepo.UpdateObjectCompressionByExternalId(ctx, object.ExternalId, blob.Compression, blob.StoredSize); err != nil {
+					log.Warn().Err(err).Str("object_id", object.ExternalId).Msg("unable to update object compression")
+				}
+			}
+
+			return
+		}
+
+		// The blob's canonical file went away while it was being released, so our copy takes its place
+		if !os.IsNotExist(err) {
+			log.Warn().Err(err).Str("object_id", object.ExternalId).Msg("unable to link blob")
+			return
+		}
+	}
+
+	if err := os.MkdirAll(path.Dir(blobPath), 0755); err != nil {
+		log.Warn().Err(err).Str("object_id", object.ExternalId).Msg("unable to create blob directory")
+		return
+	}
+
+	// A canonical file left behind by a previously released blob holds the same content, so it's safe to replace
+	os.Remove(blobPath)
+	if err := os.Link(objectPath, blobPath); err != nil {
+		log.Warn().Err(err).Str("object_id", object.ExternalId).Msg("unable to create blob")
+	}
+}
+
+// releaseObjectBlob drops an object's reference on its blob and removes the blob's canonical file once
+// nothing references it. Objects that aren't backed by a blob are left untouched.
+func (gws *GatewayService) releaseObjectBlob(ctx context.Context, object *types.Object) error {
+	blob, removed, err := gws.backendRepo.ReleaseObjectBlob(ctx, object.ExternalId)
+	if err != nil {
+		return err
+	}
+
+	if removed {
+		os.Remove(objectBlobPath(blob.Digest))
+	}
+
+	return nil
+}
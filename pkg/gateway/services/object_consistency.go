@@ -0,0 +1,130 @@
+package gatewayservices
+
+import (
+	"context"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/clients"
+	"github.com/beam-cloud/beta9/pkg/metrics"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+	"github.com/rs/zerolog/log"
+)
+
+func (gws *GatewayService) CheckObjectConsistency(ctx context.Context, in *pb.CheckObjectConsistencyRequest) (*pb.CheckObjectConsistencyResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionRead) {
+		return &pb.CheckObjectConsistencyResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	var storageClient *clients.WorkspaceStorageClient
+	if authInfo.Workspace.StorageAvailable() {
+		var err error
+		storageClient, err = clients.NewWorkspaceStorageClient(ctx, authInfo.Workspace.Name, authInfo.Workspace.Storage)
+		if err != nil {
+			return &pb.CheckObjectConsistencyResponse{
+				Ok:       false,
+				ErrorMsg: "Unable to create storage client",
+			}, nil
+		}
+	}
+
+	reports := make([]*pb.ObjectConsistencyReport, 0, len(in.ObjectIds))
+	for _, objectId := range in.ObjectIds {
+		reports = append(reports, gws.checkObjectConsistency(ctx, authInfo, storageClient, objectId, in.RepairMode))
+	}
+
+	return &pb.CheckObjectConsistencyResponse{
+		Ok:      true,
+		Reports: reports,
+	}, nil
+}
+
+func (gws *GatewayService) checkObjectConsistency(ctx context.Context, authInfo *auth.AuthInfo, storageClient *clients.WorkspaceStorageClient, objectId string, repairMode pb.ObjectRepairMode) *pb.ObjectConsistencyReport {
+	report := &pb.ObjectConsistencyReport{ObjectId: objectId}
+
+	object, err := gws.backendRepo.GetObjectByExternalId(ctx, objectId, authInfo.Workspace.Id)
+	if err != nil {
+		report.ErrorMsg = "Object not found"
+		return report
+	}
+	report.Hash = object.Hash
+	report.ExpectedSize = object.StoredSizeOrSize()
+
+	actualSize, exists, err := gws.objectStoredSize(ctx, authInfo.Workspace, storageClient, object)
+	if err != nil {
+		report.ErrorMsg = "Unable to check object in storage"
+		return report
+	}
+	report.Exists = exists
+	report.ActualSize = actualSize
+	report.Consistent = exists && actualSize == report.ExpectedSize
+
+	if report.Consistent {
+		return report
+	}
+
+	switch repairMode {
+	case pb.ObjectRepairMode_OBJECT_REPAIR_CORRECT_SIZE:
+		// A size can only be corrected from a file that is actually present
+		if !exists {
+			report.ErrorMsg = "Unable to correct size of missing object"
+			break
+		}
+
+		if object.Compression == types.ObjectCompressionNone && object.Encryption == types.ObjectEncryptionNone {
+			err = gws.backendRepo.UpdateObjectSizeByExternalId(ctx, object.ExternalId, int(actualSize))
+		} else {
+			err = gws.backendRepo.UpdateObjectCompressionByExternalId(ctx, object.ExternalId, object.Compression, actualSize)
+		}
+		report.Repaired = err == nil
+	case pb.ObjectRepairMode_OBJECT_REPAIR_MARK_INCOMPLETE:
+		err = gws.backendRepo.MarkObjectIncompleteByExternalId(ctx, object.ExternalId)
+		report.Repaired = err == nil
+	}
+
+	if err != nil {
+		log.Error().Err(err).Str("object_id", object.ExternalId).Msg("unable to repair object")
+		report.ErrorMsg = "Unable to repair object"
+	}
+
+	metrics.RecordObjectSizeDiscrepancy(authInfo.Workspace.Name, report.Repaired)
+	return report
+}
+
+// objectStoredSize returns the number of bytes an object occupies in the storage backend
+func (gws *GatewayService) objectStoredSize(ctx context.Context, workspace *types.Workspace, storageClient *clients.WorkspaceStorageClient, object types.Object) (int64, bool, error) {
+	if storageClient == nil {
+		fileInfo, err := os.Stat(localObjectPath(workspace.Name, object.ExternalId))
+		if os.IsNotExist(err) {
+			return 0, false, nil
+		}
+
+		if err != nil {
+			return 0, false, err
+		}
+
+		return fileInfo.Size(), true, nil
+	}
+
+	storageCtx, cancel := gws.withStorageTimeout(ctx)
+	defer cancel()
+
+	exists, err := storageClient.Exists(storageCtx, workspaceObjectKey(object.ExternalId))
+	if err != nil || !exists {
+		return 0, false, err
+	}
+
+	_, output, err := storageClient.Head(storageCtx, workspaceObjectKey(object.ExternalId))
+	if err != nil {
+		return 0, false, err
+	}
+
+	return aws.ToInt64(output.ContentLength), true, nil
+}
@@ -0,0 +1,344 @@
+package gatewayservices
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/clients"
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	auditActionObjectCopy = "object.copy"
+)
+
+var errCopyTargetNotAuthorized = errors.New("caller is not a member of the target workspace")
+
+// resolveCopyTarget returns the caller's access to the workspace an object is being copied into. Workspaces
+// are only reachable through their tokens, so callers prove membership with a token for the target workspace;
+// cluster admins may copy into any workspace.
+func (gws *GatewayService) resolveCopyTarget(ctx context.Context, authInfo *auth.AuthInfo, workspaceId, tokenKey string) (*auth.AuthInfo, error) {
+	if authInfo.Token.TokenType == types.TokenTypeClusterAdmin {
+		workspace, err := gws.backendRepo.GetWorkspaceByExternalId(ctx, workspaceId)
+		if err != nil {
+			return nil, err
+		}
+
+		workspace, err = gws.backendRepo.GetWorkspace(ctx, workspace.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		return &auth.AuthInfo{Workspace: workspace, Token: authInfo.Token}, nil
+	}
+
+	if tokenKey == "" {
+		return nil, errCopyTargetNotAuthorized
+	}
+
+	token, workspace, err := gws.backendRepo.AuthorizeToken(ctx, tokenKey)
+	if err != nil || token.DisabledByClusterAdmin || workspace.ExternalId != workspaceId {
+		return nil, errCopyTargetNotAuthorized
+	}
+
+	targetAuthInfo := &auth.AuthInfo{Workspace: workspace, Token: token}
+	if !auth.HasPermission(targetAuthInfo, types.PermissionWrite) {
+		return nil, errCopyTargetNotAuthorized
+	}
+
+	return targetAuthInfo, nil
+}
+
+// copyObjectData copies an object's content between workspaces without involving the client. Objects in
+// storage behind the same endpoint are copied by the storage provider and plaintext objects on the gateway
+// filesystem are hard linked; anything else is streamed through the gateway and encrypted with the target
+// workspace's key if encryption is enabled. The target's compression, encryption and location are updated to
+// describe the copied bytes.
+func (gws *GatewayService) copyObjectData(ctx context.Context, source *types.Workspace, sourceObject *types.Object, target *types.Workspace, targetObject *types.Object) error {
+	if source.StorageAvailable() && target.StorageAvailable() && sameStorageEndpoint(source.Storage, target.Storage) {
+		copied, err := gws.copyStorageObject(ctx, source, sourceObject, target, targetObject)
+		if copied {
+			targetObject.Location = types.ObjectLocationWorkspaceStorage
+		}
+		if err != nil || copied {
+			return err
+		}
+	}
+
+	sourcePath := localObjectPath(source.Name, sourceObject.ExternalId)
+	targetPath := localObjectPath(target.Name, targetObject.ExternalId)
+
+	if !target.StorageAvailable() {
+		if err := os.MkdirAll(localObjectDir(target.Name), 0755); err != nil {
+			return err
+		}
+
+		// Encrypted content is bound to its workspace's key, so it's never linked into another workspace
+		if sourceObject.Encryption == types.ObjectEncryptionNone && !gws.appConfig.Storage.ObjectEncryption.Enabled {
+			if err := os.Link(sourcePath, targetPath); err == nil {
+				targetObject.Compression = sourceObject.Compression
+				targetObject.StoredSize = sourceObject.StoredSizeOrSize()
+				return nil
+			}
+		}
+	}
+
+	reader, err := gws.openObjectRange(ctx, source, sourceObject, 0, sourceObject.Size)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	if target.StorageAvailable() {
+		storageClient, err := clients.NewWorkspaceStorageClient(ctx, target.Name, target.Storage)
+		if err != nil {
+			return err
+		}
+
+		if err := storageClient.UploadWithReader(ctx, workspaceObjectKey(targetObject.ExternalId), reader); err != nil {
+			return err
+		}
+
+		targetObject.Location = types.ObjectLocationWorkspaceStorage
+		return nil
+	}
+
+	dataKey, err := gws.objectDataKey(ctx, target)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(targetPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer, err := common.NewObjectWriter(types.ObjectCompressionNone, dataKey, file)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(writer, reader); err != nil {
+		return err
+	}
+
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	if err := file.Sync(); err != nil {
+		return err
+	}
+
+	if fileInfo, err := file.Stat(); err == nil {
+		targetObject.StoredSize = fileInfo.Size()
+	}
+	targetObject.Encryption, targetObject.WrappedDataKey = objectEncryptionMetadata(dataKey)
+	return nil
+}
+
+// sameStorageEndpoint reports whether both workspaces' buckets can be reached with the default S3 client
+func sameStorageEndpoint(source, target *types.WorkspaceStorage) bool {
+	return source.StorageProvider() == types.WorkspaceStorageProviderS3 && target.StorageProvider() == types.WorkspaceStorageProviderS3 &&
+		source.EndpointUrl == target.EndpointUrl
+}
+
+// copyStorageObject copies an object between buckets behind the same storage endpoint. It reports false
+// if the source was never uploaded to storage, e.g. it was streamed to the gateway filesystem instead.
+func (gws *GatewayService) copyStorageObject(ctx context.Context, source *types.Workspace, sourceObject *types.Object, target *types.Workspace, targetObject *types.Object) (bool, error) {
+	sourceClient, err := clients.NewWorkspaceStorageClient(ctx, source.Name, source.Storage)
+	if err != nil {
+		return false, err
+	}
+
+	storageClient, err := clients.NewDefaultStorageClient(ctx, gws.appConfig)
+	if err != nil {
+		return false, err
+	}
+
+	storageCtx, cancel := gws.withStorageTimeout(ctx)
+	defer cancel()
+
+	sourceKey := workspaceObjectKey(sourceObject.ExternalId)
+	exists, err := sourceClient.Exists(storageCtx, sourceKey)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	err = storageClient.CopyObject(storageCtx, clients.CopyObjectInput{
+		SourceKey:             sourceKey,
+		SourceBucketName:      *source.Storage.BucketName,
+		DestinationKey:        workspaceObjectKey(targetObject.ExternalId),
+		DestinationBucketName: *target.Storage.BucketName,
+	})
+	return err == nil, err
+}
+
+// reusableCopyTarget reports whether an object already in the target workspace can stand in for a copy of
+// the source. Hashes are supplied by clients, so the digests the gateway computed from the uploaded bytes must
+// match as well, and the existing object must be stored with the same encryption as the source.
+func reusableCopyTarget(source, existing *types.Object) bool {
+	if existing.Incomplete || source.Digest == "" {
+		return false
+	}
+
+	return existing.Hash == source.Hash && existing.Digest == source.Digest && existing.Encryption == source.Encryption
+}
+
+func (gws *GatewayService) CopyObject(ctx context.Context, in *pb.CopyObjectRequest) (*pb.CopyObjectResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionWrite) {
+		return &pb.CopyObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	sourceObject, err := gws.backendRepo.GetObjectByExternalId(ctx, in.ObjectId, authInfo.Workspace.Id)
+	if err != nil || sourceObject.Incomplete {
+		return &pb.CopyObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Object not found",
+		}, nil
+	}
+
+	if accessible, err := gws.objectAccessible(ctx, authInfo, &sourceObject, types.ResourceAccessRead); err != nil || !accessible {
+		return &pb.CopyObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	targetAuthInfo, err := gws.resolveCopyTarget(ctx, authInfo, in.TargetWorkspaceId, in.TargetWorkspaceToken)
+	if err != nil {
+		return &pb.CopyObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Target workspace not found or access denied",
+		}, nil
+	}
+	target := targetAuthInfo.Workspace
+
+	if target.Id == authInfo.Workspace.Id {
+		return &pb.CopyObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Object already belongs to the target workspace",
+		}, nil
+	}
+
+	existingObject, err := gws.backendRepo.GetObjectByHash(ctx, sourceObject.Hash, target.Id)
+	if err == nil {
+		if accessible, err := gws.objectAccessible(ctx, targetAuthInfo, existingObject, types.ResourceAccessWrite); err != nil || !accessible {
+			gws.auditObjectCopy(authInfo, target, &sourceObject, existingObject.ExternalId, errCopyTargetNotAuthorized)
+			return &pb.CopyObjectResponse{
+				Ok:       false,
+				ErrorMsg: "Unauthorized Access",
+			}, nil
+		}
+	}
+
+	// Identical content already in the target workspace is reused rather than copied again
+	if err == nil && reusableCopyTarget(&sourceObject, existingObject) {
+		gws.auditObjectCopy(authInfo, target, &sourceObject, existingObject.ExternalId, nil)
+		return &pb.CopyObjectResponse{
+			Ok:       true,
+			ObjectId: existingObject.ExternalId,
+		}, nil
+	}
+
+	usage, err := gws.objectStorageUsage(ctx, target.Id)
+	if err != nil {
+		return &pb.CopyObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to check storage usage",
+		}, nil
+	}
+
+	if err := gws.checkObjectQuota(usage, sourceObject.Size); err != nil {
+		gws.auditObjectCopy(authInfo, target, &sourceObject, "", err)
+		return &pb.CopyObjectResponse{
+			Ok:       false,
+			ErrorMsg: objectQuotaExceededErrMessage,
+		}, nil
+	}
+
+	targetObject, err := gws.backendRepo.CreateObject(ctx, sourceObject.Hash, sourceObject.Size, target.Id)
+	if err != nil {
+		gws.auditObjectCopy(authInfo, target, &sourceObject, "", err)
+		return &pb.CopyObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to create object",
+		}, nil
+	}
+
+	err = gws.copyObjectData(ctx, authInfo.Workspace, &sourceObject, target, targetObject)
+	if err == nil && sourceObject.Digest != "" {
+		err = gws.backendRepo.UpdateObjectDigestByExternalId(ctx, targetObject.ExternalId, sourceObject.Digest)
+	}
+	if err == nil && (targetObject.Compression != types.ObjectCompressionNone || targetObject.Encryption != types.ObjectEncryptionNone) {
+		err = gws.backendRepo.UpdateObjectCompressionByExternalId(ctx, targetObject.ExternalId, targetObject.Compression, targetObject.StoredSize)
+	}
+	if err == nil && targetObject.Encryption != types.ObjectEncryptionNone {
+		err = gws.backendRepo.UpdateObjectEncryptionByExternalId(ctx, targetObject.ExternalId, targetObject.Encryption, targetObject.WrappedDataKey)
+	}
+	if err == nil && targetObject.Location != types.ObjectLocationLocal {
+		err = gws.backendRepo.UpdateObjectLocationByExternalId(ctx, targetObject.ExternalId, targetObject.Location)
+	}
+
+	gws.auditObjectCopy(authInfo, target, &sourceObject, targetObject.ExternalId, err)
+
+	if err != nil {
+		log.Error().Err(err).Str("object_id", sourceObject.ExternalId).Str("target_workspace_id", target.ExternalId).Msg("failed to copy object")
+		os.Remove(localObjectPath(target.Name, targetObject.ExternalId))
+		gws.backendRepo.DeleteObjectByExternalId(ctx, targetObject.ExternalId)
+
+		if isStorageTimeout(err) {
+			return &pb.CopyObjectResponse{
+				Ok:       false,
+				ErrorMsg: storageOperationTimeoutErrMessage,
+			}, nil
+		}
+
+		return &pb.CopyObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to copy object",
+		}, nil
+	}
+
+	gws.notifyObjectEvent(ctx, target, types.ObjectWebhookEventCreated, targetObject)
+
+	return &pb.CopyObjectResponse{
+		Ok:       true,
+		ObjectId: targetObject.ExternalId,
+	}, nil
+}
+
+// auditObjectCopy records a copy in the target workspace's audit trail, since that's where the new object lives
+func (gws *GatewayService) auditObjectCopy(authInfo *auth.AuthInfo, target *types.Workspace, sourceObject *types.Object, targetObjectId string, err error) {
+	event := common.AuditEvent{
+		Action:       auditActionObjectCopy,
+		WorkspaceId:  target.ExternalId,
+		ResourceType: "object",
+		ResourceId:   targetObjectId,
+		Outcome:      auditOutcome(err),
+		Reason:       errorMessage(err),
+		Attributes: map[string]interface{}{
+			"hash":                sourceObject.Hash,
+			"size":                sourceObject.Size,
+			"source_object_id":    sourceObject.ExternalId,
+			"source_workspace_id": authInfo.Workspace.ExternalId,
+		},
+	}
+	if authInfo.Token != nil {
+		event.Principal = authInfo.Token.ExternalId
+	}
+
+	gws.auditLogger.Log(event)
+}
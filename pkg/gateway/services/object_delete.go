@@ -0,0 +1,134 @@
+package gatewayservices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	maxDeleteObjectsBatchSize = 100
+	auditActionObjectDelete   = "object.delete"
+)
+
+// checkObjectReferences returns *types.ErrObjectInUse if an active deployment or in-flight task uses the object
+func (gws *GatewayService) checkObjectReferences(ctx context.Context, object *types.Object) error {
+	references, err := gws.backendRepo.GetObjectReferences(ctx, object.Id)
+	if err != nil {
+		return err
+	}
+
+	if references.InUse() {
+		return &types.ErrObjectInUse{ObjectId: object.ExternalId, References: *references}
+	}
+
+	return nil
+}
+
+// deleteWorkspaceObject deletes one of the caller's objects and returns a message suitable for the client on failure
+func (gws *GatewayService) deleteWorkspaceObject(ctx context.Context, authInfo *auth.AuthInfo, objectId string, force bool) string {
+	object, err := gws.backendRepo.GetObjectByExternalId(ctx, objectId, authInfo.Workspace.Id)
+	if err != nil {
+		return "Object not found"
+	}
+
+	if accessible, err := gws.objectAccessible(ctx, authInfo, &object, types.ResourceAccessWrite); err != nil || !accessible {
+		return "Unauthorized Access"
+	}
+
+	if !force {
+		if err := gws.checkObjectReferences(ctx, &object); err != nil {
+			gws.auditObject(authInfo, auditActionObjectDelete, object.ExternalId, object.Hash, object.Size, err.Error())
+
+			var inUseErr *types.ErrObjectInUse
+			if errors.As(err, &inUseErr) {
+				return fmt.Sprintf("Object is in use by %d active deployment(s) and %d in-flight task(s), use force to delete anyway",
+					inUseErr.References.ActiveDeployments, inUseErr.References.InflightTasks)
+			}
+
+			return "Unable to check object references"
+		}
+	}
+
+	if err := gws.deleteObject(ctx, authInfo.Workspace, &object); err != nil {
+		gws.auditObject(authInfo, auditActionObjectDelete, object.ExternalId, object.Hash, object.Size, err.Error())
+
+		var lockErr *types.ErrObjectLocked
+		if errors.As(err, &lockErr) {
+			return lockErr.Error()
+		}
+
+		log.Error().Err(err).Str("object_id", object.ExternalId).Msg("failed to delete object")
+		return "Unable to delete object"
+	}
+
+	gws.auditObject(authInfo, auditActionObjectDelete, object.ExternalId, object.Hash, object.Size, "")
+	gws.notifyObjectEvent(ctx, authInfo.Workspace, types.ObjectWebhookEventDeleted, &object)
+	return ""
+}
+
+func (gws *GatewayService) DeleteObject(ctx context.Context, in *pb.DeleteObjectRequest) (*pb.DeleteObjectResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionWrite) {
+		return &pb.DeleteObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	if errMsg := gws.deleteWorkspaceObject(ctx, authInfo, in.ObjectId, in.Force); errMsg != "" {
+		return &pb.DeleteObjectResponse{
+			Ok:       false,
+			ErrorMsg: errMsg,
+		}, nil
+	}
+
+	return &pb.DeleteObjectResponse{
+		Ok: true,
+	}, nil
+}
+
+func (gws *GatewayService) DeleteObjects(ctx context.Context, in *pb.DeleteObjectsRequest) (*pb.DeleteObjectsResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionWrite) {
+		return &pb.DeleteObjectsResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	if len(in.ObjectIds) > maxDeleteObjectsBatchSize {
+		return &pb.DeleteObjectsResponse{
+			Ok:       false,
+			ErrorMsg: fmt.Sprintf("At most %d objects can be deleted at once", maxDeleteObjectsBatchSize),
+		}, nil
+	}
+
+	ok := true
+	results := make([]*pb.DeleteObjectResult, 0, len(in.ObjectIds))
+	for _, objectId := range in.ObjectIds {
+		errMsg := gws.deleteWorkspaceObject(ctx, authInfo, objectId, in.Force)
+		if errMsg != "" {
+			ok = false
+		}
+
+		results = append(results, &pb.DeleteObjectResult{
+			ObjectId: objectId,
+			Ok:       errMsg == "",
+			ErrorMsg: errMsg,
+		})
+	}
+
+	// Ok reports whether every object was deleted; per object results say which ones failed
+	return &pb.DeleteObjectsResponse{
+		Ok:      ok,
+		Results: results,
+	}, nil
+}
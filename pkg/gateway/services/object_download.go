@@ -0,0 +1,261 @@
+package gatewayservices
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/clients"
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	objectStreamChunkSize       = 1024 * 1024
+	defaultObjectGetExpirationS = 60 * 60
+	// S3 rejects presigned URLs valid for longer than 7 days
+	maxObjectGetExpirationS     = 60 * 60 * 24 * 7
+	auditActionObjectPresignGet = "object.presign_get"
+)
+
+func (gws *GatewayService) GetObjectStream(in *pb.GetObjectStreamRequest, stream pb.GatewayService_GetObjectStreamServer) error {
+	ctx := stream.Context()
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionRead) {
+		return status.Error(codes.PermissionDenied, "Unauthorized Access")
+	}
+
+	if in.Offset < 0 || in.Length < 0 {
+		return status.Error(codes.InvalidArgument, "Offset and length must be non-negative")
+	}
+
+	object, err := gws.backendRepo.GetObjectByExternalId(ctx, in.ObjectId, authInfo.Workspace.Id)
+	if err != nil || object.Incomplete {
+		return status.Error(codes.NotFound, "Object not found")
+	}
+
+	if accessible, err := gws.objectAccessible(ctx, authInfo, &object, types.ResourceAccessRead); err != nil || !accessible {
+		return status.Error(codes.PermissionDenied, "Unauthorized Access")
+	}
+
+	gws.touchObject(ctx, object.ExternalId)
+
+	if in.Offset > object.Size {
+		return status.Error(codes.OutOfRange, "Offset is beyond the end of the object")
+	}
+
+	length := object.Size - in.Offset
+	if in.Length > 0 && in.Length < length {
+		length = in.Length
+	}
+
+	reader, err := gws.openObjectRange(ctx, authInfo.Workspace, &object, in.Offset, length)
+	if err != nil {
+		log.Error().Err(err).Str("object_id", object.ExternalId).Msg("GetObjectStream: error opening object")
+		return status.Error(codes.Internal, "Unable to read object")
+	}
+	defer reader.Close()
+
+	offset := in.Offset
+	buf := make([]byte, objectStreamChunkSize)
+	for {
+		n, err := io.ReadFull(reader, buf)
+		if n > 0 {
+			if sendErr := stream.Send(&pb.GetObjectStreamResponse{
+				Content: buf[:n],
+				Offset:  offset,
+				Size:    object.Size,
+			}); sendErr != nil {
+				return sendErr
+			}
+			offset += int64(n)
+		}
+
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil
+		}
+
+		if err != nil {
+			log.Error().Err(err).Str("object_id", object.ExternalId).Msg("GetObjectStream: error reading object")
+			return status.Error(codes.Internal, "Unable to read object")
+		}
+	}
+}
+
+// openObjectRange returns a reader over length bytes of the object's original content starting at offset.
+// Objects written to the gateway filesystem are read locally, anything else is read from workspace storage.
+func (gws *GatewayService) openObjectRange(ctx context.Context, workspace *types.Workspace, object *types.Object, offset, length int64) (io.ReadCloser, error) {
+	file, err := os.Open(localObjectPath(workspace.Name, object.ExternalId))
+	if err == nil {
+		if object.Compression == types.ObjectCompressionNone && object.Encryption == types.ObjectEncryptionNone {
+			if _, err := file.Seek(offset, io.SeekStart); err != nil {
+				file.Close()
+				return nil, err
+			}
+
+			return &limitedReadCloser{Reader: io.LimitReader(file, length), closers: []io.Closer{file}}, nil
+		}
+
+		dataKey, err := gws.objectReadKey(object)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+
+		// Compressed or encrypted content can't be seeked, so skip forward to the requested offset
+		decompressed, err := common.NewObjectReader(object.Compression, dataKey, file)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+
+		if _, err := io.CopyN(io.Discard, decompressed, offset); err != nil {
+			decompressed.Close()
+			file.Close()
+			return nil, err
+		}
+
+		return &limitedReadCloser{Reader: io.LimitReader(decompressed, length), closers: []io.Closer{decompressed, file}}, nil
+	}
+
+	if !os.IsNotExist(err) || !workspace.StorageAvailable() {
+		return nil, err
+	}
+
+	storageClient, err := clients.NewWorkspaceStorageClient(ctx, workspace.Name, workspace.Storage)
+	if err != nil {
+		return nil, err
+	}
+
+	if length == 0 {
+		return io.NopCloser(io.LimitReader(nil, 0)), nil
+	}
+
+	return storageClient.DownloadRangeWithReader(ctx, workspaceObjectKey(object.ExternalId), offset, length)
+}
+
+// limitedReadCloser closes every underlying reader once the caller is done with the limited view
+type limitedReadCloser struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (r *limitedReadCloser) Close() error {
+	var err error
+	for _, c := range r.closers {
+		if closeErr := c.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+func (gws *GatewayService) GetObjectURL(ctx context.Context, in *pb.GetObjectURLRequest) (*pb.GetObjectURLResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionRead) {
+		return &pb.GetObjectURLResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	if !authInfo.Workspace.StorageAvailable() {
+		return &pb.GetObjectURLResponse{
+			Ok:       false,
+			ErrorMsg: "Workspace storage is not available",
+		}, nil
+	}
+
+	expiresIn := int64(in.ExpiresInSeconds)
+	if expiresIn == 0 {
+		expiresIn = defaultObjectGetExpirationS
+	}
+
+	if expiresIn > maxObjectGetExpirationS {
+		return &pb.GetObjectURLResponse{
+			Ok:       false,
+			ErrorMsg: fmt.Sprintf("Expiration must be %d seconds or less", maxObjectGetExpirationS),
+		}, nil
+	}
+
+	object, err := gws.backendRepo.GetObjectByExternalId(ctx, in.ObjectId, authInfo.Workspace.Id)
+	if err != nil || object.Incomplete {
+		return &pb.GetObjectURLResponse{
+			Ok:       false,
+			ErrorMsg: "Object not found",
+		}, nil
+	}
+
+	if accessible, err := gws.objectAccessible(ctx, authInfo, &object, types.ResourceAccessRead); err != nil || !accessible {
+		return &pb.GetObjectURLResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	// Objects are read from a replica in the requested region when one is available
+	storage, region := authInfo.Workspace.Storage, object.Region
+	if in.Region != "" && in.Region != object.Region {
+		replicas, err := gws.backendRepo.ListObjectReplicas(ctx, object.Id)
+		if err == nil {
+			if replicaStorage := gws.objectReplicaStorage(ctx, authInfo.Workspace, replicas, in.Region); replicaStorage != nil {
+				storage, region = replicaStorage, in.Region
+			}
+		}
+	}
+
+	storageClient, err := clients.NewWorkspaceStorageClient(ctx, authInfo.Workspace.Name, storage)
+	if err != nil {
+		return &pb.GetObjectURLResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to create storage client",
+		}, nil
+	}
+
+	storageCtx, cancel := gws.withStorageTimeout(ctx)
+	defer cancel()
+
+	key := workspaceObjectKey(object.ExternalId)
+	exists, err := storageClient.Exists(storageCtx, key)
+	if err == nil && exists {
+		var url string
+		url, err = storageClient.GeneratePresignedGetURL(storageCtx, key, expiresIn)
+		if err == nil {
+			gws.touchObject(ctx, object.ExternalId)
+			gws.auditObject(authInfo, auditActionObjectPresignGet, object.ExternalId, object.Hash, object.Size, "")
+			return &pb.GetObjectURLResponse{
+				Ok:        true,
+				Url:       url,
+				ExpiresAt: timestamppb.New(time.Now().Add(time.Duration(expiresIn) * time.Second)),
+				Region:    region,
+			}, nil
+		}
+	}
+
+	if isStorageTimeout(err) {
+		return nil, status.Error(codes.DeadlineExceeded, storageOperationTimeoutErrMessage)
+	}
+
+	if err == nil {
+		return &pb.GetObjectURLResponse{
+			Ok:       false,
+			ErrorMsg: "Object not found in workspace storage",
+		}, nil
+	}
+
+	gws.auditObject(authInfo, auditActionObjectPresignGet, object.ExternalId, object.Hash, object.Size, err.Error())
+	return &pb.GetObjectURLResponse{
+		Ok:       false,
+		ErrorMsg: "Unable to generate presigned URL",
+	}, nil
+}
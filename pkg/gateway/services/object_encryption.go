@@ -0,0 +1,66 @@
+package gatewayservices
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+// objectDataKey returns the workspace's key for encrypting new object content, creating it on first use.
+// It returns nil if object encryption is disabled.
+func (gws *GatewayService) objectDataKey(ctx context.Context, workspace *types.Workspace) (*common.ObjectDataKey, error) {
+	config := gws.appConfig.Storage.ObjectEncryption
+	if !config.Enabled {
+		return nil, nil
+	}
+
+	wrapper, err := common.NewObjectKeyWrapper(config)
+	if err != nil {
+		return nil, err
+	}
+
+	wrappedKey, err := gws.backendRepo.GetWorkspaceObjectKey(ctx, workspace.Id)
+	if errors.Is(err, sql.ErrNoRows) {
+		dataKey, err := common.GenerateObjectDataKey()
+		if err != nil {
+			return nil, err
+		}
+
+		wrapped, err := wrapper.WrapKey(dataKey)
+		if err != nil {
+			return nil, err
+		}
+
+		// Concurrent uploads may race to create the key, in which case they all use the one that was stored first
+		wrappedKey, err = gws.backendRepo.CreateWorkspaceObjectKey(ctx, workspace.Id, wrapped)
+		if err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := wrapper.UnwrapKey(wrappedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &common.ObjectDataKey{Plaintext: plaintext, Wrapped: wrappedKey}, nil
+}
+
+// objectReadKey returns the key an object's content was encrypted with, or nil if it's stored in plaintext
+func (gws *GatewayService) objectReadKey(object *types.Object) ([]byte, error) {
+	return common.UnwrapObjectDataKey(gws.appConfig.Storage.ObjectEncryption, object.Encryption, object.WrappedDataKey)
+}
+
+// objectEncryptionMetadata returns what's recorded on an object whose content was written with dataKey
+func objectEncryptionMetadata(dataKey *common.ObjectDataKey) (types.ObjectEncryption, string) {
+	if dataKey == nil {
+		return types.ObjectEncryptionNone, ""
+	}
+
+	return types.ObjectEncryptionAES256GCM, dataKey.Wrapped
+}
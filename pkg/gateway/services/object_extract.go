@@ -0,0 +1,307 @@
+package gatewayservices
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/beam-cloud/beta9/pkg/abstractions/volume"
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/clients"
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/sys/unix"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	auditActionObjectExtract       = "object.extract"
+	defaultObjectExtractMaxFiles   = 100000
+	defaultObjectExtractMaxBytes   = 10 * 1024 * 1024 * 1024
+	objectExtractDestinationErrMsg = "Exactly one of prefix or volume_name is required"
+)
+
+var errExtractPathOutsideRoot = errors.New("extraction path resolves outside the destination")
+
+// objectExtractLimits returns the limits on what a single archive may unpack to
+func (gws *GatewayService) objectExtractLimits() common.ArchiveLimits {
+	limits := common.ArchiveLimits{
+		MaxFiles: gws.appConfig.GatewayService.ObjectExtractMaxFiles,
+		MaxBytes: gws.appConfig.GatewayService.ObjectExtractMaxBytes,
+	}
+
+	if limits.MaxFiles <= 0 {
+		limits.MaxFiles = defaultObjectExtractMaxFiles
+	}
+
+	if limits.MaxBytes <= 0 {
+		limits.MaxBytes = defaultObjectExtractMaxBytes
+	}
+
+	return limits
+}
+
+// objectExtractWriter returns where the archive is unpacked to. Entries go to keys under a workspace
+// storage prefix when the workspace has storage, and to files under a volume directory otherwise.
+func (gws *GatewayService) objectExtractWriter(ctx context.Context, workspace *types.Workspace, in *pb.ExtractObjectRequest) (common.ArchiveEntryWriter, string, error) {
+	if (in.Prefix == "") == (in.VolumeName == "") {
+		return nil, objectExtractDestinationErrMsg, nil
+	}
+
+	var storageClient *clients.WorkspaceStorageClient
+	if workspace.StorageAvailable() {
+		client, err := clients.NewWorkspaceStorageClient(ctx, workspace.Name, workspace.Storage)
+		if err != nil {
+			return nil, "", err
+		}
+		storageClient = client
+	}
+
+	if in.Prefix != "" {
+		if storageClient == nil {
+			return nil, "Workspace storage is not enabled for this workspace", nil
+		}
+
+		prefix, err := common.SanitizeArchivePath(in.Prefix)
+		if err != nil {
+			return nil, "Invalid prefix", nil
+		}
+
+		root := strings.SplitN(prefix, "/", 2)[0]
+		if root == types.DefaultObjectPrefix || root == types.DefaultVolumesPrefix {
+			return nil, "Prefix is reserved", nil
+		}
+
+		return storageExtractWriter(ctx, storageClient, prefix), "", nil
+	}
+
+	volumePath := ""
+	if in.VolumePath != "" {
+		sanitized, err := common.SanitizeArchivePath(in.VolumePath)
+		if err != nil {
+			return nil, "Invalid volume path", nil
+		}
+		volumePath = sanitized
+	}
+
+	vol, err := gws.backendRepo.GetVolume(ctx, workspace.Id, in.VolumeName)
+	if err != nil {
+		return nil, "Volume not found", nil
+	}
+
+	quota, err := volume.NewVolumeQuota(ctx, gws.backendRepo, gws.appConfig.Storage.VolumeQuotaBytes, workspace, vol)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if storageClient != nil {
+		return quotaExtractWriter(quota, storageExtractWriter(ctx, storageClient, path.Join(types.DefaultVolumesPrefix, vol.ExternalId, volumePath))), "", nil
+	}
+
+	return quotaExtractWriter(quota, localExtractWriter(volume.JoinVolumePath(workspace.Name, vol.ExternalId), volumePath)), "", nil
+}
+
+// quotaExtractWriter fails the extraction once the entries written exceed the volume's quota
+func quotaExtractWriter(quota *volume.VolumeQuota, write common.ArchiveEntryWriter) common.ArchiveEntryWriter {
+	return func(name string, mode os.FileMode, r io.Reader) error {
+		return write(name, mode, quota.Reader(r))
+	}
+}
+
+func storageExtractWriter(ctx context.Context, storageClient *clients.WorkspaceStorageClient, prefix string) common.ArchiveEntryWriter {
+	return func(name string, mode os.FileMode, r io.Reader) error {
+		return storageClient.UploadWithReader(ctx, path.Join(prefix, name), r)
+	}
+}
+
+// localExtractWriter writes entries under volumePath in the volume at volumeRoot. Entry names can't climb
+// out, but the volume may already hold symlinks, or have them swapped in while extracting. So every directory is
+// opened relative to its parent without following symlinks, and each file is written to a new temporary file
+// that's renamed over the entry.
+func localExtractWriter(volumeRoot, volumePath string) common.ArchiveEntryWriter {
+	return func(name string, mode os.FileMode, r io.Reader) error {
+		entryPath := path.Join(volumePath, name)
+
+		dirFd, err := openExtractDir(volumeRoot, path.Dir(entryPath))
+		if err != nil {
+			return err
+		}
+		defer unix.Close(dirFd)
+
+		base := path.Base(entryPath)
+		tmpName := "." + base + "." + uuid.New().String()[:8] + ".tmp"
+		fd, err := unix.Openat(dirFd, tmpName, unix.O_WRONLY|unix.O_CREAT|unix.O_EXCL|unix.O_NOFOLLOW|unix.O_CLOEXEC, uint32(mode.Perm()|0600))
+		if err != nil {
+			return err
+		}
+
+		file := os.NewFile(uintptr(fd), tmpName)
+		if _, err := io.Copy(file, r); err != nil {
+			file.Close()
+			unix.Unlinkat(dirFd, tmpName, 0)
+			return err
+		}
+
+		if err := file.Sync(); err != nil {
+			file.Close()
+			unix.Unlinkat(dirFd, tmpName, 0)
+			return err
+		}
+
+		if err := file.Close(); err != nil {
+			unix.Unlinkat(dirFd, tmpName, 0)
+			return err
+		}
+
+		// Renaming replaces an existing symlink at the entry rather than following it
+		if err := unix.Renameat(dirFd, tmpName, dirFd, base); err != nil {
+			unix.Unlinkat(dirFd, tmpName, 0)
+			return err
+		}
+
+		return nil
+	}
+}
+
+// openExtractDir opens dir below root, creating the components that don't exist yet. Each component is opened
+// relative to the one before it and never through a symlink, so nothing is created or opened outside root.
+// The caller closes the returned descriptor.
+func openExtractDir(root, dir string) (int, error) {
+	fd, err := unix.Open(root, unix.O_RDONLY|unix.O_DIRECTORY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return -1, err
+	}
+
+	for _, component := range strings.Split(dir, "/") {
+		if component == "" || component == "." {
+			continue
+		}
+
+		if component == ".." {
+			unix.Close(fd)
+			return -1, errExtractPathOutsideRoot
+		}
+
+		if err := unix.Mkdirat(fd, component, 0755); err != nil && !errors.Is(err, unix.EEXIST) {
+			unix.Close(fd)
+			return -1, err
+		}
+
+		next, err := unix.Openat(fd, component, unix.O_RDONLY|unix.O_DIRECTORY|unix.O_NOFOLLOW|unix.O_CLOEXEC, 0)
+		unix.Close(fd)
+		if err != nil {
+			if errors.Is(err, unix.ELOOP) {
+				return -1, errExtractPathOutsideRoot
+			}
+			return -1, err
+		}
+
+		fd = next
+	}
+
+	return fd, nil
+}
+
+// ExtractObject unpacks a zip or tar object on the gateway so containers can mount its content directly
+// instead of unpacking it themselves. Entries written before a failure are left in place.
+func (gws *GatewayService) ExtractObject(ctx context.Context, in *pb.ExtractObjectRequest) (*pb.ExtractObjectResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionWrite) {
+		return &pb.ExtractObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	object, err := gws.backendRepo.GetObjectByExternalId(ctx, in.ObjectId, authInfo.Workspace.Id)
+	if err != nil || object.Incomplete {
+		return &pb.ExtractObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Object not found",
+		}, nil
+	}
+
+	if accessible, err := gws.objectAccessible(ctx, authInfo, &object, types.ResourceAccessRead); err != nil || !accessible {
+		return &pb.ExtractObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	write, errMsg, err := gws.objectExtractWriter(ctx, authInfo.Workspace, in)
+	if err != nil {
+		log.Error().Err(err).Str("object_id", object.ExternalId).Msg("unable to prepare object extraction")
+		return &pb.ExtractObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to prepare extraction",
+		}, nil
+	}
+
+	if errMsg != "" {
+		return &pb.ExtractObjectResponse{
+			Ok:       false,
+			ErrorMsg: errMsg,
+		}, nil
+	}
+
+	objectPath, err := gws.ResolveObjectPath(ctx, authInfo, object.ExternalId)
+	if err != nil {
+		return &pb.ExtractObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Object not found",
+		}, nil
+	}
+
+	file, err := os.Open(objectPath)
+	if err != nil {
+		return &pb.ExtractObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Object not found",
+		}, nil
+	}
+	defer file.Close()
+
+	// Extraction writes as much as an upload does, so it shares the upload slots
+	release, err := gws.uploadLimiter.Acquire(ctx)
+	if err != nil {
+		return nil, status.Error(codes.ResourceExhausted, "Too many concurrent uploads, try again later")
+	}
+	defer release()
+
+	summary, err := common.ExtractArchive(file, gws.objectExtractLimits(), write)
+	gws.auditObject(authInfo, auditActionObjectExtract, object.ExternalId, object.Hash, object.Size, errorMessage(err))
+
+	var quotaErr *types.ErrVolumeQuotaExceeded
+	if errors.Is(err, common.ErrUnsupportedArchive) || errors.Is(err, common.ErrUnsafeArchivePath) || errors.Is(err, common.ErrArchiveTooLarge) || errors.As(err, &quotaErr) {
+		return &pb.ExtractObjectResponse{
+			Ok:         false,
+			ErrorMsg:   err.Error(),
+			FileCount:  int64(summary.Files),
+			TotalBytes: summary.Bytes,
+		}, nil
+	}
+
+	if err != nil {
+		log.Error().Err(err).Str("object_id", object.ExternalId).Msg("failed to extract object")
+		return &pb.ExtractObjectResponse{
+			Ok:         false,
+			ErrorMsg:   "Unable to extract object",
+			FileCount:  int64(summary.Files),
+			TotalBytes: summary.Bytes,
+		}, nil
+	}
+
+	return &pb.ExtractObjectResponse{
+		Ok:         true,
+		FileCount:  int64(summary.Files),
+		TotalBytes: summary.Bytes,
+	}, nil
+}
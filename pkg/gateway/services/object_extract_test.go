@@ -0,0 +1,52 @@
+package gatewayservices
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalExtractWriter(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+	write := localExtractWriter(root, "data")
+
+	require.NoError(t, write("nested/file.txt", 0644, bytes.NewReader([]byte("hello"))))
+	content, err := os.ReadFile(filepath.Join(root, "data", "nested", "file.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(content))
+
+	// Nothing is created through a symlinked directory, not even the directories leading to the entry
+	require.NoError(t, os.Symlink(outside, filepath.Join(root, "data", "link")))
+	err = write("link/a/b/x", 0644, bytes.NewReader([]byte("hello")))
+	assert.ErrorIs(t, err, errExtractPathOutsideRoot)
+	_, err = os.Lstat(filepath.Join(outside, "a"))
+	assert.True(t, os.IsNotExist(err))
+
+	// A symlink at the entry itself is replaced rather than written through
+	target := filepath.Join(outside, "target")
+	require.NoError(t, os.WriteFile(target, []byte("keep"), 0644))
+	require.NoError(t, os.Symlink(target, filepath.Join(root, "data", "planted")))
+	require.NoError(t, write("planted", 0644, bytes.NewReader([]byte("hello"))))
+
+	content, err = os.ReadFile(target)
+	require.NoError(t, err)
+	assert.Equal(t, "keep", string(content))
+
+	info, err := os.Lstat(filepath.Join(root, "data", "planted"))
+	require.NoError(t, err)
+	assert.True(t, info.Mode().IsRegular())
+
+	// No temporary files are left behind
+	entries, err := os.ReadDir(filepath.Join(root, "data"))
+	require.NoError(t, err)
+	names := []string{}
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.ElementsMatch(t, []string{"nested", "link", "planted"}, names)
+}
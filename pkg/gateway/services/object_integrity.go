@@ -0,0 +1,289 @@
+package gatewayservices
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/clients"
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/metrics"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultObjectIntegrityInterval   = time.Hour
+	defaultObjectIntegritySampleSize = 100
+	defaultCorruptedObjectsLimit     = 100
+	maxCorruptedObjectsLimit         = 1000
+	auditActionObjectQuarantine      = "object.quarantine"
+
+	objectIntegrityResultOk        = "ok"
+	objectIntegrityResultCorrupted = "corrupted"
+	objectIntegrityResultError     = "error"
+)
+
+// auditObjectIntegrity periodically re-reads a sample of stored objects and compares them with their recorded
+// size and hash. Only one gateway replica audits at a time.
+func (gws *GatewayService) auditObjectIntegrity() {
+	config := gws.appConfig.Storage.ObjectIntegrityAudit
+	if !config.Enabled {
+		return
+	}
+
+	interval := config.Interval
+	if interval <= 0 {
+		interval = defaultObjectIntegrityInterval
+	}
+
+	sampleSize := config.SampleSize
+	if sampleSize <= 0 {
+		sampleSize = defaultObjectIntegritySampleSize
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lock := common.NewRedisLock(gws.redisClient)
+	lockKey := common.RedisKeys.GatewayObjectIntegrityLock()
+
+	for {
+		select {
+		case <-gws.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := lock.Acquire(gws.ctx, lockKey, common.RedisLockOptions{TtlS: int(interval.Seconds()), Retries: 0}); err != nil {
+				continue
+			}
+
+			gws.auditObjectSample(sampleSize, config.Quarantine)
+			lock.Release(lockKey)
+		}
+	}
+}
+
+func (gws *GatewayService) auditObjectSample(sampleSize int, quarantine bool) {
+	objects, err := gws.backendRepo.ListObjectsForIntegrityCheck(gws.ctx, sampleSize)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to list objects for integrity check")
+		return
+	}
+
+	workspaces := map[uint]*types.Workspace{}
+	corrupted := 0
+
+	for i := range objects {
+		object := &objects[i]
+
+		workspace, ok := workspaces[object.WorkspaceId]
+		if !ok {
+			workspace, err = gws.backendRepo.GetWorkspace(gws.ctx, object.WorkspaceId)
+			if err != nil {
+				continue
+			}
+			workspaces[object.WorkspaceId] = workspace
+		}
+
+		corruption, err := gws.checkObjectIntegrity(gws.ctx, workspace, object)
+		if err != nil {
+			// Transient failures are retried on a later pass, so the object isn't marked as checked
+			log.Warn().Err(err).Str("object_id", object.ExternalId).Msg("unable to check object integrity")
+			metrics.RecordObjectIntegrityCheck(workspace.Name, objectIntegrityResultError)
+			continue
+		}
+
+		if corruption == nil {
+			gws.backendRepo.DeleteObjectCorruption(gws.ctx, object.Id)
+			metrics.RecordObjectIntegrityCheck(workspace.Name, objectIntegrityResultOk)
+		} else {
+			corrupted++
+			gws.handleObjectCorruption(workspace, object, corruption, quarantine)
+			metrics.RecordObjectIntegrityCheck(workspace.Name, objectIntegrityResultCorrupted)
+		}
+
+		if err := gws.backendRepo.MarkObjectIntegrityChecked(gws.ctx, object.Id); err != nil {
+			log.Warn().Err(err).Str("object_id", object.ExternalId).Msg("unable to record object integrity check")
+		}
+	}
+
+	if corrupted > 0 {
+		log.Warn().Int("checked", len(objects)).Int("corrupted", corrupted).Msg("object integrity audit found corrupted objects")
+	}
+}
+
+func (gws *GatewayService) handleObjectCorruption(workspace *types.Workspace, object *types.Object, corruption *types.ObjectCorruption, quarantine bool) {
+	log.Error().Str("object_id", object.ExternalId).Str("workspace_id", workspace.ExternalId).Str("reason", corruption.Reason).Msg("object failed integrity check")
+
+	if quarantine {
+		err := gws.backendRepo.MarkObjectIncompleteByExternalId(gws.ctx, object.ExternalId)
+		corruption.Quarantined = err == nil
+
+		gws.auditLogger.Log(common.AuditEvent{
+			Action:       auditActionObjectQuarantine,
+			Principal:    "integrity_audit",
+			WorkspaceId:  workspace.ExternalId,
+			ResourceType: "object",
+			ResourceId:   object.ExternalId,
+			Outcome:      auditOutcome(err),
+			Reason:       corruption.Reason,
+			Attributes:   map[string]interface{}{"hash": object.Hash, "size": object.Size},
+		})
+
+		if err != nil {
+			log.Error().Err(err).Str("object_id", object.ExternalId).Msg("unable to quarantine object")
+		} else {
+			metrics.RecordObjectQuarantined(workspace.Name)
+		}
+	}
+
+	if err := gws.backendRepo.RecordObjectCorruption(gws.ctx, corruption); err != nil {
+		log.Error().Err(err).Str("object_id", object.ExternalId).Msg("unable to record object corruption")
+	}
+}
+
+// checkObjectIntegrity re-reads an object's original content and returns a description of how it differs
+// from what was recorded, or nil if it matches. An error means the object couldn't be checked.
+func (gws *GatewayService) checkObjectIntegrity(ctx context.Context, workspace *types.Workspace, object *types.Object) (*types.ObjectCorruption, error) {
+	corruption := &types.ObjectCorruption{
+		ObjectId:       object.Id,
+		WorkspaceId:    object.WorkspaceId,
+		ExpectedSize:   object.Size,
+		ExpectedDigest: expectedObjectDigest(object),
+	}
+
+	_, err := os.Stat(localObjectPath(workspace.Name, object.ExternalId))
+	local := err == nil
+	if !local && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	if !local {
+		exists := false
+		if workspace.StorageAvailable() {
+			storageClient, err := clients.NewWorkspaceStorageClient(ctx, workspace.Name, workspace.Storage)
+			if err != nil {
+				return nil, err
+			}
+
+			storageCtx, cancel := gws.withStorageTimeout(ctx)
+			exists, err = storageClient.Exists(storageCtx, workspaceObjectKey(object.ExternalId))
+			cancel()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if !exists {
+			corruption.Reason = "content is missing"
+			return corruption, nil
+		}
+	}
+
+	// One byte past the recorded size shows whether content was appended
+	reader, err := gws.openObjectRange(ctx, workspace, object, 0, object.Size+1)
+	if err != nil {
+		if local {
+			corruption.Reason = fmt.Sprintf("content is unreadable: %v", err)
+			return corruption, nil
+		}
+		return nil, err
+	}
+	defer reader.Close()
+
+	hasher := sha256.New()
+	size, err := io.Copy(hasher, reader)
+	if err != nil {
+		// Local content that can't be decoded is damaged; storage reads may just have failed
+		if local {
+			corruption.Reason = fmt.Sprintf("content is unreadable: %v", err)
+			corruption.ActualSize = size
+			return corruption, nil
+		}
+		return nil, err
+	}
+
+	digest := hasher.Sum(nil)
+	corruption.Reason = objectContentMismatch(object.Size, corruption.ExpectedDigest, size, digest)
+	if corruption.Reason == "" {
+		return nil, nil
+	}
+
+	corruption.ActualSize = size
+	corruption.ActualDigest = hex.EncodeToString(digest)
+	return corruption, nil
+}
+
+// expectedObjectDigest returns the SHA256 an object's content should have. Objects stored before digests were
+// recorded fall back to the hash they were uploaded with, if it is one.
+func expectedObjectDigest(object *types.Object) string {
+	if object.Digest == "" && isValidObjectHash(object.Hash) {
+		return object.Hash
+	}
+	return object.Digest
+}
+
+// objectContentMismatch describes how content read back differs from the recorded size and digest, or returns
+// an empty string if it matches. Content is only compared by size if no digest was recorded.
+func objectContentMismatch(expectedSize int64, expectedDigest string, size int64, digest []byte) string {
+	switch {
+	case size != expectedSize:
+		return "size does not match"
+	case expectedDigest != "" && !objectDigestMatches(expectedDigest, digest):
+		return "hash does not match"
+	default:
+		return ""
+	}
+}
+
+func (gws *GatewayService) ListCorruptedObjects(ctx context.Context, in *pb.ListCorruptedObjectsRequest) (*pb.ListCorruptedObjectsResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionRead) {
+		return &pb.ListCorruptedObjectsResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	limit := int(in.Limit)
+	if limit <= 0 {
+		limit = defaultCorruptedObjectsLimit
+	}
+	limit = min(limit, maxCorruptedObjectsLimit)
+
+	corruptions, err := gws.backendRepo.ListObjectCorruptions(ctx, authInfo.Workspace.Id, limit)
+	if err != nil {
+		return &pb.ListCorruptedObjectsResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to list corrupted objects",
+		}, nil
+	}
+
+	objects := make([]*pb.CorruptedObject, 0, len(corruptions))
+	for _, corruption := range corruptions {
+		objects = append(objects, &pb.CorruptedObject{
+			ObjectId:       corruption.ObjectExternalId,
+			Hash:           corruption.Hash,
+			Reason:         corruption.Reason,
+			ExpectedSize:   corruption.ExpectedSize,
+			ActualSize:     corruption.ActualSize,
+			ExpectedDigest: corruption.ExpectedDigest,
+			ActualDigest:   corruption.ActualDigest,
+			Quarantined:    corruption.Quarantined,
+			DetectedAt:     timestamppb.New(corruption.DetectedAt.Time),
+		})
+	}
+
+	return &pb.ListCorruptedObjectsResponse{
+		Ok:      true,
+		Objects: objects,
+	}, nil
+}
@@ -0,0 +1,61 @@
+package gatewayservices
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/beam-cloud/beta9/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpectedObjectDigest(t *testing.T) {
+	digest := sha256.Sum256([]byte("hello"))
+	hash := hex.EncodeToString(digest[:])
+
+	tests := []struct {
+		name   string
+		object types.Object
+		want   string
+	}{
+		{"recorded digest", types.Object{Digest: hash, Hash: "upload-hash"}, hash},
+		{"falls back to upload hash", types.Object{Hash: hash}, hash},
+		{"upload hash that isn't a digest", types.Object{Hash: "upload-hash"}, ""},
+		{"nothing recorded", types.Object{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, expectedObjectDigest(&tt.object))
+		})
+	}
+}
+
+func TestObjectContentMismatch(t *testing.T) {
+	digest := sha256.Sum256([]byte("hello"))
+	hash := hex.EncodeToString(digest[:])
+	otherDigest := sha256.Sum256([]byte("world"))
+
+	tests := []struct {
+		name           string
+		expectedSize   int64
+		expectedDigest string
+		size           int64
+		digest         []byte
+		want           string
+	}{
+		{"matches", 5, hash, 5, digest[:], ""},
+		{"digest case doesn't matter", 5, strings.ToUpper(hash), 5, digest[:], ""},
+		{"no recorded digest", 5, "", 5, otherDigest[:], ""},
+		{"truncated", 5, hash, 3, digest[:], "size does not match"},
+		{"appended", 5, hash, 6, digest[:], "size does not match"},
+		{"changed content", 5, hash, 5, otherDigest[:], "hash does not match"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, objectContentMismatch(tt.expectedSize, tt.expectedDigest, tt.size, tt.digest))
+		})
+	}
+}
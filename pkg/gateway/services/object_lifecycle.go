@@ -0,0 +1,301 @@
+package gatewayservices
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/clients"
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	objectReaperInterval         = 10 * time.Minute
+	objectReaperBatchSize        = 100
+	objectReaperLockTtlS         = 300
+	maxObjectLifecycleDays       = 36500
+	auditActionObjectExpire      = "object.expire"
+	auditActionObjectSetTTL      = "object.set_lifecycle_policy"
+	auditResourceLifecyclePolicy = "object_lifecycle_policy"
+)
+
+// touchObject records that an object was used, which resets its expire-unused timer
+func (gws *GatewayService) touchObject(ctx context.Context, objectId string) {
+	if err := gws.backendRepo.TouchObjectByExternalId(ctx, objectId); err != nil {
+		log.Warn().Err(err).Str("object_id", objectId).Msg("unable to record object usage")
+	}
+}
+
+// invalidateCachedObject has workers evict the object's content from their object caches, so the
+// scheduler stops preferring them for content that was overwritten or deleted
+func (gws *GatewayService) invalidateCachedObject(object *types.Object) {
+	if object.Digest == "" || gws.workerRepo == nil {
+		return
+	}
+
+	if err := gws.workerRepo.InvalidateCachedObject(object.Digest); err != nil {
+		log.Warn().Err(err).Str("object_id", object.ExternalId).Msg("unable to invalidate cached object")
+	}
+}
+
+// deleteObject removes an object's repo row and its data from local disk and workspace storage.
+// Locked objects are refused with *types.ErrObjectLocked.
+func (gws *GatewayService) deleteObject(ctx context.Context, workspace *types.Workspace, object *types.Object) error {
+	if object.IsLocked() {
+		return &types.ErrObjectLocked{ObjectId: object.ExternalId, RetainUntil: object.RetainUntil.Time}
+	}
+
+	// Replica rows are removed along with the object, so look them up first
+	replicas, err := gws.backendRepo.ListObjectReplicas(ctx, object.Id)
+	if err != nil {
+		return err
+	}
+
+	// The object's reference on its blob is only dropped along with the object, so a failed delete leaves
+	// the object readable
+	blob, removed, err := gws.backendRepo.DeleteObjectAndReleaseBlob(ctx, object.ExternalId)
+	if err != nil {
+		return err
+	}
+
+	if removed {
+		os.Remove(objectBlobPath(blob.Digest))
+	}
+
+	os.Remove(localObjectPath(workspace.Name, object.ExternalId))
+	invalidateResolvedObjectPath(workspace.Name, object.ExternalId)
+	gws.deleteObjectReplica(ctx, workspace, object, replicas)
+	gws.invalidateCachedObject(object)
+
+	var storageClient *clients.WorkspaceStorageClient
+	if workspace.StorageAvailable() {
+		storageClient, err = clients.NewWorkspaceStorageClient(ctx, workspace.Name, workspace.Storage)
+		if err != nil {
+			return err
+		}
+
+		storageCtx, cancel := gws.withStorageTimeout(ctx)
+		defer cancel()
+
+		if err := storageClient.Delete(storageCtx, workspaceObjectKey(object.ExternalId)); err != nil {
+			return err
+		}
+	}
+
+	return gws.deleteObjectVersions(ctx, workspace, storageClient, object)
+}
+
+// reapExpiredObjects periodically deletes objects that have expired under their workspace's lifecycle
+// policy. Only one gateway replica reaps at a time.
+func (gws *GatewayService) reapExpiredObjects() {
+	ticker := time.NewTicker(objectReaperInterval)
+	defer ticker.Stop()
+
+	lock := common.NewRedisLock(gws.redisClient)
+	lockKey := common.RedisKeys.GatewayObjectReaperLock()
+
+	for {
+		select {
+		case <-gws.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := lock.Acquire(gws.ctx, lockKey, common.RedisLockOptions{TtlS: objectReaperLockTtlS, Retries: 0}); err != nil {
+				continue
+			}
+
+			gws.reapExpiredObjectBatches()
+			lock.Release(lockKey)
+		}
+	}
+}
+
+func (gws *GatewayService) reapExpiredObjectBatches() {
+	workspaces := map[uint]*types.Workspace{}
+	deadline := time.Now().Add(objectReaperLockTtlS * time.Second / 2)
+
+	for time.Now().Before(deadline) {
+		objects, err := gws.backendRepo.ListExpiredObjects(gws.ctx, objectReaperBatchSize)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to list expired objects")
+			return
+		}
+
+		deleted := 0
+		for i := range objects {
+			object := &objects[i]
+
+			workspace, ok := workspaces[object.WorkspaceId]
+			if !ok {
+				workspace, err = gws.backendRepo.GetWorkspace(gws.ctx, object.WorkspaceId)
+				if err != nil {
+					continue
+				}
+				workspaces[object.WorkspaceId] = workspace
+			}
+
+			// The listing only excludes objects used by active deployments, so in-flight tasks are checked here
+			err := gws.checkObjectReferences(gws.ctx, object)
+			if err == nil {
+				err = gws.deleteObject(gws.ctx, workspace, object)
+			}
+			gws.auditLogger.Log(common.AuditEvent{
+				Action:       auditActionObjectExpire,
+				Principal:    "lifecycle",
+				WorkspaceId:  workspace.ExternalId,
+				ResourceType: "object",
+				ResourceId:   object.ExternalId,
+				Outcome:      auditOutcome(err),
+				Reason:       errorMessage(err),
+				Attributes:   map[string]interface{}{"hash": object.Hash, "size": object.Size},
+			})
+			if err != nil {
+				log.Warn().Err(err).Str("object_id", object.ExternalId).Msg("failed to delete expired object")
+				continue
+			}
+
+			gws.notifyObjectEvent(gws.ctx, workspace, types.ObjectWebhookEventExpired, object)
+			deleted++
+		}
+
+		if deleted > 0 {
+			log.Info().Int("count", deleted).Msg("deleted expired objects")
+		}
+
+		// Stop once a batch makes no progress, otherwise the same failing objects would be retried forever
+		if len(objects) < objectReaperBatchSize || deleted == 0 {
+			return
+		}
+	}
+}
+
+func auditOutcome(err error) common.AuditOutcome {
+	if err != nil {
+		return common.AuditOutcomeFailure
+	}
+	return common.AuditOutcomeSuccess
+}
+
+func errorMessage(err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
+func lifecyclePolicyToProto(policy *types.ObjectLifecyclePolicy) *pb.ObjectLifecyclePolicy {
+	if policy == nil {
+		return &pb.ObjectLifecyclePolicy{}
+	}
+
+	pbPolicy := &pb.ObjectLifecyclePolicy{
+		ExpireAfterDays:       uint32(policy.ExpireAfterDays),
+		ExpireUnusedAfterDays: uint32(policy.ExpireUnusedAfterDays),
+	}
+
+	if policy.RetainVersions != nil {
+		retainVersions := uint32(*policy.RetainVersions)
+		pbPolicy.RetainVersions = &retainVersions
+	}
+
+	return pbPolicy
+}
+
+func (gws *GatewayService) SetObjectLifecyclePolicy(ctx context.Context, in *pb.SetObjectLifecyclePolicyRequest) (*pb.SetObjectLifecyclePolicyResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionWrite) {
+		return &pb.SetObjectLifecyclePolicyResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	policy := in.Policy
+	if policy == nil {
+		policy = &pb.ObjectLifecyclePolicy{}
+	}
+
+	if policy.ExpireAfterDays > maxObjectLifecycleDays || policy.ExpireUnusedAfterDays > maxObjectLifecycleDays {
+		return &pb.SetObjectLifecyclePolicyResponse{
+			Ok:       false,
+			ErrorMsg: fmt.Sprintf("Expiration must be %d days or less", maxObjectLifecycleDays),
+		}, nil
+	}
+
+	var retainVersions *int
+	if policy.RetainVersions != nil {
+		if *policy.RetainVersions > maxObjectVersionRetention {
+			return &pb.SetObjectLifecyclePolicyResponse{
+				Ok:       false,
+				ErrorMsg: fmt.Sprintf("At most %d versions can be retained", maxObjectVersionRetention),
+			}, nil
+		}
+
+		n := int(*policy.RetainVersions)
+		retainVersions = &n
+	}
+
+	updated, err := gws.backendRepo.SetObjectLifecyclePolicy(ctx, authInfo.Workspace.Id, int(policy.ExpireAfterDays), int(policy.ExpireUnusedAfterDays), retainVersions)
+
+	event := common.AuditEvent{
+		Action:       auditActionObjectSetTTL,
+		WorkspaceId:  authInfo.Workspace.ExternalId,
+		ResourceType: auditResourceLifecyclePolicy,
+		ResourceId:   authInfo.Workspace.ExternalId,
+		Outcome:      auditOutcome(err),
+		Reason:       errorMessage(err),
+		Attributes: map[string]interface{}{
+			"expire_after_days":        policy.ExpireAfterDays,
+			"expire_unused_after_days": policy.ExpireUnusedAfterDays,
+			"retain_versions":          policy.RetainVersions,
+		},
+	}
+	if authInfo.Token != nil {
+		event.Principal = authInfo.Token.ExternalId
+	}
+	gws.auditLogger.Log(event)
+
+	if err != nil {
+		return &pb.SetObjectLifecyclePolicyResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to set lifecycle policy",
+		}, nil
+	}
+
+	return &pb.SetObjectLifecyclePolicyResponse{
+		Ok:     true,
+		Policy: lifecyclePolicyToProto(updated),
+	}, nil
+}
+
+func (gws *GatewayService) GetObjectLifecyclePolicy(ctx context.Context, in *pb.GetObjectLifecyclePolicyRequest) (*pb.GetObjectLifecyclePolicyResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionRead) {
+		return &pb.GetObjectLifecyclePolicyResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	policy, err := gws.backendRepo.GetObjectLifecyclePolicy(ctx, authInfo.Workspace.Id)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return &pb.GetObjectLifecyclePolicyResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to get lifecycle policy",
+		}, nil
+	}
+
+	// Workspaces without a policy never expire objects
+	return &pb.GetObjectLifecyclePolicyResponse{
+		Ok:     true,
+		Policy: lifecyclePolicyToProto(policy),
+	}, nil
+}
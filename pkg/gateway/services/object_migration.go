@@ -0,0 +1,284 @@
+package gatewayservices
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/clients"
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	objectMigratorInterval    = time.Minute
+	objectMigratorBatchSize   = 50
+	objectMigratorLockTtlS    = 300
+	auditActionObjectMigrate  = "object.migrate"
+	auditActionObjectsMigrate = "objects.migrate"
+)
+
+// migrateObjects moves objects stranded on the gateway filesystem into workspace storage for every
+// workspace that has requested it with MigrateObjects
+func (gws *GatewayService) migrateObjects() {
+	ticker := time.NewTicker(objectMigratorInterval)
+	defer ticker.Stop()
+
+	lock := common.NewRedisLock(gws.redisClient)
+	lockKey := common.RedisKeys.GatewayObjectMigratorLock()
+
+	for {
+		select {
+		case <-gws.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := lock.Acquire(gws.ctx, lockKey, common.RedisLockOptions{TtlS: objectMigratorLockTtlS, Retries: 0}); err != nil {
+				continue
+			}
+
+			gws.migratePendingWorkspaces()
+			lock.Release(lockKey)
+		}
+	}
+}
+
+func (gws *GatewayService) migratePendingWorkspaces() {
+	pendingKey := common.RedisKeys.GatewayObjectMigrationPending()
+	deadline := time.Now().Add(objectMigratorLockTtlS * time.Second / 2)
+
+	workspaceIds, err := gws.redisClient.SMembers(gws.ctx, pendingKey).Result()
+	if err != nil {
+		log.Error().Err(err).Msg("failed to list workspaces pending object migration")
+		return
+	}
+
+	for _, member := range workspaceIds {
+		if time.Now().After(deadline) {
+			return
+		}
+
+		workspaceId, err := strconv.ParseUint(member, 10, 64)
+		if err != nil {
+			gws.redisClient.SRem(gws.ctx, pendingKey, member)
+			continue
+		}
+
+		workspace, err := gws.backendRepo.GetWorkspace(gws.ctx, uint(workspaceId))
+		if err != nil || !workspace.StorageAvailable() {
+			gws.redisClient.SRem(gws.ctx, pendingKey, member)
+			continue
+		}
+
+		if done := gws.migrateWorkspaceObjects(workspace, deadline); done {
+			gws.redisClient.SRem(gws.ctx, pendingKey, member)
+		}
+	}
+}
+
+// migrateWorkspaceObjects migrates the workspace's objects in batches until the deadline, reporting
+// whether it ran out of objects it could migrate
+func (gws *GatewayService) migrateWorkspaceObjects(workspace *types.Workspace, deadline time.Time) bool {
+	storageClient, err := clients.NewWorkspaceStorageClient(gws.ctx, workspace.Name, workspace.Storage)
+	if err != nil {
+		log.Error().Err(err).Str("workspace_id", workspace.ExternalId).Msg("unable to migrate objects")
+		return false
+	}
+
+	for time.Now().Before(deadline) {
+		objects, err := gws.backendRepo.ListObjectsPendingMigration(gws.ctx, workspace.Id, objectMigratorBatchSize)
+		if err != nil {
+			log.Error().Err(err).Str("workspace_id", workspace.ExternalId).Msg("failed to list objects pending migration")
+			return false
+		}
+
+		migrated := 0
+		for i := range objects {
+			object := &objects[i]
+
+			err := gws.migrateObject(gws.ctx, workspace, storageClient, object)
+			gws.auditLogger.Log(common.AuditEvent{
+				Action:       auditActionObjectMigrate,
+				Principal:    "migrator",
+				WorkspaceId:  workspace.ExternalId,
+				ResourceType: "object",
+				ResourceId:   object.ExternalId,
+				Outcome:      auditOutcome(err),
+				Reason:       errorMessage(err),
+				Attributes:   map[string]interface{}{"hash": object.Hash, "size": object.Size},
+			})
+			if err != nil {
+				log.Warn().Err(err).Str("object_id", object.ExternalId).Msg("failed to migrate object")
+				continue
+			}
+
+			migrated++
+		}
+
+		if migrated > 0 {
+			log.Info().Int("count", migrated).Str("workspace_id", workspace.ExternalId).Msg("migrated objects to workspace storage")
+		}
+
+		// Objects that keep failing stay local, and the workspace is retried when it's requested again
+		if len(objects) < objectMigratorBatchSize || migrated == 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// migrateObject uploads an object's original content to workspace storage, verifies it and then removes the
+// local copy. Objects with no local copy were uploaded to workspace storage directly and are only relabelled.
+func (gws *GatewayService) migrateObject(ctx context.Context, workspace *types.Workspace, storageClient *clients.WorkspaceStorageClient, object *types.Object) error {
+	objectPath := localObjectPath(workspace.Name, object.ExternalId)
+	key := workspaceObjectKey(object.ExternalId)
+
+	if _, err := os.Stat(objectPath); os.IsNotExist(err) {
+		storageCtx, cancel := gws.withStorageTimeout(ctx)
+		defer cancel()
+
+		exists, err := storageClient.Exists(storageCtx, key)
+		if err != nil {
+			return err
+		}
+
+		if !exists {
+			return fmt.Errorf("object content not found")
+		}
+
+		return gws.backendRepo.UpdateObjectLocationByExternalId(ctx, object.ExternalId, types.ObjectLocationWorkspaceStorage)
+	}
+
+	if err := gws.copyObjectToStorage(ctx, workspace, storageClient, object); err != nil {
+		return err
+	}
+
+	if err := gws.clearObjectEncoding(ctx, object); err != nil {
+		return err
+	}
+
+	if err := gws.backendRepo.UpdateObjectLocationByExternalId(ctx, object.ExternalId, types.ObjectLocationWorkspaceStorage); err != nil {
+		return err
+	}
+
+	if err := gws.releaseObjectBlob(ctx, object); err != nil {
+		log.Warn().Err(err).Str("object_id", object.ExternalId).Msg("unable to release object blob")
+	}
+
+	os.Remove(objectPath)
+	return nil
+}
+
+// copyObjectToStorage uploads an object's original content to a storage bucket and verifies the copy.
+// Workers read workspace storage directly, so it holds the original bytes rather than the gateway's encoding.
+func (gws *GatewayService) copyObjectToStorage(ctx context.Context, workspace *types.Workspace, storageClient *clients.WorkspaceStorageClient, object *types.Object) error {
+	key := workspaceObjectKey(object.ExternalId)
+
+	reader, err := gws.openObjectRange(ctx, workspace, object, 0, object.Size)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	hasher := sha256.New()
+	if err := storageClient.UploadWithReader(ctx, key, io.TeeReader(reader, hasher)); err != nil {
+		return err
+	}
+
+	// Objects uploaded before digests were recorded can only be checked against a well formed declared hash
+	expected := object.Digest
+	if expected == "" && isValidObjectHash(object.Hash) {
+		expected = object.Hash
+	}
+
+	digest := hasher.Sum(nil)
+	if expected != "" && !objectDigestMatches(expected, digest) {
+		storageClient.Delete(ctx, key)
+		return fmt.Errorf("checksum mismatch: expected %s, got %s", expected, hex.EncodeToString(digest))
+	}
+
+	storageCtx, cancel := gws.withStorageTimeout(ctx)
+	defer cancel()
+
+	exists, head, err := storageClient.Head(storageCtx, key)
+	if err != nil {
+		return err
+	}
+
+	if !exists || head.ContentLength == nil || *head.ContentLength != object.Size {
+		storageClient.Delete(ctx, key)
+		return fmt.Errorf("uploaded object size does not match")
+	}
+
+	return nil
+}
+
+func (gws *GatewayService) MigrateObjects(ctx context.Context, in *pb.MigrateObjectsRequest) (*pb.MigrateObjectsResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionWrite) {
+		return &pb.MigrateObjectsResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	if !authInfo.Workspace.StorageAvailable() {
+		return &pb.MigrateObjectsResponse{
+			Ok:       false,
+			ErrorMsg: "Workspace storage is not enabled for this workspace",
+		}, nil
+	}
+
+	pending, err := gws.backendRepo.CountObjectsPendingMigration(ctx, authInfo.Workspace.Id)
+	if err != nil {
+		return &pb.MigrateObjectsResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to count objects pending migration",
+		}, nil
+	}
+
+	if in.DryRun || pending == 0 {
+		return &pb.MigrateObjectsResponse{
+			Ok:           true,
+			PendingCount: pending,
+		}, nil
+	}
+
+	err = gws.redisClient.SAdd(ctx, common.RedisKeys.GatewayObjectMigrationPending(), strconv.FormatUint(uint64(authInfo.Workspace.Id), 10)).Err()
+
+	event := common.AuditEvent{
+		Action:       auditActionObjectsMigrate,
+		WorkspaceId:  authInfo.Workspace.ExternalId,
+		ResourceType: "workspace",
+		ResourceId:   authInfo.Workspace.ExternalId,
+		Outcome:      auditOutcome(err),
+		Reason:       errorMessage(err),
+		Attributes:   map[string]interface{}{"pending_count": pending},
+	}
+	if authInfo.Token != nil {
+		event.Principal = authInfo.Token.ExternalId
+	}
+	gws.auditLogger.Log(event)
+
+	if err != nil {
+		return &pb.MigrateObjectsResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to schedule object migration",
+		}, nil
+	}
+
+	return &pb.MigrateObjectsResponse{
+		Ok:           true,
+		PendingCount: pending,
+		Scheduled:    true,
+	}, nil
+}
@@ -0,0 +1,162 @@
+package gatewayservices
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/clients"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// S3 allows at most 10,000 parts in a single multipart upload
+	maxObjectUploadParts = 10000
+	// S3 rejects single PUT uploads larger than 5GB
+	maxPresignedPutObjectSize = 5 * 1024 * 1024 * 1024
+
+	auditActionObjectPresignParts = "object.presign_parts"
+	auditActionObjectComplete     = "object.complete"
+)
+
+// createMultipartObject starts a multipart upload for an object and returns a presigned URL per part,
+// letting clients upload the parts of a single object in parallel
+func (gws *GatewayService) createMultipartObject(ctx context.Context, authInfo *auth.AuthInfo, storageClient *clients.WorkspaceStorageClient, object *types.Object, in *pb.CreateObjectRequest) (*pb.CreateObjectResponse, error) {
+	if in.PartCount > maxObjectUploadParts {
+		return &pb.CreateObjectResponse{
+			Ok:       false,
+			ErrorMsg: fmt.Sprintf("Part count must be %d or less", maxObjectUploadParts),
+		}, nil
+	}
+
+	storageCtx, cancel := gws.withStorageTimeout(ctx)
+	defer cancel()
+
+	key := workspaceObjectKey(object.ExternalId)
+	uploadId, err := storageClient.CreateMultipartUpload(storageCtx, key)
+	if err == nil {
+		var partURLs []string
+		partURLs, err = storageClient.GeneratePresignedUploadPartURLs(storageCtx, key, uploadId, int32(in.PartCount), defaultObjectPutExpirationS)
+		if err == nil {
+			gws.auditObject(authInfo, auditActionObjectPresignParts, object.ExternalId, in.Hash, in.Size, "")
+			return &pb.CreateObjectResponse{
+				Ok:       true,
+				ObjectId: object.ExternalId,
+				UploadId: uploadId,
+				PartUrls: partURLs,
+			}, nil
+		}
+
+		storageClient.AbortMultipartUpload(ctx, key, uploadId)
+	}
+
+	gws.auditObject(authInfo, auditActionObjectPresignParts, object.ExternalId, in.Hash, in.Size, err.Error())
+	if isStorageTimeout(err) {
+		return nil, status.Error(codes.DeadlineExceeded, storageOperationTimeoutErrMessage)
+	}
+
+	return &pb.CreateObjectResponse{
+		Ok:       false,
+		ErrorMsg: "Unable to generate presigned part URLs",
+	}, nil
+}
+
+func (gws *GatewayService) CompleteObject(ctx context.Context, in *pb.CompleteObjectRequest) (*pb.CompleteObjectResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !authInfo.Workspace.StorageAvailable() {
+		return &pb.CompleteObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Workspace storage is not available",
+		}, nil
+	}
+
+	if in.UploadId == "" || len(in.Parts) == 0 {
+		return &pb.CompleteObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Upload id and parts are required",
+		}, nil
+	}
+
+	object, err := gws.backendRepo.GetObjectByExternalId(ctx, in.ObjectId, authInfo.Workspace.Id)
+	if err != nil {
+		return &pb.CompleteObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Object not found",
+		}, nil
+	}
+
+	if accessible, err := gws.objectAccessible(ctx, authInfo, &object, types.ResourceAccessWrite); err != nil || !accessible {
+		return &pb.CompleteObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	storageClient, err := clients.NewWorkspaceStorageClient(ctx, authInfo.Workspace.Name, authInfo.Workspace.Storage)
+	if err != nil {
+		return &pb.CompleteObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to create storage client",
+		}, nil
+	}
+
+	parts := make([]clients.CompletedPart, 0, len(in.Parts))
+	for _, part := range in.Parts {
+		parts = append(parts, clients.CompletedPart{
+			PartNumber: int32(part.PartNumber),
+			ETag:       part.Etag,
+		})
+	}
+
+	storageCtx, cancel := gws.withStorageTimeout(ctx)
+	defer cancel()
+
+	key := workspaceObjectKey(object.ExternalId)
+	if err := storageClient.CompleteMultipartUpload(storageCtx, key, in.UploadId, parts); err != nil {
+		gws.auditObject(authInfo, auditActionObjectComplete, object.ExternalId, object.Hash, object.Size, err.Error())
+		if isStorageTimeout(err) {
+			return nil, status.Error(codes.DeadlineExceeded, storageOperationTimeoutErrMessage)
+		}
+
+		return &pb.CompleteObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to complete upload",
+		}, nil
+	}
+
+	_, output, err := storageClient.Head(storageCtx, key)
+	if err == nil && object.Size > 0 && aws.ToInt64(output.ContentLength) != object.Size {
+		// A missing or truncated part leaves an object that can't be trusted, so it has to be uploaded again
+		actualSize := aws.ToInt64(output.ContentLength)
+		sizeErr := fmt.Sprintf("Uploaded size %d does not match declared size %d", actualSize, object.Size)
+		gws.auditObject(authInfo, auditActionObjectComplete, object.ExternalId, object.Hash, actualSize, sizeErr)
+		gws.backendRepo.MarkObjectIncompleteByExternalId(ctx, object.ExternalId)
+		storageClient.Delete(storageCtx, key)
+		return &pb.CompleteObjectResponse{
+			Ok:       false,
+			ErrorMsg: sizeErr,
+		}, nil
+	}
+
+	if err == nil {
+		err = gws.backendRepo.UpdateObjectSizeByExternalId(ctx, object.ExternalId, int(aws.ToInt64(output.ContentLength)))
+	}
+
+	if err != nil {
+		gws.auditObject(authInfo, auditActionObjectComplete, object.ExternalId, object.Hash, object.Size, err.Error())
+		return &pb.CompleteObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to update object size",
+		}, nil
+	}
+
+	gws.auditObject(authInfo, auditActionObjectComplete, object.ExternalId, object.Hash, aws.ToInt64(output.ContentLength), "")
+	return &pb.CompleteObjectResponse{
+		Ok: true,
+	}, nil
+}
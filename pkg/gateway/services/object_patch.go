@@ -0,0 +1,271 @@
+package gatewayservices
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+	"github.com/rs/zerolog/log"
+)
+
+const auditActionObjectPatch = "object.patch"
+
+func (gws *GatewayService) GetObjectSignature(ctx context.Context, in *pb.GetObjectSignatureRequest) (*pb.GetObjectSignatureResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionDeploy) {
+		return &pb.GetObjectSignatureResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	objectPath, err := gws.ResolveObjectPath(ctx, authInfo, in.ObjectId)
+	if errors.Is(err, ErrObjectAccessForbidden) {
+		return &pb.GetObjectSignatureResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+	if err != nil {
+		return &pb.GetObjectSignatureResponse{
+			Ok:       false,
+			ErrorMsg: "Object not found",
+		}, nil
+	}
+
+	file, err := os.Open(objectPath)
+	if err != nil {
+		return &pb.GetObjectSignatureResponse{
+			Ok:       false,
+			ErrorMsg: "Object not found",
+		}, nil
+	}
+	defer file.Close()
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return &pb.GetObjectSignatureResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to read object",
+		}, nil
+	}
+
+	blockSize := common.DeltaBlockSize(fileInfo.Size(), int(in.BlockSize))
+	signatures, err := common.ComputeBlockSignatures(file, blockSize)
+	if err != nil {
+		log.Error().Err(err).Str("object_id", in.ObjectId).Msg("failed to compute object signature")
+		return &pb.GetObjectSignatureResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to read object",
+		}, nil
+	}
+
+	blocks := make([]*pb.ObjectBlockSignature, 0, len(signatures))
+	for _, signature := range signatures {
+		blocks = append(blocks, &pb.ObjectBlockSignature{
+			Weak:   signature.Weak,
+			Strong: signature.Strong,
+		})
+	}
+
+	return &pb.GetObjectSignatureResponse{
+		Ok:        true,
+		BlockSize: uint32(blockSize),
+		Size:      fileInfo.Size(),
+		Blocks:    blocks,
+	}, nil
+}
+
+// PatchObject creates an object from a base object and a delta against it, so clients only send the
+// blocks that changed. The rebuilt content goes through the same path as a regular upload.
+func (gws *GatewayService) PatchObject(stream pb.GatewayService_PatchObjectServer) error {
+	patch := &patchObjectStream{gws: gws, stream: stream}
+	defer patch.close()
+
+	err := gws.putObject(patch)
+
+	// Uploads that fail after the stream started are reported in the response rather than as an error
+	if err == nil && patch.resp != nil && !patch.resp.Ok {
+		err = errors.New(patch.resp.ErrorMsg)
+	}
+
+	authInfo, _ := auth.AuthInfoFromContext(stream.Context())
+	gws.auditObjectPatch(authInfo, patch, err)
+
+	return err
+}
+
+// auditObjectPatch records a patch along with how much of the new content was copied from the base object
+func (gws *GatewayService) auditObjectPatch(authInfo *auth.AuthInfo, patch *patchObjectStream, err error) {
+	objectId := ""
+	if patch.resp != nil {
+		objectId = patch.resp.ObjectId
+	}
+
+	event := common.AuditEvent{
+		Action:       auditActionObjectPatch,
+		ResourceType: "object",
+		ResourceId:   objectId,
+		Outcome:      auditOutcome(err),
+		Reason:       errorMessage(err),
+		Attributes: map[string]interface{}{
+			"hash":           patch.hash,
+			"base_object_id": patch.baseObjectId,
+			"copied_bytes":   patch.copiedBytes,
+			"literal_bytes":  patch.literalBytes,
+		},
+	}
+	if authInfo != nil {
+		event.WorkspaceId = authInfo.Workspace.ExternalId
+		if authInfo.Token != nil {
+			event.Principal = authInfo.Token.ExternalId
+		}
+	}
+
+	gws.auditLogger.Log(event)
+}
+
+// patchObjectStream turns a stream of patch ops into the content chunks of an upload. Copied blocks are
+// read from the base object's decoded content.
+type patchObjectStream struct {
+	gws          *GatewayService
+	stream       pb.GatewayService_PatchObjectServer
+	base         *os.File
+	baseObjectId string
+	baseSize     int64
+	blockSize    int
+	hash         string
+	overwrite    bool
+	pending      [][]byte
+	copiedBytes  int64
+	literalBytes int64
+	resp         *pb.PutObjectResponse
+}
+
+func (s *patchObjectStream) Context() context.Context {
+	return s.stream.Context()
+}
+
+func (s *patchObjectStream) Recv() (*pb.PutObjectRequest, error) {
+	for len(s.pending) == 0 {
+		request, err := s.stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+
+		if s.base == nil {
+			if err := s.open(request); err != nil {
+				return nil, err
+			}
+
+			// The first chunk creates the object even if the patch turns out to be empty
+			s.pending = append(s.pending, nil)
+		}
+
+		for _, op := range request.Ops {
+			chunk, err := s.apply(op)
+			if err != nil {
+				return nil, err
+			}
+			s.pending = append(s.pending, chunk)
+		}
+	}
+
+	chunk := s.pending[0]
+	s.pending = s.pending[1:]
+
+	return &pb.PutObjectRequest{
+		ObjectContent: chunk,
+		Hash:          s.hash,
+		Overwrite:     s.overwrite,
+	}, nil
+}
+
+func (s *patchObjectStream) open(request *pb.PatchObjectRequest) error {
+	authInfo, _ := auth.AuthInfoFromContext(s.Context())
+
+	s.baseObjectId = request.BaseObjectId
+	s.hash = request.Hash
+
+	blockSize := int(request.BlockSize)
+	if blockSize < common.MinDeltaBlockSize || blockSize > common.MaxDeltaBlockSize {
+		return &putObjectStreamError{msg: "Invalid block size"}
+	}
+
+	baseObject, err := s.gws.backendRepo.GetObjectByExternalId(s.Context(), request.BaseObjectId, authInfo.Workspace.Id)
+	if err != nil {
+		return &putObjectStreamError{msg: "Base object not found"}
+	}
+
+	if accessible, err := s.gws.objectAccessible(s.Context(), authInfo, &baseObject, types.ResourceAccessRead); err != nil || !accessible {
+		return &putObjectStreamError{msg: "Unauthorized Access"}
+	}
+
+	// The upload would replace the base's content while it's still being read
+	if baseObject.Hash == request.Hash {
+		return &putObjectStreamError{msg: "Patched content is identical to the base object"}
+	}
+
+	objectPath, err := s.gws.ResolveObjectPath(s.Context(), authInfo, baseObject.ExternalId)
+	if err != nil {
+		return &putObjectStreamError{msg: "Base object not found"}
+	}
+
+	base, err := os.Open(objectPath)
+	if err != nil {
+		return &putObjectStreamError{msg: "Base object not found"}
+	}
+
+	fileInfo, err := base.Stat()
+	if err != nil {
+		base.Close()
+		return err
+	}
+
+	s.base = base
+	s.baseSize = fileInfo.Size()
+	s.blockSize = blockSize
+	s.overwrite = request.Overwrite
+	return nil
+}
+
+func (s *patchObjectStream) apply(op *pb.ObjectPatchOp) ([]byte, error) {
+	switch o := op.Op.(type) {
+	case *pb.ObjectPatchOp_Data:
+		s.literalBytes += int64(len(o.Data))
+		return o.Data, nil
+	case *pb.ObjectPatchOp_CopyBlock:
+		block, err := common.ReadDeltaBlock(s.base, s.baseSize, s.blockSize, o.CopyBlock)
+		if errors.Is(err, common.ErrDeltaBlockOutOfRange) {
+			return nil, &putObjectStreamError{msg: "Patch references a block outside the base object"}
+		}
+		s.copiedBytes += int64(len(block))
+		return block, err
+	}
+
+	return nil, &putObjectStreamError{msg: "Invalid patch operation"}
+}
+
+func (s *patchObjectStream) Progress(offset int64) error {
+	return nil
+}
+
+func (s *patchObjectStream) Finish(resp *pb.PutObjectResponse) error {
+	s.resp = resp
+	return s.stream.SendAndClose(&pb.PatchObjectResponse{
+		Ok:       resp.Ok,
+		ObjectId: resp.ObjectId,
+		ErrorMsg: resp.ErrorMsg,
+	})
+}
+
+func (s *patchObjectStream) close() {
+	if s.base != nil {
+		s.base.Close()
+	}
+}
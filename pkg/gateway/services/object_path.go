@@ -0,0 +1,275 @@
+package gatewayservices
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/clients"
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/types"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	objectCacheDirName   = ".cache"
+	objectVersionDirName = ".versions"
+	objectTempFileSuffix = ".tmp"
+)
+
+var (
+	ErrObjectNotFound        = errors.New("object not found")
+	ErrObjectAccessForbidden = errors.New("object access forbidden")
+)
+
+// localObjectDir returns the directory that holds a workspace's objects on the gateway filesystem
+func localObjectDir(workspaceName string) string {
+	return path.Join(types.DefaultObjectPath, workspaceName)
+}
+
+// localObjectPath returns the on-disk path of an object stored on the gateway filesystem
+func localObjectPath(workspaceName, objectId string) string {
+	return path.Join(localObjectDir(workspaceName), objectId)
+}
+
+// createLocalObjectTempFile creates the file an object's content is written to before being renamed to
+// objectPath. It's created in the same directory so the rename is atomic.
+func createLocalObjectTempFile(objectPath string) (*os.File, error) {
+	file, err := os.CreateTemp(path.Dir(objectPath), path.Base(objectPath)+".*"+objectTempFileSuffix)
+	if err != nil {
+		return nil, err
+	}
+
+	// Match the permissions of files created with os.Create, since workers read objects from the same filesystem
+	if err := file.Chmod(0644); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// removeOrphanedObjectTempFiles deletes temp files left behind by uploads that were interrupted by a crash.
+// Other gateway replicas share the object directories, and running uploads keep writing to their temp file
+// and heartbeating, so a file is only orphaned once nothing in this process has it open, no upload heartbeat
+// is registered for its object, and it has gone untouched for longer than the upload heartbeat timeout.
+func (gws *GatewayService) removeOrphanedObjectTempFiles() {
+	workspaceDirs, err := os.ReadDir(types.DefaultObjectPath)
+	if err != nil {
+		return
+	}
+
+	activeUploads, err := gws.activeUploadObjectIds()
+	if err != nil {
+		log.Warn().Err(err).Msg("unable to list active uploads, skipping orphaned object temp file cleanup")
+		return
+	}
+
+	openFiles := openFilePaths()
+
+	removed := 0
+	cutoff := time.Now().Add(-gws.uploadHeartbeatTimeout())
+	for _, workspaceDir := range workspaceDirs {
+		if !workspaceDir.IsDir() {
+			continue
+		}
+
+		objectDir := localObjectDir(workspaceDir.Name())
+		for _, dir := range []string{objectDir, path.Join(objectDir, objectCacheDirName)} {
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				continue
+			}
+
+			for _, entry := range entries {
+				if entry.IsDir() || !strings.HasSuffix(entry.Name(), objectTempFileSuffix) {
+					continue
+				}
+
+				// Temp files are named after the object they're written for
+				objectId, _, _ := strings.Cut(entry.Name(), ".")
+				tempFilePath := path.Join(dir, entry.Name())
+				if activeUploads[objectId] || openFiles[tempFilePath] {
+					continue
+				}
+
+				info, err := entry.Info()
+				if err != nil || info.ModTime().After(cutoff) {
+					continue
+				}
+
+				if err := os.Remove(tempFilePath); err == nil {
+					removed++
+				}
+			}
+		}
+	}
+
+	if removed > 0 {
+		log.Info().Int("count", removed).Msg("removed orphaned object temp files")
+	}
+}
+
+// openFilePaths returns the paths of the files this process has open
+func openFilePaths() map[string]bool {
+	paths := map[string]bool{}
+
+	fds, err := os.ReadDir("/proc/self/fd")
+	if err != nil {
+		return paths
+	}
+
+	for _, fd := range fds {
+		if target, err := os.Readlink(path.Join("/proc/self/fd", fd.Name())); err == nil {
+			paths[target] = true
+		}
+	}
+
+	return paths
+}
+
+// workspaceObjectKey returns the key of an object in workspace storage
+func workspaceObjectKey(objectId string) string {
+	return path.Join(types.DefaultObjectPrefix, objectId)
+}
+
+// localObjectVersionDir returns the directory that holds an object's previous versions on the gateway filesystem
+func localObjectVersionDir(workspaceName, objectId string) string {
+	return path.Join(localObjectDir(workspaceName), objectVersionDirName, objectId)
+}
+
+// workspaceObjectVersionPrefix returns the prefix of an object's previous versions in workspace storage
+func workspaceObjectVersionPrefix(objectId string) string {
+	return path.Join(types.DefaultObjectPrefix, objectVersionDirName, objectId)
+}
+
+// resolvedObjectCachePath returns where ResolveObjectPath keeps an object's decoded or downloaded content
+func resolvedObjectCachePath(workspaceName, objectId string) string {
+	return path.Join(localObjectDir(workspaceName), objectCacheDirName, objectId)
+}
+
+// invalidateResolvedObjectPath removes the copy ResolveObjectPath keeps of an object, so reads after the
+// object is overwritten or deleted don't see its previous content
+func invalidateResolvedObjectPath(workspaceName, objectId string) {
+	os.Remove(resolvedObjectCachePath(workspaceName, objectId))
+}
+
+// ResolveObjectPath returns a local path that can be opened to read the given object in the caller's
+// workspace. For workspaces without workspace storage this is the object's on-disk path. For workspaces
+// with workspace storage, the mounted path is preferred, falling back to downloading the
+// object into a local cache directory.
+func (gws *GatewayService) ResolveObjectPath(ctx context.Context, authInfo *auth.AuthInfo, objectId string) (string, error) {
+	if authInfo == nil || !auth.HasPermission(authInfo, types.PermissionRead) {
+		return "", ErrObjectAccessForbidden
+	}
+
+	workspace := authInfo.Workspace
+	object, err := gws.backendRepo.GetObjectByExternalId(ctx, objectId, workspace.Id)
+	if err != nil {
+		return "", ErrObjectNotFound
+	}
+
+	if accessible, err := gws.objectAccessible(ctx, authInfo, &object, types.ResourceAccessRead); err != nil || !accessible {
+		return "", ErrObjectAccessForbidden
+	}
+
+	gws.touchObject(ctx, object.ExternalId)
+
+	cachePath := resolvedObjectCachePath(workspace.Name, object.ExternalId)
+
+	if !workspace.StorageAvailable() {
+		objectPath := localObjectPath(workspace.Name, object.ExternalId)
+		if _, err := os.Stat(objectPath); err != nil {
+			return "", ErrObjectNotFound
+		}
+
+		if object.Compression == types.ObjectCompressionNone && object.Encryption == types.ObjectEncryptionNone {
+			return objectPath, nil
+		}
+
+		// Compressed or encrypted objects are decoded into the cache so callers always read the original bytes
+		if _, err := os.Stat(cachePath); err == nil {
+			return cachePath, nil
+		}
+
+		dataKey, err := gws.objectReadKey(&object)
+		if err != nil {
+			return "", err
+		}
+
+		f, err := os.Open(objectPath)
+		if err != nil {
+			return "", err
+		}
+		defer f.Close()
+
+		reader, err := common.NewObjectReader(object.Compression, dataKey, f)
+		if err != nil {
+			return "", err
+		}
+		defer reader.Close()
+
+		if err := writeObjectCacheFile(reader, cachePath); err != nil {
+			return "", err
+		}
+
+		return cachePath, nil
+	}
+
+	mountedPath := path.Join(gws.appConfig.Storage.WorkspaceStorage.BaseMountPath, workspace.Name, workspaceObjectKey(object.ExternalId))
+	if _, err := os.Stat(mountedPath); err == nil {
+		return mountedPath, nil
+	}
+
+	if _, err := os.Stat(cachePath); err == nil {
+		return cachePath, nil
+	}
+
+	storageClient, err := clients.NewWorkspaceStorageClient(ctx, workspace.Name, workspace.Storage)
+	if err != nil {
+		return "", err
+	}
+
+	reader, err := storageClient.DownloadWithReader(ctx, workspaceObjectKey(object.ExternalId))
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	if err := writeObjectCacheFile(reader, cachePath); err != nil {
+		return "", err
+	}
+
+	return cachePath, nil
+}
+
+// writeObjectCacheFile copies reader into cachePath. The content is written to a
+// temporary file first so readers never observe a partial file.
+func writeObjectCacheFile(reader io.Reader, cachePath string) error {
+	if err := os.MkdirAll(path.Dir(cachePath), 0755); err != nil {
+		return err
+	}
+
+	tmpFile, err := os.CreateTemp(path.Dir(cachePath), path.Base(cachePath)+".*"+objectTempFileSuffix)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := io.Copy(tmpFile, reader); err != nil {
+		tmpFile.Close()
+		return err
+	}
+
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFile.Name(), cachePath)
+}
@@ -0,0 +1,155 @@
+package gatewayservices
+
+import (
+	"context"
+
+	pb "github.com/beam-cloud/beta9/proto"
+)
+
+const (
+	defaultPutObjectAckIntervalBytes = 4 * 1024 * 1024
+	minPutObjectAckIntervalBytes     = 64 * 1024
+	defaultObjectStreamChunkSize     = 4 * 1024 * 1024
+	defaultObjectStreamWindowSize    = 32 * 1024 * 1024
+)
+
+// objectStreamLimits returns the largest chunk an upload stream accepts and how many bytes a client may
+// send past the last acked offset. The window always fits two chunks so a client can't stall waiting for
+// an ack the gateway won't send yet.
+func (gws *GatewayService) objectStreamLimits() (int64, int64) {
+	chunkSize := gws.appConfig.GatewayService.ObjectStreamChunkSize
+	if chunkSize <= 0 {
+		chunkSize = defaultObjectStreamChunkSize
+	}
+
+	window := gws.appConfig.GatewayService.ObjectStreamWindowSize
+	if window <= 0 {
+		window = defaultObjectStreamWindowSize
+	}
+
+	return chunkSize, max(window, 2*chunkSize)
+}
+
+// putObjectStream is what putObject needs from an upload stream, so the client streaming and
+// bidirectional upload RPCs can share one implementation
+type putObjectStream interface {
+	Context() context.Context
+	Recv() (*pb.PutObjectRequest, error)
+	// Progress is called each time more of the object's content has been written, with the total written so far
+	Progress(offset int64) error
+	// Finish sends the outcome of the upload
+	Finish(resp *pb.PutObjectResponse) error
+}
+
+// putObjectStreamError is returned by a putObjectStream's Recv when the client sent something invalid.
+// Its message is reported back to the client.
+type putObjectStreamError struct {
+	msg string
+}
+
+func (e *putObjectStreamError) Error() string {
+	return e.msg
+}
+
+// putObjectStreamV1 only reports the outcome once the client has sent everything
+type putObjectStreamV1 struct {
+	stream pb.GatewayService_PutObjectStreamServer
+}
+
+func (s *putObjectStreamV1) Context() context.Context {
+	return s.stream.Context()
+}
+
+func (s *putObjectStreamV1) Recv() (*pb.PutObjectRequest, error) {
+	return s.stream.Recv()
+}
+
+func (s *putObjectStreamV1) Progress(offset int64) error {
+	return nil
+}
+
+func (s *putObjectStreamV1) Finish(resp *pb.PutObjectResponse) error {
+	return s.stream.SendAndClose(resp)
+}
+
+// putObjectStreamV2 acks the written offset every ackInterval bytes and reports the outcome as soon as it's
+// known, so clients can show progress and stop sending when an upload fails part way through. It opens
+// with a handshake advertising the stream's limits, and rejects clients that send more than the window
+// ahead of what has been written, which keeps slow disks from building up data in the gateway.
+type putObjectStreamV2 struct {
+	stream         pb.GatewayService_PutObjectStreamV2Server
+	maxChunkSize   int64
+	window         int64
+	handshakeSent  bool
+	ackInterval    int64
+	ackedOffset    int64
+	receivedOffset int64
+}
+
+func (s *putObjectStreamV2) Context() context.Context {
+	return s.stream.Context()
+}
+
+func (s *putObjectStreamV2) Recv() (*pb.PutObjectRequest, error) {
+	if !s.handshakeSent {
+		err := s.stream.Send(&pb.PutObjectStreamResponse{
+			Payload: &pb.PutObjectStreamResponse_Handshake{
+				Handshake: &pb.PutObjectStreamHandshake{
+					MaxChunkSize: s.maxChunkSize,
+					WindowBytes:  s.window,
+				},
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		s.handshakeSent = true
+	}
+
+	request, err := s.stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+
+	// The first message may choose how often it's acked, as long as acks come often enough to reopen the window
+	if s.ackInterval == 0 {
+		s.ackInterval = defaultPutObjectAckIntervalBytes
+		if request.AckIntervalBytes > 0 {
+			s.ackInterval = max(request.AckIntervalBytes, minPutObjectAckIntervalBytes)
+		}
+		s.ackInterval = min(s.ackInterval, s.window/2)
+	}
+
+	s.receivedOffset += int64(len(request.ObjectContent))
+	if s.receivedOffset-s.ackedOffset > s.window {
+		return nil, &putObjectStreamError{msg: "Upload exceeded the in-flight window"}
+	}
+
+	return request, nil
+}
+
+func (s *putObjectStreamV2) Progress(offset int64) error {
+	if offset-s.ackedOffset < s.ackInterval {
+		return nil
+	}
+
+	s.ackedOffset = offset
+	return s.stream.Send(&pb.PutObjectStreamResponse{
+		Payload: &pb.PutObjectStreamResponse_Ack{
+			Ack: &pb.PutObjectAck{CommittedOffset: offset},
+		},
+	})
+}
+
+func (s *putObjectStreamV2) Finish(resp *pb.PutObjectResponse) error {
+	return s.stream.Send(&pb.PutObjectStreamResponse{
+		Payload: &pb.PutObjectStreamResponse_Result{
+			Result: resp,
+		},
+	})
+}
+
+func (gws *GatewayService) PutObjectStreamV2(stream pb.GatewayService_PutObjectStreamV2Server) error {
+	maxChunkSize, window := gws.objectStreamLimits()
+	return gws.putObject(&putObjectStreamV2{stream: stream, maxChunkSize: maxChunkSize, window: window})
+}
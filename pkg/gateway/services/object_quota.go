@@ -0,0 +1,69 @@
+package gatewayservices
+
+import (
+	"context"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+)
+
+const (
+	objectQuotaExceededErrMessage = "Workspace object storage quota exceeded"
+)
+
+// checkObjectQuota returns *types.ErrObjectQuotaExceeded if storing additional bytes on top of the workspace's
+// current usage would exceed the configured quota. A quota of zero means workspaces are unlimited.
+func (gws *GatewayService) checkObjectQuota(usage *types.ObjectStorageUsage, additional int64) error {
+	quota := gws.appConfig.Storage.ObjectQuotaBytes
+	if quota <= 0 || usage == nil {
+		return nil
+	}
+
+	if usage.UsedBytes+additional > quota {
+		return &types.ErrObjectQuotaExceeded{UsedBytes: usage.UsedBytes, QuotaBytes: quota}
+	}
+
+	return nil
+}
+
+// objectStorageUsage looks up the workspace's usage only when a quota is configured, so unlimited
+// deployments don't pay for the query on every upload
+func (gws *GatewayService) objectStorageUsage(ctx context.Context, workspaceId uint) (*types.ObjectStorageUsage, error) {
+	if gws.appConfig.Storage.ObjectQuotaBytes <= 0 {
+		return nil, nil
+	}
+
+	return gws.backendRepo.GetObjectStorageUsage(ctx, workspaceId)
+}
+
+func (gws *GatewayService) GetStorageUsage(ctx context.Context, in *pb.GetStorageUsageRequest) (*pb.GetStorageUsageResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionRead) {
+		return &pb.GetStorageUsageResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	usage, err := gws.backendRepo.GetObjectStorageUsage(ctx, authInfo.Workspace.Id)
+	if err != nil {
+		return &pb.GetStorageUsageResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to get storage usage",
+		}, nil
+	}
+
+	quota := gws.appConfig.Storage.ObjectQuotaBytes
+	if quota < 0 {
+		quota = 0
+	}
+
+	return &pb.GetStorageUsageResponse{
+		Ok:          true,
+		UsedBytes:   usage.UsedBytes,
+		QuotaBytes:  quota,
+		ObjectCount: usage.ObjectCount,
+	}, nil
+}
@@ -0,0 +1,217 @@
+package gatewayservices
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/beam-cloud/beta9/pkg/clients"
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultObjectReplicationInterval      = time.Minute
+	defaultObjectReplicationBatchSize     = 50
+	defaultObjectReplicationRetryInterval = time.Hour
+	objectReplicatorLockTtlS              = 300
+	auditActionObjectReplicate            = "object.replicate"
+)
+
+// replicateObjects copies objects to the replica storage of every workspace that has one, so workers in
+// the replica's region don't have to pull content across regions
+func (gws *GatewayService) replicateObjects() {
+	config := gws.appConfig.Storage.ObjectReplication
+	if !config.Enabled {
+		return
+	}
+
+	interval := config.Interval
+	if interval <= 0 {
+		interval = defaultObjectReplicationInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lock := common.NewRedisLock(gws.redisClient)
+	lockKey := common.RedisKeys.GatewayObjectReplicatorLock()
+
+	for {
+		select {
+		case <-gws.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := lock.Acquire(gws.ctx, lockKey, common.RedisLockOptions{TtlS: objectReplicatorLockTtlS, Retries: 0}); err != nil {
+				continue
+			}
+
+			gws.replicateWorkspaces(config)
+			lock.Release(lockKey)
+		}
+	}
+}
+
+func (gws *GatewayService) replicateWorkspaces(config types.ObjectReplicationConfig) {
+	deadline := time.Now().Add(objectReplicatorLockTtlS * time.Second / 2)
+
+	batchSize := config.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultObjectReplicationBatchSize
+	}
+
+	retryInterval := config.RetryInterval
+	if retryInterval <= 0 {
+		retryInterval = defaultObjectReplicationRetryInterval
+	}
+
+	workspaceIds, err := gws.backendRepo.ListWorkspaceIdsWithReplicaStorage(gws.ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to list workspaces with replica storage")
+		return
+	}
+
+	for _, workspaceId := range workspaceIds {
+		if time.Now().After(deadline) {
+			return
+		}
+
+		workspace, err := gws.backendRepo.GetWorkspace(gws.ctx, workspaceId)
+		if err != nil || !workspace.StorageAvailable() {
+			continue
+		}
+
+		replicaStorage, err := gws.backendRepo.GetWorkspaceReplicaStorage(gws.ctx, workspaceId)
+		if err != nil {
+			log.Error().Err(err).Str("workspace_id", workspace.ExternalId).Msg("unable to get replica storage")
+			continue
+		}
+
+		gws.replicateWorkspaceObjects(workspace, replicaStorage, batchSize, time.Now().Add(-retryInterval), deadline)
+	}
+}
+
+// replicateWorkspaceObjects copies the workspace's objects in batches until the deadline or until every
+// object has been attempted
+func (gws *GatewayService) replicateWorkspaceObjects(workspace *types.Workspace, replicaStorage *types.WorkspaceStorage, batchSize int, retryBefore, deadline time.Time) {
+	region := aws.ToString(replicaStorage.Region)
+
+	storageClient, err := clients.NewWorkspaceStorageClient(gws.ctx, workspace.Name, replicaStorage)
+	if err != nil {
+		log.Error().Err(err).Str("workspace_id", workspace.ExternalId).Msg("unable to replicate objects")
+		return
+	}
+
+	for time.Now().Before(deadline) {
+		objects, err := gws.backendRepo.ListObjectsPendingReplication(gws.ctx, workspace.Id, region, retryBefore, batchSize)
+		if err != nil {
+			log.Error().Err(err).Str("workspace_id", workspace.ExternalId).Msg("failed to list objects pending replication")
+			return
+		}
+
+		replicated := 0
+		for i := range objects {
+			object := &objects[i]
+
+			err := gws.copyObjectToStorage(gws.ctx, workspace, storageClient, object)
+			gws.auditLogger.Log(common.AuditEvent{
+				Action:       auditActionObjectReplicate,
+				Principal:    "replicator",
+				WorkspaceId:  workspace.ExternalId,
+				ResourceType: "object",
+				ResourceId:   object.ExternalId,
+				Outcome:      auditOutcome(err),
+				Reason:       errorMessage(err),
+				Attributes:   map[string]interface{}{"hash": object.Hash, "size": object.Size, "region": region},
+			})
+
+			status := types.ObjectReplicaStatusReplicated
+			if err != nil {
+				log.Warn().Err(err).Str("object_id", object.ExternalId).Str("region", region).Msg("failed to replicate object")
+				status = types.ObjectReplicaStatusFailed
+			}
+
+			if err := gws.backendRepo.UpdateObjectReplica(gws.ctx, object.Id, region, status, errorMessage(err)); err != nil {
+				log.Error().Err(err).Str("object_id", object.ExternalId).Msg("failed to record object replica")
+				return
+			}
+
+			if status == types.ObjectReplicaStatusReplicated {
+				replicated++
+			}
+		}
+
+		if replicated > 0 {
+			log.Info().Int("count", replicated).Str("workspace_id", workspace.ExternalId).Str("region", region).Msg("replicated objects")
+		}
+
+		if len(objects) < batchSize {
+			return
+		}
+	}
+}
+
+// objectReplicaStorage returns the workspace's replica storage if it holds a copy of the object in region
+func (gws *GatewayService) objectReplicaStorage(ctx context.Context, workspace *types.Workspace, replicas []types.ObjectReplica, region string) *types.WorkspaceStorage {
+	if region == "" {
+		return nil
+	}
+
+	for _, replica := range replicas {
+		if replica.Region != region || replica.Status != types.ObjectReplicaStatusReplicated {
+			continue
+		}
+
+		// Replicas recorded for a replica storage that has since been replaced can't be read
+		replicaStorage, err := gws.backendRepo.GetWorkspaceReplicaStorage(ctx, workspace.Id)
+		if err != nil || aws.ToString(replicaStorage.Region) != region {
+			return nil
+		}
+
+		return replicaStorage
+	}
+
+	return nil
+}
+
+// deleteObjectReplica removes the object's copy from the workspace's replica storage. Failures are only
+// logged, since the object itself is gone either way.
+func (gws *GatewayService) deleteObjectReplica(ctx context.Context, workspace *types.Workspace, object *types.Object, replicas []types.ObjectReplica) {
+	if len(replicas) == 0 {
+		return
+	}
+
+	replicaStorage, err := gws.backendRepo.GetWorkspaceReplicaStorage(ctx, workspace.Id)
+	if err != nil {
+		return
+	}
+
+	storageClient, err := clients.NewWorkspaceStorageClient(ctx, workspace.Name, replicaStorage)
+	if err != nil {
+		log.Warn().Err(err).Str("object_id", object.ExternalId).Msg("unable to delete object replica")
+		return
+	}
+
+	storageCtx, cancel := gws.withStorageTimeout(ctx)
+	defer cancel()
+
+	if err := storageClient.Delete(storageCtx, workspaceObjectKey(object.ExternalId)); err != nil {
+		log.Warn().Err(err).Str("object_id", object.ExternalId).Msg("unable to delete object replica")
+	}
+}
+
+func objectReplicasToProto(replicas []types.ObjectReplica) []*pb.ObjectReplica {
+	result := make([]*pb.ObjectReplica, 0, len(replicas))
+	for _, replica := range replicas {
+		result = append(result, &pb.ObjectReplica{
+			Region:    replica.Region,
+			Status:    string(replica.Status),
+			UpdatedAt: timestamppb.New(replica.UpdatedAt.Time),
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,778 @@
+package gatewayservices
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// S3RoutePrefix is where the S3 compatible API is served. Each workspace is exposed as a single bucket named
+// after the workspace, holding the objects that have a logical key. Requests are signed with AWS Signature
+// Version 4, using a token's id as the access key and the token itself as the secret key.
+const S3RoutePrefix = "/s3"
+
+const (
+	s3TimeFormat       = "2006-01-02T15:04:05.000Z"
+	s3DefaultMaxKeys   = 1000
+	s3MaxPutObjectSize = 5 * 1024 * 1024 * 1024
+	// s3UnsignedTrailer marks an aws-chunked body whose chunks aren't signed
+	s3UnsignedTrailer = "STREAMING-UNSIGNED-PAYLOAD-TRAILER"
+	// s3MaxKeySuffix sorts after any key starting with the same prefix, to skip past a common prefix
+	s3MaxKeySuffix = string(utf8.MaxRune)
+)
+
+var s3PayloadHashPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+type s3Error struct {
+	XMLName  xml.Name `xml:"Error"`
+	Code     string   `xml:"Code"`
+	Message  string   `xml:"Message"`
+	Resource string   `xml:"Resource,omitempty"`
+	status   int
+}
+
+func (e *s3Error) Error() string {
+	return e.Message
+}
+
+var (
+	errS3AccessDenied       = &s3Error{Code: "AccessDenied", Message: "Access Denied", status: http.StatusForbidden}
+	errS3InvalidAccessKeyId = &s3Error{Code: "InvalidAccessKeyId", Message: "The access key id you provided does not exist", status: http.StatusForbidden}
+	errS3SignatureMismatch  = &s3Error{Code: "SignatureDoesNotMatch", Message: "The request signature we calculated does not match the signature you provided", status: http.StatusForbidden}
+	errS3TimeTooSkewed      = &s3Error{Code: "RequestTimeTooSkewed", Message: "The difference between the request time and the server's time is too large, or the request has expired", status: http.StatusForbidden}
+	errS3MalformedAuth      = &s3Error{Code: "AuthorizationHeaderMalformed", Message: "The authorization header is malformed", status: http.StatusBadRequest}
+	errS3NoSuchBucket       = &s3Error{Code: "NoSuchBucket", Message: "The specified bucket does not exist", status: http.StatusNotFound}
+	errS3NoSuchKey          = &s3Error{Code: "NoSuchKey", Message: "The specified key does not exist", status: http.StatusNotFound}
+	errS3InvalidRange       = &s3Error{Code: "InvalidRange", Message: "The requested range is not satisfiable", status: http.StatusRequestedRangeNotSatisfiable}
+	errS3InvalidArgument    = &s3Error{Code: "InvalidArgument", Message: "Invalid argument", status: http.StatusBadRequest}
+	errS3EntityTooLarge     = &s3Error{Code: "EntityTooLarge", Message: "Your proposed upload exceeds the maximum allowed object size", status: http.StatusBadRequest}
+	errS3IncompleteBody     = &s3Error{Code: "IncompleteBody", Message: "You did not provide the number of bytes specified by the Content-Length HTTP header", status: http.StatusBadRequest}
+	errS3ContentMismatch    = &s3Error{Code: "XAmzContentSHA256Mismatch", Message: "The provided 'x-amz-content-sha256' header does not match what was computed", status: http.StatusBadRequest}
+	errS3QuotaExceeded      = &s3Error{Code: "QuotaExceeded", Message: objectQuotaExceededErrMessage, status: http.StatusForbidden}
+	errS3SlowDown           = &s3Error{Code: "SlowDown", Message: "Please reduce your request rate", status: http.StatusServiceUnavailable}
+	errS3InternalError      = &s3Error{Code: "InternalError", Message: "We encountered an internal error, please try again", status: http.StatusInternalServerError}
+	errS3NotImplemented     = &s3Error{Code: "NotImplemented", Message: "A header or query you provided implies functionality that is not implemented", status: http.StatusNotImplemented}
+)
+
+type s3Owner struct {
+	ID          string `xml:"ID"`
+	DisplayName string `xml:"DisplayName"`
+}
+
+type s3Bucket struct {
+	Name         string `xml:"Name"`
+	CreationDate string `xml:"CreationDate"`
+}
+
+type s3ListBucketsResult struct {
+	XMLName xml.Name   `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListAllMyBucketsResult"`
+	Owner   s3Owner    `xml:"Owner"`
+	Buckets []s3Bucket `xml:"Buckets>Bucket"`
+}
+
+type s3BucketLocation struct {
+	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ LocationConstraint"`
+}
+
+type s3ObjectEntry struct {
+	Key          string `xml:"Key"`
+	LastModified string `xml:"LastModified"`
+	ETag         string `xml:"ETag"`
+	Size         int64  `xml:"Size"`
+	StorageClass string `xml:"StorageClass"`
+}
+
+type s3CommonPrefix struct {
+	Prefix string `xml:"Prefix"`
+}
+
+type s3ListObjectsResult struct {
+	XMLName        xml.Name         `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListBucketResult"`
+	Name           string           `xml:"Name"`
+	Prefix         string           `xml:"Prefix"`
+	Delimiter      string           `xml:"Delimiter,omitempty"`
+	MaxKeys        int              `xml:"MaxKeys"`
+	IsTruncated    bool             `xml:"IsTruncated"`
+	Contents       []s3ObjectEntry  `xml:"Contents"`
+	CommonPrefixes []s3CommonPrefix `xml:"CommonPrefixes,omitempty"`
+
+	// Set for ListObjects
+	Marker     *string `xml:"Marker"`
+	NextMarker string  `xml:"NextMarker,omitempty"`
+
+	// Set for ListObjectsV2
+	KeyCount              *int   `xml:"KeyCount"`
+	StartAfter            string `xml:"StartAfter,omitempty"`
+	ContinuationToken     string `xml:"ContinuationToken,omitempty"`
+	NextContinuationToken string `xml:"NextContinuationToken,omitempty"`
+}
+
+type s3Group struct {
+	gws       *GatewayService
+	allowList *auth.IPAllowList
+}
+
+func registerS3Routes(g *echo.Group, gws *GatewayService) *s3Group {
+	group := &s3Group{
+		gws:       gws,
+		allowList: auth.NewIPAllowList(gws.appConfig.GatewayService.IPAllowList, gws.backendRepo, gws.workspaceRepo),
+	}
+
+	g.GET("", group.withAuth(group.ListBuckets))
+	g.GET("/", group.withAuth(group.ListBuckets))
+	g.HEAD("/:bucket", group.withBucket(group.HeadBucket))
+	g.HEAD("/:bucket/", group.withBucket(group.HeadBucket))
+	g.GET("/:bucket", group.withBucket(group.ListObjects))
+	g.GET("/:bucket/", group.withBucket(group.ListObjects))
+	g.HEAD("/:bucket/*", group.withBucket(group.HeadObject))
+	g.GET("/:bucket/*", group.withBucket(group.GetObject))
+	g.PUT("/:bucket/*", group.withBucket(group.PutObject))
+
+	return group
+}
+
+type s3HandlerFunc func(c echo.Context, authInfo *auth.AuthInfo, signature *auth.SigV4Request) error
+
+func (g *s3Group) withAuth(next s3HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		authInfo, signature, s3Err := g.authenticate(c.Request())
+		if s3Err != nil {
+			return g.writeError(c, s3Err)
+		}
+
+		return next(c, authInfo, signature)
+	}
+}
+
+// withBucket also checks the bucket in the path is the workspace the credentials belong to
+func (g *s3Group) withBucket(next s3HandlerFunc) echo.HandlerFunc {
+	return g.withAuth(func(c echo.Context, authInfo *auth.AuthInfo, signature *auth.SigV4Request) error {
+		bucket := c.Param("bucket")
+		if bucket != authInfo.Workspace.Name && bucket != authInfo.Workspace.ExternalId {
+			return g.writeError(c, errS3NoSuchBucket)
+		}
+
+		return next(c, authInfo, signature)
+	})
+}
+
+func (g *s3Group) authenticate(req *http.Request) (*auth.AuthInfo, *auth.SigV4Request, *s3Error) {
+	signature, err := auth.ParseSigV4Request(req)
+	if errors.Is(err, auth.ErrSigV4Missing) {
+		return nil, nil, errS3AccessDenied
+	} else if err != nil {
+		return nil, nil, errS3MalformedAuth
+	}
+
+	token, workspace, err := g.gws.backendRepo.AuthorizeTokenByExternalId(req.Context(), signature.AccessKeyId)
+	if err != nil || token.DisabledByClusterAdmin {
+		return nil, nil, errS3InvalidAccessKeyId
+	}
+
+	if err := signature.Verify(req, token.Key, time.Now()); err != nil {
+		switch {
+		case errors.Is(err, auth.ErrSigV4Expired):
+			return nil, nil, errS3TimeTooSkewed
+		case errors.Is(err, auth.ErrSigV4Malformed):
+			return nil, nil, errS3MalformedAuth
+		default:
+			return nil, nil, errS3SignatureMismatch
+		}
+	}
+
+	permission, scope := s3RequiredAccess(req.Method)
+	authInfo := &auth.AuthInfo{Token: token, Workspace: workspace}
+	if !auth.HasPermission(authInfo, permission) || !token.Scopes.Allows(scope) {
+		return nil, nil, errS3AccessDenied
+	}
+
+	if !g.allowList.AllowsHTTPRequest(req, authInfo) {
+		return nil, nil, errS3AccessDenied
+	}
+
+	return authInfo, signature, nil
+}
+
+// s3RequiredAccess returns the workspace permission and token scope a request needs. Reads only need read
+// access to the workspace's objects, anything else changes them.
+func s3RequiredAccess(method string) (types.Permission, types.TokenScope) {
+	if method == http.MethodGet || method == http.MethodHead {
+		return types.PermissionRead, types.NewTokenScope(types.TokenScopeResourceObjects, types.TokenScopeAccessRead)
+	}
+
+	return types.PermissionWrite, types.NewTokenScope(types.TokenScopeResourceObjects, types.TokenScopeAccessWrite)
+}
+
+func (g *s3Group) writeError(c echo.Context, s3Err *s3Error) error {
+	if c.Request().Method == http.MethodHead {
+		return c.NoContent(s3Err.status)
+	}
+
+	resp := *s3Err
+	resp.Resource = c.Request().URL.Path
+	return c.XML(s3Err.status, resp)
+}
+
+// objectKey is read from the decoded path, since route params keep keys percent encoded
+func (g *s3Group) objectKey(c echo.Context) string {
+	return strings.TrimPrefix(c.Request().URL.Path, S3RoutePrefix+"/"+c.Param("bucket")+"/")
+}
+
+func (g *s3Group) ListBuckets(c echo.Context, authInfo *auth.AuthInfo, signature *auth.SigV4Request) error {
+	return c.XML(http.StatusOK, s3ListBucketsResult{
+		Owner: s3Owner{ID: authInfo.Workspace.ExternalId, DisplayName: authInfo.Workspace.Name},
+		Buckets: []s3Bucket{
+			{Name: authInfo.Workspace.Name, CreationDate: authInfo.Workspace.CreatedAt.UTC().Format(s3TimeFormat)},
+		},
+	})
+}
+
+func (g *s3Group) HeadBucket(c echo.Context, authInfo *auth.AuthInfo, signature *auth.SigV4Request) error {
+	return c.NoContent(http.StatusOK)
+}
+
+func (g *s3Group) ListObjects(c echo.Context, authInfo *auth.AuthInfo, signature *auth.SigV4Request) error {
+	query := c.QueryParams()
+	if query.Has("location") {
+		return c.XML(http.StatusOK, s3BucketLocation{})
+	}
+
+	maxKeys := s3DefaultMaxKeys
+	if value := query.Get("max-keys"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return g.writeError(c, errS3InvalidArgument)
+		}
+		maxKeys = min(n, s3DefaultMaxKeys)
+	}
+
+	result := s3ListObjectsResult{
+		Name:      c.Param("bucket"),
+		Prefix:    query.Get("prefix"),
+		Delimiter: query.Get("delimiter"),
+		MaxKeys:   maxKeys,
+	}
+
+	listV2 := query.Get("list-type") == "2"
+	startAfter := query.Get("marker")
+	if listV2 {
+		result.StartAfter = query.Get("start-after")
+		result.ContinuationToken = query.Get("continuation-token")
+		startAfter = result.StartAfter
+
+		if result.ContinuationToken != "" {
+			token, err := base64.RawURLEncoding.DecodeString(result.ContinuationToken)
+			if err != nil {
+				return g.writeError(c, errS3InvalidArgument)
+			}
+			startAfter = string(token)
+		}
+	} else {
+		result.Marker = &startAfter
+	}
+
+	next, err := g.listEntries(c.Request().Context(), authInfo, &result, startAfter)
+	if err != nil {
+		log.Error().Err(err).Str("workspace_id", authInfo.Workspace.ExternalId).Msg("s3: error listing objects")
+		return g.writeError(c, errS3InternalError)
+	}
+
+	if listV2 {
+		keyCount := len(result.Contents) + len(result.CommonPrefixes)
+		result.KeyCount = &keyCount
+		if result.IsTruncated {
+			result.NextContinuationToken = base64.RawURLEncoding.EncodeToString([]byte(next))
+		}
+	} else if result.IsTruncated && result.Delimiter != "" {
+		result.NextMarker = next
+	}
+
+	return c.XML(http.StatusOK, result)
+}
+
+// listEntries fills in up to MaxKeys keys and common prefixes after startAfter. It returns the marker to
+// continue from when the listing is truncated. Objects the caller can't read are left out.
+func (g *s3Group) listEntries(ctx context.Context, authInfo *auth.AuthInfo, result *s3ListObjectsResult, startAfter string) (string, error) {
+	next := startAfter
+	lastPrefix := ""
+	batchSize := result.MaxKeys + 1
+
+	for {
+		objects, err := g.gws.backendRepo.ListObjectsByKey(ctx, authInfo.Workspace.Id, result.Prefix, next, batchSize)
+		if err != nil {
+			return "", err
+		}
+
+		acls, err := g.gws.objectACLs(ctx, objects)
+		if err != nil {
+			return "", err
+		}
+
+		for _, object := range objects {
+			key := *object.Key
+
+			if !auth.HasResourceAccess(authInfo, acls[object.Id], types.ResourceAccessRead) {
+				next = key
+				continue
+			}
+
+			commonPrefix := ""
+			if result.Delimiter != "" {
+				if i := strings.Index(key[len(result.Prefix):], result.Delimiter); i >= 0 {
+					commonPrefix = key[:len(result.Prefix)+i+len(result.Delimiter)]
+				}
+			}
+
+			// Keys are listed in order, so everything under a common prefix comes together
+			if commonPrefix != "" && commonPrefix == lastPrefix {
+				continue
+			}
+
+			if len(result.Contents)+len(result.CommonPrefixes) == result.MaxKeys {
+				result.IsTruncated = true
+				return next, nil
+			}
+
+			if commonPrefix != "" {
+				result.CommonPrefixes = append(result.CommonPrefixes, s3CommonPrefix{Prefix: commonPrefix})
+				lastPrefix = commonPrefix
+				next = commonPrefix + s3MaxKeySuffix
+				continue
+			}
+
+			result.Contents = append(result.Contents, s3ObjectEntry{
+				Key:          key,
+				LastModified: object.CreatedAt.UTC().Format(s3TimeFormat),
+				ETag:         s3ETag(&object),
+				Size:         object.Size,
+				StorageClass: "STANDARD",
+			})
+			next = key
+		}
+
+		// A full batch may have been rolled up into common prefixes, so keep going until there's nothing left
+		if len(objects) < batchSize {
+			return next, nil
+		}
+	}
+}
+
+func (g *s3Group) HeadObject(c echo.Context, authInfo *auth.AuthInfo, signature *auth.SigV4Request) error {
+	object, s3Err := g.getObject(c.Request().Context(), authInfo, g.objectKey(c))
+	if s3Err != nil {
+		return g.writeError(c, s3Err)
+	}
+
+	g.setObjectHeaders(c, object)
+	c.Response().Header().Set(echo.HeaderContentLength, strconv.FormatInt(object.Size, 10))
+	return c.NoContent(http.StatusOK)
+}
+
+func (g *s3Group) GetObject(c echo.Context, authInfo *auth.AuthInfo, signature *auth.SigV4Request) error {
+	ctx := c.Request().Context()
+
+	object, s3Err := g.getObject(ctx, authInfo, g.objectKey(c))
+	if s3Err != nil {
+		return g.writeError(c, s3Err)
+	}
+
+	offset, length, partial, err := parseS3Range(c.Request().Header.Get("Range"), object.Size)
+	if err != nil {
+		c.Response().Header().Set("Content-Range", fmt.Sprintf("bytes */%d", object.Size))
+		return g.writeError(c, errS3InvalidRange)
+	}
+
+	gws := g.gws
+	gws.touchObject(ctx, object.ExternalId)
+
+	reader, err := gws.openObjectRange(ctx, authInfo.Workspace, object, offset, length)
+	if err != nil {
+		log.Error().Err(err).Str("object_id", object.ExternalId).Msg("s3: error opening object")
+		return g.writeError(c, errS3InternalError)
+	}
+	defer reader.Close()
+
+	g.setObjectHeaders(c, object)
+	c.Response().Header().Set(echo.HeaderContentLength, strconv.FormatInt(length, 10))
+
+	code := http.StatusOK
+	if partial {
+		code = http.StatusPartialContent
+		c.Response().Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, offset+length-1, object.Size))
+	}
+
+	return c.Stream(code, echo.MIMEOctetStream, reader)
+}
+
+func (g *s3Group) getObject(ctx context.Context, authInfo *auth.AuthInfo, key string) (*types.Object, *s3Error) {
+	if key == "" {
+		return nil, errS3NoSuchKey
+	}
+
+	object, err := g.gws.backendRepo.GetObjectByKey(ctx, key, authInfo.Workspace.Id)
+	if err != nil || object.Incomplete {
+		return nil, errS3NoSuchKey
+	}
+
+	if accessible, err := g.gws.objectAccessible(ctx, authInfo, object, types.ResourceAccessRead); err != nil || !accessible {
+		return nil, errS3AccessDenied
+	}
+
+	return object, nil
+}
+
+func (g *s3Group) setObjectHeaders(c echo.Context, object *types.Object) {
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentType, echo.MIMEOctetStream)
+	header.Set(echo.HeaderLastModified, object.CreatedAt.UTC().Format(http.TimeFormat))
+	header.Set("ETag", s3ETag(object))
+	header.Set("Accept-Ranges", "bytes")
+}
+
+func (g *s3Group) PutObject(c echo.Context, authInfo *auth.AuthInfo, signature *auth.SigV4Request) error {
+	req := c.Request()
+	ctx := auth.ContextWithAuthInfo(req.Context(), authInfo)
+	gws := g.gws
+
+	key := g.objectKey(c)
+	if key == "" || req.Header.Get("X-Amz-Copy-Source") != "" {
+		return g.writeError(c, errS3NotImplemented)
+	}
+
+	if req.ContentLength > s3MaxPutObjectSize {
+		return g.writeError(c, errS3EntityTooLarge)
+	}
+
+	existingObject, err := gws.backendRepo.GetObjectByKey(ctx, key, authInfo.Workspace.Id)
+	if err == nil {
+		if accessible, err := gws.objectAccessible(ctx, authInfo, existingObject, types.ResourceAccessWrite); err != nil || !accessible {
+			return g.writeError(c, errS3AccessDenied)
+		}
+	}
+	if err == nil && existingObject.IsLocked() {
+		return g.writeError(c, errS3AccessDenied)
+	}
+
+	body, hash, cleanup, s3Err := g.readPutBody(req, authInfo.Workspace, signature)
+	if s3Err != nil {
+		return g.writeError(c, s3Err)
+	}
+	defer cleanup()
+
+	maxChunkSize, _ := gws.objectStreamLimits()
+	stream := &s3PutObjectStream{
+		ctx:       ctx,
+		body:      body,
+		hash:      hash,
+		chunkSize: maxChunkSize,
+	}
+
+	if err := gws.putObject(stream); err != nil {
+		switch status.Code(err) {
+		case codes.PermissionDenied, codes.FailedPrecondition:
+			return g.writeError(c, errS3AccessDenied)
+		case codes.ResourceExhausted:
+			return g.writeError(c, errS3SlowDown)
+		default:
+			return g.writeError(c, errS3InternalError)
+		}
+	}
+
+	if resp := stream.resp; resp == nil || !resp.Ok {
+		if resp == nil {
+			return g.writeError(c, errS3InternalError)
+		}
+
+		switch resp.ErrorMsg {
+		case objectQuotaExceededErrMessage:
+			return g.writeError(c, errS3QuotaExceeded)
+		case objectChecksumMismatchErrMessage:
+			return g.writeError(c, errS3ContentMismatch)
+		case errS3IncompleteBody.Message:
+			return g.writeError(c, errS3IncompleteBody)
+		default:
+			return g.writeError(c, errS3InternalError)
+		}
+	}
+
+	object, err := gws.backendRepo.GetObjectByExternalId(ctx, stream.resp.ObjectId, authInfo.Workspace.Id)
+	if err != nil {
+		return g.writeError(c, errS3InternalError)
+	}
+
+	// Keys are unique per workspace, so the object the key pointed to before keeps its content but loses the key
+	if existingObject != nil && existingObject.ExternalId != object.ExternalId {
+		if err := gws.backendRepo.UpdateObjectKeyByExternalId(ctx, existingObject.ExternalId, authInfo.Workspace.Id, ""); err != nil {
+			gws.auditObject(authInfo, auditActionObjectRename, existingObject.ExternalId, existingObject.Hash, existingObject.Size, err.Error())
+			return g.writeError(c, errS3InternalError)
+		}
+	}
+
+	if err := gws.backendRepo.UpdateObjectKeyByExternalId(ctx, object.ExternalId, authInfo.Workspace.Id, key); err != nil {
+		gws.auditObject(authInfo, auditActionObjectRename, object.ExternalId, object.Hash, object.Size, err.Error())
+		return g.writeError(c, errS3InternalError)
+	}
+	gws.auditObject(authInfo, auditActionObjectRename, object.ExternalId, object.Hash, object.Size, "")
+
+	c.Response().Header().Set("ETag", s3ETag(&object))
+	return c.NoContent(http.StatusOK)
+}
+
+// readPutBody returns the content of an upload along with its SHA256. Uploads are created from their hash,
+// so a body whose hash wasn't signed is spooled to disk first to hash it.
+func (g *s3Group) readPutBody(req *http.Request, workspace *types.Workspace, signature *auth.SigV4Request) (io.Reader, string, func(), *s3Error) {
+	var body io.Reader = req.Body
+
+	switch {
+	case s3PayloadHashPattern.MatchString(signature.PayloadHash):
+		return body, signature.PayloadHash, func() {}, nil
+	case signature.PayloadHash == auth.SigV4UnsignedBody:
+	case signature.PayloadHash == s3UnsignedTrailer:
+		body = newAWSChunkedReader(body)
+	default:
+		// Chunks signed individually aren't supported, clients only send them when told not to sign the payload
+		return nil, "", nil, errS3NotImplemented
+	}
+
+	uploadDir := path.Join(localObjectDir(workspace.Name), objectUploadDirName)
+	os.MkdirAll(uploadDir, 0755)
+
+	file, err := os.CreateTemp(uploadDir, "s3-")
+	if err != nil {
+		log.Error().Err(err).Msg("s3: error creating upload file")
+		return nil, "", nil, errS3InternalError
+	}
+
+	cleanup := func() {
+		file.Close()
+		os.Remove(file.Name())
+	}
+
+	hasher := sha256.New()
+	n, err := io.Copy(io.MultiWriter(file, hasher), io.LimitReader(body, s3MaxPutObjectSize+1))
+	if err != nil {
+		cleanup()
+		if errors.Is(err, errAWSChunkedMalformed) {
+			return nil, "", nil, errS3IncompleteBody
+		}
+
+		log.Error().Err(err).Msg("s3: error reading upload body")
+		return nil, "", nil, errS3InternalError
+	}
+
+	if n > s3MaxPutObjectSize {
+		cleanup()
+		return nil, "", nil, errS3EntityTooLarge
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		cleanup()
+		return nil, "", nil, errS3InternalError
+	}
+
+	return file, hex.EncodeToString(hasher.Sum(nil)), cleanup, nil
+}
+
+// s3PutObjectStream feeds the body of a PUT request through the regular upload path
+type s3PutObjectStream struct {
+	ctx       context.Context
+	body      io.Reader
+	hash      string
+	chunkSize int64
+	started   bool
+	resp      *pb.PutObjectResponse
+}
+
+func (s *s3PutObjectStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *s3PutObjectStream) Recv() (*pb.PutObjectRequest, error) {
+	buf := make([]byte, s.chunkSize)
+	n, err := io.ReadFull(s.body, buf)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		err = nil
+	}
+
+	if err != nil {
+		if errors.Is(err, errAWSChunkedMalformed) {
+			return nil, &putObjectStreamError{msg: errS3IncompleteBody.Message}
+		}
+		return nil, err
+	}
+
+	// The first chunk creates the object even if the body is empty
+	if n == 0 && s.started {
+		return nil, io.EOF
+	}
+	s.started = true
+
+	return &pb.PutObjectRequest{
+		ObjectContent: buf[:n],
+		Hash:          s.hash,
+	}, nil
+}
+
+func (s *s3PutObjectStream) Progress(offset int64) error {
+	return nil
+}
+
+func (s *s3PutObjectStream) Finish(resp *pb.PutObjectResponse) error {
+	s.resp = resp
+	return nil
+}
+
+// parseS3Range reads a single byte range. Headers with several ranges are ignored and the whole object is
+// returned, as S3 does.
+func parseS3Range(header string, size int64) (offset, length int64, partial bool, err error) {
+	spec, ok := strings.CutPrefix(header, "bytes=")
+	if !ok || strings.Contains(spec, ",") {
+		return 0, size, false, nil
+	}
+
+	first, last, ok := strings.Cut(spec, "-")
+	if !ok {
+		return 0, size, false, nil
+	}
+
+	if first == "" {
+		suffix, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || suffix <= 0 || size == 0 {
+			return 0, 0, false, errS3InvalidRange
+		}
+
+		suffix = min(suffix, size)
+		return size - suffix, suffix, true, nil
+	}
+
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || start < 0 || start >= size {
+		return 0, 0, false, errS3InvalidRange
+	}
+
+	end := size - 1
+	if last != "" {
+		end, err = strconv.ParseInt(last, 10, 64)
+		if err != nil || end < start {
+			return 0, 0, false, errS3InvalidRange
+		}
+		end = min(end, size-1)
+	}
+
+	return start, end - start + 1, true, nil
+}
+
+func s3ETag(object *types.Object) string {
+	if object.Digest != "" {
+		return `"` + object.Digest + `"`
+	}
+
+	return `"` + object.Hash + `"`
+}
+
+var errAWSChunkedMalformed = errors.New("malformed aws-chunked body")
+
+// awsChunkedReader decodes an aws-chunked body, where each chunk is prefixed with its hex encoded size and
+// the final, empty chunk is followed by trailing headers
+type awsChunkedReader struct {
+	r         *bufio.Reader
+	remaining int64
+	done      bool
+}
+
+func newAWSChunkedReader(r io.Reader) *awsChunkedReader {
+	return &awsChunkedReader{r: bufio.NewReader(r)}
+}
+
+func (r *awsChunkedReader) Read(p []byte) (int, error) {
+	if r.done {
+		return 0, io.EOF
+	}
+
+	if r.remaining == 0 {
+		line, err := r.readLine()
+		if err != nil {
+			return 0, err
+		}
+
+		sizeField, _, _ := strings.Cut(line, ";")
+		size, err := strconv.ParseInt(sizeField, 16, 64)
+		if err != nil || size < 0 {
+			return 0, errAWSChunkedMalformed
+		}
+
+		if size == 0 {
+			// Trailers such as checksums end with an empty line
+			for {
+				line, err := r.readLine()
+				if err != nil {
+					return 0, err
+				}
+
+				if line == "" {
+					r.done = true
+					return 0, io.EOF
+				}
+			}
+		}
+
+		r.remaining = size
+	}
+
+	if int64(len(p)) > r.remaining {
+		p = p[:r.remaining]
+	}
+
+	n, err := r.r.Read(p)
+	r.remaining -= int64(n)
+	if err == io.EOF {
+		return n, errAWSChunkedMalformed
+	}
+
+	if err != nil {
+		return n, err
+	}
+
+	if r.remaining == 0 {
+		if line, err := r.readLine(); err != nil || line != "" {
+			return n, errAWSChunkedMalformed
+		}
+	}
+
+	return n, nil
+}
+
+func (r *awsChunkedReader) readLine() (string, error) {
+	line, err := r.r.ReadString('\n')
+	if err == io.EOF {
+		return "", errAWSChunkedMalformed
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(line, "\r\n"), nil
+}
@@ -0,0 +1,41 @@
+package gatewayservices
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/beam-cloud/beta9/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestS3RequiredAccess(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		scopes      types.TokenScopes
+		wantAllowed bool
+	}{
+		{"unscoped get", http.MethodGet, nil, true},
+		{"unscoped put", http.MethodPut, nil, true},
+		{"objects read get", http.MethodGet, types.TokenScopes{"objects:read"}, true},
+		{"objects read head", http.MethodHead, types.TokenScopes{"objects:read"}, true},
+		{"objects read put", http.MethodPut, types.TokenScopes{"objects:read"}, false},
+		{"objects write put", http.MethodPut, types.TokenScopes{"objects:write"}, true},
+		{"objects write get", http.MethodGet, types.TokenScopes{"objects:write"}, true},
+		{"deployments read get", http.MethodGet, types.TokenScopes{"deployments:read"}, false},
+		{"deployments admin put", http.MethodPut, types.TokenScopes{"deployments:admin"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, scope := s3RequiredAccess(tt.method)
+			assert.Equal(t, tt.wantAllowed, tt.scopes.Allows(scope))
+		})
+	}
+
+	permission, _ := s3RequiredAccess(http.MethodHead)
+	assert.Equal(t, types.PermissionRead, permission)
+
+	permission, _ = s3RequiredAccess(http.MethodPut)
+	assert.Equal(t, types.PermissionWrite, permission)
+}
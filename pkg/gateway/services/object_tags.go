@@ -0,0 +1,113 @@
+package gatewayservices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+)
+
+const (
+	auditActionObjectTag = "object.tag"
+	maxObjectTags        = 50
+	maxObjectTagKeyLen   = 128
+	maxObjectTagValueLen = 256
+)
+
+var errTooManyObjectTags = fmt.Errorf("objects can have at most %d tags", maxObjectTags)
+
+// validateObjectTags checks tags supplied by a client. The total is checked after they're merged.
+func validateObjectTags(tags map[string]string) error {
+	for key, value := range tags {
+		if key == "" {
+			return errors.New("tag keys must not be empty")
+		}
+
+		if len(key) > maxObjectTagKeyLen {
+			return fmt.Errorf("tag key %q is longer than %d characters", key, maxObjectTagKeyLen)
+		}
+
+		if len(value) > maxObjectTagValueLen {
+			return fmt.Errorf("value of tag %q is longer than %d characters", key, maxObjectTagValueLen)
+		}
+	}
+
+	if len(tags) > maxObjectTags {
+		return errTooManyObjectTags
+	}
+
+	return nil
+}
+
+// tagObject merges tags into an object's existing tags, rolling back if the result has too many
+func (gws *GatewayService) tagObject(ctx context.Context, object *types.Object, tags types.ObjectTags, removeKeys []string, replace bool) (types.ObjectTags, error) {
+	updated, err := gws.backendRepo.UpdateObjectTagsByExternalId(ctx, object.ExternalId, object.WorkspaceId, tags, removeKeys, replace)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(updated) > maxObjectTags {
+		gws.backendRepo.UpdateObjectTagsByExternalId(ctx, object.ExternalId, object.WorkspaceId, object.Tags, nil, true)
+		return nil, errTooManyObjectTags
+	}
+
+	object.Tags = updated
+	return updated, nil
+}
+
+func (gws *GatewayService) TagObject(ctx context.Context, in *pb.TagObjectRequest) (*pb.TagObjectResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionWrite) {
+		return &pb.TagObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	if err := validateObjectTags(in.Tags); err != nil {
+		return &pb.TagObjectResponse{
+			Ok:       false,
+			ErrorMsg: err.Error(),
+		}, nil
+	}
+
+	object, err := gws.backendRepo.GetObjectByExternalId(ctx, in.ObjectId, authInfo.Workspace.Id)
+	if err != nil {
+		return &pb.TagObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Object not found",
+		}, nil
+	}
+
+	if accessible, err := gws.objectAccessible(ctx, authInfo, &object, types.ResourceAccessWrite); err != nil || !accessible {
+		return &pb.TagObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	tags, err := gws.tagObject(ctx, &object, in.Tags, in.RemoveKeys, in.Replace)
+	gws.auditObject(authInfo, auditActionObjectTag, object.ExternalId, object.Hash, object.Size, errorMessage(err))
+	if errors.Is(err, errTooManyObjectTags) {
+		return &pb.TagObjectResponse{
+			Ok:       false,
+			ErrorMsg: err.Error(),
+		}, nil
+	}
+
+	if err != nil {
+		return &pb.TagObjectResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to tag object",
+		}, nil
+	}
+
+	return &pb.TagObjectResponse{
+		Ok:   true,
+		Tags: tags,
+	}, nil
+}
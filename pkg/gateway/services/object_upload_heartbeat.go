@@ -0,0 +1,195 @@
+package gatewayservices
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultUploadHeartbeatTimeout   = 10 * time.Minute
+	abandonedUploadCleanupInterval  = time.Minute
+	abandonedUploadCleanupBatchSize = 100
+)
+
+// uploadHeartbeat tracks an in-progress PutObjectStream upload, so uploads whose client went quiet or whose
+// gateway died can be cleaned up by any gateway. The upload and the janitor both claim the entry by removing
+// it, which makes exactly one of them responsible for the object row and partial file.
+type uploadHeartbeat struct {
+	redisClient *common.RedisClient
+	member      string
+	interval    time.Duration
+	lastBeat    time.Time
+}
+
+func uploadHeartbeatMember(workspaceId uint, objectId string) string {
+	return fmt.Sprintf("%d:%s", workspaceId, objectId)
+}
+
+func parseUploadHeartbeatMember(member string) (uint, string, error) {
+	workspaceId, objectId, ok := strings.Cut(member, ":")
+	if !ok {
+		return 0, "", fmt.Errorf("invalid upload heartbeat member: %s", member)
+	}
+
+	id, err := strconv.ParseUint(workspaceId, 10, 64)
+	if err != nil {
+		return 0, "", err
+	}
+
+	return uint(id), objectId, nil
+}
+
+// activeUploadObjectIds returns the ids of objects with a stream upload in progress on any gateway
+func (gws *GatewayService) activeUploadObjectIds() (map[string]bool, error) {
+	members, err := gws.redisClient.ZRange(gws.ctx, common.RedisKeys.GatewayObjectUploads(), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	objectIds := make(map[string]bool, len(members))
+	for _, member := range members {
+		if _, objectId, err := parseUploadHeartbeatMember(member); err == nil {
+			objectIds[objectId] = true
+		}
+	}
+
+	return objectIds, nil
+}
+
+func (gws *GatewayService) uploadHeartbeatTimeout() time.Duration {
+	if timeout := gws.appConfig.GatewayService.UploadHeartbeatTimeout; timeout > 0 {
+		return timeout
+	}
+
+	return defaultUploadHeartbeatTimeout
+}
+
+// startUploadHeartbeat registers an upload as in progress. Failing to register only means a crash mid-upload
+// leaves its object row behind, so errors are logged rather than failing the upload.
+func (gws *GatewayService) startUploadHeartbeat(ctx context.Context, workspaceId uint, objectId string) *uploadHeartbeat {
+	h := &uploadHeartbeat{
+		redisClient: gws.redisClient,
+		member:      uploadHeartbeatMember(workspaceId, objectId),
+		interval:    gws.uploadHeartbeatTimeout() / 4,
+		lastBeat:    time.Now(),
+	}
+
+	if err := h.redisClient.ZAdd(ctx, common.RedisKeys.GatewayObjectUploads(), redis.Z{
+		Score:  float64(h.lastBeat.UnixMilli()),
+		Member: h.member,
+	}).Err(); err != nil {
+		log.Warn().Err(err).Str("object_id", objectId).Msg("unable to register upload heartbeat")
+	}
+
+	return h
+}
+
+// Beat records that the upload is still receiving content. It returns false once the upload has been
+// abandoned and cleaned up, in which case the caller must stop without touching the object.
+func (h *uploadHeartbeat) Beat(ctx context.Context) bool {
+	now := time.Now()
+	if now.Sub(h.lastBeat) < h.interval {
+		return true
+	}
+
+	changed, err := h.redisClient.ZAddArgs(ctx, common.RedisKeys.GatewayObjectUploads(), redis.ZAddArgs{
+		XX:      true,
+		Ch:      true,
+		Members: []redis.Z{{Score: float64(now.UnixMilli()), Member: h.member}},
+	}).Result()
+	if err != nil {
+		log.Warn().Err(err).Str("member", h.member).Msg("unable to update upload heartbeat")
+		return true
+	}
+
+	h.lastBeat = now
+	return changed > 0
+}
+
+// Finish stops tracking the upload. It returns false if the janitor already claimed it.
+func (h *uploadHeartbeat) Finish(ctx context.Context) bool {
+	removed, err := h.redisClient.ZRem(ctx, common.RedisKeys.GatewayObjectUploads(), h.member).Result()
+	if err != nil {
+		log.Warn().Err(err).Str("member", h.member).Msg("unable to remove upload heartbeat")
+		return true
+	}
+
+	return removed > 0
+}
+
+// collectAbandonedUploads deletes the object rows and partial files of stream uploads that stopped
+// heartbeating, either because the client went away without closing the stream or the gateway died
+func (gws *GatewayService) collectAbandonedUploads() {
+	timeout := gws.uploadHeartbeatTimeout()
+
+	ticker := time.NewTicker(abandonedUploadCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-gws.ctx.Done():
+			return
+		case <-ticker.C:
+			removed := gws.removeAbandonedUploads(time.Now().Add(-timeout))
+			if removed > 0 {
+				log.Info().Int("count", removed).Msg("removed abandoned uploads")
+			}
+		}
+	}
+}
+
+func (gws *GatewayService) removeAbandonedUploads(before time.Time) int {
+	key := common.RedisKeys.GatewayObjectUploads()
+
+	members, err := gws.redisClient.ZRangeByScore(gws.ctx, key, &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(before.UnixMilli(), 10),
+		Count: abandonedUploadCleanupBatchSize,
+	}).Result()
+	if err != nil {
+		log.Error().Err(err).Msg("failed to list abandoned uploads")
+		return 0
+	}
+
+	removed := 0
+	for _, member := range members {
+		// Another gateway, or the upload itself, may have claimed the entry since it was listed
+		claimed, err := gws.redisClient.ZRem(gws.ctx, key, member).Result()
+		if err != nil || claimed == 0 {
+			continue
+		}
+
+		workspaceId, objectId, err := parseUploadHeartbeatMember(member)
+		if err != nil {
+			continue
+		}
+
+		workspace, err := gws.backendRepo.GetWorkspace(gws.ctx, workspaceId)
+		if err == nil {
+			tempFiles, _ := filepath.Glob(path.Join(localObjectDir(workspace.Name), objectId+".*"+objectTempFileSuffix))
+			for _, tempFile := range tempFiles {
+				os.Remove(tempFile)
+			}
+			invalidateResolvedObjectPath(workspace.Name, objectId)
+		}
+
+		if err := gws.backendRepo.DeleteObjectByExternalId(gws.ctx, objectId); err != nil {
+			log.Warn().Err(err).Str("object_id", objectId).Msg("unable to delete abandoned upload")
+			continue
+		}
+
+		removed++
+	}
+
+	return removed
+}
@@ -0,0 +1,522 @@
+package gatewayservices
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"time"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	objectUploadDirName                = ".uploads"
+	defaultUploadSessionTTL            = 24 * time.Hour
+	uploadSessionCleanupInterval       = 10 * time.Minute
+	auditActionObjectUploadInitiate    = "object.upload_initiate"
+	auditActionObjectUploadComplete    = "object.upload_complete"
+	uploadSessionNotFoundErrMessage    = "Upload session not found"
+	uploadSessionOffsetMismatchMessage = "Offset does not match the last acknowledged offset"
+	minUploadChunkSize                 = 64 * 1024
+)
+
+// uploadSessionPath returns the on-disk path of the partial data for a resumable upload
+func uploadSessionPath(workspaceName, sessionId string) string {
+	return path.Join(localObjectDir(workspaceName), objectUploadDirName, sessionId)
+}
+
+func (gws *GatewayService) InitiateUpload(ctx context.Context, in *pb.InitiateUploadRequest) (*pb.InitiateUploadResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionDeploy) {
+		return &pb.InitiateUploadResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	// Resuming returns the last acknowledged offset so the client knows where to continue from
+	if in.SessionId != "" {
+		session, err := gws.backendRepo.GetObjectUploadSession(ctx, in.SessionId, authInfo.Workspace.Id)
+		if err != nil {
+			return &pb.InitiateUploadResponse{
+				Ok:       false,
+				ErrorMsg: uploadSessionNotFoundErrMessage,
+			}, nil
+		}
+
+		var receivedChunks []int64
+		if session.ChunkSize > 0 {
+			receivedChunks, err = gws.backendRepo.ListObjectUploadChunks(ctx, session.Id)
+			if err != nil {
+				return &pb.InitiateUploadResponse{
+					Ok:       false,
+					ErrorMsg: "Unable to list received chunks",
+				}, nil
+			}
+		}
+
+		return &pb.InitiateUploadResponse{
+			Ok:             true,
+			SessionId:      session.ExternalId,
+			Offset:         session.Offset,
+			ChunkSize:      session.ChunkSize,
+			ReceivedChunks: receivedChunks,
+		}, nil
+	}
+
+	if !isValidObjectHash(in.Hash) || in.Size < 0 {
+		return &pb.InitiateUploadResponse{
+			Ok:       false,
+			ErrorMsg: "A hex encoded SHA256 hash and a non-negative size are required",
+		}, nil
+	}
+
+	if in.ChunkSize != 0 {
+		// Chunks have to fit in a single message, and placing them by index needs the total size up front
+		maxChunkSize := int64(gws.appConfig.GatewayService.GRPC.MaxRecvMsgSize) * 1024 * 1024
+		if in.ChunkSize < minUploadChunkSize || in.ChunkSize > maxChunkSize || in.Size == 0 {
+			return &pb.InitiateUploadResponse{
+				Ok:       false,
+				ErrorMsg: fmt.Sprintf("Chunked uploads need a size and a chunk size between %d and %d bytes", minUploadChunkSize, maxChunkSize),
+			}, nil
+		}
+	}
+
+	existingObject, err := gws.backendRepo.GetObjectByHash(ctx, in.Hash, authInfo.Workspace.Id)
+	if err == nil {
+		if accessible, err := gws.objectAccessible(ctx, authInfo, existingObject, types.ResourceAccessWrite); err != nil || !accessible {
+			gws.auditObject(authInfo, auditActionObjectUploadInitiate, existingObject.ExternalId, in.Hash, in.Size, "Unauthorized Access")
+			return nil, status.Error(codes.PermissionDenied, "Unauthorized Access")
+		}
+	}
+	if err == nil && existingObject.IsLocked() {
+		lockErr := &types.ErrObjectLocked{ObjectId: existingObject.ExternalId, RetainUntil: existingObject.RetainUntil.Time}
+		gws.auditObject(authInfo, auditActionObjectUploadInitiate, existingObject.ExternalId, in.Hash, in.Size, lockErr.Error())
+		return nil, status.Error(codes.FailedPrecondition, lockErr.Error())
+	}
+
+	usage, err := gws.objectStorageUsage(ctx, authInfo.Workspace.Id)
+	if err != nil {
+		return &pb.InitiateUploadResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to check storage usage",
+		}, nil
+	}
+
+	if err := gws.checkObjectQuota(usage, in.Size); err != nil {
+		gws.auditObject(authInfo, auditActionObjectUploadInitiate, "", in.Hash, in.Size, err.Error())
+		return &pb.InitiateUploadResponse{
+			Ok:       false,
+			ErrorMsg: objectQuotaExceededErrMessage,
+		}, nil
+	}
+
+	session, err := gws.backendRepo.CreateObjectUploadSession(ctx, authInfo.Workspace.Id, in.Hash, in.Size, in.ChunkSize)
+	if err != nil {
+		gws.auditObject(authInfo, auditActionObjectUploadInitiate, "", in.Hash, in.Size, err.Error())
+		return &pb.InitiateUploadResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to create upload session",
+		}, nil
+	}
+
+	gws.auditObject(authInfo, auditActionObjectUploadInitiate, "", in.Hash, in.Size, "")
+	return &pb.InitiateUploadResponse{
+		Ok:        true,
+		SessionId: session.ExternalId,
+		Offset:    0,
+		ChunkSize: session.ChunkSize,
+	}, nil
+}
+
+func (gws *GatewayService) AppendChunk(ctx context.Context, in *pb.AppendChunkRequest) (*pb.AppendChunkResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionDeploy) {
+		return &pb.AppendChunkResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	session, err := gws.backendRepo.GetObjectUploadSession(ctx, in.SessionId, authInfo.Workspace.Id)
+	if err != nil {
+		return &pb.AppendChunkResponse{
+			Ok:       false,
+			ErrorMsg: uploadSessionNotFoundErrMessage,
+		}, nil
+	}
+
+	if session.ChunkSize > 0 {
+		return gws.writeSessionChunk(ctx, authInfo, session, in)
+	}
+
+	if in.Offset != session.Offset {
+		return &pb.AppendChunkResponse{
+			Ok:       false,
+			Offset:   session.Offset,
+			ErrorMsg: uploadSessionOffsetMismatchMessage,
+		}, nil
+	}
+
+	newOffset := session.Offset + int64(len(in.Content))
+	if session.Size > 0 && newOffset > session.Size {
+		return &pb.AppendChunkResponse{
+			Ok:       false,
+			Offset:   session.Offset,
+			ErrorMsg: "Chunk exceeds the declared upload size",
+		}, nil
+	}
+
+	release, err := gws.uploadLimiter.Acquire(ctx)
+	if err != nil {
+		return nil, status.Error(codes.ResourceExhausted, "Too many concurrent uploads, try again later")
+	}
+	defer release()
+
+	if err := writeUploadChunk(uploadSessionPath(authInfo.Workspace.Name, session.ExternalId), session.Offset, in.Content); err != nil {
+		log.Error().Err(err).Str("session_id", session.ExternalId).Msg("AppendChunk: error writing chunk")
+		return &pb.AppendChunkResponse{
+			Ok:       false,
+			Offset:   session.Offset,
+			ErrorMsg: "Unable to write chunk",
+		}, nil
+	}
+
+	if err := gws.backendRepo.UpdateObjectUploadSessionOffset(ctx, session.ExternalId, newOffset); err != nil {
+		log.Error().Err(err).Str("session_id", session.ExternalId).Msg("AppendChunk: error updating offset")
+		return &pb.AppendChunkResponse{
+			Ok:       false,
+			Offset:   session.Offset,
+			ErrorMsg: "Unable to record chunk",
+		}, nil
+	}
+
+	return &pb.AppendChunkResponse{
+		Ok:     true,
+		Offset: newOffset,
+	}, nil
+}
+
+// writeSessionChunk stores one chunk of a chunked session at its place in the partial upload file. Chunks
+// don't overlap, so concurrent streams can write their chunks in any order.
+func (gws *GatewayService) writeSessionChunk(ctx context.Context, authInfo *auth.AuthInfo, session *types.ObjectUploadSession, in *pb.AppendChunkRequest) (*pb.AppendChunkResponse, error) {
+	if in.ChunkIndex < 0 || in.ChunkIndex >= session.ChunkCount() {
+		return &pb.AppendChunkResponse{
+			Ok:       false,
+			Offset:   session.Offset,
+			ErrorMsg: "Chunk index is out of range",
+		}, nil
+	}
+
+	if int64(len(in.Content)) != session.ChunkLength(in.ChunkIndex) {
+		return &pb.AppendChunkResponse{
+			Ok:       false,
+			Offset:   session.Offset,
+			ErrorMsg: fmt.Sprintf("Chunk %d must be %d bytes", in.ChunkIndex, session.ChunkLength(in.ChunkIndex)),
+		}, nil
+	}
+
+	release, err := gws.uploadLimiter.Acquire(ctx)
+	if err != nil {
+		return nil, status.Error(codes.ResourceExhausted, "Too many concurrent uploads, try again later")
+	}
+	defer release()
+
+	if err := gws.workspaceUploadThrottle(ctx, authInfo.Workspace).Wait(ctx, len(in.Content)); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+
+	partialPath := uploadSessionPath(authInfo.Workspace.Name, session.ExternalId)
+	if err := writeUploadChunkAt(partialPath, in.ChunkIndex*session.ChunkSize, in.Content); err != nil {
+		log.Error().Err(err).Str("session_id", session.ExternalId).Int64("chunk_index", in.ChunkIndex).Msg("AppendChunk: error writing chunk")
+		return &pb.AppendChunkResponse{
+			Ok:       false,
+			Offset:   session.Offset,
+			ErrorMsg: "Unable to write chunk",
+		}, nil
+	}
+
+	// A chunk sent again overwrites itself with the same bytes and isn't counted twice
+	offset, err := gws.backendRepo.RecordObjectUploadChunk(ctx, session.Id, in.ChunkIndex, int64(len(in.Content)))
+	if err != nil {
+		log.Error().Err(err).Str("session_id", session.ExternalId).Int64("chunk_index", in.ChunkIndex).Msg("AppendChunk: error recording chunk")
+		return &pb.AppendChunkResponse{
+			Ok:       false,
+			Offset:   session.Offset,
+			ErrorMsg: "Unable to record chunk",
+		}, nil
+	}
+
+	return &pb.AppendChunkResponse{
+		Ok:     true,
+		Offset: offset,
+	}, nil
+}
+
+// writeUploadChunkAt writes content at offset in the partial upload file without touching the rest of it
+func writeUploadChunkAt(partialPath string, offset int64, content []byte) error {
+	if err := os.MkdirAll(path.Dir(partialPath), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(partialPath, os.O_WRONLY|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := file.WriteAt(content, offset); err != nil {
+		return err
+	}
+
+	return file.Sync()
+}
+
+// writeUploadChunk writes content at offset in the partial upload file. Anything past offset
+// was never acknowledged to the client (e.g. the gateway died before recording it) and is discarded.
+func writeUploadChunk(partialPath string, offset int64, content []byte) error {
+	if err := os.MkdirAll(path.Dir(partialPath), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(partialPath, os.O_WRONLY|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := file.Truncate(offset); err != nil {
+		return err
+	}
+
+	if _, err := file.WriteAt(content, offset); err != nil {
+		return err
+	}
+
+	return file.Sync()
+}
+
+func (gws *GatewayService) CompleteUpload(ctx context.Context, in *pb.CompleteUploadRequest) (*pb.CompleteUploadResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionDeploy) {
+		return &pb.CompleteUploadResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	session, err := gws.backendRepo.GetObjectUploadSession(ctx, in.SessionId, authInfo.Workspace.Id)
+	if err != nil {
+		return &pb.CompleteUploadResponse{
+			Ok:       false,
+			ErrorMsg: uploadSessionNotFoundErrMessage,
+		}, nil
+	}
+
+	if session.Size > 0 && session.Offset != session.Size {
+		return &pb.CompleteUploadResponse{
+			Ok:       false,
+			ErrorMsg: "Upload is incomplete",
+		}, nil
+	}
+
+	existingObject, err := gws.backendRepo.GetObjectByHash(ctx, session.Hash, authInfo.Workspace.Id)
+	if err == nil {
+		if accessible, err := gws.objectAccessible(ctx, authInfo, existingObject, types.ResourceAccessWrite); err != nil || !accessible {
+			gws.auditObject(authInfo, auditActionObjectUploadComplete, existingObject.ExternalId, session.Hash, session.Offset, "Unauthorized Access")
+			return nil, status.Error(codes.PermissionDenied, "Unauthorized Access")
+		}
+	}
+	if err == nil && existingObject.IsLocked() {
+		lockErr := &types.ErrObjectLocked{ObjectId: existingObject.ExternalId, RetainUntil: existingObject.RetainUntil.Time}
+		gws.auditObject(authInfo, auditActionObjectUploadComplete, existingObject.ExternalId, session.Hash, session.Offset, lockErr.Error())
+		return nil, status.Error(codes.FailedPrecondition, lockErr.Error())
+	}
+
+	newObject, err := gws.backendRepo.CreateObject(ctx, session.Hash, 0, authInfo.Workspace.Id)
+	if err != nil {
+		gws.auditObject(authInfo, auditActionObjectUploadComplete, "", session.Hash, session.Offset, err.Error())
+		return &pb.CompleteUploadResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to create object",
+		}, nil
+	}
+
+	// On failure the object is removed but the partial data is kept so the client can retry completion
+	fail := func(err error, errMsg string) (*pb.CompleteUploadResponse, error) {
+		log.Error().Err(err).Str("session_id", session.ExternalId).Msg("CompleteUpload: " + errMsg)
+		os.Remove(localObjectPath(authInfo.Workspace.Name, newObject.ExternalId))
+		gws.backendRepo.DeleteObjectByExternalId(ctx, newObject.ExternalId)
+		gws.auditObject(authInfo, auditActionObjectUploadComplete, newObject.ExternalId, session.Hash, session.Offset, err.Error())
+		return &pb.CompleteUploadResponse{
+			Ok:       false,
+			ErrorMsg: errMsg,
+		}, nil
+	}
+
+	partialPath := uploadSessionPath(authInfo.Workspace.Name, session.ExternalId)
+	objectPath := localObjectPath(authInfo.Workspace.Name, newObject.ExternalId)
+	compression := gws.appConfig.Storage.ObjectCompression
+
+	dataKey, err := gws.objectDataKey(ctx, authInfo.Workspace)
+	if err != nil {
+		return fail(err, "Unable to prepare object encryption")
+	}
+
+	storedSize, digest, err := finalizeUploadSession(partialPath, objectPath, compression, dataKey)
+	if err != nil {
+		return fail(err, "Unable to write object content")
+	}
+	invalidateResolvedObjectPath(authInfo.Workspace.Name, newObject.ExternalId)
+
+	if !objectDigestMatches(session.Hash, digest) {
+		return fail(errors.New("checksum mismatch"), objectChecksumMismatchErrMessage)
+	}
+
+	if err := gws.backendRepo.UpdateObjectSizeByExternalId(ctx, newObject.ExternalId, int(session.Offset)); err != nil {
+		return fail(err, "Unable to complete file upload")
+	}
+
+	if err := gws.backendRepo.UpdateObjectDigestByExternalId(ctx, newObject.ExternalId, hex.EncodeToString(digest)); err != nil {
+		return fail(err, "Unable to complete file upload")
+	}
+
+	if compression != types.ObjectCompressionNone || dataKey != nil {
+		if err := gws.backendRepo.UpdateObjectCompressionByExternalId(ctx, newObject.ExternalId, compression, storedSize); err != nil {
+			return fail(err, "Unable to complete file upload")
+		}
+	}
+
+	if dataKey != nil {
+		encryption, wrappedDataKey := objectEncryptionMetadata(dataKey)
+		if err := gws.backendRepo.UpdateObjectEncryptionByExternalId(ctx, newObject.ExternalId, encryption, wrappedDataKey); err != nil {
+			return fail(err, "Unable to complete file upload")
+		}
+	}
+
+	gws.deduplicateObject(ctx, authInfo.Workspace, newObject, hex.EncodeToString(digest), session.Offset, storedSize, compression)
+
+	if err := gws.backendRepo.DeleteObjectUploadSession(ctx, session.ExternalId); err != nil {
+		log.Warn().Err(err).Str("session_id", session.ExternalId).Msg("CompleteUpload: error deleting upload session")
+	}
+	os.Remove(partialPath)
+
+	gws.auditObject(authInfo, auditActionObjectUploadComplete, newObject.ExternalId, session.Hash, session.Offset, "")
+
+	newObject.Size = session.Offset
+	gws.notifyObjectEvent(ctx, authInfo.Workspace, types.ObjectWebhookEventCreated, newObject)
+
+	return &pb.CompleteUploadResponse{
+		Ok:       true,
+		ObjectId: newObject.ExternalId,
+	}, nil
+}
+
+// finalizeUploadSession copies the partial upload into the object's path, compressing and encrypting it if
+// configured. The object only appears at its path once fully written. It returns the number of bytes
+// stored along with the SHA256 of the original content. The partial file is left in place for the caller
+// to remove.
+func finalizeUploadSession(partialPath, objectPath string, compression types.ObjectCompression, dataKey *common.ObjectDataKey) (int64, []byte, error) {
+	src, err := os.Open(partialPath)
+	if err != nil {
+		// Zero-length uploads never write a partial file
+		if !os.IsNotExist(err) {
+			return 0, nil, err
+		}
+
+		src, err = os.Open(os.DevNull)
+		if err != nil {
+			return 0, nil, err
+		}
+	}
+	defer src.Close()
+
+	dst, err := createLocalObjectTempFile(objectPath)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer dst.Close()
+	defer os.Remove(dst.Name())
+
+	writer, err := common.NewObjectWriter(compression, dataKey, dst)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	hasher := sha256.New()
+	if _, err := io.Copy(writer, io.TeeReader(src, hasher)); err != nil {
+		return 0, nil, err
+	}
+
+	if err := writer.Close(); err != nil {
+		return 0, nil, err
+	}
+
+	// Sync file to ensure data is flushed to the filesystem (required for JuiceFS)
+	if err := dst.Sync(); err != nil {
+		return 0, nil, err
+	}
+
+	fileInfo, err := dst.Stat()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if err := os.Rename(dst.Name(), objectPath); err != nil {
+		return 0, nil, err
+	}
+
+	return fileInfo.Size(), hasher.Sum(nil), nil
+}
+
+// collectStaleUploadSessions periodically removes resumable upload sessions that have seen no
+// activity within the configured TTL, along with their partial data
+func (gws *GatewayService) collectStaleUploadSessions() {
+	ttl := gws.appConfig.GatewayService.UploadSessionTTL
+	if ttl <= 0 {
+		ttl = defaultUploadSessionTTL
+	}
+
+	ticker := time.NewTicker(uploadSessionCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-gws.ctx.Done():
+			return
+		case <-ticker.C:
+			sessions, err := gws.backendRepo.DeleteStaleObjectUploadSessions(gws.ctx, time.Now().Add(-ttl))
+			if err != nil {
+				log.Error().Err(err).Msg("failed to delete stale upload sessions")
+				continue
+			}
+
+			for _, session := range sessions {
+				workspace, err := gws.backendRepo.GetWorkspace(gws.ctx, session.WorkspaceId)
+				if err != nil {
+					continue
+				}
+
+				os.Remove(uploadSessionPath(workspace.Name, session.ExternalId))
+			}
+
+			if len(sessions) > 0 {
+				log.Info().Int("count", len(sessions)).Msg("removed stale upload sessions")
+			}
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package gatewayservices
+
+import (
+	"crypto/sha256"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/beam-cloud/beta9/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteUploadChunk(t *testing.T) {
+	type chunk struct {
+		offset  int64
+		content string
+	}
+
+	tests := []struct {
+		name   string
+		chunks []chunk
+		want   string
+	}{
+		{"single chunk", []chunk{{0, "hello"}}, "hello"},
+		{"appended chunks", []chunk{{0, "hello"}, {5, " world"}}, "hello world"},
+		{"resent chunk", []chunk{{0, "hello"}, {5, " world"}, {5, " world"}}, "hello world"},
+		{"unacknowledged tail is dropped", []chunk{{0, "hello"}, {5, " world"}, {5, "!"}}, "hello!"},
+		{"empty chunk truncates", []chunk{{0, "hello"}, {2, ""}}, "he"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			partialPath := path.Join(t.TempDir(), "uploads", "session-1")
+			for _, c := range tt.chunks {
+				require.NoError(t, writeUploadChunk(partialPath, c.offset, []byte(c.content)))
+			}
+
+			content, err := os.ReadFile(partialPath)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(content))
+		})
+	}
+}
+
+func TestFinalizeUploadSession(t *testing.T) {
+	tests := []struct {
+		name    string
+		partial bool
+		content string
+	}{
+		{"uploaded content", true, "hello world"},
+		{"empty partial file", true, ""},
+		{"zero-length upload", false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			partialPath := path.Join(dir, "session-1")
+			objectPath := path.Join(dir, "object-1")
+
+			want := tt.content
+			if tt.partial {
+				require.NoError(t, os.WriteFile(partialPath, []byte(want), 0644))
+			}
+
+			size, digest, err := finalizeUploadSession(partialPath, objectPath, types.ObjectCompressionNone, nil)
+			require.NoError(t, err)
+
+			wantDigest := sha256.Sum256([]byte(want))
+			assert.Equal(t, int64(len(want)), size)
+			assert.Equal(t, wantDigest[:], digest)
+
+			content, err := os.ReadFile(objectPath)
+			require.NoError(t, err)
+			assert.Equal(t, want, string(content))
+
+			// No temporary file is left behind, and the partial file is the caller's to remove
+			entries, err := os.ReadDir(dir)
+			require.NoError(t, err)
+			if tt.partial {
+				assert.Len(t, entries, 2)
+			} else {
+				assert.Len(t, entries, 1)
+			}
+		})
+	}
+}
+
+func TestWriteUploadChunkAt(t *testing.T) {
+	const chunkSize = 4
+	chunks := []string{"abcd", "efgh", "ij"}
+
+	tests := []struct {
+		name  string
+		order []int
+	}{
+		{"in order", []int{0, 1, 2}},
+		{"out of order", []int{2, 0, 1}},
+		{"last chunk first", []int{2, 1, 0}},
+		{"resent chunk", []int{1, 0, 1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			partialPath := path.Join(t.TempDir(), "uploads", "session-1")
+			for _, index := range tt.order {
+				require.NoError(t, writeUploadChunkAt(partialPath, int64(index*chunkSize), []byte(chunks[index])))
+			}
+
+			content, err := os.ReadFile(partialPath)
+			require.NoError(t, err)
+			assert.Equal(t, "abcdefghij", string(content))
+		})
+	}
+}
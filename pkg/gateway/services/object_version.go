@@ -0,0 +1,370 @@
+package gatewayservices
+
+import (
+	"context"
+	"os"
+	"path"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/clients"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	maxObjectVersionRetention           = 100
+	auditActionObjectRestoreVersion     = "object.restore_version"
+	objectVersionContentNotFoundMessage = "Version content not found"
+)
+
+// objectVersionRetention returns how many previous versions are kept per object in the workspace
+func (gws *GatewayService) objectVersionRetention(ctx context.Context, workspaceId uint) int {
+	policy, err := gws.backendRepo.GetObjectLifecyclePolicy(ctx, workspaceId)
+	if err == nil && policy.RetainVersions != nil {
+		return *policy.RetainVersions
+	}
+
+	return gws.appConfig.Storage.ObjectVersionRetention
+}
+
+// snapshotObjectVersion keeps the object's current content as a version before it's replaced. Local content
+// is moved aside, since reads prefer the gateway filesystem over workspace storage, and stored content is
+// copied. It does nothing if versioning is disabled or the object has no content yet.
+func (gws *GatewayService) snapshotObjectVersion(ctx context.Context, workspace *types.Workspace, object *types.Object) error {
+	if gws.objectVersionRetention(ctx, workspace.Id) <= 0 {
+		return nil
+	}
+
+	version, err := gws.backendRepo.CreateObjectVersion(ctx, object)
+	if err != nil {
+		return err
+	}
+
+	preserved := false
+	objectPath := localObjectPath(workspace.Name, object.ExternalId)
+	versionPath := path.Join(localObjectVersionDir(workspace.Name, object.ExternalId), version.ExternalId)
+
+	if _, err := os.Stat(objectPath); err == nil {
+		if err := os.MkdirAll(path.Dir(versionPath), 0755); err != nil {
+			gws.backendRepo.DeleteObjectVersion(ctx, version.Id)
+			return err
+		}
+
+		if err := os.Rename(objectPath, versionPath); err != nil {
+			gws.backendRepo.DeleteObjectVersion(ctx, version.Id)
+			return err
+		}
+
+		// The object's next content is written with its own encoding, so the moved file's is cleared
+		if err := gws.clearObjectEncoding(ctx, object); err != nil {
+			os.Rename(versionPath, objectPath)
+			gws.backendRepo.DeleteObjectVersion(ctx, version.Id)
+			return err
+		}
+
+		preserved = true
+	}
+
+	if workspace.StorageAvailable() {
+		copied, err := gws.copyStoredObject(ctx, workspace, workspaceObjectKey(object.ExternalId), path.Join(workspaceObjectVersionPrefix(object.ExternalId), version.ExternalId))
+		if err != nil {
+			if preserved {
+				os.Rename(versionPath, objectPath)
+			}
+			gws.backendRepo.DeleteObjectVersion(ctx, version.Id)
+			return err
+		}
+
+		preserved = preserved || copied
+	}
+
+	if !preserved {
+		return gws.backendRepo.DeleteObjectVersion(ctx, version.Id)
+	}
+
+	// The version's file now holds the data, so the object no longer references its blob
+	return gws.releaseObjectBlob(ctx, object)
+}
+
+// clearObjectEncoding marks an object's content as stored uncompressed and in plaintext
+func (gws *GatewayService) clearObjectEncoding(ctx context.Context, object *types.Object) error {
+	if object.Compression == types.ObjectCompressionNone && object.Encryption == types.ObjectEncryptionNone {
+		return nil
+	}
+
+	if err := gws.backendRepo.UpdateObjectCompressionByExternalId(ctx, object.ExternalId, types.ObjectCompressionNone, 0); err != nil {
+		return err
+	}
+
+	return gws.backendRepo.UpdateObjectEncryptionByExternalId(ctx, object.ExternalId, types.ObjectEncryptionNone, "")
+}
+
+// copyStoredObject copies a key within workspace storage, reporting false if the source doesn't exist
+func (gws *GatewayService) copyStoredObject(ctx context.Context, workspace *types.Workspace, sourceKey, destinationKey string) (bool, error) {
+	storageClient, err := clients.NewWorkspaceStorageClient(ctx, workspace.Name, workspace.Storage)
+	if err != nil {
+		return false, err
+	}
+
+	storageCtx, cancel := gws.withStorageTimeout(ctx)
+	defer cancel()
+
+	exists, err := storageClient.Exists(storageCtx, sourceKey)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	if err := storageClient.CopyObject(storageCtx, sourceKey, destinationKey); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// pruneObjectVersions deletes the object's oldest versions beyond the workspace's retention count
+func (gws *GatewayService) pruneObjectVersions(ctx context.Context, workspace *types.Workspace, object *types.Object) {
+	versions, err := gws.backendRepo.ListObjectVersions(ctx, object.Id)
+	if err != nil {
+		log.Warn().Err(err).Str("object_id", object.ExternalId).Msg("unable to list object versions")
+		return
+	}
+
+	pruned := prunableObjectVersions(versions, gws.objectVersionRetention(ctx, workspace.Id))
+	if len(pruned) == 0 {
+		return
+	}
+
+	var storageClient *clients.WorkspaceStorageClient
+	if workspace.StorageAvailable() {
+		storageClient, err = clients.NewWorkspaceStorageClient(ctx, workspace.Name, workspace.Storage)
+		if err != nil {
+			log.Warn().Err(err).Str("object_id", object.ExternalId).Msg("unable to prune object versions")
+			return
+		}
+	}
+
+	for _, version := range pruned {
+		os.Remove(path.Join(localObjectVersionDir(workspace.Name, object.ExternalId), version.ExternalId))
+
+		if storageClient != nil {
+			storageCtx, cancel := gws.withStorageTimeout(ctx)
+			err := storageClient.Delete(storageCtx, path.Join(workspaceObjectVersionPrefix(object.ExternalId), version.ExternalId))
+			cancel()
+			if err != nil {
+				log.Warn().Err(err).Str("object_id", object.ExternalId).Str("version_id", version.ExternalId).Msg("unable to delete object version")
+				continue
+			}
+		}
+
+		if err := gws.backendRepo.DeleteObjectVersion(ctx, version.Id); err != nil {
+			log.Warn().Err(err).Str("object_id", object.ExternalId).Str("version_id", version.ExternalId).Msg("unable to delete object version")
+		}
+	}
+}
+
+// prunableObjectVersions returns the versions beyond the newest retention ones. Versions are listed newest first.
+func prunableObjectVersions(versions []types.ObjectVersion, retention int) []types.ObjectVersion {
+	retention = max(retention, 0)
+	if len(versions) <= retention {
+		return nil
+	}
+
+	return versions[retention:]
+}
+
+// deleteObjectVersions removes the data of every version of an object. The rows go with the object itself.
+func (gws *GatewayService) deleteObjectVersions(ctx context.Context, workspace *types.Workspace, storageClient *clients.WorkspaceStorageClient, object *types.Object) error {
+	os.RemoveAll(localObjectVersionDir(workspace.Name, object.ExternalId))
+
+	if storageClient == nil {
+		return nil
+	}
+
+	storageCtx, cancel := gws.withStorageTimeout(ctx)
+	defer cancel()
+
+	_, err := storageClient.DeleteWithPrefix(storageCtx, workspaceObjectVersionPrefix(object.ExternalId)+"/")
+	return err
+}
+
+// restoreObjectVersionData replaces the object's content with the version's content, wherever it was kept
+func (gws *GatewayService) restoreObjectVersionData(ctx context.Context, workspace *types.Workspace, object *types.Object, version *types.ObjectVersion) (bool, error) {
+	restored := false
+
+	versionPath := path.Join(localObjectVersionDir(workspace.Name, object.ExternalId), version.ExternalId)
+	if _, err := os.Stat(versionPath); err == nil {
+		objectPath := localObjectPath(workspace.Name, object.ExternalId)
+		tmpPath := objectPath + ".restore"
+
+		os.Remove(tmpPath)
+		if err := os.Link(versionPath, tmpPath); err != nil {
+			return false, err
+		}
+
+		if err := os.Rename(tmpPath, objectPath); err != nil {
+			os.Remove(tmpPath)
+			return false, err
+		}
+
+		restored = true
+	}
+
+	if workspace.StorageAvailable() {
+		copied, err := gws.copyStoredObject(ctx, workspace, path.Join(workspaceObjectVersionPrefix(object.ExternalId), version.ExternalId), workspaceObjectKey(object.ExternalId))
+		if err != nil {
+			return restored, err
+		}
+
+		restored = restored || copied
+	}
+
+	return restored, nil
+}
+
+func (gws *GatewayService) ListObjectVersions(ctx context.Context, in *pb.ListObjectVersionsRequest) (*pb.ListObjectVersionsResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionRead) {
+		return &pb.ListObjectVersionsResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	object, err := gws.backendRepo.GetObjectByExternalId(ctx, in.ObjectId, authInfo.Workspace.Id)
+	if err != nil {
+		return &pb.ListObjectVersionsResponse{
+			Ok:       false,
+			ErrorMsg: "Object not found",
+		}, nil
+	}
+
+	if accessible, err := gws.objectAccessible(ctx, authInfo, &object, types.ResourceAccessRead); err != nil || !accessible {
+		return &pb.ListObjectVersionsResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	versions, err := gws.backendRepo.ListObjectVersions(ctx, object.Id)
+	if err != nil {
+		return &pb.ListObjectVersionsResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to list object versions",
+		}, nil
+	}
+
+	pbVersions := make([]*pb.ObjectVersion, 0, len(versions))
+	for _, version := range versions {
+		pbVersions = append(pbVersions, &pb.ObjectVersion{
+			VersionId: version.ExternalId,
+			Size:      version.Size,
+			Digest:    version.Digest,
+			CreatedAt: timestamppb.New(version.CreatedAt.Time),
+		})
+	}
+
+	return &pb.ListObjectVersionsResponse{
+		Ok:       true,
+		Versions: pbVersions,
+	}, nil
+}
+
+func (gws *GatewayService) RestoreObjectVersion(ctx context.Context, in *pb.RestoreObjectVersionRequest) (*pb.RestoreObjectVersionResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionWrite) {
+		return &pb.RestoreObjectVersionResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	object, err := gws.backendRepo.GetObjectByExternalId(ctx, in.ObjectId, authInfo.Workspace.Id)
+	if err != nil {
+		return &pb.RestoreObjectVersionResponse{
+			Ok:       false,
+			ErrorMsg: "Object not found",
+		}, nil
+	}
+
+	if accessible, err := gws.objectAccessible(ctx, authInfo, &object, types.ResourceAccessWrite); err != nil || !accessible {
+		return &pb.RestoreObjectVersionResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	if object.IsLocked() {
+		lockErr := &types.ErrObjectLocked{ObjectId: object.ExternalId, RetainUntil: object.RetainUntil.Time}
+		gws.auditObject(authInfo, auditActionObjectRestoreVersion, object.ExternalId, object.Hash, object.Size, lockErr.Error())
+		return &pb.RestoreObjectVersionResponse{
+			Ok:       false,
+			ErrorMsg: lockErr.Error(),
+		}, nil
+	}
+
+	version, err := gws.backendRepo.GetObjectVersion(ctx, object.Id, in.VersionId)
+	if err != nil {
+		return &pb.RestoreObjectVersionResponse{
+			Ok:       false,
+			ErrorMsg: "Version not found",
+		}, nil
+	}
+
+	// Restoring is itself an overwrite, so the current content becomes a version too
+	if err := gws.snapshotObjectVersion(ctx, authInfo.Workspace, &object); err != nil {
+		log.Error().Err(err).Str("object_id", object.ExternalId).Msg("failed to snapshot object before restore")
+		gws.auditObject(authInfo, auditActionObjectRestoreVersion, object.ExternalId, object.Hash, object.Size, err.Error())
+		return &pb.RestoreObjectVersionResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to preserve the current object version",
+		}, nil
+	}
+
+	restored, err := gws.restoreObjectVersionData(ctx, authInfo.Workspace, &object, version)
+	if err == nil && !restored {
+		gws.auditObject(authInfo, auditActionObjectRestoreVersion, object.ExternalId, version.Hash, version.Size, objectVersionContentNotFoundMessage)
+		return &pb.RestoreObjectVersionResponse{
+			Ok:       false,
+			ErrorMsg: objectVersionContentNotFoundMessage,
+		}, nil
+	}
+
+	if err == nil {
+		err = gws.backendRepo.UpdateObjectSizeByExternalId(ctx, object.ExternalId, int(version.Size))
+	}
+	if err == nil {
+		err = gws.backendRepo.UpdateObjectDigestByExternalId(ctx, object.ExternalId, version.Digest)
+	}
+	if err == nil {
+		err = gws.backendRepo.UpdateObjectCompressionByExternalId(ctx, object.ExternalId, version.Compression, version.StoredSize)
+	}
+	if err == nil {
+		err = gws.backendRepo.UpdateObjectEncryptionByExternalId(ctx, object.ExternalId, version.Encryption, version.WrappedDataKey)
+	}
+
+	gws.auditObject(authInfo, auditActionObjectRestoreVersion, object.ExternalId, version.Hash, version.Size, errorMessage(err))
+
+	if err != nil {
+		log.Error().Err(err).Str("object_id", object.ExternalId).Str("version_id", version.ExternalId).Msg("failed to restore object version")
+		return &pb.RestoreObjectVersionResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to restore object version",
+		}, nil
+	}
+
+	gws.pruneObjectVersions(ctx, authInfo.Workspace, &object)
+	gws.invalidateCachedObject(&object)
+	invalidateResolvedObjectPath(authInfo.Workspace.Name, object.ExternalId)
+
+	current := object
+	current.Hash, current.Size = version.Hash, version.Size
+	gws.notifyObjectEvent(ctx, authInfo.Workspace, types.ObjectWebhookEventOverwritten, &current)
+
+	return &pb.RestoreObjectVersionResponse{
+		Ok: true,
+	}, nil
+}
@@ -0,0 +1,73 @@
+package gatewayservices
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/beam-cloud/beta9/pkg/repository"
+	"github.com/beam-cloud/beta9/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type lifecyclePolicyRepo struct {
+	repository.BackendRepository
+	policy *types.ObjectLifecyclePolicy
+	err    error
+}
+
+func (r *lifecyclePolicyRepo) GetObjectLifecyclePolicy(ctx context.Context, workspaceId uint) (*types.ObjectLifecyclePolicy, error) {
+	return r.policy, r.err
+}
+
+func TestObjectVersionRetention(t *testing.T) {
+	retain := func(n int) *int { return &n }
+
+	tests := []struct {
+		name   string
+		policy *types.ObjectLifecyclePolicy
+		err    error
+		want   int
+	}{
+		{"no policy", nil, sql.ErrNoRows, 5},
+		{"policy without version retention", &types.ObjectLifecyclePolicy{ExpireAfterDays: 30}, nil, 5},
+		{"policy retains more versions", &types.ObjectLifecyclePolicy{RetainVersions: retain(20)}, nil, 20},
+		{"policy disables versioning", &types.ObjectLifecyclePolicy{RetainVersions: retain(0)}, nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gws := &GatewayService{backendRepo: &lifecyclePolicyRepo{policy: tt.policy, err: tt.err}}
+			gws.appConfig.Storage.ObjectVersionRetention = 5
+
+			assert.Equal(t, tt.want, gws.objectVersionRetention(context.Background(), 1))
+		})
+	}
+}
+
+func TestPrunableObjectVersions(t *testing.T) {
+	versions := []types.ObjectVersion{{ExternalId: "v3"}, {ExternalId: "v2"}, {ExternalId: "v1"}}
+
+	tests := []struct {
+		name      string
+		retention int
+		want      []string
+	}{
+		{"retains all", 3, nil},
+		{"retains more than exist", 10, nil},
+		{"prunes oldest", 2, []string{"v1"}},
+		{"keeps newest only", 1, []string{"v2", "v1"}},
+		{"versioning disabled", 0, []string{"v3", "v2", "v1"}},
+		{"negative retention", -1, []string{"v3", "v2", "v1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, version := range prunableObjectVersions(versions, tt.retention) {
+				got = append(got, version.ExternalId)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
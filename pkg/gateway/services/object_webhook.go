@@ -0,0 +1,489 @@
+package gatewayservices
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultObjectWebhookInterval     = 5 * time.Second
+	defaultObjectWebhookBatchSize    = 50
+	defaultObjectWebhookMaxAttempts  = 8
+	defaultObjectWebhookRetryBackoff = 30 * time.Second
+	defaultObjectWebhookTimeout      = 10 * time.Second
+	maxObjectWebhookRetryBackoff     = 6 * time.Hour
+	maxObjectWebhooksPerWorkspace    = 20
+	maxObjectWebhookUrlLength        = 2048
+	objectWebhookLockTtlS            = 60
+	objectWebhookErrorBodyLimit      = 256
+	auditActionWebhookCreate         = "webhook.create"
+	auditActionWebhookDelete         = "webhook.delete"
+	auditResourceWebhook             = "webhook"
+
+	objectWebhookEventHeader     = "X-Beta9-Event"
+	objectWebhookDeliveryHeader  = "X-Beta9-Delivery"
+	objectWebhookSignatureHeader = "X-Beta9-Signature"
+	objectWebhookTimestampHeader = "X-Beta9-Timestamp"
+)
+
+var errObjectWebhookPrivateAddress = errors.New("webhook url resolves to a private address")
+
+// objectWebhookPayload is the JSON body delivered to webhooks
+type objectWebhookPayload struct {
+	Event       types.WebhookEvent `json:"event"`
+	WorkspaceId string             `json:"workspace_id"`
+	ObjectId    string             `json:"object_id"`
+	Hash        string             `json:"hash"`
+	Size        int64              `json:"size"`
+	Key         *string            `json:"key,omitempty"`
+	Timestamp   time.Time          `json:"timestamp"`
+}
+
+// notifyObjectEvent queues a delivery of event to every webhook in the workspace subscribed to it. Failures
+// are only logged, since the change to the object has already been made.
+func (gws *GatewayService) notifyObjectEvent(ctx context.Context, workspace *types.Workspace, event types.WebhookEvent, object *types.Object) {
+	if !gws.appConfig.Storage.ObjectWebhooks.Enabled {
+		return
+	}
+
+	payload, err := json.Marshal(objectWebhookPayload{
+		Event:       event,
+		WorkspaceId: workspace.ExternalId,
+		ObjectId:    object.ExternalId,
+		Hash:        object.Hash,
+		Size:        object.Size,
+		Key:         object.Key,
+		Timestamp:   time.Now().UTC(),
+	})
+	if err != nil {
+		log.Error().Err(err).Str("object_id", object.ExternalId).Msg("failed to encode webhook payload")
+		return
+	}
+
+	if _, err := gws.backendRepo.CreateObjectWebhookDeliveries(ctx, workspace.Id, event, string(payload)); err != nil {
+		log.Error().Err(err).Str("object_id", object.ExternalId).Str("event", string(event)).Msg("failed to queue webhook deliveries")
+	}
+}
+
+// deliverObjectWebhooks periodically sends queued webhook notifications, retrying failed deliveries with
+// exponential backoff. Only one gateway replica delivers at a time.
+func (gws *GatewayService) deliverObjectWebhooks() {
+	if !gws.webhooksEnabled() {
+		return
+	}
+
+	config := gws.appConfig.Storage.ObjectWebhooks
+
+	interval := config.Interval
+	if interval <= 0 {
+		interval = defaultObjectWebhookInterval
+	}
+
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = defaultObjectWebhookTimeout
+	}
+
+	client := newObjectWebhookClient(timeout, config.AllowPrivateNetworks)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lock := common.NewRedisLock(gws.redisClient)
+	lockKey := common.RedisKeys.GatewayObjectWebhookLock()
+
+	for {
+		select {
+		case <-gws.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := lock.Acquire(gws.ctx, lockKey, common.RedisLockOptions{TtlS: objectWebhookLockTtlS, Retries: 0}); err != nil {
+				continue
+			}
+
+			gws.deliverObjectWebhookBatches(client, config)
+			lock.Release(lockKey)
+		}
+	}
+}
+
+func (gws *GatewayService) deliverObjectWebhookBatches(client *http.Client, config types.ObjectWebhooksConfig) {
+	deadline := time.Now().Add(objectWebhookLockTtlS * time.Second / 2)
+
+	batchSize := config.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultObjectWebhookBatchSize
+	}
+
+	if config.DeliveryRetention > 0 {
+		if _, err := gws.backendRepo.DeleteFinishedObjectWebhookDeliveries(gws.ctx, time.Now().Add(-config.DeliveryRetention)); err != nil {
+			log.Error().Err(err).Msg("failed to delete finished webhook deliveries")
+		}
+	}
+
+	for time.Now().Before(deadline) {
+		deliveries, err := gws.backendRepo.ListDueObjectWebhookDeliveries(gws.ctx, batchSize)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to list due webhook deliveries")
+			return
+		}
+
+		for i := range deliveries {
+			// Deliveries can each take up to the timeout, so stop well before the lock expires
+			if time.Now().After(deadline) {
+				return
+			}
+
+			gws.deliverObjectWebhook(client, config, &deliveries[i])
+		}
+
+		if len(deliveries) < batchSize {
+			return
+		}
+	}
+}
+
+// deliverObjectWebhook makes one attempt at sending a delivery and records the outcome
+func (gws *GatewayService) deliverObjectWebhook(client *http.Client, config types.ObjectWebhooksConfig, delivery *types.ObjectWebhookDelivery) {
+	maxAttempts := config.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultObjectWebhookMaxAttempts
+	}
+
+	attempts := delivery.Attempts + 1
+	err := sendObjectWebhook(gws.ctx, client, delivery)
+
+	status := types.ObjectWebhookDeliveryStatusDelivered
+	nextAttemptAt := time.Now()
+	if err != nil {
+		log.Warn().Err(err).Str("delivery_id", delivery.ExternalId).Str("webhook_id", delivery.Webhook.ExternalId).Int("attempts", attempts).Msg("failed to deliver webhook")
+
+		status = types.ObjectWebhookDeliveryStatusPending
+		nextAttemptAt = nextAttemptAt.Add(objectWebhookRetryBackoff(config.RetryBackoff, attempts))
+		if attempts >= maxAttempts {
+			status = types.ObjectWebhookDeliveryStatusFailed
+		}
+	}
+
+	if err := gws.backendRepo.UpdateObjectWebhookDelivery(gws.ctx, delivery.Id, status, attempts, nextAttemptAt, errorMessage(err)); err != nil {
+		log.Error().Err(err).Str("delivery_id", delivery.ExternalId).Msg("failed to record webhook delivery")
+	}
+}
+
+// objectWebhookRetryBackoff doubles the wait after every failed attempt, up to maxObjectWebhookRetryBackoff
+func objectWebhookRetryBackoff(backoff time.Duration, attempts int) time.Duration {
+	if backoff <= 0 {
+		backoff = defaultObjectWebhookRetryBackoff
+	}
+
+	for i := 1; i < attempts && backoff < maxObjectWebhookRetryBackoff; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, maxObjectWebhookRetryBackoff)
+}
+
+// sendObjectWebhook posts the delivery's payload, signed with the webhook's secret the same way SignPayload
+// signs payloads for workspace signing keys. Any non-2xx response counts as a failure.
+func sendObjectWebhook(ctx context.Context, client *http.Client, delivery *types.ObjectWebhookDelivery) error {
+	payload := []byte(delivery.Payload)
+	signature := auth.SignPayload(payload, delivery.Webhook.Secret)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Webhook.Url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(objectWebhookEventHeader, string(delivery.Event))
+	req.Header.Set(objectWebhookDeliveryHeader, delivery.ExternalId)
+	req.Header.Set(objectWebhookSignatureHeader, signature.Key)
+	req.Header.Set(objectWebhookTimestampHeader, strconv.FormatInt(signature.Timestamp, 10))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, objectWebhookErrorBodyLimit))
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+
+	io.Copy(io.Discard, io.LimitReader(resp.Body, objectWebhookErrorBodyLimit))
+	return nil
+}
+
+// newObjectWebhookClient returns a client that doesn't follow redirects and, unless allowPrivateNetworks is
+// set, refuses to connect to loopback, private and link-local addresses, so webhooks can't be used to reach
+// services inside the cluster
+func newObjectWebhookClient(timeout time.Duration, allowPrivateNetworks bool) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivateNetworks {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			ip := net.ParseIP(host)
+			if ip == nil || isPrivateWebhookAddress(ip) {
+				return errObjectWebhookPrivateAddress
+			}
+
+			return nil
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func isPrivateWebhookAddress(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified()
+}
+
+// validateObjectWebhookUrl requires an absolute http(s) URL without credentials
+func validateObjectWebhookUrl(rawUrl string) error {
+	if rawUrl == "" || len(rawUrl) > maxObjectWebhookUrlLength {
+		return fmt.Errorf("webhook url must be between 1 and %d characters", maxObjectWebhookUrlLength)
+	}
+
+	u, err := url.Parse(rawUrl)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("webhook url must be an absolute http or https url")
+	}
+
+	if u.User != nil {
+		return errors.New("webhook url must not contain credentials")
+	}
+
+	return nil
+}
+
+// webhooksEnabled reports whether webhooks can be created and delivered, for object or deployment events
+func (gws *GatewayService) webhooksEnabled() bool {
+	return gws.appConfig.Storage.ObjectWebhooks.Enabled || gws.appConfig.GatewayService.DeploymentWebhooks.Enabled
+}
+
+// parseWebhookEvents validates the events a webhook subscribes to. Only events whose kind of webhook is enabled
+// can be subscribed to, and a webhook without events gets every object event.
+func parseWebhookEvents(events []string, objectEvents, deploymentEvents bool) (types.ObjectWebhookEventFilter, error) {
+	if len(events) == 0 {
+		if !objectEvents {
+			return nil, errors.New("events are required")
+		}
+
+		return append(types.ObjectWebhookEventFilter{}, types.ObjectWebhookEvents...), nil
+	}
+
+	filter := types.ObjectWebhookEventFilter{}
+	seen := map[types.WebhookEvent]bool{}
+	for _, name := range events {
+		event := types.WebhookEvent(name)
+
+		switch {
+		case slices.Contains(types.ObjectWebhookEvents, event):
+			if !objectEvents {
+				return nil, fmt.Errorf("object webhooks are not enabled, can't subscribe to %q", name)
+			}
+		case slices.Contains(types.DeploymentWebhookEvents, event):
+			if !deploymentEvents {
+				return nil, fmt.Errorf("deployment webhooks are not enabled, can't subscribe to %q", name)
+			}
+		default:
+			return nil, fmt.Errorf("unknown webhook event %q", name)
+		}
+
+		if !seen[event] {
+			seen[event] = true
+			filter = append(filter, event)
+		}
+	}
+
+	return filter, nil
+}
+
+func objectWebhookToProto(webhook *types.ObjectWebhook) *pb.Webhook {
+	events := make([]string, 0, len(webhook.Events))
+	for _, event := range webhook.Events {
+		events = append(events, string(event))
+	}
+
+	return &pb.Webhook{
+		WebhookId: webhook.ExternalId,
+		Url:       webhook.Url,
+		Events:    events,
+		CreatedAt: timestamppb.New(webhook.CreatedAt.Time),
+	}
+}
+
+func (gws *GatewayService) auditWebhook(authInfo *auth.AuthInfo, action, webhookId, webhookUrl string, err error) {
+	event := common.AuditEvent{
+		Action:       action,
+		WorkspaceId:  authInfo.Workspace.ExternalId,
+		ResourceType: auditResourceWebhook,
+		ResourceId:   webhookId,
+		Outcome:      auditOutcome(err),
+		Reason:       errorMessage(err),
+		Attributes:   map[string]interface{}{"url": webhookUrl},
+	}
+	if authInfo.Token != nil {
+		event.Principal = authInfo.Token.ExternalId
+	}
+	gws.auditLogger.Log(event)
+}
+
+func (gws *GatewayService) CreateWebhook(ctx context.Context, in *pb.CreateWebhookRequest) (*pb.CreateWebhookResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionWrite) {
+		return &pb.CreateWebhookResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	if !gws.webhooksEnabled() {
+		return &pb.CreateWebhookResponse{
+			Ok:       false,
+			ErrorMsg: "Webhooks are not enabled",
+		}, nil
+	}
+
+	if err := validateObjectWebhookUrl(in.Url); err != nil {
+		return &pb.CreateWebhookResponse{
+			Ok:       false,
+			ErrorMsg: err.Error(),
+		}, nil
+	}
+
+	events, err := parseWebhookEvents(in.Events, gws.appConfig.Storage.ObjectWebhooks.Enabled, gws.appConfig.GatewayService.DeploymentWebhooks.Enabled)
+	if err != nil {
+		return &pb.CreateWebhookResponse{
+			Ok:       false,
+			ErrorMsg: err.Error(),
+		}, nil
+	}
+
+	webhooks, err := gws.backendRepo.ListObjectWebhooks(ctx, authInfo.Workspace.Id)
+	if err != nil {
+		return &pb.CreateWebhookResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to list webhooks",
+		}, nil
+	}
+
+	if len(webhooks) >= maxObjectWebhooksPerWorkspace {
+		return &pb.CreateWebhookResponse{
+			Ok:       false,
+			ErrorMsg: fmt.Sprintf("A workspace can have at most %d webhooks", maxObjectWebhooksPerWorkspace),
+		}, nil
+	}
+
+	webhook, err := gws.backendRepo.CreateObjectWebhook(ctx, authInfo.Workspace.Id, in.Url, events)
+	if err != nil {
+		gws.auditWebhook(authInfo, auditActionWebhookCreate, "", in.Url, err)
+		return &pb.CreateWebhookResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to create webhook",
+		}, nil
+	}
+
+	gws.auditWebhook(authInfo, auditActionWebhookCreate, webhook.ExternalId, webhook.Url, nil)
+	return &pb.CreateWebhookResponse{
+		Ok:      true,
+		Webhook: objectWebhookToProto(webhook),
+		Secret:  webhook.Secret,
+	}, nil
+}
+
+func (gws *GatewayService) ListWebhooks(ctx context.Context, in *pb.ListWebhooksRequest) (*pb.ListWebhooksResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionRead) {
+		return &pb.ListWebhooksResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	webhooks, err := gws.backendRepo.ListObjectWebhooks(ctx, authInfo.Workspace.Id)
+	if err != nil {
+		return &pb.ListWebhooksResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to list webhooks",
+		}, nil
+	}
+
+	result := make([]*pb.Webhook, 0, len(webhooks))
+	for i := range webhooks {
+		result = append(result, objectWebhookToProto(&webhooks[i]))
+	}
+
+	return &pb.ListWebhooksResponse{
+		Ok:       true,
+		Webhooks: result,
+	}, nil
+}
+
+func (gws *GatewayService) DeleteWebhook(ctx context.Context, in *pb.DeleteWebhookRequest) (*pb.DeleteWebhookResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionWrite) {
+		return &pb.DeleteWebhookResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	err := gws.backendRepo.DeleteObjectWebhook(ctx, authInfo.Workspace.Id, in.WebhookId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &pb.DeleteWebhookResponse{
+			Ok:       false,
+			ErrorMsg: "Webhook not found",
+		}, nil
+	}
+
+	gws.auditWebhook(authInfo, auditActionWebhookDelete, in.WebhookId, "", err)
+	if err != nil {
+		return &pb.DeleteWebhookResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to delete webhook",
+		}, nil
+	}
+
+	return &pb.DeleteWebhookResponse{
+		Ok: true,
+	}, nil
+}
@@ -12,7 +12,7 @@ import (
 func (gws *GatewayService) ListPools(ctx context.Context, in *pb.ListPoolsRequest) (*pb.ListPoolsResponse, error) {
 	authInfo, _ := auth.AuthInfoFromContext(ctx)
 
-	if !auth.HasPermission(authInfo) {
+	if !auth.HasPermission(authInfo, types.PermissionRead) {
 		return &pb.ListPoolsResponse{
 			Ok:     false,
 			ErrMsg: "Unauthorized Access",
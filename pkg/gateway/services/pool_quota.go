@@ -0,0 +1,169 @@
+package gatewayservices
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/repository"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+)
+
+const auditActionWorkspaceSetPoolQuota = "workspace.set_pool_quota"
+
+// SetPoolQuota replaces how much of a worker pool a workspace may use at once, and how many of the pool's GPUs are
+// reserved for it. Only cluster admins may change quotas. The cached quotas are reloaded on the next scheduling
+// decision, so they apply to requests in the backlog too.
+func (gws *GatewayService) SetPoolQuota(ctx context.Context, in *pb.SetPoolQuotaRequest) (*pb.SetPoolQuotaResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if _, err := isClusterAdmin(ctx); err != nil {
+		return &pb.SetPoolQuotaResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	quota, err := gws.parsePoolQuota(in)
+	if err != nil {
+		return &pb.SetPoolQuotaResponse{
+			Ok:       false,
+			ErrorMsg: err.Error(),
+		}, nil
+	}
+
+	workspace, err := gws.backendRepo.GetWorkspaceByExternalId(ctx, in.WorkspaceId)
+	if err != nil {
+		return &pb.SetPoolQuotaResponse{
+			Ok:       false,
+			ErrorMsg: "Workspace not found",
+		}, nil
+	}
+
+	updated := quota
+	if quota.IsEmpty() {
+		err = gws.backendRepo.DeletePoolQuota(ctx, workspace.Id, quota.PoolName)
+	} else {
+		updated, err = gws.backendRepo.SetPoolQuota(ctx, workspace.Id, *quota)
+	}
+
+	event := common.AuditEvent{
+		Action:       auditActionWorkspaceSetPoolQuota,
+		WorkspaceId:  in.WorkspaceId,
+		ResourceType: auditResourceWorkspace,
+		ResourceId:   in.WorkspaceId,
+		Outcome:      auditOutcome(err),
+		Reason:       errorMessage(err),
+		Attributes: map[string]interface{}{
+			"pool_name":     in.PoolName,
+			"max_machines":  in.MaxMachines,
+			"max_gpus":      in.MaxGpus,
+			"reserved_gpus": in.ReservedGpus,
+		},
+	}
+	if authInfo.Token != nil {
+		event.Principal = authInfo.Token.ExternalId
+	}
+	gws.auditLogger.Log(event)
+
+	if err != nil {
+		return &pb.SetPoolQuotaResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to set pool quota",
+		}, nil
+	}
+
+	// Reload every workspace's quotas, since reservations affect the other workspaces of the pool
+	if quotas, err := gws.backendRepo.ListPoolQuotas(ctx); err == nil {
+		if err := gws.workspaceRepo.SetPoolQuotas(quotas); err != nil {
+			log.Warn().Err(err).Msg("failed to cache pool quotas")
+		}
+	}
+
+	updated.WorkspaceId = workspace.ExternalId
+	return &pb.SetPoolQuotaResponse{
+		Ok:    true,
+		Quota: poolQuotaToProto(updated),
+	}, nil
+}
+
+// ListPoolQuotas returns the pool quotas of the caller's workspace. Cluster admins can name any workspace, or
+// list the quotas of all workspaces.
+func (gws *GatewayService) ListPoolQuotas(ctx context.Context, in *pb.ListPoolQuotasRequest) (*pb.ListPoolQuotasResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	workspaceId := authInfo.Workspace.ExternalId
+	if in.WorkspaceId != workspaceId {
+		if _, err := isClusterAdmin(ctx); err == nil {
+			workspaceId = in.WorkspaceId
+		} else if in.WorkspaceId != "" {
+			return &pb.ListPoolQuotasResponse{
+				Ok:       false,
+				ErrorMsg: "Unauthorized Access",
+			}, nil
+		}
+	}
+
+	if workspaceId == authInfo.Workspace.ExternalId && !auth.HasPermission(authInfo, types.PermissionRead) {
+		return &pb.ListPoolQuotasResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	quotas, err := repository.LoadPoolQuotas(ctx, gws.workspaceRepo, gws.backendRepo)
+	if err != nil {
+		return &pb.ListPoolQuotasResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to list pool quotas",
+		}, nil
+	}
+
+	response := &pb.ListPoolQuotasResponse{Ok: true, Quotas: []*pb.PoolQuota{}}
+	for i := range quotas {
+		if workspaceId != "" && quotas[i].WorkspaceId != workspaceId {
+			continue
+		}
+		response.Quotas = append(response.Quotas, poolQuotaToProto(&quotas[i]))
+	}
+
+	return response, nil
+}
+
+func (gws *GatewayService) parsePoolQuota(in *pb.SetPoolQuotaRequest) (*types.PoolQuota, error) {
+	if _, ok := gws.appConfig.Worker.Pools[in.PoolName]; !ok {
+		return nil, fmt.Errorf("pool %s not found", in.PoolName)
+	}
+
+	if in.MaxGpus > 0 && in.ReservedGpus > in.MaxGpus {
+		return nil, fmt.Errorf("reserved_gpus can't be more than max_gpus")
+	}
+
+	return &types.PoolQuota{
+		PoolName:     in.PoolName,
+		MaxMachines:  in.MaxMachines,
+		MaxGpus:      in.MaxGpus,
+		ReservedGpus: in.ReservedGpus,
+	}, nil
+}
+
+func poolQuotaToProto(quota *types.PoolQuota) *pb.PoolQuota {
+	result := &pb.PoolQuota{
+		WorkspaceId:  quota.WorkspaceId,
+		PoolName:     quota.PoolName,
+		MaxMachines:  quota.MaxMachines,
+		MaxGpus:      quota.MaxGpus,
+		ReservedGpus: quota.ReservedGpus,
+	}
+
+	if !quota.UpdatedAt.IsZero() {
+		result.UpdatedAt = timestamppb.New(quota.UpdatedAt.Time)
+	}
+
+	return result
+}
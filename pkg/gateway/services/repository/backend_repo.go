@@ -86,3 +86,22 @@ func (s *BackendRepositoryService) UpdateCheckpoint(ctx context.Context, req *pb
 
 	return &pb.UpdateCheckpointResponse{Ok: true, Checkpoint: checkpoint.ToProto()}, nil
 }
+
+// GetSharedVolume lets workers confirm a workspace still holds an unexpired share on a volume before mounting it
+func (s *BackendRepositoryService) GetSharedVolume(ctx context.Context, req *pb.GetSharedVolumeRequest) (*pb.GetSharedVolumeResponse, error) {
+	workspace, err := s.backendRepo.GetWorkspaceByExternalId(ctx, req.GranteeWorkspaceId)
+	if err != nil {
+		return &pb.GetSharedVolumeResponse{Ok: false, ErrorMsg: err.Error()}, nil
+	}
+
+	volume, err := s.backendRepo.GetSharedVolume(ctx, workspace.Id, req.VolumeId)
+	if err != nil {
+		return &pb.GetSharedVolumeResponse{Ok: false, ErrorMsg: err.Error()}, nil
+	}
+
+	if volume == nil {
+		return &pb.GetSharedVolumeResponse{Ok: false, ErrorMsg: "volume share not found or expired"}, nil
+	}
+
+	return &pb.GetSharedVolumeResponse{Ok: true, OwnerWorkspaceName: volume.Workspace.Name}, nil
+}
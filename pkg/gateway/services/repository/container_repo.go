@@ -91,6 +91,15 @@ func (s *ContainerRepositoryService) SetContainerAddressMap(ctx context.Context,
 	return &pb.SetContainerAddressMapResponse{Ok: true}, nil
 }
 
+func (s *ContainerRepositoryService) SetContainerGpuTopology(ctx context.Context, req *pb.SetContainerGpuTopologyRequest) (*pb.SetContainerGpuTopologyResponse, error) {
+	err := s.containerRepo.SetContainerGpuTopology(req.ContainerId, req.GpuInterconnect, req.NumaNodes)
+	if err != nil {
+		return &pb.SetContainerGpuTopologyResponse{Ok: false, ErrorMsg: err.Error()}, nil
+	}
+
+	return &pb.SetContainerGpuTopologyResponse{Ok: true}, nil
+}
+
 func (s *ContainerRepositoryService) GetContainerAddressMap(ctx context.Context, req *pb.GetContainerAddressMapRequest) (*pb.GetContainerAddressMapResponse, error) {
 	addressMap, err := s.containerRepo.GetContainerAddressMap(req.ContainerId)
 	if err != nil {
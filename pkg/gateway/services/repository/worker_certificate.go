@@ -0,0 +1,53 @@
+package repository_services
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/repository"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+)
+
+// WorkerCertificateService signs the certificates workers use for mutual TLS with the gateway
+type WorkerCertificateService struct {
+	ca         *common.CertificateAuthority
+	certTTL    time.Duration
+	workerRepo repository.WorkerRepository
+	pb.UnimplementedWorkerCertificateServiceServer
+}
+
+func NewWorkerCertificateService(ca *common.CertificateAuthority, certTTL time.Duration, workerRepo repository.WorkerRepository) *WorkerCertificateService {
+	return &WorkerCertificateService{ca: ca, certTTL: certTTL, workerRepo: workerRepo}
+}
+
+// IssueWorkerCertificate signs a certificate for a registered worker. Only worker tokens can request one, and the
+// certificate is always issued for the worker id in the request.
+func (s *WorkerCertificateService) IssueWorkerCertificate(ctx context.Context, req *pb.IssueWorkerCertificateRequest) (*pb.IssueWorkerCertificateResponse, error) {
+	authInfo, ok := auth.AuthInfoFromContext(ctx)
+	if !ok || authInfo.Token == nil || authInfo.Token.TokenType != types.TokenTypeWorker {
+		return &pb.IssueWorkerCertificateResponse{Ok: false, ErrorMsg: "Unauthorized Access"}, nil
+	}
+
+	if _, err := s.workerRepo.GetWorkerById(req.WorkerId); err != nil {
+		return &pb.IssueWorkerCertificateResponse{Ok: false, ErrorMsg: "Worker not found"}, nil
+	}
+
+	certPEM, expiresAt, err := s.ca.SignCSR(req.CsrPem, req.WorkerId, s.certTTL)
+	if err != nil {
+		return &pb.IssueWorkerCertificateResponse{Ok: false, ErrorMsg: err.Error()}, nil
+	}
+
+	log.Info().Str("worker_id", req.WorkerId).Time("expires_at", expiresAt).Msg("issued worker certificate")
+
+	return &pb.IssueWorkerCertificateResponse{
+		Ok:             true,
+		CertificatePem: certPEM,
+		CaPem:          s.ca.CertPEM(),
+		ExpiresAt:      expiresAt.Unix(),
+	}, nil
+}
@@ -116,6 +116,51 @@ func (s *WorkerRepositoryService) ToggleWorkerAvailable(ctx context.Context, req
 	return &pb.ToggleWorkerAvailableResponse{Ok: true}, nil
 }
 
+func (s *WorkerRepositoryService) UpdateWorkerStatus(ctx context.Context, req *pb.UpdateWorkerStatusRequest) (*pb.UpdateWorkerStatusResponse, error) {
+	err := s.workerRepo.UpdateWorkerStatus(req.WorkerId, types.WorkerStatus(req.Status))
+	if err != nil {
+		return &pb.UpdateWorkerStatusResponse{Ok: false, ErrorMsg: err.Error()}, nil
+	}
+
+	return &pb.UpdateWorkerStatusResponse{Ok: true}, nil
+}
+
+func (s *WorkerRepositoryService) SetWorkerGpuInterconnect(ctx context.Context, req *pb.SetWorkerGpuInterconnectRequest) (*pb.SetWorkerGpuInterconnectResponse, error) {
+	err := s.workerRepo.SetWorkerGpuInterconnect(req.WorkerId, req.GpuInterconnect)
+	if err != nil {
+		return &pb.SetWorkerGpuInterconnectResponse{Ok: false, ErrorMsg: err.Error()}, nil
+	}
+
+	return &pb.SetWorkerGpuInterconnectResponse{Ok: true}, nil
+}
+
+func (s *WorkerRepositoryService) SetWorkerServerAddress(ctx context.Context, req *pb.SetWorkerServerAddressRequest) (*pb.SetWorkerServerAddressResponse, error) {
+	err := s.workerRepo.SetWorkerServerAddress(req.WorkerId, req.Address)
+	if err != nil {
+		return &pb.SetWorkerServerAddressResponse{Ok: false, ErrorMsg: err.Error()}, nil
+	}
+
+	return &pb.SetWorkerServerAddressResponse{Ok: true}, nil
+}
+
+func (s *WorkerRepositoryService) SetWorkerArch(ctx context.Context, req *pb.SetWorkerArchRequest) (*pb.SetWorkerArchResponse, error) {
+	err := s.workerRepo.SetWorkerArch(req.WorkerId, req.Arch)
+	if err != nil {
+		return &pb.SetWorkerArchResponse{Ok: false, ErrorMsg: err.Error()}, nil
+	}
+
+	return &pb.SetWorkerArchResponse{Ok: true}, nil
+}
+
+func (s *WorkerRepositoryService) SetWorkerMigSlices(ctx context.Context, req *pb.SetWorkerMigSlicesRequest) (*pb.SetWorkerMigSlicesResponse, error) {
+	err := s.workerRepo.SetWorkerMigSlices(req.WorkerId, types.MigSlices(req.MigSlices))
+	if err != nil {
+		return &pb.SetWorkerMigSlicesResponse{Ok: false, ErrorMsg: err.Error()}, nil
+	}
+
+	return &pb.SetWorkerMigSlicesResponse{Ok: true}, nil
+}
+
 func (s *WorkerRepositoryService) UpdateWorkerCapacity(ctx context.Context, req *pb.UpdateWorkerCapacityRequest) (*pb.UpdateWorkerCapacityResponse, error) {
 	worker, err := s.workerRepo.GetWorkerById(req.WorkerId)
 	if err != nil {
@@ -201,3 +246,30 @@ func (s *WorkerRepositoryService) RemoveContainerIp(ctx context.Context, req *pb
 
 	return &pb.RemoveContainerIpResponse{Ok: true}, nil
 }
+
+func (s *WorkerRepositoryService) AddCachedObject(ctx context.Context, req *pb.AddCachedObjectRequest) (*pb.AddCachedObjectResponse, error) {
+	err := s.workerRepo.AddCachedObject(req.WorkerId, req.Digest)
+	if err != nil {
+		return &pb.AddCachedObjectResponse{Ok: false, ErrorMsg: err.Error()}, nil
+	}
+
+	return &pb.AddCachedObjectResponse{Ok: true}, nil
+}
+
+func (s *WorkerRepositoryService) RemoveCachedObject(ctx context.Context, req *pb.RemoveCachedObjectRequest) (*pb.RemoveCachedObjectResponse, error) {
+	err := s.workerRepo.RemoveCachedObject(req.WorkerId, req.Digest)
+	if err != nil {
+		return &pb.RemoveCachedObjectResponse{Ok: false, ErrorMsg: err.Error()}, nil
+	}
+
+	return &pb.RemoveCachedObjectResponse{Ok: true}, nil
+}
+
+func (s *WorkerRepositoryService) PopCachedObjectInvalidations(ctx context.Context, req *pb.PopCachedObjectInvalidationsRequest) (*pb.PopCachedObjectInvalidationsResponse, error) {
+	digests, err := s.workerRepo.PopCachedObjectInvalidations(req.WorkerId)
+	if err != nil {
+		return &pb.PopCachedObjectInvalidationsResponse{Ok: false, ErrorMsg: err.Error()}, nil
+	}
+
+	return &pb.PopCachedObjectInvalidationsResponse{Ok: true, Digests: digests}, nil
+}
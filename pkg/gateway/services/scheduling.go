@@ -0,0 +1,181 @@
+package gatewayservices
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+)
+
+// SimulateScheduling works out where a container of an existing or hypothetical stub would be placed right now,
+// or why it would stay pending, without running anything. The workers that failed each constraint are counted
+// by reason, and only listed for cluster admins.
+func (gws *GatewayService) SimulateScheduling(ctx context.Context, in *pb.SimulateSchedulingRequest) (*pb.SimulateSchedulingResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionRead) {
+		return &pb.SimulateSchedulingResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	request, err := gws.simulationRequest(ctx, authInfo, in)
+	if err != nil {
+		return &pb.SimulateSchedulingResponse{
+			Ok:     false,
+			ErrMsg: err.Error(),
+		}, nil
+	}
+
+	simulation, err := gws.scheduler.Simulate(request)
+	if err != nil {
+		return &pb.SimulateSchedulingResponse{
+			Ok:     false,
+			ErrMsg: "Unable to simulate scheduling",
+		}, nil
+	}
+
+	clusterAdmin, _ := isClusterAdmin(ctx)
+
+	response := &pb.SimulateSchedulingResponse{
+		Ok:                  true,
+		Outcome:             simulation.Outcome,
+		Reason:              simulation.Reason,
+		PreemptedContainers: uint32(len(simulation.Preempted)),
+		ScaleUpPools:        simulation.ScaleUpPools,
+		Constraints:         []*pb.SchedulingConstraint{},
+	}
+
+	if simulation.Worker != nil {
+		response.PoolName = simulation.Worker.PoolName
+		response.Gpu = simulation.Worker.Gpu
+		if clusterAdmin {
+			response.WorkerId = simulation.Worker.Id
+		}
+	}
+
+	for _, constraint := range simulation.Constraints {
+		result := &pb.SchedulingConstraint{
+			Name:            constraint.Name,
+			Reasons:         map[string]uint32{},
+			RejectedWorkers: []*pb.SchedulingRejection{},
+		}
+
+		for _, rejection := range constraint.Rejected {
+			result.Reasons[rejection.Reason]++
+
+			if clusterAdmin {
+				result.RejectedWorkers = append(result.RejectedWorkers, &pb.SchedulingRejection{
+					WorkerId: rejection.WorkerId,
+					PoolName: rejection.PoolName,
+					Gpu:      rejection.Gpu,
+					Reason:   rejection.Reason,
+				})
+			}
+		}
+
+		response.Constraints = append(response.Constraints, result)
+	}
+
+	return response, nil
+}
+
+// simulationRequest builds the container request the scheduler would get for the stub, the same way a
+// function's container request is built from its stub config
+func (gws *GatewayService) simulationRequest(ctx context.Context, authInfo *auth.AuthInfo, in *pb.SimulateSchedulingRequest) (*types.ContainerRequest, error) {
+	request := &types.ContainerRequest{
+		ContainerId: fmt.Sprintf("simulation-%s", uuid.New().String()),
+		WorkspaceId: authInfo.Workspace.ExternalId,
+		Workspace:   *authInfo.Workspace,
+	}
+
+	if in.StubId != "" {
+		stub, err := gws.backendRepo.GetStubByExternalId(ctx, in.StubId)
+		if err != nil || stub == nil || stub.Workspace.ExternalId != authInfo.Workspace.ExternalId {
+			return nil, errors.New("Invalid stub ID")
+		}
+
+		var stubConfig types.StubConfigV1
+		if err := json.Unmarshal([]byte(stub.Config), &stubConfig); err != nil {
+			return nil, errors.New("Invalid stub config")
+		}
+
+		gpuRequest := types.GpuTypesToStrings(stubConfig.Runtime.Gpus)
+		if stubConfig.Runtime.Gpu != "" {
+			gpuRequest = append(gpuRequest, stubConfig.Runtime.Gpu.String())
+		}
+
+		gpuCount := stubConfig.Runtime.GpuCount
+		if stubConfig.RequiresGPU() && gpuCount == 0 {
+			gpuCount = 1
+		}
+
+		request.StubId = stub.ExternalId
+		request.Cpu = stubConfig.Runtime.Cpu
+		request.Memory = stubConfig.Runtime.Memory
+		request.GpuRequest = gpuRequest
+		request.GpuCount = gpuCount
+		request.GpuFraction = stubConfig.Runtime.GpuFraction
+		request.MigProfile = stubConfig.Runtime.MigProfile
+		request.Architectures = stubConfig.Runtime.Architectures
+		request.Affinity = stubConfig.Affinity
+		request.PriorityClass = stubConfig.PriorityClass
+		request.DockerEnabled = stubConfig.DockerEnabled
+		request.Preemptable = stub.Type.Kind() == types.StubTypeEndpoint
+		return request, nil
+	}
+
+	valid, errorMsg := types.ValidateCpuAndMemory(in.Cpu, in.Memory, gws.appConfig.GatewayService.StubLimits)
+	if !valid {
+		return nil, errors.New(errorMsg)
+	}
+
+	gpus := types.GPUTypesFromString(in.Gpu)
+	if err := types.ValidateGpuFraction(in.GpuFraction, gpus, in.GpuCount); err != nil {
+		return nil, err
+	}
+
+	if err := types.ValidateMigProfile(in.MigProfile, gpus, in.GpuCount, in.GpuFraction); err != nil {
+		return nil, err
+	}
+
+	affinity, err := types.NewWorkerAffinityFromProto(in.Affinity).WithRegions(in.Regions, in.PinRegions)
+	if err != nil {
+		return nil, err
+	}
+
+	if affinity != nil {
+		if err := affinity.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	priorityClass := types.PriorityClass(in.PriorityClass)
+	if err := priorityClass.Validate(); err != nil {
+		return nil, err
+	}
+
+	gpuCount := in.GpuCount
+	if len(gpus) > 0 && gpuCount == 0 {
+		gpuCount = 1
+	}
+
+	request.Cpu = in.Cpu
+	request.Memory = in.Memory
+	request.GpuRequest = types.GpuTypesToStrings(gpus)
+	request.GpuCount = gpuCount
+	request.GpuFraction = in.GpuFraction
+	request.MigProfile = in.MigProfile
+	request.Affinity = affinity
+	request.PriorityClass = priorityClass
+	request.DockerEnabled = in.DockerEnabled
+	request.Preemptable = in.Preemptable
+	return request, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"sync"
 
+	"github.com/beam-cloud/beta9/pkg/auth"
 	"github.com/beam-cloud/beta9/pkg/common"
 	"github.com/beam-cloud/beta9/pkg/network"
 	"github.com/beam-cloud/beta9/pkg/repository"
@@ -11,12 +12,14 @@ import (
 	"github.com/beam-cloud/beta9/pkg/task"
 	"github.com/beam-cloud/beta9/pkg/types"
 	pb "github.com/beam-cloud/beta9/proto"
+	"github.com/labstack/echo/v4"
 )
 
 type GatewayService struct {
 	ctx              context.Context
 	appConfig        types.AppConfig
 	backendRepo      repository.BackendRepository
+	workspaceRepo    repository.WorkspaceRepository
 	containerRepo    repository.ContainerRepository
 	providerRepo     repository.ProviderRepository
 	scheduler        *scheduler.Scheduler
@@ -28,6 +31,11 @@ type GatewayService struct {
 	usageMetricsRepo repository.UsageMetricsRepository
 	tailscale        *network.Tailscale
 	keyEventManager  *common.KeyEventManager
+	auditLogger      *common.AuditLogger
+	mailer           *common.Mailer
+	sessions         *auth.SessionManager
+	uploadLimiter    *uploadLimiter
+	uploadThrottle   *uploadThrottle
 	clientCache      *sync.Map
 	pb.UnimplementedGatewayServiceServer
 }
@@ -36,6 +44,7 @@ type GatewayServiceOpts struct {
 	Ctx              context.Context
 	Config           types.AppConfig
 	BackendRepo      repository.BackendRepository
+	WorkspaceRepo    repository.WorkspaceRepository
 	ContainerRepo    repository.ContainerRepository
 	ProviderRepo     repository.ProviderRepository
 	Scheduler        *scheduler.Scheduler
@@ -47,6 +56,7 @@ type GatewayServiceOpts struct {
 	UsageMetricsRepo repository.UsageMetricsRepository
 	Tailscale        *network.Tailscale
 	KeyEventManager  *common.KeyEventManager
+	RouteGroup       *echo.Group
 }
 
 func NewGatewayService(opts *GatewayServiceOpts) (*GatewayService, error) {
@@ -55,10 +65,16 @@ func NewGatewayService(opts *GatewayServiceOpts) (*GatewayService, error) {
 		return nil, err
 	}
 
-	return &GatewayService{
+	auditLogger, err := common.NewAuditLogger(opts.Config.Monitoring.Audit)
+	if err != nil {
+		return nil, err
+	}
+
+	gws := &GatewayService{
 		ctx:              opts.Ctx,
 		appConfig:        opts.Config,
 		backendRepo:      opts.BackendRepo,
+		workspaceRepo:    opts.WorkspaceRepo,
 		containerRepo:    opts.ContainerRepo,
 		providerRepo:     opts.ProviderRepo,
 		scheduler:        opts.Scheduler,
@@ -70,6 +86,27 @@ func NewGatewayService(opts *GatewayServiceOpts) (*GatewayService, error) {
 		usageMetricsRepo: opts.UsageMetricsRepo,
 		tailscale:        opts.Tailscale,
 		keyEventManager:  keyEventManager,
+		auditLogger:      auditLogger,
+		mailer:           common.NewMailer(opts.Config.GatewayService.Invites.SMTP),
+		sessions:         auth.NewSessionManager(opts.Config.GatewayService.SessionTokens, opts.BackendRepo, opts.WorkspaceRepo),
+		uploadLimiter:    newUploadLimiter(opts.Config.GatewayService.MaxConcurrentUploads, opts.Config.GatewayService.UploadQueueTimeout),
+		uploadThrottle:   newUploadThrottle(opts.Config.GatewayService.UploadBandwidthLimit, opts.Config.GatewayService.WorkspaceUploadBandwidthLimit),
 		clientCache:      &sync.Map{},
-	}, nil
+	}
+
+	if opts.RouteGroup != nil {
+		registerS3Routes(opts.RouteGroup.Group(S3RoutePrefix), gws)
+	}
+
+	go gws.removeOrphanedObjectTempFiles()
+	go gws.collectStaleUploadSessions()
+	go gws.collectAbandonedUploads()
+	go gws.reapExpiredObjects()
+	go gws.migrateObjects()
+	go gws.auditObjectIntegrity()
+	go gws.replicateObjects()
+	go gws.deliverObjectWebhooks()
+	go gws.pruneAuditLog()
+
+	return gws, nil
 }
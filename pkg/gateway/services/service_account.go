@@ -0,0 +1,312 @@
+package gatewayservices
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+)
+
+const (
+	defaultServiceAccountTokenTTL = time.Hour
+	maxServiceAccountTokenTTL     = 24 * time.Hour
+)
+
+var serviceAccountNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,62}$`)
+
+func (gws *GatewayService) CreateServiceAccount(ctx context.Context, in *pb.CreateServiceAccountRequest) (*pb.CreateServiceAccountResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionAdmin) {
+		return &pb.CreateServiceAccountResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	if !serviceAccountNamePattern.MatchString(in.Name) {
+		return &pb.CreateServiceAccountResponse{
+			Ok:     false,
+			ErrMsg: "Invalid name. Names are up to 63 lowercase letters, digits, dashes and underscores.",
+		}, nil
+	}
+
+	role := types.WorkspaceRole(in.Role)
+	if !role.IsValid() {
+		return &pb.CreateServiceAccountResponse{
+			Ok:     false,
+			ErrMsg: "Invalid role. Allowed roles: admin, developer, read_only, deploy_only",
+		}, nil
+	}
+
+	serviceAccount, err := gws.backendRepo.CreateServiceAccount(ctx, authInfo.Workspace.Id, in.Name, role)
+	if err != nil {
+		return &pb.CreateServiceAccountResponse{
+			Ok:     false,
+			ErrMsg: "Unable to create service account. Its name may already be taken.",
+		}, nil
+	}
+
+	return &pb.CreateServiceAccountResponse{
+		Ok:             true,
+		ServiceAccount: serviceAccountToProto(serviceAccount, nil),
+	}, nil
+}
+
+func (gws *GatewayService) ListServiceAccounts(ctx context.Context, in *pb.ListServiceAccountsRequest) (*pb.ListServiceAccountsResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionAdmin) {
+		return &pb.ListServiceAccountsResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	serviceAccounts, err := gws.backendRepo.ListServiceAccounts(ctx, authInfo.Workspace.Id)
+	if err != nil {
+		return &pb.ListServiceAccountsResponse{
+			Ok:     false,
+			ErrMsg: "Unable to list service accounts.",
+		}, nil
+	}
+
+	resp := &pb.ListServiceAccountsResponse{
+		Ok:              true,
+		ServiceAccounts: make([]*pb.ServiceAccount, 0, len(serviceAccounts)),
+	}
+	for i := range serviceAccounts {
+		tokens, err := gws.backendRepo.ListServiceAccountTokens(ctx, serviceAccounts[i].Id)
+		if err != nil {
+			return &pb.ListServiceAccountsResponse{
+				Ok:     false,
+				ErrMsg: "Unable to list service accounts.",
+			}, nil
+		}
+
+		resp.ServiceAccounts = append(resp.ServiceAccounts, serviceAccountToProto(&serviceAccounts[i], tokens))
+	}
+
+	return resp, nil
+}
+
+// DeleteServiceAccount deletes a service account, and with it every token it was issued
+func (gws *GatewayService) DeleteServiceAccount(ctx context.Context, in *pb.DeleteServiceAccountRequest) (*pb.DeleteServiceAccountResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionAdmin) {
+		return &pb.DeleteServiceAccountResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	serviceAccount, err := gws.backendRepo.GetServiceAccount(ctx, authInfo.Workspace.Id, in.ServiceAccountId)
+	if err != nil || serviceAccount == nil {
+		return &pb.DeleteServiceAccountResponse{
+			Ok:     false,
+			ErrMsg: "Service account not found.",
+		}, nil
+	}
+
+	tokens, err := gws.backendRepo.ListServiceAccountTokens(ctx, serviceAccount.Id)
+	if err != nil {
+		return &pb.DeleteServiceAccountResponse{
+			Ok:     false,
+			ErrMsg: "Unable to delete service account.",
+		}, nil
+	}
+
+	if err := gws.backendRepo.DeleteServiceAccount(ctx, serviceAccount.Id); err != nil {
+		return &pb.DeleteServiceAccountResponse{
+			Ok:     false,
+			ErrMsg: "Unable to delete service account.",
+		}, nil
+	}
+
+	for i := range tokens {
+		gws.revokeToken(&tokens[i])
+	}
+
+	return &pb.DeleteServiceAccountResponse{Ok: true}, nil
+}
+
+func (gws *GatewayService) IssueServiceAccountToken(ctx context.Context, in *pb.IssueServiceAccountTokenRequest) (*pb.IssueServiceAccountTokenResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionAdmin) {
+		return &pb.IssueServiceAccountTokenResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	serviceAccount, err := gws.backendRepo.GetServiceAccount(ctx, authInfo.Workspace.Id, in.ServiceAccountId)
+	if err != nil || serviceAccount == nil {
+		return &pb.IssueServiceAccountTokenResponse{
+			Ok:     false,
+			ErrMsg: "Service account not found.",
+		}, nil
+	}
+
+	token, err := gws.backendRepo.CreateServiceAccountToken(ctx, serviceAccount, time.Now().Add(gws.serviceAccountTokenTTL(in.ExpiresInSeconds)))
+	if err != nil {
+		return &pb.IssueServiceAccountTokenResponse{
+			Ok:     false,
+			ErrMsg: "Unable to issue token.",
+		}, nil
+	}
+
+	return &pb.IssueServiceAccountTokenResponse{
+		Ok:        true,
+		TokenId:   token.ExternalId,
+		Token:     token.Key,
+		ExpiresAt: timestamppb.New(token.ExpiresAt.Time),
+	}, nil
+}
+
+// RenewServiceAccountToken extends a service account token that hasn't expired yet. Service accounts can renew
+// their own tokens, any other token can only be renewed by an admin.
+func (gws *GatewayService) RenewServiceAccountToken(ctx context.Context, in *pb.RenewServiceAccountTokenRequest) (*pb.RenewServiceAccountTokenResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	tokenId := in.TokenId
+	if tokenId == "" {
+		tokenId = authInfo.Token.ExternalId
+	}
+
+	isSelf := authInfo.Token.TokenType == types.TokenTypeServiceAccount && tokenId == authInfo.Token.ExternalId
+	if !isSelf && !auth.HasPermission(authInfo, types.PermissionAdmin) {
+		return &pb.RenewServiceAccountTokenResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	token, err := gws.backendRepo.GetTokenByExternalId(ctx, authInfo.Workspace.Id, tokenId)
+	if err != nil || token.TokenType != types.TokenTypeServiceAccount {
+		return &pb.RenewServiceAccountTokenResponse{
+			Ok:     false,
+			ErrMsg: "Service account token not found.",
+		}, nil
+	}
+
+	token, err = gws.backendRepo.SetTokenExpiry(ctx, authInfo.Workspace.Id, tokenId, time.Now().Add(gws.serviceAccountTokenTTL(in.ExpiresInSeconds)))
+	if err != nil {
+		return &pb.RenewServiceAccountTokenResponse{
+			Ok:     false,
+			ErrMsg: "Unable to renew token.",
+		}, nil
+	}
+
+	if token == nil {
+		return &pb.RenewServiceAccountTokenResponse{
+			Ok:     false,
+			ErrMsg: "The token has expired and can't be renewed.",
+		}, nil
+	}
+
+	// Drop the cached token so its new expiry is picked up
+	gws.invalidateCachedToken(token)
+
+	return &pb.RenewServiceAccountTokenResponse{
+		Ok:        true,
+		ExpiresAt: timestamppb.New(token.ExpiresAt.Time),
+	}, nil
+}
+
+func (gws *GatewayService) RevokeServiceAccountToken(ctx context.Context, in *pb.RevokeServiceAccountTokenRequest) (*pb.RevokeServiceAccountTokenResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionAdmin) {
+		return &pb.RevokeServiceAccountTokenResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	token, err := gws.backendRepo.GetTokenByExternalId(ctx, authInfo.Workspace.Id, in.TokenId)
+	if err != nil || token.TokenType != types.TokenTypeServiceAccount {
+		return &pb.RevokeServiceAccountTokenResponse{
+			Ok:     false,
+			ErrMsg: "Service account token not found.",
+		}, nil
+	}
+
+	if err := gws.backendRepo.DeleteToken(ctx, authInfo.Workspace.Id, in.TokenId); err != nil {
+		return &pb.RevokeServiceAccountTokenResponse{
+			Ok:     false,
+			ErrMsg: "Unable to revoke token.",
+		}, nil
+	}
+
+	gws.revokeToken(token)
+
+	return &pb.RevokeServiceAccountTokenResponse{Ok: true}, nil
+}
+
+// serviceAccountTokenTTL is how long a service account token is issued or renewed for, the requested time if
+// it's set and within the gateway's limit
+func (gws *GatewayService) serviceAccountTokenTTL(expiresInSeconds uint32) time.Duration {
+	config := gws.appConfig.GatewayService.ServiceAccounts
+
+	maxTTL := config.MaxTokenTTL
+	if maxTTL <= 0 {
+		maxTTL = maxServiceAccountTokenTTL
+	}
+
+	ttl := config.DefaultTokenTTL
+	if ttl <= 0 {
+		ttl = defaultServiceAccountTokenTTL
+	}
+
+	if expiresInSeconds > 0 {
+		ttl = time.Duration(expiresInSeconds) * time.Second
+	}
+
+	return min(ttl, maxTTL)
+}
+
+// revokeToken makes every gateway refuse a token on its next use, without waiting for cached copies to expire
+func (gws *GatewayService) revokeToken(token *types.Token) {
+	if err := gws.workspaceRepo.RevokeToken(token.Key); err != nil {
+		log.Error().Err(err).Str("token_id", token.ExternalId).Msg("failed to revoke token")
+	}
+}
+
+// invalidateCachedToken drops the cached copy of a token, so changes to it apply to its next use
+func (gws *GatewayService) invalidateCachedToken(token *types.Token) {
+	if err := gws.workspaceRepo.InvalidateToken(token.Key); err != nil {
+		log.Error().Err(err).Str("token_id", token.ExternalId).Msg("failed to invalidate cached token")
+	}
+}
+
+func serviceAccountToProto(serviceAccount *types.ServiceAccount, tokens []types.Token) *pb.ServiceAccount {
+	sa := &pb.ServiceAccount{
+		ServiceAccountId: serviceAccount.ExternalId,
+		Name:             serviceAccount.Name,
+		Role:             serviceAccount.Role,
+		CreatedAt:        timestamppb.New(serviceAccount.CreatedAt.Time),
+		Tokens:           make([]*pb.ServiceAccountToken, 0, len(tokens)),
+	}
+
+	if serviceAccount.LastUsedAt.Valid {
+		sa.LastUsedAt = timestamppb.New(serviceAccount.LastUsedAt.Time)
+	}
+
+	for _, token := range tokens {
+		sa.Tokens = append(sa.Tokens, &pb.ServiceAccountToken{
+			TokenId:   token.ExternalId,
+			ExpiresAt: timestamppb.New(token.ExpiresAt.Time),
+			CreatedAt: timestamppb.New(token.CreatedAt.Time),
+		})
+	}
+
+	return sa
+}
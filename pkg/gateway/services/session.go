@@ -0,0 +1,168 @@
+package gatewayservices
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+)
+
+const (
+	auditActionSessionCreate = "session.create"
+	auditActionSessionRevoke = "session.revoke"
+	auditResourceSession     = "session"
+)
+
+// ExchangeSessionToken starts a session for the caller's token, so browsers can hold short-lived, scoped tokens
+// instead of the workspace token itself
+func (gws *GatewayService) ExchangeSessionToken(ctx context.Context, in *pb.ExchangeSessionTokenRequest) (*pb.ExchangeSessionTokenResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if gws.sessions == nil {
+		return &pb.ExchangeSessionTokenResponse{
+			Ok:     false,
+			ErrMsg: "Session tokens are not enabled",
+		}, nil
+	}
+
+	if authInfo.Session != "" {
+		return &pb.ExchangeSessionTokenResponse{
+			Ok:     false,
+			ErrMsg: "Session tokens can't be exchanged for other sessions",
+		}, nil
+	}
+
+	switch authInfo.Token.TokenType {
+	case types.TokenTypeWorkspace, types.TokenTypeWorkspacePrimary, types.TokenTypeServiceAccount:
+	default:
+		return &pb.ExchangeSessionTokenResponse{
+			Ok:     false,
+			ErrMsg: "Tokens of this type can't be exchanged for sessions",
+		}, nil
+	}
+
+	session, err := gws.sessions.Start(authInfo.Token, authInfo.Workspace, in.Scopes)
+
+	event := common.AuditEvent{
+		Action:       auditActionSessionCreate,
+		Principal:    authInfo.Token.ExternalId,
+		WorkspaceId:  authInfo.Workspace.ExternalId,
+		ResourceType: auditResourceSession,
+		Outcome:      auditOutcome(err),
+		Reason:       errorMessage(err),
+	}
+	if session != nil {
+		event.ResourceId = session.SessionId
+	}
+	gws.auditLogger.Log(event)
+
+	if err != nil {
+		return &pb.ExchangeSessionTokenResponse{
+			Ok:     false,
+			ErrMsg: err.Error(),
+		}, nil
+	}
+
+	return &pb.ExchangeSessionTokenResponse{
+		Ok:      true,
+		Session: sessionTokensToProto(session),
+	}, nil
+}
+
+// RefreshSessionToken replaces a session's tokens. It's called without a bearer token, since the access token
+// has usually expired by the time it's needed.
+func (gws *GatewayService) RefreshSessionToken(ctx context.Context, in *pb.RefreshSessionTokenRequest) (*pb.RefreshSessionTokenResponse, error) {
+	if gws.sessions == nil {
+		return &pb.RefreshSessionTokenResponse{
+			Ok:     false,
+			ErrMsg: "Session tokens are not enabled",
+		}, nil
+	}
+
+	session, err := gws.sessions.Refresh(in.RefreshToken)
+	if err != nil {
+		log.Debug().Err(err).Msg("failed to refresh session")
+		return &pb.RefreshSessionTokenResponse{
+			Ok:     false,
+			ErrMsg: "Invalid or expired refresh token",
+		}, nil
+	}
+
+	// Refresh tokens authenticate the call themselves, so the audit entry is attributed to the session's token
+	if owner, err := gws.sessions.Get(session.SessionId); err == nil && owner != nil {
+		if token, workspace, err := gws.backendRepo.AuthorizeToken(ctx, owner.TokenKey); err == nil {
+			auth.SetAuditCaller(ctx, &auth.AuthInfo{Token: token, Workspace: workspace, Session: session.SessionId})
+		}
+	}
+
+	return &pb.RefreshSessionTokenResponse{
+		Ok:      true,
+		Session: sessionTokensToProto(session),
+	}, nil
+}
+
+// RevokeSessionToken ends a session. Sessions can end themselves, and can be ended by the token they were
+// started from or by an admin of their workspace.
+func (gws *GatewayService) RevokeSessionToken(ctx context.Context, in *pb.RevokeSessionTokenRequest) (*pb.RevokeSessionTokenResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if gws.sessions == nil {
+		return &pb.RevokeSessionTokenResponse{
+			Ok:     false,
+			ErrMsg: "Session tokens are not enabled",
+		}, nil
+	}
+
+	session, err := gws.sessions.Get(in.SessionId)
+	if err != nil || session == nil {
+		return &pb.RevokeSessionTokenResponse{
+			Ok:     false,
+			ErrMsg: "Session not found",
+		}, nil
+	}
+
+	allowed := authInfo.Session == in.SessionId ||
+		(authInfo.Session == "" && authInfo.Token.Key == session.TokenKey) ||
+		(authInfo.Workspace.ExternalId == session.WorkspaceId && auth.HasPermission(authInfo, types.PermissionAdmin))
+	if !allowed {
+		return &pb.RevokeSessionTokenResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	err = gws.sessions.Revoke(in.SessionId)
+	gws.auditLogger.Log(common.AuditEvent{
+		Action:       auditActionSessionRevoke,
+		Principal:    authInfo.Token.ExternalId,
+		WorkspaceId:  authInfo.Workspace.ExternalId,
+		ResourceType: auditResourceSession,
+		ResourceId:   in.SessionId,
+		Outcome:      auditOutcome(err),
+		Reason:       errorMessage(err),
+	})
+
+	if err != nil {
+		return &pb.RevokeSessionTokenResponse{
+			Ok:     false,
+			ErrMsg: "Unable to revoke session",
+		}, nil
+	}
+
+	return &pb.RevokeSessionTokenResponse{Ok: true}, nil
+}
+
+func sessionTokensToProto(session *auth.SessionTokens) *pb.SessionTokens {
+	return &pb.SessionTokens{
+		SessionId:             session.SessionId,
+		AccessToken:           session.AccessToken,
+		AccessTokenExpiresAt:  timestamppb.New(session.AccessTokenExpiresAt),
+		RefreshToken:          session.RefreshToken,
+		RefreshTokenExpiresAt: timestamppb.New(session.RefreshTokenExpiresAt),
+	}
+}
@@ -12,11 +12,14 @@ import (
 	"strings"
 	"time"
 
+	abstractions "github.com/beam-cloud/beta9/pkg/abstractions/common"
 	"github.com/beam-cloud/beta9/pkg/abstractions/endpoint"
 	"github.com/beam-cloud/beta9/pkg/abstractions/function"
 	"github.com/beam-cloud/beta9/pkg/abstractions/taskqueue"
+	"github.com/beam-cloud/beta9/pkg/abstractions/volume"
 	"github.com/beam-cloud/beta9/pkg/auth"
 	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/repository"
 	"github.com/beam-cloud/beta9/pkg/types"
 	pb "github.com/beam-cloud/beta9/proto"
 )
@@ -86,6 +89,50 @@ func (gws *GatewayService) GetOrCreateStub(ctx context.Context, in *pb.GetOrCrea
 		}, nil
 	}
 
+	if err := types.ValidateGpuFraction(in.GpuFraction, gpus, in.GpuCount); err != nil {
+		return &pb.GetOrCreateStubResponse{
+			Ok:     false,
+			ErrMsg: err.Error(),
+		}, nil
+	}
+
+	if err := types.ValidateMigProfile(in.MigProfile, gpus, in.GpuCount, in.GpuFraction); err != nil {
+		return &pb.GetOrCreateStubResponse{
+			Ok:     false,
+			ErrMsg: err.Error(),
+		}, nil
+	}
+
+	if err := types.ValidateArchitectures(in.Architectures); err != nil {
+		return &pb.GetOrCreateStubResponse{
+			Ok:     false,
+			ErrMsg: err.Error(),
+		}, nil
+	}
+
+	if in.Nodes > 1 {
+		if types.StubType(in.StubType).Kind() != types.StubTypePod {
+			return &pb.GetOrCreateStubResponse{
+				Ok:     false,
+				ErrMsg: "Multi-node jobs are only supported for pods.",
+			}, nil
+		}
+
+		if maxNodes := gws.appConfig.Worker.GangScheduling.MaxNodes; maxNodes > 0 && int(in.Nodes) > maxNodes {
+			return &pb.GetOrCreateStubResponse{
+				Ok:     false,
+				ErrMsg: fmt.Sprintf("Nodes must be %d or less.", maxNodes),
+			}, nil
+		}
+
+		if in.GpuFraction > 0 {
+			return &pb.GetOrCreateStubResponse{
+				Ok:     false,
+				ErrMsg: "Multi-node jobs can't use a fraction of a GPU.",
+			}, nil
+		}
+	}
+
 	if len(gws.appConfig.GatewayService.StubLimits.GPUBlackList.GPUTypes) > 0 {
 		if slices.Contains(gws.appConfig.GatewayService.StubLimits.GPUBlackList.GPUTypes, in.Gpu) {
 			return &pb.GetOrCreateStubResponse{
@@ -116,6 +163,154 @@ func (gws *GatewayService) GetOrCreateStub(ctx context.Context, in *pb.GetOrCrea
 		}
 	}
 
+	sidecars := make([]types.Sidecar, 0, len(in.Sidecars))
+	for _, sidecar := range in.Sidecars {
+		sidecars = append(sidecars, types.NewSidecarFromProto(sidecar))
+	}
+
+	volumePaths := make([]string, 0, len(in.Volumes))
+	for _, volume := range in.Volumes {
+		volumePaths = append(volumePaths, volume.MountPath)
+	}
+
+	if err := types.ValidateSidecars(sidecars, volumePaths); err != nil {
+		return &pb.GetOrCreateStubResponse{
+			Ok:     false,
+			ErrMsg: err.Error(),
+		}, nil
+	}
+
+	secretMountPath := ""
+	for _, secretVar := range in.Secrets {
+		if secretVar.Mount {
+			secretMountPath = in.SecretMountPath
+			if secretMountPath == "" {
+				secretMountPath = types.DefaultSecretMountPath
+			}
+			break
+		}
+	}
+
+	if secretMountPath != "" {
+		if err := types.ValidateSecretMountPath(secretMountPath, volumePaths); err != nil {
+			return &pb.GetOrCreateStubResponse{
+				Ok:     false,
+				ErrMsg: err.Error(),
+			}, nil
+		}
+	}
+
+	healthCheck := types.NewHealthCheckFromProto(in.HealthCheck)
+	if healthCheck != nil {
+		kind := types.StubType(in.StubType).Kind()
+		if kind != types.StubTypeEndpoint && kind != types.StubTypeASGI && kind != types.StubTypeGRPC {
+			return &pb.GetOrCreateStubResponse{
+				Ok:     false,
+				ErrMsg: "Health checks are only supported for endpoints",
+			}, nil
+		}
+
+		if err := healthCheck.Validate(); err != nil {
+			return &pb.GetOrCreateStubResponse{
+				Ok:     false,
+				ErrMsg: err.Error(),
+			}, nil
+		}
+	}
+
+	requestLimits := types.NewRequestLimitsFromProto(in.RequestLimits)
+	if requestLimits != nil {
+		kind := types.StubType(in.StubType).Kind()
+		if kind != types.StubTypeEndpoint && kind != types.StubTypeASGI {
+			return &pb.GetOrCreateStubResponse{
+				Ok:     false,
+				ErrMsg: "Request limits are only supported for endpoints",
+			}, nil
+		}
+	}
+
+	concurrencyPolicy := types.NewConcurrencyPolicyFromProto(in.ConcurrencyPolicy)
+	if concurrencyPolicy != nil {
+		kind := types.StubType(in.StubType).Kind()
+		if kind != types.StubTypeEndpoint && kind != types.StubTypeASGI && kind != types.StubTypeGRPC && kind != types.StubTypeFunction {
+			return &pb.GetOrCreateStubResponse{
+				Ok:     false,
+				ErrMsg: "Concurrency policies are only supported for endpoints and functions",
+			}, nil
+		}
+
+		if err := concurrencyPolicy.Validate(); err != nil {
+			return &pb.GetOrCreateStubResponse{
+				Ok:     false,
+				ErrMsg: err.Error(),
+			}, nil
+		}
+	}
+
+	connectionPolicy := types.NewConnectionPolicyFromProto(in.ConnectionPolicy)
+	if connectionPolicy != nil {
+		kind := types.StubType(in.StubType).Kind()
+		if kind != types.StubTypeEndpoint && kind != types.StubTypeASGI {
+			return &pb.GetOrCreateStubResponse{
+				Ok:     false,
+				ErrMsg: "Connection policies are only supported for endpoints",
+			}, nil
+		}
+
+		if err := connectionPolicy.Validate(); err != nil {
+			return &pb.GetOrCreateStubResponse{
+				Ok:     false,
+				ErrMsg: err.Error(),
+			}, nil
+		}
+	}
+
+	affinity, err := types.NewWorkerAffinityFromProto(in.Affinity).WithRegions(in.Regions, in.PinRegions)
+	if err != nil {
+		return &pb.GetOrCreateStubResponse{
+			Ok:     false,
+			ErrMsg: err.Error(),
+		}, nil
+	}
+
+	if affinity != nil {
+		if err := affinity.Validate(); err != nil {
+			return &pb.GetOrCreateStubResponse{
+				Ok:     false,
+				ErrMsg: err.Error(),
+			}, nil
+		}
+	}
+
+	priorityClass := types.PriorityClass(in.PriorityClass)
+	if err := priorityClass.Validate(); err != nil {
+		return &pb.GetOrCreateStubResponse{
+			Ok:     false,
+			ErrMsg: err.Error(),
+		}, nil
+	}
+
+	if types.StubType(in.StubType).Kind() == types.StubTypeGRPC {
+		if len(in.Entrypoint) == 0 {
+			return &pb.GetOrCreateStubResponse{
+				Ok:     false,
+				ErrMsg: "gRPC stubs require an entrypoint that starts the server",
+			}, nil
+		}
+
+		if err := types.ValidateGRPCServices(in.GrpcServices); err != nil {
+			return &pb.GetOrCreateStubResponse{
+				Ok:     false,
+				ErrMsg: err.Error(),
+			}, nil
+		}
+	} else if len(in.GrpcServices) > 0 {
+		return &pb.GetOrCreateStubResponse{
+			Ok:     false,
+			ErrMsg: "gRPC services are only supported for gRPC stubs",
+		}, nil
+	}
+
 	var inputs *types.Schema = nil
 	if in.Inputs != nil {
 		inputs = types.NewSchemaFromProto(in.Inputs)
@@ -128,11 +323,14 @@ func (gws *GatewayService) GetOrCreateStub(ctx context.Context, in *pb.GetOrCrea
 
 	stubConfig := types.StubConfigV1{
 		Runtime: types.Runtime{
-			Cpu:      in.Cpu,
-			Gpus:     gpus,
-			GpuCount: in.GpuCount,
-			Memory:   in.Memory,
-			ImageId:  in.ImageId,
+			Cpu:           in.Cpu,
+			Gpus:          gpus,
+			GpuCount:      in.GpuCount,
+			GpuFraction:   in.GpuFraction,
+			MigProfile:    in.MigProfile,
+			Memory:        in.Memory,
+			ImageId:       in.ImageId,
+			Architectures: in.Architectures,
 		},
 		Handler:            in.Handler,
 		OnStart:            in.OnStart,
@@ -161,6 +359,16 @@ func (gws *GatewayService) GetOrCreateStub(ctx context.Context, in *pb.GetOrCrea
 		BlockNetwork:       in.BlockNetwork,
 		AllowList:          in.AllowList,
 		DockerEnabled:      in.DockerEnabled,
+		Sidecars:           sidecars,
+		SecretMountPath:    secretMountPath,
+		HealthCheck:        healthCheck,
+		RequestLimits:      requestLimits,
+		Concurrency:        concurrencyPolicy,
+		GRPCServices:       in.GrpcServices,
+		Connections:        connectionPolicy,
+		Affinity:           affinity,
+		PriorityClass:      priorityClass,
+		Nodes:              in.Nodes,
 	}
 
 	// Ensure GPU count is at least 1 if a GPU is required
@@ -207,9 +415,29 @@ func (gws *GatewayService) GetOrCreateStub(ctx context.Context, in *pb.GetOrCrea
 		}
 	}
 
+	policyWarning, err := gws.applyWorkspacePolicy(ctx, authInfo.Workspace, &stubConfig)
+	if err != nil {
+		return &pb.GetOrCreateStubResponse{
+			Ok:     false,
+			ErrMsg: err.Error(),
+		}, nil
+	}
+
+	if policyWarning != "" {
+		warning = strings.TrimSpace(warning + " " + policyWarning)
+	}
+
 	// Get secrets
-	for _, secret := range in.Secrets {
-		secret, err := gws.backendRepo.GetSecretByName(ctx, authInfo.Workspace, secret.Name)
+	for _, secretVar := range in.Secrets {
+		var (
+			secret *types.Secret
+			err    error
+		)
+		if secretVar.Version > 0 {
+			secret, err = gws.backendRepo.GetSecretVersion(ctx, authInfo.Workspace, secretVar.Name, uint(secretVar.Version))
+		} else {
+			secret, err = gws.backendRepo.GetSecretByName(ctx, authInfo.Workspace, secretVar.Name)
+		}
 		if err != nil {
 			if err == sql.ErrNoRows {
 				continue // Skip secret if not found
@@ -225,10 +453,13 @@ func (gws *GatewayService) GetOrCreateStub(ctx context.Context, in *pb.GetOrCrea
 			Value:     secret.Value,
 			CreatedAt: secret.CreatedAt,
 			UpdatedAt: secret.UpdatedAt,
+			Version:   secret.Version,
+			Pinned:    secretVar.Version > 0,
+			Mount:     secretVar.Mount,
 		})
 	}
 
-	err := gws.configureVolumes(ctx, in.Volumes, authInfo.Workspace)
+	err = gws.configureVolumes(ctx, in.Volumes, authInfo)
 	if err != nil {
 		return &pb.GetOrCreateStubResponse{
 			Ok:     false,
@@ -255,6 +486,7 @@ func (gws *GatewayService) GetOrCreateStub(ctx context.Context, in *pb.GetOrCrea
 			Ok: false,
 		}, nil
 	}
+	gws.touchObject(ctx, object.ExternalId)
 
 	stub, err := gws.backendRepo.GetOrCreateStub(ctx, in.Name, in.StubType, stubConfig, object.Id, authInfo.Workspace.Id, in.ForceCreate, app.Id)
 	if err != nil {
@@ -278,6 +510,14 @@ func (gws *GatewayService) handleCheckpointEnabled(ctx context.Context, authInfo
 		return fmt.Errorf("Checkpoints are yet not supported for multi-GPU")
 	}
 
+	if in.GpuFraction > 0 {
+		return fmt.Errorf("Checkpoints are not yet supported for fractional GPUs")
+	}
+
+	if in.MigProfile != "" {
+		return fmt.Errorf("Checkpoints are not yet supported for MIG slices")
+	}
+
 	if len(gpus) > 1 {
 		return fmt.Errorf("Checkpoints are yet not supported between multiple GPUs")
 	}
@@ -320,6 +560,12 @@ func (gws *GatewayService) handleCheckpointEnabled(ctx context.Context, authInfo
 func (gws *GatewayService) DeployStub(ctx context.Context, in *pb.DeployStubRequest) (*pb.DeployStubResponse, error) {
 	authInfo, _ := auth.AuthInfoFromContext(ctx)
 
+	if !auth.HasPermission(authInfo, types.PermissionDeploy) {
+		return &pb.DeployStubResponse{
+			Ok: false,
+		}, nil
+	}
+
 	stub, err := gws.backendRepo.GetStubByExternalId(ctx, in.StubId)
 	if err != nil || stub.Workspace.ExternalId != authInfo.Workspace.ExternalId {
 		return &pb.DeployStubResponse{
@@ -358,6 +604,9 @@ func (gws *GatewayService) DeployStub(ctx context.Context, in *pb.DeployStubRequ
 	invokeUrl := common.BuildDeploymentURL(gws.appConfig.GatewayService.HTTP.GetExternalURL(), common.InvokeUrlTypePath, stub, deployment)
 
 	go gws.eventRepo.PushDeployStubEvent(authInfo.Workspace.ExternalId, &stub.Stub)
+	abstractions.NotifyDeploymentEvent(ctx, gws.appConfig, gws.backendRepo, authInfo.Workspace.ExternalId, deployment, stub.ExternalId, abstractions.DeploymentWebhookPayload{
+		Event: types.DeploymentWebhookEventCreated,
+	})
 
 	var config types.StubConfigV1
 	if err := json.Unmarshal([]byte(stub.Config), &config); err != nil {
@@ -486,7 +735,10 @@ func (gws *GatewayService) GetURL(ctx context.Context, in *pb.GetURLRequest) (*p
 	}, nil
 }
 
-func (gws *GatewayService) configureVolumes(ctx context.Context, volumes []*pb.Volume, workspace *types.Workspace) error {
+func (gws *GatewayService) configureVolumes(ctx context.Context, volumes []*pb.Volume, authInfo *auth.AuthInfo) error {
+	workspace := authInfo.Workspace
+	mountsOwnVolume := false
+
 	for i, volume := range volumes {
 		if volume.Config != nil {
 			// De-reference secrets
@@ -501,12 +753,82 @@ func (gws *GatewayService) configureVolumes(ctx context.Context, volumes []*pb.V
 				return fmt.Errorf("Failed to get secret: %s", volume.Config.SecretKey)
 			}
 			volumes[i].Config.SecretKey = secretKey.Value
+			continue
+		}
+
+		// Owner fields are only ever set here, never trusted from the client
+		volumes[i].OwnerWorkspaceId = ""
+		volumes[i].OwnerWorkspaceName = ""
+
+		owned, err := gws.backendRepo.GetVolumeByExternalId(ctx, workspace.Id, volume.Id)
+		if err != nil {
+			return fmt.Errorf("Failed to get volume: %s", volume.Id)
+		}
+		if owned != nil {
+			acl, err := gws.backendRepo.ListAccessControlEntries(ctx, types.ACLResourceVolume, owned.Id)
+			if err != nil {
+				return fmt.Errorf("Failed to get volume: %s", volume.Id)
+			}
+
+			if !auth.HasResourceAccess(authInfo, acl, types.ResourceAccessRead) {
+				return fmt.Errorf("Unauthorized access to volume: %s", volume.Id)
+			}
+
+			// Callers that can only read the volume get it mounted read-only
+			if !auth.HasResourceAccess(authInfo, acl, types.ResourceAccessWrite) {
+				volumes[i].ReadOnly = true
+			}
+
+			mountsOwnVolume = true
+			continue
+		}
+
+		shared, err := gws.backendRepo.GetSharedVolume(ctx, workspace.Id, volume.Id)
+		if err != nil {
+			return fmt.Errorf("Failed to get volume: %s", volume.Id)
+		}
+		if shared != nil {
+			volumes[i].OwnerWorkspaceId = shared.Workspace.ExternalId
+			volumes[i].OwnerWorkspaceName = shared.Workspace.Name
+		}
+	}
+
+	// Refuse to mount writable volumes once the workspace is past its storage plan
+	if mountsOwnVolume {
+		quota, err := volume.NewVolumeQuota(ctx, gws.backendRepo, gws.appConfig.Storage.VolumeQuotaBytes, workspace, nil)
+		if err != nil {
+			return fmt.Errorf("Failed to check volume quota")
+		}
+
+		if err := quota.Reserve(0); err != nil {
+			return fmt.Errorf("Unable to mount volumes: %v", err)
 		}
 	}
 
 	return nil
 }
 
+// applyWorkspacePolicy rejects stubs that need more than the workspace's resource policy allows. Timeouts over
+// the policy's maximum run duration are lowered to it instead, with a warning.
+func (gws *GatewayService) applyWorkspacePolicy(ctx context.Context, workspace *types.Workspace, stubConfig *types.StubConfigV1) (string, error) {
+	policy, err := repository.LoadWorkspacePolicy(ctx, gws.workspaceRepo, gws.backendRepo, workspace.ExternalId)
+	if err != nil {
+		return "", fmt.Errorf("Failed to check workspace policy.")
+	}
+
+	if err := policy.CheckRuntime(stubConfig.Runtime); err != nil {
+		return "", err
+	}
+
+	timeout, clamped := policy.ClampRunDuration(stubConfig.TaskPolicy.Timeout)
+	if !clamped {
+		return "", nil
+	}
+
+	stubConfig.TaskPolicy.Timeout = timeout
+	return fmt.Sprintf("Timeout was limited to %d seconds by the workspace policy.", timeout), nil
+}
+
 func (gws *GatewayService) configureTaskPolicy(policy *pb.TaskPolicy, stubType types.StubType) types.TaskPolicy {
 	p := types.TaskPolicy{
 		MaxRetries: uint(math.Min(float64(policy.MaxRetries), float64(types.MaxTaskRetries))),
@@ -515,7 +837,7 @@ func (gws *GatewayService) configureTaskPolicy(policy *pb.TaskPolicy, stubType t
 	}
 
 	switch stubType.Kind() {
-	case types.StubTypeASGI:
+	case types.StubTypeASGI, types.StubTypeGRPC:
 		fallthrough
 	case types.StubTypeEndpoint:
 		p.Timeout = int(math.Min(float64(policy.Timeout), float64(endpoint.DefaultEndpointRequestTimeoutS)))
@@ -12,6 +12,7 @@ import (
 	"github.com/beam-cloud/beta9/pkg/common"
 	"github.com/beam-cloud/beta9/pkg/types"
 	pb "github.com/beam-cloud/beta9/proto"
+	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -19,7 +20,7 @@ import (
 func (gws *GatewayService) StartTask(ctx context.Context, in *pb.StartTaskRequest) (*pb.StartTaskResponse, error) {
 	authInfo, _ := auth.AuthInfoFromContext(ctx)
 
-	if !auth.HasPermission(authInfo) {
+	if !auth.HasPermission(authInfo, types.PermissionWrite) {
 		return &pb.StartTaskResponse{
 			Ok: false,
 		}, nil
@@ -51,6 +52,11 @@ func (gws *GatewayService) StartTask(ctx context.Context, in *pb.StartTaskReques
 		task.ContainerId = in.ContainerId
 	}
 
+	// Record the GPU type the scheduler picked from the stub's acceptable GPUs
+	if state, err := gws.containerRepo.GetContainerState(task.ContainerId); err == nil {
+		task.Gpu = state.Gpu
+	}
+
 	err = gws.taskDispatcher.Claim(ctx, task.Workspace.Name, task.Stub.ExternalId, task.ExternalId, task.ContainerId)
 	if err != nil {
 		return &pb.StartTaskResponse{
@@ -67,7 +73,7 @@ func (gws *GatewayService) StartTask(ctx context.Context, in *pb.StartTaskReques
 func (gws *GatewayService) EndTask(ctx context.Context, in *pb.EndTaskRequest) (*pb.EndTaskResponse, error) {
 	authInfo, _ := auth.AuthInfoFromContext(ctx)
 
-	if !auth.HasPermission(authInfo) {
+	if !auth.HasPermission(authInfo, types.PermissionWrite) {
 		return &pb.EndTaskResponse{
 			Ok: false,
 		}, nil
@@ -155,7 +161,7 @@ func (gws *GatewayService) trackExternalTaskCost(task *types.TaskWithRelated, ex
 		return err
 	}
 
-	abstractions.TrackTaskCost(duration, stubWithRelated, stubConfig.Pricing, gws.usageMetricsRepo, task.ExternalId, externalWorkspace.ExternalId)
+	abstractions.TrackTaskCost(duration, stubWithRelated, stubConfig.Pricing, gws.usageMetricsRepo, task.ExternalId, task.Gpu, externalWorkspace.ExternalId)
 	return nil
 }
 
@@ -196,6 +202,21 @@ func (gws *GatewayService) ListTasks(ctx context.Context, in *pb.ListTasksReques
 		}, nil
 	}
 
+	taskIds := make([]uint, len(tasks))
+	for i, task := range tasks {
+		taskIds[i] = task.Id
+	}
+
+	// Attempt history is informational, so tasks are still listed without it
+	attempts := map[uint][]*pb.TaskAttempt{}
+	taskAttempts, err := gws.backendRepo.ListTaskAttempts(ctx, taskIds)
+	if err != nil {
+		log.Warn().Err(err).Msg("failed to retrieve task attempts")
+	}
+	for _, attempt := range taskAttempts {
+		attempts[attempt.TaskId] = append(attempts[attempt.TaskId], taskAttemptToProto(attempt))
+	}
+
 	response := &pb.ListTasksResponse{
 		Ok:    true,
 		Total: int32(len(tasks)),
@@ -214,6 +235,8 @@ func (gws *GatewayService) ListTasks(ctx context.Context, in *pb.ListTasksReques
 			StubName:      task.Stub.Name,
 			CreatedAt:     timestamppb.New(task.CreatedAt.Time),
 			UpdatedAt:     timestamppb.New(task.UpdatedAt.Time),
+			Attempts:      attempts[task.Id],
+			Gpu:           task.Gpu,
 		}
 	}
 
@@ -255,21 +278,134 @@ func (gws *GatewayService) stopTask(ctx context.Context, authInfo *auth.AuthInfo
 		return nil
 	}
 
+	if err := gws.signalTaskCancelled(ctx, task); err != nil {
+		return err
+	}
+
+	task.Status = types.TaskStatusCancelled
+	task.EndedAt = types.NullTime{}.Now()
+	if _, err := gws.backendRepo.UpdateTask(ctx, task.ExternalId, task.Task); err != nil {
+		return errors.New("failed to update task")
+	}
+
+	return nil
+}
+
+// signalTaskCancelled stops the dispatcher tracking a task and tells the container running it to stop
+func (gws *GatewayService) signalTaskCancelled(ctx context.Context, task *types.TaskWithRelated) error {
 	err := gws.taskDispatcher.Complete(ctx, task.Workspace.Name, task.Stub.ExternalId, task.ExternalId)
 	if err != nil {
 		return errors.New("failed to complete task")
 	}
 
-	err = gws.redisClient.Publish(ctx, common.RedisKeys.TaskCancel(authInfo.Workspace.Name, task.Stub.ExternalId, task.ExternalId), task.ExternalId).Err()
+	err = gws.redisClient.Publish(ctx, common.RedisKeys.TaskCancel(task.Workspace.Name, task.Stub.ExternalId, task.ExternalId), task.ExternalId).Err()
 	if err != nil {
 		return errors.New("failed to cancel task")
 	}
 
-	task.Status = types.TaskStatusCancelled
-	task.EndedAt = types.NullTime{}.Now()
-	if _, err := gws.backendRepo.UpdateTask(ctx, task.ExternalId, task.Task); err != nil {
-		return errors.New("failed to update task")
+	return nil
+}
+
+// bulkTaskFilter converts a bulk task filter into a repo filter scoped to the caller's workspace
+func bulkTaskFilter(authInfo *auth.AuthInfo, in *pb.BulkTaskFilter) (types.TaskFilter, error) {
+	if in == nil || (len(in.StubIds) == 0 && in.CreatedBefore == nil) {
+		return types.TaskFilter{}, errors.New("A stub ID or created before time is required")
 	}
 
-	return nil
+	limit := uint32(1000)
+	if in.Limit > 0 && in.Limit < limit {
+		limit = in.Limit
+	}
+
+	filters := types.TaskFilter{
+		WorkspaceID: authInfo.Workspace.Id,
+		StubIds:     in.StubIds,
+		Status:      strings.ToUpper(strings.Join(in.Statuses, ",")),
+	}
+	filters.Limit = limit
+
+	if in.CreatedBefore != nil {
+		filters.CreatedAtEnd = in.CreatedBefore.AsTime().Format(time.RFC3339Nano)
+	}
+
+	return filters, nil
+}
+
+func (gws *GatewayService) CancelTasks(ctx context.Context, in *pb.CancelTasksRequest) (*pb.CancelTasksResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionWrite) {
+		return &pb.CancelTasksResponse{Ok: false, ErrMsg: "Unauthorized Access"}, nil
+	}
+
+	filters, err := bulkTaskFilter(authInfo, in.Filter)
+	if err != nil {
+		return &pb.CancelTasksResponse{Ok: false, ErrMsg: err.Error()}, nil
+	}
+
+	tasks, err := gws.backendRepo.CancelTasks(ctx, filters)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to cancel tasks")
+		return &pb.CancelTasksResponse{Ok: false, ErrMsg: "Failed to cancel tasks."}, nil
+	}
+
+	response := &pb.CancelTasksResponse{
+		Ok:            true,
+		TaskIds:       make([]string, 0, len(tasks)),
+		FailedTaskIds: []string{},
+	}
+	for i := range tasks {
+		task := &tasks[i]
+		response.TaskIds = append(response.TaskIds, task.ExternalId)
+
+		if err := gws.signalTaskCancelled(ctx, task); err != nil {
+			log.Warn().Err(err).Str("task_id", task.ExternalId).Msg("failed to signal cancelled task")
+			response.FailedTaskIds = append(response.FailedTaskIds, task.ExternalId)
+		}
+	}
+
+	return response, nil
+}
+
+func (gws *GatewayService) RequeueTasks(ctx context.Context, in *pb.RequeueTasksRequest) (*pb.RequeueTasksResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionWrite) {
+		return &pb.RequeueTasksResponse{Ok: false, ErrMsg: "Unauthorized Access"}, nil
+	}
+
+	filters, err := bulkTaskFilter(authInfo, in.Filter)
+	if err != nil {
+		return &pb.RequeueTasksResponse{Ok: false, ErrMsg: err.Error()}, nil
+	}
+
+	tasks, err := gws.backendRepo.RequeueTasks(ctx, filters)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to requeue tasks")
+		return &pb.RequeueTasksResponse{Ok: false, ErrMsg: "Failed to requeue tasks."}, nil
+	}
+
+	response := &pb.RequeueTasksResponse{
+		Ok:            true,
+		TaskIds:       make([]string, 0, len(tasks)),
+		FailedTaskIds: []string{},
+	}
+	for _, task := range tasks {
+		err := gws.taskDispatcher.Requeue(ctx, task.Workspace.Name, task.Stub.ExternalId, task.ExternalId)
+		if err == nil {
+			response.TaskIds = append(response.TaskIds, task.ExternalId)
+			continue
+		}
+
+		log.Warn().Err(err).Str("task_id", task.ExternalId).Msg("failed to requeue task")
+		response.FailedTaskIds = append(response.FailedTaskIds, task.ExternalId)
+
+		if errors.Is(err, redis.Nil) {
+			task.Status = types.TaskStatusExpired
+			task.EndedAt = types.NullTime{}.Now()
+			gws.backendRepo.UpdateTask(ctx, task.ExternalId, task.Task)
+		}
+	}
+
+	return response, nil
 }
@@ -0,0 +1,199 @@
+package gatewayservices
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	errPolicyStubNotFound      = errors.New("Stub not found")
+	errPolicyStubAmbiguous     = errors.New("Specify either a stub or a deployment")
+	errTaskRetryNotSupported   = errors.New("Retry policies are not supported for endpoints")
+	taskRetryBackoffStrategies = []types.TaskBackoffStrategy{types.TaskBackoffNone, types.TaskBackoffFixed, types.TaskBackoffExponential}
+)
+
+// resolvePolicyStub returns the stub a retry, autoscaling or keep warm policy request refers to, either directly or through a deployment
+func (gws *GatewayService) resolvePolicyStub(ctx context.Context, authInfo *auth.AuthInfo, stubId, deploymentId string) (*types.Stub, error) {
+	if (stubId == "") == (deploymentId == "") {
+		return nil, errPolicyStubAmbiguous
+	}
+
+	if deploymentId != "" {
+		deployment, err := gws.backendRepo.GetDeploymentByExternalId(ctx, authInfo.Workspace.Id, deploymentId)
+		if err != nil || deployment == nil {
+			return nil, errPolicyStubNotFound
+		}
+
+		return &deployment.Stub, nil
+	}
+
+	stub, err := gws.backendRepo.GetStubByExternalId(ctx, stubId)
+	if err != nil || stub == nil || stub.WorkspaceId != authInfo.Workspace.Id {
+		return nil, errPolicyStubNotFound
+	}
+
+	return &stub.Stub, nil
+}
+
+func validateTaskRetryPolicy(policy *pb.TaskRetryPolicy) error {
+	if policy.MaxAttempts < 1 || policy.MaxAttempts > uint32(types.MaxTaskRetries+1) {
+		return fmt.Errorf("Max attempts must be between 1 and %d", types.MaxTaskRetries+1)
+	}
+
+	if !slices.Contains(taskRetryBackoffStrategies, types.TaskBackoffStrategy(policy.BackoffStrategy)) {
+		return fmt.Errorf("Backoff strategy must be one of none, fixed or exponential")
+	}
+
+	if uint(policy.BackoffSeconds) > types.MaxTaskRetryBackoffSeconds || uint(policy.MaxBackoffSeconds) > types.MaxTaskRetryBackoffSeconds {
+		return fmt.Errorf("Backoff must be %d seconds or less", types.MaxTaskRetryBackoffSeconds)
+	}
+
+	return nil
+}
+
+func taskRetryPolicyToProto(policy *types.TaskRetryPolicy) *pb.TaskRetryPolicy {
+	if policy == nil {
+		return nil
+	}
+
+	exitCodes := make([]int32, len(policy.RetryOnExitCodes))
+	for i, exitCode := range policy.RetryOnExitCodes {
+		exitCodes[i] = int32(exitCode)
+	}
+
+	return &pb.TaskRetryPolicy{
+		MaxAttempts:       uint32(policy.MaxAttempts),
+		BackoffStrategy:   string(policy.BackoffStrategy),
+		BackoffSeconds:    uint32(policy.BackoffSeconds),
+		MaxBackoffSeconds: uint32(policy.MaxBackoffSeconds),
+		RetryOnExitCodes:  exitCodes,
+	}
+}
+
+func taskAttemptToProto(attempt types.TaskAttempt) *pb.TaskAttempt {
+	pbAttempt := &pb.TaskAttempt{
+		Attempt:     uint32(attempt.Attempt),
+		ContainerId: attempt.ContainerId,
+		Reason:      string(attempt.Reason),
+		Retried:     attempt.Retried,
+		CreatedAt:   timestamppb.New(attempt.CreatedAt.Time),
+	}
+
+	if attempt.ExitCode != nil {
+		exitCode := int32(*attempt.ExitCode)
+		pbAttempt.ExitCode = &exitCode
+	}
+
+	return pbAttempt
+}
+
+func (gws *GatewayService) SetTaskRetryPolicy(ctx context.Context, in *pb.SetTaskRetryPolicyRequest) (*pb.SetTaskRetryPolicyResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionWrite) {
+		return &pb.SetTaskRetryPolicyResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	stub, err := gws.resolvePolicyStub(ctx, authInfo, in.StubId, in.DeploymentId)
+	if err != nil {
+		return &pb.SetTaskRetryPolicyResponse{
+			Ok:     false,
+			ErrMsg: err.Error(),
+		}, nil
+	}
+
+	// Endpoint requests are never retried, a client is waiting on the response
+	switch stub.Type.Kind() {
+	case types.StubTypeEndpoint, types.StubTypeASGI, types.StubTypeGRPC:
+		return &pb.SetTaskRetryPolicyResponse{
+			Ok:     false,
+			ErrMsg: errTaskRetryNotSupported.Error(),
+		}, nil
+	}
+
+	if in.Policy == nil {
+		if err := gws.backendRepo.DeleteTaskRetryPolicy(ctx, stub.Id); err != nil {
+			return &pb.SetTaskRetryPolicyResponse{
+				Ok:     false,
+				ErrMsg: "Unable to remove retry policy",
+			}, nil
+		}
+
+		return &pb.SetTaskRetryPolicyResponse{Ok: true}, nil
+	}
+
+	if err := validateTaskRetryPolicy(in.Policy); err != nil {
+		return &pb.SetTaskRetryPolicyResponse{
+			Ok:     false,
+			ErrMsg: err.Error(),
+		}, nil
+	}
+
+	exitCodes := make(types.TaskExitCodes, len(in.Policy.RetryOnExitCodes))
+	for i, exitCode := range in.Policy.RetryOnExitCodes {
+		exitCodes[i] = int(exitCode)
+	}
+
+	policy, err := gws.backendRepo.SetTaskRetryPolicy(ctx, types.TaskRetryPolicy{
+		StubId:            stub.Id,
+		MaxAttempts:       uint(in.Policy.MaxAttempts),
+		BackoffStrategy:   types.TaskBackoffStrategy(in.Policy.BackoffStrategy),
+		BackoffSeconds:    uint(in.Policy.BackoffSeconds),
+		MaxBackoffSeconds: uint(in.Policy.MaxBackoffSeconds),
+		RetryOnExitCodes:  exitCodes,
+	})
+	if err != nil {
+		return &pb.SetTaskRetryPolicyResponse{
+			Ok:     false,
+			ErrMsg: "Unable to set retry policy",
+		}, nil
+	}
+
+	return &pb.SetTaskRetryPolicyResponse{
+		Ok:     true,
+		Policy: taskRetryPolicyToProto(policy),
+	}, nil
+}
+
+func (gws *GatewayService) GetTaskRetryPolicy(ctx context.Context, in *pb.GetTaskRetryPolicyRequest) (*pb.GetTaskRetryPolicyResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionRead) {
+		return &pb.GetTaskRetryPolicyResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	stub, err := gws.resolvePolicyStub(ctx, authInfo, in.StubId, in.DeploymentId)
+	if err != nil {
+		return &pb.GetTaskRetryPolicyResponse{
+			Ok:     false,
+			ErrMsg: err.Error(),
+		}, nil
+	}
+
+	policy, err := gws.backendRepo.GetTaskRetryPolicy(ctx, stub.ExternalId)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return &pb.GetTaskRetryPolicyResponse{
+			Ok:     false,
+			ErrMsg: "Unable to get retry policy",
+		}, nil
+	}
+
+	return &pb.GetTaskRetryPolicyResponse{
+		Ok:     true,
+		Policy: taskRetryPolicyToProto(policy),
+	}, nil
+}
@@ -0,0 +1,246 @@
+package gatewayservices
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	abstractions "github.com/beam-cloud/beta9/pkg/abstractions/common"
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+)
+
+const (
+	defaultTaskTriggersPerWorkspace = 50
+	maxTaskTriggerNameLength        = 255
+	maxTaskTriggerSecretLength      = 255
+	auditActionTaskTriggerCreate    = "task_trigger.create"
+	auditActionTaskTriggerDelete    = "task_trigger.delete"
+	auditResourceTaskTrigger        = "task_trigger"
+)
+
+// taskTriggerStubTypes are the deployments triggers can run, by the stub kind clients name them with
+var taskTriggerStubTypes = map[string]string{
+	types.StubTypeFunction:  types.StubTypeFunctionDeployment,
+	types.StubTypeTaskQueue: types.StubTypeTaskQueueDeployment,
+}
+
+func (gws *GatewayService) CreateTaskTrigger(ctx context.Context, in *pb.CreateTaskTriggerRequest) (*pb.CreateTaskTriggerResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionWrite) {
+		return &pb.CreateTaskTriggerResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	config := gws.appConfig.GatewayService.TaskTriggers
+	if !config.Enabled {
+		return &pb.CreateTaskTriggerResponse{
+			Ok:       false,
+			ErrorMsg: "Task triggers are not enabled",
+		}, nil
+	}
+
+	trigger, err := gws.parseTaskTrigger(ctx, authInfo.Workspace, in)
+	if err != nil {
+		return &pb.CreateTaskTriggerResponse{
+			Ok:       false,
+			ErrorMsg: err.Error(),
+		}, nil
+	}
+
+	triggers, err := gws.backendRepo.ListTaskTriggers(ctx, authInfo.Workspace.Id)
+	if err != nil {
+		return &pb.CreateTaskTriggerResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to list triggers",
+		}, nil
+	}
+
+	maxTriggers := config.MaxPerWorkspace
+	if maxTriggers <= 0 {
+		maxTriggers = defaultTaskTriggersPerWorkspace
+	}
+
+	if len(triggers) >= maxTriggers {
+		return &pb.CreateTaskTriggerResponse{
+			Ok:       false,
+			ErrorMsg: fmt.Sprintf("A workspace can have at most %d triggers", maxTriggers),
+		}, nil
+	}
+
+	for _, t := range triggers {
+		if t.Name == trigger.Name {
+			return &pb.CreateTaskTriggerResponse{
+				Ok:       false,
+				ErrorMsg: "A trigger with this name already exists",
+			}, nil
+		}
+	}
+
+	created, err := gws.backendRepo.CreateTaskTrigger(ctx, authInfo.Workspace.Id, *trigger)
+	if err != nil {
+		log.Error().Err(err).Str("name", trigger.Name).Msg("failed to create task trigger")
+		gws.auditTaskTrigger(authInfo, auditActionTaskTriggerCreate, "", trigger.DeploymentName, err)
+		return &pb.CreateTaskTriggerResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to create trigger",
+		}, nil
+	}
+
+	gws.auditTaskTrigger(authInfo, auditActionTaskTriggerCreate, created.ExternalId, created.DeploymentName, nil)
+	return &pb.CreateTaskTriggerResponse{
+		Ok:      true,
+		Trigger: gws.taskTriggerToProto(created),
+		Secret:  created.Secret,
+	}, nil
+}
+
+func (gws *GatewayService) ListTaskTriggers(ctx context.Context, in *pb.ListTaskTriggersRequest) (*pb.ListTaskTriggersResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionRead) {
+		return &pb.ListTaskTriggersResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	triggers, err := gws.backendRepo.ListTaskTriggers(ctx, authInfo.Workspace.Id)
+	if err != nil {
+		return &pb.ListTaskTriggersResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to list triggers",
+		}, nil
+	}
+
+	result := make([]*pb.TaskTrigger, 0, len(triggers))
+	for i := range triggers {
+		result = append(result, gws.taskTriggerToProto(&triggers[i]))
+	}
+
+	return &pb.ListTaskTriggersResponse{
+		Ok:       true,
+		Triggers: result,
+	}, nil
+}
+
+func (gws *GatewayService) DeleteTaskTrigger(ctx context.Context, in *pb.DeleteTaskTriggerRequest) (*pb.DeleteTaskTriggerResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionWrite) {
+		return &pb.DeleteTaskTriggerResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	err := gws.backendRepo.DeleteTaskTrigger(ctx, authInfo.Workspace.Id, in.TriggerId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &pb.DeleteTaskTriggerResponse{
+			Ok:       false,
+			ErrorMsg: "Trigger not found",
+		}, nil
+	}
+
+	gws.auditTaskTrigger(authInfo, auditActionTaskTriggerDelete, in.TriggerId, "", err)
+	if err != nil {
+		return &pb.DeleteTaskTriggerResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to delete trigger",
+		}, nil
+	}
+
+	return &pb.DeleteTaskTriggerResponse{
+		Ok: true,
+	}, nil
+}
+
+// parseTaskTrigger validates a new trigger. The deployment has to exist when the trigger is created, but
+// deliveries run whichever version is the latest active one at the time.
+func (gws *GatewayService) parseTaskTrigger(ctx context.Context, workspace *types.Workspace, in *pb.CreateTaskTriggerRequest) (*types.TaskTrigger, error) {
+	if in.Name == "" || len(in.Name) > maxTaskTriggerNameLength {
+		return nil, fmt.Errorf("name is required and must be at most %d characters", maxTaskTriggerNameLength)
+	}
+
+	stubType, ok := taskTriggerStubTypes[in.StubType]
+	if !ok {
+		return nil, errors.New("Invalid stub type. Allowed types: function, taskqueue")
+	}
+
+	scheme := types.TaskTriggerSignatureScheme(in.SignatureScheme)
+	if scheme == "" {
+		scheme = types.TaskTriggerSignatureBeta9
+	}
+
+	if !scheme.IsValid() {
+		return nil, errors.New("Invalid signature scheme. Allowed schemes: beta9, github, stripe, hmac-sha256")
+	}
+
+	if len(in.Secret) > maxTaskTriggerSecretLength {
+		return nil, fmt.Errorf("secret must be at most %d characters", maxTaskTriggerSecretLength)
+	}
+
+	if in.MaxPayloadBytes < 0 {
+		return nil, errors.New("max_payload_bytes can't be negative")
+	}
+
+	if err := abstractions.ValidateTaskTriggerInputMapping(in.InputMapping); err != nil {
+		return nil, err
+	}
+
+	deployment, err := gws.backendRepo.GetLatestDeploymentByName(ctx, workspace.Id, in.DeploymentName, stubType, true)
+	if err != nil || deployment == nil {
+		return nil, errors.New("Deployment not found")
+	}
+
+	return &types.TaskTrigger{
+		Name:            in.Name,
+		DeploymentName:  in.DeploymentName,
+		StubType:        stubType,
+		SignatureScheme: scheme,
+		Secret:          in.Secret,
+		MaxPayloadBytes: in.MaxPayloadBytes,
+		InputMapping:    in.InputMapping,
+	}, nil
+}
+
+func (gws *GatewayService) taskTriggerToProto(trigger *types.TaskTrigger) *pb.TaskTrigger {
+	return &pb.TaskTrigger{
+		TriggerId:       trigger.ExternalId,
+		Name:            trigger.Name,
+		DeploymentName:  trigger.DeploymentName,
+		StubType:        types.StubType(trigger.StubType).Kind(),
+		SignatureScheme: string(trigger.SignatureScheme),
+		MaxPayloadBytes: trigger.MaxPayloadBytes,
+		InputMapping:    trigger.InputMapping,
+		Url:             common.BuildTaskTriggerURL(gws.appConfig.GatewayService.HTTP.GetExternalURL(), trigger),
+		CreatedAt:       timestamppb.New(trigger.CreatedAt.Time),
+	}
+}
+
+func (gws *GatewayService) auditTaskTrigger(authInfo *auth.AuthInfo, action, triggerId, deploymentName string, err error) {
+	event := common.AuditEvent{
+		Action:       action,
+		WorkspaceId:  authInfo.Workspace.ExternalId,
+		ResourceType: auditResourceTaskTrigger,
+		ResourceId:   triggerId,
+		Outcome:      auditOutcome(err),
+		Reason:       errorMessage(err),
+	}
+	if deploymentName != "" {
+		event.Attributes = map[string]interface{}{"deployment_name": deploymentName}
+	}
+	if authInfo.Token != nil {
+		event.Principal = authInfo.Token.ExternalId
+	}
+	gws.auditLogger.Log(event)
+}
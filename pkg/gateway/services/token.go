@@ -2,7 +2,9 @@ package gatewayservices
 
 import (
 	"context"
+	"time"
 
+	"github.com/rs/zerolog/log"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/beam-cloud/beta9/pkg/auth"
@@ -10,10 +12,15 @@ import (
 	pb "github.com/beam-cloud/beta9/proto"
 )
 
+const (
+	defaultTokenRotationGracePeriod = time.Hour
+	maxTokenRotationGracePeriod     = 7 * 24 * time.Hour
+)
+
 func (gws *GatewayService) ListTokens(ctx context.Context, req *pb.ListTokensRequest) (*pb.ListTokensResponse, error) {
 	authInfo, _ := auth.AuthInfoFromContext(ctx)
 
-	if !auth.HasPermission(authInfo) {
+	if !auth.HasPermission(authInfo, types.PermissionAdmin) {
 		return &pb.ListTokensResponse{
 			Tokens: []*pb.Token{},
 			Ok:     false,
@@ -49,6 +56,8 @@ func (gws *GatewayService) ListTokens(ctx context.Context, req *pb.ListTokensReq
 			TokenType:   token.TokenType,
 			CreatedAt:   timestamppb.New(token.CreatedAt.Time),
 			UpdatedAt:   &updatedAt,
+			Role:        string(token.WorkspaceRole()),
+			Scopes:      token.Scopes.Strings(),
 		})
 	}
 
@@ -61,7 +70,7 @@ func (gws *GatewayService) ListTokens(ctx context.Context, req *pb.ListTokensReq
 func (gws *GatewayService) CreateToken(ctx context.Context, req *pb.CreateTokenRequest) (*pb.CreateTokenResponse, error) {
 	authInfo, _ := auth.AuthInfoFromContext(ctx)
 
-	if !auth.HasPermission(authInfo) {
+	if !auth.HasPermission(authInfo, types.PermissionAdmin) {
 		return &pb.CreateTokenResponse{
 			Ok:     false,
 			ErrMsg: "Unauthorized Access",
@@ -82,6 +91,49 @@ func (gws *GatewayService) CreateToken(ctx context.Context, req *pb.CreateTokenR
 		}, nil
 	}
 
+	role := types.WorkspaceRole(req.Role)
+	if role == "" {
+		role = types.WorkspaceRoleAdmin
+	}
+
+	if !role.IsValid() {
+		return &pb.CreateTokenResponse{
+			Token:  &pb.Token{},
+			Ok:     false,
+			ErrMsg: "Invalid role. Allowed roles: admin, developer, read_only, deploy_only",
+		}, nil
+	}
+
+	scopes, err := types.ParseTokenScopes(req.Scopes)
+	if err != nil {
+		return &pb.CreateTokenResponse{
+			Token:  &pb.Token{},
+			Ok:     false,
+			ErrMsg: err.Error(),
+		}, nil
+	}
+
+	// Scoped tokens can only hand out scopes they hold themselves
+	if authInfo.Token.Scopes.Restricted() {
+		for _, scope := range scopes {
+			if !authInfo.Token.Scopes.Allows(scope) {
+				return &pb.CreateTokenResponse{
+					Token:  &pb.Token{},
+					Ok:     false,
+					ErrMsg: "Unable to grant scopes the requesting token doesn't have.",
+				}, nil
+			}
+		}
+
+		if !scopes.Restricted() {
+			return &pb.CreateTokenResponse{
+				Token:  &pb.Token{},
+				Ok:     false,
+				ErrMsg: "Scoped tokens can only create scoped tokens.",
+			}, nil
+		}
+	}
+
 	token, err := gws.backendRepo.CreateToken(ctx, authInfo.Workspace.Id, tokenType, true)
 	if err != nil {
 		return &pb.CreateTokenResponse{
@@ -91,6 +143,35 @@ func (gws *GatewayService) CreateToken(ctx context.Context, req *pb.CreateTokenR
 		}, nil
 	}
 
+	// Tokens are created as admins, so anything narrower is set afterwards
+	if role != types.WorkspaceRoleAdmin {
+		roleToken, err := gws.backendRepo.SetTokenRole(ctx, authInfo.Workspace.Id, token.ExternalId, role)
+		if err != nil {
+			gws.backendRepo.DeleteToken(ctx, authInfo.Workspace.Id, token.ExternalId)
+			return &pb.CreateTokenResponse{
+				Token:  &pb.Token{},
+				Ok:     false,
+				ErrMsg: "Unable to create token.",
+			}, nil
+		}
+
+		token = *roleToken
+	}
+
+	if scopes.Restricted() {
+		scopedToken, err := gws.backendRepo.SetTokenScopes(ctx, authInfo.Workspace.Id, token.ExternalId, scopes)
+		if err != nil {
+			gws.backendRepo.DeleteToken(ctx, authInfo.Workspace.Id, token.ExternalId)
+			return &pb.CreateTokenResponse{
+				Token:  &pb.Token{},
+				Ok:     false,
+				ErrMsg: "Unable to create token.",
+			}, nil
+		}
+
+		token = *scopedToken
+	}
+
 	updatedAt := *timestamppb.New(token.UpdatedAt.Time)
 	workspaceId := uint32(authInfo.Workspace.Id)
 
@@ -104,6 +185,8 @@ func (gws *GatewayService) CreateToken(ctx context.Context, req *pb.CreateTokenR
 			TokenType:   token.TokenType,
 			CreatedAt:   timestamppb.New(token.CreatedAt.Time),
 			UpdatedAt:   &updatedAt,
+			Role:        string(token.WorkspaceRole()),
+			Scopes:      token.Scopes.Strings(),
 		},
 		Ok: true,
 	}, nil
@@ -112,7 +195,7 @@ func (gws *GatewayService) CreateToken(ctx context.Context, req *pb.CreateTokenR
 func (gws *GatewayService) ToggleToken(ctx context.Context, req *pb.ToggleTokenRequest) (*pb.ToggleTokenResponse, error) {
 	authInfo, _ := auth.AuthInfoFromContext(ctx)
 
-	if !auth.HasPermission(authInfo) {
+	if !auth.HasPermission(authInfo, types.PermissionAdmin) {
 		return &pb.ToggleTokenResponse{
 			Ok:     false,
 			ErrMsg: "Unauthorized Access",
@@ -128,6 +211,14 @@ func (gws *GatewayService) ToggleToken(ctx context.Context, req *pb.ToggleTokenR
 		}, nil
 	}
 
+	if token.Active {
+		if err := gws.workspaceRepo.RestoreToken(token.Key); err != nil {
+			log.Error().Err(err).Str("token_id", token.ExternalId).Msg("failed to restore token")
+		}
+	} else {
+		gws.revokeToken(&token)
+	}
+
 	updatedAt := *timestamppb.New(token.UpdatedAt.Time)
 	workspaceId := uint32(authInfo.Workspace.Id)
 
@@ -141,6 +232,8 @@ func (gws *GatewayService) ToggleToken(ctx context.Context, req *pb.ToggleTokenR
 			TokenType:   token.TokenType,
 			CreatedAt:   timestamppb.New(token.CreatedAt.Time),
 			UpdatedAt:   &updatedAt,
+			Role:        string(token.WorkspaceRole()),
+			Scopes:      token.Scopes.Strings(),
 		},
 		Ok: true,
 	}, nil
@@ -149,13 +242,22 @@ func (gws *GatewayService) ToggleToken(ctx context.Context, req *pb.ToggleTokenR
 func (gws *GatewayService) DeleteToken(ctx context.Context, req *pb.DeleteTokenRequest) (*pb.DeleteTokenResponse, error) {
 	authInfo, _ := auth.AuthInfoFromContext(ctx)
 
-	if !auth.HasPermission(authInfo) {
+	if !auth.HasPermission(authInfo, types.PermissionAdmin) {
 		return &pb.DeleteTokenResponse{
 			Ok:     false,
 			ErrMsg: "Unauthorized Access",
 		}, nil
 	}
-	err := gws.backendRepo.DeleteToken(ctx, authInfo.Workspace.Id, req.TokenId)
+
+	token, err := gws.backendRepo.GetTokenByExternalId(ctx, authInfo.Workspace.Id, req.TokenId)
+	if err != nil {
+		return &pb.DeleteTokenResponse{
+			Ok:     false,
+			ErrMsg: "Unable to delete token.",
+		}, nil
+	}
+
+	err = gws.backendRepo.DeleteToken(ctx, authInfo.Workspace.Id, req.TokenId)
 	if err != nil {
 		return &pb.DeleteTokenResponse{
 			Ok:     false,
@@ -163,7 +265,140 @@ func (gws *GatewayService) DeleteToken(ctx context.Context, req *pb.DeleteTokenR
 		}, nil
 	}
 
+	gws.revokeToken(token)
+
 	return &pb.DeleteTokenResponse{
 		Ok: true,
 	}, nil
 }
+
+// SetTokenRateLimit overrides the gateway's default rate limit for a token in any workspace. Only cluster admins
+// can change limits, so workspaces can't lift their own.
+func (gws *GatewayService) SetTokenRateLimit(ctx context.Context, req *pb.SetTokenRateLimitRequest) (*pb.SetTokenRateLimitResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if authInfo.Token.TokenType != types.TokenTypeClusterAdmin {
+		return &pb.SetTokenRateLimitResponse{
+			Ok:     false,
+			ErrMsg: "This action is not permitted",
+		}, nil
+	}
+
+	var limit *types.RateLimit
+	if !req.Clear {
+		if req.RateLimit == nil || req.RateLimit.RequestsPerSecond < 0 {
+			return &pb.SetTokenRateLimitResponse{
+				Ok:     false,
+				ErrMsg: "Invalid rate limit.",
+			}, nil
+		}
+
+		limit = &types.RateLimit{
+			RequestsPerSecond:    req.RateLimit.RequestsPerSecond,
+			Burst:                int(req.RateLimit.Burst),
+			MaxConcurrentStreams: int(req.RateLimit.MaxConcurrentStreams),
+		}
+	}
+
+	token, err := gws.backendRepo.SetTokenRateLimit(ctx, req.TokenId, limit)
+	if err != nil {
+		return &pb.SetTokenRateLimitResponse{
+			Ok:     false,
+			ErrMsg: "Unable to set rate limit.",
+		}, nil
+	}
+
+	if token == nil {
+		return &pb.SetTokenRateLimitResponse{
+			Ok:     false,
+			ErrMsg: "Token not found.",
+		}, nil
+	}
+
+	// Drop the cached token so the new limit applies right away
+	gws.invalidateCachedToken(token)
+
+	resp := &pb.SetTokenRateLimitResponse{Ok: true}
+	if token.RateLimit != nil {
+		resp.RateLimit = &pb.RateLimit{
+			RequestsPerSecond:    token.RateLimit.RequestsPerSecond,
+			Burst:                uint32(token.RateLimit.Burst),
+			MaxConcurrentStreams: uint32(token.RateLimit.MaxConcurrentStreams),
+		}
+	}
+
+	return resp, nil
+}
+
+// RotateToken issues a replacement for a token. The old token keeps working for a grace period so clients can
+// switch over, and is then refused like any expired token.
+func (gws *GatewayService) RotateToken(ctx context.Context, req *pb.RotateTokenRequest) (*pb.RotateTokenResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionAdmin) {
+		return &pb.RotateTokenResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	gracePeriod := defaultTokenRotationGracePeriod
+	if req.GracePeriodSeconds > 0 {
+		gracePeriod = min(time.Duration(req.GracePeriodSeconds)*time.Second, maxTokenRotationGracePeriod)
+	}
+
+	token, err := gws.backendRepo.GetTokenByExternalId(ctx, authInfo.Workspace.Id, req.TokenId)
+	if err != nil {
+		return &pb.RotateTokenResponse{
+			Ok:     false,
+			ErrMsg: "Token not found.",
+		}, nil
+	}
+
+	switch token.TokenType {
+	case types.TokenTypeWorkspace, types.TokenTypeWorkspacePrimary, types.TokenTypeWorkspaceRestricted, types.TokenTypeServiceAccount:
+	default:
+		return &pb.RotateTokenResponse{
+			Ok:     false,
+			ErrMsg: "Tokens of this type can't be rotated.",
+		}, nil
+	}
+
+	previous, token, err := gws.backendRepo.RotateToken(ctx, authInfo.Workspace.Id, req.TokenId, time.Now().Add(gracePeriod))
+	if err != nil {
+		return &pb.RotateTokenResponse{
+			Ok:     false,
+			ErrMsg: "Unable to rotate token.",
+		}, nil
+	}
+
+	if previous == nil {
+		return &pb.RotateTokenResponse{
+			Ok:     false,
+			ErrMsg: "Only active tokens can be rotated.",
+		}, nil
+	}
+
+	// Drop the cached token so its new expiry is picked up
+	gws.invalidateCachedToken(previous)
+
+	updatedAt := *timestamppb.New(token.UpdatedAt.Time)
+	workspaceId := uint32(authInfo.Workspace.Id)
+
+	return &pb.RotateTokenResponse{
+		Ok: true,
+		Token: &pb.Token{
+			TokenId:     token.ExternalId,
+			Key:         token.Key,
+			Active:      token.Active,
+			Reusable:    token.Reusable,
+			WorkspaceId: &workspaceId,
+			TokenType:   token.TokenType,
+			CreatedAt:   timestamppb.New(token.CreatedAt.Time),
+			UpdatedAt:   &updatedAt,
+			Role:        string(token.WorkspaceRole()),
+			Scopes:      token.Scopes.Strings(),
+		},
+		PreviousTokenExpiresAt: timestamppb.New(previous.ExpiresAt.Time),
+	}, nil
+}
@@ -0,0 +1,72 @@
+package gatewayservices
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/beam-cloud/beta9/pkg/metrics"
+)
+
+var ErrUploadLimitReached = errors.New("upload concurrency limit reached")
+
+// uploadLimiter bounds the number of object uploads a single gateway handles at once,
+// so a burst of large uploads can't overwhelm the storage backend with open files and fsyncs
+type uploadLimiter struct {
+	slots    chan struct{}
+	timeout  time.Duration
+	inFlight atomic.Int64
+	queued   atomic.Int64
+}
+
+func newUploadLimiter(maxConcurrent int, timeout time.Duration) *uploadLimiter {
+	l := &uploadLimiter{timeout: timeout}
+	if maxConcurrent > 0 {
+		l.slots = make(chan struct{}, maxConcurrent)
+	}
+
+	return l
+}
+
+// Acquire blocks until an upload slot is free. It fails with ErrUploadLimitReached once the
+// queue timeout elapses, or with the context error if the caller goes away first.
+// The returned func must be called to release the slot.
+func (l *uploadLimiter) Acquire(ctx context.Context) (func(), error) {
+	if l.slots == nil {
+		return func() {}, nil
+	}
+
+	metrics.SetObjectUploadsQueued(l.queued.Add(1))
+
+	var timeoutCh <-chan time.Time
+	if l.timeout > 0 {
+		timer := time.NewTimer(l.timeout)
+		defer timer.Stop()
+		timeoutCh = timer.C
+	}
+
+	var err error
+	select {
+	case l.slots <- struct{}{}:
+	case <-timeoutCh:
+		err = ErrUploadLimitReached
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	metrics.SetObjectUploadsQueued(l.queued.Add(-1))
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.SetObjectUploadsInFlight(l.inFlight.Add(1))
+
+	var once atomic.Bool
+	return func() {
+		if once.CompareAndSwap(false, true) {
+			<-l.slots
+			metrics.SetObjectUploadsInFlight(l.inFlight.Add(-1))
+		}
+	}, nil
+}
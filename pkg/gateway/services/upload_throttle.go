@@ -0,0 +1,175 @@
+package gatewayservices
+
+import (
+	"context"
+	"sync"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/time/rate"
+)
+
+const (
+	auditActionWorkspaceSetUploadLimit = "workspace.set_upload_bandwidth_limit"
+	auditResourceWorkspace             = "workspace"
+)
+
+// uploadThrottle limits the rate a single gateway receives object content at, both overall and per
+// workspace, so one tenant's large uploads can't saturate the gateway's disk or network. Each bucket
+// holds a second's worth of bytes.
+type uploadThrottle struct {
+	global           *rate.Limiter
+	defaultWorkspace int64
+	mu               sync.Mutex
+	workspaces       map[uint]*rate.Limiter
+}
+
+func newUploadThrottle(globalBytesPerSecond, workspaceBytesPerSecond int64) *uploadThrottle {
+	t := &uploadThrottle{
+		defaultWorkspace: workspaceBytesPerSecond,
+		workspaces:       make(map[uint]*rate.Limiter),
+	}
+
+	if globalBytesPerSecond > 0 {
+		t.global = newBandwidthLimiter(globalBytesPerSecond)
+	}
+
+	return t
+}
+
+func newBandwidthLimiter(bytesPerSecond int64) *rate.Limiter {
+	return rate.NewLimiter(rate.Limit(bytesPerSecond), int(bytesPerSecond))
+}
+
+// workspaceUploadThrottle is the throttle applied to a single upload
+type workspaceUploadThrottle struct {
+	global    *rate.Limiter
+	workspace *rate.Limiter
+}
+
+// ForWorkspace returns the throttle for an upload into a workspace. The override comes from the workspace
+// record and replaces the gateway default; nil uses the default and 0 disables the workspace limit.
+func (t *uploadThrottle) ForWorkspace(workspaceId uint, override *int64) *workspaceUploadThrottle {
+	bytesPerSecond := t.defaultWorkspace
+	if override != nil {
+		bytesPerSecond = *override
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if bytesPerSecond <= 0 {
+		delete(t.workspaces, workspaceId)
+		return &workspaceUploadThrottle{global: t.global}
+	}
+
+	// Uploads already in progress share the workspace's bucket, so a changed limit applies to them too
+	limiter, ok := t.workspaces[workspaceId]
+	if !ok {
+		limiter = newBandwidthLimiter(bytesPerSecond)
+		t.workspaces[workspaceId] = limiter
+	} else if int64(limiter.Limit()) != bytesPerSecond {
+		limiter.SetLimit(rate.Limit(bytesPerSecond))
+		limiter.SetBurst(int(bytesPerSecond))
+	}
+
+	return &workspaceUploadThrottle{global: t.global, workspace: limiter}
+}
+
+// Wait blocks until n more bytes may be received, or the context is done
+func (w *workspaceUploadThrottle) Wait(ctx context.Context, n int) error {
+	if err := waitBandwidth(ctx, w.workspace, n); err != nil {
+		return err
+	}
+
+	return waitBandwidth(ctx, w.global, n)
+}
+
+// waitBandwidth takes n tokens from the limiter, a burst at a time since chunks can be larger than a
+// second's worth of bytes
+func waitBandwidth(ctx context.Context, limiter *rate.Limiter, n int) error {
+	if limiter == nil {
+		return nil
+	}
+
+	for n > 0 {
+		take := min(n, limiter.Burst())
+		if err := limiter.WaitN(ctx, take); err != nil {
+			return err
+		}
+
+		n -= take
+	}
+
+	return nil
+}
+
+// workspaceUploadThrottle returns the throttle for an upload into the workspace, reading the workspace's
+// limit fresh so changes made through any gateway apply to the next upload
+func (gws *GatewayService) workspaceUploadThrottle(ctx context.Context, workspace *types.Workspace) *workspaceUploadThrottle {
+	override, err := gws.backendRepo.GetWorkspaceUploadBandwidthLimit(ctx, workspace.Id)
+	if err != nil {
+		log.Warn().Err(err).Str("workspace_id", workspace.ExternalId).Msg("unable to get workspace upload bandwidth limit, using default")
+		override = nil
+	}
+
+	return gws.uploadThrottle.ForWorkspace(workspace.Id, override)
+}
+
+// SetUploadBandwidthLimit changes the rate a workspace's uploads are received at. Only cluster admins may
+// change it, since the limit exists to protect other tenants.
+func (gws *GatewayService) SetUploadBandwidthLimit(ctx context.Context, in *pb.SetUploadBandwidthLimitRequest) (*pb.SetUploadBandwidthLimitResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if _, err := isClusterAdmin(ctx); err != nil {
+		return &pb.SetUploadBandwidthLimitResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	if in.BytesPerSecond != nil && *in.BytesPerSecond < 0 {
+		return &pb.SetUploadBandwidthLimitResponse{
+			Ok:       false,
+			ErrorMsg: "Limit must not be negative",
+		}, nil
+	}
+
+	workspace, err := gws.backendRepo.GetWorkspaceByExternalId(ctx, in.WorkspaceId)
+	if err != nil {
+		return &pb.SetUploadBandwidthLimitResponse{
+			Ok:       false,
+			ErrorMsg: "Workspace not found",
+		}, nil
+	}
+
+	err = gws.backendRepo.SetWorkspaceUploadBandwidthLimit(ctx, workspace.Id, in.BytesPerSecond)
+
+	event := common.AuditEvent{
+		Action:       auditActionWorkspaceSetUploadLimit,
+		WorkspaceId:  in.WorkspaceId,
+		ResourceType: auditResourceWorkspace,
+		ResourceId:   in.WorkspaceId,
+		Outcome:      auditOutcome(err),
+		Reason:       errorMessage(err),
+		Attributes: map[string]interface{}{
+			"bytes_per_second": in.BytesPerSecond,
+		},
+	}
+	if authInfo.Token != nil {
+		event.Principal = authInfo.Token.ExternalId
+	}
+	gws.auditLogger.Log(event)
+
+	if err != nil {
+		return &pb.SetUploadBandwidthLimitResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to set upload bandwidth limit",
+		}, nil
+	}
+
+	return &pb.SetUploadBandwidthLimitResponse{Ok: true}, nil
+}
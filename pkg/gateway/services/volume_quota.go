@@ -0,0 +1,66 @@
+package gatewayservices
+
+import (
+	"context"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/common"
+	pb "github.com/beam-cloud/beta9/proto"
+)
+
+const auditActionWorkspaceSetVolumeQuota = "workspace.set_volume_quota"
+
+// SetWorkspaceVolumeQuota changes how much a workspace may store across all of its volumes. Only cluster
+// admins may change it, since it reflects the workspace's storage plan.
+func (gws *GatewayService) SetWorkspaceVolumeQuota(ctx context.Context, in *pb.SetWorkspaceVolumeQuotaRequest) (*pb.SetWorkspaceVolumeQuotaResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if _, err := isClusterAdmin(ctx); err != nil {
+		return &pb.SetWorkspaceVolumeQuotaResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	if in.QuotaBytes != nil && *in.QuotaBytes < 0 {
+		return &pb.SetWorkspaceVolumeQuotaResponse{
+			Ok:       false,
+			ErrorMsg: "Quota must not be negative",
+		}, nil
+	}
+
+	workspace, err := gws.backendRepo.GetWorkspaceByExternalId(ctx, in.WorkspaceId)
+	if err != nil {
+		return &pb.SetWorkspaceVolumeQuotaResponse{
+			Ok:       false,
+			ErrorMsg: "Workspace not found",
+		}, nil
+	}
+
+	err = gws.backendRepo.SetWorkspaceVolumeQuota(ctx, workspace.Id, in.QuotaBytes)
+
+	event := common.AuditEvent{
+		Action:       auditActionWorkspaceSetVolumeQuota,
+		WorkspaceId:  in.WorkspaceId,
+		ResourceType: auditResourceWorkspace,
+		ResourceId:   in.WorkspaceId,
+		Outcome:      auditOutcome(err),
+		Reason:       errorMessage(err),
+		Attributes: map[string]interface{}{
+			"quota_bytes": in.QuotaBytes,
+		},
+	}
+	if authInfo.Token != nil {
+		event.Principal = authInfo.Token.ExternalId
+	}
+	gws.auditLogger.Log(event)
+
+	if err != nil {
+		return &pb.SetWorkspaceVolumeQuotaResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to set volume quota",
+		}, nil
+	}
+
+	return &pb.SetWorkspaceVolumeQuotaResponse{Ok: true}, nil
+}
@@ -16,7 +16,7 @@ import (
 func (gws *GatewayService) ListWorkers(ctx context.Context, in *pb.ListWorkersRequest) (*pb.ListWorkersResponse, error) {
 	authInfo, _ := auth.AuthInfoFromContext(ctx)
 
-	if !auth.HasPermission(authInfo) {
+	if !auth.HasPermission(authInfo, types.PermissionAdmin) {
 		return &pb.ListWorkersResponse{
 			Ok:     false,
 			ErrMsg: "Unauthorized Access",
@@ -43,19 +43,24 @@ func (gws *GatewayService) ListWorkers(ctx context.Context, in *pb.ListWorkersRe
 	pbWorkers := make([]*pb.Worker, len(workers))
 	for i, w := range workers {
 		pbWorkers[i] = &pb.Worker{
-			Id:            w.Id,
-			Status:        string(w.Status),
-			Gpu:           w.Gpu,
-			PoolName:      w.PoolName,
-			MachineId:     w.MachineId,
-			Priority:      w.Priority,
-			TotalCpu:      w.TotalCpu,
-			TotalMemory:   w.TotalMemory,
-			TotalGpuCount: w.TotalGpuCount,
-			FreeCpu:       w.FreeCpu,
-			FreeMemory:    w.FreeMemory,
-			FreeGpuCount:  w.FreeGpuCount,
-			BuildVersion:  w.BuildVersion,
+			Id:              w.Id,
+			Status:          string(w.Status),
+			Gpu:             w.Gpu,
+			PoolName:        w.PoolName,
+			MachineId:       w.MachineId,
+			Priority:        w.Priority,
+			TotalCpu:        w.TotalCpu,
+			TotalMemory:     w.TotalMemory,
+			TotalGpuCount:   w.TotalGpuCount,
+			FreeCpu:         w.FreeCpu,
+			FreeMemory:      w.FreeMemory,
+			FreeGpuCount:    w.FreeGpuCount,
+			BuildVersion:    w.BuildVersion,
+			Labels:          w.Labels.Map(),
+			UnhealthyReason: w.UnhealthyReason,
+			Arch:            w.WorkerArch(),
+			TotalMigSlices:  string(w.TotalMigSlices),
+			FreeMigSlices:   string(w.FreeMigSlices),
 		}
 
 		containers, err := gws.containerRepo.GetActiveContainersByWorkerId(w.Id)
@@ -113,7 +118,7 @@ func sortWorkers(w []*types.Worker) {
 func (gws *GatewayService) CordonWorker(ctx context.Context, in *pb.CordonWorkerRequest) (*pb.CordonWorkerResponse, error) {
 	authInfo, _ := auth.AuthInfoFromContext(ctx)
 
-	if !auth.HasPermission(authInfo) {
+	if !auth.HasPermission(authInfo, types.PermissionAdmin) {
 		return &pb.CordonWorkerResponse{
 			Ok:     false,
 			ErrMsg: "Unauthorized Access",
@@ -150,7 +155,7 @@ func (gws *GatewayService) CordonWorker(ctx context.Context, in *pb.CordonWorker
 func (gws *GatewayService) UncordonWorker(ctx context.Context, in *pb.UncordonWorkerRequest) (*pb.UncordonWorkerResponse, error) {
 	authInfo, _ := auth.AuthInfoFromContext(ctx)
 
-	if !auth.HasPermission(authInfo) {
+	if !auth.HasPermission(authInfo, types.PermissionAdmin) {
 		return &pb.UncordonWorkerResponse{
 			Ok:     false,
 			ErrMsg: "Unauthorized Access",
@@ -184,10 +189,13 @@ func (gws *GatewayService) UncordonWorker(ctx context.Context, in *pb.UncordonWo
 	}, nil
 }
 
+// DrainWorker cordons a worker and stops its containers, or waits for them to exit when wait is set. Stopped
+// deployment containers are rescheduled on other workers. Once no containers are left the worker is marked
+// drained and ready for termination, so callers repeat the request until it reports that.
 func (gws *GatewayService) DrainWorker(ctx context.Context, in *pb.DrainWorkerRequest) (*pb.DrainWorkerResponse, error) {
 	authInfo, _ := auth.AuthInfoFromContext(ctx)
 
-	if !auth.HasPermission(authInfo) {
+	if !auth.HasPermission(authInfo, types.PermissionAdmin) {
 		return &pb.DrainWorkerResponse{
 			Ok:     false,
 			ErrMsg: "Unauthorized Access",
@@ -209,6 +217,15 @@ func (gws *GatewayService) DrainWorker(ctx context.Context, in *pb.DrainWorkerRe
 		}, nil
 	}
 
+	if !worker.Cordoned() {
+		if err := gws.workerRepo.UpdateWorkerStatus(worker.Id, types.WorkerStatusDisabled); err != nil {
+			return &pb.DrainWorkerResponse{
+				Ok:     false,
+				ErrMsg: err.Error(),
+			}, nil
+		}
+	}
+
 	containers, err := gws.containerRepo.GetActiveContainersByWorkerId(worker.Id)
 	if err != nil {
 		return &pb.DrainWorkerResponse{
@@ -217,21 +234,104 @@ func (gws *GatewayService) DrainWorker(ctx context.Context, in *pb.DrainWorkerRe
 		}, err
 	}
 
-	var group errgroup.Group
-	for _, container := range containers {
-		group.Go(func() error {
-			return gws.scheduler.Stop(&types.StopContainerArgs{ContainerId: container.ContainerId, Reason: types.StopContainerReasonAdmin})
-		})
-	}
-	if err := group.Wait(); err != nil {
+	if len(containers) == 0 {
+		if err := gws.workerRepo.UpdateWorkerStatus(worker.Id, types.WorkerStatusDrained); err != nil {
+			return &pb.DrainWorkerResponse{
+				Ok:     false,
+				ErrMsg: err.Error(),
+			}, nil
+		}
+
 		return &pb.DrainWorkerResponse{
+			Ok:                  true,
+			ReadyForTermination: true,
+		}, nil
+	}
+
+	if !in.Wait {
+		var group errgroup.Group
+		for _, container := range containers {
+			if container.Status == types.ContainerStatusStopping {
+				continue
+			}
+
+			group.Go(func() error {
+				return gws.scheduler.Stop(&types.StopContainerArgs{ContainerId: container.ContainerId, Reason: types.StopContainerReasonAdmin})
+			})
+		}
+		if err := group.Wait(); err != nil {
+			return &pb.DrainWorkerResponse{
+				Ok:     false,
+				ErrMsg: err.Error(),
+			}, nil
+		}
+	}
+
+	return &pb.DrainWorkerResponse{
+		Ok:                  true,
+		RemainingContainers: int32(len(containers)),
+	}, nil
+}
+
+// SetWorkerLabels changes the labels stubs' affinity rules match workers by. Labels are merged into the worker's
+// current ones unless replace is set, and keys in remove are dropped.
+func (gws *GatewayService) SetWorkerLabels(ctx context.Context, in *pb.SetWorkerLabelsRequest) (*pb.SetWorkerLabelsResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if !auth.HasPermission(authInfo, types.PermissionAdmin) {
+		return &pb.SetWorkerLabelsResponse{
+			Ok:     false,
+			ErrMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	if _, err := isClusterAdmin(ctx); err != nil {
+		return &pb.SetWorkerLabelsResponse{
 			Ok:     false,
 			ErrMsg: err.Error(),
 		}, nil
 	}
 
-	return &pb.DrainWorkerResponse{
-		Ok: true,
+	for key, value := range in.Labels {
+		if err := types.ValidateWorkerLabel(key, value); err != nil {
+			return &pb.SetWorkerLabelsResponse{
+				Ok:     false,
+				ErrMsg: err.Error(),
+			}, nil
+		}
+	}
+
+	worker, err := gws.workerRepo.GetWorkerById(in.WorkerId)
+	if err != nil {
+		return &pb.SetWorkerLabelsResponse{
+			Ok:     false,
+			ErrMsg: err.Error(),
+		}, nil
+	}
+
+	labels := worker.Labels.Map()
+	if in.Replace {
+		labels = map[string]string{}
+	}
+
+	for key, value := range in.Labels {
+		labels[key] = value
+	}
+
+	for _, key := range in.Remove {
+		delete(labels, key)
+	}
+
+	if err := gws.workerRepo.SetWorkerLabels(worker.Id, types.NewWorkerLabels(labels)); err != nil {
+		return &pb.SetWorkerLabelsResponse{
+			Ok:     false,
+			ErrMsg: err.Error(),
+		}, nil
+	}
+
+	return &pb.SetWorkerLabelsResponse{
+		Ok:     true,
+		Labels: labels,
 	}, nil
 }
 
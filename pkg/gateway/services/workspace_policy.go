@@ -0,0 +1,167 @@
+package gatewayservices
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/beam-cloud/beta9/pkg/auth"
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/repository"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+)
+
+const auditActionWorkspaceSetPolicy = "workspace.set_policy"
+
+// SetWorkspacePolicy replaces the resource limits of a workspace's stubs. Only cluster admins may change them,
+// since they cap what the tenant can consume. The cached policy is replaced too, so new stubs and tasks are
+// checked against it right away.
+func (gws *GatewayService) SetWorkspacePolicy(ctx context.Context, in *pb.SetWorkspacePolicyRequest) (*pb.SetWorkspacePolicyResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	if _, err := isClusterAdmin(ctx); err != nil {
+		return &pb.SetWorkspacePolicyResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	policy, err := parseWorkspacePolicy(in)
+	if err != nil {
+		return &pb.SetWorkspacePolicyResponse{
+			Ok:       false,
+			ErrorMsg: err.Error(),
+		}, nil
+	}
+
+	workspace, err := gws.backendRepo.GetWorkspaceByExternalId(ctx, in.WorkspaceId)
+	if err != nil {
+		return &pb.SetWorkspacePolicyResponse{
+			Ok:       false,
+			ErrorMsg: "Workspace not found",
+		}, nil
+	}
+
+	updated, err := gws.backendRepo.SetWorkspacePolicy(ctx, workspace.Id, *policy)
+
+	event := common.AuditEvent{
+		Action:       auditActionWorkspaceSetPolicy,
+		WorkspaceId:  in.WorkspaceId,
+		ResourceType: auditResourceWorkspace,
+		ResourceId:   in.WorkspaceId,
+		Outcome:      auditOutcome(err),
+		Reason:       errorMessage(err),
+		Attributes: map[string]interface{}{
+			"max_concurrent_gpus":      in.MaxConcurrentGpus,
+			"max_container_memory":     in.MaxContainerMemory,
+			"allowed_gpu_types":        in.AllowedGpuTypes,
+			"max_run_duration_seconds": in.MaxRunDurationSeconds,
+		},
+	}
+	if authInfo.Token != nil {
+		event.Principal = authInfo.Token.ExternalId
+	}
+	gws.auditLogger.Log(event)
+
+	if err != nil {
+		return &pb.SetWorkspacePolicyResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to set workspace policy",
+		}, nil
+	}
+
+	if err := gws.workspaceRepo.SetWorkspacePolicyByWorkspaceId(workspace.ExternalId, updated); err != nil {
+		log.Warn().Err(err).Str("workspace_id", workspace.ExternalId).Msg("failed to cache workspace policy")
+	}
+
+	return &pb.SetWorkspacePolicyResponse{
+		Ok:     true,
+		Policy: workspacePolicyToProto(workspace.ExternalId, updated),
+	}, nil
+}
+
+// GetWorkspacePolicy returns the resource limits of the caller's workspace. Cluster admins can name any workspace.
+func (gws *GatewayService) GetWorkspacePolicy(ctx context.Context, in *pb.GetWorkspacePolicyRequest) (*pb.GetWorkspacePolicyResponse, error) {
+	authInfo, _ := auth.AuthInfoFromContext(ctx)
+
+	workspaceId := authInfo.Workspace.ExternalId
+	if in.WorkspaceId != "" && in.WorkspaceId != workspaceId {
+		if _, err := isClusterAdmin(ctx); err != nil {
+			return &pb.GetWorkspacePolicyResponse{
+				Ok:       false,
+				ErrorMsg: "Unauthorized Access",
+			}, nil
+		}
+
+		workspaceId = in.WorkspaceId
+	} else if !auth.HasPermission(authInfo, types.PermissionRead) {
+		return &pb.GetWorkspacePolicyResponse{
+			Ok:       false,
+			ErrorMsg: "Unauthorized Access",
+		}, nil
+	}
+
+	policy, err := repository.LoadWorkspacePolicy(ctx, gws.workspaceRepo, gws.backendRepo, workspaceId)
+	if err != nil {
+		return &pb.GetWorkspacePolicyResponse{
+			Ok:       false,
+			ErrorMsg: "Unable to get workspace policy",
+		}, nil
+	}
+
+	return &pb.GetWorkspacePolicyResponse{
+		Ok:     true,
+		Policy: workspacePolicyToProto(workspaceId, policy),
+	}, nil
+}
+
+func parseWorkspacePolicy(in *pb.SetWorkspacePolicyRequest) (*types.WorkspacePolicy, error) {
+	if in.MaxContainerMemory < 0 {
+		return nil, fmt.Errorf("max_container_memory can't be negative")
+	}
+
+	if in.MaxRunDurationSeconds < 0 {
+		return nil, fmt.Errorf("max_run_duration_seconds can't be negative")
+	}
+
+	policy := &types.WorkspacePolicy{
+		MaxConcurrentGpus:     in.MaxConcurrentGpus,
+		MaxContainerMemory:    in.MaxContainerMemory,
+		MaxRunDurationSeconds: in.MaxRunDurationSeconds,
+	}
+
+	knownGpuTypes := types.AllGPUTypes()
+	for _, gpu := range in.AllowedGpuTypes {
+		gpuType := types.GpuType(strings.TrimSpace(gpu))
+		if !slices.Contains(knownGpuTypes, gpuType) {
+			return nil, fmt.Errorf("Invalid GPU type %s. Allowed types: %s", gpu, strings.Join(types.GpuTypesToStrings(knownGpuTypes), ", "))
+		}
+
+		if !slices.Contains(policy.AllowedGpuTypes, gpuType) {
+			policy.AllowedGpuTypes = append(policy.AllowedGpuTypes, gpuType)
+		}
+	}
+
+	return policy, nil
+}
+
+func workspacePolicyToProto(workspaceId string, policy *types.WorkspacePolicy) *pb.WorkspacePolicy {
+	result := &pb.WorkspacePolicy{
+		WorkspaceId:           workspaceId,
+		MaxConcurrentGpus:     policy.MaxConcurrentGpus,
+		MaxContainerMemory:    policy.MaxContainerMemory,
+		AllowedGpuTypes:       types.GpuTypesToStrings(policy.AllowedGpuTypes),
+		MaxRunDurationSeconds: policy.MaxRunDurationSeconds,
+	}
+
+	if !policy.UpdatedAt.IsZero() {
+		result.UpdatedAt = timestamppb.New(policy.UpdatedAt.Time)
+	}
+
+	return result
+}
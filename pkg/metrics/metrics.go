@@ -38,6 +38,11 @@ const (
 	metricS3GetSpeed                = "s3_get_speed_mbps"
 	metricDialTime                  = "dial_time_ms"
 	metricContainerStartLatency     = "container_start_latency_ms"
+	metricObjectSizeDiscrepancies   = "gateway_object_size_discrepancies"
+	metricObjectUploadsInFlight     = "gateway_object_uploads_in_flight"
+	metricObjectUploadsQueued       = "gateway_object_uploads_queued"
+	metricObjectIntegrityChecks     = "gateway_object_integrity_checks"
+	metricObjectsQuarantined        = "gateway_objects_quarantined"
 )
 
 func InitializeMetricsRepository(config types.VictoriaMetricsConfig) {
@@ -199,3 +204,27 @@ func RecordContainerStartLatency(container *types.ContainerState, duration time.
 	)
 	vmetrics.GetDefaultSet().GetOrCreateHistogram(metricName).Update(float64(duration.Milliseconds()))
 }
+
+func RecordObjectSizeDiscrepancy(workspaceName string, repaired bool) {
+	metricName := fmt.Sprintf("%s{workspace=\"%s\",repaired=\"%t\"}", metricObjectSizeDiscrepancies, workspaceName, repaired)
+	vmetrics.GetDefaultSet().GetOrCreateCounter(metricName).Inc()
+}
+
+// RecordObjectIntegrityCheck counts an audited object by its outcome: "ok", "corrupted" or "error"
+func RecordObjectIntegrityCheck(workspaceName string, result string) {
+	metricName := fmt.Sprintf("%s{workspace=\"%s\",result=\"%s\"}", metricObjectIntegrityChecks, workspaceName, result)
+	vmetrics.GetDefaultSet().GetOrCreateCounter(metricName).Inc()
+}
+
+func RecordObjectQuarantined(workspaceName string) {
+	metricName := fmt.Sprintf("%s{workspace=\"%s\"}", metricObjectsQuarantined, workspaceName)
+	vmetrics.GetDefaultSet().GetOrCreateCounter(metricName).Inc()
+}
+
+func SetObjectUploadsInFlight(count int64) {
+	vmetrics.GetDefaultSet().GetOrCreateGauge(metricObjectUploadsInFlight, nil).Set(float64(count))
+}
+
+func SetObjectUploadsQueued(count int64) {
+	vmetrics.GetDefaultSet().GetOrCreateGauge(metricObjectUploadsQueued, nil).Set(float64(count))
+}
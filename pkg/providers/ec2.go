@@ -124,6 +124,16 @@ func (p *EC2Provider) ProvisionMachine(ctx context.Context, poolName, token stri
 		SubnetId:     p.providerConfig.SubnetId,
 	}
 
+	if compute.Spot {
+		input.InstanceMarketOptions = &awsTypes.InstanceMarketOptionsRequest{
+			MarketType: awsTypes.MarketTypeSpot,
+			SpotOptions: &awsTypes.SpotMarketOptions{
+				SpotInstanceType:             awsTypes.SpotInstanceTypeOneTime,
+				InstanceInterruptionBehavior: awsTypes.InstanceInterruptionBehaviorTerminate,
+			},
+		}
+	}
+
 	result, err := p.client.RunInstances(ctx, input)
 	if err != nil {
 		return "", err
@@ -0,0 +1,186 @@
+package providers
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/network"
+	"github.com/beam-cloud/beta9/pkg/repository"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+)
+
+const defaultPluginTimeout time.Duration = 30 * time.Second
+
+// PluginProvider creates machines through a server implementing the ProviderPlugin service, so providers can be
+// added without changes to beta9. The contract plugins implement is documented in plugin.proto.
+type PluginProvider struct {
+	*ExternalProvider
+	client       pb.ProviderPluginClient
+	pluginConfig types.ProviderPluginConfig
+}
+
+func NewPluginProvider(ctx context.Context, name string, appConfig types.AppConfig, providerRepo repository.ProviderRepository, workerRepo repository.WorkerRepository, tailscale *network.Tailscale) (*PluginProvider, error) {
+	pluginConfig, ok := appConfig.Providers.Plugins[name]
+	if !ok {
+		return nil, fmt.Errorf("no provider plugin named %s", name)
+	}
+
+	if pluginConfig.Address == "" {
+		return nil, fmt.Errorf("provider plugin %s has no address", name)
+	}
+
+	if pluginConfig.Timeout <= 0 {
+		pluginConfig.Timeout = defaultPluginTimeout
+	}
+
+	creds := insecure.NewCredentials()
+	if pluginConfig.TLS {
+		creds = credentials.NewTLS(&tls.Config{})
+	}
+
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	if pluginConfig.Token != "" {
+		opts = append(opts, grpc.WithUnaryInterceptor(common.GRPCClientAuthInterceptor(pluginConfig.Token)))
+	}
+
+	conn, err := grpc.Dial(pluginConfig.Address, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to provider plugin %s: %w", name, err)
+	}
+
+	return newPluginProvider(ctx, name, appConfig, pluginConfig, pb.NewProviderPluginClient(conn), providerRepo, workerRepo, tailscale), nil
+}
+
+func newPluginProvider(ctx context.Context, name string, appConfig types.AppConfig, pluginConfig types.ProviderPluginConfig, client pb.ProviderPluginClient, providerRepo repository.ProviderRepository, workerRepo repository.WorkerRepository, tailscale *network.Tailscale) *PluginProvider {
+	pluginProvider := &PluginProvider{
+		client:       client,
+		pluginConfig: pluginConfig,
+	}
+
+	baseProvider := NewExternalProvider(ctx, &ExternalProviderConfig{
+		Name:                 name,
+		ClusterName:          appConfig.ClusterName,
+		AppConfig:            appConfig,
+		TailScale:            tailscale,
+		ProviderRepo:         providerRepo,
+		WorkerRepo:           workerRepo,
+		ListMachinesFunc:     pluginProvider.listMachines,
+		TerminateMachineFunc: pluginProvider.TerminateMachine,
+	})
+	pluginProvider.ExternalProvider = baseProvider
+
+	return pluginProvider
+}
+
+func (p *PluginProvider) ProvisionMachine(ctx context.Context, poolName, token string, compute types.ProviderComputeRequest) (string, error) {
+	machineId := MachineId()
+	userData, err := renderUserData(userDataConfig{
+		TailscaleAuth:     p.AppConfig.Tailscale.AuthKey,
+		TailscaleUrl:      p.AppConfig.Tailscale.ControlURL,
+		RegistrationToken: token,
+		MachineId:         machineId,
+		PoolName:          poolName,
+		ProviderName:      p.Name,
+	}, pluginUserDataTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.pluginConfig.Timeout)
+	defer cancel()
+
+	response, err := p.client.ProvisionMachine(ctx, &pb.ProvisionMachineRequest{
+		PoolName:  poolName,
+		MachineId: machineId,
+		Cpu:       compute.Cpu,
+		Memory:    compute.Memory,
+		Gpu:       compute.Gpu,
+		GpuCount:  compute.GpuCount,
+		Spot:      compute.Spot,
+		Registration: &pb.MachineRegistration{
+			Token:         token,
+			ProviderName:  p.Name,
+			ClusterName:   p.ClusterName,
+			TailscaleUrl:  p.AppConfig.Tailscale.ControlURL,
+			TailscaleAuth: p.AppConfig.Tailscale.AuthKey,
+			UserData:      userData,
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("provider plugin %s failed to provision machine: %w", p.Name, err)
+	}
+
+	log.Info().Str("provider", p.Name).Str("machine_id", machineId).Str("instance_id", response.InstanceId).Str("instance_type", response.InstanceType).Float64("hourly_price", response.HourlyPrice).Msg("provisioned machine")
+
+	err = p.ProviderRepo.AddMachine(p.Name, poolName, machineId, &types.ProviderMachineState{
+		PoolName:          poolName,
+		Cpu:               response.Cpu,
+		Memory:            response.Memory,
+		Gpu:               response.Gpu,
+		GpuCount:          response.GpuCount,
+		RegistrationToken: token,
+		AutoConsolidate:   true,
+		InstanceType:      response.InstanceType,
+		HourlyPrice:       response.HourlyPrice,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return machineId, nil
+}
+
+func (p *PluginProvider) TerminateMachine(ctx context.Context, poolName, instanceId, machineId string) error {
+	ctx, cancel := context.WithTimeout(ctx, p.pluginConfig.Timeout)
+	defer cancel()
+
+	_, err := p.client.TerminateMachine(ctx, &pb.TerminateMachineRequest{
+		PoolName:   poolName,
+		MachineId:  machineId,
+		InstanceId: instanceId,
+	})
+	if err != nil {
+		return fmt.Errorf("provider plugin %s failed to terminate machine: %w", p.Name, err)
+	}
+
+	log.Info().Str("provider", p.Name).Str("machine_id", machineId).Str("instance_id", instanceId).Msg("terminated machine")
+
+	return p.ProviderRepo.RemoveMachine(p.Name, poolName, machineId)
+}
+
+func (p *PluginProvider) listMachines(ctx context.Context, poolName string) (map[string]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.pluginConfig.Timeout)
+	defer cancel()
+
+	response, err := p.client.ListPoolMachines(ctx, &pb.ListPoolMachinesRequest{PoolName: poolName})
+	if status.Code(err) == codes.Unimplemented {
+		return nil, types.NewProviderNotImplemented()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Machines, nil
+}
+
+// Plugins set up the machine's OS themselves, so the agent is all that's left to install
+const pluginUserDataTemplate string = `#!/bin/bash
+curl -L -o agent https://release.beam.cloud/agent/agent && \
+chmod +x agent && \
+./agent --token "{{.RegistrationToken}}" --machine-id "{{.MachineId}}" \
+--tailscale-url "{{.TailscaleUrl}}" \
+--tailscale-auth "{{.TailscaleAuth}}" \
+--pool-name "{{.PoolName}}" \
+--provider-name "{{.ProviderName}}"
+`
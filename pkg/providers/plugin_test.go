@@ -0,0 +1,81 @@
+package providers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/beam-cloud/beta9/pkg/repository"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+)
+
+type fakePluginClient struct {
+	provisioned *pb.ProvisionMachineRequest
+	terminated  *pb.TerminateMachineRequest
+	listErr     error
+}
+
+func (c *fakePluginClient) ProvisionMachine(ctx context.Context, in *pb.ProvisionMachineRequest, opts ...grpc.CallOption) (*pb.ProvisionMachineResponse, error) {
+	c.provisioned = in
+	return &pb.ProvisionMachineResponse{
+		InstanceId:   "instance-1",
+		InstanceType: "metal.large",
+		Cpu:          16000,
+		Memory:       65536,
+		Gpu:          in.Gpu,
+		GpuCount:     in.GpuCount,
+		HourlyPrice:  1.25,
+	}, nil
+}
+
+func (c *fakePluginClient) TerminateMachine(ctx context.Context, in *pb.TerminateMachineRequest, opts ...grpc.CallOption) (*pb.TerminateMachineResponse, error) {
+	c.terminated = in
+	return &pb.TerminateMachineResponse{}, nil
+}
+
+func (c *fakePluginClient) ListPoolMachines(ctx context.Context, in *pb.ListPoolMachinesRequest, opts ...grpc.CallOption) (*pb.ListPoolMachinesResponse, error) {
+	if c.listErr != nil {
+		return nil, c.listErr
+	}
+	return &pb.ListPoolMachinesResponse{}, nil
+}
+
+func TestPluginProvider(t *testing.T) {
+	rdb, err := repository.NewRedisClientForTest()
+	require.NoError(t, err)
+
+	providerRepo := repository.NewProviderRedisRepositoryForTest(rdb)
+	client := &fakePluginClient{}
+	provider := newPluginProvider(context.Background(), "metal", types.AppConfig{ClusterName: "beta9"}, types.ProviderPluginConfig{Timeout: defaultPluginTimeout}, client, providerRepo, nil, nil)
+
+	// The machine is created by the plugin and recorded with the resources and price the plugin returned
+	machineId, err := provider.ProvisionMachine(context.Background(), "pool", "token", types.ProviderComputeRequest{Cpu: 1000, Memory: 1024, Gpu: "A10G", GpuCount: 1})
+	require.NoError(t, err)
+	assert.Equal(t, machineId, client.provisioned.MachineId)
+	assert.Equal(t, "token", client.provisioned.Registration.Token)
+	assert.Contains(t, client.provisioned.Registration.UserData, `--machine-id "`+machineId+`"`)
+
+	machine, err := providerRepo.GetMachine("metal", "pool", machineId)
+	require.NoError(t, err)
+	assert.Equal(t, int64(16000), machine.State.Cpu)
+	assert.Equal(t, "metal.large", machine.State.InstanceType)
+	assert.Equal(t, 1.25, machine.State.HourlyPrice)
+
+	// Terminating the machine removes it from the pool
+	require.NoError(t, provider.TerminateMachine(context.Background(), "pool", "instance-1", machineId))
+	assert.Equal(t, "instance-1", client.terminated.InstanceId)
+
+	_, err = providerRepo.GetMachine("metal", "pool", machineId)
+	assert.Error(t, err)
+
+	// Plugins that can't list their machines aren't reconciled
+	client.listErr = status.Error(codes.Unimplemented, "not implemented")
+	_, err = provider.listMachines(context.Background(), "pool")
+	assert.IsType(t, &types.ProviderNotImplemented{}, err)
+}
@@ -51,6 +51,15 @@ type userDataConfig struct {
 }
 
 func generateCloudInitData(config userDataConfig, userDataTemplate string) (string, error) {
+	userData, err := renderUserData(config, userDataTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString([]byte(userData)), nil
+}
+
+func renderUserData(config userDataConfig, userDataTemplate string) (string, error) {
 	t, err := template.New("userdata").Parse(userDataTemplate)
 	if err != nil {
 		return "", fmt.Errorf("error parsing user data template: %w", err)
@@ -61,7 +70,7 @@ func generateCloudInitData(config userDataConfig, userDataTemplate string) (stri
 		return "", fmt.Errorf("error executing user data template: %w", err)
 	}
 
-	return base64.StdEncoding.EncodeToString(populatedTemplate.Bytes()), nil
+	return populatedTemplate.String(), nil
 }
 
 func selectInstance(availableInstances []Instance, requiredCpu int64, requiredMemory int64, requiredGpuType string, requiredGpuCount uint32) (*Instance, error) {
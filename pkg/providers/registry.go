@@ -0,0 +1,35 @@
+package providers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/beam-cloud/beta9/pkg/network"
+	"github.com/beam-cloud/beta9/pkg/repository"
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+// NewProvider returns the provider that creates machines for pools with the given provider name. Names that
+// aren't built in are looked up in the configured provider plugins.
+func NewProvider(ctx context.Context, name types.MachineProvider, appConfig types.AppConfig, providerRepo repository.ProviderRepository, workerRepo repository.WorkerRepository, tailscale *network.Tailscale) (Provider, error) {
+	switch name {
+	case types.ProviderEC2:
+		return NewEC2Provider(ctx, appConfig, providerRepo, workerRepo, tailscale)
+	case types.ProviderOCI:
+		return NewOCIProvider(ctx, appConfig, providerRepo, workerRepo, tailscale)
+	case types.ProviderLambdaLabs:
+		return NewLambdaLabsProvider(ctx, appConfig, providerRepo, workerRepo, tailscale)
+	case types.ProviderCrusoe:
+		return NewCrusoeProvider(ctx, appConfig, providerRepo, workerRepo, tailscale)
+	case types.ProviderHydra:
+		return NewHydraProvider(ctx, appConfig, providerRepo, workerRepo, tailscale)
+	case types.ProviderGeneric:
+		return NewGenericProvider(ctx, appConfig, providerRepo, workerRepo, tailscale)
+	}
+
+	if _, ok := appConfig.Providers.Plugins[string(name)]; ok {
+		return NewPluginProvider(ctx, string(name), appConfig, providerRepo, workerRepo, tailscale)
+	}
+
+	return nil, errors.New("invalid provider name")
+}
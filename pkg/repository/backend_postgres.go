@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -172,7 +173,7 @@ func (r *PostgresBackendRepository) GetWorkspace(ctx context.Context, workspaceI
 	query := `
 	SELECT w.id, w.external_id, w.name, w.created_at, w.concurrency_limit_id, w.volume_cache_enabled, w.multi_gpu_enabled,
 	ws.id "storage.id", ws.bucket_name "storage.bucket_name", ws.access_key "storage.access_key",
-	ws.secret_key "storage.secret_key", ws.endpoint_url "storage.endpoint_url", ws.region "storage.region",
+	ws.secret_key "storage.secret_key", ws.endpoint_url "storage.endpoint_url", ws.region "storage.region", ws.provider "storage.provider",
 	ws.created_at "storage.created_at", ws.updated_at "storage.updated_at"
 	FROM workspace w
 	LEFT JOIN workspace_storage ws ON w.storage_id = ws.id
@@ -235,17 +236,15 @@ func (r *PostgresBackendRepository) CreateToken(ctx context.Context, workspaceId
 		return types.Token{}, err
 	}
 
-	// Generate a new key for the token
-	randomBytes := make([]byte, tokenLength)
-	if _, err := rand.Read(randomBytes); err != nil {
+	key, err := generateTokenKey()
+	if err != nil {
 		return types.Token{}, err
 	}
-	key := base64.URLEncoding.EncodeToString(randomBytes)
 
 	query := `
 	INSERT INTO token (external_id, key, active, token_type, reusable, workspace_id)
 	VALUES ($1, $2, $3, $4, $5, $6)
-	RETURNING id, external_id, key, created_at, updated_at, active, token_type, reusable, workspace_id;
+	RETURNING id, external_id, key, created_at, updated_at, active, token_type, reusable, workspace_id, role, scopes, expires_at, service_account_id, rate_limit;
 	`
 
 	var token types.Token
@@ -256,17 +255,27 @@ func (r *PostgresBackendRepository) CreateToken(ctx context.Context, workspaceId
 	return token, nil
 }
 
+// generateTokenKey generates a new key for a token
+func generateTokenKey() (string, error) {
+	randomBytes := make([]byte, tokenLength)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", err
+	}
+
+	return base64.URLEncoding.EncodeToString(randomBytes), nil
+}
+
 func (r *PostgresBackendRepository) AuthorizeToken(ctx context.Context, tokenKey string) (*types.Token, *types.Workspace, error) {
 	query := `
-	SELECT t.id, t.external_id, t.key, t.created_at, t.updated_at, t.active, t.disabled_by_cluster_admin , t.token_type, t.reusable, t.workspace_id,
+	SELECT t.id, t.external_id, t.key, t.created_at, t.updated_at, t.active, t.disabled_by_cluster_admin , t.token_type, t.reusable, t.workspace_id, t.role, t.scopes, t.expires_at, t.service_account_id, t.rate_limit,
 	       w.id "workspace.id", w.name "workspace.name", w.external_id "workspace.external_id", w.signing_key "workspace.signing_key", w.created_at "workspace.created_at",
 		   w.updated_at "workspace.updated_at", w.volume_cache_enabled "workspace.volume_cache_enabled", w.multi_gpu_enabled "workspace.multi_gpu_enabled", w.storage_id "workspace.storage_id",
 		   ws.id AS "workspace.storage.id", ws.external_id AS "workspace.storage.external_id", ws.bucket_name AS "workspace.storage.bucket_name", ws.access_key AS "workspace.storage.access_key", 
-		   ws.secret_key AS "workspace.storage.secret_key", ws.endpoint_url AS "workspace.storage.endpoint_url", ws.region AS "workspace.storage.region", ws.created_at AS "workspace.storage.created_at", ws.updated_at AS "workspace.storage.updated_at"
+		   ws.secret_key AS "workspace.storage.secret_key", ws.endpoint_url AS "workspace.storage.endpoint_url", ws.region AS "workspace.storage.region", ws.provider AS "workspace.storage.provider", ws.created_at AS "workspace.storage.created_at", ws.updated_at AS "workspace.storage.updated_at"
 	FROM token t
 	INNER JOIN workspace w ON t.workspace_id = w.id
 	LEFT JOIN workspace_storage ws ON w.storage_id = ws.id
-	WHERE t.key = $1 AND t.active = TRUE;
+	WHERE t.key = $1 AND t.active = TRUE AND (t.expires_at IS NULL OR t.expires_at > CURRENT_TIMESTAMP);
 	`
 
 	var token types.Token
@@ -298,11 +307,43 @@ func (r *PostgresBackendRepository) AuthorizeToken(ctx context.Context, tokenKey
 	return &token, token.Workspace, nil
 }
 
+// AuthorizeTokenByExternalId looks up a token by its public id, for requests that are signed with the
+// token's key instead of carrying it. Only reusable tokens can sign requests.
+func (r *PostgresBackendRepository) AuthorizeTokenByExternalId(ctx context.Context, externalId string) (*types.Token, *types.Workspace, error) {
+	query := `
+	SELECT t.id, t.external_id, t.key, t.created_at, t.updated_at, t.active, t.disabled_by_cluster_admin , t.token_type, t.reusable, t.workspace_id, t.role, t.scopes, t.expires_at, t.service_account_id, t.rate_limit,
+	       w.id "workspace.id", w.name "workspace.name", w.external_id "workspace.external_id", w.signing_key "workspace.signing_key", w.created_at "workspace.created_at",
+		   w.updated_at "workspace.updated_at", w.volume_cache_enabled "workspace.volume_cache_enabled", w.multi_gpu_enabled "workspace.multi_gpu_enabled", w.storage_id "workspace.storage_id",
+		   ws.id AS "workspace.storage.id", ws.external_id AS "workspace.storage.external_id", ws.bucket_name AS "workspace.storage.bucket_name", ws.access_key AS "workspace.storage.access_key", 
+		   ws.secret_key AS "workspace.storage.secret_key", ws.endpoint_url AS "workspace.storage.endpoint_url", ws.region AS "workspace.storage.region", ws.provider AS "workspace.storage.provider", ws.created_at AS "workspace.storage.created_at", ws.updated_at AS "workspace.storage.updated_at"
+	FROM token t
+	INNER JOIN workspace w ON t.workspace_id = w.id
+	LEFT JOIN workspace_storage ws ON w.storage_id = ws.id
+	WHERE t.external_id = $1 AND t.active = TRUE AND t.reusable = TRUE AND (t.expires_at IS NULL OR t.expires_at > CURRENT_TIMESTAMP);
+	`
+
+	var token types.Token
+
+	if err := r.client.GetContext(ctx, &token, query, externalId); err != nil {
+		return nil, nil, err
+	}
+
+	if token.Workspace.StorageAvailable() {
+		if err := r.decryptFields(token.Workspace.Storage); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return &token, token.Workspace, nil
+}
+
 func (r *PostgresBackendRepository) RetrieveActiveToken(ctx context.Context, workspaceId uint) (*types.Token, error) {
 	query := `
-	SELECT id, external_id, key, created_at, updated_at, active, token_type, reusable, workspace_id
+	SELECT id, external_id, key, created_at, updated_at, active, token_type, reusable, workspace_id, role, scopes, expires_at, service_account_id, rate_limit
 	FROM token
 	WHERE workspace_id = $1 AND active = TRUE AND token_type = 'workspace_primary' AND disabled_by_cluster_admin = FALSE
+	AND (expires_at IS NULL OR expires_at > CURRENT_TIMESTAMP)
+	ORDER BY created_at DESC
 	LIMIT 1;
 	`
 
@@ -317,10 +358,11 @@ func (r *PostgresBackendRepository) RetrieveActiveToken(ctx context.Context, wor
 
 func (r *PostgresBackendRepository) ListTokens(ctx context.Context, workspaceId uint) ([]types.Token, error) {
 	query := `
-    SELECT id, external_id, key, created_at, updated_at, active, token_type, reusable, workspace_id
+    SELECT id, external_id, key, created_at, updated_at, active, token_type, reusable, workspace_id, role, scopes, expires_at, service_account_id, rate_limit
     FROM token
     WHERE workspace_id = $1
 	AND token_type != 'worker'
+	AND token_type != 'service_account'
     ORDER BY created_at DESC;
     `
 
@@ -334,7 +376,7 @@ func (r *PostgresBackendRepository) ListTokens(ctx context.Context, workspaceId
 }
 
 func (r *PostgresBackendRepository) GetTokenByExternalId(ctx context.Context, workspaceId uint, extTokenId string) (*types.Token, error) {
-	query := `SELECT id, external_id, key, created_at, updated_at, active, token_type, reusable, workspace_id FROM token WHERE external_id = $1 AND workspace_id = $2;`
+	query := `SELECT id, external_id, key, created_at, updated_at, active, token_type, reusable, workspace_id, role, scopes, expires_at, service_account_id, rate_limit FROM token WHERE external_id = $1 AND workspace_id = $2;`
 
 	var token types.Token
 	err := r.client.GetContext(ctx, &token, query, extTokenId, workspaceId)
@@ -353,7 +395,7 @@ func (r *PostgresBackendRepository) ToggleToken(ctx context.Context, workspaceId
 	UPDATE token
 	SET active = NOT active
 	WHERE external_id = $1 AND workspace_id = $2
-	RETURNING id, external_id, key, created_at, updated_at, active, token_type, reusable, workspace_id;
+	RETURNING id, external_id, key, created_at, updated_at, active, token_type, reusable, workspace_id, role, scopes, expires_at, service_account_id, rate_limit;
 	`
 
 	var token types.Token
@@ -365,6 +407,46 @@ func (r *PostgresBackendRepository) ToggleToken(ctx context.Context, workspaceId
 	return token, nil
 }
 
+func (r *PostgresBackendRepository) SetTokenRole(ctx context.Context, workspaceId uint, extTokenId string, role types.WorkspaceRole) (*types.Token, error) {
+	query := `
+	UPDATE token
+	SET role = $3, updated_at = CURRENT_TIMESTAMP
+	WHERE external_id = $1 AND workspace_id = $2
+	RETURNING id, external_id, key, created_at, updated_at, active, token_type, reusable, workspace_id, role, scopes, expires_at, service_account_id, rate_limit;
+	`
+
+	var token types.Token
+	err := r.client.GetContext(ctx, &token, query, extTokenId, workspaceId, role)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("token not found")
+		}
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+func (r *PostgresBackendRepository) SetTokenScopes(ctx context.Context, workspaceId uint, extTokenId string, scopes types.TokenScopes) (*types.Token, error) {
+	query := `
+	UPDATE token
+	SET scopes = $3, updated_at = CURRENT_TIMESTAMP
+	WHERE external_id = $1 AND workspace_id = $2
+	RETURNING id, external_id, key, created_at, updated_at, active, token_type, reusable, workspace_id, role, scopes, expires_at, service_account_id, rate_limit;
+	`
+
+	var token types.Token
+	err := r.client.GetContext(ctx, &token, query, extTokenId, workspaceId, scopes)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("token not found")
+		}
+		return nil, err
+	}
+
+	return &token, nil
+}
+
 func (r *PostgresBackendRepository) DeleteToken(ctx context.Context, workspaceId uint, extTokenId string) error {
 	query := `DELETE FROM token WHERE external_id = $1 AND workspace_id = $2;`
 	_, err := r.client.ExecContext(ctx, query, extTokenId, workspaceId)
@@ -401,13 +483,119 @@ func (r *PostgresBackendRepository) UpdateTokenAsClusterAdmin(ctx context.Contex
 	return nil
 }
 
+// SetTokenRateLimit overrides the gateway's default rate limit for a token, a nil limit restores the default.
+// It returns nil if there's no such token.
+func (r *PostgresBackendRepository) SetTokenRateLimit(ctx context.Context, tokenId string, limit *types.RateLimit) (*types.Token, error) {
+	var token types.Token
+	err := r.client.GetContext(ctx, &token, `
+		UPDATE token
+		SET rate_limit = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE external_id = $1
+		RETURNING id, external_id, key, created_at, updated_at, active, token_type, reusable, workspace_id, role, scopes, expires_at, service_account_id, rate_limit;
+	`, tokenId, limit)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Class() == PostgresDataError {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// RotateToken issues a replacement for an active token, with the same type, role, scopes and limits, and cuts the
+// old token's lifetime to previousExpiresAt. It returns nil tokens if there's no such active token.
+func (r *PostgresBackendRepository) RotateToken(ctx context.Context, workspaceId uint, extTokenId string, previousExpiresAt time.Time) (*types.Token, *types.Token, error) {
+	tx, err := r.client.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	var previous types.Token
+	err = tx.GetContext(ctx, &previous, `
+		SELECT id, external_id, key, created_at, updated_at, active, token_type, reusable, workspace_id, role, scopes, expires_at, service_account_id, rate_limit
+		FROM token
+		WHERE external_id = $1 AND workspace_id = $2 AND active = TRUE AND (expires_at IS NULL OR expires_at > CURRENT_TIMESTAMP)
+		FOR UPDATE;
+	`, extTokenId, workspaceId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil, nil
+		}
+
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Class() == PostgresDataError {
+			return nil, nil, nil
+		}
+
+		return nil, nil, err
+	}
+
+	externalId, err := r.generateExternalId()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key, err := generateTokenKey()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var token types.Token
+	err = tx.GetContext(ctx, &token, `
+		INSERT INTO token (external_id, key, active, token_type, reusable, workspace_id, role, scopes, expires_at, service_account_id, rate_limit)
+		VALUES ($1, $2, true, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, external_id, key, created_at, updated_at, active, token_type, reusable, workspace_id, role, scopes, expires_at, service_account_id, rate_limit;
+	`, externalId, key, previous.TokenType, previous.Reusable, previous.WorkspaceId, previous.Role, previous.Scopes, previous.ExpiresAt, previous.ServiceAccountId, previous.RateLimit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = tx.GetContext(ctx, &previous, `
+		UPDATE token
+		SET expires_at = LEAST(COALESCE(expires_at, $2), $2), updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING id, external_id, key, created_at, updated_at, active, token_type, reusable, workspace_id, role, scopes, expires_at, service_account_id, rate_limit;
+	`, previous.Id, previousExpiresAt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Users signed in through the identity provider move over to the new token
+	if _, err := tx.ExecContext(ctx, `UPDATE oidc_identity SET token_id = $2 WHERE token_id = $1;`, previous.Id, token.Id); err != nil {
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+
+	return &previous, &token, nil
+}
+
 // Object
 
+const objectColumns = "id, external_id, hash, size, workspace_id, created_at, retain_until, compression, stored_size, incomplete, key, region, digest, blob_id, last_used_at, tags, location, encryption, wrapped_data_key"
+
 func (r *PostgresBackendRepository) CreateObject(ctx context.Context, hash string, size int64, workspaceId uint) (*types.Object, error) {
 	query := `
-    INSERT INTO object (hash, size, workspace_id)
-    VALUES ($1, $2, $3)
-    RETURNING id, external_id, hash, size, created_at, workspace_id;
+    WITH inserted AS (
+        INSERT INTO object (hash, size, workspace_id, region)
+        VALUES ($1, $2, $3, COALESCE((
+            SELECT ws.region FROM workspace w
+            JOIN workspace_storage ws ON w.storage_id = ws.id
+            WHERE w.id = $3
+        ), ''))
+        RETURNING ` + objectColumns + `
+    ), usage AS (
+        UPDATE workspace SET object_storage_used = object_storage_used + $2 WHERE id = $3
+    )
+    SELECT * FROM inserted;
     `
 
 	var newObject types.Object
@@ -421,7 +609,7 @@ func (r *PostgresBackendRepository) CreateObject(ctx context.Context, hash strin
 func (r *PostgresBackendRepository) GetObjectByHash(ctx context.Context, hash string, workspaceId uint) (*types.Object, error) {
 	var object types.Object
 
-	query := `SELECT id, external_id, hash, size, created_at FROM object WHERE hash = $1 AND workspace_id = $2;`
+	query := `SELECT ` + objectColumns + ` FROM object WHERE hash = $1 AND workspace_id = $2;`
 	err := r.client.GetContext(ctx, &object, query, hash, workspaceId)
 	if err != nil {
 		return nil, err
@@ -430,10 +618,34 @@ func (r *PostgresBackendRepository) GetObjectByHash(ctx context.Context, hash st
 	return &object, nil
 }
 
+func (r *PostgresBackendRepository) GetObjectByKey(ctx context.Context, key string, workspaceId uint) (*types.Object, error) {
+	var object types.Object
+
+	query := `SELECT ` + objectColumns + ` FROM object WHERE key = $1 AND workspace_id = $2;`
+	err := r.client.GetContext(ctx, &object, query, key, workspaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &object, nil
+}
+
+func (r *PostgresBackendRepository) ListObjectsByRegion(ctx context.Context, region string) ([]types.Object, error) {
+	var objects []types.Object
+
+	query := `SELECT ` + objectColumns + ` FROM object WHERE region = $1 ORDER BY id;`
+	err := r.client.SelectContext(ctx, &objects, query, region)
+	if err != nil {
+		return nil, err
+	}
+
+	return objects, nil
+}
+
 func (r *PostgresBackendRepository) GetObjectByExternalId(ctx context.Context, externalId string, workspaceId uint) (types.Object, error) {
 	var object types.Object
 
-	query := `SELECT id, external_id, hash, size, created_at FROM object WHERE external_id = $1 AND workspace_id = $2;`
+	query := `SELECT ` + objectColumns + ` FROM object WHERE external_id = $1 AND workspace_id = $2;`
 	err := r.client.GetContext(ctx, &object, query, externalId, workspaceId)
 	if err != nil {
 		return types.Object{}, err
@@ -444,7 +656,7 @@ func (r *PostgresBackendRepository) GetObjectByExternalId(ctx context.Context, e
 
 func (r *PostgresBackendRepository) GetObjectByExternalStubId(ctx context.Context, stubId string, workspaceId uint) (types.Object, error) {
 	query := `
-	SELECT o.id, o.external_id, o.hash, o.size, o.created_at
+	SELECT o.id, o.external_id, o.hash, o.size, o.workspace_id, o.created_at, o.retain_until, o.compression, o.stored_size, o.incomplete, o.key, o.region, o.digest, o.blob_id, o.last_used_at, o.tags, o.location, o.encryption, o.wrapped_data_key
 	FROM object o
 	INNER JOIN stub s ON o.id = s.object_id
 	WHERE s.external_id = $1 AND o.workspace_id = $2;
@@ -460,10 +672,21 @@ func (r *PostgresBackendRepository) GetObjectByExternalStubId(ctx context.Contex
 }
 
 func (r *PostgresBackendRepository) UpdateObjectSizeByExternalId(ctx context.Context, externalId string, size int) error {
+	// The workspace's usage counter is adjusted by the difference from the object's previous size
 	query := `
-	UPDATE object
-	SET size = $2
-	WHERE external_id = $1;
+	WITH previous AS (
+		SELECT id, size FROM object WHERE external_id = $1 FOR UPDATE
+	), updated AS (
+		UPDATE object o
+		SET size = $2, incomplete = false
+		FROM previous p
+		WHERE o.id = p.id
+		RETURNING o.workspace_id, o.size - p.size AS delta
+	)
+	UPDATE workspace w
+	SET object_storage_used = GREATEST(w.object_storage_used + u.delta, 0)
+	FROM updated u
+	WHERE w.id = u.workspace_id;
 	`
 	_, err := r.client.ExecContext(ctx, query, externalId, size)
 	if err != nil {
@@ -473,914 +696,3009 @@ func (r *PostgresBackendRepository) UpdateObjectSizeByExternalId(ctx context.Con
 	return nil
 }
 
-func (r *PostgresBackendRepository) DeleteObjectByExternalId(ctx context.Context, externalId string) error {
-	query := `DELETE FROM object WHERE external_id = $1;`
-	_, err := r.client.ExecContext(ctx, query, externalId)
-	if err != nil {
-		return err
-	}
+func (r *PostgresBackendRepository) UpdateObjectEncryptionByExternalId(ctx context.Context, externalId string, encryption types.ObjectEncryption, wrappedDataKey string) error {
+	query := `
+	UPDATE object
+	SET encryption = $2, wrapped_data_key = $3
+	WHERE external_id = $1;
+	`
+	_, err := r.client.ExecContext(ctx, query, externalId, encryption, wrappedDataKey)
+	return err
+}
 
-	return nil
+func (r *PostgresBackendRepository) UpdateObjectCompressionByExternalId(ctx context.Context, externalId string, compression types.ObjectCompression, storedSize int64) error {
+	query := `
+	UPDATE object
+	SET compression = $2, stored_size = $3
+	WHERE external_id = $1;
+	`
+	_, err := r.client.ExecContext(ctx, query, externalId, compression, storedSize)
+	return err
 }
 
-// Task
+func (r *PostgresBackendRepository) UpdateObjectLocationByExternalId(ctx context.Context, externalId string, location types.ObjectLocation) error {
+	query := `
+	UPDATE object
+	SET location = $2
+	WHERE external_id = $1;
+	`
+	_, err := r.client.ExecContext(ctx, query, externalId, location)
+	return err
+}
 
-func (r *PostgresBackendRepository) handleTaskEvent(taskId string, callback func(*types.TaskWithRelated)) {
-	task, err := r.GetTaskWithRelated(context.Background(), taskId)
-	if err != nil {
-		return
+// ListObjectsPendingMigration returns complete objects in the workspace that are still on the gateway filesystem
+// ListObjectsForIntegrityCheck returns the objects across all workspaces that were checked least recently
+func (r *PostgresBackendRepository) ListObjectsForIntegrityCheck(ctx context.Context, limit int) ([]types.Object, error) {
+	query := `
+	SELECT ` + objectColumns + ` FROM object
+	WHERE incomplete = false
+	ORDER BY integrity_checked_at NULLS FIRST, id
+	LIMIT $1;
+	`
+
+	var objects []types.Object
+	if err := r.client.SelectContext(ctx, &objects, query, limit); err != nil {
+		return nil, err
 	}
 
-	callback(task)
+	return objects, nil
 }
 
-func (r *PostgresBackendRepository) CreateTask(ctx context.Context, params *types.TaskParams) (*types.Task, error) {
-	if params.TaskId == "" {
-		externalId, err := r.generateExternalId()
-		if err != nil {
-			return nil, err
-		}
-
-		params.TaskId = externalId
-	}
+func (r *PostgresBackendRepository) MarkObjectIntegrityChecked(ctx context.Context, objectId uint) error {
+	query := `UPDATE object SET integrity_checked_at = CURRENT_TIMESTAMP WHERE id = $1;`
+	_, err := r.client.ExecContext(ctx, query, objectId)
+	return err
+}
 
+// RecordObjectCorruption stores the latest finding for an object, replacing any earlier one
+func (r *PostgresBackendRepository) RecordObjectCorruption(ctx context.Context, corruption *types.ObjectCorruption) error {
 	query := `
-    INSERT INTO task (external_id, container_id, workspace_id, external_workspace_id, stub_id)
-    VALUES ($1, $2, $3, $4, $5)
-    RETURNING id, external_id, status, container_id, workspace_id, external_workspace_id, stub_id, started_at, ended_at, created_at, updated_at;
-    `
+	INSERT INTO object_corruption (object_id, workspace_id, reason, expected_size, actual_size, expected_digest, actual_digest, quarantined)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	ON CONFLICT (object_id) DO UPDATE SET
+		reason = EXCLUDED.reason,
+		expected_size = EXCLUDED.expected_size,
+		actual_size = EXCLUDED.actual_size,
+		expected_digest = EXCLUDED.expected_digest,
+		actual_digest = EXCLUDED.actual_digest,
+		quarantined = object_corruption.quarantined OR EXCLUDED.quarantined,
+		detected_at = CURRENT_TIMESTAMP;
+	`
+	_, err := r.client.ExecContext(ctx, query, corruption.ObjectId, corruption.WorkspaceId, corruption.Reason, corruption.ExpectedSize,
+		corruption.ActualSize, corruption.ExpectedDigest, corruption.ActualDigest, corruption.Quarantined)
+	return err
+}
 
-	var newTask types.Task
+func (r *PostgresBackendRepository) DeleteObjectCorruption(ctx context.Context, objectId uint) error {
+	query := `DELETE FROM object_corruption WHERE object_id = $1;`
+	_, err := r.client.ExecContext(ctx, query, objectId)
+	return err
+}
 
-	if err := r.client.GetContext(ctx, &newTask, query, params.TaskId, params.ContainerId, params.WorkspaceId, params.ExternalWorkspaceId, params.StubId); err != nil {
-		return &types.Task{}, err
+func (r *PostgresBackendRepository) ListObjectCorruptions(ctx context.Context, workspaceId uint, limit int) ([]types.ObjectCorruption, error) {
+	query := `
+	SELECT c.object_id, o.external_id AS object_external_id, o.hash, c.workspace_id, c.reason, c.expected_size, c.actual_size,
+		c.expected_digest, c.actual_digest, c.quarantined, c.detected_at
+	FROM object_corruption c
+	JOIN object o ON o.id = c.object_id
+	WHERE c.workspace_id = $1
+	ORDER BY c.detected_at DESC
+	LIMIT $2;
+	`
+
+	var corruptions []types.ObjectCorruption
+	if err := r.client.SelectContext(ctx, &corruptions, query, workspaceId, limit); err != nil {
+		return nil, err
 	}
 
-	go r.handleTaskEvent(params.TaskId, r.eventRepo.PushTaskCreatedEvent)
-	return &newTask, nil
+	return corruptions, nil
 }
 
-func (r *PostgresBackendRepository) UpdateTask(ctx context.Context, externalId string, updatedTask types.Task) (*types.Task, error) {
+func (r *PostgresBackendRepository) ListObjectsPendingMigration(ctx context.Context, workspaceId uint, limit int) ([]types.Object, error) {
 	query := `
-	UPDATE task
-	SET status = $2, container_id = $3, started_at = $4, ended_at = $5, workspace_id = $6, stub_id = $7, updated_at = CURRENT_TIMESTAMP
-	WHERE external_id = $1
-	RETURNING id, external_id, status, container_id, workspace_id, stub_id, started_at, ended_at, created_at, updated_at;
+	SELECT ` + objectColumns + ` FROM object
+	WHERE workspace_id = $1 AND location = $2 AND incomplete = false
+	ORDER BY id
+	LIMIT $3;
 	`
 
-	var task types.Task
-	if err := r.client.GetContext(ctx, &task, query,
-		externalId, updatedTask.Status, updatedTask.ContainerId,
-		updatedTask.StartedAt, updatedTask.EndedAt,
-		updatedTask.WorkspaceId, updatedTask.StubId); err != nil {
-		return &types.Task{}, err
+	var objects []types.Object
+	if err := r.client.SelectContext(ctx, &objects, query, workspaceId, types.ObjectLocationLocal, limit); err != nil {
+		return nil, err
 	}
 
-	go r.handleTaskEvent(externalId, r.eventRepo.PushTaskUpdatedEvent)
-	return &task, nil
+	return objects, nil
 }
 
-func (r *PostgresBackendRepository) DeleteTask(ctx context.Context, externalId string) error {
-	query := `DELETE FROM task WHERE external_id = $1;`
-	_, err := r.client.ExecContext(ctx, query, externalId)
-	return err
+func (r *PostgresBackendRepository) CountObjectsPendingMigration(ctx context.Context, workspaceId uint) (int64, error) {
+	query := `SELECT COUNT(*) FROM object WHERE workspace_id = $1 AND location = $2 AND incomplete = false;`
+
+	var count int64
+	if err := r.client.GetContext(ctx, &count, query, workspaceId, types.ObjectLocationLocal); err != nil {
+		return 0, err
+	}
+
+	return count, nil
 }
 
-func (r *PostgresBackendRepository) GetTask(ctx context.Context, externalId string) (*types.Task, error) {
-	var task types.Task
-	query := `SELECT id, external_id, status, container_id, started_at, ended_at, workspace_id, external_workspace_id, stub_id, created_at, updated_at FROM task WHERE external_id = $1;`
-	err := r.client.GetContext(ctx, &task, query, externalId)
-	if err != nil {
-		return &types.Task{}, err
+// UpdateObjectTagsByExternalId merges tags into an object's tags, or replaces them entirely if replace is set,
+// then removes any keys in removeKeys
+func (r *PostgresBackendRepository) UpdateObjectTagsByExternalId(ctx context.Context, externalId string, workspaceId uint, tags types.ObjectTags, removeKeys []string, replace bool) (types.ObjectTags, error) {
+	query := `
+	UPDATE object
+	SET tags = (CASE WHEN $4::boolean THEN '{}'::jsonb ELSE tags END || $3::jsonb) - $5::text[]
+	WHERE external_id = $1 AND workspace_id = $2
+	RETURNING tags;
+	`
+
+	var updated types.ObjectTags
+	if err := r.client.GetContext(ctx, &updated, query, externalId, workspaceId, tags, replace, pq.Array(removeKeys)); err != nil {
+		return nil, err
 	}
 
-	return &task, nil
+	return updated, nil
 }
 
-func (r *PostgresBackendRepository) GetTaskStatus(ctx context.Context, externalId string) (types.TaskStatus, error) {
-	var status string
-	query := `SELECT status FROM task WHERE external_id = $1;`
-	err := r.client.GetContext(ctx, &status, query, externalId)
-	if err != nil {
-		return "", err
+// UpdateObjectKeyByExternalId sets the logical key of an object. An empty key clears it.
+func (r *PostgresBackendRepository) UpdateObjectKeyByExternalId(ctx context.Context, externalId string, workspaceId uint, key string) error {
+	query := `
+	UPDATE object
+	SET key = NULLIF($3, '')
+	WHERE external_id = $1 AND workspace_id = $2;
+	`
+	_, err := r.client.ExecContext(ctx, query, externalId, workspaceId, key)
+	return err
+}
+
+// ListObjectsByKey lists complete objects that have a logical key, in byte order of their keys
+func (r *PostgresBackendRepository) ListObjectsByKey(ctx context.Context, workspaceId uint, prefix string, startAfter string, limit int) ([]types.Object, error) {
+	query := `
+	SELECT ` + objectColumns + ` FROM object
+	WHERE workspace_id = $1 AND key IS NOT NULL AND incomplete = false
+	  AND starts_with(key, $2) AND key COLLATE "C" > $3
+	ORDER BY key COLLATE "C"
+	LIMIT $4;
+	`
+
+	var objects []types.Object
+	if err := r.client.SelectContext(ctx, &objects, query, workspaceId, prefix, startAfter, limit); err != nil {
+		return nil, err
 	}
 
-	return types.TaskStatus(status), nil
+	return objects, nil
 }
 
-func (r *PostgresBackendRepository) GetTaskWithRelated(ctx context.Context, externalId string) (*types.TaskWithRelated, error) {
-	var taskWithRelated types.TaskWithRelated
+func (r *PostgresBackendRepository) UpdateObjectDigestByExternalId(ctx context.Context, externalId string, digest string) error {
 	query := `
-	SELECT
-		t.*,
-		w.external_id AS "workspace.external_id",
-		w.name AS "workspace.name",
-		w.created_at AS "workspace.created_at",
-		w.updated_at AS "workspace.updated_at",
-		ew.id AS "external_workspace.id",
-		ew.external_id AS "external_workspace.external_id",
-		ew.name AS "external_workspace.name",
-		s.external_id AS "stub.external_id",
-		s.name AS "stub.name",
-		s.config AS "stub.config",
-		s.type AS "stub.type",
-		s.app_id AS "app.id",
-		a.external_id AS "app.external_id",
-		a.name AS "app.name",
-		a.external_id AS "app.external_id",
-		d.name AS "deployment.name",
-		d.version AS "deployment.version",
-		d.external_id AS "deployment.external_id"
-	FROM task t
-	JOIN workspace w ON t.workspace_id = w.id
-	JOIN stub s ON t.stub_id = s.id
-	JOIN app a ON s.app_id = a.id
-	LEFT JOIN workspace ew ON ew.id = t.external_workspace_id
-	LEFT JOIN deployment d ON d.stub_id = s.id
-	WHERE t.external_id = $1;
+	UPDATE object
+	SET digest = $2
+	WHERE external_id = $1;
 	`
+	_, err := r.client.ExecContext(ctx, query, externalId, digest)
+	return err
+}
 
-	err := r.client.GetContext(ctx, &taskWithRelated, query, externalId)
-	if err != nil {
-		if err, ok := err.(*pq.Error); ok && err.Code.Class() == PostgresDataError {
-			return nil, nil
-		}
+func (r *PostgresBackendRepository) MarkObjectIncompleteByExternalId(ctx context.Context, externalId string) error {
+	query := `
+	UPDATE object
+	SET incomplete = true
+	WHERE external_id = $1;
+	`
+	_, err := r.client.ExecContext(ctx, query, externalId)
+	return err
+}
 
-		if err == sql.ErrNoRows {
-			return nil, nil
-		}
+func (r *PostgresBackendRepository) LockObject(ctx context.Context, externalId string, workspaceId uint, retainUntil time.Time) error {
+	// A retention lock can only be extended, never shortened
+	query := `
+	UPDATE object
+	SET retain_until = GREATEST(COALESCE(retain_until, $3), $3)
+	WHERE external_id = $1 AND workspace_id = $2;
+	`
+	result, err := r.client.ExecContext(ctx, query, externalId, workspaceId, retainUntil)
+	if err != nil {
+		return err
+	}
 
-		return nil, err
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
 	}
 
-	// If external_workspace.id is nil, set ExternalWorkspace to nil
-	if taskWithRelated.ExternalWorkspace != nil && taskWithRelated.ExternalWorkspace.Id == nil {
-		taskWithRelated.ExternalWorkspace = nil
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
 	}
 
-	return &taskWithRelated, nil
+	return nil
 }
 
-func (r *PostgresBackendRepository) GetTaskByWorkspace(ctx context.Context, externalId string, workspace *types.Workspace) (*types.TaskWithRelated, error) {
-	var taskWithRelated types.TaskWithRelated
+func (r *PostgresBackendRepository) DeleteObjectByExternalId(ctx context.Context, externalId string) error {
 	query := `
-	SELECT
-		w.external_id AS "workspace.external_id", w.name AS "workspace.name", w.updated_at AS "workspace.updated_at", w.created_at AS "workspace.created_at", t.*,
-		s.external_id AS "stub.external_id", s.name AS "stub.name", s.config AS "stub.config", s.type AS "stub.type", s.created_at AS "stub.created_at", s.updated_at AS "stub.updated_at"
-	FROM task t
-	JOIN workspace w ON t.workspace_id = w.id
-	JOIN stub s ON t.stub_id = s.id
-	WHERE
-		t.external_id = $1
-		AND w.id = $2;
-    `
-	err := r.client.GetContext(ctx, &taskWithRelated, query, externalId, workspace.Id)
+	WITH deleted AS (
+		DELETE FROM object WHERE external_id = $1 AND (retain_until IS NULL OR retain_until <= NOW())
+		RETURNING workspace_id, size
+	)
+	UPDATE workspace w
+	SET object_storage_used = GREATEST(w.object_storage_used - d.size, 0)
+	FROM deleted d
+	WHERE w.id = d.workspace_id;
+	`
+	result, err := r.client.ExecContext(ctx, query, externalId)
 	if err != nil {
-		if err, ok := err.(*pq.Error); ok && err.Code.Class() == PostgresDataError {
-			return nil, nil
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		var retainUntil types.NullTime
+		lockQuery := `SELECT retain_until FROM object WHERE external_id = $1 AND retain_until > NOW();`
+		err := r.client.GetContext(ctx, &retainUntil, lockQuery, externalId)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
 		}
-		if err == sql.ErrNoRows {
-			return nil, nil
+
+		if err == nil && retainUntil.Valid {
+			return &types.ErrObjectLocked{ObjectId: externalId, RetainUntil: retainUntil.Time}
 		}
-		return nil, err
 	}
 
-	return &taskWithRelated, nil
+	return nil
 }
 
-func (r *PostgresBackendRepository) ListTasks(ctx context.Context) ([]types.Task, error) {
-	var tasks []types.Task
-	query := `SELECT id, external_id, status, container_id, started_at, ended_at, workspace_id, stub_id, created_at, updated_at FROM task;`
-	err := r.client.SelectContext(ctx, &tasks, query)
-	if err != nil {
+func (r *PostgresBackendRepository) TouchObjectByExternalId(ctx context.Context, externalId string) error {
+	query := `UPDATE object SET last_used_at = CURRENT_TIMESTAMP WHERE external_id = $1;`
+	_, err := r.client.ExecContext(ctx, query, externalId)
+	return err
+}
+
+// ListExpiredObjects returns objects past their workspace's lifecycle policy. Objects under a retention
+// lock or referenced by an active deployment never expire.
+func (r *PostgresBackendRepository) ListExpiredObjects(ctx context.Context, limit int) ([]types.Object, error) {
+	var objects []types.Object
+
+	query := `
+	SELECT ` + objectColumns + ` FROM object WHERE id IN (
+		SELECT o.id
+		FROM object o
+		JOIN object_lifecycle_policy p ON p.workspace_id = o.workspace_id
+		WHERE (
+			(p.expire_after_days > 0 AND o.created_at < NOW() - make_interval(days => p.expire_after_days))
+			OR (p.expire_unused_after_days > 0 AND o.last_used_at < NOW() - make_interval(days => p.expire_unused_after_days))
+		)
+		AND (o.retain_until IS NULL OR o.retain_until <= NOW())
+		AND NOT EXISTS (
+			SELECT 1 FROM stub s
+			JOIN deployment d ON d.stub_id = s.id
+			WHERE s.object_id = o.id AND d.active = true AND d.deleted_at IS NULL
+		)
+		ORDER BY o.id
+		LIMIT $1
+	)
+	ORDER BY id;
+	`
+	if err := r.client.SelectContext(ctx, &objects, query, limit); err != nil {
 		return nil, err
 	}
 
-	return tasks, nil
+	return objects, nil
 }
 
-func (c *PostgresBackendRepository) listTaskWithRelatedQueryBuilder(filters types.TaskFilter) squirrel.SelectBuilder {
-	qb := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar).Select(
-		"t.*, w.external_id AS \"workspace.external_id\", w.name AS \"workspace.name\", " +
-			"w.created_at AS \"workspace.created_at\", w.updated_at AS \"workspace.updated_at\", " +
-			"s.external_id AS \"stub.external_id\", s.name AS \"stub.name\", s.config AS \"stub.config\", s.type AS \"stub.type\", d.external_id AS \"deployment.external_id\", d.name AS \"deployment.name\", d.version AS \"deployment.version\"",
-	).From("task t").
-		Join("workspace w ON t.workspace_id = w.id").
-		Join("stub s ON t.stub_id = s.id").
-		LeftJoin("deployment d ON s.id = d.stub_id").
-		OrderBy("t.id DESC")
+const objectLifecyclePolicyColumns = "workspace_id, expire_after_days, expire_unused_after_days, retain_versions, created_at, updated_at"
 
-	// Apply filters
-	if filters.All {
-		qb = qb.Where(squirrel.Or{
-			squirrel.Eq{"t.external_workspace_id": filters.WorkspaceID},
-			squirrel.Eq{"t.workspace_id": filters.WorkspaceID},
-		})
-	} else if filters.WorkspaceID > 0 {
-		qb = qb.Where(squirrel.Eq{"t.workspace_id": filters.WorkspaceID})
-	} else if filters.ExternalWorkspaceID > 0 {
-		qb = qb.Where(squirrel.Eq{"t.external_workspace_id": filters.ExternalWorkspaceID})
-	}
+func (r *PostgresBackendRepository) GetObjectLifecyclePolicy(ctx context.Context, workspaceId uint) (*types.ObjectLifecyclePolicy, error) {
+	var policy types.ObjectLifecyclePolicy
 
-	if len(filters.StubIds) > 0 {
-		// Subquery to get the stub ids from the external ids
-		stubIdsSubquery := squirrel.Select("id").From("stub").Where(squirrel.Eq{"external_id": filters.StubIds})
-		qb = qb.Where(squirrel.Expr("s.id in (?)", stubIdsSubquery))
+	query := `SELECT ` + objectLifecyclePolicyColumns + ` FROM object_lifecycle_policy WHERE workspace_id = $1;`
+	if err := r.client.GetContext(ctx, &policy, query, workspaceId); err != nil {
+		return nil, err
 	}
 
-	if len(filters.StubNames) > 0 {
-		qb = qb.Where(squirrel.Eq{"s.name": filters.StubNames})
-	}
+	return &policy, nil
+}
 
-	if len(filters.StubTypes) > 0 {
-		qb = qb.Where(squirrel.Eq{"s.type": filters.StubTypes})
-	}
+func (r *PostgresBackendRepository) SetObjectLifecyclePolicy(ctx context.Context, workspaceId uint, expireAfterDays, expireUnusedAfterDays int, retainVersions *int) (*types.ObjectLifecyclePolicy, error) {
+	var policy types.ObjectLifecyclePolicy
 
-	if len(filters.ContainerIds) > 0 {
-		qb = qb.Where(squirrel.Eq{"t.container_id": filters.ContainerIds})
+	query := `
+	INSERT INTO object_lifecycle_policy (workspace_id, expire_after_days, expire_unused_after_days, retain_versions)
+	VALUES ($1, $2, $3, $4)
+	ON CONFLICT (workspace_id) DO UPDATE
+	SET expire_after_days = EXCLUDED.expire_after_days,
+		expire_unused_after_days = EXCLUDED.expire_unused_after_days,
+		retain_versions = EXCLUDED.retain_versions,
+		updated_at = CURRENT_TIMESTAMP
+	RETURNING ` + objectLifecyclePolicyColumns + `;
+	`
+	if err := r.client.GetContext(ctx, &policy, query, workspaceId, expireAfterDays, expireUnusedAfterDays, retainVersions); err != nil {
+		return nil, err
 	}
 
-	if len(filters.TaskIds) > 0 {
-		validTaskIds := []string{}
+	return &policy, nil
+}
 
-		for _, taskId := range filters.TaskIds {
-			// Filter out invalid UUIDs
-			if _, err := uuid.Parse(taskId); err == nil {
-				validTaskIds = append(validTaskIds, taskId)
-			}
-		}
+const objectVersionColumns = "id, external_id, object_id, hash, size, digest, compression, stored_size, encryption, wrapped_data_key, created_at"
 
-		if len(validTaskIds) > 0 {
-			qb = qb.Where(squirrel.Eq{"t.external_id": validTaskIds})
-		}
-	}
+const objectReplicaColumns = "id, object_id, region, status, error, created_at, updated_at"
 
-	if filters.CreatedAtStart != "" {
-		qb = qb.Where(squirrel.GtOrEq{"t.created_at": filters.CreatedAtStart})
-	}
+// ListObjectsPendingReplication returns complete objects with no copy in the region yet. Objects whose
+// replication failed are retried once their last attempt is older than retryBefore.
+func (r *PostgresBackendRepository) ListObjectsPendingReplication(ctx context.Context, workspaceId uint, region string, retryBefore time.Time, limit int) ([]types.Object, error) {
+	query := `
+	SELECT o.id, o.external_id, o.hash, o.size, o.workspace_id, o.created_at, o.retain_until, o.compression, o.stored_size, o.incomplete, o.key, o.region, o.digest, o.blob_id, o.last_used_at, o.tags, o.location, o.encryption, o.wrapped_data_key
+	FROM object o
+	LEFT JOIN object_replica r ON r.object_id = o.id AND r.region = $2
+	WHERE o.workspace_id = $1 AND o.incomplete = false AND o.region != $2
+	  AND (r.id IS NULL OR (r.status = $3 AND r.updated_at < $4))
+	ORDER BY o.id
+	LIMIT $5;
+	`
 
-	if filters.CreatedAtEnd != "" {
-		qb = qb.Where(squirrel.LtOrEq{"t.created_at": filters.CreatedAtEnd})
+	var objects []types.Object
+	if err := r.client.SelectContext(ctx, &objects, query, workspaceId, region, types.ObjectReplicaStatusFailed, retryBefore, limit); err != nil {
+		return nil, err
 	}
 
-	if filters.Offset > 0 {
-		qb = qb.Offset(uint64(filters.Offset))
-	}
+	return objects, nil
+}
 
-	if filters.Status != "" {
-		statuses := strings.Split(filters.Status, ",")
-		if len(statuses) > 0 {
-			qb = qb.Where(squirrel.Eq{"t.status": statuses})
-		}
-	}
+func (r *PostgresBackendRepository) UpdateObjectReplica(ctx context.Context, objectId uint, region string, status types.ObjectReplicaStatus, errMsg string) error {
+	query := `
+	INSERT INTO object_replica (object_id, region, status, error)
+	VALUES ($1, $2, $3, $4)
+	ON CONFLICT (object_id, region) DO UPDATE
+	SET status = EXCLUDED.status, error = EXCLUDED.error, updated_at = CURRENT_TIMESTAMP;
+	`
+	_, err := r.client.ExecContext(ctx, query, objectId, region, status, errMsg)
+	return err
+}
 
-	if filters.MinDuration > 0 {
-		qb = qb.Where("EXTRACT(EPOCH FROM (t.ended_at - t.started_at)) * 1000 >= ?", filters.MinDuration)
-	}
+func (r *PostgresBackendRepository) ListObjectReplicas(ctx context.Context, objectId uint) ([]types.ObjectReplica, error) {
+	var replicas []types.ObjectReplica
 
-	if filters.MaxDuration > 0 {
-		qb = qb.Where("EXTRACT(EPOCH FROM (t.ended_at - t.started_at)) * 1000 <= ?", filters.MaxDuration)
+	query := `SELECT ` + objectReplicaColumns + ` FROM object_replica WHERE object_id = $1 ORDER BY region;`
+	if err := r.client.SelectContext(ctx, &replicas, query, objectId); err != nil {
+		return nil, err
 	}
 
-	if filters.Limit > 0 {
-		qb = qb.Limit(uint64(filters.Limit))
+	return replicas, nil
+}
+
+const objectWebhookColumns = "id, external_id, workspace_id, url, secret, events, created_at"
+
+// CreateObjectWebhook subscribes url to the given events, generating the secret deliveries are signed with
+func (r *PostgresBackendRepository) CreateObjectWebhook(ctx context.Context, workspaceId uint, url string, events types.ObjectWebhookEventFilter) (*types.ObjectWebhook, error) {
+	var webhook types.ObjectWebhook
+
+	secretBytes := make([]byte, 32) // 256 bits
+	if _, err := rand.Read(secretBytes); err != nil {
+		return nil, err
 	}
+	secret := "whsec_" + base64.RawURLEncoding.EncodeToString(secretBytes)
 
-	if filters.AppId != "" {
-		qb = qb.Join("app a ON s.app_id = a.id")
-		qb = qb.Where(squirrel.Eq{"a.external_id": filters.AppId})
+	query := `
+	INSERT INTO object_webhook (workspace_id, url, secret, events)
+	VALUES ($1, $2, $3, $4)
+	RETURNING ` + objectWebhookColumns + `;
+	`
+	if err := r.client.GetContext(ctx, &webhook, query, workspaceId, url, secret, events); err != nil {
+		return nil, err
 	}
 
-	return qb
+	return &webhook, nil
 }
 
-func (c *PostgresBackendRepository) GetTaskCountPerDeployment(ctx context.Context, filters types.TaskFilter) ([]types.TaskCountPerDeployment, error) {
-	qb := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar).Select(
-		"d.name as deployment_name, COUNT(t.id) AS task_count",
-	).
-		From("deployment d").
-		Join("task t ON d.stub_id = t.stub_id").
-		GroupBy(
-			"d.name",
-		).OrderBy("task_count DESC")
-
-	// Apply filters
-	if filters.WorkspaceID > 0 {
-		qb = qb.Where(squirrel.Eq{"t.workspace_id": filters.WorkspaceID})
-	}
+func (r *PostgresBackendRepository) ListObjectWebhooks(ctx context.Context, workspaceId uint) ([]types.ObjectWebhook, error) {
+	var webhooks []types.ObjectWebhook
 
-	if len(filters.StubIds) > 0 || filters.AppId != "" {
-		qb = qb.Join("stub s ON t.stub_id = s.id")
+	query := `SELECT ` + objectWebhookColumns + ` FROM object_webhook WHERE workspace_id = $1 ORDER BY created_at;`
+	if err := r.client.SelectContext(ctx, &webhooks, query, workspaceId); err != nil {
+		return nil, err
 	}
 
-	if len(filters.StubIds) > 0 {
-		qb = qb.Where(squirrel.Eq{"s.external_id": filters.StubIds})
-	}
+	return webhooks, nil
+}
 
-	if filters.CreatedAtStart != "" {
-		qb = qb.Where(squirrel.GtOrEq{"t.created_at": filters.CreatedAtStart})
+// DeleteObjectWebhook removes a webhook along with its queued deliveries. It returns sql.ErrNoRows if the
+// workspace has no such webhook.
+func (r *PostgresBackendRepository) DeleteObjectWebhook(ctx context.Context, workspaceId uint, externalId string) error {
+	query := `DELETE FROM object_webhook WHERE external_id = $1 AND workspace_id = $2;`
+	result, err := r.client.ExecContext(ctx, query, externalId, workspaceId)
+	if err != nil {
+		return err
 	}
 
-	if filters.CreatedAtEnd != "" {
-		qb = qb.Where(squirrel.LtOrEq{"t.created_at": filters.CreatedAtEnd})
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
 	}
 
-	if filters.AppId != "" {
-		qb = qb.Join("app a ON s.app_id = a.id")
-		qb = qb.Where(squirrel.Eq{"a.external_id": filters.AppId})
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
 	}
 
-	sql, args, err := qb.ToSql()
-	if err != nil {
-		return nil, err
-	}
+	return nil
+}
 
-	var taskCounts []types.TaskCountPerDeployment
-	err = c.client.SelectContext(ctx, &taskCounts, sql, args...)
+// CreateObjectWebhookDeliveries queues a delivery of payload to every webhook in the workspace subscribed to
+// event, and returns the number queued
+func (r *PostgresBackendRepository) CreateObjectWebhookDeliveries(ctx context.Context, workspaceId uint, event types.WebhookEvent, payload string) (int64, error) {
+	query := `
+	INSERT INTO object_webhook_delivery (webhook_id, event, payload)
+	SELECT id, $2, $3 FROM object_webhook
+	WHERE workspace_id = $1 AND events ? $4;
+	`
+	result, err := r.client.ExecContext(ctx, query, workspaceId, event, payload, string(event))
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 
-	return taskCounts, nil
+	return result.RowsAffected()
 }
 
-func (c *PostgresBackendRepository) AggregateTasksByTimeWindow(ctx context.Context, filters types.TaskFilter) ([]types.TaskCountByTime, error) {
-	interval := strings.ToLower(filters.Interval)
-	if interval == "" {
-		interval = "hour"
+// ListDueObjectWebhookDeliveries returns pending deliveries whose next attempt is due, oldest first
+func (r *PostgresBackendRepository) ListDueObjectWebhookDeliveries(ctx context.Context, limit int) ([]types.ObjectWebhookDelivery, error) {
+	var deliveries []types.ObjectWebhookDelivery
+
+	query := `
+	SELECT d.id, d.external_id, d.webhook_id, d.event, d.payload, d.status, d.attempts, d.error, d.next_attempt_at, d.created_at, d.updated_at,
+	       w.id AS "webhook.id", w.external_id AS "webhook.external_id", w.workspace_id AS "webhook.workspace_id", w.url AS "webhook.url",
+	       w.secret AS "webhook.secret", w.events AS "webhook.events", w.created_at AS "webhook.created_at"
+	FROM object_webhook_delivery d
+	JOIN object_webhook w ON w.id = d.webhook_id
+	WHERE d.status = $1 AND d.next_attempt_at <= NOW()
+	ORDER BY d.next_attempt_at, d.id
+	LIMIT $2;
+	`
+	if err := r.client.SelectContext(ctx, &deliveries, query, types.ObjectWebhookDeliveryStatusPending, limit); err != nil {
+		return nil, err
 	}
 
-	date_truc := fmt.Sprintf("DATE_TRUNC('%s', t.created_at)", interval)
+	return deliveries, nil
+}
 
-	qb := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar).Select(
-		date_truc + ` as time, COUNT(t.id) as count,
-			json_build_object(
-				'COMPLETE', SUM(CASE WHEN t.status = 'COMPLETE' THEN 1 ELSE 0 END),
-				'ERROR', SUM(CASE WHEN t.status = 'ERROR' THEN 1 ELSE 0 END),
-				'CANCELLED', SUM(CASE WHEN t.status = 'CANCELLED' THEN 1 ELSE 0 END),
-				'TIMEOUT', SUM(CASE WHEN t.status = 'TIMEOUT' THEN 1 ELSE 0 END),
-				'RETRY', SUM(CASE WHEN t.status = 'RETRY' THEN 1 ELSE 0 END),
-				'PENDING', SUM(CASE WHEN t.status = 'PENDING' THEN 1 ELSE 0 END),
-				'RUNNING', SUM(CASE WHEN t.status = 'RUNNING' THEN 1 ELSE 0 END)
-			)::json AS status_counts`,
-	).
-		From("task t").GroupBy("time").OrderBy("time DESC")
+// UpdateObjectWebhookDelivery records the outcome of a delivery attempt
+func (r *PostgresBackendRepository) UpdateObjectWebhookDelivery(ctx context.Context, id uint, status types.ObjectWebhookDeliveryStatus, attempts int, nextAttemptAt time.Time, errMsg string) error {
+	query := `
+	UPDATE object_webhook_delivery
+	SET status = $2, attempts = $3, next_attempt_at = $4, error = $5, updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1;
+	`
+	_, err := r.client.ExecContext(ctx, query, id, status, attempts, nextAttemptAt, errMsg)
+	return err
+}
 
-	// Apply filters
-	if filters.WorkspaceID > 0 {
-		qb = qb.Where(squirrel.Eq{"t.workspace_id": filters.WorkspaceID})
+// DeleteFinishedObjectWebhookDeliveries removes delivered and failed deliveries last updated before the given time
+func (r *PostgresBackendRepository) DeleteFinishedObjectWebhookDeliveries(ctx context.Context, finishedBefore time.Time) (int64, error) {
+	query := `DELETE FROM object_webhook_delivery WHERE status <> $1 AND updated_at < $2;`
+	result, err := r.client.ExecContext(ctx, query, types.ObjectWebhookDeliveryStatusPending, finishedBefore)
+	if err != nil {
+		return 0, err
 	}
 
-	if len(filters.StubIds) > 0 || filters.AppId != "" {
-		qb = qb.Join("stub s ON t.stub_id = s.id")
-	}
+	return result.RowsAffected()
+}
 
-	if len(filters.StubIds) > 0 {
-		qb = qb.Where(squirrel.Eq{"s.external_id": filters.StubIds})
-	}
+func (r *PostgresBackendRepository) CreateObjectVersion(ctx context.Context, object *types.Object) (*types.ObjectVersion, error) {
+	var version types.ObjectVersion
 
-	if filters.AppId != "" {
-		qb = qb.Join("app a ON s.app_id = a.id")
-		qb = qb.Where(squirrel.Eq{"a.external_id": filters.AppId})
+	query := `
+	INSERT INTO object_version (object_id, hash, size, digest, compression, stored_size, encryption, wrapped_data_key)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	RETURNING ` + objectVersionColumns + `;
+	`
+	if err := r.client.GetContext(ctx, &version, query, object.Id, object.Hash, object.Size, object.Digest, object.Compression, object.StoredSize, object.Encryption, object.WrappedDataKey); err != nil {
+		return nil, err
 	}
 
-	if filters.CreatedAtStart != "" {
-		qb = qb.Where(squirrel.GtOrEq{"t.created_at": filters.CreatedAtStart})
-	}
+	return &version, nil
+}
 
-	if filters.CreatedAtEnd != "" {
-		qb = qb.Where(squirrel.LtOrEq{"t.created_at": filters.CreatedAtEnd})
-	}
+func (r *PostgresBackendRepository) GetObjectVersion(ctx context.Context, objectId uint, externalId string) (*types.ObjectVersion, error) {
+	var version types.ObjectVersion
 
-	sql, args, err := qb.ToSql()
-	if err != nil {
+	query := `SELECT ` + objectVersionColumns + ` FROM object_version WHERE object_id = $1 AND external_id = $2;`
+	if err := r.client.GetContext(ctx, &version, query, objectId, externalId); err != nil {
 		return nil, err
 	}
 
-	var taskCounts []types.TaskCountByTime
-	err = c.client.SelectContext(ctx, &taskCounts, sql, args...)
-	if err != nil {
-		log.Printf("%v", err)
+	return &version, nil
+}
+
+// ListObjectVersions returns an object's previous versions, newest first
+func (r *PostgresBackendRepository) ListObjectVersions(ctx context.Context, objectId uint) ([]types.ObjectVersion, error) {
+	var versions []types.ObjectVersion
+
+	query := `SELECT ` + objectVersionColumns + ` FROM object_version WHERE object_id = $1 ORDER BY created_at DESC, id DESC;`
+	if err := r.client.SelectContext(ctx, &versions, query, objectId); err != nil {
 		return nil, err
 	}
 
-	return taskCounts, nil
+	return versions, nil
 }
 
-func (c *PostgresBackendRepository) ListTasksWithRelated(ctx context.Context, filters types.TaskFilter) ([]types.TaskWithRelated, error) {
-	if filters.All {
-		filters.Limit = 0
+func (r *PostgresBackendRepository) DeleteObjectVersion(ctx context.Context, id uint) error {
+	_, err := r.client.ExecContext(ctx, `DELETE FROM object_version WHERE id = $1;`, id)
+	return err
+}
 
-		tasks, err := c.listAllTasksWithRelatedPaginated(ctx, filters)
-		if err != nil {
-			return nil, err
-		}
+func (r *PostgresBackendRepository) ListObjectsPaginated(ctx context.Context, filters types.ObjectFilter) (common.CursorPaginationInfo[types.Object], error) {
+	qb := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar).
+		Select(objectColumns).
+		From("object o").
+		Where(squirrel.Eq{"o.workspace_id": filters.WorkspaceID}).
+		Where(squirrel.Eq{"o.incomplete": false})
 
-		return tasks.Data, nil
+	if filters.Prefix != "" {
+		pattern := likePrefixEscaper.Replace(filters.Prefix) + "%"
+		qb = qb.Where(squirrel.Or{
+			squirrel.Like{"o.key": pattern},
+			squirrel.Like{"o.hash": pattern},
+		})
 	}
 
-	qb := c.listTaskWithRelatedQueryBuilder(filters)
-
-	sql, args, err := qb.ToSql()
-	if err != nil {
-		return nil, err
+	if filters.MinSize > 0 {
+		qb = qb.Where(squirrel.GtOrEq{"o.size": filters.MinSize})
 	}
 
-	var tasks []types.TaskWithRelated
-	err = c.client.SelectContext(ctx, &tasks, sql, args...)
-	if err != nil {
-		return nil, err
+	if filters.MaxSize > 0 {
+		qb = qb.Where(squirrel.LtOrEq{"o.size": filters.MaxSize})
 	}
 
-	return tasks, nil
-}
+	if filters.CreatedAtStart != "" {
+		qb = qb.Where(squirrel.GtOrEq{"o.created_at": filters.CreatedAtStart})
+	}
 
-func (c *PostgresBackendRepository) ListTasksWithRelatedPaginated(ctx context.Context, filters types.TaskFilter) (common.CursorPaginationInfo[types.TaskWithRelated], error) {
-	if filters.All {
-		return c.listAllTasksWithRelatedPaginated(ctx, filters)
+	if filters.CreatedAtEnd != "" {
+		qb = qb.Where(squirrel.LtOrEq{"o.created_at": filters.CreatedAtEnd})
 	}
 
-	qb := c.listTaskWithRelatedQueryBuilder(filters)
+	if len(filters.Tags) > 0 {
+		qb = qb.Where("o.tags @> ?", types.ObjectTags(filters.Tags))
+	}
 
 	page, err := common.Paginate(
-		common.SquirrelCursorPaginator[types.TaskWithRelated]{
-			Client:          c.client,
+		common.SquirrelCursorPaginator[types.Object]{
+			Client:          r.client,
 			SelectBuilder:   qb,
 			SortOrder:       "DESC",
 			SortColumn:      "created_at",
-			SortQueryPrefix: "t",
+			SortQueryPrefix: "o",
 			PageSize:        int(filters.Limit),
 		},
 		filters.Cursor,
 	)
 	if err != nil {
-		return common.CursorPaginationInfo[types.TaskWithRelated]{}, err
+		return common.CursorPaginationInfo[types.Object]{}, err
 	}
 
 	return *page, nil
 }
 
-func (c *PostgresBackendRepository) listAllTasksWithRelatedPaginated(ctx context.Context, filters types.TaskFilter) (common.CursorPaginationInfo[types.TaskWithRelated], error) {
-	// Apply limit// Apply limit
-	pageSize := filters.Limit
-	if pageSize <= 0 {
-		pageSize = 10
+// likePrefixEscaper escapes LIKE wildcards so user supplied prefixes match literally
+var likePrefixEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func (r *PostgresBackendRepository) GetObjectReferences(ctx context.Context, objectId uint) (*types.ObjectReferences, error) {
+	var references types.ObjectReferences
+
+	query := `
+	SELECT
+		(SELECT COUNT(*) FROM deployment d JOIN stub s ON d.stub_id = s.id
+			WHERE s.object_id = $1 AND d.active = true AND d.deleted_at IS NULL) AS active_deployments,
+		(SELECT COUNT(*) FROM task t JOIN stub s ON t.stub_id = s.id
+			WHERE s.object_id = $1 AND t.status = ANY($2)) AS inflight_tasks;
+	`
+	inflightStatuses := pq.Array([]string{string(types.TaskStatusPending), string(types.TaskStatusRunning), string(types.TaskStatusRetry)})
+	if err := r.client.GetContext(ctx, &references, query, objectId, inflightStatuses); err != nil {
+		return nil, err
 	}
 
-	filtersWorkspace := filters
-	filtersWorkspace.All = false
-	filtersWorkspace.WorkspaceID = filters.WorkspaceID
-	filtersWorkspace.ExternalWorkspaceID = 0
-	// We want to get one more result past the page size to check if there is more data
-	filtersWorkspace.Limit = pageSize + 1
+	return &references, nil
+}
 
-	filtersExternalWorkspace := filters
-	filtersExternalWorkspace.All = false
-	filtersExternalWorkspace.WorkspaceID = 0
-	filtersExternalWorkspace.ExternalWorkspaceID = filters.WorkspaceID
-	// We want to get one more result past the page size to check if there is more data
-	filtersExternalWorkspace.Limit = pageSize + 1
+func (r *PostgresBackendRepository) GetObjectStorageUsage(ctx context.Context, workspaceId uint) (*types.ObjectStorageUsage, error) {
+	var usage types.ObjectStorageUsage
 
-	// Get results from both calls
-	resultWorkspace, err := c.ListTasksWithRelatedPaginated(ctx, filtersWorkspace)
-	if err != nil {
-		return common.CursorPaginationInfo[types.TaskWithRelated]{}, err
+	query := `
+	SELECT
+		w.object_storage_used AS used_bytes,
+		(SELECT COUNT(*) FROM object o WHERE o.workspace_id = w.id) AS object_count
+	FROM workspace w
+	WHERE w.id = $1;
+	`
+	if err := r.client.GetContext(ctx, &usage, query, workspaceId); err != nil {
+		return nil, err
 	}
 
-	resultExternalWorkspace, err := c.ListTasksWithRelatedPaginated(ctx, filtersExternalWorkspace)
-	if err != nil {
-		return common.CursorPaginationInfo[types.TaskWithRelated]{}, err
-	}
+	return &usage, nil
+}
 
-	// Merge and sort results
-	allTasks := append(resultWorkspace.Data, resultExternalWorkspace.Data...)
+func (r *PostgresBackendRepository) GetWorkspaceObjectKey(ctx context.Context, workspaceId uint) (string, error) {
+	var wrappedKey string
 
-	// Sort by created_at DESC, id DESC
-	sort.Slice(allTasks, func(i, j int) bool {
-		if allTasks[i].CreatedAt.Equal(allTasks[j].CreatedAt.Time) {
-			return allTasks[i].Id > allTasks[j].Id
-		}
+	query := `SELECT wrapped_key FROM workspace_object_key WHERE workspace_id = $1;`
+	if err := r.client.GetContext(ctx, &wrappedKey, query, workspaceId); err != nil {
+		return "", err
+	}
 
-		return allTasks[i].CreatedAt.After(allTasks[j].CreatedAt.Time)
-	})
+	return wrappedKey, nil
+}
 
-	var nextCursor string
-	if len(allTasks) > int(pageSize) {
-		allTasks = allTasks[:pageSize]
-		lastRow := allTasks[pageSize-1]
+// CreateWorkspaceObjectKey stores the workspace's wrapped data key. If another gateway created one
+// first, that key is returned instead so every object in the workspace shares the same key.
+func (r *PostgresBackendRepository) CreateWorkspaceObjectKey(ctx context.Context, workspaceId uint, wrappedKey string) (string, error) {
+	query := `
+	WITH inserted AS (
+		INSERT INTO workspace_object_key (workspace_id, wrapped_key)
+		VALUES ($1, $2)
+		ON CONFLICT (workspace_id) DO NOTHING
+		RETURNING wrapped_key
+	)
+	SELECT wrapped_key FROM inserted
+	UNION ALL
+	SELECT wrapped_key FROM workspace_object_key WHERE workspace_id = $1
+	LIMIT 1;
+	`
 
-		newCursor := common.DatetimeCursor{
-			Value: lastRow.CreatedAt.Format(common.CursorTimestampFormat),
-			Id:    lastRow.Id,
-		}
-		nextCursor = common.EncodeCursor(newCursor)
+	var storedKey string
+	if err := r.client.GetContext(ctx, &storedKey, query, workspaceId, wrappedKey); err != nil {
+		return "", err
 	}
 
-	return common.CursorPaginationInfo[types.TaskWithRelated]{
-		Next: nextCursor,
-		Data: allTasks,
-	}, nil
+	return storedKey, nil
 }
 
-// Stub
-
-func (r *PostgresBackendRepository) GetOrCreateStub(ctx context.Context, name, stubType string, config types.StubConfigV1, objectId, workspaceId uint, forceCreate bool, appId uint) (types.Stub, error) {
-	var stub types.Stub
+const objectBlobColumns = "id, digest, size, compression, stored_size, ref_count, created_at"
 
-	// Serialize config to JSON
-	configJSON, err := json.Marshal(config)
+// LinkObjectBlob points an object at the blob holding its content and takes a reference on it, creating the
+// blob if this is the first object with that digest. A returned RefCount of 1 means the blob is new.
+func (r *PostgresBackendRepository) LinkObjectBlob(ctx context.Context, externalId string, digest string, size, storedSize int64, compression types.ObjectCompression) (*types.ObjectBlob, error) {
+	tx, err := r.client.BeginTxx(ctx, nil)
 	if err != nil {
-		return types.Stub{}, err
+		return nil, err
 	}
+	defer tx.Rollback()
 
-	// @Luke slightly concerned that this is can get the wrong stub. If name + stubtype + objectId + config matches (Likely can happen from clone but haven't seen it yet)
-	if !forceCreate {
-		// Query to check if a stub with the same name, type, object_id, and config exists
-		queryGet := `
-    SELECT id, external_id, name, type, config, config_version, object_id, workspace_id, created_at, updated_at, app_id
-    FROM stub
-    WHERE name = $1 AND type = $2 AND object_id = $3 AND config::jsonb = $4::jsonb;
-    `
-		err = r.client.GetContext(ctx, &stub, queryGet, name, stubType, objectId, string(configJSON))
-		if err == nil {
-			// Stub found, return it
-			return stub, nil
-		}
+	insertQuery := `
+	INSERT INTO object_blob (digest, size, stored_size, compression)
+	VALUES ($1, $2, $3, $4)
+	ON CONFLICT (digest) DO NOTHING;
+	`
+	if _, err := tx.ExecContext(ctx, insertQuery, digest, size, storedSize, compression); err != nil {
+		return nil, err
 	}
 
-	// Stub not found, create a new one
-	queryCreate := `
-    INSERT INTO stub (name, type, config, object_id, workspace_id, app_id)
-    VALUES ($1, $2, $3, $4, $5, $6)
-    RETURNING id, external_id, name, type, config, config_version, object_id, workspace_id, created_at, updated_at, app_id;
-  `
-	if err := r.client.GetContext(ctx, &stub, queryCreate, name, stubType, string(configJSON), objectId, workspaceId, appId); err != nil {
-		return types.Stub{}, err
+	var blob types.ObjectBlob
+	referenceQuery := `UPDATE object_blob SET ref_count = ref_count + 1 WHERE digest = $1 RETURNING ` + objectBlobColumns + `;`
+	if err := tx.GetContext(ctx, &blob, referenceQuery, digest); err != nil {
+		return nil, err
 	}
 
-	if err := r.updateAppActivity(ctx, appId); err != nil {
-		return stub, err
+	if _, err := tx.ExecContext(ctx, `UPDATE object SET blob_id = $2 WHERE external_id = $1;`, externalId, blob.Id); err != nil {
+		return nil, err
 	}
 
-	return stub, nil
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &blob, nil
 }
 
-func (r *PostgresBackendRepository) UpdateStubConfig(ctx context.Context, stubId uint, config *types.StubConfigV1) error {
-	configJSON, err := json.Marshal(config)
+// ReleaseObjectBlob drops an object's reference on its blob. It returns the blob and whether that was the
+// last reference, in which case the blob has been removed and the caller should delete its data.
+func (r *PostgresBackendRepository) ReleaseObjectBlob(ctx context.Context, externalId string) (*types.ObjectBlob, bool, error) {
+	tx, err := r.client.BeginTxx(ctx, nil)
 	if err != nil {
-		return err
+		return nil, false, err
 	}
+	defer tx.Rollback()
 
-	query := `UPDATE stub SET config = $1 WHERE id = $2;`
-	_, err = r.client.ExecContext(ctx, query, string(configJSON), stubId)
-	if err != nil {
-		return err
+	var blobId sql.NullInt64
+	if err := tx.GetContext(ctx, &blobId, `SELECT blob_id FROM object WHERE external_id = $1 FOR UPDATE;`, externalId); err != nil {
+		return nil, false, err
 	}
 
-	return nil
-}
+	if !blobId.Valid {
+		return nil, false, nil
+	}
 
-func (r *PostgresBackendRepository) GetStubByExternalId(ctx context.Context, externalId string, queryFilters ...types.QueryFilter) (*types.StubWithRelated, error) {
-	var stub types.StubWithRelated
-	qb := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar).Select(
-		`s.id, s.external_id, s.name, s.type, s.config, s.config_version, s.object_id, s.workspace_id, s.created_at, s.updated_at, s.public, s.app_id,
-	    w.id AS "workspace.id", w.external_id AS "workspace.external_id", w.name AS "workspace.name", w.created_at AS "workspace.created_at", w.updated_at AS "workspace.updated_at", w.signing_key AS "workspace.signing_key", w.volume_cache_enabled AS "workspace.volume_cache_enabled", w.multi_gpu_enabled AS "workspace.multi_gpu_enabled",
-	    o.id AS "object.id", o.external_id AS "object.external_id", o.hash AS "object.hash", o.size AS "object.size", o.workspace_id AS "object.workspace_id", o.created_at AS "object.created_at",
-			a.id as "app.id", a.external_id as "app.external_id", a.name as "app.name"
-		`,
-		`ws.id AS "workspace.storage.id", ws.external_id AS "workspace.storage.external_id", ws.bucket_name AS "workspace.storage.bucket_name", ws.access_key AS "workspace.storage.access_key", ws.secret_key AS "workspace.storage.secret_key", 
-		ws.endpoint_url AS "workspace.storage.endpoint_url", ws.region AS "workspace.storage.region", ws.created_at AS "workspace.storage.created_at", ws.updated_at AS "workspace.storage.updated_at"`,
-	).
-		From("stub s").
-		Join("workspace w ON s.workspace_id = w.id").
-		Join("app a ON s.app_id = a.id").
-		LeftJoin("object o ON s.object_id = o.id").
-		LeftJoin("workspace_storage ws ON w.storage_id = ws.id").
-		Where(squirrel.Eq{"s.external_id": externalId})
+	if _, err := tx.ExecContext(ctx, `UPDATE object SET blob_id = NULL WHERE external_id = $1;`, externalId); err != nil {
+		return nil, false, err
+	}
 
-	var stubFilters types.StubFilter
-	types.ParseConditionFromQueryFilters(&stubFilters, queryFilters...)
+	var blob types.ObjectBlob
+	releaseQuery := `UPDATE object_blob SET ref_count = ref_count - 1 WHERE id = $1 RETURNING ` + objectBlobColumns + `;`
+	if err := tx.GetContext(ctx, &blob, releaseQuery, blobId.Int64); err != nil {
+		return nil, false, err
+	}
 
-	if stubFilters.WorkspaceID != "" {
-		qb = qb.Where(squirrel.Eq{"w.external_id": stubFilters.WorkspaceID})
+	removed := blob.RefCount <= 0
+	if removed {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM object_blob WHERE id = $1;`, blob.Id); err != nil {
+			return nil, false, err
+		}
 	}
 
-	query, args, err := qb.ToSql()
-	if err != nil {
-		return nil, err
+	if err := tx.Commit(); err != nil {
+		return nil, false, err
 	}
 
-	err = r.client.GetContext(ctx, &stub, query, args...)
+	return &blob, removed, nil
+}
+
+// DeleteObjectAndReleaseBlob deletes an object and drops its reference on its blob in one transaction, so an
+// object that fails to delete keeps its blob. It returns the blob and whether that was the last reference, like
+// ReleaseObjectBlob. Locked objects are refused with *types.ErrObjectLocked.
+func (r *PostgresBackendRepository) DeleteObjectAndReleaseBlob(ctx context.Context, externalId string) (*types.ObjectBlob, bool, error) {
+	tx, err := r.client.BeginTxx(ctx, nil)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil
+		return nil, false, err
+	}
+	defer tx.Rollback()
+
+	var object struct {
+		BlobId      sql.NullInt64  `db:"blob_id"`
+		RetainUntil types.NullTime `db:"retain_until"`
+		Locked      bool           `db:"locked"`
+	}
+	lockQuery := `SELECT blob_id, retain_until, COALESCE(retain_until > NOW(), false) AS locked FROM object WHERE external_id = $1 FOR UPDATE;`
+	if err := tx.GetContext(ctx, &object, lockQuery, externalId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, false, nil
 		}
-		return &types.StubWithRelated{}, err
+		return nil, false, err
+	}
+
+	if object.Locked {
+		return nil, false, &types.ErrObjectLocked{ObjectId: externalId, RetainUntil: object.RetainUntil.Time}
+	}
+
+	deleteQuery := `
+	WITH deleted AS (
+		DELETE FROM object WHERE external_id = $1
+		RETURNING workspace_id, size
+	)
+	UPDATE workspace w
+	SET object_storage_used = GREATEST(w.object_storage_used - d.size, 0)
+	FROM deleted d
+	WHERE w.id = d.workspace_id;
+	`
+	if _, err := tx.ExecContext(ctx, deleteQuery, externalId); err != nil {
+		return nil, false, err
+	}
+
+	if !object.BlobId.Valid {
+		return nil, false, tx.Commit()
+	}
+
+	var blob types.ObjectBlob
+	releaseQuery := `UPDATE object_blob SET ref_count = ref_count - 1 WHERE id = $1 RETURNING ` + objectBlobColumns + `;`
+	if err := tx.GetContext(ctx, &blob, releaseQuery, object.BlobId.Int64); err != nil {
+		return nil, false, err
+	}
+
+	removed := blob.RefCount <= 0
+	if removed {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM object_blob WHERE id = $1;`, blob.Id); err != nil {
+			return nil, false, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, false, err
+	}
+
+	return &blob, removed, nil
+}
+
+const objectUploadSessionColumns = `id, external_id, workspace_id, hash, size, "offset", chunk_size, created_at, updated_at`
+
+func (r *PostgresBackendRepository) CreateObjectUploadSession(ctx context.Context, workspaceId uint, hash string, size int64, chunkSize int64) (*types.ObjectUploadSession, error) {
+	var session types.ObjectUploadSession
+
+	query := `
+    INSERT INTO object_upload_session (workspace_id, hash, size, chunk_size)
+    VALUES ($1, $2, $3, $4)
+    RETURNING ` + objectUploadSessionColumns + `;
+    `
+
+	if err := r.client.GetContext(ctx, &session, query, workspaceId, hash, size, chunkSize); err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+func (r *PostgresBackendRepository) GetObjectUploadSession(ctx context.Context, externalId string, workspaceId uint) (*types.ObjectUploadSession, error) {
+	var session types.ObjectUploadSession
+
+	query := `SELECT ` + objectUploadSessionColumns + ` FROM object_upload_session WHERE external_id = $1 AND workspace_id = $2;`
+	if err := r.client.GetContext(ctx, &session, query, externalId, workspaceId); err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+func (r *PostgresBackendRepository) UpdateObjectUploadSessionOffset(ctx context.Context, externalId string, offset int64) error {
+	query := `
+	UPDATE object_upload_session
+	SET "offset" = $2, updated_at = CURRENT_TIMESTAMP
+	WHERE external_id = $1;
+	`
+	_, err := r.client.ExecContext(ctx, query, externalId, offset)
+	return err
+}
+
+// RecordObjectUploadChunk marks a chunk of a chunked session as received and returns the total size of the
+// chunks received so far. Recording the same chunk again doesn't count it twice.
+func (r *PostgresBackendRepository) RecordObjectUploadChunk(ctx context.Context, sessionId uint, index int64, size int64) (int64, error) {
+	var offset int64
+
+	query := `
+	WITH inserted AS (
+		INSERT INTO object_upload_chunk (session_id, chunk_index, size)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (session_id, chunk_index) DO NOTHING
+		RETURNING size
+	)
+	UPDATE object_upload_session
+	SET "offset" = "offset" + COALESCE((SELECT size FROM inserted), 0), updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1
+	RETURNING "offset";
+	`
+	if err := r.client.GetContext(ctx, &offset, query, sessionId, index, size); err != nil {
+		return 0, err
+	}
+
+	return offset, nil
+}
+
+func (r *PostgresBackendRepository) ListObjectUploadChunks(ctx context.Context, sessionId uint) ([]int64, error) {
+	var indexes []int64
+
+	query := `SELECT chunk_index FROM object_upload_chunk WHERE session_id = $1 ORDER BY chunk_index;`
+	if err := r.client.SelectContext(ctx, &indexes, query, sessionId); err != nil {
+		return nil, err
+	}
+
+	return indexes, nil
+}
+
+func (r *PostgresBackendRepository) DeleteObjectUploadSession(ctx context.Context, externalId string) error {
+	query := `DELETE FROM object_upload_session WHERE external_id = $1;`
+	_, err := r.client.ExecContext(ctx, query, externalId)
+	return err
+}
+
+// DeleteStaleObjectUploadSessions removes sessions with no activity since inactiveSince and
+// returns them so the caller can clean up any partially uploaded data
+func (r *PostgresBackendRepository) DeleteStaleObjectUploadSessions(ctx context.Context, inactiveSince time.Time) ([]types.ObjectUploadSession, error) {
+	var sessions []types.ObjectUploadSession
+
+	query := `DELETE FROM object_upload_session WHERE updated_at < $1 RETURNING ` + objectUploadSessionColumns + `;`
+	if err := r.client.SelectContext(ctx, &sessions, query, inactiveSince); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// Task
+
+func (r *PostgresBackendRepository) handleTaskEvent(taskId string, callback func(*types.TaskWithRelated)) {
+	task, err := r.GetTaskWithRelated(context.Background(), taskId)
+	if err != nil {
+		return
+	}
+
+	callback(task)
+}
+
+func (r *PostgresBackendRepository) CreateTask(ctx context.Context, params *types.TaskParams) (*types.Task, error) {
+	if params.TaskId == "" {
+		externalId, err := r.generateExternalId()
+		if err != nil {
+			return nil, err
+		}
+
+		params.TaskId = externalId
+	}
+
+	query := `
+    INSERT INTO task (external_id, container_id, workspace_id, external_workspace_id, stub_id)
+    VALUES ($1, $2, $3, $4, $5)
+    RETURNING id, external_id, status, container_id, workspace_id, external_workspace_id, stub_id, started_at, ended_at, created_at, updated_at;
+    `
+
+	var newTask types.Task
+
+	if err := r.client.GetContext(ctx, &newTask, query, params.TaskId, params.ContainerId, params.WorkspaceId, params.ExternalWorkspaceId, params.StubId); err != nil {
+		return &types.Task{}, err
+	}
+
+	go r.handleTaskEvent(params.TaskId, r.eventRepo.PushTaskCreatedEvent)
+	return &newTask, nil
+}
+
+func (r *PostgresBackendRepository) UpdateTask(ctx context.Context, externalId string, updatedTask types.Task) (*types.Task, error) {
+	query := `
+	UPDATE task
+	SET status = $2, container_id = $3, started_at = $4, ended_at = $5, workspace_id = $6, stub_id = $7, gpu = $8, updated_at = CURRENT_TIMESTAMP
+	WHERE external_id = $1
+	RETURNING id, external_id, status, container_id, gpu, workspace_id, stub_id, started_at, ended_at, created_at, updated_at;
+	`
+
+	var task types.Task
+	if err := r.client.GetContext(ctx, &task, query,
+		externalId, updatedTask.Status, updatedTask.ContainerId,
+		updatedTask.StartedAt, updatedTask.EndedAt,
+		updatedTask.WorkspaceId, updatedTask.StubId, updatedTask.Gpu); err != nil {
+		return &types.Task{}, err
+	}
+
+	go r.handleTaskEvent(externalId, r.eventRepo.PushTaskUpdatedEvent)
+	return &task, nil
+}
+
+func (r *PostgresBackendRepository) DeleteTask(ctx context.Context, externalId string) error {
+	query := `DELETE FROM task WHERE external_id = $1;`
+	_, err := r.client.ExecContext(ctx, query, externalId)
+	return err
+}
+
+func (r *PostgresBackendRepository) GetTask(ctx context.Context, externalId string) (*types.Task, error) {
+	var task types.Task
+	query := `SELECT id, external_id, status, container_id, gpu, started_at, ended_at, workspace_id, external_workspace_id, stub_id, created_at, updated_at FROM task WHERE external_id = $1;`
+	err := r.client.GetContext(ctx, &task, query, externalId)
+	if err != nil {
+		return &types.Task{}, err
+	}
+
+	return &task, nil
+}
+
+func (r *PostgresBackendRepository) GetTaskStatus(ctx context.Context, externalId string) (types.TaskStatus, error) {
+	var status string
+	query := `SELECT status FROM task WHERE external_id = $1;`
+	err := r.client.GetContext(ctx, &status, query, externalId)
+	if err != nil {
+		return "", err
+	}
+
+	return types.TaskStatus(status), nil
+}
+
+func (r *PostgresBackendRepository) GetTaskWithRelated(ctx context.Context, externalId string) (*types.TaskWithRelated, error) {
+	var taskWithRelated types.TaskWithRelated
+	query := `
+	SELECT
+		t.*,
+		w.external_id AS "workspace.external_id",
+		w.name AS "workspace.name",
+		w.created_at AS "workspace.created_at",
+		w.updated_at AS "workspace.updated_at",
+		ew.id AS "external_workspace.id",
+		ew.external_id AS "external_workspace.external_id",
+		ew.name AS "external_workspace.name",
+		s.external_id AS "stub.external_id",
+		s.name AS "stub.name",
+		s.config AS "stub.config",
+		s.type AS "stub.type",
+		s.app_id AS "app.id",
+		a.external_id AS "app.external_id",
+		a.name AS "app.name",
+		a.external_id AS "app.external_id",
+		d.name AS "deployment.name",
+		d.version AS "deployment.version",
+		d.external_id AS "deployment.external_id"
+	FROM task t
+	JOIN workspace w ON t.workspace_id = w.id
+	JOIN stub s ON t.stub_id = s.id
+	JOIN app a ON s.app_id = a.id
+	LEFT JOIN workspace ew ON ew.id = t.external_workspace_id
+	LEFT JOIN deployment d ON d.stub_id = s.id
+	WHERE t.external_id = $1;
+	`
+
+	err := r.client.GetContext(ctx, &taskWithRelated, query, externalId)
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok && err.Code.Class() == PostgresDataError {
+			return nil, nil
+		}
+
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	// If external_workspace.id is nil, set ExternalWorkspace to nil
+	if taskWithRelated.ExternalWorkspace != nil && taskWithRelated.ExternalWorkspace.Id == nil {
+		taskWithRelated.ExternalWorkspace = nil
+	}
+
+	return &taskWithRelated, nil
+}
+
+func (r *PostgresBackendRepository) GetTaskByWorkspace(ctx context.Context, externalId string, workspace *types.Workspace) (*types.TaskWithRelated, error) {
+	var taskWithRelated types.TaskWithRelated
+	query := `
+	SELECT
+		w.external_id AS "workspace.external_id", w.name AS "workspace.name", w.updated_at AS "workspace.updated_at", w.created_at AS "workspace.created_at", t.*,
+		s.external_id AS "stub.external_id", s.name AS "stub.name", s.config AS "stub.config", s.type AS "stub.type", s.created_at AS "stub.created_at", s.updated_at AS "stub.updated_at"
+	FROM task t
+	JOIN workspace w ON t.workspace_id = w.id
+	JOIN stub s ON t.stub_id = s.id
+	WHERE
+		t.external_id = $1
+		AND w.id = $2;
+    `
+	err := r.client.GetContext(ctx, &taskWithRelated, query, externalId, workspace.Id)
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok && err.Code.Class() == PostgresDataError {
+			return nil, nil
+		}
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &taskWithRelated, nil
+}
+
+const taskRetryPolicyColumns = "stub_id, max_attempts, backoff_strategy, backoff_seconds, max_backoff_seconds, retry_on_exit_codes, created_at, updated_at"
+
+func (r *PostgresBackendRepository) GetTaskRetryPolicy(ctx context.Context, stubExternalId string) (*types.TaskRetryPolicy, error) {
+	var policy types.TaskRetryPolicy
+
+	query := `
+	SELECT p.stub_id, p.max_attempts, p.backoff_strategy, p.backoff_seconds, p.max_backoff_seconds, p.retry_on_exit_codes, p.created_at, p.updated_at
+	FROM task_retry_policy p
+	JOIN stub s ON p.stub_id = s.id
+	WHERE s.external_id = $1;
+	`
+	if err := r.client.GetContext(ctx, &policy, query, stubExternalId); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+func (r *PostgresBackendRepository) SetTaskRetryPolicy(ctx context.Context, policy types.TaskRetryPolicy) (*types.TaskRetryPolicy, error) {
+	var updated types.TaskRetryPolicy
+
+	query := `
+	INSERT INTO task_retry_policy (stub_id, max_attempts, backoff_strategy, backoff_seconds, max_backoff_seconds, retry_on_exit_codes)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	ON CONFLICT (stub_id) DO UPDATE
+	SET max_attempts = EXCLUDED.max_attempts,
+		backoff_strategy = EXCLUDED.backoff_strategy,
+		backoff_seconds = EXCLUDED.backoff_seconds,
+		max_backoff_seconds = EXCLUDED.max_backoff_seconds,
+		retry_on_exit_codes = EXCLUDED.retry_on_exit_codes,
+		updated_at = CURRENT_TIMESTAMP
+	RETURNING ` + taskRetryPolicyColumns + `;
+	`
+	if err := r.client.GetContext(ctx, &updated, query, policy.StubId, policy.MaxAttempts, policy.BackoffStrategy, policy.BackoffSeconds, policy.MaxBackoffSeconds, policy.RetryOnExitCodes); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+func (r *PostgresBackendRepository) DeleteTaskRetryPolicy(ctx context.Context, stubId uint) error {
+	_, err := r.client.ExecContext(ctx, `DELETE FROM task_retry_policy WHERE stub_id = $1;`, stubId)
+	return err
+}
+
+func (r *PostgresBackendRepository) CreateTaskAttempt(ctx context.Context, taskExternalId string, attempt types.TaskAttempt) error {
+	query := `
+	INSERT INTO task_attempt (task_id, attempt, container_id, exit_code, reason, retried)
+	SELECT id, $2, $3, $4, $5, $6 FROM task WHERE external_id = $1;
+	`
+
+	_, err := r.client.ExecContext(ctx, query, taskExternalId, attempt.Attempt, attempt.ContainerId, attempt.ExitCode, attempt.Reason, attempt.Retried)
+	return err
+}
+
+// ListTaskAttempts returns the recorded attempts of the given tasks, oldest first
+func (r *PostgresBackendRepository) ListTaskAttempts(ctx context.Context, taskIds []uint) ([]types.TaskAttempt, error) {
+	attempts := []types.TaskAttempt{}
+	if len(taskIds) == 0 {
+		return attempts, nil
+	}
+
+	ids := make([]int64, len(taskIds))
+	for i, id := range taskIds {
+		ids[i] = int64(id)
+	}
+
+	query := `
+	SELECT id, task_id, attempt, container_id, exit_code, reason, retried, created_at
+	FROM task_attempt
+	WHERE task_id = ANY($1)
+	ORDER BY task_id, attempt;
+	`
+	if err := r.client.SelectContext(ctx, &attempts, query, pq.Array(ids)); err != nil {
+		return nil, err
+	}
+
+	return attempts, nil
+}
+
+func (r *PostgresBackendRepository) GetAutoscalingPolicy(ctx context.Context, stubExternalId string) (*types.AutoscalingPolicy, error) {
+	var policy types.AutoscalingPolicy
+
+	query := `
+	SELECT p.stub_id, p.metric, p.target_value, p.created_at, p.updated_at
+	FROM autoscaling_policy p
+	JOIN stub s ON p.stub_id = s.id
+	WHERE s.external_id = $1;
+	`
+	if err := r.client.GetContext(ctx, &policy, query, stubExternalId); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+func (r *PostgresBackendRepository) SetAutoscalingPolicy(ctx context.Context, policy types.AutoscalingPolicy) (*types.AutoscalingPolicy, error) {
+	var updated types.AutoscalingPolicy
+
+	query := `
+	INSERT INTO autoscaling_policy (stub_id, metric, target_value)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (stub_id) DO UPDATE
+	SET metric = EXCLUDED.metric,
+		target_value = EXCLUDED.target_value,
+		updated_at = CURRENT_TIMESTAMP
+	RETURNING stub_id, metric, target_value, created_at, updated_at;
+	`
+	if err := r.client.GetContext(ctx, &updated, query, policy.StubId, policy.Metric, policy.TargetValue); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+func (r *PostgresBackendRepository) DeleteAutoscalingPolicy(ctx context.Context, stubId uint) error {
+	_, err := r.client.ExecContext(ctx, `DELETE FROM autoscaling_policy WHERE stub_id = $1;`, stubId)
+	return err
+}
+
+func (r *PostgresBackendRepository) GetKeepWarmPolicy(ctx context.Context, stubExternalId string) (*types.KeepWarmPolicy, error) {
+	var policy types.KeepWarmPolicy
+
+	query := `
+	SELECT p.stub_id, p.schedule, p.timezone, p.containers, p.created_at, p.updated_at
+	FROM keep_warm_policy p
+	JOIN stub s ON p.stub_id = s.id
+	WHERE s.external_id = $1;
+	`
+	if err := r.client.GetContext(ctx, &policy, query, stubExternalId); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+func (r *PostgresBackendRepository) SetKeepWarmPolicy(ctx context.Context, policy types.KeepWarmPolicy) (*types.KeepWarmPolicy, error) {
+	var updated types.KeepWarmPolicy
+
+	query := `
+	INSERT INTO keep_warm_policy (stub_id, schedule, timezone, containers)
+	VALUES ($1, $2, $3, $4)
+	ON CONFLICT (stub_id) DO UPDATE
+	SET schedule = EXCLUDED.schedule,
+		timezone = EXCLUDED.timezone,
+		containers = EXCLUDED.containers,
+		updated_at = CURRENT_TIMESTAMP
+	RETURNING stub_id, schedule, timezone, containers, created_at, updated_at;
+	`
+	if err := r.client.GetContext(ctx, &updated, query, policy.StubId, policy.Schedule, policy.Timezone, policy.Containers); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+func (r *PostgresBackendRepository) DeleteKeepWarmPolicy(ctx context.Context, stubId uint) error {
+	_, err := r.client.ExecContext(ctx, `DELETE FROM keep_warm_policy WHERE stub_id = $1;`, stubId)
+	return err
+}
+
+const outputRetentionPolicyColumns = "p.stub_id, s.external_id AS stub_external_id, s.workspace_id, p.max_age_seconds, p.max_count, p.max_total_bytes, p.created_at, p.updated_at"
+
+func (r *PostgresBackendRepository) GetOutputRetentionPolicy(ctx context.Context, stubExternalId string) (*types.OutputRetentionPolicy, error) {
+	var policy types.OutputRetentionPolicy
+
+	query := `
+	SELECT ` + outputRetentionPolicyColumns + `
+	FROM output_retention_policy p
+	JOIN stub s ON p.stub_id = s.id
+	WHERE s.external_id = $1;
+	`
+	if err := r.client.GetContext(ctx, &policy, query, stubExternalId); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+func (r *PostgresBackendRepository) SetOutputRetentionPolicy(ctx context.Context, policy types.OutputRetentionPolicy) (*types.OutputRetentionPolicy, error) {
+	var updated types.OutputRetentionPolicy
+
+	query := `
+	WITH p AS (
+		INSERT INTO output_retention_policy (stub_id, max_age_seconds, max_count, max_total_bytes)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (stub_id) DO UPDATE
+		SET max_age_seconds = EXCLUDED.max_age_seconds,
+			max_count = EXCLUDED.max_count,
+			max_total_bytes = EXCLUDED.max_total_bytes,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING stub_id, max_age_seconds, max_count, max_total_bytes, created_at, updated_at
+	)
+	SELECT ` + outputRetentionPolicyColumns + `
+	FROM p
+	JOIN stub s ON p.stub_id = s.id;
+	`
+	if err := r.client.GetContext(ctx, &updated, query, policy.StubId, policy.MaxAgeSeconds, policy.MaxCount, policy.MaxTotalBytes); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+func (r *PostgresBackendRepository) DeleteOutputRetentionPolicy(ctx context.Context, stubId uint) error {
+	_, err := r.client.ExecContext(ctx, `DELETE FROM output_retention_policy WHERE stub_id = $1;`, stubId)
+	return err
+}
+
+func (r *PostgresBackendRepository) ListOutputRetentionPolicies(ctx context.Context) ([]types.OutputRetentionPolicy, error) {
+	var policies []types.OutputRetentionPolicy
+
+	query := `
+	SELECT ` + outputRetentionPolicyColumns + `
+	FROM output_retention_policy p
+	JOIN stub s ON p.stub_id = s.id
+	ORDER BY s.workspace_id, p.stub_id;
+	`
+	if err := r.client.SelectContext(ctx, &policies, query); err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+// CancelTasks marks the workspace's in-flight tasks matching the filters as cancelled, returning the tasks it updated
+func (r *PostgresBackendRepository) CancelTasks(ctx context.Context, filters types.TaskFilter) ([]types.TaskWithRelated, error) {
+	return r.updateInFlightTasks(ctx, filters, types.TaskStatusCancelled)
+}
+
+// RequeueTasks marks the workspace's in-flight tasks matching the filters for retry, returning the tasks it updated
+func (r *PostgresBackendRepository) RequeueTasks(ctx context.Context, filters types.TaskFilter) ([]types.TaskWithRelated, error) {
+	return r.updateInFlightTasks(ctx, filters, types.TaskStatusRetry)
+}
+
+// updateInFlightTasks moves pending, running and retrying tasks matching the filters to status. The tasks are
+// locked while they're selected and updated, so concurrent bulk operations never act on the same task twice.
+func (r *PostgresBackendRepository) updateInFlightTasks(ctx context.Context, filters types.TaskFilter, status types.TaskStatus) ([]types.TaskWithRelated, error) {
+	requested := []string{}
+	if filters.Status != "" {
+		requested = strings.Split(filters.Status, ",")
+	}
+
+	statuses := []string{}
+	for _, s := range []types.TaskStatus{types.TaskStatusPending, types.TaskStatusRunning, types.TaskStatusRetry} {
+		if len(requested) == 0 || slices.Contains(requested, string(s)) {
+			statuses = append(statuses, string(s))
+		}
+	}
+
+	tasks := []types.TaskWithRelated{}
+	if len(statuses) == 0 {
+		return tasks, nil
+	}
+
+	qb := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar).Select("t.id").
+		From("task t").
+		Where(squirrel.Eq{"t.workspace_id": filters.WorkspaceID, "t.status": statuses}).
+		OrderBy("t.id")
+
+	if len(filters.StubIds) > 0 {
+		qb = qb.Join("stub s ON t.stub_id = s.id").Where(squirrel.Eq{"s.external_id": filters.StubIds})
+	}
+
+	if filters.CreatedAtEnd != "" {
+		qb = qb.Where(squirrel.Lt{"t.created_at": filters.CreatedAtEnd})
+	}
+
+	if filters.Limit > 0 {
+		qb = qb.Limit(uint64(filters.Limit))
+	}
+
+	selectQuery, args, err := qb.Suffix("FOR UPDATE OF t").ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.client.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var ids []int64
+	if err := tx.SelectContext(ctx, &ids, selectQuery, args...); err != nil {
+		return nil, err
+	}
+
+	if len(ids) == 0 {
+		return tasks, nil
+	}
+
+	endedAt := ""
+	if status.IsCompleted() {
+		endedAt = "ended_at = CURRENT_TIMESTAMP,"
+	}
+
+	updateQuery := `
+	UPDATE task t
+	SET status = $1, ` + endedAt + ` updated_at = CURRENT_TIMESTAMP
+	FROM stub s, workspace w
+	WHERE t.id = ANY($2) AND s.id = t.stub_id AND w.id = t.workspace_id
+	RETURNING t.*, s.external_id AS "stub.external_id", s.name AS "stub.name", s.type AS "stub.type",
+		w.external_id AS "workspace.external_id", w.name AS "workspace.name";
+	`
+	if err := tx.SelectContext(ctx, &tasks, updateQuery, status, pq.Array(ids)); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+func (r *PostgresBackendRepository) ListTasks(ctx context.Context) ([]types.Task, error) {
+	var tasks []types.Task
+	query := `SELECT id, external_id, status, container_id, gpu, started_at, ended_at, workspace_id, stub_id, created_at, updated_at FROM task;`
+	err := r.client.SelectContext(ctx, &tasks, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+func (c *PostgresBackendRepository) listTaskWithRelatedQueryBuilder(filters types.TaskFilter) squirrel.SelectBuilder {
+	qb := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar).Select(
+		"t.*, w.external_id AS \"workspace.external_id\", w.name AS \"workspace.name\", " +
+			"w.created_at AS \"workspace.created_at\", w.updated_at AS \"workspace.updated_at\", " +
+			"s.external_id AS \"stub.external_id\", s.name AS \"stub.name\", s.config AS \"stub.config\", s.type AS \"stub.type\", d.external_id AS \"deployment.external_id\", d.name AS \"deployment.name\", d.version AS \"deployment.version\"",
+	).From("task t").
+		Join("workspace w ON t.workspace_id = w.id").
+		Join("stub s ON t.stub_id = s.id").
+		LeftJoin("deployment d ON s.id = d.stub_id").
+		OrderBy("t.id DESC")
+
+	// Apply filters
+	if filters.All {
+		qb = qb.Where(squirrel.Or{
+			squirrel.Eq{"t.external_workspace_id": filters.WorkspaceID},
+			squirrel.Eq{"t.workspace_id": filters.WorkspaceID},
+		})
+	} else if filters.WorkspaceID > 0 {
+		qb = qb.Where(squirrel.Eq{"t.workspace_id": filters.WorkspaceID})
+	} else if filters.ExternalWorkspaceID > 0 {
+		qb = qb.Where(squirrel.Eq{"t.external_workspace_id": filters.ExternalWorkspaceID})
+	}
+
+	if len(filters.StubIds) > 0 {
+		// Subquery to get the stub ids from the external ids
+		stubIdsSubquery := squirrel.Select("id").From("stub").Where(squirrel.Eq{"external_id": filters.StubIds})
+		qb = qb.Where(squirrel.Expr("s.id in (?)", stubIdsSubquery))
+	}
+
+	if len(filters.StubNames) > 0 {
+		qb = qb.Where(squirrel.Eq{"s.name": filters.StubNames})
+	}
+
+	if len(filters.StubTypes) > 0 {
+		qb = qb.Where(squirrel.Eq{"s.type": filters.StubTypes})
+	}
+
+	if len(filters.ContainerIds) > 0 {
+		qb = qb.Where(squirrel.Eq{"t.container_id": filters.ContainerIds})
+	}
+
+	if len(filters.TaskIds) > 0 {
+		validTaskIds := []string{}
+
+		for _, taskId := range filters.TaskIds {
+			// Filter out invalid UUIDs
+			if _, err := uuid.Parse(taskId); err == nil {
+				validTaskIds = append(validTaskIds, taskId)
+			}
+		}
+
+		if len(validTaskIds) > 0 {
+			qb = qb.Where(squirrel.Eq{"t.external_id": validTaskIds})
+		}
+	}
+
+	if filters.CreatedAtStart != "" {
+		qb = qb.Where(squirrel.GtOrEq{"t.created_at": filters.CreatedAtStart})
+	}
+
+	if filters.CreatedAtEnd != "" {
+		qb = qb.Where(squirrel.LtOrEq{"t.created_at": filters.CreatedAtEnd})
+	}
+
+	if filters.Offset > 0 {
+		qb = qb.Offset(uint64(filters.Offset))
+	}
+
+	if filters.Status != "" {
+		statuses := strings.Split(filters.Status, ",")
+		if len(statuses) > 0 {
+			qb = qb.Where(squirrel.Eq{"t.status": statuses})
+		}
+	}
+
+	if filters.MinDuration > 0 {
+		qb = qb.Where("EXTRACT(EPOCH FROM (t.ended_at - t.started_at)) * 1000 >= ?", filters.MinDuration)
+	}
+
+	if filters.MaxDuration > 0 {
+		qb = qb.Where("EXTRACT(EPOCH FROM (t.ended_at - t.started_at)) * 1000 <= ?", filters.MaxDuration)
+	}
+
+	if filters.Limit > 0 {
+		qb = qb.Limit(uint64(filters.Limit))
+	}
+
+	if filters.AppId != "" {
+		qb = qb.Join("app a ON s.app_id = a.id")
+		qb = qb.Where(squirrel.Eq{"a.external_id": filters.AppId})
+	}
+
+	return qb
+}
+
+func (c *PostgresBackendRepository) GetTaskCountPerDeployment(ctx context.Context, filters types.TaskFilter) ([]types.TaskCountPerDeployment, error) {
+	qb := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar).Select(
+		"d.name as deployment_name, COUNT(t.id) AS task_count",
+	).
+		From("deployment d").
+		Join("task t ON d.stub_id = t.stub_id").
+		GroupBy(
+			"d.name",
+		).OrderBy("task_count DESC")
+
+	// Apply filters
+	if filters.WorkspaceID > 0 {
+		qb = qb.Where(squirrel.Eq{"t.workspace_id": filters.WorkspaceID})
+	}
+
+	if len(filters.StubIds) > 0 || filters.AppId != "" {
+		qb = qb.Join("stub s ON t.stub_id = s.id")
+	}
+
+	if len(filters.StubIds) > 0 {
+		qb = qb.Where(squirrel.Eq{"s.external_id": filters.StubIds})
+	}
+
+	if filters.CreatedAtStart != "" {
+		qb = qb.Where(squirrel.GtOrEq{"t.created_at": filters.CreatedAtStart})
+	}
+
+	if filters.CreatedAtEnd != "" {
+		qb = qb.Where(squirrel.LtOrEq{"t.created_at": filters.CreatedAtEnd})
+	}
+
+	if filters.AppId != "" {
+		qb = qb.Join("app a ON s.app_id = a.id")
+		qb = qb.Where(squirrel.Eq{"a.external_id": filters.AppId})
+	}
+
+	sql, args, err := qb.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var taskCounts []types.TaskCountPerDeployment
+	err = c.client.SelectContext(ctx, &taskCounts, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return taskCounts, nil
+}
+
+func (c *PostgresBackendRepository) AggregateTasksByTimeWindow(ctx context.Context, filters types.TaskFilter) ([]types.TaskCountByTime, error) {
+	interval := strings.ToLower(filters.Interval)
+	if interval == "" {
+		interval = "hour"
+	}
+
+	date_truc := fmt.Sprintf("DATE_TRUNC('%s', t.created_at)", interval)
+
+	qb := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar).Select(
+		date_truc + ` as time, COUNT(t.id) as count,
+			json_build_object(
+				'COMPLETE', SUM(CASE WHEN t.status = 'COMPLETE' THEN 1 ELSE 0 END),
+				'ERROR', SUM(CASE WHEN t.status = 'ERROR' THEN 1 ELSE 0 END),
+				'CANCELLED', SUM(CASE WHEN t.status = 'CANCELLED' THEN 1 ELSE 0 END),
+				'TIMEOUT', SUM(CASE WHEN t.status = 'TIMEOUT' THEN 1 ELSE 0 END),
+				'RETRY', SUM(CASE WHEN t.status = 'RETRY' THEN 1 ELSE 0 END),
+				'PENDING', SUM(CASE WHEN t.status = 'PENDING' THEN 1 ELSE 0 END),
+				'RUNNING', SUM(CASE WHEN t.status = 'RUNNING' THEN 1 ELSE 0 END)
+			)::json AS status_counts`,
+	).
+		From("task t").GroupBy("time").OrderBy("time DESC")
+
+	// Apply filters
+	if filters.WorkspaceID > 0 {
+		qb = qb.Where(squirrel.Eq{"t.workspace_id": filters.WorkspaceID})
+	}
+
+	if len(filters.StubIds) > 0 || filters.AppId != "" {
+		qb = qb.Join("stub s ON t.stub_id = s.id")
+	}
+
+	if len(filters.StubIds) > 0 {
+		qb = qb.Where(squirrel.Eq{"s.external_id": filters.StubIds})
+	}
+
+	if filters.AppId != "" {
+		qb = qb.Join("app a ON s.app_id = a.id")
+		qb = qb.Where(squirrel.Eq{"a.external_id": filters.AppId})
+	}
+
+	if filters.CreatedAtStart != "" {
+		qb = qb.Where(squirrel.GtOrEq{"t.created_at": filters.CreatedAtStart})
+	}
+
+	if filters.CreatedAtEnd != "" {
+		qb = qb.Where(squirrel.LtOrEq{"t.created_at": filters.CreatedAtEnd})
+	}
+
+	sql, args, err := qb.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var taskCounts []types.TaskCountByTime
+	err = c.client.SelectContext(ctx, &taskCounts, sql, args...)
+	if err != nil {
+		log.Printf("%v", err)
+		return nil, err
+	}
+
+	return taskCounts, nil
+}
+
+func (c *PostgresBackendRepository) ListTasksWithRelated(ctx context.Context, filters types.TaskFilter) ([]types.TaskWithRelated, error) {
+	if filters.All {
+		filters.Limit = 0
+
+		tasks, err := c.listAllTasksWithRelatedPaginated(ctx, filters)
+		if err != nil {
+			return nil, err
+		}
+
+		return tasks.Data, nil
+	}
+
+	qb := c.listTaskWithRelatedQueryBuilder(filters)
+
+	sql, args, err := qb.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []types.TaskWithRelated
+	err = c.client.SelectContext(ctx, &tasks, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+func (c *PostgresBackendRepository) ListTasksWithRelatedPaginated(ctx context.Context, filters types.TaskFilter) (common.CursorPaginationInfo[types.TaskWithRelated], error) {
+	if filters.All {
+		return c.listAllTasksWithRelatedPaginated(ctx, filters)
+	}
+
+	qb := c.listTaskWithRelatedQueryBuilder(filters)
+
+	page, err := common.Paginate(
+		common.SquirrelCursorPaginator[types.TaskWithRelated]{
+			Client:          c.client,
+			SelectBuilder:   qb,
+			SortOrder:       "DESC",
+			SortColumn:      "created_at",
+			SortQueryPrefix: "t",
+			PageSize:        int(filters.Limit),
+		},
+		filters.Cursor,
+	)
+	if err != nil {
+		return common.CursorPaginationInfo[types.TaskWithRelated]{}, err
+	}
+
+	return *page, nil
+}
+
+func (c *PostgresBackendRepository) listAllTasksWithRelatedPaginated(ctx context.Context, filters types.TaskFilter) (common.CursorPaginationInfo[types.TaskWithRelated], error) {
+	// Apply limit// Apply limit
+	pageSize := filters.Limit
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+
+	filtersWorkspace := filters
+	filtersWorkspace.All = false
+	filtersWorkspace.WorkspaceID = filters.WorkspaceID
+	filtersWorkspace.ExternalWorkspaceID = 0
+	// We want to get one more result past the page size to check if there is more data
+	filtersWorkspace.Limit = pageSize + 1
+
+	filtersExternalWorkspace := filters
+	filtersExternalWorkspace.All = false
+	filtersExternalWorkspace.WorkspaceID = 0
+	filtersExternalWorkspace.ExternalWorkspaceID = filters.WorkspaceID
+	// We want to get one more result past the page size to check if there is more data
+	filtersExternalWorkspace.Limit = pageSize + 1
+
+	// Get results from both calls
+	resultWorkspace, err := c.ListTasksWithRelatedPaginated(ctx, filtersWorkspace)
+	if err != nil {
+		return common.CursorPaginationInfo[types.TaskWithRelated]{}, err
+	}
+
+	resultExternalWorkspace, err := c.ListTasksWithRelatedPaginated(ctx, filtersExternalWorkspace)
+	if err != nil {
+		return common.CursorPaginationInfo[types.TaskWithRelated]{}, err
+	}
+
+	// Merge and sort results
+	allTasks := append(resultWorkspace.Data, resultExternalWorkspace.Data...)
+
+	// Sort by created_at DESC, id DESC
+	sort.Slice(allTasks, func(i, j int) bool {
+		if allTasks[i].CreatedAt.Equal(allTasks[j].CreatedAt.Time) {
+			return allTasks[i].Id > allTasks[j].Id
+		}
+
+		return allTasks[i].CreatedAt.After(allTasks[j].CreatedAt.Time)
+	})
+
+	var nextCursor string
+	if len(allTasks) > int(pageSize) {
+		allTasks = allTasks[:pageSize]
+		lastRow := allTasks[pageSize-1]
+
+		newCursor := common.DatetimeCursor{
+			Value: lastRow.CreatedAt.Format(common.CursorTimestampFormat),
+			Id:    lastRow.Id,
+		}
+		nextCursor = common.EncodeCursor(newCursor)
+	}
+
+	return common.CursorPaginationInfo[types.TaskWithRelated]{
+		Next: nextCursor,
+		Data: allTasks,
+	}, nil
+}
+
+// Stub
+
+func (r *PostgresBackendRepository) GetOrCreateStub(ctx context.Context, name, stubType string, config types.StubConfigV1, objectId, workspaceId uint, forceCreate bool, appId uint) (types.Stub, error) {
+	var stub types.Stub
+
+	// Serialize config to JSON
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		return types.Stub{}, err
+	}
+
+	// @Luke slightly concerned that this is can get the wrong stub. If name + stubtype + objectId + config matches (Likely can happen from clone but haven't seen it yet)
+	if !forceCreate {
+		// Query to check if a stub with the same name, type, object_id, and config exists
+		queryGet := `
+    SELECT id, external_id, name, type, config, config_version, object_id, workspace_id, created_at, updated_at, app_id
+    FROM stub
+    WHERE name = $1 AND type = $2 AND object_id = $3 AND config::jsonb = $4::jsonb;
+    `
+		err = r.client.GetContext(ctx, &stub, queryGet, name, stubType, objectId, string(configJSON))
+		if err == nil {
+			// Stub found, return it
+			return stub, nil
+		}
+	}
+
+	// Stub not found, create a new one
+	queryCreate := `
+    INSERT INTO stub (name, type, config, object_id, workspace_id, app_id)
+    VALUES ($1, $2, $3, $4, $5, $6)
+    RETURNING id, external_id, name, type, config, config_version, object_id, workspace_id, created_at, updated_at, app_id;
+  `
+	if err := r.client.GetContext(ctx, &stub, queryCreate, name, stubType, string(configJSON), objectId, workspaceId, appId); err != nil {
+		return types.Stub{}, err
+	}
+
+	if err := r.updateAppActivity(ctx, appId); err != nil {
+		return stub, err
+	}
+
+	return stub, nil
+}
+
+func (r *PostgresBackendRepository) UpdateStubConfig(ctx context.Context, stubId uint, config *types.StubConfigV1) error {
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE stub SET config = $1 WHERE id = $2;`
+	_, err = r.client.ExecContext(ctx, query, string(configJSON), stubId)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *PostgresBackendRepository) GetStubByExternalId(ctx context.Context, externalId string, queryFilters ...types.QueryFilter) (*types.StubWithRelated, error) {
+	var stub types.StubWithRelated
+	qb := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar).Select(
+		`s.id, s.external_id, s.name, s.type, s.config, s.config_version, s.object_id, s.workspace_id, s.created_at, s.updated_at, s.public, s.app_id,
+	    w.id AS "workspace.id", w.external_id AS "workspace.external_id", w.name AS "workspace.name", w.created_at AS "workspace.created_at", w.updated_at AS "workspace.updated_at", w.signing_key AS "workspace.signing_key", w.volume_cache_enabled AS "workspace.volume_cache_enabled", w.multi_gpu_enabled AS "workspace.multi_gpu_enabled",
+	    o.id AS "object.id", o.external_id AS "object.external_id", o.hash AS "object.hash", o.size AS "object.size", o.workspace_id AS "object.workspace_id", o.created_at AS "object.created_at", o.compression AS "object.compression", o.stored_size AS "object.stored_size", o.region AS "object.region",
+	    o.encryption AS "object.encryption", o.wrapped_data_key AS "object.wrapped_data_key",
+			a.id as "app.id", a.external_id as "app.external_id", a.name as "app.name"
+		`,
+		`ws.id AS "workspace.storage.id", ws.external_id AS "workspace.storage.external_id", ws.bucket_name AS "workspace.storage.bucket_name", ws.access_key AS "workspace.storage.access_key", ws.secret_key AS "workspace.storage.secret_key", 
+		ws.endpoint_url AS "workspace.storage.endpoint_url", ws.region AS "workspace.storage.region", ws.provider AS "workspace.storage.provider", ws.created_at AS "workspace.storage.created_at", ws.updated_at AS "workspace.storage.updated_at"`,
+	).
+		From("stub s").
+		Join("workspace w ON s.workspace_id = w.id").
+		Join("app a ON s.app_id = a.id").
+		LeftJoin("object o ON s.object_id = o.id").
+		LeftJoin("workspace_storage ws ON w.storage_id = ws.id").
+		Where(squirrel.Eq{"s.external_id": externalId})
+
+	var stubFilters types.StubFilter
+	types.ParseConditionFromQueryFilters(&stubFilters, queryFilters...)
+
+	if stubFilters.WorkspaceID != "" {
+		qb = qb.Where(squirrel.Eq{"w.external_id": stubFilters.WorkspaceID})
+	}
+
+	query, args, err := qb.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.client.GetContext(ctx, &stub, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return &types.StubWithRelated{}, err
+	}
+
+	if stub.Workspace.StorageAvailable() {
+		if err := r.decryptFields(stub.Workspace.Storage); err != nil {
+			return nil, err
+		}
+	}
+
+	return &stub, nil
+}
+
+func (r *PostgresBackendRepository) GetStubById(ctx context.Context, stubId uint, queryFilters ...types.QueryFilter) (*types.StubWithRelated, error) {
+	var stub types.StubWithRelated
+	qb := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar).Select(
+		`s.id, s.external_id, s.name, s.type, s.config, s.config_version, s.object_id, s.workspace_id, s.created_at, s.updated_at, s.public, s.app_id,
+	    w.id AS "workspace.id", w.external_id AS "workspace.external_id", w.name AS "workspace.name", w.created_at AS "workspace.created_at", w.updated_at AS "workspace.updated_at", w.signing_key AS "workspace.signing_key", w.volume_cache_enabled AS "workspace.volume_cache_enabled", w.multi_gpu_enabled AS "workspace.multi_gpu_enabled",
+	    o.id AS "object.id", o.external_id AS "object.external_id", o.hash AS "object.hash", o.size AS "object.size", o.workspace_id AS "object.workspace_id", o.created_at AS "object.created_at", o.compression AS "object.compression", o.stored_size AS "object.stored_size", o.region AS "object.region",
+	    o.encryption AS "object.encryption", o.wrapped_data_key AS "object.wrapped_data_key",
+			a.id as "app.id", a.external_id as "app.external_id", a.name as "app.name"
+		`,
+		`ws.id AS "workspace.storage.id", ws.external_id AS "workspace.storage.external_id", ws.bucket_name AS "workspace.storage.bucket_name", ws.access_key AS "workspace.storage.access_key", ws.secret_key AS "workspace.storage.secret_key", 
+		ws.endpoint_url AS "workspace.storage.endpoint_url", ws.region AS "workspace.storage.region", ws.provider AS "workspace.storage.provider", ws.created_at AS "workspace.storage.created_at", ws.updated_at AS "workspace.storage.updated_at"`,
+	).
+		From("stub s").
+		Join("workspace w ON s.workspace_id = w.id").
+		Join("app a ON s.app_id = a.id").
+		LeftJoin("object o ON s.object_id = o.id").
+		LeftJoin("workspace_storage ws ON w.storage_id = ws.id").
+		Where(squirrel.Eq{"s.id": stubId})
+
+	var stubFilters types.StubFilter
+	types.ParseConditionFromQueryFilters(&stubFilters, queryFilters...)
+
+	if stubFilters.WorkspaceID != "" {
+		qb = qb.Where(squirrel.Eq{"w.external_id": stubFilters.WorkspaceID})
+	}
+
+	query, args, err := qb.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.client.GetContext(ctx, &stub, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return &types.StubWithRelated{}, err
+	}
+
+	if stub.Workspace.StorageAvailable() {
+		if err := r.decryptFields(stub.Workspace.Storage); err != nil {
+			return nil, err
+		}
+	}
+
+	return &stub, nil
+}
+
+// Volume
+func (c *PostgresBackendRepository) GetVolume(ctx context.Context, workspaceId uint, name string) (*types.Volume, error) {
+	var volume types.Volume
+
+	queryGet := `SELECT id, external_id, name, workspace_id, quota_bytes, created_at, updated_at FROM volume WHERE name = $1 AND workspace_id = $2;`
+
+	if err := c.client.GetContext(ctx, &volume, queryGet, name, workspaceId); err != nil {
+		return nil, err
+	}
+
+	return &volume, nil
+}
+
+func (c *PostgresBackendRepository) GetVolumeByExternalId(ctx context.Context, workspaceId uint, externalId string) (*types.Volume, error) {
+	var volume types.Volume
+	queryGet := `SELECT id, external_id, name, workspace_id, quota_bytes, created_at, updated_at FROM volume WHERE external_id = $1 AND workspace_id = $2;`
+
+	if err := c.client.GetContext(ctx, &volume, queryGet, externalId, workspaceId); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &volume, nil
+}
+
+func (c *PostgresBackendRepository) GetOrCreateVolume(ctx context.Context, workspaceId uint, name string) (*types.Volume, error) {
+	v, err := c.GetVolume(ctx, workspaceId, name)
+	if err == nil {
+		return v, nil
+	}
+
+	queryCreate := `INSERT INTO volume (name, workspace_id) VALUES ($1, $2) RETURNING id, external_id, name, workspace_id, quota_bytes, created_at, updated_at;`
+
+	var volume types.Volume
+	err = c.client.GetContext(ctx, &volume, queryCreate, name, workspaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &volume, nil
+}
+
+func (c *PostgresBackendRepository) DeleteVolume(ctx context.Context, workspaceId uint, name string) error {
+	query := `DELETE FROM volume WHERE name = $1 AND workspace_id = $2;`
+	_, err := c.client.ExecContext(ctx, query, name, workspaceId)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *PostgresBackendRepository) ListVolumesWithRelated(ctx context.Context, workspaceId uint) ([]types.VolumeWithRelated, error) {
+	var volumes []types.VolumeWithRelated
+	query := `
+		SELECT v.id, v.external_id, v.name, v.workspace_id, v.quota_bytes, v.created_at, v.updated_at, w.name as "workspace.name"
+		FROM volume v
+		JOIN workspace w ON v.workspace_id = w.id
+		WHERE v.workspace_id = $1;
+	`
+
+	err := c.client.SelectContext(ctx, &volumes, query, workspaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	return volumes, nil
+}
+
+func (c *PostgresBackendRepository) CreateVolumeSnapshot(ctx context.Context, snapshot *types.VolumeSnapshot) (*types.VolumeSnapshot, error) {
+	query := `
+	INSERT INTO volume_snapshot (external_id, volume_id, name, size, file_count)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING id, external_id, volume_id, name, size, file_count, created_at;
+	`
+
+	var created types.VolumeSnapshot
+	if err := c.client.GetContext(ctx, &created, query, snapshot.ExternalId, snapshot.VolumeId, snapshot.Name, snapshot.Size, snapshot.FileCount); err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+func (c *PostgresBackendRepository) GetVolumeSnapshot(ctx context.Context, volumeId uint, externalId string) (*types.VolumeSnapshot, error) {
+	query := `SELECT id, external_id, volume_id, name, size, file_count, created_at FROM volume_snapshot WHERE volume_id = $1 AND external_id = $2;`
+
+	var snapshot types.VolumeSnapshot
+	if err := c.client.GetContext(ctx, &snapshot, query, volumeId, externalId); err != nil {
+		return nil, err
+	}
+
+	return &snapshot, nil
+}
+
+func (c *PostgresBackendRepository) ListVolumeSnapshots(ctx context.Context, volumeId uint) ([]types.VolumeSnapshot, error) {
+	query := `SELECT id, external_id, volume_id, name, size, file_count, created_at FROM volume_snapshot WHERE volume_id = $1 ORDER BY created_at DESC;`
+
+	var snapshots []types.VolumeSnapshot
+	if err := c.client.SelectContext(ctx, &snapshots, query, volumeId); err != nil {
+		return nil, err
+	}
+
+	return snapshots, nil
+}
+
+// SetVolumeQuota limits the total size of a volume's contents, 0 removes the limit
+func (c *PostgresBackendRepository) SetVolumeQuota(ctx context.Context, volumeId uint, quotaBytes int64) error {
+	query := `UPDATE volume SET quota_bytes = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2;`
+	_, err := c.client.ExecContext(ctx, query, quotaBytes, volumeId)
+	return err
+}
+
+const sharedVolumeQuery = `
+	SELECT v.id, v.external_id, v.name, v.workspace_id, v.quota_bytes, v.created_at, v.updated_at,
+		w.name as "workspace.name", w.external_id as "workspace.external_id", s.expires_at
+	FROM resource_grant s
+	JOIN volume v ON s.resource_id = v.external_id AND s.workspace_id = v.workspace_id
+	JOIN workspace w ON v.workspace_id = w.id
+	WHERE s.grantee_workspace_id = $1 AND s.resource_type = 'volume' AND (s.expires_at IS NULL OR s.expires_at > CURRENT_TIMESTAMP)
+`
+
+// GetSharedVolume returns a volume the workspace holds an unexpired share on, or nil if it doesn't
+func (c *PostgresBackendRepository) GetSharedVolume(ctx context.Context, granteeWorkspaceId uint, volumeExternalId string) (*types.SharedVolume, error) {
+	var volume types.SharedVolume
+	if err := c.client.GetContext(ctx, &volume, sharedVolumeQuery+` AND v.external_id = $2;`, granteeWorkspaceId, volumeExternalId); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &volume, nil
+}
+
+func (c *PostgresBackendRepository) ListSharedVolumes(ctx context.Context, granteeWorkspaceId uint) ([]types.SharedVolume, error) {
+	var volumes []types.SharedVolume
+	if err := c.client.SelectContext(ctx, &volumes, sharedVolumeQuery+` ORDER BY v.name;`, granteeWorkspaceId); err != nil {
+		return nil, err
+	}
+
+	return volumes, nil
+}
+
+// Resource grants
+
+const resourceGrantColumns = `id, workspace_id, grantee_workspace_id, resource_type, resource_id, expires_at, created_at`
+
+// CreateResourceGrant lets another workspace use one of the workspace's resources, replacing the expiry of any
+// existing grant
+func (c *PostgresBackendRepository) CreateResourceGrant(ctx context.Context, workspaceId uint, granteeWorkspaceId uint, resourceType types.ResourceGrantType, resourceId string, expiresAt *time.Time) (*types.ResourceGrant, error) {
+	query := `
+	INSERT INTO resource_grant (workspace_id, grantee_workspace_id, resource_type, resource_id, expires_at)
+	VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT (workspace_id, resource_type, resource_id, grantee_workspace_id) DO UPDATE SET expires_at = EXCLUDED.expires_at
+	RETURNING ` + resourceGrantColumns + `;
+	`
+
+	var grant types.ResourceGrant
+	if err := c.client.GetContext(ctx, &grant, query, workspaceId, granteeWorkspaceId, resourceType, resourceId, expiresAt); err != nil {
+		return nil, err
+	}
+
+	return &grant, nil
+}
+
+func (c *PostgresBackendRepository) DeleteResourceGrant(ctx context.Context, workspaceId uint, granteeWorkspaceId uint, resourceType types.ResourceGrantType, resourceId string) error {
+	query := `DELETE FROM resource_grant WHERE workspace_id = $1 AND grantee_workspace_id = $2 AND resource_type = $3 AND resource_id = $4;`
+	_, err := c.client.ExecContext(ctx, query, workspaceId, granteeWorkspaceId, resourceType, resourceId)
+	return err
+}
+
+// GetResourceGrant returns the grantee's unexpired grant on a resource, or nil if it doesn't hold one
+func (c *PostgresBackendRepository) GetResourceGrant(ctx context.Context, workspaceId uint, granteeWorkspaceId uint, resourceType types.ResourceGrantType, resourceId string) (*types.ResourceGrant, error) {
+	query := `
+	SELECT ` + resourceGrantColumns + `
+	FROM resource_grant
+	WHERE workspace_id = $1 AND grantee_workspace_id = $2 AND resource_type = $3 AND resource_id = $4
+		AND (expires_at IS NULL OR expires_at > CURRENT_TIMESTAMP);
+	`
+
+	var grant types.ResourceGrant
+	if err := c.client.GetContext(ctx, &grant, query, workspaceId, granteeWorkspaceId, resourceType, resourceId); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &grant, nil
+}
+
+// ListResourceGrants returns the unexpired grants on a workspace's resources, or the ones it holds on other
+// workspaces' resources if received is set. An empty resourceType lists every type.
+func (c *PostgresBackendRepository) ListResourceGrants(ctx context.Context, workspaceId uint, received bool, resourceType types.ResourceGrantType) ([]types.ResourceGrantWithRelated, error) {
+	column := "g.workspace_id"
+	if received {
+		column = "g.grantee_workspace_id"
+	}
+
+	query := `
+	SELECT g.id, g.workspace_id, g.grantee_workspace_id, g.resource_type, g.resource_id, g.expires_at, g.created_at,
+		w.external_id AS "workspace.external_id", w.name AS "workspace.name",
+		gw.external_id AS "grantee_workspace.external_id", gw.name AS "grantee_workspace.name"
+	FROM resource_grant g
+	JOIN workspace w ON g.workspace_id = w.id
+	JOIN workspace gw ON g.grantee_workspace_id = gw.id
+	WHERE ` + column + ` = $1 AND ($2 = '' OR g.resource_type = $2)
+		AND (g.expires_at IS NULL OR g.expires_at > CURRENT_TIMESTAMP)
+	ORDER BY g.created_at DESC;
+	`
+
+	var grants []types.ResourceGrantWithRelated
+	if err := c.client.SelectContext(ctx, &grants, query, workspaceId, resourceType); err != nil {
+		return nil, err
+	}
+
+	return grants, nil
+}
+
+// GetImageCredentialWorkspaceId returns the workspace owning the registry credentials an image is pulled with,
+// or 0 if the image doesn't need any
+func (c *PostgresBackendRepository) GetImageCredentialWorkspaceId(ctx context.Context, imageId string) (uint, error) {
+	query := `
+	SELECT s.workspace_id
+	FROM image i
+	JOIN workspace_secret s ON s.external_id = i.credential_secret_id
+	WHERE i.image_id = $1;
+	`
+
+	var workspaceId uint
+	if err := c.client.GetContext(ctx, &workspaceId, query, imageId); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	return workspaceId, nil
+}
+
+// Task triggers
+
+const taskTriggerColumns = `id, external_id, workspace_id, name, deployment_name, stub_type, signature_scheme, secret, max_payload_bytes, input_mapping, created_at`
+
+// CreateTaskTrigger adds a trigger to a workspace, generating the secret deliveries are signed with unless the
+// calling service issued its own
+func (c *PostgresBackendRepository) CreateTaskTrigger(ctx context.Context, workspaceId uint, trigger types.TaskTrigger) (*types.TaskTrigger, error) {
+	if trigger.Secret == "" {
+		secretBytes := make([]byte, 32) // 256 bits
+		if _, err := rand.Read(secretBytes); err != nil {
+			return nil, err
+		}
+		trigger.Secret = "whsec_" + base64.RawURLEncoding.EncodeToString(secretBytes)
+	}
+
+	query := `
+	INSERT INTO task_trigger (workspace_id, name, deployment_name, stub_type, signature_scheme, secret, max_payload_bytes, input_mapping)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	RETURNING ` + taskTriggerColumns + `;
+	`
+
+	var created types.TaskTrigger
+	if err := c.client.GetContext(ctx, &created, query, workspaceId, trigger.Name, trigger.DeploymentName, trigger.StubType, trigger.SignatureScheme, trigger.Secret, trigger.MaxPayloadBytes, trigger.InputMapping); err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+func (c *PostgresBackendRepository) ListTaskTriggers(ctx context.Context, workspaceId uint) ([]types.TaskTrigger, error) {
+	var triggers []types.TaskTrigger
+
+	query := `SELECT ` + taskTriggerColumns + ` FROM task_trigger WHERE workspace_id = $1 ORDER BY created_at;`
+	if err := c.client.SelectContext(ctx, &triggers, query, workspaceId); err != nil {
+		return nil, err
+	}
+
+	return triggers, nil
+}
+
+// GetTaskTrigger returns a trigger with the workspace it belongs to, or nil if there's no such trigger
+func (c *PostgresBackendRepository) GetTaskTrigger(ctx context.Context, externalId string) (*types.TaskTriggerWithRelated, error) {
+	query := `
+	SELECT t.id, t.external_id, t.workspace_id, t.name, t.deployment_name, t.stub_type, t.signature_scheme, t.secret,
+		t.max_payload_bytes, t.input_mapping, t.created_at,
+		w.id AS "workspace.id", w.external_id AS "workspace.external_id", w.name AS "workspace.name",
+		w.created_at AS "workspace.created_at", w.updated_at AS "workspace.updated_at"
+	FROM task_trigger t
+	JOIN workspace w ON t.workspace_id = w.id
+	WHERE t.external_id = $1;
+	`
+
+	var trigger types.TaskTriggerWithRelated
+	if err := c.client.GetContext(ctx, &trigger, query, externalId); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &trigger, nil
+}
+
+// DeleteTaskTrigger returns sql.ErrNoRows if the workspace has no such trigger
+func (c *PostgresBackendRepository) DeleteTaskTrigger(ctx context.Context, workspaceId uint, externalId string) error {
+	query := `DELETE FROM task_trigger WHERE external_id = $1 AND workspace_id = $2;`
+	result, err := c.client.ExecContext(ctx, query, externalId, workspaceId)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// Access control
+
+const accessControlEntryColumns = `id, workspace_id, object_id, volume_id, principal_type, principal, access, created_at`
+
+// aclResourceColumn is the column that links an access control entry to its resource
+func aclResourceColumn(resourceType string) (string, error) {
+	switch resourceType {
+	case types.ACLResourceObject:
+		return "object_id", nil
+	case types.ACLResourceVolume:
+		return "volume_id", nil
+	}
+
+	return "", fmt.Errorf("unknown acl resource type: %s", resourceType)
+}
+
+func (c *PostgresBackendRepository) ListAccessControlEntries(ctx context.Context, resourceType string, resourceId uint) ([]types.AccessControlEntry, error) {
+	column, err := aclResourceColumn(resourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []types.AccessControlEntry
+	query := `SELECT ` + accessControlEntryColumns + ` FROM access_control_entry WHERE ` + column + ` = $1 ORDER BY principal_type, principal;`
+	if err := c.client.SelectContext(ctx, &entries, query, resourceId); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// ListAccessControlEntriesForResources loads the ACLs of several resources of one type in a single query, keyed
+// by resource id. Resources without entries are missing from the result.
+func (c *PostgresBackendRepository) ListAccessControlEntriesForResources(ctx context.Context, resourceType string, resourceIds []uint) (map[uint][]types.AccessControlEntry, error) {
+	column, err := aclResourceColumn(resourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	acls := make(map[uint][]types.AccessControlEntry)
+	if len(resourceIds) == 0 {
+		return acls, nil
+	}
+
+	ids := make([]int64, len(resourceIds))
+	for i, id := range resourceIds {
+		ids[i] = int64(id)
+	}
+
+	var entries []types.AccessControlEntry
+	query := `SELECT ` + accessControlEntryColumns + ` FROM access_control_entry WHERE ` + column + ` = ANY($1) ORDER BY principal_type, principal;`
+	if err := c.client.SelectContext(ctx, &entries, query, pq.Array(ids)); err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		resourceId := entry.ObjectId
+		if resourceType == types.ACLResourceVolume {
+			resourceId = entry.VolumeId
+		}
+
+		if resourceId != nil {
+			acls[*resourceId] = append(acls[*resourceId], entry)
+		}
+	}
+
+	return acls, nil
+}
+
+// SetAccessControlEntry grants a principal access to a resource, replacing the access it had
+func (c *PostgresBackendRepository) SetAccessControlEntry(ctx context.Context, workspaceId uint, resourceType string, resourceId uint, principalType, principal string, access types.ResourceAccess) error {
+	column, err := aclResourceColumn(resourceType)
+	if err != nil {
+		return err
+	}
+
+	query := `
+	INSERT INTO access_control_entry (workspace_id, ` + column + `, principal_type, principal, access)
+	VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT (` + column + `, principal_type, principal) WHERE ` + column + ` IS NOT NULL DO UPDATE SET access = EXCLUDED.access;
+	`
+	_, err = c.client.ExecContext(ctx, query, workspaceId, resourceId, principalType, principal, access)
+	return err
+}
+
+func (c *PostgresBackendRepository) DeleteAccessControlEntry(ctx context.Context, resourceType string, resourceId uint, principalType, principal string) error {
+	column, err := aclResourceColumn(resourceType)
+	if err != nil {
+		return err
+	}
+
+	query := `DELETE FROM access_control_entry WHERE ` + column + ` = $1 AND principal_type = $2 AND principal = $3;`
+	_, err = c.client.ExecContext(ctx, query, resourceId, principalType, principal)
+	return err
+}
+
+// Deployment
+
+func (c *PostgresBackendRepository) GetLatestDeploymentByName(ctx context.Context, workspaceId uint, name string, stubType string, filterDeleted bool) (*types.DeploymentWithRelated, error) {
+	var deploymentWithRelated types.DeploymentWithRelated
+
+	filterDeletedQuery := ""
+	if filterDeleted {
+		filterDeletedQuery = "AND d.deleted_at IS NULL "
+	}
+
+	query := `
+        SELECT
+            d.*,
+            w.external_id AS "workspace.external_id", w.name AS "workspace.name", w.id AS "workspace.id",
+            s.external_id AS "stub.external_id",
+            s.name AS "stub.name",
+            s.config AS "stub.config"
+        FROM deployment d
+		JOIN workspace w ON d.workspace_id = w.id
+        JOIN stub s ON d.stub_id = s.id
+        WHERE d.workspace_id = $1 AND d.name = $2 AND d.stub_type = $3 ` + filterDeletedQuery + `
+        ORDER BY d.version DESC
+        LIMIT 1;
+    `
+
+	err := c.client.GetContext(ctx, &deploymentWithRelated, query, workspaceId, name, stubType)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Return nil if no deployment found
+		}
+		return nil, err
+	}
+
+	return &deploymentWithRelated, nil
+}
+
+// GetLatestDeploymentByGRPCService returns the latest active gRPC deployment in the workspace that exposes
+// service, nil if there isn't one
+func (c *PostgresBackendRepository) GetLatestDeploymentByGRPCService(ctx context.Context, workspaceId uint, service string) (*types.DeploymentWithRelated, error) {
+	var deploymentWithRelated types.DeploymentWithRelated
+
+	query := `
+        SELECT d.*,
+               w.external_id AS "workspace.external_id", w.name AS "workspace.name", w.id AS "workspace.id",
+               s.external_id AS "stub.external_id", s.name AS "stub.name", s.config AS "stub.config"
+        FROM deployment d
+        JOIN workspace w ON d.workspace_id = w.id
+        JOIN stub s ON d.stub_id = s.id
+        WHERE d.workspace_id = $1 AND d.stub_type = $2 AND d.active = true AND d.deleted_at IS NULL
+          AND s.config::jsonb -> 'grpc_services' @> jsonb_build_array($3::text)
+        ORDER BY d.created_at DESC
+        LIMIT 1;
+    `
+
+	err := c.client.GetContext(ctx, &deploymentWithRelated, query, workspaceId, types.StubTypeGRPCDeployment, service)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &deploymentWithRelated, nil
+}
+
+func (c *PostgresBackendRepository) GetDeploymentByNameAndVersion(ctx context.Context, workspaceId uint, name string, version uint, stubType string) (*types.DeploymentWithRelated, error) {
+	var deploymentWithRelated types.DeploymentWithRelated
+
+	query := `
+        SELECT d.*,
+               w.external_id AS "workspace.external_id", w.name AS "workspace.name", w.id AS "workspace.id",
+               s.external_id AS "stub.external_id", s.name AS "stub.name", s.config AS "stub.config"
+        FROM deployment d
+        JOIN workspace w ON d.workspace_id = w.id
+        JOIN stub s ON d.stub_id = s.id
+        WHERE d.workspace_id = $1 AND d.name = $2 AND d.version = $3 AND d.stub_type = $4 and d.deleted_at IS NULL
+        LIMIT 1;
+    `
+
+	err := c.client.GetContext(ctx, &deploymentWithRelated, query, workspaceId, name, version, stubType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deploymentWithRelated, nil
+}
+
+// GetDeploymentBySubdomain retrieves the deployment by name, version, and stub group
+// If version is 0, it will return the latest version
+func (c *PostgresBackendRepository) GetDeploymentBySubdomain(ctx context.Context, subdomain string, version uint) (*types.DeploymentWithRelated, error) {
+	var deploymentWithRelated types.DeploymentWithRelated
+
+	query := `
+		WITH deployment_data AS (
+			SELECT
+				d.*,
+				w.external_id AS "workspace.external_id", w.name AS "workspace.name",
+				s.external_id AS "stub.external_id", s.name AS "stub.name", s.type AS "stub.type", s.config AS "stub.config"
+			FROM deployment d
+			JOIN workspace w ON d.workspace_id = w.id
+			JOIN stub s ON d.stub_id = s.id
+			WHERE
+				d.subdomain = $1
+				AND d.deleted_at IS NULL
+		)
+		SELECT
+			d.id, d.name, d.external_id, d.version,
+			d."workspace.external_id", d."workspace.name",
+			d."stub.external_id", d."stub.name", d."stub.type", d."stub.config"
+		FROM deployment_data d
+		WHERE version = CASE
+				WHEN $2 = 0 THEN (SELECT MAX(version) FROM deployment_data)
+				ELSE $2
+			END
+		LIMIT 1;
+	`
+	err := c.client.GetContext(ctx, &deploymentWithRelated, query, subdomain, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deploymentWithRelated, nil
+}
+
+func (c *PostgresBackendRepository) ListLatestDeploymentsWithRelatedPaginated(ctx context.Context, filters types.DeploymentFilter) (common.CursorPaginationInfo[types.DeploymentWithRelated], error) {
+	query := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar).
+		Select(
+			"d.*",
+			"s.external_id AS \"stub.external_id\"", "s.name AS \"stub.name\"", "s.config AS \"stub.config\"",
+			"s.created_at AS \"stub.created_at\"", "s.type AS \"stub.type\"", "s.updated_at AS \"stub.updated_at\"",
+		).
+		From("deployment d").
+		Join(`(
+			select name, max(version) as version, stub_type
+			from deployment
+			where workspace_id = ? and deleted_at is null
+			group by name, stub_type
+		)
+		as latest
+		on d.name = latest.name
+		and d.stub_type = latest.stub_type
+		and d.version = latest.version
+		and d.workspace_id = ?`, filters.WorkspaceID, filters.WorkspaceID).
+		Join(
+			"stub s ON d.stub_id = s.id",
+		)
+
+	if filters.AppId != "" {
+		query = query.Join("app a on d.app_id=a.id")
+		query = query.Where(squirrel.Eq{"a.external_id": filters.AppId})
+	}
+
+	if filters.SearchQuery != "" {
+		if err := uuid.Validate(filters.SearchQuery); err == nil {
+			query = query.Where(squirrel.Eq{"d.external_id": filters.SearchQuery})
+		} else {
+			query = query.Where(squirrel.Like{"d.name": "%" + filters.SearchQuery + "%"})
+		}
+	}
+
+	if len(filters.StubType) > 0 {
+		query = query.Where(squirrel.Eq{"s.type": filters.StubType})
+	}
+
+	page, err := common.Paginate(
+		common.SquirrelCursorPaginator[types.DeploymentWithRelated]{
+			Client:          c.client,
+			SelectBuilder:   query,
+			SortOrder:       "DESC",
+			SortColumn:      "created_at",
+			SortQueryPrefix: "d",
+			PageSize:        10,
+		},
+		filters.Cursor,
+	)
+
+	if err != nil {
+		log.Error().Err(err).Msg("error paginating deployments")
+		return common.CursorPaginationInfo[types.DeploymentWithRelated]{}, err
+	}
+
+	return *page, nil
+}
+
+func (c *PostgresBackendRepository) GetDeploymentByExternalId(ctx context.Context, workspaceId uint, deploymentExternalId string) (*types.DeploymentWithRelated, error) {
+	var deploymentWithRelated types.DeploymentWithRelated
+
+	query := `
+        SELECT d.*,
+               w.external_id AS "workspace.external_id", w.name AS "workspace.name",
+               s.id AS "stub.id", s.external_id AS "stub.external_id", s.name AS "stub.name", s.config AS "stub.config", s.type AS "stub.type",
+							 a.external_id AS "app.external_id", a.name AS "app.name"
+        FROM deployment d
+        JOIN workspace w ON d.workspace_id = w.id
+        JOIN stub s ON d.stub_id = s.id
+				JOIN app a ON d.app_id = a.id
+        WHERE d.workspace_id = $1 AND d.external_id = $2 and d.deleted_at IS NULL
+        LIMIT 1;
+    `
+
+	err := c.client.GetContext(ctx, &deploymentWithRelated, query, workspaceId, deploymentExternalId)
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok && err.Code.Class() == PostgresDataError {
+			return nil, nil
+		}
+
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &deploymentWithRelated, nil
+}
+
+func (c *PostgresBackendRepository) GetAnyDeploymentByExternalId(ctx context.Context, deploymentExternalId string) (*types.DeploymentWithRelated, error) {
+	var deploymentWithRelated types.DeploymentWithRelated
+
+	query := `
+        SELECT d.*,
+               w.external_id AS "workspace.external_id", w.name AS "workspace.name",
+               s.id AS "stub.id", s.external_id AS "stub.external_id", s.name AS "stub.name", s.config AS "stub.config", s.type AS "stub.type"
+        FROM deployment d
+        JOIN workspace w ON d.workspace_id = w.id
+        JOIN stub s ON d.stub_id = s.id
+        WHERE d.external_id = $1 and d.deleted_at IS NULL
+        LIMIT 1;
+    `
+
+	err := c.client.GetContext(ctx, &deploymentWithRelated, query, deploymentExternalId)
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok && err.Code.Class() == PostgresDataError {
+			return nil, nil
+		}
+
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &deploymentWithRelated, nil
+}
+
+func (c *PostgresBackendRepository) GetDeploymentByStubExternalId(ctx context.Context, workspaceId uint, stubExternalId string) (*types.DeploymentWithRelated, error) {
+	var deploymentWithRelated types.DeploymentWithRelated
+
+	query := `
+        SELECT d.*,
+               w.external_id AS "workspace.external_id", w.name AS "workspace.name",
+               s.id AS "stub.id", s.external_id AS "stub.external_id", s.name AS "stub.name", s.config AS "stub.config", s.type AS "stub.type"
+        FROM deployment d
+        JOIN workspace w ON d.workspace_id = w.id
+        JOIN stub s ON d.stub_id = s.id
+        WHERE d.workspace_id = $1 AND s.external_id = $2 and d.deleted_at IS NULL
+        LIMIT 1;
+    `
+
+	err := c.client.GetContext(ctx, &deploymentWithRelated, query, workspaceId, stubExternalId)
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok && err.Code.Class() == PostgresDataError {
+			return nil, nil
+		}
+
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &deploymentWithRelated, nil
+}
+
+func (c *PostgresBackendRepository) listDeploymentsQueryBuilder(filters types.DeploymentFilter) squirrel.SelectBuilder {
+	qb := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar).Select(
+		"d.id, d.external_id, d.name, d.active, d.subdomain, d.workspace_id, d.stub_id, d.stub_type, d.version, d.created_at, d.updated_at, d.deleted_at",
+		"w.external_id AS \"workspace.external_id\"", "w.name AS \"workspace.name\"", "w.created_at AS \"workspace.created_at\"", "w.updated_at AS \"workspace.updated_at\"",
+		"s.external_id AS \"stub.external_id\"", "s.name AS \"stub.name\"", "s.config AS \"stub.config\"", "s.type AS \"stub.type\"", "s.created_at AS \"stub.created_at\"", "s.updated_at AS \"stub.updated_at\"",
+		"a.external_id AS \"app.external_id\"", "a.name AS \"app.name\"", "a.created_at AS \"app.created_at\"", "a.updated_at AS \"app.updated_at\"",
+	).From("deployment d").
+		Join("workspace w ON d.workspace_id = w.id").
+		Join("stub s ON d.stub_id = s.id").
+		Join("app a ON d.app_id = a.id")
+
+	// Apply filters
+	if !filters.ShowDeleted {
+		qb = qb.Where("d.deleted_at IS NULL")
+	}
+
+	if filters.WorkspaceID != 0 {
+		qb = qb.Where(squirrel.Eq{"d.workspace_id": filters.WorkspaceID})
+	}
+
+	if len(filters.StubIds) > 0 {
+		qb = qb.Where(squirrel.Eq{"s.external_id": filters.StubIds})
+	}
+
+	if len(filters.StubType) > 0 {
+		qb = qb.Where(squirrel.Eq{"d.stub_type": filters.StubType})
+	}
+
+	if filters.Subdomain != "" {
+		qb = qb.Where(squirrel.Eq{"d.subdomain": filters.Subdomain})
+	}
+
+	if filters.SearchQuery != "" {
+		if err := uuid.Validate(filters.SearchQuery); err == nil {
+			qb = qb.Where(squirrel.Eq{"d.external_id": filters.SearchQuery})
+		} else {
+			qb = qb.Where(squirrel.Like{"d.name": "%" + filters.SearchQuery + "%"})
+		}
+	}
+
+	if filters.Name != "" {
+		qb = qb.Where(squirrel.Like{"d.name": fmt.Sprintf("%%%s%%", filters.Name)})
+	}
+
+	if filters.NamePrefix != "" {
+		qb = qb.Where(squirrel.Like{"d.name": likePrefixEscaper.Replace(filters.NamePrefix) + "%"})
+	}
+
+	if filters.Active != nil {
+		qb = qb.Where(squirrel.Eq{"d.active": filters.Active})
+	}
+
+	if filters.Version > 0 {
+		qb = qb.Where(squirrel.Eq{"d.version": filters.Version})
+	}
+
+	if filters.Offset > 0 {
+		qb = qb.Offset(uint64(filters.Offset))
+	}
+
+	if filters.Limit > 0 {
+		qb = qb.Limit(uint64(filters.Limit))
+	}
+
+	if filters.CreatedAtStart != "" {
+		qb = qb.Where(squirrel.GtOrEq{"d.created_at": filters.CreatedAtStart})
+	}
+
+	if filters.CreatedAtEnd != "" {
+		qb = qb.Where(squirrel.LtOrEq{"d.created_at": filters.CreatedAtEnd})
+	}
+
+	if filters.MinContainersGTE > 0 {
+		qb = qb.Where("COALESCE((s.config->'autoscaler'->>'min_containers')::int, 0) >= ?", filters.MinContainersGTE)
 	}
 
-	if stub.Workspace.StorageAvailable() {
-		if err := r.decryptFields(stub.Workspace.Storage); err != nil {
-			return nil, err
-		}
+	if filters.AppId != "" {
+		qb = qb.Where(squirrel.Eq{"a.external_id": filters.AppId})
 	}
 
-	return &stub, nil
+	return qb
 }
 
-func (r *PostgresBackendRepository) GetStubById(ctx context.Context, stubId uint, queryFilters ...types.QueryFilter) (*types.StubWithRelated, error) {
-	var stub types.StubWithRelated
-	qb := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar).Select(
-		`s.id, s.external_id, s.name, s.type, s.config, s.config_version, s.object_id, s.workspace_id, s.created_at, s.updated_at, s.public, s.app_id,
-	    w.id AS "workspace.id", w.external_id AS "workspace.external_id", w.name AS "workspace.name", w.created_at AS "workspace.created_at", w.updated_at AS "workspace.updated_at", w.signing_key AS "workspace.signing_key", w.volume_cache_enabled AS "workspace.volume_cache_enabled", w.multi_gpu_enabled AS "workspace.multi_gpu_enabled",
-	    o.id AS "object.id", o.external_id AS "object.external_id", o.hash AS "object.hash", o.size AS "object.size", o.workspace_id AS "object.workspace_id", o.created_at AS "object.created_at",
-			a.id as "app.id", a.external_id as "app.external_id", a.name as "app.name"
-		`,
-		`ws.id AS "workspace.storage.id", ws.external_id AS "workspace.storage.external_id", ws.bucket_name AS "workspace.storage.bucket_name", ws.access_key AS "workspace.storage.access_key", ws.secret_key AS "workspace.storage.secret_key", 
-		ws.endpoint_url AS "workspace.storage.endpoint_url", ws.region AS "workspace.storage.region", ws.created_at AS "workspace.storage.created_at", ws.updated_at AS "workspace.storage.updated_at"`,
-	).
-		From("stub s").
-		Join("workspace w ON s.workspace_id = w.id").
-		Join("app a ON s.app_id = a.id").
-		LeftJoin("object o ON s.object_id = o.id").
-		LeftJoin("workspace_storage ws ON w.storage_id = ws.id").
-		Where(squirrel.Eq{"s.id": stubId})
+func (c *PostgresBackendRepository) ListDeploymentsWithRelated(ctx context.Context, filters types.DeploymentFilter) ([]types.DeploymentWithRelated, error) {
+	column, order, err := filters.Sort()
+	if err != nil {
+		return nil, err
+	}
 
-	var stubFilters types.StubFilter
-	types.ParseConditionFromQueryFilters(&stubFilters, queryFilters...)
+	qb := c.listDeploymentsQueryBuilder(filters).OrderBy("d." + column + " " + order)
 
-	if stubFilters.WorkspaceID != "" {
-		qb = qb.Where(squirrel.Eq{"w.external_id": stubFilters.WorkspaceID})
+	sql, args, err := qb.ToSql()
+	if err != nil {
+		return nil, err
 	}
 
-	query, args, err := qb.ToSql()
+	var deployments []types.DeploymentWithRelated
+	err = c.client.SelectContext(ctx, &deployments, sql, args...)
 	if err != nil {
 		return nil, err
 	}
 
-	err = r.client.GetContext(ctx, &stub, query, args...)
+	return deployments, nil
+}
+
+func (c *PostgresBackendRepository) ListDeploymentsPaginated(ctx context.Context, filters types.DeploymentFilter) (common.CursorPaginationInfo[types.DeploymentWithRelated], error) {
+	column, order, err := filters.Sort()
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil
-		}
-		return &types.StubWithRelated{}, err
+		return common.CursorPaginationInfo[types.DeploymentWithRelated]{}, err
 	}
 
-	if stub.Workspace.StorageAvailable() {
-		if err := r.decryptFields(stub.Workspace.Storage); err != nil {
-			return nil, err
-		}
+	qb := c.listDeploymentsQueryBuilder(filters)
+
+	page, err := common.Paginate(
+		common.SquirrelCursorPaginator[types.DeploymentWithRelated]{
+			Client:          c.client,
+			SelectBuilder:   qb,
+			SortOrder:       order,
+			SortColumn:      column,
+			SortQueryPrefix: "d",
+			PageSize:        int(filters.Limit),
+		},
+		filters.Cursor,
+	)
+	if err != nil {
+		return common.CursorPaginationInfo[types.DeploymentWithRelated]{}, err
 	}
 
-	return &stub, nil
+	return *page, nil
 }
 
-// Volume
-func (c *PostgresBackendRepository) GetVolume(ctx context.Context, workspaceId uint, name string) (*types.Volume, error) {
-	var volume types.Volume
+// GetDeploymentStats returns the pending task count and last invocation time of each stub, keyed by stub ID.
+// Stubs that never ran a task are left out.
+func (c *PostgresBackendRepository) GetDeploymentStats(ctx context.Context, stubIds []uint) (map[uint]types.DeploymentStats, error) {
+	stats := map[uint]types.DeploymentStats{}
+	if len(stubIds) == 0 {
+		return stats, nil
+	}
+
+	ids := make([]int64, len(stubIds))
+	for i, id := range stubIds {
+		ids[i] = int64(id)
+	}
 
-	queryGet := `SELECT id, external_id, name, workspace_id, created_at, updated_at FROM volume WHERE name = $1 AND workspace_id = $2;`
+	query := `
+	SELECT stub_id, COUNT(*) FILTER (WHERE status = $2) AS pending_tasks, MAX(created_at) AS last_invoked_at
+	FROM task
+	WHERE stub_id = ANY($1)
+	GROUP BY stub_id;
+	`
 
-	if err := c.client.GetContext(ctx, &volume, queryGet, name, workspaceId); err != nil {
+	var rows []types.DeploymentStats
+	if err := c.client.SelectContext(ctx, &rows, query, pq.Array(ids), types.TaskStatusPending); err != nil {
 		return nil, err
 	}
 
-	return &volume, nil
+	for _, row := range rows {
+		stats[row.StubId] = row
+	}
+
+	return stats, nil
 }
 
-func (c *PostgresBackendRepository) GetVolumeByExternalId(ctx context.Context, workspaceId uint, externalId string) (*types.Volume, error) {
-	var volume types.Volume
-	queryGet := `SELECT id, external_id, name, workspace_id, created_at, updated_at FROM volume WHERE external_id = $1 AND workspace_id = $2;`
+func (c *PostgresBackendRepository) CreateDeployment(ctx context.Context, workspaceId uint, name string, version uint, stubId uint, stubType string, appId uint) (*types.Deployment, error) {
+	var deployment types.Deployment
 
-	if err := c.client.GetContext(ctx, &volume, queryGet, externalId, workspaceId); err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil
-		}
+	subdomain := generateSubdomain(name, stubType, workspaceId)
+	queryCreate := `
+		INSERT INTO deployment (name, active, subdomain, workspace_id, stub_id, version, stub_type, app_id)
+		VALUES ($1, true, $2, $3, $4, $5, $6, $7)
+		RETURNING id, external_id, name, active, subdomain, workspace_id, stub_id, stub_type, version, created_at, updated_at, app_id;
+	`
+
+	tx, err := c.client.BeginTxx(ctx, nil)
+	if err != nil {
 		return nil, err
 	}
+	defer tx.Rollback()
 
-	return &volume, nil
-}
+	err = tx.GetContext(ctx, &deployment, queryCreate, name, subdomain, workspaceId, stubId, version, stubType, appId)
+	if err != nil {
+		return nil, err
+	}
 
-func (c *PostgresBackendRepository) GetOrCreateVolume(ctx context.Context, workspaceId uint, name string) (*types.Volume, error) {
-	v, err := c.GetVolume(ctx, workspaceId, name)
-	if err == nil {
-		return v, nil
+	if err := createDeploymentHistory(ctx, tx, deployment, types.DeploymentHistoryActionDeploy, nil); err != nil {
+		return nil, err
 	}
 
-	queryCreate := `INSERT INTO volume (name, workspace_id) VALUES ($1, $2) RETURNING id, external_id, name, workspace_id, created_at, updated_at;`
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
 
-	var volume types.Volume
-	err = c.client.GetContext(ctx, &volume, queryCreate, name, workspaceId)
-	if err != nil {
+	if err := c.updateAppActivity(ctx, appId); err != nil {
 		return nil, err
 	}
 
-	return &volume, nil
+	return &deployment, nil
 }
 
-func (c *PostgresBackendRepository) DeleteVolume(ctx context.Context, workspaceId uint, name string) error {
-	query := `DELETE FROM volume WHERE name = $1 AND workspace_id = $2;`
-	_, err := c.client.ExecContext(ctx, query, name, workspaceId)
+// RollbackDeployment points a deployment name back at an earlier version's stub, and with it that version's
+// image and config, by creating a new latest version that reuses the stub. The name's versions are locked so
+// a concurrent deploy can't take the same version number.
+func (c *PostgresBackendRepository) RollbackDeployment(ctx context.Context, workspaceId uint, name string, stubType string, targetVersion uint) (*types.Deployment, error) {
+	tx, err := c.client.BeginTxx(ctx, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return nil
-}
-
-func (c *PostgresBackendRepository) ListVolumesWithRelated(ctx context.Context, workspaceId uint) ([]types.VolumeWithRelated, error) {
-	var volumes []types.VolumeWithRelated
-	query := `
-		SELECT v.id, v.external_id, v.name, v.workspace_id, v.created_at, v.updated_at, w.name as "workspace.name"
-		FROM volume v
-		JOIN workspace w ON v.workspace_id = w.id
-		WHERE v.workspace_id = $1;
-	`
+	defer tx.Rollback()
 
-	err := c.client.SelectContext(ctx, &volumes, query, workspaceId)
+	var versions []types.Deployment
+	err = tx.SelectContext(ctx, &versions, `
+		SELECT id, external_id, name, active, subdomain, workspace_id, stub_id, stub_type, version, created_at, updated_at, deleted_at, app_id
+		FROM deployment
+		WHERE workspace_id = $1 AND name = $2 AND stub_type = $3
+		ORDER BY version DESC
+		FOR UPDATE;
+	`, workspaceId, name, stubType)
 	if err != nil {
 		return nil, err
 	}
 
-	return volumes, nil
-}
+	if len(versions) == 0 {
+		return nil, sql.ErrNoRows
+	}
 
-// Deployment
+	targetIdx := slices.IndexFunc(versions, func(d types.Deployment) bool {
+		return d.Version == targetVersion && !d.DeletedAt.Valid
+	})
+	if targetIdx == -1 {
+		return nil, sql.ErrNoRows
+	}
+	target := versions[targetIdx]
 
-func (c *PostgresBackendRepository) GetLatestDeploymentByName(ctx context.Context, workspaceId uint, name string, stubType string, filterDeleted bool) (*types.DeploymentWithRelated, error) {
-	var deploymentWithRelated types.DeploymentWithRelated
+	var deployment types.Deployment
+	err = tx.GetContext(ctx, &deployment, `
+		INSERT INTO deployment (name, active, subdomain, workspace_id, stub_id, version, stub_type, app_id)
+		VALUES ($1, true, $2, $3, $4, $5, $6, $7)
+		RETURNING id, external_id, name, active, subdomain, workspace_id, stub_id, stub_type, version, created_at, updated_at, app_id;
+	`, name, generateSubdomain(name, stubType, workspaceId), workspaceId, target.StubId, versions[0].Version+1, stubType, target.AppId)
+	if err != nil {
+		return nil, err
+	}
 
-	filterDeletedQuery := ""
-	if filterDeleted {
-		filterDeletedQuery = "AND d.deleted_at IS NULL "
+	if err := createDeploymentHistory(ctx, tx, deployment, types.DeploymentHistoryActionRollback, &target.Version); err != nil {
+		return nil, err
 	}
 
-	query := `
-        SELECT
-            d.*,
-            w.external_id AS "workspace.external_id", w.name AS "workspace.name", w.id AS "workspace.id",
-            s.external_id AS "stub.external_id",
-            s.name AS "stub.name",
-            s.config AS "stub.config"
-        FROM deployment d
-		JOIN workspace w ON d.workspace_id = w.id
-        JOIN stub s ON d.stub_id = s.id
-        WHERE d.workspace_id = $1 AND d.name = $2 AND d.stub_type = $3 ` + filterDeletedQuery + `
-        ORDER BY d.version DESC
-        LIMIT 1;
-    `
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
 
-	err := c.client.GetContext(ctx, &deploymentWithRelated, query, workspaceId, name, stubType)
+	return &deployment, nil
+}
+
+func createDeploymentHistory(ctx context.Context, tx *sqlx.Tx, deployment types.Deployment, action types.DeploymentHistoryAction, rolledBackToVersion *uint) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO deployment_history (workspace_id, name, stub_type, version, deployment_id, stub_id, action, rolled_back_to_version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+	`, deployment.WorkspaceId, deployment.Name, deployment.StubType, deployment.Version, deployment.Id, deployment.StubId, action, rolledBackToVersion)
+	return err
+}
+
+// GetTrafficSplit returns the traffic split for a deployment name, or nil if it has never had one
+func (c *PostgresBackendRepository) GetTrafficSplit(ctx context.Context, workspaceId uint, name string, stubType string) (*types.DeploymentTrafficSplit, error) {
+	var split types.DeploymentTrafficSplit
+	err := c.client.GetContext(ctx, &split, `
+		SELECT id, workspace_id, name, stub_type, stable_version, canary_version, canary_percent,
+			error_rate_threshold, min_requests, status, created_at, updated_at
+		FROM deployment_traffic_split
+		WHERE workspace_id = $1 AND name = $2 AND stub_type = $3;
+	`, workspaceId, name, stubType)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, nil // Return nil if no deployment found
+			return nil, nil
 		}
+
 		return nil, err
 	}
 
-	return &deploymentWithRelated, nil
+	return &split, nil
 }
 
-func (c *PostgresBackendRepository) GetDeploymentByNameAndVersion(ctx context.Context, workspaceId uint, name string, version uint, stubType string) (*types.DeploymentWithRelated, error) {
-	var deploymentWithRelated types.DeploymentWithRelated
-
-	query := `
-        SELECT d.*,
-               w.external_id AS "workspace.external_id", w.name AS "workspace.name", w.id AS "workspace.id",
-               s.external_id AS "stub.external_id", s.name AS "stub.name", s.config AS "stub.config"
-        FROM deployment d
-        JOIN workspace w ON d.workspace_id = w.id
-        JOIN stub s ON d.stub_id = s.id
-        WHERE d.workspace_id = $1 AND d.name = $2 AND d.version = $3 AND d.stub_type = $4 and d.deleted_at IS NULL
-        LIMIT 1;
-    `
-
-	err := c.client.GetContext(ctx, &deploymentWithRelated, query, workspaceId, name, version, stubType)
+// SetTrafficSplit starts a traffic split for a deployment name, replacing any earlier split
+func (c *PostgresBackendRepository) SetTrafficSplit(ctx context.Context, split types.DeploymentTrafficSplit) (*types.DeploymentTrafficSplit, error) {
+	var updated types.DeploymentTrafficSplit
+	err := c.client.GetContext(ctx, &updated, `
+		INSERT INTO deployment_traffic_split (workspace_id, name, stub_type, stable_version, canary_version, canary_percent, error_rate_threshold, min_requests, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (workspace_id, name, stub_type) DO UPDATE SET
+			stable_version = EXCLUDED.stable_version,
+			canary_version = EXCLUDED.canary_version,
+			canary_percent = EXCLUDED.canary_percent,
+			error_rate_threshold = EXCLUDED.error_rate_threshold,
+			min_requests = EXCLUDED.min_requests,
+			status = EXCLUDED.status,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING id, workspace_id, name, stub_type, stable_version, canary_version, canary_percent,
+			error_rate_threshold, min_requests, status, created_at, updated_at;
+	`, split.WorkspaceId, split.Name, split.StubType, split.StableVersion, split.CanaryVersion, split.CanaryPercent,
+		split.ErrorRateThreshold, split.MinRequests, types.TrafficSplitStatusActive)
 	if err != nil {
 		return nil, err
 	}
 
-	return &deploymentWithRelated, nil
+	return &updated, nil
 }
 
-// GetDeploymentBySubdomain retrieves the deployment by name, version, and stub group
-// If version is 0, it will return the latest version
-func (c *PostgresBackendRepository) GetDeploymentBySubdomain(ctx context.Context, subdomain string, version uint) (*types.DeploymentWithRelated, error) {
-	var deploymentWithRelated types.DeploymentWithRelated
+// EndTrafficSplit moves an active split to its final status. It returns false if the split had already
+// ended, so only one gateway acts on a canary that is promoted or rolled back concurrently.
+func (c *PostgresBackendRepository) EndTrafficSplit(ctx context.Context, splitId uint, status types.TrafficSplitStatus) (bool, error) {
+	res, err := c.client.ExecContext(ctx, `
+		UPDATE deployment_traffic_split
+		SET status = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = $3;
+	`, splitId, status, types.TrafficSplitStatusActive)
+	if err != nil {
+		return false, err
+	}
 
-	query := `
-		WITH deployment_data AS (
-			SELECT
-				d.*,
-				w.external_id AS "workspace.external_id", w.name AS "workspace.name",
-				s.external_id AS "stub.external_id", s.name AS "stub.name", s.type AS "stub.type", s.config AS "stub.config"
-			FROM deployment d
-			JOIN workspace w ON d.workspace_id = w.id
-			JOIN stub s ON d.stub_id = s.id
-			WHERE
-				d.subdomain = $1
-				AND d.deleted_at IS NULL
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+const customDomainColumns = `
+	cd.id, cd.external_id, cd.workspace_id, cd.deployment_id, cd.domain, cd.status, cd.last_error,
+	cd.verification_token, cd.verified_at, cd.certificate_expires_at, cd.created_at, cd.updated_at,
+	d.external_id AS deployment_external_id, d.subdomain`
+
+func (c *PostgresBackendRepository) CreateCustomDomain(ctx context.Context, workspaceId uint, deploymentId uint, domain string) (*types.CustomDomain, error) {
+	var customDomain types.CustomDomain
+	err := c.client.GetContext(ctx, &customDomain, `
+		WITH cd AS (
+			INSERT INTO custom_domain (workspace_id, deployment_id, domain, status)
+			VALUES ($1, $2, $3, $4)
+			RETURNING *
 		)
-		SELECT
-			d.id, d.name, d.external_id, d.version,
-			d."workspace.external_id", d."workspace.name",
-			d."stub.external_id", d."stub.name", d."stub.type", d."stub.config"
-		FROM deployment_data d
-		WHERE version = CASE
-				WHEN $2 = 0 THEN (SELECT MAX(version) FROM deployment_data)
-				ELSE $2
-			END
-		LIMIT 1;
-	`
-	err := c.client.GetContext(ctx, &deploymentWithRelated, query, subdomain, version)
+		SELECT `+customDomainColumns+`
+		FROM cd
+		JOIN deployment d ON cd.deployment_id = d.id;
+	`, workspaceId, deploymentId, domain, types.CustomDomainStatusPending)
 	if err != nil {
 		return nil, err
 	}
 
-	return &deploymentWithRelated, nil
+	return &customDomain, nil
 }
 
-func (c *PostgresBackendRepository) ListLatestDeploymentsWithRelatedPaginated(ctx context.Context, filters types.DeploymentFilter) (common.CursorPaginationInfo[types.DeploymentWithRelated], error) {
-	query := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar).
-		Select(
-			"d.*",
-			"s.external_id AS \"stub.external_id\"", "s.name AS \"stub.name\"", "s.config AS \"stub.config\"",
-			"s.created_at AS \"stub.created_at\"", "s.type AS \"stub.type\"", "s.updated_at AS \"stub.updated_at\"",
-		).
-		From("deployment d").
-		Join(`(
-			select name, max(version) as version, stub_type
-			from deployment
-			where workspace_id = ? and deleted_at is null
-			group by name, stub_type
-		)
-		as latest
-		on d.name = latest.name
-		and d.stub_type = latest.stub_type
-		and d.version = latest.version
-		and d.workspace_id = ?`, filters.WorkspaceID, filters.WorkspaceID).
-		Join(
-			"stub s ON d.stub_id = s.id",
-		)
+// GetCustomDomain returns the verified custom domain attached under domain, nil if there isn't one or its
+// deployment was deleted. Other workspaces' unverified claims on the domain are ignored.
+func (c *PostgresBackendRepository) GetCustomDomain(ctx context.Context, domain string) (*types.CustomDomain, error) {
+	var customDomain types.CustomDomain
+	err := c.client.GetContext(ctx, &customDomain, `
+		SELECT `+customDomainColumns+`
+		FROM custom_domain cd
+		JOIN deployment d ON cd.deployment_id = d.id
+		WHERE cd.domain = $1 AND cd.verified_at IS NOT NULL AND d.deleted_at IS NULL;
+	`, domain)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
 
-	if filters.AppId != "" {
-		query = query.Join("app a on d.app_id=a.id")
-		query = query.Where(squirrel.Eq{"a.external_id": filters.AppId})
+		return nil, err
 	}
 
-	if filters.SearchQuery != "" {
-		if err := uuid.Validate(filters.SearchQuery); err == nil {
-			query = query.Where(squirrel.Eq{"d.external_id": filters.SearchQuery})
-		} else {
-			query = query.Where(squirrel.Like{"d.name": "%" + filters.SearchQuery + "%"})
+	return &customDomain, nil
+}
+
+func (c *PostgresBackendRepository) GetCustomDomainByExternalId(ctx context.Context, workspaceId uint, externalId string) (*types.CustomDomain, error) {
+	var customDomain types.CustomDomain
+	err := c.client.GetContext(ctx, &customDomain, `
+		SELECT `+customDomainColumns+`
+		FROM custom_domain cd
+		JOIN deployment d ON cd.deployment_id = d.id
+		WHERE cd.workspace_id = $1 AND cd.external_id = $2;
+	`, workspaceId, externalId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
 		}
-	}
 
-	if len(filters.StubType) > 0 {
-		query = query.Where(squirrel.Eq{"s.type": filters.StubType})
+		return nil, err
 	}
 
-	page, err := common.Paginate(
-		common.SquirrelCursorPaginator[types.DeploymentWithRelated]{
-			Client:          c.client,
-			SelectBuilder:   query,
-			SortOrder:       "DESC",
-			SortColumn:      "created_at",
-			SortQueryPrefix: "d",
-			PageSize:        10,
-		},
-		filters.Cursor,
-	)
+	return &customDomain, nil
+}
 
+// ListCustomDomains returns a workspace's custom domains, only those of one deployment if deploymentId is set
+func (c *PostgresBackendRepository) ListCustomDomains(ctx context.Context, workspaceId uint, deploymentId uint) ([]types.CustomDomain, error) {
+	var customDomains []types.CustomDomain
+	err := c.client.SelectContext(ctx, &customDomains, `
+		SELECT `+customDomainColumns+`
+		FROM custom_domain cd
+		JOIN deployment d ON cd.deployment_id = d.id
+		WHERE cd.workspace_id = $1 AND ($2 = 0 OR cd.deployment_id = $2)
+		ORDER BY cd.domain;
+	`, workspaceId, deploymentId)
 	if err != nil {
-		log.Error().Err(err).Msg("error paginating deployments")
-		return common.CursorPaginationInfo[types.DeploymentWithRelated]{}, err
+		return nil, err
 	}
 
-	return *page, nil
+	return customDomains, nil
 }
 
-func (c *PostgresBackendRepository) GetDeploymentByExternalId(ctx context.Context, workspaceId uint, deploymentExternalId string) (*types.DeploymentWithRelated, error) {
-	var deploymentWithRelated types.DeploymentWithRelated
+func (c *PostgresBackendRepository) DeleteCustomDomain(ctx context.Context, id uint) error {
+	_, err := c.client.ExecContext(ctx, `DELETE FROM custom_domain WHERE id = $1;`, id)
+	return err
+}
 
-	query := `
-        SELECT d.*,
-               w.external_id AS "workspace.external_id", w.name AS "workspace.name",
-               s.id AS "stub.id", s.external_id AS "stub.external_id", s.name AS "stub.name", s.config AS "stub.config", s.type AS "stub.type",
-							 a.external_id AS "app.external_id", a.name AS "app.name"
-        FROM deployment d
-        JOIN workspace w ON d.workspace_id = w.id
-        JOIN stub s ON d.stub_id = s.id
-				JOIN app a ON d.app_id = a.id
-        WHERE d.workspace_id = $1 AND d.external_id = $2 and d.deleted_at IS NULL
-        LIMIT 1;
-    `
+// VerifyCustomDomain marks a domain as verified once its TXT record holds the verification token. It fails
+// with a unique violation if another workspace already verified the domain.
+func (c *PostgresBackendRepository) VerifyCustomDomain(ctx context.Context, id uint) error {
+	_, err := c.client.ExecContext(ctx, `
+		UPDATE custom_domain
+		SET verified_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND verified_at IS NULL;
+	`, id)
+	return err
+}
 
-	err := c.client.GetContext(ctx, &deploymentWithRelated, query, workspaceId, deploymentExternalId)
-	if err != nil {
-		if err, ok := err.(*pq.Error); ok && err.Code.Class() == PostgresDataError {
-			return nil, nil
-		}
+// UpdateCustomDomainStatus records the outcome of issuing a certificate for a verified domain
+func (c *PostgresBackendRepository) UpdateCustomDomainStatus(ctx context.Context, domain string, status types.CustomDomainStatus, lastError string, certificateExpiresAt *time.Time) error {
+	_, err := c.client.ExecContext(ctx, `
+		UPDATE custom_domain
+		SET status = $2, last_error = $3, certificate_expires_at = COALESCE($4, certificate_expires_at), updated_at = CURRENT_TIMESTAMP
+		WHERE domain = $1 AND verified_at IS NOT NULL;
+	`, domain, status, lastError, certificateExpiresAt)
+	return err
+}
 
+// GetOIDCIdentity returns the identity a user was provisioned with in a workspace, along with its token's key.
+// It's nil if the user hasn't signed in to the workspace yet.
+func (c *PostgresBackendRepository) GetOIDCIdentity(ctx context.Context, issuer string, subject string, workspaceId uint) (*types.OIDCIdentity, error) {
+	var identity types.OIDCIdentity
+	err := c.client.GetContext(ctx, &identity, `
+		SELECT i.id, i.issuer, i.subject, i.email, i.workspace_id, i.token_id, i.created_at, i.updated_at, t.key AS token_key
+		FROM oidc_identity i
+		JOIN token t ON i.token_id = t.id
+		WHERE i.issuer = $1 AND i.subject = $2 AND i.workspace_id = $3;
+	`, issuer, subject, workspaceId)
+	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
@@ -1388,55 +3706,122 @@ func (c *PostgresBackendRepository) GetDeploymentByExternalId(ctx context.Contex
 		return nil, err
 	}
 
-	return &deploymentWithRelated, nil
+	return &identity, nil
 }
 
-func (c *PostgresBackendRepository) GetAnyDeploymentByExternalId(ctx context.Context, deploymentExternalId string) (*types.DeploymentWithRelated, error) {
-	var deploymentWithRelated types.DeploymentWithRelated
+func (c *PostgresBackendRepository) CreateOIDCIdentity(ctx context.Context, issuer string, subject string, email string, workspaceId uint, tokenId uint) (*types.OIDCIdentity, error) {
+	var identity types.OIDCIdentity
+	err := c.client.GetContext(ctx, &identity, `
+		WITH i AS (
+			INSERT INTO oidc_identity (issuer, subject, email, workspace_id, token_id)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING *
+		)
+		SELECT i.id, i.issuer, i.subject, i.email, i.workspace_id, i.token_id, i.created_at, i.updated_at, t.key AS token_key
+		FROM i
+		JOIN token t ON i.token_id = t.id;
+	`, issuer, subject, email, workspaceId, tokenId)
+	if err != nil {
+		return nil, err
+	}
 
-	query := `
-        SELECT d.*,
-               w.external_id AS "workspace.external_id", w.name AS "workspace.name",
-               s.id AS "stub.id", s.external_id AS "stub.external_id", s.name AS "stub.name", s.config AS "stub.config", s.type AS "stub.type"
-        FROM deployment d
-        JOIN workspace w ON d.workspace_id = w.id
-        JOIN stub s ON d.stub_id = s.id
-        WHERE d.external_id = $1 and d.deleted_at IS NULL
-        LIMIT 1;
-    `
+	return &identity, nil
+}
 
-	err := c.client.GetContext(ctx, &deploymentWithRelated, query, deploymentExternalId)
-	if err != nil {
-		if err, ok := err.(*pq.Error); ok && err.Code.Class() == PostgresDataError {
-			return nil, nil
-		}
+func (c *PostgresBackendRepository) UpdateOIDCIdentityEmail(ctx context.Context, id uint, email string) error {
+	_, err := c.client.ExecContext(ctx, `
+		UPDATE oidc_identity SET email = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1;
+	`, id, email)
+	return err
+}
 
-		if err == sql.ErrNoRows {
-			return nil, nil
-		}
+func (c *PostgresBackendRepository) CreateAuditLogEntry(ctx context.Context, entry types.AuditLogEntry) error {
+	_, err := c.client.ExecContext(ctx, `
+		INSERT INTO audit_log (workspace_id, token_id, method, resource_ids, outcome, status_code, error)
+		VALUES ($1, $2, $3, $4, $5, $6, $7);
+	`, entry.WorkspaceId, entry.TokenId, entry.Method, entry.ResourceIds, entry.Outcome, entry.StatusCode, entry.Error)
+	return err
+}
+
+// ListAuditLogEntries returns a workspace's audit log entries matching the filter, newest first
+func (c *PostgresBackendRepository) ListAuditLogEntries(ctx context.Context, filter types.AuditLogFilter) ([]types.AuditLogEntry, error) {
+	qb := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar).
+		Select("id, workspace_id, token_id, method, resource_ids, outcome, status_code, error, created_at").
+		From("audit_log").
+		Where(squirrel.Eq{"workspace_id": filter.WorkspaceId}).
+		OrderBy("id DESC").
+		Limit(uint64(filter.Limit))
+
+	if !filter.Since.IsZero() {
+		qb = qb.Where(squirrel.GtOrEq{"created_at": filter.Since})
+	}
+
+	if !filter.Until.IsZero() {
+		qb = qb.Where(squirrel.Lt{"created_at": filter.Until})
+	}
 
+	if filter.TokenId != "" {
+		qb = qb.Where(squirrel.Eq{"token_id": filter.TokenId})
+	}
+
+	if filter.Method != "" {
+		qb = qb.Where(squirrel.Eq{"method": filter.Method})
+	}
+
+	if filter.BeforeId > 0 {
+		qb = qb.Where(squirrel.Lt{"id": filter.BeforeId})
+	}
+
+	query, args, err := qb.ToSql()
+	if err != nil {
 		return nil, err
 	}
 
-	return &deploymentWithRelated, nil
+	var entries []types.AuditLogEntry
+	if err := c.client.SelectContext(ctx, &entries, query, args...); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
 }
 
-func (c *PostgresBackendRepository) GetDeploymentByStubExternalId(ctx context.Context, workspaceId uint, stubExternalId string) (*types.DeploymentWithRelated, error) {
-	var deploymentWithRelated types.DeploymentWithRelated
+// DeleteAuditLogEntriesBefore prunes entries past retention, returning how many were removed
+func (c *PostgresBackendRepository) DeleteAuditLogEntriesBefore(ctx context.Context, before time.Time) (int64, error) {
+	res, err := c.client.ExecContext(ctx, `DELETE FROM audit_log WHERE created_at < $1;`, before)
+	if err != nil {
+		return 0, err
+	}
 
-	query := `
-        SELECT d.*,
-               w.external_id AS "workspace.external_id", w.name AS "workspace.name",
-               s.id AS "stub.id", s.external_id AS "stub.external_id", s.name AS "stub.name", s.config AS "stub.config", s.type AS "stub.type"
-        FROM deployment d
-        JOIN workspace w ON d.workspace_id = w.id
-        JOIN stub s ON d.stub_id = s.id
-        WHERE d.workspace_id = $1 AND s.external_id = $2 and d.deleted_at IS NULL
-        LIMIT 1;
-    `
+	return res.RowsAffected()
+}
 
-	err := c.client.GetContext(ctx, &deploymentWithRelated, query, workspaceId, stubExternalId)
+const workspaceInviteColumns = `id, external_id, workspace_id, email, role, token_hash, status, expires_at, accepted_token_id, created_at, updated_at`
+
+func (c *PostgresBackendRepository) CreateWorkspaceInvite(ctx context.Context, workspaceId uint, email string, role types.WorkspaceRole, tokenHash string, expiresAt time.Time) (*types.WorkspaceInvite, error) {
+	var invite types.WorkspaceInvite
+	err := c.client.GetContext(ctx, &invite, `
+		INSERT INTO workspace_invite (workspace_id, email, role, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING `+workspaceInviteColumns+`;
+	`, workspaceId, email, role, tokenHash, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &invite, nil
+}
+
+// RevokeWorkspaceInvite revokes a pending invitation, returning nil if there's no pending invitation with the id
+func (c *PostgresBackendRepository) RevokeWorkspaceInvite(ctx context.Context, workspaceId uint, externalId string) (*types.WorkspaceInvite, error) {
+	var invite types.WorkspaceInvite
+	err := c.client.GetContext(ctx, &invite, `
+		UPDATE workspace_invite
+		SET status = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE workspace_id = $1 AND external_id = $2 AND status = $4
+		RETURNING `+workspaceInviteColumns+`;
+	`, workspaceId, externalId, types.WorkspaceInviteStatusRevoked, types.WorkspaceInviteStatusPending)
 	if err != nil {
+		// Ids that aren't UUIDs can't match an invitation
 		if err, ok := err.(*pq.Error); ok && err.Code.Class() == PostgresDataError {
 			return nil, nil
 		}
@@ -1448,146 +3833,212 @@ func (c *PostgresBackendRepository) GetDeploymentByStubExternalId(ctx context.Co
 		return nil, err
 	}
 
-	return &deploymentWithRelated, nil
+	return &invite, nil
 }
 
-func (c *PostgresBackendRepository) listDeploymentsQueryBuilder(filters types.DeploymentFilter) squirrel.SelectBuilder {
-	qb := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar).Select(
-		"d.id, d.external_id, d.name, d.active, d.subdomain, d.workspace_id, d.stub_id, d.stub_type, d.version, d.created_at, d.updated_at, d.deleted_at",
-		"w.external_id AS \"workspace.external_id\"", "w.name AS \"workspace.name\"", "w.created_at AS \"workspace.created_at\"", "w.updated_at AS \"workspace.updated_at\"",
-		"s.external_id AS \"stub.external_id\"", "s.name AS \"stub.name\"", "s.config AS \"stub.config\"", "s.type AS \"stub.type\"", "s.created_at AS \"stub.created_at\"", "s.updated_at AS \"stub.updated_at\"",
-		"a.external_id AS \"app.external_id\"", "a.name AS \"app.name\"", "a.created_at AS \"app.created_at\"", "a.updated_at AS \"app.updated_at\"",
-	).From("deployment d").
-		Join("workspace w ON d.workspace_id = w.id").
-		Join("stub s ON d.stub_id = s.id").
-		Join("app a ON d.app_id = a.id").
-		OrderBy("d.created_at DESC")
-
-	// Apply filters
-	if !filters.ShowDeleted {
-		qb = qb.Where("d.deleted_at IS NULL")
+// AcceptWorkspaceInvite uses up a pending invitation, creating the workspace token the invitee joins with.
+// It returns nil if no pending invitation that hasn't expired has the token hash.
+func (c *PostgresBackendRepository) AcceptWorkspaceInvite(ctx context.Context, tokenHash string) (*types.WorkspaceInvite, *types.Token, error) {
+	tx, err := c.client.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, nil, err
 	}
+	defer tx.Rollback()
 
-	if filters.WorkspaceID != 0 {
-		qb = qb.Where(squirrel.Eq{"d.workspace_id": filters.WorkspaceID})
-	}
+	var invite types.WorkspaceInvite
+	err = tx.GetContext(ctx, &invite, `
+		SELECT `+workspaceInviteColumns+`
+		FROM workspace_invite
+		WHERE token_hash = $1 AND status = $2 AND expires_at > CURRENT_TIMESTAMP
+		FOR UPDATE;
+	`, tokenHash, types.WorkspaceInviteStatusPending)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil, nil
+		}
 
-	if len(filters.StubIds) > 0 {
-		qb = qb.Where(squirrel.Eq{"s.external_id": filters.StubIds})
+		return nil, nil, err
 	}
 
-	if len(filters.StubType) > 0 {
-		qb = qb.Where(squirrel.Eq{"d.stub_type": filters.StubType})
+	externalId, err := c.generateExternalId()
+	if err != nil {
+		return nil, nil, err
 	}
 
-	if filters.Subdomain != "" {
-		qb = qb.Where(squirrel.Eq{"d.subdomain": filters.Subdomain})
+	key, err := generateTokenKey()
+	if err != nil {
+		return nil, nil, err
 	}
 
-	if filters.SearchQuery != "" {
-		if err := uuid.Validate(filters.SearchQuery); err == nil {
-			qb = qb.Where(squirrel.Eq{"d.external_id": filters.SearchQuery})
-		} else {
-			qb = qb.Where(squirrel.Like{"d.name": "%" + filters.SearchQuery + "%"})
-		}
+	var token types.Token
+	err = tx.GetContext(ctx, &token, `
+		INSERT INTO token (external_id, key, active, token_type, reusable, workspace_id, role)
+		VALUES ($1, $2, true, $3, true, $4, $5)
+		RETURNING id, external_id, key, created_at, updated_at, active, token_type, reusable, workspace_id, role, scopes, expires_at, service_account_id, rate_limit;
+	`, externalId, key, types.TokenTypeWorkspace, invite.WorkspaceId, invite.Role)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	if filters.Name != "" {
-		qb = qb.Where(squirrel.Like{"d.name": fmt.Sprintf("%%%s%%", filters.Name)})
+	err = tx.GetContext(ctx, &invite, `
+		UPDATE workspace_invite
+		SET status = $2, accepted_token_id = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING `+workspaceInviteColumns+`;
+	`, invite.Id, types.WorkspaceInviteStatusAccepted, token.Id)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	if filters.Active != nil {
-		qb = qb.Where(squirrel.Eq{"d.active": filters.Active})
+	if err := tx.Commit(); err != nil {
+		return nil, nil, err
 	}
 
-	if filters.Version > 0 {
-		qb = qb.Where(squirrel.Eq{"d.version": filters.Version})
-	}
+	return &invite, &token, nil
+}
 
-	if filters.Offset > 0 {
-		qb = qb.Offset(uint64(filters.Offset))
-	}
+const serviceAccountColumns = `id, external_id, workspace_id, name, role, last_used_at, created_at, updated_at`
 
-	if filters.Limit > 0 {
-		qb = qb.Limit(uint64(filters.Limit))
+func (c *PostgresBackendRepository) CreateServiceAccount(ctx context.Context, workspaceId uint, name string, role types.WorkspaceRole) (*types.ServiceAccount, error) {
+	var serviceAccount types.ServiceAccount
+	err := c.client.GetContext(ctx, &serviceAccount, `
+		INSERT INTO service_account (workspace_id, name, role)
+		VALUES ($1, $2, $3)
+		RETURNING `+serviceAccountColumns+`;
+	`, workspaceId, name, role)
+	if err != nil {
+		return nil, err
 	}
 
-	if filters.CreatedAtStart != "" {
-		qb = qb.Where(squirrel.GtOrEq{"d.created_at": filters.CreatedAtStart})
-	}
+	return &serviceAccount, nil
+}
 
-	if filters.CreatedAtEnd != "" {
-		qb = qb.Where(squirrel.LtOrEq{"d.created_at": filters.CreatedAtEnd})
-	}
+// GetServiceAccount returns nil if the workspace has no service account with the id
+func (c *PostgresBackendRepository) GetServiceAccount(ctx context.Context, workspaceId uint, externalId string) (*types.ServiceAccount, error) {
+	var serviceAccount types.ServiceAccount
+	err := c.client.GetContext(ctx, &serviceAccount, `
+		SELECT `+serviceAccountColumns+`
+		FROM service_account
+		WHERE workspace_id = $1 AND external_id = $2;
+	`, workspaceId, externalId)
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok && err.Code.Class() == PostgresDataError {
+			return nil, nil
+		}
 
-	if filters.MinContainersGTE > 0 {
-		qb = qb.Where("COALESCE((s.config->'autoscaler'->>'min_containers')::int, 0) >= ?", filters.MinContainersGTE)
-	}
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
 
-	if filters.AppId != "" {
-		qb = qb.Where(squirrel.Eq{"a.external_id": filters.AppId})
+		return nil, err
 	}
 
-	return qb
+	return &serviceAccount, nil
 }
 
-func (c *PostgresBackendRepository) ListDeploymentsWithRelated(ctx context.Context, filters types.DeploymentFilter) ([]types.DeploymentWithRelated, error) {
-	qb := c.listDeploymentsQueryBuilder(filters)
-
-	sql, args, err := qb.ToSql()
+func (c *PostgresBackendRepository) ListServiceAccounts(ctx context.Context, workspaceId uint) ([]types.ServiceAccount, error) {
+	var serviceAccounts []types.ServiceAccount
+	err := c.client.SelectContext(ctx, &serviceAccounts, `
+		SELECT `+serviceAccountColumns+`
+		FROM service_account
+		WHERE workspace_id = $1
+		ORDER BY name;
+	`, workspaceId)
 	if err != nil {
 		return nil, err
 	}
 
-	var deployments []types.DeploymentWithRelated
-	err = c.client.SelectContext(ctx, &deployments, sql, args...)
+	return serviceAccounts, nil
+}
+
+// DeleteServiceAccount deletes a service account along with its tokens
+func (c *PostgresBackendRepository) DeleteServiceAccount(ctx context.Context, id uint) error {
+	_, err := c.client.ExecContext(ctx, `DELETE FROM service_account WHERE id = $1;`, id)
+	return err
+}
+
+// TouchServiceAccount records that one of the service account's tokens was just used
+func (c *PostgresBackendRepository) TouchServiceAccount(ctx context.Context, id uint) error {
+	_, err := c.client.ExecContext(ctx, `UPDATE service_account SET last_used_at = CURRENT_TIMESTAMP WHERE id = $1;`, id)
+	return err
+}
+
+func (c *PostgresBackendRepository) ListServiceAccountTokens(ctx context.Context, serviceAccountId uint) ([]types.Token, error) {
+	var tokens []types.Token
+	err := c.client.SelectContext(ctx, &tokens, `
+		SELECT id, external_id, key, created_at, updated_at, active, token_type, reusable, workspace_id, role, scopes, expires_at, service_account_id, rate_limit
+		FROM token
+		WHERE service_account_id = $1
+		ORDER BY created_at DESC;
+	`, serviceAccountId)
 	if err != nil {
 		return nil, err
 	}
 
-	return deployments, nil
+	return tokens, nil
 }
 
-func (c *PostgresBackendRepository) ListDeploymentsPaginated(ctx context.Context, filters types.DeploymentFilter) (common.CursorPaginationInfo[types.DeploymentWithRelated], error) {
-	qb := c.listDeploymentsQueryBuilder(filters)
+// CreateServiceAccountToken issues a token for a service account, with the service account's role
+func (c *PostgresBackendRepository) CreateServiceAccountToken(ctx context.Context, serviceAccount *types.ServiceAccount, expiresAt time.Time) (*types.Token, error) {
+	externalId, err := c.generateExternalId()
+	if err != nil {
+		return nil, err
+	}
 
-	page, err := common.Paginate(
-		common.SquirrelCursorPaginator[types.DeploymentWithRelated]{
-			Client:          c.client,
-			SelectBuilder:   qb,
-			SortOrder:       "DESC",
-			SortColumn:      "created_at",
-			SortQueryPrefix: "d",
-			PageSize:        int(filters.Limit),
-		},
-		filters.Cursor,
-	)
+	key, err := generateTokenKey()
 	if err != nil {
-		return common.CursorPaginationInfo[types.DeploymentWithRelated]{}, err
+		return nil, err
 	}
 
-	return *page, nil
+	var token types.Token
+	err = c.client.GetContext(ctx, &token, `
+		INSERT INTO token (external_id, key, active, token_type, reusable, workspace_id, role, expires_at, service_account_id)
+		VALUES ($1, $2, true, $3, true, $4, $5, $6, $7)
+		RETURNING id, external_id, key, created_at, updated_at, active, token_type, reusable, workspace_id, role, scopes, expires_at, service_account_id, rate_limit;
+	`, externalId, key, types.TokenTypeServiceAccount, serviceAccount.WorkspaceId, serviceAccount.Role, expiresAt, serviceAccount.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &token, nil
 }
 
-func (c *PostgresBackendRepository) CreateDeployment(ctx context.Context, workspaceId uint, name string, version uint, stubId uint, stubType string, appId uint) (*types.Deployment, error) {
-	var deployment types.Deployment
-
-	subdomain := generateSubdomain(name, stubType, workspaceId)
-	queryCreate := `
-		INSERT INTO deployment (name, active, subdomain, workspace_id, stub_id, version, stub_type, app_id)
-		VALUES ($1, true, $2, $3, $4, $5, $6, $7)
-		RETURNING id, external_id, name, active, subdomain, workspace_id, stub_id, stub_type, version, created_at, updated_at, app_id;
-	`
-	err := c.client.GetContext(ctx, &deployment, queryCreate, name, subdomain, workspaceId, stubId, version, stubType, appId)
+// SetTokenExpiry moves the expiry of a token that hasn't expired yet, returning nil if there's no such token
+func (c *PostgresBackendRepository) SetTokenExpiry(ctx context.Context, workspaceId uint, extTokenId string, expiresAt time.Time) (*types.Token, error) {
+	var token types.Token
+	err := c.client.GetContext(ctx, &token, `
+		UPDATE token
+		SET expires_at = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE external_id = $1 AND workspace_id = $2 AND expires_at > CURRENT_TIMESTAMP
+		RETURNING id, external_id, key, created_at, updated_at, active, token_type, reusable, workspace_id, role, scopes, expires_at, service_account_id, rate_limit;
+	`, extTokenId, workspaceId, expiresAt)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
 		return nil, err
 	}
 
-	if err := c.updateAppActivity(ctx, appId); err != nil {
+	return &token, nil
+}
+
+// ListDeploymentHistory returns every version a deployment name has pointed at, newest first
+func (c *PostgresBackendRepository) ListDeploymentHistory(ctx context.Context, workspaceId uint, name string, stubType string) ([]types.DeploymentHistory, error) {
+	var history []types.DeploymentHistory
+	err := c.client.SelectContext(ctx, &history, `
+		SELECT h.id, h.workspace_id, h.name, h.stub_type, h.version, h.deployment_id, h.stub_id,
+			s.external_id AS stub_external_id, h.action, h.rolled_back_to_version, h.created_at
+		FROM deployment_history h
+		JOIN stub s ON h.stub_id = s.id
+		WHERE h.workspace_id = $1 AND h.name = $2 AND h.stub_type = $3
+		ORDER BY h.version DESC;
+	`, workspaceId, name, stubType)
+	if err != nil {
 		return nil, err
 	}
 
-	return &deployment, nil
+	return history, nil
 }
 
 func (c *PostgresBackendRepository) listStubsQueryBuilder(filters types.StubFilter) squirrel.SelectBuilder {
@@ -1705,7 +4156,7 @@ func (r *PostgresBackendRepository) GetConcurrencyLimit(ctx context.Context, con
 func (r *PostgresBackendRepository) GetWorkspaceStorage(ctx context.Context, storageId uint) (*types.WorkspaceStorage, error) {
 	var storage types.WorkspaceStorage
 
-	query := `SELECT bucket_name, access_key, secret_key, endpoint_url, region, created_at, updated_at FROM workspace_storage WHERE id = $1;`
+	query := `SELECT bucket_name, access_key, secret_key, endpoint_url, region, provider, created_at, updated_at FROM workspace_storage WHERE id = $1;`
 	if err := r.client.GetContext(ctx, &storage, query, storageId); err != nil {
 		return nil, err
 	}
@@ -1718,24 +4169,34 @@ func (r *PostgresBackendRepository) GetWorkspaceStorage(ctx context.Context, sto
 }
 
 func (r *PostgresBackendRepository) CreateWorkspaceStorage(ctx context.Context, workspaceId uint, storage types.WorkspaceStorage) (*types.WorkspaceStorage, error) {
-	query := `
-	INSERT INTO workspace_storage (bucket_name, access_key, secret_key, endpoint_url, region)
-	VALUES ($1, $2, $3, $4, $5)
-	RETURNING id, external_id, bucket_name, access_key, secret_key, endpoint_url, region, created_at, updated_at;
+	created, err := r.insertWorkspaceStorage(ctx, storage)
+	if err != nil {
+		return nil, err
+	}
+
+	queryUpdateWorkspace := `
+	UPDATE workspace
+	SET storage_id = $1
+	WHERE id = $2;
 	`
 
-	if err := r.encryptFields(&storage); err != nil {
+	if _, err := r.client.ExecContext(ctx, queryUpdateWorkspace, created.Id, workspaceId); err != nil {
 		return nil, err
 	}
 
-	var created types.WorkspaceStorage
-	if err := r.client.GetContext(ctx, &created, query, storage.BucketName, storage.AccessKey, storage.SecretKey, storage.EndpointUrl, storage.Region); err != nil {
+	return created, nil
+}
+
+// SetWorkspaceReplicaStorage registers the secondary storage a workspace's objects are replicated to
+func (r *PostgresBackendRepository) SetWorkspaceReplicaStorage(ctx context.Context, workspaceId uint, storage types.WorkspaceStorage) (*types.WorkspaceStorage, error) {
+	created, err := r.insertWorkspaceStorage(ctx, storage)
+	if err != nil {
 		return nil, err
 	}
 
 	queryUpdateWorkspace := `
 	UPDATE workspace
-	SET storage_id = $1
+	SET replica_storage_id = $1
 	WHERE id = $2;
 	`
 
@@ -1743,6 +4204,318 @@ func (r *PostgresBackendRepository) CreateWorkspaceStorage(ctx context.Context,
 		return nil, err
 	}
 
+	return created, nil
+}
+
+func (r *PostgresBackendRepository) GetWorkspaceReplicaStorage(ctx context.Context, workspaceId uint) (*types.WorkspaceStorage, error) {
+	var storage types.WorkspaceStorage
+
+	query := `
+	SELECT ws.id, ws.external_id, ws.bucket_name, ws.access_key, ws.secret_key, ws.endpoint_url, ws.region, ws.provider, ws.created_at, ws.updated_at
+	FROM workspace w
+	JOIN workspace_storage ws ON w.replica_storage_id = ws.id
+	WHERE w.id = $1;
+	`
+	if err := r.client.GetContext(ctx, &storage, query, workspaceId); err != nil {
+		return nil, err
+	}
+
+	if err := r.decryptFields(&storage); err != nil {
+		return nil, err
+	}
+
+	return &storage, nil
+}
+
+// GetWorkspaceVolumeQuota returns the workspace's volume quota override, nil if it uses the gateway default
+func (r *PostgresBackendRepository) GetWorkspaceVolumeQuota(ctx context.Context, workspaceId uint) (*int64, error) {
+	var quota *int64
+
+	query := `SELECT volume_quota_bytes FROM workspace WHERE id = $1;`
+	if err := r.client.GetContext(ctx, &quota, query, workspaceId); err != nil {
+		return nil, err
+	}
+
+	return quota, nil
+}
+
+func (r *PostgresBackendRepository) SetWorkspaceVolumeQuota(ctx context.Context, workspaceId uint, quotaBytes *int64) error {
+	query := `
+	UPDATE workspace
+	SET volume_quota_bytes = $1, updated_at = CURRENT_TIMESTAMP
+	WHERE id = $2;
+	`
+
+	result, err := r.client.ExecContext(ctx, query, quotaBytes, workspaceId)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetWorkspaceIPAllowList returns the CIDRs the workspace's API can be used from, empty if it can be used from anywhere
+func (r *PostgresBackendRepository) GetWorkspaceIPAllowList(ctx context.Context, workspaceId uint) ([]string, error) {
+	var cidrs pq.StringArray
+
+	query := `SELECT ip_allowlist FROM workspace WHERE id = $1;`
+	if err := r.client.GetContext(ctx, &cidrs, query, workspaceId); err != nil {
+		return nil, err
+	}
+
+	return []string(cidrs), nil
+}
+
+func (r *PostgresBackendRepository) SetWorkspaceIPAllowList(ctx context.Context, workspaceId uint, cidrs []string) error {
+	query := `
+	UPDATE workspace
+	SET ip_allowlist = $1, updated_at = CURRENT_TIMESTAMP
+	WHERE id = $2;
+	`
+
+	result, err := r.client.ExecContext(ctx, query, pq.Array(cidrs), workspaceId)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetWorkspacePolicy returns the resource policy of the workspace with this external id, nil if it has none
+func (r *PostgresBackendRepository) GetWorkspacePolicy(ctx context.Context, workspaceId string) (*types.WorkspacePolicy, error) {
+	var policy types.WorkspacePolicy
+
+	query := `
+	SELECT wp.id, wp.workspace_id, wp.max_concurrent_gpus, wp.max_container_memory, wp.allowed_gpu_types, wp.max_run_duration_seconds, wp.created_at, wp.updated_at
+	FROM workspace_policy wp
+	JOIN workspace w ON wp.workspace_id = w.id
+	WHERE w.external_id = $1;
+	`
+
+	if err := r.client.GetContext(ctx, &policy, query, workspaceId); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// SetWorkspacePolicy replaces the workspace's resource policy
+func (r *PostgresBackendRepository) SetWorkspacePolicy(ctx context.Context, workspaceId uint, policy types.WorkspacePolicy) (*types.WorkspacePolicy, error) {
+	query := `
+	INSERT INTO workspace_policy (workspace_id, max_concurrent_gpus, max_container_memory, allowed_gpu_types, max_run_duration_seconds)
+	VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT (workspace_id) DO UPDATE
+	SET max_concurrent_gpus = EXCLUDED.max_concurrent_gpus,
+		max_container_memory = EXCLUDED.max_container_memory,
+		allowed_gpu_types = EXCLUDED.allowed_gpu_types,
+		max_run_duration_seconds = EXCLUDED.max_run_duration_seconds,
+		updated_at = CURRENT_TIMESTAMP
+	RETURNING id, workspace_id, max_concurrent_gpus, max_container_memory, allowed_gpu_types, max_run_duration_seconds, created_at, updated_at;
+	`
+
+	var updated types.WorkspacePolicy
+	if err := r.client.GetContext(ctx, &updated, query, workspaceId, policy.MaxConcurrentGpus, policy.MaxContainerMemory, policy.AllowedGpuTypes, policy.MaxRunDurationSeconds); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// ListPoolQuotas returns the pool quotas of all workspaces, with the workspaces' external ids
+func (r *PostgresBackendRepository) ListPoolQuotas(ctx context.Context) ([]types.PoolQuota, error) {
+	var quotas []types.PoolQuota
+
+	query := `
+	SELECT q.id, w.external_id AS workspace_id, q.pool_name, q.max_machines, q.max_gpus, q.reserved_gpus, q.created_at, q.updated_at
+	FROM pool_quota q
+	JOIN workspace w ON q.workspace_id = w.id
+	ORDER BY w.external_id, q.pool_name;
+	`
+
+	if err := r.client.SelectContext(ctx, &quotas, query); err != nil {
+		return nil, err
+	}
+
+	return quotas, nil
+}
+
+// SetPoolQuota replaces the workspace's quota for a pool
+func (r *PostgresBackendRepository) SetPoolQuota(ctx context.Context, workspaceId uint, quota types.PoolQuota) (*types.PoolQuota, error) {
+	query := `
+	WITH upserted AS (
+		INSERT INTO pool_quota (workspace_id, pool_name, max_machines, max_gpus, reserved_gpus)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (workspace_id, pool_name) DO UPDATE
+		SET max_machines = EXCLUDED.max_machines,
+			max_gpus = EXCLUDED.max_gpus,
+			reserved_gpus = EXCLUDED.reserved_gpus,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING id, workspace_id, pool_name, max_machines, max_gpus, reserved_gpus, created_at, updated_at
+	)
+	SELECT u.id, w.external_id AS workspace_id, u.pool_name, u.max_machines, u.max_gpus, u.reserved_gpus, u.created_at, u.updated_at
+	FROM upserted u
+	JOIN workspace w ON u.workspace_id = w.id;
+	`
+
+	var updated types.PoolQuota
+	if err := r.client.GetContext(ctx, &updated, query, workspaceId, quota.PoolName, quota.MaxMachines, quota.MaxGpus, quota.ReservedGpus); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+func (r *PostgresBackendRepository) DeletePoolQuota(ctx context.Context, workspaceId uint, poolName string) error {
+	query := `DELETE FROM pool_quota WHERE workspace_id = $1 AND pool_name = $2;`
+	_, err := r.client.ExecContext(ctx, query, workspaceId, poolName)
+	return err
+}
+
+// ListCapacityReservations returns the reservations of all workspaces that haven't ended yet, with the workspaces'
+// external ids
+func (r *PostgresBackendRepository) ListCapacityReservations(ctx context.Context) ([]types.CapacityReservation, error) {
+	var reservations []types.CapacityReservation
+
+	query := `
+	SELECT c.id, c.external_id, w.external_id AS workspace_id, c.pool_name, c.gpu_type, c.gpu_count, c.starts_at, c.ends_at, c.created_at
+	FROM capacity_reservation c
+	JOIN workspace w ON c.workspace_id = w.id
+	WHERE c.ends_at > CURRENT_TIMESTAMP
+	ORDER BY c.starts_at;
+	`
+
+	if err := r.client.SelectContext(ctx, &reservations, query); err != nil {
+		return nil, err
+	}
+
+	return reservations, nil
+}
+
+func (r *PostgresBackendRepository) CreateCapacityReservation(ctx context.Context, workspaceId uint, reservation types.CapacityReservation) (*types.CapacityReservation, error) {
+	query := `
+	WITH inserted AS (
+		INSERT INTO capacity_reservation (workspace_id, pool_name, gpu_type, gpu_count, starts_at, ends_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, external_id, workspace_id, pool_name, gpu_type, gpu_count, starts_at, ends_at, created_at
+	)
+	SELECT i.id, i.external_id, w.external_id AS workspace_id, i.pool_name, i.gpu_type, i.gpu_count, i.starts_at, i.ends_at, i.created_at
+	FROM inserted i
+	JOIN workspace w ON i.workspace_id = w.id;
+	`
+
+	var created types.CapacityReservation
+	if err := r.client.GetContext(ctx, &created, query, workspaceId, reservation.PoolName, reservation.GpuType, reservation.GpuCount, reservation.StartsAt.Time, reservation.EndsAt.Time); err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// DeleteCapacityReservation returns sql.ErrNoRows if the workspace has no such reservation
+func (r *PostgresBackendRepository) DeleteCapacityReservation(ctx context.Context, workspaceId uint, externalId string) error {
+	query := `DELETE FROM capacity_reservation WHERE external_id = $1 AND workspace_id = $2;`
+	result, err := r.client.ExecContext(ctx, query, externalId, workspaceId)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetWorkspaceUploadBandwidthLimit returns the workspace's upload limit override, nil if it uses the gateway default
+func (r *PostgresBackendRepository) GetWorkspaceUploadBandwidthLimit(ctx context.Context, workspaceId uint) (*int64, error) {
+	var limit *int64
+
+	query := `SELECT upload_bandwidth_limit FROM workspace WHERE id = $1;`
+	if err := r.client.GetContext(ctx, &limit, query, workspaceId); err != nil {
+		return nil, err
+	}
+
+	return limit, nil
+}
+
+func (r *PostgresBackendRepository) SetWorkspaceUploadBandwidthLimit(ctx context.Context, workspaceId uint, bytesPerSecond *int64) error {
+	query := `
+	UPDATE workspace
+	SET upload_bandwidth_limit = $1, updated_at = CURRENT_TIMESTAMP
+	WHERE id = $2;
+	`
+
+	result, err := r.client.ExecContext(ctx, query, bytesPerSecond, workspaceId)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *PostgresBackendRepository) ListWorkspaceIdsWithReplicaStorage(ctx context.Context) ([]uint, error) {
+	var workspaceIds []uint
+
+	query := `SELECT id FROM workspace WHERE replica_storage_id IS NOT NULL AND storage_id IS NOT NULL ORDER BY id;`
+	if err := r.client.SelectContext(ctx, &workspaceIds, query); err != nil {
+		return nil, err
+	}
+
+	return workspaceIds, nil
+}
+
+func (r *PostgresBackendRepository) insertWorkspaceStorage(ctx context.Context, storage types.WorkspaceStorage) (*types.WorkspaceStorage, error) {
+	query := `
+	INSERT INTO workspace_storage (bucket_name, access_key, secret_key, endpoint_url, region, provider)
+	VALUES ($1, $2, $3, $4, $5, COALESCE($6, 's3'))
+	RETURNING id, external_id, bucket_name, access_key, secret_key, endpoint_url, region, provider, created_at, updated_at;
+	`
+
+	if err := r.encryptFields(&storage); err != nil {
+		return nil, err
+	}
+
+	var created types.WorkspaceStorage
+	if err := r.client.GetContext(ctx, &created, query, storage.BucketName, storage.AccessKey, storage.SecretKey, storage.EndpointUrl, storage.Region, storage.Provider); err != nil {
+		return nil, err
+	}
+
 	return &created, nil
 }
 
@@ -1892,9 +4665,15 @@ func validateEnvironmentVariableName(name string) error {
 
 func (r *PostgresBackendRepository) CreateSecret(ctx context.Context, workspace *types.Workspace, tokenId uint, name string, value string, validateName bool) (*types.Secret, error) {
 	query := `
-	INSERT INTO workspace_secret (name, value, workspace_id, last_updated_by)
-	VALUES ($1, $2, $3, $4)
-	RETURNING id, external_id, name, workspace_id, last_updated_by, created_at, updated_at;
+	WITH secret AS (
+		INSERT INTO workspace_secret (name, value, workspace_id, last_updated_by)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, external_id, name, value, version, workspace_id, last_updated_by, created_at, updated_at
+	), secret_version AS (
+		INSERT INTO workspace_secret_version (secret_id, version, value, created_by)
+		SELECT id, version, value, last_updated_by FROM secret
+	)
+	SELECT id, external_id, name, version, workspace_id, last_updated_by, created_at, updated_at FROM secret;
 	`
 
 	if validateName {
@@ -1925,7 +4704,7 @@ func (r *PostgresBackendRepository) CreateSecret(ctx context.Context, workspace
 func (r *PostgresBackendRepository) GetSecretByName(ctx context.Context, workspace *types.Workspace, name string) (*types.Secret, error) {
 	var secret types.Secret
 
-	query := `SELECT id, external_id, name, value, workspace_id, last_updated_by, created_at, updated_at FROM workspace_secret WHERE name = $1 AND workspace_id = $2;`
+	query := `SELECT id, external_id, name, value, version, workspace_id, last_updated_by, created_at, updated_at FROM workspace_secret WHERE name = $1 AND workspace_id = $2;`
 	err := r.client.GetContext(ctx, &secret, query, name, workspace.Id)
 	if err != nil {
 		return nil, err
@@ -1935,7 +4714,7 @@ func (r *PostgresBackendRepository) GetSecretByName(ctx context.Context, workspa
 }
 
 func (r *PostgresBackendRepository) GetSecretsByName(ctx context.Context, workspace *types.Workspace, names []string) ([]types.Secret, error) {
-	query := `SELECT id, external_id, name, value, workspace_id, last_updated_by, created_at, updated_at FROM workspace_secret WHERE name = ANY($1) AND workspace_id = $2;`
+	query := `SELECT id, external_id, name, value, version, workspace_id, last_updated_by, created_at, updated_at FROM workspace_secret WHERE name = ANY($1) AND workspace_id = $2;`
 
 	var secrets []types.Secret
 	err := r.client.SelectContext(ctx, &secrets, query, pq.Array(names), workspace.Id)
@@ -1990,7 +4769,7 @@ func (r *PostgresBackendRepository) GetSecretsByNameDecrypted(ctx context.Contex
 }
 
 func (r *PostgresBackendRepository) ListSecrets(ctx context.Context, workspace *types.Workspace) ([]types.Secret, error) {
-	query := `SELECT id, external_id, name, workspace_id, last_updated_by, created_at, updated_at FROM workspace_secret WHERE workspace_id = $1;`
+	query := `SELECT id, external_id, name, version, workspace_id, last_updated_by, created_at, updated_at FROM workspace_secret WHERE workspace_id = $1;`
 
 	var secrets []types.Secret
 	err := r.client.SelectContext(ctx, &secrets, query, workspace.Id)
@@ -2012,11 +4791,22 @@ func (r *PostgresBackendRepository) DeleteSecret(ctx context.Context, workspace
 }
 
 func (r *PostgresBackendRepository) UpdateSecret(ctx context.Context, workspace *types.Workspace, tokenId uint, secretName string, value string) (*types.Secret, error) {
+	return r.RotateSecret(ctx, workspace, tokenId, secretName, value)
+}
+
+// RotateSecret stores value as the secret's next version and makes it the current value
+func (r *PostgresBackendRepository) RotateSecret(ctx context.Context, workspace *types.Workspace, tokenId uint, secretName string, value string) (*types.Secret, error) {
 	query := `
-	UPDATE workspace_secret
-	SET value = $3, last_updated_by = $4, updated_at = CURRENT_TIMESTAMP
-	WHERE name = $1 AND workspace_id = $2
-	RETURNING id, external_id, name, workspace_id, last_updated_by, created_at, updated_at;
+	WITH secret AS (
+		UPDATE workspace_secret
+		SET value = $3, version = version + 1, last_updated_by = $4, updated_at = CURRENT_TIMESTAMP
+		WHERE name = $1 AND workspace_id = $2
+		RETURNING id, external_id, name, value, version, workspace_id, last_updated_by, created_at, updated_at
+	), secret_version AS (
+		INSERT INTO workspace_secret_version (secret_id, version, value, created_by)
+		SELECT id, version, value, last_updated_by FROM secret
+	)
+	SELECT id, external_id, name, version, workspace_id, last_updated_by, created_at, updated_at FROM secret;
 	`
 
 	secretKey, err := pkgCommon.ParseSecretKey(*workspace.SigningKey)
@@ -2037,6 +4827,44 @@ func (r *PostgresBackendRepository) UpdateSecret(ctx context.Context, workspace
 	return &secret, nil
 }
 
+// GetSecretVersion returns a secret with the (encrypted) value it had at the given version
+func (r *PostgresBackendRepository) GetSecretVersion(ctx context.Context, workspace *types.Workspace, name string, version uint) (*types.Secret, error) {
+	query := `
+	SELECT ws.id, ws.external_id, ws.name, wsv.value, wsv.version, ws.workspace_id, wsv.created_by AS last_updated_by, ws.created_at, wsv.created_at AS updated_at
+	FROM workspace_secret ws
+	JOIN workspace_secret_version wsv ON wsv.secret_id = ws.id
+	WHERE ws.name = $1 AND ws.workspace_id = $2 AND wsv.version = $3;
+	`
+
+	var secret types.Secret
+	if err := r.client.GetContext(ctx, &secret, query, name, workspace.Id, version); err != nil {
+		return nil, err
+	}
+
+	return &secret, nil
+}
+
+func (r *PostgresBackendRepository) GetSecretVersionDecrypted(ctx context.Context, workspace *types.Workspace, name string, version uint) (*types.Secret, error) {
+	secret, err := r.GetSecretVersion(ctx, workspace, name, version)
+	if err != nil {
+		return nil, err
+	}
+
+	secretKey, err := pkgCommon.ParseSecretKey(*workspace.SigningKey)
+	if err != nil {
+		return nil, err
+	}
+
+	decryptedSecret, err := pkgCommon.Decrypt(secretKey, secret.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	secret.Value = string(decryptedSecret)
+
+	return secret, nil
+}
+
 func (r *PostgresBackendRepository) CreateScheduledJob(ctx context.Context, scheduledJob *types.ScheduledJob) (*types.ScheduledJob, error) {
 	payloadJSON, err := json.Marshal(scheduledJob.Payload)
 	if err != nil {
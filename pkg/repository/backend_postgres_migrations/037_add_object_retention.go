@@ -0,0 +1,24 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddObjectRetention, downAddObjectRetention)
+}
+
+func upAddObjectRetention(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE object ADD COLUMN IF NOT EXISTS retain_until TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+	`)
+	return err
+}
+
+func downAddObjectRetention(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE object DROP COLUMN IF EXISTS retain_until;`)
+	return err
+}
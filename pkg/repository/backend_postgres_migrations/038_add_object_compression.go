@@ -0,0 +1,30 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddObjectCompression, downAddObjectCompression)
+}
+
+func upAddObjectCompression(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE object
+		ADD COLUMN IF NOT EXISTS compression VARCHAR(16) NOT NULL DEFAULT '',
+		ADD COLUMN IF NOT EXISTS stored_size BIGINT NOT NULL DEFAULT 0;
+	`)
+	return err
+}
+
+func downAddObjectCompression(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE object
+		DROP COLUMN IF EXISTS stored_size,
+		DROP COLUMN IF EXISTS compression;
+	`)
+	return err
+}
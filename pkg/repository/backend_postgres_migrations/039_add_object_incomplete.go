@@ -0,0 +1,24 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddObjectIncomplete, downAddObjectIncomplete)
+}
+
+func upAddObjectIncomplete(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE object ADD COLUMN IF NOT EXISTS incomplete BOOLEAN NOT NULL DEFAULT false;
+	`)
+	return err
+}
+
+func downAddObjectIncomplete(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE object DROP COLUMN IF EXISTS incomplete;`)
+	return err
+}
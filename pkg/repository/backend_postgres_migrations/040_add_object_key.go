@@ -0,0 +1,40 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddObjectKey, downAddObjectKey)
+}
+
+func upAddObjectKey(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE object ADD COLUMN IF NOT EXISTS key VARCHAR(1024) DEFAULT NULL;
+	`)
+	if err != nil {
+		return err
+	}
+
+	// Logical keys are unique per workspace
+	_, err = tx.Exec(`
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_object_workspace_key
+		ON object(workspace_id, key) WHERE key IS NOT NULL;
+	`)
+	return err
+}
+
+func downAddObjectKey(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		DROP INDEX IF EXISTS idx_object_workspace_key;
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`ALTER TABLE object DROP COLUMN IF EXISTS key;`)
+	return err
+}
@@ -0,0 +1,50 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddObjectRegion, downAddObjectRegion)
+}
+
+func upAddObjectRegion(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE object ADD COLUMN IF NOT EXISTS region TEXT NOT NULL DEFAULT '';
+	`)
+	if err != nil {
+		return err
+	}
+
+	// Backfill existing objects from their workspace's storage config
+	_, err = tx.Exec(`
+		UPDATE object o
+		SET region = ws.region
+		FROM workspace w
+		JOIN workspace_storage ws ON w.storage_id = ws.id
+		WHERE o.workspace_id = w.id;
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX IF NOT EXISTS idx_object_region ON object(region);
+	`)
+	return err
+}
+
+func downAddObjectRegion(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		DROP INDEX IF EXISTS idx_object_region;
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`ALTER TABLE object DROP COLUMN IF EXISTS region;`)
+	return err
+}
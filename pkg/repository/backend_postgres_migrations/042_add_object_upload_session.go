@@ -0,0 +1,41 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddObjectUploadSession, downAddObjectUploadSession)
+}
+
+func upAddObjectUploadSession(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS object_upload_session (
+			id SERIAL PRIMARY KEY,
+			external_id UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+			workspace_id INT NOT NULL REFERENCES workspace(id) ON DELETE CASCADE,
+			hash VARCHAR(255) NOT NULL,
+			size BIGINT NOT NULL DEFAULT 0,
+			"offset" BIGINT NOT NULL DEFAULT 0,
+			created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+		);
+	`)
+	if err != nil {
+		return err
+	}
+
+	// Stale sessions are garbage collected by last activity
+	_, err = tx.Exec(`
+		CREATE INDEX IF NOT EXISTS idx_object_upload_session_updated_at ON object_upload_session(updated_at);
+	`)
+	return err
+}
+
+func downAddObjectUploadSession(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`DROP TABLE IF EXISTS object_upload_session;`)
+	return err
+}
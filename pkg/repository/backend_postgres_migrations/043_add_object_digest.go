@@ -0,0 +1,25 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddObjectDigest, downAddObjectDigest)
+}
+
+func upAddObjectDigest(ctx context.Context, tx *sql.Tx) error {
+	// An empty digest means the content was never verified by the gateway
+	_, err := tx.Exec(`
+		ALTER TABLE object ADD COLUMN IF NOT EXISTS digest VARCHAR(64) NOT NULL DEFAULT '';
+	`)
+	return err
+}
+
+func downAddObjectDigest(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE object DROP COLUMN IF EXISTS digest;`)
+	return err
+}
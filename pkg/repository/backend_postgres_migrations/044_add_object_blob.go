@@ -0,0 +1,44 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddObjectBlob, downAddObjectBlob)
+}
+
+func upAddObjectBlob(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS object_blob (
+			id SERIAL PRIMARY KEY,
+			digest VARCHAR(64) UNIQUE NOT NULL,
+			size BIGINT NOT NULL DEFAULT 0,
+			compression VARCHAR(16) NOT NULL DEFAULT '',
+			stored_size BIGINT NOT NULL DEFAULT 0,
+			ref_count INT NOT NULL DEFAULT 0,
+			created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+		);
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE object ADD COLUMN IF NOT EXISTS blob_id INT REFERENCES object_blob(id) ON DELETE SET NULL DEFAULT NULL;
+	`)
+	return err
+}
+
+func downAddObjectBlob(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE object DROP COLUMN IF EXISTS blob_id;`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`DROP TABLE IF EXISTS object_blob;`)
+	return err
+}
@@ -0,0 +1,64 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddObjectLifecycle, downAddObjectLifecycle)
+}
+
+func upAddObjectLifecycle(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS object_lifecycle_policy (
+			workspace_id INT PRIMARY KEY REFERENCES workspace(id) ON DELETE CASCADE,
+			expire_after_days INT NOT NULL DEFAULT 0 CHECK (expire_after_days >= 0),
+			expire_unused_after_days INT NOT NULL DEFAULT 0 CHECK (expire_unused_after_days >= 0),
+			created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+		);
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE object ADD COLUMN IF NOT EXISTS last_used_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP;
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`UPDATE object SET last_used_at = created_at WHERE created_at IS NOT NULL;`)
+	if err != nil {
+		return err
+	}
+
+	// Expired objects may still be referenced by stubs of inactive deployments
+	_, err = tx.Exec(`
+		ALTER TABLE stub DROP CONSTRAINT IF EXISTS stub_object_id_fkey;
+		ALTER TABLE stub ADD CONSTRAINT stub_object_id_fkey FOREIGN KEY (object_id) REFERENCES object(id) ON DELETE SET NULL;
+	`)
+	return err
+}
+
+func downAddObjectLifecycle(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE stub DROP CONSTRAINT IF EXISTS stub_object_id_fkey;
+		ALTER TABLE stub ADD CONSTRAINT stub_object_id_fkey FOREIGN KEY (object_id) REFERENCES object(id);
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`ALTER TABLE object DROP COLUMN IF EXISTS last_used_at;`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`DROP TABLE IF EXISTS object_lifecycle_policy;`)
+	return err
+}
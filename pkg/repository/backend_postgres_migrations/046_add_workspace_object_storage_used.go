@@ -0,0 +1,32 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddWorkspaceObjectStorageUsed, downAddWorkspaceObjectStorageUsed)
+}
+
+func upAddWorkspaceObjectStorageUsed(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE workspace ADD COLUMN IF NOT EXISTS object_storage_used BIGINT NOT NULL DEFAULT 0;
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE workspace w
+		SET object_storage_used = COALESCE((SELECT SUM(o.size) FROM object o WHERE o.workspace_id = w.id), 0);
+	`)
+	return err
+}
+
+func downAddWorkspaceObjectStorageUsed(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE workspace DROP COLUMN IF EXISTS object_storage_used;`)
+	return err
+}
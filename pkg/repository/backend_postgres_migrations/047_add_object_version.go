@@ -0,0 +1,48 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddObjectVersion, downAddObjectVersion)
+}
+
+func upAddObjectVersion(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS object_version (
+			id SERIAL PRIMARY KEY,
+			external_id UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+			object_id INT NOT NULL REFERENCES object(id) ON DELETE CASCADE,
+			hash VARCHAR(255) NOT NULL,
+			size BIGINT NOT NULL DEFAULT 0,
+			digest VARCHAR(64) NOT NULL DEFAULT '',
+			compression VARCHAR(16) NOT NULL DEFAULT '',
+			stored_size BIGINT NOT NULL DEFAULT 0,
+			created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE INDEX IF NOT EXISTS idx_object_version_object_id ON object_version (object_id, created_at DESC);
+	`)
+	if err != nil {
+		return err
+	}
+
+	// NULL falls back to the gateway's default retention
+	_, err = tx.Exec(`
+		ALTER TABLE object_lifecycle_policy ADD COLUMN IF NOT EXISTS retain_versions INT NULL CHECK (retain_versions >= 0);
+	`)
+	return err
+}
+
+func downAddObjectVersion(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE object_lifecycle_policy DROP COLUMN IF EXISTS retain_versions;`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`DROP TABLE IF EXISTS object_version;`)
+	return err
+}
@@ -0,0 +1,64 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddObjectEncryption, downAddObjectEncryption)
+}
+
+func upAddObjectEncryption(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS workspace_object_key (
+			workspace_id INT PRIMARY KEY REFERENCES workspace(id) ON DELETE CASCADE,
+			wrapped_key TEXT NOT NULL,
+			created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+		);
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE object
+		ADD COLUMN IF NOT EXISTS encryption VARCHAR(16) NOT NULL DEFAULT '',
+		ADD COLUMN IF NOT EXISTS wrapped_data_key TEXT NOT NULL DEFAULT '';
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE object_version
+		ADD COLUMN IF NOT EXISTS encryption VARCHAR(16) NOT NULL DEFAULT '',
+		ADD COLUMN IF NOT EXISTS wrapped_data_key TEXT NOT NULL DEFAULT '';
+	`)
+	return err
+}
+
+func downAddObjectEncryption(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE object_version
+		DROP COLUMN IF EXISTS wrapped_data_key,
+		DROP COLUMN IF EXISTS encryption;
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE object
+		DROP COLUMN IF EXISTS wrapped_data_key,
+		DROP COLUMN IF EXISTS encryption;
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`DROP TABLE IF EXISTS workspace_object_key;`)
+	return err
+}
@@ -0,0 +1,34 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddObjectTags, downAddObjectTags)
+}
+
+func upAddObjectTags(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE object ADD COLUMN IF NOT EXISTS tags JSONB NOT NULL DEFAULT '{}'::jsonb;
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX IF NOT EXISTS idx_object_tags ON object USING GIN (tags jsonb_path_ops);
+	`)
+	return err
+}
+
+func downAddObjectTags(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		DROP INDEX IF EXISTS idx_object_tags;
+		ALTER TABLE object DROP COLUMN IF EXISTS tags;
+	`)
+	return err
+}
@@ -0,0 +1,26 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddObjectLocation, downAddObjectLocation)
+}
+
+func upAddObjectLocation(ctx context.Context, tx *sql.Tx) error {
+	// Existing objects are assumed to be on the gateway filesystem; the object migrator corrects any that
+	// were uploaded straight to workspace storage when it finds no local file
+	_, err := tx.Exec(`
+		ALTER TABLE object ADD COLUMN IF NOT EXISTS location VARCHAR(32) NOT NULL DEFAULT 'local';
+	`)
+	return err
+}
+
+func downAddObjectLocation(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE object DROP COLUMN IF EXISTS location;`)
+	return err
+}
@@ -0,0 +1,43 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddObjectUploadChunk, downAddObjectUploadChunk)
+}
+
+func upAddObjectUploadChunk(ctx context.Context, tx *sql.Tx) error {
+	// Sessions with a chunk size accept fixed size chunks in any order instead of sequential appends
+	_, err := tx.Exec(`
+		ALTER TABLE object_upload_session ADD COLUMN IF NOT EXISTS chunk_size BIGINT NOT NULL DEFAULT 0;
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE TABLE IF NOT EXISTS object_upload_chunk (
+			session_id INT NOT NULL REFERENCES object_upload_session(id) ON DELETE CASCADE,
+			chunk_index INT NOT NULL,
+			size BIGINT NOT NULL,
+			created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (session_id, chunk_index)
+		);
+	`)
+	return err
+}
+
+func downAddObjectUploadChunk(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`DROP TABLE IF EXISTS object_upload_chunk;`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`ALTER TABLE object_upload_session DROP COLUMN IF EXISTS chunk_size;`)
+	return err
+}
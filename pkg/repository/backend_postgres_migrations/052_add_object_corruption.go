@@ -0,0 +1,52 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddObjectCorruption, downAddObjectCorruption)
+}
+
+func upAddObjectCorruption(ctx context.Context, tx *sql.Tx) error {
+	// The integrity auditor samples the objects it checked least recently, starting with those never checked
+	_, err := tx.Exec(`
+		ALTER TABLE object ADD COLUMN IF NOT EXISTS integrity_checked_at TIMESTAMP WITH TIME ZONE;
+		CREATE INDEX IF NOT EXISTS idx_object_integrity_checked_at ON object(integrity_checked_at NULLS FIRST, id);
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE TABLE IF NOT EXISTS object_corruption (
+			object_id INT PRIMARY KEY REFERENCES object(id) ON DELETE CASCADE,
+			workspace_id INT NOT NULL REFERENCES workspace(id) ON DELETE CASCADE,
+			reason VARCHAR(255) NOT NULL,
+			expected_size BIGINT NOT NULL DEFAULT 0,
+			actual_size BIGINT NOT NULL DEFAULT 0,
+			expected_digest VARCHAR(64) NOT NULL DEFAULT '',
+			actual_digest VARCHAR(64) NOT NULL DEFAULT '',
+			quarantined BOOLEAN NOT NULL DEFAULT false,
+			detected_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE INDEX IF NOT EXISTS idx_object_corruption_workspace_id ON object_corruption(workspace_id);
+	`)
+	return err
+}
+
+func downAddObjectCorruption(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`DROP TABLE IF EXISTS object_corruption;`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		DROP INDEX IF EXISTS idx_object_integrity_checked_at;
+		ALTER TABLE object DROP COLUMN IF EXISTS integrity_checked_at;
+	`)
+	return err
+}
@@ -0,0 +1,23 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddWorkspaceStorageProvider, downAddWorkspaceStorageProvider)
+}
+
+func upAddWorkspaceStorageProvider(ctx context.Context, tx *sql.Tx) error {
+	// Existing workspace storage is all S3 compatible
+	_, err := tx.Exec(`ALTER TABLE workspace_storage ADD COLUMN IF NOT EXISTS provider VARCHAR(32) NOT NULL DEFAULT 's3';`)
+	return err
+}
+
+func downAddWorkspaceStorageProvider(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE workspace_storage DROP COLUMN IF EXISTS provider;`)
+	return err
+}
@@ -0,0 +1,45 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddObjectReplica, downAddObjectReplica)
+}
+
+func upAddObjectReplica(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE workspace ADD COLUMN IF NOT EXISTS replica_storage_id INT NULL REFERENCES workspace_storage(id) ON DELETE SET NULL;
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE TABLE IF NOT EXISTS object_replica (
+			id SERIAL PRIMARY KEY,
+			object_id INT NOT NULL REFERENCES object(id) ON DELETE CASCADE,
+			region VARCHAR(255) NOT NULL,
+			status VARCHAR(32) NOT NULL DEFAULT 'pending',
+			error TEXT NOT NULL DEFAULT '',
+			created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE (object_id, region)
+		);
+	`)
+	return err
+}
+
+func downAddObjectReplica(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`DROP TABLE IF EXISTS object_replica;`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`ALTER TABLE workspace DROP COLUMN IF EXISTS replica_storage_id;`)
+	return err
+}
@@ -0,0 +1,57 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddObjectWebhook, downAddObjectWebhook)
+}
+
+func upAddObjectWebhook(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS object_webhook (
+			id SERIAL PRIMARY KEY,
+			external_id UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+			workspace_id INT NOT NULL REFERENCES workspace(id) ON DELETE CASCADE,
+			url TEXT NOT NULL,
+			secret VARCHAR(64) NOT NULL,
+			events JSONB NOT NULL DEFAULT '[]',
+			created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE INDEX IF NOT EXISTS idx_object_webhook_workspace_id ON object_webhook(workspace_id);
+	`)
+	if err != nil {
+		return err
+	}
+
+	// Deliveries are queued when an event happens and picked up by the delivery loop once they're due
+	_, err = tx.Exec(`
+		CREATE TABLE IF NOT EXISTS object_webhook_delivery (
+			id SERIAL PRIMARY KEY,
+			external_id UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+			webhook_id INT NOT NULL REFERENCES object_webhook(id) ON DELETE CASCADE,
+			event VARCHAR(64) NOT NULL,
+			payload TEXT NOT NULL,
+			status VARCHAR(32) NOT NULL DEFAULT 'pending',
+			attempts INT NOT NULL DEFAULT 0,
+			error TEXT NOT NULL DEFAULT '',
+			next_attempt_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE INDEX IF NOT EXISTS idx_object_webhook_delivery_due ON object_webhook_delivery(status, next_attempt_at);
+	`)
+	return err
+}
+
+func downAddObjectWebhook(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		DROP TABLE IF EXISTS object_webhook_delivery;
+		DROP TABLE IF EXISTS object_webhook;
+	`)
+	return err
+}
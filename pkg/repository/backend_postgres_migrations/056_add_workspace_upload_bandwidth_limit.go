@@ -0,0 +1,22 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddWorkspaceUploadBandwidthLimit, downAddWorkspaceUploadBandwidthLimit)
+}
+
+func upAddWorkspaceUploadBandwidthLimit(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE workspace ADD COLUMN IF NOT EXISTS upload_bandwidth_limit BIGINT NULL;`)
+	return err
+}
+
+func downAddWorkspaceUploadBandwidthLimit(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE workspace DROP COLUMN IF EXISTS upload_bandwidth_limit;`)
+	return err
+}
@@ -0,0 +1,56 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddTaskRetryPolicy, downAddTaskRetryPolicy)
+}
+
+func upAddTaskRetryPolicy(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS task_retry_policy (
+			stub_id INT PRIMARY KEY REFERENCES stub(id) ON DELETE CASCADE,
+			max_attempts INT NOT NULL,
+			backoff_strategy VARCHAR(32) NOT NULL DEFAULT 'none',
+			backoff_seconds INT NOT NULL DEFAULT 0,
+			max_backoff_seconds INT NOT NULL DEFAULT 0,
+			retry_on_exit_codes JSONB NOT NULL DEFAULT '[]',
+			created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+		);
+	`)
+	if err != nil {
+		return err
+	}
+
+	// One row per failed attempt, written by the dispatcher when it retries or gives up on a task
+	_, err = tx.Exec(`
+		CREATE TABLE IF NOT EXISTS task_attempt (
+			id SERIAL PRIMARY KEY,
+			task_id INT NOT NULL REFERENCES task(id) ON DELETE CASCADE,
+			attempt INT NOT NULL,
+			container_id VARCHAR(255) NOT NULL DEFAULT '',
+			exit_code INT NULL,
+			reason VARCHAR(64) NOT NULL,
+			retried BOOLEAN NOT NULL DEFAULT false,
+			created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE INDEX IF NOT EXISTS idx_task_attempt_task_id ON task_attempt(task_id);
+	`)
+	return err
+}
+
+func downAddTaskRetryPolicy(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`DROP TABLE IF EXISTS task_attempt;`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`DROP TABLE IF EXISTS task_retry_policy;`)
+	return err
+}
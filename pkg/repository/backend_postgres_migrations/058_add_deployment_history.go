@@ -0,0 +1,47 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddDeploymentHistory, downAddDeploymentHistory)
+}
+
+func upAddDeploymentHistory(ctx context.Context, tx *sql.Tx) error {
+	// One row per version a deployment name was pointed at, whether by a deploy or a rollback
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS deployment_history (
+			id SERIAL PRIMARY KEY,
+			workspace_id INT NOT NULL REFERENCES workspace(id) ON DELETE CASCADE,
+			name VARCHAR(255) NOT NULL,
+			stub_type stub_type NOT NULL,
+			version INT NOT NULL,
+			deployment_id INT NOT NULL REFERENCES deployment(id) ON DELETE CASCADE,
+			stub_id INT NOT NULL REFERENCES stub(id) ON DELETE CASCADE,
+			action VARCHAR(32) NOT NULL,
+			rolled_back_to_version INT NULL,
+			created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE INDEX IF NOT EXISTS idx_deployment_history_name ON deployment_history(workspace_id, name, stub_type);
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO deployment_history (workspace_id, name, stub_type, version, deployment_id, stub_id, action, created_at)
+		SELECT workspace_id, name, stub_type, version, id, stub_id, 'deploy', created_at
+		FROM deployment
+		WHERE workspace_id IS NOT NULL AND stub_id IS NOT NULL;
+	`)
+	return err
+}
+
+func downAddDeploymentHistory(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`DROP TABLE IF EXISTS deployment_history;`)
+	return err
+}
@@ -0,0 +1,38 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddDeploymentTrafficSplit, downAddDeploymentTrafficSplit)
+}
+
+func upAddDeploymentTrafficSplit(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS deployment_traffic_split (
+			id SERIAL PRIMARY KEY,
+			workspace_id INT NOT NULL REFERENCES workspace(id) ON DELETE CASCADE,
+			name VARCHAR(255) NOT NULL,
+			stub_type stub_type NOT NULL,
+			stable_version INT NOT NULL,
+			canary_version INT NOT NULL,
+			canary_percent INT NOT NULL CHECK (canary_percent >= 0 AND canary_percent <= 100),
+			error_rate_threshold DOUBLE PRECISION NOT NULL DEFAULT 0,
+			min_requests INT NOT NULL DEFAULT 0,
+			status VARCHAR(32) NOT NULL DEFAULT 'active',
+			created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE (workspace_id, name, stub_type)
+		);
+	`)
+	return err
+}
+
+func downAddDeploymentTrafficSplit(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`DROP TABLE IF EXISTS deployment_traffic_split;`)
+	return err
+}
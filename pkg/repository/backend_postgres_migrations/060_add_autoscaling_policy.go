@@ -0,0 +1,30 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddAutoscalingPolicy, downAddAutoscalingPolicy)
+}
+
+func upAddAutoscalingPolicy(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS autoscaling_policy (
+			stub_id INT PRIMARY KEY REFERENCES stub(id) ON DELETE CASCADE,
+			metric VARCHAR(64) NOT NULL,
+			target_value DOUBLE PRECISION NOT NULL CHECK (target_value > 0),
+			created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+		);
+	`)
+	return err
+}
+
+func downAddAutoscalingPolicy(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`DROP TABLE IF EXISTS autoscaling_policy;`)
+	return err
+}
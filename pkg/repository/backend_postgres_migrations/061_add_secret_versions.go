@@ -0,0 +1,41 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddSecretVersions, downAddSecretVersions)
+}
+
+func upAddSecretVersions(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE workspace_secret ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
+
+		CREATE TABLE IF NOT EXISTS workspace_secret_version (
+			id SERIAL PRIMARY KEY,
+			secret_id INT NOT NULL REFERENCES workspace_secret(id) ON DELETE CASCADE,
+			version INT NOT NULL,
+			value TEXT NOT NULL,
+			created_by INT REFERENCES token(id) ON DELETE SET NULL,
+			created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE (secret_id, version)
+		);
+
+		INSERT INTO workspace_secret_version (secret_id, version, value, created_by, created_at)
+		SELECT id, version, value, last_updated_by, updated_at FROM workspace_secret
+		ON CONFLICT (secret_id, version) DO NOTHING;
+	`)
+	return err
+}
+
+func downAddSecretVersions(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		DROP TABLE IF EXISTS workspace_secret_version;
+		ALTER TABLE workspace_secret DROP COLUMN IF EXISTS version;
+	`)
+	return err
+}
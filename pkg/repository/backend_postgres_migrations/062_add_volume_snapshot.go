@@ -0,0 +1,34 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddVolumeSnapshot, downAddVolumeSnapshot)
+}
+
+func upAddVolumeSnapshot(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS volume_snapshot (
+			id SERIAL PRIMARY KEY,
+			external_id UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+			volume_id INT NOT NULL REFERENCES volume(id) ON DELETE CASCADE,
+			name VARCHAR(255) NOT NULL DEFAULT '',
+			size BIGINT NOT NULL DEFAULT 0,
+			file_count BIGINT NOT NULL DEFAULT 0,
+			created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_volume_snapshot_volume_id ON volume_snapshot(volume_id, created_at DESC);
+	`)
+	return err
+}
+
+func downAddVolumeSnapshot(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`DROP TABLE IF EXISTS volume_snapshot;`)
+	return err
+}
@@ -0,0 +1,33 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddVolumeShare, downAddVolumeShare)
+}
+
+func upAddVolumeShare(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS volume_share (
+			id SERIAL PRIMARY KEY,
+			volume_id INT NOT NULL REFERENCES volume(id) ON DELETE CASCADE,
+			grantee_workspace_id INT NOT NULL REFERENCES workspace(id) ON DELETE CASCADE,
+			expires_at TIMESTAMP WITH TIME ZONE,
+			created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE (volume_id, grantee_workspace_id)
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_volume_share_grantee ON volume_share(grantee_workspace_id);
+	`)
+	return err
+}
+
+func downAddVolumeShare(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`DROP TABLE IF EXISTS volume_share;`)
+	return err
+}
@@ -0,0 +1,28 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddVolumeQuotas, downAddVolumeQuotas)
+}
+
+func upAddVolumeQuotas(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE volume ADD COLUMN IF NOT EXISTS quota_bytes BIGINT NOT NULL DEFAULT 0;
+		ALTER TABLE workspace ADD COLUMN IF NOT EXISTS volume_quota_bytes BIGINT NULL;
+	`)
+	return err
+}
+
+func downAddVolumeQuotas(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE volume DROP COLUMN IF EXISTS quota_bytes;
+		ALTER TABLE workspace DROP COLUMN IF EXISTS volume_quota_bytes;
+	`)
+	return err
+}
@@ -0,0 +1,31 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddOutputRetentionPolicy, downAddOutputRetentionPolicy)
+}
+
+func upAddOutputRetentionPolicy(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS output_retention_policy (
+			stub_id INT PRIMARY KEY REFERENCES stub(id) ON DELETE CASCADE,
+			max_age_seconds BIGINT NOT NULL DEFAULT 0,
+			max_count INT NOT NULL DEFAULT 0,
+			max_total_bytes BIGINT NOT NULL DEFAULT 0,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+	`)
+	return err
+}
+
+func downAddOutputRetentionPolicy(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`DROP TABLE IF EXISTS output_retention_policy;`)
+	return err
+}
@@ -0,0 +1,38 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddCustomDomain, downAddCustomDomain)
+}
+
+func upAddCustomDomain(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS custom_domain (
+			id SERIAL PRIMARY KEY,
+			external_id UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+			workspace_id INT NOT NULL REFERENCES workspace(id) ON DELETE CASCADE,
+			deployment_id INT NOT NULL REFERENCES deployment(id) ON DELETE CASCADE,
+			domain VARCHAR(253) UNIQUE NOT NULL,
+			status VARCHAR(32) NOT NULL DEFAULT 'pending',
+			last_error TEXT NOT NULL DEFAULT '',
+			certificate_expires_at TIMESTAMP WITH TIME ZONE,
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_custom_domain_workspace_id ON custom_domain(workspace_id);
+		CREATE INDEX IF NOT EXISTS idx_custom_domain_deployment_id ON custom_domain(deployment_id);
+	`)
+	return err
+}
+
+func downAddCustomDomain(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`DROP TABLE IF EXISTS custom_domain;`)
+	return err
+}
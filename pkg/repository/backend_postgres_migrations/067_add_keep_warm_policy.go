@@ -0,0 +1,31 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddKeepWarmPolicy, downAddKeepWarmPolicy)
+}
+
+func upAddKeepWarmPolicy(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS keep_warm_policy (
+			stub_id INT PRIMARY KEY REFERENCES stub(id) ON DELETE CASCADE,
+			schedule VARCHAR(128) NOT NULL,
+			timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+			containers INT NOT NULL CHECK (containers > 0),
+			created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+		);
+	`)
+	return err
+}
+
+func downAddKeepWarmPolicy(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`DROP TABLE IF EXISTS keep_warm_policy;`)
+	return err
+}
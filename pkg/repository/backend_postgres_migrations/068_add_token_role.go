@@ -0,0 +1,25 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddTokenRole, downAddTokenRole)
+}
+
+// Existing tokens keep the full access they had before roles were added
+func upAddTokenRole(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE token ADD COLUMN IF NOT EXISTS role VARCHAR(32) NOT NULL DEFAULT 'admin';
+	`)
+	return err
+}
+
+func downAddTokenRole(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE token DROP COLUMN IF EXISTS role;`)
+	return err
+}
@@ -0,0 +1,25 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddTokenScopes, downAddTokenScopes)
+}
+
+// Existing tokens have no scopes, which leaves them limited only by their role
+func upAddTokenScopes(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE token ADD COLUMN IF NOT EXISTS scopes JSONB NOT NULL DEFAULT '[]';
+	`)
+	return err
+}
+
+func downAddTokenScopes(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE token DROP COLUMN IF EXISTS scopes;`)
+	return err
+}
@@ -0,0 +1,36 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddOIDCIdentity, downAddOIDCIdentity)
+}
+
+func upAddOIDCIdentity(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS oidc_identity (
+			id SERIAL PRIMARY KEY,
+			issuer VARCHAR(255) NOT NULL,
+			subject VARCHAR(255) NOT NULL,
+			email VARCHAR(255) NOT NULL DEFAULT '',
+			workspace_id INT NOT NULL REFERENCES workspace(id) ON DELETE CASCADE,
+			token_id INT NOT NULL REFERENCES token(id) ON DELETE CASCADE,
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE (issuer, subject, workspace_id)
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_oidc_identity_token_id ON oidc_identity(token_id);
+	`)
+	return err
+}
+
+func downAddOIDCIdentity(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`DROP TABLE IF EXISTS oidc_identity;`)
+	return err
+}
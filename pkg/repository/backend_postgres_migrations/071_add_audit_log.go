@@ -0,0 +1,51 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddAuditLog, downAddAuditLog)
+}
+
+// The audit log is append-only: entries can be pruned once they're past retention, but never changed
+func upAddAuditLog(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS audit_log (
+			id BIGSERIAL PRIMARY KEY,
+			workspace_id INT NOT NULL REFERENCES workspace(id) ON DELETE CASCADE,
+			token_id VARCHAR(255) NOT NULL,
+			method VARCHAR(255) NOT NULL,
+			resource_ids JSONB NOT NULL DEFAULT '{}',
+			outcome VARCHAR(16) NOT NULL,
+			status_code VARCHAR(32) NOT NULL DEFAULT '',
+			error TEXT NOT NULL DEFAULT '',
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_audit_log_workspace_id_created_at ON audit_log(workspace_id, created_at);
+		CREATE INDEX IF NOT EXISTS idx_audit_log_created_at ON audit_log(created_at);
+
+		CREATE OR REPLACE FUNCTION audit_log_prevent_update() RETURNS TRIGGER AS $$
+		BEGIN
+			RAISE EXCEPTION 'audit_log is append-only';
+		END;
+		$$ LANGUAGE plpgsql;
+
+		DROP TRIGGER IF EXISTS audit_log_prevent_update ON audit_log;
+		CREATE TRIGGER audit_log_prevent_update BEFORE UPDATE ON audit_log
+			FOR EACH ROW EXECUTE FUNCTION audit_log_prevent_update();
+	`)
+	return err
+}
+
+func downAddAuditLog(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		DROP TABLE IF EXISTS audit_log;
+		DROP FUNCTION IF EXISTS audit_log_prevent_update();
+	`)
+	return err
+}
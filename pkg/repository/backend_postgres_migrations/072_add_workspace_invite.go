@@ -0,0 +1,38 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddWorkspaceInvite, downAddWorkspaceInvite)
+}
+
+func upAddWorkspaceInvite(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS workspace_invite (
+			id SERIAL PRIMARY KEY,
+			external_id UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+			workspace_id INT NOT NULL REFERENCES workspace(id) ON DELETE CASCADE,
+			email VARCHAR(255) NOT NULL,
+			role VARCHAR(32) NOT NULL,
+			token_hash VARCHAR(64) UNIQUE NOT NULL,
+			status VARCHAR(16) NOT NULL DEFAULT 'pending',
+			expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+			accepted_token_id INT REFERENCES token(id) ON DELETE SET NULL,
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_workspace_invite_workspace_id ON workspace_invite(workspace_id);
+	`)
+	return err
+}
+
+func downAddWorkspaceInvite(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`DROP TABLE IF EXISTS workspace_invite;`)
+	return err
+}
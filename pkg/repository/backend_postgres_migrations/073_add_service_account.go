@@ -0,0 +1,43 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddServiceAccount, downAddServiceAccount)
+}
+
+// Service account tokens expire, other tokens keep no expiry
+func upAddServiceAccount(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS service_account (
+			id SERIAL PRIMARY KEY,
+			external_id UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+			workspace_id INT NOT NULL REFERENCES workspace(id) ON DELETE CASCADE,
+			name VARCHAR(255) NOT NULL,
+			role VARCHAR(32) NOT NULL,
+			last_used_at TIMESTAMP WITH TIME ZONE,
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE (workspace_id, name)
+		);
+
+		ALTER TABLE token ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP WITH TIME ZONE;
+		ALTER TABLE token ADD COLUMN IF NOT EXISTS service_account_id INT REFERENCES service_account(id) ON DELETE CASCADE;
+		CREATE INDEX IF NOT EXISTS idx_token_service_account_id ON token(service_account_id);
+	`)
+	return err
+}
+
+func downAddServiceAccount(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE token DROP COLUMN IF EXISTS service_account_id;
+		ALTER TABLE token DROP COLUMN IF EXISTS expires_at;
+		DROP TABLE IF EXISTS service_account;
+	`)
+	return err
+}
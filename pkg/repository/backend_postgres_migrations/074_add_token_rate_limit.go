@@ -0,0 +1,23 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddTokenRateLimit, downAddTokenRateLimit)
+}
+
+// Tokens without a rate limit of their own use the gateway's default
+func upAddTokenRateLimit(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE token ADD COLUMN IF NOT EXISTS rate_limit JSONB;`)
+	return err
+}
+
+func downAddTokenRateLimit(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE token DROP COLUMN IF EXISTS rate_limit;`)
+	return err
+}
@@ -0,0 +1,23 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddWorkspaceIPAllowList, downAddWorkspaceIPAllowList)
+}
+
+// An empty allowlist lets the workspace be used from anywhere
+func upAddWorkspaceIPAllowList(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE workspace ADD COLUMN IF NOT EXISTS ip_allowlist TEXT[] NOT NULL DEFAULT '{}';`)
+	return err
+}
+
+func downAddWorkspaceIPAllowList(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE workspace DROP COLUMN IF EXISTS ip_allowlist;`)
+	return err
+}
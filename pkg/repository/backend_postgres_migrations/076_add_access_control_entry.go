@@ -0,0 +1,38 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddAccessControlEntry, downAddAccessControlEntry)
+}
+
+// Each entry belongs to exactly one object or volume, and goes away with it
+func upAddAccessControlEntry(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS access_control_entry (
+			id SERIAL PRIMARY KEY,
+			workspace_id INT NOT NULL REFERENCES workspace(id) ON DELETE CASCADE,
+			object_id INT REFERENCES object(id) ON DELETE CASCADE,
+			volume_id INT REFERENCES volume(id) ON DELETE CASCADE,
+			principal_type VARCHAR(16) NOT NULL,
+			principal VARCHAR(255) NOT NULL,
+			access VARCHAR(16) NOT NULL,
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			CHECK ((object_id IS NULL) <> (volume_id IS NULL))
+		);
+
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_access_control_entry_object ON access_control_entry(object_id, principal_type, principal) WHERE object_id IS NOT NULL;
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_access_control_entry_volume ON access_control_entry(volume_id, principal_type, principal) WHERE volume_id IS NOT NULL;
+	`)
+	return err
+}
+
+func downAddAccessControlEntry(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`DROP TABLE IF EXISTS access_control_entry;`)
+	return err
+}
@@ -0,0 +1,64 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddResourceGrant, downAddResourceGrant)
+}
+
+// Volume shares become volume grants, so every kind of cross-workspace access is kept in one place
+func upAddResourceGrant(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS resource_grant (
+			id SERIAL PRIMARY KEY,
+			workspace_id INT NOT NULL REFERENCES workspace(id) ON DELETE CASCADE,
+			grantee_workspace_id INT NOT NULL REFERENCES workspace(id) ON DELETE CASCADE,
+			resource_type VARCHAR(16) NOT NULL,
+			resource_id VARCHAR(255) NOT NULL,
+			expires_at TIMESTAMP WITH TIME ZONE,
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE (workspace_id, resource_type, resource_id, grantee_workspace_id)
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_resource_grant_grantee ON resource_grant(grantee_workspace_id, resource_type);
+
+		INSERT INTO resource_grant (workspace_id, grantee_workspace_id, resource_type, resource_id, expires_at, created_at)
+		SELECT v.workspace_id, s.grantee_workspace_id, 'volume', v.external_id, s.expires_at, s.created_at
+		FROM volume_share s
+		JOIN volume v ON s.volume_id = v.id
+		ON CONFLICT DO NOTHING;
+
+		DROP TABLE IF EXISTS volume_share;
+	`)
+	return err
+}
+
+func downAddResourceGrant(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS volume_share (
+			id SERIAL PRIMARY KEY,
+			volume_id INT NOT NULL REFERENCES volume(id) ON DELETE CASCADE,
+			grantee_workspace_id INT NOT NULL REFERENCES workspace(id) ON DELETE CASCADE,
+			expires_at TIMESTAMP WITH TIME ZONE,
+			created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE (volume_id, grantee_workspace_id)
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_volume_share_grantee ON volume_share(grantee_workspace_id);
+
+		INSERT INTO volume_share (volume_id, grantee_workspace_id, expires_at, created_at)
+		SELECT v.id, g.grantee_workspace_id, g.expires_at, g.created_at
+		FROM resource_grant g
+		JOIN volume v ON v.external_id = g.resource_id AND v.workspace_id = g.workspace_id
+		WHERE g.resource_type = 'volume'
+		ON CONFLICT DO NOTHING;
+
+		DROP TABLE IF EXISTS resource_grant;
+	`)
+	return err
+}
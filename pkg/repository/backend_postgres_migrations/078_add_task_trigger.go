@@ -0,0 +1,37 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddTaskTrigger, downAddTaskTrigger)
+}
+
+func upAddTaskTrigger(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS task_trigger (
+			id SERIAL PRIMARY KEY,
+			external_id UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+			workspace_id INT NOT NULL REFERENCES workspace(id) ON DELETE CASCADE,
+			name VARCHAR(255) NOT NULL,
+			deployment_name VARCHAR(255) NOT NULL,
+			stub_type VARCHAR(255) NOT NULL,
+			signature_scheme VARCHAR(32) NOT NULL,
+			secret VARCHAR(255) NOT NULL,
+			max_payload_bytes BIGINT NOT NULL DEFAULT 0,
+			input_mapping JSONB NOT NULL DEFAULT '{}',
+			created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE (workspace_id, name)
+		);
+	`)
+	return err
+}
+
+func downAddTaskTrigger(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`DROP TABLE IF EXISTS task_trigger;`)
+	return err
+}
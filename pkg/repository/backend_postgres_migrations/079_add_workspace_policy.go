@@ -0,0 +1,33 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddWorkspacePolicy, downAddWorkspacePolicy)
+}
+
+func upAddWorkspacePolicy(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS workspace_policy (
+			id SERIAL PRIMARY KEY,
+			workspace_id INT NOT NULL UNIQUE REFERENCES workspace(id) ON DELETE CASCADE,
+			max_concurrent_gpus INT NOT NULL DEFAULT 0,
+			max_container_memory BIGINT NOT NULL DEFAULT 0,
+			allowed_gpu_types JSONB NOT NULL DEFAULT '[]',
+			max_run_duration_seconds BIGINT NOT NULL DEFAULT 0,
+			created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+		);
+	`)
+	return err
+}
+
+func downAddWorkspacePolicy(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`DROP TABLE IF EXISTS workspace_policy;`)
+	return err
+}
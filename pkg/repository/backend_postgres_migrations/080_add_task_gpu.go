@@ -0,0 +1,23 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddTaskGpu, downAddTaskGpu)
+}
+
+// The GPU type that served the task, which can be any of the stub's acceptable GPU types
+func upAddTaskGpu(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE task ADD COLUMN IF NOT EXISTS gpu VARCHAR(255) NOT NULL DEFAULT '';`)
+	return err
+}
+
+func downAddTaskGpu(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE task DROP COLUMN IF EXISTS gpu;`)
+	return err
+}
@@ -0,0 +1,52 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddPoolQuota, downAddPoolQuota)
+}
+
+func upAddPoolQuota(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS pool_quota (
+			id SERIAL PRIMARY KEY,
+			workspace_id INT NOT NULL REFERENCES workspace(id) ON DELETE CASCADE,
+			pool_name VARCHAR(255) NOT NULL,
+			max_machines INT NOT NULL DEFAULT 0,
+			max_gpus INT NOT NULL DEFAULT 0,
+			reserved_gpus INT NOT NULL DEFAULT 0,
+			created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE (workspace_id, pool_name)
+		);
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+DO $$
+BEGIN
+   IF NOT EXISTS (
+      SELECT 1 FROM pg_type t
+      JOIN pg_enum e ON t.oid = e.enumtypid
+      JOIN pg_catalog.pg_namespace n ON n.oid = t.typnamespace
+      WHERE n.nspname = 'public' AND t.typname = 'task_status' AND e.enumlabel = 'QUOTA_EXCEEDED'
+   ) THEN
+      EXECUTE 'ALTER TYPE task_status ADD VALUE ' || quote_literal('QUOTA_EXCEEDED');
+   END IF;
+END
+$$;`)
+	return err
+}
+
+func downAddPoolQuota(ctx context.Context, tx *sql.Tx) error {
+	// PostgreSQL doesn't support removing values from an ENUM directly
+	_, err := tx.Exec(`DROP TABLE IF EXISTS pool_quota;`)
+	return err
+}
@@ -0,0 +1,36 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddCapacityReservation, downAddCapacityReservation)
+}
+
+func upAddCapacityReservation(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS capacity_reservation (
+			id SERIAL PRIMARY KEY,
+			external_id UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+			workspace_id INT NOT NULL REFERENCES workspace(id) ON DELETE CASCADE,
+			pool_name VARCHAR(255) NOT NULL,
+			gpu_type VARCHAR(255) NOT NULL,
+			gpu_count INT NOT NULL,
+			starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+			ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+			created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_capacity_reservation_ends_at ON capacity_reservation (ends_at);
+	`)
+	return err
+}
+
+func downAddCapacityReservation(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`DROP TABLE IF EXISTS capacity_reservation;`)
+	return err
+}
@@ -0,0 +1,40 @@
+package backend_postgres_migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddCustomDomainVerification, downAddCustomDomainVerification)
+}
+
+func upAddCustomDomainVerification(ctx context.Context, tx *sql.Tx) error {
+	// Domains attached before verification existed have to be verified too. Any workspace can claim a domain
+	// while it's unverified, so only verified domains are unique across workspaces.
+	_, err := tx.Exec(`
+		ALTER TABLE custom_domain ADD COLUMN IF NOT EXISTS verification_token VARCHAR(64) NOT NULL DEFAULT replace(uuid_generate_v4()::text, '-', '');
+		ALTER TABLE custom_domain ADD COLUMN IF NOT EXISTS verified_at TIMESTAMP WITH TIME ZONE;
+
+		ALTER TABLE custom_domain DROP CONSTRAINT IF EXISTS custom_domain_domain_key;
+		ALTER TABLE custom_domain ADD CONSTRAINT custom_domain_workspace_id_domain_key UNIQUE (workspace_id, domain);
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_custom_domain_verified_domain ON custom_domain(domain) WHERE verified_at IS NOT NULL;
+	`)
+	return err
+}
+
+func downAddCustomDomainVerification(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		DELETE FROM custom_domain WHERE verified_at IS NULL;
+
+		DROP INDEX IF EXISTS idx_custom_domain_verified_domain;
+		ALTER TABLE custom_domain DROP CONSTRAINT IF EXISTS custom_domain_workspace_id_domain_key;
+		ALTER TABLE custom_domain ADD CONSTRAINT custom_domain_domain_key UNIQUE (domain);
+
+		ALTER TABLE custom_domain DROP COLUMN IF EXISTS verified_at;
+		ALTER TABLE custom_domain DROP COLUMN IF EXISTS verification_token;
+	`)
+	return err
+}
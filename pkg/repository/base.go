@@ -20,10 +20,17 @@ type WorkerRepository interface {
 	AddWorker(w *types.Worker) error
 	ToggleWorkerAvailable(workerId string) error
 	UpdateWorkerStatus(workerId string, status types.WorkerStatus) error
+	SetWorkerLabels(workerId string, labels types.WorkerLabels) error
+	SetWorkerGpuInterconnect(workerId string, interconnect string) error
+	SetWorkerServerAddress(workerId string, address string) error
+	SetWorkerArch(workerId string, arch string) error
+	SetWorkerMigSlices(workerId string, slices types.MigSlices) error
+	CordonUnhealthyWorker(workerId string, reason string) error
 	RemoveWorker(workerId string) error
 	SetWorkerKeepAlive(workerId string) error
 	UpdateWorkerCapacity(w *types.Worker, cr *types.ContainerRequest, ut types.CapacityUpdateType) error
 	ScheduleContainerRequest(worker *types.Worker, request *types.ContainerRequest) error
+	PushContainerRequest(workerId string, request *types.ContainerRequest) error
 	GetNextContainerRequest(workerId string) (*types.ContainerRequest, error)
 	AddContainerToWorker(workerId string, containerId string) error
 	RemoveContainerFromWorker(workerId string, containerId string) error
@@ -40,6 +47,11 @@ type WorkerRepository interface {
 	GetGpuAvailability() (map[string]bool, error)
 	GetFreeGpuCounts() (map[string]int, error)
 	GetPreemptibleGpus() []string
+	AddCachedObject(workerId, digest string) error
+	RemoveCachedObject(workerId, digest string) error
+	GetWorkersCachingObject(digest string) ([]string, error)
+	InvalidateCachedObject(digest string) error
+	PopCachedObjectInvalidations(workerId string) ([]string, error)
 }
 
 type ContainerRepository interface {
@@ -47,10 +59,14 @@ type ContainerRepository interface {
 	SetContainerState(string, *types.ContainerState) error
 	SetContainerExitCode(string, int) error
 	GetContainerExitCode(string) (int, error)
+	SetContainerHealth(containerId string, health *types.ContainerHealth) error
+	GetContainerHealth(containerId string) (*types.ContainerHealth, error)
 	SetContainerAddress(containerId string, addr string) error
 	GetContainerAddress(containerId string) (string, error)
 	UpdateContainerStatus(string, types.ContainerStatus, int64) error
 	UpdateAssignedContainerGPU(string, string) error
+	SetContainerGpuTopology(containerId string, interconnect string, numaNodes string) error
+	SetContainerGpuShare(containerId string, share int32, memory int64) error
 	DeleteContainerState(containerId string) error
 	SetContainerRequestStatus(containerId string, status types.ContainerRequestStatus) error
 	SetWorkerAddress(containerId string, addr string) error
@@ -79,6 +95,8 @@ type WorkerPoolRepository interface {
 	RemoveWorkerPoolSizerLock(poolName string) error
 	SetWorkerCleanerLock(poolName string) error
 	RemoveWorkerCleanerLock(poolName string) error
+	RecordWorkerPoolDemand(ctx context.Context, poolName, stubId string, at time.Time, demand *types.WorkerPoolDemand, ttl time.Duration) error
+	GetWorkerPoolDemand(ctx context.Context, poolName string, at time.Time) (map[string]*types.WorkerPoolDemand, error)
 }
 
 type WorkspaceRepository interface {
@@ -86,7 +104,22 @@ type WorkspaceRepository interface {
 	SetConcurrencyLimitByWorkspaceId(workspaceId string, limit *types.ConcurrencyLimit) error
 	AuthorizeToken(string) (*types.Token, *types.Workspace, error)
 	RevokeToken(tokenKey string) error
+	RestoreToken(tokenKey string) error
+	IsTokenRevoked(tokenKey string) (bool, error)
+	InvalidateToken(tokenKey string) error
 	SetAuthorizationToken(*types.Token, *types.Workspace) error
+	SetTokenSession(sessionId string, session *types.TokenSession) error
+	GetTokenSession(sessionId string) (*types.TokenSession, error)
+	RotateTokenSession(sessionId string, refreshId string, session *types.TokenSession) (bool, error)
+	DeleteTokenSession(sessionId string) error
+	GetIPAllowListByWorkspaceId(workspaceId string) ([]string, bool, error)
+	SetIPAllowListByWorkspaceId(workspaceId string, cidrs []string) error
+	GetWorkspacePolicyByWorkspaceId(workspaceId string) (*types.WorkspacePolicy, bool, error)
+	SetWorkspacePolicyByWorkspaceId(workspaceId string, policy *types.WorkspacePolicy) error
+	GetPoolQuotas() ([]types.PoolQuota, bool, error)
+	SetPoolQuotas(quotas []types.PoolQuota) error
+	GetCapacityReservations() ([]types.CapacityReservation, bool, error)
+	SetCapacityReservations(reservations []types.CapacityReservation) error
 }
 
 type BackendRepository interface {
@@ -97,21 +130,94 @@ type BackendRepository interface {
 	GetWorkspace(ctx context.Context, workspaceId uint) (*types.Workspace, error)
 	GetWorkspaceStorage(ctx context.Context, storageId uint) (*types.WorkspaceStorage, error)
 	CreateWorkspaceStorage(ctx context.Context, workspaceId uint, storage types.WorkspaceStorage) (*types.WorkspaceStorage, error)
+	SetWorkspaceReplicaStorage(ctx context.Context, workspaceId uint, storage types.WorkspaceStorage) (*types.WorkspaceStorage, error)
+	GetWorkspaceUploadBandwidthLimit(ctx context.Context, workspaceId uint) (*int64, error)
+	SetWorkspaceUploadBandwidthLimit(ctx context.Context, workspaceId uint, bytesPerSecond *int64) error
+	GetWorkspaceVolumeQuota(ctx context.Context, workspaceId uint) (*int64, error)
+	SetWorkspaceVolumeQuota(ctx context.Context, workspaceId uint, quotaBytes *int64) error
+	GetWorkspaceIPAllowList(ctx context.Context, workspaceId uint) ([]string, error)
+	SetWorkspaceIPAllowList(ctx context.Context, workspaceId uint, cidrs []string) error
+	GetWorkspacePolicy(ctx context.Context, workspaceId string) (*types.WorkspacePolicy, error)
+	SetWorkspacePolicy(ctx context.Context, workspaceId uint, policy types.WorkspacePolicy) (*types.WorkspacePolicy, error)
+	ListPoolQuotas(ctx context.Context) ([]types.PoolQuota, error)
+	SetPoolQuota(ctx context.Context, workspaceId uint, quota types.PoolQuota) (*types.PoolQuota, error)
+	DeletePoolQuota(ctx context.Context, workspaceId uint, poolName string) error
+	ListCapacityReservations(ctx context.Context) ([]types.CapacityReservation, error)
+	CreateCapacityReservation(ctx context.Context, workspaceId uint, reservation types.CapacityReservation) (*types.CapacityReservation, error)
+	DeleteCapacityReservation(ctx context.Context, workspaceId uint, externalId string) error
+	GetWorkspaceReplicaStorage(ctx context.Context, workspaceId uint) (*types.WorkspaceStorage, error)
+	ListWorkspaceIdsWithReplicaStorage(ctx context.Context) ([]uint, error)
 	GetAdminWorkspace(ctx context.Context) (*types.Workspace, error)
 	CreateObject(ctx context.Context, hash string, size int64, workspaceId uint) (*types.Object, error)
 	GetObjectByHash(ctx context.Context, hash string, workspaceId uint) (*types.Object, error)
+	GetObjectByKey(ctx context.Context, key string, workspaceId uint) (*types.Object, error)
 	GetObjectByExternalId(ctx context.Context, externalId string, workspaceId uint) (types.Object, error)
+	ListObjectsPaginated(ctx context.Context, filters types.ObjectFilter) (common.CursorPaginationInfo[types.Object], error)
+	ListObjectsByRegion(ctx context.Context, region string) ([]types.Object, error)
 	GetObjectByExternalStubId(ctx context.Context, stubId string, workspaceId uint) (types.Object, error)
 	UpdateObjectSizeByExternalId(ctx context.Context, externalId string, size int) error
+	UpdateObjectCompressionByExternalId(ctx context.Context, externalId string, compression types.ObjectCompression, storedSize int64) error
+	UpdateObjectEncryptionByExternalId(ctx context.Context, externalId string, encryption types.ObjectEncryption, wrappedDataKey string) error
+	UpdateObjectLocationByExternalId(ctx context.Context, externalId string, location types.ObjectLocation) error
+	ListObjectsForIntegrityCheck(ctx context.Context, limit int) ([]types.Object, error)
+	MarkObjectIntegrityChecked(ctx context.Context, objectId uint) error
+	RecordObjectCorruption(ctx context.Context, corruption *types.ObjectCorruption) error
+	DeleteObjectCorruption(ctx context.Context, objectId uint) error
+	ListObjectCorruptions(ctx context.Context, workspaceId uint, limit int) ([]types.ObjectCorruption, error)
+	ListObjectsPendingMigration(ctx context.Context, workspaceId uint, limit int) ([]types.Object, error)
+	CountObjectsPendingMigration(ctx context.Context, workspaceId uint) (int64, error)
+	UpdateObjectTagsByExternalId(ctx context.Context, externalId string, workspaceId uint, tags types.ObjectTags, removeKeys []string, replace bool) (types.ObjectTags, error)
+	UpdateObjectKeyByExternalId(ctx context.Context, externalId string, workspaceId uint, key string) error
+	ListObjectsByKey(ctx context.Context, workspaceId uint, prefix string, startAfter string, limit int) ([]types.Object, error)
+	ListObjectsPendingReplication(ctx context.Context, workspaceId uint, region string, retryBefore time.Time, limit int) ([]types.Object, error)
+	UpdateObjectReplica(ctx context.Context, objectId uint, region string, status types.ObjectReplicaStatus, errMsg string) error
+	ListObjectReplicas(ctx context.Context, objectId uint) ([]types.ObjectReplica, error)
+	CreateObjectWebhook(ctx context.Context, workspaceId uint, url string, events types.ObjectWebhookEventFilter) (*types.ObjectWebhook, error)
+	ListObjectWebhooks(ctx context.Context, workspaceId uint) ([]types.ObjectWebhook, error)
+	DeleteObjectWebhook(ctx context.Context, workspaceId uint, externalId string) error
+	CreateObjectWebhookDeliveries(ctx context.Context, workspaceId uint, event types.WebhookEvent, payload string) (int64, error)
+	ListDueObjectWebhookDeliveries(ctx context.Context, limit int) ([]types.ObjectWebhookDelivery, error)
+	UpdateObjectWebhookDelivery(ctx context.Context, id uint, status types.ObjectWebhookDeliveryStatus, attempts int, nextAttemptAt time.Time, errMsg string) error
+	DeleteFinishedObjectWebhookDeliveries(ctx context.Context, finishedBefore time.Time) (int64, error)
+	UpdateObjectDigestByExternalId(ctx context.Context, externalId string, digest string) error
+	MarkObjectIncompleteByExternalId(ctx context.Context, externalId string) error
+	LockObject(ctx context.Context, externalId string, workspaceId uint, retainUntil time.Time) error
 	DeleteObjectByExternalId(ctx context.Context, externalId string) error
+	TouchObjectByExternalId(ctx context.Context, externalId string) error
+	ListExpiredObjects(ctx context.Context, limit int) ([]types.Object, error)
+	GetObjectLifecyclePolicy(ctx context.Context, workspaceId uint) (*types.ObjectLifecyclePolicy, error)
+	SetObjectLifecyclePolicy(ctx context.Context, workspaceId uint, expireAfterDays, expireUnusedAfterDays int, retainVersions *int) (*types.ObjectLifecyclePolicy, error)
+	CreateObjectVersion(ctx context.Context, object *types.Object) (*types.ObjectVersion, error)
+	GetObjectVersion(ctx context.Context, objectId uint, externalId string) (*types.ObjectVersion, error)
+	ListObjectVersions(ctx context.Context, objectId uint) ([]types.ObjectVersion, error)
+	DeleteObjectVersion(ctx context.Context, id uint) error
+	GetObjectReferences(ctx context.Context, objectId uint) (*types.ObjectReferences, error)
+	GetObjectStorageUsage(ctx context.Context, workspaceId uint) (*types.ObjectStorageUsage, error)
+	GetWorkspaceObjectKey(ctx context.Context, workspaceId uint) (string, error)
+	CreateWorkspaceObjectKey(ctx context.Context, workspaceId uint, wrappedKey string) (string, error)
+	LinkObjectBlob(ctx context.Context, externalId string, digest string, size, storedSize int64, compression types.ObjectCompression) (*types.ObjectBlob, error)
+	ReleaseObjectBlob(ctx context.Context, externalId string) (*types.ObjectBlob, bool, error)
+	DeleteObjectAndReleaseBlob(ctx context.Context, externalId string) (*types.ObjectBlob, bool, error)
+	CreateObjectUploadSession(ctx context.Context, workspaceId uint, hash string, size int64, chunkSize int64) (*types.ObjectUploadSession, error)
+	GetObjectUploadSession(ctx context.Context, externalId string, workspaceId uint) (*types.ObjectUploadSession, error)
+	UpdateObjectUploadSessionOffset(ctx context.Context, externalId string, offset int64) error
+	RecordObjectUploadChunk(ctx context.Context, sessionId uint, index int64, size int64) (int64, error)
+	ListObjectUploadChunks(ctx context.Context, sessionId uint) ([]int64, error)
+	DeleteObjectUploadSession(ctx context.Context, externalId string) error
+	DeleteStaleObjectUploadSessions(ctx context.Context, inactiveSince time.Time) ([]types.ObjectUploadSession, error)
 	CreateToken(ctx context.Context, workspaceId uint, tokenType string, reusable bool) (types.Token, error)
 	AuthorizeToken(ctx context.Context, tokenKey string) (*types.Token, *types.Workspace, error)
+	AuthorizeTokenByExternalId(ctx context.Context, externalId string) (*types.Token, *types.Workspace, error)
 	RetrieveActiveToken(ctx context.Context, workspaceId uint) (*types.Token, error)
 	GetTokenByExternalId(ctx context.Context, workspaceId uint, extTokenId string) (*types.Token, error)
 	ListTokens(ctx context.Context, workspaceId uint) ([]types.Token, error)
 	UpdateTokenAsClusterAdmin(ctx context.Context, tokenId string, disabled bool) error
+	SetTokenRateLimit(ctx context.Context, tokenId string, limit *types.RateLimit) (*types.Token, error)
+	RotateToken(ctx context.Context, workspaceId uint, extTokenId string, previousExpiresAt time.Time) (*types.Token, *types.Token, error)
 	ToggleToken(ctx context.Context, workspaceId uint, extTokenId string) (types.Token, error)
 	DeleteToken(ctx context.Context, workspaceId uint, extTokenId string) error
+	SetTokenRole(ctx context.Context, workspaceId uint, extTokenId string, role types.WorkspaceRole) (*types.Token, error)
+	SetTokenScopes(ctx context.Context, workspaceId uint, extTokenId string, scopes types.TokenScopes) (*types.Token, error)
 	GetTask(ctx context.Context, externalId string) (*types.Task, error)
 	GetTaskStatus(ctx context.Context, externalId string) (types.TaskStatus, error)
 	GetTaskWithRelated(ctx context.Context, externalId string) (*types.TaskWithRelated, error)
@@ -124,6 +230,23 @@ type BackendRepository interface {
 	ListTasksWithRelatedPaginated(ctx context.Context, filters types.TaskFilter) (common.CursorPaginationInfo[types.TaskWithRelated], error)
 	AggregateTasksByTimeWindow(ctx context.Context, filters types.TaskFilter) ([]types.TaskCountByTime, error)
 	GetTaskCountPerDeployment(ctx context.Context, filters types.TaskFilter) ([]types.TaskCountPerDeployment, error)
+	GetTaskRetryPolicy(ctx context.Context, stubExternalId string) (*types.TaskRetryPolicy, error)
+	SetTaskRetryPolicy(ctx context.Context, policy types.TaskRetryPolicy) (*types.TaskRetryPolicy, error)
+	DeleteTaskRetryPolicy(ctx context.Context, stubId uint) error
+	CreateTaskAttempt(ctx context.Context, taskExternalId string, attempt types.TaskAttempt) error
+	ListTaskAttempts(ctx context.Context, taskIds []uint) ([]types.TaskAttempt, error)
+	GetAutoscalingPolicy(ctx context.Context, stubExternalId string) (*types.AutoscalingPolicy, error)
+	SetAutoscalingPolicy(ctx context.Context, policy types.AutoscalingPolicy) (*types.AutoscalingPolicy, error)
+	DeleteAutoscalingPolicy(ctx context.Context, stubId uint) error
+	GetKeepWarmPolicy(ctx context.Context, stubExternalId string) (*types.KeepWarmPolicy, error)
+	SetKeepWarmPolicy(ctx context.Context, policy types.KeepWarmPolicy) (*types.KeepWarmPolicy, error)
+	DeleteKeepWarmPolicy(ctx context.Context, stubId uint) error
+	GetOutputRetentionPolicy(ctx context.Context, stubExternalId string) (*types.OutputRetentionPolicy, error)
+	SetOutputRetentionPolicy(ctx context.Context, policy types.OutputRetentionPolicy) (*types.OutputRetentionPolicy, error)
+	DeleteOutputRetentionPolicy(ctx context.Context, stubId uint) error
+	ListOutputRetentionPolicies(ctx context.Context) ([]types.OutputRetentionPolicy, error)
+	CancelTasks(ctx context.Context, filters types.TaskFilter) ([]types.TaskWithRelated, error)
+	RequeueTasks(ctx context.Context, filters types.TaskFilter) ([]types.TaskWithRelated, error)
 	GetOrCreateStub(ctx context.Context, name, stubType string, config types.StubConfigV1, objectId, workspaceId uint, forceCreate bool, appId uint) (types.Stub, error)
 	UpdateStubConfig(ctx context.Context, stubId uint, config *types.StubConfigV1) error
 	GetStubByExternalId(ctx context.Context, externalId string, queryFilters ...types.QueryFilter) (*types.StubWithRelated, error)
@@ -134,15 +257,65 @@ type BackendRepository interface {
 	GetOrCreateVolume(ctx context.Context, workspaceId uint, name string) (*types.Volume, error)
 	DeleteVolume(ctx context.Context, workspaceId uint, name string) error
 	ListVolumesWithRelated(ctx context.Context, workspaceId uint) ([]types.VolumeWithRelated, error)
+	CreateVolumeSnapshot(ctx context.Context, snapshot *types.VolumeSnapshot) (*types.VolumeSnapshot, error)
+	GetVolumeSnapshot(ctx context.Context, volumeId uint, externalId string) (*types.VolumeSnapshot, error)
+	ListVolumeSnapshots(ctx context.Context, volumeId uint) ([]types.VolumeSnapshot, error)
+	SetVolumeQuota(ctx context.Context, volumeId uint, quotaBytes int64) error
+	GetSharedVolume(ctx context.Context, granteeWorkspaceId uint, volumeExternalId string) (*types.SharedVolume, error)
+	ListSharedVolumes(ctx context.Context, granteeWorkspaceId uint) ([]types.SharedVolume, error)
+	CreateResourceGrant(ctx context.Context, workspaceId uint, granteeWorkspaceId uint, resourceType types.ResourceGrantType, resourceId string, expiresAt *time.Time) (*types.ResourceGrant, error)
+	DeleteResourceGrant(ctx context.Context, workspaceId uint, granteeWorkspaceId uint, resourceType types.ResourceGrantType, resourceId string) error
+	GetResourceGrant(ctx context.Context, workspaceId uint, granteeWorkspaceId uint, resourceType types.ResourceGrantType, resourceId string) (*types.ResourceGrant, error)
+	ListResourceGrants(ctx context.Context, workspaceId uint, received bool, resourceType types.ResourceGrantType) ([]types.ResourceGrantWithRelated, error)
+	GetImageCredentialWorkspaceId(ctx context.Context, imageId string) (uint, error)
+	CreateTaskTrigger(ctx context.Context, workspaceId uint, trigger types.TaskTrigger) (*types.TaskTrigger, error)
+	ListTaskTriggers(ctx context.Context, workspaceId uint) ([]types.TaskTrigger, error)
+	GetTaskTrigger(ctx context.Context, externalId string) (*types.TaskTriggerWithRelated, error)
+	DeleteTaskTrigger(ctx context.Context, workspaceId uint, externalId string) error
+	ListAccessControlEntries(ctx context.Context, resourceType string, resourceId uint) ([]types.AccessControlEntry, error)
+	ListAccessControlEntriesForResources(ctx context.Context, resourceType string, resourceIds []uint) (map[uint][]types.AccessControlEntry, error)
+	SetAccessControlEntry(ctx context.Context, workspaceId uint, resourceType string, resourceId uint, principalType, principal string, access types.ResourceAccess) error
+	DeleteAccessControlEntry(ctx context.Context, resourceType string, resourceId uint, principalType, principal string) error
 	ListDeploymentsWithRelated(ctx context.Context, filters types.DeploymentFilter) ([]types.DeploymentWithRelated, error)
 	ListLatestDeploymentsWithRelatedPaginated(ctx context.Context, filters types.DeploymentFilter) (common.CursorPaginationInfo[types.DeploymentWithRelated], error)
 	ListDeploymentsPaginated(ctx context.Context, filters types.DeploymentFilter) (common.CursorPaginationInfo[types.DeploymentWithRelated], error)
+	GetDeploymentStats(ctx context.Context, stubIds []uint) (map[uint]types.DeploymentStats, error)
 	GetLatestDeploymentByName(ctx context.Context, workspaceId uint, name string, stubType string, filterDeleted bool) (*types.DeploymentWithRelated, error)
+	GetLatestDeploymentByGRPCService(ctx context.Context, workspaceId uint, service string) (*types.DeploymentWithRelated, error)
 	GetDeploymentByExternalId(ctx context.Context, workspaceId uint, deploymentExternalId string) (*types.DeploymentWithRelated, error)
 	GetAnyDeploymentByExternalId(ctx context.Context, deploymentExternalId string) (*types.DeploymentWithRelated, error)
 	GetDeploymentByStubExternalId(ctx context.Context, workspaceId uint, stubExternalId string) (*types.DeploymentWithRelated, error)
 	GetDeploymentByNameAndVersion(ctx context.Context, workspaceId uint, name string, version uint, stubType string) (*types.DeploymentWithRelated, error)
 	CreateDeployment(ctx context.Context, workspaceId uint, name string, version uint, stubId uint, stubType string, appId uint) (*types.Deployment, error)
+	RollbackDeployment(ctx context.Context, workspaceId uint, name string, stubType string, targetVersion uint) (*types.Deployment, error)
+	ListDeploymentHistory(ctx context.Context, workspaceId uint, name string, stubType string) ([]types.DeploymentHistory, error)
+	GetTrafficSplit(ctx context.Context, workspaceId uint, name string, stubType string) (*types.DeploymentTrafficSplit, error)
+	SetTrafficSplit(ctx context.Context, split types.DeploymentTrafficSplit) (*types.DeploymentTrafficSplit, error)
+	EndTrafficSplit(ctx context.Context, splitId uint, status types.TrafficSplitStatus) (bool, error)
+	CreateCustomDomain(ctx context.Context, workspaceId uint, deploymentId uint, domain string) (*types.CustomDomain, error)
+	GetCustomDomain(ctx context.Context, domain string) (*types.CustomDomain, error)
+	GetCustomDomainByExternalId(ctx context.Context, workspaceId uint, externalId string) (*types.CustomDomain, error)
+	ListCustomDomains(ctx context.Context, workspaceId uint, deploymentId uint) ([]types.CustomDomain, error)
+	DeleteCustomDomain(ctx context.Context, id uint) error
+	VerifyCustomDomain(ctx context.Context, id uint) error
+	UpdateCustomDomainStatus(ctx context.Context, domain string, status types.CustomDomainStatus, lastError string, certificateExpiresAt *time.Time) error
+	GetOIDCIdentity(ctx context.Context, issuer string, subject string, workspaceId uint) (*types.OIDCIdentity, error)
+	CreateOIDCIdentity(ctx context.Context, issuer string, subject string, email string, workspaceId uint, tokenId uint) (*types.OIDCIdentity, error)
+	UpdateOIDCIdentityEmail(ctx context.Context, id uint, email string) error
+	CreateAuditLogEntry(ctx context.Context, entry types.AuditLogEntry) error
+	ListAuditLogEntries(ctx context.Context, filter types.AuditLogFilter) ([]types.AuditLogEntry, error)
+	DeleteAuditLogEntriesBefore(ctx context.Context, before time.Time) (int64, error)
+	CreateWorkspaceInvite(ctx context.Context, workspaceId uint, email string, role types.WorkspaceRole, tokenHash string, expiresAt time.Time) (*types.WorkspaceInvite, error)
+	RevokeWorkspaceInvite(ctx context.Context, workspaceId uint, externalId string) (*types.WorkspaceInvite, error)
+	AcceptWorkspaceInvite(ctx context.Context, tokenHash string) (*types.WorkspaceInvite, *types.Token, error)
+	CreateServiceAccount(ctx context.Context, workspaceId uint, name string, role types.WorkspaceRole) (*types.ServiceAccount, error)
+	GetServiceAccount(ctx context.Context, workspaceId uint, externalId string) (*types.ServiceAccount, error)
+	ListServiceAccounts(ctx context.Context, workspaceId uint) ([]types.ServiceAccount, error)
+	DeleteServiceAccount(ctx context.Context, id uint) error
+	TouchServiceAccount(ctx context.Context, id uint) error
+	ListServiceAccountTokens(ctx context.Context, serviceAccountId uint) ([]types.Token, error)
+	CreateServiceAccountToken(ctx context.Context, serviceAccount *types.ServiceAccount, expiresAt time.Time) (*types.Token, error)
+	SetTokenExpiry(ctx context.Context, workspaceId uint, extTokenId string, expiresAt time.Time) (*types.Token, error)
 	UpdateDeployment(ctx context.Context, deployment types.Deployment) (*types.Deployment, error)
 	DeleteDeployment(ctx context.Context, deployment types.Deployment) error
 	ListStubs(ctx context.Context, filters types.StubFilter) ([]types.StubWithRelated, error)
@@ -161,6 +334,9 @@ type BackendRepository interface {
 	GetSecretsByNameDecrypted(ctx context.Context, workspace *types.Workspace, names []string) ([]types.Secret, error)
 	ListSecrets(ctx context.Context, workspace *types.Workspace) ([]types.Secret, error)
 	UpdateSecret(ctx context.Context, workspace *types.Workspace, tokenId uint, secretName string, value string) (*types.Secret, error)
+	RotateSecret(ctx context.Context, workspace *types.Workspace, tokenId uint, secretName string, value string) (*types.Secret, error)
+	GetSecretVersion(ctx context.Context, workspace *types.Workspace, name string, version uint) (*types.Secret, error)
+	GetSecretVersionDecrypted(ctx context.Context, workspace *types.Workspace, name string, version uint) (*types.Secret, error)
 	DeleteSecret(ctx context.Context, workspace *types.Workspace, secretName string) error
 	CreateScheduledJob(ctx context.Context, scheduledJob *types.ScheduledJob) (*types.ScheduledJob, error)
 	DeleteScheduledJob(ctx context.Context, scheduledJob *types.ScheduledJob) error
@@ -230,6 +406,7 @@ type EventRepository interface {
 	PushWorkerStartedEvent(workerID string)
 	PushWorkerStoppedEvent(workerID string)
 	PushWorkerDeletedEvent(workerID, machineID, poolName string, reason types.DeletedWorkerReason)
+	PushWorkerUnhealthyEvent(workerID, machineID, poolName, reason string)
 	PushDeployStubEvent(workspaceId string, stub *types.Stub)
 	PushServeStubEvent(workspaceId string, stub *types.Stub)
 	PushRunStubEvent(workspaceId string, stub *types.Stub)
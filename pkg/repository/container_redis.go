@@ -68,6 +68,10 @@ func (cr *ContainerRedisRepository) SetContainerState(containerId string, state
 		"gpu_count", state.GpuCount,
 		"cpu", state.Cpu,
 		"memory", state.Memory,
+		"priority_class", string(state.PriorityClass),
+		"gpu_share", state.GpuShare,
+		"gpu_memory_limit", state.GpuMemoryLimit,
+		"mig_profile", state.MigProfile,
 	).Err()
 	if err != nil {
 		return fmt.Errorf("failed to set container state <%v>: %w", stateKey, err)
@@ -115,6 +119,36 @@ func (cr *ContainerRedisRepository) GetContainerExitCode(containerId string) (in
 	return exitCode, nil
 }
 
+// containerHealthTtl outlives a few missed checks, so health of containers that are no longer checked expires
+var containerHealthTtl = 10 * time.Minute
+
+func (cr *ContainerRedisRepository) SetContainerHealth(containerId string, health *types.ContainerHealth) error {
+	data, err := json.Marshal(health)
+	if err != nil {
+		return err
+	}
+
+	return cr.rdb.SetEx(context.TODO(), common.RedisKeys.SchedulerContainerHealth(containerId), data, containerHealthTtl).Err()
+}
+
+// GetContainerHealth returns the container's latest health, nil if it hasn't been checked
+func (cr *ContainerRedisRepository) GetContainerHealth(containerId string) (*types.ContainerHealth, error) {
+	data, err := cr.rdb.Get(context.TODO(), common.RedisKeys.SchedulerContainerHealth(containerId)).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	health := &types.ContainerHealth{}
+	if err := json.Unmarshal(data, health); err != nil {
+		return nil, err
+	}
+
+	return health, nil
+}
+
 func (cr *ContainerRedisRepository) UpdateContainerStatus(containerId string, status types.ContainerStatus, expirySeconds int64) error {
 	expiry := time.Duration(expirySeconds) * time.Second
 
@@ -201,6 +235,58 @@ func (cr *ContainerRedisRepository) UpdateAssignedContainerGPU(containerId strin
 	return nil
 }
 
+func (cr *ContainerRedisRepository) SetContainerGpuTopology(containerId string, interconnect string, numaNodes string) error {
+	err := cr.lock.Acquire(context.TODO(), common.RedisKeys.SchedulerContainerLock(containerId), common.RedisLockOptions{TtlS: 10, Retries: 5})
+	if err != nil {
+		return err
+	}
+	defer cr.lock.Release(common.RedisKeys.SchedulerContainerLock(containerId))
+
+	stateKey := common.RedisKeys.SchedulerContainerState(containerId)
+	exists, err := cr.rdb.Exists(context.TODO(), stateKey).Result()
+	if err != nil {
+		return err
+	}
+
+	if exists == 0 {
+		return &types.ErrContainerStateNotFound{ContainerId: containerId}
+	}
+
+	err = cr.rdb.HSet(context.TODO(), stateKey, "gpu_interconnect", interconnect, "numa_nodes", numaNodes).Err()
+	if err != nil {
+		return fmt.Errorf("failed to update container state gpu topology <%v>: %w", stateKey, err)
+	}
+
+	return nil
+}
+
+// SetContainerGpuShare records the share of a GPU a fractional GPU container was scheduled on, so the memory it
+// holds can be accounted for while it runs
+func (cr *ContainerRedisRepository) SetContainerGpuShare(containerId string, share int32, memory int64) error {
+	err := cr.lock.Acquire(context.TODO(), common.RedisKeys.SchedulerContainerLock(containerId), common.RedisLockOptions{TtlS: 10, Retries: 5})
+	if err != nil {
+		return err
+	}
+	defer cr.lock.Release(common.RedisKeys.SchedulerContainerLock(containerId))
+
+	stateKey := common.RedisKeys.SchedulerContainerState(containerId)
+	exists, err := cr.rdb.Exists(context.TODO(), stateKey).Result()
+	if err != nil {
+		return err
+	}
+
+	if exists == 0 {
+		return &types.ErrContainerStateNotFound{ContainerId: containerId}
+	}
+
+	err = cr.rdb.HSet(context.TODO(), stateKey, "gpu_share", share, "gpu_memory_limit", memory).Err()
+	if err != nil {
+		return fmt.Errorf("failed to update container state gpu share <%v>: %w", stateKey, err)
+	}
+
+	return nil
+}
+
 func (cr *ContainerRedisRepository) DeleteContainerState(containerId string) error {
 	err := cr.lock.Acquire(context.TODO(), common.RedisKeys.SchedulerContainerLock(containerId), common.RedisLockOptions{TtlS: 10, Retries: 0})
 	if err != nil {
@@ -232,6 +318,12 @@ func (cr *ContainerRedisRepository) DeleteContainerState(containerId string) err
 		return fmt.Errorf("failed to delete worker addr <%v>: %w", workerAddrKey, err)
 	}
 
+	healthKey := common.RedisKeys.SchedulerContainerHealth(containerId)
+	err = cr.rdb.Del(context.TODO(), healthKey).Err()
+	if err != nil {
+		return fmt.Errorf("failed to delete container health <%v>: %w", healthKey, err)
+	}
+
 	return nil
 }
 
@@ -436,43 +528,24 @@ func (c *ContainerRedisRepository) SetContainerStateWithConcurrencyLimit(quota *
 			return err
 		}
 
-		totalGpuCount := 0
-		totalCpu := 0
-		totalMemory := int64(0)
-		for _, container := range containers {
-			if container.Status == types.ContainerStatusStopping {
-				continue
-			}
-
-			totalGpuCount += int(container.GpuCount)
-			totalCpu += int(container.Cpu)
-			totalMemory += container.Memory
-		}
-
-		if totalGpuCount+int(request.GpuCount) > int(quota.GPULimit) {
-			return &types.ThrottledByConcurrencyLimitError{
-				Reason: "gpu quota exceeded",
-			}
-		}
-
-		if totalCpu+int(request.Cpu) > int(quota.CPUMillicoreLimit) {
-			return &types.ThrottledByConcurrencyLimitError{
-				Reason: "cpu quota exceeded",
-			}
+		if err := quota.Check(containers, request); err != nil {
+			return err
 		}
 	}
 
 	err = c.SetContainerState(request.ContainerId, &types.ContainerState{
-		ContainerId: request.ContainerId,
-		StubId:      request.StubId,
-		Status:      types.ContainerStatusPending,
-		WorkspaceId: request.WorkspaceId,
-		ScheduledAt: time.Now().Unix(),
-		StartedAt:   0,
-		Gpu:         request.Gpu,
-		GpuCount:    request.GpuCount,
-		Cpu:         request.Cpu,
-		Memory:      request.Memory,
+		ContainerId:   request.ContainerId,
+		StubId:        request.StubId,
+		Status:        types.ContainerStatusPending,
+		WorkspaceId:   request.WorkspaceId,
+		ScheduledAt:   time.Now().Unix(),
+		StartedAt:     0,
+		Gpu:           request.Gpu,
+		GpuCount:      request.GpuCount,
+		Cpu:           request.Cpu,
+		Memory:        request.Memory,
+		PriorityClass: request.PriorityClass,
+		MigProfile:    request.MigProfile,
 	})
 	if err != nil {
 		return err
@@ -212,6 +212,20 @@ func (t *TCPEventClientRepo) PushWorkerDeletedEvent(workerID, machineID, poolNam
 	)
 }
 
+func (t *TCPEventClientRepo) PushWorkerUnhealthyEvent(workerID, machineID, poolName, reason string) {
+	t.pushEvent(
+		types.EventWorkerLifecycle,
+		types.EventWorkerLifecycleSchemaVersion,
+		types.EventWorkerLifecycleSchema{
+			WorkerID:        workerID,
+			MachineID:       machineID,
+			PoolName:        poolName,
+			Status:          types.EventWorkerLifecycleUnhealthy,
+			UnhealthyReason: reason,
+		},
+	)
+}
+
 func (t *TCPEventClientRepo) PushContainerResourceMetricsEvent(workerID string, request *types.ContainerRequest, metrics types.EventContainerMetricsData) {
 	t.pushEvent(
 		types.EventContainerMetrics,
@@ -401,6 +415,7 @@ func (t *TCPEventClientRepo) PushGatewayEndpointCalledEvent(method, path, worksp
 func sanitizeContainerRequest(request *types.ContainerRequest) types.ContainerRequest {
 	requestCopy := *request
 	requestCopy.Env = nil
+	requestCopy.SecretMount = nil
 	requestCopy.EntryPoint = nil
 	requestCopy.Stub = types.StubWithRelated{}
 	requestCopy.Workspace = types.Workspace{}
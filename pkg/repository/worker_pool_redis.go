@@ -3,6 +3,9 @@ package repository
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	common "github.com/beam-cloud/beta9/pkg/common"
 	types "github.com/beam-cloud/beta9/pkg/types"
@@ -79,3 +82,57 @@ func (r *WorkerPoolRedisRepository) SetWorkerCleanerLock(poolName string) error
 func (r *WorkerPoolRedisRepository) RemoveWorkerCleanerLock(poolName string) error {
 	return r.lock.Release(common.RedisKeys.WorkerPoolCleanerLock(poolName))
 }
+
+// RecordWorkerPoolDemand adds the capacity requested by a stub's container to the demand of the hour it started in
+func (r *WorkerPoolRedisRepository) RecordWorkerPoolDemand(ctx context.Context, poolName, stubId string, at time.Time, demand *types.WorkerPoolDemand, ttl time.Duration) error {
+	key := common.RedisKeys.WorkerPoolDemand(poolName, at.Unix()/3600)
+
+	pipe := r.rdb.Pipeline()
+	pipe.HIncrBy(ctx, key, stubId+":cpu", demand.Cpu)
+	pipe.HIncrBy(ctx, key, stubId+":memory", demand.Memory)
+	pipe.HIncrBy(ctx, key, stubId+":gpu", demand.Gpu)
+	pipe.HIncrBy(ctx, key, stubId+":containers", demand.Containers)
+	pipe.Expire(ctx, key, ttl)
+
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// GetWorkerPoolDemand returns the demand of each stub that started containers in a pool during the hour of at
+func (r *WorkerPoolRedisRepository) GetWorkerPoolDemand(ctx context.Context, poolName string, at time.Time) (map[string]*types.WorkerPoolDemand, error) {
+	res, err := r.rdb.HGetAll(ctx, common.RedisKeys.WorkerPoolDemand(poolName, at.Unix()/3600)).Result()
+	if err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("failed to get worker pool demand: %w", err)
+	}
+
+	demand := map[string]*types.WorkerPoolDemand{}
+	for field, value := range res {
+		idx := strings.LastIndex(field, ":")
+		if idx < 0 {
+			continue
+		}
+
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		stubId := field[:idx]
+		if _, ok := demand[stubId]; !ok {
+			demand[stubId] = &types.WorkerPoolDemand{}
+		}
+
+		switch field[idx+1:] {
+		case "cpu":
+			demand[stubId].Cpu = n
+		case "memory":
+			demand[stubId].Memory = n
+		case "gpu":
+			demand[stubId].Gpu = n
+		case "containers":
+			demand[stubId].Containers = n
+		}
+	}
+
+	return demand, nil
+}
@@ -93,6 +93,11 @@ func (r *WorkerRedisRepository) RemoveWorker(workerId string) error {
 		return err
 	}
 
+	err = r.rdb.Del(context.TODO(), common.RedisKeys.WorkerObjectCacheInvalidated(workerId)).Err()
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -109,8 +114,11 @@ func (r *WorkerRedisRepository) UpdateWorkerStatus(workerId string, status types
 		return err
 	}
 
-	// Update worker status
+	// Update worker status, forgetting why the worker was unhealthy once it's made available again
 	worker.Status = status
+	if status == types.WorkerStatusAvailable {
+		worker.UnhealthyReason = ""
+	}
 	worker.ResourceVersion++
 	err = r.rdb.HSet(context.TODO(), stateKey, common.ToSlice(worker)).Err()
 	if err != nil {
@@ -142,6 +150,189 @@ func (r *WorkerRedisRepository) ToggleWorkerAvailable(workerId string) error {
 	return r.UpdateWorkerStatus(workerId, types.WorkerStatusAvailable)
 }
 
+// SetWorkerLabels replaces the labels of a worker. Labels don't change its capacity, so its resource version
+// is left alone.
+func (r *WorkerRedisRepository) SetWorkerLabels(workerId string, labels types.WorkerLabels) error {
+	err := r.lock.Acquire(context.TODO(), common.RedisKeys.SchedulerWorkerLock(workerId), common.RedisLockOptions{TtlS: 10, Retries: 3})
+	if err != nil {
+		return err
+	}
+	defer r.lock.Release(common.RedisKeys.SchedulerWorkerLock(workerId))
+
+	stateKey := common.RedisKeys.SchedulerWorkerState(workerId)
+	exists, err := r.rdb.Exists(context.TODO(), stateKey).Result()
+	if err != nil {
+		return err
+	}
+
+	if exists == 0 {
+		return &types.ErrWorkerNotFound{WorkerId: workerId}
+	}
+
+	err = r.rdb.HSet(context.TODO(), stateKey, "labels", string(labels)).Err()
+	if err != nil {
+		return fmt.Errorf("failed to update worker labels <%s>: %v", stateKey, err)
+	}
+
+	return nil
+}
+
+// SetWorkerGpuInterconnect records how the worker's GPUs are connected to each other, as found by the worker
+// when it starts
+func (r *WorkerRedisRepository) SetWorkerGpuInterconnect(workerId string, interconnect string) error {
+	err := r.lock.Acquire(context.TODO(), common.RedisKeys.SchedulerWorkerLock(workerId), common.RedisLockOptions{TtlS: 10, Retries: 3})
+	if err != nil {
+		return err
+	}
+	defer r.lock.Release(common.RedisKeys.SchedulerWorkerLock(workerId))
+
+	stateKey := common.RedisKeys.SchedulerWorkerState(workerId)
+	exists, err := r.rdb.Exists(context.TODO(), stateKey).Result()
+	if err != nil {
+		return err
+	}
+
+	if exists == 0 {
+		return &types.ErrWorkerNotFound{WorkerId: workerId}
+	}
+
+	err = r.rdb.HSet(context.TODO(), stateKey, "gpu_interconnect", interconnect).Err()
+	if err != nil {
+		return fmt.Errorf("failed to update worker gpu interconnect <%s>: %v", stateKey, err)
+	}
+
+	return nil
+}
+
+// SetWorkerServerAddress records the address of the worker's container server, which the scheduler's health
+// checks connect to
+func (r *WorkerRedisRepository) SetWorkerServerAddress(workerId string, address string) error {
+	err := r.lock.Acquire(context.TODO(), common.RedisKeys.SchedulerWorkerLock(workerId), common.RedisLockOptions{TtlS: 10, Retries: 3})
+	if err != nil {
+		return err
+	}
+	defer r.lock.Release(common.RedisKeys.SchedulerWorkerLock(workerId))
+
+	stateKey := common.RedisKeys.SchedulerWorkerState(workerId)
+	exists, err := r.rdb.Exists(context.TODO(), stateKey).Result()
+	if err != nil {
+		return err
+	}
+
+	if exists == 0 {
+		return &types.ErrWorkerNotFound{WorkerId: workerId}
+	}
+
+	err = r.rdb.HSet(context.TODO(), stateKey, "server_address", address).Err()
+	if err != nil {
+		return fmt.Errorf("failed to update worker server address <%s>: %v", stateKey, err)
+	}
+
+	return nil
+}
+
+// SetWorkerArch records the CPU architecture the worker found it runs on when it started, which may differ from
+// the architecture its pool was configured with
+func (r *WorkerRedisRepository) SetWorkerArch(workerId string, arch string) error {
+	err := r.lock.Acquire(context.TODO(), common.RedisKeys.SchedulerWorkerLock(workerId), common.RedisLockOptions{TtlS: 10, Retries: 3})
+	if err != nil {
+		return err
+	}
+	defer r.lock.Release(common.RedisKeys.SchedulerWorkerLock(workerId))
+
+	stateKey := common.RedisKeys.SchedulerWorkerState(workerId)
+	exists, err := r.rdb.Exists(context.TODO(), stateKey).Result()
+	if err != nil {
+		return err
+	}
+
+	if exists == 0 {
+		return &types.ErrWorkerNotFound{WorkerId: workerId}
+	}
+
+	err = r.rdb.HSet(context.TODO(), stateKey, "arch", arch).Err()
+	if err != nil {
+		return fmt.Errorf("failed to update worker arch <%s>: %v", stateKey, err)
+	}
+
+	return nil
+}
+
+// SetWorkerMigSlices records the MIG slices the worker found its GPUs partitioned into when it started, which may
+// differ from the layout of its pool. Slices taken by containers placed on the worker before then stay taken.
+func (r *WorkerRedisRepository) SetWorkerMigSlices(workerId string, slices types.MigSlices) error {
+	err := r.lock.Acquire(context.TODO(), common.RedisKeys.SchedulerWorkerLock(workerId), common.RedisLockOptions{TtlS: 10, Retries: 3})
+	if err != nil {
+		return err
+	}
+	defer r.lock.Release(common.RedisKeys.SchedulerWorkerLock(workerId))
+
+	stateKey := common.RedisKeys.SchedulerWorkerState(workerId)
+	exists, err := r.rdb.Exists(context.TODO(), stateKey).Result()
+	if err != nil {
+		return err
+	}
+
+	if exists == 0 {
+		return &types.ErrWorkerNotFound{WorkerId: workerId}
+	}
+
+	worker, err := r.getWorkerFromKey(stateKey)
+	if err != nil {
+		return err
+	}
+
+	worker.SetMigSlices(slices)
+	worker.ResourceVersion++
+
+	err = r.rdb.HSet(context.TODO(), stateKey,
+		"total_mig_slices", string(worker.TotalMigSlices),
+		"free_mig_slices", string(worker.FreeMigSlices),
+		"resource_version", worker.ResourceVersion,
+	).Err()
+	if err != nil {
+		return fmt.Errorf("failed to update worker mig slices <%s>: %v", stateKey, err)
+	}
+
+	return nil
+}
+
+// CordonUnhealthyWorker takes a worker that failed its health checks out of scheduling, and records why for
+// operators. The reason is cleared when the worker is uncordoned.
+func (r *WorkerRedisRepository) CordonUnhealthyWorker(workerId string, reason string) error {
+	err := r.lock.Acquire(context.TODO(), common.RedisKeys.SchedulerWorkerLock(workerId), common.RedisLockOptions{TtlS: 10, Retries: 3})
+	if err != nil {
+		return err
+	}
+	defer r.lock.Release(common.RedisKeys.SchedulerWorkerLock(workerId))
+
+	stateKey := common.RedisKeys.SchedulerWorkerState(workerId)
+	exists, err := r.rdb.Exists(context.TODO(), stateKey).Result()
+	if err != nil {
+		return err
+	}
+
+	// The worker may have gone away while it was being checked
+	if exists == 0 {
+		return &types.ErrWorkerNotFound{WorkerId: workerId}
+	}
+
+	worker, err := r.getWorkerFromKey(stateKey)
+	if err != nil {
+		return err
+	}
+
+	worker.Status = types.WorkerStatusDisabled
+	worker.UnhealthyReason = reason
+	worker.ResourceVersion++
+	err = r.rdb.HSet(context.TODO(), stateKey, common.ToSlice(worker)).Err()
+	if err != nil {
+		return fmt.Errorf("failed to cordon unhealthy worker <%s>: %v", stateKey, err)
+	}
+
+	return nil
+}
+
 // getWorkers retrieves a list of worker objects from the Redis store that match a given pattern.
 // If useLock is set to true, a lock will be acquired for each worker and released after retrieval.
 // If you can afford to not have the most up-to-date worker information, you can set useLock to false.
@@ -450,7 +641,13 @@ func (r *WorkerRedisRepository) UpdateWorkerCapacity(worker *types.Worker, reque
 		updatedWorker.FreeMemory = updatedWorker.FreeMemory + request.Memory
 
 		if request.Gpu != "" {
-			updatedWorker.FreeGpuCount += request.GpuCount
+			if request.UsesMig() {
+				updatedWorker.ReleaseMigSlice(request.MigProfile)
+			} else if request.SharesGpu() {
+				updatedWorker.ReleaseGpuShare(request.GpuShare, request.GpuMemoryLimit)
+			} else {
+				updatedWorker.FreeGpuCount += request.GpuCount
+			}
 		}
 
 	case types.RemoveCapacity:
@@ -458,7 +655,22 @@ func (r *WorkerRedisRepository) UpdateWorkerCapacity(worker *types.Worker, reque
 		updatedWorker.FreeMemory = updatedWorker.FreeMemory - request.Memory
 
 		if request.Gpu != "" {
-			updatedWorker.FreeGpuCount -= request.GpuCount
+			if request.UsesMig() {
+				if err := updatedWorker.ReserveMigSlice(request.MigProfile); err != nil {
+					return fmt.Errorf("unable to schedule container: %w", err)
+				}
+			} else if request.SharesGpu() {
+				memory := types.GpuShareMemory(types.GpuType(updatedWorker.Gpu), request.GpuFraction)
+				share, err := updatedWorker.ReserveGpuShare(memory)
+				if err != nil {
+					return fmt.Errorf("unable to schedule container: %w", err)
+				}
+
+				request.GpuShare = share
+				request.GpuMemoryLimit = memory
+			} else {
+				updatedWorker.FreeGpuCount -= request.GpuCount
+			}
 		}
 
 		if updatedWorker.FreeCpu < 0 || updatedWorker.FreeMemory < 0 || updatedWorker.FreeGpuCount < 0 {
@@ -480,25 +692,30 @@ func (r *WorkerRedisRepository) UpdateWorkerCapacity(worker *types.Worker, reque
 }
 
 func (r *WorkerRedisRepository) ScheduleContainerRequest(worker *types.Worker, request *types.ContainerRequest) error {
-	// Serialize the ContainerRequest -> JSON
-	requestJSON, err := json.Marshal(request)
+	// Update the worker capacity first, which also picks the GPU share of fractional GPU requests
+	err := r.UpdateWorkerCapacity(worker, request, types.RemoveCapacity)
 	if err != nil {
-		return fmt.Errorf("failed to serialize request: %w", err)
+		return err
 	}
 
-	// Update the worker capacity first
-	err = r.UpdateWorkerCapacity(worker, request, types.RemoveCapacity)
+	return r.PushContainerRequest(worker.Id, request)
+}
+
+// PushContainerRequest queues a request on a worker whose capacity has already been taken for it
+func (r *WorkerRedisRepository) PushContainerRequest(workerId string, request *types.ContainerRequest) error {
+	// Serialize the ContainerRequest -> JSON
+	requestJSON, err := json.Marshal(request)
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to serialize request: %w", err)
 	}
 
 	// Push the serialized ContainerRequest
-	err = r.rdb.RPush(context.TODO(), common.RedisKeys.SchedulerWorkerRequests(worker.Id), requestJSON).Err()
+	err = r.rdb.RPush(context.TODO(), common.RedisKeys.SchedulerWorkerRequests(workerId), requestJSON).Err()
 	if err != nil {
 		return fmt.Errorf("failed to push request: %w", err)
 	}
 
-	log.Info().Str("container_id", request.ContainerId).Str("worker_id", worker.Id).Msg("request for container added")
+	log.Info().Str("container_id", request.ContainerId).Str("worker_id", workerId).Msg("request for container added")
 
 	return nil
 }
@@ -665,3 +882,73 @@ func (r *WorkerRedisRepository) RemoveContainerIp(networkPrefix string, containe
 
 	return nil
 }
+
+// AddCachedObject records that a worker holds an object's content in its local object cache
+func (r *WorkerRedisRepository) AddCachedObject(workerId, digest string) error {
+	indexKey := common.RedisKeys.WorkerObjectCacheIndex(digest)
+	err := r.rdb.SAdd(context.TODO(), indexKey, workerId).Err()
+	if err != nil {
+		return fmt.Errorf("failed to add worker to object cache index <%v>: %w", indexKey, err)
+	}
+
+	// Workers re-register on every cache hit, so entries left behind by workers that went away age out
+	return r.rdb.Expire(context.TODO(), indexKey, types.WorkerObjectCacheRegistryTtl).Err()
+}
+
+func (r *WorkerRedisRepository) RemoveCachedObject(workerId, digest string) error {
+	return r.rdb.SRem(context.TODO(), common.RedisKeys.WorkerObjectCacheIndex(digest), workerId).Err()
+}
+
+func (r *WorkerRedisRepository) GetWorkersCachingObject(digest string) ([]string, error) {
+	return r.rdb.SMembers(context.TODO(), common.RedisKeys.WorkerObjectCacheIndex(digest)).Result()
+}
+
+// InvalidateCachedObject drops a digest from the cache registry and queues it for eviction on every
+// worker that holds it
+func (r *WorkerRedisRepository) InvalidateCachedObject(digest string) error {
+	indexKey := common.RedisKeys.WorkerObjectCacheIndex(digest)
+	workerIds, err := r.rdb.SMembers(context.TODO(), indexKey).Result()
+	if err != nil {
+		return err
+	}
+
+	for _, workerId := range workerIds {
+		invalidatedKey := common.RedisKeys.WorkerObjectCacheInvalidated(workerId)
+		err = r.rdb.SAdd(context.TODO(), invalidatedKey, digest).Err()
+		if err != nil {
+			return fmt.Errorf("failed to queue object cache invalidation <%v>: %w", invalidatedKey, err)
+		}
+
+		err = r.rdb.Expire(context.TODO(), invalidatedKey, types.WorkerObjectCacheRegistryTtl).Err()
+		if err != nil {
+			return err
+		}
+	}
+
+	return r.rdb.Del(context.TODO(), indexKey).Err()
+}
+
+// PopCachedObjectInvalidations returns and clears the digests a worker should evict from its object cache
+func (r *WorkerRedisRepository) PopCachedObjectInvalidations(workerId string) ([]string, error) {
+	invalidatedKey := common.RedisKeys.WorkerObjectCacheInvalidated(workerId)
+	digests, err := r.rdb.SMembers(context.TODO(), invalidatedKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(digests) == 0 {
+		return digests, nil
+	}
+
+	members := make([]interface{}, 0, len(digests))
+	for _, digest := range digests {
+		members = append(members, digest)
+	}
+
+	err = r.rdb.SRem(context.TODO(), invalidatedKey, members...).Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return digests, nil
+}
@@ -118,6 +118,38 @@ func TestToggleWorkerAvailable(t *testing.T) {
 	assert.Equal(t, types.WorkerStatusAvailable, worker.Status)
 }
 
+func TestCordonUnhealthyWorker(t *testing.T) {
+	rdb, err := NewRedisClientForTest()
+	assert.NotNil(t, rdb)
+	assert.Nil(t, err)
+
+	repo := NewWorkerRedisRepositoryForTest(rdb)
+
+	err = repo.AddWorker(&types.Worker{Id: "worker1", Status: types.WorkerStatusAvailable, FreeCpu: 1000, FreeMemory: 1000})
+	assert.Nil(t, err)
+
+	err = repo.CordonUnhealthyWorker("worker1", "disk: 97% of /images used")
+	assert.Nil(t, err)
+
+	worker, err := repo.GetWorkerById("worker1")
+	assert.Nil(t, err)
+	assert.Equal(t, types.WorkerStatusDisabled, worker.Status)
+	assert.Equal(t, "disk: 97% of /images used", worker.UnhealthyReason)
+
+	// Uncordoning the worker clears the reason
+	err = repo.UpdateWorkerStatus("worker1", types.WorkerStatusAvailable)
+	assert.Nil(t, err)
+
+	worker, err = repo.GetWorkerById("worker1")
+	assert.Nil(t, err)
+	assert.Equal(t, types.WorkerStatusAvailable, worker.Status)
+	assert.Equal(t, "", worker.UnhealthyReason)
+
+	err = repo.CordonUnhealthyWorker("worker2", "unreachable")
+	_, ok := err.(*types.ErrWorkerNotFound)
+	assert.True(t, ok)
+}
+
 func TestUpdateWorkerCapacityForGPUWorker(t *testing.T) {
 	rdb, err := NewRedisClientForTest()
 	assert.NotNil(t, rdb)
@@ -357,3 +389,57 @@ func TestGetId(t *testing.T) {
 	id := repo.GetId()
 	assert.Len(t, id, 8)
 }
+
+func TestCachedObjectInvalidation(t *testing.T) {
+	rdb, err := NewRedisClientForTest()
+	assert.NotNil(t, rdb)
+	assert.Nil(t, err)
+
+	repo := NewWorkerRedisRepositoryForTest(rdb)
+
+	err = repo.AddCachedObject("worker1", "digest1")
+	assert.Nil(t, err)
+
+	err = repo.AddCachedObject("worker2", "digest1")
+	assert.Nil(t, err)
+
+	err = repo.AddCachedObject("worker2", "digest2")
+	assert.Nil(t, err)
+
+	workerIds, err := repo.GetWorkersCachingObject("digest1")
+	assert.Nil(t, err)
+	assert.Len(t, workerIds, 2)
+	assert.Contains(t, workerIds, "worker1")
+	assert.Contains(t, workerIds, "worker2")
+
+	err = repo.RemoveCachedObject("worker1", "digest1")
+	assert.Nil(t, err)
+
+	workerIds, err = repo.GetWorkersCachingObject("digest1")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"worker2"}, workerIds)
+
+	err = repo.InvalidateCachedObject("digest1")
+	assert.Nil(t, err)
+
+	workerIds, err = repo.GetWorkersCachingObject("digest1")
+	assert.Nil(t, err)
+	assert.Len(t, workerIds, 0)
+
+	// Only workers that held the object are told to evict it
+	digests, err := repo.PopCachedObjectInvalidations("worker1")
+	assert.Nil(t, err)
+	assert.Len(t, digests, 0)
+
+	digests, err = repo.PopCachedObjectInvalidations("worker2")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"digest1"}, digests)
+
+	digests, err = repo.PopCachedObjectInvalidations("worker2")
+	assert.Nil(t, err)
+	assert.Len(t, digests, 0)
+
+	workerIds, err = repo.GetWorkersCachingObject("digest2")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"worker2"}, workerIds)
+}
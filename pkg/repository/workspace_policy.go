@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+// LoadWorkspacePolicy returns the workspace's resource policy from the cache, falling back to the database. A
+// workspace without a policy gets an empty one, which doesn't limit anything.
+func LoadWorkspacePolicy(ctx context.Context, workspaceRepo WorkspaceRepository, backendRepo BackendRepository, workspaceId string) (*types.WorkspacePolicy, error) {
+	policy, cached, err := workspaceRepo.GetWorkspacePolicyByWorkspaceId(workspaceId)
+	if err == nil && cached {
+		return policy, nil
+	}
+
+	policy, err = backendRepo.GetWorkspacePolicy(ctx, workspaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	if policy == nil {
+		policy = &types.WorkspacePolicy{}
+	}
+
+	if err := workspaceRepo.SetWorkspacePolicyByWorkspaceId(workspaceId, policy); err != nil {
+		log.Warn().Err(err).Str("workspace_id", workspaceId).Msg("failed to cache workspace policy")
+	}
+
+	return policy, nil
+}
+
+// LoadPoolQuotas returns the pool quotas of all workspaces from the cache, falling back to the database
+func LoadPoolQuotas(ctx context.Context, workspaceRepo WorkspaceRepository, backendRepo BackendRepository) ([]types.PoolQuota, error) {
+	quotas, cached, err := workspaceRepo.GetPoolQuotas()
+	if err == nil && cached {
+		return quotas, nil
+	}
+
+	quotas, err = backendRepo.ListPoolQuotas(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := workspaceRepo.SetPoolQuotas(quotas); err != nil {
+		log.Warn().Err(err).Msg("failed to cache pool quotas")
+	}
+
+	return quotas, nil
+}
+
+// LoadCapacityReservations returns the reservations of all workspaces that haven't ended from the cache, falling
+// back to the database
+func LoadCapacityReservations(ctx context.Context, workspaceRepo WorkspaceRepository, backendRepo BackendRepository) ([]types.CapacityReservation, error) {
+	reservations, cached, err := workspaceRepo.GetCapacityReservations()
+	if err == nil && cached {
+		return reservations, nil
+	}
+
+	reservations, err = backendRepo.ListCapacityReservations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := workspaceRepo.SetCapacityReservations(reservations); err != nil {
+		log.Warn().Err(err).Msg("failed to cache capacity reservations")
+	}
+
+	return reservations, nil
+}
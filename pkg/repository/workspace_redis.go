@@ -6,6 +6,8 @@ import (
 	"errors"
 	"time"
 
+	"github.com/redis/go-redis/v9"
+
 	"github.com/beam-cloud/beta9/pkg/common"
 	"github.com/beam-cloud/beta9/pkg/types"
 )
@@ -20,6 +22,16 @@ func NewWorkspaceRedisRepository(r *common.RedisClient) WorkspaceRepository {
 
 const cachedTokenTTLS = 600 // 10 minutes
 
+const revokedTokenTTLS = 2 * cachedTokenTTLS
+
+const cachedIPAllowListTTLS = 600
+
+const cachedWorkspacePolicyTTLS = 600
+
+const cachedPoolQuotasTTLS = 60
+
+const cachedCapacityReservationsTTLS = 60
+
 func (wr *WorkspaceRedisRepository) GetConcurrencyLimitByWorkspaceId(workspaceId string) (*types.ConcurrencyLimit, error) {
 	key := common.RedisKeys.WorkspaceConcurrencyLimit(workspaceId)
 	res, err := wr.rdb.HGetAll(context.Background(), key).Result()
@@ -85,13 +97,35 @@ func (wr *WorkspaceRedisRepository) AuthorizeToken(token string) (*types.Token,
 	return info.Token, info.Workspace, nil
 }
 
+// RevokeToken drops a token from the cache and adds it to the revocation list, so it's refused on its next use by
+// every gateway. The revocation outlives the cache, in case a request that loaded the token before it was revoked
+// caches it again.
 func (wr *WorkspaceRedisRepository) RevokeToken(tokenKey string) error {
-	err := wr.rdb.Del(context.Background(), common.RedisKeys.WorkspaceAuthorizedToken(tokenKey)).Err()
+	err := wr.rdb.Set(context.Background(), common.RedisKeys.WorkspaceRevokedToken(tokenKey), 1, time.Duration(revokedTokenTTLS)*time.Second).Err()
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return wr.InvalidateToken(tokenKey)
+}
+
+// RestoreToken removes a token from the revocation list, for tokens that are enabled again
+func (wr *WorkspaceRedisRepository) RestoreToken(tokenKey string) error {
+	return wr.rdb.Del(context.Background(), common.RedisKeys.WorkspaceRevokedToken(tokenKey)).Err()
+}
+
+func (wr *WorkspaceRedisRepository) IsTokenRevoked(tokenKey string) (bool, error) {
+	n, err := wr.rdb.Exists(context.Background(), common.RedisKeys.WorkspaceRevokedToken(tokenKey)).Result()
+	if err != nil {
+		return false, err
+	}
+
+	return n > 0, nil
+}
+
+// InvalidateToken drops a token from the cache, so changes to it are picked up on its next use
+func (wr *WorkspaceRedisRepository) InvalidateToken(tokenKey string) error {
+	return wr.rdb.Del(context.Background(), common.RedisKeys.WorkspaceAuthorizedToken(tokenKey)).Err()
 }
 
 func (wr *WorkspaceRedisRepository) SetAuthorizationToken(token *types.Token, workspace *types.Workspace) error {
@@ -111,3 +145,220 @@ func (wr *WorkspaceRedisRepository) SetAuthorizationToken(token *types.Token, wo
 
 	return nil
 }
+
+// SetTokenSession stores a session until it expires
+func (wr *WorkspaceRedisRepository) SetTokenSession(sessionId string, session *types.TokenSession) error {
+	ttl := time.Until(session.ExpiresAt)
+	if ttl <= 0 {
+		return errors.New("session has expired")
+	}
+
+	bytes, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	return wr.rdb.Set(context.Background(), common.RedisKeys.WorkspaceTokenSession(sessionId), bytes, ttl).Err()
+}
+
+// GetTokenSession returns nil if the session expired or was revoked
+func (wr *WorkspaceRedisRepository) GetTokenSession(sessionId string) (*types.TokenSession, error) {
+	res, err := wr.rdb.Get(context.Background(), common.RedisKeys.WorkspaceTokenSession(sessionId)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	session := &types.TokenSession{}
+	if err := json.Unmarshal([]byte(res), session); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// Replaces a session if it's still on the refresh id, and deletes it if it's on another one.
+//
+// KEYS: session
+// ARGV: refresh id, new session, ttl (ms)
+//
+// Returns 1 if the session was replaced, 0 if it was deleted, -1 if it doesn't exist
+var rotateTokenSessionScript = redis.NewScript(`
+local current = redis.call("GET", KEYS[1])
+if not current then
+	return -1
+end
+
+if cjson.decode(current)["refresh_id"] ~= ARGV[1] then
+	redis.call("DEL", KEYS[1])
+	return 0
+end
+
+redis.call("SET", KEYS[1], ARGV[2], "PX", tonumber(ARGV[3]))
+return 1
+`)
+
+// RotateTokenSession replaces a session only if its refresh id is still refreshId, so concurrent refreshes with
+// the same refresh token can't both succeed. A session on another refresh id is deleted. It returns whether the
+// session was replaced.
+func (wr *WorkspaceRedisRepository) RotateTokenSession(sessionId string, refreshId string, session *types.TokenSession) (bool, error) {
+	ttl := time.Until(session.ExpiresAt)
+	if ttl <= 0 {
+		return false, errors.New("session has expired")
+	}
+
+	bytes, err := json.Marshal(session)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := rotateTokenSessionScript.Run(
+		context.Background(), wr.rdb, []string{common.RedisKeys.WorkspaceTokenSession(sessionId)},
+		refreshId, string(bytes), ttl.Milliseconds(),
+	).Int()
+	if err != nil {
+		return false, err
+	}
+
+	return res == 1, nil
+}
+
+func (wr *WorkspaceRedisRepository) DeleteTokenSession(sessionId string) error {
+	return wr.rdb.Del(context.Background(), common.RedisKeys.WorkspaceTokenSession(sessionId)).Err()
+}
+
+// GetIPAllowListByWorkspaceId returns the workspace's cached IP allowlist, and false if it isn't cached
+func (wr *WorkspaceRedisRepository) GetIPAllowListByWorkspaceId(workspaceId string) ([]string, bool, error) {
+	res, err := wr.rdb.Get(context.Background(), common.RedisKeys.WorkspaceIPAllowList(workspaceId)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	var cidrs []string
+	if err := json.Unmarshal([]byte(res), &cidrs); err != nil {
+		return nil, false, err
+	}
+
+	return cidrs, true, nil
+}
+
+func (wr *WorkspaceRedisRepository) SetIPAllowListByWorkspaceId(workspaceId string, cidrs []string) error {
+	if cidrs == nil {
+		cidrs = []string{}
+	}
+
+	bytes, err := json.Marshal(cidrs)
+	if err != nil {
+		return err
+	}
+
+	key := common.RedisKeys.WorkspaceIPAllowList(workspaceId)
+	return wr.rdb.Set(context.Background(), key, bytes, time.Duration(cachedIPAllowListTTLS)*time.Second).Err()
+}
+
+// GetWorkspacePolicyByWorkspaceId returns the workspace's cached resource policy, and false if it isn't cached.
+// Workspaces without a policy are cached with an empty one.
+func (wr *WorkspaceRedisRepository) GetWorkspacePolicyByWorkspaceId(workspaceId string) (*types.WorkspacePolicy, bool, error) {
+	res, err := wr.rdb.Get(context.Background(), common.RedisKeys.WorkspacePolicy(workspaceId)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	policy := &types.WorkspacePolicy{}
+	if err := json.Unmarshal([]byte(res), policy); err != nil {
+		return nil, false, err
+	}
+
+	return policy, true, nil
+}
+
+func (wr *WorkspaceRedisRepository) SetWorkspacePolicyByWorkspaceId(workspaceId string, policy *types.WorkspacePolicy) error {
+	if policy == nil {
+		policy = &types.WorkspacePolicy{}
+	}
+
+	bytes, err := json.Marshal(policy)
+	if err != nil {
+		return err
+	}
+
+	key := common.RedisKeys.WorkspacePolicy(workspaceId)
+	return wr.rdb.Set(context.Background(), key, bytes, time.Duration(cachedWorkspacePolicyTTLS)*time.Second).Err()
+}
+
+// GetPoolQuotas returns the cached pool quotas of all workspaces, and false if they aren't cached
+func (wr *WorkspaceRedisRepository) GetPoolQuotas() ([]types.PoolQuota, bool, error) {
+	res, err := wr.rdb.Get(context.Background(), common.RedisKeys.WorkspacePoolQuotas()).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	quotas := []types.PoolQuota{}
+	if err := json.Unmarshal([]byte(res), &quotas); err != nil {
+		return nil, false, err
+	}
+
+	return quotas, true, nil
+}
+
+func (wr *WorkspaceRedisRepository) SetPoolQuotas(quotas []types.PoolQuota) error {
+	if quotas == nil {
+		quotas = []types.PoolQuota{}
+	}
+
+	bytes, err := json.Marshal(quotas)
+	if err != nil {
+		return err
+	}
+
+	key := common.RedisKeys.WorkspacePoolQuotas()
+	return wr.rdb.Set(context.Background(), key, bytes, time.Duration(cachedPoolQuotasTTLS)*time.Second).Err()
+}
+
+// GetCapacityReservations returns the cached reservations of all workspaces, and false if they aren't cached
+func (wr *WorkspaceRedisRepository) GetCapacityReservations() ([]types.CapacityReservation, bool, error) {
+	res, err := wr.rdb.Get(context.Background(), common.RedisKeys.WorkspaceCapacityReservations()).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	reservations := []types.CapacityReservation{}
+	if err := json.Unmarshal([]byte(res), &reservations); err != nil {
+		return nil, false, err
+	}
+
+	return reservations, true, nil
+}
+
+func (wr *WorkspaceRedisRepository) SetCapacityReservations(reservations []types.CapacityReservation) error {
+	if reservations == nil {
+		reservations = []types.CapacityReservation{}
+	}
+
+	bytes, err := json.Marshal(reservations)
+	if err != nil {
+		return err
+	}
+
+	key := common.RedisKeys.WorkspaceCapacityReservations()
+	return wr.rdb.Set(context.Background(), key, bytes, time.Duration(cachedCapacityReservationsTTLS)*time.Second).Err()
+}
@@ -2,7 +2,9 @@ package runtime
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
 	"os/exec"
 	"syscall"
 	"time"
@@ -75,6 +77,10 @@ func (r *Runc) Run(ctx context.Context, containerID, bundlePath string, opts *Ru
 }
 
 func (r *Runc) Exec(ctx context.Context, containerID string, proc specs.Process, opts *ExecOpts) error {
+	if opts.HasStdio() {
+		return r.execWithStdio(ctx, containerID, proc, opts)
+	}
+
 	runcOpts := &runc.ExecOpts{}
 	
 	if opts != nil {
@@ -89,6 +95,48 @@ func (r *Runc) Exec(ctx context.Context, containerID string, proc specs.Process,
 	return r.handle.Exec(ctx, containerID, proc, runcOpts)
 }
 
+// execWithStdio runs the process through the runc binary directly so its stdin, stdout and stderr
+// can be attached separately
+func (r *Runc) execWithStdio(ctx context.Context, containerID string, proc specs.Process, opts *ExecOpts) error {
+	procFile, err := os.CreateTemp("", "runc-process-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to create process spec: %w", err)
+	}
+	defer os.Remove(procFile.Name())
+
+	procJSON, err := json.Marshal(proc)
+	if err != nil {
+		return fmt.Errorf("failed to marshal process spec: %w", err)
+	}
+
+	if _, err := procFile.Write(procJSON); err != nil {
+		return fmt.Errorf("failed to write process spec: %w", err)
+	}
+	procFile.Close()
+
+	args := []string{"exec", "--process", procFile.Name(), containerID}
+	if r.cfg.Debug {
+		args = append([]string{"--debug"}, args...)
+	}
+
+	cmd := exec.CommandContext(ctx, r.cfg.RuncPath, args...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = opts.Stdin, opts.Stdout, opts.Stderr
+	cmd.WaitDelay = execStdioWaitDelay
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to exec in container: %w", err)
+	}
+
+	if opts.Started != nil {
+		select {
+		case opts.Started <- cmd.Process.Pid:
+		default:
+		}
+	}
+
+	return cmd.Wait()
+}
+
 func (r *Runc) Kill(ctx context.Context, containerID string, sig syscall.Signal, opts *KillOpts) error {
 	runcOpts := &runc.KillOpts{}
 	
@@ -206,6 +206,11 @@ func (r *Runsc) Exec(ctx context.Context, containerID string, proc specs.Process
 		cmd.Stderr = opts.OutputWriter
 	}
 
+	if opts.HasStdio() {
+		cmd.Stdin, cmd.Stdout, cmd.Stderr = opts.Stdin, opts.Stdout, opts.Stderr
+		cmd.WaitDelay = execStdioWaitDelay
+	}
+
 	if err := cmd.Start(); err != nil {
 		return fmt.Errorf("failed to exec in container: %w", err)
 	}
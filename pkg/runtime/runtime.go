@@ -2,7 +2,9 @@ package runtime
 
 import (
 	"context"
+	"io"
 	"syscall"
+	"time"
 
 	types "github.com/beam-cloud/beta9/pkg/types"
 	"github.com/opencontainers/runtime-spec/specs-go"
@@ -37,10 +39,22 @@ type RunOpts struct {
 	DockerEnabled bool       // Enable Docker-in-Docker (gVisor only)
 }
 
+// An exec's stdin stays open until its caller closes it, so once the process exits Wait only gives stdio
+// copying this long to finish
+const execStdioWaitDelay = 2 * time.Second
+
 // ExecOpts contains options for executing a command in a container
 type ExecOpts struct {
 	OutputWriter OutputWriter
 	Started      chan<- int
+	Stdin        io.Reader // Optional stdin for the process
+	Stdout       io.Writer // Separates stdout from stderr, takes precedence over OutputWriter
+	Stderr       io.Writer
+}
+
+// HasStdio reports whether the process's stdio is attached to the caller rather than an output writer
+func (o *ExecOpts) HasStdio() bool {
+	return o != nil && (o.Stdin != nil || o.Stdout != nil || o.Stderr != nil)
 }
 
 // KillOpts contains options for killing a container
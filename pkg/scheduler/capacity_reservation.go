@@ -0,0 +1,161 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	repo "github.com/beam-cloud/beta9/pkg/repository"
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+// StartCapacityReservations adds workers to pools that don't have the free GPUs of the capacity reservations
+// active in them, or starting within the lead time. Idle workers spin down like any other, so pools are topped up
+// again for as long as a reservation lasts.
+func (s *Scheduler) StartCapacityReservations() {
+	config := s.config.Worker.CapacityReservations
+	if config.Interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			s.provisionReservedCapacity()
+		}
+	}
+}
+
+func (s *Scheduler) provisionReservedCapacity() {
+	reservations, err := repo.LoadCapacityReservations(s.ctx, s.workspaceRepo, s.backendRepo)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to load capacity reservations")
+		return
+	}
+
+	if len(reservations) == 0 {
+		return
+	}
+
+	workers, err := s.workerRepo.GetAllWorkers()
+	if err != nil {
+		log.Error().Err(err).Msg("failed to get workers for capacity reservations")
+		return
+	}
+
+	pools := map[string]bool{}
+	for _, reservation := range reservations {
+		pools[reservation.PoolName] = true
+	}
+
+	containers := map[string][]types.ContainerState{}
+	for _, worker := range workers {
+		if !pools[worker.PoolName] {
+			continue
+		}
+
+		workerContainers, err := s.containerRepo.GetActiveContainersByWorkerId(worker.Id)
+		if err != nil {
+			continue
+		}
+		containers[worker.Id] = workerContainers
+	}
+
+	deficits := reservedGpuDeficits(reservations, workers, containers, time.Now(), s.config.Worker.CapacityReservations.LeadTime)
+	for poolName, deficit := range deficits {
+		s.addReservedWorkers(poolName, deficit)
+	}
+}
+
+// addReservedWorkers adds enough of the pool's default sized workers to make up gpuCount GPUs. Pool sizers and
+// the scheduler share a lock per pool, so only one gateway adds them.
+func (s *Scheduler) addReservedWorkers(poolName string, gpuCount uint32) {
+	pool, ok := s.workerPoolManager.GetPool(poolName)
+	if !ok {
+		log.Warn().Str("pool_name", poolName).Msg("capacity reserved in unknown pool")
+		return
+	}
+
+	if err := s.workerPoolRepo.SetWorkerPoolSizerLock(poolName); err != nil {
+		return
+	}
+	defer s.workerPoolRepo.RemoveWorkerPoolSizerLock(poolName)
+
+	sizing, err := parsePoolSizingConfig(pool.Config.PoolSizing)
+	if err != nil {
+		log.Error().Str("pool_name", poolName).Err(err).Msg("failed to parse pool sizing config")
+		return
+	}
+
+	workerGpuCount := max(sizing.DefaultWorkerGpuCount, 1)
+	for added := uint32(0); added < gpuCount; added += workerGpuCount {
+		worker, err := pool.Controller.AddWorker(sizing.DefaultWorkerCpu, sizing.DefaultWorkerMemory, workerGpuCount)
+		if err != nil {
+			log.Error().Str("pool_name", poolName).Err(err).Msg("failed to add worker for capacity reservation")
+			return
+		}
+
+		log.Info().Str("pool_name", poolName).Str("worker_id", worker.Id).Msg("added new worker for capacity reservation")
+	}
+}
+
+// reservedGpuDeficits returns the GPUs each pool is short of for the reservations active at now or starting within
+// leadTime, once the GPUs the reserving workspaces already use there and the pool's free GPUs are taken into
+// account. Pending workers count as free, so workers being added aren't asked for again.
+func reservedGpuDeficits(reservations []types.CapacityReservation, workers []*types.Worker, containers map[string][]types.ContainerState, now time.Time, leadTime time.Duration) map[string]uint32 {
+	reserved := map[string]map[string]uint32{}
+	for _, reservation := range reservations {
+		if !reservation.StartsAt.Before(now.Add(leadTime)) || !reservation.EndsAt.After(now) {
+			continue
+		}
+
+		if reserved[reservation.PoolName] == nil {
+			reserved[reservation.PoolName] = map[string]uint32{}
+		}
+		reserved[reservation.PoolName][reservation.WorkspaceId] += reservation.GpuCount
+	}
+
+	free := map[string]uint32{}
+	used := map[string]map[string]uint32{}
+	for _, worker := range workers {
+		if reserved[worker.PoolName] == nil {
+			continue
+		}
+
+		if !worker.Cordoned() {
+			free[worker.PoolName] += worker.FreeGpuCount
+		}
+
+		for _, container := range containers[worker.Id] {
+			if container.Status == types.ContainerStatusStopping {
+				continue
+			}
+
+			if used[worker.PoolName] == nil {
+				used[worker.PoolName] = map[string]uint32{}
+			}
+			used[worker.PoolName][container.WorkspaceId] += container.GpuCount
+		}
+	}
+
+	deficits := map[string]uint32{}
+	for pool, workspaces := range reserved {
+		var held uint32
+		for workspaceId, gpus := range workspaces {
+			if inUse := used[pool][workspaceId]; inUse < gpus {
+				held += gpus - inUse
+			}
+		}
+
+		if held > free[pool] {
+			deficits[pool] = held - free[pool]
+		}
+	}
+
+	return deficits
+}
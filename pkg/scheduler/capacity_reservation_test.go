@@ -0,0 +1,51 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+func TestCapacityReservations(t *testing.T) {
+	now := time.Now()
+	window := func(start, end time.Duration) (types.Time, types.Time) {
+		return types.Time{Time: now.Add(start)}, types.Time{Time: now.Add(end)}
+	}
+
+	active := types.CapacityReservation{WorkspaceId: "ws-b", PoolName: "h100", GpuCount: 4}
+	active.StartsAt, active.EndsAt = window(-time.Hour, time.Hour)
+	upcoming := types.CapacityReservation{WorkspaceId: "ws-c", PoolName: "h100", GpuCount: 2}
+	upcoming.StartsAt, upcoming.EndsAt = window(10*time.Minute, time.Hour)
+	later := types.CapacityReservation{WorkspaceId: "ws-c", PoolName: "a100", GpuCount: 8}
+	later.StartsAt, later.EndsAt = window(2*time.Hour, 3*time.Hour)
+	reservations := []types.CapacityReservation{active, upcoming, later}
+
+	workers := []*types.Worker{
+		{Id: "w1", PoolName: "h100", Gpu: "H100", FreeGpuCount: 1},
+		{Id: "w2", PoolName: "h100", Gpu: "H100", FreeGpuCount: 0},
+	}
+	containers := map[string][]types.ContainerState{
+		"w2": {
+			{ContainerId: "a", WorkspaceId: "ws-b", GpuCount: 1, Status: types.ContainerStatusRunning},
+			{ContainerId: "b", WorkspaceId: "ws-a", GpuCount: 1, Status: types.ContainerStatusRunning},
+		},
+	}
+
+	// Reservations held for the pool's workspaces only count what they don't already use
+	assert.Equal(t, map[string]uint32{"h100": 4}, reservedGpuDeficits(reservations, workers, containers, now, 15*time.Minute))
+	assert.Equal(t, map[string]uint32{"h100": 2}, reservedGpuDeficits(reservations, workers, containers, now, 0))
+
+	// Only active reservations hold GPUs from other workspaces, on top of their quotas
+	quotas := withCapacityReservations([]types.PoolQuota{{WorkspaceId: "ws-b", PoolName: "h100", MaxGpus: 8, ReservedGpus: 1}}, reservations, now)
+	assert.Equal(t, []types.PoolQuota{{WorkspaceId: "ws-b", PoolName: "h100", MaxGpus: 8, ReservedGpus: 5}}, quotas)
+
+	pq := newPoolQuotas("ws-a", quotas, workers, containers)
+	assert.Equal(t, uint32(4), pq.held["h100"])
+	assert.False(t, pq.allowsReservedGpus("h100", 1))
+
+	pq = newPoolQuotas("ws-b", quotas, workers, containers)
+	assert.True(t, pq.allowsReservedGpus("h100", 1))
+}
@@ -0,0 +1,264 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/beam-cloud/beta9/pkg/metrics"
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+const gangRequeueInterval = 5 * time.Second
+
+// RunGang runs the requests as one gang, a job spanning several workers, with requests[i] as rank i. None of
+// the gang's containers are started until all of them can be placed at the same time, each on a worker of its
+// own. Gangs that can't be placed within the timeout fail as a whole.
+func (s *Scheduler) RunGang(requests []*types.ContainerRequest, timeout time.Duration) error {
+	if len(requests) == 0 {
+		return errors.New("gang has no containers")
+	}
+
+	if maxNodes := s.config.Worker.GangScheduling.MaxNodes; maxNodes > 0 && len(requests) > maxNodes {
+		return fmt.Errorf("gang of %d containers is over the limit of %d", len(requests), maxNodes)
+	}
+
+	if timeout <= 0 {
+		timeout = s.config.Worker.GangScheduling.Timeout
+	}
+	if timeout <= 0 {
+		timeout = types.DefaultGangTimeout
+	}
+
+	gangId := uuid.New().String()
+	containerIds := make([]string, len(requests))
+	for i, request := range requests {
+		containerIds[i] = request.ContainerId
+	}
+
+	log.Info().Str("gang_id", gangId).Strs("container_ids", containerIds).Msg("received gang run request")
+
+	now := time.Now()
+	for i, request := range requests {
+		if request.SharesGpu() {
+			return errors.New("containers of a gang can't share GPUs")
+		}
+
+		if request.RequiresGPU() && request.GpuCount <= 0 {
+			request.GpuCount = 1
+		}
+
+		request.Timestamp = now
+		request.Gang = &types.GangRequest{
+			Id:           gangId,
+			Rank:         uint32(i),
+			ContainerIds: containerIds,
+			Timeout:      timeout,
+		}
+	}
+
+	// The whole gang has to fit in the workspace's limits, so the containers admitted before one that doesn't
+	// are let go
+	for i, request := range requests {
+		if err := s.admitRequest(request); err != nil {
+			for _, admitted := range requests[:i] {
+				s.containerRepo.DeleteContainerState(admitted.ContainerId)
+			}
+			return err
+		}
+	}
+
+	leader := requests[0]
+	leader.Gang.Members = requests[1:]
+	return s.addRequestToBacklog(leader)
+}
+
+// processGang places all of a gang's containers at once. If some of them don't fit, pools are asked for the
+// workers that are missing and the gang waits for them in the backlog.
+func (s *Scheduler) processGang(leader *types.ContainerRequest) {
+	requests := leader.Gang.Requests(leader)
+
+	if time.Since(leader.Timestamp) >= leader.Gang.Timeout {
+		s.failGang(requests, fmt.Sprintf("not placed within %s", leader.Gang.Timeout))
+		return
+	}
+
+	placements, unplaced := s.placeGang(requests)
+	if len(unplaced) > 0 {
+		log.Info().Str("gang_id", leader.Gang.Id).Int("unplaced", len(unplaced)).Msg("gang waiting for workers")
+		s.addGangWorkers(unplaced)
+		s.requeueGang(leader)
+		return
+	}
+
+	if err := s.scheduleGang(placements); err != nil {
+		log.Warn().Str("gang_id", leader.Gang.Id).Err(err).Msg("unable to schedule gang")
+		s.requeueGang(leader)
+		return
+	}
+
+	metrics.RecordRequestSchedulingDuration(time.Since(leader.Timestamp), leader)
+}
+
+type gangPlacement struct {
+	worker  *types.Worker
+	request *types.ContainerRequest
+}
+
+// placeGang picks a worker of its own for each of the gang's containers. It returns the containers no worker
+// was left for.
+func (s *Scheduler) placeGang(requests []*types.ContainerRequest) ([]gangPlacement, []*types.ContainerRequest) {
+	workers, err := s.workerRepo.GetAllWorkers()
+	if err != nil {
+		return nil, requests
+	}
+
+	used := map[string]bool{}
+	placements := []gangPlacement{}
+	unplaced := []*types.ContainerRequest{}
+	for _, request := range requests {
+		// Filters adjust the workers they keep, so each container is placed on copies of them
+		candidates := []*types.Worker{}
+		for _, worker := range workers {
+			if !used[worker.Id] {
+				candidate := *worker
+				candidates = append(candidates, &candidate)
+			}
+		}
+
+		spreadReplicas := s.stubReplicasBySpreadDomain(request, workers)
+		for _, filter := range s.workerFilters(request, workers, spreadReplicas) {
+			candidates = filter.apply(candidates)
+		}
+
+		if len(candidates) == 0 {
+			unplaced = append(unplaced, request)
+			continue
+		}
+
+		worker := s.bestWorker(request, candidates, spreadReplicas)
+		used[worker.Id] = true
+		placements = append(placements, gangPlacement{worker: worker, request: request})
+	}
+
+	return placements, unplaced
+}
+
+// scheduleGang takes the capacity of every container on its worker before any of them is queued, so if a worker
+// was taken since the gang was placed, none of the gang starts
+func (s *Scheduler) scheduleGang(placements []gangPlacement) error {
+	reserved := []gangPlacement{}
+	for _, p := range placements {
+		worker, err := s.workerRepo.GetWorkerById(p.worker.Id)
+		if err == nil {
+			p.request.Gpu = worker.Gpu
+			err = s.workerRepo.UpdateWorkerCapacity(worker, p.request, types.RemoveCapacity)
+		}
+		if err != nil {
+			s.releaseGangCapacity(reserved)
+			return err
+		}
+
+		reserved = append(reserved, gangPlacement{worker: worker, request: p.request})
+	}
+
+	for i, p := range reserved {
+		err := s.assignRequest(p.worker, p.request)
+		if err == nil {
+			err = s.workerRepo.PushContainerRequest(p.worker.Id, p.request)
+		}
+		if err != nil {
+			// The containers already queued can't rendezvous without this one, so the gang is stopped
+			log.Error().Str("gang_id", p.request.Gang.Id).Str("container_id", p.request.ContainerId).Err(err).Msg("unable to queue gang container")
+			for _, queued := range reserved[:i] {
+				s.Stop(&types.StopContainerArgs{ContainerId: queued.request.ContainerId, Reason: types.StopContainerReasonScheduler})
+			}
+			s.releaseGangCapacity(reserved[i:])
+			s.failGang(requestsOf(reserved[i:]), "unable to queue gang container")
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// releaseGangCapacity gives back the capacity taken for containers that weren't queued
+func (s *Scheduler) releaseGangCapacity(placements []gangPlacement) {
+	for _, p := range placements {
+		worker, err := s.workerRepo.GetWorkerById(p.worker.Id)
+		if err == nil {
+			err = s.workerRepo.UpdateWorkerCapacity(worker, p.request, types.AddCapacity)
+		}
+		if err != nil {
+			log.Error().Str("worker_id", p.worker.Id).Str("container_id", p.request.ContainerId).Err(err).Msg("failed to release gang capacity")
+		}
+	}
+}
+
+// addGangWorkers asks pools for a worker for each of the gang's containers that didn't fit. The containers
+// aren't given to the new workers, they wait for the whole gang to be placed.
+func (s *Scheduler) addGangWorkers(requests []*types.ContainerRequest) {
+	workers, err := s.workerRepo.GetAllWorkers()
+	if err != nil {
+		return
+	}
+
+	for _, request := range requests {
+		controllers, err := s.getControllers(request)
+		if err != nil {
+			log.Error().Str("container_id", request.ContainerId).Err(err).Msg("no controller found for gang container")
+			continue
+		}
+
+		controllers = filterControllersByPoolQuota(controllers, request, s.loadPoolQuotas(request, workers))
+
+		go func(request *types.ContainerRequest) {
+			for _, c := range controllers {
+				if c == nil {
+					continue
+				}
+
+				worker, err := c.AddWorker(request.Cpu, request.Memory, request.GpuCount)
+				if err == nil {
+					log.Info().Str("worker_id", worker.Id).Str("gang_id", request.Gang.Id).Msg("added new worker for gang")
+					return
+				}
+			}
+
+			log.Error().Str("container_id", request.ContainerId).Str("gang_id", request.Gang.Id).Msg("unable to add worker for gang")
+		}(request)
+	}
+}
+
+// requeueGang puts a gang back in the backlog to be placed again. Gangs have a timeout of their own, so waiting
+// doesn't use up retries.
+func (s *Scheduler) requeueGang(leader *types.ContainerRequest) {
+	go func() {
+		time.Sleep(gangRequeueInterval)
+		if s.requestBacklog.Push(leader) == nil {
+			s.signalNewRequest()
+		}
+	}()
+}
+
+// failGang gives up on the containers of a gang, none of which are running
+func (s *Scheduler) failGang(requests []*types.ContainerRequest, reason string) {
+	log.Error().Str("gang_id", requests[0].Gang.Id).Str("reason", reason).Msg("giving up on gang")
+
+	for _, request := range requests {
+		s.containerRepo.DeleteContainerState(request.ContainerId)
+		s.containerRepo.SetContainerRequestStatus(request.ContainerId, types.ContainerRequestStatusFailed)
+		metrics.RecordRequestScheduleFailure(request)
+	}
+}
+
+func requestsOf(placements []gangPlacement) []*types.ContainerRequest {
+	requests := make([]*types.ContainerRequest, len(placements))
+	for i, p := range placements {
+		requests[i] = p.request
+	}
+	return requests
+}
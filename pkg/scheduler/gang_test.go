@@ -0,0 +1,59 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+func TestPlaceGang(t *testing.T) {
+	wb, err := NewSchedulerForTest()
+	require.NoError(t, err)
+
+	for _, id := range []string{"a10g-1", "a10g-2"} {
+		require.NoError(t, wb.workerRepo.AddWorker(&types.Worker{Id: id, Status: types.WorkerStatusAvailable, PoolName: "beta9-a10g", FreeCpu: 8000, FreeMemory: 8000, Gpu: "A10G", FreeGpuCount: 2}))
+	}
+	require.NoError(t, wb.workerRepo.AddWorker(&types.Worker{Id: "cpu", Status: types.WorkerStatusAvailable, PoolName: "beta9-cpu", FreeCpu: 8000, FreeMemory: 8000}))
+
+	newGang := func(size int) []*types.ContainerRequest {
+		requests := []*types.ContainerRequest{}
+		for i := 0; i < size; i++ {
+			requests = append(requests, &types.ContainerRequest{
+				ContainerId: uuid.New().String(),
+				Cpu:         1000,
+				Memory:      1000,
+				GpuRequest:  []string{"A10G"},
+				GpuCount:    1,
+				Gang:        &types.GangRequest{Id: "gang", Rank: uint32(i)},
+			})
+		}
+		return requests
+	}
+
+	// Each container gets a worker of its own, even though one worker has room for two of them
+	placements, unplaced := wb.placeGang(newGang(2))
+	assert.Empty(t, unplaced)
+	require.Len(t, placements, 2)
+	assert.ElementsMatch(t, []string{"a10g-1", "a10g-2"}, []string{placements[0].worker.Id, placements[1].worker.Id})
+
+	// Capacity is only taken once the whole gang is placed
+	worker, err := wb.workerRepo.GetWorkerById("a10g-1")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), worker.FreeGpuCount)
+
+	require.NoError(t, wb.scheduleGang(placements))
+	for _, id := range []string{"a10g-1", "a10g-2"} {
+		worker, err := wb.workerRepo.GetWorkerById(id)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(1), worker.FreeGpuCount)
+	}
+
+	// The third container has no worker left that the others aren't on
+	placements, unplaced = wb.placeGang(newGang(3))
+	assert.Len(t, placements, 2)
+	assert.Len(t, unplaced, 1)
+}
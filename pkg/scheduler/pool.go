@@ -130,7 +130,7 @@ func freePoolCapacity(workerRepo repository.WorkerRepository, wpc WorkerPoolCont
 
 	for _, worker := range workers {
 		switch worker.Status {
-		case types.WorkerStatusDisabled:
+		case types.WorkerStatusDisabled, types.WorkerStatusDrained:
 			continue
 		case types.WorkerStatusPending:
 			capacity.PendingCpu += worker.FreeCpu
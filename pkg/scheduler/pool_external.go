@@ -47,29 +47,11 @@ type ExternalWorkerPoolController struct {
 }
 
 func NewExternalWorkerPoolController(opts WorkerPoolControllerOptions) (WorkerPoolController, error) {
-	var provider providers.Provider = nil
-	var err error = nil
-
 	workerPoolName := opts.Name
 	providerName := opts.ProviderName
 	opts.Config.Worker.Namespace = externalWorkerNamespace
 
-	switch *providerName {
-	case types.ProviderEC2:
-		provider, err = providers.NewEC2Provider(opts.Context, opts.Config, opts.ProviderRepo, opts.WorkerRepo, opts.Tailscale)
-	case types.ProviderOCI:
-		provider, err = providers.NewOCIProvider(opts.Context, opts.Config, opts.ProviderRepo, opts.WorkerRepo, opts.Tailscale)
-	case types.ProviderLambdaLabs:
-		provider, err = providers.NewLambdaLabsProvider(opts.Context, opts.Config, opts.ProviderRepo, opts.WorkerRepo, opts.Tailscale)
-	case types.ProviderCrusoe:
-		provider, err = providers.NewCrusoeProvider(opts.Context, opts.Config, opts.ProviderRepo, opts.WorkerRepo, opts.Tailscale)
-	case types.ProviderHydra:
-		provider, err = providers.NewHydraProvider(opts.Context, opts.Config, opts.ProviderRepo, opts.WorkerRepo, opts.Tailscale)
-	case types.ProviderGeneric:
-		provider, err = providers.NewGenericProvider(opts.Context, opts.Config, opts.ProviderRepo, opts.WorkerRepo, opts.Tailscale)
-	default:
-		return nil, errors.New("invalid provider name")
-	}
+	provider, err := providers.NewProvider(opts.Context, *providerName, opts.Config, opts.ProviderRepo, opts.WorkerRepo, opts.Tailscale)
 	if err != nil {
 		return nil, err
 	}
@@ -126,7 +108,7 @@ func (wpc *ExternalWorkerPoolController) Context() context.Context {
 }
 
 func (wpc *ExternalWorkerPoolController) IsPreemptable() bool {
-	return wpc.workerPoolConfig.Preemptable
+	return wpc.workerPoolConfig.Preemptable || wpc.workerPoolConfig.Spot
 }
 
 func (wpc *ExternalWorkerPoolController) State() (*types.WorkerPoolState, error) {
@@ -187,6 +169,7 @@ func (wpc *ExternalWorkerPoolController) AddWorker(cpu int64, memory int64, gpuC
 		Memory:   memory,
 		Gpu:      wpc.workerPoolConfig.GPUType,
 		GpuCount: gpuCount,
+		Spot:     wpc.workerPoolConfig.Spot,
 	})
 	if err != nil {
 		return nil, err
@@ -307,6 +290,11 @@ func (wpc *ExternalWorkerPoolController) createWorkerOnMachine(workerId, machine
 	worker.PoolName = wpc.name
 	worker.MachineId = machineId
 	worker.RequiresPoolSelector = wpc.workerPoolConfig.RequiresPoolSelector
+	worker.Labels = types.NewWorkerLabels(wpc.workerPoolConfig.Labels)
+	worker.Region = wpc.workerPoolConfig.Region
+	worker.Arch = wpc.workerPoolConfig.Arch
+	worker.TotalMigSlices = types.MigSlicesForGpus(wpc.workerPoolConfig.MigSlices, worker.TotalGpuCount)
+	worker.FreeMigSlices = worker.TotalMigSlices
 
 	// Create the job in the cluster
 	_, err = client.BatchV1().Jobs(wpc.config.Worker.Namespace).Create(wpc.ctx, job, metav1.CreateOptions{})
@@ -430,7 +418,7 @@ func (wpc *ExternalWorkerPoolController) createWorkerJob(workerId, machineId str
 		Priority:      wpc.workerPoolConfig.Priority,
 		Runtime:       wpc.workerPoolConfig.ContainerRuntime,
 		BuildVersion:  wpc.config.Worker.ImageTag,
-		Preemptable:   wpc.workerPoolConfig.Preemptable,
+		Preemptable:   wpc.IsPreemptable(),
 	}, nil
 }
 
@@ -513,7 +501,7 @@ func (wpc *ExternalWorkerPoolController) getWorkerEnvironment(workerId, machineI
 		},
 		{
 			Name:  "PREEMPTABLE",
-			Value: strconv.FormatBool(wpc.workerPoolConfig.Preemptable),
+			Value: strconv.FormatBool(wpc.IsPreemptable()),
 		},
 	}
 
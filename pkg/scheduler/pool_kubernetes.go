@@ -0,0 +1,84 @@
+package scheduler
+
+import (
+	"errors"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+const defaultGpuResourceName string = "nvidia.com/gpu"
+
+// NewKubernetesWorkerPoolController runs the pool's containers as pods in an existing cluster, instead of on
+// machines of its own. Every container gets a worker pod sized to its request, with GPUs allocated by the cluster's
+// device plugin. The worker in the pod runs the container, reports its status and logs like any other worker, and
+// exits with it so the cluster gets the resources back.
+func NewKubernetesWorkerPoolController(opts WorkerPoolControllerOptions) (WorkerPoolController, error) {
+	poolConfig := opts.Config.Worker.Pools[opts.Name].Kubernetes
+
+	kubeConfig, err := kubernetesPoolRestConfig(poolConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if poolConfig.Namespace != "" {
+		opts.Config.Worker.Namespace = poolConfig.Namespace
+	}
+
+	return newLocalKubernetesWorkerPoolController(opts, kubeClient), nil
+}
+
+func kubernetesPoolRestConfig(config types.KubernetesPoolConfig) (*rest.Config, error) {
+	if config.Host == "" {
+		return rest.InClusterConfig()
+	}
+
+	if config.Token == "" {
+		return nil, errors.New("kubernetes pool has a host but no token")
+	}
+
+	return &rest.Config{
+		Host:        config.Host,
+		BearerToken: config.Token,
+		TLSClientConfig: rest.TLSClientConfig{
+			CAData: []byte(config.CACert),
+		},
+	}, nil
+}
+
+// podPerContainer reports whether each of the pool's workers only ever runs the container it was created for
+func (wpc *LocalKubernetesWorkerPoolController) podPerContainer() bool {
+	return wpc.workerPoolConfig.Mode == types.PoolModeKubernetes
+}
+
+// inRemoteCluster reports whether the pool's workers run in a cluster other than the gateway's, where the
+// gateway's service hostname doesn't resolve
+func (wpc *LocalKubernetesWorkerPoolController) inRemoteCluster() bool {
+	return wpc.podPerContainer() && wpc.workerPoolConfig.Kubernetes.Host != ""
+}
+
+func (wpc *LocalKubernetesWorkerPoolController) gpuResourceName() string {
+	if wpc.workerPoolConfig.Kubernetes.GPUResourceName != "" {
+		return wpc.workerPoolConfig.Kubernetes.GPUResourceName
+	}
+
+	return defaultGpuResourceName
+}
+
+// configurePodPerContainerJob makes the job run its pod once. The pod lives as long as its container does, so it
+// isn't given a deadline, and it isn't restarted since the scheduler retries failed containers on a new pod.
+func configurePodPerContainerJob(job *batchv1.Job) {
+	job.Spec.ActiveDeadlineSeconds = nil
+	job.Spec.BackoffLimit = ptr.To(int32(0))
+	job.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyNever
+}
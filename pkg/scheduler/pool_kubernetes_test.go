@@ -0,0 +1,62 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+func TestCreateWorkerJobPodPerContainer(t *testing.T) {
+	config := types.AppConfig{}
+	config.Worker.DefaultWorkerCPURequest = 2000
+	config.Worker.DefaultWorkerMemoryRequest = 4096
+	config.Worker.UseGatewayServiceHostname = true
+	config.GatewayService.Host = "gateway.beta9"
+	config.GatewayService.GRPC.ExternalHost = "gateway.example.com"
+
+	wpc := &LocalKubernetesWorkerPoolController{
+		name:   "k8s-a10g",
+		config: config,
+		workerPoolConfig: types.WorkerPoolConfig{
+			Mode:    types.PoolModeKubernetes,
+			GPUType: "A10G",
+			Kubernetes: types.KubernetesPoolConfig{
+				Host:            "https://10.0.0.1:6443",
+				GPUResourceName: "nvidia.com/gpu.shared",
+			},
+		},
+	}
+
+	job, worker := wpc.createWorkerJob("abc", 500, 1024, "A10G", 1, "token")
+
+	// The pod is sized to the container, not to the default worker size
+	assert.Equal(t, int64(500), worker.TotalCpu)
+	assert.Equal(t, int64(1024), worker.TotalMemory)
+
+	resources := job.Spec.Template.Spec.Containers[0].Resources
+	assert.Equal(t, "500m", resources.Limits.Cpu().String())
+	assert.Equal(t, "1Gi", resources.Limits.Memory().String())
+	assert.Equal(t, int64(1), resources.Limits.Name("nvidia.com/gpu.shared", resource.DecimalSI).Value())
+
+	// The pod runs once, for as long as its container does
+	assert.Equal(t, corev1.RestartPolicyNever, job.Spec.Template.Spec.RestartPolicy)
+	assert.Nil(t, job.Spec.ActiveDeadlineSeconds)
+	assert.Equal(t, int32(0), *job.Spec.BackoffLimit)
+
+	// Workers in another cluster reach the gateway by its external host
+	env := map[string]string{}
+	for _, envVar := range job.Spec.Template.Spec.Containers[0].Env {
+		env[envVar.Name] = envVar.Value
+	}
+	assert.Equal(t, "gateway.example.com", env["BETA9_GATEWAY_HOST"])
+
+	// Local pools still size workers to at least the default
+	wpc.workerPoolConfig.Mode = types.PoolModeLocal
+	job, worker = wpc.createWorkerJob("def", 500, 1024, "A10G", 1, "token")
+	assert.Equal(t, int64(2000), worker.TotalCpu)
+	assert.Equal(t, corev1.RestartPolicyOnFailure, job.Spec.Template.Spec.RestartPolicy)
+}
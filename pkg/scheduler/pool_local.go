@@ -47,6 +47,10 @@ func NewLocalKubernetesWorkerPoolController(opts WorkerPoolControllerOptions) (W
 		return nil, err
 	}
 
+	return newLocalKubernetesWorkerPoolController(opts, kubeClient), nil
+}
+
+func newLocalKubernetesWorkerPoolController(opts WorkerPoolControllerOptions, kubeClient *kubernetes.Clientset) *LocalKubernetesWorkerPoolController {
 	workerPoolName := opts.Name
 	workerPoolConfig := opts.Config.Worker.Pools[workerPoolName]
 	wpc := &LocalKubernetesWorkerPoolController{
@@ -63,7 +67,7 @@ func NewLocalKubernetesWorkerPoolController(opts WorkerPoolControllerOptions) (W
 	}
 
 	// Start monitoring worker pool size
-	err = MonitorPoolSize(wpc, &workerPoolConfig, wpc.workerRepo, wpc.workerPoolRepo, opts.ProviderRepo)
+	err := MonitorPoolSize(wpc, &workerPoolConfig, wpc.workerRepo, wpc.workerPoolRepo, opts.ProviderRepo)
 	if err != nil {
 		log.Error().Str("pool_name", wpc.name).Err(err).Msg("unable to monitor pool size")
 	}
@@ -85,7 +89,7 @@ func NewLocalKubernetesWorkerPoolController(opts WorkerPoolControllerOptions) (W
 
 	go wpc.monitorAndCleanupWorkers()
 
-	return wpc, nil
+	return wpc
 }
 
 func (wpc *LocalKubernetesWorkerPoolController) Context() context.Context {
@@ -93,7 +97,7 @@ func (wpc *LocalKubernetesWorkerPoolController) Context() context.Context {
 }
 
 func (wpc *LocalKubernetesWorkerPoolController) IsPreemptable() bool {
-	return wpc.workerPoolConfig.Preemptable
+	return wpc.workerPoolConfig.Preemptable || wpc.workerPoolConfig.Spot
 }
 
 func (wpc *LocalKubernetesWorkerPoolController) Name() string {
@@ -154,6 +158,11 @@ func (wpc *LocalKubernetesWorkerPoolController) addWorkerWithId(workerId string,
 
 	worker.PoolName = wpc.name
 	worker.RequiresPoolSelector = wpc.workerPoolConfig.RequiresPoolSelector
+	worker.Labels = types.NewWorkerLabels(wpc.workerPoolConfig.Labels)
+	worker.Region = wpc.workerPoolConfig.Region
+	worker.Arch = wpc.workerPoolConfig.Arch
+	worker.TotalMigSlices = types.MigSlicesForGpus(wpc.workerPoolConfig.MigSlices, worker.TotalGpuCount)
+	worker.FreeMigSlices = worker.TotalMigSlices
 
 	// Add the worker state
 	if err := wpc.workerRepo.AddWorker(worker); err != nil {
@@ -181,8 +190,9 @@ func (wpc *LocalKubernetesWorkerPoolController) createWorkerJob(workerId string,
 	workerGpuCount := gpuCount
 
 	resourceRequests := corev1.ResourceList{}
+	podPerContainer := wpc.podPerContainer()
 
-	if cpu > 0 && cpu > wpc.config.Worker.DefaultWorkerCPURequest {
+	if cpu > 0 && (cpu > wpc.config.Worker.DefaultWorkerCPURequest || podPerContainer) {
 		cpuString := fmt.Sprintf("%dm", cpu) // convert cpu to millicores string
 		resourceRequests[corev1.ResourceCPU] = resource.MustParse(cpuString)
 	} else {
@@ -190,7 +200,7 @@ func (wpc *LocalKubernetesWorkerPoolController) createWorkerJob(workerId string,
 		workerCpu = wpc.config.Worker.DefaultWorkerCPURequest
 	}
 
-	if memory > 0 && memory > wpc.config.Worker.DefaultWorkerMemoryRequest {
+	if memory > 0 && (memory > wpc.config.Worker.DefaultWorkerMemoryRequest || podPerContainer) {
 		memoryString := fmt.Sprintf("%dMi", memory) // convert memory to Mi string
 		resourceRequests[corev1.ResourceMemory] = resource.MustParse(memoryString)
 	} else {
@@ -200,7 +210,7 @@ func (wpc *LocalKubernetesWorkerPoolController) createWorkerJob(workerId string,
 
 	// We only support nvidia for now
 	if gpuType != "" {
-		resourceRequests[corev1.ResourceName("nvidia.com/gpu")] = *resource.NewQuantity(int64(gpuCount), resource.DecimalSI)
+		resourceRequests[corev1.ResourceName(wpc.gpuResourceName())] = *resource.NewQuantity(int64(gpuCount), resource.DecimalSI)
 	}
 
 	workerImage := fmt.Sprintf("%s/%s:%s",
@@ -209,8 +219,9 @@ func (wpc *LocalKubernetesWorkerPoolController) createWorkerJob(workerId string,
 		wpc.config.Worker.ImageTag,
 	)
 
+	// Pods that run a single container are always sized to it, so the cluster's scheduler places them
 	resources := corev1.ResourceRequirements{}
-	if wpc.config.Worker.JobResourcesEnforced {
+	if wpc.config.Worker.JobResourcesEnforced || podPerContainer {
 		resources.Requests = resourceRequests
 		resources.Limits = resourceRequests
 	}
@@ -268,6 +279,15 @@ func (wpc *LocalKubernetesWorkerPoolController) createWorkerJob(workerId string,
 		podTemplate.Spec.RuntimeClassName = ptr.To(wpc.workerPoolConfig.Runtime)
 	}
 
+	// Workers of an arm64 pool have to land on arm64 nodes, since they report the architecture they run on
+	if wpc.workerPoolConfig.Arch != "" {
+		nodeSelector := map[string]string{corev1.LabelArchStable: wpc.workerPoolConfig.Arch}
+		for key, value := range wpc.workerPoolConfig.JobSpec.NodeSelector {
+			nodeSelector[key] = value
+		}
+		podTemplate.Spec.NodeSelector = nodeSelector
+	}
+
 	ttl := int32(30)
 	activeDeadline := int64(7200) // 2 hours max worker lifetime to prevent accumulation
 	job := &batchv1.Job{
@@ -283,6 +303,10 @@ func (wpc *LocalKubernetesWorkerPoolController) createWorkerJob(workerId string,
 		},
 	}
 
+	if podPerContainer {
+		configurePodPerContainerJob(job)
+	}
+
 	return job, &types.Worker{
 		Id:            workerId,
 		FreeCpu:       workerCpu,
@@ -296,7 +320,7 @@ func (wpc *LocalKubernetesWorkerPoolController) createWorkerJob(workerId string,
 		Priority:      wpc.workerPoolConfig.Priority,
 		Runtime:       wpc.workerPoolConfig.ContainerRuntime,
 		BuildVersion:  wpc.config.Worker.ImageTag,
-		Preemptable:   wpc.workerPoolConfig.Preemptable,
+		Preemptable:   wpc.IsPreemptable(),
 	}
 }
 
@@ -485,11 +509,11 @@ func (wpc *LocalKubernetesWorkerPoolController) getWorkerEnvironment(workerId st
 		},
 		{
 			Name:  "PREEMPTABLE",
-			Value: strconv.FormatBool(wpc.workerPoolConfig.Preemptable),
+			Value: strconv.FormatBool(wpc.IsPreemptable()),
 		},
 	}
 
-	if wpc.config.Worker.UseGatewayServiceHostname {
+	if wpc.config.Worker.UseGatewayServiceHostname && !wpc.inRemoteCluster() {
 		envVars = append(envVars, []corev1.EnvVar{
 			{
 				Name:  "BETA9_GATEWAY_HOST",
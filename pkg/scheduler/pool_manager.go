@@ -15,6 +15,23 @@ type WorkerPool struct {
 	Controller WorkerPoolController
 }
 
+// label looks up a label the pool's workers start with. Machine labels aren't known until a worker is added.
+func (p *WorkerPool) label(key string) (string, bool) {
+	switch key {
+	case types.WorkerLabelPool:
+		return p.Name, true
+	case types.WorkerLabelGpu:
+		return p.Config.GPUType, p.Config.GPUType != ""
+	case types.WorkerLabelMachine:
+		return "", false
+	case types.WorkerLabelRegion:
+		return p.Config.Region, p.Config.Region != ""
+	}
+
+	value, ok := p.Config.Labels[key]
+	return value, ok
+}
+
 // WorkerPoolManager manages a collection of WorkerPools using a thread-safe SafeMap.
 // It provides additional functionality to filter and retrieve pools based on specific criteria, such as GPU type.
 type WorkerPoolManager struct {
@@ -0,0 +1,264 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	repo "github.com/beam-cloud/beta9/pkg/repository"
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+// poolQuotas is what a workspace's containers already use of the pools it has quotas for, along with the GPUs
+// other workspaces have reserved in each pool and aren't using yet
+type poolQuotas struct {
+	workspaceId string
+	quotas      map[string]types.PoolQuota // The workspace's quotas by pool name
+	gpus        map[string]uint32          // GPUs the workspace uses in each pool
+	machines    map[string]map[string]bool // Workers running the workspace's containers in each pool
+	held        map[string]uint32          // GPUs held for other workspaces' reservations in each pool
+	freeGpus    map[string]uint32          // Free GPUs across each pool's workers
+}
+
+func newPoolQuotas(workspaceId string, quotas []types.PoolQuota, workers []*types.Worker, containers map[string][]types.ContainerState) *poolQuotas {
+	pq := &poolQuotas{
+		workspaceId: workspaceId,
+		quotas:      map[string]types.PoolQuota{},
+		gpus:        map[string]uint32{},
+		machines:    map[string]map[string]bool{},
+		held:        map[string]uint32{},
+		freeGpus:    map[string]uint32{},
+	}
+
+	reserved := map[string]map[string]uint32{}
+	for _, quota := range quotas {
+		if quota.WorkspaceId == workspaceId {
+			pq.quotas[quota.PoolName] = quota
+			continue
+		}
+
+		if quota.ReservedGpus > 0 {
+			if reserved[quota.PoolName] == nil {
+				reserved[quota.PoolName] = map[string]uint32{}
+			}
+			reserved[quota.PoolName][quota.WorkspaceId] = quota.ReservedGpus
+		}
+	}
+
+	used := map[string]map[string]uint32{}
+	for _, worker := range workers {
+		if !worker.Cordoned() {
+			pq.freeGpus[worker.PoolName] += worker.FreeGpuCount
+		}
+
+		for _, container := range containers[worker.Id] {
+			// Stopping containers are on their way out, the same as for concurrency limits
+			if container.Status == types.ContainerStatusStopping {
+				continue
+			}
+
+			if container.WorkspaceId == workspaceId {
+				pq.gpus[worker.PoolName] += container.GpuCount
+				if pq.machines[worker.PoolName] == nil {
+					pq.machines[worker.PoolName] = map[string]bool{}
+				}
+				pq.machines[worker.PoolName][worker.Id] = true
+				continue
+			}
+
+			if used[worker.PoolName] == nil {
+				used[worker.PoolName] = map[string]uint32{}
+			}
+			used[worker.PoolName][container.WorkspaceId] += container.GpuCount
+		}
+	}
+
+	for pool, workspaces := range reserved {
+		for workspaceId, gpus := range workspaces {
+			if inUse := used[pool][workspaceId]; inUse < gpus {
+				pq.held[pool] += gpus - inUse
+			}
+		}
+	}
+
+	return pq
+}
+
+// allowsGpus reports whether the workspace can use gpuCount more GPUs of the pool without going over its quota
+func (pq *poolQuotas) allowsGpus(pool string, gpuCount uint32) bool {
+	quota, ok := pq.quotas[pool]
+	return !ok || quota.MaxGpus == 0 || pq.gpus[pool]+gpuCount <= quota.MaxGpus
+}
+
+// allowsNewMachine reports whether the workspace can run containers on one more of the pool's workers
+func (pq *poolQuotas) allowsNewMachine(pool string) bool {
+	quota, ok := pq.quotas[pool]
+	return !ok || quota.MaxMachines == 0 || uint32(len(pq.machines[pool])) < quota.MaxMachines
+}
+
+// allowsReservedGpus reports whether taking gpuCount of the pool's free GPUs leaves enough for other workspaces'
+// reservations. GPUs within the workspace's own reservation are guaranteed to it.
+func (pq *poolQuotas) allowsReservedGpus(pool string, gpuCount uint32) bool {
+	if gpuCount == 0 || pq.held[pool] == 0 {
+		return true
+	}
+
+	if pq.gpus[pool]+gpuCount <= pq.quotas[pool].ReservedGpus {
+		return true
+	}
+
+	return pq.freeGpus[pool] >= pq.held[pool]+gpuCount
+}
+
+// allowsWorker reports whether the request can be placed on the worker within the workspace's quota for the
+// worker's pool, and without taking GPUs reserved for other workspaces
+func (pq *poolQuotas) allowsWorker(worker *types.Worker, gpuCount uint32) bool {
+	if !pq.allowsGpus(worker.PoolName, gpuCount) || !pq.allowsReservedGpus(worker.PoolName, gpuCount) {
+		return false
+	}
+
+	return pq.machines[worker.PoolName][worker.Id] || pq.allowsNewMachine(worker.PoolName)
+}
+
+func filterWorkersByPoolQuota(workers []*types.Worker, request *types.ContainerRequest, pq *poolQuotas) []*types.Worker {
+	if pq == nil {
+		return workers
+	}
+
+	filteredWorkers := []*types.Worker{}
+	for _, worker := range workers {
+		if pq.allowsWorker(worker, request.GpuCount) {
+			filteredWorkers = append(filteredWorkers, worker)
+		}
+	}
+	return filteredWorkers
+}
+
+// filterControllersByPoolQuota drops the pools the workspace can't run the request on a new worker of
+func filterControllersByPoolQuota(controllers []WorkerPoolController, request *types.ContainerRequest, pq *poolQuotas) []WorkerPoolController {
+	if pq == nil {
+		return controllers
+	}
+
+	filteredControllers := []WorkerPoolController{}
+	for _, controller := range controllers {
+		if pq.allowsGpus(controller.Name(), request.GpuCount) && pq.allowsNewMachine(controller.Name()) {
+			filteredControllers = append(filteredControllers, controller)
+		}
+	}
+	return filteredControllers
+}
+
+// withCapacityReservations adds the GPUs of reservations active at now to the GPUs their workspaces have reserved in
+// each pool, so they're held the same way
+func withCapacityReservations(quotas []types.PoolQuota, reservations []types.CapacityReservation, now time.Time) []types.PoolQuota {
+	merged := make([]types.PoolQuota, len(quotas))
+	copy(merged, quotas)
+
+	for _, reservation := range reservations {
+		if !reservation.Active(now) {
+			continue
+		}
+
+		found := false
+		for i := range merged {
+			if merged[i].WorkspaceId == reservation.WorkspaceId && merged[i].PoolName == reservation.PoolName {
+				merged[i].ReservedGpus += reservation.GpuCount
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			merged = append(merged, types.PoolQuota{
+				WorkspaceId:  reservation.WorkspaceId,
+				PoolName:     reservation.PoolName,
+				ReservedGpus: reservation.GpuCount,
+			})
+		}
+	}
+
+	return merged
+}
+
+// loadPoolQuotas works out what the request's workspace can still use of the pools it has quotas for, including
+// the GPUs of capacity reservations active now. It returns nil if no quota or reservation applies, and also if the
+// quotas can't be loaded, so scheduling isn't held up by them.
+func (s *Scheduler) loadPoolQuotas(request *types.ContainerRequest, workers []*types.Worker) *poolQuotas {
+	quotas, err := repo.LoadPoolQuotas(s.ctx, s.workspaceRepo, s.backendRepo)
+	if err != nil {
+		log.Warn().Err(err).Msg("failed to load pool quotas")
+		return nil
+	}
+
+	if reservations, err := repo.LoadCapacityReservations(s.ctx, s.workspaceRepo, s.backendRepo); err == nil {
+		quotas = withCapacityReservations(quotas, reservations, time.Now())
+	} else {
+		log.Warn().Err(err).Msg("failed to load capacity reservations")
+	}
+
+	pools := map[string]bool{}
+	for _, quota := range quotas {
+		if quota.WorkspaceId == request.WorkspaceId || quota.ReservedGpus > 0 {
+			pools[quota.PoolName] = true
+		}
+	}
+
+	if len(pools) == 0 {
+		return nil
+	}
+
+	containers := map[string][]types.ContainerState{}
+	for _, worker := range workers {
+		if !pools[worker.PoolName] {
+			continue
+		}
+
+		workerContainers, err := s.containerRepo.GetActiveContainersByWorkerId(worker.Id)
+		if err != nil {
+			continue
+		}
+		containers[worker.Id] = workerContainers
+	}
+
+	return newPoolQuotas(request.WorkspaceId, quotas, workers, containers)
+}
+
+// checkPoolQuotas rejects a request when its workspace already uses all the GPUs its quotas allow in every pool
+// that could run it. Requests that fit a quota may still wait for it in the backlog, if other requests of the
+// workspace get there first.
+func (s *Scheduler) checkPoolQuotas(request *types.ContainerRequest) error {
+	if !request.RequiresGPU() {
+		return nil
+	}
+
+	controllers, err := s.getControllers(request)
+	if err != nil {
+		return nil
+	}
+
+	workers, err := s.workerRepo.GetAllWorkers()
+	if err != nil {
+		return nil
+	}
+
+	pq := s.loadPoolQuotas(request, workers)
+	if pq == nil {
+		return nil
+	}
+
+	gpuCount := request.GpuCount
+	if gpuCount == 0 {
+		gpuCount = 1
+	}
+
+	exceeded := []string{}
+	for _, controller := range controllers {
+		if pq.allowsGpus(controller.Name(), gpuCount) {
+			return nil
+		}
+		exceeded = append(exceeded, controller.Name())
+	}
+
+	return &types.PoolQuotaExceededError{Pools: exceeded}
+}
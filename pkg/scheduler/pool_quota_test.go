@@ -0,0 +1,58 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+func TestPoolQuotas(t *testing.T) {
+	workers := []*types.Worker{
+		{Id: "w1", PoolName: "h100", Gpu: "H100", FreeGpuCount: 1},
+		{Id: "w2", PoolName: "h100", Gpu: "H100", FreeGpuCount: 2},
+		{Id: "w3", PoolName: "h100", Gpu: "H100", FreeGpuCount: 2},
+	}
+	containers := map[string][]types.ContainerState{
+		"w1": {
+			{ContainerId: "a", WorkspaceId: "ws-a", GpuCount: 2, Status: types.ContainerStatusRunning},
+			{ContainerId: "b", WorkspaceId: "ws-b", GpuCount: 1, Status: types.ContainerStatusRunning},
+		},
+		"w2": {
+			{ContainerId: "c", WorkspaceId: "ws-a", GpuCount: 1, Status: types.ContainerStatusStopping},
+		},
+	}
+	quotas := []types.PoolQuota{
+		{WorkspaceId: "ws-a", PoolName: "h100", MaxGpus: 4, MaxMachines: 2},
+		{WorkspaceId: "ws-b", PoolName: "h100", ReservedGpus: 3},
+	}
+
+	pq := newPoolQuotas("ws-a", quotas, workers, containers)
+
+	// Stopping containers don't count towards the quota
+	assert.Equal(t, uint32(2), pq.gpus["h100"])
+	assert.True(t, pq.allowsGpus("h100", 2))
+	assert.False(t, pq.allowsGpus("h100", 3))
+
+	// Other workspaces' unused reservations hold back free GPUs
+	assert.Equal(t, uint32(2), pq.held["h100"])
+	assert.Equal(t, uint32(5), pq.freeGpus["h100"])
+	assert.True(t, pq.allowsReservedGpus("h100", 2))
+	assert.False(t, pq.allowsReservedGpus("h100", 4))
+
+	// The workspace can add one more worker to the ones it already runs on
+	request := &types.ContainerRequest{WorkspaceId: "ws-a", GpuCount: 1}
+	assert.Len(t, filterWorkersByPoolQuota(workers, request, pq), 3)
+	pq.machines["h100"]["w2"] = true
+	assert.Equal(t, []*types.Worker{workers[0], workers[1]}, filterWorkersByPoolQuota(workers, request, pq))
+
+	// A workspace's own reservation doesn't hold back GPUs from it
+	pq = newPoolQuotas("ws-b", quotas, workers, containers)
+	assert.Equal(t, uint32(0), pq.held["h100"])
+	assert.True(t, pq.allowsReservedGpus("h100", 2))
+
+	// Pools without quotas or reservations aren't limited
+	assert.True(t, pq.allowsGpus("a100", 8))
+	assert.True(t, pq.allowsNewMachine("a100"))
+}
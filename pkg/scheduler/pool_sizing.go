@@ -147,8 +147,18 @@ func (s *WorkerPoolSizer) occupyAvailableMachines() error {
 }
 
 func (s *WorkerPoolSizer) addWorkerIfNeeded(freeCapacity *WorkerPoolCapacity) (*types.Worker, error) {
+	config := s.workerPoolSizingConfig
+	if config.Predictive {
+		demand, err := s.predictDemand(time.Now().Add(config.PredictionLeadTime))
+		if err != nil {
+			log.Warn().Str("pool_name", s.controller.Name()).Err(err).Msg("failed to predict pool demand, using configured minimums")
+		} else {
+			config = withPredictedDemand(config, demand)
+		}
+	}
+
 	// Check if the free capacity is below the configured minimum and add a worker if needed
-	if !shouldAddWorker(freeCapacity, s.workerPoolSizingConfig) {
+	if !shouldAddWorker(freeCapacity, config) {
 		return nil, nil
 	}
 
@@ -159,6 +169,78 @@ func (s *WorkerPoolSizer) addWorkerIfNeeded(freeCapacity *WorkerPoolCapacity) (*
 	)
 }
 
+// predictDemand predicts the capacity deployments will request in the pool during the hour of at, from what they
+// requested in the same hour on previous days
+func (s *WorkerPoolSizer) predictDemand(at time.Time) (*types.WorkerPoolDemand, error) {
+	history := make([]map[string]*types.WorkerPoolDemand, 0, s.workerPoolSizingConfig.PredictionLookbackDays)
+	for day := 1; day <= s.workerPoolSizingConfig.PredictionLookbackDays; day++ {
+		demand, err := s.workerPoolRepo.GetWorkerPoolDemand(s.controller.Context(), s.controller.Name(), at.Add(-time.Duration(day)*24*time.Hour))
+		if err != nil {
+			return nil, err
+		}
+
+		history = append(history, demand)
+	}
+
+	return predictWorkerPoolDemand(history, s.workerPoolSizingConfig.PredictionMinDays), nil
+}
+
+// predictWorkerPoolDemand averages each deployment's demand over the days in history. Deployments that were busy
+// on fewer than minDays of them are left out, so one-off spikes don't keep capacity around.
+func predictWorkerPoolDemand(history []map[string]*types.WorkerPoolDemand, minDays int) *types.WorkerPoolDemand {
+	prediction := &types.WorkerPoolDemand{}
+	if len(history) == 0 {
+		return prediction
+	}
+
+	totals := map[string]*types.WorkerPoolDemand{}
+	days := map[string]int{}
+	for _, demand := range history {
+		for stubId, d := range demand {
+			if _, ok := totals[stubId]; !ok {
+				totals[stubId] = &types.WorkerPoolDemand{}
+			}
+			totals[stubId].Add(d)
+			days[stubId]++
+		}
+	}
+
+	n := int64(len(history))
+	for stubId, total := range totals {
+		if days[stubId] < minDays {
+			continue
+		}
+
+		prediction.Add(&types.WorkerPoolDemand{
+			Cpu:        total.Cpu / n,
+			Memory:     total.Memory / n,
+			Gpu:        (total.Gpu + n - 1) / n,
+			Containers: total.Containers / n,
+		})
+	}
+
+	return prediction
+}
+
+// withPredictedDemand returns a copy of config whose minimum free capacity covers the predicted demand
+func withPredictedDemand(config *types.WorkerPoolSizingConfig, demand *types.WorkerPoolDemand) *types.WorkerPoolSizingConfig {
+	predicted := *config
+	predicted.MinFreeCpu = max(config.MinFreeCpu, demand.Cpu)
+	predicted.MinFreeMemory = max(config.MinFreeMemory, demand.Memory)
+	predicted.MinFreeGpu = max(config.MinFreeGpu, uint(demand.Gpu))
+	return &predicted
+}
+
+// workerPoolDemandTtl is how long the demand of an hour is kept, so it can be used for the pool's lookback window
+func workerPoolDemandTtl(config types.WorkerPoolPredictiveScalingConfig) time.Duration {
+	days := config.LookbackDays
+	if days <= 0 {
+		days = types.NewWorkerPoolSizingConfig().PredictionLookbackDays
+	}
+
+	return time.Duration(days+1) * 24 * time.Hour
+}
+
 // shouldAddWorker checks if the conditions are met for a new worker to be added
 func shouldAddWorker(freeCapacity *WorkerPoolCapacity, config *types.WorkerPoolSizingConfig) bool {
 	freeCpu := freeCapacity.FreeCpu + freeCapacity.PendingCpu
@@ -204,6 +286,20 @@ func parsePoolSizingConfig(config types.WorkerPoolJobSpecPoolSizingConfig) (*typ
 		c.DefaultWorkerGpuCount = uint32(defaultWorkerGpuCount)
 	}
 
+	c.Predictive = config.Predictive.Enabled
+	if config.Predictive.LookbackDays > 0 {
+		c.PredictionLookbackDays = config.Predictive.LookbackDays
+	}
+
+	if config.Predictive.LeadTime > 0 {
+		c.PredictionLeadTime = config.Predictive.LeadTime
+	}
+
+	if config.Predictive.MinDays > 0 {
+		c.PredictionMinDays = config.Predictive.MinDays
+	}
+	c.PredictionMinDays = min(c.PredictionMinDays, c.PredictionLookbackDays)
+
 	// Don't allow creation of workers with no gpu count if there is a GPU type set
 	if c.DefaultWorkerGpuCount <= 0 && defaultWorkerGpuType != "" {
 		c.DefaultWorkerGpuCount = 1
@@ -475,3 +475,27 @@ func TestOccupyAvailableMachinesConcurrency(t *testing.T) {
 
 	cancel()
 }
+
+func TestPredictWorkerPoolDemand(t *testing.T) {
+	daily := &types.WorkerPoolDemand{Cpu: 4000, Memory: 8192, Gpu: 1, Containers: 2}
+	spike := &types.WorkerPoolDemand{Cpu: 64000, Memory: 131072, Gpu: 8, Containers: 16}
+
+	history := []map[string]*types.WorkerPoolDemand{
+		{"daily": daily, "spike": spike},
+		{"daily": daily},
+		{},
+		{"daily": daily},
+	}
+
+	// The spike was only seen once, and the daily deployment is averaged over every day in the window
+	prediction := predictWorkerPoolDemand(history, 3)
+	assert.Equal(t, &types.WorkerPoolDemand{Cpu: 3000, Memory: 6144, Gpu: 1, Containers: 1}, prediction)
+
+	config := withPredictedDemand(&types.WorkerPoolSizingConfig{MinFreeCpu: 1000, MinFreeMemory: 10000}, prediction)
+	assert.Equal(t, int64(3000), config.MinFreeCpu)
+	assert.Equal(t, int64(10000), config.MinFreeMemory)
+	assert.Equal(t, uint(1), config.MinFreeGpu)
+	assert.True(t, shouldAddWorker(&WorkerPoolCapacity{FreeCpu: 2000, FreeMemory: 10000, FreeGpu: 1}, config))
+
+	assert.Equal(t, &types.WorkerPoolDemand{}, predictWorkerPoolDemand(nil, 3))
+}
@@ -0,0 +1,184 @@
+package scheduler
+
+import (
+	"sort"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+const (
+	defaultPreemptionGracePeriod = 30 * time.Second
+	preemptionRequeueInterval    = 2 * time.Second
+)
+
+// preemptForRequest makes room for a request that doesn't fit on any worker by stopping running containers of a
+// lower priority class. It picks the worker where the lowest classes have to give way, and the fewest containers
+// within them, then stops them with a grace period. It returns whether the request should wait for capacity
+// freed by preemption, which is also the case when earlier preemptions are already freeing enough of it.
+// Requests for a share of a GPU never preempt.
+func (s *Scheduler) preemptForRequest(request *types.ContainerRequest) bool {
+	best := s.bestPreemptionPlan(request)
+	if best == nil {
+		return false
+	}
+
+	gracePeriod := s.config.Worker.Preemption.CheckpointWindow
+	if gracePeriod <= 0 {
+		gracePeriod = defaultPreemptionGracePeriod
+	}
+
+	for _, victim := range best.victims {
+		log.Info().Str("container_id", victim.ContainerId).Str("priority_class", string(victim.PriorityClass)).Str("preempted_by", request.ContainerId).Str("worker_id", best.worker.Id).Msg("preempting container")
+
+		err := s.Stop(&types.StopContainerArgs{
+			ContainerId:  victim.ContainerId,
+			Reason:       types.StopContainerReasonPreempted,
+			GracePeriodS: int64(gracePeriod.Seconds()),
+		})
+		if err != nil {
+			log.Error().Str("container_id", victim.ContainerId).Err(err).Msg("failed to preempt container")
+		}
+	}
+
+	return true
+}
+
+// bestPreemptionPlan finds the cheapest plan for stopping containers to make room for the request. The plan has
+// no victims when earlier preemptions are already freeing enough, and is nil when the request can't preempt.
+func (s *Scheduler) bestPreemptionPlan(request *types.ContainerRequest) *preemptionPlan {
+	if request.PriorityClass.Rank() == types.PriorityClassBatch.Rank() || request.SharesGpu() {
+		return nil
+	}
+
+	workers, err := s.workerRepo.GetAllWorkers()
+	if err != nil {
+		return nil
+	}
+
+	candidates := filterWorkersByPoolSelector(workers, request)
+	candidates = filterWorkersByFlags(candidates, request)
+	candidates = filterWorkersByArch(candidates, request)
+	candidates = filterWorkersByAffinity(candidates, request, s.stubReplicasBySpreadDomain(request, workers))
+	candidates = filterWorkersByPoolQuota(candidates, request, s.loadPoolQuotas(request, workers))
+
+	var best *preemptionPlan
+	for _, worker := range candidates {
+		containers, err := s.containerRepo.GetActiveContainersByWorkerId(worker.Id)
+		if err != nil {
+			continue
+		}
+
+		plan := planPreemption(worker, containers, request)
+		if plan == nil {
+			continue
+		}
+
+		if len(plan.victims) == 0 {
+			return plan
+		}
+
+		if best == nil || plan.cheaperThan(best) {
+			best = plan
+		}
+	}
+
+	return best
+}
+
+// requeuePreemptingRequest puts a request that's waiting for preempted containers to exit back in the backlog.
+// Waiting doesn't use up the request's retries, but requests that have waited too long are retried as usual.
+func (s *Scheduler) requeuePreemptingRequest(request *types.ContainerRequest) {
+	if time.Since(request.Timestamp) >= maxScheduleRetryDuration {
+		s.addRequestToBacklog(request)
+		return
+	}
+
+	go func() {
+		time.Sleep(preemptionRequeueInterval)
+		if s.requestBacklog.Push(request) == nil {
+			s.signalNewRequest()
+		}
+	}()
+}
+
+type preemptionPlan struct {
+	worker  *types.Worker
+	victims []types.ContainerState
+	maxRank int
+}
+
+func (p *preemptionPlan) cheaperThan(other *preemptionPlan) bool {
+	if p.maxRank != other.maxRank {
+		return p.maxRank < other.maxRank
+	}
+	return len(p.victims) < len(other.victims)
+}
+
+// planPreemption finds the running containers of lower priority classes that have to stop for the request to
+// fit on the worker, lowest classes and most recently started containers first. Containers that are already
+// stopping count as freed. It returns nil if the request can't fit even with all of them stopped.
+func planPreemption(worker *types.Worker, containers []types.ContainerState, request *types.ContainerRequest) *preemptionPlan {
+	freed := *worker
+	release := func(c types.ContainerState) {
+		freed.FreeCpu += c.Cpu
+		freed.FreeMemory += c.Memory
+		if freed.Gpu == "" {
+			return
+		}
+
+		// A container on a share of a GPU only frees the GPU once every container sharing it has stopped, and
+		// one on a MIG slice only frees the slice
+		switch {
+		case c.MigProfile != "":
+			freed.ReleaseMigSlice(c.MigProfile)
+		case c.GpuMemoryLimit > 0:
+			freed.ReleaseGpuShare(c.GpuShare, c.GpuMemoryLimit)
+		default:
+			freed.FreeGpuCount += c.GpuCount
+		}
+	}
+
+	candidates := []types.ContainerState{}
+	for _, c := range containers {
+		switch c.Status {
+		case types.ContainerStatusStopping:
+			release(c)
+		case types.ContainerStatusRunning:
+			if c.PriorityClass.Rank() < request.PriorityClass.Rank() {
+				candidates = append(candidates, c)
+			}
+		}
+	}
+
+	fits := func() bool {
+		check := freed
+		return len(filterWorkersByResources([]*types.Worker{&check}, request)) > 0
+	}
+
+	plan := &preemptionPlan{worker: worker}
+	if fits() {
+		return plan
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].PriorityClass.Rank() != candidates[j].PriorityClass.Rank() {
+			return candidates[i].PriorityClass.Rank() < candidates[j].PriorityClass.Rank()
+		}
+		return candidates[i].StartedAt > candidates[j].StartedAt
+	})
+
+	for _, c := range candidates {
+		release(c)
+		plan.victims = append(plan.victims, c)
+		plan.maxRank = max(plan.maxRank, c.PriorityClass.Rank())
+
+		if fits() {
+			return plan
+		}
+	}
+
+	return nil
+}
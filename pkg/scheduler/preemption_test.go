@@ -0,0 +1,129 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+func TestPlanPreemption(t *testing.T) {
+	worker := &types.Worker{Id: "w1", FreeCpu: 1000, FreeMemory: 1024, Gpu: "A10G", FreeGpuCount: 0}
+	containers := []types.ContainerState{
+		{ContainerId: "prod", Status: types.ContainerStatusRunning, PriorityClass: types.PriorityClassProduction, Cpu: 4000, Memory: 8192, GpuCount: 1},
+		{ContainerId: "default", Status: types.ContainerStatusRunning, Cpu: 2000, Memory: 4096, GpuCount: 1, StartedAt: 100},
+		{ContainerId: "batch-old", Status: types.ContainerStatusRunning, PriorityClass: types.PriorityClassBatch, Cpu: 1000, Memory: 2048, GpuCount: 1, StartedAt: 100},
+		{ContainerId: "batch-new", Status: types.ContainerStatusRunning, PriorityClass: types.PriorityClassBatch, Cpu: 1000, Memory: 2048, GpuCount: 1, StartedAt: 200},
+	}
+
+	request := &types.ContainerRequest{Cpu: 2000, Memory: 2048, GpuRequest: []string{"A10G"}, GpuCount: 1, PriorityClass: types.PriorityClassProduction}
+
+	// Batch containers go first, the most recently started one before the other
+	plan := planPreemption(worker, containers, request)
+	require.NotNil(t, plan)
+	assert.Equal(t, []string{"batch-new"}, containerIds(plan.victims))
+	assert.Equal(t, types.PriorityClassBatch.Rank(), plan.maxRank)
+
+	// Default requests can only preempt batch containers, which don't free enough for a bigger request
+	request = &types.ContainerRequest{Cpu: 4000, Memory: 4096, GpuRequest: []string{"A10G"}, GpuCount: 1}
+	assert.Nil(t, planPreemption(worker, containers, request))
+
+	// Containers that are already stopping count as freed, so nothing else has to stop
+	containers[3].Status = types.ContainerStatusStopping
+	request = &types.ContainerRequest{Cpu: 2000, Memory: 2048, GpuRequest: []string{"A10G"}, GpuCount: 1, PriorityClass: types.PriorityClassProduction}
+	plan = planPreemption(worker, containers, request)
+	require.NotNil(t, plan)
+	assert.Empty(t, plan.victims)
+
+	// The worker passed in is left alone
+	assert.Equal(t, int64(1000), worker.FreeCpu)
+	assert.Equal(t, uint32(0), worker.FreeGpuCount)
+}
+
+func TestPlanPreemptionOfGpuShares(t *testing.T) {
+	// Both halves of the worker's only GPU are taken by containers sharing it
+	worker := &types.Worker{Id: "w1", FreeCpu: 8000, FreeMemory: 8192, Gpu: "A10G", GpuShares: types.NewGpuShares([]int64{0})}
+	containers := []types.ContainerState{
+		{ContainerId: "batch-old", Status: types.ContainerStatusRunning, PriorityClass: types.PriorityClassBatch, Cpu: 1000, Memory: 1024, GpuCount: 1, GpuShare: 0, GpuMemoryLimit: 12 * 1024, StartedAt: 100},
+		{ContainerId: "batch-new", Status: types.ContainerStatusRunning, PriorityClass: types.PriorityClassBatch, Cpu: 1000, Memory: 1024, GpuCount: 1, GpuShare: 0, GpuMemoryLimit: 12 * 1024, StartedAt: 200},
+	}
+
+	request := &types.ContainerRequest{Cpu: 1000, Memory: 1024, GpuRequest: []string{"A10G"}, GpuCount: 1, PriorityClass: types.PriorityClassProduction}
+
+	// The GPU is only free once every container sharing it has stopped
+	plan := planPreemption(worker, containers, request)
+	require.NotNil(t, plan)
+	assert.Equal(t, []string{"batch-new", "batch-old"}, containerIds(plan.victims))
+
+	// A share held by a container that can't be preempted keeps the GPU in use
+	containers[0].PriorityClass = types.PriorityClassProduction
+	assert.Nil(t, planPreemption(worker, containers, request))
+
+	assert.Equal(t, uint32(0), worker.FreeGpuCount)
+	assert.Equal(t, []int64{0}, worker.GpuShares.Free())
+}
+
+func TestPlanPreemptionOfMigSlices(t *testing.T) {
+	worker := &types.Worker{
+		Id:             "w1",
+		FreeCpu:        8000,
+		FreeMemory:     8192,
+		Gpu:            "A100-80",
+		TotalMigSlices: types.NewMigSlices(map[string]int{"1g.10gb": 4, "3g.40gb": 1}),
+		FreeMigSlices:  types.NewMigSlices(map[string]int{"1g.10gb": 0, "3g.40gb": 0}),
+	}
+	containers := []types.ContainerState{
+		{ContainerId: "batch-slice", Status: types.ContainerStatusRunning, PriorityClass: types.PriorityClassBatch, Cpu: 1000, Memory: 1024, GpuCount: 1, MigProfile: "1g.10gb"},
+	}
+
+	// A request for a slice of the same profile gets the victim's slice
+	request := &types.ContainerRequest{Cpu: 1000, Memory: 1024, GpuRequest: []string{"A100-80"}, GpuCount: 1, MigProfile: "1g.10gb", PriorityClass: types.PriorityClassProduction}
+	plan := planPreemption(worker, containers, request)
+	require.NotNil(t, plan)
+	assert.Equal(t, []string{"batch-slice"}, containerIds(plan.victims))
+
+	// Freeing a slice of another profile doesn't help
+	request.MigProfile = "3g.40gb"
+	assert.Nil(t, planPreemption(worker, containers, request))
+
+	// Nor does it free a whole GPU
+	request.MigProfile = ""
+	assert.Nil(t, planPreemption(worker, containers, request))
+
+	assert.Equal(t, 0, worker.FreeMigSlices.Count("1g.10gb"))
+	assert.Equal(t, uint32(0), worker.FreeGpuCount)
+}
+
+func TestBestPreemptionPlanMatchesImageArch(t *testing.T) {
+	wb, err := NewSchedulerForTest()
+	require.NoError(t, err)
+
+	armWorker := &types.Worker{Id: uuid.New().String(), Status: types.WorkerStatusAvailable, FreeCpu: 0, FreeMemory: 0, Arch: types.ArchArm64}
+	require.NoError(t, wb.workerRepo.AddWorker(armWorker))
+
+	victim := &types.ContainerState{ContainerId: uuid.New().String(), Status: types.ContainerStatusRunning, PriorityClass: types.PriorityClassBatch, Cpu: 4000, Memory: 4096}
+	require.NoError(t, wb.containerRepo.SetContainerState(victim.ContainerId, victim))
+	require.NoError(t, wb.workerRepo.AddContainerToWorker(armWorker.Id, victim.ContainerId))
+
+	request := &types.ContainerRequest{ContainerId: uuid.New().String(), Cpu: 1000, Memory: 1024, PriorityClass: types.PriorityClassProduction}
+
+	// An amd64 image can't run on the arm64 worker, so stopping its containers doesn't help
+	assert.Nil(t, wb.bestPreemptionPlan(request))
+
+	request.Architectures = []string{types.ArchArm64}
+	plan := wb.bestPreemptionPlan(request)
+	require.NotNil(t, plan)
+	assert.Equal(t, armWorker.Id, plan.worker.Id)
+	assert.Equal(t, []string{victim.ContainerId}, containerIds(plan.victims))
+}
+
+func containerIds(containers []types.ContainerState) []string {
+	ids := []string{}
+	for _, c := range containers {
+		ids = append(ids, c.ContainerId)
+	}
+	return ids
+}
@@ -12,6 +12,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/beam-cloud/beta9/pkg/auth"
 	"github.com/beam-cloud/beta9/pkg/common"
 	"github.com/beam-cloud/beta9/pkg/metrics"
 	"github.com/beam-cloud/beta9/pkg/network"
@@ -30,6 +31,7 @@ type Scheduler struct {
 	config                types.AppConfig
 	backendRepo           repo.BackendRepository
 	workerRepo            repo.WorkerRepository
+	workerPoolRepo        repo.WorkerPoolRepository
 	workerPoolManager     *WorkerPoolManager
 	requestBacklog        *RequestBacklog
 	containerRepo         repo.ContainerRepository
@@ -38,6 +40,9 @@ type Scheduler struct {
 	schedulerUsageMetrics SchedulerUsageMetrics
 	eventBus              *common.EventBus
 	requestSignal         chan struct{}
+	tailscale             *network.Tailscale
+	workerHealthFailures  *common.SafeMap[int] // Health checks each worker has failed in a row
+	workerHealthCheck     func(ctx context.Context, worker *types.Worker) string
 }
 
 func NewScheduler(ctx context.Context, config types.AppConfig, redisClient *common.RedisClient, usageRepo repo.UsageMetricsRepository, backendRepo repo.BackendRepository, workspaceRepo repo.WorkspaceRepository, tailscale *network.Tailscale) (*Scheduler, error) {
@@ -70,6 +75,18 @@ func NewScheduler(ctx context.Context, config types.AppConfig, redisClient *comm
 				ContainerRepo:  containerRepo,
 				EventRepo:      eventRepo,
 			})
+		case types.PoolModeKubernetes:
+			controller, err = NewKubernetesWorkerPoolController(WorkerPoolControllerOptions{
+				Context:        ctx,
+				Name:           name,
+				Config:         config,
+				BackendRepo:    backendRepo,
+				WorkerRepo:     workerRepo,
+				ProviderRepo:   providerRepo,
+				WorkerPoolRepo: workerPoolRepo,
+				ContainerRepo:  containerRepo,
+				EventRepo:      eventRepo,
+			})
 		case types.PoolModeExternal:
 			controller, err = NewExternalWorkerPoolController(WorkerPoolControllerOptions{
 				Context:        ctx,
@@ -98,12 +115,13 @@ func NewScheduler(ctx context.Context, config types.AppConfig, redisClient *comm
 		log.Info().Str("pool_name", name).Str("mode", string(pool.Mode)).Str("gpu_type", pool.GPUType).Msg("loaded controller")
 	}
 
-	return &Scheduler{
+	s := &Scheduler{
 		ctx:                   ctx,
 		config:                config,
 		eventBus:              eventBus,
 		backendRepo:           backendRepo,
 		workerRepo:            workerRepo,
+		workerPoolRepo:        workerPoolRepo,
 		workerPoolManager:     workerPoolManager,
 		requestBacklog:        requestBacklog,
 		containerRepo:         containerRepo,
@@ -111,7 +129,12 @@ func NewScheduler(ctx context.Context, config types.AppConfig, redisClient *comm
 		eventRepo:             eventRepo,
 		workspaceRepo:         workspaceRepo,
 		requestSignal:         make(chan struct{}, 1),
-	}, nil
+		tailscale:             tailscale,
+		workerHealthFailures:  common.NewSafeMap[int](),
+	}
+	s.workerHealthCheck = s.requestWorkerHealth
+
+	return s, nil
 }
 
 func (s *Scheduler) Run(request *types.ContainerRequest) error {
@@ -119,6 +142,15 @@ func (s *Scheduler) Run(request *types.ContainerRequest) error {
 
 	request.Timestamp = time.Now()
 
+	if err := s.admitRequest(request); err != nil {
+		return err
+	}
+
+	return s.addRequestToBacklog(request)
+}
+
+// admitRequest checks the request is within the workspace's limits and records its container as pending
+func (s *Scheduler) admitRequest(request *types.ContainerRequest) error {
 	containerState, err := s.containerRepo.GetContainerState(request.ContainerId)
 	if err == nil {
 		switch types.ContainerStatus(containerState.Status) {
@@ -146,12 +178,12 @@ func (s *Scheduler) Run(request *types.ContainerRequest) error {
 		return err
 	}
 
-	err = s.containerRepo.SetContainerStateWithConcurrencyLimit(quota, request)
+	err = s.checkPoolQuotas(request)
 	if err != nil {
 		return err
 	}
 
-	return s.addRequestToBacklog(request)
+	return s.containerRepo.SetContainerStateWithConcurrencyLimit(quota, request)
 }
 
 func (s *Scheduler) getConcurrencyLimit(request *types.ContainerRequest) (*types.ConcurrencyLimit, error) {
@@ -164,20 +196,28 @@ func (s *Scheduler) getConcurrencyLimit(request *types.ContainerRequest) (*types
 
 	if quota == nil {
 		quota, err = s.backendRepo.GetConcurrencyLimitByWorkspaceId(s.ctx, request.WorkspaceId)
-		if err != nil && err == sql.ErrNoRows {
-			return nil, nil // No quota set for this workspace
-		}
-		if err != nil {
+		if err != nil && err != sql.ErrNoRows {
 			return nil, err
 		}
 
-		err = s.workspaceRepo.SetConcurrencyLimitByWorkspaceId(request.WorkspaceId, quota)
-		if err != nil {
-			return nil, err
+		// No quota set for this workspace
+		if err == sql.ErrNoRows {
+			quota = nil
+		} else {
+			err = s.workspaceRepo.SetConcurrencyLimitByWorkspaceId(request.WorkspaceId, quota)
+			if err != nil {
+				return nil, err
+			}
 		}
 	}
 
-	return quota, nil
+	// The workspace's resource policy can cap concurrent GPUs below its quota
+	policy, err := repo.LoadWorkspacePolicy(s.ctx, s.workspaceRepo, s.backendRepo, request.WorkspaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	return policy.LimitConcurrency(quota), nil
 }
 
 func (s *Scheduler) Stop(stopArgs *types.StopContainerArgs) error {
@@ -209,19 +249,27 @@ func (s *Scheduler) Stop(stopArgs *types.StopContainerArgs) error {
 func (s *Scheduler) getControllers(request *types.ContainerRequest) ([]WorkerPoolController, error) {
 	controllers := []WorkerPoolController{}
 
+	// Pools whose workers would never match the request's required affinity, can't run its image, or don't have
+	// the MIG slice it needs, aren't asked for new workers
+	addPool := func(pool *WorkerPool) {
+		if request.Affinity.Allows(pool.label) && request.SupportsArch(pool.Config.Arch) && request.FitsMigLayout(pool.Config.MigSlices) {
+			controllers = append(controllers, pool.Controller)
+		}
+	}
+
 	if request.PoolSelector != "" {
 		wp, ok := s.workerPoolManager.GetPool(request.PoolSelector)
 		if !ok {
 			return nil, errors.New("no controller found for request")
 		}
-		controllers = append(controllers, wp.Controller)
+		addPool(wp)
 
 	} else if !request.RequiresGPU() {
 		pools := s.workerPoolManager.GetPoolByFilters(poolFilters{
 			GPUType: "",
 		})
 		for _, pool := range pools {
-			controllers = append(controllers, pool.Controller)
+			addPool(pool)
 		}
 	} else {
 		for _, gpu := range request.GpuRequest {
@@ -230,7 +278,7 @@ func (s *Scheduler) getControllers(request *types.ContainerRequest) ([]WorkerPoo
 			})
 
 			for _, pool := range pools {
-				controllers = append(controllers, pool.Controller)
+				addPool(pool)
 			}
 
 			// If the request contains the "any" GPU selector, we've already retrieved all pools
@@ -287,9 +335,20 @@ func (s *Scheduler) processBacklog() {
 			return
 		}
 
+		if request.Gang != nil {
+			s.processGang(request)
+			continue
+		}
+
 		// Find a worker to schedule ContainerRequests on
 		worker, err := s.selectWorker(request)
 		if err != nil || worker == nil {
+			// Stopping lower priority containers frees capacity sooner than adding a worker
+			if s.preemptForRequest(request) {
+				s.requeuePreemptingRequest(request)
+				continue
+			}
+
 			// We didn't find a Worker that fit the ContainerRequest's requirements. Let's find a controller
 			// so we can add a new worker.
 
@@ -299,6 +358,17 @@ func (s *Scheduler) processBacklog() {
 				continue
 			}
 
+			// Pools the workspace is using all it may of can't get new workers for it, so the request waits
+			// for its own containers to free up quota
+			if workers, err := s.workerRepo.GetAllWorkers(); err == nil {
+				controllers = filterControllersByPoolQuota(controllers, request, s.loadPoolQuotas(request, workers))
+				if len(controllers) == 0 {
+					log.Info().Str("container_id", request.ContainerId).Str("workspace_id", request.WorkspaceId).Msg("request waiting for pool quota")
+					s.addRequestToBacklog(request)
+					continue
+				}
+			}
+
 			go func() {
 				var err error
 				for _, c := range controllers {
@@ -345,6 +415,25 @@ func (s *Scheduler) processBacklog() {
 }
 
 func (s *Scheduler) scheduleRequest(worker *types.Worker, request *types.ContainerRequest) error {
+	if err := s.assignRequest(worker, request); err != nil {
+		return err
+	}
+
+	if err := s.workerRepo.ScheduleContainerRequest(worker, request); err != nil {
+		return err
+	}
+
+	if request.SharesGpu() {
+		if err := s.containerRepo.SetContainerGpuShare(request.ContainerId, request.GpuShare, request.GpuMemoryLimit); err != nil {
+			log.Warn().Str("container_id", request.ContainerId).Err(err).Msg("failed to record container gpu share")
+		}
+	}
+
+	return nil
+}
+
+// assignRequest records the worker the request is being scheduled on, before it's queued there
+func (s *Scheduler) assignRequest(worker *types.Worker, request *types.ContainerRequest) error {
 	if err := s.containerRepo.UpdateAssignedContainerGPU(request.ContainerId, worker.Gpu); err != nil {
 		log.Error().Str("container_id", request.ContainerId).Err(err).Msg("failed to update assigned container gpu")
 		return err
@@ -371,7 +460,26 @@ func (s *Scheduler) scheduleRequest(worker *types.Worker, request *types.Contain
 
 	go s.schedulerUsageMetrics.CounterIncContainerScheduled(request)
 	go s.eventRepo.PushContainerScheduledEvent(request.ContainerId, worker.Id, request)
-	return s.workerRepo.ScheduleContainerRequest(worker, request)
+
+	if predictive := s.config.Worker.Pools[worker.PoolName].PoolSizing.Predictive; predictive.Enabled {
+		go s.recordPoolDemand(worker.PoolName, request, workerPoolDemandTtl(predictive))
+	}
+
+	return nil
+}
+
+// recordPoolDemand records the capacity a request used in its pool, so the pool's sizer can provision it ahead of
+// time when the deployment gets busy again at the same hour
+func (s *Scheduler) recordPoolDemand(poolName string, request *types.ContainerRequest, ttl time.Duration) {
+	err := s.workerPoolRepo.RecordWorkerPoolDemand(s.ctx, poolName, request.StubId, time.Now(), &types.WorkerPoolDemand{
+		Cpu:        request.Cpu,
+		Memory:     request.Memory,
+		Gpu:        int64(request.GpuCount),
+		Containers: 1,
+	}, ttl)
+	if err != nil {
+		log.Warn().Str("pool_name", poolName).Str("stub_id", request.StubId).Err(err).Msg("failed to record pool demand")
+	}
 }
 
 // attachImageCredentials fetches and attaches OCI credentials to a container request
@@ -400,7 +508,7 @@ func (s *Scheduler) attachImageCredentials(request *types.ContainerRequest) erro
 		return nil
 	}
 
-	secret, err := s.backendRepo.GetSecretByNameDecrypted(context.TODO(), &request.Workspace, secretName)
+	secret, err := s.imageCredentialSecret(request, secretName)
 	if err != nil {
 		log.Warn().
 			Err(err).
@@ -424,6 +532,49 @@ func (s *Scheduler) attachImageCredentials(request *types.ContainerRequest) erro
 	return nil
 }
 
+// imageCredentialSecret finds the registry credentials to pull an image with. The request's workspace uses its
+// own secret when it has one, otherwise it needs a grant to pull the image with the credentials of the
+// workspace they belong to.
+func (s *Scheduler) imageCredentialSecret(request *types.ContainerRequest, secretName string) (*types.Secret, error) {
+	ctx := context.TODO()
+
+	secret, err := s.backendRepo.GetSecretByNameDecrypted(ctx, &request.Workspace, secretName)
+	if err == nil || !errors.Is(err, sql.ErrNoRows) {
+		return secret, err
+	}
+
+	ownerId, err := s.backendRepo.GetImageCredentialWorkspaceId(ctx, request.ImageId)
+	if err != nil {
+		return nil, err
+	}
+
+	if ownerId == 0 || ownerId == request.Workspace.Id {
+		return nil, sql.ErrNoRows
+	}
+
+	granted, err := auth.AuthorizeResource(ctx, s.backendRepo, &request.Workspace, ownerId, types.ResourceGrantImage, request.ImageId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !granted {
+		return nil, errors.New("workspace has no grant to pull image")
+	}
+
+	owner, err := s.backendRepo.GetWorkspace(ctx, ownerId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decrypting the owner's secret needs its signing key, which GetWorkspace leaves out
+	ownerWithKey, err := s.backendRepo.GetWorkspaceByExternalIdWithSigningKey(ctx, owner.ExternalId)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.backendRepo.GetSecretByNameDecrypted(ctx, &ownerWithKey, secretName)
+}
+
 // attachBuildRegistryCredentials generates and attaches build registry credentials to a container request
 // These credentials are used for both build-time (push) and runtime (CLIP layer mounting)
 func (s *Scheduler) attachBuildRegistryCredentials(request *types.ContainerRequest) error {
@@ -534,7 +685,7 @@ func filterWorkersByResources(workers []*types.Worker, request *types.ContainerR
 		}
 
 		// Check if the worker has been cordoned
-		if worker.Status == types.WorkerStatusDisabled {
+		if worker.Cordoned() {
 			continue
 		}
 
@@ -547,7 +698,23 @@ func filterWorkersByResources(workers []*types.Worker, request *types.ContainerR
 		if requiresGPU {
 			// Validate GPU resource availability
 			priorityModifier, validGpu := gpuRequestsMap[worker.Gpu]
-			if !validGpu || worker.FreeGpuCount < request.GpuCount {
+			if !validGpu {
+				continue
+			}
+
+			switch {
+			case request.UsesMig():
+				if !worker.CanFitMigSlice(request.MigProfile) {
+					continue
+				}
+			case worker.UsesMig():
+				// GPUs partitioned into MIG slices can't be handed out whole, or shared
+				continue
+			case request.SharesGpu():
+				if !worker.CanFitGpuShare(types.GpuShareMemory(types.GpuType(worker.Gpu), request.GpuFraction)) {
+					continue
+				}
+			case worker.FreeGpuCount < request.GpuCount:
 				continue
 			}
 
@@ -561,6 +728,86 @@ func filterWorkersByResources(workers []*types.Worker, request *types.ContainerR
 	return filteredWorkers
 }
 
+// filterWorkersByGpuPreference keeps the workers with the GPU type that comes first in the request's list of
+// acceptable GPUs. The rest of the list are fallbacks, only used when none of the earlier types can run the request.
+func filterWorkersByGpuPreference(workers []*types.Worker, request *types.ContainerRequest) []*types.Worker {
+	if len(request.GpuRequest) < 2 || slices.Contains(request.GpuRequest, string(types.GPU_ANY)) {
+		return workers
+	}
+
+	preferred := -1
+	for _, worker := range workers {
+		index := slices.Index(request.GpuRequest, worker.Gpu)
+		if index >= 0 && (preferred < 0 || index < preferred) {
+			preferred = index
+		}
+	}
+
+	if preferred < 0 {
+		return workers
+	}
+
+	filteredWorkers := []*types.Worker{}
+	for _, worker := range workers {
+		if worker.Gpu == request.GpuRequest[preferred] {
+			filteredWorkers = append(filteredWorkers, worker)
+		}
+	}
+	return filteredWorkers
+}
+
+func filterWorkersByAffinity(workers []*types.Worker, request *types.ContainerRequest, spreadReplicas map[string]int) []*types.Worker {
+	if request.Affinity == nil {
+		return workers
+	}
+
+	filteredWorkers := []*types.Worker{}
+	for _, worker := range workers {
+		if !request.Affinity.Allows(worker.Label) {
+			continue
+		}
+
+		if request.Affinity.SpreadRequired && spreadReplicas[spreadDomain(worker, request.Affinity.SpreadBy)] > 0 {
+			continue
+		}
+
+		filteredWorkers = append(filteredWorkers, worker)
+	}
+
+	return filteredWorkers
+}
+
+// spreadDomain is the value of the worker's spread label, with workers missing the label sharing one domain
+func spreadDomain(worker *types.Worker, spreadBy string) string {
+	value, _ := worker.Label(spreadBy)
+	return value
+}
+
+// stubReplicasBySpreadDomain counts the stub's containers in each domain of the request's spread label. Container
+// state doesn't record its worker, so each worker's containers are looked up, which only stubs that spread pay for.
+func (s *Scheduler) stubReplicasBySpreadDomain(request *types.ContainerRequest, workers []*types.Worker) map[string]int {
+	replicas := map[string]int{}
+	if request.Affinity == nil || request.Affinity.SpreadBy == "" || request.StubId == "" {
+		return replicas
+	}
+
+	for _, worker := range workers {
+		containers, err := s.containerRepo.GetActiveContainersByWorkerId(worker.Id)
+		if err != nil {
+			log.Warn().Str("worker_id", worker.Id).Err(err).Msg("unable to get containers to spread replicas")
+			continue
+		}
+
+		for _, container := range containers {
+			if container.StubId == request.StubId && container.ContainerId != request.ContainerId {
+				replicas[spreadDomain(worker, request.Affinity.SpreadBy)]++
+			}
+		}
+	}
+
+	return replicas
+}
+
 func filterWorkersByFlags(workers []*types.Worker, request *types.ContainerRequest) []*types.Worker {
 	filteredWorkers := []*types.Worker{}
 	for _, worker := range workers {
@@ -578,30 +825,90 @@ func filterWorkersByFlags(workers []*types.Worker, request *types.ContainerReque
 	return filteredWorkers
 }
 
+// filterWorkersByArch keeps the workers whose CPU architecture the request's image is built for
+func filterWorkersByArch(workers []*types.Worker, request *types.ContainerRequest) []*types.Worker {
+	filteredWorkers := []*types.Worker{}
+	for _, worker := range workers {
+		if request.SupportsArch(worker.Arch) {
+			filteredWorkers = append(filteredWorkers, worker)
+		}
+	}
+
+	return filteredWorkers
+}
+
 type scoredWorker struct {
 	worker *types.Worker
 	score  int32
+	cached bool
 }
 
 // Constants used for scoring workers
 const (
 	scoreAvailableWorker int32 = 10
+	scoreSharedGpuWorker int32 = 5
+	scoreSpreadReplica   int32 = 5
+	scoreDataLocality    int32 = 8 // For each volume or bucket mount in the worker's region
+	scoreObjectLocality  int32 = 2 // When the stub's object or a replica of it is in the worker's region
+	scoreGpuInterconnect int32 = 5 // When a request for several GPUs can get GPUs that are all NVLinked
+)
+
+// Constraints workers are filtered by, in the order they're checked
+const (
+	constraintPoolSelector  = "pool_selector"
+	constraintResources     = "resources"
+	constraintFlags         = "flags"
+	constraintArch          = "arch"
+	constraintAffinity      = "affinity"
+	constraintPoolQuota     = "pool_quota"
+	constraintGpuPreference = "gpu_preference"
 )
 
+// workerFilter drops the workers that don't meet one of the constraints of a request
+type workerFilter struct {
+	constraint string
+	apply      func(workers []*types.Worker) []*types.Worker
+}
+
+func (s *Scheduler) workerFilters(request *types.ContainerRequest, workers []*types.Worker, spreadReplicas map[string]int) []workerFilter {
+	quotas := s.loadPoolQuotas(request, workers)
+
+	return []workerFilter{
+		{constraintPoolSelector, func(w []*types.Worker) []*types.Worker { return filterWorkersByPoolSelector(w, request) }},
+		{constraintResources, func(w []*types.Worker) []*types.Worker { return filterWorkersByResources(w, request) }},
+		{constraintFlags, func(w []*types.Worker) []*types.Worker { return filterWorkersByFlags(w, request) }},
+		{constraintArch, func(w []*types.Worker) []*types.Worker { return filterWorkersByArch(w, request) }},
+		{constraintAffinity, func(w []*types.Worker) []*types.Worker { return filterWorkersByAffinity(w, request, spreadReplicas) }},
+		{constraintPoolQuota, func(w []*types.Worker) []*types.Worker { return filterWorkersByPoolQuota(w, request, quotas) }},
+		{constraintGpuPreference, func(w []*types.Worker) []*types.Worker { return filterWorkersByGpuPreference(w, request) }},
+	}
+}
+
 func (s *Scheduler) selectWorker(request *types.ContainerRequest) (*types.Worker, error) {
 	workers, err := s.workerRepo.GetAllWorkers()
 	if err != nil {
 		return nil, err
 	}
 
-	filteredWorkers := filterWorkersByPoolSelector(workers, request)     // Filter workers by pool selector
-	filteredWorkers = filterWorkersByResources(filteredWorkers, request) // Filter workers resource requirements
-	filteredWorkers = filterWorkersByFlags(filteredWorkers, request)     // Filter workers by flags
+	spreadReplicas := s.stubReplicasBySpreadDomain(request, workers)
+
+	filteredWorkers := workers
+	for _, filter := range s.workerFilters(request, workers, spreadReplicas) {
+		filteredWorkers = filter.apply(filteredWorkers)
+	}
 
 	if len(filteredWorkers) == 0 {
 		return nil, &types.ErrNoSuitableWorkerFound{}
 	}
 
+	return s.bestWorker(request, filteredWorkers, spreadReplicas), nil
+}
+
+// bestWorker scores the workers that can run the request and returns the one with the highest score
+func (s *Scheduler) bestWorker(request *types.ContainerRequest, filteredWorkers []*types.Worker, spreadReplicas map[string]int) *types.Worker {
+	cachingWorkers := s.workersCachingObject(request)
+	dataLocality := s.dataLocality(request, filteredWorkers)
+
 	// Score workers based on status and priority
 	scoredWorkers := []scoredWorker{}
 	for _, worker := range filteredWorkers {
@@ -611,19 +918,107 @@ func (s *Scheduler) selectWorker(request *types.ContainerRequest) (*types.Worker
 			score += scoreAvailableWorker
 		}
 
+		// Pack fractional GPU requests onto GPUs that are already shared, keeping whole GPUs free
+		if request.SharesGpu() && worker.HasGpuShareFor(types.GpuShareMemory(types.GpuType(worker.Gpu), request.GpuFraction)) {
+			score += scoreSharedGpuWorker
+		}
+
+		// Prefer workers matching the stub's preferred labels, and domains with fewer of its replicas
+		if request.Affinity != nil {
+			score += request.Affinity.Score(worker.Label)
+			score -= scoreSpreadReplica * int32(spreadReplicas[spreadDomain(worker, request.Affinity.SpreadBy)])
+		}
+
+		// Prefer workers whose GPUs are all NVLinked for requests that span several GPUs
+		if request.GpuCount > 1 && worker.GpuInterconnect == types.GpuInterconnectNVLink {
+			score += scoreGpuInterconnect
+		}
+
+		// Prefer regions the request's data already lives in, avoiding cross-region egress
+		if worker.Region != "" {
+			score += dataLocality[worker.Region]
+		}
+
 		score += worker.Priority
-		scoredWorkers = append(scoredWorkers, scoredWorker{worker: worker, score: score})
+		scoredWorkers = append(scoredWorkers, scoredWorker{worker: worker, score: score, cached: cachingWorkers[worker.Id]})
 	}
 
-	// Select the worker with the highest score, randomizing among equal-score workers
+	// Select the worker with the highest score, preferring workers that already hold the stub's object
+	// and randomizing among the rest of the equal-score workers
 	rand.Shuffle(len(scoredWorkers), func(i, j int) {
 		scoredWorkers[i], scoredWorkers[j] = scoredWorkers[j], scoredWorkers[i]
 	})
 	sort.SliceStable(scoredWorkers, func(i, j int) bool {
-		return scoredWorkers[i].score > scoredWorkers[j].score
+		if scoredWorkers[i].score != scoredWorkers[j].score {
+			return scoredWorkers[i].score > scoredWorkers[j].score
+		}
+		return scoredWorkers[i].cached && !scoredWorkers[j].cached
 	})
 
-	return scoredWorkers[0].worker, nil
+	return scoredWorkers[0].worker
+}
+
+// dataLocality scores each region by the request's data that lives in it. It's only worked out when the
+// workers span regions, so single region clusters don't look up object replicas.
+func (s *Scheduler) dataLocality(request *types.ContainerRequest, workers []*types.Worker) map[string]int32 {
+	locality := map[string]int32{}
+
+	regions := map[string]bool{}
+	for _, worker := range workers {
+		if worker.Region != "" {
+			regions[worker.Region] = true
+		}
+	}
+
+	if len(regions) < 2 {
+		return locality
+	}
+
+	for region, count := range request.DataRegions() {
+		locality[region] += scoreDataLocality * int32(count)
+	}
+
+	object := request.Stub.Object
+	if object.Region == "" {
+		return locality
+	}
+	locality[object.Region] += scoreObjectLocality
+
+	replicas, err := s.backendRepo.ListObjectReplicas(s.ctx, object.Id)
+	if err != nil {
+		log.Warn().Err(err).Str("container_id", request.ContainerId).Msg("unable to list object replicas")
+		return locality
+	}
+
+	for _, replica := range replicas {
+		if replica.Status == types.ObjectReplicaStatusReplicated && replica.Region != object.Region {
+			locality[replica.Region] += scoreObjectLocality
+		}
+	}
+
+	return locality
+}
+
+// workersCachingObject returns the ids of workers holding the request's stub object in their object cache
+func (s *Scheduler) workersCachingObject(request *types.ContainerRequest) map[string]bool {
+	cachingWorkers := map[string]bool{}
+
+	digest := request.Stub.Object.Digest
+	if digest == "" {
+		return cachingWorkers
+	}
+
+	workerIds, err := s.workerRepo.GetWorkersCachingObject(digest)
+	if err != nil {
+		log.Warn().Err(err).Str("container_id", request.ContainerId).Msg("unable to look up workers caching object")
+		return cachingWorkers
+	}
+
+	for _, workerId := range workerIds {
+		cachingWorkers[workerId] = true
+	}
+
+	return cachingWorkers
 }
 
 const maxScheduleRetryCount = 5
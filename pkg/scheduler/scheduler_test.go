@@ -33,6 +33,7 @@ func NewSchedulerForTest() (*Scheduler, error) {
 	workerRepo := repo.NewWorkerRedisRepositoryForTest(rdb)
 	containerRepo := repo.NewContainerRedisRepositoryForTest(rdb)
 	workspaceRepo := repo.NewWorkspaceRedisRepositoryForTest(rdb)
+	backendRepo, _ := repo.NewBackendPostgresRepositoryForTest()
 	requestBacklog := NewRequestBacklogForTest(rdb)
 
 	configManager, err := common.NewConfigManager[types.AppConfig]()
@@ -62,6 +63,7 @@ func NewSchedulerForTest() (*Scheduler, error) {
 	return &Scheduler{
 		ctx:                   context.Background(),
 		eventBus:              eventBus,
+		backendRepo:           backendRepo,
 		workerRepo:            workerRepo,
 		workerPoolManager:     workerPoolManager,
 		requestBacklog:        requestBacklog,
@@ -69,6 +71,7 @@ func NewSchedulerForTest() (*Scheduler, error) {
 		schedulerUsageMetrics: schedulerUsageMetrics,
 		eventRepo:             eventRepo,
 		workspaceRepo:         workspaceRepo,
+		workerHealthFailures:  common.NewSafeMap[int](),
 	}, nil
 }
 
@@ -585,6 +588,101 @@ func TestSelectCPUWorker(t *testing.T) {
 
 }
 
+func TestSelectWorkerFollowsGpuFallbackOrder(t *testing.T) {
+	wb, err := NewSchedulerForTest()
+	assert.Nil(t, err)
+	assert.NotNil(t, wb)
+
+	workers := []*types.Worker{
+		{Id: uuid.New().String(), Status: types.WorkerStatusPending, FreeCpu: 1000, FreeMemory: 1000, Gpu: "H100", FreeGpuCount: 1},
+		{Id: uuid.New().String(), Status: types.WorkerStatusAvailable, FreeCpu: 1000, FreeMemory: 1000, Gpu: "A100-80", FreeGpuCount: 1},
+		{Id: uuid.New().String(), Status: types.WorkerStatusAvailable, FreeCpu: 1000, FreeMemory: 1000, Gpu: "A10G", FreeGpuCount: 1},
+	}
+	for _, worker := range workers {
+		err = wb.workerRepo.AddWorker(worker)
+		assert.Nil(t, err)
+	}
+
+	// Each request takes the first GPU type in its list that's still free, even over available workers
+	for _, expectedGpu := range []string{"H100", "A100-80", "A10G"} {
+		request := &types.ContainerRequest{
+			ContainerId: uuid.New().String(),
+			Cpu:         1000,
+			Memory:      1000,
+			GpuCount:    1,
+			GpuRequest:  []string{"H100", "A100-80", "A10G"},
+		}
+
+		worker, err := wb.selectWorker(request)
+		assert.Nil(t, err)
+		assert.Equal(t, expectedGpu, worker.Gpu)
+
+		err = wb.scheduleRequest(worker, request)
+		assert.Nil(t, err)
+		assert.Equal(t, expectedGpu, request.Gpu)
+	}
+}
+
+func TestSelectWorkerPrefersNVLinkForMultipleGpus(t *testing.T) {
+	wb, err := NewSchedulerForTest()
+	assert.Nil(t, err)
+	assert.NotNil(t, wb)
+
+	pcieWorker := &types.Worker{Id: uuid.New().String(), Status: types.WorkerStatusAvailable, FreeCpu: 4000, FreeMemory: 4000, Gpu: "A100-80", FreeGpuCount: 4, GpuInterconnect: types.GpuInterconnectPCIe}
+	nvlinkWorker := &types.Worker{Id: uuid.New().String(), Status: types.WorkerStatusAvailable, FreeCpu: 4000, FreeMemory: 4000, Gpu: "A100-80", FreeGpuCount: 4, GpuInterconnect: types.GpuInterconnectNVLink}
+	for _, worker := range []*types.Worker{pcieWorker, nvlinkWorker} {
+		err = wb.workerRepo.AddWorker(worker)
+		assert.Nil(t, err)
+	}
+
+	for i := 0; i < 10; i++ {
+		worker, err := wb.selectWorker(&types.ContainerRequest{
+			ContainerId: uuid.New().String(),
+			Cpu:         1000,
+			Memory:      1000,
+			GpuCount:    2,
+			GpuRequest:  []string{"A100-80"},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, nvlinkWorker.Id, worker.Id)
+	}
+}
+
+func TestSelectWorkerMatchesImageArch(t *testing.T) {
+	wb, err := NewSchedulerForTest()
+	assert.Nil(t, err)
+	assert.NotNil(t, wb)
+
+	amdWorker := &types.Worker{Id: uuid.New().String(), Status: types.WorkerStatusAvailable, FreeCpu: 4000, FreeMemory: 4000}
+	armWorker := &types.Worker{Id: uuid.New().String(), Status: types.WorkerStatusAvailable, FreeCpu: 4000, FreeMemory: 4000, Arch: types.ArchArm64}
+	for _, worker := range []*types.Worker{amdWorker, armWorker} {
+		err = wb.workerRepo.AddWorker(worker)
+		assert.Nil(t, err)
+	}
+
+	tests := []struct {
+		architectures []string
+		workerIds     []string
+	}{
+		{nil, []string{amdWorker.Id}},
+		{[]string{types.ArchArm64}, []string{armWorker.Id}},
+		{[]string{types.ArchAmd64, types.ArchArm64}, []string{amdWorker.Id, armWorker.Id}},
+	}
+
+	for _, tt := range tests {
+		for i := 0; i < 10; i++ {
+			worker, err := wb.selectWorker(&types.ContainerRequest{
+				ContainerId:   uuid.New().String(),
+				Cpu:           1000,
+				Memory:        1000,
+				Architectures: tt.architectures,
+			})
+			assert.Nil(t, err)
+			assert.Contains(t, tt.workerIds, worker.Id)
+		}
+	}
+}
+
 func stringInSlice(a string, list []string) bool {
 	for _, b := range list {
 		if b == a {
@@ -929,6 +1027,55 @@ func TestPoolPriority(t *testing.T) {
 	assert.Equal(t, newWorkerWithLowPriority.Id, worker.Id)
 }
 
+func TestSelectWorkerCachingObject(t *testing.T) {
+	wb, err := NewSchedulerForTest()
+	assert.Nil(t, err)
+	assert.NotNil(t, wb)
+
+	for _, workerId := range []string{"worker1", "worker2", "worker3"} {
+		err = wb.workerRepo.AddWorker(&types.Worker{
+			Id:         workerId,
+			Status:     types.WorkerStatusAvailable,
+			FreeCpu:    2000,
+			FreeMemory: 2000,
+			Gpu:        "",
+			PoolName:   "cpu",
+		})
+		assert.Nil(t, err)
+	}
+
+	err = wb.workerRepo.AddCachedObject("worker2", "digest1")
+	assert.Nil(t, err)
+
+	request := &types.ContainerRequest{
+		Cpu:    1000,
+		Memory: 1000,
+		Gpu:    "",
+		Stub: types.StubWithRelated{
+			Object: types.Object{Digest: "digest1"},
+		},
+	}
+
+	// Equal-score workers are otherwise picked at random, so repeat to make sure the cache decides
+	for i := 0; i < 10; i++ {
+		worker, err := wb.selectWorker(request)
+		assert.Nil(t, err)
+		assert.Equal(t, "worker2", worker.Id)
+	}
+
+	// Once the object is invalidated no worker is preferred for it
+	err = wb.workerRepo.InvalidateCachedObject("digest1")
+	assert.Nil(t, err)
+
+	selected := map[string]bool{}
+	for i := 0; i < 50; i++ {
+		worker, err := wb.selectWorker(request)
+		assert.Nil(t, err)
+		selected[worker.Id] = true
+	}
+	assert.True(t, len(selected) > 1)
+}
+
 func TestSelectBuildWorker(t *testing.T) {
 	wb, err := NewSchedulerForTest()
 	assert.Nil(t, err)
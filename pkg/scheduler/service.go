@@ -14,6 +14,8 @@ type SchedulerService struct {
 
 func NewSchedulerService(scheduler *Scheduler) (*SchedulerService, error) {
 	go scheduler.StartProcessingRequests() // Start processing ContainerRequests
+	go scheduler.StartWorkerHealthChecks()
+	go scheduler.StartCapacityReservations()
 
 	return &SchedulerService{
 		Scheduler: scheduler,
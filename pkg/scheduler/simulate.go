@@ -0,0 +1,184 @@
+package scheduler
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+// Simulate works out where the request would be placed if it were run now, without scheduling it or stopping
+// anything. It makes the same checks as Run and the backlog, in the same order: the workspace's concurrency limit
+// and pool quotas, each worker filter, then preemption and new workers.
+func (s *Scheduler) Simulate(request *types.ContainerRequest) (*types.SchedulingSimulation, error) {
+	simulation := &types.SchedulingSimulation{}
+
+	limit, err := s.getConcurrencyLimit(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit != nil {
+		containers, err := s.containerRepo.GetActiveContainersByWorkspaceId(request.WorkspaceId)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := limit.Check(containers, request); err != nil {
+			simulation.Outcome = types.SchedulingOutcomeRejected
+			simulation.Reason = err.Error()
+			return simulation, nil
+		}
+	}
+
+	if err := s.checkPoolQuotas(request); err != nil {
+		simulation.Outcome = types.SchedulingOutcomeRejected
+		simulation.Reason = err.Error()
+		return simulation, nil
+	}
+
+	workers, err := s.workerRepo.GetAllWorkers()
+	if err != nil {
+		return nil, err
+	}
+
+	spreadReplicas := s.stubReplicasBySpreadDomain(request, workers)
+
+	filteredWorkers := workers
+	for _, filter := range s.workerFilters(request, workers, spreadReplicas) {
+		remaining := filter.apply(filteredWorkers)
+		simulation.Constraints = append(simulation.Constraints, rejectedByFilter(filter.constraint, filteredWorkers, remaining, request, spreadReplicas))
+		filteredWorkers = remaining
+	}
+
+	if len(filteredWorkers) > 0 {
+		simulation.Outcome = types.SchedulingOutcomeScheduled
+		simulation.Worker = s.bestWorker(request, filteredWorkers, spreadReplicas)
+		return simulation, nil
+	}
+
+	if plan := s.bestPreemptionPlan(request); plan != nil {
+		simulation.Outcome = types.SchedulingOutcomePreempt
+		simulation.Worker = plan.worker
+		simulation.Reason = "no worker has room, so containers of a lower priority class are stopped to make room"
+		for _, victim := range plan.victims {
+			simulation.Preempted = append(simulation.Preempted, victim.ContainerId)
+		}
+		return simulation, nil
+	}
+
+	controllers, err := s.getControllers(request)
+	if err != nil {
+		simulation.Outcome = types.SchedulingOutcomeRejected
+		simulation.Reason = "no worker can run the request and no pool can add one that could"
+		return simulation, nil
+	}
+
+	controllers = filterControllersByPoolQuota(controllers, request, s.loadPoolQuotas(request, workers))
+	if len(controllers) == 0 {
+		simulation.Outcome = types.SchedulingOutcomePending
+		simulation.Reason = "the workspace is using all of its quota in every pool that can run the request"
+		return simulation, nil
+	}
+
+	simulation.Outcome = types.SchedulingOutcomeScaleUp
+	simulation.Reason = "no worker can run the request, so a new one is added"
+	for _, controller := range controllers {
+		simulation.ScaleUpPools = append(simulation.ScaleUpPools, controller.Name())
+	}
+
+	return simulation, nil
+}
+
+// rejectedByFilter lists the workers a filter dropped, and why each of them doesn't meet its constraint
+func rejectedByFilter(constraint string, before, after []*types.Worker, request *types.ContainerRequest, spreadReplicas map[string]int) types.SchedulingConstraint {
+	result := types.SchedulingConstraint{Name: constraint, Rejected: []types.SchedulingRejection{}}
+
+	for _, worker := range before {
+		if slices.Contains(after, worker) {
+			continue
+		}
+
+		result.Rejected = append(result.Rejected, types.SchedulingRejection{
+			WorkerId: worker.Id,
+			PoolName: worker.PoolName,
+			Gpu:      worker.Gpu,
+			Reason:   rejectionReason(constraint, worker, request, spreadReplicas),
+		})
+	}
+
+	return result
+}
+
+func rejectionReason(constraint string, worker *types.Worker, request *types.ContainerRequest, spreadReplicas map[string]int) string {
+	switch constraint {
+	case constraintPoolSelector:
+		if request.PoolSelector != "" {
+			return fmt.Sprintf("not in pool %s", request.PoolSelector)
+		}
+		return "pool only runs requests that select it"
+	case constraintResources:
+		return resourceShortfall(worker, request)
+	case constraintFlags:
+		if !request.Preemptable && worker.Preemptable {
+			return "worker is preemptable"
+		}
+		return fmt.Sprintf("docker needs the %s runtime", types.ContainerRuntimeGvisor)
+	case constraintArch:
+		return fmt.Sprintf("worker is %s, the image is built for %s", worker.WorkerArch(), strings.Join(types.ImageArchitectures(request.Architectures), ", "))
+	case constraintAffinity:
+		if !request.Affinity.Allows(worker.Label) {
+			return "labels don't match the required affinity"
+		}
+		return fmt.Sprintf("already runs a replica in its %s domain", request.Affinity.SpreadBy)
+	case constraintPoolQuota:
+		return "over the workspace's pool quota, or would take GPUs reserved for other workspaces"
+	case constraintGpuPreference:
+		return "a GPU type earlier in the request's list is free"
+	}
+
+	return ""
+}
+
+// resourceShortfall is why a worker doesn't have the resources the request needs, making the same checks as
+// filterWorkersByResources
+func resourceShortfall(worker *types.Worker, request *types.ContainerRequest) string {
+	if worker.FreeCpu < request.Cpu {
+		return fmt.Sprintf("not enough free cpu, %dm of %dm", worker.FreeCpu, request.Cpu)
+	}
+
+	if worker.FreeMemory < request.Memory {
+		return fmt.Sprintf("not enough free memory, %dMi of %dMi", worker.FreeMemory, request.Memory)
+	}
+
+	if worker.Cordoned() {
+		return "worker is cordoned"
+	}
+
+	if !request.RequiresGPU() {
+		return "worker has a GPU, which requests without GPUs don't use"
+	}
+
+	if worker.Gpu == "" {
+		return "worker has no GPU"
+	}
+
+	if !slices.Contains(request.GpuRequest, worker.Gpu) && !slices.Contains(request.GpuRequest, string(types.GPU_ANY)) {
+		return fmt.Sprintf("GPU type %s wasn't requested", worker.Gpu)
+	}
+
+	if request.UsesMig() {
+		return fmt.Sprintf("no free MIG slice of profile %s", request.MigProfile)
+	}
+
+	if worker.UsesMig() {
+		return "worker's GPUs are partitioned into MIG slices"
+	}
+
+	if request.SharesGpu() {
+		return "no GPU has room for the requested share"
+	}
+
+	return fmt.Sprintf("not enough free GPUs, %d of %d", worker.FreeGpuCount, request.GpuCount)
+}
@@ -0,0 +1,78 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+func TestSimulate(t *testing.T) {
+	wb, err := NewSchedulerForTest()
+	require.NoError(t, err)
+
+	workspaceId := uuid.New().String()
+	require.NoError(t, wb.workspaceRepo.SetConcurrencyLimitByWorkspaceId(workspaceId, &types.ConcurrencyLimit{GPULimit: 2, CPUMillicoreLimit: 100000}))
+	require.NoError(t, wb.workspaceRepo.SetWorkspacePolicyByWorkspaceId(workspaceId, &types.WorkspacePolicy{}))
+	require.NoError(t, wb.workspaceRepo.SetPoolQuotas(nil))
+
+	a10g := &types.Worker{Id: "a10g", Status: types.WorkerStatusAvailable, PoolName: "beta9-a10g", FreeCpu: 4000, FreeMemory: 4000, Gpu: "A10G", FreeGpuCount: 1}
+	t4 := &types.Worker{Id: "t4", Status: types.WorkerStatusDisabled, PoolName: "beta9-t4", FreeCpu: 4000, FreeMemory: 4000, Gpu: "T4", FreeGpuCount: 1}
+	cpu := &types.Worker{Id: "cpu", Status: types.WorkerStatusAvailable, PoolName: "beta9-cpu", FreeCpu: 4000, FreeMemory: 4000}
+	for _, worker := range []*types.Worker{a10g, t4, cpu} {
+		require.NoError(t, wb.workerRepo.AddWorker(worker))
+	}
+
+	newRequest := func(gpuCount uint32) *types.ContainerRequest {
+		return &types.ContainerRequest{
+			ContainerId: uuid.New().String(),
+			WorkspaceId: workspaceId,
+			Cpu:         1000,
+			Memory:      1000,
+			GpuRequest:  []string{"A10G"},
+			GpuCount:    gpuCount,
+		}
+	}
+
+	// A request that fits goes to the worker that has room for it
+	simulation, err := wb.Simulate(newRequest(1))
+	require.NoError(t, err)
+	assert.Equal(t, types.SchedulingOutcomeScheduled, simulation.Outcome)
+	assert.Equal(t, "a10g", simulation.Worker.Id)
+
+	// A request that doesn't fit gets a new worker, and each worker's reason for not running it is recorded
+	simulation, err = wb.Simulate(newRequest(2))
+	require.NoError(t, err)
+	assert.Equal(t, types.SchedulingOutcomeScaleUp, simulation.Outcome)
+	assert.Equal(t, []string{"beta9-a10g"}, simulation.ScaleUpPools)
+	assert.Nil(t, simulation.Worker)
+
+	reasons := map[string]string{}
+	for _, constraint := range simulation.Constraints {
+		for _, rejection := range constraint.Rejected {
+			assert.Equal(t, constraintResources, constraint.Name)
+			reasons[rejection.WorkerId] = rejection.Reason
+		}
+	}
+	assert.Equal(t, map[string]string{
+		"a10g": "not enough free GPUs, 1 of 2",
+		"t4":   "worker is cordoned",
+		"cpu":  "worker has no GPU",
+	}, reasons)
+
+	// Requests over the workspace's concurrency limit are rejected before any worker is looked at
+	simulation, err = wb.Simulate(newRequest(3))
+	require.NoError(t, err)
+	assert.Equal(t, types.SchedulingOutcomeRejected, simulation.Outcome)
+	assert.Contains(t, simulation.Reason, "gpu quota exceeded")
+	assert.Empty(t, simulation.Constraints)
+
+	// Nothing was scheduled along the way
+	worker, err := wb.workerRepo.GetWorkerById("a10g")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), worker.FreeGpuCount)
+	assert.Equal(t, int64(0), wb.requestBacklog.Len())
+}
@@ -0,0 +1,172 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/network"
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+// StartWorkerHealthChecks asks every available worker to check its GPUs, disks and container runtime each
+// interval. Workers that fail enough checks in a row, or can't be reached, are cordoned with the reason they
+// failed, and their containers are stopped so they're rescheduled on healthy workers. Each gateway's scheduler
+// counts failures on its own.
+func (s *Scheduler) StartWorkerHealthChecks() {
+	config := s.config.Worker.HealthCheck
+	if !config.Enabled || config.Interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			s.checkWorkers()
+		}
+	}
+}
+
+func (s *Scheduler) checkWorkers() {
+	workers, err := s.workerRepo.GetAllWorkers()
+	if err != nil {
+		log.Error().Err(err).Msg("failed to get workers for health checks")
+		return
+	}
+
+	checked := map[string]bool{}
+	var wg sync.WaitGroup
+	for _, worker := range workers {
+		// Pending workers haven't started their container server yet, and cordoned workers are already out of
+		// scheduling
+		if worker.Status != types.WorkerStatusAvailable || worker.ServerAddress == "" {
+			continue
+		}
+
+		checked[worker.Id] = true
+		wg.Add(1)
+		go func(worker *types.Worker) {
+			defer wg.Done()
+			s.checkWorker(worker)
+		}(worker)
+	}
+	wg.Wait()
+
+	// Forget the failures of workers that have gone away or were cordoned since
+	stale := []string{}
+	s.workerHealthFailures.Range(func(workerId string, _ int) bool {
+		if !checked[workerId] {
+			stale = append(stale, workerId)
+		}
+		return true
+	})
+	for _, workerId := range stale {
+		s.workerHealthFailures.Delete(workerId)
+	}
+}
+
+func (s *Scheduler) checkWorker(worker *types.Worker) {
+	ctx, cancel := context.WithTimeout(s.ctx, s.config.Worker.HealthCheck.Timeout)
+	defer cancel()
+
+	reason := s.workerHealthCheck(ctx, worker)
+	if reason == "" {
+		s.workerHealthFailures.Delete(worker.Id)
+		return
+	}
+
+	failures, _ := s.workerHealthFailures.Get(worker.Id)
+	failures++
+
+	log.Warn().Str("worker_id", worker.Id).Str("reason", reason).Int("failures", failures).Msg("worker failed health check")
+
+	if failures < s.config.Worker.HealthCheck.FailureThreshold {
+		s.workerHealthFailures.Set(worker.Id, failures)
+		return
+	}
+
+	s.workerHealthFailures.Delete(worker.Id)
+	s.cordonUnhealthyWorker(worker, reason)
+}
+
+// requestWorkerHealth asks the worker to run its health checks, and returns why it's unhealthy if it is
+func (s *Scheduler) requestWorkerHealth(ctx context.Context, worker *types.Worker) string {
+	conn, err := network.ConnectToHost(ctx, worker.ServerAddress, s.config.Worker.HealthCheck.Timeout, s.tailscale, s.config.Tailscale)
+	if err != nil {
+		return fmt.Sprintf("unreachable: %v", err)
+	}
+
+	client, err := common.NewContainerClient(worker.ServerAddress, "", conn)
+	if err != nil {
+		conn.Close()
+		return fmt.Sprintf("unreachable: %v", err)
+	}
+	defer client.Close()
+
+	response, err := client.WorkerHealth(ctx)
+	if status.Code(err) == codes.Unimplemented {
+		// Workers running an older build don't check their own health
+		return ""
+	}
+	if err != nil {
+		return fmt.Sprintf("unreachable: %v", err)
+	}
+
+	if !response.Ok {
+		return ""
+	}
+
+	failed := []string{}
+	for _, check := range response.Checks {
+		if !check.Healthy {
+			failed = append(failed, fmt.Sprintf("%s: %s", check.Name, check.Message))
+		}
+	}
+
+	return strings.Join(failed, "; ")
+}
+
+// cordonUnhealthyWorker takes the worker out of scheduling and stops its containers. They're stopped the way
+// preempted containers are, so deployments replace them and tasks are retried without using up a retry.
+func (s *Scheduler) cordonUnhealthyWorker(worker *types.Worker, reason string) {
+	log.Warn().Str("worker_id", worker.Id).Str("pool_name", worker.PoolName).Str("reason", reason).Msg("cordoning unhealthy worker")
+
+	if err := s.workerRepo.CordonUnhealthyWorker(worker.Id, reason); err != nil {
+		log.Error().Str("worker_id", worker.Id).Err(err).Msg("failed to cordon unhealthy worker")
+		return
+	}
+
+	go s.eventRepo.PushWorkerUnhealthyEvent(worker.Id, worker.MachineId, worker.PoolName, reason)
+
+	containers, err := s.containerRepo.GetActiveContainersByWorkerId(worker.Id)
+	if err != nil {
+		log.Error().Str("worker_id", worker.Id).Err(err).Msg("failed to get containers of unhealthy worker")
+		return
+	}
+
+	for _, container := range containers {
+		if container.Status == types.ContainerStatusStopping {
+			continue
+		}
+
+		err := s.Stop(&types.StopContainerArgs{
+			ContainerId: container.ContainerId,
+			Reason:      types.StopContainerReasonPreempted,
+		})
+		if err != nil {
+			log.Error().Str("container_id", container.ContainerId).Err(err).Msg("failed to stop container on unhealthy worker")
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+func TestUnhealthyWorkersAreCordoned(t *testing.T) {
+	wb, err := NewSchedulerForTest()
+	require.NoError(t, err)
+
+	wb.config.Worker.HealthCheck = types.WorkerHealthCheckConfig{Enabled: true, Timeout: time.Second, FailureThreshold: 2}
+
+	healthy := &types.Worker{Id: "healthy", Status: types.WorkerStatusAvailable, ServerAddress: "10.0.0.1:1000", FreeCpu: 1000, FreeMemory: 1000}
+	sick := &types.Worker{Id: "sick", Status: types.WorkerStatusAvailable, ServerAddress: "10.0.0.2:1000", FreeCpu: 1000, FreeMemory: 1000}
+	pending := &types.Worker{Id: "pending", Status: types.WorkerStatusPending, FreeCpu: 1000, FreeMemory: 1000}
+	for _, worker := range []*types.Worker{healthy, sick, pending} {
+		require.NoError(t, wb.workerRepo.AddWorker(worker))
+	}
+
+	require.NoError(t, wb.containerRepo.SetContainerState("container1", &types.ContainerState{ContainerId: "container1", Status: types.ContainerStatusRunning}))
+	require.NoError(t, wb.workerRepo.AddContainerToWorker("sick", "container1"))
+
+	var mu sync.Mutex
+	checked := []string{}
+	wb.workerHealthCheck = func(ctx context.Context, worker *types.Worker) string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		checked = append(checked, worker.Id)
+		if worker.Id == "sick" {
+			return "gpu: gpu 0: requires reset"
+		}
+		return ""
+	}
+
+	// A single failure isn't enough to cordon a worker
+	wb.checkWorker(sick)
+	worker, err := wb.workerRepo.GetWorkerById("sick")
+	require.NoError(t, err)
+	assert.Equal(t, types.WorkerStatusAvailable, worker.Status)
+
+	wb.checkWorkers()
+	assert.ElementsMatch(t, []string{"sick", "healthy", "sick"}, checked)
+
+	worker, err = wb.workerRepo.GetWorkerById("sick")
+	require.NoError(t, err)
+	assert.Equal(t, types.WorkerStatusDisabled, worker.Status)
+	assert.Equal(t, "gpu: gpu 0: requires reset", worker.UnhealthyReason)
+
+	// Its containers are stopped so they get rescheduled
+	state, err := wb.containerRepo.GetContainerState("container1")
+	require.NoError(t, err)
+	assert.Equal(t, types.ContainerStatusStopping, state.Status)
+
+	worker, err = wb.workerRepo.GetWorkerById("healthy")
+	require.NoError(t, err)
+	assert.Equal(t, types.WorkerStatusAvailable, worker.Status)
+
+	// Cordoned workers aren't checked again, and their failures are forgotten
+	checked = []string{}
+	wb.checkWorkers()
+	assert.Equal(t, []string{"healthy"}, checked)
+	assert.Equal(t, 0, wb.workerHealthFailures.Len())
+}
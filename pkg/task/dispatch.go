@@ -2,6 +2,8 @@ package task
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -19,10 +21,13 @@ func GetTaskResultPath(taskId string) string {
 	return fmt.Sprintf("task/%s/result", taskId)
 }
 
-func NewDispatcher(ctx context.Context, taskRepo repository.TaskRepository) (*Dispatcher, error) {
+func NewDispatcher(ctx context.Context, taskRepo repository.TaskRepository, backendRepo repository.BackendRepository, containerRepo repository.ContainerRepository, workspaceRepo repository.WorkspaceRepository) (*Dispatcher, error) {
 	d := &Dispatcher{
 		ctx:                ctx,
 		taskRepo:           taskRepo,
+		backendRepo:        backendRepo,
+		containerRepo:      containerRepo,
+		workspaceRepo:      workspaceRepo,
 		executors:          common.NewSafeMap[func(ctx context.Context, message types.TaskMessage) (types.TaskInterface, error)](),
 		storageClientCache: sync.Map{},
 	}
@@ -34,6 +39,9 @@ func NewDispatcher(ctx context.Context, taskRepo repository.TaskRepository) (*Di
 type Dispatcher struct {
 	ctx                context.Context
 	taskRepo           repository.TaskRepository
+	backendRepo        repository.BackendRepository
+	containerRepo      repository.ContainerRepository
+	workspaceRepo      repository.WorkspaceRepository
 	executors          *common.SafeMap[func(ctx context.Context, message types.TaskMessage) (types.TaskInterface, error)]
 	storageClientCache sync.Map
 }
@@ -82,6 +90,10 @@ func (d *Dispatcher) SendAndExecute(ctx context.Context, executor string, authIn
 }
 
 func (d *Dispatcher) Send(ctx context.Context, executor string, authInfo *auth.AuthInfo, stubId string, payload *types.TaskPayload, policy types.TaskPolicy) (types.TaskInterface, error) {
+	if err := d.checkWorkspacePolicy(ctx, authInfo.Workspace, stubId); err != nil {
+		return nil, err
+	}
+
 	taskMessage := d.getTaskMessage()
 	taskMessage.Executor = executor
 	taskMessage.WorkspaceName = authInfo.Workspace.Name
@@ -117,6 +129,39 @@ func (d *Dispatcher) Send(ctx context.Context, executor string, authInfo *auth.A
 	return task, nil
 }
 
+// checkWorkspacePolicy refuses tasks for stubs that don't fit the workspace's resource policy, like stubs created
+// before the policy was set or tightened
+func (d *Dispatcher) checkWorkspacePolicy(ctx context.Context, workspace *types.Workspace, stubId string) error {
+	policy, err := repository.LoadWorkspacePolicy(ctx, d.workspaceRepo, d.backendRepo, workspace.ExternalId)
+	if err != nil {
+		return err
+	}
+
+	if policy.IsEmpty() {
+		return nil
+	}
+
+	stub, err := d.backendRepo.GetStubByExternalId(ctx, stubId)
+	if err != nil {
+		return err
+	}
+
+	if stub == nil {
+		return fmt.Errorf("stub not found: %s", stubId)
+	}
+
+	stubConfig, err := stub.UnmarshalConfig()
+	if err != nil {
+		return err
+	}
+
+	if err := policy.CheckRuntime(stubConfig.Runtime); err != nil {
+		return err
+	}
+
+	return policy.CheckRunDuration(stubConfig.TaskPolicy.Timeout)
+}
+
 func (d *Dispatcher) StoreTaskResult(workspace *types.Workspace, taskId string, result []byte) error {
 	var err error
 	var storageClient *clients.WorkspaceStorageClient
@@ -221,7 +266,7 @@ func (d *Dispatcher) monitor(ctx context.Context) {
 				}
 
 				if !heartbeat {
-					d.RetryTask(ctx, task)
+					d.RetryTask(ctx, task, d.lostTaskFailure(ctx, taskMessage.TaskId))
 					continue
 				}
 			}
@@ -229,58 +274,173 @@ func (d *Dispatcher) monitor(ctx context.Context) {
 	}
 }
 
-func (d *Dispatcher) RetryTask(ctx context.Context, task types.TaskInterface) error {
+// lostTaskFailure describes a task whose heartbeat stopped, using the exit code of the container that was
+// running it if the container has exited
+func (d *Dispatcher) lostTaskFailure(ctx context.Context, taskId string) types.TaskFailure {
+	failure := types.TaskFailure{Reason: types.TaskFailureHeartbeatLost}
+
+	task, err := d.backendRepo.GetTask(ctx, taskId)
+	if err != nil || task.ContainerId == "" {
+		return failure
+	}
+	failure.ContainerId = task.ContainerId
+
+	if exitCode, err := d.containerRepo.GetContainerExitCode(task.ContainerId); err == nil {
+		failure.ExitCode = &exitCode
+		if exitCode == int(types.ContainerExitCodePreempted) {
+			failure.Reason = types.TaskFailurePreempted
+		}
+	}
+
+	return failure
+}
+
+// retryPolicy returns the stub's retry policy, or nil if the task's own policy applies
+func (d *Dispatcher) retryPolicy(ctx context.Context, stubId string) *types.TaskRetryPolicy {
+	policy, err := d.backendRepo.GetTaskRetryPolicy(ctx, stubId)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Warn().Str("stub_id", stubId).Err(err).Msg("dispatcher unable to get retry policy, using task policy")
+		}
+		return nil
+	}
+
+	return policy
+}
+
+// RetryTask reinserts a failed task if its retry policy allows it, and cancels it otherwise. It returns
+// whether the task was reinserted. Retries requested by the task itself ignore the policy's exit codes, and
+// tasks whose worker was preempted are always reinserted without using up a retry.
+func (d *Dispatcher) RetryTask(ctx context.Context, task types.TaskInterface, failure types.TaskFailure) (bool, error) {
 	taskMessage := task.Message()
 
 	err := d.taskRepo.SetTaskRetryLock(ctx, taskMessage.WorkspaceName, taskMessage.StubId, taskMessage.TaskId)
 	if err != nil {
-		return err
+		return false, err
 	}
 	defer d.taskRepo.RemoveTaskRetryLock(ctx, taskMessage.WorkspaceName, taskMessage.StubId, taskMessage.TaskId)
 
-	// Hit retry limit, cancel task and resolve
-	if taskMessage.Retries >= taskMessage.Policy.MaxRetries {
-		if taskMessage.Policy.MaxRetries > 0 {
-			log.Info().Str("task_id", taskMessage.TaskId).Str("stub_id", taskMessage.StubId).Msg("dispatcher hit retry limit, not reinserting task")
+	maxRetries := taskMessage.Policy.MaxRetries
+	retryable := true
+	policy := d.retryPolicy(ctx, taskMessage.StubId)
+	if policy != nil && policy.MaxAttempts > 0 {
+		maxRetries = policy.MaxAttempts - 1
+		retryable = failure.Reason == types.TaskFailureRequested || policy.RetriesExitCode(failure.ExitCode)
+	}
+
+	preempted := failure.Reason == types.TaskFailurePreempted
+	retry := preempted || (retryable && taskMessage.Retries < maxRetries)
+	d.recordAttempt(ctx, taskMessage, failure, retry)
+
+	// Hit retry limit or the failure isn't retryable, cancel task and resolve
+	if !retry {
+		if maxRetries > 0 {
+			log.Info().Str("task_id", taskMessage.TaskId).Str("stub_id", taskMessage.StubId).Bool("retryable", retryable).Msg("dispatcher not reinserting task")
 		}
 
 		err = task.Cancel(ctx, types.TaskExceededRetryLimit)
 		if err != nil {
 			log.Error().Str("task_id", task.Metadata().TaskId).Err(err).Msg("dispatcher unable to cancel task")
-			return err
+			return false, err
 		}
 
-		return d.Complete(ctx, taskMessage.WorkspaceName, taskMessage.StubId, taskMessage.TaskId)
+		return false, d.Complete(ctx, taskMessage.WorkspaceName, taskMessage.StubId, taskMessage.TaskId)
 	}
 
 	// Remove task claim so other replicas of Dispatcher don't try to retry the same task
 	err = d.taskRepo.RemoveTaskClaim(ctx, taskMessage.WorkspaceName, taskMessage.StubId, taskMessage.TaskId)
 	if err != nil {
 		log.Error().Str("task_id", task.Metadata().TaskId).Err(err).Msg("dispatcher failed to remove task claim")
-		return err
+		return false, err
 	}
 
 	// Retry task
-	log.Info().Str("workspace_name", taskMessage.WorkspaceName).Str("task_id", taskMessage.TaskId).Str("stub_id", taskMessage.StubId).Msg("dispatcher missing heartbeat, reinserting task")
+	log.Info().Str("workspace_name", taskMessage.WorkspaceName).Str("task_id", taskMessage.TaskId).Str("stub_id", taskMessage.StubId).Str("reason", string(failure.Reason)).Msg("dispatcher reinserting task")
 
-	taskMessage.Retries += 1
+	if !preempted {
+		taskMessage.Retries += 1
+	}
 	taskMessage.Timestamp = time.Now().Unix()
 
 	msg, err := taskMessage.Encode()
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	err = d.taskRepo.SetTaskState(ctx, taskMessage.WorkspaceName, taskMessage.StubId, taskMessage.TaskId, msg)
+	if err != nil {
+		return false, err
+	}
+
+	var backoff time.Duration
+	if policy != nil {
+		backoff = policy.Backoff(taskMessage.Retries)
+	}
+
+	if backoff <= 0 {
+		err = task.Retry(ctx)
+		if err != nil {
+			log.Error().Err(err).Msg("dispatcher retry failed")
+			return false, err
+		}
+
+		return true, nil
+	}
+
+	// The task stays unclaimed while it waits, so it still expires if the gateway goes away before retrying it
+	time.AfterFunc(backoff, func() {
+		if err := task.Retry(d.ctx); err != nil {
+			log.Error().Str("task_id", taskMessage.TaskId).Err(err).Msg("dispatcher retry failed")
+		}
+	})
+
+	return true, nil
+}
+
+// Requeue puts an in-flight task back on its queue without counting it as a retry. A container still running
+// the task stops working on it once it sees the task is no longer claimed.
+func (d *Dispatcher) Requeue(ctx context.Context, workspaceName, stubId, taskId string) error {
+	task, err := d.Retrieve(ctx, workspaceName, stubId, taskId)
 	if err != nil {
 		return err
 	}
 
-	err = task.Retry(ctx)
+	err = d.taskRepo.SetTaskRetryLock(ctx, workspaceName, stubId, taskId)
 	if err != nil {
-		log.Error().Err(err).Msg("dispatcher retry failed")
 		return err
 	}
+	defer d.taskRepo.RemoveTaskRetryLock(ctx, workspaceName, stubId, taskId)
 
-	return nil
+	err = d.taskRepo.RemoveTaskClaim(ctx, workspaceName, stubId, taskId)
+	if err != nil {
+		return err
+	}
+
+	taskMessage := task.Message()
+	taskMessage.Timestamp = time.Now().Unix()
+
+	msg, err := taskMessage.Encode()
+	if err != nil {
+		return err
+	}
+
+	err = d.taskRepo.SetTaskState(ctx, workspaceName, stubId, taskId, msg)
+	if err != nil {
+		return err
+	}
+
+	return task.Retry(ctx)
+}
+
+func (d *Dispatcher) recordAttempt(ctx context.Context, taskMessage *types.TaskMessage, failure types.TaskFailure, retried bool) {
+	err := d.backendRepo.CreateTaskAttempt(ctx, taskMessage.TaskId, types.TaskAttempt{
+		Attempt:     taskMessage.Retries + 1,
+		ContainerId: failure.ContainerId,
+		ExitCode:    failure.ExitCode,
+		Reason:      failure.Reason,
+		Retried:     retried,
+	})
+	if err != nil {
+		log.Warn().Str("task_id", taskMessage.TaskId).Err(err).Msg("dispatcher unable to record task attempt")
+	}
 }
@@ -3,6 +3,7 @@ package task
 import (
 	"errors"
 	"io"
+	"net/http"
 	"net/url"
 	"strconv"
 
@@ -20,6 +21,12 @@ func SerializeHttpPayload(ctx echo.Context) (*types.TaskPayload, error) {
 
 	payload := map[string]interface{}{}
 	if err := decoder.Decode(&payload); err != nil {
+		// Let callers tell an oversized body apart from a malformed one
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, err
+		}
+
 		if err != io.EOF {
 			return nil, errors.New("invalid request payload")
 		}
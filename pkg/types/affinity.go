@@ -0,0 +1,253 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	pb "github.com/beam-cloud/beta9/proto"
+)
+
+// Labels every worker has without them being set, so affinity rules and spreading can use them
+const (
+	WorkerLabelPool    = "pool"
+	WorkerLabelMachine = "machine"
+	WorkerLabelGpu     = "gpu"
+	WorkerLabelRegion  = "region"
+)
+
+var builtinWorkerLabels = []string{WorkerLabelPool, WorkerLabelMachine, WorkerLabelGpu, WorkerLabelRegion}
+
+const (
+	maxWorkerLabelLength = 63
+
+	// Score a preferred selector without a weight adds to the workers it matches
+	DefaultAffinityWeight int32 = 10
+)
+
+// WorkerLabels are the labels of a worker as comma separated key=value pairs, sorted by key, so they can be
+// stored in the worker's state hash
+type WorkerLabels string
+
+func NewWorkerLabels(labels map[string]string) WorkerLabels {
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+
+	return WorkerLabels(strings.Join(pairs, ","))
+}
+
+func (l WorkerLabels) Map() map[string]string {
+	labels := map[string]string{}
+	if l == "" {
+		return labels
+	}
+
+	for _, pair := range strings.Split(string(l), ",") {
+		if key, value, ok := strings.Cut(pair, "="); ok {
+			labels[key] = value
+		}
+	}
+
+	return labels
+}
+
+// ValidateWorkerLabel checks a label can be stored and doesn't shadow one every worker has
+func ValidateWorkerLabel(key, value string) error {
+	if key == "" || len(key) > maxWorkerLabelLength || len(value) > maxWorkerLabelLength {
+		return fmt.Errorf("label keys and values must be at most %d characters, and keys can't be empty", maxWorkerLabelLength)
+	}
+
+	if strings.ContainsAny(key, ",= ") || strings.ContainsAny(value, ",= ") {
+		return fmt.Errorf("label %s can't contain commas, equals signs or spaces", key)
+	}
+
+	if slices.Contains(builtinWorkerLabels, key) {
+		return fmt.Errorf("label %s is set on every worker and can't be changed", key)
+	}
+
+	return nil
+}
+
+// Label looks up one of the worker's labels, including the ones every worker has. Workers that don't run on a
+// machine of their own are their own machine.
+func (w *Worker) Label(key string) (string, bool) {
+	switch key {
+	case WorkerLabelPool:
+		return w.PoolName, w.PoolName != ""
+	case WorkerLabelMachine:
+		if w.MachineId != "" {
+			return w.MachineId, true
+		}
+		return w.Id, true
+	case WorkerLabelGpu:
+		return w.Gpu, w.Gpu != ""
+	case WorkerLabelRegion:
+		return w.Region, w.Region != ""
+	}
+
+	value, ok := w.Labels.Map()[key]
+	return value, ok
+}
+
+type LabelSelectorOperator string
+
+const (
+	LabelSelectorIn           LabelSelectorOperator = "In"
+	LabelSelectorNotIn        LabelSelectorOperator = "NotIn"
+	LabelSelectorExists       LabelSelectorOperator = "Exists"
+	LabelSelectorDoesNotExist LabelSelectorOperator = "DoesNotExist"
+)
+
+// WorkerLabelSelector matches workers by one of their labels. NotIn and DoesNotExist keep containers away from
+// workers, which is how anti-affinity to labels is expressed.
+type WorkerLabelSelector struct {
+	Key      string                `json:"key"`
+	Operator LabelSelectorOperator `json:"operator"`
+	Values   []string              `json:"values,omitempty"`
+	Weight   int32                 `json:"weight,omitempty"` // Only used by preferred selectors
+}
+
+func (s WorkerLabelSelector) Validate() error {
+	if s.Key == "" {
+		return errors.New("affinity selectors require a label key")
+	}
+
+	switch s.Operator {
+	case LabelSelectorIn, LabelSelectorNotIn:
+		if len(s.Values) == 0 {
+			return fmt.Errorf("affinity selector on %s requires values for %s", s.Key, s.Operator)
+		}
+	case LabelSelectorExists, LabelSelectorDoesNotExist:
+		if len(s.Values) > 0 {
+			return fmt.Errorf("affinity selector on %s can't have values for %s", s.Key, s.Operator)
+		}
+	default:
+		return fmt.Errorf("invalid affinity operator: %s", s.Operator)
+	}
+
+	if s.Weight < 0 {
+		return fmt.Errorf("affinity selector on %s can't have a negative weight", s.Key)
+	}
+
+	return nil
+}
+
+// Matches checks the selector against labels looked up with label
+func (s WorkerLabelSelector) Matches(label func(key string) (string, bool)) bool {
+	value, ok := label(s.Key)
+
+	switch s.Operator {
+	case LabelSelectorIn:
+		return ok && slices.Contains(s.Values, value)
+	case LabelSelectorNotIn:
+		return !ok || !slices.Contains(s.Values, value)
+	case LabelSelectorExists:
+		return ok
+	case LabelSelectorDoesNotExist:
+		return !ok
+	}
+
+	return false
+}
+
+// WorkerAffinity constrains which workers a stub's containers are placed on. Workers must match every required
+// selector, and matching preferred selectors raises their score. SpreadBy names a label whose values are the
+// domains replicas are spread across, e.g. machine or a zone label, so replicas prefer domains that have fewer
+// of them. With SpreadRequired, a domain never gets a second replica.
+type WorkerAffinity struct {
+	Required       []WorkerLabelSelector `json:"required,omitempty"`
+	Preferred      []WorkerLabelSelector `json:"preferred,omitempty"`
+	SpreadBy       string                `json:"spread_by,omitempty"`
+	SpreadRequired bool                  `json:"spread_required,omitempty"`
+}
+
+func NewWorkerAffinityFromProto(in *pb.WorkerAffinity) *WorkerAffinity {
+	if in == nil || (len(in.Required) == 0 && len(in.Preferred) == 0 && in.SpreadBy == "") {
+		return nil
+	}
+
+	affinity := &WorkerAffinity{
+		SpreadBy:       in.SpreadBy,
+		SpreadRequired: in.SpreadRequired,
+	}
+
+	for _, s := range in.Required {
+		affinity.Required = append(affinity.Required, newWorkerLabelSelectorFromProto(s))
+	}
+
+	for _, s := range in.Preferred {
+		affinity.Preferred = append(affinity.Preferred, newWorkerLabelSelectorFromProto(s))
+	}
+
+	return affinity
+}
+
+func newWorkerLabelSelectorFromProto(in *pb.WorkerLabelSelector) WorkerLabelSelector {
+	operator := LabelSelectorOperator(in.Operator)
+	if operator == "" {
+		operator = LabelSelectorIn
+	}
+
+	return WorkerLabelSelector{
+		Key:      in.Key,
+		Operator: operator,
+		Values:   in.Values,
+		Weight:   in.Weight,
+	}
+}
+
+func (a *WorkerAffinity) Validate() error {
+	for _, s := range append(slices.Clone(a.Required), a.Preferred...) {
+		if err := s.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if a.SpreadRequired && a.SpreadBy == "" {
+		return errors.New("spread_required requires spread_by")
+	}
+
+	return nil
+}
+
+// Allows reports whether labels looked up with label match every required selector
+func (a *WorkerAffinity) Allows(label func(key string) (string, bool)) bool {
+	if a == nil {
+		return true
+	}
+
+	for _, s := range a.Required {
+		if !s.Matches(label) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Score is the sum of the weights of the preferred selectors labels looked up with label match
+func (a *WorkerAffinity) Score(label func(key string) (string, bool)) int32 {
+	if a == nil {
+		return 0
+	}
+
+	score := int32(0)
+	for _, s := range a.Preferred {
+		if !s.Matches(label) {
+			continue
+		}
+
+		if s.Weight == 0 {
+			score += DefaultAffinityWeight
+		} else {
+			score += s.Weight
+		}
+	}
+
+	return score
+}
@@ -0,0 +1,64 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkerLabels(t *testing.T) {
+	labels := NewWorkerLabels(map[string]string{"zone": "us-east-1a", "disk": "nvme"})
+	assert.Equal(t, WorkerLabels("disk=nvme,zone=us-east-1a"), labels)
+	assert.Equal(t, map[string]string{"zone": "us-east-1a", "disk": "nvme"}, labels.Map())
+	assert.Equal(t, map[string]string{}, WorkerLabels("").Map())
+
+	assert.NoError(t, ValidateWorkerLabel("zone", "us-east-1a"))
+	assert.Error(t, ValidateWorkerLabel("", "x"))
+	assert.Error(t, ValidateWorkerLabel("zone", "a,b"))
+	assert.Error(t, ValidateWorkerLabel(WorkerLabelPool, "default"))
+
+	worker := &Worker{Id: "w1", PoolName: "default", Gpu: "A10G", Labels: labels}
+	zone, ok := worker.Label("zone")
+	assert.True(t, ok)
+	assert.Equal(t, "us-east-1a", zone)
+
+	machine, ok := worker.Label(WorkerLabelMachine)
+	assert.True(t, ok)
+	assert.Equal(t, "w1", machine)
+
+	_, ok = worker.Label("rack")
+	assert.False(t, ok)
+}
+
+func TestWorkerAffinity(t *testing.T) {
+	east := &Worker{Id: "w1", PoolName: "default", Labels: "disk=nvme,zone=us-east-1a"}
+	west := &Worker{Id: "w2", PoolName: "default", Labels: "zone=us-west-2a"}
+	spot := &Worker{Id: "w3", PoolName: "spot", Labels: "zone=us-east-1b"}
+
+	affinity := &WorkerAffinity{
+		Required: []WorkerLabelSelector{
+			{Key: "zone", Operator: LabelSelectorExists},
+			{Key: WorkerLabelPool, Operator: LabelSelectorNotIn, Values: []string{"spot"}},
+		},
+		Preferred: []WorkerLabelSelector{
+			{Key: "zone", Operator: LabelSelectorIn, Values: []string{"us-east-1a"}},
+			{Key: "disk", Operator: LabelSelectorExists, Weight: 3},
+		},
+	}
+	assert.NoError(t, affinity.Validate())
+
+	assert.True(t, affinity.Allows(east.Label))
+	assert.True(t, affinity.Allows(west.Label))
+	assert.False(t, affinity.Allows(spot.Label))
+
+	assert.Equal(t, DefaultAffinityWeight+3, affinity.Score(east.Label))
+	assert.Equal(t, int32(0), affinity.Score(west.Label))
+
+	var none *WorkerAffinity
+	assert.True(t, none.Allows(spot.Label))
+	assert.Equal(t, int32(0), none.Score(spot.Label))
+
+	assert.Error(t, (&WorkerAffinity{Required: []WorkerLabelSelector{{Key: "zone", Operator: LabelSelectorIn}}}).Validate())
+	assert.Error(t, (&WorkerAffinity{Preferred: []WorkerLabelSelector{{Key: "zone", Operator: "Near"}}}).Validate())
+	assert.Error(t, (&WorkerAffinity{SpreadRequired: true}).Validate())
+}
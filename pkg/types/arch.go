@@ -0,0 +1,65 @@
+package types
+
+import (
+	"fmt"
+	"slices"
+)
+
+// CPU architectures workers run on and images are built for, named like GOARCH
+const (
+	ArchAmd64 = "amd64"
+	ArchArm64 = "arm64"
+
+	// Images built before architectures were tracked, and workers that haven't reported theirs, are amd64
+	DefaultArch = ArchAmd64
+)
+
+var supportedArchitectures = []string{ArchAmd64, ArchArm64}
+
+// ValidateArchitectures checks the architectures an image is built for are ones workers run on
+func ValidateArchitectures(architectures []string) error {
+	for _, arch := range architectures {
+		if !slices.Contains(supportedArchitectures, arch) {
+			return fmt.Errorf("unsupported architecture %s, must be one of %v", arch, supportedArchitectures)
+		}
+	}
+
+	return nil
+}
+
+// ImageArchitectures returns the architectures of an image, defaulting to amd64 for images that don't list any
+func ImageArchitectures(architectures []string) []string {
+	if len(architectures) == 0 {
+		return []string{DefaultArch}
+	}
+
+	return architectures
+}
+
+// ImageIdForArch is the ID of the archive of an image built for an architecture. Images are identified by their
+// build options alone, so amd64 archives keep the image's ID and other architectures are stored beside them.
+func ImageIdForArch(imageId, arch string) string {
+	if arch == "" || arch == DefaultArch {
+		return imageId
+	}
+
+	return fmt.Sprintf("%s-%s", imageId, arch)
+}
+
+// WorkerArch returns the architecture of a worker, defaulting to amd64 for workers that haven't reported one
+func (w *Worker) WorkerArch() string {
+	if w.Arch == "" {
+		return DefaultArch
+	}
+
+	return w.Arch
+}
+
+// SupportsArch reports whether the request's image is built for the architecture
+func (c *ContainerRequest) SupportsArch(arch string) bool {
+	if arch == "" {
+		arch = DefaultArch
+	}
+
+	return slices.Contains(ImageArchitectures(c.Architectures), arch)
+}
@@ -0,0 +1,30 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImageArchitectures(t *testing.T) {
+	assert.NoError(t, ValidateArchitectures(nil))
+	assert.NoError(t, ValidateArchitectures([]string{ArchAmd64, ArchArm64}))
+	assert.Error(t, ValidateArchitectures([]string{"riscv64"}))
+
+	assert.Equal(t, "abc", ImageIdForArch("abc", ""))
+	assert.Equal(t, "abc", ImageIdForArch("abc", ArchAmd64))
+	assert.Equal(t, "abc-arm64", ImageIdForArch("abc", ArchArm64))
+
+	// Images that don't list architectures only run on amd64 workers
+	legacy := &ContainerRequest{}
+	assert.True(t, legacy.SupportsArch(""))
+	assert.True(t, legacy.SupportsArch(ArchAmd64))
+	assert.False(t, legacy.SupportsArch(ArchArm64))
+
+	arm := &ContainerRequest{Architectures: []string{ArchArm64}}
+	assert.False(t, arm.SupportsArch(""))
+	assert.True(t, arm.SupportsArch(ArchArm64))
+
+	assert.Equal(t, ArchAmd64, (&Worker{}).WorkerArch())
+	assert.Equal(t, ArchArm64, (&Worker{Arch: ArchArm64}).WorkerArch())
+}
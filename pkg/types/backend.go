@@ -4,7 +4,13 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"net"
+	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -71,6 +77,12 @@ type Workspace struct {
 	ConcurrencyLimit   *ConcurrencyLimit `db:"concurrency_limit" json:"concurrency_limit" serializer:"concurrency_limit,omitempty"`
 	StorageId          *uint             `db:"storage_id" json:"storage_id,omitempty" serializer:"storage_id,from:storage.external_id,omitempty"`
 	Storage            *WorkspaceStorage `db:"storage" json:"storage" serializer:"storage,omitempty"`
+	// ReplicaStorageId is the secondary storage objects are replicated to, if any
+	ReplicaStorageId *uint `db:"replica_storage_id" json:"replica_storage_id,omitempty"`
+	// UploadBandwidthLimit overrides the gateway's per-workspace upload limit in bytes per second, 0 disables it
+	UploadBandwidthLimit *int64 `db:"upload_bandwidth_limit" json:"upload_bandwidth_limit,omitempty"`
+	// VolumeQuotaBytes overrides the gateway's limit on the total size of the workspace's volumes, 0 removes it
+	VolumeQuotaBytes *int64 `db:"volume_quota_bytes" json:"volume_quota_bytes,omitempty"`
 }
 
 func (w *Workspace) StorageAvailable() bool {
@@ -132,10 +144,26 @@ type WorkspaceStorage struct {
 	SecretKey   *string    `db:"secret_key" json:"secret_key" encrypt:"true"`
 	EndpointUrl *string    `db:"endpoint_url" json:"endpoint_url"`
 	Region      *string    `db:"region" json:"region"`
+	Provider    *string    `db:"provider" json:"provider"`
 	CreatedAt   *time.Time `db:"created_at" json:"created_at,omitempty"`
 	UpdatedAt   *time.Time `db:"updated_at" json:"updated_at,omitempty"`
 }
 
+const (
+	WorkspaceStorageProviderS3    string = "s3"
+	WorkspaceStorageProviderGCS   string = "gcs"
+	WorkspaceStorageProviderAzure string = "azure"
+)
+
+// StorageProvider returns which kind of object store the workspace storage lives in. Storage created
+// before providers were recorded is S3 compatible.
+func (w *WorkspaceStorage) StorageProvider() string {
+	if w == nil || w.Provider == nil || *w.Provider == "" {
+		return WorkspaceStorageProviderS3
+	}
+	return *w.Provider
+}
+
 func NewWorkspaceStorageFromProto(in *pb.WorkspaceStorage) *WorkspaceStorage {
 	id := uint(in.Id)
 	createdAt := in.CreatedAt.AsTime()
@@ -149,6 +177,7 @@ func NewWorkspaceStorageFromProto(in *pb.WorkspaceStorage) *WorkspaceStorage {
 		SecretKey:   &in.SecretKey,
 		EndpointUrl: &in.EndpointUrl,
 		Region:      &in.Region,
+		Provider:    &in.Provider,
 		CreatedAt:   &createdAt,
 		UpdatedAt:   &updatedAt,
 	}
@@ -163,6 +192,7 @@ func (w *WorkspaceStorage) ToProto() *pb.WorkspaceStorage {
 		SecretKey:   *w.SecretKey,
 		Region:      *w.Region,
 		EndpointUrl: *w.EndpointUrl,
+		Provider:    w.StorageProvider(),
 		CreatedAt:   timestamppb.New(*w.CreatedAt),
 		UpdatedAt:   timestamppb.New(*w.UpdatedAt),
 	}
@@ -175,20 +205,262 @@ const (
 	TokenTypeWorker              string = "worker"
 	TokenTypeMachine             string = "machine"
 	TokenTypeWorkspaceRestricted string = "workspace_restricted"
+	TokenTypeServiceAccount      string = "service_account"
 )
 
 type Token struct {
-	Id                     uint       `db:"id" json:"id" serializer:"id,source:external_id"`
-	ExternalId             string     `db:"external_id" json:"external_id" serializer:"external_id"`
-	Key                    string     `db:"key" json:"key" serializer:"key"`
-	Active                 bool       `db:"active" json:"active" serializer:"active"`
-	Reusable               bool       `db:"reusable" json:"reusable" serializer:"reusable"`
-	WorkspaceId            *uint      `db:"workspace_id" json:"workspace_id,omitempty"`                            // Foreign key to Workspace
-	Workspace              *Workspace `db:"workspace" json:"workspace,omitempty" serializer:"workspace,omitempty"` // Pointer to associated Workspace
-	TokenType              string     `db:"token_type" json:"token_type" serializer:"token_type"`
-	CreatedAt              Time       `db:"created_at" json:"created_at" serializer:"created_at"`
-	UpdatedAt              Time       `db:"updated_at" json:"updated_at" serializer:"updated_at"`
-	DisabledByClusterAdmin bool       `db:"disabled_by_cluster_admin" json:"disabled_by_cluster_admin" serializer:"disabled_by_cluster_admin"`
+	Id                     uint        `db:"id" json:"id" serializer:"id,source:external_id"`
+	ExternalId             string      `db:"external_id" json:"external_id" serializer:"external_id"`
+	Key                    string      `db:"key" json:"key" serializer:"key"`
+	Active                 bool        `db:"active" json:"active" serializer:"active"`
+	Reusable               bool        `db:"reusable" json:"reusable" serializer:"reusable"`
+	WorkspaceId            *uint       `db:"workspace_id" json:"workspace_id,omitempty"`                            // Foreign key to Workspace
+	Workspace              *Workspace  `db:"workspace" json:"workspace,omitempty" serializer:"workspace,omitempty"` // Pointer to associated Workspace
+	TokenType              string      `db:"token_type" json:"token_type" serializer:"token_type"`
+	CreatedAt              Time        `db:"created_at" json:"created_at" serializer:"created_at"`
+	UpdatedAt              Time        `db:"updated_at" json:"updated_at" serializer:"updated_at"`
+	DisabledByClusterAdmin bool        `db:"disabled_by_cluster_admin" json:"disabled_by_cluster_admin" serializer:"disabled_by_cluster_admin"`
+	Role                   string      `db:"role" json:"role" serializer:"role"`
+	Scopes                 TokenScopes `db:"scopes" json:"scopes" serializer:"scopes"`
+	ExpiresAt              NullTime    `db:"expires_at" json:"expires_at" serializer:"expires_at"`
+	ServiceAccountId       *uint       `db:"service_account_id" json:"service_account_id,omitempty"`
+	RateLimit              *RateLimit  `db:"rate_limit" json:"rate_limit,omitempty"`
+}
+
+// Expired reports whether the token had an expiry set and it has passed
+func (t *Token) Expired() bool {
+	return t.ExpiresAt.Valid && !t.ExpiresAt.Time.After(time.Now())
+}
+
+// WorkspaceRole returns the role the token acts with in its workspace. Tokens cached before roles were
+// added don't carry one, and like every token created before then they're admins.
+func (t *Token) WorkspaceRole() WorkspaceRole {
+	if t.Role == "" {
+		return WorkspaceRoleAdmin
+	}
+
+	return WorkspaceRole(t.Role)
+}
+
+// WorkspaceRole decides which permissions a token has in its workspace
+type WorkspaceRole string
+
+const (
+	WorkspaceRoleAdmin      WorkspaceRole = "admin"
+	WorkspaceRoleDeveloper  WorkspaceRole = "developer"
+	WorkspaceRoleReadOnly   WorkspaceRole = "read_only"
+	WorkspaceRoleDeployOnly WorkspaceRole = "deploy_only"
+)
+
+type Permission string
+
+const (
+	// PermissionRead views the workspace's resources
+	PermissionRead Permission = "read"
+	// PermissionWrite creates, changes and removes objects, volumes, secrets, tasks and containers
+	PermissionWrite Permission = "write"
+	// PermissionDeploy uploads code and manages deployments and their policies
+	PermissionDeploy Permission = "deploy"
+	// PermissionAdmin manages tokens, member roles, machines and workers
+	PermissionAdmin Permission = "admin"
+)
+
+var workspaceRolePermissions = map[WorkspaceRole][]Permission{
+	WorkspaceRoleAdmin:      {PermissionRead, PermissionWrite, PermissionDeploy, PermissionAdmin},
+	WorkspaceRoleDeveloper:  {PermissionRead, PermissionWrite, PermissionDeploy},
+	WorkspaceRoleDeployOnly: {PermissionDeploy},
+	WorkspaceRoleReadOnly:   {PermissionRead},
+}
+
+func (r WorkspaceRole) IsValid() bool {
+	_, ok := workspaceRolePermissions[r]
+	return ok
+}
+
+func (r WorkspaceRole) Can(permission Permission) bool {
+	return slices.Contains(workspaceRolePermissions[r], permission)
+}
+
+// TokenScope narrows a token to one kind of resource in its workspace. Scopes are written as
+// "<resource>:<access>", e.g. objects:write, and higher access includes the lower ones.
+type TokenScope string
+
+type TokenScopeResource string
+
+const (
+	TokenScopeResourceObjects     TokenScopeResource = "objects"
+	TokenScopeResourceVolumes     TokenScopeResource = "volumes"
+	TokenScopeResourceSecrets     TokenScopeResource = "secrets"
+	TokenScopeResourceTasks       TokenScopeResource = "tasks"
+	TokenScopeResourceDeployments TokenScopeResource = "deployments"
+	TokenScopeResourceContainers  TokenScopeResource = "containers"
+	TokenScopeResourceImages      TokenScopeResource = "images"
+	TokenScopeResourceWorkspace   TokenScopeResource = "workspace"
+)
+
+var tokenScopeResources = []TokenScopeResource{
+	TokenScopeResourceObjects,
+	TokenScopeResourceVolumes,
+	TokenScopeResourceSecrets,
+	TokenScopeResourceTasks,
+	TokenScopeResourceDeployments,
+	TokenScopeResourceContainers,
+	TokenScopeResourceImages,
+	TokenScopeResourceWorkspace,
+}
+
+type TokenScopeAccess string
+
+const (
+	TokenScopeAccessRead  TokenScopeAccess = "read"
+	TokenScopeAccessWrite TokenScopeAccess = "write"
+	TokenScopeAccessAdmin TokenScopeAccess = "admin"
+)
+
+var tokenScopeAccessLevels = map[TokenScopeAccess]int{
+	TokenScopeAccessRead:  1,
+	TokenScopeAccessWrite: 2,
+	TokenScopeAccessAdmin: 3,
+}
+
+func NewTokenScope(resource TokenScopeResource, access TokenScopeAccess) TokenScope {
+	return TokenScope(string(resource) + ":" + string(access))
+}
+
+// ParseTokenScope validates a scope given by a user
+func ParseTokenScope(scope string) (TokenScope, error) {
+	resource, access, ok := strings.Cut(strings.TrimSpace(scope), ":")
+	if !ok || !slices.Contains(tokenScopeResources, TokenScopeResource(resource)) {
+		return "", fmt.Errorf("invalid token scope: %q", scope)
+	}
+
+	if _, ok := tokenScopeAccessLevels[TokenScopeAccess(access)]; !ok {
+		return "", fmt.Errorf("invalid token scope: %q", scope)
+	}
+
+	return NewTokenScope(TokenScopeResource(resource), TokenScopeAccess(access)), nil
+}
+
+func (s TokenScope) Resource() TokenScopeResource {
+	resource, _, _ := strings.Cut(string(s), ":")
+	return TokenScopeResource(resource)
+}
+
+func (s TokenScope) Access() TokenScopeAccess {
+	_, access, _ := strings.Cut(string(s), ":")
+	return TokenScopeAccess(access)
+}
+
+// Includes reports whether holding this scope grants the required one
+func (s TokenScope) Includes(required TokenScope) bool {
+	return s.Resource() == required.Resource() && tokenScopeAccessLevels[s.Access()] >= tokenScopeAccessLevels[required.Access()]
+}
+
+// TokenScopes are the scopes a token was created with, stored as a JSONB array. Tokens without scopes
+// aren't narrowed beyond their role.
+type TokenScopes []TokenScope
+
+// ParseTokenScopes validates and deduplicates the scopes a token is created with
+func ParseTokenScopes(scopes []string) (TokenScopes, error) {
+	parsed := TokenScopes{}
+	for _, scope := range scopes {
+		s, err := ParseTokenScope(scope)
+		if err != nil {
+			return nil, err
+		}
+
+		if !slices.Contains(parsed, s) {
+			parsed = append(parsed, s)
+		}
+	}
+
+	return parsed, nil
+}
+
+func (s TokenScopes) Restricted() bool {
+	return len(s) > 0
+}
+
+// Allows reports whether the scopes grant any of the required scopes
+func (s TokenScopes) Allows(required ...TokenScope) bool {
+	if !s.Restricted() {
+		return true
+	}
+
+	for _, scope := range s {
+		for _, r := range required {
+			if scope.Includes(r) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (s TokenScopes) Strings() []string {
+	scopes := make([]string, 0, len(s))
+	for _, scope := range s {
+		scopes = append(scopes, string(scope))
+	}
+	return scopes
+}
+
+func (s *TokenScopes) Scan(value interface{}) error {
+	if value == nil {
+		*s = nil
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(bytes, s)
+}
+
+func (s TokenScopes) Value() (driver.Value, error) {
+	if s == nil {
+		return []byte("[]"), nil
+	}
+
+	return json.Marshal(s)
+}
+
+// TokenSession is a short-lived session started from a workspace token, for clients like the dashboard that
+// shouldn't hold the token itself. Its access and refresh tokens act as TokenKey narrowed to Scopes.
+type TokenSession struct {
+	TokenKey    string      `json:"token_key"`
+	WorkspaceId string      `json:"workspace_id"`
+	Scopes      TokenScopes `json:"scopes"`
+	// Id of the only refresh token that can still be used, the ones before it were spent
+	RefreshId string    `json:"refresh_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// RateLimit caps how fast a token can make requests. Tokens without their own limit get the gateway's default.
+type RateLimit struct {
+	RequestsPerSecond    float64 `json:"requests_per_second"`
+	Burst                int     `json:"burst"`
+	MaxConcurrentStreams int     `json:"max_concurrent_streams"`
+}
+
+func (l *RateLimit) Scan(value interface{}) error {
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(bytes, l)
+}
+
+func (l *RateLimit) Value() (driver.Value, error) {
+	if l == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(l)
 }
 
 type Volume struct {
@@ -197,15 +469,195 @@ type Volume struct {
 	Name        string `db:"name" json:"name"`
 	Size        uint64 `json:"size"`                           // Populated by volume abstraction
 	WorkspaceId uint   `db:"workspace_id" json:"workspace_id"` // Foreign key to Workspace
+	QuotaBytes  int64  `db:"quota_bytes" json:"quota_bytes"`   // 0 means unlimited
 	CreatedAt   Time   `db:"created_at" json:"created_at"`
 	UpdatedAt   Time   `db:"updated_at" json:"updated_at"`
 }
 
+type VolumeUsage struct {
+	UsedBytes int64 `json:"used_bytes"`
+	FileCount int64 `json:"file_count"`
+}
+
+// ResourceAccess is what an access control entry lets its principal do with a resource
+type ResourceAccess string
+
+const (
+	ResourceAccessRead  ResourceAccess = "read"
+	ResourceAccessWrite ResourceAccess = "write"
+)
+
+func (a ResourceAccess) IsValid() bool {
+	return a == ResourceAccessRead || a == ResourceAccessWrite
+}
+
+// Allows reports whether this access includes want. Write access includes read.
+func (a ResourceAccess) Allows(want ResourceAccess) bool {
+	return a == want || a == ResourceAccessWrite
+}
+
+const (
+	ACLResourceObject = "object"
+	ACLResourceVolume = "volume"
+
+	ACLPrincipalToken = "token"
+	ACLPrincipalRole  = "role"
+)
+
+// AccessControlEntry grants a token, or every token with a role, access to one object or volume. Resources
+// without entries can be used by the whole workspace.
+type AccessControlEntry struct {
+	Id            uint           `db:"id" json:"id"`
+	WorkspaceId   uint           `db:"workspace_id" json:"workspace_id"`
+	ObjectId      *uint          `db:"object_id" json:"object_id,omitempty"`
+	VolumeId      *uint          `db:"volume_id" json:"volume_id,omitempty"`
+	PrincipalType string         `db:"principal_type" json:"principal_type"`
+	Principal     string         `db:"principal" json:"principal"` // Token external id or role name
+	Access        ResourceAccess `db:"access" json:"access"`
+	CreatedAt     Time           `db:"created_at" json:"created_at"`
+}
+
+// VolumeSnapshot is a point-in-time copy of a volume's contents
+type VolumeSnapshot struct {
+	Id         uint   `db:"id" json:"id"`
+	ExternalId string `db:"external_id" json:"external_id"`
+	VolumeId   uint   `db:"volume_id" json:"volume_id"` // Foreign key to Volume
+	Name       string `db:"name" json:"name"`
+	Size       uint64 `db:"size" json:"size"`
+	FileCount  uint64 `db:"file_count" json:"file_count"`
+	CreatedAt  Time   `db:"created_at" json:"created_at"`
+}
+
 type VolumeWithRelated struct {
 	Volume
 	Workspace Workspace `db:"workspace" json:"workspace"`
 }
 
+// SharedVolume is a volume owned by Workspace that the caller's workspace has been granted access to
+type SharedVolume struct {
+	VolumeWithRelated
+	ExpiresAt NullTime `db:"expires_at" json:"expires_at"`
+}
+
+// ResourceGrantType is the kind of resource a grant gives another workspace access to. Each type allows one
+// kind of access, see Permission.
+type ResourceGrantType string
+
+const (
+	ResourceGrantDeployment ResourceGrantType = "deployment"
+	ResourceGrantVolume     ResourceGrantType = "volume"
+	ResourceGrantImage      ResourceGrantType = "image"
+)
+
+func (t ResourceGrantType) IsValid() bool {
+	switch t {
+	case ResourceGrantDeployment, ResourceGrantVolume, ResourceGrantImage:
+		return true
+	}
+	return false
+}
+
+// Permission is what a grant on this type of resource allows the grantee to do with it
+func (t ResourceGrantType) Permission() string {
+	switch t {
+	case ResourceGrantDeployment:
+		return "invoke"
+	case ResourceGrantVolume:
+		return "read"
+	case ResourceGrantImage:
+		return "pull"
+	}
+	return ""
+}
+
+// ResourceGrant lets another workspace use a resource owned by WorkspaceId, until ExpiresAt when set.
+// ResourceId is a deployment name, volume external id or image id.
+type ResourceGrant struct {
+	Id                 uint              `db:"id" json:"id"`
+	WorkspaceId        uint              `db:"workspace_id" json:"workspace_id"`                 // Foreign key to Workspace
+	GranteeWorkspaceId uint              `db:"grantee_workspace_id" json:"grantee_workspace_id"` // Foreign key to Workspace
+	ResourceType       ResourceGrantType `db:"resource_type" json:"resource_type"`
+	ResourceId         string            `db:"resource_id" json:"resource_id"`
+	ExpiresAt          NullTime          `db:"expires_at" json:"expires_at"`
+	CreatedAt          Time              `db:"created_at" json:"created_at"`
+}
+
+type ResourceGrantWithRelated struct {
+	ResourceGrant
+	Workspace        Workspace `db:"workspace" json:"workspace"`
+	GranteeWorkspace Workspace `db:"grantee_workspace" json:"grantee_workspace"`
+}
+
+// TaskTriggerSignatureScheme is how the service calling a task trigger signs its deliveries
+type TaskTriggerSignatureScheme string
+
+const (
+	// TaskTriggerSignatureBeta9 is how beta9 signs its own webhooks, with X-Beta9-Signature and X-Beta9-Timestamp
+	TaskTriggerSignatureBeta9 TaskTriggerSignatureScheme = "beta9"
+	// TaskTriggerSignatureGitHub is a hex HMAC-SHA256 of the body in X-Hub-Signature-256
+	TaskTriggerSignatureGitHub TaskTriggerSignatureScheme = "github"
+	// TaskTriggerSignatureStripe is a timestamped HMAC-SHA256 in Stripe-Signature
+	TaskTriggerSignatureStripe TaskTriggerSignatureScheme = "stripe"
+	// TaskTriggerSignatureHMACSHA256 is a hex HMAC-SHA256 of the body in X-Signature, for other services
+	TaskTriggerSignatureHMACSHA256 TaskTriggerSignatureScheme = "hmac-sha256"
+)
+
+func (s TaskTriggerSignatureScheme) IsValid() bool {
+	switch s {
+	case TaskTriggerSignatureBeta9, TaskTriggerSignatureGitHub, TaskTriggerSignatureStripe, TaskTriggerSignatureHMACSHA256:
+		return true
+	}
+	return false
+}
+
+// TaskTrigger lets an external service start tasks on a function or task queue deployment by calling a signed
+// webhook url. Tasks run on the latest active version of the deployment.
+type TaskTrigger struct {
+	Id              uint                       `db:"id" json:"id"`
+	ExternalId      string                     `db:"external_id" json:"external_id"`
+	WorkspaceId     uint                       `db:"workspace_id" json:"workspace_id"` // Foreign key to Workspace
+	Name            string                     `db:"name" json:"name"`
+	DeploymentName  string                     `db:"deployment_name" json:"deployment_name"`
+	StubType        string                     `db:"stub_type" json:"stub_type"`
+	SignatureScheme TaskTriggerSignatureScheme `db:"signature_scheme" json:"signature_scheme"`
+	Secret          string                     `db:"secret" json:"-"`
+	// Largest payload accepted, 0 for the gateway's limit
+	MaxPayloadBytes int64                   `db:"max_payload_bytes" json:"max_payload_bytes"`
+	InputMapping    TaskTriggerInputMapping `db:"input_mapping" json:"input_mapping"`
+	CreatedAt       Time                    `db:"created_at" json:"created_at"`
+}
+
+type TaskTriggerWithRelated struct {
+	TaskTrigger
+	Workspace Workspace `db:"workspace" json:"workspace"`
+}
+
+// TaskTriggerInputMapping maps task kwargs to values in webhook deliveries, stored as a JSONB object. Values are
+// dot separated paths into the JSON payload, or header.<name> for request headers.
+type TaskTriggerInputMapping map[string]string
+
+func (m *TaskTriggerInputMapping) Scan(value interface{}) error {
+	if value == nil {
+		*m = nil
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(bytes, m)
+}
+
+func (m TaskTriggerInputMapping) Value() (driver.Value, error) {
+	if m == nil {
+		return []byte("{}"), nil
+	}
+
+	return json.Marshal(m)
+}
+
 type Deployment struct {
 	Id          uint     `db:"id" json:"id" serializer:"id,source:external_id"`
 	ExternalId  string   `db:"external_id" json:"external_id,omitempty" serializer:"external_id"`
@@ -222,6 +674,264 @@ type Deployment struct {
 	AppId       uint     `db:"app_id" json:"app_id,omitempty"` // Foreign key to App
 }
 
+type DeploymentHistoryAction string
+
+const (
+	DeploymentHistoryActionDeploy   DeploymentHistoryAction = "deploy"
+	DeploymentHistoryActionRollback DeploymentHistoryAction = "rollback"
+)
+
+// DeploymentHistory records each version a deployment name was pointed at. A rollback creates a new
+// version that reuses an earlier version's stub, so RolledBackToVersion names the version it restored.
+type DeploymentHistory struct {
+	Id                  uint                    `db:"id" json:"id"`
+	WorkspaceId         uint                    `db:"workspace_id" json:"workspace_id"`
+	Name                string                  `db:"name" json:"name"`
+	StubType            string                  `db:"stub_type" json:"stub_type"`
+	Version             uint                    `db:"version" json:"version"`
+	DeploymentId        uint                    `db:"deployment_id" json:"deployment_id"`
+	StubId              uint                    `db:"stub_id" json:"stub_id"`
+	StubExternalId      string                  `db:"stub_external_id" json:"stub_external_id"`
+	Action              DeploymentHistoryAction `db:"action" json:"action"`
+	RolledBackToVersion *uint                   `db:"rolled_back_to_version" json:"rolled_back_to_version"`
+	CreatedAt           Time                    `db:"created_at" json:"created_at"`
+}
+
+type TrafficSplitStatus string
+
+const (
+	TrafficSplitStatusActive     TrafficSplitStatus = "active"
+	TrafficSplitStatusPromoted   TrafficSplitStatus = "promoted"
+	TrafficSplitStatusRolledBack TrafficSplitStatus = "rolled_back"
+)
+
+// DeploymentTrafficSplit sends a share of a deployment name's unversioned requests to a canary version and
+// the rest to a stable one. A split with an error rate threshold is rolled back to the stable version once
+// the canary has served at least MinRequests and its share of failed requests goes over the threshold.
+type DeploymentTrafficSplit struct {
+	Id                 uint               `db:"id" json:"id"`
+	WorkspaceId        uint               `db:"workspace_id" json:"workspace_id"`
+	Name               string             `db:"name" json:"name"`
+	StubType           string             `db:"stub_type" json:"stub_type"`
+	StableVersion      uint               `db:"stable_version" json:"stable_version"`
+	CanaryVersion      uint               `db:"canary_version" json:"canary_version"`
+	CanaryPercent      uint               `db:"canary_percent" json:"canary_percent"`
+	ErrorRateThreshold float64            `db:"error_rate_threshold" json:"error_rate_threshold"`
+	MinRequests        uint               `db:"min_requests" json:"min_requests"`
+	Status             TrafficSplitStatus `db:"status" json:"status"`
+	CreatedAt          Time               `db:"created_at" json:"created_at"`
+	UpdatedAt          Time               `db:"updated_at" json:"updated_at"`
+}
+
+// PickVersion chooses the version a request is sent to, given a roll in [0, 100)
+func (s *DeploymentTrafficSplit) PickVersion(roll uint) (version uint, canary bool) {
+	if roll < s.CanaryPercent {
+		return s.CanaryVersion, true
+	}
+
+	return s.StableVersion, false
+}
+
+// ErrorRateExceeded reports whether the canary's failures call for rolling back to the stable version
+func (s *DeploymentTrafficSplit) ErrorRateExceeded(requests, failures int64) bool {
+	if s.ErrorRateThreshold <= 0 || requests <= 0 || requests < int64(s.MinRequests) {
+		return false
+	}
+
+	return float64(failures)/float64(requests) > s.ErrorRateThreshold
+}
+
+type CustomDomainStatus string
+
+const (
+	// CustomDomainStatusPending domains are waiting for their ownership to be verified, their DNS to point at
+	// the gateway and a certificate
+	CustomDomainStatusPending CustomDomainStatus = "pending"
+	CustomDomainStatusActive  CustomDomainStatus = "active"
+	CustomDomainStatusFailed  CustomDomainStatus = "failed"
+)
+
+// AuditLogEntry records a mutating gateway RPC: who made it, what it touched and how it turned out.
+// Entries are never updated, only removed once they're older than the retention period.
+type AuditLogEntry struct {
+	Id          uint64           `db:"id" json:"id"`
+	WorkspaceId uint             `db:"workspace_id" json:"workspace_id"`
+	TokenId     string           `db:"token_id" json:"token_id"` // External id of the token the RPC was made with
+	Method      string           `db:"method" json:"method"`
+	ResourceIds AuditResourceIds `db:"resource_ids" json:"resource_ids"`
+	Outcome     string           `db:"outcome" json:"outcome"`
+	StatusCode  string           `db:"status_code" json:"status_code"`
+	Error       string           `db:"error" json:"error"`
+	CreatedAt   Time             `db:"created_at" json:"created_at"`
+}
+
+// AuditResourceIds are the ids an RPC's request referred to, by field name, stored as a JSONB object
+type AuditResourceIds map[string]string
+
+func (r *AuditResourceIds) Scan(value interface{}) error {
+	if value == nil {
+		*r = nil
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(bytes, r)
+}
+
+func (r AuditResourceIds) Value() (driver.Value, error) {
+	if r == nil {
+		return []byte("{}"), nil
+	}
+
+	return json.Marshal(r)
+}
+
+type AuditLogFilter struct {
+	WorkspaceId uint
+	Since       time.Time
+	Until       time.Time
+	TokenId     string
+	Method      string
+	BeforeId    uint64
+	Limit       int
+}
+
+// ServiceAccount is a machine identity owned by a workspace, for CI pipelines and other automation. Its
+// tokens are short lived and carry its role.
+type ServiceAccount struct {
+	Id          uint     `db:"id" json:"id"`
+	ExternalId  string   `db:"external_id" json:"external_id"`
+	WorkspaceId uint     `db:"workspace_id" json:"workspace_id"`
+	Name        string   `db:"name" json:"name"`
+	Role        string   `db:"role" json:"role"`
+	LastUsedAt  NullTime `db:"last_used_at" json:"last_used_at"`
+	CreatedAt   Time     `db:"created_at" json:"created_at"`
+	UpdatedAt   Time     `db:"updated_at" json:"updated_at"`
+}
+
+type WorkspaceInviteStatus string
+
+const (
+	WorkspaceInviteStatusPending  WorkspaceInviteStatus = "pending"
+	WorkspaceInviteStatusAccepted WorkspaceInviteStatus = "accepted"
+	WorkspaceInviteStatusRevoked  WorkspaceInviteStatus = "revoked"
+)
+
+// WorkspaceInvite lets someone join a workspace with a role. Only a hash of the invitation token is stored,
+// the token itself is handed out once when the invitation is created.
+type WorkspaceInvite struct {
+	Id              uint                  `db:"id" json:"id"`
+	ExternalId      string                `db:"external_id" json:"external_id"`
+	WorkspaceId     uint                  `db:"workspace_id" json:"workspace_id"`
+	Email           string                `db:"email" json:"email"`
+	Role            string                `db:"role" json:"role"`
+	TokenHash       string                `db:"token_hash" json:"-"`
+	Status          WorkspaceInviteStatus `db:"status" json:"status"`
+	ExpiresAt       Time                  `db:"expires_at" json:"expires_at"`
+	AcceptedTokenId *uint                 `db:"accepted_token_id" json:"accepted_token_id,omitempty"`
+	CreatedAt       Time                  `db:"created_at" json:"created_at"`
+	UpdatedAt       Time                  `db:"updated_at" json:"updated_at"`
+}
+
+// OIDCIdentity links a user of the OIDC identity provider to the workspace token they were provisioned with
+type OIDCIdentity struct {
+	Id          uint   `db:"id" json:"id"`
+	Issuer      string `db:"issuer" json:"issuer"`
+	Subject     string `db:"subject" json:"subject"`
+	Email       string `db:"email" json:"email"`
+	WorkspaceId uint   `db:"workspace_id" json:"workspace_id"`
+	TokenId     uint   `db:"token_id" json:"token_id"`
+	CreatedAt   Time   `db:"created_at" json:"created_at"`
+	UpdatedAt   Time   `db:"updated_at" json:"updated_at"`
+
+	// Related
+	TokenKey string `db:"token_key" json:"-"`
+}
+
+// CustomDomain routes a domain the workspace owns to a deployment. Like the deployment's subdomain, requests
+// go to the latest version of the deployment. Nothing is routed until the workspace proves it controls the
+// domain by publishing VerificationToken in a TXT record.
+type CustomDomain struct {
+	Id                   uint               `db:"id" json:"id"`
+	ExternalId           string             `db:"external_id" json:"external_id"`
+	WorkspaceId          uint               `db:"workspace_id" json:"workspace_id"`
+	DeploymentId         uint               `db:"deployment_id" json:"deployment_id"`
+	Domain               string             `db:"domain" json:"domain"`
+	Status               CustomDomainStatus `db:"status" json:"status"`
+	LastError            string             `db:"last_error" json:"last_error"`
+	VerificationToken    string             `db:"verification_token" json:"-"`
+	VerifiedAt           NullTime           `db:"verified_at" json:"verified_at"`
+	CertificateExpiresAt NullTime           `db:"certificate_expires_at" json:"certificate_expires_at"`
+	CreatedAt            Time               `db:"created_at" json:"created_at"`
+	UpdatedAt            Time               `db:"updated_at" json:"updated_at"`
+
+	// Related
+	DeploymentExternalId string `db:"deployment_external_id" json:"deployment_external_id"`
+	Subdomain            string `db:"subdomain" json:"subdomain"`
+}
+
+// customDomainVerificationPrefix is the label the TXT record verifying a custom domain lives under
+const customDomainVerificationPrefix = "_beta9-verification."
+
+// VerificationRecord is the name of the TXT record that has to hold the domain's verification token
+func (d *CustomDomain) VerificationRecord() string {
+	return customDomainVerificationPrefix + d.Domain
+}
+
+// MatchesVerification reports whether any of the TXT records published for the domain holds its verification
+// token
+func (d *CustomDomain) MatchesVerification(records []string) bool {
+	if d.VerificationToken == "" {
+		return false
+	}
+
+	for _, record := range records {
+		if strings.TrimSpace(record) == d.VerificationToken {
+			return true
+		}
+	}
+
+	return false
+}
+
+var domainLabelRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// NormalizeCustomDomain lowercases a domain and checks it can be attached to a deployment. Domains under the
+// gateway's own base domain are rejected, since those are deployment subdomains.
+func NormalizeCustomDomain(domain, baseDomain string) (string, error) {
+	domain = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+	baseDomain = strings.ToLower(baseDomain)
+
+	if domain == "" || len(domain) > 253 {
+		return "", fmt.Errorf("invalid domain: %q", domain)
+	}
+
+	labels := strings.Split(domain, ".")
+	if len(labels) < 2 {
+		return "", fmt.Errorf("domain must be fully qualified: %s", domain)
+	}
+
+	for _, label := range labels {
+		if !domainLabelRegex.MatchString(label) {
+			return "", fmt.Errorf("invalid domain: %s", domain)
+		}
+	}
+
+	if net.ParseIP(domain) != nil {
+		return "", fmt.Errorf("domain can't be an IP address: %s", domain)
+	}
+
+	if baseDomain != "" && (domain == baseDomain || strings.HasSuffix(domain, "."+baseDomain)) {
+		return "", fmt.Errorf("domain can't be under %s", baseDomain)
+	}
+
+	return domain, nil
+}
+
 type DeploymentWithRelated struct {
 	Deployment
 	Workspace   Workspace `db:"workspace" json:"workspace" serializer:"workspace"`
@@ -230,35 +940,311 @@ type DeploymentWithRelated struct {
 	StubId      string    `serializer:"stub_id,source:stub.id"`
 	AppId       string    `serializer:"app_id,source:app.id"`
 	WorkspaceId string    `serializer:"workspace_id,source:workspace.id"`
+
+	// Health of the deployment's containers, only set when its stub has a health check
+	Health []ContainerHealth `db:"-" json:"health,omitempty" serializer:"health,omitempty"`
+}
+
+// DeploymentStats summarizes a deployment's current activity
+type DeploymentStats struct {
+	StubId           uint     `db:"stub_id" json:"stub_id"`
+	ActiveContainers int      `json:"active_containers" serializer:"active_containers"`
+	PendingTasks     int      `db:"pending_tasks" json:"pending_tasks" serializer:"pending_tasks"`
+	LastInvokedAt    NullTime `db:"last_invoked_at" json:"last_invoked_at" serializer:"last_invoked_at"`
 }
 
 // @go2proto
 type Object struct {
-	Id          uint   `db:"id" json:"id" serializer:"id,source:external_id"`
-	ExternalId  string `db:"external_id" json:"external_id,omitempty" serializer:"external_id"`
-	Hash        string `db:"hash" json:"hash" serializer:"hash"`
-	Size        int64  `db:"size" json:"size" serializer:"size"`
-	WorkspaceId uint   `db:"workspace_id" json:"workspace_id"` // Foreign key to Workspace
+	Id          uint              `db:"id" json:"id" serializer:"id,source:external_id"`
+	ExternalId  string            `db:"external_id" json:"external_id,omitempty" serializer:"external_id"`
+	Hash        string            `db:"hash" json:"hash" serializer:"hash"`
+	Size        int64             `db:"size" json:"size" serializer:"size"`
+	WorkspaceId uint              `db:"workspace_id" json:"workspace_id"` // Foreign key to Workspace
+	CreatedAt   Time              `db:"created_at" json:"created_at"`
+	RetainUntil NullTime          `db:"retain_until" json:"retain_until" serializer:"retain_until"`
+	Compression ObjectCompression `db:"compression" json:"compression" serializer:"compression"`
+	StoredSize  int64             `db:"stored_size" json:"stored_size" serializer:"stored_size"`
+	Incomplete  bool              `db:"incomplete" json:"incomplete" serializer:"incomplete"`
+	Key         *string           `db:"key" json:"key,omitempty" serializer:"key,omitempty"`
+	Region      string            `db:"region" json:"region" serializer:"region"`
+	Digest      string            `db:"digest" json:"digest" serializer:"digest"`
+	BlobId      *uint             `db:"blob_id" json:"blob_id,omitempty"` // Foreign key to ObjectBlob
+	LastUsedAt  Time              `db:"last_used_at" json:"last_used_at" serializer:"last_used_at"`
+	Tags        ObjectTags        `db:"tags" json:"tags,omitempty" serializer:"tags,omitempty"`
+	Location    ObjectLocation    `db:"location" json:"location" serializer:"location"`
+	Encryption  ObjectEncryption  `db:"encryption" json:"encryption" serializer:"encryption"`
+	// WrappedDataKey is the workspace data key the object was encrypted with, wrapped by the master key
+	WrappedDataKey string `db:"wrapped_data_key" json:"wrapped_data_key,omitempty"`
+}
+
+// ObjectTags are user defined labels on an object, stored as a JSONB object
+type ObjectTags map[string]string
+
+func (t *ObjectTags) Scan(value interface{}) error {
+	if value == nil {
+		*t = nil
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(bytes, t)
+}
+
+func (t ObjectTags) Value() (driver.Value, error) {
+	if t == nil {
+		return []byte("{}"), nil
+	}
+
+	return json.Marshal(t)
+}
+
+// IsLocked reports whether the object is under a write-once retention lock
+func (o *Object) IsLocked() bool {
+	return o.RetainUntil.Valid && time.Now().Before(o.RetainUntil.Time)
+}
+
+// StoredSizeOrSize returns the number of bytes the object occupies in storage.
+// Objects stored uncompressed and unencrypted don't record a separate stored size.
+func (o *Object) StoredSizeOrSize() int64 {
+	if (o.Compression == ObjectCompressionNone && o.Encryption == ObjectEncryptionNone) || o.StoredSize == 0 {
+		return o.Size
+	}
+
+	return o.StoredSize
+}
+
+// ObjectBlob is content shared by every object with the same verified digest, across workspaces.
+// The blob's data is removed once RefCount drops to zero.
+type ObjectBlob struct {
+	Id          uint              `db:"id" json:"id"`
+	Digest      string            `db:"digest" json:"digest"`
+	Size        int64             `db:"size" json:"size"`
+	Compression ObjectCompression `db:"compression" json:"compression"`
+	StoredSize  int64             `db:"stored_size" json:"stored_size"`
+	RefCount    int               `db:"ref_count" json:"ref_count"`
+	CreatedAt   Time              `db:"created_at" json:"created_at"`
+}
+
+// ObjectLifecyclePolicy controls when a workspace's objects expire. A value of 0 disables that rule.
+// RetainVersions bounds how many previous versions are kept per object; nil uses the gateway default.
+type ObjectLifecyclePolicy struct {
+	WorkspaceId           uint `db:"workspace_id" json:"workspace_id"`
+	ExpireAfterDays       int  `db:"expire_after_days" json:"expire_after_days"`
+	ExpireUnusedAfterDays int  `db:"expire_unused_after_days" json:"expire_unused_after_days"`
+	RetainVersions        *int `db:"retain_versions" json:"retain_versions"`
+	CreatedAt             Time `db:"created_at" json:"created_at"`
+	UpdatedAt             Time `db:"updated_at" json:"updated_at"`
+}
+
+// ObjectVersion is a previous content of an object, kept when the object is overwritten
+type ObjectVersion struct {
+	Id             uint              `db:"id" json:"id"`
+	ExternalId     string            `db:"external_id" json:"external_id"`
+	ObjectId       uint              `db:"object_id" json:"object_id"`
+	Hash           string            `db:"hash" json:"hash"`
+	Size           int64             `db:"size" json:"size"`
+	Digest         string            `db:"digest" json:"digest"`
+	Compression    ObjectCompression `db:"compression" json:"compression"`
+	StoredSize     int64             `db:"stored_size" json:"stored_size"`
+	Encryption     ObjectEncryption  `db:"encryption" json:"encryption"`
+	WrappedDataKey string            `db:"wrapped_data_key" json:"-"`
+	CreatedAt      Time              `db:"created_at" json:"created_at"`
+}
+
+// ObjectReplica tracks the copy of an object in the replica storage of a region
+type ObjectReplica struct {
+	Id        uint                `db:"id" json:"id"`
+	ObjectId  uint                `db:"object_id" json:"object_id"`
+	Region    string              `db:"region" json:"region"`
+	Status    ObjectReplicaStatus `db:"status" json:"status"`
+	Error     string              `db:"error" json:"error"`
+	CreatedAt Time                `db:"created_at" json:"created_at"`
+	UpdatedAt Time                `db:"updated_at" json:"updated_at"`
+}
+
+// ObjectWebhook subscribes a URL to a workspace's object lifecycle events
+type ObjectWebhook struct {
+	Id          uint                     `db:"id" json:"id"`
+	ExternalId  string                   `db:"external_id" json:"external_id"`
+	WorkspaceId uint                     `db:"workspace_id" json:"workspace_id"`
+	Url         string                   `db:"url" json:"url"`
+	Secret      string                   `db:"secret" json:"-"`
+	Events      ObjectWebhookEventFilter `db:"events" json:"events"`
+	CreatedAt   Time                     `db:"created_at" json:"created_at"`
+}
+
+// ObjectWebhookEventFilter is the set of events a webhook receives, stored as a JSONB array
+type ObjectWebhookEventFilter []WebhookEvent
+
+func (f *ObjectWebhookEventFilter) Scan(value interface{}) error {
+	if value == nil {
+		*f = nil
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(bytes, f)
+}
+
+func (f ObjectWebhookEventFilter) Value() (driver.Value, error) {
+	if f == nil {
+		return []byte("[]"), nil
+	}
+
+	return json.Marshal(f)
+}
+
+// ObjectWebhookDelivery is a notification queued for a webhook, retried until it's delivered or runs out of attempts
+type ObjectWebhookDelivery struct {
+	Id            uint                        `db:"id" json:"id"`
+	ExternalId    string                      `db:"external_id" json:"external_id"`
+	WebhookId     uint                        `db:"webhook_id" json:"webhook_id"`
+	Event         WebhookEvent                `db:"event" json:"event"`
+	Payload       string                      `db:"payload" json:"payload"`
+	Status        ObjectWebhookDeliveryStatus `db:"status" json:"status"`
+	Attempts      int                         `db:"attempts" json:"attempts"`
+	Error         string                      `db:"error" json:"error"`
+	NextAttemptAt Time                        `db:"next_attempt_at" json:"next_attempt_at"`
+	CreatedAt     Time                        `db:"created_at" json:"created_at"`
+	UpdatedAt     Time                        `db:"updated_at" json:"updated_at"`
+	Webhook       ObjectWebhook               `db:"webhook" json:"webhook"`
+}
+
+// ObjectCorruption records an object whose stored content no longer matches its recorded size or hash
+type ObjectCorruption struct {
+	ObjectId         uint   `db:"object_id" json:"object_id"`
+	ObjectExternalId string `db:"object_external_id" json:"object_external_id"`
+	Hash             string `db:"hash" json:"hash"`
+	WorkspaceId      uint   `db:"workspace_id" json:"workspace_id"`
+	Reason           string `db:"reason" json:"reason"`
+	ExpectedSize     int64  `db:"expected_size" json:"expected_size"`
+	ActualSize       int64  `db:"actual_size" json:"actual_size"`
+	ExpectedDigest   string `db:"expected_digest" json:"expected_digest"`
+	ActualDigest     string `db:"actual_digest" json:"actual_digest"`
+	Quarantined      bool   `db:"quarantined" json:"quarantined"`
+	DetectedAt       Time   `db:"detected_at" json:"detected_at"`
+}
+
+// ObjectUploadSession tracks a resumable upload. Offset is the number of bytes durably
+// received so far; a client that reconnects continues from there. Sessions with a ChunkSize
+// accept chunks of that size in any order, and Offset is the total size of the chunks received.
+type ObjectUploadSession struct {
+	Id          uint   `db:"id" json:"id"`
+	ExternalId  string `db:"external_id" json:"external_id"`
+	WorkspaceId uint   `db:"workspace_id" json:"workspace_id"`
+	Hash        string `db:"hash" json:"hash"`
+	Size        int64  `db:"size" json:"size"`
+	Offset      int64  `db:"offset" json:"offset"`
+	ChunkSize   int64  `db:"chunk_size" json:"chunk_size"`
 	CreatedAt   Time   `db:"created_at" json:"created_at"`
+	UpdatedAt   Time   `db:"updated_at" json:"updated_at"`
+}
+
+// ChunkCount returns the number of chunks a chunked session is made of
+func (s *ObjectUploadSession) ChunkCount() int64 {
+	if s.ChunkSize <= 0 {
+		return 0
+	}
+
+	return (s.Size + s.ChunkSize - 1) / s.ChunkSize
+}
+
+// ChunkLength returns the size the chunk at index must have, the last chunk holding whatever remains
+func (s *ObjectUploadSession) ChunkLength(index int64) int64 {
+	return min(s.ChunkSize, s.Size-index*s.ChunkSize)
+}
+
+// ObjectReferences counts what still depends on an object through the stubs built from it
+type ObjectReferences struct {
+	ActiveDeployments int `db:"active_deployments" json:"active_deployments"`
+	InflightTasks     int `db:"inflight_tasks" json:"inflight_tasks"`
+}
+
+func (r *ObjectReferences) InUse() bool {
+	return r.ActiveDeployments > 0 || r.InflightTasks > 0
+}
+
+type ErrObjectInUse struct {
+	ObjectId   string
+	References ObjectReferences
+}
+
+func (e *ErrObjectInUse) Error() string {
+	return fmt.Sprintf("object %s is used by %d active deployment(s) and %d in-flight task(s)", e.ObjectId, e.References.ActiveDeployments, e.References.InflightTasks)
+}
+
+type ObjectStorageUsage struct {
+	UsedBytes   int64 `db:"used_bytes" json:"used_bytes"`
+	ObjectCount int64 `db:"object_count" json:"object_count"`
+}
+
+type ErrObjectQuotaExceeded struct {
+	UsedBytes  int64
+	QuotaBytes int64
+}
+
+func (e *ErrObjectQuotaExceeded) Error() string {
+	return fmt.Sprintf("object storage quota exceeded: %d of %d bytes used", e.UsedBytes, e.QuotaBytes)
+}
+
+type ErrVolumeQuotaExceeded struct {
+	// Workspace is set when the workspace's quota across all volumes was exceeded rather than the volume's own
+	Workspace  bool
+	UsedBytes  int64
+	QuotaBytes int64
+}
+
+func (e *ErrVolumeQuotaExceeded) Error() string {
+	scope := "volume"
+	if e.Workspace {
+		scope = "workspace volume"
+	}
+
+	return fmt.Sprintf("%s quota exceeded: %d of %d bytes used", scope, e.UsedBytes, e.QuotaBytes)
+}
+
+type ErrObjectLocked struct {
+	ObjectId    string
+	RetainUntil time.Time
+}
+
+func (e *ErrObjectLocked) Error() string {
+	return fmt.Sprintf("object %s is locked until %s", e.ObjectId, e.RetainUntil.Format(time.RFC3339))
 }
 
 func (o *Object) ToProto() *pb.Object {
 	return &pb.Object{
-		Id:          uint32(o.Id),
-		ExternalId:  o.ExternalId,
-		Hash:        o.Hash,
-		Size:        o.Size,
-		WorkspaceId: uint32(o.WorkspaceId),
+		Id:             uint32(o.Id),
+		ExternalId:     o.ExternalId,
+		Hash:           o.Hash,
+		Size:           o.Size,
+		WorkspaceId:    uint32(o.WorkspaceId),
+		Compression:    string(o.Compression),
+		StoredSize:     o.StoredSize,
+		Encryption:     string(o.Encryption),
+		WrappedDataKey: o.WrappedDataKey,
 	}
 }
 
 func NewObjectFromProto(in *pb.Object) *Object {
 	return &Object{
-		Id:          uint(in.Id),
-		ExternalId:  in.ExternalId,
-		Hash:        in.Hash,
-		Size:        in.Size,
-		WorkspaceId: uint(in.WorkspaceId),
+		Id:             uint(in.Id),
+		ExternalId:     in.ExternalId,
+		Hash:           in.Hash,
+		Size:           in.Size,
+		WorkspaceId:    uint(in.WorkspaceId),
+		Compression:    ObjectCompression(in.Compression),
+		StoredSize:     in.StoredSize,
+		Encryption:     ObjectEncryption(in.Encryption),
+		WrappedDataKey: in.WrappedDataKey,
 	}
 }
 
@@ -266,7 +1252,7 @@ type TaskStatus string
 
 func (ts TaskStatus) IsCompleted() bool {
 	switch ts {
-	case TaskStatusComplete, TaskStatusCancelled, TaskStatusError, TaskStatusTimeout, TaskStatusExpired:
+	case TaskStatusComplete, TaskStatusCancelled, TaskStatusError, TaskStatusTimeout, TaskStatusExpired, TaskStatusQuotaExceeded:
 		return true
 	default:
 		return false
@@ -291,6 +1277,9 @@ const (
 	TaskStatusExpired   TaskStatus = "EXPIRED"
 	TaskStatusTimeout   TaskStatus = "TIMEOUT"
 	TaskStatusRetry     TaskStatus = "RETRY"
+
+	// The task couldn't start because its workspace is using all it may of the pools that could run it
+	TaskStatusQuotaExceeded TaskStatus = "QUOTA_EXCEEDED"
 )
 
 type TaskParams struct {
@@ -306,6 +1295,7 @@ type Task struct {
 	ExternalId          string     `db:"external_id" json:"external_id,omitempty" serializer:"external_id"`
 	Status              TaskStatus `db:"status" json:"status,omitempty" serializer:"status"`
 	ContainerId         string     `db:"container_id" json:"container_id,omitempty" serializer:"container_id"`
+	Gpu                 string     `db:"gpu" json:"gpu,omitempty" serializer:"gpu"` // GPU type that served the task
 	StartedAt           NullTime   `db:"started_at" json:"started_at,omitempty" serializer:"started_at"`
 	EndedAt             NullTime   `db:"ended_at" json:"ended_at,omitempty" serializer:"ended_at"`
 	WorkspaceId         uint       `db:"workspace_id" json:"workspace_id,omitempty"`                   // Foreign key to Workspace
@@ -316,6 +1306,18 @@ type Task struct {
 	UpdatedAt           Time       `db:"updated_at" json:"updated_at,omitempty" serializer:"updated_at"`
 }
 
+// TaskAttempt records a failed attempt at running a task and whether the dispatcher retried it
+type TaskAttempt struct {
+	Id          uint              `db:"id" json:"id"`
+	TaskId      uint              `db:"task_id" json:"task_id"`
+	Attempt     uint              `db:"attempt" json:"attempt"`
+	ContainerId string            `db:"container_id" json:"container_id"`
+	ExitCode    *int              `db:"exit_code" json:"exit_code"`
+	Reason      TaskFailureReason `db:"reason" json:"reason"`
+	Retried     bool              `db:"retried" json:"retried"`
+	CreatedAt   Time              `db:"created_at" json:"created_at"`
+}
+
 type TaskWithRelated struct {
 	Task
 	Deployment struct {
@@ -351,6 +1353,30 @@ type TaskOutput struct {
 	Name      string `json:"name" serializer:"name"`
 	URL       string `json:"url" serializer:"url"`
 	ExpiresIn uint32 `json:"expires_in" serializer:"expires_in"`
+	// RetainUntil is when the stub's retention policy deletes the output by age, nil if it isn't aged out
+	RetainUntil *Time `json:"retain_until,omitempty" serializer:"retain_until"`
+}
+
+// OutputRetentionPolicy bounds how much task output a stub keeps in storage. Outputs older than MaxAgeSeconds
+// are deleted, and the oldest outputs beyond MaxCount or MaxTotalBytes are trimmed. A value of 0 disables that rule.
+type OutputRetentionPolicy struct {
+	StubId         uint   `db:"stub_id" json:"stub_id"`
+	StubExternalId string `db:"stub_external_id" json:"stub_external_id"`
+	WorkspaceId    uint   `db:"workspace_id" json:"workspace_id"`
+	MaxAgeSeconds  int64  `db:"max_age_seconds" json:"max_age_seconds"`
+	MaxCount       int    `db:"max_count" json:"max_count"`
+	MaxTotalBytes  int64  `db:"max_total_bytes" json:"max_total_bytes"`
+	CreatedAt      Time   `db:"created_at" json:"created_at"`
+	UpdatedAt      Time   `db:"updated_at" json:"updated_at"`
+}
+
+// RetainUntil returns when an output last modified at modTime expires by age, nil if there's no age limit
+func (p *OutputRetentionPolicy) RetainUntil(modTime time.Time) *Time {
+	if p == nil || p.MaxAgeSeconds <= 0 {
+		return nil
+	}
+
+	return &Time{Time: modTime.Add(time.Duration(p.MaxAgeSeconds) * time.Second)}
 }
 
 type TaskStats struct {
@@ -358,175 +1384,651 @@ type TaskStats struct {
 	QueueDepth       uint32 `json:"queue_depth" serializer:"queue_depth"`
 }
 
-// @go2proto
-type PricingPolicy struct {
-	MaxInFlight           int     `json:"max_in_flight"`
-	CostModel             string  `json:"cost_model"`
-	CostPerTask           float64 `json:"cost_per_task"`
-	CostPerTaskDurationMs float64 `json:"cost_per_task_duration_ms"`
+// @go2proto
+type PricingPolicy struct {
+	MaxInFlight           int     `json:"max_in_flight"`
+	CostModel             string  `json:"cost_model"`
+	CostPerTask           float64 `json:"cost_per_task"`
+	CostPerTaskDurationMs float64 `json:"cost_per_task_duration_ms"`
+}
+
+// @go2proto
+type PricingPolicyCostModel string
+
+const (
+	PricingPolicyCostModelTask     PricingPolicyCostModel = "task"
+	PricingPolicyCostModelDuration PricingPolicyCostModel = "duration"
+)
+
+// @go2proto
+type Checkpoint struct {
+	Id                uint     `db:"id" json:"id" serializer:"id,source:external_id" go2proto:"ignore"`
+	CheckpointId      string   `db:"checkpoint_id" json:"checkpoint_id" serializer:"checkpoint_id"`
+	ExternalId        string   `db:"external_id" json:"external_id,omitempty" serializer:"external_id"`
+	SourceContainerId string   `db:"source_container_id" json:"source_container_id" serializer:"source_container_id"`
+	ContainerIp       string   `db:"container_ip" json:"container_ip" serializer:"container_ip"`
+	Status            string   `db:"status" json:"status" serializer:"status"`
+	RemoteKey         string   `db:"remote_key" json:"remote_key" serializer:"remote_key"`
+	WorkspaceId       uint     `db:"workspace_id" json:"workspace_id" go2proto:"ignore"` // Foreign key to Workspace
+	StubId            uint     `db:"stub_id" json:"stub_id" go2proto:"ignore"`           // Foreign key to Stub
+	StubType          string   `db:"stub_type" json:"stub_type" serializer:"stub_type"`
+	AppId             uint     `db:"app_id" json:"app_id,omitempty" go2proto:"ignore"` // Foreign key to App
+	ExposedPorts      []uint32 `db:"exposed_ports" json:"exposed_ports" serializer:"exposed_ports"`
+	CreatedAt         Time     `db:"created_at" json:"created_at" serializer:"created_at"`
+	LastRestoredAt    Time     `db:"last_restored_at" json:"last_restored_at" serializer:"last_restored_at"`
+	DeletedAt         NullTime `db:"deleted_at" json:"deleted_at" serializer:"deleted_at"`
+}
+
+func (c *Checkpoint) ToProto() *pb.Checkpoint {
+	exposedPorts := make([]uint32, len(c.ExposedPorts))
+	for i, port := range c.ExposedPorts {
+		exposedPorts[i] = port
+	}
+
+	return &pb.Checkpoint{
+		CheckpointId:      c.CheckpointId,
+		ExternalId:        c.ExternalId,
+		SourceContainerId: c.SourceContainerId,
+		ContainerIp:       c.ContainerIp,
+		Status:            c.Status,
+		RemoteKey:         c.RemoteKey,
+		StubType:          c.StubType,
+		ExposedPorts:      exposedPorts,
+		CreatedAt:         timestamppb.New(c.CreatedAt.Time),
+		LastRestoredAt:    timestamppb.New(c.LastRestoredAt.Time),
+	}
+}
+
+func NewCheckpointFromProto(in *pb.Checkpoint) *Checkpoint {
+	exposedPorts := make([]uint32, len(in.ExposedPorts))
+	for i, port := range in.ExposedPorts {
+		exposedPorts[i] = uint32(port)
+	}
+
+	return &Checkpoint{
+		CheckpointId:      in.CheckpointId,
+		ExternalId:        in.ExternalId,
+		SourceContainerId: in.SourceContainerId,
+		ContainerIp:       in.ContainerIp,
+		Status:            in.Status,
+		RemoteKey:         in.RemoteKey,
+		StubType:          in.StubType,
+		ExposedPorts:      exposedPorts,
+		CreatedAt:         Time{Time: in.CreatedAt.AsTime()},
+		LastRestoredAt:    Time{Time: in.LastRestoredAt.AsTime()},
+	}
+}
+
+type StubConfigV1 struct {
+	Runtime            Runtime            `json:"runtime"`
+	Handler            string             `json:"handler"`
+	OnStart            string             `json:"on_start"`
+	OnDeploy           string             `json:"on_deploy"`
+	OnDeployStubId     string             `json:"on_deploy_stub_id"`
+	PythonVersion      string             `json:"python_version"`
+	KeepWarmSeconds    uint               `json:"keep_warm_seconds"`
+	MaxPendingTasks    uint               `json:"max_pending_tasks"`
+	CallbackUrl        string             `json:"callback_url"`
+	TaskPolicy         TaskPolicy         `json:"task_policy"`
+	Workers            uint               `json:"workers"`
+	ConcurrentRequests uint               `json:"concurrent_requests"`
+	Authorized         bool               `json:"authorized"`
+	Volumes            []*pb.Volume       `json:"volumes"`
+	Secrets            []Secret           `json:"secrets,omitempty"`
+	Env                []string           `json:"env,omitempty"`
+	Autoscaler         *Autoscaler        `json:"autoscaler"`
+	Extra              json.RawMessage    `json:"extra"`
+	CheckpointEnabled  bool               `json:"checkpoint_enabled"`
+	WorkDir            string             `json:"work_dir"`
+	EntryPoint         []string           `json:"entry_point"`
+	Ports              []uint32           `json:"ports"`
+	Pricing            *PricingPolicy     `json:"pricing"`
+	Inputs             *Schema            `json:"inputs"`
+	Outputs            *Schema            `json:"outputs"`
+	TCP                bool               `json:"tcp"`
+	BlockNetwork       bool               `json:"block_network"`
+	AllowList          []string           `json:"allow_list"`
+	DockerEnabled      bool               `json:"docker_enabled"`
+	Sidecars           []Sidecar          `json:"sidecars,omitempty"`
+	SecretMountPath    string             `json:"secret_mount_path,omitempty"`
+	HealthCheck        *HealthCheck       `json:"health_check,omitempty"`
+	RequestLimits      *RequestLimits     `json:"request_limits,omitempty"`
+	Concurrency        *ConcurrencyPolicy `json:"concurrency,omitempty"`
+	GRPCServices       []string           `json:"grpc_services,omitempty"`
+	Connections        *ConnectionPolicy  `json:"connections,omitempty"`
+	Affinity           *WorkerAffinity    `json:"affinity,omitempty"`
+	PriorityClass      PriorityClass      `json:"priority_class,omitempty"`
+	Nodes              uint32             `json:"nodes,omitempty"` // Workers the stub's pods span, started as one gang
+}
+
+type StubConfigLimitedValues struct {
+	Pricing       *PricingPolicy `json:"pricing"`
+	Inputs        *Schema        `json:"inputs"`
+	Outputs       *Schema        `json:"outputs"`
+	TaskPolicy    TaskPolicy     `json:"task_policy"`
+	PythonVersion string         `json:"python_version"`
+	Runtime       Runtime        `json:"runtime"`
+}
+
+type Schema struct {
+	Fields map[string]SchemaField `json:"fields"`
+}
+
+func (s *Schema) ToString() string {
+	json, err := json.Marshal(s)
+	if err != nil {
+		return ""
+	}
+	return string(json)
+}
+
+func (s *Schema) ToProto() *pb.Schema {
+	fields := make(map[string]*pb.SchemaField, len(s.Fields))
+	for k, v := range s.Fields {
+		field := &pb.SchemaField{
+			Type: v.Type,
+		}
+		if v.Type == "object" && v.Fields != nil {
+			field.Fields = v.Fields.ToProto()
+		}
+		fields[k] = field
+	}
+	return &pb.Schema{
+		Fields: fields,
+	}
+}
+
+func NewSchemaFromProto(in *pb.Schema) *Schema {
+	fields := make(map[string]SchemaField, len(in.Fields))
+	for k, v := range in.Fields {
+		var nested *Schema
+		if v.Type == "object" && v.Fields != nil {
+			nested = NewSchemaFromProto(v.Fields)
+		}
+		fields[k] = SchemaField{
+			Type:   v.Type,
+			Fields: nested,
+		}
+	}
+
+	return &Schema{
+		Fields: fields,
+	}
+}
+
+type SchemaField struct {
+	Type   string  `json:"type"`
+	Fields *Schema `json:"fields,omitempty"` // Only for type == "object"
+}
+
+func (c *StubConfigV1) RequiresGPU() bool {
+	return len(c.Runtime.Gpus) > 0 || c.Runtime.Gpu != ""
+}
+
+// SidecarOrder is when a sidecar runs relative to the container it's attached to
+type SidecarOrder string
+
+const (
+	// SidecarOrderInit sidecars run to completion, and must succeed, before the main container starts
+	SidecarOrderInit SidecarOrder = "init"
+	// SidecarOrderBefore sidecars start before the main container and run alongside it
+	SidecarOrderBefore SidecarOrder = "before"
+	// SidecarOrderAfter sidecars start once the main container has started
+	SidecarOrderAfter SidecarOrder = "after"
+)
+
+var envNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ValidateEnvName checks that name can be used as an environment variable
+func ValidateEnvName(name string) error {
+	if !envNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid environment variable name: %q", name)
+	}
+	return nil
+}
+
+// EnvMap returns the stub's plain environment variables by name
+func (c *StubConfigV1) EnvMap() map[string]string {
+	env := map[string]string{}
+	for _, kv := range c.Env {
+		name, value, _ := strings.Cut(kv, "=")
+		env[name] = value
+	}
+	return env
+}
+
+// SetEnv sets environment variables, replacing existing values and any secret exposed under the same name.
+// Variables keep their order, with new ones appended sorted by name.
+func (c *StubConfigV1) SetEnv(vars map[string]string) {
+	env := make([]string, 0, len(c.Env)+len(vars))
+	for _, kv := range c.Env {
+		name, _, _ := strings.Cut(kv, "=")
+		if _, ok := vars[name]; !ok {
+			env = append(env, kv)
+		}
+	}
+
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		env = append(env, name+"="+vars[name])
+	}
+
+	c.Env = env
+	c.removeEnvSecrets(names)
+}
+
+// SetEnvSecrets exposes secrets as environment variables of the same name, replacing plain variables and
+// secret references with those names
+func (c *StubConfigV1) SetEnvSecrets(secrets []Secret) {
+	names := make([]string, 0, len(secrets))
+	for _, secret := range secrets {
+		names = append(names, secret.Name)
+	}
+
+	c.UnsetEnv(names)
+	c.Secrets = append(c.Secrets, secrets...)
+}
+
+// UnsetEnv removes environment variables and secret references by name, returning how many were removed.
+// Secrets mounted as files are left alone.
+func (c *StubConfigV1) UnsetEnv(names []string) int {
+	env := make([]string, 0, len(c.Env))
+	for _, kv := range c.Env {
+		name, _, _ := strings.Cut(kv, "=")
+		if !slices.Contains(names, name) {
+			env = append(env, kv)
+		}
+	}
+
+	removed := len(c.Env) - len(env)
+	c.Env = env
+
+	return removed + c.removeEnvSecrets(names)
+}
+
+func (c *StubConfigV1) removeEnvSecrets(names []string) int {
+	secrets := make([]Secret, 0, len(c.Secrets))
+	for _, secret := range c.Secrets {
+		if secret.Mount || !slices.Contains(names, secret.Name) {
+			secrets = append(secrets, secret)
+		}
+	}
+
+	removed := len(c.Secrets) - len(secrets)
+	c.Secrets = secrets
+	return removed
+}
+
+const (
+	MaxSidecars = 4
+	// SidecarSharedPath is a scratch directory mounted into the main container and each of its sidecars
+	SidecarSharedPath = "/mnt/shared"
+)
+
+var sidecarNameRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,30}[a-z0-9])?$`)
+
+// Sidecar is an extra container launched and torn down alongside a stub's containers. It shares the main
+// container's network, so each can reach the other on localhost.
+type Sidecar struct {
+	Name       string       `json:"name"`
+	ImageId    string       `json:"image_id"`
+	EntryPoint []string     `json:"entry_point"`
+	Env        []string     `json:"env,omitempty"`
+	Volumes    []string     `json:"volumes,omitempty"` // Mount paths of the stub's volumes to share with the sidecar
+	Order      SidecarOrder `json:"order,omitempty"`
+}
+
+func (s Sidecar) OrderOrDefault() SidecarOrder {
+	if s.Order == "" {
+		return SidecarOrderBefore
+	}
+
+	return s.Order
+}
+
+func (s Sidecar) ToProto() *pb.Sidecar {
+	return &pb.Sidecar{
+		Name:       s.Name,
+		ImageId:    s.ImageId,
+		EntryPoint: s.EntryPoint,
+		Env:        s.Env,
+		Volumes:    s.Volumes,
+		Order:      string(s.Order),
+	}
+}
+
+func NewSidecarFromProto(in *pb.Sidecar) Sidecar {
+	return Sidecar{
+		Name:       in.Name,
+		ImageId:    in.ImageId,
+		EntryPoint: in.EntryPoint,
+		Env:        in.Env,
+		Volumes:    in.Volumes,
+		Order:      SidecarOrder(in.Order),
+	}
+}
+
+// ValidateSidecars checks a stub's sidecars against the mount paths of its volumes
+func ValidateSidecars(sidecars []Sidecar, volumePaths []string) error {
+	if len(sidecars) > MaxSidecars {
+		return fmt.Errorf("at most %d sidecars are allowed", MaxSidecars)
+	}
+
+	names := map[string]bool{}
+	for _, sidecar := range sidecars {
+		if !sidecarNameRegex.MatchString(sidecar.Name) {
+			return fmt.Errorf("invalid sidecar name %q: names may only contain lowercase letters, digits and dashes", sidecar.Name)
+		}
+
+		if names[sidecar.Name] {
+			return fmt.Errorf("duplicate sidecar name: %s", sidecar.Name)
+		}
+		names[sidecar.Name] = true
+
+		if sidecar.ImageId == "" {
+			return fmt.Errorf("sidecar %s requires an image", sidecar.Name)
+		}
+
+		if len(sidecar.EntryPoint) == 0 {
+			return fmt.Errorf("sidecar %s requires an entry point", sidecar.Name)
+		}
+
+		switch sidecar.OrderOrDefault() {
+		case SidecarOrderInit, SidecarOrderBefore, SidecarOrderAfter:
+		default:
+			return fmt.Errorf("invalid order for sidecar %s: %s", sidecar.Name, sidecar.Order)
+		}
+
+		for _, volume := range sidecar.Volumes {
+			if !slices.Contains(volumePaths, volume) {
+				return fmt.Errorf("sidecar %s mounts %s, which isn't one of the stub's volumes", sidecar.Name, volume)
+			}
+		}
+	}
+
+	return nil
+}
+
+type HealthCheckType string
+
+const (
+	HealthCheckTypeHTTP HealthCheckType = "http"
+	HealthCheckTypeTCP  HealthCheckType = "tcp"
+)
+
+const (
+	DefaultHealthCheckPath             = "/health"
+	DefaultHealthCheckIntervalS        = 10
+	DefaultHealthCheckTimeoutS         = 2
+	DefaultHealthCheckFailureThreshold = 3
+	DefaultHealthCheckGracePeriodS     = 60
+)
+
+// HealthCheck is how the gateway decides whether an endpoint's container can take traffic. Containers are only
+// routed to while their checks pass, and are restarted once they fail FailureThreshold checks in a row.
+// Failures don't count until a container has either passed a check or been up for the grace period.
+type HealthCheck struct {
+	Type               HealthCheckType `json:"type"`
+	Path               string          `json:"path,omitempty"`
+	IntervalSeconds    uint            `json:"interval_seconds"`
+	TimeoutSeconds     uint            `json:"timeout_seconds"`
+	FailureThreshold   uint            `json:"failure_threshold"`
+	GracePeriodSeconds uint            `json:"grace_period_seconds"`
+}
+
+func NewHealthCheckFromProto(in *pb.HealthCheck) *HealthCheck {
+	if in == nil {
+		return nil
+	}
+
+	check := &HealthCheck{
+		Type:               HealthCheckType(in.Type),
+		Path:               in.Path,
+		IntervalSeconds:    uint(in.IntervalSeconds),
+		TimeoutSeconds:     uint(in.TimeoutSeconds),
+		FailureThreshold:   uint(in.FailureThreshold),
+		GracePeriodSeconds: uint(in.GracePeriodSeconds),
+	}
+
+	if check.Type == "" {
+		check.Type = HealthCheckTypeHTTP
+	}
+	if check.Type == HealthCheckTypeHTTP && check.Path == "" {
+		check.Path = DefaultHealthCheckPath
+	}
+	if check.IntervalSeconds == 0 {
+		check.IntervalSeconds = DefaultHealthCheckIntervalS
+	}
+	if check.TimeoutSeconds == 0 {
+		check.TimeoutSeconds = DefaultHealthCheckTimeoutS
+	}
+	if check.FailureThreshold == 0 {
+		check.FailureThreshold = DefaultHealthCheckFailureThreshold
+	}
+	if check.GracePeriodSeconds == 0 {
+		check.GracePeriodSeconds = DefaultHealthCheckGracePeriodS
+	}
+
+	return check
+}
+
+// Validate checks the health check's settings. Checks always target the endpoint's own port.
+func (h *HealthCheck) Validate() error {
+	switch h.Type {
+	case HealthCheckTypeHTTP:
+		if !strings.HasPrefix(h.Path, "/") {
+			return fmt.Errorf("health check path must start with /: %s", h.Path)
+		}
+	case HealthCheckTypeTCP:
+		if h.Path != "" {
+			return errors.New("tcp health checks don't take a path")
+		}
+	default:
+		return fmt.Errorf("invalid health check type: %s", h.Type)
+	}
+
+	if h.TimeoutSeconds > h.IntervalSeconds {
+		return errors.New("health check timeout can't be longer than its interval")
+	}
+
+	return nil
+}
+
+func (h *HealthCheck) Interval() time.Duration {
+	return time.Duration(h.IntervalSeconds) * time.Second
+}
+
+func (h *HealthCheck) Timeout() time.Duration {
+	return time.Duration(h.TimeoutSeconds) * time.Second
 }
 
-// @go2proto
-type PricingPolicyCostModel string
+type ContainerHealthStatus string
 
 const (
-	PricingPolicyCostModelTask     PricingPolicyCostModel = "task"
-	PricingPolicyCostModelDuration PricingPolicyCostModel = "duration"
+	ContainerHealthStatusStarting  ContainerHealthStatus = "starting"
+	ContainerHealthStatusHealthy   ContainerHealthStatus = "healthy"
+	ContainerHealthStatusUnhealthy ContainerHealthStatus = "unhealthy"
 )
 
-// @go2proto
-type Checkpoint struct {
-	Id                uint     `db:"id" json:"id" serializer:"id,source:external_id" go2proto:"ignore"`
-	CheckpointId      string   `db:"checkpoint_id" json:"checkpoint_id" serializer:"checkpoint_id"`
-	ExternalId        string   `db:"external_id" json:"external_id,omitempty" serializer:"external_id"`
-	SourceContainerId string   `db:"source_container_id" json:"source_container_id" serializer:"source_container_id"`
-	ContainerIp       string   `db:"container_ip" json:"container_ip" serializer:"container_ip"`
-	Status            string   `db:"status" json:"status" serializer:"status"`
-	RemoteKey         string   `db:"remote_key" json:"remote_key" serializer:"remote_key"`
-	WorkspaceId       uint     `db:"workspace_id" json:"workspace_id" go2proto:"ignore"` // Foreign key to Workspace
-	StubId            uint     `db:"stub_id" json:"stub_id" go2proto:"ignore"`           // Foreign key to Stub
-	StubType          string   `db:"stub_type" json:"stub_type" serializer:"stub_type"`
-	AppId             uint     `db:"app_id" json:"app_id,omitempty" go2proto:"ignore"` // Foreign key to App
-	ExposedPorts      []uint32 `db:"exposed_ports" json:"exposed_ports" serializer:"exposed_ports"`
-	CreatedAt         Time     `db:"created_at" json:"created_at" serializer:"created_at"`
-	LastRestoredAt    Time     `db:"last_restored_at" json:"last_restored_at" serializer:"last_restored_at"`
-	DeletedAt         NullTime `db:"deleted_at" json:"deleted_at" serializer:"deleted_at"`
+// ContainerHealth is the result of a container's most recent health checks
+type ContainerHealth struct {
+	ContainerId         string                `json:"container_id" serializer:"container_id"`
+	Status              ContainerHealthStatus `json:"status" serializer:"status"`
+	ConsecutiveFailures uint                  `json:"consecutive_failures" serializer:"consecutive_failures"`
+	LastCheckedAt       int64                 `json:"last_checked_at" serializer:"last_checked_at"`
+	LastError           string                `json:"last_error,omitempty" serializer:"last_error"`
 }
 
-func (c *Checkpoint) ToProto() *pb.Checkpoint {
-	exposedPorts := make([]uint32, len(c.ExposedPorts))
-	for i, port := range c.ExposedPorts {
-		exposedPorts[i] = port
+// Record updates the container's health with the result of a check and reports whether the container has now
+// failed enough checks in a row to be restarted
+func (c *ContainerHealth) Record(check *HealthCheck, checkErr error, startedAt time.Time, now time.Time) bool {
+	c.LastCheckedAt = now.Unix()
+
+	if checkErr == nil {
+		c.Status = ContainerHealthStatusHealthy
+		c.ConsecutiveFailures = 0
+		c.LastError = ""
+		return false
 	}
 
-	return &pb.Checkpoint{
-		CheckpointId:      c.CheckpointId,
-		ExternalId:        c.ExternalId,
-		SourceContainerId: c.SourceContainerId,
-		ContainerIp:       c.ContainerIp,
-		Status:            c.Status,
-		RemoteKey:         c.RemoteKey,
-		StubType:          c.StubType,
-		ExposedPorts:      exposedPorts,
-		CreatedAt:         timestamppb.New(c.CreatedAt.Time),
-		LastRestoredAt:    timestamppb.New(c.LastRestoredAt.Time),
+	c.LastError = checkErr.Error()
+
+	// Give containers that have never passed a check time to boot
+	gracePeriod := time.Duration(check.GracePeriodSeconds) * time.Second
+	if c.Status != ContainerHealthStatusHealthy && c.Status != ContainerHealthStatusUnhealthy && now.Sub(startedAt) < gracePeriod {
+		c.Status = ContainerHealthStatusStarting
+		return false
 	}
+
+	c.Status = ContainerHealthStatusUnhealthy
+	c.ConsecutiveFailures++
+	return c.ConsecutiveFailures >= check.FailureThreshold
 }
 
-func NewCheckpointFromProto(in *pb.Checkpoint) *Checkpoint {
-	exposedPorts := make([]uint32, len(in.ExposedPorts))
-	for i, port := range in.ExposedPorts {
-		exposedPorts[i] = uint32(port)
+// RequestLimits bound what a single request can cost the endpoint proxy. Zero values leave that limit off.
+type RequestLimits struct {
+	MaxRequestDurationSeconds uint   `json:"max_request_duration_seconds,omitempty"`
+	MaxRequestBodyBytes       uint64 `json:"max_request_body_bytes,omitempty"`
+	MaxResponseBytes          uint64 `json:"max_response_bytes,omitempty"`
+}
+
+func NewRequestLimitsFromProto(in *pb.RequestLimits) *RequestLimits {
+	if in == nil || (in.MaxRequestDurationSeconds == 0 && in.MaxRequestBodyBytes == 0 && in.MaxResponseBytes == 0) {
+		return nil
 	}
 
-	return &Checkpoint{
-		CheckpointId:      in.CheckpointId,
-		ExternalId:        in.ExternalId,
-		SourceContainerId: in.SourceContainerId,
-		ContainerIp:       in.ContainerIp,
-		Status:            in.Status,
-		RemoteKey:         in.RemoteKey,
-		StubType:          in.StubType,
-		ExposedPorts:      exposedPorts,
-		CreatedAt:         Time{Time: in.CreatedAt.AsTime()},
-		LastRestoredAt:    Time{Time: in.LastRestoredAt.AsTime()},
+	return &RequestLimits{
+		MaxRequestDurationSeconds: uint(in.MaxRequestDurationSeconds),
+		MaxRequestBodyBytes:       in.MaxRequestBodyBytes,
+		MaxResponseBytes:          in.MaxResponseBytes,
 	}
 }
 
-type StubConfigV1 struct {
-	Runtime            Runtime         `json:"runtime"`
-	Handler            string          `json:"handler"`
-	OnStart            string          `json:"on_start"`
-	OnDeploy           string          `json:"on_deploy"`
-	OnDeployStubId     string          `json:"on_deploy_stub_id"`
-	PythonVersion      string          `json:"python_version"`
-	KeepWarmSeconds    uint            `json:"keep_warm_seconds"`
-	MaxPendingTasks    uint            `json:"max_pending_tasks"`
-	CallbackUrl        string          `json:"callback_url"`
-	TaskPolicy         TaskPolicy      `json:"task_policy"`
-	Workers            uint            `json:"workers"`
-	ConcurrentRequests uint            `json:"concurrent_requests"`
-	Authorized         bool            `json:"authorized"`
-	Volumes            []*pb.Volume    `json:"volumes"`
-	Secrets            []Secret        `json:"secrets,omitempty"`
-	Env                []string        `json:"env,omitempty"`
-	Autoscaler         *Autoscaler     `json:"autoscaler"`
-	Extra              json.RawMessage `json:"extra"`
-	CheckpointEnabled  bool            `json:"checkpoint_enabled"`
-	WorkDir            string          `json:"work_dir"`
-	EntryPoint         []string        `json:"entry_point"`
-	Ports              []uint32        `json:"ports"`
-	Pricing            *PricingPolicy  `json:"pricing"`
-	Inputs             *Schema         `json:"inputs"`
-	Outputs            *Schema         `json:"outputs"`
-	TCP                bool            `json:"tcp"`
-	BlockNetwork       bool            `json:"block_network"`
-	AllowList          []string        `json:"allow_list"`
-	DockerEnabled      bool            `json:"docker_enabled"`
+func (l *RequestLimits) MaxRequestDuration() time.Duration {
+	return time.Duration(l.MaxRequestDurationSeconds) * time.Second
 }
 
-type StubConfigLimitedValues struct {
-	Pricing       *PricingPolicy `json:"pricing"`
-	Inputs        *Schema        `json:"inputs"`
-	Outputs       *Schema        `json:"outputs"`
-	TaskPolicy    TaskPolicy     `json:"task_policy"`
-	PythonVersion string         `json:"python_version"`
-	Runtime       Runtime        `json:"runtime"`
-}
+const MaxConnectionDrainTimeoutSeconds = 3600
 
-type Schema struct {
-	Fields map[string]SchemaField `json:"fields"`
+// ConnectionPolicy configures long-lived WebSocket and server-sent event connections to an endpoint. Zero
+// values leave that behavior off.
+type ConnectionPolicy struct {
+	// IdleTimeoutSeconds closes a connection once no data has moved over it for this long
+	IdleTimeoutSeconds uint `json:"idle_timeout_seconds,omitempty"`
+	// DrainTimeoutSeconds is how long a container being scaled down stops taking new requests while its open
+	// connections finish, before it's stopped anyway
+	DrainTimeoutSeconds uint `json:"drain_timeout_seconds,omitempty"`
+	// ConnectionsPerContainer has the autoscaler count open connections against their own target, instead of
+	// as requests
+	ConnectionsPerContainer uint `json:"connections_per_container,omitempty"`
 }
 
-func (s *Schema) ToString() string {
-	json, err := json.Marshal(s)
-	if err != nil {
-		return ""
+func NewConnectionPolicyFromProto(in *pb.ConnectionPolicy) *ConnectionPolicy {
+	if in == nil || (in.IdleTimeoutSeconds == 0 && in.DrainTimeoutSeconds == 0 && in.ConnectionsPerContainer == 0) {
+		return nil
 	}
-	return string(json)
-}
 
-func (s *Schema) ToProto() *pb.Schema {
-	fields := make(map[string]*pb.SchemaField, len(s.Fields))
-	for k, v := range s.Fields {
-		field := &pb.SchemaField{
-			Type: v.Type,
-		}
-		if v.Type == "object" && v.Fields != nil {
-			field.Fields = v.Fields.ToProto()
-		}
-		fields[k] = field
+	return &ConnectionPolicy{
+		IdleTimeoutSeconds:      uint(in.IdleTimeoutSeconds),
+		DrainTimeoutSeconds:     uint(in.DrainTimeoutSeconds),
+		ConnectionsPerContainer: uint(in.ConnectionsPerContainer),
 	}
-	return &pb.Schema{
-		Fields: fields,
+}
+
+func (p *ConnectionPolicy) Validate() error {
+	if p.DrainTimeoutSeconds > MaxConnectionDrainTimeoutSeconds {
+		return fmt.Errorf("drain timeout must be at most %d seconds", MaxConnectionDrainTimeoutSeconds)
 	}
+
+	return nil
 }
 
-func NewSchemaFromProto(in *pb.Schema) *Schema {
-	fields := make(map[string]SchemaField, len(in.Fields))
-	for k, v := range in.Fields {
-		var nested *Schema
-		if v.Type == "object" && v.Fields != nil {
-			nested = NewSchemaFromProto(v.Fields)
-		}
-		fields[k] = SchemaField{
-			Type:   v.Type,
-			Fields: nested,
-		}
+func (p *ConnectionPolicy) IdleTimeout() time.Duration {
+	return time.Duration(p.IdleTimeoutSeconds) * time.Second
+}
+
+func (p *ConnectionPolicy) DrainTimeout() time.Duration {
+	return time.Duration(p.DrainTimeoutSeconds) * time.Second
+}
+
+type ConcurrencyOverflowPolicy string
+
+const (
+	// ConcurrencyOverflowReject turns new requests away while the queue is full
+	ConcurrencyOverflowReject ConcurrencyOverflowPolicy = "reject"
+	// ConcurrencyOverflowShedOldest drops the longest waiting request to make room for a new one
+	ConcurrencyOverflowShedOldest ConcurrencyOverflowPolicy = "shed_oldest"
+)
+
+const DefaultConcurrencyMaxQueueDepth = 100
+
+// ConcurrencyPolicy caps how many requests a deployment works on at once, across all of its containers.
+// Requests over the cap wait in a queue of up to MaxQueueDepth; once it's full, OverflowPolicy decides which
+// request is turned away.
+type ConcurrencyPolicy struct {
+	MaxConcurrentRequests uint                      `json:"max_concurrent_requests"`
+	MaxQueueDepth         uint                      `json:"max_queue_depth"`
+	OverflowPolicy        ConcurrencyOverflowPolicy `json:"overflow_policy"`
+}
+
+func NewConcurrencyPolicyFromProto(in *pb.ConcurrencyPolicy) *ConcurrencyPolicy {
+	if in == nil || in.MaxConcurrentRequests == 0 {
+		return nil
 	}
 
-	return &Schema{
-		Fields: fields,
+	policy := &ConcurrencyPolicy{
+		MaxConcurrentRequests: uint(in.MaxConcurrentRequests),
+		MaxQueueDepth:         uint(in.MaxQueueDepth),
+		OverflowPolicy:        ConcurrencyOverflowPolicy(in.OverflowPolicy),
 	}
+
+	if policy.MaxQueueDepth == 0 {
+		policy.MaxQueueDepth = DefaultConcurrencyMaxQueueDepth
+	}
+	if policy.OverflowPolicy == "" {
+		policy.OverflowPolicy = ConcurrencyOverflowReject
+	}
+
+	return policy
 }
 
-type SchemaField struct {
-	Type   string  `json:"type"`
-	Fields *Schema `json:"fields,omitempty"` // Only for type == "object"
+func (p *ConcurrencyPolicy) Validate() error {
+	switch p.OverflowPolicy {
+	case ConcurrencyOverflowReject, ConcurrencyOverflowShedOldest:
+		return nil
+	default:
+		return fmt.Errorf("invalid overflow policy: %s", p.OverflowPolicy)
+	}
 }
 
-func (c *StubConfigV1) RequiresGPU() bool {
-	return len(c.Runtime.Gpus) > 0 || c.Runtime.Gpu != ""
+// grpcServiceRegex matches fully qualified gRPC service names, e.g. "inference.v1.Predictor"
+var grpcServiceRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)+$`)
+
+// ValidateGRPCServices checks the services a gRPC stub exposes. Requests are routed on service name, so
+// every stub needs at least one and each must be fully qualified.
+func ValidateGRPCServices(services []string) error {
+	if len(services) == 0 {
+		return errors.New("grpc stubs must expose at least one service")
+	}
+
+	seen := make(map[string]bool, len(services))
+	for _, service := range services {
+		if !grpcServiceRegex.MatchString(service) {
+			return fmt.Errorf("invalid grpc service name: %q, expected a fully qualified name like package.Service", service)
+		}
+
+		if seen[service] {
+			return fmt.Errorf("duplicate grpc service: %s", service)
+		}
+		seen[service] = true
+	}
+
+	return nil
 }
 
 type AutoscalerType string
@@ -542,6 +2044,54 @@ type Autoscaler struct {
 	MinContainers     uint           `json:"min_containers"`
 }
 
+// AutoscalingMetricMaxAge is how long a reported metric value counts towards scaling. Containers are
+// expected to report more often than this, so values from containers that went away drop out.
+var AutoscalingMetricMaxAge = 60 * time.Second
+
+// AutoscalingPolicy scales a deployment on a metric reported by its containers, such as GPU utilization or
+// a gauge emitted through the SDK. It runs alongside the stub's queue depth autoscaler, and the larger of
+// the two results wins.
+type AutoscalingPolicy struct {
+	StubId uint   `db:"stub_id" json:"stub_id"`
+	Metric string `db:"metric" json:"metric"`
+	// TargetValue is the value each container should average, e.g. 70 for 70% GPU utilization
+	TargetValue float64 `db:"target_value" json:"target_value"`
+	CreatedAt   Time    `db:"created_at" json:"created_at"`
+	UpdatedAt   Time    `db:"updated_at" json:"updated_at"`
+}
+
+// DesiredContainers returns how many containers keep the metric's per-container average at or under
+// the target, given the latest value from each reporting container
+func (p *AutoscalingPolicy) DesiredContainers(values []float64) int {
+	if p.TargetValue <= 0 || len(values) == 0 {
+		return 0
+	}
+
+	total := 0.0
+	for _, v := range values {
+		total += max(v, 0)
+	}
+
+	return int(math.Ceil(total / p.TargetValue))
+}
+
+// KeepWarmPolicy holds a number of a deployment's containers warm while its cron schedule matches, e.g.
+// "* 9-17 * * 1-5" for working hours. The schedule is evaluated in Timezone.
+type KeepWarmPolicy struct {
+	StubId     uint   `db:"stub_id" json:"stub_id"`
+	Schedule   string `db:"schedule" json:"schedule"`
+	Timezone   string `db:"timezone" json:"timezone"`
+	Containers uint   `db:"containers" json:"containers"`
+	CreatedAt  Time   `db:"created_at" json:"created_at"`
+	UpdatedAt  Time   `db:"updated_at" json:"updated_at"`
+}
+
+// AutoscalingMetricSample is the latest value a container reported for a metric
+type AutoscalingMetricSample struct {
+	Value     float64 `json:"value"`
+	Timestamp int64   `json:"timestamp"`
+}
+
 // @go2proto
 type App struct {
 	Id          uint     `db:"id" json:"id" serializer:"id,source:external_id"`
@@ -580,6 +2130,9 @@ const (
 	StubTypeASGI                   string = "asgi"
 	StubTypeASGIDeployment         string = "asgi/deployment"
 	StubTypeASGIServe              string = "asgi/serve"
+	StubTypeGRPC                   string = "grpc"
+	StubTypeGRPCDeployment         string = "grpc/deployment"
+	StubTypeGRPCServe              string = "grpc/serve"
 	StubTypeScheduledJob           string = "schedule"
 	StubTypeScheduledJobDeployment string = "schedule/deployment"
 	StubTypeBot                    string = "bot"
@@ -719,6 +2272,12 @@ type Runtime struct {
 	Memory   int64     `json:"memory"`
 	ImageId  string    `json:"image_id"`
 	Gpus     []GpuType `json:"gpus"`
+	// Share of a single GPU the container runs on, 0 for whole GPUs
+	GpuFraction float64 `json:"gpu_fraction,omitempty"`
+	// MIG slice the container runs on, e.g. 1g.10gb, empty for whole GPUs
+	MigProfile string `json:"mig_profile,omitempty"`
+	// CPU architectures the image is built for, amd64 if none
+	Architectures []string `json:"architectures,omitempty"`
 }
 
 // FilterFieldMapping represents a mapping between a client-provided field and
@@ -740,6 +2299,35 @@ type ConcurrencyLimit struct {
 	UpdatedAt         time.Time `db:"updated_at" json:"updated_at,omitempty" redis:"-"`
 }
 
+// Check returns an error if running the request next to the workspace's active containers would go over the
+// limit. Containers that are stopping don't count.
+func (c *ConcurrencyLimit) Check(containers []ContainerState, request *ContainerRequest) error {
+	totalGpuCount := 0
+	totalCpu := 0
+	for _, container := range containers {
+		if container.Status == ContainerStatusStopping {
+			continue
+		}
+
+		totalGpuCount += int(container.GpuCount)
+		totalCpu += int(container.Cpu)
+	}
+
+	if totalGpuCount+int(request.GpuCount) > int(c.GPULimit) {
+		return &ThrottledByConcurrencyLimitError{
+			Reason: "gpu quota exceeded",
+		}
+	}
+
+	if totalCpu+int(request.Cpu) > int(c.CPUMillicoreLimit) {
+		return &ThrottledByConcurrencyLimitError{
+			Reason: "cpu quota exceeded",
+		}
+	}
+
+	return nil
+}
+
 func (c *ConcurrencyLimit) ToProto() *pb.ConcurrencyLimit {
 	return &pb.ConcurrencyLimit{
 		Id:                uint32(c.Id),
@@ -760,6 +2348,176 @@ func NewConcurrencyLimitFromProto(in *pb.ConcurrencyLimit) *ConcurrencyLimit {
 	}
 }
 
+// WorkspacePolicy caps what a workspace's stubs may use. Zero values leave a resource unlimited, so a workspace
+// without a policy behaves the same as one with an empty policy.
+type WorkspacePolicy struct {
+	Id                uint   `db:"id" json:"-"`
+	WorkspaceId       uint   `db:"workspace_id" json:"-"`
+	MaxConcurrentGpus uint32 `db:"max_concurrent_gpus" json:"max_concurrent_gpus"`
+	// Largest memory a single container may request, in MiB
+	MaxContainerMemory    int64          `db:"max_container_memory" json:"max_container_memory"`
+	AllowedGpuTypes       PolicyGpuTypes `db:"allowed_gpu_types" json:"allowed_gpu_types"`
+	MaxRunDurationSeconds int64          `db:"max_run_duration_seconds" json:"max_run_duration_seconds"`
+	CreatedAt             Time           `db:"created_at" json:"created_at"`
+	UpdatedAt             Time           `db:"updated_at" json:"updated_at"`
+}
+
+// IsEmpty reports whether the policy doesn't limit anything
+func (p *WorkspacePolicy) IsEmpty() bool {
+	return p.MaxConcurrentGpus == 0 && p.MaxContainerMemory == 0 && len(p.AllowedGpuTypes) == 0 && p.MaxRunDurationSeconds == 0
+}
+
+// CheckRuntime returns an ErrWorkspacePolicyViolation if a container with this runtime isn't allowed to run
+func (p *WorkspacePolicy) CheckRuntime(runtime Runtime) error {
+	if p.MaxContainerMemory > 0 && runtime.Memory > p.MaxContainerMemory {
+		return &ErrWorkspacePolicyViolation{Reason: fmt.Sprintf("memory of %d MiB exceeds the workspace limit of %d MiB", runtime.Memory, p.MaxContainerMemory)}
+	}
+
+	gpus := runtime.Gpus
+	if len(gpus) == 0 && runtime.Gpu != "" {
+		gpus = []GpuType{runtime.Gpu}
+	}
+
+	if len(gpus) == 0 {
+		return nil
+	}
+
+	if len(p.AllowedGpuTypes) > 0 {
+		allowed := strings.Join(GpuTypesToStrings(p.AllowedGpuTypes), ", ")
+		for _, gpu := range gpus {
+			// "any" could be placed on a GPU outside of the allowed types
+			if !slices.Contains(p.AllowedGpuTypes, gpu) {
+				return &ErrWorkspacePolicyViolation{Reason: fmt.Sprintf("GPU type %s is not allowed, allowed types are %s", gpu, allowed)}
+			}
+		}
+	}
+
+	gpuCount := runtime.GpuCount
+	if gpuCount == 0 {
+		gpuCount = 1
+	}
+
+	if p.MaxConcurrentGpus > 0 && gpuCount > p.MaxConcurrentGpus {
+		return &ErrWorkspacePolicyViolation{Reason: fmt.Sprintf("%d GPUs exceeds the workspace limit of %d concurrent GPUs", gpuCount, p.MaxConcurrentGpus)}
+	}
+
+	return nil
+}
+
+// CheckRunDuration returns an ErrWorkspacePolicyViolation if a task timeout in seconds is over the policy's
+// maximum run duration. A timeout of 0 or less never expires, so it's only allowed without a maximum.
+func (p *WorkspacePolicy) CheckRunDuration(timeout int) error {
+	if p.MaxRunDurationSeconds <= 0 {
+		return nil
+	}
+
+	if timeout <= 0 || int64(timeout) > p.MaxRunDurationSeconds {
+		return &ErrWorkspacePolicyViolation{Reason: fmt.Sprintf("timeout must be between 1 and %d seconds", p.MaxRunDurationSeconds)}
+	}
+
+	return nil
+}
+
+// ClampRunDuration returns timeout capped at the policy's maximum run duration, and whether it was changed
+func (p *WorkspacePolicy) ClampRunDuration(timeout int) (int, bool) {
+	if p.CheckRunDuration(timeout) == nil {
+		return timeout, false
+	}
+
+	return int(p.MaxRunDurationSeconds), true
+}
+
+// LimitConcurrency lowers the workspace's GPU concurrency limit to the policy's, returning a limit that only
+// caps GPUs if the workspace didn't have one
+func (p *WorkspacePolicy) LimitConcurrency(limit *ConcurrencyLimit) *ConcurrencyLimit {
+	if p.MaxConcurrentGpus == 0 {
+		return limit
+	}
+
+	if limit == nil {
+		return &ConcurrencyLimit{GPULimit: p.MaxConcurrentGpus, CPUMillicoreLimit: math.MaxUint32}
+	}
+
+	limited := *limit
+	if limited.GPULimit > p.MaxConcurrentGpus {
+		limited.GPULimit = p.MaxConcurrentGpus
+	}
+
+	return &limited
+}
+
+// PolicyGpuTypes is a list of GPU types stored as a JSONB array
+type PolicyGpuTypes []GpuType
+
+func (g *PolicyGpuTypes) Scan(value interface{}) error {
+	if value == nil {
+		*g = nil
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(bytes, g)
+}
+
+func (g PolicyGpuTypes) Value() (driver.Value, error) {
+	if g == nil {
+		return []byte("[]"), nil
+	}
+
+	return json.Marshal(g)
+}
+
+// PoolQuota limits how much of a worker pool a workspace's containers may use at once. Reserved GPUs are held for
+// the workspace, so other workspaces can't take the pool's last free GPUs while it uses fewer than that. Zero
+// values leave a resource unlimited.
+type PoolQuota struct {
+	Id           uint   `db:"id" json:"-"`
+	WorkspaceId  string `db:"workspace_id" json:"workspace_id"` // External id of the workspace
+	PoolName     string `db:"pool_name" json:"pool_name"`
+	MaxMachines  uint32 `db:"max_machines" json:"max_machines"`
+	MaxGpus      uint32 `db:"max_gpus" json:"max_gpus"`
+	ReservedGpus uint32 `db:"reserved_gpus" json:"reserved_gpus"`
+	CreatedAt    Time   `db:"created_at" json:"created_at"`
+	UpdatedAt    Time   `db:"updated_at" json:"updated_at"`
+}
+
+// IsEmpty reports whether the quota neither limits nor reserves anything
+func (q *PoolQuota) IsEmpty() bool {
+	return q.MaxMachines == 0 && q.MaxGpus == 0 && q.ReservedGpus == 0
+}
+
+// CapacityReservation holds GPUs of a pool for a workspace between StartsAt and EndsAt. The scheduler adds workers
+// to the pool ahead of the window if it doesn't have the GPUs free, and other workspaces can't take them while it's
+// active.
+type CapacityReservation struct {
+	Id          uint    `db:"id" json:"-"`
+	ExternalId  string  `db:"external_id" json:"external_id"`
+	WorkspaceId string  `db:"workspace_id" json:"workspace_id"` // External id of the workspace
+	PoolName    string  `db:"pool_name" json:"pool_name"`
+	GpuType     GpuType `db:"gpu_type" json:"gpu_type"`
+	GpuCount    uint32  `db:"gpu_count" json:"gpu_count"`
+	StartsAt    Time    `db:"starts_at" json:"starts_at"`
+	EndsAt      Time    `db:"ends_at" json:"ends_at"`
+	CreatedAt   Time    `db:"created_at" json:"created_at"`
+}
+
+// Active reports whether the reservation's window includes at
+func (r *CapacityReservation) Active(at time.Time) bool {
+	return !at.Before(r.StartsAt.Time) && at.Before(r.EndsAt.Time)
+}
+
+type ErrWorkspacePolicyViolation struct {
+	Reason string
+}
+
+func (e *ErrWorkspacePolicyViolation) Error() string {
+	return fmt.Sprintf("workspace policy violation: %s", e.Reason)
+}
+
 type Secret struct {
 	Id            uint      `db:"id" json:"-"`
 	ExternalId    string    `db:"external_id" json:"external_id,omitempty"`
@@ -769,6 +2527,45 @@ type Secret struct {
 	Value         string    `db:"value" json:"value,omitempty"`
 	WorkspaceId   uint      `db:"workspace_id" json:"workspace_id,omitempty"`
 	LastUpdatedBy *uint     `db:"last_updated_by" json:"last_updated_by,omitempty"`
+	Version       uint      `db:"version" json:"version,omitempty"`
+	Pinned        bool      `db:"-" json:"pinned,omitempty"` // In a stub config: use Version instead of following the latest value
+	Mount         bool      `db:"-" json:"mount,omitempty"`  // In a stub config: write to a file instead of an env var
+}
+
+// Where a stub's mounted secrets are written when it doesn't choose a path
+const DefaultSecretMountPath = "/run/secrets"
+
+var reservedSecretMountPaths = []string{"/proc", "/sys", "/dev", WorkerContainerUploadsMountPath, SidecarSharedPath}
+
+// ValidateSecretMountPath checks that mounting secrets at path won't shadow system directories or the stub's volumes
+func ValidateSecretMountPath(path string, volumePaths []string) error {
+	if !filepath.IsAbs(path) {
+		return fmt.Errorf("secret mount path must be absolute: %s", path)
+	}
+
+	path = filepath.Clean(path)
+	if path == "/" {
+		return fmt.Errorf("secrets can't be mounted at /")
+	}
+
+	isWithin := func(path, dir string) bool {
+		return path == dir || strings.HasPrefix(path, dir+"/")
+	}
+
+	for _, reserved := range reservedSecretMountPaths {
+		if isWithin(path, reserved) {
+			return fmt.Errorf("secrets can't be mounted at %s", path)
+		}
+	}
+
+	for _, volumePath := range volumePaths {
+		volumePath = filepath.Clean(volumePath)
+		if isWithin(path, volumePath) || isWithin(volumePath, path) {
+			return fmt.Errorf("secret mount path %s overlaps volume %s", path, volumePath)
+		}
+	}
+
+	return nil
 }
 
 type ScheduledJob struct {
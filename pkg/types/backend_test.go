@@ -1,7 +1,12 @@
 package types
 
 import (
+	"database/sql"
+	"errors"
+	"math"
+	"strings"
 	"testing"
+	"time"
 )
 
 // TestIsServe checks the IsServe method for various stub types
@@ -61,3 +66,515 @@ func TestIsDeployment(t *testing.T) {
 		})
 	}
 }
+
+// TestDeploymentTrafficSplitPickVersion checks how requests are split between the stable and canary versions
+func TestDeploymentTrafficSplitPickVersion(t *testing.T) {
+	split := DeploymentTrafficSplit{StableVersion: 3, CanaryVersion: 4, CanaryPercent: 10}
+
+	tests := []struct {
+		roll       uint
+		wantVer    uint
+		wantCanary bool
+	}{
+		{0, 4, true},
+		{9, 4, true},
+		{10, 3, false},
+		{99, 3, false},
+	}
+
+	for _, tt := range tests {
+		version, canary := split.PickVersion(tt.roll)
+		if version != tt.wantVer || canary != tt.wantCanary {
+			t.Errorf("PickVersion(%d) = (%d, %v), want (%d, %v)", tt.roll, version, canary, tt.wantVer, tt.wantCanary)
+		}
+	}
+}
+
+// TestDeploymentTrafficSplitErrorRateExceeded checks when a canary's failures trigger a rollback
+func TestDeploymentTrafficSplitErrorRateExceeded(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold float64
+		min       uint
+		requests  int64
+		failures  int64
+		want      bool
+	}{
+		{"no threshold", 0, 0, 100, 100, false},
+		{"below min requests", 0.1, 50, 20, 20, false},
+		{"under threshold", 0.1, 10, 100, 10, false},
+		{"over threshold", 0.1, 10, 100, 11, true},
+		{"no requests", 0.1, 0, 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			split := DeploymentTrafficSplit{ErrorRateThreshold: tt.threshold, MinRequests: tt.min}
+			if got := split.ErrorRateExceeded(tt.requests, tt.failures); got != tt.want {
+				t.Errorf("ErrorRateExceeded() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestAutoscalingPolicyDesiredContainers checks the container count a custom metric policy asks for
+func TestAutoscalingPolicyDesiredContainers(t *testing.T) {
+	tests := []struct {
+		name   string
+		target float64
+		values []float64
+		want   int
+	}{
+		{"no values", 70, nil, 0},
+		{"no target", 0, []float64{90}, 0},
+		{"at target", 70, []float64{70, 70}, 2},
+		{"over target", 70, []float64{90, 95}, 3},
+		{"under target", 70, []float64{10, 20}, 1},
+		{"idle", 70, []float64{0, 0}, 0},
+		{"negative values ignored", 10, []float64{-5, 15}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := AutoscalingPolicy{TargetValue: tt.target}
+			if got := policy.DesiredContainers(tt.values); got != tt.want {
+				t.Errorf("DesiredContainers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestValidateSidecars checks the sidecars a stub may declare
+func TestValidateSidecars(t *testing.T) {
+	valid := Sidecar{Name: "log-shipper", ImageId: "img", EntryPoint: []string{"fluent-bit"}}
+
+	withName := func(name string) Sidecar {
+		sidecar := valid
+		sidecar.Name = name
+		return sidecar
+	}
+
+	tests := []struct {
+		name     string
+		sidecars []Sidecar
+		wantErr  bool
+	}{
+		{"none", nil, false},
+		{"valid", []Sidecar{valid}, false},
+		{"shared volume", []Sidecar{{Name: "fetch", ImageId: "img", EntryPoint: []string{"fetch"}, Volumes: []string{"/volumes/models"}, Order: SidecarOrderInit}}, false},
+		{"unknown volume", []Sidecar{{Name: "fetch", ImageId: "img", EntryPoint: []string{"fetch"}, Volumes: []string{"/data"}}}, true},
+		{"invalid name", []Sidecar{withName("Log_Shipper")}, true},
+		{"duplicate name", []Sidecar{valid, valid}, true},
+		{"missing image", []Sidecar{{Name: "proxy", EntryPoint: []string{"envoy"}}}, true},
+		{"missing entry point", []Sidecar{{Name: "proxy", ImageId: "img"}}, true},
+		{"invalid order", []Sidecar{{Name: "proxy", ImageId: "img", EntryPoint: []string{"envoy"}, Order: "later"}}, true},
+		{"too many", []Sidecar{withName("a"), withName("b"), withName("c"), withName("d"), withName("e")}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSidecars(tt.sidecars, []string{"/volumes/models"})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSidecars() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateGRPCServices(t *testing.T) {
+	tests := []struct {
+		name     string
+		services []string
+		wantErr  bool
+	}{
+		{"single", []string{"inference.v1.Predictor"}, false},
+		{"multiple", []string{"inference.Predictor", "grpc.health.v1.Health"}, false},
+		{"none", nil, true},
+		{"unqualified", []string{"Predictor"}, true},
+		{"with method", []string{"inference.Predictor/Predict"}, true},
+		{"trailing dot", []string{"inference."}, true},
+		{"duplicate", []string{"inference.Predictor", "inference.Predictor"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateGRPCServices(tt.services)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateGRPCServices() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateSecretMountPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		wantErr bool
+	}{
+		{"default", DefaultSecretMountPath, false},
+		{"custom", "/etc/app/secrets", false},
+		{"relative", "run/secrets", true},
+		{"root", "/", true},
+		{"proc", "/proc/secrets", true},
+		{"uploads", WorkerContainerUploadsMountPath, true},
+		{"inside volume", "/volumes/models/secrets", true},
+		{"contains volume", "/volumes", true},
+		{"volume prefix", "/volumes/models-secrets", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSecretMountPath(tt.path, []string{"/volumes/models"})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSecretMountPath() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStubConfigEnv(t *testing.T) {
+	config := &StubConfigV1{
+		Env: []string{"A=1", "B=2"},
+		Secrets: []Secret{
+			{Name: "TOKEN", Value: "enc"},
+			{Name: "C", Value: "enc"},
+			{Name: "CERT", Value: "enc", Mount: true},
+		},
+	}
+
+	config.SetEnv(map[string]string{"B": "3", "D": "4", "C": "5"})
+	if got, want := strings.Join(config.Env, ","), "A=1,B=3,C=5,D=4"; got != want {
+		t.Errorf("SetEnv() env = %q, want %q", got, want)
+	}
+	if len(config.Secrets) != 2 || config.Secrets[0].Name != "TOKEN" || config.Secrets[1].Name != "CERT" {
+		t.Errorf("SetEnv() should replace secrets with the same name, got %v", config.Secrets)
+	}
+
+	config.SetEnvSecrets([]Secret{{Name: "A", Value: "enc"}, {Name: "TOKEN", Value: "enc2"}})
+	if got, want := strings.Join(config.Env, ","), "B=3,C=5,D=4"; got != want {
+		t.Errorf("SetEnvSecrets() env = %q, want %q", got, want)
+	}
+	if len(config.Secrets) != 3 || config.Secrets[2].Name != "TOKEN" || config.Secrets[2].Value != "enc2" {
+		t.Errorf("SetEnvSecrets() secrets = %v", config.Secrets)
+	}
+
+	if removed := config.UnsetEnv([]string{"B", "A", "CERT", "MISSING"}); removed != 2 {
+		t.Errorf("UnsetEnv() removed = %d, want 2", removed)
+	}
+	if env := config.EnvMap(); len(env) != 2 || env["C"] != "5" || env["D"] != "4" {
+		t.Errorf("EnvMap() = %v", env)
+	}
+
+	for name, wantErr := range map[string]bool{"FOO_1": false, "_foo": false, "1FOO": true, "FOO-BAR": true, "": true, "A=B": true} {
+		if err := ValidateEnvName(name); (err != nil) != wantErr {
+			t.Errorf("ValidateEnvName(%q) error = %v, wantErr %v", name, err, wantErr)
+		}
+	}
+}
+
+func TestHealthCheckValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		check   HealthCheck
+		wantErr bool
+	}{
+		{"http", HealthCheck{Type: HealthCheckTypeHTTP, Path: "/ready", IntervalSeconds: 10, TimeoutSeconds: 2}, false},
+		{"tcp", HealthCheck{Type: HealthCheckTypeTCP, IntervalSeconds: 10, TimeoutSeconds: 2}, false},
+		{"relative path", HealthCheck{Type: HealthCheckTypeHTTP, Path: "ready", IntervalSeconds: 10, TimeoutSeconds: 2}, true},
+		{"tcp with path", HealthCheck{Type: HealthCheckTypeTCP, Path: "/ready", IntervalSeconds: 10, TimeoutSeconds: 2}, true},
+		{"unknown type", HealthCheck{Type: "grpc", IntervalSeconds: 10, TimeoutSeconds: 2}, true},
+		{"timeout over interval", HealthCheck{Type: HealthCheckTypeTCP, IntervalSeconds: 1, TimeoutSeconds: 2}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.check.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestContainerHealthRecord(t *testing.T) {
+	check := &HealthCheck{Type: HealthCheckTypeHTTP, Path: "/health", FailureThreshold: 2, GracePeriodSeconds: 60}
+	startedAt := time.Now()
+	failed := errors.New("connection refused")
+
+	health := &ContainerHealth{ContainerId: "container"}
+
+	// Failures while booting don't count
+	if health.Record(check, failed, startedAt, startedAt.Add(10*time.Second)) || health.Status != ContainerHealthStatusStarting || health.ConsecutiveFailures != 0 {
+		t.Errorf("Record() during grace period = %+v", health)
+	}
+
+	if health.Record(check, nil, startedAt, startedAt.Add(20*time.Second)) || health.Status != ContainerHealthStatusHealthy {
+		t.Errorf("Record() after passing = %+v", health)
+	}
+
+	// Once healthy, failures count even inside the grace period
+	if health.Record(check, failed, startedAt, startedAt.Add(30*time.Second)) || health.Status != ContainerHealthStatusUnhealthy || health.LastError != failed.Error() {
+		t.Errorf("Record() after first failure = %+v", health)
+	}
+	if !health.Record(check, failed, startedAt, startedAt.Add(40*time.Second)) || health.ConsecutiveFailures != 2 {
+		t.Errorf("Record() should restart after reaching the failure threshold, got %+v", health)
+	}
+
+	// A container that never passes is restarted once the grace period is over
+	health = &ContainerHealth{ContainerId: "container"}
+	health.Record(check, failed, startedAt, startedAt.Add(61*time.Second))
+	if !health.Record(check, failed, startedAt, startedAt.Add(71*time.Second)) {
+		t.Errorf("Record() should restart a container that never became healthy, got %+v", health)
+	}
+}
+
+func TestNormalizeCustomDomain(t *testing.T) {
+	tests := []struct {
+		domain  string
+		want    string
+		wantErr bool
+	}{
+		{"API.Example.com.", "api.example.com", false},
+		{" example.co.uk ", "example.co.uk", false},
+		{"localhost", "", true},
+		{"-bad.example.com", "", true},
+		{"under_score.example.com", "", true},
+		{"10.0.0.1", "", true},
+		{"app.beam.run", "", true},
+		{"beam.run", "", true},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizeCustomDomain(tt.domain, "beam.run")
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("NormalizeCustomDomain(%q) = %q, %v, want %q, wantErr %v", tt.domain, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestCustomDomainMatchesVerification(t *testing.T) {
+	domain := CustomDomain{Domain: "api.example.com", VerificationToken: "token-1"}
+	if got := domain.VerificationRecord(); got != "_beta9-verification.api.example.com" {
+		t.Errorf("VerificationRecord() = %q", got)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		records []string
+		want    bool
+	}{
+		{"matching record", "token-1", []string{"token-1"}, true},
+		{"among other records", "token-1", []string{"v=spf1 -all", " token-1 "}, true},
+		{"another domain's token", "token-1", []string{"token-2"}, false},
+		{"no records", "token-1", nil, false},
+		{"no token", "", []string{""}, false},
+	}
+
+	for _, tt := range tests {
+		domain := CustomDomain{Domain: "api.example.com", VerificationToken: tt.token}
+		if got := domain.MatchesVerification(tt.records); got != tt.want {
+			t.Errorf("%s: MatchesVerification(%q) = %v, want %v", tt.name, tt.records, got, tt.want)
+		}
+	}
+}
+
+func TestTokenScopesAllows(t *testing.T) {
+	if _, err := ParseTokenScopes([]string{"objects:write", "clusters:admin"}); err == nil {
+		t.Errorf("ParseTokenScopes() should reject unknown resources")
+	}
+
+	if _, err := ParseTokenScopes([]string{"objects:delete"}); err == nil {
+		t.Errorf("ParseTokenScopes() should reject unknown access levels")
+	}
+
+	scopes, err := ParseTokenScopes([]string{"objects:write", " tasks:read", "objects:write"})
+	if err != nil {
+		t.Fatalf("ParseTokenScopes() error = %v", err)
+	}
+
+	if len(scopes) != 2 {
+		t.Errorf("ParseTokenScopes() = %v, want duplicates removed", scopes)
+	}
+
+	tests := []struct {
+		scopes   TokenScopes
+		required []TokenScope
+		want     bool
+	}{
+		{nil, []TokenScope{"workspace:admin"}, true},
+		{scopes, []TokenScope{"objects:read"}, true},
+		{scopes, []TokenScope{"objects:write"}, true},
+		{scopes, []TokenScope{"objects:admin"}, false},
+		{scopes, []TokenScope{"tasks:write"}, false},
+		{scopes, []TokenScope{"deployments:read"}, false},
+		{scopes, []TokenScope{"deployments:write", "tasks:read"}, true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.scopes.Allows(tt.required...); got != tt.want {
+			t.Errorf("%v.Allows(%v) = %v, want %v", tt.scopes, tt.required, got, tt.want)
+		}
+	}
+}
+
+func TestTokenExpired(t *testing.T) {
+	tests := []struct {
+		name      string
+		expiresAt NullTime
+		want      bool
+	}{
+		{"no expiry", NullTime{}, false},
+		{"expires later", NullTime{sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true}}, false},
+		{"expired", NullTime{sql.NullTime{Time: time.Now().Add(-time.Second), Valid: true}}, true},
+	}
+
+	for _, tt := range tests {
+		token := Token{ExpiresAt: tt.expiresAt}
+		if got := token.Expired(); got != tt.want {
+			t.Errorf("%s: Expired() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestWorkspacePolicyCheckRuntime(t *testing.T) {
+	policy := WorkspacePolicy{
+		MaxConcurrentGpus:  2,
+		MaxContainerMemory: 8192,
+		AllowedGpuTypes:    PolicyGpuTypes{GPU_T4, GPU_A10G},
+	}
+
+	tests := []struct {
+		name    string
+		runtime Runtime
+		wantErr bool
+	}{
+		{"cpu only", Runtime{Cpu: 1000, Memory: 4096}, false},
+		{"memory over limit", Runtime{Memory: 16384}, true},
+		{"allowed gpu", Runtime{Memory: 4096, Gpus: []GpuType{GPU_A10G}, GpuCount: 2}, false},
+		{"legacy gpu field", Runtime{Memory: 4096, Gpu: GPU_T4}, false},
+		{"disallowed gpu", Runtime{Memory: 4096, Gpus: []GpuType{GPU_T4, GPU_H100}}, true},
+		{"any gpu", Runtime{Memory: 4096, Gpus: []GpuType{GPU_ANY}}, true},
+		{"too many gpus", Runtime{Memory: 4096, Gpus: []GpuType{GPU_T4}, GpuCount: 4}, true},
+	}
+
+	for _, tt := range tests {
+		err := policy.CheckRuntime(tt.runtime)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: CheckRuntime() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+
+		var policyErr *ErrWorkspacePolicyViolation
+		if err != nil && !errors.As(err, &policyErr) {
+			t.Errorf("%s: CheckRuntime() error = %T, want *ErrWorkspacePolicyViolation", tt.name, err)
+		}
+	}
+
+	empty := WorkspacePolicy{}
+	if err := empty.CheckRuntime(Runtime{Memory: 1 << 20, Gpus: []GpuType{GPU_ANY}, GpuCount: 8}); err != nil {
+		t.Errorf("empty policy: CheckRuntime() error = %v", err)
+	}
+}
+
+func TestWorkspacePolicyRunDuration(t *testing.T) {
+	policy := WorkspacePolicy{MaxRunDurationSeconds: 3600}
+
+	tests := []struct {
+		timeout     int
+		wantTimeout int
+		wantClamped bool
+	}{
+		{600, 600, false},
+		{3600, 3600, false},
+		{7200, 3600, true},
+		{0, 3600, true},
+		{-1, 3600, true},
+	}
+
+	for _, tt := range tests {
+		timeout, clamped := policy.ClampRunDuration(tt.timeout)
+		if timeout != tt.wantTimeout || clamped != tt.wantClamped {
+			t.Errorf("ClampRunDuration(%d) = %d, %v, want %d, %v", tt.timeout, timeout, clamped, tt.wantTimeout, tt.wantClamped)
+		}
+
+		if err := policy.CheckRunDuration(tt.timeout); (err != nil) != tt.wantClamped {
+			t.Errorf("CheckRunDuration(%d) error = %v", tt.timeout, err)
+		}
+	}
+
+	empty := WorkspacePolicy{}
+	if err := empty.CheckRunDuration(-1); err != nil {
+		t.Errorf("empty policy: CheckRunDuration() error = %v", err)
+	}
+}
+
+func TestWorkspacePolicyLimitConcurrency(t *testing.T) {
+	policy := WorkspacePolicy{MaxConcurrentGpus: 4}
+
+	limit := policy.LimitConcurrency(nil)
+	if limit == nil || limit.GPULimit != 4 || limit.CPUMillicoreLimit != math.MaxUint32 {
+		t.Errorf("LimitConcurrency(nil) = %+v", limit)
+	}
+
+	quota := &ConcurrencyLimit{GPULimit: 8, CPUMillicoreLimit: 10000}
+	limit = policy.LimitConcurrency(quota)
+	if limit.GPULimit != 4 || limit.CPUMillicoreLimit != 10000 || quota.GPULimit != 8 {
+		t.Errorf("LimitConcurrency(quota) = %+v, quota = %+v", limit, quota)
+	}
+
+	quota = &ConcurrencyLimit{GPULimit: 2, CPUMillicoreLimit: 10000}
+	if limit = policy.LimitConcurrency(quota); limit.GPULimit != 2 {
+		t.Errorf("LimitConcurrency(lower quota) = %+v", limit)
+	}
+
+	empty := WorkspacePolicy{}
+	if limit = empty.LimitConcurrency(nil); limit != nil {
+		t.Errorf("empty policy: LimitConcurrency(nil) = %+v", limit)
+	}
+}
+
+func TestObjectUploadSessionChunks(t *testing.T) {
+	tests := []struct {
+		name      string
+		size      int64
+		chunkSize int64
+		wantCount int64
+		wantLast  int64
+	}{
+		{"exact multiple", 12, 4, 3, 4},
+		{"short last chunk", 10, 4, 3, 2},
+		{"single chunk", 3, 4, 1, 3},
+		{"chunk size of the whole object", 4, 4, 1, 4},
+		{"empty object", 0, 4, 0, 0},
+		{"not chunked", 10, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := &ObjectUploadSession{Size: tt.size, ChunkSize: tt.chunkSize}
+
+			count := session.ChunkCount()
+			if count != tt.wantCount {
+				t.Errorf("ChunkCount() = %d, want %d", count, tt.wantCount)
+			}
+
+			if count == 0 {
+				return
+			}
+
+			total := int64(0)
+			for i := int64(0); i < count; i++ {
+				total += session.ChunkLength(i)
+			}
+			if total != tt.size {
+				t.Errorf("chunk lengths add up to %d, want %d", total, tt.size)
+			}
+
+			if got := session.ChunkLength(count - 1); got != tt.wantLast {
+				t.Errorf("ChunkLength(%d) = %d, want %d", count-1, got, tt.wantLast)
+			}
+		})
+	}
+}
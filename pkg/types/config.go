@@ -152,12 +152,151 @@ type ContainerCostHookConfig struct {
 }
 
 type GatewayServiceConfig struct {
-	Host            string        `key:"host" json:"host"`
-	InvokeURLType   string        `key:"invokeURLType" json:"invoke_url_type"`
-	GRPC            GRPCConfig    `key:"grpc" json:"grpc"`
-	HTTP            HTTPConfig    `key:"http" json:"http"`
-	ShutdownTimeout time.Duration `key:"shutdownTimeout" json:"shutdown_timeout"`
-	StubLimits      StubLimits    `key:"stubLimits" json:"stub_limits"`
+	Host                          string                   `key:"host" json:"host"`
+	InvokeURLType                 string                   `key:"invokeURLType" json:"invoke_url_type"`
+	GRPC                          GRPCConfig               `key:"grpc" json:"grpc"`
+	HTTP                          HTTPConfig               `key:"http" json:"http"`
+	ShutdownTimeout               time.Duration            `key:"shutdownTimeout" json:"shutdown_timeout"`
+	StubLimits                    StubLimits               `key:"stubLimits" json:"stub_limits"`
+	StorageOperationTimeout       time.Duration            `key:"storageOperationTimeout" json:"storage_operation_timeout"`
+	MaxConcurrentUploads          int                      `key:"maxConcurrentUploads" json:"max_concurrent_uploads"`
+	UploadQueueTimeout            time.Duration            `key:"uploadQueueTimeout" json:"upload_queue_timeout"`
+	UploadBandwidthLimit          int64                    `key:"uploadBandwidthLimit" json:"upload_bandwidth_limit"`
+	WorkspaceUploadBandwidthLimit int64                    `key:"workspaceUploadBandwidthLimit" json:"workspace_upload_bandwidth_limit"`
+	UploadSessionTTL              time.Duration            `key:"uploadSessionTTL" json:"upload_session_ttl"`
+	UploadHeartbeatTimeout        time.Duration            `key:"uploadHeartbeatTimeout" json:"upload_heartbeat_timeout"`
+	ObjectStreamChunkSize         int64                    `key:"objectStreamChunkSize" json:"object_stream_chunk_size"`
+	ObjectStreamWindowSize        int64                    `key:"objectStreamWindowSize" json:"object_stream_window_size"`
+	ObjectExtractMaxFiles         int                      `key:"objectExtractMaxFiles" json:"object_extract_max_files"`
+	ObjectExtractMaxBytes         int64                    `key:"objectExtractMaxBytes" json:"object_extract_max_bytes"`
+	CustomDomains                 CustomDomainsConfig      `key:"customDomains" json:"custom_domains"`
+	DeploymentWebhooks            DeploymentWebhooksConfig `key:"deploymentWebhooks" json:"deployment_webhooks"`
+	OIDC                          OIDCConfig               `key:"oidc" json:"oidc"`
+	Invites                       InvitesConfig            `key:"invites" json:"invites"`
+	ServiceAccounts               ServiceAccountsConfig    `key:"serviceAccounts" json:"service_accounts"`
+	SessionTokens                 SessionTokensConfig      `key:"sessionTokens" json:"session_tokens"`
+	TaskTriggers                  TaskTriggersConfig       `key:"taskTriggers" json:"task_triggers"`
+	RateLimits                    RateLimitsConfig         `key:"rateLimits" json:"rate_limits"`
+	IPAllowList                   IPAllowListConfig        `key:"ipAllowList" json:"ip_allowlist"`
+	InternalTLS                   InternalTLSConfig        `key:"internalTLS" json:"internal_tls"`
+}
+
+// InternalTLSConfig requires mutual TLS for traffic between the gateway and workers. The gateway signs short-lived
+// certificates for itself and for workers with an internal CA, and workers get theirs when they start.
+type InternalTLSConfig struct {
+	Enabled bool `key:"enabled" json:"enabled"`
+	// Port workers connect to the gateway on. Only clients with a certificate from the internal CA are accepted.
+	Port         int    `key:"port" json:"port"`
+	ExternalHost string `key:"externalHost" json:"external_host"`
+	ExternalPort int    `key:"externalPort" json:"external_port"`
+	// PEM files with the CA certificate and key. Only gateways need them, workers are sent the certificate.
+	CACertFile string        `key:"caCertFile" json:"ca_cert_file"`
+	CAKeyFile  string        `key:"caKeyFile" json:"ca_key_file"`
+	CertTTL    time.Duration `key:"certTTL" json:"cert_ttl"`
+}
+
+// IPAllowListConfig tells the gateway where requests really come from when checking workspace IP allowlists
+type IPAllowListConfig struct {
+	// Load balancers and proxies in front of the gateway, whose X-Forwarded-For headers are trusted
+	TrustedProxies []string `key:"trustedProxies" json:"trusted_proxies"`
+	// Networks inside the cluster, like the one containers run on. Requests from them skip allowlists.
+	InternalNetworks []string `key:"internalNetworks" json:"internal_networks"`
+}
+
+// RateLimitsConfig sets the limits each token gets unless a cluster admin overrides them for the token.
+// Zero leaves that limit off.
+type RateLimitsConfig struct {
+	Enabled              bool    `key:"enabled" json:"enabled"`
+	RequestsPerSecond    float64 `key:"requestsPerSecond" json:"requests_per_second"`
+	Burst                int     `key:"burst" json:"burst"`
+	MaxConcurrentStreams int     `key:"maxConcurrentStreams" json:"max_concurrent_streams"`
+}
+
+// ServiceAccountsConfig limits how long service account tokens are valid for. Tokens can be renewed before they
+// expire, each renewal extending them by at most MaxTokenTTL from then.
+type ServiceAccountsConfig struct {
+	DefaultTokenTTL time.Duration `key:"defaultTokenTTL" json:"default_token_ttl"`
+	MaxTokenTTL     time.Duration `key:"maxTokenTTL" json:"max_token_ttl"`
+}
+
+// SessionTokensConfig lets clients exchange a workspace token for short-lived session tokens. Access tokens are
+// refreshed with a refresh token until the session expires, SessionTTL after it started.
+type SessionTokensConfig struct {
+	Enabled bool `key:"enabled" json:"enabled"`
+	// HMAC key session tokens are signed with, shared by every gateway
+	SigningKey     string        `key:"signingKey" json:"signing_key"`
+	AccessTokenTTL time.Duration `key:"accessTokenTTL" json:"access_token_ttl"`
+	SessionTTL     time.Duration `key:"sessionTTL" json:"session_ttl"`
+	// Scopes sessions get when the client doesn't ask for any
+	DefaultScopes []string `key:"defaultScopes" json:"default_scopes"`
+}
+
+// TaskTriggersConfig controls the signed webhook urls external services call to start tasks on function and task
+// queue deployments
+type TaskTriggersConfig struct {
+	Enabled bool `key:"enabled" json:"enabled"`
+	// Largest payload a trigger accepts. Triggers can lower it, but not raise it.
+	MaxPayloadBytes int64 `key:"maxPayloadBytes" json:"max_payload_bytes"`
+	// How far signed timestamps can be from the gateway's clock, so captured deliveries can't be replayed later
+	SignatureTolerance time.Duration `key:"signatureTolerance" json:"signature_tolerance"`
+	MaxPerWorkspace    int           `key:"maxPerWorkspace" json:"max_per_workspace"`
+}
+
+// InvitesConfig controls invitations workspace admins send to onboard teammates
+type InvitesConfig struct {
+	// How long invitations are valid for, unless the admin sending one picks a shorter time
+	TTL time.Duration `key:"ttl" json:"ttl"`
+	// Link included in invitation emails, with the invitation token appended as the token query parameter
+	AcceptURL string     `key:"acceptUrl" json:"accept_url"`
+	SMTP      SMTPConfig `key:"smtp" json:"smtp"`
+}
+
+// SMTPConfig is the mail server emails are sent through. Emails aren't sent if no host is set.
+type SMTPConfig struct {
+	Host     string `key:"host" json:"host"`
+	Port     int    `key:"port" json:"port"`
+	Username string `key:"username" json:"username"`
+	Password string `key:"password" json:"password"`
+	From     string `key:"from" json:"from"`
+}
+
+// OIDCConfig lets users authenticate with ID tokens from an OpenID Connect identity provider. Users are given
+// membership in a workspace the first time they sign in, based on the groups their identity provider puts them in.
+type OIDCConfig struct {
+	Enabled   bool   `key:"enabled" json:"enabled"`
+	IssuerURL string `key:"issuerUrl" json:"issuer_url"`
+	// Audience ID tokens must be issued for
+	ClientID string `key:"clientId" json:"client_id"`
+	// Claim holding the user's groups
+	GroupsClaim string `key:"groupsClaim" json:"groups_claim"`
+	// Checked in order, the first mapping for one of the user's groups decides their workspace and role
+	GroupMappings []OIDCGroupMapping `key:"groupMappings" json:"group_mappings"`
+}
+
+type OIDCGroupMapping struct {
+	Group string `key:"group" json:"group"`
+	// External id of the workspace members of the group join
+	Workspace string `key:"workspace" json:"workspace"`
+	Role      string `key:"role" json:"role"`
+}
+
+// DeploymentWebhooksConfig controls delivery of deployment lifecycle events to workspace webhooks. They're sent
+// with the same delivery settings as object webhooks, but can be enabled on their own.
+type DeploymentWebhooksConfig struct {
+	Enabled bool `key:"enabled" json:"enabled"`
+}
+
+// CustomDomainsConfig lets workspaces serve endpoint deployments on their own domains. The gateway terminates
+// TLS for those domains itself, picking certificates by SNI and issuing them through ACME.
+type CustomDomainsConfig struct {
+	Enabled bool `key:"enabled" json:"enabled"`
+	// Port the gateway serves HTTPS for custom domains on
+	Port int `key:"port" json:"port"`
+	// Host users point their domains' CNAME records at
+	CNAMETarget      string `key:"cnameTarget" json:"cname_target"`
+	ACMEDirectoryURL string `key:"acmeDirectoryUrl" json:"acme_directory_url"`
+	ACMEEmail        string `key:"acmeEmail" json:"acme_email"`
+	MaxPerWorkspace  int    `key:"maxPerWorkspace" json:"max_per_workspace"`
 }
 
 type FileServiceConfig struct {
@@ -244,15 +383,58 @@ type PythonStandaloneConfig struct {
 }
 
 type StorageConfig struct {
-	Mode             string                 `key:"mode" json:"mode"`
-	FilesystemName   string                 `key:"fsName" json:"filesystem_name"`
-	FilesystemPath   string                 `key:"fsPath" json:"filesystem_path"`
-	ObjectPath       string                 `key:"objectPath" json:"object_path"`
-	JuiceFS          JuiceFSConfig          `key:"juicefs" json:"juicefs"`
-	Geese            GeeseConfig            `key:"geese" json:"geese"`
-	Alluxio          AlluxioConfig          `key:"alluxio" json:"alluxio"`
-	MountPoint       MountPointConfig       `key:"mountpoint" json:"mountpoint"`
-	WorkspaceStorage WorkspaceStorageConfig `key:"workspaceStorage" json:"workspace_storage"`
+	Mode                   string                  `key:"mode" json:"mode"`
+	FilesystemName         string                  `key:"fsName" json:"filesystem_name"`
+	FilesystemPath         string                  `key:"fsPath" json:"filesystem_path"`
+	ObjectPath             string                  `key:"objectPath" json:"object_path"`
+	ObjectCompression      ObjectCompression       `key:"objectCompression" json:"object_compression"`
+	ObjectDeduplication    bool                    `key:"objectDeduplication" json:"object_deduplication"`
+	ObjectQuotaBytes       int64                   `key:"objectQuotaBytes" json:"object_quota_bytes"`
+	VolumeQuotaBytes       int64                   `key:"volumeQuotaBytes" json:"volume_quota_bytes"`
+	ObjectVersionRetention int                     `key:"objectVersionRetention" json:"object_version_retention"`
+	ObjectEncryption       ObjectEncryptionConfig  `key:"objectEncryption" json:"object_encryption"`
+	ObjectIntegrityAudit   ObjectIntegrityConfig   `key:"objectIntegrityAudit" json:"object_integrity_audit"`
+	ObjectReplication      ObjectReplicationConfig `key:"objectReplication" json:"object_replication"`
+	ObjectWebhooks         ObjectWebhooksConfig    `key:"objectWebhooks" json:"object_webhooks"`
+	JuiceFS                JuiceFSConfig           `key:"juicefs" json:"juicefs"`
+	Geese                  GeeseConfig             `key:"geese" json:"geese"`
+	Alluxio                AlluxioConfig           `key:"alluxio" json:"alluxio"`
+	MountPoint             MountPointConfig        `key:"mountpoint" json:"mountpoint"`
+	WorkspaceStorage       WorkspaceStorageConfig  `key:"workspaceStorage" json:"workspace_storage"`
+}
+
+type ObjectEncryptionConfig struct {
+	Enabled   bool   `key:"enabled" json:"enabled"`
+	MasterKey string `key:"masterKey" json:"master_key"`
+}
+
+type ObjectIntegrityConfig struct {
+	Enabled    bool          `key:"enabled" json:"enabled"`
+	Interval   time.Duration `key:"interval" json:"interval"`
+	SampleSize int           `key:"sampleSize" json:"sample_size"`
+	Quarantine bool          `key:"quarantine" json:"quarantine"`
+}
+
+// ObjectReplicationConfig controls copying objects to the replica storage of workspaces that have one
+type ObjectReplicationConfig struct {
+	Enabled       bool          `key:"enabled" json:"enabled"`
+	Interval      time.Duration `key:"interval" json:"interval"`
+	BatchSize     int           `key:"batchSize" json:"batch_size"`
+	RetryInterval time.Duration `key:"retryInterval" json:"retry_interval"`
+}
+
+// ObjectWebhooksConfig controls delivery of object lifecycle notifications to workspace webhooks, and how every
+// webhook notification is delivered. Deliveries to loopback and private addresses are refused unless
+// AllowPrivateNetworks is set.
+type ObjectWebhooksConfig struct {
+	Enabled              bool          `key:"enabled" json:"enabled"`
+	Interval             time.Duration `key:"interval" json:"interval"`
+	BatchSize            int           `key:"batchSize" json:"batch_size"`
+	MaxAttempts          int           `key:"maxAttempts" json:"max_attempts"`
+	RetryBackoff         time.Duration `key:"retryBackoff" json:"retry_backoff"`
+	Timeout              time.Duration `key:"timeout" json:"timeout"`
+	DeliveryRetention    time.Duration `key:"deliveryRetention" json:"delivery_retention"`
+	AllowPrivateNetworks bool          `key:"allowPrivateNetworks" json:"allow_private_networks"`
 }
 
 type WorkspaceStorageConfig struct {
@@ -383,6 +565,52 @@ type WorkerConfig struct {
 	ContainerLogLinesPerHour     int                           `key:"containerLogLinesPerHour" json:"container_log_lines_per_hour"`
 	Failover                     FailoverConfig                `key:"failover" json:"failover"`
 	ContainerRuntime             string                        `key:"containerRuntime" json:"container_runtime"`
+	ObjectCache                  WorkerObjectCacheConfig       `key:"objectCache" json:"object_cache"`
+	Preemption                   WorkerPreemptionConfig        `key:"preemption" json:"preemption"`
+	HealthCheck                  WorkerHealthCheckConfig       `key:"healthCheck" json:"health_check"`
+	GangScheduling               GangSchedulingConfig          `key:"gangScheduling" json:"gang_scheduling"`
+	CapacityReservations         CapacityReservationConfig     `key:"capacityReservations" json:"capacity_reservations"`
+}
+
+// CapacityReservationConfig limits the GPUs workspaces can reserve ahead of time. The scheduler checks every
+// Interval that pools have the GPUs of reservations starting within LeadTime, and adds workers if they don't.
+type CapacityReservationConfig struct {
+	MaxGpus     uint32        `key:"maxGpus" json:"max_gpus"`         // GPUs one reservation may hold, 0 for no limit
+	MaxDuration time.Duration `key:"maxDuration" json:"max_duration"` // Longest window a reservation may have, 0 for no limit
+	LeadTime    time.Duration `key:"leadTime" json:"lead_time"`
+	Interval    time.Duration `key:"interval" json:"interval"`
+}
+
+// GangSchedulingConfig limits jobs that span several workers, whose containers are placed all at once or not
+// at all
+type GangSchedulingConfig struct {
+	MaxNodes int           `key:"maxNodes" json:"max_nodes"`
+	Timeout  time.Duration `key:"timeout" json:"timeout"` // How long a gang waits to be placed before it fails
+}
+
+// WorkerHealthCheckConfig controls the scheduler's health checks of available workers. Workers that fail
+// FailureThreshold checks in a row are cordoned, and their containers are stopped so they're rescheduled elsewhere.
+type WorkerHealthCheckConfig struct {
+	Enabled               bool          `key:"enabled" json:"enabled"`
+	Interval              time.Duration `key:"interval" json:"interval"`
+	Timeout               time.Duration `key:"timeout" json:"timeout"`
+	FailureThreshold      int           `key:"failureThreshold" json:"failure_threshold"`
+	DiskPressureThreshold float64       `key:"diskPressureThreshold" json:"disk_pressure_threshold"` // Fraction of a worker's disk that can be used before it's unhealthy
+}
+
+// WorkerPreemptionConfig controls how workers in spot pools watch for and react to preemption notices. The
+// checkpoint window is also the grace period of containers preempted by higher priority ones.
+type WorkerPreemptionConfig struct {
+	NoticeURL        string        `key:"noticeURL" json:"notice_url"`
+	PollInterval     time.Duration `key:"pollInterval" json:"poll_interval"`
+	CheckpointWindow time.Duration `key:"checkpointWindow" json:"checkpoint_window"` // Time preempted containers get to checkpoint after SIGTERM
+}
+
+type WorkerObjectCacheConfig struct {
+	Enabled              bool          `key:"enabled" json:"enabled"`
+	Path                 string        `key:"path" json:"path"`
+	MaxSizeBytes         int64         `key:"maxSizeBytes" json:"max_size_bytes"`
+	InvalidationInterval time.Duration `key:"invalidationInterval" json:"invalidation_interval"`
 }
 
 type ContainerResourceLimitsConfig struct {
@@ -400,8 +628,9 @@ type FailoverConfig struct {
 type PoolMode string
 
 var (
-	PoolModeLocal    PoolMode = "local"
-	PoolModeExternal PoolMode = "external"
+	PoolModeLocal      PoolMode = "local"
+	PoolModeExternal   PoolMode = "external"
+	PoolModeKubernetes PoolMode = "kubernetes" // Each container runs in a worker pod of its own, in an existing cluster
 )
 
 type WorkerPoolConfig struct {
@@ -417,6 +646,7 @@ type WorkerPoolConfig struct {
 	RequiresPoolSelector   bool                              `key:"requiresPoolSelector" json:"requires_pool_selector"`
 	Priority               int32                             `key:"priority" json:"priority"`
 	Preemptable            bool                              `key:"preemptable" json:"preemptable"`
+	Spot                   bool                              `key:"spot" json:"spot"` // Launch interruptible instances; spot pools are always preemptable
 	UserData               string                            `key:"userData" json:"user_data"`
 	CRIUEnabled            bool                              `key:"criuEnabled" json:"criu_enabled"`
 	TmpSizeLimit           string                            `key:"tmpSizeLimit" json:"tmp_size_limit"`
@@ -425,6 +655,21 @@ type WorkerPoolConfig struct {
 	StoragePath            string                            `key:"storagePath" json:"storage_path"`
 	StorageMode            string                            `key:"storageMode" json:"storage_mode"`
 	CheckpointPath         string                            `key:"checkpointPath" json:"checkpoint_path"`
+	Labels                 map[string]string                 `key:"labels" json:"labels"`        // Labels the pool's workers start with, e.g. zone
+	Region                 string                            `key:"region" json:"region"`        // Region the pool's machines run in
+	Arch                   string                            `key:"arch" json:"arch"`            // CPU architecture of the pool's machines, amd64 if not set
+	MigSlices              map[string]int                    `key:"migSlices" json:"mig_slices"` // MIG slices each of the pool's GPUs is partitioned into, by profile
+	Kubernetes             KubernetesPoolConfig              `key:"kubernetes" json:"kubernetes"`
+}
+
+// KubernetesPoolConfig is the cluster a pool in kubernetes mode runs its pods in. Pools without a host use the
+// cluster beta9 runs in. Pods in another cluster have to be reachable from the gateway, like external machines are.
+type KubernetesPoolConfig struct {
+	Host            string `key:"host" json:"host"`                         // API server, e.g. https://10.0.0.1:6443
+	Token           string `key:"token" json:"token"`                       // Service account token with access to pods and jobs in the namespace
+	CACert          string `key:"caCert" json:"ca_cert"`                    // PEM encoded CA of the API server
+	Namespace       string `key:"namespace" json:"namespace"`               // Defaults to the worker namespace
+	GPUResourceName string `key:"gpuResourceName" json:"gpu_resource_name"` // Resource the cluster's GPU device plugin advertises, defaults to nvidia.com/gpu
 }
 
 type RuntimeConfig struct {
@@ -449,14 +694,24 @@ type WorkerPoolJobSpecConfig struct {
 }
 
 type WorkerPoolJobSpecPoolSizingConfig struct {
-	DefaultWorkerCPU      string `key:"defaultWorkerCPU" json:"default_worker_cpu"`
-	DefaultWorkerMemory   string `key:"defaultWorkerMemory" json:"default_worker_memory"`
-	DefaultWorkerGpuType  string `key:"defaultWorkerGPUType" json:"default_worker_gpu_type"`
-	DefaultWorkerGpuCount string `key:"defaultWorkerGpuCount" json:"default_worker_gpu_count"`
-	MinFreeCPU            string `key:"minFreeCPU" json:"min_free_cpu"`
-	MinFreeMemory         string `key:"minFreeMemory" json:"min_free_memory"`
-	MinFreeGPU            string `key:"minFreeGPU" json:"min_free_gpu"`
-	SharedMemoryLimitPct  string `key:"sharedMemoryLimitPct" json:"shared_memory_limit_pct"`
+	DefaultWorkerCPU      string                            `key:"defaultWorkerCPU" json:"default_worker_cpu"`
+	DefaultWorkerMemory   string                            `key:"defaultWorkerMemory" json:"default_worker_memory"`
+	DefaultWorkerGpuType  string                            `key:"defaultWorkerGPUType" json:"default_worker_gpu_type"`
+	DefaultWorkerGpuCount string                            `key:"defaultWorkerGpuCount" json:"default_worker_gpu_count"`
+	MinFreeCPU            string                            `key:"minFreeCPU" json:"min_free_cpu"`
+	MinFreeMemory         string                            `key:"minFreeMemory" json:"min_free_memory"`
+	MinFreeGPU            string                            `key:"minFreeGPU" json:"min_free_gpu"`
+	SharedMemoryLimitPct  string                            `key:"sharedMemoryLimitPct" json:"shared_memory_limit_pct"`
+	Predictive            WorkerPoolPredictiveScalingConfig `key:"predictive" json:"predictive"`
+}
+
+// WorkerPoolPredictiveScalingConfig controls provisioning capacity ahead of the load deployments placed on a pool
+// in the same hour on previous days
+type WorkerPoolPredictiveScalingConfig struct {
+	Enabled      bool          `key:"enabled" json:"enabled"`
+	LookbackDays int           `key:"lookbackDays" json:"lookback_days"`
+	LeadTime     time.Duration `key:"leadTime" json:"lead_time"`
+	MinDays      int           `key:"minDays" json:"min_days"` // Days a deployment must have been busy in an hour before capacity is provisioned for it
 }
 
 type MachineProvider string
@@ -477,6 +732,17 @@ type ProviderConfig struct {
 	Crusoe     CrusoeProviderConfig     `key:"crusoe" json:"crusoe"`
 	Hydra      HydraProviderConfig      `key:"hydra" json:"hydra"`
 	Generic    GenericProviderConfig    `key:"generic" json:"generic"`
+	// Providers beta9 doesn't ship with, by name. Pools use one by setting their provider to its name.
+	Plugins map[string]ProviderPluginConfig `key:"plugins" json:"plugins"`
+}
+
+// ProviderPluginConfig is how to reach a server implementing the ProviderPlugin service in
+// pkg/providers/plugin.proto
+type ProviderPluginConfig struct {
+	Address string        `key:"address" json:"address"`
+	Token   string        `key:"token" json:"token"`
+	TLS     bool          `key:"tls" json:"tls"`
+	Timeout time.Duration `key:"timeout" json:"timeout"` // Deadline of each call to the plugin
 }
 
 type AgentConfig struct {
@@ -558,6 +824,29 @@ type MonitoringConfig struct {
 	ContainerMetricsInterval time.Duration           `key:"containerMetricsInterval" json:"container_metrics_interval"`
 	VictoriaMetrics          VictoriaMetricsConfig   `key:"victoriametrics" json:"victoriametrics"`
 	ContainerCostHookConfig  ContainerCostHookConfig `key:"containerCostHook" json:"container_cost_hook"`
+	Audit                    AuditConfig             `key:"audit" json:"audit"`
+}
+
+type AuditSink string
+
+var (
+	AuditSinkStdout AuditSink = "stdout"
+	AuditSinkFile   AuditSink = "file"
+)
+
+type AuditConfig struct {
+	Enabled bool             `key:"enabled" json:"enabled"`
+	Sink    AuditSink        `key:"sink" json:"sink"`
+	Path    string           `key:"path" json:"path"`
+	Store   AuditStoreConfig `key:"store" json:"store"`
+}
+
+// AuditStoreConfig controls recording every mutating gateway RPC to the database, where workspace admins can
+// query them with QueryAuditLog
+type AuditStoreConfig struct {
+	Enabled bool `key:"enabled" json:"enabled"`
+	// How long entries are kept for (0 keeps them forever)
+	Retention time.Duration `key:"retention" json:"retention"`
 }
 
 type VictoriaMetricsConfig struct {
@@ -670,8 +959,16 @@ var (
 )
 
 type AbstractionConfig struct {
-	Bot BotConfig `key:"bot" json:"bot"`
-	Pod PodConfig `key:"pod" json:"pod"`
+	Bot       BotConfig       `key:"bot" json:"bot"`
+	Pod       PodConfig       `key:"pod" json:"pod"`
+	TaskQueue TaskQueueConfig `key:"taskQueue" json:"task_queue"`
+}
+
+// TaskQueueConfig reserves a percentage of each queue's task slots (max containers times workers) for tasks
+// at or above a priority, so a flood of lower priority tasks can't take every slot
+type TaskQueueConfig struct {
+	HighPriorityReservedPercent   uint `key:"highPriorityReservedPercent" json:"high_priority_reserved_percent"`
+	NormalPriorityReservedPercent uint `key:"normalPriorityReservedPercent" json:"normal_priority_reserved_percent"`
 }
 
 type BotConfig struct {
@@ -58,9 +58,10 @@ var (
 )
 
 var (
-	EventWorkerLifecycleStarted = "started"
-	EventWorkerLifecycleStopped = "stopped"
-	EventWorkerLifecycleDeleted = "deleted"
+	EventWorkerLifecycleStarted   = "started"
+	EventWorkerLifecycleStopped   = "stopped"
+	EventWorkerLifecycleDeleted   = "deleted"
+	EventWorkerLifecycleUnhealthy = "unhealthy"
 )
 
 // Schema versions should be in ISO 8601 format
@@ -120,14 +121,15 @@ type EventContainerStatusRequestedSchema struct {
 	Status      string           `json:"status"`
 }
 
-var EventWorkerLifecycleSchemaVersion = "1.0"
+var EventWorkerLifecycleSchemaVersion = "1.1"
 
 type EventWorkerLifecycleSchema struct {
-	WorkerID  string              `json:"worker_id"`
-	MachineID string              `json:"machine_id"`
-	Status    string              `json:"status"`
-	PoolName  string              `json:"pool_name"`
-	Reason    DeletedWorkerReason `json:"reason"`
+	WorkerID        string              `json:"worker_id"`
+	MachineID       string              `json:"machine_id"`
+	Status          string              `json:"status"`
+	PoolName        string              `json:"pool_name"`
+	Reason          DeletedWorkerReason `json:"reason"`
+	UnhealthyReason string              `json:"unhealthy_reason,omitempty"`
 }
 
 type DeletedWorkerReason string
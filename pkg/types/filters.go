@@ -1,7 +1,9 @@
 package types
 
 import (
+	"fmt"
 	"reflect"
+	"slices"
 	"strings"
 )
 
@@ -41,6 +43,37 @@ type DeploymentFilter struct {
 	MinContainersGTE uint        `query:"min_containers"`
 	ShowDeleted      bool        `query:"show_deleted"`
 	AppId            string      `query:"app_id"`
+	NamePrefix       string      `query:"name_prefix"`
+	SortBy           string      `query:"sort_by"`    // created_at or updated_at
+	SortOrder        string      `query:"sort_order"` // asc or desc
+}
+
+// DeploymentSortColumns are the columns deployments can be sorted by. Only timestamps are allowed since
+// cursors encode the sort column's value.
+var DeploymentSortColumns = []string{"created_at", "updated_at"}
+
+// Sort returns the column and direction to order deployments by, newest first by default
+func (f DeploymentFilter) Sort() (column string, order string, err error) {
+	column, order = "created_at", "DESC"
+
+	if f.SortBy != "" {
+		if !slices.Contains(DeploymentSortColumns, f.SortBy) {
+			return "", "", fmt.Errorf("invalid sort column: %s", f.SortBy)
+		}
+		column = f.SortBy
+	}
+
+	switch strings.ToLower(f.SortOrder) {
+	case "":
+	case "asc":
+		order = "ASC"
+	case "desc":
+		order = "DESC"
+	default:
+		return "", "", fmt.Errorf("invalid sort order: %s", f.SortOrder)
+	}
+
+	return column, order, nil
 }
 
 type TaskFilter struct {
@@ -64,6 +97,18 @@ type TaskFilter struct {
 	All                 bool        `query:"all"`
 }
 
+type ObjectFilter struct {
+	BaseFilter
+	WorkspaceID    uint              `query:"workspace_id"`
+	Prefix         string            `query:"prefix"` // Matches the start of an object's key or hash
+	MinSize        int64             `query:"min_size"`
+	MaxSize        int64             `query:"max_size"`
+	CreatedAtStart string            `query:"created_at_start"`
+	CreatedAtEnd   string            `query:"created_at_end"`
+	Cursor         string            `query:"cursor"`
+	Tags           map[string]string // Matches objects carrying every one of these tags
+}
+
 // Struct that includes the custom type
 type StubFilter struct {
 	WorkspaceID string      `query:"workspace_id"`
@@ -0,0 +1,38 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeploymentFilterSort(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  DeploymentFilter
+		column  string
+		order   string
+		wantErr bool
+	}{
+		{name: "default", filter: DeploymentFilter{}, column: "created_at", order: "DESC"},
+		{name: "updated ascending", filter: DeploymentFilter{SortBy: "updated_at", SortOrder: "ASC"}, column: "updated_at", order: "ASC"},
+		{name: "order only", filter: DeploymentFilter{SortOrder: "asc"}, column: "created_at", order: "ASC"},
+		{name: "unsupported column", filter: DeploymentFilter{SortBy: "name"}, wantErr: true},
+		{name: "injection", filter: DeploymentFilter{SortBy: "created_at; DROP TABLE deployment"}, wantErr: true},
+		{name: "invalid order", filter: DeploymentFilter{SortOrder: "sideways"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			column, order, err := tt.filter.Sort()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.column, column)
+			assert.Equal(t, tt.order, order)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	pb "github.com/beam-cloud/beta9/proto"
+)
+
+const (
+	// Port rank 0 of a gang listens on for the other ranks to rendezvous with, the default of torch.distributed
+	GangRendezvousPort = 29500
+
+	DefaultGangTimeout = 10 * time.Minute
+)
+
+// GangRequest puts a container in a gang, a job spanning several workers. The gang's containers are only
+// started once all of them can be placed at the same time, each on a worker of its own, and if that doesn't
+// happen within the timeout none of them are.
+type GangRequest struct {
+	Id           string        `json:"id"`
+	Rank         uint32        `json:"rank"`
+	ContainerIds []string      `json:"container_ids"` // By rank
+	Timeout      time.Duration `json:"timeout"`       // How long the gang waits to be placed before it fails
+
+	// Requests of the other ranks. The gang waits in the backlog as rank 0's request, which carries them.
+	Members []*ContainerRequest `json:"members,omitempty"`
+}
+
+func (g *GangRequest) Size() int {
+	return len(g.ContainerIds)
+}
+
+// Requests returns the requests of the whole gang by rank, given rank 0's request
+func (g *GangRequest) Requests(leader *ContainerRequest) []*ContainerRequest {
+	return append([]*ContainerRequest{leader}, g.Members...)
+}
+
+// Environment returns the env vars distributed training frameworks rendezvous with, given the address rank 0
+// listens on
+func (g *GangRequest) Environment(masterAddr string, masterPort int) []string {
+	return []string{
+		fmt.Sprintf("BETA9_GANG_ID=%s", g.Id),
+		fmt.Sprintf("MASTER_ADDR=%s", masterAddr),
+		fmt.Sprintf("MASTER_PORT=%d", masterPort),
+		fmt.Sprintf("NNODES=%d", g.Size()),
+		fmt.Sprintf("NODE_RANK=%d", g.Rank),
+		fmt.Sprintf("WORLD_SIZE=%d", g.Size()),
+		fmt.Sprintf("RANK=%d", g.Rank),
+	}
+}
+
+func (g *GangRequest) ToProto() *pb.GangRequest {
+	if g == nil {
+		return nil
+	}
+
+	return &pb.GangRequest{
+		Id:           g.Id,
+		Rank:         g.Rank,
+		ContainerIds: g.ContainerIds,
+		Timeout:      int64(g.Timeout),
+	}
+}
+
+func NewGangRequestFromProto(in *pb.GangRequest) *GangRequest {
+	if in == nil {
+		return nil
+	}
+
+	return &GangRequest{
+		Id:           in.Id,
+		Rank:         in.Rank,
+		ContainerIds: in.ContainerIds,
+		Timeout:      time.Duration(in.Timeout),
+	}
+}
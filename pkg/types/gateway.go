@@ -4,10 +4,12 @@ const (
 	DefaultGatewayServiceName          string = "gateway"
 	DefaultExtractedObjectPath         string = "/data/unpacked"
 	DefaultVolumesPath                 string = "/data/volumes"
+	DefaultVolumeSnapshotsPath         string = "/data/volume-snapshots"
 	DefaultObjectPath                  string = "/data/objects"
 	DefaultOutputsPath                 string = "/data/outputs"
 	DefaultObjectPrefix                string = "objects"
 	DefaultVolumesPrefix               string = "volumes"
+	DefaultVolumeSnapshotsPrefix       string = "volume-snapshots"
 	DefaultOutputsPrefix               string = "outputs"
 	DefaultFilesystemName              string = "beta9-fs"
 	DefaultFilesystemPath              string = "/data"
@@ -2,6 +2,10 @@ package types
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
 	"strings"
 )
 
@@ -77,6 +81,12 @@ const (
 	GPU_ANY GpuType = "any"
 )
 
+// How the GPUs of a worker with more than one GPU are connected to each other
+const (
+	GpuInterconnectNVLink = "nvlink"
+	GpuInterconnectPCIe   = "pcie"
+)
+
 func AllGPUTypes() []GpuType {
 	return []GpuType{GPU_A10G, GPU_A100_40, GPU_A100_80, GPU_L4, GPU_T4, GPU_H100, GPU_A6000, GPU_RTX4090, GPU_L40S, GPU_ANY}
 }
@@ -96,3 +106,107 @@ func GPUTypesToMap(gpuTypes []GpuType) map[string]int {
 	}
 	return gpuTypesMap
 }
+
+// Memory of each GPU type in MiB, which fractional GPU requests are partitioned by
+var gpuTypeMemory = map[GpuType]int64{
+	GPU_A10G:    24 * 1024,
+	GPU_A100_40: 40 * 1024,
+	GPU_A100_80: 80 * 1024,
+	GPU_L4:      24 * 1024,
+	GPU_T4:      16 * 1024,
+	GPU_H100:    80 * 1024,
+	GPU_A6000:   48 * 1024,
+	GPU_RTX4090: 24 * 1024,
+	GPU_L40S:    48 * 1024,
+}
+
+// Smallest share of a GPU a container can request
+const MinGpuFraction = 0.05
+
+// GpuMemory returns the memory of a GPU type in MiB, or 0 if it isn't known
+func GpuMemory(gpu GpuType) int64 {
+	return gpuTypeMemory[gpu]
+}
+
+// GpuShareMemory is the GPU memory in MiB a container gets for a fraction of a GPU
+func GpuShareMemory(gpu GpuType, fraction float64) int64 {
+	return int64(math.Ceil(fraction * float64(GpuMemory(gpu))))
+}
+
+// ValidateGpuFraction checks a fractional GPU request is for a share of a single GPU. A fraction of 0 requests
+// whole GPUs.
+func ValidateGpuFraction(fraction float64, gpus []GpuType, gpuCount uint32) error {
+	if fraction == 0 {
+		return nil
+	}
+
+	if fraction < MinGpuFraction || fraction >= 1 {
+		return fmt.Errorf("GPU fraction must be at least %v and less than 1", MinGpuFraction)
+	}
+
+	if len(gpus) == 0 {
+		return errors.New("GPU fraction requires a GPU type")
+	}
+
+	if gpuCount > 1 {
+		return errors.New("GPU fraction can't be combined with multiple GPUs")
+	}
+
+	for _, gpu := range gpus {
+		if gpu != GPU_ANY && GpuMemory(gpu) == 0 {
+			return fmt.Errorf("GPU type %s can't be shared", gpu)
+		}
+	}
+
+	return nil
+}
+
+// GpuShares is the free memory in MiB of each GPU a worker shares between containers, as a comma separated list.
+// A share keeps its index while any container uses it, so containers can give back their memory when they
+// exit. Shares of GPUs that are no longer shared are -1, and their GPU is counted as free again.
+type GpuShares string
+
+func NewGpuShares(free []int64) GpuShares {
+	// Trailing unused shares don't need to be kept
+	for len(free) > 0 && free[len(free)-1] < 0 {
+		free = free[:len(free)-1]
+	}
+
+	values := make([]string, 0, len(free))
+	for _, f := range free {
+		values = append(values, strconv.FormatInt(f, 10))
+	}
+
+	return GpuShares(strings.Join(values, ","))
+}
+
+// Free returns the free memory of each share, -1 for shares that aren't in use
+func (s GpuShares) Free() []int64 {
+	free := []int64{}
+	if s == "" {
+		return free
+	}
+
+	for _, value := range strings.Split(string(s), ",") {
+		f, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			f = -1
+		}
+		free = append(free, f)
+	}
+
+	return free
+}
+
+// bestFit returns the share with the least free memory that still fits memory, so partly used GPUs fill up before
+// another GPU is shared, or -1 if none fit
+func (s GpuShares) bestFit(memory int64) int {
+	best := -1
+	free := s.Free()
+	for i, f := range free {
+		if f >= memory && (best == -1 || f < free[best]) {
+			best = i
+		}
+	}
+	return best
+}
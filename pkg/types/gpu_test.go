@@ -0,0 +1,81 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateGpuFraction(t *testing.T) {
+	tests := []struct {
+		name     string
+		fraction float64
+		gpus     []GpuType
+		gpuCount uint32
+		wantErr  bool
+	}{
+		{"whole gpus", 0, []GpuType{GPU_A10G}, 2, false},
+		{"quarter", 0.25, []GpuType{GPU_A10G}, 1, false},
+		{"any gpu", 0.5, []GpuType{GPU_ANY}, 1, false},
+		{"too small", 0.01, []GpuType{GPU_A10G}, 1, true},
+		{"whole gpu", 1, []GpuType{GPU_A10G}, 1, true},
+		{"no gpu", 0.5, nil, 0, true},
+		{"multiple gpus", 0.5, []GpuType{GPU_A10G}, 2, true},
+		{"unknown memory", 0.5, []GpuType{GPU_A100_80, GpuType("B200")}, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateGpuFraction(tt.fraction, tt.gpus, tt.gpuCount)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestWorkerGpuShares checks fractional requests fill a shared GPU before taking another, and that a GPU is
+// free again once all of its shares are released
+func TestWorkerGpuShares(t *testing.T) {
+	worker := &Worker{Gpu: string(GPU_A10G), FreeGpuCount: 2}
+	quarter := GpuShareMemory(GPU_A10G, 0.25)
+	half := GpuShareMemory(GPU_A10G, 0.5)
+	assert.Equal(t, int64(6144), quarter)
+
+	first, err := worker.ReserveGpuShare(half)
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), first)
+	assert.Equal(t, uint32(1), worker.FreeGpuCount)
+
+	second, err := worker.ReserveGpuShare(quarter)
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), second)
+	assert.Equal(t, uint32(1), worker.FreeGpuCount)
+
+	third, err := worker.ReserveGpuShare(half)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), third)
+	assert.Equal(t, uint32(0), worker.FreeGpuCount)
+	assert.Equal(t, GpuShares("6144,12288"), worker.GpuShares)
+
+	assert.True(t, worker.CanFitGpuShare(quarter))
+	assert.False(t, worker.CanFitGpuShare(GpuShareMemory(GPU_A10G, 0.75)))
+	_, err = worker.ReserveGpuShare(GpuShareMemory(GPU_A10G, 0.75))
+	assert.Error(t, err)
+
+	worker.ReleaseGpuShare(first, half)
+	worker.ReleaseGpuShare(second, quarter)
+	assert.Equal(t, uint32(1), worker.FreeGpuCount)
+	assert.Equal(t, GpuShares("-1,12288"), worker.GpuShares)
+
+	worker.ReleaseGpuShare(third, half)
+	assert.Equal(t, uint32(2), worker.FreeGpuCount)
+	assert.Equal(t, GpuShares(""), worker.GpuShares)
+
+	// Shares that were already released are ignored
+	worker.ReleaseGpuShare(third, half)
+	assert.Equal(t, uint32(2), worker.FreeGpuCount)
+}
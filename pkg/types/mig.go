@@ -0,0 +1,185 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MIG profiles each GPU type can be partitioned into, with how many slices of the profile fit on one GPU
+var gpuTypeMigProfiles = map[GpuType]map[string]int{
+	GPU_A100_40: {"1g.5gb": 7, "1g.10gb": 4, "2g.10gb": 3, "3g.20gb": 2, "4g.20gb": 1, "7g.40gb": 1},
+	GPU_A100_80: {"1g.10gb": 7, "1g.20gb": 4, "2g.20gb": 3, "3g.40gb": 2, "4g.40gb": 1, "7g.80gb": 1},
+	GPU_H100:    {"1g.10gb": 7, "1g.20gb": 4, "2g.20gb": 3, "3g.40gb": 2, "4g.40gb": 1, "7g.80gb": 1},
+}
+
+// MigProfiles returns the MIG profiles a GPU type can be partitioned into, or none if it doesn't support MIG
+func MigProfiles(gpu GpuType) []string {
+	return sortedProfiles(gpuTypeMigProfiles[gpu])
+}
+
+func sortedProfiles(counts map[string]int) []string {
+	profiles := make([]string, 0, len(counts))
+	for profile := range counts {
+		profiles = append(profiles, profile)
+	}
+	sort.Strings(profiles)
+	return profiles
+}
+
+// ValidateMigProfile checks a request for a MIG slice is for a single slice of a GPU type that has the profile. An
+// empty profile requests whole GPUs.
+func ValidateMigProfile(profile string, gpus []GpuType, gpuCount uint32, fraction float64) error {
+	if profile == "" {
+		return nil
+	}
+
+	if len(gpus) == 0 {
+		return errors.New("MIG profile requires a GPU type")
+	}
+
+	if gpuCount > 1 {
+		return errors.New("MIG profile can't be combined with multiple GPUs")
+	}
+
+	if fraction > 0 {
+		return errors.New("MIG profile can't be combined with a GPU fraction")
+	}
+
+	for _, gpu := range gpus {
+		if gpu == GPU_ANY {
+			continue
+		}
+
+		if _, ok := gpuTypeMigProfiles[gpu][profile]; !ok {
+			return fmt.Errorf("GPU type %s has no MIG profile %s, must be one of %v", gpu, profile, MigProfiles(gpu))
+		}
+	}
+
+	return nil
+}
+
+// MigSlices is the number of MIG slices of each profile, as a comma separated list of profile=count sorted by
+// profile, e.g. 1g.10gb=3,3g.40gb=1. Workers keep the slices their GPUs are partitioned into and the ones that
+// are free, which containers that request a profile take one of.
+type MigSlices string
+
+func NewMigSlices(counts map[string]int) MigSlices {
+	values := []string{}
+	for _, profile := range sortedProfiles(counts) {
+		values = append(values, fmt.Sprintf("%s=%d", profile, counts[profile]))
+	}
+
+	return MigSlices(strings.Join(values, ","))
+}
+
+// MigSlicesForGpus returns the slices of gpuCount GPUs that are each partitioned into layout, the number of
+// slices of each profile on one GPU
+func MigSlicesForGpus(layout map[string]int, gpuCount uint32) MigSlices {
+	if len(layout) == 0 || gpuCount == 0 {
+		return ""
+	}
+
+	counts := map[string]int{}
+	for profile, count := range layout {
+		counts[profile] = count * int(gpuCount)
+	}
+
+	return NewMigSlices(counts)
+}
+
+// Counts returns the number of slices of each profile. Entries that can't be parsed are skipped.
+func (s MigSlices) Counts() map[string]int {
+	counts := map[string]int{}
+	if s == "" {
+		return counts
+	}
+
+	for _, value := range strings.Split(string(s), ",") {
+		profile, count, ok := strings.Cut(strings.TrimSpace(value), "=")
+		if !ok {
+			continue
+		}
+
+		n, err := strconv.Atoi(count)
+		if err != nil {
+			continue
+		}
+		counts[profile] = n
+	}
+
+	return counts
+}
+
+func (s MigSlices) Count(profile string) int {
+	return s.Counts()[profile]
+}
+
+// UsesMig reports whether the worker's GPUs are partitioned into MIG slices. Those GPUs can't be handed out
+// whole, so the worker only runs containers that request a slice.
+func (w *Worker) UsesMig() bool {
+	return w.TotalMigSlices != ""
+}
+
+// CanFitMigSlice reports whether the worker has a free MIG slice of the profile
+func (w *Worker) CanFitMigSlice(profile string) bool {
+	return w.FreeMigSlices.Count(profile) > 0
+}
+
+// ReserveMigSlice takes one of the worker's free MIG slices of the profile
+func (w *Worker) ReserveMigSlice(profile string) error {
+	if !w.CanFitMigSlice(profile) {
+		return fmt.Errorf("no free MIG slice of profile %s", profile)
+	}
+
+	free := w.FreeMigSlices.Counts()
+	free[profile]--
+	w.FreeMigSlices = NewMigSlices(free)
+	return nil
+}
+
+// ReleaseMigSlice gives a MIG slice of the profile back to the worker
+func (w *Worker) ReleaseMigSlice(profile string) {
+	free := w.FreeMigSlices.Counts()
+	if free[profile] >= w.TotalMigSlices.Count(profile) {
+		return
+	}
+
+	free[profile]++
+	w.FreeMigSlices = NewMigSlices(free)
+}
+
+// SetMigSlices replaces the worker's MIG slices with the ones it found when it started, keeping the slices
+// containers placed on it in the meantime have taken
+func (w *Worker) SetMigSlices(total MigSlices) {
+	oldTotal, oldFree := w.TotalMigSlices.Counts(), w.FreeMigSlices.Counts()
+
+	free := total.Counts()
+	for profile := range free {
+		free[profile] -= oldTotal[profile] - oldFree[profile]
+	}
+
+	w.TotalMigSlices = total
+	w.FreeMigSlices = NewMigSlices(free)
+}
+
+// UsesMig reports whether the container runs on a MIG slice of a GPU rather than whole GPUs
+func (c *ContainerRequest) UsesMig() bool {
+	return c.RequiresGPU() && c.MigProfile != ""
+}
+
+// FitsMigLayout reports whether workers with GPUs partitioned into layout can run the container. Workers of pools
+// without a layout have whole GPUs, which containers that request a slice don't run on.
+func (c *ContainerRequest) FitsMigLayout(layout map[string]int) bool {
+	if !c.RequiresGPU() {
+		return true
+	}
+
+	if c.UsesMig() {
+		return layout[c.MigProfile] > 0
+	}
+
+	return len(layout) == 0
+}
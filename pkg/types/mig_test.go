@@ -0,0 +1,56 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateMigProfile(t *testing.T) {
+	a100 := []GpuType{GPU_A100_80}
+
+	assert.NoError(t, ValidateMigProfile("", nil, 0, 0))
+	assert.NoError(t, ValidateMigProfile("1g.10gb", a100, 1, 0))
+	assert.NoError(t, ValidateMigProfile("1g.10gb", []GpuType{GPU_ANY}, 0, 0))
+
+	assert.Error(t, ValidateMigProfile("1g.10gb", nil, 0, 0))
+	assert.Error(t, ValidateMigProfile("1g.10gb", a100, 2, 0))
+	assert.Error(t, ValidateMigProfile("1g.10gb", a100, 1, 0.5))
+	assert.Error(t, ValidateMigProfile("1g.5gb", a100, 1, 0))
+	assert.Error(t, ValidateMigProfile("1g.10gb", []GpuType{GPU_A10G}, 1, 0))
+}
+
+func TestMigSlices(t *testing.T) {
+	slices := MigSlicesForGpus(map[string]int{"3g.40gb": 1, "1g.10gb": 4}, 2)
+	assert.Equal(t, MigSlices("1g.10gb=8,3g.40gb=2"), slices)
+	assert.Equal(t, map[string]int{"1g.10gb": 8, "3g.40gb": 2}, slices.Counts())
+	assert.Equal(t, MigSlices(""), MigSlicesForGpus(nil, 2))
+
+	worker := &Worker{TotalMigSlices: "1g.10gb=1,3g.40gb=1", FreeMigSlices: "1g.10gb=1,3g.40gb=1"}
+	assert.True(t, worker.UsesMig())
+	assert.False(t, worker.CanFitMigSlice("7g.80gb"))
+
+	assert.NoError(t, worker.ReserveMigSlice("1g.10gb"))
+	assert.Error(t, worker.ReserveMigSlice("1g.10gb"))
+	assert.Equal(t, MigSlices("1g.10gb=0,3g.40gb=1"), worker.FreeMigSlices)
+
+	// The slices the worker reports keep the ones containers have already taken
+	worker.SetMigSlices("1g.10gb=7")
+	assert.Equal(t, MigSlices("1g.10gb=7"), worker.TotalMigSlices)
+	assert.Equal(t, MigSlices("1g.10gb=6"), worker.FreeMigSlices)
+
+	worker.ReleaseMigSlice("1g.10gb")
+	worker.ReleaseMigSlice("1g.10gb")
+	assert.Equal(t, MigSlices("1g.10gb=7"), worker.FreeMigSlices)
+
+	// Pools with MIG slices only run requests for one of them
+	request := &ContainerRequest{GpuRequest: []string{"A100-80"}, MigProfile: "1g.10gb"}
+	assert.True(t, request.FitsMigLayout(map[string]int{"1g.10gb": 7}))
+	assert.False(t, request.FitsMigLayout(map[string]int{"3g.40gb": 2}))
+	assert.False(t, request.FitsMigLayout(nil))
+
+	request.MigProfile = ""
+	assert.True(t, request.FitsMigLayout(nil))
+	assert.False(t, request.FitsMigLayout(map[string]int{"1g.10gb": 7}))
+	assert.True(t, (&ContainerRequest{}).FitsMigLayout(map[string]int{"1g.10gb": 7}))
+}
@@ -0,0 +1,33 @@
+package types
+
+import "fmt"
+
+// PriorityClass ranks a stub's containers when capacity is short. Pending containers can preempt running
+// containers of a lower class, and containers without a class are in the default class.
+type PriorityClass string
+
+const (
+	PriorityClassProduction PriorityClass = "production"
+	PriorityClassDefault    PriorityClass = "default"
+	PriorityClassBatch      PriorityClass = "batch"
+)
+
+func (p PriorityClass) Rank() int {
+	switch p {
+	case PriorityClassProduction:
+		return 2
+	case PriorityClassBatch:
+		return 0
+	}
+
+	return 1
+}
+
+func (p PriorityClass) Validate() error {
+	switch p {
+	case "", PriorityClassProduction, PriorityClassDefault, PriorityClassBatch:
+		return nil
+	}
+
+	return fmt.Errorf("invalid priority class: %s, must be one of %s, %s or %s", p, PriorityClassProduction, PriorityClassDefault, PriorityClassBatch)
+}
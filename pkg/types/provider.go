@@ -20,6 +20,7 @@ type ProviderComputeRequest struct {
 	Memory   int64
 	Gpu      string
 	GpuCount uint32
+	Spot     bool // Providers without interruptible instances ignore this
 }
 
 type ProviderMachine struct {
@@ -60,6 +61,8 @@ type ProviderMachineState struct {
 	LastKeepalive     string        `json:"last_keepalive" redis:"last_keepalive"`
 	AutoConsolidate   bool          `json:"auto_consolidate" redis:"auto_consolidate"`
 	AgentVersion      string        `json:"agent_version" redis:"agent_version"`
+	InstanceType      string        `json:"instance_type" redis:"instance_type"`
+	HourlyPrice       float64       `json:"hourly_price" redis:"hourly_price"` // In USD, as reported by the provider
 }
 
 type ProviderNotImplemented struct {
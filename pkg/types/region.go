@@ -0,0 +1,64 @@
+package types
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// Score a preferred region adds to the workers in it. It outweighs the default affinity weight, so a region
+// preference isn't undone by a single preferred label.
+const DefaultRegionWeight int32 = 20
+
+// WithRegions returns a copy of the affinity that pins containers to regions, or prefers them when pinned is
+// false. Regions are matched against the region label of workers.
+func (a *WorkerAffinity) WithRegions(regions []string, pinned bool) (*WorkerAffinity, error) {
+	if len(regions) == 0 {
+		return a, nil
+	}
+
+	for _, region := range regions {
+		if strings.TrimSpace(region) == "" {
+			return nil, errors.New("regions can't be empty")
+		}
+	}
+
+	affinity := &WorkerAffinity{}
+	if a != nil {
+		*affinity = *a
+	}
+
+	selector := WorkerLabelSelector{Key: WorkerLabelRegion, Operator: LabelSelectorIn, Values: regions}
+	if pinned {
+		affinity.Required = append(slices.Clone(affinity.Required), selector)
+	} else {
+		selector.Weight = DefaultRegionWeight
+		affinity.Preferred = append(slices.Clone(affinity.Preferred), selector)
+	}
+
+	return affinity, nil
+}
+
+// DataRegions counts the volumes and bucket mounts of the request that live in each region. Volumes live in the
+// workspace's storage, so they only have a region when the workspace has storage of its own.
+func (c *ContainerRequest) DataRegions() map[string]int {
+	regions := map[string]int{}
+
+	storageRegion := ""
+	if c.StorageAvailable() && c.Workspace.Storage.Region != nil {
+		storageRegion = *c.Workspace.Storage.Region
+	}
+
+	for _, m := range c.Mounts {
+		switch {
+		case m.MountPointConfig != nil:
+			if m.MountPointConfig.Region != "" {
+				regions[m.MountPointConfig.Region]++
+			}
+		case strings.HasPrefix(m.MountPath, WorkerContainerVolumePath) && storageRegion != "":
+			regions[storageRegion]++
+		}
+	}
+
+	return regions
+}
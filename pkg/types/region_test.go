@@ -0,0 +1,57 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkerAffinityWithRegions(t *testing.T) {
+	east := &Worker{Id: "w1", Region: "us-east-1"}
+	west := &Worker{Id: "w2", Region: "us-west-2"}
+	unknown := &Worker{Id: "w3"}
+
+	var none *WorkerAffinity
+	unchanged, err := none.WithRegions(nil, true)
+	require.NoError(t, err)
+	assert.Nil(t, unchanged)
+
+	pinned, err := none.WithRegions([]string{"us-east-1"}, true)
+	require.NoError(t, err)
+	assert.NoError(t, pinned.Validate())
+	assert.True(t, pinned.Allows(east.Label))
+	assert.False(t, pinned.Allows(west.Label))
+	assert.False(t, pinned.Allows(unknown.Label))
+
+	base := &WorkerAffinity{Required: []WorkerLabelSelector{{Key: "disk", Operator: LabelSelectorDoesNotExist}}}
+	preferred, err := base.WithRegions([]string{"us-west-2"}, false)
+	require.NoError(t, err)
+	assert.Len(t, base.Preferred, 0)
+	assert.True(t, preferred.Allows(east.Label))
+	assert.Equal(t, DefaultRegionWeight, preferred.Score(west.Label))
+	assert.Equal(t, int32(0), preferred.Score(east.Label))
+
+	_, err = none.WithRegions([]string{" "}, false)
+	assert.Error(t, err)
+}
+
+func TestContainerRequestDataRegions(t *testing.T) {
+	storageId := uint(1)
+	storageRegion := "us-east-1"
+
+	request := &ContainerRequest{
+		Workspace: Workspace{Storage: &WorkspaceStorage{Id: &storageId, Region: &storageRegion}},
+		Mounts: []Mount{
+			{MountPath: WorkerUserCodeVolume},
+			{MountPath: WorkerContainerVolumePath + "/weights"},
+			{MountPath: WorkerContainerVolumePath + "/datasets"},
+			{MountPath: "/bucket", MountPointConfig: &MountPointConfig{Region: "eu-west-1"}},
+		},
+	}
+	assert.Equal(t, map[string]int{"us-east-1": 2, "eu-west-1": 1}, request.DataRegions())
+
+	// Volumes without workspace storage don't have a known region
+	request.Workspace.Storage = nil
+	assert.Equal(t, map[string]int{"eu-west-1": 1}, request.DataRegions())
+}
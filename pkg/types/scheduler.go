@@ -2,7 +2,9 @@ package types
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -21,6 +23,7 @@ const (
 	WorkerStatusAvailable WorkerStatus = "available"
 	WorkerStatusPending   WorkerStatus = "pending"
 	WorkerStatusDisabled  WorkerStatus = "disabled"
+	WorkerStatusDrained   WorkerStatus = "drained" // Cordoned with no containers left, ready to be terminated
 	WorkerStateTtlS       int          = 60
 )
 
@@ -57,6 +60,75 @@ type Worker struct {
 	BuildVersion         string       `json:"build_version" redis:"build_version"`
 	ActiveContainers     []Container  `json:"active_containers" redis:"active_containers"`
 	Runtime              string       `json:"runtime" redis:"runtime"`
+	GpuShares            GpuShares    `json:"gpu_shares" redis:"gpu_shares"`
+	Labels               WorkerLabels `json:"labels" redis:"labels"`
+	Region               string       `json:"region" redis:"region"`
+	GpuInterconnect      string       `json:"gpu_interconnect" redis:"gpu_interconnect"`
+	ServerAddress        string       `json:"server_address" redis:"server_address"`     // Address of the worker's container server
+	UnhealthyReason      string       `json:"unhealthy_reason" redis:"unhealthy_reason"` // Why the worker was cordoned by its health checks
+	Arch                 string       `json:"arch" redis:"arch"`                         // CPU architecture, e.g. amd64 or arm64
+	TotalMigSlices       MigSlices    `json:"total_mig_slices" redis:"total_mig_slices"` // Slices the worker's GPUs are partitioned into
+	FreeMigSlices        MigSlices    `json:"free_mig_slices" redis:"free_mig_slices"`
+}
+
+// Cordoned reports whether the worker has been taken out of scheduling, including workers that are drained
+func (w *Worker) Cordoned() bool {
+	return w.Status == WorkerStatusDisabled || w.Status == WorkerStatusDrained
+}
+
+// CanFitGpuShare reports whether the worker has room for a container using memory MiB of one of its GPUs
+func (w *Worker) CanFitGpuShare(memory int64) bool {
+	if memory <= 0 || memory > GpuMemory(GpuType(w.Gpu)) {
+		return false
+	}
+
+	return w.HasGpuShareFor(memory) || w.FreeGpuCount > 0
+}
+
+// HasGpuShareFor reports whether a GPU the worker already shares has memory MiB free
+func (w *Worker) HasGpuShareFor(memory int64) bool {
+	return w.GpuShares.bestFit(memory) >= 0
+}
+
+// ReserveGpuShare takes memory MiB from the shared GPU it fits best, sharing one of the worker's free GPUs if none
+// of the shared ones have room. It returns the index of the share.
+func (w *Worker) ReserveGpuShare(memory int64) (int32, error) {
+	if !w.CanFitGpuShare(memory) {
+		return 0, errors.New("no GPU has enough free memory for the share")
+	}
+
+	free := w.GpuShares.Free()
+	share := w.GpuShares.bestFit(memory)
+	if share < 0 {
+		w.FreeGpuCount--
+
+		share = slices.Index(free, -1)
+		if share < 0 {
+			share = len(free)
+			free = append(free, -1)
+		}
+		free[share] = GpuMemory(GpuType(w.Gpu))
+	}
+
+	free[share] -= memory
+	w.GpuShares = NewGpuShares(free)
+	return int32(share), nil
+}
+
+// ReleaseGpuShare gives memory MiB back to a share, and its GPU back to the worker once no container uses it
+func (w *Worker) ReleaseGpuShare(share int32, memory int64) {
+	free := w.GpuShares.Free()
+	if share < 0 || int(share) >= len(free) || free[share] < 0 {
+		return
+	}
+
+	free[share] += memory
+	if free[share] >= GpuMemory(GpuType(w.Gpu)) {
+		free[share] = -1
+		w.FreeGpuCount++
+	}
+
+	w.GpuShares = NewGpuShares(free)
 }
 
 func (w *Worker) ToProto() *pb.Worker {
@@ -83,6 +155,14 @@ func (w *Worker) ToProto() *pb.Worker {
 		Preemptable:          w.Preemptable,
 		BuildVersion:         w.BuildVersion,
 		ActiveContainers:     containers,
+		GpuShares:            string(w.GpuShares),
+		Labels:               w.Labels.Map(),
+		Region:               w.Region,
+		GpuInterconnect:      w.GpuInterconnect,
+		UnhealthyReason:      w.UnhealthyReason,
+		Arch:                 w.Arch,
+		TotalMigSlices:       string(w.TotalMigSlices),
+		FreeMigSlices:        string(w.FreeMigSlices),
 	}
 }
 
@@ -110,6 +190,14 @@ func NewWorkerFromProto(in *pb.Worker) *Worker {
 		Preemptable:          in.Preemptable,
 		BuildVersion:         in.BuildVersion,
 		ActiveContainers:     containers,
+		GpuShares:            GpuShares(in.GpuShares),
+		Labels:               NewWorkerLabels(in.Labels),
+		Region:               in.Region,
+		GpuInterconnect:      in.GpuInterconnect,
+		UnhealthyReason:      in.UnhealthyReason,
+		Arch:                 in.Arch,
+		TotalMigSlices:       MigSlices(in.TotalMigSlices),
+		FreeMigSlices:        MigSlices(in.FreeMigSlices),
 	}
 }
 
@@ -148,6 +236,16 @@ type ContainerState struct {
 	Cpu         int64           `redis:"cpu" json:"cpu"`
 	Memory      int64           `redis:"memory" json:"memory"`
 	StartedAt   int64           `redis:"started_at" json:"started_at"`
+	// PriorityClass decides which running containers pending ones with a higher class can preempt
+	PriorityClass PriorityClass `redis:"priority_class" json:"priority_class"`
+	// The weakest link between the container's GPUs, as nvidia-smi names it, and the NUMA nodes they're on
+	GpuInterconnect string `redis:"gpu_interconnect" json:"gpu_interconnect"`
+	NumaNodes       string `redis:"numa_nodes" json:"numa_nodes"`
+	// The share of a GPU a fractional GPU container was scheduled on, and the MiB of it the container holds
+	GpuShare       int32 `redis:"gpu_share" json:"gpu_share"`
+	GpuMemoryLimit int64 `redis:"gpu_memory_limit" json:"gpu_memory_limit"`
+	// The MIG slice the container runs on, if it requested one
+	MigProfile string `redis:"mig_profile" json:"mig_profile"`
 }
 
 // @go2proto
@@ -161,32 +259,39 @@ type Container struct {
 	WorkerId     string          `redis:"worker_id" json:"worker_id"`
 	MachineId    string          `redis:"machine_id" json:"machine_id"`
 	DeploymentId string          `redis:"deployment_id" json:"deployment_id"`
+	// The weakest link between the container's GPUs, as nvidia-smi names it, and the NUMA nodes they're on
+	GpuInterconnect string `redis:"gpu_interconnect" json:"gpu_interconnect"`
+	NumaNodes       string `redis:"numa_nodes" json:"numa_nodes"`
 }
 
 func (c *Container) ToProto() *pb.Container {
 	return &pb.Container{
-		ContainerId:  c.ContainerId,
-		StubId:       c.StubId,
-		Status:       string(c.Status),
-		ScheduledAt:  timestamppb.New(c.ScheduledAt),
-		StartedAt:    timestamppb.New(c.StartedAt),
-		WorkspaceId:  c.WorkspaceId,
-		WorkerId:     c.WorkerId,
-		MachineId:    c.MachineId,
-		DeploymentId: c.DeploymentId,
+		ContainerId:     c.ContainerId,
+		StubId:          c.StubId,
+		Status:          string(c.Status),
+		ScheduledAt:     timestamppb.New(c.ScheduledAt),
+		StartedAt:       timestamppb.New(c.StartedAt),
+		WorkspaceId:     c.WorkspaceId,
+		WorkerId:        c.WorkerId,
+		MachineId:       c.MachineId,
+		DeploymentId:    c.DeploymentId,
+		GpuInterconnect: c.GpuInterconnect,
+		NumaNodes:       c.NumaNodes,
 	}
 }
 
 func NewContainerFromProto(in *pb.Container) *Container {
 	return &Container{
-		ContainerId: in.ContainerId,
-		StubId:      in.StubId,
-		Status:      ContainerStatus(in.Status),
-		ScheduledAt: in.ScheduledAt.AsTime(),
-		StartedAt:   in.StartedAt.AsTime(),
-		WorkspaceId: in.WorkspaceId,
-		WorkerId:    in.WorkerId,
-		MachineId:   in.MachineId,
+		ContainerId:     in.ContainerId,
+		StubId:          in.StubId,
+		Status:          ContainerStatus(in.Status),
+		ScheduledAt:     in.ScheduledAt.AsTime(),
+		StartedAt:       in.StartedAt.AsTime(),
+		WorkspaceId:     in.WorkspaceId,
+		WorkerId:        in.WorkerId,
+		MachineId:       in.MachineId,
+		GpuInterconnect: in.GpuInterconnect,
+		NumaNodes:       in.NumaNodes,
 	}
 }
 
@@ -209,7 +314,14 @@ type ContainerRequest struct {
 	Gpu                      string          `json:"gpu"`
 	GpuRequest               []string        `json:"gpu_request"`
 	GpuCount                 uint32          `json:"gpu_count"`
+	GpuFraction              float64         `json:"gpu_fraction,omitempty"` // Share of one GPU, 0 for whole GPUs
+	GpuShare                 int32           `json:"gpu_share"`              // Set by the scheduler for fractional GPUs
+	GpuMemoryLimit           int64           `json:"gpu_memory_limit"`       // MiB of the shared GPU the container may use
+	MigProfile               string          `json:"mig_profile,omitempty"`  // MIG slice the container runs on, e.g. 1g.10gb
+	Affinity                 *WorkerAffinity `json:"affinity,omitempty"`
+	PriorityClass            PriorityClass   `json:"priority_class,omitempty"`
 	ImageId                  string          `json:"image_id"`
+	Architectures            []string        `json:"architectures,omitempty"` // Architectures the image is built for, amd64 if none
 	StubId                   string          `json:"stub_id"`
 	WorkspaceId              string          `json:"workspace_id"`
 	Workspace                Workspace       `json:"workspace"`
@@ -231,12 +343,48 @@ type ContainerRequest struct {
 	BlockNetwork             bool            `json:"block_network"`
 	AllowList                []string        `json:"allow_list"`
 	DockerEnabled            bool            `json:"docker_enabled"` // Enable Docker-in-Docker (gVisor only)
+	Sidecars                 []Sidecar       `json:"sidecars,omitempty"`
+	SecretMount              *SecretMount    `json:"secret_mount,omitempty"`
+	Gang                     *GangRequest    `json:"gang,omitempty"`
+}
+
+// SecretMount holds secrets that the worker writes to tmpfs-backed files instead of passing as env vars
+type SecretMount struct {
+	Path  string            `json:"path"`
+	Files map[string]string `json:"files"` // File name to value
+}
+
+func (m *SecretMount) ToProto() *pb.SecretMount {
+	if m == nil {
+		return nil
+	}
+
+	return &pb.SecretMount{
+		Path:  m.Path,
+		Files: m.Files,
+	}
+}
+
+func NewSecretMountFromProto(in *pb.SecretMount) *SecretMount {
+	if in == nil {
+		return nil
+	}
+
+	return &SecretMount{
+		Path:  in.Path,
+		Files: in.Files,
+	}
 }
 
 func (c *ContainerRequest) RequiresGPU() bool {
 	return len(c.GpuRequest) > 0 || c.Gpu != ""
 }
 
+// SharesGpu reports whether the container runs on a fraction of a GPU, sharing it with other containers
+func (c *ContainerRequest) SharesGpu() bool {
+	return c.RequiresGPU() && c.GpuFraction > 0 && c.GpuFraction < 1
+}
+
 // IsBuildRequest checks if the sourceImage or Dockerfile field is not-nil, which means the container request is for a build container
 func (c *ContainerRequest) IsBuildRequest() bool {
 	return c.BuildOptions.SourceImage != nil || c.BuildOptions.Dockerfile != nil
@@ -279,6 +427,11 @@ func (c *ContainerRequest) ToProto() *pb.ContainerRequest {
 		checkpoint = c.Checkpoint.ToProto()
 	}
 
+	sidecars := make([]*pb.Sidecar, len(c.Sidecars))
+	for i, sidecar := range c.Sidecars {
+		sidecars[i] = sidecar.ToProto()
+	}
+
 	return &pb.ContainerRequest{
 		ContainerId:              c.ContainerId,
 		EntryPoint:               c.EntryPoint,
@@ -288,6 +441,11 @@ func (c *ContainerRequest) ToProto() *pb.ContainerRequest {
 		Gpu:                      c.Gpu,
 		GpuRequest:               c.GpuRequest,
 		GpuCount:                 c.GpuCount,
+		GpuFraction:              c.GpuFraction,
+		Architectures:            c.Architectures,
+		GpuShare:                 c.GpuShare,
+		GpuMemoryLimit:           c.GpuMemoryLimit,
+		MigProfile:               c.MigProfile,
 		ImageId:                  c.ImageId,
 		Mounts:                   mounts,
 		StubId:                   c.StubId,
@@ -308,6 +466,9 @@ func (c *ContainerRequest) ToProto() *pb.ContainerRequest {
 		BlockNetwork:             c.BlockNetwork,
 		AllowList:                c.AllowList,
 		DockerEnabled:            c.DockerEnabled,
+		Sidecars:                 sidecars,
+		SecretMount:              c.SecretMount.ToProto(),
+		Gang:                     c.Gang.ToProto(),
 	}
 }
 
@@ -333,6 +494,11 @@ func NewContainerRequestFromProto(in *pb.ContainerRequest) *ContainerRequest {
 		checkpoint = NewCheckpointFromProto(in.Checkpoint)
 	}
 
+	sidecars := make([]Sidecar, len(in.Sidecars))
+	for i, sidecar := range in.Sidecars {
+		sidecars[i] = NewSidecarFromProto(sidecar)
+	}
+
 	return &ContainerRequest{
 		ContainerId:              in.ContainerId,
 		EntryPoint:               in.EntryPoint,
@@ -342,6 +508,11 @@ func NewContainerRequestFromProto(in *pb.ContainerRequest) *ContainerRequest {
 		Gpu:                      in.Gpu,
 		GpuRequest:               in.GpuRequest,
 		GpuCount:                 in.GpuCount,
+		GpuFraction:              in.GpuFraction,
+		Architectures:            in.Architectures,
+		GpuShare:                 in.GpuShare,
+		GpuMemoryLimit:           in.GpuMemoryLimit,
+		MigProfile:               in.MigProfile,
 		ImageId:                  in.ImageId,
 		Mounts:                   mounts,
 		WorkspaceId:              in.WorkspaceId,
@@ -361,6 +532,9 @@ func NewContainerRequestFromProto(in *pb.ContainerRequest) *ContainerRequest {
 		BlockNetwork:             in.BlockNetwork,
 		AllowList:                in.AllowList,
 		DockerEnabled:            in.DockerEnabled,
+		Sidecars:                 sidecars,
+		SecretMount:              NewSecretMountFromProto(in.SecretMount),
+		Gang:                     NewGangRequestFromProto(in.Gang),
 	}
 }
 
@@ -511,27 +685,58 @@ const (
 )
 
 type WorkerPoolSizingConfig struct {
-	MinFreeCpu            int64
-	MinFreeMemory         int64
-	MinFreeGpu            uint
-	DefaultWorkerCpu      int64
-	DefaultWorkerMemory   int64
-	DefaultWorkerGpuType  string
-	DefaultWorkerGpuCount uint32
+	MinFreeCpu             int64
+	MinFreeMemory          int64
+	MinFreeGpu             uint
+	DefaultWorkerCpu       int64
+	DefaultWorkerMemory    int64
+	DefaultWorkerGpuType   string
+	DefaultWorkerGpuCount  uint32
+	Predictive             bool
+	PredictionLookbackDays int
+	PredictionLeadTime     time.Duration
+	PredictionMinDays      int
 }
 
 func NewWorkerPoolSizingConfig() *WorkerPoolSizingConfig {
 	return &WorkerPoolSizingConfig{
-		MinFreeCpu:            0,
-		MinFreeMemory:         0,
-		MinFreeGpu:            0,
-		DefaultWorkerCpu:      1000,
-		DefaultWorkerMemory:   1 * 1024, // 1Gi
-		DefaultWorkerGpuType:  "",
-		DefaultWorkerGpuCount: 0,
+		MinFreeCpu:             0,
+		MinFreeMemory:          0,
+		MinFreeGpu:             0,
+		DefaultWorkerCpu:       1000,
+		DefaultWorkerMemory:    1 * 1024, // 1Gi
+		DefaultWorkerGpuType:   "",
+		DefaultWorkerGpuCount:  0,
+		Predictive:             false,
+		PredictionLookbackDays: 7,
+		PredictionLeadTime:     10 * time.Minute,
+		PredictionMinDays:      3,
 	}
 }
 
+// WorkerPoolDemand is the capacity requested by the containers started in a worker pool during an hour
+type WorkerPoolDemand struct {
+	Cpu        int64 `json:"cpu"`
+	Memory     int64 `json:"memory"`
+	Gpu        int64 `json:"gpu"`
+	Containers int64 `json:"containers"`
+}
+
+func (d *WorkerPoolDemand) Add(other *WorkerPoolDemand) {
+	d.Cpu += other.Cpu
+	d.Memory += other.Memory
+	d.Gpu += other.Gpu
+	d.Containers += other.Containers
+}
+
+type PoolQuotaExceededError struct {
+	Pools []string
+}
+
+func (e *PoolQuotaExceededError) Error() string {
+	return fmt.Sprintf("pool quota exceeded: %s", strings.Join(e.Pools, ", "))
+}
+
 type ThrottledByConcurrencyLimitError struct {
 	Reason string
 }
@@ -546,6 +751,38 @@ func (e *QuotaDoesNotExistError) Error() string {
 	return "quota_does_not_exist"
 }
 
+// Outcomes of simulating where a request would be scheduled
+const (
+	SchedulingOutcomeScheduled = "scheduled" // Runs on an existing worker
+	SchedulingOutcomePreempt   = "preempt"   // Runs on an existing worker once lower priority containers stop
+	SchedulingOutcomeScaleUp   = "scale_up"  // Runs on a new worker
+	SchedulingOutcomePending   = "pending"   // Waits in the backlog for its workspace's pool quota to free up
+	SchedulingOutcomeRejected  = "rejected"  // Can't be scheduled as it is
+)
+
+// SchedulingSimulation is where the scheduler would place a request if it were run now, or why it wouldn't
+type SchedulingSimulation struct {
+	Outcome      string
+	Reason       string
+	Worker       *Worker  // The worker the request runs on, when it runs on an existing one
+	Preempted    []string // Containers stopped to make room for the request
+	ScaleUpPools []string // Pools asked for a new worker, in the order they're tried
+	Constraints  []SchedulingConstraint
+}
+
+// SchedulingConstraint is one of the checks workers must pass to run a request, with the workers that failed it
+type SchedulingConstraint struct {
+	Name     string
+	Rejected []SchedulingRejection
+}
+
+type SchedulingRejection struct {
+	WorkerId string
+	PoolName string
+	Gpu      string
+	Reason   string
+}
+
 type CheckpointStatus string
 
 const (
@@ -574,6 +811,11 @@ const (
 	StopContainerReasonScheduler StopContainerReason = "SCHEDULER"
 	// StopContainerReasonAdmin is used when a container is stopped by an admin request (i.e. draining a worker)
 	StopContainerReasonAdmin StopContainerReason = "ADMIN"
+	// StopContainerReasonUnhealthy is used when a container is restarted after failing its health checks
+	StopContainerReasonUnhealthy StopContainerReason = "UNHEALTHY"
+	// StopContainerReasonPreempted is used when a container is stopped because its spot worker is being reclaimed,
+	// its worker failed its health checks, or a container of a higher priority class needs its capacity
+	StopContainerReasonPreempted StopContainerReason = "PREEMPTED"
 
 	StopContainerReasonUnknown StopContainerReason = "UNKNOWN"
 )
@@ -582,6 +824,8 @@ type StopContainerArgs struct {
 	ContainerId string              `json:"container_id"`
 	Force       bool                `json:"force"`
 	Reason      StopContainerReason `json:"reason"`
+	// GracePeriodS is how long the container has to exit after SIGTERM before it's killed, 0 to never kill it
+	GracePeriodS int64 `json:"grace_period_s,omitempty"`
 }
 
 func (a StopContainerArgs) ToMap() (map[string]any, error) {
@@ -5,3 +5,78 @@ import "fmt"
 func WorkspaceBucketName(prefix, workspaceExternalId string) string {
 	return fmt.Sprintf("%s-%s", prefix, workspaceExternalId)
 }
+
+type ObjectCompression string
+
+const (
+	ObjectCompressionNone ObjectCompression = ""
+	ObjectCompressionGzip ObjectCompression = "gzip"
+	ObjectCompressionZstd ObjectCompression = "zstd"
+)
+
+type ObjectEncryption string
+
+const (
+	ObjectEncryptionNone      ObjectEncryption = ""
+	ObjectEncryptionAES256GCM ObjectEncryption = "aes256-gcm"
+)
+
+// ObjectLocation is where an object's content is kept
+type ObjectLocation string
+
+const (
+	ObjectLocationLocal            ObjectLocation = "local"
+	ObjectLocationWorkspaceStorage ObjectLocation = "workspace_storage"
+)
+
+// ObjectReplicaStatus is the state of an object's copy in a workspace's replica storage
+type ObjectReplicaStatus string
+
+const (
+	ObjectReplicaStatusReplicated ObjectReplicaStatus = "replicated"
+	ObjectReplicaStatusFailed     ObjectReplicaStatus = "failed"
+)
+
+// WebhookEvent is an event a workspace's webhooks can subscribe to, either an object event or a deployment event
+type WebhookEvent string
+
+const (
+	ObjectWebhookEventCreated     WebhookEvent = "object.created"
+	ObjectWebhookEventOverwritten WebhookEvent = "object.overwritten"
+	ObjectWebhookEventDeleted     WebhookEvent = "object.deleted"
+	ObjectWebhookEventExpired     WebhookEvent = "object.expired"
+)
+
+var ObjectWebhookEvents = []WebhookEvent{
+	ObjectWebhookEventCreated,
+	ObjectWebhookEventOverwritten,
+	ObjectWebhookEventDeleted,
+	ObjectWebhookEventExpired,
+}
+
+const (
+	DeploymentWebhookEventCreated      WebhookEvent = "deployment.created"
+	DeploymentWebhookEventScaled       WebhookEvent = "deployment.scaled"
+	DeploymentWebhookEventPromoted     WebhookEvent = "deployment.promoted"
+	DeploymentWebhookEventStopped      WebhookEvent = "deployment.stopped"
+	DeploymentWebhookEventCrashLooping WebhookEvent = "deployment.crash_looping"
+)
+
+// DeploymentWebhookEvents are only delivered to webhooks that subscribe to them explicitly, so webhooks created
+// for every object event don't start receiving deployment events
+var DeploymentWebhookEvents = []WebhookEvent{
+	DeploymentWebhookEventCreated,
+	DeploymentWebhookEventScaled,
+	DeploymentWebhookEventPromoted,
+	DeploymentWebhookEventStopped,
+	DeploymentWebhookEventCrashLooping,
+}
+
+// ObjectWebhookDeliveryStatus is the state of a queued webhook notification
+type ObjectWebhookDeliveryStatus string
+
+const (
+	ObjectWebhookDeliveryStatusPending   ObjectWebhookDeliveryStatus = "pending"
+	ObjectWebhookDeliveryStatusDelivered ObjectWebhookDeliveryStatus = "delivered"
+	ObjectWebhookDeliveryStatusFailed    ObjectWebhookDeliveryStatus = "failed"
+)
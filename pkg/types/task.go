@@ -2,9 +2,11 @@ package types
 
 import (
 	"context"
+	"database/sql/driver"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/gofrs/uuid"
@@ -29,6 +31,7 @@ const (
 	TaskExceededRetryLimit    TaskCancellationReason = "exceeded_retry_limit"
 	TaskRequestCancelled      TaskCancellationReason = "request_cancelled"
 	TaskInvalidRequestPayload TaskCancellationReason = "invalid_request_payload"
+	TaskRequestTimedOut       TaskCancellationReason = "request_timed_out"
 )
 
 type TaskInterface interface {
@@ -120,6 +123,134 @@ type TaskPolicy struct {
 	Timeout    int       `json:"timeout" redis:"timeout"`
 	Expires    time.Time `json:"expires" redis:"expires"`
 	TTL        uint32    `json:"ttl" redis:"ttl"`
+	// Priority is empty for tasks queued before priorities existed, which run as normal priority
+	Priority TaskPriority `json:"priority,omitempty" redis:"priority"`
+}
+
+type TaskPriority string
+
+const (
+	TaskPriorityHigh   TaskPriority = "high"
+	TaskPriorityNormal TaskPriority = "normal"
+	TaskPriorityLow    TaskPriority = "low"
+)
+
+// TaskPriorities lists every priority in the order tasks are dispatched
+var TaskPriorities = []TaskPriority{TaskPriorityHigh, TaskPriorityNormal, TaskPriorityLow}
+
+// ParseTaskPriority validates a priority given on task submission. An empty value leaves the priority unset,
+// so the stub's default applies.
+func ParseTaskPriority(priority string) (TaskPriority, error) {
+	p := TaskPriority(priority)
+	if p != "" && !slices.Contains(TaskPriorities, p) {
+		return "", fmt.Errorf("invalid task priority: %s", priority)
+	}
+
+	return p, nil
+}
+
+// OrDefault returns the priority, treating an unset priority as normal
+func (p TaskPriority) OrDefault() TaskPriority {
+	if p == "" {
+		return TaskPriorityNormal
+	}
+
+	return p
+}
+
+type TaskBackoffStrategy string
+
+const (
+	TaskBackoffNone        TaskBackoffStrategy = "none"
+	TaskBackoffFixed       TaskBackoffStrategy = "fixed"
+	TaskBackoffExponential TaskBackoffStrategy = "exponential"
+)
+
+var MaxTaskRetryBackoffSeconds uint = 60 * 60
+
+// TaskRetryPolicy overrides how a stub's failed tasks are retried. It's read by the dispatcher when a task
+// fails, so changes also apply to tasks already in flight.
+type TaskRetryPolicy struct {
+	StubId uint `db:"stub_id" json:"stub_id"`
+	// MaxAttempts counts the first attempt, so 1 disables retries
+	MaxAttempts       uint                `db:"max_attempts" json:"max_attempts"`
+	BackoffStrategy   TaskBackoffStrategy `db:"backoff_strategy" json:"backoff_strategy"`
+	BackoffSeconds    uint                `db:"backoff_seconds" json:"backoff_seconds"`
+	MaxBackoffSeconds uint                `db:"max_backoff_seconds" json:"max_backoff_seconds"`
+	RetryOnExitCodes  TaskExitCodes       `db:"retry_on_exit_codes" json:"retry_on_exit_codes"`
+	CreatedAt         Time                `db:"created_at" json:"created_at"`
+	UpdatedAt         Time                `db:"updated_at" json:"updated_at"`
+}
+
+// Backoff returns how long to wait before the given retry, counting from 1
+func (p *TaskRetryPolicy) Backoff(retry uint) time.Duration {
+	maxBackoff := MaxTaskRetryBackoffSeconds
+	if p.MaxBackoffSeconds > 0 && p.MaxBackoffSeconds < maxBackoff {
+		maxBackoff = p.MaxBackoffSeconds
+	}
+
+	backoff := p.BackoffSeconds
+	switch p.BackoffStrategy {
+	case TaskBackoffFixed:
+	case TaskBackoffExponential:
+		for i := uint(1); i < retry && backoff < maxBackoff; i++ {
+			backoff *= 2
+		}
+	default:
+		return 0
+	}
+
+	return time.Duration(min(backoff, maxBackoff)) * time.Second
+}
+
+// RetriesExitCode reports whether a failure with the given exit code may be retried. Without a list of
+// exit codes every failure is retried; with one, failures whose exit code isn't known are not.
+func (p *TaskRetryPolicy) RetriesExitCode(exitCode *int) bool {
+	if len(p.RetryOnExitCodes) == 0 {
+		return true
+	}
+
+	return exitCode != nil && slices.Contains(p.RetryOnExitCodes, *exitCode)
+}
+
+// TaskExitCodes is a list of container exit codes, stored as a JSONB array
+type TaskExitCodes []int
+
+func (c *TaskExitCodes) Scan(value interface{}) error {
+	if value == nil {
+		*c = nil
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(bytes, c)
+}
+
+func (c TaskExitCodes) Value() (driver.Value, error) {
+	if c == nil {
+		return []byte("[]"), nil
+	}
+
+	return json.Marshal(c)
+}
+
+type TaskFailureReason string
+
+const (
+	TaskFailureHeartbeatLost TaskFailureReason = "heartbeat_lost"
+	TaskFailureRequested     TaskFailureReason = "retry_requested"
+	TaskFailurePreempted     TaskFailureReason = "preempted"
+)
+
+// TaskFailure describes why a task attempt failed. The exit code is nil when it isn't known.
+type TaskFailure struct {
+	Reason      TaskFailureReason
+	ContainerId string
+	ExitCode    *int
 }
 
 type ErrExceededTaskLimit struct {
@@ -0,0 +1,90 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+// TestTaskRetryPolicyBackoff checks the delay before each retry for every backoff strategy
+func TestTaskRetryPolicyBackoff(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy TaskRetryPolicy
+		retry  uint
+		want   time.Duration
+	}{
+		{"none", TaskRetryPolicy{BackoffStrategy: TaskBackoffNone, BackoffSeconds: 10}, 3, 0},
+		{"fixed", TaskRetryPolicy{BackoffStrategy: TaskBackoffFixed, BackoffSeconds: 10}, 3, 10 * time.Second},
+		{"exponential first retry", TaskRetryPolicy{BackoffStrategy: TaskBackoffExponential, BackoffSeconds: 10}, 1, 10 * time.Second},
+		{"exponential third retry", TaskRetryPolicy{BackoffStrategy: TaskBackoffExponential, BackoffSeconds: 10}, 3, 40 * time.Second},
+		{"exponential capped", TaskRetryPolicy{BackoffStrategy: TaskBackoffExponential, BackoffSeconds: 10, MaxBackoffSeconds: 25}, 3, 25 * time.Second},
+		{"exponential default cap", TaskRetryPolicy{BackoffStrategy: TaskBackoffExponential, BackoffSeconds: 600}, 10, time.Duration(MaxTaskRetryBackoffSeconds) * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Backoff(tt.retry); got != tt.want {
+				t.Errorf("TaskRetryPolicy.Backoff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestTaskRetryPolicyRetriesExitCode checks which failures a policy retries
+func TestTaskRetryPolicyRetriesExitCode(t *testing.T) {
+	exitCode := func(code int) *int { return &code }
+
+	tests := []struct {
+		name     string
+		codes    TaskExitCodes
+		exitCode *int
+		want     bool
+	}{
+		{"any failure", nil, exitCode(1), true},
+		{"any failure without exit code", nil, nil, true},
+		{"listed exit code", TaskExitCodes{137, 143}, exitCode(137), true},
+		{"unlisted exit code", TaskExitCodes{137, 143}, exitCode(1), false},
+		{"unknown exit code", TaskExitCodes{137, 143}, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := TaskRetryPolicy{RetryOnExitCodes: tt.codes}
+			if got := policy.RetriesExitCode(tt.exitCode); got != tt.want {
+				t.Errorf("TaskRetryPolicy.RetriesExitCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestParseTaskPriority checks the priorities accepted on task submission
+func TestParseTaskPriority(t *testing.T) {
+	tests := []struct {
+		priority string
+		want     TaskPriority
+		wantErr  bool
+	}{
+		{"", "", false},
+		{"high", TaskPriorityHigh, false},
+		{"normal", TaskPriorityNormal, false},
+		{"low", TaskPriorityLow, false},
+		{"urgent", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.priority, func(t *testing.T) {
+			got, err := ParseTaskPriority(tt.priority)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTaskPriority() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("ParseTaskPriority() = %v, want %v", got, tt.want)
+			}
+
+			if got.OrDefault() == "" {
+				t.Errorf("TaskPriority.OrDefault() returned an empty priority")
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ const (
 	WorkerContainerUploadsMountPath          string        = "/tmp/.beta9"
 	WorkerDurationEmissionInterval           time.Duration = 30 * time.Second
 	WorkerKeepAliveInterval                  time.Duration = 15 * time.Second
+	WorkerObjectCacheRegistryTtl             time.Duration = 24 * time.Hour
 	WorkerShellPort                          int32         = 2222
 	WorkerSandboxProcessManagerPort          int32         = 7111
 	WorkerSandboxProcessManagerWorkerPath    string        = "/usr/local/bin/goproc"
@@ -50,6 +51,8 @@ type Mount struct {
 	ReadOnly         bool              `json:"read_only"`
 	MountType        string            `json:"mount_type"`
 	MountPointConfig *MountPointConfig `json:"mountpoint_config"`
+	// SharedVolumeId is set when the mount is a volume shared read-only from another workspace
+	SharedVolumeId string `json:"shared_volume_id,omitempty"`
 }
 
 func (m *Mount) ToProto() *pb.Mount {
@@ -65,6 +68,7 @@ func (m *Mount) ToProto() *pb.Mount {
 		ReadOnly:         m.ReadOnly,
 		MountType:        m.MountType,
 		MountPointConfig: mountPointConfig,
+		SharedVolumeId:   m.SharedVolumeId,
 	}
 }
 
@@ -81,6 +85,7 @@ func NewMountFromProto(in *pb.Mount) *Mount {
 		ReadOnly:         in.ReadOnly,
 		MountType:        in.MountType,
 		MountPointConfig: mountPointConfig,
+		SharedVolumeId:   in.SharedVolumeId,
 	}
 }
 
@@ -103,6 +108,7 @@ func (c ContainerExitCode) IsFailed() bool {
 			ContainerExitCodeTtl,
 			ContainerExitCodeUser,
 			ContainerExitCodeAdmin,
+			ContainerExitCodePreempted,
 		},
 		c,
 	)
@@ -120,6 +126,8 @@ const (
 	ContainerExitCodeTtl                ContainerExitCode = 559
 	ContainerExitCodeUser               ContainerExitCode = 560
 	ContainerExitCodeAdmin              ContainerExitCode = 561
+	ContainerExitCodeUnhealthy          ContainerExitCode = 562
+	ContainerExitCodePreempted          ContainerExitCode = 563
 )
 
 const (
@@ -128,6 +136,8 @@ const (
 	WorkerContainerExitCodeTtlMessage       = "Container stopped due to TTL expiration"
 	WorkerContainerExitCodeUserMessage      = "Container stopped by user"
 	WorkerContainerExitCodeAdminMessage     = "Container stopped by admin"
+	WorkerContainerExitCodeUnhealthyMessage = "Container restarted after failing health checks"
+	WorkerContainerExitCodePreemptedMessage = "Container stopped because it was preempted"
 )
 
 var ExitCodeMessages = map[ContainerExitCode]string{
@@ -136,6 +146,8 @@ var ExitCodeMessages = map[ContainerExitCode]string{
 	ContainerExitCodeTtl:       WorkerContainerExitCodeTtlMessage,
 	ContainerExitCodeUser:      WorkerContainerExitCodeUserMessage,
 	ContainerExitCodeAdmin:     WorkerContainerExitCodeAdminMessage,
+	ContainerExitCodeUnhealthy: WorkerContainerExitCodeUnhealthyMessage,
+	ContainerExitCodePreempted: WorkerContainerExitCodePreemptedMessage,
 }
 
 var WorkerContainerExitCodes = map[ContainerExitCode]string{
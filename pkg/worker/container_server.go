@@ -13,6 +13,7 @@ import (
 	"path"
 	"path/filepath"
 	"regexp"
+	goruntime "runtime"
 	"slices"
 	"strconv"
 	"strings"
@@ -22,6 +23,7 @@ import (
 
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	common "github.com/beam-cloud/beta9/pkg/common"
 	"github.com/beam-cloud/beta9/pkg/runtime"
@@ -35,6 +37,9 @@ import (
 const (
 	gRPCMaxRecvMsgSize = 1024 * 1024 * 16
 	gRPCMaxSendMsgSize = 1024 * 1024 * 16
+
+	portForwardDialTimeout = 10 * time.Second
+	portForwardBufferSize  = 32 * 1024
 )
 
 // ContainerRuntimeServer is a runtime-agnostic container server that works with any OCI runtime
@@ -49,6 +54,8 @@ type ContainerRuntimeServer struct {
 	port                    int
 	podAddr                 string
 	createCheckpoint        func(ctx context.Context, opts *CreateCheckpointOpts) error
+	checkHealth             func(ctx context.Context) []*pb.WorkerHealthCheck
+	internalCert            *common.RotatingCertificate
 	grpcServer              *grpc.Server
 	mu                      sync.Mutex
 }
@@ -61,6 +68,9 @@ type ContainerRuntimeServerOpts struct {
 	ContainerRepoClient     pb.ContainerRepositoryServiceClient
 	ContainerNetworkManager *ContainerNetworkManager
 	CreateCheckpoint        func(ctx context.Context, opts *CreateCheckpointOpts) error
+	CheckHealth             func(ctx context.Context) []*pb.WorkerHealthCheck
+	// Set when internal TLS is enabled, so only the gateway can connect
+	InternalCert *common.RotatingCertificate
 }
 
 // NewContainerRuntimeServer creates a new runtime-agnostic container server
@@ -84,6 +94,8 @@ func NewContainerRuntimeServer(opts *ContainerRuntimeServerOpts) (*ContainerRunt
 		containerRepoClient:     opts.ContainerRepoClient,
 		containerNetworkManager: opts.ContainerNetworkManager,
 		createCheckpoint:        opts.CreateCheckpoint,
+		checkHealth:             opts.CheckHealth,
+		internalCert:            opts.InternalCert,
 	}, nil
 }
 
@@ -104,10 +116,15 @@ func (s *ContainerRuntimeServer) Start() error {
 	s.port = listener.Addr().(*net.TCPAddr).Port
 	log.Info().Int("port", s.port).Msg("container runtime server started")
 
-	s.grpcServer = grpc.NewServer(
+	serverOptions := []grpc.ServerOption{
 		grpc.MaxRecvMsgSize(gRPCMaxRecvMsgSize),
 		grpc.MaxSendMsgSize(gRPCMaxSendMsgSize),
-	)
+	}
+	if s.internalCert != nil {
+		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(s.internalCert.ServerTLSConfig())))
+	}
+
+	s.grpcServer = grpc.NewServer(serverOptions...)
 
 	pb.RegisterContainerServiceServer(s.grpcServer, s)
 
@@ -190,6 +207,176 @@ func (s *ContainerRuntimeServer) ContainerExec(ctx context.Context, in *pb.Conta
 	}, nil
 }
 
+// execStreamWriter sends one of an exec's output streams back over its gRPC stream
+type execStreamWriter struct {
+	mu     *sync.Mutex
+	stream pb.ContainerService_ContainerExecStreamServer
+	stderr bool
+}
+
+func (w *execStreamWriter) Write(p []byte) (int, error) {
+	resp := &pb.ExecInContainerResponse{Stdout: p}
+	if w.stderr {
+		resp = &pb.ExecInContainerResponse{Stderr: p}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.stream.Send(resp); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// ContainerExecStream runs a command inside a running container, streaming its stdin, stdout and stderr
+// and finishing with its exit code
+func (s *ContainerRuntimeServer) ContainerExecStream(stream pb.ContainerService_ContainerExecStreamServer) error {
+	ctx := stream.Context()
+
+	initMsg, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	start := initMsg.GetStart()
+	if start == nil || len(start.Command) == 0 {
+		return stream.Send(&pb.ExecInContainerResponse{Done: true, ExitCode: -1, ErrorMsg: "Missing command"})
+	}
+
+	instance, exists := s.containerInstances.Get(start.ContainerId)
+	if !exists {
+		return stream.Send(&pb.ExecInContainerResponse{Done: true, ExitCode: -1, ErrorMsg: "Container not found"})
+	}
+
+	process := *s.baseConfigSpec.Process
+	process.Args = start.Command
+	process.Terminal = false
+	process.Cwd = instance.Spec.Process.Cwd
+	if start.Cwd != "" {
+		process.Cwd = start.Cwd
+	}
+	process.Env = append(slices.Clone(instance.Spec.Process.Env), start.Env...)
+
+	log.Info().Str("container_id", start.ContainerId).Strs("command", start.Command).Msg("running exec stream")
+
+	stdinReader, stdinWriter := io.Pipe()
+	defer stdinReader.Close()
+
+	go func() {
+		for {
+			msg, err := stream.Recv()
+			if err != nil {
+				stdinWriter.Close()
+				return
+			}
+
+			switch payload := msg.Payload.(type) {
+			case *pb.ExecInContainerRequest_Stdin:
+				if _, err := stdinWriter.Write(payload.Stdin); err != nil {
+					return
+				}
+			case *pb.ExecInContainerRequest_CloseStdin:
+				stdinWriter.Close()
+			}
+		}
+	}()
+
+	mu := &sync.Mutex{}
+	err = s.getRuntime().Exec(ctx, start.ContainerId, process, &runtime.ExecOpts{
+		Stdin:  stdinReader,
+		Stdout: &execStreamWriter{mu: mu, stream: stream},
+		Stderr: &execStreamWriter{mu: mu, stream: stream, stderr: true},
+	})
+
+	resp := &pb.ExecInContainerResponse{Done: true}
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil, errors.Is(err, exec.ErrWaitDelay):
+	case errors.As(err, &exitErr):
+		resp.ExitCode = int32(exitErr.ExitCode())
+	default:
+		resp.ExitCode = -1
+		resp.ErrorMsg = err.Error()
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	return stream.Send(resp)
+}
+
+// ContainerPortForward relays a single TCP connection between the stream and a port inside a container
+func (s *ContainerRuntimeServer) ContainerPortForward(stream pb.ContainerService_ContainerPortForwardServer) error {
+	initMsg, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	start := initMsg.GetStart()
+	if start == nil || start.Port == 0 || start.Port > 65535 {
+		return stream.Send(&pb.PortForwardResponse{Done: true, ErrorMsg: "Invalid port"})
+	}
+
+	instance, exists := s.containerInstances.Get(start.ContainerId)
+	if !exists || instance.ContainerIp == "" {
+		return stream.Send(&pb.PortForwardResponse{Done: true, ErrorMsg: "Container not found"})
+	}
+
+	addr := net.JoinHostPort(instance.ContainerIp, strconv.Itoa(int(start.Port)))
+	conn, err := net.DialTimeout("tcp", addr, portForwardDialTimeout)
+	if err != nil {
+		return stream.Send(&pb.PortForwardResponse{Done: true, ErrorMsg: fmt.Sprintf("Unable to connect to port %d", start.Port)})
+	}
+	defer conn.Close()
+
+	if err := stream.Send(&pb.PortForwardResponse{Connected: true}); err != nil {
+		return err
+	}
+
+	go func() {
+		// Half-close so the container still sends the rest of its response
+		defer func() {
+			if tcpConn, ok := conn.(*net.TCPConn); ok {
+				tcpConn.CloseWrite()
+			}
+		}()
+
+		for {
+			msg, err := stream.Recv()
+			if err != nil {
+				return
+			}
+
+			switch payload := msg.Payload.(type) {
+			case *pb.PortForwardRequest_Data:
+				if _, err := conn.Write(payload.Data); err != nil {
+					return
+				}
+			case *pb.PortForwardRequest_Close:
+				return
+			}
+		}
+	}()
+
+	buffer := make([]byte, portForwardBufferSize)
+	for {
+		n, err := conn.Read(buffer)
+		if n > 0 {
+			if err := stream.Send(&pb.PortForwardResponse{Data: buffer[:n]}); err != nil {
+				return err
+			}
+		}
+
+		if err != nil {
+			break
+		}
+	}
+
+	return stream.Send(&pb.PortForwardResponse{Done: true})
+}
+
 // ContainerStatus returns the status of a container
 func (s *ContainerRuntimeServer) ContainerStatus(ctx context.Context, in *pb.ContainerStatusRequest) (*pb.ContainerStatusResponse, error) {
 	rt := s.getRuntime()
@@ -206,6 +393,10 @@ func (s *ContainerRuntimeServer) ContainerStatus(ctx context.Context, in *pb.Con
 
 // ContainerStreamLogs streams container logs
 func (s *ContainerRuntimeServer) ContainerStreamLogs(req *pb.ContainerStreamLogsRequest, stream pb.ContainerService_ContainerStreamLogsServer) error {
+	if req.Filter != nil || req.TailLines > 0 {
+		return s.streamLogFile(req, stream)
+	}
+
 	instance, exists := s.containerInstances.Get(req.ContainerId)
 	if !exists {
 		return errors.New("container not found")
@@ -381,7 +572,7 @@ func (s *ContainerRuntimeServer) ContainerArchive(req *pb.ContainerArchiveReques
 
 	topLayerPath := NewPathInfo(instance.Overlay.TopLayerPath())
 	err = stream.Send(&pb.ContainerArchiveResponse{
-		Done: true, Success: s.imageClient.Archive(ctx, topLayerPath, req.ImageId, progressChan) == nil,
+		Done: true, Success: s.imageClient.Archive(ctx, topLayerPath, types.ImageIdForArch(req.ImageId, goruntime.GOARCH), progressChan) == nil,
 	})
 
 	close(doneChan)
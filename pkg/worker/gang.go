@@ -0,0 +1,63 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	types "github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+)
+
+const gangRendezvousPollInterval = time.Second
+
+// gangEnvironment returns the env vars the container of a gang rendezvous with the rest of it by. Rank 0 listens
+// on the rendezvous port, and the other ranks wait for its address, which is only known once rank 0's worker
+// has bound the port.
+func (s *Worker) gangEnvironment(ctx context.Context, request *types.ContainerRequest, bindPorts []int) ([]string, error) {
+	gang := request.Gang
+
+	if gang.Rank == 0 {
+		for idx, port := range request.Ports {
+			if port == uint32(types.GangRendezvousPort) {
+				return gang.Environment(s.podAddr, bindPorts[idx]), nil
+			}
+		}
+		return nil, fmt.Errorf("rendezvous port %d is not exposed", types.GangRendezvousPort)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, gang.Timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(gangRendezvousPollInterval)
+	defer ticker.Stop()
+
+	for {
+		response, err := handleGRPCResponse(s.containerRepoClient.GetContainerAddressMap(ctx, &pb.GetContainerAddressMapRequest{
+			ContainerId: gang.ContainerIds[0],
+		}))
+		if err == nil {
+			if address, ok := response.AddressMap[int32(types.GangRendezvousPort)]; ok {
+				host, port, err := net.SplitHostPort(address)
+				if err != nil {
+					return nil, err
+				}
+
+				masterPort, err := strconv.Atoi(port)
+				if err != nil {
+					return nil, err
+				}
+
+				return gang.Environment(host, masterPort), nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("rank 0 of gang %s did not start within %s", gang.Id, gang.Timeout)
+		case <-ticker.C:
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"regexp"
 	"strconv"
 	"strings"
 )
@@ -13,6 +14,24 @@ import (
 type GPUInfoClient interface {
 	AvailableGPUDevices() ([]int, error)
 	GetGPUMemoryUsage(deviceIndex int) (GPUMemoryUsageStats, error)
+	GetProcessGPUMemoryUsage() (map[int32]int64, error)
+	GPUTopology() (*GPUTopology, error)
+	UnhealthyGPUs() (map[int]string, error)
+	MigDevices() ([]MigDevice, error)
+}
+
+// MigDevice is a MIG slice of a GPU, which containers that request its profile run on as if it were a GPU of
+// its own
+type MigDevice struct {
+	GpuIndex int
+	Index    int    // Index of the slice on its GPU
+	Profile  string // e.g. 1g.10gb
+	Uuid     string
+}
+
+// cdiName is the name nvidia-ctk gives the slice's CDI device
+func (d MigDevice) cdiName() string {
+	return fmt.Sprintf("%s=%d:%d", nvidiaDeviceKindPrefix, d.GpuIndex, d.Index)
 }
 
 type GPUMemoryUsageStats struct {
@@ -42,6 +61,16 @@ var queryDevices = func() ([]byte, error) {
 	return cmd.Output()
 }
 
+var queryGPUHealth = func() ([]byte, error) {
+	cmd := exec.Command("nvidia-smi", "--query-gpu=index,ecc.errors.uncorrected.volatile.total,retired_pages.pending", "--format=csv,noheader,nounits")
+	return cmd.Output()
+}
+
+var queryMigDevices = func() ([]byte, error) {
+	cmd := exec.Command("nvidia-smi", "-L")
+	return cmd.Output()
+}
+
 var checkGPUExists = func(busId string) (bool, error) {
 	_, err := os.Stat(fmt.Sprintf("/proc/driver/nvidia/gpus/%s", busId))
 	if err == nil {
@@ -144,3 +173,160 @@ func (c *NvidiaInfoClient) GetGPUMemoryUsage(deviceIndex int) (GPUMemoryUsageSta
 
 	return stats, nil
 }
+
+// GetProcessGPUMemoryUsage returns the GPU memory in MiB used by each process with a CUDA context, by pid
+func (c *NvidiaInfoClient) GetProcessGPUMemoryUsage() (map[int32]int64, error) {
+	out, err := exec.Command("nvidia-smi", "--query-compute-apps=pid,used_memory", "--format=csv,noheader,nounits").Output()
+	if err != nil {
+		return nil, fmt.Errorf("unable to invoke nvidia-smi: %v", err)
+	}
+
+	return parseProcessGPUMemoryUsage(out)
+}
+
+// GPUTopology returns how the GPUs are connected to each other, and the NUMA node of each
+func (c *NvidiaInfoClient) GPUTopology() (*GPUTopology, error) {
+	out, err := queryTopology()
+	if err != nil {
+		return nil, fmt.Errorf("unable to invoke nvidia-smi: %v", err)
+	}
+
+	return parseGPUTopology(out)
+}
+
+// UnhealthyGPUs returns why each GPU that shouldn't run containers anymore is unhealthy, by device index. GPUs
+// that have fallen off the bus usually make nvidia-smi fail entirely, which is returned as an error.
+func (c *NvidiaInfoClient) UnhealthyGPUs() (map[int]string, error) {
+	out, err := queryGPUHealth()
+	if err != nil {
+		return nil, fmt.Errorf("unable to invoke nvidia-smi: %v", err)
+	}
+
+	return parseGPUHealth(out)
+}
+
+// MigDevices returns the MIG slices of the visible GPUs, none if the GPUs aren't partitioned
+func (c *NvidiaInfoClient) MigDevices() ([]MigDevice, error) {
+	out, err := queryMigDevices()
+	if err != nil {
+		return nil, fmt.Errorf("unable to invoke nvidia-smi: %v", err)
+	}
+
+	return parseMigDevices(out, os.Getenv("NVIDIA_VISIBLE_DEVICES"))
+}
+
+var (
+	gpuListPattern = regexp.MustCompile(`^GPU (\d+): .*\(UUID: (\S+)\)$`)
+	migListPattern = regexp.MustCompile(`^MIG (\S+)\s+Device\s+(\d+): \(UUID: (\S+)\)$`)
+)
+
+// parseMigDevices reads the GPUs nvidia-smi -L lists, each followed by its MIG slices, keeping the slices of
+// the GPUs in visibleDevices
+func parseMigDevices(out []byte, visibleDevices string) ([]MigDevice, error) {
+	devices := []MigDevice{}
+
+	gpuIndex, gpuVisible := -1, false
+	scanner := bufio.NewScanner(strings.NewReader(string(out)))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if match := gpuListPattern.FindStringSubmatch(line); match != nil {
+			index, err := strconv.Atoi(match[1])
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse gpu index: %v", err)
+			}
+
+			gpuIndex = index
+			gpuVisible = visibleDevices == "all" || strings.Contains(visibleDevices, match[2])
+			continue
+		}
+
+		match := migListPattern.FindStringSubmatch(line)
+		if match == nil || gpuIndex < 0 {
+			continue
+		}
+
+		if !gpuVisible && !strings.Contains(visibleDevices, match[3]) {
+			continue
+		}
+
+		index, err := strconv.Atoi(match[2])
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse mig device index: %v", err)
+		}
+
+		devices = append(devices, MigDevice{GpuIndex: gpuIndex, Index: index, Profile: match[1], Uuid: match[3]})
+	}
+
+	return devices, nil
+}
+
+// parseGPUHealth reads the index, uncorrected ECC error count and pending page retirements of each GPU. Fields a
+// GPU doesn't support are [N/A], and fields of a GPU that needs to be reset are [GPU requires reset].
+func parseGPUHealth(out []byte) (map[int]string, error) {
+	unhealthy := make(map[int]string)
+
+	scanner := bufio.NewScanner(strings.NewReader(string(out)))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Split(line, ",")
+		if len(fields) != 3 {
+			return nil, errors.New("unable to parse gpu health info")
+		}
+
+		index, err := strconv.Atoi(strings.TrimSpace(fields[0]))
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse gpu index: %v", err)
+		}
+
+		eccErrors := strings.TrimSpace(fields[1])
+		pendingRetirement := strings.TrimSpace(fields[2])
+
+		switch {
+		case strings.Contains(line, "requires reset"):
+			unhealthy[index] = "requires reset"
+		case eccErrors != "0" && eccErrors != "[N/A]":
+			unhealthy[index] = fmt.Sprintf("%s uncorrectable ECC errors", eccErrors)
+		case pendingRetirement == "Yes":
+			unhealthy[index] = "memory pages pending retirement"
+		}
+	}
+
+	return unhealthy, nil
+}
+
+func parseProcessGPUMemoryUsage(out []byte) (map[int32]int64, error) {
+	usage := make(map[int32]int64)
+
+	scanner := bufio.NewScanner(strings.NewReader(string(out)))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Split(line, ",")
+		if len(fields) != 2 {
+			return nil, errors.New("unable to parse gpu process memory info")
+		}
+
+		pid, err := strconv.ParseInt(strings.TrimSpace(fields[0]), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse gpu process pid: %v", err)
+		}
+
+		// Processes in the middle of creating a context report their memory as [N/A]
+		used, err := strconv.ParseInt(strings.TrimSpace(fields[1]), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		usage[int32(pid)] += used
+	}
+
+	return usage, nil
+}
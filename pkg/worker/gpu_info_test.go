@@ -94,3 +94,52 @@ func TestAvailableGPUDevicesWithNonZeroPCIDomains(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, []int{0}, devices)
 }
+
+func TestParseProcessGPUMemoryUsage(t *testing.T) {
+	usage, err := parseProcessGPUMemoryUsage([]byte("1234, 512\n1235, [N/A]\n1234, 256\n\n99, 1024\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, map[int32]int64{1234: 768, 99: 1024}, usage)
+
+	_, err = parseProcessGPUMemoryUsage([]byte("1234\n"))
+	assert.Error(t, err)
+}
+
+func TestParseGPUHealth(t *testing.T) {
+	unhealthy, err := parseGPUHealth([]byte("0, 0, No\n1, [N/A], [N/A]\n2, 3, No\n3, 0, Yes\n4, [GPU requires reset], [GPU requires reset]\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]string{
+		2: "3 uncorrectable ECC errors",
+		3: "memory pages pending retirement",
+		4: "requires reset",
+	}, unhealthy)
+
+	_, err = parseGPUHealth([]byte("0, 0\n"))
+	assert.Error(t, err)
+}
+
+func TestParseMigDevices(t *testing.T) {
+	out := []byte(`GPU 0: NVIDIA A100-SXM4-80GB (UUID: GPU-aaaa)
+  MIG 3g.40gb     Device  0: (UUID: MIG-a0)
+  MIG 1g.10gb     Device  1: (UUID: MIG-a1)
+GPU 1: NVIDIA A100-SXM4-80GB (UUID: GPU-bbbb)
+GPU 2: NVIDIA A100-SXM4-80GB (UUID: GPU-cccc)
+  MIG 7g.80gb     Device  0: (UUID: MIG-c0)
+`)
+
+	devices, err := parseMigDevices(out, "all")
+	assert.NoError(t, err)
+	assert.Equal(t, []MigDevice{
+		{GpuIndex: 0, Index: 0, Profile: "3g.40gb", Uuid: "MIG-a0"},
+		{GpuIndex: 0, Index: 1, Profile: "1g.10gb", Uuid: "MIG-a1"},
+		{GpuIndex: 2, Index: 0, Profile: "7g.80gb", Uuid: "MIG-c0"},
+	}, devices)
+	assert.Equal(t, "nvidia.com/gpu=0:1", devices[1].cdiName())
+
+	// Slices are visible when their GPU is, or when they're listed themselves
+	devices, err = parseMigDevices(out, "GPU-cccc,MIG-a1")
+	assert.NoError(t, err)
+	assert.Equal(t, []MigDevice{
+		{GpuIndex: 0, Index: 1, Profile: "1g.10gb", Uuid: "MIG-a1"},
+		{GpuIndex: 2, Index: 0, Profile: "7g.80gb", Uuid: "MIG-c0"},
+	}, devices)
+}
@@ -0,0 +1,83 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+const gpuShareWatchInterval = 5 * time.Second
+
+// gpuShareEnv tells a container on a fraction of a GPU how much of it is its own. The MPS limits only apply when
+// an MPS daemon serves the GPU, so frameworks can also size their allocators from BETA9_GPU_MEMORY_LIMIT.
+func gpuShareEnv(request *types.ContainerRequest) []string {
+	return []string{
+		fmt.Sprintf("CUDA_MPS_PINNED_DEVICE_MEM_LIMIT=0=%dM", request.GpuMemoryLimit),
+		fmt.Sprintf("CUDA_MPS_ACTIVE_THREAD_PERCENTAGE=%d", max(1, int(request.GpuFraction*100))),
+		fmt.Sprintf("BETA9_GPU_MEMORY_LIMIT=%d", request.GpuMemoryLimit),
+		fmt.Sprintf("BETA9_GPU_FRACTION=%g", request.GpuFraction),
+	}
+}
+
+// watchGpuShareMemory stops a container on a fraction of a GPU once its processes use more GPU memory than its
+// share, so it can't take memory from the other containers on the GPU
+func (s *Worker) watchGpuShareMemory(ctx context.Context, containerId string, pid int, request *types.ContainerRequest, outputLogger *slog.Logger) {
+	if request.GpuMemoryLimit <= 0 {
+		return
+	}
+
+	monitor := NewProcessMonitor(pid, nil, nil)
+
+	ticker := time.NewTicker(gpuShareWatchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			used, err := monitor.gpuMemoryUsed()
+			if err != nil {
+				log.Debug().Str("container_id", containerId).Err(err).Msg("unable to get gpu share memory usage")
+				continue
+			}
+
+			if used <= request.GpuMemoryLimit {
+				continue
+			}
+
+			log.Warn().Str("container_id", containerId).Int64("used_mib", used).Int64("limit_mib", request.GpuMemoryLimit).Msg("container exceeded its gpu memory share")
+			outputLogger.Info(fmt.Sprintf("Container used %d MiB of GPU memory, exceeding its limit of %d MiB, and was stopped", used, request.GpuMemoryLimit))
+
+			if err := s.stopContainer(containerId, true); err != nil {
+				log.Error().Str("container_id", containerId).Err(err).Msg("failed to stop container over its gpu memory share")
+			}
+			return
+		}
+	}
+}
+
+// gpuMemoryUsed returns the GPU memory in MiB used by the monitored process and its descendants
+func (m *ProcessMonitor) gpuMemoryUsed() (int64, error) {
+	usage, err := m.gpuInfoClient.GetProcessGPUMemoryUsage()
+	if err != nil {
+		return 0, err
+	}
+
+	processes, err := m.findProcesses()
+	if err != nil {
+		return 0, err
+	}
+
+	used := usage[m.pid]
+	for _, p := range processes {
+		used += usage[p.Pid]
+	}
+
+	return used, nil
+}
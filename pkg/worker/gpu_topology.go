@@ -0,0 +1,233 @@
+package worker
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+// GPUTopology is how a worker's GPUs are connected to each other, and which NUMA node each of them is closest to
+type GPUTopology struct {
+	links     map[int]map[int]string // Link between each pair of GPUs, as nvidia-smi names it (NV12, PIX, SYS...)
+	numaNodes map[int]int
+}
+
+var queryTopology = func() ([]byte, error) {
+	return exec.Command("nvidia-smi", "topo", "-m").Output()
+}
+
+var ansiEscapePattern = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// parseGPUTopology reads the matrix printed by `nvidia-smi topo -m`. Columns are tab separated and line up with
+// the header, which starts with an empty cell for the row names.
+func parseGPUTopology(out []byte) (*GPUTopology, error) {
+	topology := &GPUTopology{
+		links:     map[int]map[int]string{},
+		numaNodes: map[int]int{},
+	}
+
+	var header []string
+	for _, line := range strings.Split(ansiEscapePattern.ReplaceAllString(string(out), ""), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+
+		if header == nil {
+			header = fields
+			continue
+		}
+
+		row, ok := gpuIndex(fields[0])
+		if !ok {
+			// The legend and NIC rows come after the GPUs
+			continue
+		}
+
+		topology.links[row] = map[int]string{}
+		for i := 1; i < len(fields) && i < len(header); i++ {
+			if column, ok := gpuIndex(header[i]); ok && column != row {
+				topology.links[row][column] = fields[i]
+			}
+
+			if header[i] == "NUMA Affinity" {
+				if node, err := strconv.Atoi(fields[i]); err == nil {
+					topology.numaNodes[row] = node
+				}
+			}
+		}
+	}
+
+	if len(topology.links) == 0 {
+		return nil, fmt.Errorf("no gpus found in topology matrix")
+	}
+
+	return topology, nil
+}
+
+func gpuIndex(name string) (int, bool) {
+	if !strings.HasPrefix(name, "GPU") {
+		return 0, false
+	}
+
+	index, err := strconv.Atoi(strings.TrimPrefix(name, "GPU"))
+	return index, err == nil
+}
+
+// linkRank orders links from slowest to fastest. NVLink links with more lanes rank higher, and PCIe links rank
+// by how few bridges and CPUs sit between the GPUs.
+func linkRank(link string) int {
+	if strings.HasPrefix(link, "NV") {
+		lanes, _ := strconv.Atoi(strings.TrimPrefix(link, "NV"))
+		return 10 + lanes
+	}
+
+	switch link {
+	case "PIX":
+		return 4
+	case "PXB":
+		return 3
+	case "PHB":
+		return 2
+	case "NODE":
+		return 1
+	}
+
+	return 0
+}
+
+func (t *GPUTopology) link(a, b int) string {
+	return t.links[a][b]
+}
+
+// weakestLink is the slowest link between any two of the devices
+func (t *GPUTopology) weakestLink(devices []int) string {
+	weakest := ""
+	for i := range devices {
+		for j := i + 1; j < len(devices); j++ {
+			link := t.link(devices[i], devices[j])
+			if weakest == "" || linkRank(link) < linkRank(weakest) {
+				weakest = link
+			}
+		}
+	}
+	return weakest
+}
+
+func (t *GPUTopology) numaNodesOf(devices []int) []int {
+	nodes := []int{}
+	for _, device := range devices {
+		if node, ok := t.numaNodes[device]; ok && !slices.Contains(nodes, node) {
+			nodes = append(nodes, node)
+		}
+	}
+	slices.Sort(nodes)
+	return nodes
+}
+
+type deviceSetScore struct {
+	weakestRank int
+	numaNodes   int
+	totalRank   int
+}
+
+func (s deviceSetScore) betterThan(other deviceSetScore) bool {
+	if s.weakestRank != other.weakestRank {
+		return s.weakestRank > other.weakestRank
+	}
+	if s.numaNodes != other.numaNodes {
+		return s.numaNodes < other.numaNodes
+	}
+	return s.totalRank > other.totalRank
+}
+
+func (t *GPUTopology) score(devices []int) deviceSetScore {
+	score := deviceSetScore{
+		weakestRank: linkRank(t.weakestLink(devices)),
+		numaNodes:   len(t.numaNodesOf(devices)),
+	}
+	for i := range devices {
+		for j := i + 1; j < len(devices); j++ {
+			score.totalRank += linkRank(t.link(devices[i], devices[j]))
+		}
+	}
+	return score
+}
+
+// closestDevices picks count of the free devices with the fastest links between them, preferring devices on
+// fewer NUMA nodes when the links are as fast. Starting from each free device, it keeps adding the device
+// closest to the ones picked so far, and keeps the best of those sets.
+func (t *GPUTopology) closestDevices(free []int, count int) []int {
+	if t == nil || count < 2 || len(free) <= count {
+		return free[:min(count, len(free))]
+	}
+
+	var best []int
+	var bestScore deviceSetScore
+	for _, seed := range free {
+		picked := []int{seed}
+		for len(picked) < count {
+			next := -1
+			var nextScore deviceSetScore
+			for _, device := range free {
+				if slices.Contains(picked, device) {
+					continue
+				}
+
+				score := t.score(append(slices.Clone(picked), device))
+				if next == -1 || score.betterThan(nextScore) {
+					next, nextScore = device, score
+				}
+			}
+			picked = append(picked, next)
+		}
+
+		if score := t.score(picked); best == nil || score.betterThan(bestScore) {
+			best, bestScore = picked, score
+		}
+	}
+
+	slices.Sort(best)
+	return best
+}
+
+// Describe returns the slowest link between the devices, and the NUMA nodes they're on as a comma separated list
+func (t *GPUTopology) Describe(devices []int) (string, string) {
+	if t == nil {
+		return "", ""
+	}
+
+	nodes := []string{}
+	for _, node := range t.numaNodesOf(devices) {
+		nodes = append(nodes, strconv.Itoa(node))
+	}
+
+	return t.weakestLink(devices), strings.Join(nodes, ",")
+}
+
+// Interconnect is whether all of the GPUs are connected to each other over NVLink, or some only over PCIe
+func (t *GPUTopology) Interconnect() string {
+	if t == nil || len(t.links) < 2 {
+		return ""
+	}
+
+	devices := []int{}
+	for device := range t.links {
+		devices = append(devices, device)
+	}
+
+	if strings.HasPrefix(t.weakestLink(devices), "NV") {
+		return types.GpuInterconnectNVLink
+	}
+
+	return types.GpuInterconnectPCIe
+}
@@ -0,0 +1,78 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/beam-cloud/beta9/pkg/types"
+)
+
+// Two pairs of NVLinked GPUs, one pair on each NUMA node
+const testTopologyOutput = "\t\x1b[4mGPU0\tGPU1\tGPU2\tGPU3\tNIC0\tCPU Affinity\tNUMA Affinity\tGPU NUMA ID\x1b[0m\n" +
+	"\x1b[4mGPU0\x1b[0m\t X \tPIX\tSYS\tNV4\tSYS\t0-31\t0\t\tN/A\n" +
+	"\x1b[4mGPU1\x1b[0m\tPIX\t X \tNV4\tSYS\tSYS\t32-63\t1\t\tN/A\n" +
+	"\x1b[4mGPU2\x1b[0m\tSYS\tNV4\t X \tPIX\tSYS\t32-63\t1\t\tN/A\n" +
+	"\x1b[4mGPU3\x1b[0m\tNV4\tSYS\tPIX\t X \tSYS\t0-31\t0\t\tN/A\n" +
+	"\x1b[4mNIC0\x1b[0m\tSYS\tSYS\tSYS\tSYS\t X \n" +
+	"\n" +
+	"Legend:\n" +
+	"\n" +
+	"  X    = Self\n" +
+	"  SYS  = Connection traversing PCIe as well as the SMP interconnect between NUMA nodes (e.g., QPI/UPI)\n"
+
+func TestParseGPUTopology(t *testing.T) {
+	topology, err := parseGPUTopology([]byte(testTopologyOutput))
+	require.NoError(t, err)
+
+	assert.Equal(t, "NV4", topology.link(0, 3))
+	assert.Equal(t, "PIX", topology.link(1, 0))
+	assert.Equal(t, "SYS", topology.link(2, 0))
+	assert.Equal(t, map[int]int{0: 0, 1: 1, 2: 1, 3: 0}, topology.numaNodes)
+	assert.Equal(t, types.GpuInterconnectPCIe, topology.Interconnect())
+
+	_, err = parseGPUTopology([]byte("Legend:\n"))
+	assert.Error(t, err)
+}
+
+func TestClosestDevices(t *testing.T) {
+	topology, err := parseGPUTopology([]byte(testTopologyOutput))
+	require.NoError(t, err)
+
+	// NVLinked pairs beat pairs on the same PCIe switch
+	assert.Equal(t, []int{0, 3}, topology.closestDevices([]int{0, 1, 2, 3}, 2))
+	assert.Equal(t, []int{1, 2}, topology.closestDevices([]int{1, 2, 3}, 2))
+
+	interconnect, numaNodes := topology.Describe([]int{0, 3})
+	assert.Equal(t, "NV4", interconnect)
+	assert.Equal(t, "0", numaNodes)
+
+	interconnect, numaNodes = topology.Describe([]int{0, 1, 2})
+	assert.Equal(t, "SYS", interconnect)
+	assert.Equal(t, "0,1", numaNodes)
+
+	// Without a topology, devices are picked in order
+	var none *GPUTopology
+	assert.Equal(t, []int{1, 2}, none.closestDevices([]int{1, 2, 3}, 2))
+}
+
+func TestAssignGPUDevicesFollowsTopology(t *testing.T) {
+	topology, err := parseGPUTopology([]byte(testTopologyOutput))
+	require.NoError(t, err)
+
+	manager := NewContainerNvidiaManagerForTest(4).(*ContainerNvidiaManager)
+	manager.infoClient = &GPUInfoClientForTest{GpuCount: 4, Topology: topology}
+
+	devices, err := manager.AssignGPUDevices("container1", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 3}, devices)
+
+	devices, err = manager.AssignGPUDevices("container2", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, devices)
+
+	interconnect, numaNodes := manager.GetContainerGPUTopology("container2")
+	assert.Equal(t, "NV4", interconnect)
+	assert.Equal(t, "1", numaNodes)
+}
@@ -0,0 +1,102 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+
+	pb "github.com/beam-cloud/beta9/proto"
+)
+
+const (
+	healthCheckGpu     = "gpu"
+	healthCheckDisk    = "disk"
+	healthCheckRuntime = "runtime"
+
+	runtimeHealthCheckTimeout = 10 * time.Second
+)
+
+// Disks the worker can't run containers without room on
+var healthCheckDiskPaths = []string{"/images", containerLogsPath}
+
+var statfs = syscall.Statfs
+
+// checkHealth runs the checks the scheduler asks workers for, to find workers that shouldn't run containers
+func (s *Worker) checkHealth(ctx context.Context) []*pb.WorkerHealthCheck {
+	return []*pb.WorkerHealthCheck{
+		newWorkerHealthCheck(healthCheckGpu, s.containerGPUManager.CheckGPUHealth()),
+		newWorkerHealthCheck(healthCheckDisk, checkDiskPressure(healthCheckDiskPaths, s.config.Worker.HealthCheck.DiskPressureThreshold)),
+		newWorkerHealthCheck(healthCheckRuntime, s.checkRuntime(ctx)),
+	}
+}
+
+func newWorkerHealthCheck(name string, err error) *pb.WorkerHealthCheck {
+	if err != nil {
+		return &pb.WorkerHealthCheck{Name: name, Healthy: false, Message: err.Error()}
+	}
+
+	return &pb.WorkerHealthCheck{Name: name, Healthy: true}
+}
+
+// checkDiskPressure returns an error when more than threshold of the space or inodes of one of the paths is used
+func checkDiskPressure(paths []string, threshold float64) error {
+	if threshold <= 0 {
+		return nil
+	}
+
+	for _, path := range paths {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			continue
+		}
+
+		var stat syscall.Statfs_t
+		if err := statfs(path, &stat); err != nil {
+			return fmt.Errorf("unable to stat %s: %v", path, err)
+		}
+
+		if stat.Blocks > 0 {
+			used := 1 - float64(stat.Bavail)/float64(stat.Blocks)
+			if used > threshold {
+				return fmt.Errorf("%.0f%% of %s used", used*100, path)
+			}
+		}
+
+		if stat.Files > 0 {
+			used := 1 - float64(stat.Ffree)/float64(stat.Files)
+			if used > threshold {
+				return fmt.Errorf("%.0f%% of inodes on %s used", used*100, path)
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkRuntime makes sure the container runtime still answers. The state of a container that doesn't exist is
+// asked for, so only a runtime that hangs fails the check.
+func (s *Worker) checkRuntime(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, runtimeHealthCheckTimeout)
+	defer cancel()
+
+	// The runtime returning an error is expected, since the container doesn't exist
+	s.runtime.State(ctx, fmt.Sprintf("health-%s", uuid.New().String()))
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%s did not respond within %s", s.runtime.Name(), runtimeHealthCheckTimeout)
+	}
+
+	return nil
+}
+
+// WorkerHealth runs the worker's health checks, which the scheduler asks for to find and cordon unhealthy workers
+func (s *ContainerRuntimeServer) WorkerHealth(ctx context.Context, in *pb.WorkerHealthRequest) (*pb.WorkerHealthResponse, error) {
+	if s.checkHealth == nil {
+		return &pb.WorkerHealthResponse{Ok: false, ErrorMsg: "Health checks are not supported"}, nil
+	}
+
+	return &pb.WorkerHealthResponse{Ok: true, Checks: s.checkHealth(ctx)}, nil
+}
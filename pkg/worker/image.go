@@ -1336,7 +1336,7 @@ func (c *ImageClient) getBuildContext(buildPath string, request *types.Container
 		buildCtxPath = path.Join(buildPath, "build-ctx")
 	}
 
-	err := common.ExtractObjectFile(context.TODO(), objectPath, buildCtxPath)
+	err := common.ExtractObjectFile(context.TODO(), objectPath, buildCtxPath, c.config.Storage.ObjectEncryption)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,52 @@
+package worker
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+)
+
+// newInternalCertificate gets the worker a certificate for mutual TLS from the gateway, and renews it before it
+// expires. Certificates are requested on the gateway's public port with the worker's token, and a new key is
+// generated for every one.
+func newInternalCertificate(ctx context.Context, config types.AppConfig, workerId string, token string) (*common.RotatingCertificate, error) {
+	conn, err := newGatewayConn(config, token, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	client := pb.NewWorkerCertificateServiceClient(conn)
+
+	return common.NewRotatingCertificate(ctx, func() (*tls.Certificate, *x509.CertPool, error) {
+		csrPEM, keyPEM, err := common.NewCertificateRequest(workerId)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		resp, err := handleGRPCResponse(client.IssueWorkerCertificate(ctx, &pb.IssueWorkerCertificateRequest{
+			WorkerId: workerId,
+			CsrPem:   csrPEM,
+		}))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to issue worker certificate: %w", err)
+		}
+
+		cert, err := tls.X509KeyPair(resp.CertificatePem, keyPEM)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(resp.CaPem) {
+			return nil, nil, errors.New("invalid ca certificate")
+		}
+
+		return &cert, pool, nil
+	})
+}
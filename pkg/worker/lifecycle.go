@@ -50,11 +50,31 @@ func (s *Worker) handleStopContainerEvent(event *common.Event) bool {
 		containerInstance.StopReason = stopArgs.Reason
 		s.containerInstances.Set(stopArgs.ContainerId, containerInstance)
 		s.stopContainerChan <- stopContainerEvent{ContainerId: stopArgs.ContainerId, Kill: stopArgs.Force}
+
+		if !stopArgs.Force && stopArgs.GracePeriodS > 0 {
+			go s.killAfterGracePeriod(stopArgs.ContainerId, time.Duration(stopArgs.GracePeriodS)*time.Second)
+		}
 	}
 
 	return true
 }
 
+// killAfterGracePeriod kills a container that is still running once its grace period is over
+func (s *Worker) killAfterGracePeriod(containerId string, gracePeriod time.Duration) {
+	select {
+	case <-s.ctx.Done():
+		return
+	case <-time.After(gracePeriod):
+	}
+
+	if _, exists := s.containerInstances.Get(containerId); !exists {
+		return
+	}
+
+	log.Info().Str("container_id", containerId).Dur("grace_period", gracePeriod).Msg("container still running after grace period, killing it")
+	s.stopContainerChan <- stopContainerEvent{ContainerId: containerId, Kill: true}
+}
+
 // stopContainer stops a container. When force is true, a SIGKILL signal is sent to the container.
 func (s *Worker) stopContainer(containerId string, kill bool) error {
 	log.Info().Str("container_id", containerId).Msg("stopping container")
@@ -270,6 +290,10 @@ func (s *Worker) RunContainer(ctx context.Context, request *types.ContainerReque
 	}
 	outputLogger.Info(fmt.Sprintf("Loaded image <%s>, took: %s\n", request.ImageId, elapsed))
 
+	if err := s.pullSidecarImages(ctx, request, outputLogger); err != nil {
+		return err
+	}
+
 	// Determine how many ports we need to expose
 	portsToExpose := len(request.Ports)
 	if portsToExpose == 0 {
@@ -293,6 +317,12 @@ func (s *Worker) RunContainer(ctx context.Context, request *types.ContainerReque
 		portsToExpose = len(request.Ports)
 	}
 
+	// Expose the port containers of a gang rendezvous on
+	if request.Gang != nil {
+		request.Ports = append(request.Ports, uint32(types.GangRendezvousPort))
+		portsToExpose++
+	}
+
 	bindPorts := make([]int, 0, portsToExpose)
 	for i := 0; i < portsToExpose; i++ {
 		bindPort, err := getRandomFreePort()
@@ -304,6 +334,14 @@ func (s *Worker) RunContainer(ctx context.Context, request *types.ContainerReque
 
 	log.Info().Str("container_id", containerId).Msgf("acquired ports: %v", bindPorts)
 
+	if request.Gang != nil {
+		env, err := s.gangEnvironment(ctx, request, bindPorts)
+		if err != nil {
+			return err
+		}
+		request.Env = append(request.Env, env...)
+	}
+
 	// Read spec from bundle; guard against empty image IDs
 	if request.ImageId == "" {
 		return fmt.Errorf("empty image id in request")
@@ -317,6 +355,12 @@ func (s *Worker) RunContainer(ctx context.Context, request *types.ContainerReque
 		InitialSpec:  initialBundleSpec,
 	}
 
+	err = s.verifySharedVolumeMounts(ctx, request)
+	if err != nil {
+		s.containerLogger.Log(request.ContainerId, request.StubId, "failed to mount shared volume: %v", err)
+		return err
+	}
+
 	err = s.containerMountManager.SetupContainerMounts(ctx, request, outputLogger)
 	if err != nil {
 		s.containerLogger.Log(request.ContainerId, request.StubId, "failed to setup container mounts: %v", err)
@@ -566,6 +610,10 @@ func (s *Worker) specFromRequest(request *types.ContainerRequest, options *Conta
 		env = s.containerGPUManager.InjectEnvVars(env)
 	}
 
+	if request.SharesGpu() {
+		env = append(env, gpuShareEnv(request)...)
+	}
+
 	// Environment is already assembled in getContainerEnvironment (includes InitialSpec.Env if present)
 	spec.Process.Env = env
 
@@ -611,6 +659,11 @@ func (s *Worker) specFromRequest(request *types.ContainerRequest, options *Conta
 			if _, err := os.Stat(m.LocalPath); os.IsNotExist(err) {
 				continue
 			}
+		} else if m.SharedVolumeId != "" {
+			// Shared volumes belong to another workspace, so never create or cache them here
+			if _, err := os.Stat(m.LocalPath); os.IsNotExist(err) {
+				continue
+			}
 		} else {
 			if strings.HasPrefix(m.MountPath, types.WorkerContainerVolumePath) {
 				volumeCacheMap[filepath.Base(m.MountPath)] = m.LocalPath
@@ -683,6 +736,19 @@ func (s *Worker) specFromRequest(request *types.ContainerRequest, options *Conta
 		})
 	}
 
+	// Scratch space shared with the container's sidecars
+	if len(request.Sidecars) > 0 {
+		sharedPath := sidecarSharedDir(request.ContainerId)
+		if err := os.MkdirAll(sharedPath, 0755); err == nil {
+			spec.Mounts = append(spec.Mounts, specs.Mount{
+				Type:        "none",
+				Source:      sharedPath,
+				Destination: types.SidecarSharedPath,
+				Options:     []string{"rbind", "rw"},
+			})
+		}
+	}
+
 	// Add back tmpfs pod/sandbox mounts from initial spec if they exist
 	if (request.Stub.Type.Kind() == types.StubTypePod || request.Stub.Type.Kind() == types.StubTypeSandbox) && options.InitialSpec != nil {
 		for _, m := range options.InitialSpec.Mounts {
@@ -836,24 +902,56 @@ func (s *Worker) spawn(request *types.ContainerRequest, spec *specs.Spec, output
 		return
 	}
 
-	// Only inject GPU devices if runtime supports GPU
-	if request.RequiresGPU() && s.runtime.Capabilities().GPU {
-		// Assign n-number of GPUs to a container
-		assignedDevices, err := s.containerGPUManager.AssignGPUDevices(request.ContainerId, request.GpuCount)
+	// Write mounted secrets to a tmpfs that's wiped when the container exits
+	if request.SecretMount != nil && len(request.SecretMount.Files) > 0 {
+		secretMount, err := s.mountSecretFiles(request)
 		if err != nil {
-			log.Error().Str("container_id", request.ContainerId).Msgf("failed to assign GPUs: %v", err)
+			log.Error().Str("container_id", containerId).Msgf("failed to mount secrets: %v", err)
 			return
 		}
+		defer s.unmountSecretFiles(containerId)
 
-		// Only use CDI if runtime supports it
-		if s.runtime.Capabilities().CDI {
-			cdiCache := cdi.GetDefaultCache()
+		spec.Mounts = append(spec.Mounts, *secretMount)
+	}
+
+	// Only inject GPU devices if runtime supports GPU
+	if request.RequiresGPU() && s.runtime.Capabilities().GPU {
+		// Assign n-number of GPUs to a container, the GPU of its share if it runs on a fraction of one, or a
+		// MIG slice of the requested profile
+		var devicesToInject []string
+		if request.UsesMig() {
+			device, err := s.containerGPUManager.AssignMigDevice(request.ContainerId, request.MigProfile)
+			if err != nil {
+				log.Error().Str("container_id", request.ContainerId).Msgf("failed to assign MIG slice: %v", err)
+				return
+			}
+
+			devicesToInject = append(devicesToInject, device.cdiName())
+		} else {
+			var assignedDevices []int
+			if request.SharesGpu() {
+				assignedDevices, err = s.containerGPUManager.AssignGPUShare(request.ContainerId, request.GpuShare)
+			} else {
+				assignedDevices, err = s.containerGPUManager.AssignGPUDevices(request.ContainerId, request.GpuCount)
+			}
+			if err != nil {
+				log.Error().Str("container_id", request.ContainerId).Msgf("failed to assign GPUs: %v", err)
+				return
+			}
+
+			if !request.SharesGpu() {
+				s.setContainerGpuTopology(request.ContainerId)
+			}
 
-			var devicesToInject []string
 			for _, device := range assignedDevices {
 				devicePath := fmt.Sprintf("%s=%d", nvidiaDeviceKindPrefix, device)
 				devicesToInject = append(devicesToInject, devicePath)
 			}
+		}
+
+		// Only use CDI if runtime supports it
+		if s.runtime.Capabilities().CDI {
+			cdiCache := cdi.GetDefaultCache()
 
 			unresolvable, err := cdiCache.InjectDevices(spec, devicesToInject...)
 			if err != nil {
@@ -917,6 +1015,19 @@ func (s *Worker) spawn(request *types.ContainerRequest, spec *specs.Spec, output
 		}
 	}
 
+	// Sidecars join the container's network namespace, so they start once it's set up and are stopped
+	// after the container exits
+	sidecars := s.newSidecarGroup(request, spec, outputLogger)
+	defer sidecars.stop()
+
+	for _, order := range []types.SidecarOrder{types.SidecarOrderInit, types.SidecarOrderBefore} {
+		if err := sidecars.start(ctx, order); err != nil {
+			log.Error().Str("container_id", containerId).Err(err).Msg("failed to start sidecars")
+			outputLogger.Info(fmt.Sprintf("%v\n", err))
+			return
+		}
+	}
+
 	// Prepare spec for the selected runtime
 	if err := s.runtime.Prepare(ctx, spec); err != nil {
 		log.Error().Str("container_id", containerId).Msgf("failed to prepare spec for runtime: %v", err)
@@ -965,6 +1076,11 @@ func (s *Worker) spawn(request *types.ContainerRequest, spec *specs.Spec, output
 		monitorPIDChan <- pid
 		checkpointPIDChan <- pid
 
+		if err := sidecars.start(ctx, types.SidecarOrderAfter); err != nil {
+			log.Error().Str("container_id", containerId).Err(err).Msg("failed to start sidecars")
+			outputLogger.Info(fmt.Sprintf("%v\n", err))
+		}
+
 		// For sandboxes, wait for process manager to be ready before allowing exec calls
 		if request.Stub.Type.Kind() == types.StubTypeSandbox {
 			instance, exists := s.containerInstances.Get(containerId)
@@ -994,6 +1110,9 @@ func (s *Worker) spawn(request *types.ContainerRequest, spec *specs.Spec, output
 	go func() {
 		pid := <-monitorPIDChan
 		go s.collectAndSendContainerMetrics(ctx, request, spec, pid)
+		if request.SharesGpu() {
+			go s.watchGpuShareMemory(ctx, containerId, pid, request, outputLogger)
+		}
 		s.setupOOMWatcher(ctx, containerId, pid, spec, request, outputLogger, &isOOMKilled)
 	}()
 
@@ -1014,6 +1133,10 @@ func (s *Worker) spawn(request *types.ContainerRequest, spec *specs.Spec, output
 		exitCode = int(types.ContainerExitCodeUser)
 	case types.StopContainerReasonAdmin:
 		exitCode = int(types.ContainerExitCodeAdmin)
+	case types.StopContainerReasonUnhealthy:
+		exitCode = int(types.ContainerExitCodeUnhealthy)
+	case types.StopContainerReasonPreempted:
+		exitCode = int(types.ContainerExitCodePreempted)
 	default:
 		// Check for OOM kill and ensure exit code is 137 for both runc and gVisor
 		if isOOMKilled.Load() {
@@ -1133,3 +1256,20 @@ func (s *Worker) getContainerResources(request *types.ContainerRequest) (*specs.
 		Memory: resources.GetMemory(request),
 	}, nil
 }
+
+// setContainerGpuTopology records how the container's GPUs are connected, so it shows in the container's details
+func (s *Worker) setContainerGpuTopology(containerId string) {
+	interconnect, numaNodes := s.containerGPUManager.GetContainerGPUTopology(containerId)
+	if interconnect == "" && numaNodes == "" {
+		return
+	}
+
+	_, err := handleGRPCResponse(s.containerRepoClient.SetContainerGpuTopology(context.Background(), &pb.SetContainerGpuTopologyRequest{
+		ContainerId:     containerId,
+		GpuInterconnect: interconnect,
+		NumaNodes:       numaNodes,
+	}))
+	if err != nil {
+		log.Warn().Str("container_id", containerId).Err(err).Msg("failed to set container gpu topology")
+	}
+}
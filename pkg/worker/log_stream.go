@@ -0,0 +1,228 @@
+package worker
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	pb "github.com/beam-cloud/beta9/proto"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	logStreamPollInterval = 250 * time.Millisecond
+	// Caps how much of a log file is held in memory while replaying it
+	maxLogReplayLines = 10000
+)
+
+// containerLogFileEntry is a line of a container's log file, as written by the logrus JSON formatter
+type containerLogFileEntry struct {
+	Time   time.Time `json:"time"`
+	Level  string    `json:"level"`
+	Msg    string    `json:"msg"`
+	TaskId *string   `json:"task_id"`
+}
+
+func (e *containerLogFileEntry) toProto(containerId string) *pb.LogEntry {
+	entry := &pb.LogEntry{
+		ContainerId: containerId,
+		Level:       e.Level,
+		Msg:         e.Msg,
+		Timestamp:   timestamppb.New(e.Time),
+	}
+
+	if e.TaskId != nil {
+		entry.TaskId = *e.TaskId
+	}
+
+	return entry
+}
+
+type logFilter struct {
+	level    logrus.Level
+	contains string
+	since    time.Time
+	taskId   string
+}
+
+func newLogFilter(filter *pb.LogFilter) (*logFilter, error) {
+	f := &logFilter{level: logrus.TraceLevel}
+	if filter == nil {
+		return f, nil
+	}
+
+	if filter.Level != "" {
+		level, err := logrus.ParseLevel(filter.Level)
+		if err != nil {
+			return nil, fmt.Errorf("invalid log level: %s", filter.Level)
+		}
+
+		f.level = level
+	}
+
+	if filter.Since != nil {
+		f.since = filter.Since.AsTime()
+	}
+
+	f.contains = filter.Contains
+	f.taskId = filter.TaskId
+	return f, nil
+}
+
+func (f *logFilter) matches(entry *containerLogFileEntry) bool {
+	if parseLogLevel(entry.Level) > f.level {
+		return false
+	}
+
+	if !f.since.IsZero() && entry.Time.Before(f.since) {
+		return false
+	}
+
+	if f.contains != "" && !strings.Contains(entry.Msg, f.contains) {
+		return false
+	}
+
+	if f.taskId != "" && (entry.TaskId == nil || *entry.TaskId != f.taskId) {
+		return false
+	}
+
+	return true
+}
+
+// logFileReader reads entries from a log file that may still be being written to
+type logFileReader struct {
+	reader  *bufio.Reader
+	partial []byte
+}
+
+func newLogFileReader(r io.Reader) *logFileReader {
+	return &logFileReader{reader: bufio.NewReader(r)}
+}
+
+// next returns the next complete entry, or nil if none has been written yet. Lines that
+// aren't valid entries are skipped.
+func (r *logFileReader) next() (*containerLogFileEntry, error) {
+	for {
+		line, err := r.reader.ReadBytes('\n')
+		r.partial = append(r.partial, line...)
+
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		raw := r.partial
+		r.partial = nil
+
+		var entry containerLogFileEntry
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			continue
+		}
+
+		return &entry, nil
+	}
+}
+
+// streamLogFile replays the latest matching entries from a container's log file and then, if asked to
+// follow, sends new matching entries until the container is gone
+func (s *ContainerRuntimeServer) streamLogFile(req *pb.ContainerStreamLogsRequest, stream pb.ContainerService_ContainerStreamLogsServer) error {
+	filter, err := newLogFilter(req.Filter)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(logFilePath(req.ContainerId))
+	if err != nil {
+		return errors.New("container logs not found")
+	}
+	defer file.Close()
+
+	send := func(entry *containerLogFileEntry) error {
+		return stream.Send(&pb.ContainerLogEntry{Msg: entry.Msg, Entry: entry.toProto(req.ContainerId)})
+	}
+
+	replayLimit := maxLogReplayLines
+	if req.TailLines > 0 {
+		replayLimit = min(int(req.TailLines), maxLogReplayLines)
+	} else if filter.since.IsZero() {
+		// Without a tail or a start time, only new entries are sent
+		replayLimit = 0
+	}
+
+	reader := newLogFileReader(file)
+
+	replay := []*containerLogFileEntry{}
+	for {
+		entry, err := reader.next()
+		if err != nil {
+			return err
+		}
+
+		if entry == nil {
+			break
+		}
+
+		if replayLimit == 0 || !filter.matches(entry) {
+			continue
+		}
+
+		if len(replay) == replayLimit {
+			replay = replay[1:]
+		}
+		replay = append(replay, entry)
+	}
+
+	for _, entry := range replay {
+		if err := send(entry); err != nil {
+			return err
+		}
+	}
+
+	if !req.Follow {
+		return nil
+	}
+
+	ctx := stream.Context()
+	for {
+		// Check before reading so entries written just before the container went away are still sent
+		_, running := s.containerInstances.Get(req.ContainerId)
+
+		for {
+			entry, err := reader.next()
+			if err != nil {
+				return err
+			}
+
+			if entry == nil {
+				break
+			}
+
+			if !filter.matches(entry) {
+				continue
+			}
+
+			if err := send(entry); err != nil {
+				return err
+			}
+		}
+
+		if !running {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(logStreamPollInterval):
+		}
+	}
+}
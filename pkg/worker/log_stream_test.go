@@ -0,0 +1,72 @@
+package worker
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	pb "github.com/beam-cloud/beta9/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestLogFilterMatches(t *testing.T) {
+	now := time.Now()
+	taskId := "task-1"
+
+	entry := &containerLogFileEntry{Time: now, Level: "warning", Msg: "disk almost full", TaskId: &taskId}
+
+	tests := []struct {
+		name   string
+		filter *pb.LogFilter
+		want   bool
+	}{
+		{"no filter", nil, true},
+		{"level at entry", &pb.LogFilter{Level: "warning"}, true},
+		{"level below entry", &pb.LogFilter{Level: "info"}, true},
+		{"level above entry", &pb.LogFilter{Level: "error"}, false},
+		{"contains", &pb.LogFilter{Contains: "almost"}, true},
+		{"does not contain", &pb.LogFilter{Contains: "memory"}, false},
+		{"since before entry", &pb.LogFilter{Since: timestamppb.New(now.Add(-time.Minute))}, true},
+		{"since after entry", &pb.LogFilter{Since: timestamppb.New(now.Add(time.Minute))}, false},
+		{"task", &pb.LogFilter{TaskId: taskId}, true},
+		{"other task", &pb.LogFilter{TaskId: "task-2"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := newLogFilter(tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, filter.matches(entry))
+		})
+	}
+
+	_, err := newLogFilter(&pb.LogFilter{Level: "loud"})
+	assert.Error(t, err)
+}
+
+func TestLogFileReaderPartialLines(t *testing.T) {
+	file := &bytes.Buffer{}
+	reader := newLogFileReader(file)
+
+	file.WriteString(`{"level":"info","msg":"first"}` + "\n" + `not json` + "\n" + `{"level":"error","ms`)
+
+	entry, err := reader.next()
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+	assert.Equal(t, "first", entry.Msg)
+
+	// The second line is skipped and the third isn't complete yet
+	entry, err = reader.next()
+	require.NoError(t, err)
+	assert.Nil(t, entry)
+
+	file.WriteString(`g":"second"}` + "\n")
+
+	entry, err = reader.next()
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+	assert.Equal(t, "second", entry.Msg)
+	assert.Equal(t, "error", entry.Level)
+}
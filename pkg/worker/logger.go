@@ -74,6 +74,9 @@ func (r *ContainerLogger) CaptureLogs(request *types.ContainerRequest, logChan c
 		TimestampFormat: time.RFC3339Nano,
 	})
 
+	// Keep every level so log streams can filter on it
+	f.SetLevel(logrus.TraceLevel)
+
 	instance, exists := r.containerInstances.Get(request.ContainerId)
 	if !exists {
 		return errors.New("container not found")
@@ -125,7 +128,7 @@ func (r *ContainerLogger) CaptureLogs(request *types.ContainerRequest, logChan c
 				"container_id": request.ContainerId,
 				"task_id":      msg.TaskID,
 				"stub_id":      instance.StubId,
-			}).Info(msg.Message)
+			}).Log(parseLogLevel(msg.Level), msg.Message)
 
 			// Write logs to in-memory log buffer as well
 			if msg.Message != "" {
@@ -176,9 +179,23 @@ func (r *ContainerLogger) CaptureLogs(request *types.ContainerRequest, logChan c
 	return nil
 }
 
+// parseLogLevel maps the level a container logged at to a logrus level, falling back to info
+func parseLogLevel(level string) logrus.Level {
+	parsed, err := logrus.ParseLevel(level)
+	if err != nil {
+		return logrus.InfoLevel
+	}
+
+	// logrus panics on entries logged at panic level
+	return max(parsed, logrus.FatalLevel)
+}
+
+func logFilePath(containerId string) string {
+	return path.Join(containerLogsPath, fmt.Sprintf("%s.log", containerId))
+}
+
 func openLogFile(containerId string) (*os.File, error) {
-	logFilePath := path.Join(containerLogsPath, fmt.Sprintf("%s.log", containerId))
-	logFile, err := os.OpenFile(logFilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+	logFile, err := os.OpenFile(logFilePath(containerId), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create container log file: %w", err)
 	}
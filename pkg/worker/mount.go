@@ -18,12 +18,14 @@ import (
 type ContainerMountManager struct {
 	mountPointPaths *common.SafeMap[[]string]
 	storageConfig   types.StorageConfig
+	objectCache     *ObjectCache
 }
 
-func NewContainerMountManager(config types.AppConfig) *ContainerMountManager {
+func NewContainerMountManager(config types.AppConfig, objectCache *ObjectCache) *ContainerMountManager {
 	return &ContainerMountManager{
 		mountPointPaths: common.NewSafeMap[[]string](),
 		storageConfig:   config.Storage,
+		objectCache:     objectCache,
 	}
 }
 
@@ -36,13 +38,13 @@ func (c *ContainerMountManager) SetupContainerMounts(ctx context.Context, reques
 			if !request.StorageAvailable() {
 				objectPath := path.Join(types.DefaultObjectPath, request.Workspace.Name, request.Stub.Object.ExternalId)
 
-				err := common.ExtractObjectFile(ctx, objectPath, m.LocalPath)
+				err := common.ExtractCompressedObjectFile(ctx, objectPath, m.LocalPath, request.Stub.Object.Compression, c.storageConfig.ObjectEncryption)
 				if err != nil {
 					return err
 				}
 
 			} else {
-				if err := getAndExtractStubCode(ctx, request); err != nil {
+				if err := c.getAndExtractStubCode(ctx, request); err != nil {
 					return err
 				}
 			}
@@ -51,7 +53,8 @@ func (c *ContainerMountManager) SetupContainerMounts(ctx context.Context, reques
 		}
 
 		// NOTE: The following adjustments to local paths are part of a migration to use WorkspaceStorage and can be removed once all existing workspaces are migrated.
-		if request.StorageAvailable() {
+		// Volumes shared from other workspaces stay on the owner's path.
+		if request.StorageAvailable() && m.SharedVolumeId == "" {
 			switch {
 			case strings.HasPrefix(m.MountPath, types.WorkerContainerVolumePath):
 				m.LocalPath = strings.Replace(m.LocalPath, path.Join(types.DefaultVolumesPath, request.Workspace.Name), path.Join(c.storageConfig.WorkspaceStorage.BaseMountPath, request.Workspace.Name, types.DefaultVolumesPrefix), 1)
@@ -133,8 +136,18 @@ func checkpointSignalDir(containerId string) string {
 	return fmt.Sprintf("/tmp/%s/criu", containerId)
 }
 
-// getAndExtractStubCode downloads the object from storage and extracts it to the temp location that will be mounted
-func getAndExtractStubCode(ctx context.Context, request *types.ContainerRequest) error {
+// getAndExtractStubCode downloads the object from storage and extracts it to the temp location that will be mounted.
+// Objects held in the worker's object cache are extracted without downloading them again.
+func (c *ContainerMountManager) getAndExtractStubCode(ctx context.Context, request *types.ContainerRequest) error {
+	destPath := types.TempContainerWorkspace(request.ContainerId)
+	digest := request.Stub.Object.Digest
+
+	if c.objectCache != nil && digest != "" {
+		if objBytes, ok := c.objectCache.Get(ctx, digest); ok {
+			return common.UnzipBytesToPath(destPath, objBytes, request)
+		}
+	}
+
 	storageClient, err := clients.NewWorkspaceStorageClient(ctx, request.Workspace.Name, request.Workspace.Storage)
 	if err != nil {
 		log.Error().Str("container_id", request.ContainerId).Str("workspace_id", request.Workspace.ExternalId).Err(err).Msg("unable to instantiate storage client")
@@ -147,6 +160,11 @@ func getAndExtractStubCode(ctx context.Context, request *types.ContainerRequest)
 		return err
 	}
 
-	destPath := types.TempContainerWorkspace(request.ContainerId)
+	if c.objectCache != nil && digest != "" {
+		if err := c.objectCache.Put(ctx, digest, objBytes); err != nil {
+			log.Warn().Str("container_id", request.ContainerId).Err(err).Msg("unable to cache object")
+		}
+	}
+
 	return common.UnzipBytesToPath(destPath, objBytes, request)
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/rs/zerolog/log"
 
 	common "github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/types"
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"gvisor.dev/gvisor/pkg/sync"
 )
@@ -25,18 +26,28 @@ var (
 
 type GPUManager interface {
 	AssignGPUDevices(containerId string, gpuCount uint32) ([]int, error)
+	AssignGPUShare(containerId string, share int32) ([]int, error)
+	AssignMigDevice(containerId string, profile string) (MigDevice, error)
+	GetMigSlices() types.MigSlices
 	GetContainerGPUDevices(containerId string) []int
 	UnassignGPUDevices(containerId string)
 	InjectEnvVars(env []string) []string
 	InjectMounts(mounts []specs.Mount) []specs.Mount
+	GetGPUInterconnect() string
+	GetContainerGPUTopology(containerId string) (interconnect string, numaNodes string)
+	CheckGPUHealth() error
 }
 
 type ContainerNvidiaManager struct {
 	gpuAllocationMap *common.SafeMap[[]int]
+	gpuShareMap      *common.SafeMap[int32] // Share of the GPU each container on a fractional GPU uses
+	migAllocationMap *common.SafeMap[MigDevice]
 	gpuCount         uint32
 	mu               sync.Mutex
 	statFunc         func(path string, stat *syscall.Stat_t) (err error)
 	infoClient       GPUInfoClient
+	topology         *GPUTopology
+	topologyLoaded   bool
 }
 
 func NewContainerNvidiaManager(gpuCount uint32) GPUManager {
@@ -49,6 +60,8 @@ func NewContainerNvidiaManager(gpuCount uint32) GPUManager {
 
 	return &ContainerNvidiaManager{
 		gpuAllocationMap: common.NewSafeMap[[]int](),
+		gpuShareMap:      common.NewSafeMap[int32](),
+		migAllocationMap: common.NewSafeMap[MigDevice](),
 		gpuCount:         gpuCount,
 		mu:               sync.Mutex{},
 		statFunc:         syscall.Stat,
@@ -60,7 +73,28 @@ type AssignedGpuDevices struct {
 }
 
 func (c *ContainerNvidiaManager) UnassignGPUDevices(containerId string) {
-	c.gpuAllocationMap.Delete(containerId)
+	share, ok := c.gpuShareMap.Get(containerId)
+	if !ok {
+		c.gpuAllocationMap.Delete(containerId)
+		c.migAllocationMap.Delete(containerId)
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.gpuShareMap.Delete(containerId)
+
+	// The share's device is given back once the last container using it is gone
+	inUse := false
+	c.gpuShareMap.Range(func(_ string, s int32) bool {
+		inUse = s == share
+		return !inUse
+	})
+
+	if !inUse {
+		c.gpuAllocationMap.Delete(gpuShareKey(share))
+	}
 }
 
 func (c *ContainerNvidiaManager) AssignGPUDevices(containerId string, gpuCount uint32) ([]int, error) {
@@ -71,8 +105,78 @@ func (c *ContainerNvidiaManager) AssignGPUDevices(containerId string, gpuCount u
 	return gpuIds, nil
 }
 
+// AssignGPUShare assigns a container the device of a GPU share, which containers running on a fraction of a
+// GPU have in common. The first container of a share picks a free device for it.
+func (c *ContainerNvidiaManager) AssignGPUShare(containerId string, share int32) ([]int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	gpuIds, ok := c.gpuAllocationMap.Get(gpuShareKey(share))
+	if !ok {
+		var err error
+		gpuIds, err = c.allocateDevices(gpuShareKey(share), 1)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	c.gpuShareMap.Set(containerId, share)
+	return gpuIds, nil
+}
+
+// AssignMigDevice assigns a container a free MIG slice of the profile
+func (c *ContainerNvidiaManager) AssignMigDevice(containerId string, profile string) (MigDevice, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	devices, err := c.infoClient.MigDevices()
+	if err != nil {
+		return MigDevice{}, err
+	}
+
+	allocated := map[string]bool{}
+	c.migAllocationMap.Range(func(_ string, device MigDevice) bool {
+		allocated[device.Uuid] = true
+		return true
+	})
+
+	for _, device := range devices {
+		if device.Profile == profile && !allocated[device.Uuid] {
+			c.migAllocationMap.Set(containerId, device)
+			return device, nil
+		}
+	}
+
+	return MigDevice{}, fmt.Errorf("no free MIG slice of profile %s", profile)
+}
+
+// GetMigSlices returns how many MIG slices of each profile the worker's GPUs are partitioned into
+func (c *ContainerNvidiaManager) GetMigSlices() types.MigSlices {
+	if c.gpuCount == 0 {
+		return ""
+	}
+
+	devices, err := c.infoClient.MigDevices()
+	if err != nil {
+		log.Warn().Err(err).Msg("unable to list mig devices")
+		return ""
+	}
+
+	counts := map[string]int{}
+	for _, device := range devices {
+		counts[device.Profile]++
+	}
+
+	return types.NewMigSlices(counts)
+}
+
 func (c *ContainerNvidiaManager) GetContainerGPUDevices(containerId string) []int {
-	gpuDevices, ok := c.gpuAllocationMap.Get(containerId)
+	key := containerId
+	if share, ok := c.gpuShareMap.Get(containerId); ok {
+		key = gpuShareKey(share)
+	}
+
+	gpuDevices, ok := c.gpuAllocationMap.Get(key)
 	if !ok {
 		return []int{}
 	}
@@ -84,6 +188,11 @@ func (c *ContainerNvidiaManager) chooseDevices(containerId string, requestedGpuC
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	return c.allocateDevices(containerId, requestedGpuCount)
+}
+
+// allocateDevices picks free devices and saves them under key. The caller must hold c.mu.
+func (c *ContainerNvidiaManager) allocateDevices(key string, requestedGpuCount uint32) ([]int, error) {
 	currentAllocations := make(map[int]bool)
 	c.gpuAllocationMap.Range(func(_ string, value []int) bool {
 		for _, gpuId := range value {
@@ -113,15 +222,85 @@ func (c *ContainerNvidiaManager) chooseDevices(containerId string, requestedGpuC
 		return nil, fmt.Errorf("not enough GPUs available, requested: %d, allocable: %d out of %d", requestedGpuCount, int(c.gpuCount)-len(allocableDevices), len(availableDevices))
 	}
 
-	// Allocate the requested number of GPUs
-	devicesToAllocate := allocableDevices[:requestedGpuCount]
+	// Allocate the requested number of GPUs, keeping a container's GPUs as close to each other as we can
+	devicesToAllocate := c.loadTopology().closestDevices(allocableDevices, int(requestedGpuCount))
 
 	// Save the allocation in the SafeMap
-	c.gpuAllocationMap.Set(containerId, devicesToAllocate)
+	c.gpuAllocationMap.Set(key, devicesToAllocate)
 
 	return devicesToAllocate, nil
 }
 
+// loadTopology reads the GPU topology the first time it's needed. Without it, devices are allocated in order.
+// The caller must hold c.mu.
+func (c *ContainerNvidiaManager) loadTopology() *GPUTopology {
+	if c.topologyLoaded || c.gpuCount < 2 {
+		return c.topology
+	}
+	c.topologyLoaded = true
+
+	topology, err := c.infoClient.GPUTopology()
+	if err != nil {
+		log.Warn().Err(err).Msg("unable to read gpu topology, gpus will be assigned in order")
+		return nil
+	}
+
+	c.topology = topology
+	return topology
+}
+
+// GetGPUInterconnect returns whether all of the worker's GPUs are connected over NVLink or some only over PCIe
+func (c *ContainerNvidiaManager) GetGPUInterconnect() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.loadTopology().Interconnect()
+}
+
+// GetContainerGPUTopology returns the slowest link between the container's GPUs and the NUMA nodes they're on
+func (c *ContainerNvidiaManager) GetContainerGPUTopology(containerId string) (string, string) {
+	devices := c.GetContainerGPUDevices(containerId)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.loadTopology().Describe(devices)
+}
+
+// CheckGPUHealth returns an error when one of the worker's GPUs has gone missing or shouldn't run containers
+func (c *ContainerNvidiaManager) CheckGPUHealth() error {
+	if c.gpuCount == 0 {
+		return nil
+	}
+
+	devices, err := c.infoClient.AvailableGPUDevices()
+	if err != nil {
+		return err
+	}
+
+	if len(devices) < int(c.gpuCount) {
+		return fmt.Errorf("%d of %d gpus found", len(devices), c.gpuCount)
+	}
+
+	unhealthy, err := c.infoClient.UnhealthyGPUs()
+	if err != nil {
+		return err
+	}
+
+	for _, device := range devices {
+		if reason, ok := unhealthy[device]; ok {
+			return fmt.Errorf("gpu %d: %s", device, reason)
+		}
+	}
+
+	return nil
+}
+
+// gpuShareKey is the allocation of a GPU share's device, next to the allocations of containers with whole GPUs
+func gpuShareKey(share int32) string {
+	return fmt.Sprintf("gpu-share-%d", share)
+}
+
 func (c *ContainerNvidiaManager) InjectEnvVars(env []string) []string {
 	cudaEnvVarDefaults := map[string]string{
 		"NVIDIA_DRIVER_CAPABILITIES": "compute,utility,graphics,ngx,video",
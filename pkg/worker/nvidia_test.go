@@ -19,12 +19,17 @@ import (
 )
 
 type GPUInfoClientForTest struct {
-	GpuCount int
+	GpuCount  int
+	Topology  *GPUTopology
+	Unhealthy map[int]string
+	Mig       []MigDevice
 }
 
 func NewContainerNvidiaManagerForTest(gpuCount int) GPUManager {
 	gpuManager := &ContainerNvidiaManager{
 		gpuAllocationMap: common.NewSafeMap[[]int](),
+		gpuShareMap:      common.NewSafeMap[int32](),
+		migAllocationMap: common.NewSafeMap[MigDevice](),
 		gpuCount:         uint32(gpuCount),
 		mu:               sync.Mutex{},
 		statFunc:         syscall.Stat,
@@ -50,6 +55,22 @@ func (c *GPUInfoClientForTest) GetGPUMemoryUsage(gpuId int) (GPUMemoryUsageStats
 	return GPUMemoryUsageStats{}, nil
 }
 
+func (c *GPUInfoClientForTest) GetProcessGPUMemoryUsage() (map[int32]int64, error) {
+	return map[int32]int64{}, nil
+}
+
+func (c *GPUInfoClientForTest) GPUTopology() (*GPUTopology, error) {
+	return c.Topology, nil
+}
+
+func (c *GPUInfoClientForTest) UnhealthyGPUs() (map[int]string, error) {
+	return c.Unhealthy, nil
+}
+
+func (c *GPUInfoClientForTest) MigDevices() ([]MigDevice, error) {
+	return c.Mig, nil
+}
+
 func TestInjectNvidiaEnvVarsNoCudaInImage(t *testing.T) {
 	manager := NewContainerNvidiaManagerForTest(4)
 	initialEnv := []string{"INITIAL=1"}
@@ -290,3 +311,69 @@ func TestConcurrentlyAssignGPUDevices(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestAssignGPUShares(t *testing.T) {
+	manager := NewContainerNvidiaManagerForTest(2)
+
+	first, err := manager.AssignGPUShare("container1", 0)
+	assert.NoError(t, err)
+	second, err := manager.AssignGPUShare("container2", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, first, second)
+	assert.Equal(t, first, manager.GetContainerGPUDevices("container2"))
+
+	// The shared GPU isn't free for whole GPU requests
+	whole, err := manager.AssignGPUDevices("container3", 1)
+	assert.NoError(t, err)
+	assert.NotEqual(t, first, whole)
+	_, err = manager.AssignGPUShare("container4", 1)
+	assert.Error(t, err)
+
+	// The share keeps its GPU until its last container is gone
+	manager.UnassignGPUDevices("container1")
+	_, err = manager.AssignGPUDevices("container5", 1)
+	assert.Error(t, err)
+
+	manager.UnassignGPUDevices("container2")
+	assigned, err := manager.AssignGPUDevices("container5", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, first, assigned)
+}
+
+func TestCheckGPUHealth(t *testing.T) {
+	manager := NewContainerNvidiaManagerForTest(2).(*ContainerNvidiaManager)
+	assert.NoError(t, manager.CheckGPUHealth())
+
+	manager.infoClient = &GPUInfoClientForTest{GpuCount: 2, Unhealthy: map[int]string{1: "requires reset"}}
+	assert.EqualError(t, manager.CheckGPUHealth(), "gpu 1: requires reset")
+
+	// GPUs that have fallen off the bus aren't listed anymore
+	manager.infoClient = &GPUInfoClientForTest{GpuCount: 1}
+	assert.EqualError(t, manager.CheckGPUHealth(), "1 of 2 gpus found")
+}
+
+func TestAssignMigDevices(t *testing.T) {
+	manager := NewContainerNvidiaManagerForTest(1).(*ContainerNvidiaManager)
+	manager.infoClient = &GPUInfoClientForTest{GpuCount: 1, Mig: []MigDevice{
+		{GpuIndex: 0, Index: 0, Profile: "3g.40gb", Uuid: "MIG-0"},
+		{GpuIndex: 0, Index: 1, Profile: "1g.10gb", Uuid: "MIG-1"},
+		{GpuIndex: 0, Index: 2, Profile: "1g.10gb", Uuid: "MIG-2"},
+	}}
+
+	assert.Equal(t, "1g.10gb=2,3g.40gb=1", string(manager.GetMigSlices()))
+
+	first, err := manager.AssignMigDevice("container1", "1g.10gb")
+	assert.NoError(t, err)
+	second, err := manager.AssignMigDevice("container2", "1g.10gb")
+	assert.NoError(t, err)
+	assert.NotEqual(t, first.Uuid, second.Uuid)
+
+	_, err = manager.AssignMigDevice("container3", "1g.10gb")
+	assert.Error(t, err)
+
+	// A slice is free again once its container is gone
+	manager.UnassignGPUDevices("container1")
+	third, err := manager.AssignMigDevice("container3", "1g.10gb")
+	assert.NoError(t, err)
+	assert.Equal(t, first, third)
+}
@@ -0,0 +1,252 @@
+package worker
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+)
+
+const (
+	defaultObjectCacheInvalidationInterval = 10 * time.Second
+	objectCacheRegistryTimeout             = 5 * time.Second
+)
+
+// ObjectCache keeps downloaded stub objects on local disk keyed by their content digest, so repeated
+// cold starts of the same stub don't have to pull the object from workspace storage again. Cached
+// digests are registered with the gateway so the scheduler can prefer this worker, and evicted when
+// the gateway reports the object as overwritten or deleted.
+type ObjectCache struct {
+	workerId         string
+	config           types.WorkerObjectCacheConfig
+	workerRepoClient pb.WorkerRepositoryServiceClient
+	mu               sync.Mutex
+	entries          map[string]*objectCacheEntry
+	size             int64
+}
+
+type objectCacheEntry struct {
+	size     int64
+	lastUsed time.Time
+}
+
+func NewObjectCache(ctx context.Context, workerId string, config types.WorkerObjectCacheConfig, workerRepoClient pb.WorkerRepositoryServiceClient) (*ObjectCache, error) {
+	// Entries from a previous worker aren't registered under this worker's id, so start empty
+	if err := os.RemoveAll(config.Path); err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(config.Path, 0755); err != nil {
+		return nil, err
+	}
+
+	cache := &ObjectCache{
+		workerId:         workerId,
+		config:           config,
+		workerRepoClient: workerRepoClient,
+		entries:          make(map[string]*objectCacheEntry),
+	}
+
+	go cache.processInvalidations(ctx)
+	return cache, nil
+}
+
+// Get returns the cached content for digest, if this worker holds it
+func (c *ObjectCache) Get(ctx context.Context, digest string) ([]byte, bool) {
+	digest = strings.ToLower(digest)
+
+	c.mu.Lock()
+	entry, ok := c.entries[digest]
+	if ok {
+		entry.lastUsed = time.Now()
+	}
+	c.mu.Unlock()
+
+	if !ok {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(c.entryPath(digest))
+	if err != nil {
+		log.Warn().Err(err).Str("digest", digest).Msg("unable to read cached object")
+		c.Remove(ctx, digest)
+		return nil, false
+	}
+
+	// Registrations expire, so a hit refreshes this worker's entry in the gateway's registry
+	c.register(ctx, digest)
+	return data, true
+}
+
+// Put stores content under digest, evicting the least recently used objects to stay within the size
+// limit. Content that doesn't match its digest is never cached.
+func (c *ObjectCache) Put(ctx context.Context, digest string, data []byte) error {
+	digest = strings.ToLower(digest)
+	size := int64(len(data))
+	if c.config.MaxSizeBytes > 0 && size > c.config.MaxSizeBytes {
+		return nil
+	}
+
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != digest {
+		log.Warn().Str("digest", digest).Msg("object content does not match its digest, not caching")
+		return nil
+	}
+
+	tmpFile, err := os.CreateTemp(c.config.Path, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		return err
+	}
+
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	if _, ok := c.entries[digest]; ok {
+		c.mu.Unlock()
+		return nil
+	}
+
+	evicted := c.evictLocked(size)
+	if err := os.Rename(tmpFile.Name(), c.entryPath(digest)); err != nil {
+		c.mu.Unlock()
+		c.unregister(ctx, evicted)
+		return err
+	}
+
+	c.entries[digest] = &objectCacheEntry{size: size, lastUsed: time.Now()}
+	c.size += size
+	c.mu.Unlock()
+
+	c.unregister(ctx, evicted)
+	c.register(ctx, digest)
+	return nil
+}
+
+// Remove evicts digest from the cache and the gateway's registry
+func (c *ObjectCache) Remove(ctx context.Context, digest string) {
+	digest = strings.ToLower(digest)
+
+	if c.removeLocal(digest) {
+		c.unregister(ctx, []string{digest})
+	}
+}
+
+func (c *ObjectCache) removeLocal(digest string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[digest]
+	if !ok {
+		return false
+	}
+
+	os.Remove(c.entryPath(digest))
+	delete(c.entries, digest)
+	c.size -= entry.size
+	return true
+}
+
+// evictLocked removes least recently used entries until size more bytes fit, returning the evicted digests
+func (c *ObjectCache) evictLocked(size int64) []string {
+	evicted := []string{}
+	if c.config.MaxSizeBytes <= 0 {
+		return evicted
+	}
+
+	for c.size+size > c.config.MaxSizeBytes && len(c.entries) > 0 {
+		var oldestDigest string
+		var oldest *objectCacheEntry
+		for digest, entry := range c.entries {
+			if oldest == nil || entry.lastUsed.Before(oldest.lastUsed) {
+				oldestDigest, oldest = digest, entry
+			}
+		}
+
+		os.Remove(c.entryPath(oldestDigest))
+		delete(c.entries, oldestDigest)
+		c.size -= oldest.size
+		evicted = append(evicted, oldestDigest)
+	}
+
+	return evicted
+}
+
+// processInvalidations evicts objects the gateway reports as overwritten or deleted
+func (c *ObjectCache) processInvalidations(ctx context.Context) {
+	interval := c.config.InvalidationInterval
+	if interval <= 0 {
+		interval = defaultObjectCacheInvalidationInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			response, err := handleGRPCResponse(c.workerRepoClient.PopCachedObjectInvalidations(ctx, &pb.PopCachedObjectInvalidationsRequest{
+				WorkerId: c.workerId,
+			}))
+			if err != nil {
+				log.Warn().Err(err).Msg("unable to get object cache invalidations")
+				continue
+			}
+
+			// The gateway already dropped these from its registry
+			for _, digest := range response.Digests {
+				if c.removeLocal(strings.ToLower(digest)) {
+					log.Info().Str("digest", digest).Msg("evicted invalidated object from cache")
+				}
+			}
+		}
+	}
+}
+
+func (c *ObjectCache) register(ctx context.Context, digest string) {
+	ctx, cancel := context.WithTimeout(ctx, objectCacheRegistryTimeout)
+	defer cancel()
+
+	if _, err := handleGRPCResponse(c.workerRepoClient.AddCachedObject(ctx, &pb.AddCachedObjectRequest{
+		WorkerId: c.workerId,
+		Digest:   digest,
+	})); err != nil {
+		log.Warn().Err(err).Str("digest", digest).Msg("unable to register cached object")
+	}
+}
+
+func (c *ObjectCache) unregister(ctx context.Context, digests []string) {
+	ctx, cancel := context.WithTimeout(ctx, objectCacheRegistryTimeout)
+	defer cancel()
+
+	for _, digest := range digests {
+		if _, err := handleGRPCResponse(c.workerRepoClient.RemoveCachedObject(ctx, &pb.RemoveCachedObjectRequest{
+			WorkerId: c.workerId,
+			Digest:   digest,
+		})); err != nil {
+			log.Warn().Err(err).Str("digest", digest).Msg("unable to unregister cached object")
+		}
+	}
+}
+
+func (c *ObjectCache) entryPath(digest string) string {
+	return filepath.Join(c.config.Path, digest)
+}
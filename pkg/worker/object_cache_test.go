@@ -0,0 +1,121 @@
+package worker
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+)
+
+// MockObjectCacheRegistry records cache registrations instead of sending them to the gateway
+type MockObjectCacheRegistry struct {
+	pb.WorkerRepositoryServiceClient
+	mu         sync.Mutex
+	registered map[string]bool
+}
+
+func (m *MockObjectCacheRegistry) AddCachedObject(ctx context.Context, in *pb.AddCachedObjectRequest, opts ...grpc.CallOption) (*pb.AddCachedObjectResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.registered[in.Digest] = true
+	return &pb.AddCachedObjectResponse{Ok: true}, nil
+}
+
+func (m *MockObjectCacheRegistry) RemoveCachedObject(ctx context.Context, in *pb.RemoveCachedObjectRequest, opts ...grpc.CallOption) (*pb.RemoveCachedObjectResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.registered, in.Digest)
+	return &pb.RemoveCachedObjectResponse{Ok: true}, nil
+}
+
+func (m *MockObjectCacheRegistry) PopCachedObjectInvalidations(ctx context.Context, in *pb.PopCachedObjectInvalidationsRequest, opts ...grpc.CallOption) (*pb.PopCachedObjectInvalidationsResponse, error) {
+	return &pb.PopCachedObjectInvalidationsResponse{Ok: true}, nil
+}
+
+func (m *MockObjectCacheRegistry) isRegistered(digest string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.registered[digest]
+}
+
+func objectDigest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestObjectCache(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	registry := &MockObjectCacheRegistry{registered: make(map[string]bool)}
+	cache, err := NewObjectCache(ctx, "worker1", types.WorkerObjectCacheConfig{
+		Path:         filepath.Join(t.TempDir(), "objects"),
+		MaxSizeBytes: 10,
+	}, registry)
+	if err != nil {
+		t.Fatalf("failed to create object cache: %v", err)
+	}
+
+	first := []byte("0123")
+	second := []byte("456789")
+	third := []byte("abcde")
+
+	if err := cache.Put(ctx, objectDigest(first), first); err != nil {
+		t.Fatalf("failed to cache object: %v", err)
+	}
+
+	data, ok := cache.Get(ctx, objectDigest(first))
+	if !ok || string(data) != string(first) {
+		t.Fatalf("expected cached object %q, got %q", first, data)
+	}
+
+	if !registry.isRegistered(objectDigest(first)) {
+		t.Errorf("expected cached object to be registered")
+	}
+
+	// Content that doesn't match its digest is not cached
+	if err := cache.Put(ctx, objectDigest(first), second); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := cache.Put(ctx, objectDigest(third), second); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := cache.Get(ctx, objectDigest(third)); ok {
+		t.Errorf("expected mismatched content not to be cached")
+	}
+
+	if err := cache.Put(ctx, objectDigest(second), second); err != nil {
+		t.Fatalf("failed to cache object: %v", err)
+	}
+
+	// The cache is full, so adding a third object evicts the least recently used one
+	cache.Get(ctx, objectDigest(first))
+	if err := cache.Put(ctx, objectDigest(third), third); err != nil {
+		t.Fatalf("failed to cache object: %v", err)
+	}
+
+	if _, ok := cache.Get(ctx, objectDigest(second)); ok {
+		t.Errorf("expected least recently used object to be evicted")
+	}
+	if registry.isRegistered(objectDigest(second)) {
+		t.Errorf("expected evicted object to be unregistered")
+	}
+	if _, ok := cache.Get(ctx, objectDigest(first)); !ok {
+		t.Errorf("expected recently used object to stay cached")
+	}
+
+	cache.Remove(ctx, objectDigest(first))
+	if _, ok := cache.Get(ctx, objectDigest(first)); ok {
+		t.Errorf("expected removed object to be gone")
+	}
+	if registry.isRegistered(objectDigest(first)) {
+		t.Errorf("expected removed object to be unregistered")
+	}
+}
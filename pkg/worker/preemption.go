@@ -0,0 +1,128 @@
+package worker
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+)
+
+const (
+	defaultPreemptionPollInterval     = 5 * time.Second
+	defaultPreemptionCheckpointWindow = 90 * time.Second
+	preemptionNoticeTimeout           = 2 * time.Second
+)
+
+// watchForPreemption polls the instance metadata endpoint of a spot worker until the provider announces the
+// instance is being reclaimed, then drains the worker
+func (s *Worker) watchForPreemption() {
+	cfg := s.config.Worker.Preemption
+	if cfg.NoticeURL == "" {
+		return
+	}
+
+	interval := cfg.PollInterval
+	if interval <= 0 {
+		interval = defaultPreemptionPollInterval
+	}
+
+	client := &http.Client{Timeout: preemptionNoticeTimeout}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			if !preemptionNoticed(s.ctx, client, cfg.NoticeURL) {
+				continue
+			}
+
+			s.handlePreemption(cfg.CheckpointWindow)
+			return
+		}
+	}
+}
+
+// preemptionNoticed checks for a preemption notice. EC2 returns 404 until an instance action is scheduled, and
+// GCP returns FALSE until the instance is preempted.
+func preemptionNoticed(ctx context.Context, client *http.Client, url string) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if err != nil {
+		return false
+	}
+
+	return !strings.EqualFold(strings.TrimSpace(string(body)), "false")
+}
+
+// handlePreemption stops the worker from getting new containers, asks its containers to stop so they can
+// checkpoint, and kills the ones still running once the checkpoint window is over. Preempted containers exit
+// with ContainerExitCodePreempted, so their tasks are retried on other workers.
+func (s *Worker) handlePreemption(checkpointWindow time.Duration) {
+	if checkpointWindow <= 0 {
+		checkpointWindow = defaultPreemptionCheckpointWindow
+	}
+
+	log.Warn().Str("worker_id", s.workerId).Dur("checkpoint_window", checkpointWindow).Msg("received preemption notice, draining worker")
+
+	_, err := handleGRPCResponse(s.workerRepoClient.UpdateWorkerStatus(s.ctx, &pb.UpdateWorkerStatusRequest{
+		WorkerId: s.workerId,
+		Status:   string(types.WorkerStatusDisabled),
+	}))
+	if err != nil {
+		log.Error().Str("worker_id", s.workerId).Err(err).Msg("failed to disable preempted worker")
+	}
+
+	containerIds := []string{}
+	s.containerLock.Lock()
+	s.containerInstances.Range(func(containerId string, instance *ContainerInstance) bool {
+		instance.StopReason = types.StopContainerReasonPreempted
+		containerIds = append(containerIds, containerId)
+		return true
+	})
+	s.containerLock.Unlock()
+
+	for _, containerId := range containerIds {
+		s.stopContainerChan <- stopContainerEvent{ContainerId: containerId, Kill: false}
+	}
+
+	select {
+	case <-s.ctx.Done():
+		return
+	case <-time.After(checkpointWindow):
+	}
+
+	for _, containerId := range containerIds {
+		if _, exists := s.containerInstances.Get(containerId); !exists {
+			continue
+		}
+
+		log.Warn().Str("container_id", containerId).Msg("container still running after checkpoint window, killing it")
+		if err := s.stopContainer(containerId, true); err != nil {
+			log.Error().Str("container_id", containerId).Err(err).Msg("failed to kill preempted container")
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package worker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreemptionNoticed(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   bool
+	}{
+		{"ec2 no notice", http.StatusNotFound, "", false},
+		{"ec2 notice", http.StatusOK, `{"action": "terminate", "time": "2026-10-16T08:22:00Z"}`, true},
+		{"gcp not preempted", http.StatusOK, "FALSE", false},
+		{"gcp preempted", http.StatusOK, "TRUE", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			assert.Equal(t, tt.want, preemptionNoticed(context.Background(), server.Client(), server.URL))
+		})
+	}
+
+	assert.False(t, preemptionNoticed(context.Background(), http.DefaultClient, "http://127.0.0.1:1"))
+}
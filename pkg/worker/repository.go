@@ -22,9 +22,8 @@ const (
 )
 
 // NewWorkerRepositoryClient creates a new worker repository client
-func NewWorkerRepositoryClient(ctx context.Context, config types.AppConfig, token string) (pb.WorkerRepositoryServiceClient, error) {
-	host := fmt.Sprintf("%s:%d", config.GatewayService.GRPC.ExternalHost, config.GatewayService.GRPC.ExternalPort)
-	conn, err := newGRPCConn(host, token)
+func NewWorkerRepositoryClient(ctx context.Context, config types.AppConfig, token string, internalTLS *tls.Config) (pb.WorkerRepositoryServiceClient, error) {
+	conn, err := newGatewayConn(config, token, internalTLS)
 	if err != nil {
 		return nil, err
 	}
@@ -33,9 +32,8 @@ func NewWorkerRepositoryClient(ctx context.Context, config types.AppConfig, toke
 }
 
 // NewContainerRepositoryClient creates a new container repository client
-func NewContainerRepositoryClient(ctx context.Context, config types.AppConfig, token string) (pb.ContainerRepositoryServiceClient, error) {
-	host := fmt.Sprintf("%s:%d", config.GatewayService.GRPC.ExternalHost, config.GatewayService.GRPC.ExternalPort)
-	conn, err := newGRPCConn(host, token)
+func NewContainerRepositoryClient(ctx context.Context, config types.AppConfig, token string, internalTLS *tls.Config) (pb.ContainerRepositoryServiceClient, error) {
+	conn, err := newGatewayConn(config, token, internalTLS)
 	if err != nil {
 		return nil, err
 	}
@@ -44,9 +42,8 @@ func NewContainerRepositoryClient(ctx context.Context, config types.AppConfig, t
 }
 
 // NewBackendRepositoryClient creates a new backend repository client
-func NewBackendRepositoryClient(ctx context.Context, config types.AppConfig, token string) (pb.BackendRepositoryServiceClient, error) {
-	host := fmt.Sprintf("%s:%d", config.GatewayService.GRPC.ExternalHost, config.GatewayService.GRPC.ExternalPort)
-	conn, err := newGRPCConn(host, token)
+func NewBackendRepositoryClient(ctx context.Context, config types.AppConfig, token string, internalTLS *tls.Config) (pb.BackendRepositoryServiceClient, error) {
+	conn, err := newGatewayConn(config, token, internalTLS)
 	if err != nil {
 		return nil, err
 	}
@@ -54,10 +51,24 @@ func NewBackendRepositoryClient(ctx context.Context, config types.AppConfig, tok
 	return pb.NewBackendRepositoryServiceClient(conn), nil
 }
 
+// newGatewayConn connects to the gateway's internal port with mutual TLS if internalTLS is set, otherwise to its
+// public port
+func newGatewayConn(config types.AppConfig, token string, internalTLS *tls.Config) (*grpc.ClientConn, error) {
+	if internalTLS != nil {
+		host := fmt.Sprintf("%s:%d", config.GatewayService.InternalTLS.ExternalHost, config.GatewayService.InternalTLS.ExternalPort)
+		return newGRPCConn(host, token, internalTLS)
+	}
+
+	host := fmt.Sprintf("%s:%d", config.GatewayService.GRPC.ExternalHost, config.GatewayService.GRPC.ExternalPort)
+	return newGRPCConn(host, token, nil)
+}
+
 // newGRPCConn creates a new gRPC connection (with or without TLS/Auth) to the provided host
-func newGRPCConn(host string, token string) (*grpc.ClientConn, error) {
+func newGRPCConn(host string, token string, tlsConfig *tls.Config) (*grpc.ClientConn, error) {
 	creds := insecure.NewCredentials()
-	if strings.HasSuffix(host, "443") {
+	if tlsConfig != nil {
+		creds = credentials.NewTLS(tlsConfig)
+	} else if strings.HasSuffix(host, "443") {
 		creds = credentials.NewTLS(&tls.Config{NextProtos: []string{"h2"}})
 	}
 
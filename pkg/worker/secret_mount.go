@@ -0,0 +1,77 @@
+package worker
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	types "github.com/beam-cloud/beta9/pkg/types"
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// Upper bound on the tmpfs holding a container's secret files
+	secretMountSizeLimit = "4m"
+	secretFileMode       = 0400
+)
+
+func secretMountDir(containerId string) string {
+	return filepath.Join(baseConfigPath, containerId, "secrets")
+}
+
+// mountSecretFiles writes a container's mounted secrets into a dedicated tmpfs, so they never touch disk, and
+// returns a read-only bind mount of it for the container's spec
+func (s *Worker) mountSecretFiles(request *types.ContainerRequest) (*specs.Mount, error) {
+	dir := secretMountDir(request.ContainerId)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	flags := uintptr(unix.MS_NOSUID | unix.MS_NODEV | unix.MS_NOEXEC)
+	if err := unix.Mount("tmpfs", dir, "tmpfs", flags, fmt.Sprintf("size=%s,mode=0700", secretMountSizeLimit)); err != nil {
+		os.Remove(dir)
+		return nil, fmt.Errorf("failed to mount secrets tmpfs: %w", err)
+	}
+
+	for name, value := range request.SecretMount.Files {
+		if err := os.WriteFile(filepath.Join(dir, filepath.Base(name)), []byte(value), secretFileMode); err != nil {
+			s.unmountSecretFiles(request.ContainerId)
+			return nil, fmt.Errorf("failed to write secret %s: %w", name, err)
+		}
+	}
+
+	return &specs.Mount{
+		Type:        "none",
+		Source:      dir,
+		Destination: request.SecretMount.Path,
+		Options:     []string{"rbind", "ro", "nosuid", "nodev", "noexec"},
+	}, nil
+}
+
+// unmountSecretFiles overwrites a container's secret files before tearing down their tmpfs
+func (s *Worker) unmountSecretFiles(containerId string) {
+	dir := secretMountDir(containerId)
+
+	entries, _ := os.ReadDir(dir)
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		os.Chmod(path, 0600)
+		os.WriteFile(path, make([]byte, info.Size()), 0600)
+	}
+
+	if err := unix.Unmount(dir, unix.MNT_DETACH); err != nil && err != unix.EINVAL {
+		log.Warn().Str("container_id", containerId).Err(err).Msg("failed to unmount secrets tmpfs")
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		log.Warn().Str("container_id", containerId).Err(err).Msg("failed to remove secrets dir")
+	}
+}
@@ -0,0 +1,259 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"syscall"
+	"time"
+
+	common "github.com/beam-cloud/beta9/pkg/common"
+	"github.com/beam-cloud/beta9/pkg/runtime"
+	types "github.com/beam-cloud/beta9/pkg/types"
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/rs/zerolog/log"
+)
+
+// How long sidecars get to exit after SIGTERM before they're killed
+const sidecarStopTimeout = 10 * time.Second
+
+func sidecarContainerId(containerId, name string) string {
+	return fmt.Sprintf("%s-sidecar-%s", containerId, name)
+}
+
+func sidecarSharedDir(containerId string) string {
+	return filepath.Join(baseConfigPath, containerId, "shared")
+}
+
+// sidecarRequest is the request a sidecar's image is pulled and its overlay is created with
+func sidecarRequest(request *types.ContainerRequest, sidecar types.Sidecar) *types.ContainerRequest {
+	sidecarRequest := *request
+	sidecarRequest.ContainerId = sidecarContainerId(request.ContainerId, sidecar.Name)
+	sidecarRequest.ImageId = sidecar.ImageId
+	sidecarRequest.BuildOptions = types.BuildOptions{}
+	return &sidecarRequest
+}
+
+func (s *Worker) pullSidecarImages(ctx context.Context, request *types.ContainerRequest, outputLogger *slog.Logger) error {
+	for _, sidecar := range request.Sidecars {
+		outputLogger.Info(fmt.Sprintf("Loading sidecar image <%s>...\n", sidecar.ImageId))
+
+		elapsed, err := s.imageClient.PullLazy(ctx, sidecarRequest(request, sidecar), outputLogger)
+		if err != nil {
+			return fmt.Errorf("failed to pull image for sidecar %s: %w", sidecar.Name, err)
+		}
+
+		outputLogger.Info(fmt.Sprintf("Loaded sidecar image <%s>, took: %s\n", sidecar.ImageId, elapsed))
+	}
+
+	return nil
+}
+
+type runningSidecar struct {
+	containerId string
+	done        chan struct{}
+	exitCode    int
+}
+
+// sidecarGroup runs a container's sidecars in its network namespace and stops them along with it
+type sidecarGroup struct {
+	worker  *Worker
+	request *types.ContainerRequest
+	spec    *specs.Spec
+	logger  *slog.Logger
+	mu      sync.Mutex
+	running []*runningSidecar
+}
+
+func (s *Worker) newSidecarGroup(request *types.ContainerRequest, spec *specs.Spec, outputLogger *slog.Logger) *sidecarGroup {
+	return &sidecarGroup{
+		worker:  s,
+		request: request,
+		spec:    spec,
+		logger:  outputLogger,
+	}
+}
+
+// start runs each sidecar with the given order. Init sidecars are waited on and must exit successfully.
+func (g *sidecarGroup) start(ctx context.Context, order types.SidecarOrder) error {
+	for _, sidecar := range g.request.Sidecars {
+		if sidecar.OrderOrDefault() != order {
+			continue
+		}
+
+		running, err := g.run(ctx, sidecar)
+		if err != nil {
+			return fmt.Errorf("failed to start sidecar %s: %w", sidecar.Name, err)
+		}
+
+		if order != types.SidecarOrderInit {
+			continue
+		}
+
+		select {
+		case <-running.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		if running.exitCode != 0 {
+			return fmt.Errorf("init sidecar %s exited with code %d", sidecar.Name, running.exitCode)
+		}
+	}
+
+	return nil
+}
+
+func (g *sidecarGroup) run(ctx context.Context, sidecar types.Sidecar) (*runningSidecar, error) {
+	request := sidecarRequest(g.request, sidecar)
+
+	overlay := g.worker.createOverlay(request, filepath.Join(g.worker.imageMountPath, sidecar.ImageId))
+	if err := overlay.Setup(); err != nil {
+		return nil, err
+	}
+
+	spec, err := g.sidecarSpec(request, sidecar, overlay.TopLayerPath())
+	if err != nil {
+		overlay.Cleanup()
+		return nil, err
+	}
+
+	rt := g.worker.runtime
+	if err := rt.Prepare(ctx, spec); err != nil {
+		overlay.Cleanup()
+		return nil, err
+	}
+
+	configContents, err := json.MarshalIndent(spec, "", " ")
+	if err != nil {
+		overlay.Cleanup()
+		return nil, err
+	}
+
+	configPath := filepath.Join(spec.Root.Path, specBaseName)
+	if err := os.WriteFile(configPath, configContents, 0644); err != nil {
+		overlay.Cleanup()
+		return nil, err
+	}
+
+	running := &runningSidecar{
+		containerId: request.ContainerId,
+		done:        make(chan struct{}),
+		exitCode:    -1,
+	}
+
+	outputWriter := common.NewOutputWriter(func(s string) {
+		g.logger.Info(fmt.Sprintf("[%s] %s", sidecar.Name, s), "done", false, "success", false)
+	})
+
+	startedChan := make(chan int, 1)
+	go func() {
+		defer close(running.done)
+		defer overlay.Cleanup()
+
+		exitCode, err := rt.Run(ctx, running.containerId, filepath.Dir(configPath), &runtime.RunOpts{
+			OutputWriter: outputWriter,
+			Started:      startedChan,
+		})
+		if err != nil {
+			log.Warn().Str("container_id", g.request.ContainerId).Str("sidecar", sidecar.Name).Err(err).Msg("sidecar exited with error")
+		}
+
+		running.exitCode = exitCode
+
+		if err := rt.Delete(context.Background(), running.containerId, &runtime.DeleteOpts{Force: true}); err != nil {
+			log.Debug().Str("container_id", g.request.ContainerId).Str("sidecar", sidecar.Name).Err(err).Msg("failed to delete sidecar")
+		}
+	}()
+
+	g.mu.Lock()
+	g.running = append(g.running, running)
+	g.mu.Unlock()
+
+	select {
+	case <-startedChan:
+	case <-running.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	log.Info().Str("container_id", g.request.ContainerId).Str("sidecar", sidecar.Name).Msg("sidecar started")
+	return running, nil
+}
+
+// sidecarSpec builds a sidecar's spec from its image, joining the main container's network namespace and
+// sharing the scratch directory and any volumes the sidecar asked for
+func (g *sidecarGroup) sidecarSpec(request *types.ContainerRequest, sidecar types.Sidecar, rootPath string) (*specs.Spec, error) {
+	spec, err := g.worker.newSpecTemplate()
+	if err != nil {
+		return nil, err
+	}
+
+	spec.Root.Path = rootPath
+	spec.Root.Readonly = false
+	spec.Process.Args = sidecar.EntryPoint
+	spec.Process.Terminal = false
+	spec.Process.Cwd = "/"
+
+	env := []string{"PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"}
+	if initialSpec, err := g.worker.readBundleConfig(request); err == nil && initialSpec != nil && initialSpec.Process != nil {
+		env = initialSpec.Process.Env
+		if initialSpec.Process.Cwd != "" {
+			spec.Process.Cwd = initialSpec.Process.Cwd
+		}
+	}
+
+	env = append(env, fmt.Sprintf("CONTAINER_ID=%s", g.request.ContainerId))
+	spec.Process.Env = append(env, sidecar.Env...)
+
+	for _, namespace := range g.spec.Linux.Namespaces {
+		if namespace.Type != specs.NetworkNamespace || namespace.Path == "" {
+			continue
+		}
+
+		spec.Linux.Namespaces = slices.DeleteFunc(spec.Linux.Namespaces, func(ns specs.LinuxNamespace) bool {
+			return ns.Type == specs.NetworkNamespace
+		})
+		spec.Linux.Namespaces = append(spec.Linux.Namespaces, namespace)
+	}
+
+	for _, m := range g.spec.Mounts {
+		if m.Destination == types.SidecarSharedPath || m.Destination == "/etc/resolv.conf" || slices.Contains(sidecar.Volumes, m.Destination) {
+			spec.Mounts = append(spec.Mounts, m)
+		}
+	}
+
+	return spec, nil
+}
+
+// stop stops the sidecars in the reverse of the order they started in
+func (g *sidecarGroup) stop() {
+	g.mu.Lock()
+	running := slices.Clone(g.running)
+	g.mu.Unlock()
+
+	rt := g.worker.runtime
+	for i := len(running) - 1; i >= 0; i-- {
+		sidecar := running[i]
+
+		select {
+		case <-sidecar.done:
+			continue
+		default:
+		}
+
+		rt.Kill(context.Background(), sidecar.containerId, syscall.SIGTERM, &runtime.KillOpts{All: true})
+
+		select {
+		case <-sidecar.done:
+		case <-time.After(sidecarStopTimeout):
+			rt.Kill(context.Background(), sidecar.containerId, syscall.SIGKILL, &runtime.KillOpts{All: true})
+			<-sidecar.done
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	types "github.com/beam-cloud/beta9/pkg/types"
+	pb "github.com/beam-cloud/beta9/proto"
+)
+
+// verifySharedVolumeMounts re-checks every volume shared into the container against the gateway, so a revoked
+// or expired share can't be mounted from a stale stub config. Shared mounts are always forced read-only and
+// pointed at the owner's volume path as reported by the gateway.
+func (s *Worker) verifySharedVolumeMounts(ctx context.Context, request *types.ContainerRequest) error {
+	for i, m := range request.Mounts {
+		if m.SharedVolumeId == "" {
+			continue
+		}
+
+		resp, err := handleGRPCResponse(s.backendRepoClient.GetSharedVolume(ctx, &pb.GetSharedVolumeRequest{
+			VolumeId:           m.SharedVolumeId,
+			GranteeWorkspaceId: request.Workspace.ExternalId,
+		}))
+		if err != nil {
+			return fmt.Errorf("access to shared volume %s denied: %w", m.SharedVolumeId, err)
+		}
+
+		request.Mounts[i].LocalPath = path.Join(types.DefaultVolumesPath, resp.OwnerWorkspaceName, m.SharedVolumeId)
+		request.Mounts[i].ReadOnly = true
+	}
+
+	return nil
+}
@@ -2,12 +2,14 @@ package worker
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"net/http"
 	_ "net/http/pprof" // Import for side effects
 	"os"
 	"os/signal"
+	goruntime "runtime"
 	"strconv"
 	"sync"
 	"syscall"
@@ -153,17 +155,28 @@ func NewWorker() (*Worker, error) {
 		return nil, err
 	}
 
-	containerRepoClient, err := NewContainerRepositoryClient(context.TODO(), config, workerToken)
+	var internalCert *common.RotatingCertificate
+	var internalTLS *tls.Config
+	if config.GatewayService.InternalTLS.Enabled {
+		internalCert, err = newInternalCertificate(ctx, config, workerId, workerToken)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		internalTLS = internalCert.ClientTLSConfig()
+	}
+
+	containerRepoClient, err := NewContainerRepositoryClient(context.TODO(), config, workerToken, internalTLS)
 	if err != nil {
 		return nil, err
 	}
 
-	workerRepoClient, err := NewWorkerRepositoryClient(context.TODO(), config, workerToken)
+	workerRepoClient, err := NewWorkerRepositoryClient(context.TODO(), config, workerToken, internalTLS)
 	if err != nil {
 		return nil, err
 	}
 
-	backendRepoClient, err := NewBackendRepositoryClient(context.TODO(), config, workerToken)
+	backendRepoClient, err := NewBackendRepositoryClient(context.TODO(), config, workerToken, internalTLS)
 	if err != nil {
 		return nil, err
 	}
@@ -284,6 +297,15 @@ func NewWorker() (*Worker, error) {
 		return nil, err
 	}
 
+	var objectCache *ObjectCache = nil
+	if config.Worker.ObjectCache.Enabled {
+		objectCache, err = NewObjectCache(ctx, workerId, config.Worker.ObjectCache, workerRepoClient)
+		if err != nil {
+			log.Warn().Str("worker_id", workerId).Msgf("object cache unavailable: %v", err)
+			objectCache = nil
+		}
+	}
+
 	worker := &Worker{
 		ctx:                     ctx,
 		workerId:                workerId,
@@ -304,7 +326,7 @@ func NewWorker() (*Worker, error) {
 		fileCacheManager:        fileCacheManager,
 		containerGPUManager:     NewContainerNvidiaManager(uint32(gpuCount)),
 		containerNetworkManager: containerNetworkManager,
-		containerMountManager:   NewContainerMountManager(config),
+		containerMountManager:   NewContainerMountManager(config, objectCache),
 		redisClient:             redisClient,
 		podAddr:                 podAddr,
 		imageClient:             imageClient,
@@ -336,6 +358,8 @@ func NewWorker() (*Worker, error) {
 		ContainerRepoClient:     containerRepoClient,
 		ContainerNetworkManager: containerNetworkManager,
 		CreateCheckpoint:        worker.createCheckpoint,
+		CheckHealth:             worker.checkHealth,
+		InternalCert:            internalCert,
 	})
 	if err != nil {
 		cancel()
@@ -370,7 +394,12 @@ func (s *Worker) Run() error {
 	go s.manageWorkerCapacity()
 	go s.processStopContainerEvents()
 
+	if s.poolConfig.Spot {
+		go s.watchForPreemption()
+	}
+
 	lastContainerRequest := time.Now()
+	ranContainer := false
 
 	// Listen for container requests
 containerRequestStream:
@@ -395,11 +424,12 @@ containerRequestStream:
 
 			if response.ContainerRequest != nil {
 				lastContainerRequest = time.Now()
+				ranContainer = true
 				request := types.NewContainerRequestFromProto(response.ContainerRequest)
 				s.handleContainerRequest(request)
 			}
 
-			if exit := s.shouldShutDown(lastContainerRequest); exit {
+			if exit := s.shouldShutDown(lastContainerRequest, ranContainer); exit {
 				break containerRequestStream
 			}
 		}
@@ -412,6 +442,9 @@ containerRequestStream:
 func (s *Worker) handleContainerRequest(request *types.ContainerRequest) {
 	containerId := request.ContainerId
 
+	// Images have an archive for each architecture they're built for, so we pull, and build, ours
+	request.ImageId = types.ImageIdForArch(request.ImageId, goruntime.GOARCH)
+
 	s.containerLock.Lock()
 	_, exists := s.containerInstances.Get(containerId)
 	if !exists {
@@ -500,13 +533,19 @@ func (s *Worker) listenForShutdown() {
 }
 
 // Exit if there are no containers running and no containers have recently been spun up on this
-// worker, or if a shutdown signal has been received.
-func (s *Worker) shouldShutDown(lastContainerRequest time.Time) bool {
+// worker, or if a shutdown signal has been received. Workers in kubernetes pools exit as soon as the
+// one container they were created for is gone.
+func (s *Worker) shouldShutDown(lastContainerRequest time.Time, ranContainer bool) bool {
 	select {
 	case <-s.ctx.Done():
 		return true
 	default:
-		if (time.Since(lastContainerRequest).Seconds() > defaultWorkerSpindownTimeS) && s.containerInstances.Len() == 0 {
+		idle := time.Since(lastContainerRequest).Seconds() > defaultWorkerSpindownTimeS
+		if s.poolConfig.Mode == types.PoolModeKubernetes && ranContainer {
+			idle = true
+		}
+
+		if idle && s.containerInstances.Len() == 0 {
 			err := s.storageManager.Cleanup()
 			if err != nil {
 				log.Error().Err(err).Msg("failed to cleanup workspace storage")
@@ -625,6 +664,12 @@ func (s *Worker) manageWorkerCapacity() {
 }
 
 func (s *Worker) processCompletedRequest(request *types.ContainerRequest) error {
+	// Workers in kubernetes pools exit with their container, so its capacity isn't given back for another
+	// container to be scheduled on
+	if s.poolConfig.Mode == types.PoolModeKubernetes {
+		return nil
+	}
+
 	_, err := handleGRPCResponse(s.workerRepoClient.UpdateWorkerCapacity(context.Background(), &pb.UpdateWorkerCapacityRequest{
 		WorkerId:         s.workerId,
 		CapacityChange:   int64(types.AddCapacity),
@@ -686,7 +731,47 @@ func (s *Worker) profile() {
 func (s *Worker) startup() error {
 	log.Info().Msg("worker starting up")
 
-	_, err := handleGRPCResponse(s.workerRepoClient.ToggleWorkerAvailable(s.ctx, &pb.ToggleWorkerAvailableRequest{
+	// Let the scheduler know whether our GPUs are connected over NVLink before any container gets placed here
+	if interconnect := s.containerGPUManager.GetGPUInterconnect(); interconnect != "" {
+		_, err := handleGRPCResponse(s.workerRepoClient.SetWorkerGpuInterconnect(s.ctx, &pb.SetWorkerGpuInterconnectRequest{
+			WorkerId:        s.workerId,
+			GpuInterconnect: interconnect,
+		}))
+		if err != nil {
+			log.Warn().Err(err).Msg("failed to set worker gpu interconnect")
+		}
+	}
+
+	// Containers that request a MIG slice get one of the slices our GPUs are partitioned into
+	if migSlices := s.containerGPUManager.GetMigSlices(); migSlices != "" {
+		_, err := handleGRPCResponse(s.workerRepoClient.SetWorkerMigSlices(s.ctx, &pb.SetWorkerMigSlicesRequest{
+			WorkerId:  s.workerId,
+			MigSlices: string(migSlices),
+		}))
+		if err != nil {
+			log.Warn().Err(err).Msg("failed to set worker mig slices")
+		}
+	}
+
+	// The scheduler only places containers with images built for our architecture here
+	_, err := handleGRPCResponse(s.workerRepoClient.SetWorkerArch(s.ctx, &pb.SetWorkerArchRequest{
+		WorkerId: s.workerId,
+		Arch:     goruntime.GOARCH,
+	}))
+	if err != nil {
+		log.Warn().Err(err).Msg("failed to set worker arch")
+	}
+
+	// The scheduler's health checks connect to our container server
+	_, err = handleGRPCResponse(s.workerRepoClient.SetWorkerServerAddress(s.ctx, &pb.SetWorkerServerAddressRequest{
+		WorkerId: s.workerId,
+		Address:  fmt.Sprintf("%s:%d", s.podAddr, s.containerServer.port),
+	}))
+	if err != nil {
+		log.Warn().Err(err).Msg("failed to set worker server address")
+	}
+
+	_, err = handleGRPCResponse(s.workerRepoClient.ToggleWorkerAvailable(s.ctx, &pb.ToggleWorkerAvailableRequest{
 		WorkerId: s.workerId,
 	}))
 	if err != nil {
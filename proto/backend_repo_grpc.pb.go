@@ -24,6 +24,7 @@ const (
 	BackendRepositoryService_ListCheckpoints_FullMethodName             = "/BackendRepositoryService/ListCheckpoints"
 	BackendRepositoryService_CreateCheckpoint_FullMethodName            = "/BackendRepositoryService/CreateCheckpoint"
 	BackendRepositoryService_UpdateCheckpoint_FullMethodName            = "/BackendRepositoryService/UpdateCheckpoint"
+	BackendRepositoryService_GetSharedVolume_FullMethodName             = "/BackendRepositoryService/GetSharedVolume"
 )
 
 // BackendRepositoryServiceClient is the client API for BackendRepositoryService service.
@@ -35,6 +36,7 @@ type BackendRepositoryServiceClient interface {
 	ListCheckpoints(ctx context.Context, in *ListCheckpointsRequest, opts ...grpc.CallOption) (*ListCheckpointsResponse, error)
 	CreateCheckpoint(ctx context.Context, in *CreateCheckpointRequest, opts ...grpc.CallOption) (*CreateCheckpointResponse, error)
 	UpdateCheckpoint(ctx context.Context, in *UpdateCheckpointRequest, opts ...grpc.CallOption) (*UpdateCheckpointResponse, error)
+	GetSharedVolume(ctx context.Context, in *GetSharedVolumeRequest, opts ...grpc.CallOption) (*GetSharedVolumeResponse, error)
 }
 
 type backendRepositoryServiceClient struct {
@@ -90,6 +92,15 @@ func (c *backendRepositoryServiceClient) UpdateCheckpoint(ctx context.Context, i
 	return out, nil
 }
 
+func (c *backendRepositoryServiceClient) GetSharedVolume(ctx context.Context, in *GetSharedVolumeRequest, opts ...grpc.CallOption) (*GetSharedVolumeResponse, error) {
+	out := new(GetSharedVolumeResponse)
+	err := c.cc.Invoke(ctx, BackendRepositoryService_GetSharedVolume_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BackendRepositoryServiceServer is the server API for BackendRepositoryService service.
 // All implementations must embed UnimplementedBackendRepositoryServiceServer
 // for forward compatibility
@@ -99,6 +110,7 @@ type BackendRepositoryServiceServer interface {
 	ListCheckpoints(context.Context, *ListCheckpointsRequest) (*ListCheckpointsResponse, error)
 	CreateCheckpoint(context.Context, *CreateCheckpointRequest) (*CreateCheckpointResponse, error)
 	UpdateCheckpoint(context.Context, *UpdateCheckpointRequest) (*UpdateCheckpointResponse, error)
+	GetSharedVolume(context.Context, *GetSharedVolumeRequest) (*GetSharedVolumeResponse, error)
 	mustEmbedUnimplementedBackendRepositoryServiceServer()
 }
 
@@ -121,6 +133,9 @@ func (UnimplementedBackendRepositoryServiceServer) CreateCheckpoint(context.Cont
 func (UnimplementedBackendRepositoryServiceServer) UpdateCheckpoint(context.Context, *UpdateCheckpointRequest) (*UpdateCheckpointResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateCheckpoint not implemented")
 }
+func (UnimplementedBackendRepositoryServiceServer) GetSharedVolume(context.Context, *GetSharedVolumeRequest) (*GetSharedVolumeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSharedVolume not implemented")
+}
 func (UnimplementedBackendRepositoryServiceServer) mustEmbedUnimplementedBackendRepositoryServiceServer() {
 }
 
@@ -225,6 +240,24 @@ func _BackendRepositoryService_UpdateCheckpoint_Handler(srv interface{}, ctx con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BackendRepositoryService_GetSharedVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSharedVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackendRepositoryServiceServer).GetSharedVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BackendRepositoryService_GetSharedVolume_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackendRepositoryServiceServer).GetSharedVolume(ctx, req.(*GetSharedVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BackendRepositoryService_ServiceDesc is the grpc.ServiceDesc for BackendRepositoryService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -252,6 +285,10 @@ var BackendRepositoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateCheckpoint",
 			Handler:    _BackendRepositoryService_UpdateCheckpoint_Handler,
 		},
+		{
+			MethodName: "GetSharedVolume",
+			Handler:    _BackendRepositoryService_GetSharedVolume_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "backend_repo.proto",
@@ -688,6 +688,124 @@ func (x *SetContainerAddressMapResponse) GetErrorMsg() string {
 	return ""
 }
 
+type SetContainerGpuTopologyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ContainerId     string `protobuf:"bytes,1,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	GpuInterconnect string `protobuf:"bytes,2,opt,name=gpu_interconnect,json=gpuInterconnect,proto3" json:"gpu_interconnect,omitempty"`
+	NumaNodes       string `protobuf:"bytes,3,opt,name=numa_nodes,json=numaNodes,proto3" json:"numa_nodes,omitempty"`
+}
+
+func (x *SetContainerGpuTopologyRequest) Reset() {
+	*x = SetContainerGpuTopologyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_container_repo_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetContainerGpuTopologyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetContainerGpuTopologyRequest) ProtoMessage() {}
+
+func (x *SetContainerGpuTopologyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_container_repo_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetContainerGpuTopologyRequest.ProtoReflect.Descriptor instead.
+func (*SetContainerGpuTopologyRequest) Descriptor() ([]byte, []int) {
+	return file_container_repo_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SetContainerGpuTopologyRequest) GetContainerId() string {
+	if x != nil {
+		return x.ContainerId
+	}
+	return ""
+}
+
+func (x *SetContainerGpuTopologyRequest) GetGpuInterconnect() string {
+	if x != nil {
+		return x.GpuInterconnect
+	}
+	return ""
+}
+
+func (x *SetContainerGpuTopologyRequest) GetNumaNodes() string {
+	if x != nil {
+		return x.NumaNodes
+	}
+	return ""
+}
+
+type SetContainerGpuTopologyResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok       bool   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	ErrorMsg string `protobuf:"bytes,2,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
+}
+
+func (x *SetContainerGpuTopologyResponse) Reset() {
+	*x = SetContainerGpuTopologyResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_container_repo_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetContainerGpuTopologyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetContainerGpuTopologyResponse) ProtoMessage() {}
+
+func (x *SetContainerGpuTopologyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_container_repo_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetContainerGpuTopologyResponse.ProtoReflect.Descriptor instead.
+func (*SetContainerGpuTopologyResponse) Descriptor() ([]byte, []int) {
+	return file_container_repo_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SetContainerGpuTopologyResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *SetContainerGpuTopologyResponse) GetErrorMsg() string {
+	if x != nil {
+		return x.ErrorMsg
+	}
+	return ""
+}
+
 type GetContainerAddressMapRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -699,7 +817,7 @@ type GetContainerAddressMapRequest struct {
 func (x *GetContainerAddressMapRequest) Reset() {
 	*x = GetContainerAddressMapRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_container_repo_proto_msgTypes[12]
+		mi := &file_container_repo_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -712,7 +830,7 @@ func (x *GetContainerAddressMapRequest) String() string {
 func (*GetContainerAddressMapRequest) ProtoMessage() {}
 
 func (x *GetContainerAddressMapRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_container_repo_proto_msgTypes[12]
+	mi := &file_container_repo_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -725,7 +843,7 @@ func (x *GetContainerAddressMapRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetContainerAddressMapRequest.ProtoReflect.Descriptor instead.
 func (*GetContainerAddressMapRequest) Descriptor() ([]byte, []int) {
-	return file_container_repo_proto_rawDescGZIP(), []int{12}
+	return file_container_repo_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *GetContainerAddressMapRequest) GetContainerId() string {
@@ -748,7 +866,7 @@ type GetContainerAddressMapResponse struct {
 func (x *GetContainerAddressMapResponse) Reset() {
 	*x = GetContainerAddressMapResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_container_repo_proto_msgTypes[13]
+		mi := &file_container_repo_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -761,7 +879,7 @@ func (x *GetContainerAddressMapResponse) String() string {
 func (*GetContainerAddressMapResponse) ProtoMessage() {}
 
 func (x *GetContainerAddressMapResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_container_repo_proto_msgTypes[13]
+	mi := &file_container_repo_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -774,7 +892,7 @@ func (x *GetContainerAddressMapResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetContainerAddressMapResponse.ProtoReflect.Descriptor instead.
 func (*GetContainerAddressMapResponse) Descriptor() ([]byte, []int) {
-	return file_container_repo_proto_rawDescGZIP(), []int{13}
+	return file_container_repo_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *GetContainerAddressMapResponse) GetOk() bool {
@@ -810,7 +928,7 @@ type SetWorkerAddressRequest struct {
 func (x *SetWorkerAddressRequest) Reset() {
 	*x = SetWorkerAddressRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_container_repo_proto_msgTypes[14]
+		mi := &file_container_repo_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -823,7 +941,7 @@ func (x *SetWorkerAddressRequest) String() string {
 func (*SetWorkerAddressRequest) ProtoMessage() {}
 
 func (x *SetWorkerAddressRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_container_repo_proto_msgTypes[14]
+	mi := &file_container_repo_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -836,7 +954,7 @@ func (x *SetWorkerAddressRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetWorkerAddressRequest.ProtoReflect.Descriptor instead.
 func (*SetWorkerAddressRequest) Descriptor() ([]byte, []int) {
-	return file_container_repo_proto_rawDescGZIP(), []int{14}
+	return file_container_repo_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *SetWorkerAddressRequest) GetContainerId() string {
@@ -865,7 +983,7 @@ type SetWorkerAddressResponse struct {
 func (x *SetWorkerAddressResponse) Reset() {
 	*x = SetWorkerAddressResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_container_repo_proto_msgTypes[15]
+		mi := &file_container_repo_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -878,7 +996,7 @@ func (x *SetWorkerAddressResponse) String() string {
 func (*SetWorkerAddressResponse) ProtoMessage() {}
 
 func (x *SetWorkerAddressResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_container_repo_proto_msgTypes[15]
+	mi := &file_container_repo_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -891,7 +1009,7 @@ func (x *SetWorkerAddressResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetWorkerAddressResponse.ProtoReflect.Descriptor instead.
 func (*SetWorkerAddressResponse) Descriptor() ([]byte, []int) {
-	return file_container_repo_proto_rawDescGZIP(), []int{15}
+	return file_container_repo_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *SetWorkerAddressResponse) GetOk() bool {
@@ -988,6 +1106,20 @@ var file_container_repo_proto_rawDesc = []byte{
 	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72,
 	0x5f, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x4d, 0x73, 0x67, 0x22, 0x8d, 0x01, 0x0a, 0x1e, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74,
+	0x61, 0x69, 0x6e, 0x65, 0x72, 0x47, 0x70, 0x75, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63,
+	0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x67, 0x70,
+	0x75, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x67, 0x70, 0x75, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x63, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x75, 0x6d, 0x61, 0x5f, 0x6e, 0x6f,
+	0x64, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x75, 0x6d, 0x61, 0x4e,
+	0x6f, 0x64, 0x65, 0x73, 0x22, 0x4e, 0x0a, 0x1f, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x72, 0x47, 0x70, 0x75, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x5f, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x72, 0x72, 0x6f,
 	0x72, 0x4d, 0x73, 0x67, 0x22, 0x42, 0x0a, 0x1d, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61,
 	0x69, 0x6e, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x4d, 0x61, 0x70, 0x52, 0x65,
 	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
@@ -1016,7 +1148,7 @@ var file_container_repo_proto_rawDesc = []byte{
 	0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a,
 	0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x1b, 0x0a,
 	0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x08, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x73, 0x67, 0x32, 0xbb, 0x05, 0x0a, 0x1a, 0x43,
+	0x52, 0x08, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x73, 0x67, 0x32, 0x99, 0x06, 0x0a, 0x1a, 0x43,
 	0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f,
 	0x72, 0x79, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4a, 0x0a, 0x11, 0x47, 0x65, 0x74,
 	0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x19,
@@ -1050,20 +1182,26 @@ var file_container_repo_proto_rawDesc = []byte{
 	0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x4d, 0x61, 0x70, 0x52, 0x65, 0x71, 0x75,
 	0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
 	0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x4d, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x59, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61,
-	0x69, 0x6e, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x4d, 0x61, 0x70, 0x12, 0x1e,
-	0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x41, 0x64, 0x64,
-	0x72, 0x65, 0x73, 0x73, 0x4d, 0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f,
-	0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x41, 0x64, 0x64,
-	0x72, 0x65, 0x73, 0x73, 0x4d, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x47, 0x0a, 0x10, 0x53, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72,
-	0x65, 0x73, 0x73, 0x12, 0x18, 0x2e, 0x53, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x41,
-	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e,
-	0x53, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x23, 0x5a, 0x21, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x65, 0x61, 0x6d, 0x2d, 0x63, 0x6c, 0x6f, 0x75,
-	0x64, 0x2f, 0x62, 0x65, 0x74, 0x61, 0x39, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5c, 0x0a, 0x17, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x72, 0x47, 0x70, 0x75, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x12,
+	0x1f, 0x2e, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x47, 0x70,
+	0x75, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x20, 0x2e, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x47,
+	0x70, 0x75, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x59, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
+	0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x4d, 0x61, 0x70, 0x12, 0x1e, 0x2e, 0x47,
+	0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x4d, 0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x47,
+	0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x4d, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a,
+	0x10, 0x53, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x12, 0x18, 0x2e, 0x53, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x41, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x53, 0x65,
+	0x74, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x23, 0x5a, 0x21, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x65, 0x61, 0x6d, 0x2d, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2f,
+	0x62, 0x65, 0x74, 0x61, 0x39, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1078,50 +1216,54 @@ func file_container_repo_proto_rawDescGZIP() []byte {
 	return file_container_repo_proto_rawDescData
 }
 
-var file_container_repo_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_container_repo_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_container_repo_proto_goTypes = []interface{}{
-	(*GetContainerStateRequest)(nil),       // 0: GetContainerStateRequest
-	(*GetContainerStateResponse)(nil),      // 1: GetContainerStateResponse
-	(*DeleteContainerStateRequest)(nil),    // 2: DeleteContainerStateRequest
-	(*DeleteContainerStateResponse)(nil),   // 3: DeleteContainerStateResponse
-	(*UpdateContainerStatusRequest)(nil),   // 4: UpdateContainerStatusRequest
-	(*UpdateContainerStatusResponse)(nil),  // 5: UpdateContainerStatusResponse
-	(*SetContainerExitCodeRequest)(nil),    // 6: SetContainerExitCodeRequest
-	(*SetContainerExitCodeResponse)(nil),   // 7: SetContainerExitCodeResponse
-	(*SetContainerAddressRequest)(nil),     // 8: SetContainerAddressRequest
-	(*SetContainerAddressResponse)(nil),    // 9: SetContainerAddressResponse
-	(*SetContainerAddressMapRequest)(nil),  // 10: SetContainerAddressMapRequest
-	(*SetContainerAddressMapResponse)(nil), // 11: SetContainerAddressMapResponse
-	(*GetContainerAddressMapRequest)(nil),  // 12: GetContainerAddressMapRequest
-	(*GetContainerAddressMapResponse)(nil), // 13: GetContainerAddressMapResponse
-	(*SetWorkerAddressRequest)(nil),        // 14: SetWorkerAddressRequest
-	(*SetWorkerAddressResponse)(nil),       // 15: SetWorkerAddressResponse
-	nil,                                    // 16: SetContainerAddressMapRequest.AddressMapEntry
-	nil,                                    // 17: GetContainerAddressMapResponse.AddressMapEntry
-	(*ContainerState)(nil),                 // 18: types.ContainerState
+	(*GetContainerStateRequest)(nil),        // 0: GetContainerStateRequest
+	(*GetContainerStateResponse)(nil),       // 1: GetContainerStateResponse
+	(*DeleteContainerStateRequest)(nil),     // 2: DeleteContainerStateRequest
+	(*DeleteContainerStateResponse)(nil),    // 3: DeleteContainerStateResponse
+	(*UpdateContainerStatusRequest)(nil),    // 4: UpdateContainerStatusRequest
+	(*UpdateContainerStatusResponse)(nil),   // 5: UpdateContainerStatusResponse
+	(*SetContainerExitCodeRequest)(nil),     // 6: SetContainerExitCodeRequest
+	(*SetContainerExitCodeResponse)(nil),    // 7: SetContainerExitCodeResponse
+	(*SetContainerAddressRequest)(nil),      // 8: SetContainerAddressRequest
+	(*SetContainerAddressResponse)(nil),     // 9: SetContainerAddressResponse
+	(*SetContainerAddressMapRequest)(nil),   // 10: SetContainerAddressMapRequest
+	(*SetContainerAddressMapResponse)(nil),  // 11: SetContainerAddressMapResponse
+	(*SetContainerGpuTopologyRequest)(nil),  // 12: SetContainerGpuTopologyRequest
+	(*SetContainerGpuTopologyResponse)(nil), // 13: SetContainerGpuTopologyResponse
+	(*GetContainerAddressMapRequest)(nil),   // 14: GetContainerAddressMapRequest
+	(*GetContainerAddressMapResponse)(nil),  // 15: GetContainerAddressMapResponse
+	(*SetWorkerAddressRequest)(nil),         // 16: SetWorkerAddressRequest
+	(*SetWorkerAddressResponse)(nil),        // 17: SetWorkerAddressResponse
+	nil,                                     // 18: SetContainerAddressMapRequest.AddressMapEntry
+	nil,                                     // 19: GetContainerAddressMapResponse.AddressMapEntry
+	(*ContainerState)(nil),                  // 20: types.ContainerState
 }
 var file_container_repo_proto_depIdxs = []int32{
-	18, // 0: GetContainerStateResponse.state:type_name -> types.ContainerState
-	16, // 1: SetContainerAddressMapRequest.address_map:type_name -> SetContainerAddressMapRequest.AddressMapEntry
-	17, // 2: GetContainerAddressMapResponse.address_map:type_name -> GetContainerAddressMapResponse.AddressMapEntry
+	20, // 0: GetContainerStateResponse.state:type_name -> types.ContainerState
+	18, // 1: SetContainerAddressMapRequest.address_map:type_name -> SetContainerAddressMapRequest.AddressMapEntry
+	19, // 2: GetContainerAddressMapResponse.address_map:type_name -> GetContainerAddressMapResponse.AddressMapEntry
 	0,  // 3: ContainerRepositoryService.GetContainerState:input_type -> GetContainerStateRequest
 	2,  // 4: ContainerRepositoryService.DeleteContainerState:input_type -> DeleteContainerStateRequest
 	4,  // 5: ContainerRepositoryService.UpdateContainerStatus:input_type -> UpdateContainerStatusRequest
 	6,  // 6: ContainerRepositoryService.SetContainerExitCode:input_type -> SetContainerExitCodeRequest
 	8,  // 7: ContainerRepositoryService.SetContainerAddress:input_type -> SetContainerAddressRequest
 	10, // 8: ContainerRepositoryService.SetContainerAddressMap:input_type -> SetContainerAddressMapRequest
-	12, // 9: ContainerRepositoryService.GetContainerAddressMap:input_type -> GetContainerAddressMapRequest
-	14, // 10: ContainerRepositoryService.SetWorkerAddress:input_type -> SetWorkerAddressRequest
-	1,  // 11: ContainerRepositoryService.GetContainerState:output_type -> GetContainerStateResponse
-	3,  // 12: ContainerRepositoryService.DeleteContainerState:output_type -> DeleteContainerStateResponse
-	5,  // 13: ContainerRepositoryService.UpdateContainerStatus:output_type -> UpdateContainerStatusResponse
-	7,  // 14: ContainerRepositoryService.SetContainerExitCode:output_type -> SetContainerExitCodeResponse
-	9,  // 15: ContainerRepositoryService.SetContainerAddress:output_type -> SetContainerAddressResponse
-	11, // 16: ContainerRepositoryService.SetContainerAddressMap:output_type -> SetContainerAddressMapResponse
-	13, // 17: ContainerRepositoryService.GetContainerAddressMap:output_type -> GetContainerAddressMapResponse
-	15, // 18: ContainerRepositoryService.SetWorkerAddress:output_type -> SetWorkerAddressResponse
-	11, // [11:19] is the sub-list for method output_type
-	3,  // [3:11] is the sub-list for method input_type
+	12, // 9: ContainerRepositoryService.SetContainerGpuTopology:input_type -> SetContainerGpuTopologyRequest
+	14, // 10: ContainerRepositoryService.GetContainerAddressMap:input_type -> GetContainerAddressMapRequest
+	16, // 11: ContainerRepositoryService.SetWorkerAddress:input_type -> SetWorkerAddressRequest
+	1,  // 12: ContainerRepositoryService.GetContainerState:output_type -> GetContainerStateResponse
+	3,  // 13: ContainerRepositoryService.DeleteContainerState:output_type -> DeleteContainerStateResponse
+	5,  // 14: ContainerRepositoryService.UpdateContainerStatus:output_type -> UpdateContainerStatusResponse
+	7,  // 15: ContainerRepositoryService.SetContainerExitCode:output_type -> SetContainerExitCodeResponse
+	9,  // 16: ContainerRepositoryService.SetContainerAddress:output_type -> SetContainerAddressResponse
+	11, // 17: ContainerRepositoryService.SetContainerAddressMap:output_type -> SetContainerAddressMapResponse
+	13, // 18: ContainerRepositoryService.SetContainerGpuTopology:output_type -> SetContainerGpuTopologyResponse
+	15, // 19: ContainerRepositoryService.GetContainerAddressMap:output_type -> GetContainerAddressMapResponse
+	17, // 20: ContainerRepositoryService.SetWorkerAddress:output_type -> SetWorkerAddressResponse
+	12, // [12:21] is the sub-list for method output_type
+	3,  // [3:12] is the sub-list for method input_type
 	3,  // [3:3] is the sub-list for extension type_name
 	3,  // [3:3] is the sub-list for extension extendee
 	0,  // [0:3] is the sub-list for field type_name
@@ -1279,7 +1421,7 @@ func file_container_repo_proto_init() {
 			}
 		}
 		file_container_repo_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetContainerAddressMapRequest); i {
+			switch v := v.(*SetContainerGpuTopologyRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1291,7 +1433,7 @@ func file_container_repo_proto_init() {
 			}
 		}
 		file_container_repo_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetContainerAddressMapResponse); i {
+			switch v := v.(*SetContainerGpuTopologyResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1303,7 +1445,7 @@ func file_container_repo_proto_init() {
 			}
 		}
 		file_container_repo_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetWorkerAddressRequest); i {
+			switch v := v.(*GetContainerAddressMapRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1315,6 +1457,30 @@ func file_container_repo_proto_init() {
 			}
 		}
 		file_container_repo_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetContainerAddressMapResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_container_repo_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetWorkerAddressRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_container_repo_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SetWorkerAddressResponse); i {
 			case 0:
 				return &v.state
@@ -1333,7 +1499,7 @@ func file_container_repo_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_container_repo_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   18,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
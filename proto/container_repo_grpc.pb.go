@@ -19,14 +19,15 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	ContainerRepositoryService_GetContainerState_FullMethodName      = "/ContainerRepositoryService/GetContainerState"
-	ContainerRepositoryService_DeleteContainerState_FullMethodName   = "/ContainerRepositoryService/DeleteContainerState"
-	ContainerRepositoryService_UpdateContainerStatus_FullMethodName  = "/ContainerRepositoryService/UpdateContainerStatus"
-	ContainerRepositoryService_SetContainerExitCode_FullMethodName   = "/ContainerRepositoryService/SetContainerExitCode"
-	ContainerRepositoryService_SetContainerAddress_FullMethodName    = "/ContainerRepositoryService/SetContainerAddress"
-	ContainerRepositoryService_SetContainerAddressMap_FullMethodName = "/ContainerRepositoryService/SetContainerAddressMap"
-	ContainerRepositoryService_GetContainerAddressMap_FullMethodName = "/ContainerRepositoryService/GetContainerAddressMap"
-	ContainerRepositoryService_SetWorkerAddress_FullMethodName       = "/ContainerRepositoryService/SetWorkerAddress"
+	ContainerRepositoryService_GetContainerState_FullMethodName       = "/ContainerRepositoryService/GetContainerState"
+	ContainerRepositoryService_DeleteContainerState_FullMethodName    = "/ContainerRepositoryService/DeleteContainerState"
+	ContainerRepositoryService_UpdateContainerStatus_FullMethodName   = "/ContainerRepositoryService/UpdateContainerStatus"
+	ContainerRepositoryService_SetContainerExitCode_FullMethodName    = "/ContainerRepositoryService/SetContainerExitCode"
+	ContainerRepositoryService_SetContainerAddress_FullMethodName     = "/ContainerRepositoryService/SetContainerAddress"
+	ContainerRepositoryService_SetContainerAddressMap_FullMethodName  = "/ContainerRepositoryService/SetContainerAddressMap"
+	ContainerRepositoryService_SetContainerGpuTopology_FullMethodName = "/ContainerRepositoryService/SetContainerGpuTopology"
+	ContainerRepositoryService_GetContainerAddressMap_FullMethodName  = "/ContainerRepositoryService/GetContainerAddressMap"
+	ContainerRepositoryService_SetWorkerAddress_FullMethodName        = "/ContainerRepositoryService/SetWorkerAddress"
 )
 
 // ContainerRepositoryServiceClient is the client API for ContainerRepositoryService service.
@@ -39,6 +40,7 @@ type ContainerRepositoryServiceClient interface {
 	SetContainerExitCode(ctx context.Context, in *SetContainerExitCodeRequest, opts ...grpc.CallOption) (*SetContainerExitCodeResponse, error)
 	SetContainerAddress(ctx context.Context, in *SetContainerAddressRequest, opts ...grpc.CallOption) (*SetContainerAddressResponse, error)
 	SetContainerAddressMap(ctx context.Context, in *SetContainerAddressMapRequest, opts ...grpc.CallOption) (*SetContainerAddressMapResponse, error)
+	SetContainerGpuTopology(ctx context.Context, in *SetContainerGpuTopologyRequest, opts ...grpc.CallOption) (*SetContainerGpuTopologyResponse, error)
 	GetContainerAddressMap(ctx context.Context, in *GetContainerAddressMapRequest, opts ...grpc.CallOption) (*GetContainerAddressMapResponse, error)
 	SetWorkerAddress(ctx context.Context, in *SetWorkerAddressRequest, opts ...grpc.CallOption) (*SetWorkerAddressResponse, error)
 }
@@ -105,6 +107,15 @@ func (c *containerRepositoryServiceClient) SetContainerAddressMap(ctx context.Co
 	return out, nil
 }
 
+func (c *containerRepositoryServiceClient) SetContainerGpuTopology(ctx context.Context, in *SetContainerGpuTopologyRequest, opts ...grpc.CallOption) (*SetContainerGpuTopologyResponse, error) {
+	out := new(SetContainerGpuTopologyResponse)
+	err := c.cc.Invoke(ctx, ContainerRepositoryService_SetContainerGpuTopology_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *containerRepositoryServiceClient) GetContainerAddressMap(ctx context.Context, in *GetContainerAddressMapRequest, opts ...grpc.CallOption) (*GetContainerAddressMapResponse, error) {
 	out := new(GetContainerAddressMapResponse)
 	err := c.cc.Invoke(ctx, ContainerRepositoryService_GetContainerAddressMap_FullMethodName, in, out, opts...)
@@ -133,6 +144,7 @@ type ContainerRepositoryServiceServer interface {
 	SetContainerExitCode(context.Context, *SetContainerExitCodeRequest) (*SetContainerExitCodeResponse, error)
 	SetContainerAddress(context.Context, *SetContainerAddressRequest) (*SetContainerAddressResponse, error)
 	SetContainerAddressMap(context.Context, *SetContainerAddressMapRequest) (*SetContainerAddressMapResponse, error)
+	SetContainerGpuTopology(context.Context, *SetContainerGpuTopologyRequest) (*SetContainerGpuTopologyResponse, error)
 	GetContainerAddressMap(context.Context, *GetContainerAddressMapRequest) (*GetContainerAddressMapResponse, error)
 	SetWorkerAddress(context.Context, *SetWorkerAddressRequest) (*SetWorkerAddressResponse, error)
 	mustEmbedUnimplementedContainerRepositoryServiceServer()
@@ -160,6 +172,9 @@ func (UnimplementedContainerRepositoryServiceServer) SetContainerAddress(context
 func (UnimplementedContainerRepositoryServiceServer) SetContainerAddressMap(context.Context, *SetContainerAddressMapRequest) (*SetContainerAddressMapResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetContainerAddressMap not implemented")
 }
+func (UnimplementedContainerRepositoryServiceServer) SetContainerGpuTopology(context.Context, *SetContainerGpuTopologyRequest) (*SetContainerGpuTopologyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetContainerGpuTopology not implemented")
+}
 func (UnimplementedContainerRepositoryServiceServer) GetContainerAddressMap(context.Context, *GetContainerAddressMapRequest) (*GetContainerAddressMapResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetContainerAddressMap not implemented")
 }
@@ -288,6 +303,24 @@ func _ContainerRepositoryService_SetContainerAddressMap_Handler(srv interface{},
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ContainerRepositoryService_SetContainerGpuTopology_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetContainerGpuTopologyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerRepositoryServiceServer).SetContainerGpuTopology(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContainerRepositoryService_SetContainerGpuTopology_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerRepositoryServiceServer).SetContainerGpuTopology(ctx, req.(*SetContainerGpuTopologyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ContainerRepositoryService_GetContainerAddressMap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetContainerAddressMapRequest)
 	if err := dec(in); err != nil {
@@ -355,6 +388,10 @@ var ContainerRepositoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SetContainerAddressMap",
 			Handler:    _ContainerRepositoryService_SetContainerAddressMap_Handler,
 		},
+		{
+			MethodName: "SetContainerGpuTopology",
+			Handler:    _ContainerRepositoryService_SetContainerGpuTopology_Handler,
+		},
 		{
 			MethodName: "GetContainerAddressMap",
 			Handler:    _ContainerRepositoryService_GetContainerAddressMap_Handler,
@@ -19,40 +19,127 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	GatewayService_Authorize_FullMethodName             = "/gateway.GatewayService/Authorize"
-	GatewayService_SignPayload_FullMethodName           = "/gateway.GatewayService/SignPayload"
-	GatewayService_HeadObject_FullMethodName            = "/gateway.GatewayService/HeadObject"
-	GatewayService_CreateObject_FullMethodName          = "/gateway.GatewayService/CreateObject"
-	GatewayService_PutObjectStream_FullMethodName       = "/gateway.GatewayService/PutObjectStream"
-	GatewayService_CheckpointContainer_FullMethodName   = "/gateway.GatewayService/CheckpointContainer"
-	GatewayService_ListContainers_FullMethodName        = "/gateway.GatewayService/ListContainers"
-	GatewayService_StopContainer_FullMethodName         = "/gateway.GatewayService/StopContainer"
-	GatewayService_AttachToContainer_FullMethodName     = "/gateway.GatewayService/AttachToContainer"
-	GatewayService_StartTask_FullMethodName             = "/gateway.GatewayService/StartTask"
-	GatewayService_EndTask_FullMethodName               = "/gateway.GatewayService/EndTask"
-	GatewayService_StopTasks_FullMethodName             = "/gateway.GatewayService/StopTasks"
-	GatewayService_ListTasks_FullMethodName             = "/gateway.GatewayService/ListTasks"
-	GatewayService_GetOrCreateStub_FullMethodName       = "/gateway.GatewayService/GetOrCreateStub"
-	GatewayService_DeployStub_FullMethodName            = "/gateway.GatewayService/DeployStub"
-	GatewayService_GetURL_FullMethodName                = "/gateway.GatewayService/GetURL"
-	GatewayService_ListDeployments_FullMethodName       = "/gateway.GatewayService/ListDeployments"
-	GatewayService_StopDeployment_FullMethodName        = "/gateway.GatewayService/StopDeployment"
-	GatewayService_StartDeployment_FullMethodName       = "/gateway.GatewayService/StartDeployment"
-	GatewayService_ScaleDeployment_FullMethodName       = "/gateway.GatewayService/ScaleDeployment"
-	GatewayService_DeleteDeployment_FullMethodName      = "/gateway.GatewayService/DeleteDeployment"
-	GatewayService_ListPools_FullMethodName             = "/gateway.GatewayService/ListPools"
-	GatewayService_ListMachines_FullMethodName          = "/gateway.GatewayService/ListMachines"
-	GatewayService_CreateMachine_FullMethodName         = "/gateway.GatewayService/CreateMachine"
-	GatewayService_DeleteMachine_FullMethodName         = "/gateway.GatewayService/DeleteMachine"
-	GatewayService_ListTokens_FullMethodName            = "/gateway.GatewayService/ListTokens"
-	GatewayService_CreateToken_FullMethodName           = "/gateway.GatewayService/CreateToken"
-	GatewayService_ToggleToken_FullMethodName           = "/gateway.GatewayService/ToggleToken"
-	GatewayService_DeleteToken_FullMethodName           = "/gateway.GatewayService/DeleteToken"
-	GatewayService_ListWorkers_FullMethodName           = "/gateway.GatewayService/ListWorkers"
-	GatewayService_CordonWorker_FullMethodName          = "/gateway.GatewayService/CordonWorker"
-	GatewayService_UncordonWorker_FullMethodName        = "/gateway.GatewayService/UncordonWorker"
-	GatewayService_DrainWorker_FullMethodName           = "/gateway.GatewayService/DrainWorker"
-	GatewayService_ExportWorkspaceConfig_FullMethodName = "/gateway.GatewayService/ExportWorkspaceConfig"
+	GatewayService_Authorize_FullMethodName                 = "/gateway.GatewayService/Authorize"
+	GatewayService_SignPayload_FullMethodName               = "/gateway.GatewayService/SignPayload"
+	GatewayService_HeadObject_FullMethodName                = "/gateway.GatewayService/HeadObject"
+	GatewayService_ListObjects_FullMethodName               = "/gateway.GatewayService/ListObjects"
+	GatewayService_CreateObject_FullMethodName              = "/gateway.GatewayService/CreateObject"
+	GatewayService_PutObjectStream_FullMethodName           = "/gateway.GatewayService/PutObjectStream"
+	GatewayService_PutObjectStreamV2_FullMethodName         = "/gateway.GatewayService/PutObjectStreamV2"
+	GatewayService_GetObjectSignature_FullMethodName        = "/gateway.GatewayService/GetObjectSignature"
+	GatewayService_PatchObject_FullMethodName               = "/gateway.GatewayService/PatchObject"
+	GatewayService_GetObjectURL_FullMethodName              = "/gateway.GatewayService/GetObjectURL"
+	GatewayService_GetObjectStream_FullMethodName           = "/gateway.GatewayService/GetObjectStream"
+	GatewayService_RenameObject_FullMethodName              = "/gateway.GatewayService/RenameObject"
+	GatewayService_TagObject_FullMethodName                 = "/gateway.GatewayService/TagObject"
+	GatewayService_GetResourceACL_FullMethodName            = "/gateway.GatewayService/GetResourceACL"
+	GatewayService_SetResourceACL_FullMethodName            = "/gateway.GatewayService/SetResourceACL"
+	GatewayService_MigrateObjects_FullMethodName            = "/gateway.GatewayService/MigrateObjects"
+	GatewayService_CompleteObject_FullMethodName            = "/gateway.GatewayService/CompleteObject"
+	GatewayService_DeleteObject_FullMethodName              = "/gateway.GatewayService/DeleteObject"
+	GatewayService_DeleteObjects_FullMethodName             = "/gateway.GatewayService/DeleteObjects"
+	GatewayService_LockObject_FullMethodName                = "/gateway.GatewayService/LockObject"
+	GatewayService_ListCorruptedObjects_FullMethodName      = "/gateway.GatewayService/ListCorruptedObjects"
+	GatewayService_CheckObjectConsistency_FullMethodName    = "/gateway.GatewayService/CheckObjectConsistency"
+	GatewayService_SetObjectLifecyclePolicy_FullMethodName  = "/gateway.GatewayService/SetObjectLifecyclePolicy"
+	GatewayService_GetObjectLifecyclePolicy_FullMethodName  = "/gateway.GatewayService/GetObjectLifecyclePolicy"
+	GatewayService_ListObjectVersions_FullMethodName        = "/gateway.GatewayService/ListObjectVersions"
+	GatewayService_RestoreObjectVersion_FullMethodName      = "/gateway.GatewayService/RestoreObjectVersion"
+	GatewayService_CopyObject_FullMethodName                = "/gateway.GatewayService/CopyObject"
+	GatewayService_ExtractObject_FullMethodName             = "/gateway.GatewayService/ExtractObject"
+	GatewayService_GetStorageUsage_FullMethodName           = "/gateway.GatewayService/GetStorageUsage"
+	GatewayService_InitiateUpload_FullMethodName            = "/gateway.GatewayService/InitiateUpload"
+	GatewayService_AppendChunk_FullMethodName               = "/gateway.GatewayService/AppendChunk"
+	GatewayService_CompleteUpload_FullMethodName            = "/gateway.GatewayService/CompleteUpload"
+	GatewayService_CreateWebhook_FullMethodName             = "/gateway.GatewayService/CreateWebhook"
+	GatewayService_ListWebhooks_FullMethodName              = "/gateway.GatewayService/ListWebhooks"
+	GatewayService_DeleteWebhook_FullMethodName             = "/gateway.GatewayService/DeleteWebhook"
+	GatewayService_CreateTaskTrigger_FullMethodName         = "/gateway.GatewayService/CreateTaskTrigger"
+	GatewayService_ListTaskTriggers_FullMethodName          = "/gateway.GatewayService/ListTaskTriggers"
+	GatewayService_DeleteTaskTrigger_FullMethodName         = "/gateway.GatewayService/DeleteTaskTrigger"
+	GatewayService_SetUploadBandwidthLimit_FullMethodName   = "/gateway.GatewayService/SetUploadBandwidthLimit"
+	GatewayService_SetWorkspaceVolumeQuota_FullMethodName   = "/gateway.GatewayService/SetWorkspaceVolumeQuota"
+	GatewayService_SetWorkspacePolicy_FullMethodName        = "/gateway.GatewayService/SetWorkspacePolicy"
+	GatewayService_GetWorkspacePolicy_FullMethodName        = "/gateway.GatewayService/GetWorkspacePolicy"
+	GatewayService_SetPoolQuota_FullMethodName              = "/gateway.GatewayService/SetPoolQuota"
+	GatewayService_ListPoolQuotas_FullMethodName            = "/gateway.GatewayService/ListPoolQuotas"
+	GatewayService_ReserveCapacity_FullMethodName           = "/gateway.GatewayService/ReserveCapacity"
+	GatewayService_ListCapacityReservations_FullMethodName  = "/gateway.GatewayService/ListCapacityReservations"
+	GatewayService_CancelCapacityReservation_FullMethodName = "/gateway.GatewayService/CancelCapacityReservation"
+	GatewayService_GetWorkspaceIPAllowList_FullMethodName   = "/gateway.GatewayService/GetWorkspaceIPAllowList"
+	GatewayService_SetWorkspaceIPAllowList_FullMethodName   = "/gateway.GatewayService/SetWorkspaceIPAllowList"
+	GatewayService_CheckpointContainer_FullMethodName       = "/gateway.GatewayService/CheckpointContainer"
+	GatewayService_ListContainers_FullMethodName            = "/gateway.GatewayService/ListContainers"
+	GatewayService_StopContainer_FullMethodName             = "/gateway.GatewayService/StopContainer"
+	GatewayService_AttachToContainer_FullMethodName         = "/gateway.GatewayService/AttachToContainer"
+	GatewayService_ExecInContainer_FullMethodName           = "/gateway.GatewayService/ExecInContainer"
+	GatewayService_StreamLogs_FullMethodName                = "/gateway.GatewayService/StreamLogs"
+	GatewayService_PortForward_FullMethodName               = "/gateway.GatewayService/PortForward"
+	GatewayService_StartTask_FullMethodName                 = "/gateway.GatewayService/StartTask"
+	GatewayService_EndTask_FullMethodName                   = "/gateway.GatewayService/EndTask"
+	GatewayService_StopTasks_FullMethodName                 = "/gateway.GatewayService/StopTasks"
+	GatewayService_ListTasks_FullMethodName                 = "/gateway.GatewayService/ListTasks"
+	GatewayService_CancelTasks_FullMethodName               = "/gateway.GatewayService/CancelTasks"
+	GatewayService_RequeueTasks_FullMethodName              = "/gateway.GatewayService/RequeueTasks"
+	GatewayService_SetTaskRetryPolicy_FullMethodName        = "/gateway.GatewayService/SetTaskRetryPolicy"
+	GatewayService_GetTaskRetryPolicy_FullMethodName        = "/gateway.GatewayService/GetTaskRetryPolicy"
+	GatewayService_GetOrCreateStub_FullMethodName           = "/gateway.GatewayService/GetOrCreateStub"
+	GatewayService_DeployStub_FullMethodName                = "/gateway.GatewayService/DeployStub"
+	GatewayService_GetURL_FullMethodName                    = "/gateway.GatewayService/GetURL"
+	GatewayService_ListDeployments_FullMethodName           = "/gateway.GatewayService/ListDeployments"
+	GatewayService_StopDeployment_FullMethodName            = "/gateway.GatewayService/StopDeployment"
+	GatewayService_StartDeployment_FullMethodName           = "/gateway.GatewayService/StartDeployment"
+	GatewayService_ScaleDeployment_FullMethodName           = "/gateway.GatewayService/ScaleDeployment"
+	GatewayService_DeleteDeployment_FullMethodName          = "/gateway.GatewayService/DeleteDeployment"
+	GatewayService_RollbackDeployment_FullMethodName        = "/gateway.GatewayService/RollbackDeployment"
+	GatewayService_SetDeploymentEnv_FullMethodName          = "/gateway.GatewayService/SetDeploymentEnv"
+	GatewayService_UnsetDeploymentEnv_FullMethodName        = "/gateway.GatewayService/UnsetDeploymentEnv"
+	GatewayService_ListDeploymentEnv_FullMethodName         = "/gateway.GatewayService/ListDeploymentEnv"
+	GatewayService_AddCustomDomain_FullMethodName           = "/gateway.GatewayService/AddCustomDomain"
+	GatewayService_ListCustomDomains_FullMethodName         = "/gateway.GatewayService/ListCustomDomains"
+	GatewayService_RemoveCustomDomain_FullMethodName        = "/gateway.GatewayService/RemoveCustomDomain"
+	GatewayService_ListDeploymentHistory_FullMethodName     = "/gateway.GatewayService/ListDeploymentHistory"
+	GatewayService_SetTrafficSplit_FullMethodName           = "/gateway.GatewayService/SetTrafficSplit"
+	GatewayService_PromoteVersion_FullMethodName            = "/gateway.GatewayService/PromoteVersion"
+	GatewayService_SetAutoscalingPolicy_FullMethodName      = "/gateway.GatewayService/SetAutoscalingPolicy"
+	GatewayService_GetAutoscalingPolicy_FullMethodName      = "/gateway.GatewayService/GetAutoscalingPolicy"
+	GatewayService_ReportAutoscalingMetrics_FullMethodName  = "/gateway.GatewayService/ReportAutoscalingMetrics"
+	GatewayService_SetKeepWarm_FullMethodName               = "/gateway.GatewayService/SetKeepWarm"
+	GatewayService_GetKeepWarm_FullMethodName               = "/gateway.GatewayService/GetKeepWarm"
+	GatewayService_ListPools_FullMethodName                 = "/gateway.GatewayService/ListPools"
+	GatewayService_ListMachines_FullMethodName              = "/gateway.GatewayService/ListMachines"
+	GatewayService_CreateMachine_FullMethodName             = "/gateway.GatewayService/CreateMachine"
+	GatewayService_DeleteMachine_FullMethodName             = "/gateway.GatewayService/DeleteMachine"
+	GatewayService_ListTokens_FullMethodName                = "/gateway.GatewayService/ListTokens"
+	GatewayService_CreateToken_FullMethodName               = "/gateway.GatewayService/CreateToken"
+	GatewayService_ToggleToken_FullMethodName               = "/gateway.GatewayService/ToggleToken"
+	GatewayService_DeleteToken_FullMethodName               = "/gateway.GatewayService/DeleteToken"
+	GatewayService_SetTokenRateLimit_FullMethodName         = "/gateway.GatewayService/SetTokenRateLimit"
+	GatewayService_RotateToken_FullMethodName               = "/gateway.GatewayService/RotateToken"
+	GatewayService_ExchangeSessionToken_FullMethodName      = "/gateway.GatewayService/ExchangeSessionToken"
+	GatewayService_RefreshSessionToken_FullMethodName       = "/gateway.GatewayService/RefreshSessionToken"
+	GatewayService_RevokeSessionToken_FullMethodName        = "/gateway.GatewayService/RevokeSessionToken"
+	GatewayService_ListMemberRoles_FullMethodName           = "/gateway.GatewayService/ListMemberRoles"
+	GatewayService_SetMemberRole_FullMethodName             = "/gateway.GatewayService/SetMemberRole"
+	GatewayService_InviteMember_FullMethodName              = "/gateway.GatewayService/InviteMember"
+	GatewayService_AcceptInvite_FullMethodName              = "/gateway.GatewayService/AcceptInvite"
+	GatewayService_RevokeInvite_FullMethodName              = "/gateway.GatewayService/RevokeInvite"
+	GatewayService_CreateServiceAccount_FullMethodName      = "/gateway.GatewayService/CreateServiceAccount"
+	GatewayService_ListServiceAccounts_FullMethodName       = "/gateway.GatewayService/ListServiceAccounts"
+	GatewayService_DeleteServiceAccount_FullMethodName      = "/gateway.GatewayService/DeleteServiceAccount"
+	GatewayService_IssueServiceAccountToken_FullMethodName  = "/gateway.GatewayService/IssueServiceAccountToken"
+	GatewayService_RenewServiceAccountToken_FullMethodName  = "/gateway.GatewayService/RenewServiceAccountToken"
+	GatewayService_RevokeServiceAccountToken_FullMethodName = "/gateway.GatewayService/RevokeServiceAccountToken"
+	GatewayService_ListWorkers_FullMethodName               = "/gateway.GatewayService/ListWorkers"
+	GatewayService_CordonWorker_FullMethodName              = "/gateway.GatewayService/CordonWorker"
+	GatewayService_UncordonWorker_FullMethodName            = "/gateway.GatewayService/UncordonWorker"
+	GatewayService_DrainWorker_FullMethodName               = "/gateway.GatewayService/DrainWorker"
+	GatewayService_SetWorkerLabels_FullMethodName           = "/gateway.GatewayService/SetWorkerLabels"
+	GatewayService_SimulateScheduling_FullMethodName        = "/gateway.GatewayService/SimulateScheduling"
+	GatewayService_ExportWorkspaceConfig_FullMethodName     = "/gateway.GatewayService/ExportWorkspaceConfig"
+	GatewayService_GrantResourceAccess_FullMethodName       = "/gateway.GatewayService/GrantResourceAccess"
+	GatewayService_ListResourceGrants_FullMethodName        = "/gateway.GatewayService/ListResourceGrants"
+	GatewayService_QueryAuditLog_FullMethodName             = "/gateway.GatewayService/QueryAuditLog"
 )
 
 // GatewayServiceClient is the client API for GatewayService service.
@@ -64,18 +151,69 @@ type GatewayServiceClient interface {
 	SignPayload(ctx context.Context, in *SignPayloadRequest, opts ...grpc.CallOption) (*SignPayloadResponse, error)
 	// Objects
 	HeadObject(ctx context.Context, in *HeadObjectRequest, opts ...grpc.CallOption) (*HeadObjectResponse, error)
+	ListObjects(ctx context.Context, in *ListObjectsRequest, opts ...grpc.CallOption) (*ListObjectsResponse, error)
 	CreateObject(ctx context.Context, in *CreateObjectRequest, opts ...grpc.CallOption) (*CreateObjectResponse, error)
 	PutObjectStream(ctx context.Context, opts ...grpc.CallOption) (GatewayService_PutObjectStreamClient, error)
+	PutObjectStreamV2(ctx context.Context, opts ...grpc.CallOption) (GatewayService_PutObjectStreamV2Client, error)
+	GetObjectSignature(ctx context.Context, in *GetObjectSignatureRequest, opts ...grpc.CallOption) (*GetObjectSignatureResponse, error)
+	PatchObject(ctx context.Context, opts ...grpc.CallOption) (GatewayService_PatchObjectClient, error)
+	GetObjectURL(ctx context.Context, in *GetObjectURLRequest, opts ...grpc.CallOption) (*GetObjectURLResponse, error)
+	GetObjectStream(ctx context.Context, in *GetObjectStreamRequest, opts ...grpc.CallOption) (GatewayService_GetObjectStreamClient, error)
+	RenameObject(ctx context.Context, in *RenameObjectRequest, opts ...grpc.CallOption) (*RenameObjectResponse, error)
+	TagObject(ctx context.Context, in *TagObjectRequest, opts ...grpc.CallOption) (*TagObjectResponse, error)
+	GetResourceACL(ctx context.Context, in *GetResourceACLRequest, opts ...grpc.CallOption) (*GetResourceACLResponse, error)
+	SetResourceACL(ctx context.Context, in *SetResourceACLRequest, opts ...grpc.CallOption) (*SetResourceACLResponse, error)
+	MigrateObjects(ctx context.Context, in *MigrateObjectsRequest, opts ...grpc.CallOption) (*MigrateObjectsResponse, error)
+	CompleteObject(ctx context.Context, in *CompleteObjectRequest, opts ...grpc.CallOption) (*CompleteObjectResponse, error)
+	DeleteObject(ctx context.Context, in *DeleteObjectRequest, opts ...grpc.CallOption) (*DeleteObjectResponse, error)
+	DeleteObjects(ctx context.Context, in *DeleteObjectsRequest, opts ...grpc.CallOption) (*DeleteObjectsResponse, error)
+	LockObject(ctx context.Context, in *LockObjectRequest, opts ...grpc.CallOption) (*LockObjectResponse, error)
+	ListCorruptedObjects(ctx context.Context, in *ListCorruptedObjectsRequest, opts ...grpc.CallOption) (*ListCorruptedObjectsResponse, error)
+	CheckObjectConsistency(ctx context.Context, in *CheckObjectConsistencyRequest, opts ...grpc.CallOption) (*CheckObjectConsistencyResponse, error)
+	SetObjectLifecyclePolicy(ctx context.Context, in *SetObjectLifecyclePolicyRequest, opts ...grpc.CallOption) (*SetObjectLifecyclePolicyResponse, error)
+	GetObjectLifecyclePolicy(ctx context.Context, in *GetObjectLifecyclePolicyRequest, opts ...grpc.CallOption) (*GetObjectLifecyclePolicyResponse, error)
+	ListObjectVersions(ctx context.Context, in *ListObjectVersionsRequest, opts ...grpc.CallOption) (*ListObjectVersionsResponse, error)
+	RestoreObjectVersion(ctx context.Context, in *RestoreObjectVersionRequest, opts ...grpc.CallOption) (*RestoreObjectVersionResponse, error)
+	CopyObject(ctx context.Context, in *CopyObjectRequest, opts ...grpc.CallOption) (*CopyObjectResponse, error)
+	ExtractObject(ctx context.Context, in *ExtractObjectRequest, opts ...grpc.CallOption) (*ExtractObjectResponse, error)
+	GetStorageUsage(ctx context.Context, in *GetStorageUsageRequest, opts ...grpc.CallOption) (*GetStorageUsageResponse, error)
+	InitiateUpload(ctx context.Context, in *InitiateUploadRequest, opts ...grpc.CallOption) (*InitiateUploadResponse, error)
+	AppendChunk(ctx context.Context, in *AppendChunkRequest, opts ...grpc.CallOption) (*AppendChunkResponse, error)
+	CompleteUpload(ctx context.Context, in *CompleteUploadRequest, opts ...grpc.CallOption) (*CompleteUploadResponse, error)
+	CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*CreateWebhookResponse, error)
+	ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error)
+	DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error)
+	CreateTaskTrigger(ctx context.Context, in *CreateTaskTriggerRequest, opts ...grpc.CallOption) (*CreateTaskTriggerResponse, error)
+	ListTaskTriggers(ctx context.Context, in *ListTaskTriggersRequest, opts ...grpc.CallOption) (*ListTaskTriggersResponse, error)
+	DeleteTaskTrigger(ctx context.Context, in *DeleteTaskTriggerRequest, opts ...grpc.CallOption) (*DeleteTaskTriggerResponse, error)
+	SetUploadBandwidthLimit(ctx context.Context, in *SetUploadBandwidthLimitRequest, opts ...grpc.CallOption) (*SetUploadBandwidthLimitResponse, error)
+	SetWorkspaceVolumeQuota(ctx context.Context, in *SetWorkspaceVolumeQuotaRequest, opts ...grpc.CallOption) (*SetWorkspaceVolumeQuotaResponse, error)
+	SetWorkspacePolicy(ctx context.Context, in *SetWorkspacePolicyRequest, opts ...grpc.CallOption) (*SetWorkspacePolicyResponse, error)
+	GetWorkspacePolicy(ctx context.Context, in *GetWorkspacePolicyRequest, opts ...grpc.CallOption) (*GetWorkspacePolicyResponse, error)
+	SetPoolQuota(ctx context.Context, in *SetPoolQuotaRequest, opts ...grpc.CallOption) (*SetPoolQuotaResponse, error)
+	ListPoolQuotas(ctx context.Context, in *ListPoolQuotasRequest, opts ...grpc.CallOption) (*ListPoolQuotasResponse, error)
+	ReserveCapacity(ctx context.Context, in *ReserveCapacityRequest, opts ...grpc.CallOption) (*ReserveCapacityResponse, error)
+	ListCapacityReservations(ctx context.Context, in *ListCapacityReservationsRequest, opts ...grpc.CallOption) (*ListCapacityReservationsResponse, error)
+	CancelCapacityReservation(ctx context.Context, in *CancelCapacityReservationRequest, opts ...grpc.CallOption) (*CancelCapacityReservationResponse, error)
+	GetWorkspaceIPAllowList(ctx context.Context, in *GetWorkspaceIPAllowListRequest, opts ...grpc.CallOption) (*GetWorkspaceIPAllowListResponse, error)
+	SetWorkspaceIPAllowList(ctx context.Context, in *SetWorkspaceIPAllowListRequest, opts ...grpc.CallOption) (*SetWorkspaceIPAllowListResponse, error)
 	// Containers
 	CheckpointContainer(ctx context.Context, in *CheckpointContainerRequest, opts ...grpc.CallOption) (*CheckpointContainerResponse, error)
 	ListContainers(ctx context.Context, in *ListContainersRequest, opts ...grpc.CallOption) (*ListContainersResponse, error)
 	StopContainer(ctx context.Context, in *StopContainerRequest, opts ...grpc.CallOption) (*StopContainerResponse, error)
 	AttachToContainer(ctx context.Context, opts ...grpc.CallOption) (GatewayService_AttachToContainerClient, error)
+	ExecInContainer(ctx context.Context, opts ...grpc.CallOption) (GatewayService_ExecInContainerClient, error)
+	StreamLogs(ctx context.Context, in *StreamLogsRequest, opts ...grpc.CallOption) (GatewayService_StreamLogsClient, error)
+	PortForward(ctx context.Context, opts ...grpc.CallOption) (GatewayService_PortForwardClient, error)
 	// Tasks
 	StartTask(ctx context.Context, in *StartTaskRequest, opts ...grpc.CallOption) (*StartTaskResponse, error)
 	EndTask(ctx context.Context, in *EndTaskRequest, opts ...grpc.CallOption) (*EndTaskResponse, error)
 	StopTasks(ctx context.Context, in *StopTasksRequest, opts ...grpc.CallOption) (*StopTasksResponse, error)
 	ListTasks(ctx context.Context, in *ListTasksRequest, opts ...grpc.CallOption) (*ListTasksResponse, error)
+	CancelTasks(ctx context.Context, in *CancelTasksRequest, opts ...grpc.CallOption) (*CancelTasksResponse, error)
+	RequeueTasks(ctx context.Context, in *RequeueTasksRequest, opts ...grpc.CallOption) (*RequeueTasksResponse, error)
+	SetTaskRetryPolicy(ctx context.Context, in *SetTaskRetryPolicyRequest, opts ...grpc.CallOption) (*SetTaskRetryPolicyResponse, error)
+	GetTaskRetryPolicy(ctx context.Context, in *GetTaskRetryPolicyRequest, opts ...grpc.CallOption) (*GetTaskRetryPolicyResponse, error)
 	// Stubs
 	GetOrCreateStub(ctx context.Context, in *GetOrCreateStubRequest, opts ...grpc.CallOption) (*GetOrCreateStubResponse, error)
 	DeployStub(ctx context.Context, in *DeployStubRequest, opts ...grpc.CallOption) (*DeployStubResponse, error)
@@ -86,6 +224,22 @@ type GatewayServiceClient interface {
 	StartDeployment(ctx context.Context, in *StartDeploymentRequest, opts ...grpc.CallOption) (*StartDeploymentResponse, error)
 	ScaleDeployment(ctx context.Context, in *ScaleDeploymentRequest, opts ...grpc.CallOption) (*ScaleDeploymentResponse, error)
 	DeleteDeployment(ctx context.Context, in *DeleteDeploymentRequest, opts ...grpc.CallOption) (*DeleteDeploymentResponse, error)
+	RollbackDeployment(ctx context.Context, in *RollbackDeploymentRequest, opts ...grpc.CallOption) (*RollbackDeploymentResponse, error)
+	SetDeploymentEnv(ctx context.Context, in *SetDeploymentEnvRequest, opts ...grpc.CallOption) (*SetDeploymentEnvResponse, error)
+	UnsetDeploymentEnv(ctx context.Context, in *UnsetDeploymentEnvRequest, opts ...grpc.CallOption) (*UnsetDeploymentEnvResponse, error)
+	ListDeploymentEnv(ctx context.Context, in *ListDeploymentEnvRequest, opts ...grpc.CallOption) (*ListDeploymentEnvResponse, error)
+	AddCustomDomain(ctx context.Context, in *AddCustomDomainRequest, opts ...grpc.CallOption) (*AddCustomDomainResponse, error)
+	ListCustomDomains(ctx context.Context, in *ListCustomDomainsRequest, opts ...grpc.CallOption) (*ListCustomDomainsResponse, error)
+	RemoveCustomDomain(ctx context.Context, in *RemoveCustomDomainRequest, opts ...grpc.CallOption) (*RemoveCustomDomainResponse, error)
+	ListDeploymentHistory(ctx context.Context, in *ListDeploymentHistoryRequest, opts ...grpc.CallOption) (*ListDeploymentHistoryResponse, error)
+	SetTrafficSplit(ctx context.Context, in *SetTrafficSplitRequest, opts ...grpc.CallOption) (*SetTrafficSplitResponse, error)
+	PromoteVersion(ctx context.Context, in *PromoteVersionRequest, opts ...grpc.CallOption) (*PromoteVersionResponse, error)
+	// Autoscaling
+	SetAutoscalingPolicy(ctx context.Context, in *SetAutoscalingPolicyRequest, opts ...grpc.CallOption) (*SetAutoscalingPolicyResponse, error)
+	GetAutoscalingPolicy(ctx context.Context, in *GetAutoscalingPolicyRequest, opts ...grpc.CallOption) (*GetAutoscalingPolicyResponse, error)
+	ReportAutoscalingMetrics(ctx context.Context, in *ReportAutoscalingMetricsRequest, opts ...grpc.CallOption) (*ReportAutoscalingMetricsResponse, error)
+	SetKeepWarm(ctx context.Context, in *SetKeepWarmRequest, opts ...grpc.CallOption) (*SetKeepWarmResponse, error)
+	GetKeepWarm(ctx context.Context, in *GetKeepWarmRequest, opts ...grpc.CallOption) (*GetKeepWarmResponse, error)
 	// Pools
 	ListPools(ctx context.Context, in *ListPoolsRequest, opts ...grpc.CallOption) (*ListPoolsResponse, error)
 	// Machines
@@ -97,13 +251,38 @@ type GatewayServiceClient interface {
 	CreateToken(ctx context.Context, in *CreateTokenRequest, opts ...grpc.CallOption) (*CreateTokenResponse, error)
 	ToggleToken(ctx context.Context, in *ToggleTokenRequest, opts ...grpc.CallOption) (*ToggleTokenResponse, error)
 	DeleteToken(ctx context.Context, in *DeleteTokenRequest, opts ...grpc.CallOption) (*DeleteTokenResponse, error)
+	SetTokenRateLimit(ctx context.Context, in *SetTokenRateLimitRequest, opts ...grpc.CallOption) (*SetTokenRateLimitResponse, error)
+	RotateToken(ctx context.Context, in *RotateTokenRequest, opts ...grpc.CallOption) (*RotateTokenResponse, error)
+	ExchangeSessionToken(ctx context.Context, in *ExchangeSessionTokenRequest, opts ...grpc.CallOption) (*ExchangeSessionTokenResponse, error)
+	RefreshSessionToken(ctx context.Context, in *RefreshSessionTokenRequest, opts ...grpc.CallOption) (*RefreshSessionTokenResponse, error)
+	RevokeSessionToken(ctx context.Context, in *RevokeSessionTokenRequest, opts ...grpc.CallOption) (*RevokeSessionTokenResponse, error)
+	// Members
+	ListMemberRoles(ctx context.Context, in *ListMemberRolesRequest, opts ...grpc.CallOption) (*ListMemberRolesResponse, error)
+	SetMemberRole(ctx context.Context, in *SetMemberRoleRequest, opts ...grpc.CallOption) (*SetMemberRoleResponse, error)
+	InviteMember(ctx context.Context, in *InviteMemberRequest, opts ...grpc.CallOption) (*InviteMemberResponse, error)
+	AcceptInvite(ctx context.Context, in *AcceptInviteRequest, opts ...grpc.CallOption) (*AcceptInviteResponse, error)
+	RevokeInvite(ctx context.Context, in *RevokeInviteRequest, opts ...grpc.CallOption) (*RevokeInviteResponse, error)
+	// Service accounts
+	CreateServiceAccount(ctx context.Context, in *CreateServiceAccountRequest, opts ...grpc.CallOption) (*CreateServiceAccountResponse, error)
+	ListServiceAccounts(ctx context.Context, in *ListServiceAccountsRequest, opts ...grpc.CallOption) (*ListServiceAccountsResponse, error)
+	DeleteServiceAccount(ctx context.Context, in *DeleteServiceAccountRequest, opts ...grpc.CallOption) (*DeleteServiceAccountResponse, error)
+	IssueServiceAccountToken(ctx context.Context, in *IssueServiceAccountTokenRequest, opts ...grpc.CallOption) (*IssueServiceAccountTokenResponse, error)
+	RenewServiceAccountToken(ctx context.Context, in *RenewServiceAccountTokenRequest, opts ...grpc.CallOption) (*RenewServiceAccountTokenResponse, error)
+	RevokeServiceAccountToken(ctx context.Context, in *RevokeServiceAccountTokenRequest, opts ...grpc.CallOption) (*RevokeServiceAccountTokenResponse, error)
 	// Workers
 	ListWorkers(ctx context.Context, in *ListWorkersRequest, opts ...grpc.CallOption) (*ListWorkersResponse, error)
 	CordonWorker(ctx context.Context, in *CordonWorkerRequest, opts ...grpc.CallOption) (*CordonWorkerResponse, error)
 	UncordonWorker(ctx context.Context, in *UncordonWorkerRequest, opts ...grpc.CallOption) (*UncordonWorkerResponse, error)
 	DrainWorker(ctx context.Context, in *DrainWorkerRequest, opts ...grpc.CallOption) (*DrainWorkerResponse, error)
+	SetWorkerLabels(ctx context.Context, in *SetWorkerLabelsRequest, opts ...grpc.CallOption) (*SetWorkerLabelsResponse, error)
+	SimulateScheduling(ctx context.Context, in *SimulateSchedulingRequest, opts ...grpc.CallOption) (*SimulateSchedulingResponse, error)
 	// Workspace
 	ExportWorkspaceConfig(ctx context.Context, in *ExportWorkspaceConfigRequest, opts ...grpc.CallOption) (*ExportWorkspaceConfigResponse, error)
+	// Grants
+	GrantResourceAccess(ctx context.Context, in *GrantResourceAccessRequest, opts ...grpc.CallOption) (*GrantResourceAccessResponse, error)
+	ListResourceGrants(ctx context.Context, in *ListResourceGrantsRequest, opts ...grpc.CallOption) (*ListResourceGrantsResponse, error)
+	// Audit log
+	QueryAuditLog(ctx context.Context, in *QueryAuditLogRequest, opts ...grpc.CallOption) (*QueryAuditLogResponse, error)
 }
 
 type gatewayServiceClient struct {
@@ -141,6 +320,15 @@ func (c *gatewayServiceClient) HeadObject(ctx context.Context, in *HeadObjectReq
 	return out, nil
 }
 
+func (c *gatewayServiceClient) ListObjects(ctx context.Context, in *ListObjectsRequest, opts ...grpc.CallOption) (*ListObjectsResponse, error) {
+	out := new(ListObjectsResponse)
+	err := c.cc.Invoke(ctx, GatewayService_ListObjects_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *gatewayServiceClient) CreateObject(ctx context.Context, in *CreateObjectRequest, opts ...grpc.CallOption) (*CreateObjectResponse, error) {
 	out := new(CreateObjectResponse)
 	err := c.cc.Invoke(ctx, GatewayService_CreateObject_FullMethodName, in, out, opts...)
@@ -184,1084 +372,3952 @@ func (x *gatewayServicePutObjectStreamClient) CloseAndRecv() (*PutObjectResponse
 	return m, nil
 }
 
-func (c *gatewayServiceClient) CheckpointContainer(ctx context.Context, in *CheckpointContainerRequest, opts ...grpc.CallOption) (*CheckpointContainerResponse, error) {
-	out := new(CheckpointContainerResponse)
-	err := c.cc.Invoke(ctx, GatewayService_CheckpointContainer_FullMethodName, in, out, opts...)
+func (c *gatewayServiceClient) PutObjectStreamV2(ctx context.Context, opts ...grpc.CallOption) (GatewayService_PutObjectStreamV2Client, error) {
+	stream, err := c.cc.NewStream(ctx, &GatewayService_ServiceDesc.Streams[1], GatewayService_PutObjectStreamV2_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	x := &gatewayServicePutObjectStreamV2Client{stream}
+	return x, nil
 }
 
-func (c *gatewayServiceClient) ListContainers(ctx context.Context, in *ListContainersRequest, opts ...grpc.CallOption) (*ListContainersResponse, error) {
-	out := new(ListContainersResponse)
-	err := c.cc.Invoke(ctx, GatewayService_ListContainers_FullMethodName, in, out, opts...)
-	if err != nil {
+type GatewayService_PutObjectStreamV2Client interface {
+	Send(*PutObjectRequest) error
+	Recv() (*PutObjectStreamResponse, error)
+	grpc.ClientStream
+}
+
+type gatewayServicePutObjectStreamV2Client struct {
+	grpc.ClientStream
+}
+
+func (x *gatewayServicePutObjectStreamV2Client) Send(m *PutObjectRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *gatewayServicePutObjectStreamV2Client) Recv() (*PutObjectStreamResponse, error) {
+	m := new(PutObjectStreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return m, nil
 }
 
-func (c *gatewayServiceClient) StopContainer(ctx context.Context, in *StopContainerRequest, opts ...grpc.CallOption) (*StopContainerResponse, error) {
-	out := new(StopContainerResponse)
-	err := c.cc.Invoke(ctx, GatewayService_StopContainer_FullMethodName, in, out, opts...)
+func (c *gatewayServiceClient) GetObjectSignature(ctx context.Context, in *GetObjectSignatureRequest, opts ...grpc.CallOption) (*GetObjectSignatureResponse, error) {
+	out := new(GetObjectSignatureResponse)
+	err := c.cc.Invoke(ctx, GatewayService_GetObjectSignature_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *gatewayServiceClient) AttachToContainer(ctx context.Context, opts ...grpc.CallOption) (GatewayService_AttachToContainerClient, error) {
-	stream, err := c.cc.NewStream(ctx, &GatewayService_ServiceDesc.Streams[1], GatewayService_AttachToContainer_FullMethodName, opts...)
+func (c *gatewayServiceClient) PatchObject(ctx context.Context, opts ...grpc.CallOption) (GatewayService_PatchObjectClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GatewayService_ServiceDesc.Streams[2], GatewayService_PatchObject_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &gatewayServiceAttachToContainerClient{stream}
+	x := &gatewayServicePatchObjectClient{stream}
 	return x, nil
 }
 
-type GatewayService_AttachToContainerClient interface {
-	Send(*ContainerStreamMessage) error
-	Recv() (*AttachToContainerResponse, error)
+type GatewayService_PatchObjectClient interface {
+	Send(*PatchObjectRequest) error
+	CloseAndRecv() (*PatchObjectResponse, error)
 	grpc.ClientStream
 }
 
-type gatewayServiceAttachToContainerClient struct {
+type gatewayServicePatchObjectClient struct {
 	grpc.ClientStream
 }
 
-func (x *gatewayServiceAttachToContainerClient) Send(m *ContainerStreamMessage) error {
+func (x *gatewayServicePatchObjectClient) Send(m *PatchObjectRequest) error {
 	return x.ClientStream.SendMsg(m)
 }
 
-func (x *gatewayServiceAttachToContainerClient) Recv() (*AttachToContainerResponse, error) {
-	m := new(AttachToContainerResponse)
+func (x *gatewayServicePatchObjectClient) CloseAndRecv() (*PatchObjectResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(PatchObjectResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *gatewayServiceClient) StartTask(ctx context.Context, in *StartTaskRequest, opts ...grpc.CallOption) (*StartTaskResponse, error) {
-	out := new(StartTaskResponse)
-	err := c.cc.Invoke(ctx, GatewayService_StartTask_FullMethodName, in, out, opts...)
+func (c *gatewayServiceClient) GetObjectURL(ctx context.Context, in *GetObjectURLRequest, opts ...grpc.CallOption) (*GetObjectURLResponse, error) {
+	out := new(GetObjectURLResponse)
+	err := c.cc.Invoke(ctx, GatewayService_GetObjectURL_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *gatewayServiceClient) EndTask(ctx context.Context, in *EndTaskRequest, opts ...grpc.CallOption) (*EndTaskResponse, error) {
-	out := new(EndTaskResponse)
-	err := c.cc.Invoke(ctx, GatewayService_EndTask_FullMethodName, in, out, opts...)
+func (c *gatewayServiceClient) GetObjectStream(ctx context.Context, in *GetObjectStreamRequest, opts ...grpc.CallOption) (GatewayService_GetObjectStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GatewayService_ServiceDesc.Streams[3], GatewayService_GetObjectStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gatewayServiceGetObjectStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GatewayService_GetObjectStreamClient interface {
+	Recv() (*GetObjectStreamResponse, error)
+	grpc.ClientStream
+}
+
+type gatewayServiceGetObjectStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *gatewayServiceGetObjectStreamClient) Recv() (*GetObjectStreamResponse, error) {
+	m := new(GetObjectStreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *gatewayServiceClient) RenameObject(ctx context.Context, in *RenameObjectRequest, opts ...grpc.CallOption) (*RenameObjectResponse, error) {
+	out := new(RenameObjectResponse)
+	err := c.cc.Invoke(ctx, GatewayService_RenameObject_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *gatewayServiceClient) StopTasks(ctx context.Context, in *StopTasksRequest, opts ...grpc.CallOption) (*StopTasksResponse, error) {
-	out := new(StopTasksResponse)
-	err := c.cc.Invoke(ctx, GatewayService_StopTasks_FullMethodName, in, out, opts...)
+func (c *gatewayServiceClient) TagObject(ctx context.Context, in *TagObjectRequest, opts ...grpc.CallOption) (*TagObjectResponse, error) {
+	out := new(TagObjectResponse)
+	err := c.cc.Invoke(ctx, GatewayService_TagObject_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *gatewayServiceClient) ListTasks(ctx context.Context, in *ListTasksRequest, opts ...grpc.CallOption) (*ListTasksResponse, error) {
-	out := new(ListTasksResponse)
-	err := c.cc.Invoke(ctx, GatewayService_ListTasks_FullMethodName, in, out, opts...)
+func (c *gatewayServiceClient) GetResourceACL(ctx context.Context, in *GetResourceACLRequest, opts ...grpc.CallOption) (*GetResourceACLResponse, error) {
+	out := new(GetResourceACLResponse)
+	err := c.cc.Invoke(ctx, GatewayService_GetResourceACL_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *gatewayServiceClient) GetOrCreateStub(ctx context.Context, in *GetOrCreateStubRequest, opts ...grpc.CallOption) (*GetOrCreateStubResponse, error) {
-	out := new(GetOrCreateStubResponse)
-	err := c.cc.Invoke(ctx, GatewayService_GetOrCreateStub_FullMethodName, in, out, opts...)
+func (c *gatewayServiceClient) SetResourceACL(ctx context.Context, in *SetResourceACLRequest, opts ...grpc.CallOption) (*SetResourceACLResponse, error) {
+	out := new(SetResourceACLResponse)
+	err := c.cc.Invoke(ctx, GatewayService_SetResourceACL_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *gatewayServiceClient) DeployStub(ctx context.Context, in *DeployStubRequest, opts ...grpc.CallOption) (*DeployStubResponse, error) {
-	out := new(DeployStubResponse)
-	err := c.cc.Invoke(ctx, GatewayService_DeployStub_FullMethodName, in, out, opts...)
+func (c *gatewayServiceClient) MigrateObjects(ctx context.Context, in *MigrateObjectsRequest, opts ...grpc.CallOption) (*MigrateObjectsResponse, error) {
+	out := new(MigrateObjectsResponse)
+	err := c.cc.Invoke(ctx, GatewayService_MigrateObjects_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *gatewayServiceClient) GetURL(ctx context.Context, in *GetURLRequest, opts ...grpc.CallOption) (*GetURLResponse, error) {
-	out := new(GetURLResponse)
-	err := c.cc.Invoke(ctx, GatewayService_GetURL_FullMethodName, in, out, opts...)
+func (c *gatewayServiceClient) CompleteObject(ctx context.Context, in *CompleteObjectRequest, opts ...grpc.CallOption) (*CompleteObjectResponse, error) {
+	out := new(CompleteObjectResponse)
+	err := c.cc.Invoke(ctx, GatewayService_CompleteObject_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *gatewayServiceClient) ListDeployments(ctx context.Context, in *ListDeploymentsRequest, opts ...grpc.CallOption) (*ListDeploymentsResponse, error) {
-	out := new(ListDeploymentsResponse)
-	err := c.cc.Invoke(ctx, GatewayService_ListDeployments_FullMethodName, in, out, opts...)
+func (c *gatewayServiceClient) DeleteObject(ctx context.Context, in *DeleteObjectRequest, opts ...grpc.CallOption) (*DeleteObjectResponse, error) {
+	out := new(DeleteObjectResponse)
+	err := c.cc.Invoke(ctx, GatewayService_DeleteObject_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *gatewayServiceClient) StopDeployment(ctx context.Context, in *StopDeploymentRequest, opts ...grpc.CallOption) (*StopDeploymentResponse, error) {
-	out := new(StopDeploymentResponse)
-	err := c.cc.Invoke(ctx, GatewayService_StopDeployment_FullMethodName, in, out, opts...)
+func (c *gatewayServiceClient) DeleteObjects(ctx context.Context, in *DeleteObjectsRequest, opts ...grpc.CallOption) (*DeleteObjectsResponse, error) {
+	out := new(DeleteObjectsResponse)
+	err := c.cc.Invoke(ctx, GatewayService_DeleteObjects_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *gatewayServiceClient) StartDeployment(ctx context.Context, in *StartDeploymentRequest, opts ...grpc.CallOption) (*StartDeploymentResponse, error) {
-	out := new(StartDeploymentResponse)
-	err := c.cc.Invoke(ctx, GatewayService_StartDeployment_FullMethodName, in, out, opts...)
+func (c *gatewayServiceClient) LockObject(ctx context.Context, in *LockObjectRequest, opts ...grpc.CallOption) (*LockObjectResponse, error) {
+	out := new(LockObjectResponse)
+	err := c.cc.Invoke(ctx, GatewayService_LockObject_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *gatewayServiceClient) ScaleDeployment(ctx context.Context, in *ScaleDeploymentRequest, opts ...grpc.CallOption) (*ScaleDeploymentResponse, error) {
-	out := new(ScaleDeploymentResponse)
-	err := c.cc.Invoke(ctx, GatewayService_ScaleDeployment_FullMethodName, in, out, opts...)
+func (c *gatewayServiceClient) ListCorruptedObjects(ctx context.Context, in *ListCorruptedObjectsRequest, opts ...grpc.CallOption) (*ListCorruptedObjectsResponse, error) {
+	out := new(ListCorruptedObjectsResponse)
+	err := c.cc.Invoke(ctx, GatewayService_ListCorruptedObjects_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *gatewayServiceClient) DeleteDeployment(ctx context.Context, in *DeleteDeploymentRequest, opts ...grpc.CallOption) (*DeleteDeploymentResponse, error) {
-	out := new(DeleteDeploymentResponse)
-	err := c.cc.Invoke(ctx, GatewayService_DeleteDeployment_FullMethodName, in, out, opts...)
+func (c *gatewayServiceClient) CheckObjectConsistency(ctx context.Context, in *CheckObjectConsistencyRequest, opts ...grpc.CallOption) (*CheckObjectConsistencyResponse, error) {
+	out := new(CheckObjectConsistencyResponse)
+	err := c.cc.Invoke(ctx, GatewayService_CheckObjectConsistency_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *gatewayServiceClient) ListPools(ctx context.Context, in *ListPoolsRequest, opts ...grpc.CallOption) (*ListPoolsResponse, error) {
-	out := new(ListPoolsResponse)
-	err := c.cc.Invoke(ctx, GatewayService_ListPools_FullMethodName, in, out, opts...)
+func (c *gatewayServiceClient) SetObjectLifecyclePolicy(ctx context.Context, in *SetObjectLifecyclePolicyRequest, opts ...grpc.CallOption) (*SetObjectLifecyclePolicyResponse, error) {
+	out := new(SetObjectLifecyclePolicyResponse)
+	err := c.cc.Invoke(ctx, GatewayService_SetObjectLifecyclePolicy_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *gatewayServiceClient) ListMachines(ctx context.Context, in *ListMachinesRequest, opts ...grpc.CallOption) (*ListMachinesResponse, error) {
-	out := new(ListMachinesResponse)
-	err := c.cc.Invoke(ctx, GatewayService_ListMachines_FullMethodName, in, out, opts...)
+func (c *gatewayServiceClient) GetObjectLifecyclePolicy(ctx context.Context, in *GetObjectLifecyclePolicyRequest, opts ...grpc.CallOption) (*GetObjectLifecyclePolicyResponse, error) {
+	out := new(GetObjectLifecyclePolicyResponse)
+	err := c.cc.Invoke(ctx, GatewayService_GetObjectLifecyclePolicy_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *gatewayServiceClient) CreateMachine(ctx context.Context, in *CreateMachineRequest, opts ...grpc.CallOption) (*CreateMachineResponse, error) {
-	out := new(CreateMachineResponse)
-	err := c.cc.Invoke(ctx, GatewayService_CreateMachine_FullMethodName, in, out, opts...)
+func (c *gatewayServiceClient) ListObjectVersions(ctx context.Context, in *ListObjectVersionsRequest, opts ...grpc.CallOption) (*ListObjectVersionsResponse, error) {
+	out := new(ListObjectVersionsResponse)
+	err := c.cc.Invoke(ctx, GatewayService_ListObjectVersions_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *gatewayServiceClient) DeleteMachine(ctx context.Context, in *DeleteMachineRequest, opts ...grpc.CallOption) (*DeleteMachineResponse, error) {
-	out := new(DeleteMachineResponse)
-	err := c.cc.Invoke(ctx, GatewayService_DeleteMachine_FullMethodName, in, out, opts...)
+func (c *gatewayServiceClient) RestoreObjectVersion(ctx context.Context, in *RestoreObjectVersionRequest, opts ...grpc.CallOption) (*RestoreObjectVersionResponse, error) {
+	out := new(RestoreObjectVersionResponse)
+	err := c.cc.Invoke(ctx, GatewayService_RestoreObjectVersion_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *gatewayServiceClient) ListTokens(ctx context.Context, in *ListTokensRequest, opts ...grpc.CallOption) (*ListTokensResponse, error) {
-	out := new(ListTokensResponse)
-	err := c.cc.Invoke(ctx, GatewayService_ListTokens_FullMethodName, in, out, opts...)
+func (c *gatewayServiceClient) CopyObject(ctx context.Context, in *CopyObjectRequest, opts ...grpc.CallOption) (*CopyObjectResponse, error) {
+	out := new(CopyObjectResponse)
+	err := c.cc.Invoke(ctx, GatewayService_CopyObject_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *gatewayServiceClient) CreateToken(ctx context.Context, in *CreateTokenRequest, opts ...grpc.CallOption) (*CreateTokenResponse, error) {
-	out := new(CreateTokenResponse)
-	err := c.cc.Invoke(ctx, GatewayService_CreateToken_FullMethodName, in, out, opts...)
+func (c *gatewayServiceClient) ExtractObject(ctx context.Context, in *ExtractObjectRequest, opts ...grpc.CallOption) (*ExtractObjectResponse, error) {
+	out := new(ExtractObjectResponse)
+	err := c.cc.Invoke(ctx, GatewayService_ExtractObject_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *gatewayServiceClient) ToggleToken(ctx context.Context, in *ToggleTokenRequest, opts ...grpc.CallOption) (*ToggleTokenResponse, error) {
-	out := new(ToggleTokenResponse)
-	err := c.cc.Invoke(ctx, GatewayService_ToggleToken_FullMethodName, in, out, opts...)
+func (c *gatewayServiceClient) GetStorageUsage(ctx context.Context, in *GetStorageUsageRequest, opts ...grpc.CallOption) (*GetStorageUsageResponse, error) {
+	out := new(GetStorageUsageResponse)
+	err := c.cc.Invoke(ctx, GatewayService_GetStorageUsage_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *gatewayServiceClient) DeleteToken(ctx context.Context, in *DeleteTokenRequest, opts ...grpc.CallOption) (*DeleteTokenResponse, error) {
-	out := new(DeleteTokenResponse)
-	err := c.cc.Invoke(ctx, GatewayService_DeleteToken_FullMethodName, in, out, opts...)
+func (c *gatewayServiceClient) InitiateUpload(ctx context.Context, in *InitiateUploadRequest, opts ...grpc.CallOption) (*InitiateUploadResponse, error) {
+	out := new(InitiateUploadResponse)
+	err := c.cc.Invoke(ctx, GatewayService_InitiateUpload_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *gatewayServiceClient) ListWorkers(ctx context.Context, in *ListWorkersRequest, opts ...grpc.CallOption) (*ListWorkersResponse, error) {
-	out := new(ListWorkersResponse)
-	err := c.cc.Invoke(ctx, GatewayService_ListWorkers_FullMethodName, in, out, opts...)
+func (c *gatewayServiceClient) AppendChunk(ctx context.Context, in *AppendChunkRequest, opts ...grpc.CallOption) (*AppendChunkResponse, error) {
+	out := new(AppendChunkResponse)
+	err := c.cc.Invoke(ctx, GatewayService_AppendChunk_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *gatewayServiceClient) CordonWorker(ctx context.Context, in *CordonWorkerRequest, opts ...grpc.CallOption) (*CordonWorkerResponse, error) {
-	out := new(CordonWorkerResponse)
-	err := c.cc.Invoke(ctx, GatewayService_CordonWorker_FullMethodName, in, out, opts...)
+func (c *gatewayServiceClient) CompleteUpload(ctx context.Context, in *CompleteUploadRequest, opts ...grpc.CallOption) (*CompleteUploadResponse, error) {
+	out := new(CompleteUploadResponse)
+	err := c.cc.Invoke(ctx, GatewayService_CompleteUpload_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *gatewayServiceClient) UncordonWorker(ctx context.Context, in *UncordonWorkerRequest, opts ...grpc.CallOption) (*UncordonWorkerResponse, error) {
-	out := new(UncordonWorkerResponse)
-	err := c.cc.Invoke(ctx, GatewayService_UncordonWorker_FullMethodName, in, out, opts...)
+func (c *gatewayServiceClient) CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*CreateWebhookResponse, error) {
+	out := new(CreateWebhookResponse)
+	err := c.cc.Invoke(ctx, GatewayService_CreateWebhook_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *gatewayServiceClient) DrainWorker(ctx context.Context, in *DrainWorkerRequest, opts ...grpc.CallOption) (*DrainWorkerResponse, error) {
-	out := new(DrainWorkerResponse)
-	err := c.cc.Invoke(ctx, GatewayService_DrainWorker_FullMethodName, in, out, opts...)
+func (c *gatewayServiceClient) ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error) {
+	out := new(ListWebhooksResponse)
+	err := c.cc.Invoke(ctx, GatewayService_ListWebhooks_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *gatewayServiceClient) ExportWorkspaceConfig(ctx context.Context, in *ExportWorkspaceConfigRequest, opts ...grpc.CallOption) (*ExportWorkspaceConfigResponse, error) {
-	out := new(ExportWorkspaceConfigResponse)
-	err := c.cc.Invoke(ctx, GatewayService_ExportWorkspaceConfig_FullMethodName, in, out, opts...)
+func (c *gatewayServiceClient) DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error) {
+	out := new(DeleteWebhookResponse)
+	err := c.cc.Invoke(ctx, GatewayService_DeleteWebhook_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// GatewayServiceServer is the server API for GatewayService service.
-// All implementations must embed UnimplementedGatewayServiceServer
-// for forward compatibility
-type GatewayServiceServer interface {
-	// Auth
-	Authorize(context.Context, *AuthorizeRequest) (*AuthorizeResponse, error)
-	SignPayload(context.Context, *SignPayloadRequest) (*SignPayloadResponse, error)
-	// Objects
-	HeadObject(context.Context, *HeadObjectRequest) (*HeadObjectResponse, error)
-	CreateObject(context.Context, *CreateObjectRequest) (*CreateObjectResponse, error)
-	PutObjectStream(GatewayService_PutObjectStreamServer) error
-	// Containers
-	CheckpointContainer(context.Context, *CheckpointContainerRequest) (*CheckpointContainerResponse, error)
-	ListContainers(context.Context, *ListContainersRequest) (*ListContainersResponse, error)
-	StopContainer(context.Context, *StopContainerRequest) (*StopContainerResponse, error)
-	AttachToContainer(GatewayService_AttachToContainerServer) error
-	// Tasks
-	StartTask(context.Context, *StartTaskRequest) (*StartTaskResponse, error)
-	EndTask(context.Context, *EndTaskRequest) (*EndTaskResponse, error)
-	StopTasks(context.Context, *StopTasksRequest) (*StopTasksResponse, error)
-	ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error)
-	// Stubs
-	GetOrCreateStub(context.Context, *GetOrCreateStubRequest) (*GetOrCreateStubResponse, error)
-	DeployStub(context.Context, *DeployStubRequest) (*DeployStubResponse, error)
-	GetURL(context.Context, *GetURLRequest) (*GetURLResponse, error)
-	// Deployments
-	ListDeployments(context.Context, *ListDeploymentsRequest) (*ListDeploymentsResponse, error)
-	StopDeployment(context.Context, *StopDeploymentRequest) (*StopDeploymentResponse, error)
-	StartDeployment(context.Context, *StartDeploymentRequest) (*StartDeploymentResponse, error)
-	ScaleDeployment(context.Context, *ScaleDeploymentRequest) (*ScaleDeploymentResponse, error)
-	DeleteDeployment(context.Context, *DeleteDeploymentRequest) (*DeleteDeploymentResponse, error)
-	// Pools
-	ListPools(context.Context, *ListPoolsRequest) (*ListPoolsResponse, error)
-	// Machines
-	ListMachines(context.Context, *ListMachinesRequest) (*ListMachinesResponse, error)
-	CreateMachine(context.Context, *CreateMachineRequest) (*CreateMachineResponse, error)
-	DeleteMachine(context.Context, *DeleteMachineRequest) (*DeleteMachineResponse, error)
-	// Tokens
-	ListTokens(context.Context, *ListTokensRequest) (*ListTokensResponse, error)
-	CreateToken(context.Context, *CreateTokenRequest) (*CreateTokenResponse, error)
-	ToggleToken(context.Context, *ToggleTokenRequest) (*ToggleTokenResponse, error)
-	DeleteToken(context.Context, *DeleteTokenRequest) (*DeleteTokenResponse, error)
-	// Workers
-	ListWorkers(context.Context, *ListWorkersRequest) (*ListWorkersResponse, error)
-	CordonWorker(context.Context, *CordonWorkerRequest) (*CordonWorkerResponse, error)
-	UncordonWorker(context.Context, *UncordonWorkerRequest) (*UncordonWorkerResponse, error)
-	DrainWorker(context.Context, *DrainWorkerRequest) (*DrainWorkerResponse, error)
-	// Workspace
-	ExportWorkspaceConfig(context.Context, *ExportWorkspaceConfigRequest) (*ExportWorkspaceConfigResponse, error)
-	mustEmbedUnimplementedGatewayServiceServer()
+func (c *gatewayServiceClient) CreateTaskTrigger(ctx context.Context, in *CreateTaskTriggerRequest, opts ...grpc.CallOption) (*CreateTaskTriggerResponse, error) {
+	out := new(CreateTaskTriggerResponse)
+	err := c.cc.Invoke(ctx, GatewayService_CreateTaskTrigger_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-// UnimplementedGatewayServiceServer must be embedded to have forward compatible implementations.
-type UnimplementedGatewayServiceServer struct {
+func (c *gatewayServiceClient) ListTaskTriggers(ctx context.Context, in *ListTaskTriggersRequest, opts ...grpc.CallOption) (*ListTaskTriggersResponse, error) {
+	out := new(ListTaskTriggersResponse)
+	err := c.cc.Invoke(ctx, GatewayService_ListTaskTriggers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (UnimplementedGatewayServiceServer) Authorize(context.Context, *AuthorizeRequest) (*AuthorizeResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Authorize not implemented")
+func (c *gatewayServiceClient) DeleteTaskTrigger(ctx context.Context, in *DeleteTaskTriggerRequest, opts ...grpc.CallOption) (*DeleteTaskTriggerResponse, error) {
+	out := new(DeleteTaskTriggerResponse)
+	err := c.cc.Invoke(ctx, GatewayService_DeleteTaskTrigger_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedGatewayServiceServer) SignPayload(context.Context, *SignPayloadRequest) (*SignPayloadResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SignPayload not implemented")
+
+func (c *gatewayServiceClient) SetUploadBandwidthLimit(ctx context.Context, in *SetUploadBandwidthLimitRequest, opts ...grpc.CallOption) (*SetUploadBandwidthLimitResponse, error) {
+	out := new(SetUploadBandwidthLimitResponse)
+	err := c.cc.Invoke(ctx, GatewayService_SetUploadBandwidthLimit_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedGatewayServiceServer) HeadObject(context.Context, *HeadObjectRequest) (*HeadObjectResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method HeadObject not implemented")
+
+func (c *gatewayServiceClient) SetWorkspaceVolumeQuota(ctx context.Context, in *SetWorkspaceVolumeQuotaRequest, opts ...grpc.CallOption) (*SetWorkspaceVolumeQuotaResponse, error) {
+	out := new(SetWorkspaceVolumeQuotaResponse)
+	err := c.cc.Invoke(ctx, GatewayService_SetWorkspaceVolumeQuota_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedGatewayServiceServer) CreateObject(context.Context, *CreateObjectRequest) (*CreateObjectResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateObject not implemented")
+
+func (c *gatewayServiceClient) SetWorkspacePolicy(ctx context.Context, in *SetWorkspacePolicyRequest, opts ...grpc.CallOption) (*SetWorkspacePolicyResponse, error) {
+	out := new(SetWorkspacePolicyResponse)
+	err := c.cc.Invoke(ctx, GatewayService_SetWorkspacePolicy_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedGatewayServiceServer) PutObjectStream(GatewayService_PutObjectStreamServer) error {
-	return status.Errorf(codes.Unimplemented, "method PutObjectStream not implemented")
+
+func (c *gatewayServiceClient) GetWorkspacePolicy(ctx context.Context, in *GetWorkspacePolicyRequest, opts ...grpc.CallOption) (*GetWorkspacePolicyResponse, error) {
+	out := new(GetWorkspacePolicyResponse)
+	err := c.cc.Invoke(ctx, GatewayService_GetWorkspacePolicy_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedGatewayServiceServer) CheckpointContainer(context.Context, *CheckpointContainerRequest) (*CheckpointContainerResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CheckpointContainer not implemented")
+
+func (c *gatewayServiceClient) SetPoolQuota(ctx context.Context, in *SetPoolQuotaRequest, opts ...grpc.CallOption) (*SetPoolQuotaResponse, error) {
+	out := new(SetPoolQuotaResponse)
+	err := c.cc.Invoke(ctx, GatewayService_SetPoolQuota_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedGatewayServiceServer) ListContainers(context.Context, *ListContainersRequest) (*ListContainersResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListContainers not implemented")
+
+func (c *gatewayServiceClient) ListPoolQuotas(ctx context.Context, in *ListPoolQuotasRequest, opts ...grpc.CallOption) (*ListPoolQuotasResponse, error) {
+	out := new(ListPoolQuotasResponse)
+	err := c.cc.Invoke(ctx, GatewayService_ListPoolQuotas_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedGatewayServiceServer) StopContainer(context.Context, *StopContainerRequest) (*StopContainerResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method StopContainer not implemented")
+
+func (c *gatewayServiceClient) ReserveCapacity(ctx context.Context, in *ReserveCapacityRequest, opts ...grpc.CallOption) (*ReserveCapacityResponse, error) {
+	out := new(ReserveCapacityResponse)
+	err := c.cc.Invoke(ctx, GatewayService_ReserveCapacity_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedGatewayServiceServer) AttachToContainer(GatewayService_AttachToContainerServer) error {
-	return status.Errorf(codes.Unimplemented, "method AttachToContainer not implemented")
+
+func (c *gatewayServiceClient) ListCapacityReservations(ctx context.Context, in *ListCapacityReservationsRequest, opts ...grpc.CallOption) (*ListCapacityReservationsResponse, error) {
+	out := new(ListCapacityReservationsResponse)
+	err := c.cc.Invoke(ctx, GatewayService_ListCapacityReservations_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedGatewayServiceServer) StartTask(context.Context, *StartTaskRequest) (*StartTaskResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method StartTask not implemented")
+
+func (c *gatewayServiceClient) CancelCapacityReservation(ctx context.Context, in *CancelCapacityReservationRequest, opts ...grpc.CallOption) (*CancelCapacityReservationResponse, error) {
+	out := new(CancelCapacityReservationResponse)
+	err := c.cc.Invoke(ctx, GatewayService_CancelCapacityReservation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedGatewayServiceServer) EndTask(context.Context, *EndTaskRequest) (*EndTaskResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method EndTask not implemented")
+
+func (c *gatewayServiceClient) GetWorkspaceIPAllowList(ctx context.Context, in *GetWorkspaceIPAllowListRequest, opts ...grpc.CallOption) (*GetWorkspaceIPAllowListResponse, error) {
+	out := new(GetWorkspaceIPAllowListResponse)
+	err := c.cc.Invoke(ctx, GatewayService_GetWorkspaceIPAllowList_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedGatewayServiceServer) StopTasks(context.Context, *StopTasksRequest) (*StopTasksResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method StopTasks not implemented")
+
+func (c *gatewayServiceClient) SetWorkspaceIPAllowList(ctx context.Context, in *SetWorkspaceIPAllowListRequest, opts ...grpc.CallOption) (*SetWorkspaceIPAllowListResponse, error) {
+	out := new(SetWorkspaceIPAllowListResponse)
+	err := c.cc.Invoke(ctx, GatewayService_SetWorkspaceIPAllowList_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedGatewayServiceServer) ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListTasks not implemented")
+
+func (c *gatewayServiceClient) CheckpointContainer(ctx context.Context, in *CheckpointContainerRequest, opts ...grpc.CallOption) (*CheckpointContainerResponse, error) {
+	out := new(CheckpointContainerResponse)
+	err := c.cc.Invoke(ctx, GatewayService_CheckpointContainer_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedGatewayServiceServer) GetOrCreateStub(context.Context, *GetOrCreateStubRequest) (*GetOrCreateStubResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetOrCreateStub not implemented")
+
+func (c *gatewayServiceClient) ListContainers(ctx context.Context, in *ListContainersRequest, opts ...grpc.CallOption) (*ListContainersResponse, error) {
+	out := new(ListContainersResponse)
+	err := c.cc.Invoke(ctx, GatewayService_ListContainers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedGatewayServiceServer) DeployStub(context.Context, *DeployStubRequest) (*DeployStubResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeployStub not implemented")
+
+func (c *gatewayServiceClient) StopContainer(ctx context.Context, in *StopContainerRequest, opts ...grpc.CallOption) (*StopContainerResponse, error) {
+	out := new(StopContainerResponse)
+	err := c.cc.Invoke(ctx, GatewayService_StopContainer_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedGatewayServiceServer) GetURL(context.Context, *GetURLRequest) (*GetURLResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetURL not implemented")
+
+func (c *gatewayServiceClient) AttachToContainer(ctx context.Context, opts ...grpc.CallOption) (GatewayService_AttachToContainerClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GatewayService_ServiceDesc.Streams[4], GatewayService_AttachToContainer_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gatewayServiceAttachToContainerClient{stream}
+	return x, nil
 }
-func (UnimplementedGatewayServiceServer) ListDeployments(context.Context, *ListDeploymentsRequest) (*ListDeploymentsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListDeployments not implemented")
+
+type GatewayService_AttachToContainerClient interface {
+	Send(*ContainerStreamMessage) error
+	Recv() (*AttachToContainerResponse, error)
+	grpc.ClientStream
 }
-func (UnimplementedGatewayServiceServer) StopDeployment(context.Context, *StopDeploymentRequest) (*StopDeploymentResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method StopDeployment not implemented")
+
+type gatewayServiceAttachToContainerClient struct {
+	grpc.ClientStream
 }
-func (UnimplementedGatewayServiceServer) StartDeployment(context.Context, *StartDeploymentRequest) (*StartDeploymentResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method StartDeployment not implemented")
+
+func (x *gatewayServiceAttachToContainerClient) Send(m *ContainerStreamMessage) error {
+	return x.ClientStream.SendMsg(m)
 }
-func (UnimplementedGatewayServiceServer) ScaleDeployment(context.Context, *ScaleDeploymentRequest) (*ScaleDeploymentResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ScaleDeployment not implemented")
+
+func (x *gatewayServiceAttachToContainerClient) Recv() (*AttachToContainerResponse, error) {
+	m := new(AttachToContainerResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
-func (UnimplementedGatewayServiceServer) DeleteDeployment(context.Context, *DeleteDeploymentRequest) (*DeleteDeploymentResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteDeployment not implemented")
+
+func (c *gatewayServiceClient) ExecInContainer(ctx context.Context, opts ...grpc.CallOption) (GatewayService_ExecInContainerClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GatewayService_ServiceDesc.Streams[5], GatewayService_ExecInContainer_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gatewayServiceExecInContainerClient{stream}
+	return x, nil
 }
-func (UnimplementedGatewayServiceServer) ListPools(context.Context, *ListPoolsRequest) (*ListPoolsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListPools not implemented")
+
+type GatewayService_ExecInContainerClient interface {
+	Send(*ExecInContainerRequest) error
+	Recv() (*ExecInContainerResponse, error)
+	grpc.ClientStream
 }
-func (UnimplementedGatewayServiceServer) ListMachines(context.Context, *ListMachinesRequest) (*ListMachinesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListMachines not implemented")
+
+type gatewayServiceExecInContainerClient struct {
+	grpc.ClientStream
 }
-func (UnimplementedGatewayServiceServer) CreateMachine(context.Context, *CreateMachineRequest) (*CreateMachineResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateMachine not implemented")
+
+func (x *gatewayServiceExecInContainerClient) Send(m *ExecInContainerRequest) error {
+	return x.ClientStream.SendMsg(m)
 }
-func (UnimplementedGatewayServiceServer) DeleteMachine(context.Context, *DeleteMachineRequest) (*DeleteMachineResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteMachine not implemented")
+
+func (x *gatewayServiceExecInContainerClient) Recv() (*ExecInContainerResponse, error) {
+	m := new(ExecInContainerResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
-func (UnimplementedGatewayServiceServer) ListTokens(context.Context, *ListTokensRequest) (*ListTokensResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListTokens not implemented")
+
+func (c *gatewayServiceClient) StreamLogs(ctx context.Context, in *StreamLogsRequest, opts ...grpc.CallOption) (GatewayService_StreamLogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GatewayService_ServiceDesc.Streams[6], GatewayService_StreamLogs_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gatewayServiceStreamLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
-func (UnimplementedGatewayServiceServer) CreateToken(context.Context, *CreateTokenRequest) (*CreateTokenResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateToken not implemented")
+
+type GatewayService_StreamLogsClient interface {
+	Recv() (*StreamLogsResponse, error)
+	grpc.ClientStream
 }
-func (UnimplementedGatewayServiceServer) ToggleToken(context.Context, *ToggleTokenRequest) (*ToggleTokenResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ToggleToken not implemented")
+
+type gatewayServiceStreamLogsClient struct {
+	grpc.ClientStream
 }
-func (UnimplementedGatewayServiceServer) DeleteToken(context.Context, *DeleteTokenRequest) (*DeleteTokenResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteToken not implemented")
+
+func (x *gatewayServiceStreamLogsClient) Recv() (*StreamLogsResponse, error) {
+	m := new(StreamLogsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
-func (UnimplementedGatewayServiceServer) ListWorkers(context.Context, *ListWorkersRequest) (*ListWorkersResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListWorkers not implemented")
+
+func (c *gatewayServiceClient) PortForward(ctx context.Context, opts ...grpc.CallOption) (GatewayService_PortForwardClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GatewayService_ServiceDesc.Streams[7], GatewayService_PortForward_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gatewayServicePortForwardClient{stream}
+	return x, nil
 }
-func (UnimplementedGatewayServiceServer) CordonWorker(context.Context, *CordonWorkerRequest) (*CordonWorkerResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CordonWorker not implemented")
+
+type GatewayService_PortForwardClient interface {
+	Send(*PortForwardRequest) error
+	Recv() (*PortForwardResponse, error)
+	grpc.ClientStream
 }
-func (UnimplementedGatewayServiceServer) UncordonWorker(context.Context, *UncordonWorkerRequest) (*UncordonWorkerResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UncordonWorker not implemented")
+
+type gatewayServicePortForwardClient struct {
+	grpc.ClientStream
 }
-func (UnimplementedGatewayServiceServer) DrainWorker(context.Context, *DrainWorkerRequest) (*DrainWorkerResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DrainWorker not implemented")
+
+func (x *gatewayServicePortForwardClient) Send(m *PortForwardRequest) error {
+	return x.ClientStream.SendMsg(m)
 }
-func (UnimplementedGatewayServiceServer) ExportWorkspaceConfig(context.Context, *ExportWorkspaceConfigRequest) (*ExportWorkspaceConfigResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ExportWorkspaceConfig not implemented")
+
+func (x *gatewayServicePortForwardClient) Recv() (*PortForwardResponse, error) {
+	m := new(PortForwardResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
-func (UnimplementedGatewayServiceServer) mustEmbedUnimplementedGatewayServiceServer() {}
 
-// UnsafeGatewayServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to GatewayServiceServer will
-// result in compilation errors.
-type UnsafeGatewayServiceServer interface {
-	mustEmbedUnimplementedGatewayServiceServer()
+func (c *gatewayServiceClient) StartTask(ctx context.Context, in *StartTaskRequest, opts ...grpc.CallOption) (*StartTaskResponse, error) {
+	out := new(StartTaskResponse)
+	err := c.cc.Invoke(ctx, GatewayService_StartTask_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func RegisterGatewayServiceServer(s grpc.ServiceRegistrar, srv GatewayServiceServer) {
-	s.RegisterService(&GatewayService_ServiceDesc, srv)
+func (c *gatewayServiceClient) EndTask(ctx context.Context, in *EndTaskRequest, opts ...grpc.CallOption) (*EndTaskResponse, error) {
+	out := new(EndTaskResponse)
+	err := c.cc.Invoke(ctx, GatewayService_EndTask_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) StopTasks(ctx context.Context, in *StopTasksRequest, opts ...grpc.CallOption) (*StopTasksResponse, error) {
+	out := new(StopTasksResponse)
+	err := c.cc.Invoke(ctx, GatewayService_StopTasks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) ListTasks(ctx context.Context, in *ListTasksRequest, opts ...grpc.CallOption) (*ListTasksResponse, error) {
+	out := new(ListTasksResponse)
+	err := c.cc.Invoke(ctx, GatewayService_ListTasks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) CancelTasks(ctx context.Context, in *CancelTasksRequest, opts ...grpc.CallOption) (*CancelTasksResponse, error) {
+	out := new(CancelTasksResponse)
+	err := c.cc.Invoke(ctx, GatewayService_CancelTasks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) RequeueTasks(ctx context.Context, in *RequeueTasksRequest, opts ...grpc.CallOption) (*RequeueTasksResponse, error) {
+	out := new(RequeueTasksResponse)
+	err := c.cc.Invoke(ctx, GatewayService_RequeueTasks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) SetTaskRetryPolicy(ctx context.Context, in *SetTaskRetryPolicyRequest, opts ...grpc.CallOption) (*SetTaskRetryPolicyResponse, error) {
+	out := new(SetTaskRetryPolicyResponse)
+	err := c.cc.Invoke(ctx, GatewayService_SetTaskRetryPolicy_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) GetTaskRetryPolicy(ctx context.Context, in *GetTaskRetryPolicyRequest, opts ...grpc.CallOption) (*GetTaskRetryPolicyResponse, error) {
+	out := new(GetTaskRetryPolicyResponse)
+	err := c.cc.Invoke(ctx, GatewayService_GetTaskRetryPolicy_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) GetOrCreateStub(ctx context.Context, in *GetOrCreateStubRequest, opts ...grpc.CallOption) (*GetOrCreateStubResponse, error) {
+	out := new(GetOrCreateStubResponse)
+	err := c.cc.Invoke(ctx, GatewayService_GetOrCreateStub_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) DeployStub(ctx context.Context, in *DeployStubRequest, opts ...grpc.CallOption) (*DeployStubResponse, error) {
+	out := new(DeployStubResponse)
+	err := c.cc.Invoke(ctx, GatewayService_DeployStub_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) GetURL(ctx context.Context, in *GetURLRequest, opts ...grpc.CallOption) (*GetURLResponse, error) {
+	out := new(GetURLResponse)
+	err := c.cc.Invoke(ctx, GatewayService_GetURL_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) ListDeployments(ctx context.Context, in *ListDeploymentsRequest, opts ...grpc.CallOption) (*ListDeploymentsResponse, error) {
+	out := new(ListDeploymentsResponse)
+	err := c.cc.Invoke(ctx, GatewayService_ListDeployments_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) StopDeployment(ctx context.Context, in *StopDeploymentRequest, opts ...grpc.CallOption) (*StopDeploymentResponse, error) {
+	out := new(StopDeploymentResponse)
+	err := c.cc.Invoke(ctx, GatewayService_StopDeployment_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) StartDeployment(ctx context.Context, in *StartDeploymentRequest, opts ...grpc.CallOption) (*StartDeploymentResponse, error) {
+	out := new(StartDeploymentResponse)
+	err := c.cc.Invoke(ctx, GatewayService_StartDeployment_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) ScaleDeployment(ctx context.Context, in *ScaleDeploymentRequest, opts ...grpc.CallOption) (*ScaleDeploymentResponse, error) {
+	out := new(ScaleDeploymentResponse)
+	err := c.cc.Invoke(ctx, GatewayService_ScaleDeployment_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) DeleteDeployment(ctx context.Context, in *DeleteDeploymentRequest, opts ...grpc.CallOption) (*DeleteDeploymentResponse, error) {
+	out := new(DeleteDeploymentResponse)
+	err := c.cc.Invoke(ctx, GatewayService_DeleteDeployment_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) RollbackDeployment(ctx context.Context, in *RollbackDeploymentRequest, opts ...grpc.CallOption) (*RollbackDeploymentResponse, error) {
+	out := new(RollbackDeploymentResponse)
+	err := c.cc.Invoke(ctx, GatewayService_RollbackDeployment_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) SetDeploymentEnv(ctx context.Context, in *SetDeploymentEnvRequest, opts ...grpc.CallOption) (*SetDeploymentEnvResponse, error) {
+	out := new(SetDeploymentEnvResponse)
+	err := c.cc.Invoke(ctx, GatewayService_SetDeploymentEnv_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) UnsetDeploymentEnv(ctx context.Context, in *UnsetDeploymentEnvRequest, opts ...grpc.CallOption) (*UnsetDeploymentEnvResponse, error) {
+	out := new(UnsetDeploymentEnvResponse)
+	err := c.cc.Invoke(ctx, GatewayService_UnsetDeploymentEnv_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) ListDeploymentEnv(ctx context.Context, in *ListDeploymentEnvRequest, opts ...grpc.CallOption) (*ListDeploymentEnvResponse, error) {
+	out := new(ListDeploymentEnvResponse)
+	err := c.cc.Invoke(ctx, GatewayService_ListDeploymentEnv_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) AddCustomDomain(ctx context.Context, in *AddCustomDomainRequest, opts ...grpc.CallOption) (*AddCustomDomainResponse, error) {
+	out := new(AddCustomDomainResponse)
+	err := c.cc.Invoke(ctx, GatewayService_AddCustomDomain_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) ListCustomDomains(ctx context.Context, in *ListCustomDomainsRequest, opts ...grpc.CallOption) (*ListCustomDomainsResponse, error) {
+	out := new(ListCustomDomainsResponse)
+	err := c.cc.Invoke(ctx, GatewayService_ListCustomDomains_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) RemoveCustomDomain(ctx context.Context, in *RemoveCustomDomainRequest, opts ...grpc.CallOption) (*RemoveCustomDomainResponse, error) {
+	out := new(RemoveCustomDomainResponse)
+	err := c.cc.Invoke(ctx, GatewayService_RemoveCustomDomain_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) ListDeploymentHistory(ctx context.Context, in *ListDeploymentHistoryRequest, opts ...grpc.CallOption) (*ListDeploymentHistoryResponse, error) {
+	out := new(ListDeploymentHistoryResponse)
+	err := c.cc.Invoke(ctx, GatewayService_ListDeploymentHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) SetTrafficSplit(ctx context.Context, in *SetTrafficSplitRequest, opts ...grpc.CallOption) (*SetTrafficSplitResponse, error) {
+	out := new(SetTrafficSplitResponse)
+	err := c.cc.Invoke(ctx, GatewayService_SetTrafficSplit_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) PromoteVersion(ctx context.Context, in *PromoteVersionRequest, opts ...grpc.CallOption) (*PromoteVersionResponse, error) {
+	out := new(PromoteVersionResponse)
+	err := c.cc.Invoke(ctx, GatewayService_PromoteVersion_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) SetAutoscalingPolicy(ctx context.Context, in *SetAutoscalingPolicyRequest, opts ...grpc.CallOption) (*SetAutoscalingPolicyResponse, error) {
+	out := new(SetAutoscalingPolicyResponse)
+	err := c.cc.Invoke(ctx, GatewayService_SetAutoscalingPolicy_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) GetAutoscalingPolicy(ctx context.Context, in *GetAutoscalingPolicyRequest, opts ...grpc.CallOption) (*GetAutoscalingPolicyResponse, error) {
+	out := new(GetAutoscalingPolicyResponse)
+	err := c.cc.Invoke(ctx, GatewayService_GetAutoscalingPolicy_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) ReportAutoscalingMetrics(ctx context.Context, in *ReportAutoscalingMetricsRequest, opts ...grpc.CallOption) (*ReportAutoscalingMetricsResponse, error) {
+	out := new(ReportAutoscalingMetricsResponse)
+	err := c.cc.Invoke(ctx, GatewayService_ReportAutoscalingMetrics_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) SetKeepWarm(ctx context.Context, in *SetKeepWarmRequest, opts ...grpc.CallOption) (*SetKeepWarmResponse, error) {
+	out := new(SetKeepWarmResponse)
+	err := c.cc.Invoke(ctx, GatewayService_SetKeepWarm_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) GetKeepWarm(ctx context.Context, in *GetKeepWarmRequest, opts ...grpc.CallOption) (*GetKeepWarmResponse, error) {
+	out := new(GetKeepWarmResponse)
+	err := c.cc.Invoke(ctx, GatewayService_GetKeepWarm_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) ListPools(ctx context.Context, in *ListPoolsRequest, opts ...grpc.CallOption) (*ListPoolsResponse, error) {
+	out := new(ListPoolsResponse)
+	err := c.cc.Invoke(ctx, GatewayService_ListPools_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) ListMachines(ctx context.Context, in *ListMachinesRequest, opts ...grpc.CallOption) (*ListMachinesResponse, error) {
+	out := new(ListMachinesResponse)
+	err := c.cc.Invoke(ctx, GatewayService_ListMachines_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) CreateMachine(ctx context.Context, in *CreateMachineRequest, opts ...grpc.CallOption) (*CreateMachineResponse, error) {
+	out := new(CreateMachineResponse)
+	err := c.cc.Invoke(ctx, GatewayService_CreateMachine_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) DeleteMachine(ctx context.Context, in *DeleteMachineRequest, opts ...grpc.CallOption) (*DeleteMachineResponse, error) {
+	out := new(DeleteMachineResponse)
+	err := c.cc.Invoke(ctx, GatewayService_DeleteMachine_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) ListTokens(ctx context.Context, in *ListTokensRequest, opts ...grpc.CallOption) (*ListTokensResponse, error) {
+	out := new(ListTokensResponse)
+	err := c.cc.Invoke(ctx, GatewayService_ListTokens_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) CreateToken(ctx context.Context, in *CreateTokenRequest, opts ...grpc.CallOption) (*CreateTokenResponse, error) {
+	out := new(CreateTokenResponse)
+	err := c.cc.Invoke(ctx, GatewayService_CreateToken_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) ToggleToken(ctx context.Context, in *ToggleTokenRequest, opts ...grpc.CallOption) (*ToggleTokenResponse, error) {
+	out := new(ToggleTokenResponse)
+	err := c.cc.Invoke(ctx, GatewayService_ToggleToken_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) DeleteToken(ctx context.Context, in *DeleteTokenRequest, opts ...grpc.CallOption) (*DeleteTokenResponse, error) {
+	out := new(DeleteTokenResponse)
+	err := c.cc.Invoke(ctx, GatewayService_DeleteToken_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) SetTokenRateLimit(ctx context.Context, in *SetTokenRateLimitRequest, opts ...grpc.CallOption) (*SetTokenRateLimitResponse, error) {
+	out := new(SetTokenRateLimitResponse)
+	err := c.cc.Invoke(ctx, GatewayService_SetTokenRateLimit_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) RotateToken(ctx context.Context, in *RotateTokenRequest, opts ...grpc.CallOption) (*RotateTokenResponse, error) {
+	out := new(RotateTokenResponse)
+	err := c.cc.Invoke(ctx, GatewayService_RotateToken_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) ExchangeSessionToken(ctx context.Context, in *ExchangeSessionTokenRequest, opts ...grpc.CallOption) (*ExchangeSessionTokenResponse, error) {
+	out := new(ExchangeSessionTokenResponse)
+	err := c.cc.Invoke(ctx, GatewayService_ExchangeSessionToken_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) RefreshSessionToken(ctx context.Context, in *RefreshSessionTokenRequest, opts ...grpc.CallOption) (*RefreshSessionTokenResponse, error) {
+	out := new(RefreshSessionTokenResponse)
+	err := c.cc.Invoke(ctx, GatewayService_RefreshSessionToken_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) RevokeSessionToken(ctx context.Context, in *RevokeSessionTokenRequest, opts ...grpc.CallOption) (*RevokeSessionTokenResponse, error) {
+	out := new(RevokeSessionTokenResponse)
+	err := c.cc.Invoke(ctx, GatewayService_RevokeSessionToken_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) ListMemberRoles(ctx context.Context, in *ListMemberRolesRequest, opts ...grpc.CallOption) (*ListMemberRolesResponse, error) {
+	out := new(ListMemberRolesResponse)
+	err := c.cc.Invoke(ctx, GatewayService_ListMemberRoles_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) SetMemberRole(ctx context.Context, in *SetMemberRoleRequest, opts ...grpc.CallOption) (*SetMemberRoleResponse, error) {
+	out := new(SetMemberRoleResponse)
+	err := c.cc.Invoke(ctx, GatewayService_SetMemberRole_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) InviteMember(ctx context.Context, in *InviteMemberRequest, opts ...grpc.CallOption) (*InviteMemberResponse, error) {
+	out := new(InviteMemberResponse)
+	err := c.cc.Invoke(ctx, GatewayService_InviteMember_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) AcceptInvite(ctx context.Context, in *AcceptInviteRequest, opts ...grpc.CallOption) (*AcceptInviteResponse, error) {
+	out := new(AcceptInviteResponse)
+	err := c.cc.Invoke(ctx, GatewayService_AcceptInvite_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) RevokeInvite(ctx context.Context, in *RevokeInviteRequest, opts ...grpc.CallOption) (*RevokeInviteResponse, error) {
+	out := new(RevokeInviteResponse)
+	err := c.cc.Invoke(ctx, GatewayService_RevokeInvite_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) CreateServiceAccount(ctx context.Context, in *CreateServiceAccountRequest, opts ...grpc.CallOption) (*CreateServiceAccountResponse, error) {
+	out := new(CreateServiceAccountResponse)
+	err := c.cc.Invoke(ctx, GatewayService_CreateServiceAccount_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) ListServiceAccounts(ctx context.Context, in *ListServiceAccountsRequest, opts ...grpc.CallOption) (*ListServiceAccountsResponse, error) {
+	out := new(ListServiceAccountsResponse)
+	err := c.cc.Invoke(ctx, GatewayService_ListServiceAccounts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) DeleteServiceAccount(ctx context.Context, in *DeleteServiceAccountRequest, opts ...grpc.CallOption) (*DeleteServiceAccountResponse, error) {
+	out := new(DeleteServiceAccountResponse)
+	err := c.cc.Invoke(ctx, GatewayService_DeleteServiceAccount_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) IssueServiceAccountToken(ctx context.Context, in *IssueServiceAccountTokenRequest, opts ...grpc.CallOption) (*IssueServiceAccountTokenResponse, error) {
+	out := new(IssueServiceAccountTokenResponse)
+	err := c.cc.Invoke(ctx, GatewayService_IssueServiceAccountToken_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) RenewServiceAccountToken(ctx context.Context, in *RenewServiceAccountTokenRequest, opts ...grpc.CallOption) (*RenewServiceAccountTokenResponse, error) {
+	out := new(RenewServiceAccountTokenResponse)
+	err := c.cc.Invoke(ctx, GatewayService_RenewServiceAccountToken_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) RevokeServiceAccountToken(ctx context.Context, in *RevokeServiceAccountTokenRequest, opts ...grpc.CallOption) (*RevokeServiceAccountTokenResponse, error) {
+	out := new(RevokeServiceAccountTokenResponse)
+	err := c.cc.Invoke(ctx, GatewayService_RevokeServiceAccountToken_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) ListWorkers(ctx context.Context, in *ListWorkersRequest, opts ...grpc.CallOption) (*ListWorkersResponse, error) {
+	out := new(ListWorkersResponse)
+	err := c.cc.Invoke(ctx, GatewayService_ListWorkers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) CordonWorker(ctx context.Context, in *CordonWorkerRequest, opts ...grpc.CallOption) (*CordonWorkerResponse, error) {
+	out := new(CordonWorkerResponse)
+	err := c.cc.Invoke(ctx, GatewayService_CordonWorker_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) UncordonWorker(ctx context.Context, in *UncordonWorkerRequest, opts ...grpc.CallOption) (*UncordonWorkerResponse, error) {
+	out := new(UncordonWorkerResponse)
+	err := c.cc.Invoke(ctx, GatewayService_UncordonWorker_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) DrainWorker(ctx context.Context, in *DrainWorkerRequest, opts ...grpc.CallOption) (*DrainWorkerResponse, error) {
+	out := new(DrainWorkerResponse)
+	err := c.cc.Invoke(ctx, GatewayService_DrainWorker_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) SetWorkerLabels(ctx context.Context, in *SetWorkerLabelsRequest, opts ...grpc.CallOption) (*SetWorkerLabelsResponse, error) {
+	out := new(SetWorkerLabelsResponse)
+	err := c.cc.Invoke(ctx, GatewayService_SetWorkerLabels_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) SimulateScheduling(ctx context.Context, in *SimulateSchedulingRequest, opts ...grpc.CallOption) (*SimulateSchedulingResponse, error) {
+	out := new(SimulateSchedulingResponse)
+	err := c.cc.Invoke(ctx, GatewayService_SimulateScheduling_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) ExportWorkspaceConfig(ctx context.Context, in *ExportWorkspaceConfigRequest, opts ...grpc.CallOption) (*ExportWorkspaceConfigResponse, error) {
+	out := new(ExportWorkspaceConfigResponse)
+	err := c.cc.Invoke(ctx, GatewayService_ExportWorkspaceConfig_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) GrantResourceAccess(ctx context.Context, in *GrantResourceAccessRequest, opts ...grpc.CallOption) (*GrantResourceAccessResponse, error) {
+	out := new(GrantResourceAccessResponse)
+	err := c.cc.Invoke(ctx, GatewayService_GrantResourceAccess_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) ListResourceGrants(ctx context.Context, in *ListResourceGrantsRequest, opts ...grpc.CallOption) (*ListResourceGrantsResponse, error) {
+	out := new(ListResourceGrantsResponse)
+	err := c.cc.Invoke(ctx, GatewayService_ListResourceGrants_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) QueryAuditLog(ctx context.Context, in *QueryAuditLogRequest, opts ...grpc.CallOption) (*QueryAuditLogResponse, error) {
+	out := new(QueryAuditLogResponse)
+	err := c.cc.Invoke(ctx, GatewayService_QueryAuditLog_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GatewayServiceServer is the server API for GatewayService service.
+// All implementations must embed UnimplementedGatewayServiceServer
+// for forward compatibility
+type GatewayServiceServer interface {
+	// Auth
+	Authorize(context.Context, *AuthorizeRequest) (*AuthorizeResponse, error)
+	SignPayload(context.Context, *SignPayloadRequest) (*SignPayloadResponse, error)
+	// Objects
+	HeadObject(context.Context, *HeadObjectRequest) (*HeadObjectResponse, error)
+	ListObjects(context.Context, *ListObjectsRequest) (*ListObjectsResponse, error)
+	CreateObject(context.Context, *CreateObjectRequest) (*CreateObjectResponse, error)
+	PutObjectStream(GatewayService_PutObjectStreamServer) error
+	PutObjectStreamV2(GatewayService_PutObjectStreamV2Server) error
+	GetObjectSignature(context.Context, *GetObjectSignatureRequest) (*GetObjectSignatureResponse, error)
+	PatchObject(GatewayService_PatchObjectServer) error
+	GetObjectURL(context.Context, *GetObjectURLRequest) (*GetObjectURLResponse, error)
+	GetObjectStream(*GetObjectStreamRequest, GatewayService_GetObjectStreamServer) error
+	RenameObject(context.Context, *RenameObjectRequest) (*RenameObjectResponse, error)
+	TagObject(context.Context, *TagObjectRequest) (*TagObjectResponse, error)
+	GetResourceACL(context.Context, *GetResourceACLRequest) (*GetResourceACLResponse, error)
+	SetResourceACL(context.Context, *SetResourceACLRequest) (*SetResourceACLResponse, error)
+	MigrateObjects(context.Context, *MigrateObjectsRequest) (*MigrateObjectsResponse, error)
+	CompleteObject(context.Context, *CompleteObjectRequest) (*CompleteObjectResponse, error)
+	DeleteObject(context.Context, *DeleteObjectRequest) (*DeleteObjectResponse, error)
+	DeleteObjects(context.Context, *DeleteObjectsRequest) (*DeleteObjectsResponse, error)
+	LockObject(context.Context, *LockObjectRequest) (*LockObjectResponse, error)
+	ListCorruptedObjects(context.Context, *ListCorruptedObjectsRequest) (*ListCorruptedObjectsResponse, error)
+	CheckObjectConsistency(context.Context, *CheckObjectConsistencyRequest) (*CheckObjectConsistencyResponse, error)
+	SetObjectLifecyclePolicy(context.Context, *SetObjectLifecyclePolicyRequest) (*SetObjectLifecyclePolicyResponse, error)
+	GetObjectLifecyclePolicy(context.Context, *GetObjectLifecyclePolicyRequest) (*GetObjectLifecyclePolicyResponse, error)
+	ListObjectVersions(context.Context, *ListObjectVersionsRequest) (*ListObjectVersionsResponse, error)
+	RestoreObjectVersion(context.Context, *RestoreObjectVersionRequest) (*RestoreObjectVersionResponse, error)
+	CopyObject(context.Context, *CopyObjectRequest) (*CopyObjectResponse, error)
+	ExtractObject(context.Context, *ExtractObjectRequest) (*ExtractObjectResponse, error)
+	GetStorageUsage(context.Context, *GetStorageUsageRequest) (*GetStorageUsageResponse, error)
+	InitiateUpload(context.Context, *InitiateUploadRequest) (*InitiateUploadResponse, error)
+	AppendChunk(context.Context, *AppendChunkRequest) (*AppendChunkResponse, error)
+	CompleteUpload(context.Context, *CompleteUploadRequest) (*CompleteUploadResponse, error)
+	CreateWebhook(context.Context, *CreateWebhookRequest) (*CreateWebhookResponse, error)
+	ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error)
+	DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error)
+	CreateTaskTrigger(context.Context, *CreateTaskTriggerRequest) (*CreateTaskTriggerResponse, error)
+	ListTaskTriggers(context.Context, *ListTaskTriggersRequest) (*ListTaskTriggersResponse, error)
+	DeleteTaskTrigger(context.Context, *DeleteTaskTriggerRequest) (*DeleteTaskTriggerResponse, error)
+	SetUploadBandwidthLimit(context.Context, *SetUploadBandwidthLimitRequest) (*SetUploadBandwidthLimitResponse, error)
+	SetWorkspaceVolumeQuota(context.Context, *SetWorkspaceVolumeQuotaRequest) (*SetWorkspaceVolumeQuotaResponse, error)
+	SetWorkspacePolicy(context.Context, *SetWorkspacePolicyRequest) (*SetWorkspacePolicyResponse, error)
+	GetWorkspacePolicy(context.Context, *GetWorkspacePolicyRequest) (*GetWorkspacePolicyResponse, error)
+	SetPoolQuota(context.Context, *SetPoolQuotaRequest) (*SetPoolQuotaResponse, error)
+	ListPoolQuotas(context.Context, *ListPoolQuotasRequest) (*ListPoolQuotasResponse, error)
+	ReserveCapacity(context.Context, *ReserveCapacityRequest) (*ReserveCapacityResponse, error)
+	ListCapacityReservations(context.Context, *ListCapacityReservationsRequest) (*ListCapacityReservationsResponse, error)
+	CancelCapacityReservation(context.Context, *CancelCapacityReservationRequest) (*CancelCapacityReservationResponse, error)
+	GetWorkspaceIPAllowList(context.Context, *GetWorkspaceIPAllowListRequest) (*GetWorkspaceIPAllowListResponse, error)
+	SetWorkspaceIPAllowList(context.Context, *SetWorkspaceIPAllowListRequest) (*SetWorkspaceIPAllowListResponse, error)
+	// Containers
+	CheckpointContainer(context.Context, *CheckpointContainerRequest) (*CheckpointContainerResponse, error)
+	ListContainers(context.Context, *ListContainersRequest) (*ListContainersResponse, error)
+	StopContainer(context.Context, *StopContainerRequest) (*StopContainerResponse, error)
+	AttachToContainer(GatewayService_AttachToContainerServer) error
+	ExecInContainer(GatewayService_ExecInContainerServer) error
+	StreamLogs(*StreamLogsRequest, GatewayService_StreamLogsServer) error
+	PortForward(GatewayService_PortForwardServer) error
+	// Tasks
+	StartTask(context.Context, *StartTaskRequest) (*StartTaskResponse, error)
+	EndTask(context.Context, *EndTaskRequest) (*EndTaskResponse, error)
+	StopTasks(context.Context, *StopTasksRequest) (*StopTasksResponse, error)
+	ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error)
+	CancelTasks(context.Context, *CancelTasksRequest) (*CancelTasksResponse, error)
+	RequeueTasks(context.Context, *RequeueTasksRequest) (*RequeueTasksResponse, error)
+	SetTaskRetryPolicy(context.Context, *SetTaskRetryPolicyRequest) (*SetTaskRetryPolicyResponse, error)
+	GetTaskRetryPolicy(context.Context, *GetTaskRetryPolicyRequest) (*GetTaskRetryPolicyResponse, error)
+	// Stubs
+	GetOrCreateStub(context.Context, *GetOrCreateStubRequest) (*GetOrCreateStubResponse, error)
+	DeployStub(context.Context, *DeployStubRequest) (*DeployStubResponse, error)
+	GetURL(context.Context, *GetURLRequest) (*GetURLResponse, error)
+	// Deployments
+	ListDeployments(context.Context, *ListDeploymentsRequest) (*ListDeploymentsResponse, error)
+	StopDeployment(context.Context, *StopDeploymentRequest) (*StopDeploymentResponse, error)
+	StartDeployment(context.Context, *StartDeploymentRequest) (*StartDeploymentResponse, error)
+	ScaleDeployment(context.Context, *ScaleDeploymentRequest) (*ScaleDeploymentResponse, error)
+	DeleteDeployment(context.Context, *DeleteDeploymentRequest) (*DeleteDeploymentResponse, error)
+	RollbackDeployment(context.Context, *RollbackDeploymentRequest) (*RollbackDeploymentResponse, error)
+	SetDeploymentEnv(context.Context, *SetDeploymentEnvRequest) (*SetDeploymentEnvResponse, error)
+	UnsetDeploymentEnv(context.Context, *UnsetDeploymentEnvRequest) (*UnsetDeploymentEnvResponse, error)
+	ListDeploymentEnv(context.Context, *ListDeploymentEnvRequest) (*ListDeploymentEnvResponse, error)
+	AddCustomDomain(context.Context, *AddCustomDomainRequest) (*AddCustomDomainResponse, error)
+	ListCustomDomains(context.Context, *ListCustomDomainsRequest) (*ListCustomDomainsResponse, error)
+	RemoveCustomDomain(context.Context, *RemoveCustomDomainRequest) (*RemoveCustomDomainResponse, error)
+	ListDeploymentHistory(context.Context, *ListDeploymentHistoryRequest) (*ListDeploymentHistoryResponse, error)
+	SetTrafficSplit(context.Context, *SetTrafficSplitRequest) (*SetTrafficSplitResponse, error)
+	PromoteVersion(context.Context, *PromoteVersionRequest) (*PromoteVersionResponse, error)
+	// Autoscaling
+	SetAutoscalingPolicy(context.Context, *SetAutoscalingPolicyRequest) (*SetAutoscalingPolicyResponse, error)
+	GetAutoscalingPolicy(context.Context, *GetAutoscalingPolicyRequest) (*GetAutoscalingPolicyResponse, error)
+	ReportAutoscalingMetrics(context.Context, *ReportAutoscalingMetricsRequest) (*ReportAutoscalingMetricsResponse, error)
+	SetKeepWarm(context.Context, *SetKeepWarmRequest) (*SetKeepWarmResponse, error)
+	GetKeepWarm(context.Context, *GetKeepWarmRequest) (*GetKeepWarmResponse, error)
+	// Pools
+	ListPools(context.Context, *ListPoolsRequest) (*ListPoolsResponse, error)
+	// Machines
+	ListMachines(context.Context, *ListMachinesRequest) (*ListMachinesResponse, error)
+	CreateMachine(context.Context, *CreateMachineRequest) (*CreateMachineResponse, error)
+	DeleteMachine(context.Context, *DeleteMachineRequest) (*DeleteMachineResponse, error)
+	// Tokens
+	ListTokens(context.Context, *ListTokensRequest) (*ListTokensResponse, error)
+	CreateToken(context.Context, *CreateTokenRequest) (*CreateTokenResponse, error)
+	ToggleToken(context.Context, *ToggleTokenRequest) (*ToggleTokenResponse, error)
+	DeleteToken(context.Context, *DeleteTokenRequest) (*DeleteTokenResponse, error)
+	SetTokenRateLimit(context.Context, *SetTokenRateLimitRequest) (*SetTokenRateLimitResponse, error)
+	RotateToken(context.Context, *RotateTokenRequest) (*RotateTokenResponse, error)
+	ExchangeSessionToken(context.Context, *ExchangeSessionTokenRequest) (*ExchangeSessionTokenResponse, error)
+	RefreshSessionToken(context.Context, *RefreshSessionTokenRequest) (*RefreshSessionTokenResponse, error)
+	RevokeSessionToken(context.Context, *RevokeSessionTokenRequest) (*RevokeSessionTokenResponse, error)
+	// Members
+	ListMemberRoles(context.Context, *ListMemberRolesRequest) (*ListMemberRolesResponse, error)
+	SetMemberRole(context.Context, *SetMemberRoleRequest) (*SetMemberRoleResponse, error)
+	InviteMember(context.Context, *InviteMemberRequest) (*InviteMemberResponse, error)
+	AcceptInvite(context.Context, *AcceptInviteRequest) (*AcceptInviteResponse, error)
+	RevokeInvite(context.Context, *RevokeInviteRequest) (*RevokeInviteResponse, error)
+	// Service accounts
+	CreateServiceAccount(context.Context, *CreateServiceAccountRequest) (*CreateServiceAccountResponse, error)
+	ListServiceAccounts(context.Context, *ListServiceAccountsRequest) (*ListServiceAccountsResponse, error)
+	DeleteServiceAccount(context.Context, *DeleteServiceAccountRequest) (*DeleteServiceAccountResponse, error)
+	IssueServiceAccountToken(context.Context, *IssueServiceAccountTokenRequest) (*IssueServiceAccountTokenResponse, error)
+	RenewServiceAccountToken(context.Context, *RenewServiceAccountTokenRequest) (*RenewServiceAccountTokenResponse, error)
+	RevokeServiceAccountToken(context.Context, *RevokeServiceAccountTokenRequest) (*RevokeServiceAccountTokenResponse, error)
+	// Workers
+	ListWorkers(context.Context, *ListWorkersRequest) (*ListWorkersResponse, error)
+	CordonWorker(context.Context, *CordonWorkerRequest) (*CordonWorkerResponse, error)
+	UncordonWorker(context.Context, *UncordonWorkerRequest) (*UncordonWorkerResponse, error)
+	DrainWorker(context.Context, *DrainWorkerRequest) (*DrainWorkerResponse, error)
+	SetWorkerLabels(context.Context, *SetWorkerLabelsRequest) (*SetWorkerLabelsResponse, error)
+	SimulateScheduling(context.Context, *SimulateSchedulingRequest) (*SimulateSchedulingResponse, error)
+	// Workspace
+	ExportWorkspaceConfig(context.Context, *ExportWorkspaceConfigRequest) (*ExportWorkspaceConfigResponse, error)
+	// Grants
+	GrantResourceAccess(context.Context, *GrantResourceAccessRequest) (*GrantResourceAccessResponse, error)
+	ListResourceGrants(context.Context, *ListResourceGrantsRequest) (*ListResourceGrantsResponse, error)
+	// Audit log
+	QueryAuditLog(context.Context, *QueryAuditLogRequest) (*QueryAuditLogResponse, error)
+	mustEmbedUnimplementedGatewayServiceServer()
+}
+
+// UnimplementedGatewayServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedGatewayServiceServer struct {
+}
+
+func (UnimplementedGatewayServiceServer) Authorize(context.Context, *AuthorizeRequest) (*AuthorizeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Authorize not implemented")
+}
+func (UnimplementedGatewayServiceServer) SignPayload(context.Context, *SignPayloadRequest) (*SignPayloadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignPayload not implemented")
+}
+func (UnimplementedGatewayServiceServer) HeadObject(context.Context, *HeadObjectRequest) (*HeadObjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HeadObject not implemented")
+}
+func (UnimplementedGatewayServiceServer) ListObjects(context.Context, *ListObjectsRequest) (*ListObjectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListObjects not implemented")
+}
+func (UnimplementedGatewayServiceServer) CreateObject(context.Context, *CreateObjectRequest) (*CreateObjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateObject not implemented")
+}
+func (UnimplementedGatewayServiceServer) PutObjectStream(GatewayService_PutObjectStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method PutObjectStream not implemented")
+}
+func (UnimplementedGatewayServiceServer) PutObjectStreamV2(GatewayService_PutObjectStreamV2Server) error {
+	return status.Errorf(codes.Unimplemented, "method PutObjectStreamV2 not implemented")
+}
+func (UnimplementedGatewayServiceServer) GetObjectSignature(context.Context, *GetObjectSignatureRequest) (*GetObjectSignatureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetObjectSignature not implemented")
+}
+func (UnimplementedGatewayServiceServer) PatchObject(GatewayService_PatchObjectServer) error {
+	return status.Errorf(codes.Unimplemented, "method PatchObject not implemented")
+}
+func (UnimplementedGatewayServiceServer) GetObjectURL(context.Context, *GetObjectURLRequest) (*GetObjectURLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetObjectURL not implemented")
+}
+func (UnimplementedGatewayServiceServer) GetObjectStream(*GetObjectStreamRequest, GatewayService_GetObjectStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetObjectStream not implemented")
+}
+func (UnimplementedGatewayServiceServer) RenameObject(context.Context, *RenameObjectRequest) (*RenameObjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameObject not implemented")
+}
+func (UnimplementedGatewayServiceServer) TagObject(context.Context, *TagObjectRequest) (*TagObjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TagObject not implemented")
+}
+func (UnimplementedGatewayServiceServer) GetResourceACL(context.Context, *GetResourceACLRequest) (*GetResourceACLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetResourceACL not implemented")
+}
+func (UnimplementedGatewayServiceServer) SetResourceACL(context.Context, *SetResourceACLRequest) (*SetResourceACLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetResourceACL not implemented")
+}
+func (UnimplementedGatewayServiceServer) MigrateObjects(context.Context, *MigrateObjectsRequest) (*MigrateObjectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MigrateObjects not implemented")
+}
+func (UnimplementedGatewayServiceServer) CompleteObject(context.Context, *CompleteObjectRequest) (*CompleteObjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteObject not implemented")
+}
+func (UnimplementedGatewayServiceServer) DeleteObject(context.Context, *DeleteObjectRequest) (*DeleteObjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteObject not implemented")
+}
+func (UnimplementedGatewayServiceServer) DeleteObjects(context.Context, *DeleteObjectsRequest) (*DeleteObjectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteObjects not implemented")
+}
+func (UnimplementedGatewayServiceServer) LockObject(context.Context, *LockObjectRequest) (*LockObjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LockObject not implemented")
+}
+func (UnimplementedGatewayServiceServer) ListCorruptedObjects(context.Context, *ListCorruptedObjectsRequest) (*ListCorruptedObjectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCorruptedObjects not implemented")
+}
+func (UnimplementedGatewayServiceServer) CheckObjectConsistency(context.Context, *CheckObjectConsistencyRequest) (*CheckObjectConsistencyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckObjectConsistency not implemented")
+}
+func (UnimplementedGatewayServiceServer) SetObjectLifecyclePolicy(context.Context, *SetObjectLifecyclePolicyRequest) (*SetObjectLifecyclePolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetObjectLifecyclePolicy not implemented")
+}
+func (UnimplementedGatewayServiceServer) GetObjectLifecyclePolicy(context.Context, *GetObjectLifecyclePolicyRequest) (*GetObjectLifecyclePolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetObjectLifecyclePolicy not implemented")
+}
+func (UnimplementedGatewayServiceServer) ListObjectVersions(context.Context, *ListObjectVersionsRequest) (*ListObjectVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListObjectVersions not implemented")
+}
+func (UnimplementedGatewayServiceServer) RestoreObjectVersion(context.Context, *RestoreObjectVersionRequest) (*RestoreObjectVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreObjectVersion not implemented")
+}
+func (UnimplementedGatewayServiceServer) CopyObject(context.Context, *CopyObjectRequest) (*CopyObjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CopyObject not implemented")
+}
+func (UnimplementedGatewayServiceServer) ExtractObject(context.Context, *ExtractObjectRequest) (*ExtractObjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExtractObject not implemented")
+}
+func (UnimplementedGatewayServiceServer) GetStorageUsage(context.Context, *GetStorageUsageRequest) (*GetStorageUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStorageUsage not implemented")
+}
+func (UnimplementedGatewayServiceServer) InitiateUpload(context.Context, *InitiateUploadRequest) (*InitiateUploadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InitiateUpload not implemented")
+}
+func (UnimplementedGatewayServiceServer) AppendChunk(context.Context, *AppendChunkRequest) (*AppendChunkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AppendChunk not implemented")
+}
+func (UnimplementedGatewayServiceServer) CompleteUpload(context.Context, *CompleteUploadRequest) (*CompleteUploadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteUpload not implemented")
+}
+func (UnimplementedGatewayServiceServer) CreateWebhook(context.Context, *CreateWebhookRequest) (*CreateWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhook not implemented")
+}
+func (UnimplementedGatewayServiceServer) ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (UnimplementedGatewayServiceServer) DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhook not implemented")
+}
+func (UnimplementedGatewayServiceServer) CreateTaskTrigger(context.Context, *CreateTaskTriggerRequest) (*CreateTaskTriggerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTaskTrigger not implemented")
+}
+func (UnimplementedGatewayServiceServer) ListTaskTriggers(context.Context, *ListTaskTriggersRequest) (*ListTaskTriggersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTaskTriggers not implemented")
+}
+func (UnimplementedGatewayServiceServer) DeleteTaskTrigger(context.Context, *DeleteTaskTriggerRequest) (*DeleteTaskTriggerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTaskTrigger not implemented")
+}
+func (UnimplementedGatewayServiceServer) SetUploadBandwidthLimit(context.Context, *SetUploadBandwidthLimitRequest) (*SetUploadBandwidthLimitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetUploadBandwidthLimit not implemented")
+}
+func (UnimplementedGatewayServiceServer) SetWorkspaceVolumeQuota(context.Context, *SetWorkspaceVolumeQuotaRequest) (*SetWorkspaceVolumeQuotaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetWorkspaceVolumeQuota not implemented")
+}
+func (UnimplementedGatewayServiceServer) SetWorkspacePolicy(context.Context, *SetWorkspacePolicyRequest) (*SetWorkspacePolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetWorkspacePolicy not implemented")
+}
+func (UnimplementedGatewayServiceServer) GetWorkspacePolicy(context.Context, *GetWorkspacePolicyRequest) (*GetWorkspacePolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWorkspacePolicy not implemented")
+}
+func (UnimplementedGatewayServiceServer) SetPoolQuota(context.Context, *SetPoolQuotaRequest) (*SetPoolQuotaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPoolQuota not implemented")
+}
+func (UnimplementedGatewayServiceServer) ListPoolQuotas(context.Context, *ListPoolQuotasRequest) (*ListPoolQuotasResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPoolQuotas not implemented")
+}
+func (UnimplementedGatewayServiceServer) ReserveCapacity(context.Context, *ReserveCapacityRequest) (*ReserveCapacityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveCapacity not implemented")
+}
+func (UnimplementedGatewayServiceServer) ListCapacityReservations(context.Context, *ListCapacityReservationsRequest) (*ListCapacityReservationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCapacityReservations not implemented")
+}
+func (UnimplementedGatewayServiceServer) CancelCapacityReservation(context.Context, *CancelCapacityReservationRequest) (*CancelCapacityReservationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelCapacityReservation not implemented")
+}
+func (UnimplementedGatewayServiceServer) GetWorkspaceIPAllowList(context.Context, *GetWorkspaceIPAllowListRequest) (*GetWorkspaceIPAllowListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWorkspaceIPAllowList not implemented")
+}
+func (UnimplementedGatewayServiceServer) SetWorkspaceIPAllowList(context.Context, *SetWorkspaceIPAllowListRequest) (*SetWorkspaceIPAllowListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetWorkspaceIPAllowList not implemented")
+}
+func (UnimplementedGatewayServiceServer) CheckpointContainer(context.Context, *CheckpointContainerRequest) (*CheckpointContainerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckpointContainer not implemented")
+}
+func (UnimplementedGatewayServiceServer) ListContainers(context.Context, *ListContainersRequest) (*ListContainersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListContainers not implemented")
+}
+func (UnimplementedGatewayServiceServer) StopContainer(context.Context, *StopContainerRequest) (*StopContainerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopContainer not implemented")
+}
+func (UnimplementedGatewayServiceServer) AttachToContainer(GatewayService_AttachToContainerServer) error {
+	return status.Errorf(codes.Unimplemented, "method AttachToContainer not implemented")
+}
+func (UnimplementedGatewayServiceServer) ExecInContainer(GatewayService_ExecInContainerServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExecInContainer not implemented")
+}
+func (UnimplementedGatewayServiceServer) StreamLogs(*StreamLogsRequest, GatewayService_StreamLogsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamLogs not implemented")
+}
+func (UnimplementedGatewayServiceServer) PortForward(GatewayService_PortForwardServer) error {
+	return status.Errorf(codes.Unimplemented, "method PortForward not implemented")
+}
+func (UnimplementedGatewayServiceServer) StartTask(context.Context, *StartTaskRequest) (*StartTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartTask not implemented")
+}
+func (UnimplementedGatewayServiceServer) EndTask(context.Context, *EndTaskRequest) (*EndTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EndTask not implemented")
+}
+func (UnimplementedGatewayServiceServer) StopTasks(context.Context, *StopTasksRequest) (*StopTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopTasks not implemented")
+}
+func (UnimplementedGatewayServiceServer) ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTasks not implemented")
+}
+func (UnimplementedGatewayServiceServer) CancelTasks(context.Context, *CancelTasksRequest) (*CancelTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelTasks not implemented")
+}
+func (UnimplementedGatewayServiceServer) RequeueTasks(context.Context, *RequeueTasksRequest) (*RequeueTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequeueTasks not implemented")
+}
+func (UnimplementedGatewayServiceServer) SetTaskRetryPolicy(context.Context, *SetTaskRetryPolicyRequest) (*SetTaskRetryPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTaskRetryPolicy not implemented")
+}
+func (UnimplementedGatewayServiceServer) GetTaskRetryPolicy(context.Context, *GetTaskRetryPolicyRequest) (*GetTaskRetryPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTaskRetryPolicy not implemented")
+}
+func (UnimplementedGatewayServiceServer) GetOrCreateStub(context.Context, *GetOrCreateStubRequest) (*GetOrCreateStubResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrCreateStub not implemented")
+}
+func (UnimplementedGatewayServiceServer) DeployStub(context.Context, *DeployStubRequest) (*DeployStubResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeployStub not implemented")
+}
+func (UnimplementedGatewayServiceServer) GetURL(context.Context, *GetURLRequest) (*GetURLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetURL not implemented")
+}
+func (UnimplementedGatewayServiceServer) ListDeployments(context.Context, *ListDeploymentsRequest) (*ListDeploymentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeployments not implemented")
+}
+func (UnimplementedGatewayServiceServer) StopDeployment(context.Context, *StopDeploymentRequest) (*StopDeploymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopDeployment not implemented")
+}
+func (UnimplementedGatewayServiceServer) StartDeployment(context.Context, *StartDeploymentRequest) (*StartDeploymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartDeployment not implemented")
+}
+func (UnimplementedGatewayServiceServer) ScaleDeployment(context.Context, *ScaleDeploymentRequest) (*ScaleDeploymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScaleDeployment not implemented")
+}
+func (UnimplementedGatewayServiceServer) DeleteDeployment(context.Context, *DeleteDeploymentRequest) (*DeleteDeploymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteDeployment not implemented")
+}
+func (UnimplementedGatewayServiceServer) RollbackDeployment(context.Context, *RollbackDeploymentRequest) (*RollbackDeploymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollbackDeployment not implemented")
+}
+func (UnimplementedGatewayServiceServer) SetDeploymentEnv(context.Context, *SetDeploymentEnvRequest) (*SetDeploymentEnvResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetDeploymentEnv not implemented")
+}
+func (UnimplementedGatewayServiceServer) UnsetDeploymentEnv(context.Context, *UnsetDeploymentEnvRequest) (*UnsetDeploymentEnvResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnsetDeploymentEnv not implemented")
+}
+func (UnimplementedGatewayServiceServer) ListDeploymentEnv(context.Context, *ListDeploymentEnvRequest) (*ListDeploymentEnvResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeploymentEnv not implemented")
+}
+func (UnimplementedGatewayServiceServer) AddCustomDomain(context.Context, *AddCustomDomainRequest) (*AddCustomDomainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddCustomDomain not implemented")
+}
+func (UnimplementedGatewayServiceServer) ListCustomDomains(context.Context, *ListCustomDomainsRequest) (*ListCustomDomainsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCustomDomains not implemented")
+}
+func (UnimplementedGatewayServiceServer) RemoveCustomDomain(context.Context, *RemoveCustomDomainRequest) (*RemoveCustomDomainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveCustomDomain not implemented")
+}
+func (UnimplementedGatewayServiceServer) ListDeploymentHistory(context.Context, *ListDeploymentHistoryRequest) (*ListDeploymentHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeploymentHistory not implemented")
+}
+func (UnimplementedGatewayServiceServer) SetTrafficSplit(context.Context, *SetTrafficSplitRequest) (*SetTrafficSplitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTrafficSplit not implemented")
+}
+func (UnimplementedGatewayServiceServer) PromoteVersion(context.Context, *PromoteVersionRequest) (*PromoteVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PromoteVersion not implemented")
+}
+func (UnimplementedGatewayServiceServer) SetAutoscalingPolicy(context.Context, *SetAutoscalingPolicyRequest) (*SetAutoscalingPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAutoscalingPolicy not implemented")
+}
+func (UnimplementedGatewayServiceServer) GetAutoscalingPolicy(context.Context, *GetAutoscalingPolicyRequest) (*GetAutoscalingPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAutoscalingPolicy not implemented")
+}
+func (UnimplementedGatewayServiceServer) ReportAutoscalingMetrics(context.Context, *ReportAutoscalingMetricsRequest) (*ReportAutoscalingMetricsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportAutoscalingMetrics not implemented")
+}
+func (UnimplementedGatewayServiceServer) SetKeepWarm(context.Context, *SetKeepWarmRequest) (*SetKeepWarmResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetKeepWarm not implemented")
+}
+func (UnimplementedGatewayServiceServer) GetKeepWarm(context.Context, *GetKeepWarmRequest) (*GetKeepWarmResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetKeepWarm not implemented")
+}
+func (UnimplementedGatewayServiceServer) ListPools(context.Context, *ListPoolsRequest) (*ListPoolsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPools not implemented")
+}
+func (UnimplementedGatewayServiceServer) ListMachines(context.Context, *ListMachinesRequest) (*ListMachinesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMachines not implemented")
+}
+func (UnimplementedGatewayServiceServer) CreateMachine(context.Context, *CreateMachineRequest) (*CreateMachineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateMachine not implemented")
+}
+func (UnimplementedGatewayServiceServer) DeleteMachine(context.Context, *DeleteMachineRequest) (*DeleteMachineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMachine not implemented")
+}
+func (UnimplementedGatewayServiceServer) ListTokens(context.Context, *ListTokensRequest) (*ListTokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTokens not implemented")
+}
+func (UnimplementedGatewayServiceServer) CreateToken(context.Context, *CreateTokenRequest) (*CreateTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateToken not implemented")
+}
+func (UnimplementedGatewayServiceServer) ToggleToken(context.Context, *ToggleTokenRequest) (*ToggleTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ToggleToken not implemented")
+}
+func (UnimplementedGatewayServiceServer) DeleteToken(context.Context, *DeleteTokenRequest) (*DeleteTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteToken not implemented")
+}
+func (UnimplementedGatewayServiceServer) SetTokenRateLimit(context.Context, *SetTokenRateLimitRequest) (*SetTokenRateLimitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTokenRateLimit not implemented")
+}
+func (UnimplementedGatewayServiceServer) RotateToken(context.Context, *RotateTokenRequest) (*RotateTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateToken not implemented")
+}
+func (UnimplementedGatewayServiceServer) ExchangeSessionToken(context.Context, *ExchangeSessionTokenRequest) (*ExchangeSessionTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExchangeSessionToken not implemented")
+}
+func (UnimplementedGatewayServiceServer) RefreshSessionToken(context.Context, *RefreshSessionTokenRequest) (*RefreshSessionTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshSessionToken not implemented")
+}
+func (UnimplementedGatewayServiceServer) RevokeSessionToken(context.Context, *RevokeSessionTokenRequest) (*RevokeSessionTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeSessionToken not implemented")
+}
+func (UnimplementedGatewayServiceServer) ListMemberRoles(context.Context, *ListMemberRolesRequest) (*ListMemberRolesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMemberRoles not implemented")
+}
+func (UnimplementedGatewayServiceServer) SetMemberRole(context.Context, *SetMemberRoleRequest) (*SetMemberRoleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMemberRole not implemented")
+}
+func (UnimplementedGatewayServiceServer) InviteMember(context.Context, *InviteMemberRequest) (*InviteMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InviteMember not implemented")
+}
+func (UnimplementedGatewayServiceServer) AcceptInvite(context.Context, *AcceptInviteRequest) (*AcceptInviteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptInvite not implemented")
+}
+func (UnimplementedGatewayServiceServer) RevokeInvite(context.Context, *RevokeInviteRequest) (*RevokeInviteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeInvite not implemented")
+}
+func (UnimplementedGatewayServiceServer) CreateServiceAccount(context.Context, *CreateServiceAccountRequest) (*CreateServiceAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateServiceAccount not implemented")
+}
+func (UnimplementedGatewayServiceServer) ListServiceAccounts(context.Context, *ListServiceAccountsRequest) (*ListServiceAccountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListServiceAccounts not implemented")
+}
+func (UnimplementedGatewayServiceServer) DeleteServiceAccount(context.Context, *DeleteServiceAccountRequest) (*DeleteServiceAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteServiceAccount not implemented")
+}
+func (UnimplementedGatewayServiceServer) IssueServiceAccountToken(context.Context, *IssueServiceAccountTokenRequest) (*IssueServiceAccountTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IssueServiceAccountToken not implemented")
+}
+func (UnimplementedGatewayServiceServer) RenewServiceAccountToken(context.Context, *RenewServiceAccountTokenRequest) (*RenewServiceAccountTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenewServiceAccountToken not implemented")
+}
+func (UnimplementedGatewayServiceServer) RevokeServiceAccountToken(context.Context, *RevokeServiceAccountTokenRequest) (*RevokeServiceAccountTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeServiceAccountToken not implemented")
+}
+func (UnimplementedGatewayServiceServer) ListWorkers(context.Context, *ListWorkersRequest) (*ListWorkersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWorkers not implemented")
+}
+func (UnimplementedGatewayServiceServer) CordonWorker(context.Context, *CordonWorkerRequest) (*CordonWorkerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CordonWorker not implemented")
+}
+func (UnimplementedGatewayServiceServer) UncordonWorker(context.Context, *UncordonWorkerRequest) (*UncordonWorkerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UncordonWorker not implemented")
+}
+func (UnimplementedGatewayServiceServer) DrainWorker(context.Context, *DrainWorkerRequest) (*DrainWorkerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DrainWorker not implemented")
+}
+func (UnimplementedGatewayServiceServer) SetWorkerLabels(context.Context, *SetWorkerLabelsRequest) (*SetWorkerLabelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetWorkerLabels not implemented")
+}
+func (UnimplementedGatewayServiceServer) SimulateScheduling(context.Context, *SimulateSchedulingRequest) (*SimulateSchedulingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SimulateScheduling not implemented")
+}
+func (UnimplementedGatewayServiceServer) ExportWorkspaceConfig(context.Context, *ExportWorkspaceConfigRequest) (*ExportWorkspaceConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportWorkspaceConfig not implemented")
+}
+func (UnimplementedGatewayServiceServer) GrantResourceAccess(context.Context, *GrantResourceAccessRequest) (*GrantResourceAccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GrantResourceAccess not implemented")
+}
+func (UnimplementedGatewayServiceServer) ListResourceGrants(context.Context, *ListResourceGrantsRequest) (*ListResourceGrantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListResourceGrants not implemented")
+}
+func (UnimplementedGatewayServiceServer) QueryAuditLog(context.Context, *QueryAuditLogRequest) (*QueryAuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryAuditLog not implemented")
+}
+func (UnimplementedGatewayServiceServer) mustEmbedUnimplementedGatewayServiceServer() {}
+
+// UnsafeGatewayServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GatewayServiceServer will
+// result in compilation errors.
+type UnsafeGatewayServiceServer interface {
+	mustEmbedUnimplementedGatewayServiceServer()
+}
+
+func RegisterGatewayServiceServer(s grpc.ServiceRegistrar, srv GatewayServiceServer) {
+	s.RegisterService(&GatewayService_ServiceDesc, srv)
+}
+
+func _GatewayService_Authorize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthorizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).Authorize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_Authorize_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).Authorize(ctx, req.(*AuthorizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_SignPayload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignPayloadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).SignPayload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_SignPayload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).SignPayload(ctx, req.(*SignPayloadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_HeadObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeadObjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).HeadObject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_HeadObject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).HeadObject(ctx, req.(*HeadObjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_ListObjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListObjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).ListObjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_ListObjects_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).ListObjects(ctx, req.(*ListObjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_CreateObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateObjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).CreateObject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_CreateObject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).CreateObject(ctx, req.(*CreateObjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_PutObjectStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(GatewayServiceServer).PutObjectStream(&gatewayServicePutObjectStreamServer{stream})
+}
+
+type GatewayService_PutObjectStreamServer interface {
+	SendAndClose(*PutObjectResponse) error
+	Recv() (*PutObjectRequest, error)
+	grpc.ServerStream
+}
+
+type gatewayServicePutObjectStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *gatewayServicePutObjectStreamServer) SendAndClose(m *PutObjectResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *gatewayServicePutObjectStreamServer) Recv() (*PutObjectRequest, error) {
+	m := new(PutObjectRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _GatewayService_PutObjectStreamV2_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(GatewayServiceServer).PutObjectStreamV2(&gatewayServicePutObjectStreamV2Server{stream})
+}
+
+type GatewayService_PutObjectStreamV2Server interface {
+	Send(*PutObjectStreamResponse) error
+	Recv() (*PutObjectRequest, error)
+	grpc.ServerStream
+}
+
+type gatewayServicePutObjectStreamV2Server struct {
+	grpc.ServerStream
+}
+
+func (x *gatewayServicePutObjectStreamV2Server) Send(m *PutObjectStreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *gatewayServicePutObjectStreamV2Server) Recv() (*PutObjectRequest, error) {
+	m := new(PutObjectRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _GatewayService_GetObjectSignature_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetObjectSignatureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).GetObjectSignature(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_GetObjectSignature_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).GetObjectSignature(ctx, req.(*GetObjectSignatureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_PatchObject_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(GatewayServiceServer).PatchObject(&gatewayServicePatchObjectServer{stream})
+}
+
+type GatewayService_PatchObjectServer interface {
+	SendAndClose(*PatchObjectResponse) error
+	Recv() (*PatchObjectRequest, error)
+	grpc.ServerStream
+}
+
+type gatewayServicePatchObjectServer struct {
+	grpc.ServerStream
+}
+
+func (x *gatewayServicePatchObjectServer) SendAndClose(m *PatchObjectResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *gatewayServicePatchObjectServer) Recv() (*PatchObjectRequest, error) {
+	m := new(PatchObjectRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _GatewayService_GetObjectURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetObjectURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).GetObjectURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_GetObjectURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).GetObjectURL(ctx, req.(*GetObjectURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_GetObjectStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetObjectStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GatewayServiceServer).GetObjectStream(m, &gatewayServiceGetObjectStreamServer{stream})
+}
+
+type GatewayService_GetObjectStreamServer interface {
+	Send(*GetObjectStreamResponse) error
+	grpc.ServerStream
+}
+
+type gatewayServiceGetObjectStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *gatewayServiceGetObjectStreamServer) Send(m *GetObjectStreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GatewayService_RenameObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameObjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).RenameObject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_RenameObject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).RenameObject(ctx, req.(*RenameObjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_TagObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TagObjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).TagObject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_TagObject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).TagObject(ctx, req.(*TagObjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_GetResourceACL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetResourceACLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).GetResourceACL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_GetResourceACL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).GetResourceACL(ctx, req.(*GetResourceACLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_SetResourceACL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetResourceACLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).SetResourceACL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_SetResourceACL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).SetResourceACL(ctx, req.(*SetResourceACLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_MigrateObjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MigrateObjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).MigrateObjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_MigrateObjects_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).MigrateObjects(ctx, req.(*MigrateObjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_CompleteObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteObjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).CompleteObject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_CompleteObject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).CompleteObject(ctx, req.(*CompleteObjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_DeleteObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteObjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).DeleteObject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_DeleteObject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).DeleteObject(ctx, req.(*DeleteObjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_DeleteObjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteObjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).DeleteObjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_DeleteObjects_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).DeleteObjects(ctx, req.(*DeleteObjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_LockObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LockObjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).LockObject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_LockObject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).LockObject(ctx, req.(*LockObjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_ListCorruptedObjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCorruptedObjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).ListCorruptedObjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_ListCorruptedObjects_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).ListCorruptedObjects(ctx, req.(*ListCorruptedObjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_CheckObjectConsistency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckObjectConsistencyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).CheckObjectConsistency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_CheckObjectConsistency_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).CheckObjectConsistency(ctx, req.(*CheckObjectConsistencyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_SetObjectLifecyclePolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetObjectLifecyclePolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).SetObjectLifecyclePolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_SetObjectLifecyclePolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).SetObjectLifecyclePolicy(ctx, req.(*SetObjectLifecyclePolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_GetObjectLifecyclePolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetObjectLifecyclePolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).GetObjectLifecyclePolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_GetObjectLifecyclePolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).GetObjectLifecyclePolicy(ctx, req.(*GetObjectLifecyclePolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_ListObjectVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListObjectVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).ListObjectVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_ListObjectVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).ListObjectVersions(ctx, req.(*ListObjectVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_RestoreObjectVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreObjectVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).RestoreObjectVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_RestoreObjectVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).RestoreObjectVersion(ctx, req.(*RestoreObjectVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_CopyObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CopyObjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).CopyObject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_CopyObject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).CopyObject(ctx, req.(*CopyObjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_ExtractObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExtractObjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).ExtractObject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_ExtractObject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).ExtractObject(ctx, req.(*ExtractObjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_GetStorageUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStorageUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).GetStorageUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_GetStorageUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).GetStorageUsage(ctx, req.(*GetStorageUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_InitiateUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InitiateUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).InitiateUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_InitiateUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).InitiateUpload(ctx, req.(*InitiateUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_AppendChunk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppendChunkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).AppendChunk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_AppendChunk_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).AppendChunk(ctx, req.(*AppendChunkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_CompleteUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).CompleteUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_CompleteUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).CompleteUpload(ctx, req.(*CompleteUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).CreateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_CreateWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).CreateWebhook(ctx, req.(*CreateWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).ListWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_ListWebhooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).ListWebhooks(ctx, req.(*ListWebhooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).DeleteWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_DeleteWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).DeleteWebhook(ctx, req.(*DeleteWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_CreateTaskTrigger_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTaskTriggerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).CreateTaskTrigger(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_CreateTaskTrigger_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).CreateTaskTrigger(ctx, req.(*CreateTaskTriggerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_ListTaskTriggers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTaskTriggersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).ListTaskTriggers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_ListTaskTriggers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).ListTaskTriggers(ctx, req.(*ListTaskTriggersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_DeleteTaskTrigger_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTaskTriggerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).DeleteTaskTrigger(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_DeleteTaskTrigger_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).DeleteTaskTrigger(ctx, req.(*DeleteTaskTriggerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_SetUploadBandwidthLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetUploadBandwidthLimitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).SetUploadBandwidthLimit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_SetUploadBandwidthLimit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).SetUploadBandwidthLimit(ctx, req.(*SetUploadBandwidthLimitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_SetWorkspaceVolumeQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetWorkspaceVolumeQuotaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).SetWorkspaceVolumeQuota(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_SetWorkspaceVolumeQuota_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).SetWorkspaceVolumeQuota(ctx, req.(*SetWorkspaceVolumeQuotaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_SetWorkspacePolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetWorkspacePolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).SetWorkspacePolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_SetWorkspacePolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).SetWorkspacePolicy(ctx, req.(*SetWorkspacePolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_GetWorkspacePolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWorkspacePolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).GetWorkspacePolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_GetWorkspacePolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).GetWorkspacePolicy(ctx, req.(*GetWorkspacePolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_SetPoolQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPoolQuotaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).SetPoolQuota(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_SetPoolQuota_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).SetPoolQuota(ctx, req.(*SetPoolQuotaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_ListPoolQuotas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPoolQuotasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).ListPoolQuotas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_ListPoolQuotas_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).ListPoolQuotas(ctx, req.(*ListPoolQuotasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_ReserveCapacity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveCapacityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).ReserveCapacity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_ReserveCapacity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).ReserveCapacity(ctx, req.(*ReserveCapacityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_ListCapacityReservations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCapacityReservationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).ListCapacityReservations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_ListCapacityReservations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).ListCapacityReservations(ctx, req.(*ListCapacityReservationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_CancelCapacityReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelCapacityReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).CancelCapacityReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_CancelCapacityReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).CancelCapacityReservation(ctx, req.(*CancelCapacityReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_GetWorkspaceIPAllowList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWorkspaceIPAllowListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).GetWorkspaceIPAllowList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_GetWorkspaceIPAllowList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).GetWorkspaceIPAllowList(ctx, req.(*GetWorkspaceIPAllowListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_SetWorkspaceIPAllowList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetWorkspaceIPAllowListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).SetWorkspaceIPAllowList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_SetWorkspaceIPAllowList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).SetWorkspaceIPAllowList(ctx, req.(*SetWorkspaceIPAllowListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_CheckpointContainer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckpointContainerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).CheckpointContainer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_CheckpointContainer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).CheckpointContainer(ctx, req.(*CheckpointContainerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_ListContainers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListContainersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).ListContainers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_ListContainers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).ListContainers(ctx, req.(*ListContainersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_StopContainer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopContainerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).StopContainer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_StopContainer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).StopContainer(ctx, req.(*StopContainerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_AttachToContainer_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(GatewayServiceServer).AttachToContainer(&gatewayServiceAttachToContainerServer{stream})
+}
+
+type GatewayService_AttachToContainerServer interface {
+	Send(*AttachToContainerResponse) error
+	Recv() (*ContainerStreamMessage, error)
+	grpc.ServerStream
+}
+
+type gatewayServiceAttachToContainerServer struct {
+	grpc.ServerStream
+}
+
+func (x *gatewayServiceAttachToContainerServer) Send(m *AttachToContainerResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *gatewayServiceAttachToContainerServer) Recv() (*ContainerStreamMessage, error) {
+	m := new(ContainerStreamMessage)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _GatewayService_ExecInContainer_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(GatewayServiceServer).ExecInContainer(&gatewayServiceExecInContainerServer{stream})
+}
+
+type GatewayService_ExecInContainerServer interface {
+	Send(*ExecInContainerResponse) error
+	Recv() (*ExecInContainerRequest, error)
+	grpc.ServerStream
+}
+
+type gatewayServiceExecInContainerServer struct {
+	grpc.ServerStream
+}
+
+func (x *gatewayServiceExecInContainerServer) Send(m *ExecInContainerResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *gatewayServiceExecInContainerServer) Recv() (*ExecInContainerRequest, error) {
+	m := new(ExecInContainerRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _GatewayService_StreamLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GatewayServiceServer).StreamLogs(m, &gatewayServiceStreamLogsServer{stream})
+}
+
+type GatewayService_StreamLogsServer interface {
+	Send(*StreamLogsResponse) error
+	grpc.ServerStream
+}
+
+type gatewayServiceStreamLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *gatewayServiceStreamLogsServer) Send(m *StreamLogsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GatewayService_PortForward_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(GatewayServiceServer).PortForward(&gatewayServicePortForwardServer{stream})
+}
+
+type GatewayService_PortForwardServer interface {
+	Send(*PortForwardResponse) error
+	Recv() (*PortForwardRequest, error)
+	grpc.ServerStream
+}
+
+type gatewayServicePortForwardServer struct {
+	grpc.ServerStream
+}
+
+func (x *gatewayServicePortForwardServer) Send(m *PortForwardResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *gatewayServicePortForwardServer) Recv() (*PortForwardRequest, error) {
+	m := new(PortForwardRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _GatewayService_StartTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).StartTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_StartTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).StartTask(ctx, req.(*StartTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_EndTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EndTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).EndTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_EndTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).EndTask(ctx, req.(*EndTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_StopTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).StopTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_StopTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).StopTasks(ctx, req.(*StopTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_ListTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).ListTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_ListTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).ListTasks(ctx, req.(*ListTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_CancelTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).CancelTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_CancelTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).CancelTasks(ctx, req.(*CancelTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_RequeueTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequeueTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).RequeueTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_RequeueTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).RequeueTasks(ctx, req.(*RequeueTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_SetTaskRetryPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTaskRetryPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).SetTaskRetryPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_SetTaskRetryPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).SetTaskRetryPolicy(ctx, req.(*SetTaskRetryPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_GetTaskRetryPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTaskRetryPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).GetTaskRetryPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_GetTaskRetryPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).GetTaskRetryPolicy(ctx, req.(*GetTaskRetryPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_GetOrCreateStub_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrCreateStubRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).GetOrCreateStub(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_GetOrCreateStub_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).GetOrCreateStub(ctx, req.(*GetOrCreateStubRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_DeployStub_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeployStubRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).DeployStub(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_DeployStub_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).DeployStub(ctx, req.(*DeployStubRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_GetURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).GetURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_GetURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).GetURL(ctx, req.(*GetURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_ListDeployments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeploymentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).ListDeployments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_ListDeployments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).ListDeployments(ctx, req.(*ListDeploymentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_StopDeployment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopDeploymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).StopDeployment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_StopDeployment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).StopDeployment(ctx, req.(*StopDeploymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_StartDeployment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartDeploymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).StartDeployment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_StartDeployment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).StartDeployment(ctx, req.(*StartDeploymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_ScaleDeployment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScaleDeploymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).ScaleDeployment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_ScaleDeployment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).ScaleDeployment(ctx, req.(*ScaleDeploymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_DeleteDeployment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteDeploymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).DeleteDeployment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_DeleteDeployment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).DeleteDeployment(ctx, req.(*DeleteDeploymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_RollbackDeployment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackDeploymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).RollbackDeployment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_RollbackDeployment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).RollbackDeployment(ctx, req.(*RollbackDeploymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_SetDeploymentEnv_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDeploymentEnvRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).SetDeploymentEnv(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_SetDeploymentEnv_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).SetDeploymentEnv(ctx, req.(*SetDeploymentEnvRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_UnsetDeploymentEnv_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnsetDeploymentEnvRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).UnsetDeploymentEnv(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_UnsetDeploymentEnv_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).UnsetDeploymentEnv(ctx, req.(*UnsetDeploymentEnvRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_ListDeploymentEnv_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeploymentEnvRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).ListDeploymentEnv(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_ListDeploymentEnv_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).ListDeploymentEnv(ctx, req.(*ListDeploymentEnvRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_AddCustomDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddCustomDomainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).AddCustomDomain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_AddCustomDomain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).AddCustomDomain(ctx, req.(*AddCustomDomainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_ListCustomDomains_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCustomDomainsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).ListCustomDomains(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_ListCustomDomains_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).ListCustomDomains(ctx, req.(*ListCustomDomainsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_RemoveCustomDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveCustomDomainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).RemoveCustomDomain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_RemoveCustomDomain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).RemoveCustomDomain(ctx, req.(*RemoveCustomDomainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_ListDeploymentHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeploymentHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).ListDeploymentHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_ListDeploymentHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).ListDeploymentHistory(ctx, req.(*ListDeploymentHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_SetTrafficSplit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTrafficSplitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).SetTrafficSplit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_SetTrafficSplit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).SetTrafficSplit(ctx, req.(*SetTrafficSplitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_PromoteVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoteVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).PromoteVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_PromoteVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).PromoteVersion(ctx, req.(*PromoteVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_SetAutoscalingPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAutoscalingPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).SetAutoscalingPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_SetAutoscalingPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).SetAutoscalingPolicy(ctx, req.(*SetAutoscalingPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _GatewayService_Authorize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AuthorizeRequest)
+func _GatewayService_GetAutoscalingPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAutoscalingPolicyRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GatewayServiceServer).Authorize(ctx, in)
+		return srv.(GatewayServiceServer).GetAutoscalingPolicy(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GatewayService_Authorize_FullMethodName,
+		FullMethod: GatewayService_GetAutoscalingPolicy_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GatewayServiceServer).Authorize(ctx, req.(*AuthorizeRequest))
+		return srv.(GatewayServiceServer).GetAutoscalingPolicy(ctx, req.(*GetAutoscalingPolicyRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GatewayService_SignPayload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SignPayloadRequest)
+func _GatewayService_ReportAutoscalingMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportAutoscalingMetricsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GatewayServiceServer).SignPayload(ctx, in)
+		return srv.(GatewayServiceServer).ReportAutoscalingMetrics(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GatewayService_SignPayload_FullMethodName,
+		FullMethod: GatewayService_ReportAutoscalingMetrics_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GatewayServiceServer).SignPayload(ctx, req.(*SignPayloadRequest))
+		return srv.(GatewayServiceServer).ReportAutoscalingMetrics(ctx, req.(*ReportAutoscalingMetricsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GatewayService_HeadObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(HeadObjectRequest)
+func _GatewayService_SetKeepWarm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetKeepWarmRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GatewayServiceServer).HeadObject(ctx, in)
+		return srv.(GatewayServiceServer).SetKeepWarm(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GatewayService_HeadObject_FullMethodName,
+		FullMethod: GatewayService_SetKeepWarm_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GatewayServiceServer).HeadObject(ctx, req.(*HeadObjectRequest))
+		return srv.(GatewayServiceServer).SetKeepWarm(ctx, req.(*SetKeepWarmRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GatewayService_CreateObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateObjectRequest)
+func _GatewayService_GetKeepWarm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetKeepWarmRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GatewayServiceServer).CreateObject(ctx, in)
+		return srv.(GatewayServiceServer).GetKeepWarm(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GatewayService_CreateObject_FullMethodName,
+		FullMethod: GatewayService_GetKeepWarm_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GatewayServiceServer).CreateObject(ctx, req.(*CreateObjectRequest))
+		return srv.(GatewayServiceServer).GetKeepWarm(ctx, req.(*GetKeepWarmRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GatewayService_PutObjectStream_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(GatewayServiceServer).PutObjectStream(&gatewayServicePutObjectStreamServer{stream})
-}
-
-type GatewayService_PutObjectStreamServer interface {
-	SendAndClose(*PutObjectResponse) error
-	Recv() (*PutObjectRequest, error)
-	grpc.ServerStream
-}
-
-type gatewayServicePutObjectStreamServer struct {
-	grpc.ServerStream
-}
-
-func (x *gatewayServicePutObjectStreamServer) SendAndClose(m *PutObjectResponse) error {
-	return x.ServerStream.SendMsg(m)
-}
-
-func (x *gatewayServicePutObjectStreamServer) Recv() (*PutObjectRequest, error) {
-	m := new(PutObjectRequest)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
+func _GatewayService_ListPools_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPoolsRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return m, nil
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).ListPools(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_ListPools_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).ListPools(ctx, req.(*ListPoolsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _GatewayService_CheckpointContainer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CheckpointContainerRequest)
+func _GatewayService_ListMachines_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMachinesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GatewayServiceServer).CheckpointContainer(ctx, in)
+		return srv.(GatewayServiceServer).ListMachines(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GatewayService_CheckpointContainer_FullMethodName,
+		FullMethod: GatewayService_ListMachines_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GatewayServiceServer).CheckpointContainer(ctx, req.(*CheckpointContainerRequest))
+		return srv.(GatewayServiceServer).ListMachines(ctx, req.(*ListMachinesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GatewayService_ListContainers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListContainersRequest)
+func _GatewayService_CreateMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMachineRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GatewayServiceServer).ListContainers(ctx, in)
+		return srv.(GatewayServiceServer).CreateMachine(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GatewayService_ListContainers_FullMethodName,
+		FullMethod: GatewayService_CreateMachine_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GatewayServiceServer).ListContainers(ctx, req.(*ListContainersRequest))
+		return srv.(GatewayServiceServer).CreateMachine(ctx, req.(*CreateMachineRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GatewayService_StopContainer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(StopContainerRequest)
+func _GatewayService_DeleteMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMachineRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GatewayServiceServer).StopContainer(ctx, in)
+		return srv.(GatewayServiceServer).DeleteMachine(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GatewayService_StopContainer_FullMethodName,
+		FullMethod: GatewayService_DeleteMachine_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GatewayServiceServer).StopContainer(ctx, req.(*StopContainerRequest))
+		return srv.(GatewayServiceServer).DeleteMachine(ctx, req.(*DeleteMachineRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GatewayService_AttachToContainer_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(GatewayServiceServer).AttachToContainer(&gatewayServiceAttachToContainerServer{stream})
+func _GatewayService_ListTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).ListTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_ListTokens_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).ListTokens(ctx, req.(*ListTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type GatewayService_AttachToContainerServer interface {
-	Send(*AttachToContainerResponse) error
-	Recv() (*ContainerStreamMessage, error)
-	grpc.ServerStream
+func _GatewayService_CreateToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).CreateToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_CreateToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).CreateToken(ctx, req.(*CreateTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type gatewayServiceAttachToContainerServer struct {
-	grpc.ServerStream
+func _GatewayService_ToggleToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ToggleTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).ToggleToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_ToggleToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).ToggleToken(ctx, req.(*ToggleTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *gatewayServiceAttachToContainerServer) Send(m *AttachToContainerResponse) error {
-	return x.ServerStream.SendMsg(m)
+func _GatewayService_DeleteToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).DeleteToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_DeleteToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).DeleteToken(ctx, req.(*DeleteTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *gatewayServiceAttachToContainerServer) Recv() (*ContainerStreamMessage, error) {
-	m := new(ContainerStreamMessage)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
+func _GatewayService_SetTokenRateLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTokenRateLimitRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return m, nil
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).SetTokenRateLimit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_SetTokenRateLimit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).SetTokenRateLimit(ctx, req.(*SetTokenRateLimitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _GatewayService_StartTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(StartTaskRequest)
+func _GatewayService_RotateToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateTokenRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GatewayServiceServer).StartTask(ctx, in)
+		return srv.(GatewayServiceServer).RotateToken(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GatewayService_StartTask_FullMethodName,
+		FullMethod: GatewayService_RotateToken_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GatewayServiceServer).StartTask(ctx, req.(*StartTaskRequest))
+		return srv.(GatewayServiceServer).RotateToken(ctx, req.(*RotateTokenRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GatewayService_EndTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(EndTaskRequest)
+func _GatewayService_ExchangeSessionToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExchangeSessionTokenRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GatewayServiceServer).EndTask(ctx, in)
+		return srv.(GatewayServiceServer).ExchangeSessionToken(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GatewayService_EndTask_FullMethodName,
+		FullMethod: GatewayService_ExchangeSessionToken_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GatewayServiceServer).EndTask(ctx, req.(*EndTaskRequest))
+		return srv.(GatewayServiceServer).ExchangeSessionToken(ctx, req.(*ExchangeSessionTokenRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GatewayService_StopTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(StopTasksRequest)
+func _GatewayService_RefreshSessionToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshSessionTokenRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GatewayServiceServer).StopTasks(ctx, in)
+		return srv.(GatewayServiceServer).RefreshSessionToken(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GatewayService_StopTasks_FullMethodName,
+		FullMethod: GatewayService_RefreshSessionToken_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GatewayServiceServer).StopTasks(ctx, req.(*StopTasksRequest))
+		return srv.(GatewayServiceServer).RefreshSessionToken(ctx, req.(*RefreshSessionTokenRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GatewayService_ListTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListTasksRequest)
+func _GatewayService_RevokeSessionToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeSessionTokenRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GatewayServiceServer).ListTasks(ctx, in)
+		return srv.(GatewayServiceServer).RevokeSessionToken(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GatewayService_ListTasks_FullMethodName,
+		FullMethod: GatewayService_RevokeSessionToken_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GatewayServiceServer).ListTasks(ctx, req.(*ListTasksRequest))
+		return srv.(GatewayServiceServer).RevokeSessionToken(ctx, req.(*RevokeSessionTokenRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GatewayService_GetOrCreateStub_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetOrCreateStubRequest)
+func _GatewayService_ListMemberRoles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMemberRolesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GatewayServiceServer).GetOrCreateStub(ctx, in)
+		return srv.(GatewayServiceServer).ListMemberRoles(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GatewayService_GetOrCreateStub_FullMethodName,
+		FullMethod: GatewayService_ListMemberRoles_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GatewayServiceServer).GetOrCreateStub(ctx, req.(*GetOrCreateStubRequest))
+		return srv.(GatewayServiceServer).ListMemberRoles(ctx, req.(*ListMemberRolesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GatewayService_DeployStub_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeployStubRequest)
+func _GatewayService_SetMemberRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMemberRoleRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GatewayServiceServer).DeployStub(ctx, in)
+		return srv.(GatewayServiceServer).SetMemberRole(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GatewayService_DeployStub_FullMethodName,
+		FullMethod: GatewayService_SetMemberRole_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GatewayServiceServer).DeployStub(ctx, req.(*DeployStubRequest))
+		return srv.(GatewayServiceServer).SetMemberRole(ctx, req.(*SetMemberRoleRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GatewayService_GetURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetURLRequest)
+func _GatewayService_InviteMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InviteMemberRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GatewayServiceServer).GetURL(ctx, in)
+		return srv.(GatewayServiceServer).InviteMember(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GatewayService_GetURL_FullMethodName,
+		FullMethod: GatewayService_InviteMember_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GatewayServiceServer).GetURL(ctx, req.(*GetURLRequest))
+		return srv.(GatewayServiceServer).InviteMember(ctx, req.(*InviteMemberRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GatewayService_ListDeployments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListDeploymentsRequest)
+func _GatewayService_AcceptInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptInviteRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GatewayServiceServer).ListDeployments(ctx, in)
+		return srv.(GatewayServiceServer).AcceptInvite(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GatewayService_ListDeployments_FullMethodName,
+		FullMethod: GatewayService_AcceptInvite_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GatewayServiceServer).ListDeployments(ctx, req.(*ListDeploymentsRequest))
+		return srv.(GatewayServiceServer).AcceptInvite(ctx, req.(*AcceptInviteRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GatewayService_StopDeployment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(StopDeploymentRequest)
+func _GatewayService_RevokeInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeInviteRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GatewayServiceServer).StopDeployment(ctx, in)
+		return srv.(GatewayServiceServer).RevokeInvite(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GatewayService_StopDeployment_FullMethodName,
+		FullMethod: GatewayService_RevokeInvite_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GatewayServiceServer).StopDeployment(ctx, req.(*StopDeploymentRequest))
+		return srv.(GatewayServiceServer).RevokeInvite(ctx, req.(*RevokeInviteRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GatewayService_StartDeployment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(StartDeploymentRequest)
+func _GatewayService_CreateServiceAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateServiceAccountRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GatewayServiceServer).StartDeployment(ctx, in)
+		return srv.(GatewayServiceServer).CreateServiceAccount(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GatewayService_StartDeployment_FullMethodName,
+		FullMethod: GatewayService_CreateServiceAccount_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GatewayServiceServer).StartDeployment(ctx, req.(*StartDeploymentRequest))
+		return srv.(GatewayServiceServer).CreateServiceAccount(ctx, req.(*CreateServiceAccountRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GatewayService_ScaleDeployment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ScaleDeploymentRequest)
+func _GatewayService_ListServiceAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListServiceAccountsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GatewayServiceServer).ScaleDeployment(ctx, in)
+		return srv.(GatewayServiceServer).ListServiceAccounts(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GatewayService_ScaleDeployment_FullMethodName,
+		FullMethod: GatewayService_ListServiceAccounts_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GatewayServiceServer).ScaleDeployment(ctx, req.(*ScaleDeploymentRequest))
+		return srv.(GatewayServiceServer).ListServiceAccounts(ctx, req.(*ListServiceAccountsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GatewayService_DeleteDeployment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteDeploymentRequest)
+func _GatewayService_DeleteServiceAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteServiceAccountRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GatewayServiceServer).DeleteDeployment(ctx, in)
+		return srv.(GatewayServiceServer).DeleteServiceAccount(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GatewayService_DeleteDeployment_FullMethodName,
+		FullMethod: GatewayService_DeleteServiceAccount_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GatewayServiceServer).DeleteDeployment(ctx, req.(*DeleteDeploymentRequest))
+		return srv.(GatewayServiceServer).DeleteServiceAccount(ctx, req.(*DeleteServiceAccountRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GatewayService_ListPools_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListPoolsRequest)
+func _GatewayService_IssueServiceAccountToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssueServiceAccountTokenRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GatewayServiceServer).ListPools(ctx, in)
+		return srv.(GatewayServiceServer).IssueServiceAccountToken(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GatewayService_ListPools_FullMethodName,
+		FullMethod: GatewayService_IssueServiceAccountToken_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GatewayServiceServer).ListPools(ctx, req.(*ListPoolsRequest))
+		return srv.(GatewayServiceServer).IssueServiceAccountToken(ctx, req.(*IssueServiceAccountTokenRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GatewayService_ListMachines_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListMachinesRequest)
+func _GatewayService_RenewServiceAccountToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenewServiceAccountTokenRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GatewayServiceServer).ListMachines(ctx, in)
+		return srv.(GatewayServiceServer).RenewServiceAccountToken(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GatewayService_ListMachines_FullMethodName,
+		FullMethod: GatewayService_RenewServiceAccountToken_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GatewayServiceServer).ListMachines(ctx, req.(*ListMachinesRequest))
+		return srv.(GatewayServiceServer).RenewServiceAccountToken(ctx, req.(*RenewServiceAccountTokenRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GatewayService_CreateMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateMachineRequest)
+func _GatewayService_RevokeServiceAccountToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeServiceAccountTokenRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GatewayServiceServer).CreateMachine(ctx, in)
+		return srv.(GatewayServiceServer).RevokeServiceAccountToken(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GatewayService_CreateMachine_FullMethodName,
+		FullMethod: GatewayService_RevokeServiceAccountToken_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GatewayServiceServer).CreateMachine(ctx, req.(*CreateMachineRequest))
+		return srv.(GatewayServiceServer).RevokeServiceAccountToken(ctx, req.(*RevokeServiceAccountTokenRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GatewayService_DeleteMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteMachineRequest)
+func _GatewayService_ListWorkers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWorkersRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GatewayServiceServer).DeleteMachine(ctx, in)
+		return srv.(GatewayServiceServer).ListWorkers(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GatewayService_DeleteMachine_FullMethodName,
+		FullMethod: GatewayService_ListWorkers_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GatewayServiceServer).DeleteMachine(ctx, req.(*DeleteMachineRequest))
+		return srv.(GatewayServiceServer).ListWorkers(ctx, req.(*ListWorkersRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GatewayService_ListTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListTokensRequest)
+func _GatewayService_CordonWorker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CordonWorkerRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GatewayServiceServer).ListTokens(ctx, in)
+		return srv.(GatewayServiceServer).CordonWorker(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GatewayService_ListTokens_FullMethodName,
+		FullMethod: GatewayService_CordonWorker_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GatewayServiceServer).ListTokens(ctx, req.(*ListTokensRequest))
+		return srv.(GatewayServiceServer).CordonWorker(ctx, req.(*CordonWorkerRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GatewayService_CreateToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateTokenRequest)
+func _GatewayService_UncordonWorker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UncordonWorkerRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GatewayServiceServer).CreateToken(ctx, in)
+		return srv.(GatewayServiceServer).UncordonWorker(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GatewayService_CreateToken_FullMethodName,
+		FullMethod: GatewayService_UncordonWorker_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GatewayServiceServer).CreateToken(ctx, req.(*CreateTokenRequest))
+		return srv.(GatewayServiceServer).UncordonWorker(ctx, req.(*UncordonWorkerRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GatewayService_ToggleToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ToggleTokenRequest)
+func _GatewayService_DrainWorker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DrainWorkerRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GatewayServiceServer).ToggleToken(ctx, in)
+		return srv.(GatewayServiceServer).DrainWorker(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GatewayService_ToggleToken_FullMethodName,
+		FullMethod: GatewayService_DrainWorker_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GatewayServiceServer).ToggleToken(ctx, req.(*ToggleTokenRequest))
+		return srv.(GatewayServiceServer).DrainWorker(ctx, req.(*DrainWorkerRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GatewayService_DeleteToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteTokenRequest)
+func _GatewayService_SetWorkerLabels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetWorkerLabelsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GatewayServiceServer).DeleteToken(ctx, in)
+		return srv.(GatewayServiceServer).SetWorkerLabels(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GatewayService_DeleteToken_FullMethodName,
+		FullMethod: GatewayService_SetWorkerLabels_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GatewayServiceServer).DeleteToken(ctx, req.(*DeleteTokenRequest))
+		return srv.(GatewayServiceServer).SetWorkerLabels(ctx, req.(*SetWorkerLabelsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GatewayService_ListWorkers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListWorkersRequest)
+func _GatewayService_SimulateScheduling_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimulateSchedulingRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GatewayServiceServer).ListWorkers(ctx, in)
+		return srv.(GatewayServiceServer).SimulateScheduling(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GatewayService_ListWorkers_FullMethodName,
+		FullMethod: GatewayService_SimulateScheduling_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GatewayServiceServer).ListWorkers(ctx, req.(*ListWorkersRequest))
+		return srv.(GatewayServiceServer).SimulateScheduling(ctx, req.(*SimulateSchedulingRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GatewayService_CordonWorker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CordonWorkerRequest)
+func _GatewayService_ExportWorkspaceConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportWorkspaceConfigRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GatewayServiceServer).CordonWorker(ctx, in)
+		return srv.(GatewayServiceServer).ExportWorkspaceConfig(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GatewayService_CordonWorker_FullMethodName,
+		FullMethod: GatewayService_ExportWorkspaceConfig_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GatewayServiceServer).CordonWorker(ctx, req.(*CordonWorkerRequest))
+		return srv.(GatewayServiceServer).ExportWorkspaceConfig(ctx, req.(*ExportWorkspaceConfigRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GatewayService_UncordonWorker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UncordonWorkerRequest)
+func _GatewayService_GrantResourceAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GrantResourceAccessRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GatewayServiceServer).UncordonWorker(ctx, in)
+		return srv.(GatewayServiceServer).GrantResourceAccess(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GatewayService_UncordonWorker_FullMethodName,
+		FullMethod: GatewayService_GrantResourceAccess_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GatewayServiceServer).UncordonWorker(ctx, req.(*UncordonWorkerRequest))
+		return srv.(GatewayServiceServer).GrantResourceAccess(ctx, req.(*GrantResourceAccessRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GatewayService_DrainWorker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DrainWorkerRequest)
+func _GatewayService_ListResourceGrants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListResourceGrantsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GatewayServiceServer).DrainWorker(ctx, in)
+		return srv.(GatewayServiceServer).ListResourceGrants(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GatewayService_DrainWorker_FullMethodName,
+		FullMethod: GatewayService_ListResourceGrants_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GatewayServiceServer).DrainWorker(ctx, req.(*DrainWorkerRequest))
+		return srv.(GatewayServiceServer).ListResourceGrants(ctx, req.(*ListResourceGrantsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GatewayService_ExportWorkspaceConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ExportWorkspaceConfigRequest)
+func _GatewayService_QueryAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryAuditLogRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GatewayServiceServer).ExportWorkspaceConfig(ctx, in)
+		return srv.(GatewayServiceServer).QueryAuditLog(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GatewayService_ExportWorkspaceConfig_FullMethodName,
+		FullMethod: GatewayService_QueryAuditLog_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GatewayServiceServer).ExportWorkspaceConfig(ctx, req.(*ExportWorkspaceConfigRequest))
+		return srv.(GatewayServiceServer).QueryAuditLog(ctx, req.(*QueryAuditLogRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1285,10 +4341,174 @@ var GatewayService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "HeadObject",
 			Handler:    _GatewayService_HeadObject_Handler,
 		},
+		{
+			MethodName: "ListObjects",
+			Handler:    _GatewayService_ListObjects_Handler,
+		},
 		{
 			MethodName: "CreateObject",
 			Handler:    _GatewayService_CreateObject_Handler,
 		},
+		{
+			MethodName: "GetObjectSignature",
+			Handler:    _GatewayService_GetObjectSignature_Handler,
+		},
+		{
+			MethodName: "GetObjectURL",
+			Handler:    _GatewayService_GetObjectURL_Handler,
+		},
+		{
+			MethodName: "RenameObject",
+			Handler:    _GatewayService_RenameObject_Handler,
+		},
+		{
+			MethodName: "TagObject",
+			Handler:    _GatewayService_TagObject_Handler,
+		},
+		{
+			MethodName: "GetResourceACL",
+			Handler:    _GatewayService_GetResourceACL_Handler,
+		},
+		{
+			MethodName: "SetResourceACL",
+			Handler:    _GatewayService_SetResourceACL_Handler,
+		},
+		{
+			MethodName: "MigrateObjects",
+			Handler:    _GatewayService_MigrateObjects_Handler,
+		},
+		{
+			MethodName: "CompleteObject",
+			Handler:    _GatewayService_CompleteObject_Handler,
+		},
+		{
+			MethodName: "DeleteObject",
+			Handler:    _GatewayService_DeleteObject_Handler,
+		},
+		{
+			MethodName: "DeleteObjects",
+			Handler:    _GatewayService_DeleteObjects_Handler,
+		},
+		{
+			MethodName: "LockObject",
+			Handler:    _GatewayService_LockObject_Handler,
+		},
+		{
+			MethodName: "ListCorruptedObjects",
+			Handler:    _GatewayService_ListCorruptedObjects_Handler,
+		},
+		{
+			MethodName: "CheckObjectConsistency",
+			Handler:    _GatewayService_CheckObjectConsistency_Handler,
+		},
+		{
+			MethodName: "SetObjectLifecyclePolicy",
+			Handler:    _GatewayService_SetObjectLifecyclePolicy_Handler,
+		},
+		{
+			MethodName: "GetObjectLifecyclePolicy",
+			Handler:    _GatewayService_GetObjectLifecyclePolicy_Handler,
+		},
+		{
+			MethodName: "ListObjectVersions",
+			Handler:    _GatewayService_ListObjectVersions_Handler,
+		},
+		{
+			MethodName: "RestoreObjectVersion",
+			Handler:    _GatewayService_RestoreObjectVersion_Handler,
+		},
+		{
+			MethodName: "CopyObject",
+			Handler:    _GatewayService_CopyObject_Handler,
+		},
+		{
+			MethodName: "ExtractObject",
+			Handler:    _GatewayService_ExtractObject_Handler,
+		},
+		{
+			MethodName: "GetStorageUsage",
+			Handler:    _GatewayService_GetStorageUsage_Handler,
+		},
+		{
+			MethodName: "InitiateUpload",
+			Handler:    _GatewayService_InitiateUpload_Handler,
+		},
+		{
+			MethodName: "AppendChunk",
+			Handler:    _GatewayService_AppendChunk_Handler,
+		},
+		{
+			MethodName: "CompleteUpload",
+			Handler:    _GatewayService_CompleteUpload_Handler,
+		},
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _GatewayService_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _GatewayService_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _GatewayService_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "CreateTaskTrigger",
+			Handler:    _GatewayService_CreateTaskTrigger_Handler,
+		},
+		{
+			MethodName: "ListTaskTriggers",
+			Handler:    _GatewayService_ListTaskTriggers_Handler,
+		},
+		{
+			MethodName: "DeleteTaskTrigger",
+			Handler:    _GatewayService_DeleteTaskTrigger_Handler,
+		},
+		{
+			MethodName: "SetUploadBandwidthLimit",
+			Handler:    _GatewayService_SetUploadBandwidthLimit_Handler,
+		},
+		{
+			MethodName: "SetWorkspaceVolumeQuota",
+			Handler:    _GatewayService_SetWorkspaceVolumeQuota_Handler,
+		},
+		{
+			MethodName: "SetWorkspacePolicy",
+			Handler:    _GatewayService_SetWorkspacePolicy_Handler,
+		},
+		{
+			MethodName: "GetWorkspacePolicy",
+			Handler:    _GatewayService_GetWorkspacePolicy_Handler,
+		},
+		{
+			MethodName: "SetPoolQuota",
+			Handler:    _GatewayService_SetPoolQuota_Handler,
+		},
+		{
+			MethodName: "ListPoolQuotas",
+			Handler:    _GatewayService_ListPoolQuotas_Handler,
+		},
+		{
+			MethodName: "ReserveCapacity",
+			Handler:    _GatewayService_ReserveCapacity_Handler,
+		},
+		{
+			MethodName: "ListCapacityReservations",
+			Handler:    _GatewayService_ListCapacityReservations_Handler,
+		},
+		{
+			MethodName: "CancelCapacityReservation",
+			Handler:    _GatewayService_CancelCapacityReservation_Handler,
+		},
+		{
+			MethodName: "GetWorkspaceIPAllowList",
+			Handler:    _GatewayService_GetWorkspaceIPAllowList_Handler,
+		},
+		{
+			MethodName: "SetWorkspaceIPAllowList",
+			Handler:    _GatewayService_SetWorkspaceIPAllowList_Handler,
+		},
 		{
 			MethodName: "CheckpointContainer",
 			Handler:    _GatewayService_CheckpointContainer_Handler,
@@ -1317,6 +4537,22 @@ var GatewayService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListTasks",
 			Handler:    _GatewayService_ListTasks_Handler,
 		},
+		{
+			MethodName: "CancelTasks",
+			Handler:    _GatewayService_CancelTasks_Handler,
+		},
+		{
+			MethodName: "RequeueTasks",
+			Handler:    _GatewayService_RequeueTasks_Handler,
+		},
+		{
+			MethodName: "SetTaskRetryPolicy",
+			Handler:    _GatewayService_SetTaskRetryPolicy_Handler,
+		},
+		{
+			MethodName: "GetTaskRetryPolicy",
+			Handler:    _GatewayService_GetTaskRetryPolicy_Handler,
+		},
 		{
 			MethodName: "GetOrCreateStub",
 			Handler:    _GatewayService_GetOrCreateStub_Handler,
@@ -1349,6 +4585,66 @@ var GatewayService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteDeployment",
 			Handler:    _GatewayService_DeleteDeployment_Handler,
 		},
+		{
+			MethodName: "RollbackDeployment",
+			Handler:    _GatewayService_RollbackDeployment_Handler,
+		},
+		{
+			MethodName: "SetDeploymentEnv",
+			Handler:    _GatewayService_SetDeploymentEnv_Handler,
+		},
+		{
+			MethodName: "UnsetDeploymentEnv",
+			Handler:    _GatewayService_UnsetDeploymentEnv_Handler,
+		},
+		{
+			MethodName: "ListDeploymentEnv",
+			Handler:    _GatewayService_ListDeploymentEnv_Handler,
+		},
+		{
+			MethodName: "AddCustomDomain",
+			Handler:    _GatewayService_AddCustomDomain_Handler,
+		},
+		{
+			MethodName: "ListCustomDomains",
+			Handler:    _GatewayService_ListCustomDomains_Handler,
+		},
+		{
+			MethodName: "RemoveCustomDomain",
+			Handler:    _GatewayService_RemoveCustomDomain_Handler,
+		},
+		{
+			MethodName: "ListDeploymentHistory",
+			Handler:    _GatewayService_ListDeploymentHistory_Handler,
+		},
+		{
+			MethodName: "SetTrafficSplit",
+			Handler:    _GatewayService_SetTrafficSplit_Handler,
+		},
+		{
+			MethodName: "PromoteVersion",
+			Handler:    _GatewayService_PromoteVersion_Handler,
+		},
+		{
+			MethodName: "SetAutoscalingPolicy",
+			Handler:    _GatewayService_SetAutoscalingPolicy_Handler,
+		},
+		{
+			MethodName: "GetAutoscalingPolicy",
+			Handler:    _GatewayService_GetAutoscalingPolicy_Handler,
+		},
+		{
+			MethodName: "ReportAutoscalingMetrics",
+			Handler:    _GatewayService_ReportAutoscalingMetrics_Handler,
+		},
+		{
+			MethodName: "SetKeepWarm",
+			Handler:    _GatewayService_SetKeepWarm_Handler,
+		},
+		{
+			MethodName: "GetKeepWarm",
+			Handler:    _GatewayService_GetKeepWarm_Handler,
+		},
 		{
 			MethodName: "ListPools",
 			Handler:    _GatewayService_ListPools_Handler,
@@ -1381,6 +4677,70 @@ var GatewayService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteToken",
 			Handler:    _GatewayService_DeleteToken_Handler,
 		},
+		{
+			MethodName: "SetTokenRateLimit",
+			Handler:    _GatewayService_SetTokenRateLimit_Handler,
+		},
+		{
+			MethodName: "RotateToken",
+			Handler:    _GatewayService_RotateToken_Handler,
+		},
+		{
+			MethodName: "ExchangeSessionToken",
+			Handler:    _GatewayService_ExchangeSessionToken_Handler,
+		},
+		{
+			MethodName: "RefreshSessionToken",
+			Handler:    _GatewayService_RefreshSessionToken_Handler,
+		},
+		{
+			MethodName: "RevokeSessionToken",
+			Handler:    _GatewayService_RevokeSessionToken_Handler,
+		},
+		{
+			MethodName: "ListMemberRoles",
+			Handler:    _GatewayService_ListMemberRoles_Handler,
+		},
+		{
+			MethodName: "SetMemberRole",
+			Handler:    _GatewayService_SetMemberRole_Handler,
+		},
+		{
+			MethodName: "InviteMember",
+			Handler:    _GatewayService_InviteMember_Handler,
+		},
+		{
+			MethodName: "AcceptInvite",
+			Handler:    _GatewayService_AcceptInvite_Handler,
+		},
+		{
+			MethodName: "RevokeInvite",
+			Handler:    _GatewayService_RevokeInvite_Handler,
+		},
+		{
+			MethodName: "CreateServiceAccount",
+			Handler:    _GatewayService_CreateServiceAccount_Handler,
+		},
+		{
+			MethodName: "ListServiceAccounts",
+			Handler:    _GatewayService_ListServiceAccounts_Handler,
+		},
+		{
+			MethodName: "DeleteServiceAccount",
+			Handler:    _GatewayService_DeleteServiceAccount_Handler,
+		},
+		{
+			MethodName: "IssueServiceAccountToken",
+			Handler:    _GatewayService_IssueServiceAccountToken_Handler,
+		},
+		{
+			MethodName: "RenewServiceAccountToken",
+			Handler:    _GatewayService_RenewServiceAccountToken_Handler,
+		},
+		{
+			MethodName: "RevokeServiceAccountToken",
+			Handler:    _GatewayService_RevokeServiceAccountToken_Handler,
+		},
 		{
 			MethodName: "ListWorkers",
 			Handler:    _GatewayService_ListWorkers_Handler,
@@ -1397,10 +4757,30 @@ var GatewayService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DrainWorker",
 			Handler:    _GatewayService_DrainWorker_Handler,
 		},
+		{
+			MethodName: "SetWorkerLabels",
+			Handler:    _GatewayService_SetWorkerLabels_Handler,
+		},
+		{
+			MethodName: "SimulateScheduling",
+			Handler:    _GatewayService_SimulateScheduling_Handler,
+		},
 		{
 			MethodName: "ExportWorkspaceConfig",
 			Handler:    _GatewayService_ExportWorkspaceConfig_Handler,
 		},
+		{
+			MethodName: "GrantResourceAccess",
+			Handler:    _GatewayService_GrantResourceAccess_Handler,
+		},
+		{
+			MethodName: "ListResourceGrants",
+			Handler:    _GatewayService_ListResourceGrants_Handler,
+		},
+		{
+			MethodName: "QueryAuditLog",
+			Handler:    _GatewayService_QueryAuditLog_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1408,12 +4788,45 @@ var GatewayService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _GatewayService_PutObjectStream_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "PutObjectStreamV2",
+			Handler:       _GatewayService_PutObjectStreamV2_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "PatchObject",
+			Handler:       _GatewayService_PatchObject_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "GetObjectStream",
+			Handler:       _GatewayService_GetObjectStream_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "AttachToContainer",
 			Handler:       _GatewayService_AttachToContainer_Handler,
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "ExecInContainer",
+			Handler:       _GatewayService_ExecInContainer_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "StreamLogs",
+			Handler:       _GatewayService_StreamLogs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "PortForward",
+			Handler:       _GatewayService_PortForward_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "gateway.proto",
 }
@@ -19,9 +19,11 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	OutputService_OutputSaveStream_FullMethodName = "/output.OutputService/OutputSaveStream"
-	OutputService_OutputStat_FullMethodName       = "/output.OutputService/OutputStat"
-	OutputService_OutputPublicURL_FullMethodName  = "/output.OutputService/OutputPublicURL"
+	OutputService_OutputSaveStream_FullMethodName         = "/output.OutputService/OutputSaveStream"
+	OutputService_OutputStat_FullMethodName               = "/output.OutputService/OutputStat"
+	OutputService_OutputPublicURL_FullMethodName          = "/output.OutputService/OutputPublicURL"
+	OutputService_SetOutputRetentionPolicy_FullMethodName = "/output.OutputService/SetOutputRetentionPolicy"
+	OutputService_GetOutputRetentionPolicy_FullMethodName = "/output.OutputService/GetOutputRetentionPolicy"
 )
 
 // OutputServiceClient is the client API for OutputService service.
@@ -31,6 +33,8 @@ type OutputServiceClient interface {
 	OutputSaveStream(ctx context.Context, opts ...grpc.CallOption) (OutputService_OutputSaveStreamClient, error)
 	OutputStat(ctx context.Context, in *OutputStatRequest, opts ...grpc.CallOption) (*OutputStatResponse, error)
 	OutputPublicURL(ctx context.Context, in *OutputPublicURLRequest, opts ...grpc.CallOption) (*OutputPublicURLResponse, error)
+	SetOutputRetentionPolicy(ctx context.Context, in *SetOutputRetentionPolicyRequest, opts ...grpc.CallOption) (*SetOutputRetentionPolicyResponse, error)
+	GetOutputRetentionPolicy(ctx context.Context, in *GetOutputRetentionPolicyRequest, opts ...grpc.CallOption) (*GetOutputRetentionPolicyResponse, error)
 }
 
 type outputServiceClient struct {
@@ -93,6 +97,24 @@ func (c *outputServiceClient) OutputPublicURL(ctx context.Context, in *OutputPub
 	return out, nil
 }
 
+func (c *outputServiceClient) SetOutputRetentionPolicy(ctx context.Context, in *SetOutputRetentionPolicyRequest, opts ...grpc.CallOption) (*SetOutputRetentionPolicyResponse, error) {
+	out := new(SetOutputRetentionPolicyResponse)
+	err := c.cc.Invoke(ctx, OutputService_SetOutputRetentionPolicy_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *outputServiceClient) GetOutputRetentionPolicy(ctx context.Context, in *GetOutputRetentionPolicyRequest, opts ...grpc.CallOption) (*GetOutputRetentionPolicyResponse, error) {
+	out := new(GetOutputRetentionPolicyResponse)
+	err := c.cc.Invoke(ctx, OutputService_GetOutputRetentionPolicy_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OutputServiceServer is the server API for OutputService service.
 // All implementations must embed UnimplementedOutputServiceServer
 // for forward compatibility
@@ -100,6 +122,8 @@ type OutputServiceServer interface {
 	OutputSaveStream(OutputService_OutputSaveStreamServer) error
 	OutputStat(context.Context, *OutputStatRequest) (*OutputStatResponse, error)
 	OutputPublicURL(context.Context, *OutputPublicURLRequest) (*OutputPublicURLResponse, error)
+	SetOutputRetentionPolicy(context.Context, *SetOutputRetentionPolicyRequest) (*SetOutputRetentionPolicyResponse, error)
+	GetOutputRetentionPolicy(context.Context, *GetOutputRetentionPolicyRequest) (*GetOutputRetentionPolicyResponse, error)
 	mustEmbedUnimplementedOutputServiceServer()
 }
 
@@ -116,6 +140,12 @@ func (UnimplementedOutputServiceServer) OutputStat(context.Context, *OutputStatR
 func (UnimplementedOutputServiceServer) OutputPublicURL(context.Context, *OutputPublicURLRequest) (*OutputPublicURLResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method OutputPublicURL not implemented")
 }
+func (UnimplementedOutputServiceServer) SetOutputRetentionPolicy(context.Context, *SetOutputRetentionPolicyRequest) (*SetOutputRetentionPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetOutputRetentionPolicy not implemented")
+}
+func (UnimplementedOutputServiceServer) GetOutputRetentionPolicy(context.Context, *GetOutputRetentionPolicyRequest) (*GetOutputRetentionPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOutputRetentionPolicy not implemented")
+}
 func (UnimplementedOutputServiceServer) mustEmbedUnimplementedOutputServiceServer() {}
 
 // UnsafeOutputServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -191,6 +221,42 @@ func _OutputService_OutputPublicURL_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OutputService_SetOutputRetentionPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetOutputRetentionPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutputServiceServer).SetOutputRetentionPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OutputService_SetOutputRetentionPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutputServiceServer).SetOutputRetentionPolicy(ctx, req.(*SetOutputRetentionPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OutputService_GetOutputRetentionPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOutputRetentionPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutputServiceServer).GetOutputRetentionPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OutputService_GetOutputRetentionPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutputServiceServer).GetOutputRetentionPolicy(ctx, req.(*GetOutputRetentionPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // OutputService_ServiceDesc is the grpc.ServiceDesc for OutputService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -206,6 +272,14 @@ var OutputService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "OutputPublicURL",
 			Handler:    _OutputService_OutputPublicURL_Handler,
 		},
+		{
+			MethodName: "SetOutputRetentionPolicy",
+			Handler:    _OutputService_SetOutputRetentionPolicy_Handler,
+		},
+		{
+			MethodName: "GetOutputRetentionPolicy",
+			Handler:    _OutputService_GetOutputRetentionPolicy_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -0,0 +1,195 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.25.1
+// source: plugin.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	ProviderPlugin_ProvisionMachine_FullMethodName = "/providers.ProviderPlugin/ProvisionMachine"
+	ProviderPlugin_TerminateMachine_FullMethodName = "/providers.ProviderPlugin/TerminateMachine"
+	ProviderPlugin_ListPoolMachines_FullMethodName = "/providers.ProviderPlugin/ListPoolMachines"
+)
+
+// ProviderPluginClient is the client API for ProviderPlugin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ProviderPluginClient interface {
+	// ProvisionMachine creates a machine for the pool with at least the requested resources. Once it boots, the
+	// machine has to run the beta9 agent with the registration details in the request, which the user_data
+	// script does, so it joins the pool. It returns once the machine is being created, not once it's ready.
+	ProvisionMachine(ctx context.Context, in *ProvisionMachineRequest, opts ...grpc.CallOption) (*ProvisionMachineResponse, error)
+	// TerminateMachine deletes a machine the plugin provisioned. Machines that are already gone aren't an error.
+	TerminateMachine(ctx context.Context, in *TerminateMachineRequest, opts ...grpc.CallOption) (*TerminateMachineResponse, error)
+	// ListPoolMachines returns the machines the plugin provisioned for the pool that are still running. Machines
+	// beta9 doesn't know about, or that have been idle for a while, are terminated.
+	ListPoolMachines(ctx context.Context, in *ListPoolMachinesRequest, opts ...grpc.CallOption) (*ListPoolMachinesResponse, error)
+}
+
+type providerPluginClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewProviderPluginClient(cc grpc.ClientConnInterface) ProviderPluginClient {
+	return &providerPluginClient{cc}
+}
+
+func (c *providerPluginClient) ProvisionMachine(ctx context.Context, in *ProvisionMachineRequest, opts ...grpc.CallOption) (*ProvisionMachineResponse, error) {
+	out := new(ProvisionMachineResponse)
+	err := c.cc.Invoke(ctx, ProviderPlugin_ProvisionMachine_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *providerPluginClient) TerminateMachine(ctx context.Context, in *TerminateMachineRequest, opts ...grpc.CallOption) (*TerminateMachineResponse, error) {
+	out := new(TerminateMachineResponse)
+	err := c.cc.Invoke(ctx, ProviderPlugin_TerminateMachine_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *providerPluginClient) ListPoolMachines(ctx context.Context, in *ListPoolMachinesRequest, opts ...grpc.CallOption) (*ListPoolMachinesResponse, error) {
+	out := new(ListPoolMachinesResponse)
+	err := c.cc.Invoke(ctx, ProviderPlugin_ListPoolMachines_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ProviderPluginServer is the server API for ProviderPlugin service.
+// All implementations must embed UnimplementedProviderPluginServer
+// for forward compatibility
+type ProviderPluginServer interface {
+	// ProvisionMachine creates a machine for the pool with at least the requested resources. Once it boots, the
+	// machine has to run the beta9 agent with the registration details in the request, which the user_data
+	// script does, so it joins the pool. It returns once the machine is being created, not once it's ready.
+	ProvisionMachine(context.Context, *ProvisionMachineRequest) (*ProvisionMachineResponse, error)
+	// TerminateMachine deletes a machine the plugin provisioned. Machines that are already gone aren't an error.
+	TerminateMachine(context.Context, *TerminateMachineRequest) (*TerminateMachineResponse, error)
+	// ListPoolMachines returns the machines the plugin provisioned for the pool that are still running. Machines
+	// beta9 doesn't know about, or that have been idle for a while, are terminated.
+	ListPoolMachines(context.Context, *ListPoolMachinesRequest) (*ListPoolMachinesResponse, error)
+	mustEmbedUnimplementedProviderPluginServer()
+}
+
+// UnimplementedProviderPluginServer must be embedded to have forward compatible implementations.
+type UnimplementedProviderPluginServer struct {
+}
+
+func (UnimplementedProviderPluginServer) ProvisionMachine(context.Context, *ProvisionMachineRequest) (*ProvisionMachineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProvisionMachine not implemented")
+}
+func (UnimplementedProviderPluginServer) TerminateMachine(context.Context, *TerminateMachineRequest) (*TerminateMachineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TerminateMachine not implemented")
+}
+func (UnimplementedProviderPluginServer) ListPoolMachines(context.Context, *ListPoolMachinesRequest) (*ListPoolMachinesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPoolMachines not implemented")
+}
+func (UnimplementedProviderPluginServer) mustEmbedUnimplementedProviderPluginServer() {}
+
+// UnsafeProviderPluginServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ProviderPluginServer will
+// result in compilation errors.
+type UnsafeProviderPluginServer interface {
+	mustEmbedUnimplementedProviderPluginServer()
+}
+
+func RegisterProviderPluginServer(s grpc.ServiceRegistrar, srv ProviderPluginServer) {
+	s.RegisterService(&ProviderPlugin_ServiceDesc, srv)
+}
+
+func _ProviderPlugin_ProvisionMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProvisionMachineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProviderPluginServer).ProvisionMachine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProviderPlugin_ProvisionMachine_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProviderPluginServer).ProvisionMachine(ctx, req.(*ProvisionMachineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProviderPlugin_TerminateMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TerminateMachineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProviderPluginServer).TerminateMachine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProviderPlugin_TerminateMachine_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProviderPluginServer).TerminateMachine(ctx, req.(*TerminateMachineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProviderPlugin_ListPoolMachines_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPoolMachinesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProviderPluginServer).ListPoolMachines(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProviderPlugin_ListPoolMachines_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProviderPluginServer).ListPoolMachines(ctx, req.(*ListPoolMachinesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ProviderPlugin_ServiceDesc is the grpc.ServiceDesc for ProviderPlugin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ProviderPlugin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "providers.ProviderPlugin",
+	HandlerType: (*ProviderPluginServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ProvisionMachine",
+			Handler:    _ProviderPlugin_ProvisionMachine_Handler,
+		},
+		{
+			MethodName: "TerminateMachine",
+			Handler:    _ProviderPlugin_TerminateMachine_Handler,
+		},
+		{
+			MethodName: "ListPoolMachines",
+			Handler:    _ProviderPlugin_ListPoolMachines_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "plugin.proto",
+}
@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
-// - protoc-gen-go-grpc v1.6.1
-// - protoc             v5.28.3
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.25.1
 // source: pod.proto
 
 package proto
@@ -15,8 +15,8 @@ import (
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the grpc package it is being compiled against.
-// Requires gRPC-Go v1.64.0 or later.
-const _ = grpc.SupportPackageIsVersion9
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
 
 const (
 	PodService_CreatePod_FullMethodName                        = "/pod.PodService/CreatePod"
@@ -84,9 +84,8 @@ func NewPodServiceClient(cc grpc.ClientConnInterface) PodServiceClient {
 }
 
 func (c *podServiceClient) CreatePod(ctx context.Context, in *CreatePodRequest, opts ...grpc.CallOption) (*CreatePodResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CreatePodResponse)
-	err := c.cc.Invoke(ctx, PodService_CreatePod_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, PodService_CreatePod_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -94,9 +93,8 @@ func (c *podServiceClient) CreatePod(ctx context.Context, in *CreatePodRequest,
 }
 
 func (c *podServiceClient) SandboxExec(ctx context.Context, in *PodSandboxExecRequest, opts ...grpc.CallOption) (*PodSandboxExecResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PodSandboxExecResponse)
-	err := c.cc.Invoke(ctx, PodService_SandboxExec_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, PodService_SandboxExec_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -104,9 +102,8 @@ func (c *podServiceClient) SandboxExec(ctx context.Context, in *PodSandboxExecRe
 }
 
 func (c *podServiceClient) SandboxStatus(ctx context.Context, in *PodSandboxStatusRequest, opts ...grpc.CallOption) (*PodSandboxStatusResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PodSandboxStatusResponse)
-	err := c.cc.Invoke(ctx, PodService_SandboxStatus_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, PodService_SandboxStatus_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -114,9 +111,8 @@ func (c *podServiceClient) SandboxStatus(ctx context.Context, in *PodSandboxStat
 }
 
 func (c *podServiceClient) SandboxStdout(ctx context.Context, in *PodSandboxStdoutRequest, opts ...grpc.CallOption) (*PodSandboxStdoutResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PodSandboxStdoutResponse)
-	err := c.cc.Invoke(ctx, PodService_SandboxStdout_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, PodService_SandboxStdout_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -124,9 +120,8 @@ func (c *podServiceClient) SandboxStdout(ctx context.Context, in *PodSandboxStdo
 }
 
 func (c *podServiceClient) SandboxStderr(ctx context.Context, in *PodSandboxStderrRequest, opts ...grpc.CallOption) (*PodSandboxStderrResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PodSandboxStderrResponse)
-	err := c.cc.Invoke(ctx, PodService_SandboxStderr_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, PodService_SandboxStderr_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -134,9 +129,8 @@ func (c *podServiceClient) SandboxStderr(ctx context.Context, in *PodSandboxStde
 }
 
 func (c *podServiceClient) SandboxKill(ctx context.Context, in *PodSandboxKillRequest, opts ...grpc.CallOption) (*PodSandboxKillResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PodSandboxKillResponse)
-	err := c.cc.Invoke(ctx, PodService_SandboxKill_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, PodService_SandboxKill_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -144,9 +138,8 @@ func (c *podServiceClient) SandboxKill(ctx context.Context, in *PodSandboxKillRe
 }
 
 func (c *podServiceClient) SandboxListProcesses(ctx context.Context, in *PodSandboxListProcessesRequest, opts ...grpc.CallOption) (*PodSandboxListProcessesResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PodSandboxListProcessesResponse)
-	err := c.cc.Invoke(ctx, PodService_SandboxListProcesses_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, PodService_SandboxListProcesses_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -154,9 +147,8 @@ func (c *podServiceClient) SandboxListProcesses(ctx context.Context, in *PodSand
 }
 
 func (c *podServiceClient) SandboxUploadFile(ctx context.Context, in *PodSandboxUploadFileRequest, opts ...grpc.CallOption) (*PodSandboxUploadFileResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PodSandboxUploadFileResponse)
-	err := c.cc.Invoke(ctx, PodService_SandboxUploadFile_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, PodService_SandboxUploadFile_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -164,9 +156,8 @@ func (c *podServiceClient) SandboxUploadFile(ctx context.Context, in *PodSandbox
 }
 
 func (c *podServiceClient) SandboxDownloadFile(ctx context.Context, in *PodSandboxDownloadFileRequest, opts ...grpc.CallOption) (*PodSandboxDownloadFileResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PodSandboxDownloadFileResponse)
-	err := c.cc.Invoke(ctx, PodService_SandboxDownloadFile_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, PodService_SandboxDownloadFile_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -174,9 +165,8 @@ func (c *podServiceClient) SandboxDownloadFile(ctx context.Context, in *PodSandb
 }
 
 func (c *podServiceClient) SandboxStatFile(ctx context.Context, in *PodSandboxStatFileRequest, opts ...grpc.CallOption) (*PodSandboxStatFileResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PodSandboxStatFileResponse)
-	err := c.cc.Invoke(ctx, PodService_SandboxStatFile_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, PodService_SandboxStatFile_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -184,9 +174,8 @@ func (c *podServiceClient) SandboxStatFile(ctx context.Context, in *PodSandboxSt
 }
 
 func (c *podServiceClient) SandboxListFiles(ctx context.Context, in *PodSandboxListFilesRequest, opts ...grpc.CallOption) (*PodSandboxListFilesResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PodSandboxListFilesResponse)
-	err := c.cc.Invoke(ctx, PodService_SandboxListFiles_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, PodService_SandboxListFiles_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -194,9 +183,8 @@ func (c *podServiceClient) SandboxListFiles(ctx context.Context, in *PodSandboxL
 }
 
 func (c *podServiceClient) SandboxDeleteFile(ctx context.Context, in *PodSandboxDeleteFileRequest, opts ...grpc.CallOption) (*PodSandboxDeleteFileResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PodSandboxDeleteFileResponse)
-	err := c.cc.Invoke(ctx, PodService_SandboxDeleteFile_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, PodService_SandboxDeleteFile_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -204,9 +192,8 @@ func (c *podServiceClient) SandboxDeleteFile(ctx context.Context, in *PodSandbox
 }
 
 func (c *podServiceClient) SandboxCreateDirectory(ctx context.Context, in *PodSandboxCreateDirectoryRequest, opts ...grpc.CallOption) (*PodSandboxCreateDirectoryResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PodSandboxCreateDirectoryResponse)
-	err := c.cc.Invoke(ctx, PodService_SandboxCreateDirectory_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, PodService_SandboxCreateDirectory_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -214,9 +201,8 @@ func (c *podServiceClient) SandboxCreateDirectory(ctx context.Context, in *PodSa
 }
 
 func (c *podServiceClient) SandboxDeleteDirectory(ctx context.Context, in *PodSandboxDeleteDirectoryRequest, opts ...grpc.CallOption) (*PodSandboxDeleteDirectoryResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PodSandboxDeleteDirectoryResponse)
-	err := c.cc.Invoke(ctx, PodService_SandboxDeleteDirectory_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, PodService_SandboxDeleteDirectory_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -224,9 +210,8 @@ func (c *podServiceClient) SandboxDeleteDirectory(ctx context.Context, in *PodSa
 }
 
 func (c *podServiceClient) SandboxExposePort(ctx context.Context, in *PodSandboxExposePortRequest, opts ...grpc.CallOption) (*PodSandboxExposePortResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PodSandboxExposePortResponse)
-	err := c.cc.Invoke(ctx, PodService_SandboxExposePort_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, PodService_SandboxExposePort_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -234,9 +219,8 @@ func (c *podServiceClient) SandboxExposePort(ctx context.Context, in *PodSandbox
 }
 
 func (c *podServiceClient) SandboxUpdateNetworkPermissions(ctx context.Context, in *PodSandboxUpdateNetworkPermissionsRequest, opts ...grpc.CallOption) (*PodSandboxUpdateNetworkPermissionsResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PodSandboxUpdateNetworkPermissionsResponse)
-	err := c.cc.Invoke(ctx, PodService_SandboxUpdateNetworkPermissions_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, PodService_SandboxUpdateNetworkPermissions_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -244,9 +228,8 @@ func (c *podServiceClient) SandboxUpdateNetworkPermissions(ctx context.Context,
 }
 
 func (c *podServiceClient) SandboxReplaceInFiles(ctx context.Context, in *PodSandboxReplaceInFilesRequest, opts ...grpc.CallOption) (*PodSandboxReplaceInFilesResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PodSandboxReplaceInFilesResponse)
-	err := c.cc.Invoke(ctx, PodService_SandboxReplaceInFiles_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, PodService_SandboxReplaceInFiles_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -254,9 +237,8 @@ func (c *podServiceClient) SandboxReplaceInFiles(ctx context.Context, in *PodSan
 }
 
 func (c *podServiceClient) SandboxFindInFiles(ctx context.Context, in *PodSandboxFindInFilesRequest, opts ...grpc.CallOption) (*PodSandboxFindInFilesResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PodSandboxFindInFilesResponse)
-	err := c.cc.Invoke(ctx, PodService_SandboxFindInFiles_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, PodService_SandboxFindInFiles_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -264,9 +246,8 @@ func (c *podServiceClient) SandboxFindInFiles(ctx context.Context, in *PodSandbo
 }
 
 func (c *podServiceClient) SandboxConnect(ctx context.Context, in *PodSandboxConnectRequest, opts ...grpc.CallOption) (*PodSandboxConnectResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PodSandboxConnectResponse)
-	err := c.cc.Invoke(ctx, PodService_SandboxConnect_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, PodService_SandboxConnect_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -274,9 +255,8 @@ func (c *podServiceClient) SandboxConnect(ctx context.Context, in *PodSandboxCon
 }
 
 func (c *podServiceClient) SandboxUpdateTTL(ctx context.Context, in *PodSandboxUpdateTTLRequest, opts ...grpc.CallOption) (*PodSandboxUpdateTTLResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PodSandboxUpdateTTLResponse)
-	err := c.cc.Invoke(ctx, PodService_SandboxUpdateTTL_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, PodService_SandboxUpdateTTL_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -284,9 +264,8 @@ func (c *podServiceClient) SandboxUpdateTTL(ctx context.Context, in *PodSandboxU
 }
 
 func (c *podServiceClient) SandboxCreateImageFromFilesystem(ctx context.Context, in *PodSandboxCreateImageFromFilesystemRequest, opts ...grpc.CallOption) (*PodSandboxCreateImageFromFilesystemResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PodSandboxCreateImageFromFilesystemResponse)
-	err := c.cc.Invoke(ctx, PodService_SandboxCreateImageFromFilesystem_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, PodService_SandboxCreateImageFromFilesystem_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -294,9 +273,8 @@ func (c *podServiceClient) SandboxCreateImageFromFilesystem(ctx context.Context,
 }
 
 func (c *podServiceClient) SandboxSnapshotMemory(ctx context.Context, in *PodSandboxSnapshotMemoryRequest, opts ...grpc.CallOption) (*PodSandboxSnapshotMemoryResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PodSandboxSnapshotMemoryResponse)
-	err := c.cc.Invoke(ctx, PodService_SandboxSnapshotMemory_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, PodService_SandboxSnapshotMemory_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -304,9 +282,8 @@ func (c *podServiceClient) SandboxSnapshotMemory(ctx context.Context, in *PodSan
 }
 
 func (c *podServiceClient) SandboxListUrls(ctx context.Context, in *PodSandboxListUrlsRequest, opts ...grpc.CallOption) (*PodSandboxListUrlsResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PodSandboxListUrlsResponse)
-	err := c.cc.Invoke(ctx, PodService_SandboxListUrls_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, PodService_SandboxListUrls_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -314,9 +291,8 @@ func (c *podServiceClient) SandboxListUrls(ctx context.Context, in *PodSandboxLi
 }
 
 func (c *podServiceClient) SandboxWaitForCompletion(ctx context.Context, in *PodSandboxWaitForCompletionRequest, opts ...grpc.CallOption) (*PodSandboxWaitForCompletionResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PodSandboxWaitForCompletionResponse)
-	err := c.cc.Invoke(ctx, PodService_SandboxWaitForCompletion_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, PodService_SandboxWaitForCompletion_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -325,7 +301,7 @@ func (c *podServiceClient) SandboxWaitForCompletion(ctx context.Context, in *Pod
 
 // PodServiceServer is the server API for PodService service.
 // All implementations must embed UnimplementedPodServiceServer
-// for forward compatibility.
+// for forward compatibility
 type PodServiceServer interface {
 	CreatePod(context.Context, *CreatePodRequest) (*CreatePodResponse, error)
 	SandboxExec(context.Context, *PodSandboxExecRequest) (*PodSandboxExecResponse, error)
@@ -354,87 +330,83 @@ type PodServiceServer interface {
 	mustEmbedUnimplementedPodServiceServer()
 }
 
-// UnimplementedPodServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedPodServiceServer struct{}
+// UnimplementedPodServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedPodServiceServer struct {
+}
 
 func (UnimplementedPodServiceServer) CreatePod(context.Context, *CreatePodRequest) (*CreatePodResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method CreatePod not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePod not implemented")
 }
 func (UnimplementedPodServiceServer) SandboxExec(context.Context, *PodSandboxExecRequest) (*PodSandboxExecResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method SandboxExec not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method SandboxExec not implemented")
 }
 func (UnimplementedPodServiceServer) SandboxStatus(context.Context, *PodSandboxStatusRequest) (*PodSandboxStatusResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method SandboxStatus not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method SandboxStatus not implemented")
 }
 func (UnimplementedPodServiceServer) SandboxStdout(context.Context, *PodSandboxStdoutRequest) (*PodSandboxStdoutResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method SandboxStdout not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method SandboxStdout not implemented")
 }
 func (UnimplementedPodServiceServer) SandboxStderr(context.Context, *PodSandboxStderrRequest) (*PodSandboxStderrResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method SandboxStderr not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method SandboxStderr not implemented")
 }
 func (UnimplementedPodServiceServer) SandboxKill(context.Context, *PodSandboxKillRequest) (*PodSandboxKillResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method SandboxKill not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method SandboxKill not implemented")
 }
 func (UnimplementedPodServiceServer) SandboxListProcesses(context.Context, *PodSandboxListProcessesRequest) (*PodSandboxListProcessesResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method SandboxListProcesses not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method SandboxListProcesses not implemented")
 }
 func (UnimplementedPodServiceServer) SandboxUploadFile(context.Context, *PodSandboxUploadFileRequest) (*PodSandboxUploadFileResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method SandboxUploadFile not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method SandboxUploadFile not implemented")
 }
 func (UnimplementedPodServiceServer) SandboxDownloadFile(context.Context, *PodSandboxDownloadFileRequest) (*PodSandboxDownloadFileResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method SandboxDownloadFile not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method SandboxDownloadFile not implemented")
 }
 func (UnimplementedPodServiceServer) SandboxStatFile(context.Context, *PodSandboxStatFileRequest) (*PodSandboxStatFileResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method SandboxStatFile not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method SandboxStatFile not implemented")
 }
 func (UnimplementedPodServiceServer) SandboxListFiles(context.Context, *PodSandboxListFilesRequest) (*PodSandboxListFilesResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method SandboxListFiles not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method SandboxListFiles not implemented")
 }
 func (UnimplementedPodServiceServer) SandboxDeleteFile(context.Context, *PodSandboxDeleteFileRequest) (*PodSandboxDeleteFileResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method SandboxDeleteFile not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method SandboxDeleteFile not implemented")
 }
 func (UnimplementedPodServiceServer) SandboxCreateDirectory(context.Context, *PodSandboxCreateDirectoryRequest) (*PodSandboxCreateDirectoryResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method SandboxCreateDirectory not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method SandboxCreateDirectory not implemented")
 }
 func (UnimplementedPodServiceServer) SandboxDeleteDirectory(context.Context, *PodSandboxDeleteDirectoryRequest) (*PodSandboxDeleteDirectoryResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method SandboxDeleteDirectory not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method SandboxDeleteDirectory not implemented")
 }
 func (UnimplementedPodServiceServer) SandboxExposePort(context.Context, *PodSandboxExposePortRequest) (*PodSandboxExposePortResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method SandboxExposePort not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method SandboxExposePort not implemented")
 }
 func (UnimplementedPodServiceServer) SandboxUpdateNetworkPermissions(context.Context, *PodSandboxUpdateNetworkPermissionsRequest) (*PodSandboxUpdateNetworkPermissionsResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method SandboxUpdateNetworkPermissions not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method SandboxUpdateNetworkPermissions not implemented")
 }
 func (UnimplementedPodServiceServer) SandboxReplaceInFiles(context.Context, *PodSandboxReplaceInFilesRequest) (*PodSandboxReplaceInFilesResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method SandboxReplaceInFiles not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method SandboxReplaceInFiles not implemented")
 }
 func (UnimplementedPodServiceServer) SandboxFindInFiles(context.Context, *PodSandboxFindInFilesRequest) (*PodSandboxFindInFilesResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method SandboxFindInFiles not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method SandboxFindInFiles not implemented")
 }
 func (UnimplementedPodServiceServer) SandboxConnect(context.Context, *PodSandboxConnectRequest) (*PodSandboxConnectResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method SandboxConnect not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method SandboxConnect not implemented")
 }
 func (UnimplementedPodServiceServer) SandboxUpdateTTL(context.Context, *PodSandboxUpdateTTLRequest) (*PodSandboxUpdateTTLResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method SandboxUpdateTTL not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method SandboxUpdateTTL not implemented")
 }
 func (UnimplementedPodServiceServer) SandboxCreateImageFromFilesystem(context.Context, *PodSandboxCreateImageFromFilesystemRequest) (*PodSandboxCreateImageFromFilesystemResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method SandboxCreateImageFromFilesystem not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method SandboxCreateImageFromFilesystem not implemented")
 }
 func (UnimplementedPodServiceServer) SandboxSnapshotMemory(context.Context, *PodSandboxSnapshotMemoryRequest) (*PodSandboxSnapshotMemoryResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method SandboxSnapshotMemory not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method SandboxSnapshotMemory not implemented")
 }
 func (UnimplementedPodServiceServer) SandboxListUrls(context.Context, *PodSandboxListUrlsRequest) (*PodSandboxListUrlsResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method SandboxListUrls not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method SandboxListUrls not implemented")
 }
 func (UnimplementedPodServiceServer) SandboxWaitForCompletion(context.Context, *PodSandboxWaitForCompletionRequest) (*PodSandboxWaitForCompletionResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method SandboxWaitForCompletion not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method SandboxWaitForCompletion not implemented")
 }
 func (UnimplementedPodServiceServer) mustEmbedUnimplementedPodServiceServer() {}
-func (UnimplementedPodServiceServer) testEmbeddedByValue()                    {}
 
 // UnsafePodServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to PodServiceServer will
@@ -444,13 +416,6 @@ type UnsafePodServiceServer interface {
 }
 
 func RegisterPodServiceServer(s grpc.ServiceRegistrar, srv PodServiceServer) {
-	// If the following call panics, it indicates UnimplementedPodServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
-	}
 	s.RegisterService(&PodService_ServiceDesc, srv)
 }
 
@@ -19,11 +19,13 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	SecretService_CreateSecret_FullMethodName = "/secret.SecretService/CreateSecret"
-	SecretService_DeleteSecret_FullMethodName = "/secret.SecretService/DeleteSecret"
-	SecretService_UpdateSecret_FullMethodName = "/secret.SecretService/UpdateSecret"
-	SecretService_GetSecret_FullMethodName    = "/secret.SecretService/GetSecret"
-	SecretService_ListSecrets_FullMethodName  = "/secret.SecretService/ListSecrets"
+	SecretService_CreateSecret_FullMethodName     = "/secret.SecretService/CreateSecret"
+	SecretService_DeleteSecret_FullMethodName     = "/secret.SecretService/DeleteSecret"
+	SecretService_UpdateSecret_FullMethodName     = "/secret.SecretService/UpdateSecret"
+	SecretService_GetSecret_FullMethodName        = "/secret.SecretService/GetSecret"
+	SecretService_ListSecrets_FullMethodName      = "/secret.SecretService/ListSecrets"
+	SecretService_RotateSecret_FullMethodName     = "/secret.SecretService/RotateSecret"
+	SecretService_GetSecretVersion_FullMethodName = "/secret.SecretService/GetSecretVersion"
 )
 
 // SecretServiceClient is the client API for SecretService service.
@@ -35,6 +37,8 @@ type SecretServiceClient interface {
 	UpdateSecret(ctx context.Context, in *UpdateSecretRequest, opts ...grpc.CallOption) (*UpdateSecretResponse, error)
 	GetSecret(ctx context.Context, in *GetSecretRequest, opts ...grpc.CallOption) (*GetSecretResponse, error)
 	ListSecrets(ctx context.Context, in *ListSecretsRequest, opts ...grpc.CallOption) (*ListSecretsResponse, error)
+	RotateSecret(ctx context.Context, in *RotateSecretRequest, opts ...grpc.CallOption) (*RotateSecretResponse, error)
+	GetSecretVersion(ctx context.Context, in *GetSecretVersionRequest, opts ...grpc.CallOption) (*GetSecretVersionResponse, error)
 }
 
 type secretServiceClient struct {
@@ -90,6 +94,24 @@ func (c *secretServiceClient) ListSecrets(ctx context.Context, in *ListSecretsRe
 	return out, nil
 }
 
+func (c *secretServiceClient) RotateSecret(ctx context.Context, in *RotateSecretRequest, opts ...grpc.CallOption) (*RotateSecretResponse, error) {
+	out := new(RotateSecretResponse)
+	err := c.cc.Invoke(ctx, SecretService_RotateSecret_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) GetSecretVersion(ctx context.Context, in *GetSecretVersionRequest, opts ...grpc.CallOption) (*GetSecretVersionResponse, error) {
+	out := new(GetSecretVersionResponse)
+	err := c.cc.Invoke(ctx, SecretService_GetSecretVersion_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SecretServiceServer is the server API for SecretService service.
 // All implementations must embed UnimplementedSecretServiceServer
 // for forward compatibility
@@ -99,6 +121,8 @@ type SecretServiceServer interface {
 	UpdateSecret(context.Context, *UpdateSecretRequest) (*UpdateSecretResponse, error)
 	GetSecret(context.Context, *GetSecretRequest) (*GetSecretResponse, error)
 	ListSecrets(context.Context, *ListSecretsRequest) (*ListSecretsResponse, error)
+	RotateSecret(context.Context, *RotateSecretRequest) (*RotateSecretResponse, error)
+	GetSecretVersion(context.Context, *GetSecretVersionRequest) (*GetSecretVersionResponse, error)
 	mustEmbedUnimplementedSecretServiceServer()
 }
 
@@ -121,6 +145,12 @@ func (UnimplementedSecretServiceServer) GetSecret(context.Context, *GetSecretReq
 func (UnimplementedSecretServiceServer) ListSecrets(context.Context, *ListSecretsRequest) (*ListSecretsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListSecrets not implemented")
 }
+func (UnimplementedSecretServiceServer) RotateSecret(context.Context, *RotateSecretRequest) (*RotateSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateSecret not implemented")
+}
+func (UnimplementedSecretServiceServer) GetSecretVersion(context.Context, *GetSecretVersionRequest) (*GetSecretVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSecretVersion not implemented")
+}
 func (UnimplementedSecretServiceServer) mustEmbedUnimplementedSecretServiceServer() {}
 
 // UnsafeSecretServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -224,6 +254,42 @@ func _SecretService_ListSecrets_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SecretService_RotateSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).RotateSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecretService_RotateSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).RotateSecret(ctx, req.(*RotateSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_GetSecretVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSecretVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).GetSecretVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecretService_GetSecretVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).GetSecretVersion(ctx, req.(*GetSecretVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SecretService_ServiceDesc is the grpc.ServiceDesc for SecretService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -251,6 +317,14 @@ var SecretService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListSecrets",
 			Handler:    _SecretService_ListSecrets_Handler,
 		},
+		{
+			MethodName: "RotateSecret",
+			Handler:    _SecretService_RotateSecret_Handler,
+		},
+		{
+			MethodName: "GetSecretVersion",
+			Handler:    _SecretService_GetSecretVersion_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "secret.proto",
@@ -27,6 +27,14 @@ const (
 	VolumeService_CopyPathStream_FullMethodName          = "/volume.VolumeService/CopyPathStream"
 	VolumeService_MovePath_FullMethodName                = "/volume.VolumeService/MovePath"
 	VolumeService_StatPath_FullMethodName                = "/volume.VolumeService/StatPath"
+	VolumeService_SnapshotVolume_FullMethodName          = "/volume.VolumeService/SnapshotVolume"
+	VolumeService_ListSnapshots_FullMethodName           = "/volume.VolumeService/ListSnapshots"
+	VolumeService_RestoreVolume_FullMethodName           = "/volume.VolumeService/RestoreVolume"
+	VolumeService_GetVolumeUsage_FullMethodName          = "/volume.VolumeService/GetVolumeUsage"
+	VolumeService_SetVolumeQuota_FullMethodName          = "/volume.VolumeService/SetVolumeQuota"
+	VolumeService_ExportVolume_FullMethodName            = "/volume.VolumeService/ExportVolume"
+	VolumeService_ImportVolume_FullMethodName            = "/volume.VolumeService/ImportVolume"
+	VolumeService_ShareVolume_FullMethodName             = "/volume.VolumeService/ShareVolume"
 	VolumeService_GetFileServiceInfo_FullMethodName      = "/volume.VolumeService/GetFileServiceInfo"
 	VolumeService_CreatePresignedURL_FullMethodName      = "/volume.VolumeService/CreatePresignedURL"
 	VolumeService_CreateMultipartUpload_FullMethodName   = "/volume.VolumeService/CreateMultipartUpload"
@@ -46,6 +54,18 @@ type VolumeServiceClient interface {
 	CopyPathStream(ctx context.Context, opts ...grpc.CallOption) (VolumeService_CopyPathStreamClient, error)
 	MovePath(ctx context.Context, in *MovePathRequest, opts ...grpc.CallOption) (*MovePathResponse, error)
 	StatPath(ctx context.Context, in *StatPathRequest, opts ...grpc.CallOption) (*StatPathResponse, error)
+	// Snapshots
+	SnapshotVolume(ctx context.Context, in *SnapshotVolumeRequest, opts ...grpc.CallOption) (*SnapshotVolumeResponse, error)
+	ListSnapshots(ctx context.Context, in *ListSnapshotsRequest, opts ...grpc.CallOption) (*ListSnapshotsResponse, error)
+	RestoreVolume(ctx context.Context, in *RestoreVolumeRequest, opts ...grpc.CallOption) (*RestoreVolumeResponse, error)
+	// Quotas
+	GetVolumeUsage(ctx context.Context, in *GetVolumeUsageRequest, opts ...grpc.CallOption) (*GetVolumeUsageResponse, error)
+	SetVolumeQuota(ctx context.Context, in *SetVolumeQuotaRequest, opts ...grpc.CallOption) (*SetVolumeQuotaResponse, error)
+	// Bulk transfer to and from external buckets
+	ExportVolume(ctx context.Context, in *ExportVolumeRequest, opts ...grpc.CallOption) (VolumeService_ExportVolumeClient, error)
+	ImportVolume(ctx context.Context, in *ImportVolumeRequest, opts ...grpc.CallOption) (VolumeService_ImportVolumeClient, error)
+	// Sharing
+	ShareVolume(ctx context.Context, in *ShareVolumeRequest, opts ...grpc.CallOption) (*ShareVolumeResponse, error)
 	// Multipart Upload
 	GetFileServiceInfo(ctx context.Context, in *GetFileServiceInfoRequest, opts ...grpc.CallOption) (*GetFileServiceInfoResponse, error)
 	CreatePresignedURL(ctx context.Context, in *CreatePresignedURLRequest, opts ...grpc.CallOption) (*CreatePresignedURLResponse, error)
@@ -159,6 +179,124 @@ func (c *volumeServiceClient) StatPath(ctx context.Context, in *StatPathRequest,
 	return out, nil
 }
 
+func (c *volumeServiceClient) SnapshotVolume(ctx context.Context, in *SnapshotVolumeRequest, opts ...grpc.CallOption) (*SnapshotVolumeResponse, error) {
+	out := new(SnapshotVolumeResponse)
+	err := c.cc.Invoke(ctx, VolumeService_SnapshotVolume_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServiceClient) ListSnapshots(ctx context.Context, in *ListSnapshotsRequest, opts ...grpc.CallOption) (*ListSnapshotsResponse, error) {
+	out := new(ListSnapshotsResponse)
+	err := c.cc.Invoke(ctx, VolumeService_ListSnapshots_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServiceClient) RestoreVolume(ctx context.Context, in *RestoreVolumeRequest, opts ...grpc.CallOption) (*RestoreVolumeResponse, error) {
+	out := new(RestoreVolumeResponse)
+	err := c.cc.Invoke(ctx, VolumeService_RestoreVolume_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServiceClient) GetVolumeUsage(ctx context.Context, in *GetVolumeUsageRequest, opts ...grpc.CallOption) (*GetVolumeUsageResponse, error) {
+	out := new(GetVolumeUsageResponse)
+	err := c.cc.Invoke(ctx, VolumeService_GetVolumeUsage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServiceClient) SetVolumeQuota(ctx context.Context, in *SetVolumeQuotaRequest, opts ...grpc.CallOption) (*SetVolumeQuotaResponse, error) {
+	out := new(SetVolumeQuotaResponse)
+	err := c.cc.Invoke(ctx, VolumeService_SetVolumeQuota_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServiceClient) ExportVolume(ctx context.Context, in *ExportVolumeRequest, opts ...grpc.CallOption) (VolumeService_ExportVolumeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &VolumeService_ServiceDesc.Streams[1], VolumeService_ExportVolume_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &volumeServiceExportVolumeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type VolumeService_ExportVolumeClient interface {
+	Recv() (*VolumeTransferResponse, error)
+	grpc.ClientStream
+}
+
+type volumeServiceExportVolumeClient struct {
+	grpc.ClientStream
+}
+
+func (x *volumeServiceExportVolumeClient) Recv() (*VolumeTransferResponse, error) {
+	m := new(VolumeTransferResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *volumeServiceClient) ImportVolume(ctx context.Context, in *ImportVolumeRequest, opts ...grpc.CallOption) (VolumeService_ImportVolumeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &VolumeService_ServiceDesc.Streams[2], VolumeService_ImportVolume_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &volumeServiceImportVolumeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type VolumeService_ImportVolumeClient interface {
+	Recv() (*VolumeTransferResponse, error)
+	grpc.ClientStream
+}
+
+type volumeServiceImportVolumeClient struct {
+	grpc.ClientStream
+}
+
+func (x *volumeServiceImportVolumeClient) Recv() (*VolumeTransferResponse, error) {
+	m := new(VolumeTransferResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *volumeServiceClient) ShareVolume(ctx context.Context, in *ShareVolumeRequest, opts ...grpc.CallOption) (*ShareVolumeResponse, error) {
+	out := new(ShareVolumeResponse)
+	err := c.cc.Invoke(ctx, VolumeService_ShareVolume_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *volumeServiceClient) GetFileServiceInfo(ctx context.Context, in *GetFileServiceInfoRequest, opts ...grpc.CallOption) (*GetFileServiceInfoResponse, error) {
 	out := new(GetFileServiceInfoResponse)
 	err := c.cc.Invoke(ctx, VolumeService_GetFileServiceInfo_FullMethodName, in, out, opts...)
@@ -216,6 +354,18 @@ type VolumeServiceServer interface {
 	CopyPathStream(VolumeService_CopyPathStreamServer) error
 	MovePath(context.Context, *MovePathRequest) (*MovePathResponse, error)
 	StatPath(context.Context, *StatPathRequest) (*StatPathResponse, error)
+	// Snapshots
+	SnapshotVolume(context.Context, *SnapshotVolumeRequest) (*SnapshotVolumeResponse, error)
+	ListSnapshots(context.Context, *ListSnapshotsRequest) (*ListSnapshotsResponse, error)
+	RestoreVolume(context.Context, *RestoreVolumeRequest) (*RestoreVolumeResponse, error)
+	// Quotas
+	GetVolumeUsage(context.Context, *GetVolumeUsageRequest) (*GetVolumeUsageResponse, error)
+	SetVolumeQuota(context.Context, *SetVolumeQuotaRequest) (*SetVolumeQuotaResponse, error)
+	// Bulk transfer to and from external buckets
+	ExportVolume(*ExportVolumeRequest, VolumeService_ExportVolumeServer) error
+	ImportVolume(*ImportVolumeRequest, VolumeService_ImportVolumeServer) error
+	// Sharing
+	ShareVolume(context.Context, *ShareVolumeRequest) (*ShareVolumeResponse, error)
 	// Multipart Upload
 	GetFileServiceInfo(context.Context, *GetFileServiceInfoRequest) (*GetFileServiceInfoResponse, error)
 	CreatePresignedURL(context.Context, *CreatePresignedURLRequest) (*CreatePresignedURLResponse, error)
@@ -253,6 +403,30 @@ func (UnimplementedVolumeServiceServer) MovePath(context.Context, *MovePathReque
 func (UnimplementedVolumeServiceServer) StatPath(context.Context, *StatPathRequest) (*StatPathResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StatPath not implemented")
 }
+func (UnimplementedVolumeServiceServer) SnapshotVolume(context.Context, *SnapshotVolumeRequest) (*SnapshotVolumeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnapshotVolume not implemented")
+}
+func (UnimplementedVolumeServiceServer) ListSnapshots(context.Context, *ListSnapshotsRequest) (*ListSnapshotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSnapshots not implemented")
+}
+func (UnimplementedVolumeServiceServer) RestoreVolume(context.Context, *RestoreVolumeRequest) (*RestoreVolumeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreVolume not implemented")
+}
+func (UnimplementedVolumeServiceServer) GetVolumeUsage(context.Context, *GetVolumeUsageRequest) (*GetVolumeUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVolumeUsage not implemented")
+}
+func (UnimplementedVolumeServiceServer) SetVolumeQuota(context.Context, *SetVolumeQuotaRequest) (*SetVolumeQuotaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetVolumeQuota not implemented")
+}
+func (UnimplementedVolumeServiceServer) ExportVolume(*ExportVolumeRequest, VolumeService_ExportVolumeServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportVolume not implemented")
+}
+func (UnimplementedVolumeServiceServer) ImportVolume(*ImportVolumeRequest, VolumeService_ImportVolumeServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImportVolume not implemented")
+}
+func (UnimplementedVolumeServiceServer) ShareVolume(context.Context, *ShareVolumeRequest) (*ShareVolumeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareVolume not implemented")
+}
 func (UnimplementedVolumeServiceServer) GetFileServiceInfo(context.Context, *GetFileServiceInfoRequest) (*GetFileServiceInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetFileServiceInfo not implemented")
 }
@@ -433,6 +607,156 @@ func _VolumeService_StatPath_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VolumeService_SnapshotVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServiceServer).SnapshotVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VolumeService_SnapshotVolume_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServiceServer).SnapshotVolume(ctx, req.(*SnapshotVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VolumeService_ListSnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSnapshotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServiceServer).ListSnapshots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VolumeService_ListSnapshots_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServiceServer).ListSnapshots(ctx, req.(*ListSnapshotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VolumeService_RestoreVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServiceServer).RestoreVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VolumeService_RestoreVolume_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServiceServer).RestoreVolume(ctx, req.(*RestoreVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VolumeService_GetVolumeUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVolumeUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServiceServer).GetVolumeUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VolumeService_GetVolumeUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServiceServer).GetVolumeUsage(ctx, req.(*GetVolumeUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VolumeService_SetVolumeQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetVolumeQuotaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServiceServer).SetVolumeQuota(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VolumeService_SetVolumeQuota_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServiceServer).SetVolumeQuota(ctx, req.(*SetVolumeQuotaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VolumeService_ExportVolume_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportVolumeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VolumeServiceServer).ExportVolume(m, &volumeServiceExportVolumeServer{stream})
+}
+
+type VolumeService_ExportVolumeServer interface {
+	Send(*VolumeTransferResponse) error
+	grpc.ServerStream
+}
+
+type volumeServiceExportVolumeServer struct {
+	grpc.ServerStream
+}
+
+func (x *volumeServiceExportVolumeServer) Send(m *VolumeTransferResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _VolumeService_ImportVolume_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ImportVolumeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VolumeServiceServer).ImportVolume(m, &volumeServiceImportVolumeServer{stream})
+}
+
+type VolumeService_ImportVolumeServer interface {
+	Send(*VolumeTransferResponse) error
+	grpc.ServerStream
+}
+
+type volumeServiceImportVolumeServer struct {
+	grpc.ServerStream
+}
+
+func (x *volumeServiceImportVolumeServer) Send(m *VolumeTransferResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _VolumeService_ShareVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServiceServer).ShareVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VolumeService_ShareVolume_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServiceServer).ShareVolume(ctx, req.(*ShareVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _VolumeService_GetFileServiceInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetFileServiceInfoRequest)
 	if err := dec(in); err != nil {
@@ -558,6 +882,30 @@ var VolumeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "StatPath",
 			Handler:    _VolumeService_StatPath_Handler,
 		},
+		{
+			MethodName: "SnapshotVolume",
+			Handler:    _VolumeService_SnapshotVolume_Handler,
+		},
+		{
+			MethodName: "ListSnapshots",
+			Handler:    _VolumeService_ListSnapshots_Handler,
+		},
+		{
+			MethodName: "RestoreVolume",
+			Handler:    _VolumeService_RestoreVolume_Handler,
+		},
+		{
+			MethodName: "GetVolumeUsage",
+			Handler:    _VolumeService_GetVolumeUsage_Handler,
+		},
+		{
+			MethodName: "SetVolumeQuota",
+			Handler:    _VolumeService_SetVolumeQuota_Handler,
+		},
+		{
+			MethodName: "ShareVolume",
+			Handler:    _VolumeService_ShareVolume_Handler,
+		},
 		{
 			MethodName: "GetFileServiceInfo",
 			Handler:    _VolumeService_GetFileServiceInfo_Handler,
@@ -585,6 +933,16 @@ var VolumeService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _VolumeService_CopyPathStream_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "ExportVolume",
+			Handler:       _VolumeService_ExportVolume_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportVolume",
+			Handler:       _VolumeService_ImportVolume_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "volume.proto",
 }
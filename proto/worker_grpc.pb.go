@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
-// - protoc-gen-go-grpc v1.6.1
-// - protoc             v5.28.3
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.25.1
 // source: worker.proto
 
 package proto
@@ -15,13 +15,15 @@ import (
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the grpc package it is being compiled against.
-// Requires gRPC-Go v1.64.0 or later.
-const _ = grpc.SupportPackageIsVersion9
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
 
 const (
 	ContainerService_ContainerKill_FullMethodName                            = "/container.ContainerService/ContainerKill"
 	ContainerService_ContainerStatus_FullMethodName                          = "/container.ContainerService/ContainerStatus"
 	ContainerService_ContainerExec_FullMethodName                            = "/container.ContainerService/ContainerExec"
+	ContainerService_ContainerExecStream_FullMethodName                      = "/container.ContainerService/ContainerExecStream"
+	ContainerService_ContainerPortForward_FullMethodName                     = "/container.ContainerService/ContainerPortForward"
 	ContainerService_ContainerStreamLogs_FullMethodName                      = "/container.ContainerService/ContainerStreamLogs"
 	ContainerService_ContainerArchive_FullMethodName                         = "/container.ContainerService/ContainerArchive"
 	ContainerService_ContainerCheckpoint_FullMethodName                      = "/container.ContainerService/ContainerCheckpoint"
@@ -45,6 +47,7 @@ const (
 	ContainerService_ContainerSandboxListExposedPorts_FullMethodName         = "/container.ContainerService/ContainerSandboxListExposedPorts"
 	ContainerService_ContainerSandboxListProcesses_FullMethodName            = "/container.ContainerService/ContainerSandboxListProcesses"
 	ContainerService_ContainerSandboxWaitForCompletion_FullMethodName        = "/container.ContainerService/ContainerSandboxWaitForCompletion"
+	ContainerService_WorkerHealth_FullMethodName                             = "/container.ContainerService/WorkerHealth"
 )
 
 // ContainerServiceClient is the client API for ContainerService service.
@@ -54,8 +57,10 @@ type ContainerServiceClient interface {
 	ContainerKill(ctx context.Context, in *ContainerKillRequest, opts ...grpc.CallOption) (*ContainerKillResponse, error)
 	ContainerStatus(ctx context.Context, in *ContainerStatusRequest, opts ...grpc.CallOption) (*ContainerStatusResponse, error)
 	ContainerExec(ctx context.Context, in *ContainerExecRequest, opts ...grpc.CallOption) (*ContainerExecResponse, error)
-	ContainerStreamLogs(ctx context.Context, in *ContainerStreamLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ContainerLogEntry], error)
-	ContainerArchive(ctx context.Context, in *ContainerArchiveRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ContainerArchiveResponse], error)
+	ContainerExecStream(ctx context.Context, opts ...grpc.CallOption) (ContainerService_ContainerExecStreamClient, error)
+	ContainerPortForward(ctx context.Context, opts ...grpc.CallOption) (ContainerService_ContainerPortForwardClient, error)
+	ContainerStreamLogs(ctx context.Context, in *ContainerStreamLogsRequest, opts ...grpc.CallOption) (ContainerService_ContainerStreamLogsClient, error)
+	ContainerArchive(ctx context.Context, in *ContainerArchiveRequest, opts ...grpc.CallOption) (ContainerService_ContainerArchiveClient, error)
 	ContainerCheckpoint(ctx context.Context, in *ContainerCheckpointRequest, opts ...grpc.CallOption) (*ContainerCheckpointResponse, error)
 	ContainerSyncWorkspace(ctx context.Context, in *SyncContainerWorkspaceRequest, opts ...grpc.CallOption) (*SyncContainerWorkspaceResponse, error)
 	ContainerSandboxExec(ctx context.Context, in *ContainerSandboxExecRequest, opts ...grpc.CallOption) (*ContainerSandboxExecResponse, error)
@@ -77,6 +82,7 @@ type ContainerServiceClient interface {
 	ContainerSandboxListExposedPorts(ctx context.Context, in *ContainerSandboxListExposedPortsRequest, opts ...grpc.CallOption) (*ContainerSandboxListExposedPortsResponse, error)
 	ContainerSandboxListProcesses(ctx context.Context, in *ContainerSandboxListProcessesRequest, opts ...grpc.CallOption) (*ContainerSandboxListProcessesResponse, error)
 	ContainerSandboxWaitForCompletion(ctx context.Context, in *ContainerSandboxWaitForCompletionRequest, opts ...grpc.CallOption) (*ContainerSandboxWaitForCompletionResponse, error)
+	WorkerHealth(ctx context.Context, in *WorkerHealthRequest, opts ...grpc.CallOption) (*WorkerHealthResponse, error)
 }
 
 type containerServiceClient struct {
@@ -88,9 +94,8 @@ func NewContainerServiceClient(cc grpc.ClientConnInterface) ContainerServiceClie
 }
 
 func (c *containerServiceClient) ContainerKill(ctx context.Context, in *ContainerKillRequest, opts ...grpc.CallOption) (*ContainerKillResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ContainerKillResponse)
-	err := c.cc.Invoke(ctx, ContainerService_ContainerKill_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, ContainerService_ContainerKill_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -98,9 +103,8 @@ func (c *containerServiceClient) ContainerKill(ctx context.Context, in *Containe
 }
 
 func (c *containerServiceClient) ContainerStatus(ctx context.Context, in *ContainerStatusRequest, opts ...grpc.CallOption) (*ContainerStatusResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ContainerStatusResponse)
-	err := c.cc.Invoke(ctx, ContainerService_ContainerStatus_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, ContainerService_ContainerStatus_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -108,22 +112,82 @@ func (c *containerServiceClient) ContainerStatus(ctx context.Context, in *Contai
 }
 
 func (c *containerServiceClient) ContainerExec(ctx context.Context, in *ContainerExecRequest, opts ...grpc.CallOption) (*ContainerExecResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ContainerExecResponse)
-	err := c.cc.Invoke(ctx, ContainerService_ContainerExec_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, ContainerService_ContainerExec_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *containerServiceClient) ContainerStreamLogs(ctx context.Context, in *ContainerStreamLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ContainerLogEntry], error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &ContainerService_ServiceDesc.Streams[0], ContainerService_ContainerStreamLogs_FullMethodName, cOpts...)
+func (c *containerServiceClient) ContainerExecStream(ctx context.Context, opts ...grpc.CallOption) (ContainerService_ContainerExecStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ContainerService_ServiceDesc.Streams[0], ContainerService_ContainerExecStream_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &grpc.GenericClientStream[ContainerStreamLogsRequest, ContainerLogEntry]{ClientStream: stream}
+	x := &containerServiceContainerExecStreamClient{stream}
+	return x, nil
+}
+
+type ContainerService_ContainerExecStreamClient interface {
+	Send(*ExecInContainerRequest) error
+	Recv() (*ExecInContainerResponse, error)
+	grpc.ClientStream
+}
+
+type containerServiceContainerExecStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *containerServiceContainerExecStreamClient) Send(m *ExecInContainerRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *containerServiceContainerExecStreamClient) Recv() (*ExecInContainerResponse, error) {
+	m := new(ExecInContainerResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *containerServiceClient) ContainerPortForward(ctx context.Context, opts ...grpc.CallOption) (ContainerService_ContainerPortForwardClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ContainerService_ServiceDesc.Streams[1], ContainerService_ContainerPortForward_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &containerServiceContainerPortForwardClient{stream}
+	return x, nil
+}
+
+type ContainerService_ContainerPortForwardClient interface {
+	Send(*PortForwardRequest) error
+	Recv() (*PortForwardResponse, error)
+	grpc.ClientStream
+}
+
+type containerServiceContainerPortForwardClient struct {
+	grpc.ClientStream
+}
+
+func (x *containerServiceContainerPortForwardClient) Send(m *PortForwardRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *containerServiceContainerPortForwardClient) Recv() (*PortForwardResponse, error) {
+	m := new(PortForwardResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *containerServiceClient) ContainerStreamLogs(ctx context.Context, in *ContainerStreamLogsRequest, opts ...grpc.CallOption) (ContainerService_ContainerStreamLogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ContainerService_ServiceDesc.Streams[2], ContainerService_ContainerStreamLogs_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &containerServiceContainerStreamLogsClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -133,16 +197,29 @@ func (c *containerServiceClient) ContainerStreamLogs(ctx context.Context, in *Co
 	return x, nil
 }
 
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type ContainerService_ContainerStreamLogsClient = grpc.ServerStreamingClient[ContainerLogEntry]
+type ContainerService_ContainerStreamLogsClient interface {
+	Recv() (*ContainerLogEntry, error)
+	grpc.ClientStream
+}
+
+type containerServiceContainerStreamLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *containerServiceContainerStreamLogsClient) Recv() (*ContainerLogEntry, error) {
+	m := new(ContainerLogEntry)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
 
-func (c *containerServiceClient) ContainerArchive(ctx context.Context, in *ContainerArchiveRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ContainerArchiveResponse], error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &ContainerService_ServiceDesc.Streams[1], ContainerService_ContainerArchive_FullMethodName, cOpts...)
+func (c *containerServiceClient) ContainerArchive(ctx context.Context, in *ContainerArchiveRequest, opts ...grpc.CallOption) (ContainerService_ContainerArchiveClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ContainerService_ServiceDesc.Streams[3], ContainerService_ContainerArchive_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &grpc.GenericClientStream[ContainerArchiveRequest, ContainerArchiveResponse]{ClientStream: stream}
+	x := &containerServiceContainerArchiveClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -152,13 +229,26 @@ func (c *containerServiceClient) ContainerArchive(ctx context.Context, in *Conta
 	return x, nil
 }
 
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type ContainerService_ContainerArchiveClient = grpc.ServerStreamingClient[ContainerArchiveResponse]
+type ContainerService_ContainerArchiveClient interface {
+	Recv() (*ContainerArchiveResponse, error)
+	grpc.ClientStream
+}
+
+type containerServiceContainerArchiveClient struct {
+	grpc.ClientStream
+}
+
+func (x *containerServiceContainerArchiveClient) Recv() (*ContainerArchiveResponse, error) {
+	m := new(ContainerArchiveResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
 
 func (c *containerServiceClient) ContainerCheckpoint(ctx context.Context, in *ContainerCheckpointRequest, opts ...grpc.CallOption) (*ContainerCheckpointResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ContainerCheckpointResponse)
-	err := c.cc.Invoke(ctx, ContainerService_ContainerCheckpoint_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, ContainerService_ContainerCheckpoint_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -166,9 +256,8 @@ func (c *containerServiceClient) ContainerCheckpoint(ctx context.Context, in *Co
 }
 
 func (c *containerServiceClient) ContainerSyncWorkspace(ctx context.Context, in *SyncContainerWorkspaceRequest, opts ...grpc.CallOption) (*SyncContainerWorkspaceResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SyncContainerWorkspaceResponse)
-	err := c.cc.Invoke(ctx, ContainerService_ContainerSyncWorkspace_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, ContainerService_ContainerSyncWorkspace_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -176,9 +265,8 @@ func (c *containerServiceClient) ContainerSyncWorkspace(ctx context.Context, in
 }
 
 func (c *containerServiceClient) ContainerSandboxExec(ctx context.Context, in *ContainerSandboxExecRequest, opts ...grpc.CallOption) (*ContainerSandboxExecResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ContainerSandboxExecResponse)
-	err := c.cc.Invoke(ctx, ContainerService_ContainerSandboxExec_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, ContainerService_ContainerSandboxExec_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -186,9 +274,8 @@ func (c *containerServiceClient) ContainerSandboxExec(ctx context.Context, in *C
 }
 
 func (c *containerServiceClient) ContainerSandboxStatus(ctx context.Context, in *ContainerSandboxStatusRequest, opts ...grpc.CallOption) (*ContainerSandboxStatusResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ContainerSandboxStatusResponse)
-	err := c.cc.Invoke(ctx, ContainerService_ContainerSandboxStatus_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, ContainerService_ContainerSandboxStatus_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -196,9 +283,8 @@ func (c *containerServiceClient) ContainerSandboxStatus(ctx context.Context, in
 }
 
 func (c *containerServiceClient) ContainerSandboxStdout(ctx context.Context, in *ContainerSandboxStdoutRequest, opts ...grpc.CallOption) (*ContainerSandboxStdoutResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ContainerSandboxStdoutResponse)
-	err := c.cc.Invoke(ctx, ContainerService_ContainerSandboxStdout_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, ContainerService_ContainerSandboxStdout_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -206,9 +292,8 @@ func (c *containerServiceClient) ContainerSandboxStdout(ctx context.Context, in
 }
 
 func (c *containerServiceClient) ContainerSandboxStderr(ctx context.Context, in *ContainerSandboxStderrRequest, opts ...grpc.CallOption) (*ContainerSandboxStderrResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ContainerSandboxStderrResponse)
-	err := c.cc.Invoke(ctx, ContainerService_ContainerSandboxStderr_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, ContainerService_ContainerSandboxStderr_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -216,9 +301,8 @@ func (c *containerServiceClient) ContainerSandboxStderr(ctx context.Context, in
 }
 
 func (c *containerServiceClient) ContainerSandboxKill(ctx context.Context, in *ContainerSandboxKillRequest, opts ...grpc.CallOption) (*ContainerSandboxKillResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ContainerSandboxKillResponse)
-	err := c.cc.Invoke(ctx, ContainerService_ContainerSandboxKill_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, ContainerService_ContainerSandboxKill_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -226,9 +310,8 @@ func (c *containerServiceClient) ContainerSandboxKill(ctx context.Context, in *C
 }
 
 func (c *containerServiceClient) ContainerSandboxListFiles(ctx context.Context, in *ContainerSandboxListFilesRequest, opts ...grpc.CallOption) (*ContainerSandboxListFilesResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ContainerSandboxListFilesResponse)
-	err := c.cc.Invoke(ctx, ContainerService_ContainerSandboxListFiles_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, ContainerService_ContainerSandboxListFiles_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -236,9 +319,8 @@ func (c *containerServiceClient) ContainerSandboxListFiles(ctx context.Context,
 }
 
 func (c *containerServiceClient) ContainerSandboxUploadFile(ctx context.Context, in *ContainerSandboxUploadFileRequest, opts ...grpc.CallOption) (*ContainerSandboxUploadFileResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ContainerSandboxUploadFileResponse)
-	err := c.cc.Invoke(ctx, ContainerService_ContainerSandboxUploadFile_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, ContainerService_ContainerSandboxUploadFile_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -246,9 +328,8 @@ func (c *containerServiceClient) ContainerSandboxUploadFile(ctx context.Context,
 }
 
 func (c *containerServiceClient) ContainerSandboxDownloadFile(ctx context.Context, in *ContainerSandboxDownloadFileRequest, opts ...grpc.CallOption) (*ContainerSandboxDownloadFileResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ContainerSandboxDownloadFileResponse)
-	err := c.cc.Invoke(ctx, ContainerService_ContainerSandboxDownloadFile_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, ContainerService_ContainerSandboxDownloadFile_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -256,9 +337,8 @@ func (c *containerServiceClient) ContainerSandboxDownloadFile(ctx context.Contex
 }
 
 func (c *containerServiceClient) ContainerSandboxStatFile(ctx context.Context, in *ContainerSandboxStatFileRequest, opts ...grpc.CallOption) (*ContainerSandboxStatFileResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ContainerSandboxStatFileResponse)
-	err := c.cc.Invoke(ctx, ContainerService_ContainerSandboxStatFile_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, ContainerService_ContainerSandboxStatFile_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -266,9 +346,8 @@ func (c *containerServiceClient) ContainerSandboxStatFile(ctx context.Context, i
 }
 
 func (c *containerServiceClient) ContainerSandboxDeleteFile(ctx context.Context, in *ContainerSandboxDeleteFileRequest, opts ...grpc.CallOption) (*ContainerSandboxDeleteFileResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ContainerSandboxDeleteFileResponse)
-	err := c.cc.Invoke(ctx, ContainerService_ContainerSandboxDeleteFile_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, ContainerService_ContainerSandboxDeleteFile_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -276,9 +355,8 @@ func (c *containerServiceClient) ContainerSandboxDeleteFile(ctx context.Context,
 }
 
 func (c *containerServiceClient) ContainerSandboxCreateDirectory(ctx context.Context, in *ContainerSandboxCreateDirectoryRequest, opts ...grpc.CallOption) (*ContainerSandboxCreateDirectoryResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ContainerSandboxCreateDirectoryResponse)
-	err := c.cc.Invoke(ctx, ContainerService_ContainerSandboxCreateDirectory_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, ContainerService_ContainerSandboxCreateDirectory_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -286,9 +364,8 @@ func (c *containerServiceClient) ContainerSandboxCreateDirectory(ctx context.Con
 }
 
 func (c *containerServiceClient) ContainerSandboxDeleteDirectory(ctx context.Context, in *ContainerSandboxDeleteDirectoryRequest, opts ...grpc.CallOption) (*ContainerSandboxDeleteDirectoryResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ContainerSandboxDeleteDirectoryResponse)
-	err := c.cc.Invoke(ctx, ContainerService_ContainerSandboxDeleteDirectory_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, ContainerService_ContainerSandboxDeleteDirectory_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -296,9 +373,8 @@ func (c *containerServiceClient) ContainerSandboxDeleteDirectory(ctx context.Con
 }
 
 func (c *containerServiceClient) ContainerSandboxExposePort(ctx context.Context, in *ContainerSandboxExposePortRequest, opts ...grpc.CallOption) (*ContainerSandboxExposePortResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ContainerSandboxExposePortResponse)
-	err := c.cc.Invoke(ctx, ContainerService_ContainerSandboxExposePort_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, ContainerService_ContainerSandboxExposePort_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -306,9 +382,8 @@ func (c *containerServiceClient) ContainerSandboxExposePort(ctx context.Context,
 }
 
 func (c *containerServiceClient) ContainerSandboxUpdateNetworkPermissions(ctx context.Context, in *ContainerSandboxUpdateNetworkPermissionsRequest, opts ...grpc.CallOption) (*ContainerSandboxUpdateNetworkPermissionsResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ContainerSandboxUpdateNetworkPermissionsResponse)
-	err := c.cc.Invoke(ctx, ContainerService_ContainerSandboxUpdateNetworkPermissions_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, ContainerService_ContainerSandboxUpdateNetworkPermissions_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -316,9 +391,8 @@ func (c *containerServiceClient) ContainerSandboxUpdateNetworkPermissions(ctx co
 }
 
 func (c *containerServiceClient) ContainerSandboxReplaceInFiles(ctx context.Context, in *ContainerSandboxReplaceInFilesRequest, opts ...grpc.CallOption) (*ContainerSandboxReplaceInFilesResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ContainerSandboxReplaceInFilesResponse)
-	err := c.cc.Invoke(ctx, ContainerService_ContainerSandboxReplaceInFiles_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, ContainerService_ContainerSandboxReplaceInFiles_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -326,9 +400,8 @@ func (c *containerServiceClient) ContainerSandboxReplaceInFiles(ctx context.Cont
 }
 
 func (c *containerServiceClient) ContainerSandboxFindInFiles(ctx context.Context, in *ContainerSandboxFindInFilesRequest, opts ...grpc.CallOption) (*ContainerSandboxFindInFilesResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ContainerSandboxFindInFilesResponse)
-	err := c.cc.Invoke(ctx, ContainerService_ContainerSandboxFindInFiles_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, ContainerService_ContainerSandboxFindInFiles_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -336,9 +409,8 @@ func (c *containerServiceClient) ContainerSandboxFindInFiles(ctx context.Context
 }
 
 func (c *containerServiceClient) ContainerSandboxListExposedPorts(ctx context.Context, in *ContainerSandboxListExposedPortsRequest, opts ...grpc.CallOption) (*ContainerSandboxListExposedPortsResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ContainerSandboxListExposedPortsResponse)
-	err := c.cc.Invoke(ctx, ContainerService_ContainerSandboxListExposedPorts_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, ContainerService_ContainerSandboxListExposedPorts_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -346,9 +418,8 @@ func (c *containerServiceClient) ContainerSandboxListExposedPorts(ctx context.Co
 }
 
 func (c *containerServiceClient) ContainerSandboxListProcesses(ctx context.Context, in *ContainerSandboxListProcessesRequest, opts ...grpc.CallOption) (*ContainerSandboxListProcessesResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ContainerSandboxListProcessesResponse)
-	err := c.cc.Invoke(ctx, ContainerService_ContainerSandboxListProcesses_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, ContainerService_ContainerSandboxListProcesses_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -356,9 +427,17 @@ func (c *containerServiceClient) ContainerSandboxListProcesses(ctx context.Conte
 }
 
 func (c *containerServiceClient) ContainerSandboxWaitForCompletion(ctx context.Context, in *ContainerSandboxWaitForCompletionRequest, opts ...grpc.CallOption) (*ContainerSandboxWaitForCompletionResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ContainerSandboxWaitForCompletionResponse)
-	err := c.cc.Invoke(ctx, ContainerService_ContainerSandboxWaitForCompletion_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, ContainerService_ContainerSandboxWaitForCompletion_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *containerServiceClient) WorkerHealth(ctx context.Context, in *WorkerHealthRequest, opts ...grpc.CallOption) (*WorkerHealthResponse, error) {
+	out := new(WorkerHealthResponse)
+	err := c.cc.Invoke(ctx, ContainerService_WorkerHealth_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -367,13 +446,15 @@ func (c *containerServiceClient) ContainerSandboxWaitForCompletion(ctx context.C
 
 // ContainerServiceServer is the server API for ContainerService service.
 // All implementations must embed UnimplementedContainerServiceServer
-// for forward compatibility.
+// for forward compatibility
 type ContainerServiceServer interface {
 	ContainerKill(context.Context, *ContainerKillRequest) (*ContainerKillResponse, error)
 	ContainerStatus(context.Context, *ContainerStatusRequest) (*ContainerStatusResponse, error)
 	ContainerExec(context.Context, *ContainerExecRequest) (*ContainerExecResponse, error)
-	ContainerStreamLogs(*ContainerStreamLogsRequest, grpc.ServerStreamingServer[ContainerLogEntry]) error
-	ContainerArchive(*ContainerArchiveRequest, grpc.ServerStreamingServer[ContainerArchiveResponse]) error
+	ContainerExecStream(ContainerService_ContainerExecStreamServer) error
+	ContainerPortForward(ContainerService_ContainerPortForwardServer) error
+	ContainerStreamLogs(*ContainerStreamLogsRequest, ContainerService_ContainerStreamLogsServer) error
+	ContainerArchive(*ContainerArchiveRequest, ContainerService_ContainerArchiveServer) error
 	ContainerCheckpoint(context.Context, *ContainerCheckpointRequest) (*ContainerCheckpointResponse, error)
 	ContainerSyncWorkspace(context.Context, *SyncContainerWorkspaceRequest) (*SyncContainerWorkspaceResponse, error)
 	ContainerSandboxExec(context.Context, *ContainerSandboxExecRequest) (*ContainerSandboxExecResponse, error)
@@ -395,96 +476,102 @@ type ContainerServiceServer interface {
 	ContainerSandboxListExposedPorts(context.Context, *ContainerSandboxListExposedPortsRequest) (*ContainerSandboxListExposedPortsResponse, error)
 	ContainerSandboxListProcesses(context.Context, *ContainerSandboxListProcessesRequest) (*ContainerSandboxListProcessesResponse, error)
 	ContainerSandboxWaitForCompletion(context.Context, *ContainerSandboxWaitForCompletionRequest) (*ContainerSandboxWaitForCompletionResponse, error)
+	WorkerHealth(context.Context, *WorkerHealthRequest) (*WorkerHealthResponse, error)
 	mustEmbedUnimplementedContainerServiceServer()
 }
 
-// UnimplementedContainerServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedContainerServiceServer struct{}
+// UnimplementedContainerServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedContainerServiceServer struct {
+}
 
 func (UnimplementedContainerServiceServer) ContainerKill(context.Context, *ContainerKillRequest) (*ContainerKillResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method ContainerKill not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method ContainerKill not implemented")
 }
 func (UnimplementedContainerServiceServer) ContainerStatus(context.Context, *ContainerStatusRequest) (*ContainerStatusResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method ContainerStatus not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method ContainerStatus not implemented")
 }
 func (UnimplementedContainerServiceServer) ContainerExec(context.Context, *ContainerExecRequest) (*ContainerExecResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method ContainerExec not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method ContainerExec not implemented")
 }
-func (UnimplementedContainerServiceServer) ContainerStreamLogs(*ContainerStreamLogsRequest, grpc.ServerStreamingServer[ContainerLogEntry]) error {
-	return status.Error(codes.Unimplemented, "method ContainerStreamLogs not implemented")
+func (UnimplementedContainerServiceServer) ContainerExecStream(ContainerService_ContainerExecStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method ContainerExecStream not implemented")
 }
-func (UnimplementedContainerServiceServer) ContainerArchive(*ContainerArchiveRequest, grpc.ServerStreamingServer[ContainerArchiveResponse]) error {
-	return status.Error(codes.Unimplemented, "method ContainerArchive not implemented")
+func (UnimplementedContainerServiceServer) ContainerPortForward(ContainerService_ContainerPortForwardServer) error {
+	return status.Errorf(codes.Unimplemented, "method ContainerPortForward not implemented")
+}
+func (UnimplementedContainerServiceServer) ContainerStreamLogs(*ContainerStreamLogsRequest, ContainerService_ContainerStreamLogsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ContainerStreamLogs not implemented")
+}
+func (UnimplementedContainerServiceServer) ContainerArchive(*ContainerArchiveRequest, ContainerService_ContainerArchiveServer) error {
+	return status.Errorf(codes.Unimplemented, "method ContainerArchive not implemented")
 }
 func (UnimplementedContainerServiceServer) ContainerCheckpoint(context.Context, *ContainerCheckpointRequest) (*ContainerCheckpointResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method ContainerCheckpoint not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method ContainerCheckpoint not implemented")
 }
 func (UnimplementedContainerServiceServer) ContainerSyncWorkspace(context.Context, *SyncContainerWorkspaceRequest) (*SyncContainerWorkspaceResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method ContainerSyncWorkspace not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method ContainerSyncWorkspace not implemented")
 }
 func (UnimplementedContainerServiceServer) ContainerSandboxExec(context.Context, *ContainerSandboxExecRequest) (*ContainerSandboxExecResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method ContainerSandboxExec not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method ContainerSandboxExec not implemented")
 }
 func (UnimplementedContainerServiceServer) ContainerSandboxStatus(context.Context, *ContainerSandboxStatusRequest) (*ContainerSandboxStatusResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method ContainerSandboxStatus not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method ContainerSandboxStatus not implemented")
 }
 func (UnimplementedContainerServiceServer) ContainerSandboxStdout(context.Context, *ContainerSandboxStdoutRequest) (*ContainerSandboxStdoutResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method ContainerSandboxStdout not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method ContainerSandboxStdout not implemented")
 }
 func (UnimplementedContainerServiceServer) ContainerSandboxStderr(context.Context, *ContainerSandboxStderrRequest) (*ContainerSandboxStderrResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method ContainerSandboxStderr not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method ContainerSandboxStderr not implemented")
 }
 func (UnimplementedContainerServiceServer) ContainerSandboxKill(context.Context, *ContainerSandboxKillRequest) (*ContainerSandboxKillResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method ContainerSandboxKill not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method ContainerSandboxKill not implemented")
 }
 func (UnimplementedContainerServiceServer) ContainerSandboxListFiles(context.Context, *ContainerSandboxListFilesRequest) (*ContainerSandboxListFilesResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method ContainerSandboxListFiles not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method ContainerSandboxListFiles not implemented")
 }
 func (UnimplementedContainerServiceServer) ContainerSandboxUploadFile(context.Context, *ContainerSandboxUploadFileRequest) (*ContainerSandboxUploadFileResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method ContainerSandboxUploadFile not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method ContainerSandboxUploadFile not implemented")
 }
 func (UnimplementedContainerServiceServer) ContainerSandboxDownloadFile(context.Context, *ContainerSandboxDownloadFileRequest) (*ContainerSandboxDownloadFileResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method ContainerSandboxDownloadFile not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method ContainerSandboxDownloadFile not implemented")
 }
 func (UnimplementedContainerServiceServer) ContainerSandboxStatFile(context.Context, *ContainerSandboxStatFileRequest) (*ContainerSandboxStatFileResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method ContainerSandboxStatFile not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method ContainerSandboxStatFile not implemented")
 }
 func (UnimplementedContainerServiceServer) ContainerSandboxDeleteFile(context.Context, *ContainerSandboxDeleteFileRequest) (*ContainerSandboxDeleteFileResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method ContainerSandboxDeleteFile not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method ContainerSandboxDeleteFile not implemented")
 }
 func (UnimplementedContainerServiceServer) ContainerSandboxCreateDirectory(context.Context, *ContainerSandboxCreateDirectoryRequest) (*ContainerSandboxCreateDirectoryResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method ContainerSandboxCreateDirectory not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method ContainerSandboxCreateDirectory not implemented")
 }
 func (UnimplementedContainerServiceServer) ContainerSandboxDeleteDirectory(context.Context, *ContainerSandboxDeleteDirectoryRequest) (*ContainerSandboxDeleteDirectoryResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method ContainerSandboxDeleteDirectory not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method ContainerSandboxDeleteDirectory not implemented")
 }
 func (UnimplementedContainerServiceServer) ContainerSandboxExposePort(context.Context, *ContainerSandboxExposePortRequest) (*ContainerSandboxExposePortResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method ContainerSandboxExposePort not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method ContainerSandboxExposePort not implemented")
 }
 func (UnimplementedContainerServiceServer) ContainerSandboxUpdateNetworkPermissions(context.Context, *ContainerSandboxUpdateNetworkPermissionsRequest) (*ContainerSandboxUpdateNetworkPermissionsResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method ContainerSandboxUpdateNetworkPermissions not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method ContainerSandboxUpdateNetworkPermissions not implemented")
 }
 func (UnimplementedContainerServiceServer) ContainerSandboxReplaceInFiles(context.Context, *ContainerSandboxReplaceInFilesRequest) (*ContainerSandboxReplaceInFilesResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method ContainerSandboxReplaceInFiles not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method ContainerSandboxReplaceInFiles not implemented")
 }
 func (UnimplementedContainerServiceServer) ContainerSandboxFindInFiles(context.Context, *ContainerSandboxFindInFilesRequest) (*ContainerSandboxFindInFilesResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method ContainerSandboxFindInFiles not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method ContainerSandboxFindInFiles not implemented")
 }
 func (UnimplementedContainerServiceServer) ContainerSandboxListExposedPorts(context.Context, *ContainerSandboxListExposedPortsRequest) (*ContainerSandboxListExposedPortsResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method ContainerSandboxListExposedPorts not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method ContainerSandboxListExposedPorts not implemented")
 }
 func (UnimplementedContainerServiceServer) ContainerSandboxListProcesses(context.Context, *ContainerSandboxListProcessesRequest) (*ContainerSandboxListProcessesResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method ContainerSandboxListProcesses not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method ContainerSandboxListProcesses not implemented")
 }
 func (UnimplementedContainerServiceServer) ContainerSandboxWaitForCompletion(context.Context, *ContainerSandboxWaitForCompletionRequest) (*ContainerSandboxWaitForCompletionResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method ContainerSandboxWaitForCompletion not implemented")
+	return nil, status.Errorf(codes.Unimplemented, "method ContainerSandboxWaitForCompletion not implemented")
+}
+func (UnimplementedContainerServiceServer) WorkerHealth(context.Context, *WorkerHealthRequest) (*WorkerHealthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WorkerHealth not implemented")
 }
 func (UnimplementedContainerServiceServer) mustEmbedUnimplementedContainerServiceServer() {}
-func (UnimplementedContainerServiceServer) testEmbeddedByValue()                          {}
 
 // UnsafeContainerServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to ContainerServiceServer will
@@ -494,13 +581,6 @@ type UnsafeContainerServiceServer interface {
 }
 
 func RegisterContainerServiceServer(s grpc.ServiceRegistrar, srv ContainerServiceServer) {
-	// If the following call panics, it indicates UnimplementedContainerServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
-	}
 	s.RegisterService(&ContainerService_ServiceDesc, srv)
 }
 
@@ -558,27 +638,99 @@ func _ContainerService_ContainerExec_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ContainerService_ContainerExecStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ContainerServiceServer).ContainerExecStream(&containerServiceContainerExecStreamServer{stream})
+}
+
+type ContainerService_ContainerExecStreamServer interface {
+	Send(*ExecInContainerResponse) error
+	Recv() (*ExecInContainerRequest, error)
+	grpc.ServerStream
+}
+
+type containerServiceContainerExecStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *containerServiceContainerExecStreamServer) Send(m *ExecInContainerResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *containerServiceContainerExecStreamServer) Recv() (*ExecInContainerRequest, error) {
+	m := new(ExecInContainerRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _ContainerService_ContainerPortForward_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ContainerServiceServer).ContainerPortForward(&containerServiceContainerPortForwardServer{stream})
+}
+
+type ContainerService_ContainerPortForwardServer interface {
+	Send(*PortForwardResponse) error
+	Recv() (*PortForwardRequest, error)
+	grpc.ServerStream
+}
+
+type containerServiceContainerPortForwardServer struct {
+	grpc.ServerStream
+}
+
+func (x *containerServiceContainerPortForwardServer) Send(m *PortForwardResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *containerServiceContainerPortForwardServer) Recv() (*PortForwardRequest, error) {
+	m := new(PortForwardRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _ContainerService_ContainerStreamLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(ContainerStreamLogsRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ContainerServiceServer).ContainerStreamLogs(m, &grpc.GenericServerStream[ContainerStreamLogsRequest, ContainerLogEntry]{ServerStream: stream})
+	return srv.(ContainerServiceServer).ContainerStreamLogs(m, &containerServiceContainerStreamLogsServer{stream})
+}
+
+type ContainerService_ContainerStreamLogsServer interface {
+	Send(*ContainerLogEntry) error
+	grpc.ServerStream
+}
+
+type containerServiceContainerStreamLogsServer struct {
+	grpc.ServerStream
 }
 
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type ContainerService_ContainerStreamLogsServer = grpc.ServerStreamingServer[ContainerLogEntry]
+func (x *containerServiceContainerStreamLogsServer) Send(m *ContainerLogEntry) error {
+	return x.ServerStream.SendMsg(m)
+}
 
 func _ContainerService_ContainerArchive_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(ContainerArchiveRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ContainerServiceServer).ContainerArchive(m, &grpc.GenericServerStream[ContainerArchiveRequest, ContainerArchiveResponse]{ServerStream: stream})
+	return srv.(ContainerServiceServer).ContainerArchive(m, &containerServiceContainerArchiveServer{stream})
+}
+
+type ContainerService_ContainerArchiveServer interface {
+	Send(*ContainerArchiveResponse) error
+	grpc.ServerStream
 }
 
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type ContainerService_ContainerArchiveServer = grpc.ServerStreamingServer[ContainerArchiveResponse]
+type containerServiceContainerArchiveServer struct {
+	grpc.ServerStream
+}
+
+func (x *containerServiceContainerArchiveServer) Send(m *ContainerArchiveResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
 
 func _ContainerService_ContainerCheckpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ContainerCheckpointRequest)
@@ -958,6 +1110,24 @@ func _ContainerService_ContainerSandboxWaitForCompletion_Handler(srv interface{}
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ContainerService_WorkerHealth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkerHealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerServiceServer).WorkerHealth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContainerService_WorkerHealth_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerServiceServer).WorkerHealth(ctx, req.(*WorkerHealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ContainerService_ServiceDesc is the grpc.ServiceDesc for ContainerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1061,8 +1231,24 @@ var ContainerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ContainerSandboxWaitForCompletion",
 			Handler:    _ContainerService_ContainerSandboxWaitForCompletion_Handler,
 		},
+		{
+			MethodName: "WorkerHealth",
+			Handler:    _ContainerService_WorkerHealth_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ContainerExecStream",
+			Handler:       _ContainerService_ContainerExecStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ContainerPortForward",
+			Handler:       _ContainerService_ContainerPortForward_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 		{
 			StreamName:    "ContainerStreamLogs",
 			Handler:       _ContainerService_ContainerStreamLogs_Handler,
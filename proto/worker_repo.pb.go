@@ -830,16 +830,17 @@ func (x *ToggleWorkerAvailableResponse) GetErrorMsg() string {
 	return ""
 }
 
-type RemoveWorkerRequest struct {
+type UpdateWorkerStatusRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	WorkerId string `protobuf:"bytes,1,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+	Status   string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
 }
 
-func (x *RemoveWorkerRequest) Reset() {
-	*x = RemoveWorkerRequest{}
+func (x *UpdateWorkerStatusRequest) Reset() {
+	*x = UpdateWorkerStatusRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_worker_repo_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -847,13 +848,13 @@ func (x *RemoveWorkerRequest) Reset() {
 	}
 }
 
-func (x *RemoveWorkerRequest) String() string {
+func (x *UpdateWorkerStatusRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveWorkerRequest) ProtoMessage() {}
+func (*UpdateWorkerStatusRequest) ProtoMessage() {}
 
-func (x *RemoveWorkerRequest) ProtoReflect() protoreflect.Message {
+func (x *UpdateWorkerStatusRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_worker_repo_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -865,19 +866,26 @@ func (x *RemoveWorkerRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveWorkerRequest.ProtoReflect.Descriptor instead.
-func (*RemoveWorkerRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateWorkerStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateWorkerStatusRequest) Descriptor() ([]byte, []int) {
 	return file_worker_repo_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *RemoveWorkerRequest) GetWorkerId() string {
+func (x *UpdateWorkerStatusRequest) GetWorkerId() string {
 	if x != nil {
 		return x.WorkerId
 	}
 	return ""
 }
 
-type RemoveWorkerResponse struct {
+func (x *UpdateWorkerStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type UpdateWorkerStatusResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -886,8 +894,8 @@ type RemoveWorkerResponse struct {
 	ErrorMsg string `protobuf:"bytes,2,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
 }
 
-func (x *RemoveWorkerResponse) Reset() {
-	*x = RemoveWorkerResponse{}
+func (x *UpdateWorkerStatusResponse) Reset() {
+	*x = UpdateWorkerStatusResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_worker_repo_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -895,13 +903,13 @@ func (x *RemoveWorkerResponse) Reset() {
 	}
 }
 
-func (x *RemoveWorkerResponse) String() string {
+func (x *UpdateWorkerStatusResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveWorkerResponse) ProtoMessage() {}
+func (*UpdateWorkerStatusResponse) ProtoMessage() {}
 
-func (x *RemoveWorkerResponse) ProtoReflect() protoreflect.Message {
+func (x *UpdateWorkerStatusResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_worker_repo_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -913,37 +921,36 @@ func (x *RemoveWorkerResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveWorkerResponse.ProtoReflect.Descriptor instead.
-func (*RemoveWorkerResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateWorkerStatusResponse.ProtoReflect.Descriptor instead.
+func (*UpdateWorkerStatusResponse) Descriptor() ([]byte, []int) {
 	return file_worker_repo_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *RemoveWorkerResponse) GetOk() bool {
+func (x *UpdateWorkerStatusResponse) GetOk() bool {
 	if x != nil {
 		return x.Ok
 	}
 	return false
 }
 
-func (x *RemoveWorkerResponse) GetErrorMsg() string {
+func (x *UpdateWorkerStatusResponse) GetErrorMsg() string {
 	if x != nil {
 		return x.ErrorMsg
 	}
 	return ""
 }
 
-type UpdateWorkerCapacityRequest struct {
+type SetWorkerGpuInterconnectRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	WorkerId         string            `protobuf:"bytes,1,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
-	CapacityChange   int64             `protobuf:"varint,2,opt,name=capacity_change,json=capacityChange,proto3" json:"capacity_change,omitempty"`
-	ContainerRequest *ContainerRequest `protobuf:"bytes,3,opt,name=container_request,json=containerRequest,proto3" json:"container_request,omitempty"`
+	WorkerId        string `protobuf:"bytes,1,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+	GpuInterconnect string `protobuf:"bytes,2,opt,name=gpu_interconnect,json=gpuInterconnect,proto3" json:"gpu_interconnect,omitempty"`
 }
 
-func (x *UpdateWorkerCapacityRequest) Reset() {
-	*x = UpdateWorkerCapacityRequest{}
+func (x *SetWorkerGpuInterconnectRequest) Reset() {
+	*x = SetWorkerGpuInterconnectRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_worker_repo_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -951,13 +958,13 @@ func (x *UpdateWorkerCapacityRequest) Reset() {
 	}
 }
 
-func (x *UpdateWorkerCapacityRequest) String() string {
+func (x *SetWorkerGpuInterconnectRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateWorkerCapacityRequest) ProtoMessage() {}
+func (*SetWorkerGpuInterconnectRequest) ProtoMessage() {}
 
-func (x *UpdateWorkerCapacityRequest) ProtoReflect() protoreflect.Message {
+func (x *SetWorkerGpuInterconnectRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_worker_repo_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -969,33 +976,26 @@ func (x *UpdateWorkerCapacityRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateWorkerCapacityRequest.ProtoReflect.Descriptor instead.
-func (*UpdateWorkerCapacityRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SetWorkerGpuInterconnectRequest.ProtoReflect.Descriptor instead.
+func (*SetWorkerGpuInterconnectRequest) Descriptor() ([]byte, []int) {
 	return file_worker_repo_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *UpdateWorkerCapacityRequest) GetWorkerId() string {
+func (x *SetWorkerGpuInterconnectRequest) GetWorkerId() string {
 	if x != nil {
 		return x.WorkerId
 	}
 	return ""
 }
 
-func (x *UpdateWorkerCapacityRequest) GetCapacityChange() int64 {
-	if x != nil {
-		return x.CapacityChange
-	}
-	return 0
-}
-
-func (x *UpdateWorkerCapacityRequest) GetContainerRequest() *ContainerRequest {
+func (x *SetWorkerGpuInterconnectRequest) GetGpuInterconnect() string {
 	if x != nil {
-		return x.ContainerRequest
+		return x.GpuInterconnect
 	}
-	return nil
+	return ""
 }
 
-type UpdateWorkerCapacityResponse struct {
+type SetWorkerGpuInterconnectResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -1004,8 +1004,8 @@ type UpdateWorkerCapacityResponse struct {
 	ErrorMsg string `protobuf:"bytes,2,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
 }
 
-func (x *UpdateWorkerCapacityResponse) Reset() {
-	*x = UpdateWorkerCapacityResponse{}
+func (x *SetWorkerGpuInterconnectResponse) Reset() {
+	*x = SetWorkerGpuInterconnectResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_worker_repo_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1013,13 +1013,13 @@ func (x *UpdateWorkerCapacityResponse) Reset() {
 	}
 }
 
-func (x *UpdateWorkerCapacityResponse) String() string {
+func (x *SetWorkerGpuInterconnectResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateWorkerCapacityResponse) ProtoMessage() {}
+func (*SetWorkerGpuInterconnectResponse) ProtoMessage() {}
 
-func (x *UpdateWorkerCapacityResponse) ProtoReflect() protoreflect.Message {
+func (x *SetWorkerGpuInterconnectResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_worker_repo_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1031,35 +1031,36 @@ func (x *UpdateWorkerCapacityResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateWorkerCapacityResponse.ProtoReflect.Descriptor instead.
-func (*UpdateWorkerCapacityResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SetWorkerGpuInterconnectResponse.ProtoReflect.Descriptor instead.
+func (*SetWorkerGpuInterconnectResponse) Descriptor() ([]byte, []int) {
 	return file_worker_repo_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *UpdateWorkerCapacityResponse) GetOk() bool {
+func (x *SetWorkerGpuInterconnectResponse) GetOk() bool {
 	if x != nil {
 		return x.Ok
 	}
 	return false
 }
 
-func (x *UpdateWorkerCapacityResponse) GetErrorMsg() string {
+func (x *SetWorkerGpuInterconnectResponse) GetErrorMsg() string {
 	if x != nil {
 		return x.ErrorMsg
 	}
 	return ""
 }
 
-type SetWorkerKeepAliveRequest struct {
+type SetWorkerServerAddressRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	WorkerId string `protobuf:"bytes,1,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+	Address  string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
 }
 
-func (x *SetWorkerKeepAliveRequest) Reset() {
-	*x = SetWorkerKeepAliveRequest{}
+func (x *SetWorkerServerAddressRequest) Reset() {
+	*x = SetWorkerServerAddressRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_worker_repo_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1067,13 +1068,13 @@ func (x *SetWorkerKeepAliveRequest) Reset() {
 	}
 }
 
-func (x *SetWorkerKeepAliveRequest) String() string {
+func (x *SetWorkerServerAddressRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetWorkerKeepAliveRequest) ProtoMessage() {}
+func (*SetWorkerServerAddressRequest) ProtoMessage() {}
 
-func (x *SetWorkerKeepAliveRequest) ProtoReflect() protoreflect.Message {
+func (x *SetWorkerServerAddressRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_worker_repo_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1085,19 +1086,26 @@ func (x *SetWorkerKeepAliveRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetWorkerKeepAliveRequest.ProtoReflect.Descriptor instead.
-func (*SetWorkerKeepAliveRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SetWorkerServerAddressRequest.ProtoReflect.Descriptor instead.
+func (*SetWorkerServerAddressRequest) Descriptor() ([]byte, []int) {
 	return file_worker_repo_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *SetWorkerKeepAliveRequest) GetWorkerId() string {
+func (x *SetWorkerServerAddressRequest) GetWorkerId() string {
 	if x != nil {
 		return x.WorkerId
 	}
 	return ""
 }
 
-type SetWorkerKeepAliveResponse struct {
+func (x *SetWorkerServerAddressRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type SetWorkerServerAddressResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -1106,8 +1114,8 @@ type SetWorkerKeepAliveResponse struct {
 	ErrorMsg string `protobuf:"bytes,2,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
 }
 
-func (x *SetWorkerKeepAliveResponse) Reset() {
-	*x = SetWorkerKeepAliveResponse{}
+func (x *SetWorkerServerAddressResponse) Reset() {
+	*x = SetWorkerServerAddressResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_worker_repo_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1115,13 +1123,13 @@ func (x *SetWorkerKeepAliveResponse) Reset() {
 	}
 }
 
-func (x *SetWorkerKeepAliveResponse) String() string {
+func (x *SetWorkerServerAddressResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetWorkerKeepAliveResponse) ProtoMessage() {}
+func (*SetWorkerServerAddressResponse) ProtoMessage() {}
 
-func (x *SetWorkerKeepAliveResponse) ProtoReflect() protoreflect.Message {
+func (x *SetWorkerServerAddressResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_worker_repo_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1133,37 +1141,36 @@ func (x *SetWorkerKeepAliveResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetWorkerKeepAliveResponse.ProtoReflect.Descriptor instead.
-func (*SetWorkerKeepAliveResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SetWorkerServerAddressResponse.ProtoReflect.Descriptor instead.
+func (*SetWorkerServerAddressResponse) Descriptor() ([]byte, []int) {
 	return file_worker_repo_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *SetWorkerKeepAliveResponse) GetOk() bool {
+func (x *SetWorkerServerAddressResponse) GetOk() bool {
 	if x != nil {
 		return x.Ok
 	}
 	return false
 }
 
-func (x *SetWorkerKeepAliveResponse) GetErrorMsg() string {
+func (x *SetWorkerServerAddressResponse) GetErrorMsg() string {
 	if x != nil {
 		return x.ErrorMsg
 	}
 	return ""
 }
 
-type SetNetworkLockRequest struct {
+type SetWorkerArchRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	NetworkPrefix string `protobuf:"bytes,1,opt,name=network_prefix,json=networkPrefix,proto3" json:"network_prefix,omitempty"`
-	Ttl           int32  `protobuf:"varint,2,opt,name=ttl,proto3" json:"ttl,omitempty"`
-	Retries       int32  `protobuf:"varint,3,opt,name=retries,proto3" json:"retries,omitempty"`
+	WorkerId string `protobuf:"bytes,1,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+	Arch     string `protobuf:"bytes,2,opt,name=arch,proto3" json:"arch,omitempty"`
 }
 
-func (x *SetNetworkLockRequest) Reset() {
-	*x = SetNetworkLockRequest{}
+func (x *SetWorkerArchRequest) Reset() {
+	*x = SetWorkerArchRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_worker_repo_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1171,13 +1178,13 @@ func (x *SetNetworkLockRequest) Reset() {
 	}
 }
 
-func (x *SetNetworkLockRequest) String() string {
+func (x *SetWorkerArchRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetNetworkLockRequest) ProtoMessage() {}
+func (*SetWorkerArchRequest) ProtoMessage() {}
 
-func (x *SetNetworkLockRequest) ProtoReflect() protoreflect.Message {
+func (x *SetWorkerArchRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_worker_repo_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1189,44 +1196,36 @@ func (x *SetNetworkLockRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetNetworkLockRequest.ProtoReflect.Descriptor instead.
-func (*SetNetworkLockRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SetWorkerArchRequest.ProtoReflect.Descriptor instead.
+func (*SetWorkerArchRequest) Descriptor() ([]byte, []int) {
 	return file_worker_repo_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *SetNetworkLockRequest) GetNetworkPrefix() string {
+func (x *SetWorkerArchRequest) GetWorkerId() string {
 	if x != nil {
-		return x.NetworkPrefix
+		return x.WorkerId
 	}
 	return ""
 }
 
-func (x *SetNetworkLockRequest) GetTtl() int32 {
-	if x != nil {
-		return x.Ttl
-	}
-	return 0
-}
-
-func (x *SetNetworkLockRequest) GetRetries() int32 {
+func (x *SetWorkerArchRequest) GetArch() string {
 	if x != nil {
-		return x.Retries
+		return x.Arch
 	}
-	return 0
+	return ""
 }
 
-type SetNetworkLockResponse struct {
+type SetWorkerArchResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Ok       bool   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
-	Token    string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
-	ErrorMsg string `protobuf:"bytes,3,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
+	ErrorMsg string `protobuf:"bytes,2,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
 }
 
-func (x *SetNetworkLockResponse) Reset() {
-	*x = SetNetworkLockResponse{}
+func (x *SetWorkerArchResponse) Reset() {
+	*x = SetWorkerArchResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_worker_repo_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1234,13 +1233,13 @@ func (x *SetNetworkLockResponse) Reset() {
 	}
 }
 
-func (x *SetNetworkLockResponse) String() string {
+func (x *SetWorkerArchResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetNetworkLockResponse) ProtoMessage() {}
+func (*SetWorkerArchResponse) ProtoMessage() {}
 
-func (x *SetNetworkLockResponse) ProtoReflect() protoreflect.Message {
+func (x *SetWorkerArchResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_worker_repo_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1252,43 +1251,36 @@ func (x *SetNetworkLockResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetNetworkLockResponse.ProtoReflect.Descriptor instead.
-func (*SetNetworkLockResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SetWorkerArchResponse.ProtoReflect.Descriptor instead.
+func (*SetWorkerArchResponse) Descriptor() ([]byte, []int) {
 	return file_worker_repo_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *SetNetworkLockResponse) GetOk() bool {
+func (x *SetWorkerArchResponse) GetOk() bool {
 	if x != nil {
 		return x.Ok
 	}
 	return false
 }
 
-func (x *SetNetworkLockResponse) GetToken() string {
-	if x != nil {
-		return x.Token
-	}
-	return ""
-}
-
-func (x *SetNetworkLockResponse) GetErrorMsg() string {
+func (x *SetWorkerArchResponse) GetErrorMsg() string {
 	if x != nil {
 		return x.ErrorMsg
 	}
 	return ""
 }
 
-type RemoveNetworkLockRequest struct {
+type SetWorkerMigSlicesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	NetworkPrefix string `protobuf:"bytes,1,opt,name=network_prefix,json=networkPrefix,proto3" json:"network_prefix,omitempty"`
-	Token         string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	WorkerId  string `protobuf:"bytes,1,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+	MigSlices string `protobuf:"bytes,2,opt,name=mig_slices,json=migSlices,proto3" json:"mig_slices,omitempty"` // Slices of each MIG profile, e.g. 1g.10gb=7
 }
 
-func (x *RemoveNetworkLockRequest) Reset() {
-	*x = RemoveNetworkLockRequest{}
+func (x *SetWorkerMigSlicesRequest) Reset() {
+	*x = SetWorkerMigSlicesRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_worker_repo_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1296,13 +1288,13 @@ func (x *RemoveNetworkLockRequest) Reset() {
 	}
 }
 
-func (x *RemoveNetworkLockRequest) String() string {
+func (x *SetWorkerMigSlicesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveNetworkLockRequest) ProtoMessage() {}
+func (*SetWorkerMigSlicesRequest) ProtoMessage() {}
 
-func (x *RemoveNetworkLockRequest) ProtoReflect() protoreflect.Message {
+func (x *SetWorkerMigSlicesRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_worker_repo_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1314,26 +1306,26 @@ func (x *RemoveNetworkLockRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveNetworkLockRequest.ProtoReflect.Descriptor instead.
-func (*RemoveNetworkLockRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SetWorkerMigSlicesRequest.ProtoReflect.Descriptor instead.
+func (*SetWorkerMigSlicesRequest) Descriptor() ([]byte, []int) {
 	return file_worker_repo_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *RemoveNetworkLockRequest) GetNetworkPrefix() string {
+func (x *SetWorkerMigSlicesRequest) GetWorkerId() string {
 	if x != nil {
-		return x.NetworkPrefix
+		return x.WorkerId
 	}
 	return ""
 }
 
-func (x *RemoveNetworkLockRequest) GetToken() string {
+func (x *SetWorkerMigSlicesRequest) GetMigSlices() string {
 	if x != nil {
-		return x.Token
+		return x.MigSlices
 	}
 	return ""
 }
 
-type RemoveNetworkLockResponse struct {
+type SetWorkerMigSlicesResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -1342,8 +1334,8 @@ type RemoveNetworkLockResponse struct {
 	ErrorMsg string `protobuf:"bytes,2,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
 }
 
-func (x *RemoveNetworkLockResponse) Reset() {
-	*x = RemoveNetworkLockResponse{}
+func (x *SetWorkerMigSlicesResponse) Reset() {
+	*x = SetWorkerMigSlicesResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_worker_repo_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1351,13 +1343,13 @@ func (x *RemoveNetworkLockResponse) Reset() {
 	}
 }
 
-func (x *RemoveNetworkLockResponse) String() string {
+func (x *SetWorkerMigSlicesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveNetworkLockResponse) ProtoMessage() {}
+func (*SetWorkerMigSlicesResponse) ProtoMessage() {}
 
-func (x *RemoveNetworkLockResponse) ProtoReflect() protoreflect.Message {
+func (x *SetWorkerMigSlicesResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_worker_repo_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1369,37 +1361,35 @@ func (x *RemoveNetworkLockResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveNetworkLockResponse.ProtoReflect.Descriptor instead.
-func (*RemoveNetworkLockResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SetWorkerMigSlicesResponse.ProtoReflect.Descriptor instead.
+func (*SetWorkerMigSlicesResponse) Descriptor() ([]byte, []int) {
 	return file_worker_repo_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *RemoveNetworkLockResponse) GetOk() bool {
+func (x *SetWorkerMigSlicesResponse) GetOk() bool {
 	if x != nil {
 		return x.Ok
 	}
 	return false
 }
 
-func (x *RemoveNetworkLockResponse) GetErrorMsg() string {
+func (x *SetWorkerMigSlicesResponse) GetErrorMsg() string {
 	if x != nil {
 		return x.ErrorMsg
 	}
 	return ""
 }
 
-type SetContainerIpRequest struct {
+type RemoveWorkerRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	NetworkPrefix string `protobuf:"bytes,1,opt,name=network_prefix,json=networkPrefix,proto3" json:"network_prefix,omitempty"`
-	ContainerId   string `protobuf:"bytes,2,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
-	IpAddress     string `protobuf:"bytes,3,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	WorkerId string `protobuf:"bytes,1,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
 }
 
-func (x *SetContainerIpRequest) Reset() {
-	*x = SetContainerIpRequest{}
+func (x *RemoveWorkerRequest) Reset() {
+	*x = RemoveWorkerRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_worker_repo_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1407,13 +1397,13 @@ func (x *SetContainerIpRequest) Reset() {
 	}
 }
 
-func (x *SetContainerIpRequest) String() string {
+func (x *RemoveWorkerRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetContainerIpRequest) ProtoMessage() {}
+func (*RemoveWorkerRequest) ProtoMessage() {}
 
-func (x *SetContainerIpRequest) ProtoReflect() protoreflect.Message {
+func (x *RemoveWorkerRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_worker_repo_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1425,33 +1415,19 @@ func (x *SetContainerIpRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetContainerIpRequest.ProtoReflect.Descriptor instead.
-func (*SetContainerIpRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use RemoveWorkerRequest.ProtoReflect.Descriptor instead.
+func (*RemoveWorkerRequest) Descriptor() ([]byte, []int) {
 	return file_worker_repo_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *SetContainerIpRequest) GetNetworkPrefix() string {
-	if x != nil {
-		return x.NetworkPrefix
-	}
-	return ""
-}
-
-func (x *SetContainerIpRequest) GetContainerId() string {
-	if x != nil {
-		return x.ContainerId
-	}
-	return ""
-}
-
-func (x *SetContainerIpRequest) GetIpAddress() string {
+func (x *RemoveWorkerRequest) GetWorkerId() string {
 	if x != nil {
-		return x.IpAddress
+		return x.WorkerId
 	}
 	return ""
 }
 
-type SetContainerIpResponse struct {
+type RemoveWorkerResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -1460,8 +1436,8 @@ type SetContainerIpResponse struct {
 	ErrorMsg string `protobuf:"bytes,2,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
 }
 
-func (x *SetContainerIpResponse) Reset() {
-	*x = SetContainerIpResponse{}
+func (x *RemoveWorkerResponse) Reset() {
+	*x = RemoveWorkerResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_worker_repo_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1469,13 +1445,13 @@ func (x *SetContainerIpResponse) Reset() {
 	}
 }
 
-func (x *SetContainerIpResponse) String() string {
+func (x *RemoveWorkerResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetContainerIpResponse) ProtoMessage() {}
+func (*RemoveWorkerResponse) ProtoMessage() {}
 
-func (x *SetContainerIpResponse) ProtoReflect() protoreflect.Message {
+func (x *RemoveWorkerResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_worker_repo_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1487,36 +1463,37 @@ func (x *SetContainerIpResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetContainerIpResponse.ProtoReflect.Descriptor instead.
-func (*SetContainerIpResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use RemoveWorkerResponse.ProtoReflect.Descriptor instead.
+func (*RemoveWorkerResponse) Descriptor() ([]byte, []int) {
 	return file_worker_repo_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *SetContainerIpResponse) GetOk() bool {
+func (x *RemoveWorkerResponse) GetOk() bool {
 	if x != nil {
 		return x.Ok
 	}
 	return false
 }
 
-func (x *SetContainerIpResponse) GetErrorMsg() string {
+func (x *RemoveWorkerResponse) GetErrorMsg() string {
 	if x != nil {
 		return x.ErrorMsg
 	}
 	return ""
 }
 
-type GetContainerIpRequest struct {
+type UpdateWorkerCapacityRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	NetworkPrefix string `protobuf:"bytes,1,opt,name=network_prefix,json=networkPrefix,proto3" json:"network_prefix,omitempty"`
-	ContainerId   string `protobuf:"bytes,2,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	WorkerId         string            `protobuf:"bytes,1,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+	CapacityChange   int64             `protobuf:"varint,2,opt,name=capacity_change,json=capacityChange,proto3" json:"capacity_change,omitempty"`
+	ContainerRequest *ContainerRequest `protobuf:"bytes,3,opt,name=container_request,json=containerRequest,proto3" json:"container_request,omitempty"`
 }
 
-func (x *GetContainerIpRequest) Reset() {
-	*x = GetContainerIpRequest{}
+func (x *UpdateWorkerCapacityRequest) Reset() {
+	*x = UpdateWorkerCapacityRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_worker_repo_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1524,13 +1501,13 @@ func (x *GetContainerIpRequest) Reset() {
 	}
 }
 
-func (x *GetContainerIpRequest) String() string {
+func (x *UpdateWorkerCapacityRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetContainerIpRequest) ProtoMessage() {}
+func (*UpdateWorkerCapacityRequest) ProtoMessage() {}
 
-func (x *GetContainerIpRequest) ProtoReflect() protoreflect.Message {
+func (x *UpdateWorkerCapacityRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_worker_repo_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1542,37 +1519,43 @@ func (x *GetContainerIpRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetContainerIpRequest.ProtoReflect.Descriptor instead.
-func (*GetContainerIpRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateWorkerCapacityRequest.ProtoReflect.Descriptor instead.
+func (*UpdateWorkerCapacityRequest) Descriptor() ([]byte, []int) {
 	return file_worker_repo_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *GetContainerIpRequest) GetNetworkPrefix() string {
+func (x *UpdateWorkerCapacityRequest) GetWorkerId() string {
 	if x != nil {
-		return x.NetworkPrefix
+		return x.WorkerId
 	}
 	return ""
 }
 
-func (x *GetContainerIpRequest) GetContainerId() string {
+func (x *UpdateWorkerCapacityRequest) GetCapacityChange() int64 {
 	if x != nil {
-		return x.ContainerId
+		return x.CapacityChange
 	}
-	return ""
+	return 0
 }
 
-type GetContainerIpResponse struct {
+func (x *UpdateWorkerCapacityRequest) GetContainerRequest() *ContainerRequest {
+	if x != nil {
+		return x.ContainerRequest
+	}
+	return nil
+}
+
+type UpdateWorkerCapacityResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ok        bool   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
-	IpAddress string `protobuf:"bytes,2,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
-	ErrorMsg  string `protobuf:"bytes,3,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
+	Ok       bool   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	ErrorMsg string `protobuf:"bytes,2,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
 }
 
-func (x *GetContainerIpResponse) Reset() {
-	*x = GetContainerIpResponse{}
+func (x *UpdateWorkerCapacityResponse) Reset() {
+	*x = UpdateWorkerCapacityResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_worker_repo_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1580,13 +1563,13 @@ func (x *GetContainerIpResponse) Reset() {
 	}
 }
 
-func (x *GetContainerIpResponse) String() string {
+func (x *UpdateWorkerCapacityResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetContainerIpResponse) ProtoMessage() {}
+func (*UpdateWorkerCapacityResponse) ProtoMessage() {}
 
-func (x *GetContainerIpResponse) ProtoReflect() protoreflect.Message {
+func (x *UpdateWorkerCapacityResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_worker_repo_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1598,57 +1581,154 @@ func (x *GetContainerIpResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetContainerIpResponse.ProtoReflect.Descriptor instead.
-func (*GetContainerIpResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateWorkerCapacityResponse.ProtoReflect.Descriptor instead.
+func (*UpdateWorkerCapacityResponse) Descriptor() ([]byte, []int) {
 	return file_worker_repo_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *GetContainerIpResponse) GetOk() bool {
+func (x *UpdateWorkerCapacityResponse) GetOk() bool {
 	if x != nil {
 		return x.Ok
 	}
 	return false
 }
 
-func (x *GetContainerIpResponse) GetIpAddress() string {
+func (x *UpdateWorkerCapacityResponse) GetErrorMsg() string {
 	if x != nil {
-		return x.IpAddress
+		return x.ErrorMsg
 	}
 	return ""
 }
 
-func (x *GetContainerIpResponse) GetErrorMsg() string {
+type SetWorkerKeepAliveRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	WorkerId string `protobuf:"bytes,1,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+}
+
+func (x *SetWorkerKeepAliveRequest) Reset() {
+	*x = SetWorkerKeepAliveRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker_repo_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetWorkerKeepAliveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetWorkerKeepAliveRequest) ProtoMessage() {}
+
+func (x *SetWorkerKeepAliveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_worker_repo_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetWorkerKeepAliveRequest.ProtoReflect.Descriptor instead.
+func (*SetWorkerKeepAliveRequest) Descriptor() ([]byte, []int) {
+	return file_worker_repo_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SetWorkerKeepAliveRequest) GetWorkerId() string {
+	if x != nil {
+		return x.WorkerId
+	}
+	return ""
+}
+
+type SetWorkerKeepAliveResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok       bool   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	ErrorMsg string `protobuf:"bytes,2,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
+}
+
+func (x *SetWorkerKeepAliveResponse) Reset() {
+	*x = SetWorkerKeepAliveResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker_repo_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetWorkerKeepAliveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetWorkerKeepAliveResponse) ProtoMessage() {}
+
+func (x *SetWorkerKeepAliveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_worker_repo_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetWorkerKeepAliveResponse.ProtoReflect.Descriptor instead.
+func (*SetWorkerKeepAliveResponse) Descriptor() ([]byte, []int) {
+	return file_worker_repo_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *SetWorkerKeepAliveResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *SetWorkerKeepAliveResponse) GetErrorMsg() string {
 	if x != nil {
 		return x.ErrorMsg
 	}
 	return ""
 }
 
-type GetContainerIpsRequest struct {
+type SetNetworkLockRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	NetworkPrefix string `protobuf:"bytes,1,opt,name=network_prefix,json=networkPrefix,proto3" json:"network_prefix,omitempty"`
+	Ttl           int32  `protobuf:"varint,2,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	Retries       int32  `protobuf:"varint,3,opt,name=retries,proto3" json:"retries,omitempty"`
 }
 
-func (x *GetContainerIpsRequest) Reset() {
-	*x = GetContainerIpsRequest{}
+func (x *SetNetworkLockRequest) Reset() {
+	*x = SetNetworkLockRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_worker_repo_proto_msgTypes[28]
+		mi := &file_worker_repo_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetContainerIpsRequest) String() string {
+func (x *SetNetworkLockRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetContainerIpsRequest) ProtoMessage() {}
+func (*SetNetworkLockRequest) ProtoMessage() {}
 
-func (x *GetContainerIpsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_worker_repo_proto_msgTypes[28]
+func (x *SetNetworkLockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_worker_repo_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1659,45 +1739,59 @@ func (x *GetContainerIpsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetContainerIpsRequest.ProtoReflect.Descriptor instead.
-func (*GetContainerIpsRequest) Descriptor() ([]byte, []int) {
-	return file_worker_repo_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use SetNetworkLockRequest.ProtoReflect.Descriptor instead.
+func (*SetNetworkLockRequest) Descriptor() ([]byte, []int) {
+	return file_worker_repo_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *GetContainerIpsRequest) GetNetworkPrefix() string {
+func (x *SetNetworkLockRequest) GetNetworkPrefix() string {
 	if x != nil {
 		return x.NetworkPrefix
 	}
 	return ""
 }
 
-type GetContainerIpsResponse struct {
+func (x *SetNetworkLockRequest) GetTtl() int32 {
+	if x != nil {
+		return x.Ttl
+	}
+	return 0
+}
+
+func (x *SetNetworkLockRequest) GetRetries() int32 {
+	if x != nil {
+		return x.Retries
+	}
+	return 0
+}
+
+type SetNetworkLockResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ok       bool     `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
-	Ips      []string `protobuf:"bytes,2,rep,name=ips,proto3" json:"ips,omitempty"`
-	ErrorMsg string   `protobuf:"bytes,3,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
+	Ok       bool   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Token    string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	ErrorMsg string `protobuf:"bytes,3,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
 }
 
-func (x *GetContainerIpsResponse) Reset() {
-	*x = GetContainerIpsResponse{}
+func (x *SetNetworkLockResponse) Reset() {
+	*x = SetNetworkLockResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_worker_repo_proto_msgTypes[29]
+		mi := &file_worker_repo_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetContainerIpsResponse) String() string {
+func (x *SetNetworkLockResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetContainerIpsResponse) ProtoMessage() {}
+func (*SetNetworkLockResponse) ProtoMessage() {}
 
-func (x *GetContainerIpsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_worker_repo_proto_msgTypes[29]
+func (x *SetNetworkLockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_worker_repo_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1708,58 +1802,58 @@ func (x *GetContainerIpsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetContainerIpsResponse.ProtoReflect.Descriptor instead.
-func (*GetContainerIpsResponse) Descriptor() ([]byte, []int) {
-	return file_worker_repo_proto_rawDescGZIP(), []int{29}
+// Deprecated: Use SetNetworkLockResponse.ProtoReflect.Descriptor instead.
+func (*SetNetworkLockResponse) Descriptor() ([]byte, []int) {
+	return file_worker_repo_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *GetContainerIpsResponse) GetOk() bool {
+func (x *SetNetworkLockResponse) GetOk() bool {
 	if x != nil {
 		return x.Ok
 	}
 	return false
 }
 
-func (x *GetContainerIpsResponse) GetIps() []string {
+func (x *SetNetworkLockResponse) GetToken() string {
 	if x != nil {
-		return x.Ips
+		return x.Token
 	}
-	return nil
+	return ""
 }
 
-func (x *GetContainerIpsResponse) GetErrorMsg() string {
+func (x *SetNetworkLockResponse) GetErrorMsg() string {
 	if x != nil {
 		return x.ErrorMsg
 	}
 	return ""
 }
 
-type RemoveContainerIpRequest struct {
+type RemoveNetworkLockRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	NetworkPrefix string `protobuf:"bytes,1,opt,name=network_prefix,json=networkPrefix,proto3" json:"network_prefix,omitempty"`
-	ContainerId   string `protobuf:"bytes,2,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	Token         string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
 }
 
-func (x *RemoveContainerIpRequest) Reset() {
-	*x = RemoveContainerIpRequest{}
+func (x *RemoveNetworkLockRequest) Reset() {
+	*x = RemoveNetworkLockRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_worker_repo_proto_msgTypes[30]
+		mi := &file_worker_repo_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RemoveContainerIpRequest) String() string {
+func (x *RemoveNetworkLockRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveContainerIpRequest) ProtoMessage() {}
+func (*RemoveNetworkLockRequest) ProtoMessage() {}
 
-func (x *RemoveContainerIpRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_worker_repo_proto_msgTypes[30]
+func (x *RemoveNetworkLockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_worker_repo_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1770,26 +1864,26 @@ func (x *RemoveContainerIpRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveContainerIpRequest.ProtoReflect.Descriptor instead.
-func (*RemoveContainerIpRequest) Descriptor() ([]byte, []int) {
-	return file_worker_repo_proto_rawDescGZIP(), []int{30}
+// Deprecated: Use RemoveNetworkLockRequest.ProtoReflect.Descriptor instead.
+func (*RemoveNetworkLockRequest) Descriptor() ([]byte, []int) {
+	return file_worker_repo_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *RemoveContainerIpRequest) GetNetworkPrefix() string {
+func (x *RemoveNetworkLockRequest) GetNetworkPrefix() string {
 	if x != nil {
 		return x.NetworkPrefix
 	}
 	return ""
 }
 
-func (x *RemoveContainerIpRequest) GetContainerId() string {
+func (x *RemoveNetworkLockRequest) GetToken() string {
 	if x != nil {
-		return x.ContainerId
+		return x.Token
 	}
 	return ""
 }
 
-type RemoveContainerIpResponse struct {
+type RemoveNetworkLockResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -1798,23 +1892,23 @@ type RemoveContainerIpResponse struct {
 	ErrorMsg string `protobuf:"bytes,2,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
 }
 
-func (x *RemoveContainerIpResponse) Reset() {
-	*x = RemoveContainerIpResponse{}
+func (x *RemoveNetworkLockResponse) Reset() {
+	*x = RemoveNetworkLockResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_worker_repo_proto_msgTypes[31]
+		mi := &file_worker_repo_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RemoveContainerIpResponse) String() string {
+func (x *RemoveNetworkLockResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveContainerIpResponse) ProtoMessage() {}
+func (*RemoveNetworkLockResponse) ProtoMessage() {}
 
-func (x *RemoveContainerIpResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_worker_repo_proto_msgTypes[31]
+func (x *RemoveNetworkLockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_worker_repo_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1825,23 +1919,943 @@ func (x *RemoveContainerIpResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveContainerIpResponse.ProtoReflect.Descriptor instead.
-func (*RemoveContainerIpResponse) Descriptor() ([]byte, []int) {
-	return file_worker_repo_proto_rawDescGZIP(), []int{31}
+// Deprecated: Use RemoveNetworkLockResponse.ProtoReflect.Descriptor instead.
+func (*RemoveNetworkLockResponse) Descriptor() ([]byte, []int) {
+	return file_worker_repo_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *RemoveContainerIpResponse) GetOk() bool {
+func (x *RemoveNetworkLockResponse) GetOk() bool {
 	if x != nil {
 		return x.Ok
 	}
-	return false
+	return false
+}
+
+func (x *RemoveNetworkLockResponse) GetErrorMsg() string {
+	if x != nil {
+		return x.ErrorMsg
+	}
+	return ""
+}
+
+type SetContainerIpRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NetworkPrefix string `protobuf:"bytes,1,opt,name=network_prefix,json=networkPrefix,proto3" json:"network_prefix,omitempty"`
+	ContainerId   string `protobuf:"bytes,2,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	IpAddress     string `protobuf:"bytes,3,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+}
+
+func (x *SetContainerIpRequest) Reset() {
+	*x = SetContainerIpRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker_repo_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetContainerIpRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetContainerIpRequest) ProtoMessage() {}
+
+func (x *SetContainerIpRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_worker_repo_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetContainerIpRequest.ProtoReflect.Descriptor instead.
+func (*SetContainerIpRequest) Descriptor() ([]byte, []int) {
+	return file_worker_repo_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SetContainerIpRequest) GetNetworkPrefix() string {
+	if x != nil {
+		return x.NetworkPrefix
+	}
+	return ""
+}
+
+func (x *SetContainerIpRequest) GetContainerId() string {
+	if x != nil {
+		return x.ContainerId
+	}
+	return ""
+}
+
+func (x *SetContainerIpRequest) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+type SetContainerIpResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok       bool   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	ErrorMsg string `protobuf:"bytes,2,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
+}
+
+func (x *SetContainerIpResponse) Reset() {
+	*x = SetContainerIpResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker_repo_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetContainerIpResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetContainerIpResponse) ProtoMessage() {}
+
+func (x *SetContainerIpResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_worker_repo_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetContainerIpResponse.ProtoReflect.Descriptor instead.
+func (*SetContainerIpResponse) Descriptor() ([]byte, []int) {
+	return file_worker_repo_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SetContainerIpResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *SetContainerIpResponse) GetErrorMsg() string {
+	if x != nil {
+		return x.ErrorMsg
+	}
+	return ""
+}
+
+type GetContainerIpRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NetworkPrefix string `protobuf:"bytes,1,opt,name=network_prefix,json=networkPrefix,proto3" json:"network_prefix,omitempty"`
+	ContainerId   string `protobuf:"bytes,2,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+}
+
+func (x *GetContainerIpRequest) Reset() {
+	*x = GetContainerIpRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker_repo_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetContainerIpRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetContainerIpRequest) ProtoMessage() {}
+
+func (x *GetContainerIpRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_worker_repo_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetContainerIpRequest.ProtoReflect.Descriptor instead.
+func (*GetContainerIpRequest) Descriptor() ([]byte, []int) {
+	return file_worker_repo_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetContainerIpRequest) GetNetworkPrefix() string {
+	if x != nil {
+		return x.NetworkPrefix
+	}
+	return ""
+}
+
+func (x *GetContainerIpRequest) GetContainerId() string {
+	if x != nil {
+		return x.ContainerId
+	}
+	return ""
+}
+
+type GetContainerIpResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok        bool   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	IpAddress string `protobuf:"bytes,2,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	ErrorMsg  string `protobuf:"bytes,3,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
+}
+
+func (x *GetContainerIpResponse) Reset() {
+	*x = GetContainerIpResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker_repo_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetContainerIpResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetContainerIpResponse) ProtoMessage() {}
+
+func (x *GetContainerIpResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_worker_repo_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetContainerIpResponse.ProtoReflect.Descriptor instead.
+func (*GetContainerIpResponse) Descriptor() ([]byte, []int) {
+	return file_worker_repo_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetContainerIpResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *GetContainerIpResponse) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *GetContainerIpResponse) GetErrorMsg() string {
+	if x != nil {
+		return x.ErrorMsg
+	}
+	return ""
+}
+
+type GetContainerIpsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NetworkPrefix string `protobuf:"bytes,1,opt,name=network_prefix,json=networkPrefix,proto3" json:"network_prefix,omitempty"`
+}
+
+func (x *GetContainerIpsRequest) Reset() {
+	*x = GetContainerIpsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker_repo_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetContainerIpsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetContainerIpsRequest) ProtoMessage() {}
+
+func (x *GetContainerIpsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_worker_repo_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetContainerIpsRequest.ProtoReflect.Descriptor instead.
+func (*GetContainerIpsRequest) Descriptor() ([]byte, []int) {
+	return file_worker_repo_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetContainerIpsRequest) GetNetworkPrefix() string {
+	if x != nil {
+		return x.NetworkPrefix
+	}
+	return ""
+}
+
+type GetContainerIpsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok       bool     `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Ips      []string `protobuf:"bytes,2,rep,name=ips,proto3" json:"ips,omitempty"`
+	ErrorMsg string   `protobuf:"bytes,3,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
+}
+
+func (x *GetContainerIpsResponse) Reset() {
+	*x = GetContainerIpsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker_repo_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetContainerIpsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetContainerIpsResponse) ProtoMessage() {}
+
+func (x *GetContainerIpsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_worker_repo_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetContainerIpsResponse.ProtoReflect.Descriptor instead.
+func (*GetContainerIpsResponse) Descriptor() ([]byte, []int) {
+	return file_worker_repo_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetContainerIpsResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *GetContainerIpsResponse) GetIps() []string {
+	if x != nil {
+		return x.Ips
+	}
+	return nil
+}
+
+func (x *GetContainerIpsResponse) GetErrorMsg() string {
+	if x != nil {
+		return x.ErrorMsg
+	}
+	return ""
+}
+
+type RemoveContainerIpRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NetworkPrefix string `protobuf:"bytes,1,opt,name=network_prefix,json=networkPrefix,proto3" json:"network_prefix,omitempty"`
+	ContainerId   string `protobuf:"bytes,2,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+}
+
+func (x *RemoveContainerIpRequest) Reset() {
+	*x = RemoveContainerIpRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker_repo_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveContainerIpRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveContainerIpRequest) ProtoMessage() {}
+
+func (x *RemoveContainerIpRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_worker_repo_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveContainerIpRequest.ProtoReflect.Descriptor instead.
+func (*RemoveContainerIpRequest) Descriptor() ([]byte, []int) {
+	return file_worker_repo_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *RemoveContainerIpRequest) GetNetworkPrefix() string {
+	if x != nil {
+		return x.NetworkPrefix
+	}
+	return ""
+}
+
+func (x *RemoveContainerIpRequest) GetContainerId() string {
+	if x != nil {
+		return x.ContainerId
+	}
+	return ""
+}
+
+type RemoveContainerIpResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok       bool   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	ErrorMsg string `protobuf:"bytes,2,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
+}
+
+func (x *RemoveContainerIpResponse) Reset() {
+	*x = RemoveContainerIpResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker_repo_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveContainerIpResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveContainerIpResponse) ProtoMessage() {}
+
+func (x *RemoveContainerIpResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_worker_repo_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveContainerIpResponse.ProtoReflect.Descriptor instead.
+func (*RemoveContainerIpResponse) Descriptor() ([]byte, []int) {
+	return file_worker_repo_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *RemoveContainerIpResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *RemoveContainerIpResponse) GetErrorMsg() string {
+	if x != nil {
+		return x.ErrorMsg
+	}
+	return ""
+}
+
+type AddCachedObjectRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	WorkerId string `protobuf:"bytes,1,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+	Digest   string `protobuf:"bytes,2,opt,name=digest,proto3" json:"digest,omitempty"`
+}
+
+func (x *AddCachedObjectRequest) Reset() {
+	*x = AddCachedObjectRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker_repo_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddCachedObjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddCachedObjectRequest) ProtoMessage() {}
+
+func (x *AddCachedObjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_worker_repo_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddCachedObjectRequest.ProtoReflect.Descriptor instead.
+func (*AddCachedObjectRequest) Descriptor() ([]byte, []int) {
+	return file_worker_repo_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *AddCachedObjectRequest) GetWorkerId() string {
+	if x != nil {
+		return x.WorkerId
+	}
+	return ""
+}
+
+func (x *AddCachedObjectRequest) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
+type AddCachedObjectResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok       bool   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	ErrorMsg string `protobuf:"bytes,2,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
+}
+
+func (x *AddCachedObjectResponse) Reset() {
+	*x = AddCachedObjectResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker_repo_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddCachedObjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddCachedObjectResponse) ProtoMessage() {}
+
+func (x *AddCachedObjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_worker_repo_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddCachedObjectResponse.ProtoReflect.Descriptor instead.
+func (*AddCachedObjectResponse) Descriptor() ([]byte, []int) {
+	return file_worker_repo_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *AddCachedObjectResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *AddCachedObjectResponse) GetErrorMsg() string {
+	if x != nil {
+		return x.ErrorMsg
+	}
+	return ""
+}
+
+type RemoveCachedObjectRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	WorkerId string `protobuf:"bytes,1,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+	Digest   string `protobuf:"bytes,2,opt,name=digest,proto3" json:"digest,omitempty"`
+}
+
+func (x *RemoveCachedObjectRequest) Reset() {
+	*x = RemoveCachedObjectRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker_repo_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveCachedObjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveCachedObjectRequest) ProtoMessage() {}
+
+func (x *RemoveCachedObjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_worker_repo_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveCachedObjectRequest.ProtoReflect.Descriptor instead.
+func (*RemoveCachedObjectRequest) Descriptor() ([]byte, []int) {
+	return file_worker_repo_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *RemoveCachedObjectRequest) GetWorkerId() string {
+	if x != nil {
+		return x.WorkerId
+	}
+	return ""
+}
+
+func (x *RemoveCachedObjectRequest) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
+type RemoveCachedObjectResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok       bool   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	ErrorMsg string `protobuf:"bytes,2,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
+}
+
+func (x *RemoveCachedObjectResponse) Reset() {
+	*x = RemoveCachedObjectResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker_repo_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveCachedObjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveCachedObjectResponse) ProtoMessage() {}
+
+func (x *RemoveCachedObjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_worker_repo_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveCachedObjectResponse.ProtoReflect.Descriptor instead.
+func (*RemoveCachedObjectResponse) Descriptor() ([]byte, []int) {
+	return file_worker_repo_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *RemoveCachedObjectResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *RemoveCachedObjectResponse) GetErrorMsg() string {
+	if x != nil {
+		return x.ErrorMsg
+	}
+	return ""
+}
+
+type PopCachedObjectInvalidationsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	WorkerId string `protobuf:"bytes,1,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+}
+
+func (x *PopCachedObjectInvalidationsRequest) Reset() {
+	*x = PopCachedObjectInvalidationsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker_repo_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PopCachedObjectInvalidationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PopCachedObjectInvalidationsRequest) ProtoMessage() {}
+
+func (x *PopCachedObjectInvalidationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_worker_repo_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PopCachedObjectInvalidationsRequest.ProtoReflect.Descriptor instead.
+func (*PopCachedObjectInvalidationsRequest) Descriptor() ([]byte, []int) {
+	return file_worker_repo_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *PopCachedObjectInvalidationsRequest) GetWorkerId() string {
+	if x != nil {
+		return x.WorkerId
+	}
+	return ""
+}
+
+type PopCachedObjectInvalidationsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok       bool     `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Digests  []string `protobuf:"bytes,2,rep,name=digests,proto3" json:"digests,omitempty"`
+	ErrorMsg string   `protobuf:"bytes,3,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
+}
+
+func (x *PopCachedObjectInvalidationsResponse) Reset() {
+	*x = PopCachedObjectInvalidationsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker_repo_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PopCachedObjectInvalidationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PopCachedObjectInvalidationsResponse) ProtoMessage() {}
+
+func (x *PopCachedObjectInvalidationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_worker_repo_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PopCachedObjectInvalidationsResponse.ProtoReflect.Descriptor instead.
+func (*PopCachedObjectInvalidationsResponse) Descriptor() ([]byte, []int) {
+	return file_worker_repo_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *PopCachedObjectInvalidationsResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *PopCachedObjectInvalidationsResponse) GetDigests() []string {
+	if x != nil {
+		return x.Digests
+	}
+	return nil
+}
+
+func (x *PopCachedObjectInvalidationsResponse) GetErrorMsg() string {
+	if x != nil {
+		return x.ErrorMsg
+	}
+	return ""
+}
+
+type IssueWorkerCertificateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	WorkerId string `protobuf:"bytes,1,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+	CsrPem   []byte `protobuf:"bytes,2,opt,name=csr_pem,json=csrPem,proto3" json:"csr_pem,omitempty"`
+}
+
+func (x *IssueWorkerCertificateRequest) Reset() {
+	*x = IssueWorkerCertificateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker_repo_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IssueWorkerCertificateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueWorkerCertificateRequest) ProtoMessage() {}
+
+func (x *IssueWorkerCertificateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_worker_repo_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueWorkerCertificateRequest.ProtoReflect.Descriptor instead.
+func (*IssueWorkerCertificateRequest) Descriptor() ([]byte, []int) {
+	return file_worker_repo_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *IssueWorkerCertificateRequest) GetWorkerId() string {
+	if x != nil {
+		return x.WorkerId
+	}
+	return ""
+}
+
+func (x *IssueWorkerCertificateRequest) GetCsrPem() []byte {
+	if x != nil {
+		return x.CsrPem
+	}
+	return nil
+}
+
+type IssueWorkerCertificateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok             bool   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	ErrorMsg       string `protobuf:"bytes,2,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
+	CertificatePem []byte `protobuf:"bytes,3,opt,name=certificate_pem,json=certificatePem,proto3" json:"certificate_pem,omitempty"`
+	CaPem          []byte `protobuf:"bytes,4,opt,name=ca_pem,json=caPem,proto3" json:"ca_pem,omitempty"`
+	ExpiresAt      int64  `protobuf:"varint,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+}
+
+func (x *IssueWorkerCertificateResponse) Reset() {
+	*x = IssueWorkerCertificateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker_repo_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IssueWorkerCertificateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueWorkerCertificateResponse) ProtoMessage() {}
+
+func (x *IssueWorkerCertificateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_worker_repo_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueWorkerCertificateResponse.ProtoReflect.Descriptor instead.
+func (*IssueWorkerCertificateResponse) Descriptor() ([]byte, []int) {
+	return file_worker_repo_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *IssueWorkerCertificateResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *IssueWorkerCertificateResponse) GetErrorMsg() string {
+	if x != nil {
+		return x.ErrorMsg
+	}
+	return ""
+}
+
+func (x *IssueWorkerCertificateResponse) GetCertificatePem() []byte {
+	if x != nil {
+		return x.CertificatePem
+	}
+	return nil
+}
+
+func (x *IssueWorkerCertificateResponse) GetCaPem() []byte {
+	if x != nil {
+		return x.CaPem
+	}
+	return nil
 }
 
-func (x *RemoveContainerIpResponse) GetErrorMsg() string {
+func (x *IssueWorkerCertificateResponse) GetExpiresAt() int64 {
 	if x != nil {
-		return x.ErrorMsg
+		return x.ExpiresAt
 	}
-	return ""
+	return 0
 }
 
 var File_worker_repo_proto protoreflect.FileDescriptor
@@ -1934,107 +2948,205 @@ var file_worker_repo_proto_rawDesc = []byte{
 	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
 	0x52, 0x02, 0x6f, 0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x73,
 	0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x73,
-	0x67, 0x22, 0x32, 0x0a, 0x13, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x57, 0x6f, 0x72, 0x6b, 0x65,
-	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x77, 0x6f, 0x72, 0x6b,
-	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x77, 0x6f, 0x72,
-	0x6b, 0x65, 0x72, 0x49, 0x64, 0x22, 0x43, 0x0a, 0x14, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x57,
-	0x6f, 0x72, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a,
+	0x67, 0x22, 0x50, 0x0a, 0x19, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x57, 0x6f, 0x72, 0x6b, 0x65,
+	0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b,
+	0x0a, 0x09, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x22, 0x49, 0x0a, 0x1a, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x57, 0x6f, 0x72,
+	0x6b, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f,
+	0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x73, 0x67, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x73, 0x67, 0x22, 0x69,
+	0x0a, 0x1f, 0x53, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x47, 0x70, 0x75, 0x49, 0x6e,
+	0x74, 0x65, 0x72, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1b, 0x0a, 0x09, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x49, 0x64, 0x12, 0x29,
+	0x0a, 0x10, 0x67, 0x70, 0x75, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x67, 0x70, 0x75, 0x49, 0x6e, 0x74,
+	0x65, 0x72, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x22, 0x4f, 0x0a, 0x20, 0x53, 0x65, 0x74,
+	0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x47, 0x70, 0x75, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x63, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a,
 	0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x1b, 0x0a,
 	0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x08, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x73, 0x67, 0x22, 0xa9, 0x01, 0x0a, 0x1b, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x43, 0x61, 0x70, 0x61, 0x63,
-	0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x77, 0x6f,
-	0x72, 0x6b, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x77,
-	0x6f, 0x72, 0x6b, 0x65, 0x72, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x61, 0x70, 0x61, 0x63,
-	0x69, 0x74, 0x79, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x0e, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
-	0x12, 0x44, 0x0a, 0x11, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x72, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x79,
-	0x70, 0x65, 0x73, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x52, 0x10, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x4b, 0x0a, 0x1c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f,
-	0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x72, 0x72, 0x6f, 0x72,
-	0x4d, 0x73, 0x67, 0x22, 0x38, 0x0a, 0x19, 0x53, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72,
-	0x4b, 0x65, 0x65, 0x70, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x1b, 0x0a, 0x09, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x49, 0x64, 0x22, 0x49, 0x0a,
-	0x1a, 0x53, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x4b, 0x65, 0x65, 0x70, 0x41, 0x6c,
-	0x69, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f,
-	0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x65,
-	0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
-	0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x73, 0x67, 0x22, 0x6a, 0x0a, 0x15, 0x53, 0x65, 0x74, 0x4e,
-	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x25, 0x0a, 0x0e, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x70, 0x72, 0x65,
-	0x66, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x74, 0x77, 0x6f,
-	0x72, 0x6b, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x74, 0x6c, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x74, 0x74, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65,
-	0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x72, 0x65, 0x74,
-	0x72, 0x69, 0x65, 0x73, 0x22, 0x5b, 0x0a, 0x16, 0x53, 0x65, 0x74, 0x4e, 0x65, 0x74, 0x77, 0x6f,
-	0x72, 0x6b, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e,
-	0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x14,
-	0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74,
-	0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x73,
-	0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x73,
-	0x67, 0x22, 0x57, 0x0a, 0x18, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4e, 0x65, 0x74, 0x77, 0x6f,
-	0x72, 0x6b, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a,
-	0x0e, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x50, 0x72,
-	0x65, 0x66, 0x69, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x48, 0x0a, 0x19, 0x52, 0x65,
-	0x6d, 0x6f, 0x76, 0x65, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x4c, 0x6f, 0x63, 0x6b, 0x52,
+	0x52, 0x08, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x73, 0x67, 0x22, 0x56, 0x0a, 0x1d, 0x53, 0x65,
+	0x74, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x41, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x77,
+	0x6f, 0x72, 0x6b, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x22, 0x4d, 0x0a, 0x1e, 0x53, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x02, 0x6f, 0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x73,
+	0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x73,
+	0x67, 0x22, 0x47, 0x0a, 0x14, 0x53, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x41, 0x72,
+	0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x77, 0x6f, 0x72,
+	0x6b, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x77, 0x6f,
+	0x72, 0x6b, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x63, 0x68, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x63, 0x68, 0x22, 0x44, 0x0a, 0x15, 0x53, 0x65,
+	0x74, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x41, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x02, 0x6f, 0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x73, 0x67,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x73, 0x67,
+	0x22, 0x57, 0x0a, 0x19, 0x53, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x4d, 0x69, 0x67,
+	0x53, 0x6c, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a,
+	0x09, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x69,
+	0x67, 0x5f, 0x73, 0x6c, 0x69, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x6d, 0x69, 0x67, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x73, 0x22, 0x49, 0x0a, 0x1a, 0x53, 0x65, 0x74,
+	0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x4d, 0x69, 0x67, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x73, 0x52,
 	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72,
 	0x5f, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x72, 0x72, 0x6f,
-	0x72, 0x4d, 0x73, 0x67, 0x22, 0x80, 0x01, 0x0a, 0x15, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74,
-	0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25,
-	0x0a, 0x0e, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x50,
-	0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
-	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e,
-	0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x70, 0x5f, 0x61,
-	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x70,
-	0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x45, 0x0a, 0x16, 0x53, 0x65, 0x74, 0x43, 0x6f,
-	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x72, 0x4d, 0x73, 0x67, 0x22, 0x32, 0x0a, 0x13, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x57, 0x6f,
+	0x72, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x77,
+	0x6f, 0x72, 0x6b, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x49, 0x64, 0x22, 0x43, 0x0a, 0x14, 0x52, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b,
+	0x12, 0x1b, 0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x73, 0x67, 0x22, 0xa9, 0x01,
+	0x0a, 0x1b, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x43, 0x61,
+	0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a,
+	0x09, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x61,
+	0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0e, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x43, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x12, 0x44, 0x0a, 0x11, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72,
+	0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x10, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
+	0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x4b, 0x0a, 0x1c, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74,
+	0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x5f, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x4d, 0x73, 0x67, 0x22, 0x38, 0x0a, 0x19, 0x53, 0x65, 0x74, 0x57, 0x6f, 0x72,
+	0x6b, 0x65, 0x72, 0x4b, 0x65, 0x65, 0x70, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x49, 0x64,
+	0x22, 0x49, 0x0a, 0x1a, 0x53, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x4b, 0x65, 0x65,
+	0x70, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e,
+	0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x1b,
+	0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x73, 0x67, 0x22, 0x6a, 0x0a, 0x15, 0x53,
+	0x65, 0x74, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f,
+	0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x10, 0x0a, 0x03, 0x74,
+	0x74, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x74, 0x74, 0x6c, 0x12, 0x18, 0x0a,
+	0x07, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07,
+	0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0x5b, 0x0a, 0x16, 0x53, 0x65, 0x74, 0x4e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
 	0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f,
-	0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x73, 0x67, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x73, 0x67, 0x22, 0x61,
-	0x0a, 0x15, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x70,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x6e, 0x65, 0x74, 0x77, 0x6f,
-	0x72, 0x6b, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0d, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x21,
-	0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49,
-	0x64, 0x22, 0x64, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65,
-	0x72, 0x49, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f,
-	0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x1d, 0x0a, 0x0a, 0x69,
-	0x70, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x09, 0x69, 0x70, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x72,
-	0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x73, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65,
-	0x72, 0x72, 0x6f, 0x72, 0x4d, 0x73, 0x67, 0x22, 0x3f, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x43, 0x6f,
-	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x6b, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x5f, 0x6d, 0x73, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x4d, 0x73, 0x67, 0x22, 0x57, 0x0a, 0x18, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x25, 0x0a, 0x0e, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x70, 0x72, 0x65, 0x66,
+	0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72,
+	0x6b, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x48, 0x0a,
+	0x19, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x4c, 0x6f,
+	0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x4d, 0x73, 0x67, 0x22, 0x80, 0x01, 0x0a, 0x15, 0x53, 0x65, 0x74, 0x43,
+	0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
 	0x74, 0x12, 0x25, 0x0a, 0x0e, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x70, 0x72, 0x65,
 	0x66, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x74, 0x77, 0x6f,
-	0x72, 0x6b, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x22, 0x58, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x43,
-	0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x02, 0x6f, 0x6b, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x70, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09,
-	0x52, 0x03, 0x69, 0x70, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d,
-	0x73, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d,
-	0x73, 0x67, 0x22, 0x64, 0x0a, 0x18, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x43, 0x6f, 0x6e, 0x74,
-	0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25,
-	0x0a, 0x0e, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x50,
-	0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
-	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e,
-	0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x22, 0x48, 0x0a, 0x19, 0x52, 0x65, 0x6d, 0x6f,
-	0x76, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x70, 0x52, 0x65, 0x73,
+	0x72, 0x6b, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74,
+	0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x69,
+	0x70, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x69, 0x70, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x45, 0x0a, 0x16, 0x53, 0x65,
+	0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x70, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x02, 0x6f, 0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x73,
+	0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x73,
+	0x67, 0x22, 0x61, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65,
+	0x72, 0x49, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x6e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x50, 0x72, 0x65, 0x66, 0x69,
+	0x78, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
+	0x65, 0x72, 0x49, 0x64, 0x22, 0x64, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x72, 0x49, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e,
+	0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x1d,
+	0x0a, 0x0a, 0x69, 0x70, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x69, 0x70, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1b, 0x0a,
+	0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x73, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x73, 0x67, 0x22, 0x3f, 0x0a, 0x16, 0x47, 0x65,
+	0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x70, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f,
+	0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x22, 0x58, 0x0a, 0x17, 0x47,
+	0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x70, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x70, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x03, 0x69, 0x70, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x5f, 0x6d, 0x73, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x4d, 0x73, 0x67, 0x22, 0x64, 0x0a, 0x18, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x43,
+	0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x25, 0x0a, 0x0e, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x70, 0x72, 0x65,
+	0x66, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74,
+	0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x22, 0x48, 0x0a, 0x19, 0x52,
+	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x70,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x5f, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x4d, 0x73, 0x67, 0x22, 0x4d, 0x0a, 0x16, 0x41, 0x64, 0x64, 0x43, 0x61, 0x63, 0x68,
+	0x65, 0x64, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1b, 0x0a, 0x09, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06,
+	0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x22, 0x46, 0x0a, 0x17, 0x41, 0x64, 0x64, 0x43, 0x61, 0x63, 0x68, 0x65,
+	0x64, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12,
+	0x1b, 0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x73, 0x67, 0x22, 0x50, 0x0a, 0x19,
+	0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x4f, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x77, 0x6f, 0x72,
+	0x6b, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x77, 0x6f,
+	0x72, 0x6b, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x49,
+	0x0a, 0x1a, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x4f, 0x62,
+	0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02,
+	0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x1b, 0x0a, 0x09,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x73, 0x67, 0x22, 0x42, 0x0a, 0x23, 0x50, 0x6f, 0x70,
+	0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x6e, 0x76, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1b, 0x0a, 0x09, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x49, 0x64, 0x22, 0x6d, 0x0a,
+	0x24, 0x50, 0x6f, 0x70, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73,
 	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d,
-	0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d,
-	0x73, 0x67, 0x32, 0x8d, 0x0a, 0x0a, 0x17, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x70,
+	0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x12,
+	0x1b, 0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x73, 0x67, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x73, 0x67, 0x22, 0x55, 0x0a, 0x1d,
+	0x49, 0x73, 0x73, 0x75, 0x65, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x43, 0x65, 0x72, 0x74, 0x69,
+	0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a,
+	0x09, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x73,
+	0x72, 0x5f, 0x70, 0x65, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x63, 0x73, 0x72,
+	0x50, 0x65, 0x6d, 0x22, 0xac, 0x01, 0x0a, 0x1e, 0x49, 0x73, 0x73, 0x75, 0x65, 0x57, 0x6f, 0x72,
+	0x6b, 0x65, 0x72, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f,
+	0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x4d, 0x73, 0x67, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61,
+	0x74, 0x65, 0x5f, 0x70, 0x65, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x63, 0x65,
+	0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x50, 0x65, 0x6d, 0x12, 0x15, 0x0a, 0x06,
+	0x63, 0x61, 0x5f, 0x70, 0x65, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x63, 0x61,
+	0x50, 0x65, 0x6d, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73,
+	0x41, 0x74, 0x32, 0xb9, 0x0f, 0x0a, 0x17, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x70,
 	0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x60,
 	0x0a, 0x17, 0x47, 0x65, 0x74, 0x4e, 0x65, 0x78, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
 	0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x2e, 0x47, 0x65, 0x74, 0x4e,
@@ -2073,51 +3185,102 @@ var file_worker_repo_proto_rawDesc = []byte{
 	0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x52,
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x54, 0x6f, 0x67, 0x67, 0x6c, 0x65, 0x57,
 	0x6f, 0x72, 0x6b, 0x65, 0x72, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3d, 0x0a, 0x0c, 0x52, 0x65, 0x6d, 0x6f,
-	0x76, 0x65, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x12, 0x14, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76,
-	0x65, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15,
-	0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x55, 0x0a, 0x14, 0x55, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x12,
-	0x1c, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x43, 0x61,
-	0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x43, 0x61, 0x70, 0x61,
-	0x63, 0x69, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4f,
-	0x0a, 0x12, 0x53, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x4b, 0x65, 0x65, 0x70, 0x41,
-	0x6c, 0x69, 0x76, 0x65, 0x12, 0x1a, 0x2e, 0x53, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72,
-	0x4b, 0x65, 0x65, 0x70, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1b, 0x2e, 0x53, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x4b, 0x65, 0x65, 0x70,
-	0x41, 0x6c, 0x69, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x43, 0x0a, 0x0e, 0x53, 0x65, 0x74, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x4c, 0x6f, 0x63,
-	0x6b, 0x12, 0x16, 0x2e, 0x53, 0x65, 0x74, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x4c, 0x6f,
-	0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x53, 0x65, 0x74, 0x4e,
-	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x00, 0x12, 0x4c, 0x0a, 0x11, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4e, 0x65,
-	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x4c, 0x6f, 0x63, 0x6b, 0x12, 0x19, 0x2e, 0x52, 0x65, 0x6d, 0x6f,
-	0x76, 0x65, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4e, 0x65, 0x74,
-	0x77, 0x6f, 0x72, 0x6b, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x00, 0x12, 0x43, 0x0a, 0x0e, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
-	0x65, 0x72, 0x49, 0x70, 0x12, 0x16, 0x2e, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69,
-	0x6e, 0x65, 0x72, 0x49, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x53,
-	0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x70, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x43, 0x6f,
-	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x70, 0x12, 0x16, 0x2e, 0x47, 0x65, 0x74, 0x43,
-	0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x17, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72,
-	0x49, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x46, 0x0a, 0x0f,
-	0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x70, 0x73, 0x12,
-	0x17, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x70,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f,
-	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x00, 0x12, 0x4c, 0x0a, 0x11, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x43, 0x6f,
-	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x70, 0x12, 0x19, 0x2e, 0x52, 0x65, 0x6d, 0x6f,
-	0x76, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x70, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x43, 0x6f, 0x6e,
-	0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x00, 0x42, 0x23, 0x5a, 0x21, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2f, 0x62, 0x65, 0x61, 0x6d, 0x2d, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2f, 0x62, 0x65, 0x74, 0x61,
-	0x39, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4f, 0x0a, 0x12, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1a,
+	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x61, 0x0a, 0x18, 0x53, 0x65, 0x74,
+	0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x47, 0x70, 0x75, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x63, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12, 0x20, 0x2e, 0x53, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x65,
+	0x72, 0x47, 0x70, 0x75, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x53, 0x65, 0x74, 0x57, 0x6f, 0x72,
+	0x6b, 0x65, 0x72, 0x47, 0x70, 0x75, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5b, 0x0a, 0x16,
+	0x53, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x41,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1e, 0x2e, 0x53, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b,
+	0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x53, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b,
+	0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x0d, 0x53, 0x65, 0x74,
+	0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x41, 0x72, 0x63, 0x68, 0x12, 0x15, 0x2e, 0x53, 0x65, 0x74,
+	0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x41, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x16, 0x2e, 0x53, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x41, 0x72, 0x63,
+	0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4f, 0x0a, 0x12, 0x53,
+	0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x4d, 0x69, 0x67, 0x53, 0x6c, 0x69, 0x63, 0x65,
+	0x73, 0x12, 0x1a, 0x2e, 0x53, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x4d, 0x69, 0x67,
+	0x53, 0x6c, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e,
+	0x53, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x4d, 0x69, 0x67, 0x53, 0x6c, 0x69, 0x63,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3d, 0x0a, 0x0c,
+	0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x12, 0x14, 0x2e, 0x52,
+	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x15, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x57, 0x6f, 0x72, 0x6b, 0x65,
+	0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x55, 0x0a, 0x14, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x43, 0x61, 0x70, 0x61, 0x63,
+	0x69, 0x74, 0x79, 0x12, 0x1c, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x57, 0x6f, 0x72, 0x6b,
+	0x65, 0x72, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1d, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72,
+	0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x4f, 0x0a, 0x12, 0x53, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x4b,
+	0x65, 0x65, 0x70, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x12, 0x1a, 0x2e, 0x53, 0x65, 0x74, 0x57, 0x6f,
+	0x72, 0x6b, 0x65, 0x72, 0x4b, 0x65, 0x65, 0x70, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x53, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72,
+	0x4b, 0x65, 0x65, 0x70, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0e, 0x53, 0x65, 0x74, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72,
+	0x6b, 0x4c, 0x6f, 0x63, 0x6b, 0x12, 0x16, 0x2e, 0x53, 0x65, 0x74, 0x4e, 0x65, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e,
+	0x53, 0x65, 0x74, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4c, 0x0a, 0x11, 0x52, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x4c, 0x6f, 0x63, 0x6b, 0x12, 0x19, 0x2e,
+	0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x4c, 0x6f, 0x63,
+	0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0e, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6e,
+	0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x70, 0x12, 0x16, 0x2e, 0x53, 0x65, 0x74, 0x43, 0x6f,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x17, 0x2e, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49,
+	0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0e, 0x47,
+	0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x70, 0x12, 0x16, 0x2e,
+	0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x70, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x72, 0x49, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x46, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72,
+	0x49, 0x70, 0x73, 0x12, 0x17, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
+	0x65, 0x72, 0x49, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x47,
+	0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x70, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4c, 0x0a, 0x11, 0x52, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x70, 0x12, 0x19, 0x2e,
+	0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49,
+	0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x70, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x46, 0x0a, 0x0f, 0x41, 0x64, 0x64, 0x43, 0x61, 0x63,
+	0x68, 0x65, 0x64, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x17, 0x2e, 0x41, 0x64, 0x64, 0x43,
+	0x61, 0x63, 0x68, 0x65, 0x64, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x18, 0x2e, 0x41, 0x64, 0x64, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x4f, 0x62,
+	0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4f,
+	0x0a, 0x12, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x4f, 0x62,
+	0x6a, 0x65, 0x63, 0x74, 0x12, 0x1a, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x43, 0x61, 0x63,
+	0x68, 0x65, 0x64, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1b, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x4f,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x6d, 0x0a, 0x1c, 0x50, 0x6f, 0x70, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x4f, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x24, 0x2e, 0x50, 0x6f, 0x70, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x4f, 0x62, 0x6a, 0x65, 0x63,
+	0x74, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x50, 0x6f, 0x70, 0x43, 0x61, 0x63, 0x68, 0x65,
+	0x64, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0x77,
+	0x0a, 0x18, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63,
+	0x61, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x5b, 0x0a, 0x16, 0x49, 0x73,
+	0x73, 0x75, 0x65, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69,
+	0x63, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x2e, 0x49, 0x73, 0x73, 0x75, 0x65, 0x57, 0x6f, 0x72, 0x6b,
+	0x65, 0x72, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x49, 0x73, 0x73, 0x75, 0x65, 0x57, 0x6f, 0x72, 0x6b,
+	0x65, 0x72, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x23, 0x5a, 0x21, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x65, 0x61, 0x6d, 0x2d, 0x63, 0x6c, 0x6f, 0x75, 0x64,
+	0x2f, 0x62, 0x65, 0x74, 0x61, 0x39, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -2132,47 +3295,65 @@ func file_worker_repo_proto_rawDescGZIP() []byte {
 	return file_worker_repo_proto_rawDescData
 }
 
-var file_worker_repo_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
+var file_worker_repo_proto_msgTypes = make([]protoimpl.MessageInfo, 50)
 var file_worker_repo_proto_goTypes = []interface{}{
-	(*GetNextContainerRequestRequest)(nil),    // 0: GetNextContainerRequestRequest
-	(*GetNextContainerRequestResponse)(nil),   // 1: GetNextContainerRequestResponse
-	(*SetImagePullLockRequest)(nil),           // 2: SetImagePullLockRequest
-	(*SetImagePullLockResponse)(nil),          // 3: SetImagePullLockResponse
-	(*RemoveImagePullLockRequest)(nil),        // 4: RemoveImagePullLockRequest
-	(*RemoveImagePullLockResponse)(nil),       // 5: RemoveImagePullLockResponse
-	(*AddContainerToWorkerRequest)(nil),       // 6: AddContainerToWorkerRequest
-	(*AddContainerToWorkerResponse)(nil),      // 7: AddContainerToWorkerResponse
-	(*RemoveContainerFromWorkerRequest)(nil),  // 8: RemoveContainerFromWorkerRequest
-	(*RemoveContainerFromWorkerResponse)(nil), // 9: RemoveContainerFromWorkerResponse
-	(*GetWorkerByIdRequest)(nil),              // 10: GetWorkerByIdRequest
-	(*GetWorkerByIdResponse)(nil),             // 11: GetWorkerByIdResponse
-	(*ToggleWorkerAvailableRequest)(nil),      // 12: ToggleWorkerAvailableRequest
-	(*ToggleWorkerAvailableResponse)(nil),     // 13: ToggleWorkerAvailableResponse
-	(*RemoveWorkerRequest)(nil),               // 14: RemoveWorkerRequest
-	(*RemoveWorkerResponse)(nil),              // 15: RemoveWorkerResponse
-	(*UpdateWorkerCapacityRequest)(nil),       // 16: UpdateWorkerCapacityRequest
-	(*UpdateWorkerCapacityResponse)(nil),      // 17: UpdateWorkerCapacityResponse
-	(*SetWorkerKeepAliveRequest)(nil),         // 18: SetWorkerKeepAliveRequest
-	(*SetWorkerKeepAliveResponse)(nil),        // 19: SetWorkerKeepAliveResponse
-	(*SetNetworkLockRequest)(nil),             // 20: SetNetworkLockRequest
-	(*SetNetworkLockResponse)(nil),            // 21: SetNetworkLockResponse
-	(*RemoveNetworkLockRequest)(nil),          // 22: RemoveNetworkLockRequest
-	(*RemoveNetworkLockResponse)(nil),         // 23: RemoveNetworkLockResponse
-	(*SetContainerIpRequest)(nil),             // 24: SetContainerIpRequest
-	(*SetContainerIpResponse)(nil),            // 25: SetContainerIpResponse
-	(*GetContainerIpRequest)(nil),             // 26: GetContainerIpRequest
-	(*GetContainerIpResponse)(nil),            // 27: GetContainerIpResponse
-	(*GetContainerIpsRequest)(nil),            // 28: GetContainerIpsRequest
-	(*GetContainerIpsResponse)(nil),           // 29: GetContainerIpsResponse
-	(*RemoveContainerIpRequest)(nil),          // 30: RemoveContainerIpRequest
-	(*RemoveContainerIpResponse)(nil),         // 31: RemoveContainerIpResponse
-	(*ContainerRequest)(nil),                  // 32: types.ContainerRequest
-	(*Worker)(nil),                            // 33: types.Worker
+	(*GetNextContainerRequestRequest)(nil),       // 0: GetNextContainerRequestRequest
+	(*GetNextContainerRequestResponse)(nil),      // 1: GetNextContainerRequestResponse
+	(*SetImagePullLockRequest)(nil),              // 2: SetImagePullLockRequest
+	(*SetImagePullLockResponse)(nil),             // 3: SetImagePullLockResponse
+	(*RemoveImagePullLockRequest)(nil),           // 4: RemoveImagePullLockRequest
+	(*RemoveImagePullLockResponse)(nil),          // 5: RemoveImagePullLockResponse
+	(*AddContainerToWorkerRequest)(nil),          // 6: AddContainerToWorkerRequest
+	(*AddContainerToWorkerResponse)(nil),         // 7: AddContainerToWorkerResponse
+	(*RemoveContainerFromWorkerRequest)(nil),     // 8: RemoveContainerFromWorkerRequest
+	(*RemoveContainerFromWorkerResponse)(nil),    // 9: RemoveContainerFromWorkerResponse
+	(*GetWorkerByIdRequest)(nil),                 // 10: GetWorkerByIdRequest
+	(*GetWorkerByIdResponse)(nil),                // 11: GetWorkerByIdResponse
+	(*ToggleWorkerAvailableRequest)(nil),         // 12: ToggleWorkerAvailableRequest
+	(*ToggleWorkerAvailableResponse)(nil),        // 13: ToggleWorkerAvailableResponse
+	(*UpdateWorkerStatusRequest)(nil),            // 14: UpdateWorkerStatusRequest
+	(*UpdateWorkerStatusResponse)(nil),           // 15: UpdateWorkerStatusResponse
+	(*SetWorkerGpuInterconnectRequest)(nil),      // 16: SetWorkerGpuInterconnectRequest
+	(*SetWorkerGpuInterconnectResponse)(nil),     // 17: SetWorkerGpuInterconnectResponse
+	(*SetWorkerServerAddressRequest)(nil),        // 18: SetWorkerServerAddressRequest
+	(*SetWorkerServerAddressResponse)(nil),       // 19: SetWorkerServerAddressResponse
+	(*SetWorkerArchRequest)(nil),                 // 20: SetWorkerArchRequest
+	(*SetWorkerArchResponse)(nil),                // 21: SetWorkerArchResponse
+	(*SetWorkerMigSlicesRequest)(nil),            // 22: SetWorkerMigSlicesRequest
+	(*SetWorkerMigSlicesResponse)(nil),           // 23: SetWorkerMigSlicesResponse
+	(*RemoveWorkerRequest)(nil),                  // 24: RemoveWorkerRequest
+	(*RemoveWorkerResponse)(nil),                 // 25: RemoveWorkerResponse
+	(*UpdateWorkerCapacityRequest)(nil),          // 26: UpdateWorkerCapacityRequest
+	(*UpdateWorkerCapacityResponse)(nil),         // 27: UpdateWorkerCapacityResponse
+	(*SetWorkerKeepAliveRequest)(nil),            // 28: SetWorkerKeepAliveRequest
+	(*SetWorkerKeepAliveResponse)(nil),           // 29: SetWorkerKeepAliveResponse
+	(*SetNetworkLockRequest)(nil),                // 30: SetNetworkLockRequest
+	(*SetNetworkLockResponse)(nil),               // 31: SetNetworkLockResponse
+	(*RemoveNetworkLockRequest)(nil),             // 32: RemoveNetworkLockRequest
+	(*RemoveNetworkLockResponse)(nil),            // 33: RemoveNetworkLockResponse
+	(*SetContainerIpRequest)(nil),                // 34: SetContainerIpRequest
+	(*SetContainerIpResponse)(nil),               // 35: SetContainerIpResponse
+	(*GetContainerIpRequest)(nil),                // 36: GetContainerIpRequest
+	(*GetContainerIpResponse)(nil),               // 37: GetContainerIpResponse
+	(*GetContainerIpsRequest)(nil),               // 38: GetContainerIpsRequest
+	(*GetContainerIpsResponse)(nil),              // 39: GetContainerIpsResponse
+	(*RemoveContainerIpRequest)(nil),             // 40: RemoveContainerIpRequest
+	(*RemoveContainerIpResponse)(nil),            // 41: RemoveContainerIpResponse
+	(*AddCachedObjectRequest)(nil),               // 42: AddCachedObjectRequest
+	(*AddCachedObjectResponse)(nil),              // 43: AddCachedObjectResponse
+	(*RemoveCachedObjectRequest)(nil),            // 44: RemoveCachedObjectRequest
+	(*RemoveCachedObjectResponse)(nil),           // 45: RemoveCachedObjectResponse
+	(*PopCachedObjectInvalidationsRequest)(nil),  // 46: PopCachedObjectInvalidationsRequest
+	(*PopCachedObjectInvalidationsResponse)(nil), // 47: PopCachedObjectInvalidationsResponse
+	(*IssueWorkerCertificateRequest)(nil),        // 48: IssueWorkerCertificateRequest
+	(*IssueWorkerCertificateResponse)(nil),       // 49: IssueWorkerCertificateResponse
+	(*ContainerRequest)(nil),                     // 50: types.ContainerRequest
+	(*Worker)(nil),                               // 51: types.Worker
 }
 var file_worker_repo_proto_depIdxs = []int32{
-	32, // 0: GetNextContainerRequestResponse.container_request:type_name -> types.ContainerRequest
-	33, // 1: GetWorkerByIdResponse.worker:type_name -> types.Worker
-	32, // 2: UpdateWorkerCapacityRequest.container_request:type_name -> types.ContainerRequest
+	50, // 0: GetNextContainerRequestResponse.container_request:type_name -> types.ContainerRequest
+	51, // 1: GetWorkerByIdResponse.worker:type_name -> types.Worker
+	50, // 2: UpdateWorkerCapacityRequest.container_request:type_name -> types.ContainerRequest
 	0,  // 3: WorkerRepositoryService.GetNextContainerRequest:input_type -> GetNextContainerRequestRequest
 	2,  // 4: WorkerRepositoryService.SetImagePullLock:input_type -> SetImagePullLockRequest
 	4,  // 5: WorkerRepositoryService.RemoveImagePullLock:input_type -> RemoveImagePullLockRequest
@@ -2180,33 +3361,51 @@ var file_worker_repo_proto_depIdxs = []int32{
 	8,  // 7: WorkerRepositoryService.RemoveContainerFromWorker:input_type -> RemoveContainerFromWorkerRequest
 	10, // 8: WorkerRepositoryService.GetWorkerById:input_type -> GetWorkerByIdRequest
 	12, // 9: WorkerRepositoryService.ToggleWorkerAvailable:input_type -> ToggleWorkerAvailableRequest
-	14, // 10: WorkerRepositoryService.RemoveWorker:input_type -> RemoveWorkerRequest
-	16, // 11: WorkerRepositoryService.UpdateWorkerCapacity:input_type -> UpdateWorkerCapacityRequest
-	18, // 12: WorkerRepositoryService.SetWorkerKeepAlive:input_type -> SetWorkerKeepAliveRequest
-	20, // 13: WorkerRepositoryService.SetNetworkLock:input_type -> SetNetworkLockRequest
-	22, // 14: WorkerRepositoryService.RemoveNetworkLock:input_type -> RemoveNetworkLockRequest
-	24, // 15: WorkerRepositoryService.SetContainerIp:input_type -> SetContainerIpRequest
-	26, // 16: WorkerRepositoryService.GetContainerIp:input_type -> GetContainerIpRequest
-	28, // 17: WorkerRepositoryService.GetContainerIps:input_type -> GetContainerIpsRequest
-	30, // 18: WorkerRepositoryService.RemoveContainerIp:input_type -> RemoveContainerIpRequest
-	1,  // 19: WorkerRepositoryService.GetNextContainerRequest:output_type -> GetNextContainerRequestResponse
-	3,  // 20: WorkerRepositoryService.SetImagePullLock:output_type -> SetImagePullLockResponse
-	5,  // 21: WorkerRepositoryService.RemoveImagePullLock:output_type -> RemoveImagePullLockResponse
-	7,  // 22: WorkerRepositoryService.AddContainerToWorker:output_type -> AddContainerToWorkerResponse
-	9,  // 23: WorkerRepositoryService.RemoveContainerFromWorker:output_type -> RemoveContainerFromWorkerResponse
-	11, // 24: WorkerRepositoryService.GetWorkerById:output_type -> GetWorkerByIdResponse
-	13, // 25: WorkerRepositoryService.ToggleWorkerAvailable:output_type -> ToggleWorkerAvailableResponse
-	15, // 26: WorkerRepositoryService.RemoveWorker:output_type -> RemoveWorkerResponse
-	17, // 27: WorkerRepositoryService.UpdateWorkerCapacity:output_type -> UpdateWorkerCapacityResponse
-	19, // 28: WorkerRepositoryService.SetWorkerKeepAlive:output_type -> SetWorkerKeepAliveResponse
-	21, // 29: WorkerRepositoryService.SetNetworkLock:output_type -> SetNetworkLockResponse
-	23, // 30: WorkerRepositoryService.RemoveNetworkLock:output_type -> RemoveNetworkLockResponse
-	25, // 31: WorkerRepositoryService.SetContainerIp:output_type -> SetContainerIpResponse
-	27, // 32: WorkerRepositoryService.GetContainerIp:output_type -> GetContainerIpResponse
-	29, // 33: WorkerRepositoryService.GetContainerIps:output_type -> GetContainerIpsResponse
-	31, // 34: WorkerRepositoryService.RemoveContainerIp:output_type -> RemoveContainerIpResponse
-	19, // [19:35] is the sub-list for method output_type
-	3,  // [3:19] is the sub-list for method input_type
+	14, // 10: WorkerRepositoryService.UpdateWorkerStatus:input_type -> UpdateWorkerStatusRequest
+	16, // 11: WorkerRepositoryService.SetWorkerGpuInterconnect:input_type -> SetWorkerGpuInterconnectRequest
+	18, // 12: WorkerRepositoryService.SetWorkerServerAddress:input_type -> SetWorkerServerAddressRequest
+	20, // 13: WorkerRepositoryService.SetWorkerArch:input_type -> SetWorkerArchRequest
+	22, // 14: WorkerRepositoryService.SetWorkerMigSlices:input_type -> SetWorkerMigSlicesRequest
+	24, // 15: WorkerRepositoryService.RemoveWorker:input_type -> RemoveWorkerRequest
+	26, // 16: WorkerRepositoryService.UpdateWorkerCapacity:input_type -> UpdateWorkerCapacityRequest
+	28, // 17: WorkerRepositoryService.SetWorkerKeepAlive:input_type -> SetWorkerKeepAliveRequest
+	30, // 18: WorkerRepositoryService.SetNetworkLock:input_type -> SetNetworkLockRequest
+	32, // 19: WorkerRepositoryService.RemoveNetworkLock:input_type -> RemoveNetworkLockRequest
+	34, // 20: WorkerRepositoryService.SetContainerIp:input_type -> SetContainerIpRequest
+	36, // 21: WorkerRepositoryService.GetContainerIp:input_type -> GetContainerIpRequest
+	38, // 22: WorkerRepositoryService.GetContainerIps:input_type -> GetContainerIpsRequest
+	40, // 23: WorkerRepositoryService.RemoveContainerIp:input_type -> RemoveContainerIpRequest
+	42, // 24: WorkerRepositoryService.AddCachedObject:input_type -> AddCachedObjectRequest
+	44, // 25: WorkerRepositoryService.RemoveCachedObject:input_type -> RemoveCachedObjectRequest
+	46, // 26: WorkerRepositoryService.PopCachedObjectInvalidations:input_type -> PopCachedObjectInvalidationsRequest
+	48, // 27: WorkerCertificateService.IssueWorkerCertificate:input_type -> IssueWorkerCertificateRequest
+	1,  // 28: WorkerRepositoryService.GetNextContainerRequest:output_type -> GetNextContainerRequestResponse
+	3,  // 29: WorkerRepositoryService.SetImagePullLock:output_type -> SetImagePullLockResponse
+	5,  // 30: WorkerRepositoryService.RemoveImagePullLock:output_type -> RemoveImagePullLockResponse
+	7,  // 31: WorkerRepositoryService.AddContainerToWorker:output_type -> AddContainerToWorkerResponse
+	9,  // 32: WorkerRepositoryService.RemoveContainerFromWorker:output_type -> RemoveContainerFromWorkerResponse
+	11, // 33: WorkerRepositoryService.GetWorkerById:output_type -> GetWorkerByIdResponse
+	13, // 34: WorkerRepositoryService.ToggleWorkerAvailable:output_type -> ToggleWorkerAvailableResponse
+	15, // 35: WorkerRepositoryService.UpdateWorkerStatus:output_type -> UpdateWorkerStatusResponse
+	17, // 36: WorkerRepositoryService.SetWorkerGpuInterconnect:output_type -> SetWorkerGpuInterconnectResponse
+	19, // 37: WorkerRepositoryService.SetWorkerServerAddress:output_type -> SetWorkerServerAddressResponse
+	21, // 38: WorkerRepositoryService.SetWorkerArch:output_type -> SetWorkerArchResponse
+	23, // 39: WorkerRepositoryService.SetWorkerMigSlices:output_type -> SetWorkerMigSlicesResponse
+	25, // 40: WorkerRepositoryService.RemoveWorker:output_type -> RemoveWorkerResponse
+	27, // 41: WorkerRepositoryService.UpdateWorkerCapacity:output_type -> UpdateWorkerCapacityResponse
+	29, // 42: WorkerRepositoryService.SetWorkerKeepAlive:output_type -> SetWorkerKeepAliveResponse
+	31, // 43: WorkerRepositoryService.SetNetworkLock:output_type -> SetNetworkLockResponse
+	33, // 44: WorkerRepositoryService.RemoveNetworkLock:output_type -> RemoveNetworkLockResponse
+	35, // 45: WorkerRepositoryService.SetContainerIp:output_type -> SetContainerIpResponse
+	37, // 46: WorkerRepositoryService.GetContainerIp:output_type -> GetContainerIpResponse
+	39, // 47: WorkerRepositoryService.GetContainerIps:output_type -> GetContainerIpsResponse
+	41, // 48: WorkerRepositoryService.RemoveContainerIp:output_type -> RemoveContainerIpResponse
+	43, // 49: WorkerRepositoryService.AddCachedObject:output_type -> AddCachedObjectResponse
+	45, // 50: WorkerRepositoryService.RemoveCachedObject:output_type -> RemoveCachedObjectResponse
+	47, // 51: WorkerRepositoryService.PopCachedObjectInvalidations:output_type -> PopCachedObjectInvalidationsResponse
+	49, // 52: WorkerCertificateService.IssueWorkerCertificate:output_type -> IssueWorkerCertificateResponse
+	28, // [28:53] is the sub-list for method output_type
+	3,  // [3:28] is the sub-list for method input_type
 	3,  // [3:3] is the sub-list for extension type_name
 	3,  // [3:3] is the sub-list for extension extendee
 	0,  // [0:3] is the sub-list for field type_name
@@ -2388,7 +3587,7 @@ func file_worker_repo_proto_init() {
 			}
 		}
 		file_worker_repo_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RemoveWorkerRequest); i {
+			switch v := v.(*UpdateWorkerStatusRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2400,7 +3599,7 @@ func file_worker_repo_proto_init() {
 			}
 		}
 		file_worker_repo_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RemoveWorkerResponse); i {
+			switch v := v.(*UpdateWorkerStatusResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2412,7 +3611,7 @@ func file_worker_repo_proto_init() {
 			}
 		}
 		file_worker_repo_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateWorkerCapacityRequest); i {
+			switch v := v.(*SetWorkerGpuInterconnectRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2424,7 +3623,7 @@ func file_worker_repo_proto_init() {
 			}
 		}
 		file_worker_repo_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateWorkerCapacityResponse); i {
+			switch v := v.(*SetWorkerGpuInterconnectResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2436,7 +3635,7 @@ func file_worker_repo_proto_init() {
 			}
 		}
 		file_worker_repo_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetWorkerKeepAliveRequest); i {
+			switch v := v.(*SetWorkerServerAddressRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2448,7 +3647,7 @@ func file_worker_repo_proto_init() {
 			}
 		}
 		file_worker_repo_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetWorkerKeepAliveResponse); i {
+			switch v := v.(*SetWorkerServerAddressResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2460,7 +3659,7 @@ func file_worker_repo_proto_init() {
 			}
 		}
 		file_worker_repo_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetNetworkLockRequest); i {
+			switch v := v.(*SetWorkerArchRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2472,7 +3671,7 @@ func file_worker_repo_proto_init() {
 			}
 		}
 		file_worker_repo_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetNetworkLockResponse); i {
+			switch v := v.(*SetWorkerArchResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2484,7 +3683,7 @@ func file_worker_repo_proto_init() {
 			}
 		}
 		file_worker_repo_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RemoveNetworkLockRequest); i {
+			switch v := v.(*SetWorkerMigSlicesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2496,7 +3695,7 @@ func file_worker_repo_proto_init() {
 			}
 		}
 		file_worker_repo_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RemoveNetworkLockResponse); i {
+			switch v := v.(*SetWorkerMigSlicesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2508,7 +3707,7 @@ func file_worker_repo_proto_init() {
 			}
 		}
 		file_worker_repo_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetContainerIpRequest); i {
+			switch v := v.(*RemoveWorkerRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2520,7 +3719,7 @@ func file_worker_repo_proto_init() {
 			}
 		}
 		file_worker_repo_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetContainerIpResponse); i {
+			switch v := v.(*RemoveWorkerResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2532,7 +3731,7 @@ func file_worker_repo_proto_init() {
 			}
 		}
 		file_worker_repo_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetContainerIpRequest); i {
+			switch v := v.(*UpdateWorkerCapacityRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2544,7 +3743,7 @@ func file_worker_repo_proto_init() {
 			}
 		}
 		file_worker_repo_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetContainerIpResponse); i {
+			switch v := v.(*UpdateWorkerCapacityResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2556,7 +3755,7 @@ func file_worker_repo_proto_init() {
 			}
 		}
 		file_worker_repo_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetContainerIpsRequest); i {
+			switch v := v.(*SetWorkerKeepAliveRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2568,7 +3767,7 @@ func file_worker_repo_proto_init() {
 			}
 		}
 		file_worker_repo_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetContainerIpsResponse); i {
+			switch v := v.(*SetWorkerKeepAliveResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2580,7 +3779,7 @@ func file_worker_repo_proto_init() {
 			}
 		}
 		file_worker_repo_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RemoveContainerIpRequest); i {
+			switch v := v.(*SetNetworkLockRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2592,6 +3791,126 @@ func file_worker_repo_proto_init() {
 			}
 		}
 		file_worker_repo_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetNetworkLockResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker_repo_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveNetworkLockRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker_repo_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveNetworkLockResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker_repo_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetContainerIpRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker_repo_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetContainerIpResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker_repo_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetContainerIpRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker_repo_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetContainerIpResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker_repo_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetContainerIpsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker_repo_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetContainerIpsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker_repo_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveContainerIpRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker_repo_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RemoveContainerIpResponse); i {
 			case 0:
 				return &v.state
@@ -2603,6 +3922,102 @@ func file_worker_repo_proto_init() {
 				return nil
 			}
 		}
+		file_worker_repo_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddCachedObjectRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker_repo_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddCachedObjectResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker_repo_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveCachedObjectRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker_repo_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveCachedObjectResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker_repo_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PopCachedObjectInvalidationsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker_repo_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PopCachedObjectInvalidationsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker_repo_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IssueWorkerCertificateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker_repo_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IssueWorkerCertificateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -2610,9 +4025,9 @@ func file_worker_repo_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_worker_repo_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   32,
+			NumMessages:   50,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_worker_repo_proto_goTypes,
 		DependencyIndexes: file_worker_repo_proto_depIdxs,
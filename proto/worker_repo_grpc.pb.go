@@ -19,22 +19,30 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	WorkerRepositoryService_GetNextContainerRequest_FullMethodName   = "/WorkerRepositoryService/GetNextContainerRequest"
-	WorkerRepositoryService_SetImagePullLock_FullMethodName          = "/WorkerRepositoryService/SetImagePullLock"
-	WorkerRepositoryService_RemoveImagePullLock_FullMethodName       = "/WorkerRepositoryService/RemoveImagePullLock"
-	WorkerRepositoryService_AddContainerToWorker_FullMethodName      = "/WorkerRepositoryService/AddContainerToWorker"
-	WorkerRepositoryService_RemoveContainerFromWorker_FullMethodName = "/WorkerRepositoryService/RemoveContainerFromWorker"
-	WorkerRepositoryService_GetWorkerById_FullMethodName             = "/WorkerRepositoryService/GetWorkerById"
-	WorkerRepositoryService_ToggleWorkerAvailable_FullMethodName     = "/WorkerRepositoryService/ToggleWorkerAvailable"
-	WorkerRepositoryService_RemoveWorker_FullMethodName              = "/WorkerRepositoryService/RemoveWorker"
-	WorkerRepositoryService_UpdateWorkerCapacity_FullMethodName      = "/WorkerRepositoryService/UpdateWorkerCapacity"
-	WorkerRepositoryService_SetWorkerKeepAlive_FullMethodName        = "/WorkerRepositoryService/SetWorkerKeepAlive"
-	WorkerRepositoryService_SetNetworkLock_FullMethodName            = "/WorkerRepositoryService/SetNetworkLock"
-	WorkerRepositoryService_RemoveNetworkLock_FullMethodName         = "/WorkerRepositoryService/RemoveNetworkLock"
-	WorkerRepositoryService_SetContainerIp_FullMethodName            = "/WorkerRepositoryService/SetContainerIp"
-	WorkerRepositoryService_GetContainerIp_FullMethodName            = "/WorkerRepositoryService/GetContainerIp"
-	WorkerRepositoryService_GetContainerIps_FullMethodName           = "/WorkerRepositoryService/GetContainerIps"
-	WorkerRepositoryService_RemoveContainerIp_FullMethodName         = "/WorkerRepositoryService/RemoveContainerIp"
+	WorkerRepositoryService_GetNextContainerRequest_FullMethodName      = "/WorkerRepositoryService/GetNextContainerRequest"
+	WorkerRepositoryService_SetImagePullLock_FullMethodName             = "/WorkerRepositoryService/SetImagePullLock"
+	WorkerRepositoryService_RemoveImagePullLock_FullMethodName          = "/WorkerRepositoryService/RemoveImagePullLock"
+	WorkerRepositoryService_AddContainerToWorker_FullMethodName         = "/WorkerRepositoryService/AddContainerToWorker"
+	WorkerRepositoryService_RemoveContainerFromWorker_FullMethodName    = "/WorkerRepositoryService/RemoveContainerFromWorker"
+	WorkerRepositoryService_GetWorkerById_FullMethodName                = "/WorkerRepositoryService/GetWorkerById"
+	WorkerRepositoryService_ToggleWorkerAvailable_FullMethodName        = "/WorkerRepositoryService/ToggleWorkerAvailable"
+	WorkerRepositoryService_UpdateWorkerStatus_FullMethodName           = "/WorkerRepositoryService/UpdateWorkerStatus"
+	WorkerRepositoryService_SetWorkerGpuInterconnect_FullMethodName     = "/WorkerRepositoryService/SetWorkerGpuInterconnect"
+	WorkerRepositoryService_SetWorkerServerAddress_FullMethodName       = "/WorkerRepositoryService/SetWorkerServerAddress"
+	WorkerRepositoryService_SetWorkerArch_FullMethodName                = "/WorkerRepositoryService/SetWorkerArch"
+	WorkerRepositoryService_SetWorkerMigSlices_FullMethodName           = "/WorkerRepositoryService/SetWorkerMigSlices"
+	WorkerRepositoryService_RemoveWorker_FullMethodName                 = "/WorkerRepositoryService/RemoveWorker"
+	WorkerRepositoryService_UpdateWorkerCapacity_FullMethodName         = "/WorkerRepositoryService/UpdateWorkerCapacity"
+	WorkerRepositoryService_SetWorkerKeepAlive_FullMethodName           = "/WorkerRepositoryService/SetWorkerKeepAlive"
+	WorkerRepositoryService_SetNetworkLock_FullMethodName               = "/WorkerRepositoryService/SetNetworkLock"
+	WorkerRepositoryService_RemoveNetworkLock_FullMethodName            = "/WorkerRepositoryService/RemoveNetworkLock"
+	WorkerRepositoryService_SetContainerIp_FullMethodName               = "/WorkerRepositoryService/SetContainerIp"
+	WorkerRepositoryService_GetContainerIp_FullMethodName               = "/WorkerRepositoryService/GetContainerIp"
+	WorkerRepositoryService_GetContainerIps_FullMethodName              = "/WorkerRepositoryService/GetContainerIps"
+	WorkerRepositoryService_RemoveContainerIp_FullMethodName            = "/WorkerRepositoryService/RemoveContainerIp"
+	WorkerRepositoryService_AddCachedObject_FullMethodName              = "/WorkerRepositoryService/AddCachedObject"
+	WorkerRepositoryService_RemoveCachedObject_FullMethodName           = "/WorkerRepositoryService/RemoveCachedObject"
+	WorkerRepositoryService_PopCachedObjectInvalidations_FullMethodName = "/WorkerRepositoryService/PopCachedObjectInvalidations"
 )
 
 // WorkerRepositoryServiceClient is the client API for WorkerRepositoryService service.
@@ -48,6 +56,11 @@ type WorkerRepositoryServiceClient interface {
 	RemoveContainerFromWorker(ctx context.Context, in *RemoveContainerFromWorkerRequest, opts ...grpc.CallOption) (*RemoveContainerFromWorkerResponse, error)
 	GetWorkerById(ctx context.Context, in *GetWorkerByIdRequest, opts ...grpc.CallOption) (*GetWorkerByIdResponse, error)
 	ToggleWorkerAvailable(ctx context.Context, in *ToggleWorkerAvailableRequest, opts ...grpc.CallOption) (*ToggleWorkerAvailableResponse, error)
+	UpdateWorkerStatus(ctx context.Context, in *UpdateWorkerStatusRequest, opts ...grpc.CallOption) (*UpdateWorkerStatusResponse, error)
+	SetWorkerGpuInterconnect(ctx context.Context, in *SetWorkerGpuInterconnectRequest, opts ...grpc.CallOption) (*SetWorkerGpuInterconnectResponse, error)
+	SetWorkerServerAddress(ctx context.Context, in *SetWorkerServerAddressRequest, opts ...grpc.CallOption) (*SetWorkerServerAddressResponse, error)
+	SetWorkerArch(ctx context.Context, in *SetWorkerArchRequest, opts ...grpc.CallOption) (*SetWorkerArchResponse, error)
+	SetWorkerMigSlices(ctx context.Context, in *SetWorkerMigSlicesRequest, opts ...grpc.CallOption) (*SetWorkerMigSlicesResponse, error)
 	RemoveWorker(ctx context.Context, in *RemoveWorkerRequest, opts ...grpc.CallOption) (*RemoveWorkerResponse, error)
 	UpdateWorkerCapacity(ctx context.Context, in *UpdateWorkerCapacityRequest, opts ...grpc.CallOption) (*UpdateWorkerCapacityResponse, error)
 	SetWorkerKeepAlive(ctx context.Context, in *SetWorkerKeepAliveRequest, opts ...grpc.CallOption) (*SetWorkerKeepAliveResponse, error)
@@ -57,6 +70,9 @@ type WorkerRepositoryServiceClient interface {
 	GetContainerIp(ctx context.Context, in *GetContainerIpRequest, opts ...grpc.CallOption) (*GetContainerIpResponse, error)
 	GetContainerIps(ctx context.Context, in *GetContainerIpsRequest, opts ...grpc.CallOption) (*GetContainerIpsResponse, error)
 	RemoveContainerIp(ctx context.Context, in *RemoveContainerIpRequest, opts ...grpc.CallOption) (*RemoveContainerIpResponse, error)
+	AddCachedObject(ctx context.Context, in *AddCachedObjectRequest, opts ...grpc.CallOption) (*AddCachedObjectResponse, error)
+	RemoveCachedObject(ctx context.Context, in *RemoveCachedObjectRequest, opts ...grpc.CallOption) (*RemoveCachedObjectResponse, error)
+	PopCachedObjectInvalidations(ctx context.Context, in *PopCachedObjectInvalidationsRequest, opts ...grpc.CallOption) (*PopCachedObjectInvalidationsResponse, error)
 }
 
 type workerRepositoryServiceClient struct {
@@ -153,6 +169,51 @@ func (c *workerRepositoryServiceClient) ToggleWorkerAvailable(ctx context.Contex
 	return out, nil
 }
 
+func (c *workerRepositoryServiceClient) UpdateWorkerStatus(ctx context.Context, in *UpdateWorkerStatusRequest, opts ...grpc.CallOption) (*UpdateWorkerStatusResponse, error) {
+	out := new(UpdateWorkerStatusResponse)
+	err := c.cc.Invoke(ctx, WorkerRepositoryService_UpdateWorkerStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerRepositoryServiceClient) SetWorkerGpuInterconnect(ctx context.Context, in *SetWorkerGpuInterconnectRequest, opts ...grpc.CallOption) (*SetWorkerGpuInterconnectResponse, error) {
+	out := new(SetWorkerGpuInterconnectResponse)
+	err := c.cc.Invoke(ctx, WorkerRepositoryService_SetWorkerGpuInterconnect_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerRepositoryServiceClient) SetWorkerServerAddress(ctx context.Context, in *SetWorkerServerAddressRequest, opts ...grpc.CallOption) (*SetWorkerServerAddressResponse, error) {
+	out := new(SetWorkerServerAddressResponse)
+	err := c.cc.Invoke(ctx, WorkerRepositoryService_SetWorkerServerAddress_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerRepositoryServiceClient) SetWorkerArch(ctx context.Context, in *SetWorkerArchRequest, opts ...grpc.CallOption) (*SetWorkerArchResponse, error) {
+	out := new(SetWorkerArchResponse)
+	err := c.cc.Invoke(ctx, WorkerRepositoryService_SetWorkerArch_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerRepositoryServiceClient) SetWorkerMigSlices(ctx context.Context, in *SetWorkerMigSlicesRequest, opts ...grpc.CallOption) (*SetWorkerMigSlicesResponse, error) {
+	out := new(SetWorkerMigSlicesResponse)
+	err := c.cc.Invoke(ctx, WorkerRepositoryService_SetWorkerMigSlices_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *workerRepositoryServiceClient) RemoveWorker(ctx context.Context, in *RemoveWorkerRequest, opts ...grpc.CallOption) (*RemoveWorkerResponse, error) {
 	out := new(RemoveWorkerResponse)
 	err := c.cc.Invoke(ctx, WorkerRepositoryService_RemoveWorker_FullMethodName, in, out, opts...)
@@ -234,6 +295,33 @@ func (c *workerRepositoryServiceClient) RemoveContainerIp(ctx context.Context, i
 	return out, nil
 }
 
+func (c *workerRepositoryServiceClient) AddCachedObject(ctx context.Context, in *AddCachedObjectRequest, opts ...grpc.CallOption) (*AddCachedObjectResponse, error) {
+	out := new(AddCachedObjectResponse)
+	err := c.cc.Invoke(ctx, WorkerRepositoryService_AddCachedObject_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerRepositoryServiceClient) RemoveCachedObject(ctx context.Context, in *RemoveCachedObjectRequest, opts ...grpc.CallOption) (*RemoveCachedObjectResponse, error) {
+	out := new(RemoveCachedObjectResponse)
+	err := c.cc.Invoke(ctx, WorkerRepositoryService_RemoveCachedObject_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerRepositoryServiceClient) PopCachedObjectInvalidations(ctx context.Context, in *PopCachedObjectInvalidationsRequest, opts ...grpc.CallOption) (*PopCachedObjectInvalidationsResponse, error) {
+	out := new(PopCachedObjectInvalidationsResponse)
+	err := c.cc.Invoke(ctx, WorkerRepositoryService_PopCachedObjectInvalidations_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WorkerRepositoryServiceServer is the server API for WorkerRepositoryService service.
 // All implementations must embed UnimplementedWorkerRepositoryServiceServer
 // for forward compatibility
@@ -245,6 +333,11 @@ type WorkerRepositoryServiceServer interface {
 	RemoveContainerFromWorker(context.Context, *RemoveContainerFromWorkerRequest) (*RemoveContainerFromWorkerResponse, error)
 	GetWorkerById(context.Context, *GetWorkerByIdRequest) (*GetWorkerByIdResponse, error)
 	ToggleWorkerAvailable(context.Context, *ToggleWorkerAvailableRequest) (*ToggleWorkerAvailableResponse, error)
+	UpdateWorkerStatus(context.Context, *UpdateWorkerStatusRequest) (*UpdateWorkerStatusResponse, error)
+	SetWorkerGpuInterconnect(context.Context, *SetWorkerGpuInterconnectRequest) (*SetWorkerGpuInterconnectResponse, error)
+	SetWorkerServerAddress(context.Context, *SetWorkerServerAddressRequest) (*SetWorkerServerAddressResponse, error)
+	SetWorkerArch(context.Context, *SetWorkerArchRequest) (*SetWorkerArchResponse, error)
+	SetWorkerMigSlices(context.Context, *SetWorkerMigSlicesRequest) (*SetWorkerMigSlicesResponse, error)
 	RemoveWorker(context.Context, *RemoveWorkerRequest) (*RemoveWorkerResponse, error)
 	UpdateWorkerCapacity(context.Context, *UpdateWorkerCapacityRequest) (*UpdateWorkerCapacityResponse, error)
 	SetWorkerKeepAlive(context.Context, *SetWorkerKeepAliveRequest) (*SetWorkerKeepAliveResponse, error)
@@ -254,6 +347,9 @@ type WorkerRepositoryServiceServer interface {
 	GetContainerIp(context.Context, *GetContainerIpRequest) (*GetContainerIpResponse, error)
 	GetContainerIps(context.Context, *GetContainerIpsRequest) (*GetContainerIpsResponse, error)
 	RemoveContainerIp(context.Context, *RemoveContainerIpRequest) (*RemoveContainerIpResponse, error)
+	AddCachedObject(context.Context, *AddCachedObjectRequest) (*AddCachedObjectResponse, error)
+	RemoveCachedObject(context.Context, *RemoveCachedObjectRequest) (*RemoveCachedObjectResponse, error)
+	PopCachedObjectInvalidations(context.Context, *PopCachedObjectInvalidationsRequest) (*PopCachedObjectInvalidationsResponse, error)
 	mustEmbedUnimplementedWorkerRepositoryServiceServer()
 }
 
@@ -282,6 +378,21 @@ func (UnimplementedWorkerRepositoryServiceServer) GetWorkerById(context.Context,
 func (UnimplementedWorkerRepositoryServiceServer) ToggleWorkerAvailable(context.Context, *ToggleWorkerAvailableRequest) (*ToggleWorkerAvailableResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ToggleWorkerAvailable not implemented")
 }
+func (UnimplementedWorkerRepositoryServiceServer) UpdateWorkerStatus(context.Context, *UpdateWorkerStatusRequest) (*UpdateWorkerStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateWorkerStatus not implemented")
+}
+func (UnimplementedWorkerRepositoryServiceServer) SetWorkerGpuInterconnect(context.Context, *SetWorkerGpuInterconnectRequest) (*SetWorkerGpuInterconnectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetWorkerGpuInterconnect not implemented")
+}
+func (UnimplementedWorkerRepositoryServiceServer) SetWorkerServerAddress(context.Context, *SetWorkerServerAddressRequest) (*SetWorkerServerAddressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetWorkerServerAddress not implemented")
+}
+func (UnimplementedWorkerRepositoryServiceServer) SetWorkerArch(context.Context, *SetWorkerArchRequest) (*SetWorkerArchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetWorkerArch not implemented")
+}
+func (UnimplementedWorkerRepositoryServiceServer) SetWorkerMigSlices(context.Context, *SetWorkerMigSlicesRequest) (*SetWorkerMigSlicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetWorkerMigSlices not implemented")
+}
 func (UnimplementedWorkerRepositoryServiceServer) RemoveWorker(context.Context, *RemoveWorkerRequest) (*RemoveWorkerResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveWorker not implemented")
 }
@@ -309,6 +420,15 @@ func (UnimplementedWorkerRepositoryServiceServer) GetContainerIps(context.Contex
 func (UnimplementedWorkerRepositoryServiceServer) RemoveContainerIp(context.Context, *RemoveContainerIpRequest) (*RemoveContainerIpResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveContainerIp not implemented")
 }
+func (UnimplementedWorkerRepositoryServiceServer) AddCachedObject(context.Context, *AddCachedObjectRequest) (*AddCachedObjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddCachedObject not implemented")
+}
+func (UnimplementedWorkerRepositoryServiceServer) RemoveCachedObject(context.Context, *RemoveCachedObjectRequest) (*RemoveCachedObjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveCachedObject not implemented")
+}
+func (UnimplementedWorkerRepositoryServiceServer) PopCachedObjectInvalidations(context.Context, *PopCachedObjectInvalidationsRequest) (*PopCachedObjectInvalidationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PopCachedObjectInvalidations not implemented")
+}
 func (UnimplementedWorkerRepositoryServiceServer) mustEmbedUnimplementedWorkerRepositoryServiceServer() {
 }
 
@@ -452,6 +572,96 @@ func _WorkerRepositoryService_ToggleWorkerAvailable_Handler(srv interface{}, ctx
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WorkerRepositoryService_UpdateWorkerStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateWorkerStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerRepositoryServiceServer).UpdateWorkerStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkerRepositoryService_UpdateWorkerStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerRepositoryServiceServer).UpdateWorkerStatus(ctx, req.(*UpdateWorkerStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkerRepositoryService_SetWorkerGpuInterconnect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetWorkerGpuInterconnectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerRepositoryServiceServer).SetWorkerGpuInterconnect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkerRepositoryService_SetWorkerGpuInterconnect_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerRepositoryServiceServer).SetWorkerGpuInterconnect(ctx, req.(*SetWorkerGpuInterconnectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkerRepositoryService_SetWorkerServerAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetWorkerServerAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerRepositoryServiceServer).SetWorkerServerAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkerRepositoryService_SetWorkerServerAddress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerRepositoryServiceServer).SetWorkerServerAddress(ctx, req.(*SetWorkerServerAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkerRepositoryService_SetWorkerArch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetWorkerArchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerRepositoryServiceServer).SetWorkerArch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkerRepositoryService_SetWorkerArch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerRepositoryServiceServer).SetWorkerArch(ctx, req.(*SetWorkerArchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkerRepositoryService_SetWorkerMigSlices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetWorkerMigSlicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerRepositoryServiceServer).SetWorkerMigSlices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkerRepositoryService_SetWorkerMigSlices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerRepositoryServiceServer).SetWorkerMigSlices(ctx, req.(*SetWorkerMigSlicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _WorkerRepositoryService_RemoveWorker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RemoveWorkerRequest)
 	if err := dec(in); err != nil {
@@ -614,6 +824,60 @@ func _WorkerRepositoryService_RemoveContainerIp_Handler(srv interface{}, ctx con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WorkerRepositoryService_AddCachedObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddCachedObjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerRepositoryServiceServer).AddCachedObject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkerRepositoryService_AddCachedObject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerRepositoryServiceServer).AddCachedObject(ctx, req.(*AddCachedObjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkerRepositoryService_RemoveCachedObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveCachedObjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerRepositoryServiceServer).RemoveCachedObject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkerRepositoryService_RemoveCachedObject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerRepositoryServiceServer).RemoveCachedObject(ctx, req.(*RemoveCachedObjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkerRepositoryService_PopCachedObjectInvalidations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PopCachedObjectInvalidationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerRepositoryServiceServer).PopCachedObjectInvalidations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkerRepositoryService_PopCachedObjectInvalidations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerRepositoryServiceServer).PopCachedObjectInvalidations(ctx, req.(*PopCachedObjectInvalidationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // WorkerRepositoryService_ServiceDesc is the grpc.ServiceDesc for WorkerRepositoryService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -645,6 +909,26 @@ var WorkerRepositoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ToggleWorkerAvailable",
 			Handler:    _WorkerRepositoryService_ToggleWorkerAvailable_Handler,
 		},
+		{
+			MethodName: "UpdateWorkerStatus",
+			Handler:    _WorkerRepositoryService_UpdateWorkerStatus_Handler,
+		},
+		{
+			MethodName: "SetWorkerGpuInterconnect",
+			Handler:    _WorkerRepositoryService_SetWorkerGpuInterconnect_Handler,
+		},
+		{
+			MethodName: "SetWorkerServerAddress",
+			Handler:    _WorkerRepositoryService_SetWorkerServerAddress_Handler,
+		},
+		{
+			MethodName: "SetWorkerArch",
+			Handler:    _WorkerRepositoryService_SetWorkerArch_Handler,
+		},
+		{
+			MethodName: "SetWorkerMigSlices",
+			Handler:    _WorkerRepositoryService_SetWorkerMigSlices_Handler,
+		},
 		{
 			MethodName: "RemoveWorker",
 			Handler:    _WorkerRepositoryService_RemoveWorker_Handler,
@@ -681,6 +965,18 @@ var WorkerRepositoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveContainerIp",
 			Handler:    _WorkerRepositoryService_RemoveContainerIp_Handler,
 		},
+		{
+			MethodName: "AddCachedObject",
+			Handler:    _WorkerRepositoryService_AddCachedObject_Handler,
+		},
+		{
+			MethodName: "RemoveCachedObject",
+			Handler:    _WorkerRepositoryService_RemoveCachedObject_Handler,
+		},
+		{
+			MethodName: "PopCachedObjectInvalidations",
+			Handler:    _WorkerRepositoryService_PopCachedObjectInvalidations_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -691,3 +987,94 @@ var WorkerRepositoryService_ServiceDesc = grpc.ServiceDesc{
 	},
 	Metadata: "worker_repo.proto",
 }
+
+const (
+	WorkerCertificateService_IssueWorkerCertificate_FullMethodName = "/WorkerCertificateService/IssueWorkerCertificate"
+)
+
+// WorkerCertificateServiceClient is the client API for WorkerCertificateService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type WorkerCertificateServiceClient interface {
+	IssueWorkerCertificate(ctx context.Context, in *IssueWorkerCertificateRequest, opts ...grpc.CallOption) (*IssueWorkerCertificateResponse, error)
+}
+
+type workerCertificateServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWorkerCertificateServiceClient(cc grpc.ClientConnInterface) WorkerCertificateServiceClient {
+	return &workerCertificateServiceClient{cc}
+}
+
+func (c *workerCertificateServiceClient) IssueWorkerCertificate(ctx context.Context, in *IssueWorkerCertificateRequest, opts ...grpc.CallOption) (*IssueWorkerCertificateResponse, error) {
+	out := new(IssueWorkerCertificateResponse)
+	err := c.cc.Invoke(ctx, WorkerCertificateService_IssueWorkerCertificate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WorkerCertificateServiceServer is the server API for WorkerCertificateService service.
+// All implementations must embed UnimplementedWorkerCertificateServiceServer
+// for forward compatibility
+type WorkerCertificateServiceServer interface {
+	IssueWorkerCertificate(context.Context, *IssueWorkerCertificateRequest) (*IssueWorkerCertificateResponse, error)
+	mustEmbedUnimplementedWorkerCertificateServiceServer()
+}
+
+// UnimplementedWorkerCertificateServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedWorkerCertificateServiceServer struct {
+}
+
+func (UnimplementedWorkerCertificateServiceServer) IssueWorkerCertificate(context.Context, *IssueWorkerCertificateRequest) (*IssueWorkerCertificateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IssueWorkerCertificate not implemented")
+}
+func (UnimplementedWorkerCertificateServiceServer) mustEmbedUnimplementedWorkerCertificateServiceServer() {
+}
+
+// UnsafeWorkerCertificateServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WorkerCertificateServiceServer will
+// result in compilation errors.
+type UnsafeWorkerCertificateServiceServer interface {
+	mustEmbedUnimplementedWorkerCertificateServiceServer()
+}
+
+func RegisterWorkerCertificateServiceServer(s grpc.ServiceRegistrar, srv WorkerCertificateServiceServer) {
+	s.RegisterService(&WorkerCertificateService_ServiceDesc, srv)
+}
+
+func _WorkerCertificateService_IssueWorkerCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssueWorkerCertificateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerCertificateServiceServer).IssueWorkerCertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkerCertificateService_IssueWorkerCertificate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerCertificateServiceServer).IssueWorkerCertificate(ctx, req.(*IssueWorkerCertificateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// WorkerCertificateService_ServiceDesc is the grpc.ServiceDesc for WorkerCertificateService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WorkerCertificateService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "WorkerCertificateService",
+	HandlerType: (*WorkerCertificateServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "IssueWorkerCertificate",
+			Handler:    _WorkerCertificateService_IssueWorkerCertificate_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "worker_repo.proto",
+}
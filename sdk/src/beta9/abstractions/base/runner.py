@@ -488,6 +488,7 @@ class RunnerAbstraction(BaseAbstraction):
                 name=stub_name,
                 app_name=self.app,
                 python_version=self.image.python_version,
+                architectures=self.image.architectures,
                 cpu=self.cpu,
                 memory=self.memory,
                 gpu=self.gpu,
@@ -535,6 +536,7 @@ class RunnerAbstraction(BaseAbstraction):
                 tcp=self.tcp,
                 block_network=self.block_network,
                 allow_list=self.allow_list,
+                nodes=getattr(self, "nodes", 0),
             )
 
             if _is_stub_created_for_workspace():
@@ -116,6 +116,7 @@ class Image(BaseAbstraction):
         base_image_creds: Optional[ImageCredentials] = None,
         env_vars: Optional[Union[str, List[str], Dict[str, str]]] = None,
         image_id: Optional[str] = None,
+        architectures: Optional[List[str]] = None,
     ):
         """
         Creates an Image instance.
@@ -157,6 +158,10 @@ class Image(BaseAbstraction):
                 and when the container is running. This can be a string, a list of strings, or a
                 dictionary of strings. The string must be in the format of "KEY=VALUE". If a list of
                 strings is provided, each element should be in the same format. Deafult is None.
+            architectures (Optional[List[str]]):
+                The CPU architectures to build the image for, "amd64" and/or "arm64". The image is
+                built once for each, and your containers run on workers of any of them. Default is
+                None, which builds for amd64.
 
         Example:
 
@@ -334,6 +339,7 @@ class Image(BaseAbstraction):
         self.override_python_version = False
         self.image_id = image_id or ""
         self.include_files_patterns = []
+        self.architectures = architectures or []
 
         self.with_envs(env_vars or [])
 
@@ -517,6 +523,7 @@ class Image(BaseAbstraction):
                 gpu=self.gpu,
                 ignore_python=self.ignore_python,
                 image_id=self.image_id,
+                architectures=self.architectures,
             )
         )
 
@@ -571,6 +578,7 @@ class Image(BaseAbstraction):
                     secrets=self.secrets,
                     gpu=self.gpu,
                     ignore_python=self.ignore_python,
+                    architectures=self.architectures,
                 )
             ):
                 if r.warning:
@@ -41,12 +41,14 @@ class PodInstance(BaseAbstraction):
     Attributes:
         container_id: The unique ID of the created container.
         url: The URL for accessing the container over HTTP (if ports were exposed).
+        container_ids: The IDs of the containers on every node by rank, for pods spanning several nodes.
     """
 
     container_id: str
     url: str
     ok: bool = field(default=False)
     error_msg: str = field(default="")
+    container_ids: List[str] = field(default_factory=list)
     gateway_stub: "GatewayServiceStub" = field(init=False)
 
     def __post_init__(self):
@@ -57,10 +59,13 @@ class PodInstance(BaseAbstraction):
         """
         Terminate the container associated with this pod instance. Returns True if the container was terminated, False otherwise.
         """
-        res: "StopContainerResponse" = self.gateway_stub.stop_container(
-            StopContainerRequest(container_id=self.container_id)
-        )
-        return res.ok
+        ok = True
+        for container_id in self.container_ids or [self.container_id]:
+            res: "StopContainerResponse" = self.gateway_stub.stop_container(
+                StopContainerRequest(container_id=container_id)
+            )
+            ok = ok and res.ok
+        return ok
 
 
 class Pod(RunnerAbstraction, DeployableMixin):
@@ -105,6 +110,10 @@ class Pod(RunnerAbstraction, DeployableMixin):
         authorized (bool):
             If false, allows the pod to be accessed without an auth token.
             Default is False.
+        nodes (int):
+            The number of nodes the pod spans, each running the entrypoint in a container of its own.
+            The containers are only started once all of them can be placed, and get MASTER_ADDR,
+            MASTER_PORT, NNODES and NODE_RANK to rendezvous with, e.g. for torchrun. Default is 1.
 
     Example usage:
         ```
@@ -139,6 +148,7 @@ class Pod(RunnerAbstraction, DeployableMixin):
         block_network: bool = False,
         allow_list: Optional[List[str]] = None,
         docker_enabled: bool = False,
+        nodes: int = 1,
     ) -> None:
         super().__init__(
             cpu=cpu,
@@ -163,6 +173,7 @@ class Pod(RunnerAbstraction, DeployableMixin):
         self.parent = self
         self.func = None
         self.task_id = ""
+        self.nodes = nodes
         self._pod_stub: Optional[PodServiceStub] = None
         self.syncer: FileSyncer = FileSyncer(self.gateway_stub)
 
@@ -244,6 +255,7 @@ class Pod(RunnerAbstraction, DeployableMixin):
             url=url,
             ok=create_response.ok,
             error_msg=create_response.error_msg,
+            container_ids=create_response.container_ids,
         )
 
     def deploy(
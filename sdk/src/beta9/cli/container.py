@@ -12,6 +12,7 @@ from ..cli import extraclick
 from ..clients.gateway import (
     CheckpointContainerRequest,
     ListContainersRequest,
+    SimulateSchedulingRequest,
     StopContainerRequest,
     StopContainerResponse,
 )
@@ -41,6 +42,8 @@ AVAILABLE_LIST_COLUMNS = {
     "uptime": "Uptime",
     "worker_id": "Worker ID",
     "machine_id": "Machine ID",
+    "gpu_interconnect": "GPU Interconnect",
+    "numa_nodes": "NUMA Nodes",
 }
 
 
@@ -63,7 +66,8 @@ AVAILABLE_LIST_COLUMNS = {
     default="container_id,status,stub_id,scheduled_at,deployment_id,uptime",
     help="""
       Specify columns to display.
-      Available columns: container_id, status, stub_id, scheduled_at, deployment_id, uptime
+      Available columns: container_id, status, stub_id, scheduled_at, deployment_id, uptime,
+      gpu_interconnect, numa_nodes
     """,
 )
 @extraclick.pass_service_client
@@ -179,3 +183,102 @@ def checkpoint_container(service: ServiceClient, container_id: str):
         terminal.success(f"Checkpoint created for container: {container_id} -> {res.checkpoint_id}")
     else:
         terminal.error(f"{res.error_msg}")
+
+
+@management.command(
+    name="simulate",
+    help="""
+    Simulate scheduling a container.
+
+    Shows where a container of an existing stub, or of the given resources,
+    would be placed right now, or why it would stay pending. Nothing is run.
+    """,
+    epilog="""
+      Examples:
+
+        # Simulate a container of an existing stub
+        {cli_name} container simulate --stub-id 5b2a1f3c-0d5e-4e8a-9f7b-6c2d1e0a9b8c
+
+        # Simulate a container that needs two H100s, or A100s if there are none
+        {cli_name} container simulate --cpu 4000 --memory 32768 --gpu H100,A100-80 --gpu-count 2
+        \b
+    """,
+)
+@click.option("--stub-id", type=click.STRING, default="", help="An existing stub to simulate.")
+@click.option("--cpu", type=click.INT, default=1000, show_default=True, help="CPU in millicores.")
+@click.option("--memory", type=click.INT, default=1024, show_default=True, help="Memory in MiB.")
+@click.option("--gpu", type=click.STRING, default="", help="GPU types, comma separated.")
+@click.option("--gpu-count", type=click.INT, default=0, help="Number of GPUs.")
+@click.option(
+    "--priority-class",
+    type=click.Choice(("production", "default", "batch")),
+    default=None,
+    help="Priority class of the container.",
+)
+@click.option(
+    "--format",
+    type=click.Choice(("table", "json")),
+    default="table",
+    show_default=True,
+    help="Change the format of the output.",
+)
+@extraclick.pass_service_client
+def simulate_scheduling(
+    service: ServiceClient,
+    stub_id: str,
+    cpu: int,
+    memory: int,
+    gpu: str,
+    gpu_count: int,
+    priority_class: str,
+    format: str,
+):
+    res = service.gateway.simulate_scheduling(
+        SimulateSchedulingRequest(
+            stub_id=stub_id,
+            cpu=cpu,
+            memory=memory,
+            gpu=gpu,
+            gpu_count=gpu_count,
+            priority_class=priority_class or "",
+        )
+    )
+    if not res.ok:
+        terminal.error(res.err_msg)
+
+    if format == "json":
+        terminal.print_json(res.to_dict(casing=Casing.SNAKE))
+        return
+
+    placement = res.pool_name
+    if res.gpu:
+        placement = f"{placement} ({res.gpu})"
+    if res.worker_id:
+        placement = f"{placement} on worker {res.worker_id}"
+
+    terminal.header(f"Outcome: {res.outcome}")
+    if placement:
+        terminal.detail(f"Pool: {placement}")
+    if res.preempted_containers:
+        terminal.detail(f"Containers preempted: {res.preempted_containers}")
+    if res.scale_up_pools:
+        terminal.detail(f"New worker from: {', '.join(res.scale_up_pools)}")
+    if res.reason:
+        terminal.detail(f"Reason: {res.reason}")
+
+    table = Table(
+        Column("Constraint"),
+        Column("Workers Rejected"),
+        Column("Reasons"),
+        box=box.SIMPLE,
+    )
+    for constraint in res.constraints:
+        reasons = [f"{count} x {reason}" for reason, count in constraint.reasons.items()]
+        table.add_row(
+            constraint.name,
+            str(sum(constraint.reasons.values())),
+            "\n".join(reasons),
+        )
+
+    if res.constraints:
+        terminal.print(table)
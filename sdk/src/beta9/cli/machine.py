@@ -111,6 +111,8 @@ def list_machines(
             Column("GPU"),
             Column("Status"),
             Column("Pool"),
+            Column("Instance Type"),
+            Column("Price/hr"),
             Column("Created"),
             Column("Last Keepalive"),
             Column("Agent Version"),
@@ -128,6 +130,8 @@ def list_machines(
                 machine.gpu,
                 machine.status,
                 machine.pool_name,
+                machine.instance_type or "-",
+                f"${machine.hourly_price:,.2f}" if machine.hourly_price > 0 else "-",
                 terminal.humanize_date(
                     datetime.fromtimestamp(int(machine.created), tz=timezone.utc)
                 ),
@@ -281,8 +285,12 @@ def drain_machine(
         if not res.ok:
             text = res.err_msg.capitalize()
             terminal.warn(text)
+        elif res.ready_for_termination:
+            terminal.success(f"Worker {worker_id} has been drained and is ready for termination.")
         else:
-            terminal.success(f"Worker {worker_id} has been drained.")
+            terminal.detail(
+                f"Worker {worker_id} is draining, {res.remaining_containers} containers remaining."
+            )
 
 
 @management.command(
@@ -70,6 +70,7 @@ def list_workers(
         Column("Pool"),
         Column("Status"),
         Column("Machine ID"),
+        Column("Arch"),
         Column("Priority"),
         Column("CPU"),
         Column("Memory"),
@@ -79,6 +80,7 @@ def list_workers(
         Column("GPUs Available"),
         Column("Containers"),
         Column("Version"),
+        Column("Unhealthy Reason"),
         box=box.SIMPLE,
     )
 
@@ -92,6 +94,7 @@ def list_workers(
                 "disabled": f"[red]{worker.status}[/red]",
             }.get(worker.status, worker.status),
             worker.machine_id if worker.machine_id else "-",
+            worker.arch or "-",
             str(worker.priority),
             f"{worker.total_cpu:,}m" if worker.total_cpu > 0 else "-",
             terminal.humanize_memory(worker.total_memory * 1024 * 1024),
@@ -101,6 +104,7 @@ def list_workers(
             str(worker.free_gpu_count),
             str(len(worker.active_containers)),
             worker.build_version,
+            worker.unhealthy_reason or "-",
         )
 
     table.add_section()
@@ -195,9 +199,9 @@ def uncordon_worker(service: ServiceClient, worker_ids: List[str]):
     help="""
     Drain a worker.
 
-    When a worker is drained, all running containers on it will be stopped. The
-    worker will continue to run until it reaches its idle timeout, but only if
-    the worker was cordoned before being drained.
+    Draining cordons the worker and stops its running containers, or waits for
+    them to exit with --wait. Once no containers are left, the worker is marked
+    drained and is ready for termination. Run the command again to check.
     """,
     epilog="""
       Examples:
@@ -210,6 +214,9 @@ def uncordon_worker(service: ServiceClient, worker_ids: List[str]):
 
         # Drain workers from stdin (useful for piping)
         {cli_name} worker list --format=json | jq -r '.[].id' | {cli_name} worker drain -
+
+        # Drain a worker without stopping its containers
+        {cli_name} worker drain 675a65c3 --wait
         \b
     """,
 )
@@ -218,10 +225,18 @@ def uncordon_worker(service: ServiceClient, worker_ids: List[str]):
     nargs=-1,
     required=True,
 )
+@click.option(
+    "--wait",
+    is_flag=True,
+    required=False,
+    default=False,
+    help="Wait for running containers to exit instead of stopping them.",
+)
 @extraclick.pass_service_client
 def drain_worker(
     service: ServiceClient,
     worker_ids: List[str],
+    wait: bool,
 ):
     if worker_ids and worker_ids[0] == "-":
         worker_ids = click.get_text_stream("stdin").read().strip().split()
@@ -230,9 +245,13 @@ def drain_worker(
         return terminal.error("Must provide at least one worker ID.")
 
     for worker_id in worker_ids:
-        res = service.gateway.drain_worker(DrainWorkerRequest(worker_id=worker_id))
+        res = service.gateway.drain_worker(DrainWorkerRequest(worker_id=worker_id, wait=wait))
         if not res.ok:
             text = res.err_msg.capitalize()
             terminal.warn(text)
+        elif res.ready_for_termination:
+            terminal.success(f"Worker {worker_id} has been drained and is ready for termination.")
         else:
-            terminal.success(f"Worker {worker_id} has been drained.")
+            terminal.detail(
+                f"Worker {worker_id} is draining, {res.remaining_containers} containers remaining."
+            )
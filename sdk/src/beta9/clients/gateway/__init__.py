@@ -242,6 +242,7 @@ class Task(betterproto.Message):
     workspace_name: str = betterproto.string_field(10)
     created_at: datetime = betterproto.message_field(11)
     updated_at: datetime = betterproto.message_field(12)
+    gpu: str = betterproto.string_field(14)
 
 
 @dataclass(eq=False, repr=False)
@@ -346,6 +347,9 @@ class GetOrCreateStubRequest(betterproto.Message):
     block_network: bool = betterproto.bool_field(38)
     allow_list: List[str] = betterproto.string_field(39)
     docker_enabled: bool = betterproto.bool_field(40)
+    architectures: List[str] = betterproto.string_field(53)
+    nodes: int = betterproto.uint32_field(54)
+    mig_profile: str = betterproto.string_field(55)
 
 
 @dataclass(eq=False, repr=False)
@@ -493,6 +497,8 @@ class Machine(betterproto.Message):
     agent_version: str = betterproto.string_field(14)
     machine_metrics: "MachineMetrics" = betterproto.message_field(15)
     user_data: str = betterproto.string_field(16)
+    instance_type: str = betterproto.string_field(17)
+    hourly_price: float = betterproto.double_field(18)
 
 
 @dataclass(eq=False, repr=False)
@@ -664,12 +670,95 @@ class UncordonWorkerResponse(betterproto.Message):
 @dataclass(eq=False, repr=False)
 class DrainWorkerRequest(betterproto.Message):
     worker_id: str = betterproto.string_field(1)
+    wait: bool = betterproto.bool_field(2)
+    """Wait for running containers to exit on their own instead of stopping them"""
 
 
 @dataclass(eq=False, repr=False)
 class DrainWorkerResponse(betterproto.Message):
     ok: bool = betterproto.bool_field(1)
     err_msg: str = betterproto.string_field(2)
+    remaining_containers: int = betterproto.int32_field(3)
+    ready_for_termination: bool = betterproto.bool_field(4)
+    """
+    Set once the worker is cordoned and has no containers left, so its machine
+     can be terminated
+    """
+
+
+@dataclass(eq=False, repr=False)
+class SimulateSchedulingRequest(betterproto.Message):
+    """
+    A stub to simulate scheduling for, either an existing one or a hypothetical
+     spec with the same fields as GetOrCreateStubRequest
+    """
+
+    stub_id: str = betterproto.string_field(1)
+    """Simulates the existing stub's containers, ignoring the fields below"""
+
+    cpu: int = betterproto.int64_field(2)
+    memory: int = betterproto.int64_field(3)
+    gpu: str = betterproto.string_field(4)
+    """GPU types in the order they're preferred, comma separated"""
+
+    gpu_count: int = betterproto.uint32_field(5)
+    gpu_fraction: float = betterproto.double_field(6)
+    regions: List[str] = betterproto.string_field(8)
+    pin_regions: bool = betterproto.bool_field(9)
+    priority_class: str = betterproto.string_field(10)
+    docker_enabled: bool = betterproto.bool_field(11)
+    preemptable: bool = betterproto.bool_field(12)
+    """Endpoints run on preemptable workers, other stubs don't"""
+
+    mig_profile: str = betterproto.string_field(13)
+
+
+@dataclass(eq=False, repr=False)
+class SimulateSchedulingResponse(betterproto.Message):
+    ok: bool = betterproto.bool_field(1)
+    err_msg: str = betterproto.string_field(2)
+    outcome: str = betterproto.string_field(3)
+    """scheduled, preempt, scale_up, pending or rejected"""
+
+    reason: str = betterproto.string_field(4)
+    worker_id: str = betterproto.string_field(5)
+    """
+    The worker the container would run on, for the scheduled and preempt
+     outcomes. Only cluster admins get its id.
+    """
+
+    pool_name: str = betterproto.string_field(6)
+    gpu: str = betterproto.string_field(7)
+    preempted_containers: int = betterproto.uint32_field(8)
+    """How many running containers would be stopped to make room"""
+
+    scale_up_pools: List[str] = betterproto.string_field(9)
+    """Pools asked for a new worker, in the order they're tried"""
+
+    constraints: List["SchedulingConstraint"] = betterproto.message_field(10)
+    """Each constraint workers are checked against, in order"""
+
+
+@dataclass(eq=False, repr=False)
+class SchedulingConstraint(betterproto.Message):
+    name: str = betterproto.string_field(1)
+    """pool_selector, resources, flags, affinity, pool_quota or gpu_preference"""
+
+    reasons: Dict[str, int] = betterproto.map_field(
+        2, betterproto.TYPE_STRING, betterproto.TYPE_UINT32
+    )
+    """How many workers failed the constraint, by why they failed it"""
+
+    rejected_workers: List["SchedulingRejection"] = betterproto.message_field(3)
+    """The workers that failed it, only listed for cluster admins"""
+
+
+@dataclass(eq=False, repr=False)
+class SchedulingRejection(betterproto.Message):
+    worker_id: str = betterproto.string_field(1)
+    pool_name: str = betterproto.string_field(2)
+    gpu: str = betterproto.string_field(3)
+    reason: str = betterproto.string_field(4)
 
 
 @dataclass(eq=False, repr=False)
@@ -977,6 +1066,15 @@ class GatewayServiceStub(SyncServiceStub):
             DrainWorkerResponse,
         )(drain_worker_request)
 
+    def simulate_scheduling(
+        self, simulate_scheduling_request: "SimulateSchedulingRequest"
+    ) -> "SimulateSchedulingResponse":
+        return self._unary_unary(
+            "/gateway.GatewayService/SimulateScheduling",
+            SimulateSchedulingRequest,
+            SimulateSchedulingResponse,
+        )(simulate_scheduling_request)
+
     def export_workspace_config(
         self, export_workspace_config_request: "ExportWorkspaceConfigRequest"
     ) -> "ExportWorkspaceConfigResponse":
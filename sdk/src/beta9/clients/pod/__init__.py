@@ -38,6 +38,7 @@ class CreatePodResponse(betterproto.Message):
     container_id: str = betterproto.string_field(2)
     error_msg: str = betterproto.string_field(3)
     stub_id: str = betterproto.string_field(4)
+    container_ids: List[str] = betterproto.string_field(5)
 
 
 @dataclass(eq=False, repr=False)
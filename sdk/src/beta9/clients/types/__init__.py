@@ -68,6 +68,8 @@ class Container(betterproto.Message):
     worker_id: str = betterproto.string_field(7)
     machine_id: str = betterproto.string_field(8)
     deployment_id: str = betterproto.string_field(9)
+    gpu_interconnect: str = betterproto.string_field(10)
+    numa_nodes: str = betterproto.string_field(11)
 
 
 @dataclass(eq=False, repr=False)
@@ -254,6 +256,8 @@ class Worker(betterproto.Message):
     build_version: str = betterproto.string_field(16)
     active_containers: List["Container"] = betterproto.message_field(17)
     runtime: str = betterproto.string_field(18)
+    unhealthy_reason: str = betterproto.string_field(23)
+    arch: str = betterproto.string_field(24)
 
 
 @dataclass(eq=False, repr=False)
@@ -15,12 +15,13 @@ class TaskStatus(str, Enum):
     Cancelled = "CANCELLED"
     Retry = "RETRY"
     Timeout = "TIMEOUT"
+    QuotaExceeded = "QUOTA_EXCEEDED"
 
     def __str__(self) -> str:
         return self.value
 
     def is_complete(self) -> bool:
-        return self.value in [self.Complete, self.Error, self.Cancelled, self.Timeout]
+        return self.value in [self.Complete, self.Error, self.Cancelled, self.Timeout, self.QuotaExceeded]
 
 
 class TaskExitCode: